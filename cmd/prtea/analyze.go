@@ -0,0 +1,94 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/signal"
+	"slices"
+	"strings"
+
+	"github.com/shhac/prtea/internal/github"
+	"github.com/shhac/prtea/internal/ui"
+)
+
+const analyzeUsage = `Usage: prtea analyze <owner/repo#123 | PR URL> [flags]
+
+Analyzes a pull request with the configured AI backend and prints the result.
+
+Flags:
+  --json             Print the analysis as JSON (default: markdown)
+  --fresh            Ignore a cached analysis of the same diff
+  --fail-on LEVEL    Exit with status 3 when the risk is LEVEL or higher
+                     (low, medium, high, critical)
+
+Exit status: 0 on success, 1 on error, 2 on bad usage, 3 for --fail-on.
+`
+
+// riskLevels orders the analysis risk levels from lowest to highest.
+var riskLevels = []string{"low", "medium", "high", "critical"}
+
+// runAnalyze handles `prtea analyze ...` and returns the process exit code.
+func runAnalyze(args []string) int {
+	var refArg, failOn string
+	asJSON, fresh := false, false
+	for i := 0; i < len(args); i++ {
+		switch arg := args[i]; {
+		case arg == "--json":
+			asJSON = true
+		case arg == "--fresh":
+			fresh = true
+		case arg == "--fail-on" && i+1 < len(args):
+			failOn = args[i+1]
+			i++
+		case strings.HasPrefix(arg, "--fail-on="):
+			failOn = strings.TrimPrefix(arg, "--fail-on=")
+		case arg == "-h" || arg == "--help":
+			fmt.Print(analyzeUsage)
+			return 0
+		case strings.HasPrefix(arg, "-") || refArg != "":
+			fmt.Fprint(os.Stderr, analyzeUsage)
+			return 2
+		default:
+			refArg = arg
+		}
+	}
+	if refArg == "" {
+		fmt.Fprint(os.Stderr, analyzeUsage)
+		return 2
+	}
+	if failOn != "" && !slices.Contains(riskLevels, failOn) {
+		fmt.Fprintf(os.Stderr, "Error: unknown risk level %q for --fail-on\n", failOn)
+		return 2
+	}
+	ref, err := github.ParsePRRef(refArg)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 2
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	analysis, err := ui.AnalyzePR(ctx, ref, fresh)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+
+	if asJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(analysis.Result); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return 1
+		}
+	} else {
+		fmt.Print(analysis.Markdown())
+	}
+
+	if failOn != "" && slices.Index(riskLevels, strings.ToLower(analysis.Result.Risk.Level)) >= slices.Index(riskLevels, failOn) {
+		return 3
+	}
+	return 0
+}
@@ -0,0 +1,133 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/shhac/prtea/internal/config"
+	"github.com/shhac/prtea/internal/github"
+)
+
+const authUsage = `Usage: prtea auth <command>
+
+Commands:
+  login [--client-id ID]   Log in with the GitHub device flow
+  login --with-token       Read a token from stdin and save it
+  logout                   Remove the saved token
+  status                   Show how prtea authenticates with GitHub
+`
+
+// runAuth handles `prtea auth ...` and returns the process exit code.
+func runAuth(args []string) int {
+	if len(args) == 0 {
+		fmt.Fprint(os.Stderr, authUsage)
+		return 2
+	}
+	cfg, err := config.Load()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+
+	switch args[0] {
+	case "login":
+		err = authLogin(cfg, args[1:])
+	case "logout":
+		cfg.GitHubToken = ""
+		if err = config.Save(cfg); err == nil {
+			fmt.Println("Removed the saved GitHub token.")
+		}
+	case "status":
+		err = authStatus(cfg)
+	default:
+		fmt.Fprint(os.Stderr, authUsage)
+		return 2
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	return 0
+}
+
+// authLogin obtains a token (device flow or stdin), verifies it and saves
+// it to the config file.
+func authLogin(cfg *config.Config, args []string) error {
+	clientID := os.Getenv("PRTEA_GITHUB_CLIENT_ID")
+	if clientID == "" {
+		clientID = cfg.GitHubClientID
+	}
+	withToken := false
+	for i := 0; i < len(args); i++ {
+		switch {
+		case args[i] == "--with-token":
+			withToken = true
+		case args[i] == "--client-id" && i+1 < len(args):
+			clientID = args[i+1]
+			i++
+		case strings.HasPrefix(args[i], "--client-id="):
+			clientID = strings.TrimPrefix(args[i], "--client-id=")
+		default:
+			return fmt.Errorf("unknown flag %q", args[i])
+		}
+	}
+
+	var token string
+	if withToken {
+		line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+		if err != nil && line == "" {
+			return fmt.Errorf("failed to read token from stdin: %w", err)
+		}
+		token = strings.TrimSpace(line)
+	} else {
+		if clientID == "" {
+			return fmt.Errorf("device flow needs an OAuth app client ID: pass --client-id, set PRTEA_GITHUB_CLIENT_ID, " +
+				"or set githubClientId in the config (the app must have device flow enabled)")
+		}
+		ctx := context.Background()
+		flow := github.NewDeviceFlow(clientID)
+		code, err := flow.RequestCode(ctx)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Open %s and enter the code: %s\n", code.VerificationURI, code.UserCode)
+		fmt.Println("Waiting for authorization...")
+		if token, err = flow.PollToken(ctx, code); err != nil {
+			return err
+		}
+	}
+
+	client, err := github.NewTokenClient(token)
+	if err != nil {
+		return err
+	}
+	cfg.GitHubToken = token
+	if clientID != "" && cfg.GitHubClientID == "" {
+		cfg.GitHubClientID = clientID
+	}
+	if err := config.Save(cfg); err != nil {
+		return err
+	}
+	fmt.Printf("Logged in as %s.\n", client.GetUsername())
+	return nil
+}
+
+// authStatus reports which credentials prtea will use.
+func authStatus(cfg *config.Config) error {
+	token, source := cfg.GitHubAuth()
+	var client *github.Client
+	var err error
+	if token != "" {
+		client, err = github.NewTokenClient(token)
+	} else {
+		client, err = github.NewClient()
+	}
+	if err != nil {
+		return fmt.Errorf("%s: %w", source, err)
+	}
+	fmt.Printf("Logged in as %s (%s).\n", client.GetUsername(), source)
+	return nil
+}
@@ -0,0 +1,114 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/shhac/prtea/internal/config"
+	"github.com/shhac/prtea/internal/ui"
+)
+
+const configUsage = `Usage: prtea config <command>
+
+Reads and changes the config file without the TUI.
+
+Commands:
+  list             Print every setting (secrets masked)
+  get KEY          Print a setting as JSON
+  set KEY VALUE    Change a setting: strings as is, anything else as JSON
+                   (true, 30000, ["right"])
+  edit             Open the config file in $VISUAL or $EDITOR, then check it
+  path             Print the config file's path
+
+Settings from the settings panel are held to its bounds and choices.
+`
+
+// runConfig handles `prtea config ...` and returns the process exit code.
+func runConfig(args []string) int {
+	if len(args) == 0 {
+		fmt.Fprint(os.Stderr, configUsage)
+		return 2
+	}
+	want := map[string]int{"list": 0, "get": 1, "set": 2, "edit": 0, "path": 0}
+	n, ok := want[args[0]]
+	if !ok {
+		if args[0] == "-h" || args[0] == "--help" {
+			fmt.Print(configUsage)
+			return 0
+		}
+		fmt.Fprint(os.Stderr, configUsage)
+		return 2
+	}
+	if len(args)-1 != n {
+		fmt.Fprint(os.Stderr, configUsage)
+		return 2
+	}
+
+	if args[0] == "path" {
+		fmt.Println(config.ConfigFile())
+		return 0
+	}
+	if args[0] == "edit" {
+		return editConfig()
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	switch args[0] {
+	case "list":
+		fmt.Print(ui.ConfigListing(cfg))
+	case "get":
+		value, err := ui.GetConfigValue(cfg, args[1])
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return 1
+		}
+		fmt.Println(value)
+	case "set":
+		if err := ui.SetConfigValue(cfg, args[1], args[2]); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return 1
+		}
+		if err := config.Save(cfg); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return 1
+		}
+	}
+	return 0
+}
+
+// editConfig opens the config file in the user's editor, writing the
+// defaults first if there is no file yet, and checks the result.
+func editConfig() int {
+	path := config.ConfigFile()
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		cfg, err := config.Load()
+		if err == nil {
+			err = config.Save(cfg)
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return 1
+		}
+	}
+
+	cmd := ui.EditorCommand(path)
+	cmd.Stdin, cmd.Stdout, cmd.Stderr = os.Stdin, os.Stdout, os.Stderr
+	if err := cmd.Run(); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: editor failed: %v\n", err)
+		return 1
+	}
+
+	cfg, err := config.Load()
+	if err == nil {
+		err = ui.ValidateConfig(cfg)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %s is invalid: %v\nRun `prtea config edit` again to fix it.\n", path, err)
+		return 1
+	}
+	return 0
+}
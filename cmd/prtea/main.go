@@ -3,8 +3,11 @@ package main
 import (
 	"fmt"
 	"os"
+	"strings"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/shhac/prtea/internal/config"
+	"github.com/shhac/prtea/internal/rpc"
 	"github.com/shhac/prtea/internal/ui"
 )
 
@@ -16,19 +19,83 @@ var (
 
 func main() {
 	var opts []ui.AppOption
+	var rpcPath string
+	var scripts []string
+	useRC := true
+	programOpts := []tea.ProgramOption{tea.WithAltScreen()}
 
-	for _, arg := range os.Args[1:] {
-		switch arg {
-		case "--version", "version":
+	if len(os.Args) > 1 && os.Args[1] == "auth" {
+		os.Exit(runAuth(os.Args[2:]))
+	}
+	if len(os.Args) > 1 && os.Args[1] == "analyze" {
+		os.Exit(runAnalyze(os.Args[2:]))
+	}
+	if len(os.Args) > 1 && os.Args[1] == "review" {
+		os.Exit(runReview(os.Args[2:]))
+	}
+	if len(os.Args) > 1 && os.Args[1] == "config" {
+		os.Exit(runConfig(os.Args[2:]))
+	}
+
+	args := os.Args[1:]
+	for i := 0; i < len(args); i++ {
+		switch arg := args[i]; {
+		case arg == "--version" || arg == "version":
 			fmt.Printf("prtea %s (commit: %s, built: %s)\n", version, commit, date)
 			os.Exit(0)
-		case "--demo":
+		case arg == "--demo":
 			opts = append(opts, ui.WithDemo())
+		case arg == "--no-color" || arg == "--ascii":
+			opts = append(opts, ui.WithNoColor())
+		case arg == "--compat":
+			opts = append(opts, ui.WithCompat())
+		case arg == "--mouse":
+			programOpts = append(programOpts, tea.WithMouseCellMotion())
+		case arg == "--rpc":
+			rpcPath = rpc.DefaultSocketPath()
+		case strings.HasPrefix(arg, "--rpc="):
+			rpcPath = strings.TrimPrefix(arg, "--rpc=")
+		case arg == "--cmd" && i+1 < len(args):
+			scripts = append(scripts, args[i+1])
+			i++
+		case strings.HasPrefix(arg, "--cmd="):
+			scripts = append(scripts, strings.TrimPrefix(arg, "--cmd="))
+		case arg == "--no-rc":
+			useRC = false
+		}
+	}
+
+	// Startup commands: the RC file's, then each --cmd in order
+	var startup []string
+	if useRC {
+		cmds, err := config.LoadRC(config.RCPath())
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
 		}
+		startup = cmds
+	}
+	for _, s := range scripts {
+		startup = append(startup, config.SplitCommands(s)...)
 	}
+	opts = append(opts, ui.WithStartupCommands(startup...))
 
-	p := tea.NewProgram(ui.NewApp(opts...), tea.WithAltScreen())
-	if _, err := p.Run(); err != nil {
+	p := tea.NewProgram(ui.NewApp(opts...), programOpts...)
+
+	var srv *rpc.Server
+	if rpcPath != "" {
+		var err error
+		srv, err = rpc.Listen(rpcPath, ui.SnapshotQuery(p.Send))
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
+	_, err := p.Run()
+	if srv != nil {
+		srv.Close()
+	}
+	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		os.Exit(1)
 	}
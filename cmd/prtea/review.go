@@ -0,0 +1,137 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+
+	"github.com/shhac/prtea/internal/claude"
+	"github.com/shhac/prtea/internal/github"
+	"github.com/shhac/prtea/internal/ui"
+)
+
+const reviewUsage = `Usage: prtea review <owner/repo#123 | PR URL> <action> [flags]
+
+Submits a review without the TUI.
+
+Actions (one required):
+  --approve
+  --comment
+  --request-changes
+
+Flags:
+  --body TEXT            Review body
+  --body-file FILE       Read the review body from FILE ("-" for stdin)
+  --comments-file FILE   Read inline comments from FILE ("-" for stdin): a JSON
+                         array of {path, line, body, side, start_line}, or an
+                         AI review object {"body": ..., "comments": [...]}
+
+A body is required for --comment and --request-changes.
+`
+
+// runReview handles `prtea review ...` and returns the process exit code.
+func runReview(args []string) int {
+	var refArg, action, body, bodyFile, commentsFile string
+	hasBody := false
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		name, value, hasValue := strings.Cut(arg, "=")
+		takeValue := func() bool {
+			if hasValue {
+				return true
+			}
+			if i+1 >= len(args) {
+				return false
+			}
+			i++
+			value = args[i]
+			return true
+		}
+		switch {
+		case arg == "--approve" || arg == "--comment" || arg == "--request-changes":
+			if action != "" {
+				fmt.Fprintln(os.Stderr, "Error: give only one of --approve, --comment, --request-changes")
+				return 2
+			}
+			action = strings.TrimPrefix(arg, "--")
+		case name == "--body" && takeValue():
+			body, hasBody = value, true
+		case name == "--body-file" && takeValue():
+			bodyFile = value
+		case name == "--comments-file" && takeValue():
+			commentsFile = value
+		case arg == "-h" || arg == "--help":
+			fmt.Print(reviewUsage)
+			return 0
+		case strings.HasPrefix(arg, "-") || refArg != "":
+			fmt.Fprint(os.Stderr, reviewUsage)
+			return 2
+		default:
+			refArg = arg
+		}
+	}
+	if refArg == "" || action == "" {
+		fmt.Fprint(os.Stderr, reviewUsage)
+		return 2
+	}
+	if bodyFile == "-" && commentsFile == "-" {
+		fmt.Fprintln(os.Stderr, "Error: only one of --body-file and --comments-file can read stdin")
+		return 2
+	}
+	ref, err := github.ParsePRRef(refArg)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 2
+	}
+	reviewAction, err := ui.ParseReviewAction(action)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 2
+	}
+
+	if bodyFile != "" {
+		data, err := readInputFile(bodyFile)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return 1
+		}
+		body, hasBody = string(data), true
+	}
+	var comments []claude.InlineReviewComment
+	if commentsFile != "" {
+		data, err := readInputFile(commentsFile)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return 1
+		}
+		reviewBody, parsed, err := ui.ParseReviewComments(data)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return 1
+		}
+		comments = parsed
+		if !hasBody {
+			body = reviewBody
+		}
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	if err := ui.SubmitPRReview(ctx, ref, reviewAction, strings.TrimSpace(body), comments); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	fmt.Printf("Submitted %s review on %s with %d inline comment(s).\n", action, ref, len(comments))
+	return 0
+}
+
+// readInputFile reads a file, or stdin for "-".
+func readInputFile(path string) ([]byte, error) {
+	if path == "-" {
+		return io.ReadAll(os.Stdin)
+	}
+	return os.ReadFile(path)
+}
@@ -0,0 +1,131 @@
+package claude
+
+import (
+	"strings"
+	"unicode"
+)
+
+// AnalysisDiff is what changed between two analyses of the same PR, so a
+// re-analysis after a push can show only what is new.
+type AnalysisDiff struct {
+	RiskBefore, RiskAfter string // risk levels; equal when unchanged
+	SummaryBefore         string // the previous summary, "" when unchanged
+
+	NewFindings      []Finding // file review comments not in the previous run
+	ResolvedFindings []Finding // previous comments no longer raised
+
+	NewFiles     []string // files reviewed now but not before
+	DroppedFiles []string // files no longer reviewed
+
+	NewSuggestions     []Suggestion
+	DroppedSuggestions []Suggestion
+}
+
+// Finding is a file review comment with the file it was made on.
+type Finding struct {
+	File string
+	ReviewComment
+}
+
+// Empty reports whether nothing changed between the two runs.
+func (d *AnalysisDiff) Empty() bool {
+	return d.RiskBefore == d.RiskAfter && d.SummaryBefore == "" &&
+		len(d.NewFindings) == 0 && len(d.ResolvedFindings) == 0 &&
+		len(d.NewFiles) == 0 && len(d.DroppedFiles) == 0 &&
+		len(d.NewSuggestions) == 0 && len(d.DroppedSuggestions) == 0
+}
+
+// DiffAnalyses compares a previous analysis with the current one. Claude
+// words the same finding differently from run to run, so comments and
+// suggestions match when their wording is mostly the same, and line
+// numbers (which move with every push) are ignored.
+func DiffAnalyses(prev, cur *AnalysisResult) *AnalysisDiff {
+	d := &AnalysisDiff{RiskBefore: prev.Risk.Level, RiskAfter: cur.Risk.Level}
+	if !similarText(prev.Summary, cur.Summary) {
+		d.SummaryBefore = prev.Summary
+	}
+
+	prevFindings, curFindings := findings(prev), findings(cur)
+	d.NewFindings = unmatched(curFindings, prevFindings, sameFinding)
+	d.ResolvedFindings = unmatched(prevFindings, curFindings, sameFinding)
+
+	prevFiles, curFiles := reviewedFiles(prev), reviewedFiles(cur)
+	d.NewFiles = unmatched(curFiles, prevFiles, func(a, b string) bool { return a == b })
+	d.DroppedFiles = unmatched(prevFiles, curFiles, func(a, b string) bool { return a == b })
+
+	d.NewSuggestions = unmatched(cur.Suggestions, prev.Suggestions, sameSuggestion)
+	d.DroppedSuggestions = unmatched(prev.Suggestions, cur.Suggestions, sameSuggestion)
+	return d
+}
+
+func findings(r *AnalysisResult) []Finding {
+	var out []Finding
+	for _, fr := range r.FileReviews {
+		for _, c := range fr.Comments {
+			out = append(out, Finding{File: fr.File, ReviewComment: c})
+		}
+	}
+	return out
+}
+
+func reviewedFiles(r *AnalysisResult) []string {
+	files := make([]string, 0, len(r.FileReviews))
+	for _, fr := range r.FileReviews {
+		files = append(files, fr.File)
+	}
+	return files
+}
+
+func sameFinding(a, b Finding) bool {
+	return a.File == b.File && similarText(a.Comment, b.Comment)
+}
+
+func sameSuggestion(a, b Suggestion) bool {
+	return similarText(a.Title, b.Title) || (a.Description != "" && similarText(a.Description, b.Description))
+}
+
+// unmatched returns the items of xs that match nothing in ys.
+func unmatched[T any](xs, ys []T, same func(T, T) bool) []T {
+	var out []T
+	for _, x := range xs {
+		found := false
+		for _, y := range ys {
+			if same(x, y) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			out = append(out, x)
+		}
+	}
+	return out
+}
+
+// similarText reports whether two texts share most of their words: a
+// Jaccard similarity of at least 0.5 over words of three letters or more.
+func similarText(a, b string) bool {
+	wa, wb := wordSet(a), wordSet(b)
+	if len(wa) == 0 || len(wb) == 0 {
+		return strings.EqualFold(strings.TrimSpace(a), strings.TrimSpace(b))
+	}
+	common := 0
+	for w := range wa {
+		if wb[w] {
+			common++
+		}
+	}
+	return float64(common)/float64(len(wa)+len(wb)-common) >= 0.5
+}
+
+func wordSet(s string) map[string]bool {
+	words := make(map[string]bool)
+	for _, w := range strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '_'
+	}) {
+		if len(w) >= 3 {
+			words[w] = true
+		}
+	}
+	return words
+}
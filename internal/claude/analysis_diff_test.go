@@ -0,0 +1,53 @@
+package claude
+
+import "testing"
+
+func TestDiffAnalyses(t *testing.T) {
+	prev := &AnalysisResult{
+		Summary: "Adds a cache for user lookups.",
+		Risk:    RiskAssessment{Level: "medium"},
+		FileReviews: []FileReview{
+			{File: "cache.go", Comments: []ReviewComment{
+				{Line: 10, Severity: "warning", Comment: "The cache map is not guarded by a mutex"},
+				{Line: 30, Severity: "suggestion", Comment: "Consider a TTL constant"},
+			}},
+		},
+		Suggestions: []Suggestion{{Title: "Add eviction"}},
+	}
+	cur := &AnalysisResult{
+		Summary: "Adds a cache for user lookups.",
+		Risk:    RiskAssessment{Level: "high"},
+		FileReviews: []FileReview{
+			{File: "cache.go", Comments: []ReviewComment{
+				{Line: 14, Severity: "warning", Comment: "The cache map isn't guarded by any mutex"},
+			}},
+			{File: "api.go", Comments: []ReviewComment{
+				{Line: 3, Severity: "critical", Comment: "Errors from the lookup are dropped"},
+			}},
+		},
+		Suggestions: []Suggestion{{Title: "Add cache eviction"}, {Title: "Benchmark the hot path"}},
+	}
+
+	d := DiffAnalyses(prev, cur)
+	if d.RiskBefore != "medium" || d.RiskAfter != "high" || d.SummaryBefore != "" {
+		t.Errorf("risk/summary = %q→%q, %q", d.RiskBefore, d.RiskAfter, d.SummaryBefore)
+	}
+	if len(d.NewFindings) != 1 || d.NewFindings[0].File != "api.go" {
+		t.Errorf("new findings = %+v, want only the api.go one (reworded and moved ones match)", d.NewFindings)
+	}
+	if len(d.ResolvedFindings) != 1 || d.ResolvedFindings[0].Comment != "Consider a TTL constant" {
+		t.Errorf("resolved findings = %+v", d.ResolvedFindings)
+	}
+	if len(d.NewFiles) != 1 || d.NewFiles[0] != "api.go" || len(d.DroppedFiles) != 0 {
+		t.Errorf("files = +%v -%v", d.NewFiles, d.DroppedFiles)
+	}
+	if len(d.NewSuggestions) != 1 || d.NewSuggestions[0].Title != "Benchmark the hot path" || len(d.DroppedSuggestions) != 0 {
+		t.Errorf("suggestions = +%v -%v", d.NewSuggestions, d.DroppedSuggestions)
+	}
+	if d.Empty() {
+		t.Error("diff should not be empty")
+	}
+	if !DiffAnalyses(cur, cur).Empty() {
+		t.Error("a run compared with itself should be empty")
+	}
+}
@@ -2,6 +2,8 @@ package claude
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"os"
@@ -10,12 +12,12 @@ import (
 	"time"
 )
 
-// Analyzer spawns claude CLI to produce structured PR analysis.
+// Analyzer produces structured PR analysis through an LLMProvider.
 type Analyzer struct {
-	executor   CommandExecutor
 	promptsDir string
 
 	mu               sync.RWMutex
+	provider         LLMProvider
 	timeout          time.Duration
 	analysisMaxTurns int
 }
@@ -25,14 +27,26 @@ type Analyzer struct {
 // promptsDir is the directory for custom per-repo prompts (may be empty).
 // analysisMaxTurns is the max agentic turns for analysis (0 defaults to 30).
 func NewAnalyzer(executor CommandExecutor, timeout time.Duration, promptsDir string, analysisMaxTurns int) *Analyzer {
+	return NewAnalyzerWithProvider(NewCLIProvider(executor, ""), timeout, promptsDir, analysisMaxTurns)
+}
+
+// NewAnalyzerWithProvider creates an Analyzer backed by any LLMProvider.
+func NewAnalyzerWithProvider(provider LLMProvider, timeout time.Duration, promptsDir string, analysisMaxTurns int) *Analyzer {
 	return &Analyzer{
-		executor:         executor,
+		provider:         provider,
 		timeout:          timeout,
 		promptsDir:       promptsDir,
 		analysisMaxTurns: analysisMaxTurns,
 	}
 }
 
+// SetProvider switches the backend used by future analysis requests.
+func (a *Analyzer) SetProvider(p LLMProvider) {
+	a.mu.Lock()
+	a.provider = p
+	a.mu.Unlock()
+}
+
 // SetTimeout updates the command timeout for future analysis requests.
 func (a *Analyzer) SetTimeout(d time.Duration) {
 	a.mu.Lock()
@@ -66,12 +80,16 @@ type AnalyzeDiffInput struct {
 	PRNumber    int
 	PRTitle     string
 	PRBody      string
-	DiffContent string // unified diff patches for all changed files
+	DiffContent string   // unified diff patches for all changed files
+	Truncations []string // parts of the diff left out of DiffContent, e.g. "yarn.lock: patch omitted"
+	CILogs      string   // log tails of failing CI checks; empty for none
+	CIChecks    []string // names of the failing checks in CILogs
 }
 
 // config returns a snapshot of mutable config fields under read lock.
-func (a *Analyzer) config() (timeout time.Duration, maxTurns int) {
+func (a *Analyzer) config() (provider LLMProvider, timeout time.Duration, maxTurns int) {
 	a.mu.RLock()
+	provider = a.provider
 	timeout = a.timeout
 	maxTurns = a.analysisMaxTurns
 	a.mu.RUnlock()
@@ -79,8 +97,13 @@ func (a *Analyzer) config() (timeout time.Duration, maxTurns int) {
 }
 
 // Analyze runs Claude CLI analysis on a PR and returns the structured result.
+// It explores a local checkout with tools, so it needs the CLI provider.
 func (a *Analyzer) Analyze(ctx context.Context, input AnalyzeInput, onProgress ProgressFunc) (*AnalysisResult, error) {
-	timeout, maxTurns := a.config()
+	provider, timeout, maxTurns := a.config()
+	cli, ok := provider.(*CLIProvider)
+	if !ok {
+		return nil, fmt.Errorf("analysis of a local checkout needs the Claude CLI, not %s", provider.Name())
+	}
 	ctx, cancel := context.WithTimeout(ctx, timeout)
 	defer cancel()
 
@@ -97,16 +120,20 @@ func (a *Analyzer) Analyze(ctx context.Context, input AnalyzeInput, onProgress P
 		"--allowedTools", "Read,Glob,Grep,Bash",
 		"--max-turns", fmt.Sprintf("%d", maxTurns),
 	}
+	if cli.model != "" {
+		args = append(args, "--model", cli.model)
+	}
 
 	opts := ExecOptions{
 		Dir: input.RepoPath,
 		Env: filterEnv(os.Environ(), "ANTHROPIC_API_KEY"),
 	}
 
-	resultEvent, err := runCLI(ctx, a.executor, args, opts, progressVisitor(onProgress))
+	resultEvent, err := runCLI(ctx, cli.executor, args, opts, progressVisitor(onProgress))
 	if err != nil {
 		return nil, err
 	}
+	recordUsage(ctx, resultUsage(resultEvent))
 
 	return extractAnalysisResult(resultEvent)
 }
@@ -119,157 +146,186 @@ type ReviewInput struct {
 	PRTitle     string
 	PRBody      string
 	DiffContent string // unified diff patches for all changed files
+	FocusHunks  string // hunks the reviewer selected, most important first; empty for none
+	CILogs      string // log tails of failing CI checks; empty for none
+
+	// Scoped limits the review to the hunks in DiffContent, which then holds
+	// only the reviewer's selection. ChangedFiles lists every file in the PR
+	// for context.
+	Scoped       bool
+	ChangedFiles []string
 }
 
 // AnalyzeForReview runs Claude to generate a GitHub-ready review with inline comments.
 func (a *Analyzer) AnalyzeForReview(ctx context.Context, input ReviewInput, onProgress ProgressFunc) (*ReviewAnalysis, error) {
-	timeout, _ := a.config()
-	ctx, cancel := context.WithTimeout(ctx, timeout)
-	defer cancel()
-
-	prompt := buildReviewPrompt(a.promptsDir, input)
-
-	args := []string{
-		"-p", prompt,
-		"--output-format", "stream-json",
-		"--verbose",
-		"--max-turns", "1",
-	}
-
-	opts := ExecOptions{
-		Env: filterEnv(os.Environ(), "ANTHROPIC_API_KEY"),
-	}
-
-	resultEvent, err := runCLI(ctx, a.executor, args, opts, progressVisitor(onProgress))
+	c, err := a.complete(ctx, CompletionRequest{
+		Prompt:     buildReviewPrompt(a.promptsDir, input),
+		OnProgress: onProgress,
+	})
 	if err != nil {
 		return nil, err
 	}
-
-	return extractReviewResult(resultEvent)
+	return parseReviewResult(c.Text)
 }
 
 // AnalyzeDiff runs analysis using inline diff content (no local repo needed).
 func (a *Analyzer) AnalyzeDiff(ctx context.Context, input AnalyzeDiffInput, onProgress ProgressFunc) (*AnalysisResult, error) {
-	timeout, _ := a.config()
-	ctx, cancel := context.WithTimeout(ctx, timeout)
-	defer cancel()
-
-	prompt := buildDiffAnalysisPrompt(a.promptsDir, input)
-
-	args := []string{
-		"-p", prompt,
-		"--output-format", "stream-json",
-		"--verbose",
-		"--max-turns", "1",
-	}
-
-	opts := ExecOptions{
-		Env: filterEnv(os.Environ(), "ANTHROPIC_API_KEY"),
-	}
-
-	resultEvent, err := runCLI(ctx, a.executor, args, opts, progressVisitor(onProgress))
+	c, err := a.complete(ctx, CompletionRequest{
+		Prompt:     buildDiffAnalysisPrompt(a.promptsDir, input),
+		OnProgress: onProgress,
+	})
 	if err != nil {
 		return nil, err
 	}
-
-	return extractAnalysisResult(resultEvent)
+	return parseAnalysisResult(c.Text)
 }
 
 // AnalyzeDiffStream is like AnalyzeDiff but with token-level streaming.
-// onChunk is called with each text delta as it arrives from the Claude CLI.
+// onChunk is called with each text delta as it arrives from the provider.
 func (a *Analyzer) AnalyzeDiffStream(ctx context.Context, input AnalyzeDiffInput, onChunk func(string)) (*AnalysisResult, error) {
-	timeout, _ := a.config()
-	ctx, cancel := context.WithTimeout(ctx, timeout)
-	defer cancel()
-
-	prompt := buildDiffAnalysisPrompt(a.promptsDir, input)
-
-	args := []string{
-		"-p", prompt,
-		"--output-format", "stream-json",
-		"--verbose",
-		"--include-partial-messages",
-		"--max-turns", "1",
+	c, err := a.complete(ctx, CompletionRequest{
+		Prompt:  buildDiffAnalysisPrompt(a.promptsDir, input),
+		OnChunk: onChunk,
+	})
+	if err != nil {
+		return nil, err
 	}
-
-	opts := ExecOptions{
-		Env: filterEnv(os.Environ(), "ANTHROPIC_API_KEY"),
+	result, err := parseAnalysisResult(c.Text)
+	if err != nil {
+		return nil, err
 	}
+	result.Model = c.Model
+	return result, nil
+}
 
-	resultEvent, err := runCLI(ctx, a.executor, args, opts, streamDeltaVisitor(onChunk))
+// SummarizeDiff produces a short what/why/risk summary of a PR, a cheaper
+// alternative to a full analysis.
+func (a *Analyzer) SummarizeDiff(ctx context.Context, input AnalyzeDiffInput) (*PRSummary, error) {
+	c, err := a.complete(ctx, CompletionRequest{Prompt: buildSummaryPrompt(input)})
 	if err != nil {
 		return nil, err
 	}
-
-	return extractAnalysisResult(resultEvent)
+	var summary PRSummary
+	if err := parseJSONAnswer(c.Text, &summary); err != nil {
+		return nil, fmt.Errorf("failed to parse summary JSON: %w\nraw: %s", err, truncate(c.Text, 500))
+	}
+	if len(summary.Bullets) == 0 {
+		return nil, fmt.Errorf("summary has no bullets")
+	}
+	summary.Model = c.Model
+	return &summary, nil
 }
 
-func extractAnalysisResult(event *StreamEvent) (*AnalysisResult, error) {
-	var resultText string
+// DraftMergeMessage writes a squash-merge commit message and release note
+// for a PR.
+func (a *Analyzer) DraftMergeMessage(ctx context.Context, input AnalyzeDiffInput) (*MergeMessage, error) {
+	c, err := a.complete(ctx, CompletionRequest{Prompt: buildMergeMessagePrompt(input)})
+	if err != nil {
+		return nil, err
+	}
+	var msg MergeMessage
+	if err := parseJSONAnswer(c.Text, &msg); err != nil {
+		return nil, fmt.Errorf("failed to parse merge message JSON: %w\nraw: %s", err, truncate(c.Text, 500))
+	}
+	msg.Title = strings.TrimSpace(msg.Title)
+	if msg.Title == "" {
+		return nil, fmt.Errorf("merge message has no title")
+	}
+	msg.Body = strings.TrimSpace(msg.Body)
+	msg.ReleaseNote = strings.TrimSpace(msg.ReleaseNote)
+	return &msg, nil
+}
 
-	switch v := event.Result.(type) {
-	case string:
-		resultText = v
+// TriagePR makes a quick pass over a PR, summarizing it in one line and
+// tagging how much review attention it needs.
+func (a *Analyzer) TriagePR(ctx context.Context, input AnalyzeDiffInput) (*PRTriage, error) {
+	c, err := a.complete(ctx, CompletionRequest{Prompt: buildTriagePrompt(input)})
+	if err != nil {
+		return nil, err
+	}
+	var triage PRTriage
+	if err := parseJSONAnswer(c.Text, &triage); err != nil {
+		return nil, fmt.Errorf("failed to parse triage JSON: %w\nraw: %s", err, truncate(c.Text, 500))
+	}
+	triage.Summary = strings.TrimSpace(triage.Summary)
+	switch triage.Tag {
+	case TriageTrivial, TriageCareful, TriageRisky:
 	default:
-		data, err := json.Marshal(v)
-		if err != nil {
-			return nil, fmt.Errorf("failed to marshal result: %w", err)
-		}
-		resultText = string(data)
+		return nil, fmt.Errorf("unknown triage tag %q", triage.Tag)
 	}
+	return &triage, nil
+}
 
-	// Try direct parse
-	var result AnalysisResult
-	if err := json.Unmarshal([]byte(resultText), &result); err == nil {
-		return &result, nil
+// complete runs a single-turn request through the provider under the
+// analysis timeout.
+func (a *Analyzer) complete(ctx context.Context, req CompletionRequest) (*Completion, error) {
+	provider, timeout, _ := a.config()
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	req.MaxTurns = 1
+	c, err := provider.Complete(ctx, req)
+	if err != nil {
+		return nil, err
 	}
+	recordUsage(ctx, c.Usage)
+	return c, nil
+}
 
-	// Fallback: extract JSON between first { and last }
-	start := strings.Index(resultText, "{")
-	end := strings.LastIndex(resultText, "}")
-	if start == -1 || end == -1 || end <= start {
-		return nil, fmt.Errorf("no JSON object found in claude result")
+// DiffAnalysisInputs describes what AnalyzeDiffStream sends for input. The
+// model is only known once the CLI reports it (see AnalysisResult.Model).
+func (a *Analyzer) DiffAnalysisInputs(input AnalyzeDiffInput) *AnalysisInputs {
+	inputs := &AnalysisInputs{
+		PromptHash:  shortHash(buildDiffAnalysisPrompt(a.promptsDir, input)),
+		DiffHash:    shortHash(input.DiffContent),
+		Truncations: input.Truncations,
+		CIChecks:    input.CIChecks,
 	}
-
-	if err := json.Unmarshal([]byte(resultText[start:end+1]), &result); err != nil {
-		return nil, fmt.Errorf("failed to parse analysis JSON: %w\nraw: %s", err, truncate(resultText, 500))
+	if loadCustomPrompt(a.promptsDir, input.Owner, input.Repo) != "" {
+		inputs.CustomPrompt = CustomPromptPath(a.promptsDir, input.Owner, input.Repo)
 	}
+	return inputs
+}
 
-	return &result, nil
+func extractAnalysisResult(event *StreamEvent) (*AnalysisResult, error) {
+	return parseAnalysisResult(extractResultText(event))
 }
 
 func extractReviewResult(event *StreamEvent) (*ReviewAnalysis, error) {
-	var resultText string
+	return parseReviewResult(extractResultText(event))
+}
 
-	switch v := event.Result.(type) {
-	case string:
-		resultText = v
-	default:
-		data, err := json.Marshal(v)
-		if err != nil {
-			return nil, fmt.Errorf("failed to marshal result: %w", err)
-		}
-		resultText = string(data)
+// parseAnalysisResult decodes an analysis from the model's answer, which
+// should be bare JSON but may be wrapped in prose or a code fence.
+func parseAnalysisResult(text string) (*AnalysisResult, error) {
+	var result AnalysisResult
+	if err := parseJSONAnswer(text, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse analysis JSON: %w\nraw: %s", err, truncate(text, 500))
+	}
+	return &result, nil
+}
+
+// parseReviewResult decodes a review from the model's answer.
+func parseReviewResult(text string) (*ReviewAnalysis, error) {
+	var result ReviewAnalysis
+	if err := parseJSONAnswer(text, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse review JSON: %w\nraw: %s", err, truncate(text, 500))
 	}
+	return &result, nil
+}
 
+func parseJSONAnswer(text string, v any) error {
 	// Try direct parse
-	var result ReviewAnalysis
-	if err := json.Unmarshal([]byte(resultText), &result); err == nil {
-		return &result, nil
+	if err := json.Unmarshal([]byte(text), v); err == nil {
+		return nil
 	}
 
 	// Fallback: extract JSON between first { and last }
-	start := strings.Index(resultText, "{")
-	end := strings.LastIndex(resultText, "}")
+	start := strings.Index(text, "{")
+	end := strings.LastIndex(text, "}")
 	if start == -1 || end == -1 || end <= start {
-		return nil, fmt.Errorf("no JSON object found in claude review result")
+		return fmt.Errorf("no JSON object found in answer")
 	}
-
-	if err := json.Unmarshal([]byte(resultText[start:end+1]), &result); err != nil {
-		return nil, fmt.Errorf("failed to parse review JSON: %w\nraw: %s", err, truncate(resultText, 500))
-	}
-
-	return &result, nil
+	return json.Unmarshal([]byte(text[start:end+1]), v)
 }
 
 func filterEnv(env []string, remove string) []string {
@@ -287,6 +343,12 @@ func isNotFound(err error) bool {
 	return strings.Contains(err.Error(), "executable file not found")
 }
 
+// shortHash returns the first 16 hex digits of the SHA-256 of s.
+func shortHash(s string) string {
+	sum := sha256.Sum256([]byte(s))
+	return hex.EncodeToString(sum[:8])
+}
+
 func truncate(s string, maxLen int) string {
 	if len(s) <= maxLen {
 		return s
@@ -2,6 +2,9 @@ package claude
 
 import (
 	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 )
 
@@ -51,6 +54,24 @@ func TestExtractAnalysisResult_WrappedJSON(t *testing.T) {
 	}
 }
 
+func TestExtractAnalysisResult_FractionalReviewMinutes(t *testing.T) {
+	event := &StreamEvent{
+		Type:   "result",
+		Result: `{"summary":"Tidy logging","risk":{"level":"low"},"estimatedReviewMinutes":7.5,"reviewOrder":[{"file":"log.go","reason":"only file"}]}`,
+	}
+
+	got, err := extractAnalysisResult(event)
+	if err != nil {
+		t.Fatalf("a fractional estimate should not lose the analysis: %v", err)
+	}
+	if got.EstimatedReviewMinutes != 8 {
+		t.Errorf("EstimatedReviewMinutes = %d, want 8", got.EstimatedReviewMinutes)
+	}
+	if got.Summary != "Tidy logging" || len(got.ReviewOrder) != 1 {
+		t.Errorf("other fields lost: %+v", got)
+	}
+}
+
 func TestExtractAnalysisResult_NoJSON(t *testing.T) {
 	event := &StreamEvent{
 		Type:   "result",
@@ -132,3 +153,81 @@ func TestTruncate(t *testing.T) {
 		})
 	}
 }
+
+func TestBuildReviewPrompt_FocusHunks(t *testing.T) {
+	input := ReviewInput{Owner: "o", Repo: "r", PRNumber: 1, DiffContent: "FULL DIFF"}
+	if prompt := buildReviewPrompt("", input); strings.Contains(prompt, "focus on these hunks") {
+		t.Error("prompt should not mention focus hunks when none are selected")
+	}
+
+	input.FocusHunks = "--- a/x.go\n+++ b/x.go\n@@ -1 +1 @@\n+focus\n"
+	prompt := buildReviewPrompt("", input)
+	if !strings.Contains(prompt, "primary concern") || !strings.Contains(prompt, "+focus") {
+		t.Errorf("prompt missing focus section:\n%s", prompt)
+	}
+	if strings.Index(prompt, "+focus") < strings.Index(prompt, "FULL DIFF") {
+		t.Error("focus hunks should follow the full diff")
+	}
+}
+
+func TestBuildReviewPrompt_Scoped(t *testing.T) {
+	input := ReviewInput{
+		Owner: "o", Repo: "r", PRNumber: 1,
+		DiffContent:  "--- a/x.go\n+++ b/x.go\n@@ -1 +1 @@\n+picked\n",
+		Scoped:       true,
+		ChangedFiles: []string{"x.go", "y.go"},
+	}
+	prompt := buildReviewPrompt("", input)
+	if strings.Contains(prompt, "complete diff") || strings.Contains(prompt, "Review all changes") {
+		t.Errorf("scoped prompt should not claim the full diff:\n%s", prompt)
+	}
+	for _, want := range []string{"only these hunks", "Files changed in this PR: x.go, y.go", "+picked", "Review only the hunks"} {
+		if !strings.Contains(prompt, want) {
+			t.Errorf("scoped prompt missing %q", want)
+		}
+	}
+}
+
+func TestPrompts_CILogs(t *testing.T) {
+	input := AnalyzeDiffInput{Owner: "o", Repo: "r", PRNumber: 1, DiffContent: "FULL DIFF"}
+	if prompt := buildDiffAnalysisPrompt("", input); strings.Contains(prompt, "CI is failing") {
+		t.Error("no CI section without logs")
+	}
+	input.CILogs = "### test\nFAIL TestCache\n"
+	prompt := buildDiffAnalysisPrompt("", input)
+	if i := strings.Index(prompt, "FAIL TestCache"); i < strings.Index(prompt, "FULL DIFF") || !strings.Contains(prompt, "whether the changes in this diff explain it") {
+		t.Errorf("analysis prompt should follow the diff with the CI logs:\n%s", prompt)
+	}
+	review := buildReviewPrompt("", ReviewInput{Owner: "o", Repo: "r", PRNumber: 1, DiffContent: "FULL DIFF", CILogs: input.CILogs})
+	if !strings.Contains(review, "FAIL TestCache") {
+		t.Errorf("review prompt missing the CI logs:\n%s", review)
+	}
+}
+
+func TestCustomPrompts_RepoOverridesDefault(t *testing.T) {
+	dir := t.TempDir()
+	input := AnalyzeDiffInput{Owner: "acme", Repo: "my_repo"}
+	if got := DiffAnalysisPrompt(dir, input); strings.Contains(got, "Additional review instructions") {
+		t.Error("no custom prompt exists yet")
+	}
+
+	os.WriteFile(filepath.Join(dir, DefaultPromptName), []byte("Check for SQL injection."), 0o644)
+	if got := DiffAnalysisPrompt(dir, input); !strings.Contains(got, "Check for SQL injection.") {
+		t.Error("the default prompt should apply to repos without their own")
+	}
+
+	os.WriteFile(RepoPromptPath(dir, "acme", "my_repo"), []byte("Mind the cache."), 0o644)
+	got := DiffAnalysisPrompt(dir, input)
+	if !strings.Contains(got, "Mind the cache.") || strings.Contains(got, "SQL injection") {
+		t.Error("a repo prompt should replace the default")
+	}
+
+	os.WriteFile(filepath.Join(dir, "notes.txt"), nil, 0o644)
+	prompts, err := ListCustomPrompts(dir)
+	if err != nil {
+		t.Fatalf("ListCustomPrompts: %v", err)
+	}
+	if len(prompts) != 2 || !prompts[0].IsDefault() || prompts[1].Owner != "acme" || prompts[1].Repo != "my_repo" {
+		t.Errorf("prompts = %+v", prompts)
+	}
+}
@@ -0,0 +1,148 @@
+package claude
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// DefaultAnthropicBaseURL is the Anthropic API root.
+const DefaultAnthropicBaseURL = "https://api.anthropic.com"
+
+// anthropicVersion is the Messages API version header value.
+const anthropicVersion = "2023-06-01"
+
+// anthropicMaxTokens caps each response. Analysis JSON for a large PR is the
+// longest answer prtea asks for and fits well within it.
+const anthropicMaxTokens = 8192
+
+// AnthropicProvider calls the Anthropic Messages API directly, avoiding the
+// Claude CLI subprocess and its startup cost on every prompt.
+type AnthropicProvider struct {
+	baseURL string
+	apiKey  string
+	model   string
+	client  *http.Client
+}
+
+// NewAnthropicProvider creates a provider using apiKey (usually
+// ANTHROPIC_API_KEY). An empty baseURL uses DefaultAnthropicBaseURL.
+func NewAnthropicProvider(baseURL, apiKey, model string) *AnthropicProvider {
+	if baseURL == "" {
+		baseURL = DefaultAnthropicBaseURL
+	}
+	return &AnthropicProvider{
+		baseURL: strings.TrimRight(baseURL, "/"),
+		apiKey:  apiKey,
+		model:   model,
+		client:  http.DefaultClient,
+	}
+}
+
+// Name implements LLMProvider.
+func (p *AnthropicProvider) Name() string {
+	return "Anthropic API (" + p.model + ")"
+}
+
+// anthropicEvent is one server-sent event from a streamed Messages request.
+// Deltas share their shape with the CLI's stream_event payloads.
+type anthropicEvent struct {
+	StreamInnerEvent
+	Message *struct {
+		Model string `json:"model"`
+		Usage Usage  `json:"usage"`
+	} `json:"message,omitempty"`
+	Usage *Usage `json:"usage,omitempty"` // on message_delta: output tokens so far
+	Error *struct {
+		Type    string `json:"type"`
+		Message string `json:"message"`
+	} `json:"error,omitempty"`
+}
+
+// Complete implements LLMProvider using a streamed Messages request.
+func (p *AnthropicProvider) Complete(ctx context.Context, req CompletionRequest) (*Completion, error) {
+	body, err := json.Marshal(map[string]any{
+		"model":      p.model,
+		"max_tokens": anthropicMaxTokens,
+		"stream":     true,
+		"messages":   []map[string]string{{"role": "user", "content": req.Prompt}},
+	})
+	if err != nil {
+		return nil, err
+	}
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, p.baseURL+"/v1/messages", bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("x-api-key", p.apiKey)
+	httpReq.Header.Set("anthropic-version", anthropicVersion)
+
+	resp, err := p.client.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", p.Name(), err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, httpStatusError(p.Name(), resp)
+	}
+
+	// Server-sent events; only the "data:" lines matter since each payload
+	// carries its own type.
+	var text strings.Builder
+	completion := &Completion{Model: p.model}
+	scanner := bufio.NewScanner(resp.Body)
+	scanner.Buffer(make([]byte, 1024*1024), 1024*1024)
+	for scanner.Scan() {
+		data, ok := strings.CutPrefix(scanner.Text(), "data:")
+		if !ok {
+			continue
+		}
+		var event anthropicEvent
+		if err := json.Unmarshal([]byte(strings.TrimSpace(data)), &event); err != nil {
+			continue
+		}
+		switch event.Type {
+		case "message_start":
+			if event.Message != nil {
+				if event.Message.Model != "" {
+					completion.Model = event.Message.Model
+				}
+				completion.Usage = event.Message.Usage
+			}
+		case "message_delta":
+			if event.Usage != nil {
+				completion.Usage.OutputTokens = event.Usage.OutputTokens
+			}
+		case "content_block_delta":
+			if event.Delta == nil || event.Delta.Type != "text_delta" || event.Delta.Text == "" {
+				continue
+			}
+			text.WriteString(event.Delta.Text)
+			if req.OnChunk != nil {
+				req.OnChunk(event.Delta.Text)
+			}
+		case "error":
+			if event.Error != nil {
+				return nil, fmt.Errorf("%s: %s", p.Name(), event.Error.Message)
+			}
+			return nil, fmt.Errorf("%s: stream error", p.Name())
+		}
+		if event.Type == "message_stop" {
+			break
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("%s: reading response: %w", p.Name(), err)
+	}
+
+	completion.Text = text.String()
+	completion.Streamed = completion.Text
+	completion.Usage.Calls = 1
+	completion.Usage.CostUSD = estimateAnthropicCost(completion.Model, completion.Usage)
+	return completion, nil
+}
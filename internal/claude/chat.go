@@ -4,7 +4,6 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"os"
 	"strings"
 	"sync"
 	"time"
@@ -12,7 +11,7 @@ import (
 
 // ChatService manages Claude chat sessions for PR discussions.
 type ChatService struct {
-	executor           CommandExecutor
+	provider           LLMProvider
 	timeout            time.Duration
 	maxPromptTokens    int
 	maxHistoryMessages int
@@ -24,8 +23,13 @@ type ChatService struct {
 
 // NewChatService creates a ChatService with optional persistent storage.
 func NewChatService(executor CommandExecutor, timeout time.Duration, store *ChatStore, maxPromptTokens, maxHistory, maxTurns int) *ChatService {
+	return NewChatServiceWithProvider(NewCLIProvider(executor, ""), timeout, store, maxPromptTokens, maxHistory, maxTurns)
+}
+
+// NewChatServiceWithProvider creates a ChatService backed by any LLMProvider.
+func NewChatServiceWithProvider(provider LLMProvider, timeout time.Duration, store *ChatStore, maxPromptTokens, maxHistory, maxTurns int) *ChatService {
 	return &ChatService{
-		executor:           executor,
+		provider:           provider,
 		timeout:            timeout,
 		maxPromptTokens:    maxPromptTokens,
 		maxHistoryMessages: maxHistory,
@@ -44,8 +48,20 @@ type ChatInput struct {
 	PRContext     string // PR metadata + diff content embedded as text
 	HunksSelected bool   // true when the user has selected specific hunks
 	Message       string
+
+	// RepoPath is a local clone of the repo that Claude may explore with
+	// chatRepoTools. OnProgress reports each tool use; it may be nil.
+	RepoPath   string
+	OnProgress ProgressFunc
 }
 
+// chatRepoTools are the read-only tools chat may use in a local clone.
+var chatRepoTools = []string{"Read", "Grep", "Glob"}
+
+// chatToolTurns is the fewest turns chat gets with repo tools, since each
+// tool call takes a turn.
+const chatToolTurns = 10
+
 // ClearSession removes the chat history for a PR (in memory and on disk).
 func (cs *ChatService) ClearSession(owner, repo string, prNumber int) {
 	key := sessionKey(owner, repo, prNumber)
@@ -68,7 +84,7 @@ func (cs *ChatService) SaveSession(owner, repo string, prNumber int) {
 	session, ok := cs.sessions[key]
 	cs.mu.Unlock()
 	if ok && len(session.Messages) > 0 {
-		_ = cs.store.Put(owner, repo, prNumber, session.Messages)
+		_ = cs.store.PutSession(owner, repo, prNumber, session)
 	}
 }
 
@@ -87,7 +103,11 @@ func (cs *ChatService) GetSessionMessages(owner, repo string, prNumber int) []Ch
 		if cached, err := cs.store.Get(owner, repo, prNumber); err == nil && cached != nil {
 			// Restore into memory
 			cs.mu.Lock()
-			cs.sessions[key] = &ChatSession{Messages: cached.Messages}
+			cs.sessions[key] = &ChatSession{
+				Messages:        cached.Messages,
+				Summary:         cached.Summary,
+				SummarizedCount: cached.SummarizedCount,
+			}
 			cs.mu.Unlock()
 			return cached.Messages
 		}
@@ -106,8 +126,10 @@ func (cs *ChatService) getOrCreateSession(input ChatInput) *ChatSession {
 		if cs.store != nil {
 			if cached, err := cs.store.Get(input.Owner, input.Repo, input.PRNumber); err == nil && cached != nil {
 				session = &ChatSession{
-					PRContext: input.PRContext,
-					Messages:  cached.Messages,
+					PRContext:       input.PRContext,
+					Messages:        cached.Messages,
+					Summary:         cached.Summary,
+					SummarizedCount: cached.SummarizedCount,
 				}
 				cs.sessions[key] = session
 				return session
@@ -134,15 +156,10 @@ const (
 func (cs *ChatService) ChatStream(ctx context.Context, input ChatInput, onChunk func(text string)) (string, error) {
 	// Snapshot config under lock to avoid races with Set* methods.
 	cs.mu.Lock()
-	timeout := cs.timeout
 	maxTokens := cs.maxPromptTokens
 	maxHistory := cs.maxHistoryMessages
-	turns := cs.maxTurns
 	cs.mu.Unlock()
 
-	ctx, cancel := context.WithTimeout(ctx, timeout)
-	defer cancel()
-
 	session := cs.getOrCreateSession(input)
 
 	if maxTokens == 0 {
@@ -151,58 +168,24 @@ func (cs *ChatService) ChatStream(ctx context.Context, input ChatInput, onChunk
 	if maxHistory == 0 {
 		maxHistory = defaultMaxHistoryMessages
 	}
-	if turns == 0 {
-		turns = defaultChatMaxTurns
-	}
 
-	prompt := buildChatPrompt(session, input, maxTokens, maxHistory)
-
-	args := []string{
-		"-p", prompt,
-		"--output-format", "stream-json",
-		"--verbose",
-		"--include-partial-messages",
-		"--max-turns", fmt.Sprintf("%d", turns),
-	}
-
-	opts := ExecOptions{
-		Env: filterEnv(os.Environ(), "ANTHROPIC_API_KEY"),
-	}
+	cs.summarizeOverflow(ctx, session, maxHistory)
 
-	var streamedText strings.Builder
-	visitor := func(event *StreamEvent) {
-		// Token-level streaming: stream_event with content_block_delta
-		if event.Type == "stream_event" && event.Event != nil {
-			if event.Event.Type == "content_block_delta" && event.Event.Delta != nil {
-				if event.Event.Delta.Type == "text_delta" && event.Event.Delta.Text != "" {
-					onChunk(event.Event.Delta.Text)
-					streamedText.WriteString(event.Event.Delta.Text)
-				}
-			}
-			return
-		}
+	cs.mu.Lock()
+	prompt := buildChatPrompt(session, input, maxTokens, maxHistory)
+	cs.mu.Unlock()
 
-		// Fallback: complete assistant turn (without --include-partial-messages)
-		if event.Type == "assistant" && event.Message != nil {
-			for _, block := range event.Message.Content {
-				if block.Type == "text" && block.Text != "" {
-					onChunk(block.Text)
-				}
-			}
-		}
+	req := CompletionRequest{Prompt: prompt, OnChunk: onChunk}
+	if input.RepoPath != "" {
+		req.AllowedTools = chatRepoTools
+		req.Dir = input.RepoPath
+		req.OnProgress = input.OnProgress
 	}
-
-	resultEvent, err := runCLI(ctx, cs.executor, args, opts, visitor)
+	finalText, err := cs.streamPrompt(ctx, req)
 	if err != nil {
 		return "", err
 	}
 
-	// Prefer streamed text if available (token-level), fall back to result event
-	finalText := extractResultText(resultEvent)
-	if streamedText.Len() > 0 {
-		finalText = streamedText.String()
-	}
-
 	// Append exchange to session history
 	cs.mu.Lock()
 	session.Messages = append(session.Messages,
@@ -213,12 +196,89 @@ func (cs *ChatService) ChatStream(ctx context.Context, input ChatInput, onChunk
 
 	// Persist to disk after each exchange
 	if cs.store != nil {
-		_ = cs.store.Put(input.Owner, input.Repo, input.PRNumber, session.Messages)
+		_ = cs.store.PutSession(input.Owner, input.Repo, input.PRNumber, session)
 	}
 
 	return finalText, nil
 }
 
+// summarizeOverflow folds older messages into the session's running summary
+// once the unsummarized history grows past maxHistory, keeping the most
+// recent half verbatim. On failure the session is left untouched and
+// buildChatPrompt falls back to dropping the oldest messages.
+func (cs *ChatService) summarizeOverflow(ctx context.Context, session *ChatSession, maxHistory int) {
+	keep := max(2, maxHistory/2&^1) // whole user/assistant exchanges
+
+	cs.mu.Lock()
+	start := session.SummarizedCount
+	end := len(session.Messages) - keep
+	if len(session.Messages)-start <= maxHistory || end <= start {
+		cs.mu.Unlock()
+		return
+	}
+	previous := session.Summary
+	older := append([]ChatMessage(nil), session.Messages[start:end]...)
+	cs.mu.Unlock()
+
+	summary, err := cs.streamPrompt(ctx, CompletionRequest{Prompt: buildChatSummaryPrompt(previous, older), OnChunk: func(string) {}})
+	summary = strings.TrimSpace(summary)
+	if err != nil || summary == "" {
+		return
+	}
+
+	cs.mu.Lock()
+	session.Summary = summary
+	session.SummarizedCount = end
+	cs.mu.Unlock()
+}
+
+// AskOnce sends a one-off question about input.PRContext (typically a single
+// hunk) without any chat history. The exchange is not recorded in the PR's
+// chat session.
+func (cs *ChatService) AskOnce(ctx context.Context, input ChatInput, onChunk func(text string)) (string, error) {
+	cs.mu.Lock()
+	maxTokens := cs.maxPromptTokens
+	cs.mu.Unlock()
+	if maxTokens == 0 {
+		maxTokens = defaultMaxPromptTokens
+	}
+	return cs.streamPrompt(ctx, CompletionRequest{Prompt: buildQuickQuestionPrompt(input, maxTokens), OnChunk: onChunk})
+}
+
+// streamPrompt runs a chat request through the provider with token-level
+// streaming and returns the complete response text. The turn limit comes
+// from the service.
+func (cs *ChatService) streamPrompt(ctx context.Context, req CompletionRequest) (string, error) {
+	cs.mu.Lock()
+	provider := cs.provider
+	timeout := cs.timeout
+	turns := cs.maxTurns
+	cs.mu.Unlock()
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	if turns == 0 {
+		turns = defaultChatMaxTurns
+	}
+	if len(req.AllowedTools) > 0 {
+		turns = max(turns, chatToolTurns)
+	}
+	req.MaxTurns = turns
+
+	c, err := provider.Complete(ctx, req)
+	if err != nil {
+		return "", err
+	}
+	recordUsage(ctx, c.Usage)
+
+	// Prefer streamed text if available (token-level), fall back to the final answer
+	if c.Streamed != "" {
+		return c.Streamed, nil
+	}
+	return c.Text, nil
+}
+
 // extractResultText pulls the text content from a result stream event.
 func extractResultText(event *StreamEvent) string {
 	switch v := event.Result.(type) {
@@ -236,6 +296,13 @@ func extractResultText(event *StreamEvent) string {
 	}
 }
 
+// SetProvider switches the backend used by future chat requests.
+func (cs *ChatService) SetProvider(p LLMProvider) {
+	cs.mu.Lock()
+	cs.provider = p
+	cs.mu.Unlock()
+}
+
 // SetTimeout updates the command timeout for future chat requests.
 func (cs *ChatService) SetTimeout(d time.Duration) {
 	cs.mu.Lock()
@@ -20,8 +20,10 @@ func NewChatStore(cacheDir string) *ChatStore {
 
 // CachedChatSession wraps a chat session with persistence metadata.
 type CachedChatSession struct {
-	Messages  []ChatMessage `json:"messages"`
-	UpdatedAt time.Time     `json:"updatedAt"`
+	Messages        []ChatMessage `json:"messages"`
+	Summary         string        `json:"summary,omitempty"`
+	SummarizedCount int           `json:"summarizedCount,omitempty"`
+	UpdatedAt       time.Time     `json:"updatedAt"`
 }
 
 // Get loads a cached chat session for a PR. Returns nil if not found.
@@ -43,9 +45,14 @@ func (s *ChatStore) Get(owner, repo string, number int) (*CachedChatSession, err
 	return &cached, nil
 }
 
-// Put saves a chat session to disk.
+// Put saves a chat session's messages to disk.
 func (s *ChatStore) Put(owner, repo string, number int, messages []ChatMessage) error {
-	if len(messages) == 0 {
+	return s.PutSession(owner, repo, number, &ChatSession{Messages: messages})
+}
+
+// PutSession saves a chat session, including its running summary, to disk.
+func (s *ChatStore) PutSession(owner, repo string, number int, session *ChatSession) error {
+	if len(session.Messages) == 0 {
 		return nil // nothing to persist
 	}
 
@@ -54,8 +61,10 @@ func (s *ChatStore) Put(owner, repo string, number int, messages []ChatMessage)
 	}
 
 	cached := CachedChatSession{
-		Messages:  messages,
-		UpdatedAt: time.Now(),
+		Messages:        session.Messages,
+		Summary:         session.Summary,
+		SummarizedCount: session.SummarizedCount,
+		UpdatedAt:       time.Now(),
 	}
 
 	data, err := json.MarshalIndent(cached, "", "  ")
@@ -1,9 +1,12 @@
 package claude
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
 	"strings"
 	"testing"
+	"time"
 )
 
 func TestSessionKey(t *testing.T) {
@@ -164,6 +167,29 @@ func TestBuildChatPrompt_TokenBudget_TruncatesDiff(t *testing.T) {
 	}
 }
 
+func TestBuildQuickQuestionPrompt(t *testing.T) {
+	input := ChatInput{
+		PRContext: "--- a/main.go\n+++ b/main.go\n@@ -1,2 +1,2 @@\n-x := f()\n+x := g()",
+		Message:   "does this handle nil?",
+	}
+
+	prompt := buildQuickQuestionPrompt(input, defaultMaxPromptTokens)
+	for _, want := range []string{"+x := g()", "Question: does this handle nil?", "one hunk"} {
+		if !strings.Contains(prompt, want) {
+			t.Errorf("prompt missing %q", want)
+		}
+	}
+
+	input.PRContext = strings.Repeat("+ added line\n", 100000)
+	prompt = buildQuickQuestionPrompt(input, defaultMaxPromptTokens)
+	if !strings.Contains(prompt, "[... hunk truncated to fit context window ...]") {
+		t.Error("large hunk should be truncated")
+	}
+	if !strings.HasSuffix(prompt, "Question: does this handle nil?") {
+		t.Error("question should survive truncation")
+	}
+}
+
 func TestExtractResultText(t *testing.T) {
 	t.Run("string result", func(t *testing.T) {
 		event := &StreamEvent{Type: "result", Result: "The answer is 42"}
@@ -290,3 +316,134 @@ func TestChatService_ClearSession_WithStore(t *testing.T) {
 		t.Error("session should be cleared from disk")
 	}
 }
+
+func TestBuildChatPrompt_UsesSummaryForFoldedMessages(t *testing.T) {
+	session := &ChatSession{
+		Messages: []ChatMessage{
+			{Role: "user", Content: "old question"},
+			{Role: "assistant", Content: "old answer"},
+			{Role: "user", Content: "recent question"},
+			{Role: "assistant", Content: "recent answer"},
+		},
+		Summary:         "- Agreed to rename Foo to Bar",
+		SummarizedCount: 2,
+	}
+	input := ChatInput{PRContext: "diff", Message: "next"}
+
+	prompt := buildChatPrompt(session, input, defaultMaxPromptTokens, defaultMaxHistoryMessages)
+
+	if !strings.Contains(prompt, "Agreed to rename Foo to Bar") {
+		t.Error("prompt should include the running summary")
+	}
+	if strings.Contains(prompt, "old question") {
+		t.Error("summarized messages should not be repeated verbatim")
+	}
+	if !strings.Contains(prompt, "recent question") {
+		t.Error("unsummarized messages should still be included")
+	}
+}
+
+func TestChatService_SummarizesOverflowingHistory(t *testing.T) {
+	exec := &sequentialMockExecutor{calls: []mockCall{
+		{stdout: resultEvent("- Decided to keep the retry loop")},
+		{stdout: resultEvent("sure")},
+	}}
+	store := NewChatStore(t.TempDir())
+	svc := NewChatService(exec, time.Minute, store, 0, 4, 0)
+
+	var messages []ChatMessage
+	for i := 0; i < 3; i++ {
+		messages = append(messages,
+			ChatMessage{Role: "user", Content: fmt.Sprintf("question %d", i)},
+			ChatMessage{Role: "assistant", Content: fmt.Sprintf("answer %d", i)},
+		)
+	}
+	svc.sessions[sessionKey("alice", "widget-factory", 42)] = &ChatSession{Messages: messages}
+
+	_, err := svc.ChatStream(context.Background(), ChatInput{
+		Owner: "alice", Repo: "widget-factory", PRNumber: 42, Message: "and now?",
+	}, func(string) {})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	session := svc.sessions[sessionKey("alice", "widget-factory", 42)]
+	if session.Summary != "- Decided to keep the retry loop" {
+		t.Errorf("Summary = %q", session.Summary)
+	}
+	// 6 messages with maxHistory 4: all but the last exchange are folded.
+	if session.SummarizedCount != 4 {
+		t.Errorf("SummarizedCount = %d, want 4", session.SummarizedCount)
+	}
+	if len(session.Messages) != 8 {
+		t.Errorf("all %d messages should be kept for display, got %d", 8, len(session.Messages))
+	}
+
+	cached, err := store.Get("alice", "widget-factory", 42)
+	if err != nil || cached == nil {
+		t.Fatalf("expected persisted session, err = %v", err)
+	}
+	if cached.Summary != session.Summary || cached.SummarizedCount != 4 {
+		t.Errorf("persisted summary = %q/%d", cached.Summary, cached.SummarizedCount)
+	}
+}
+
+func TestChatService_SummaryFailureKeepsHistory(t *testing.T) {
+	exec := &sequentialMockExecutor{calls: []mockCall{
+		{stdout: resultEvent("")},
+		{stdout: resultEvent("ok")},
+	}}
+	svc := NewChatService(exec, time.Minute, nil, 0, 2, 0)
+
+	session := &ChatSession{Messages: []ChatMessage{
+		{Role: "user", Content: "q1"}, {Role: "assistant", Content: "a1"},
+		{Role: "user", Content: "q2"}, {Role: "assistant", Content: "a2"},
+	}}
+	svc.sessions[sessionKey("alice", "widget-factory", 1)] = session
+
+	if _, err := svc.ChatStream(context.Background(), ChatInput{
+		Owner: "alice", Repo: "widget-factory", PRNumber: 1, Message: "q3",
+	}, func(string) {}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if session.Summary != "" || session.SummarizedCount != 0 {
+		t.Errorf("empty summary should leave the session unsummarized, got %q/%d", session.Summary, session.SummarizedCount)
+	}
+}
+
+func TestChatService_RepoTools(t *testing.T) {
+	toolUse, _ := json.Marshal(map[string]interface{}{
+		"type": "assistant",
+		"message": map[string]interface{}{"content": []map[string]interface{}{
+			{"type": "tool_use", "name": "Grep", "input": map[string]interface{}{"pattern": "parseConfig", "path": "internal"}},
+		}},
+	})
+	mock := &mockExecutor{stdout: string(toolUse) + "\n" + resultEvent("It is called from main.go") + "\n"}
+	svc := NewChatService(mock, time.Minute, nil, 0, 0, 0)
+
+	var tools []ProgressEvent
+	_, err := svc.ChatStream(context.Background(), ChatInput{
+		Owner: "alice", Repo: "widget-factory", PRNumber: 1, Message: "where else is parseConfig called?",
+		RepoPath: "/src/widget-factory", OnProgress: func(e ProgressEvent) { tools = append(tools, e) },
+	}, func(string) {})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	args := strings.Join(mock.lastArgs, " ")
+	if !strings.Contains(args, "--allowedTools Read,Grep,Glob") || !strings.Contains(args, "--max-turns 10") {
+		t.Errorf("args = %s", args)
+	}
+	if mock.lastOpts.Dir != "/src/widget-factory" {
+		t.Errorf("Dir = %q, want the clone", mock.lastOpts.Dir)
+	}
+	if len(tools) != 1 || tools[0].Tool != "Grep" || tools[0].Detail != `"parseConfig" in internal` {
+		t.Errorf("progress = %+v", tools)
+	}
+
+	mock.lastOpts = ExecOptions{}
+	svc.ChatStream(context.Background(), ChatInput{Owner: "alice", Repo: "widget-factory", PRNumber: 1, Message: "hi"}, func(string) {})
+	if args := strings.Join(mock.lastArgs, " "); strings.Contains(args, "--allowedTools") || mock.lastOpts.Dir != "" {
+		t.Errorf("chat without a clone should have no tools: %s", args)
+	}
+}
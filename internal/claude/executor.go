@@ -169,6 +169,27 @@ func streamDeltaVisitor(onChunk func(string)) EventVisitor {
 	}
 }
 
+// toolInputDetail summarizes a tool call's input: the file read, the
+// pattern searched for (and where), or the command run.
+func toolInputDetail(input interface{}) string {
+	fields, _ := input.(map[string]interface{})
+	str := func(key string) string {
+		s, _ := fields[key].(string)
+		return s
+	}
+	switch {
+	case str("file_path") != "":
+		return str("file_path")
+	case str("pattern") != "" && str("path") != "":
+		return fmt.Sprintf("%q in %s", str("pattern"), str("path"))
+	case str("pattern") != "":
+		return fmt.Sprintf("%q", str("pattern"))
+	case str("command") != "":
+		return truncate(str("command"), 80)
+	}
+	return ""
+}
+
 // reportProgress extracts progress information from stream events.
 func reportProgress(event *StreamEvent, onProgress ProgressFunc) {
 	switch event.Type {
@@ -182,6 +203,8 @@ func reportProgress(event *StreamEvent, onProgress ProgressFunc) {
 				onProgress(ProgressEvent{
 					Type:    "tool_use",
 					Message: fmt.Sprintf("Using %s...", block.Name),
+					Tool:    block.Name,
+					Detail:  toolInputDetail(block.Input),
 				})
 			case "text":
 				if block.Text != "" {
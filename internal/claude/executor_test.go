@@ -5,6 +5,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 	"time"
@@ -373,6 +375,131 @@ func TestAnalyzer_AnalyzeDiffStream(t *testing.T) {
 	}
 }
 
+func TestAnalyzer_SummarizeDiff(t *testing.T) {
+	mock := &mockExecutor{
+		stdout: resultEvent("Here you go:\n"+`{"bullets": ["What: a", "What: b", "Why: c", "Risk: d", "Risk: e"]}`) + "\n",
+	}
+	analyzer := NewAnalyzer(mock, 30*time.Second, "", 0)
+
+	summary, err := analyzer.SummarizeDiff(context.Background(), AnalyzeDiffInput{PRNumber: 42, DiffContent: "+x"})
+	if err != nil {
+		t.Fatalf("SummarizeDiff: %v", err)
+	}
+	if len(summary.Bullets) != 5 || summary.Bullets[2] != "Why: c" {
+		t.Errorf("bullets = %v", summary.Bullets)
+	}
+	if args := strings.Join(mock.lastArgs, " "); !strings.Contains(args, "exactly 5 short bullets") {
+		t.Error("expected the summary prompt, not the analysis prompt")
+	}
+
+	mock.stdout = resultEvent(`{"bullets": []}`) + "\n"
+	if _, err := analyzer.SummarizeDiff(context.Background(), AnalyzeDiffInput{}); err == nil {
+		t.Error("an empty summary should be an error")
+	}
+}
+
+func TestAnalyzer_TriagePR(t *testing.T) {
+	mock := &mockExecutor{
+		stdout: resultEvent(`{"summary": " Bumps lodash to 4.17.21 ", "tag": "trivial"}`) + "\n",
+	}
+	analyzer := NewAnalyzer(mock, 30*time.Second, "", 0)
+
+	triage, err := analyzer.TriagePR(context.Background(), AnalyzeDiffInput{PRNumber: 42, DiffContent: strings.Repeat("+x\n", 10000)})
+	if err != nil {
+		t.Fatalf("TriagePR: %v", err)
+	}
+	if triage.Summary != "Bumps lodash to 4.17.21" || triage.Tag != TriageTrivial {
+		t.Errorf("triage = %+v", triage)
+	}
+	args := strings.Join(mock.lastArgs, " ")
+	if !strings.Contains(args, "deciding which PR to open first") {
+		t.Error("expected the triage prompt")
+	}
+	if len(args) > triageMaxDiff+2000 {
+		t.Errorf("prompt is %d bytes; the diff should be capped", len(args))
+	}
+
+	mock.stdout = resultEvent(`{"summary": "x", "tag": "scary"}`) + "\n"
+	if _, err := analyzer.TriagePR(context.Background(), AnalyzeDiffInput{}); err == nil {
+		t.Error("an unknown tag should be an error")
+	}
+}
+
+func TestAnalyzer_DraftMergeMessage(t *testing.T) {
+	mock := &mockExecutor{
+		stdout: resultEvent(`{"title": " Add retry to uploads ", "body": "Uploads now retry twice.", "releaseNote": ""}`) + "\n",
+	}
+	analyzer := NewAnalyzer(mock, 30*time.Second, "", 0)
+
+	msg, err := analyzer.DraftMergeMessage(context.Background(), AnalyzeDiffInput{PRNumber: 42, DiffContent: "+x"})
+	if err != nil {
+		t.Fatalf("DraftMergeMessage: %v", err)
+	}
+	if got := msg.CommitMessage(); got != "Add retry to uploads\n\nUploads now retry twice." {
+		t.Errorf("commit message = %q", got)
+	}
+	if args := strings.Join(mock.lastArgs, " "); !strings.Contains(args, "squash-merge commit message") {
+		t.Error("expected the merge message prompt")
+	}
+
+	mock.stdout = resultEvent(`{"title": ""}`) + "\n"
+	if _, err := analyzer.DraftMergeMessage(context.Background(), AnalyzeDiffInput{}); err == nil {
+		t.Error("a message without a title should be an error")
+	}
+}
+
+func TestAnalyzer_AnalyzeDiffStreamRecordsModel(t *testing.T) {
+	resultJSON, _ := json.Marshal(AnalysisResult{Summary: "ok"})
+	mock := &mockExecutor{
+		stdout: `{"type":"system","subtype":"init","model":"claude-sonnet-4"}` + "\n" +
+			resultEvent(string(resultJSON)) + "\n",
+	}
+
+	analyzer := NewAnalyzer(mock, 30*time.Second, "", 0)
+	result, err := analyzer.AnalyzeDiffStream(context.Background(), AnalyzeDiffInput{DiffContent: "+x"}, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Model != "claude-sonnet-4" {
+		t.Errorf("Model = %q, want claude-sonnet-4", result.Model)
+	}
+}
+
+func TestAnalyzer_DiffAnalysisInputs(t *testing.T) {
+	dir := t.TempDir()
+	analyzer := NewAnalyzer(&mockExecutor{}, 30*time.Second, dir, 0)
+	input := AnalyzeDiffInput{
+		Owner:       "alice",
+		Repo:        "widget",
+		PRNumber:    42,
+		DiffContent: "+new line",
+		Truncations: []string{"big.lock: patch omitted"},
+	}
+
+	before := analyzer.DiffAnalysisInputs(input)
+	if before.PromptHash == "" || before.DiffHash == "" || before.CustomPrompt != "" {
+		t.Fatalf("inputs = %+v", before)
+	}
+	if len(before.Truncations) != 1 {
+		t.Errorf("Truncations = %v", before.Truncations)
+	}
+	if again := analyzer.DiffAnalysisInputs(input); again.PromptHash != before.PromptHash {
+		t.Error("same input should hash the same")
+	}
+
+	// A per-repo prompt changes the prompt but not the diff.
+	if err := os.WriteFile(filepath.Join(dir, "alice_widget.md"), []byte("Check SQL."), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	after := analyzer.DiffAnalysisInputs(input)
+	if after.PromptHash == before.PromptHash || after.DiffHash != before.DiffHash {
+		t.Errorf("custom prompt: before %+v, after %+v", before, after)
+	}
+	if !strings.HasSuffix(after.CustomPrompt, "alice_widget.md") {
+		t.Errorf("CustomPrompt = %q", after.CustomPrompt)
+	}
+}
+
 func TestAnalyzer_AnalyzeForReview(t *testing.T) {
 	reviewResult := ReviewAnalysis{
 		Action: "comment",
@@ -0,0 +1,117 @@
+package claude
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// DefaultOllamaURL is where a local Ollama server listens by default.
+const DefaultOllamaURL = "http://localhost:11434"
+
+// OllamaProvider talks to a local (or remote) Ollama server.
+type OllamaProvider struct {
+	baseURL string
+	model   string
+	client  *http.Client
+}
+
+// NewOllamaProvider creates a provider for the Ollama server at baseURL.
+func NewOllamaProvider(baseURL, model string) *OllamaProvider {
+	if baseURL == "" {
+		baseURL = DefaultOllamaURL
+	}
+	return &OllamaProvider{
+		baseURL: strings.TrimRight(baseURL, "/"),
+		model:   model,
+		client:  http.DefaultClient,
+	}
+}
+
+// Name implements LLMProvider.
+func (p *OllamaProvider) Name() string {
+	return "Ollama (" + p.model + ")"
+}
+
+type ollamaChunk struct {
+	Model   string `json:"model"`
+	Message struct {
+		Content string `json:"content"`
+	} `json:"message"`
+	Done  bool   `json:"done"`
+	Error string `json:"error"`
+
+	// Token counts, on the final chunk.
+	PromptEvalCount int `json:"prompt_eval_count"`
+	EvalCount       int `json:"eval_count"`
+}
+
+// Complete implements LLMProvider using Ollama's streaming chat endpoint.
+func (p *OllamaProvider) Complete(ctx context.Context, req CompletionRequest) (*Completion, error) {
+	body, err := json.Marshal(map[string]any{
+		"model":    p.model,
+		"stream":   true,
+		"messages": []map[string]string{{"role": "user", "content": req.Prompt}},
+	})
+	if err != nil {
+		return nil, err
+	}
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, p.baseURL+"/api/chat", bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	resp, err := p.client.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w (is ollama running?)", p.Name(), err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, httpStatusError(p.Name(), resp)
+	}
+
+	// Newline-delimited JSON objects, the last one with "done": true.
+	var text strings.Builder
+	completion := &Completion{Model: p.model}
+	scanner := bufio.NewScanner(resp.Body)
+	scanner.Buffer(make([]byte, 1024*1024), 1024*1024)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		var chunk ollamaChunk
+		if err := json.Unmarshal([]byte(line), &chunk); err != nil {
+			continue
+		}
+		if chunk.Error != "" {
+			return nil, fmt.Errorf("%s: %s", p.Name(), chunk.Error)
+		}
+		if chunk.Model != "" {
+			completion.Model = chunk.Model
+		}
+		if c := chunk.Message.Content; c != "" {
+			text.WriteString(c)
+			if req.OnChunk != nil {
+				req.OnChunk(c)
+			}
+		}
+		if chunk.Done {
+			completion.Usage = Usage{InputTokens: chunk.PromptEvalCount, OutputTokens: chunk.EvalCount}
+			break
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("%s: reading response: %w", p.Name(), err)
+	}
+
+	completion.Text = text.String()
+	completion.Streamed = completion.Text
+	completion.Usage.Calls = 1
+	return completion, nil
+}
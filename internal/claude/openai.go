@@ -0,0 +1,162 @@
+package claude
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// DefaultOpenAIBaseURL is the API root used when none is configured.
+const DefaultOpenAIBaseURL = "https://api.openai.com/v1"
+
+// OpenAIProvider talks to an OpenAI-compatible chat completions API: OpenAI
+// itself, or any server that mirrors it (vLLM, LM Studio, llama.cpp, ...).
+type OpenAIProvider struct {
+	baseURL string
+	apiKey  string
+	model   string
+	client  *http.Client
+}
+
+// NewOpenAIProvider creates a provider for the API at baseURL (e.g.
+// "https://api.openai.com/v1"). apiKey may be empty for local servers.
+func NewOpenAIProvider(baseURL, apiKey, model string) *OpenAIProvider {
+	if baseURL == "" {
+		baseURL = DefaultOpenAIBaseURL
+	}
+	return &OpenAIProvider{
+		baseURL: strings.TrimRight(baseURL, "/"),
+		apiKey:  apiKey,
+		model:   model,
+		client:  http.DefaultClient,
+	}
+}
+
+// Name implements LLMProvider.
+func (p *OpenAIProvider) Name() string {
+	return "OpenAI-compatible API (" + p.model + ")"
+}
+
+type openAIChunk struct {
+	Model   string `json:"model"`
+	Choices []struct {
+		Delta struct {
+			Content string `json:"content"`
+		} `json:"delta"`
+	} `json:"choices"`
+	Usage *struct {
+		PromptTokens     int `json:"prompt_tokens"`
+		CompletionTokens int `json:"completion_tokens"`
+	} `json:"usage"`
+	Error *struct {
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+// Complete implements LLMProvider using a streamed chat completion.
+func (p *OpenAIProvider) Complete(ctx context.Context, req CompletionRequest) (*Completion, error) {
+	body, err := json.Marshal(map[string]any{
+		"model":    p.model,
+		"stream":   true,
+		"messages": []map[string]string{{"role": "user", "content": req.Prompt}},
+		// Ask for a final chunk with token counts; servers that don't
+		// support it leave usage empty.
+		"stream_options": map[string]bool{"include_usage": true},
+	})
+	if err != nil {
+		return nil, err
+	}
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, p.baseURL+"/chat/completions", bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	if p.apiKey != "" {
+		httpReq.Header.Set("Authorization", "Bearer "+p.apiKey)
+	}
+
+	resp, err := p.client.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", p.Name(), err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, httpStatusError(p.Name(), resp)
+	}
+
+	// Server-sent events: "data: {...}" lines, ending with "data: [DONE]".
+	var text strings.Builder
+	completion := &Completion{Model: p.model}
+	scanner := bufio.NewScanner(resp.Body)
+	scanner.Buffer(make([]byte, 1024*1024), 1024*1024)
+	for scanner.Scan() {
+		data, ok := strings.CutPrefix(scanner.Text(), "data:")
+		if !ok {
+			continue
+		}
+		data = strings.TrimSpace(data)
+		if data == "[DONE]" {
+			break
+		}
+		var chunk openAIChunk
+		if err := json.Unmarshal([]byte(data), &chunk); err != nil {
+			continue
+		}
+		if chunk.Error != nil {
+			return nil, fmt.Errorf("%s: %s", p.Name(), chunk.Error.Message)
+		}
+		if chunk.Model != "" {
+			completion.Model = chunk.Model
+		}
+		if chunk.Usage != nil {
+			completion.Usage.InputTokens = chunk.Usage.PromptTokens
+			completion.Usage.OutputTokens = chunk.Usage.CompletionTokens
+		}
+		for _, c := range chunk.Choices {
+			if c.Delta.Content == "" {
+				continue
+			}
+			text.WriteString(c.Delta.Content)
+			if req.OnChunk != nil {
+				req.OnChunk(c.Delta.Content)
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("%s: reading response: %w", p.Name(), err)
+	}
+
+	completion.Text = text.String()
+	completion.Streamed = completion.Text
+	completion.Usage.Calls = 1
+	return completion, nil
+}
+
+// httpStatusError turns a failed HTTP response into an error, using the
+// API's own error message when the body has one.
+func httpStatusError(name string, resp *http.Response) error {
+	raw, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+	var body struct {
+		Error any `json:"error"`
+	}
+	msg := strings.TrimSpace(string(raw))
+	if json.Unmarshal(raw, &body) == nil {
+		switch e := body.Error.(type) {
+		case string:
+			msg = e
+		case map[string]any:
+			if m, ok := e["message"].(string); ok {
+				msg = m
+			}
+		}
+	}
+	if msg == "" {
+		return fmt.Errorf("%s: %s", name, resp.Status)
+	}
+	return fmt.Errorf("%s: %s: %s", name, resp.Status, msg)
+}
@@ -27,6 +27,7 @@ Instructions:
 3. Produce a thorough code review as structured JSON output.
 
 Focus on: correctness, security, performance, maintainability, and test coverage. Be specific with line numbers when possible.
+Also estimate how many minutes a careful human review would take, and list every changed file in the order a reviewer should read it — riskiest and most central changes first — with a short reason for each.
 %s
 IMPORTANT: Your final response must be ONLY valid JSON matching this schema (no markdown, no wrapping):
 %s`,
@@ -55,23 +56,35 @@ PR description:
 Here is the complete diff for this PR:
 
 %s
-
+%s
 Instructions:
 1. Review all changes shown in the diff above.
 2. Produce a thorough code review as structured JSON output.
 
 Focus on: correctness, security, performance, maintainability, and test coverage. Be specific with line numbers when possible.
+Also estimate how many minutes a careful human review would take, and list every changed file in the order a reviewer should read it — riskiest and most central changes first — with a short reason for each.
 %s
 IMPORTANT: Your final response must be ONLY valid JSON matching this schema (no markdown, no wrapping):
 %s`,
 		input.PRNumber, input.Owner, input.Repo, input.PRTitle,
 		body,
 		input.DiffContent,
+		ciLogsSection(input.CILogs),
 		customPrompt,
 		analysisJSONSchema,
 	)
 }
 
+// ciLogsSection introduces the log tails of failing CI checks, or returns
+// "" when there are none.
+func ciLogsSection(logs string) string {
+	if logs == "" {
+		return ""
+	}
+	return "\nCI is failing on this PR. Here is the end of each failing job's log:\n\n" + logs +
+		"\nSay why CI is failing and whether the changes in this diff explain it, or whether it looks unrelated (flaky, infrastructure, a base branch problem).\n"
+}
+
 func buildReviewPrompt(promptsDir string, input ReviewInput) string {
 	body := input.PRBody
 	if body == "" {
@@ -80,17 +93,32 @@ func buildReviewPrompt(promptsDir string, input ReviewInput) string {
 
 	customPrompt := loadCustomPrompt(promptsDir, input.Owner, input.Repo)
 
+	focus := ""
+	if input.FocusHunks != "" {
+		focus = "\nThe reviewer asked you to focus on these hunks, in this order. The first is their primary concern: review it first and most carefully.\n\n" + input.FocusHunks
+	}
+
+	diffIntro := "Here is the complete diff for this PR:"
+	scope := "Review all changes shown in the diff above."
+	if input.Scoped {
+		diffIntro = "The reviewer wants only these hunks reviewed, most important first. The rest of the PR is out of scope:"
+		if len(input.ChangedFiles) > 0 {
+			diffIntro = "Files changed in this PR: " + strings.Join(input.ChangedFiles, ", ") + "\n\n" + diffIntro
+		}
+		scope = "Review only the hunks shown above. Do not comment on code that isn't shown."
+	}
+
 	return fmt.Sprintf(`You are generating a GitHub pull request review for PR #%d in %s/%s: "%s".
 
 PR description:
 %s
 
-Here is the complete diff for this PR:
-
 %s
 
+%s
+%s%s
 Instructions:
-1. Review all changes shown in the diff above.
+1. %s
 2. Decide whether to approve, comment, or request changes.
 3. Write an overall review body summarizing your assessment.
 4. For specific issues, add inline comments targeting the exact file path and line number.
@@ -98,35 +126,212 @@ Instructions:
    - Only comment on lines that actually appear in the diff.
    - Each comment should be actionable and specific.
    - Focus on bugs, security issues, and significant improvements. Skip trivial style nits.
+   - Give each comment a severity: "critical" for bugs, security holes or data loss that must be fixed before merging,
+     "warning" for likely problems or risky code worth changing, "suggestion" for optional improvements.
 %s
 IMPORTANT: Your final response must be ONLY valid JSON matching this schema (no markdown, no wrapping):
 %s`,
 		input.PRNumber, input.Owner, input.Repo, input.PRTitle,
 		body,
+		diffIntro,
 		input.DiffContent,
+		focus,
+		ciLogsSection(input.CILogs),
+		scope,
 		customPrompt,
 		reviewJSONSchema,
 	)
 }
 
-func loadCustomPrompt(promptsDir, owner, repo string) string {
+// buildSummaryPrompt asks for a five-bullet overview of a PR, a much
+// shorter job than a full analysis.
+func buildSummaryPrompt(input AnalyzeDiffInput) string {
+	body := input.PRBody
+	if body == "" {
+		body = "No description provided."
+	}
+
+	return fmt.Sprintf(`Summarize PR #%d in %s/%s: "%s" for a reviewer who has not read it yet.
+
+PR description:
+%s
+
+Diff:
+
+%s
+
+Write exactly 5 short bullets, one sentence each:
+- two starting "What:" saying what the PR changes,
+- one starting "Why:" giving the motivation (from the description, or your best inference),
+- two starting "Risk:" naming what is most likely to break or deserves the closest look.
+
+IMPORTANT: Your final response must be ONLY valid JSON of the form {"bullets": ["...", "...", "...", "...", "..."]} (no markdown, no wrapping).`,
+		input.PRNumber, input.Owner, input.Repo, input.PRTitle,
+		body,
+		input.DiffContent,
+	)
+}
+
+// buildMergeMessagePrompt asks for a squash-merge commit message and a
+// release note for a PR, written from the author's side.
+func buildMergeMessagePrompt(input AnalyzeDiffInput) string {
+	body := input.PRBody
+	if body == "" {
+		body = "No description provided."
+	}
+
+	return fmt.Sprintf(`Write the squash-merge commit message and a release note for PR #%d in %s/%s: "%s".
+
+PR description:
+%s
+
+Diff:
+
+%s
+
+The commit title is imperative, at most 72 characters, with no trailing period and no PR number.
+The commit body explains what changed and why in a few short lines wrapped at 72 characters; leave it empty for trivial changes.
+The release note is one sentence for users of the project; leave it empty if the change is internal only.
+
+IMPORTANT: Your final response must be ONLY valid JSON of the form {"title": "...", "body": "...", "releaseNote": "..."} (no markdown, no wrapping).`,
+		input.PRNumber, input.Owner, input.Repo, input.PRTitle,
+		body,
+		input.DiffContent,
+	)
+}
+
+// triageMaxDiff caps the diff sent for triage, which only needs the gist
+// of a PR and runs for every one in the queue.
+const triageMaxDiff = 12000
+
+// buildTriagePrompt asks for a one-line summary and a triage tag for a PR,
+// from its title and the start of its diff.
+func buildTriagePrompt(input AnalyzeDiffInput) string {
+	return fmt.Sprintf(`Triage PR #%d in %s/%s: "%s" for a reviewer deciding which PR to open first.
+
+Diff (may be cut short):
+
+%s
+
+Summarize what the PR does in one sentence of at most 12 words, and tag it:
+- "%s": mechanical or tiny, e.g. typos, version bumps, renames, docs;
+- "%s": real logic changes that need a careful read;
+- "%s": touches security, data migrations, concurrency, public APIs, or is large and hard to follow.
+
+IMPORTANT: Your final response must be ONLY valid JSON of the form {"summary": "...", "tag": "..."} (no markdown, no wrapping).`,
+		input.PRNumber, input.Owner, input.Repo, input.PRTitle,
+		truncate(input.DiffContent, triageMaxDiff),
+		TriageTrivial, TriageCareful, TriageRisky,
+	)
+}
+
+// DefaultPromptName is the file name of the custom prompt applied to repos
+// that have none of their own.
+const DefaultPromptName = "default.md"
+
+// CustomPrompt is a custom review prompt file in the prompts directory.
+type CustomPrompt struct {
+	Owner string // "" for the default prompt
+	Repo  string
+	Path  string
+}
+
+// IsDefault reports whether p is the default prompt.
+func (p CustomPrompt) IsDefault() bool {
+	return p.Owner == ""
+}
+
+// RepoPromptPath returns where the custom prompt for a repo lives.
+func RepoPromptPath(promptsDir, owner, repo string) string {
+	return fmt.Sprintf("%s/%s_%s.md", promptsDir, owner, repo)
+}
+
+// DefaultPromptPath returns where the default custom prompt lives.
+func DefaultPromptPath(promptsDir string) string {
+	return promptsDir + "/" + DefaultPromptName
+}
+
+// ListCustomPrompts returns the default prompt, if any, followed by the
+// per-repo prompts in name order. A missing directory lists nothing.
+func ListCustomPrompts(promptsDir string) ([]CustomPrompt, error) {
+	entries, err := os.ReadDir(promptsDir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read prompts directory: %w", err)
+	}
+	var prompts []CustomPrompt
+	for _, e := range entries {
+		name, ok := strings.CutSuffix(e.Name(), ".md")
+		if e.IsDir() || !ok {
+			continue
+		}
+		path := promptsDir + "/" + e.Name()
+		if e.Name() == DefaultPromptName {
+			prompts = append([]CustomPrompt{{Path: path}}, prompts...)
+			continue
+		}
+		// owner_repo; owners cannot contain "_" but repo names can.
+		owner, repo, ok := strings.Cut(name, "_")
+		if !ok || owner == "" || repo == "" {
+			continue
+		}
+		prompts = append(prompts, CustomPrompt{Owner: owner, Repo: repo, Path: path})
+	}
+	return prompts, nil
+}
+
+// CustomPromptPath returns the custom prompt file that applies to a repo:
+// its own, else the default, else "".
+func CustomPromptPath(promptsDir, owner, repo string) string {
 	if promptsDir == "" {
 		return ""
 	}
-	path := fmt.Sprintf("%s/%s_%s.md", promptsDir, owner, repo)
+	for _, path := range []string{RepoPromptPath(promptsDir, owner, repo), DefaultPromptPath(promptsDir)} {
+		if _, err := os.Stat(path); err == nil {
+			return path
+		}
+	}
+	return ""
+}
+
+func loadCustomPrompt(promptsDir, owner, repo string) string {
+	path := CustomPromptPath(promptsDir, owner, repo)
+	if path == "" {
+		return ""
+	}
 	data, err := os.ReadFile(path)
-	if err != nil {
+	if err != nil || strings.TrimSpace(string(data)) == "" {
 		return ""
 	}
 	return "\nAdditional review instructions:\n" + string(data)
 }
 
+// DiffAnalysisPrompt returns the analysis prompt for input exactly as it
+// would be sent, for previewing the effect of custom prompts.
+func DiffAnalysisPrompt(promptsDir string, input AnalyzeDiffInput) string {
+	return buildDiffAnalysisPrompt(promptsDir, input)
+}
+
 // analysisJSONSchema is the JSON schema that Claude must produce.
 var analysisJSONSchema = `{
   "type": "object",
-  "required": ["summary", "risk", "architectureImpact", "fileReviews", "testCoverage", "suggestions"],
+  "required": ["summary", "risk", "architectureImpact", "fileReviews", "testCoverage", "suggestions", "estimatedReviewMinutes", "reviewOrder"],
   "properties": {
     "summary": { "type": "string" },
+    "estimatedReviewMinutes": { "type": "integer" },
+    "reviewOrder": {
+      "type": "array",
+      "items": {
+        "type": "object",
+        "required": ["file", "reason"],
+        "properties": {
+          "file": { "type": "string" },
+          "reason": { "type": "string" }
+        }
+      }
+    },
     "risk": {
       "type": "object",
       "required": ["level", "reasoning"],
@@ -201,11 +406,12 @@ var reviewJSONSchema = `{
       "type": "array",
       "items": {
         "type": "object",
-        "required": ["path", "line", "body"],
+        "required": ["path", "line", "body", "severity"],
         "properties": {
           "path": { "type": "string", "description": "Relative file path" },
           "line": { "type": "number", "description": "Line number in the new file (right side)" },
-          "body": { "type": "string", "description": "Inline comment text" }
+          "body": { "type": "string", "description": "Inline comment text" },
+          "severity": { "type": "string", "enum": ["critical", "warning", "suggestion"] }
         }
       }
     }
@@ -229,15 +435,29 @@ func buildChatPrompt(session *ChatSession, input ChatInput, maxTokens, maxHistor
 	} else {
 		instruction = "\n\nAnswer questions about this PR based on the diff and metadata provided above.\n"
 	}
+	if input.RepoPath != "" {
+		instruction += "Your working directory is a local clone of this repository. Use the Read, Grep and Glob tools " +
+			"to ground your answer in the code, e.g. to find where a function is defined or called. " +
+			"The clone may not have the PR's branch checked out: the diff above shows what the PR changes.\n"
+	}
+
+	// Earlier messages already folded into the running summary are replaced by it
+	var summary string
+	if session.Summary != "" {
+		summary = "\nSummary of the earlier conversation:\n" + session.Summary + "\n"
+	}
 
 	currentMsg := fmt.Sprintf("\nUser: %s\n\nRespond helpfully and concisely.", input.Message)
 
 	// Calculate fixed token costs
-	fixedTokens := estimateTokens(systemPrefix) + estimateTokens(instruction) + estimateTokens(currentMsg)
+	fixedTokens := estimateTokens(systemPrefix) + estimateTokens(instruction) + estimateTokens(summary) + estimateTokens(currentMsg)
 	contextTokens := estimateTokens(input.PRContext)
 
 	// Determine which messages to include (most recent first, up to budget)
 	messages := session.Messages
+	if n := session.SummarizedCount; n > 0 && n <= len(messages) {
+		messages = messages[n:]
+	}
 	if len(messages) > maxHistory {
 		messages = messages[len(messages)-maxHistory:]
 	}
@@ -274,6 +494,7 @@ func buildChatPrompt(session *ChatSession, input ChatInput, maxTokens, maxHistor
 
 	b.WriteString(prContext)
 	b.WriteString(instruction)
+	b.WriteString(summary)
 
 	for _, msg := range messages {
 		if msg.Role == "user" {
@@ -288,9 +509,53 @@ func buildChatPrompt(session *ChatSession, input ChatInput, maxTokens, maxHistor
 	return b.String()
 }
 
+// buildChatSummaryPrompt asks for an updated running summary of a chat,
+// merging the previous summary with the messages about to leave the history.
+func buildChatSummaryPrompt(previous string, messages []ChatMessage) string {
+	var b strings.Builder
+	b.WriteString("You are condensing an ongoing pull request review discussion so it can continue within a limited context window.\n")
+	if previous != "" {
+		fmt.Fprintf(&b, "\nSummary so far:\n%s\n", previous)
+	}
+	b.WriteString("\nEarlier messages to fold into the summary:\n")
+	for _, msg := range messages {
+		if msg.Role == "user" {
+			fmt.Fprintf(&b, "\nUser: %s", msg.Content)
+		} else {
+			fmt.Fprintf(&b, "\nAssistant: %s", msg.Content)
+		}
+	}
+	b.WriteString("\n\nWrite an updated summary in a few short bullet points. Keep decisions, agreed changes, open questions, and any files or lines discussed. " +
+		"Reply with the summary only.")
+	return b.String()
+}
+
 // estimateTokens returns a rough token count for a string.
 // Code and diffs average ~3 chars per token; prose ~4 chars.
 // We use 3 as a conservative estimate (overestimates slightly for prose).
 func estimateTokens(s string) int {
 	return len(s) / 3
 }
+
+// EstimateTokens returns the rough token count chat uses to fit prompts
+// within the max prompt token budget.
+func EstimateTokens(s string) int {
+	return estimateTokens(s)
+}
+
+// buildQuickQuestionPrompt builds a history-free prompt for a one-line
+// question about a single hunk. The hunk is truncated to fit maxTokens.
+func buildQuickQuestionPrompt(input ChatInput, maxTokens int) string {
+	const instruction = "\n\nAnswer the user's question about the code above in a few sentences. " +
+		"Be direct: start with yes/no when the question allows it, and cite line content when relevant.\n"
+	systemPrefix := "You are helping review a pull request. The user is asking a quick question about one hunk of the diff:\n\n"
+	question := fmt.Sprintf("\nQuestion: %s", input.Message)
+
+	hunk := input.PRContext
+	budget := maxTokens - estimateTokens(systemPrefix) - estimateTokens(instruction) - estimateTokens(question)
+	if maxChars := budget * 3; maxChars > 0 && maxChars < len(hunk) {
+		hunk = hunk[:maxChars] + "\n\n[... hunk truncated to fit context window ...]"
+	}
+
+	return systemPrefix + hunk + instruction + question
+}
@@ -0,0 +1,129 @@
+package claude
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// LLMProvider runs a single prompt against a language model backend. The
+// Claude CLI is one provider; HTTP backends (OpenAI-compatible APIs, Ollama)
+// are others, so that AI features work without the CLI installed.
+type LLMProvider interface {
+	// Name describes the backend for status and error messages.
+	Name() string
+	// Complete runs req.Prompt and returns the model's answer, streaming text
+	// deltas to req.OnChunk as they arrive.
+	Complete(ctx context.Context, req CompletionRequest) (*Completion, error)
+}
+
+// CompletionRequest is one prompt for an LLMProvider.
+type CompletionRequest struct {
+	Prompt     string
+	MaxTurns   int          // agentic turns; backends without tool use ignore it
+	OnChunk    func(string) // streamed text deltas, may be nil
+	OnProgress ProgressFunc // tool use and thinking events, may be nil
+
+	// Tools the model may use without asking, run in Dir. Only the Claude
+	// CLI has tools; other backends answer from the prompt alone.
+	AllowedTools []string
+	Dir          string
+}
+
+// Completion is a provider's answer to a CompletionRequest.
+type Completion struct {
+	Text     string // the final answer
+	Streamed string // all text passed to OnChunk, which may span several turns
+	Model    string // model that answered, empty if the backend didn't say
+	Usage    Usage  // tokens used, with cost where the backend reports or we can estimate it
+}
+
+// CLIProvider runs prompts through the Claude CLI.
+type CLIProvider struct {
+	executor CommandExecutor
+	model    string
+}
+
+// NewCLIProvider creates a provider that spawns the Claude CLI via executor.
+// model is passed as --model (an alias such as "sonnet" or a full model name);
+// empty leaves the choice to the CLI.
+func NewCLIProvider(executor CommandExecutor, model string) *CLIProvider {
+	return &CLIProvider{executor: executor, model: model}
+}
+
+// Name implements LLMProvider.
+func (p *CLIProvider) Name() string {
+	return "Claude CLI"
+}
+
+// Complete implements LLMProvider.
+func (p *CLIProvider) Complete(ctx context.Context, req CompletionRequest) (*Completion, error) {
+	turns := req.MaxTurns
+	if turns == 0 {
+		turns = 1
+	}
+	args := []string{
+		"-p", req.Prompt,
+		"--output-format", "stream-json",
+		"--verbose",
+	}
+	if req.OnChunk != nil {
+		args = append(args, "--include-partial-messages")
+	}
+	args = append(args, "--max-turns", fmt.Sprintf("%d", turns))
+	if p.model != "" {
+		args = append(args, "--model", p.model)
+	}
+	if len(req.AllowedTools) > 0 {
+		args = append(args, "--allowedTools", strings.Join(req.AllowedTools, ","))
+	}
+
+	opts := ExecOptions{
+		Dir: req.Dir,
+		Env: filterEnv(os.Environ(), "ANTHROPIC_API_KEY"),
+	}
+
+	var model string
+	var streamed strings.Builder
+	onDelta := streamDeltaVisitor(func(text string) {
+		streamed.WriteString(text)
+		req.OnChunk(text)
+	})
+	visitor := func(event *StreamEvent) {
+		if event.Type == "system" && event.Model != "" {
+			model = event.Model
+		}
+		if req.OnProgress != nil {
+			reportProgress(event, req.OnProgress)
+		}
+		if req.OnChunk == nil {
+			return
+		}
+		// Token-level streaming: stream_event with content_block_delta
+		if event.Type == "stream_event" {
+			onDelta(event)
+			return
+		}
+
+		// Fallback: complete assistant turn when the CLI sent no deltas
+		if event.Type == "assistant" && event.Message != nil && streamed.Len() == 0 {
+			for _, block := range event.Message.Content {
+				if block.Type == "text" && block.Text != "" {
+					req.OnChunk(block.Text)
+				}
+			}
+		}
+	}
+
+	resultEvent, err := runCLI(ctx, p.executor, args, opts, visitor)
+	if err != nil {
+		return nil, err
+	}
+	return &Completion{
+		Text:     extractResultText(resultEvent),
+		Streamed: streamed.String(),
+		Model:    model,
+		Usage:    resultUsage(resultEvent),
+	}, nil
+}
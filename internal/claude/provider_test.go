@@ -0,0 +1,167 @@
+package claude
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestOpenAIProvider_StreamsChatCompletion(t *testing.T) {
+	var gotAuth string
+	var gotBody map[string]any
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/chat/completions" {
+			t.Errorf("path = %s", r.URL.Path)
+		}
+		gotAuth = r.Header.Get("Authorization")
+		_ = json.NewDecoder(r.Body).Decode(&gotBody)
+		for _, c := range []string{"Hel", "lo"} {
+			fmt.Fprintf(w, "data: {\"model\":\"gpt-test-0613\",\"choices\":[{\"delta\":{\"content\":%q}}]}\n\n", c)
+		}
+		fmt.Fprint(w, "data: [DONE]\n\n")
+	}))
+	defer srv.Close()
+
+	p := NewOpenAIProvider(srv.URL+"/v1/", "sk-test", "gpt-test")
+	var chunks []string
+	c, err := p.Complete(context.Background(), CompletionRequest{Prompt: "hi", OnChunk: func(s string) { chunks = append(chunks, s) }})
+	if err != nil {
+		t.Fatalf("Complete: %v", err)
+	}
+	if c.Text != "Hello" || c.Streamed != "Hello" || c.Model != "gpt-test-0613" {
+		t.Errorf("completion = %+v", c)
+	}
+	if strings.Join(chunks, "|") != "Hel|lo" {
+		t.Errorf("chunks = %v", chunks)
+	}
+	if gotAuth != "Bearer sk-test" || gotBody["model"] != "gpt-test" || gotBody["stream"] != true {
+		t.Errorf("auth = %q, body = %v", gotAuth, gotBody)
+	}
+}
+
+func TestOpenAIProvider_ReportsAPIError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		fmt.Fprint(w, `{"error":{"message":"Incorrect API key provided"}}`)
+	}))
+	defer srv.Close()
+
+	_, err := NewOpenAIProvider(srv.URL, "bad", "gpt-test").Complete(context.Background(), CompletionRequest{Prompt: "hi"})
+	if err == nil || !strings.Contains(err.Error(), "Incorrect API key provided") {
+		t.Errorf("err = %v, want the API's message", err)
+	}
+}
+
+func TestAnthropicProvider_StreamsMessages(t *testing.T) {
+	var gotKey, gotVersion string
+	var gotBody map[string]any
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/messages" {
+			t.Errorf("path = %s", r.URL.Path)
+		}
+		gotKey, gotVersion = r.Header.Get("x-api-key"), r.Header.Get("anthropic-version")
+		_ = json.NewDecoder(r.Body).Decode(&gotBody)
+		fmt.Fprint(w, "event: message_start\ndata: {\"type\":\"message_start\",\"message\":{\"model\":\"claude-test-20250101\"}}\n\n")
+		for _, c := range []string{"Hel", "lo"} {
+			fmt.Fprintf(w, "event: content_block_delta\ndata: {\"type\":\"content_block_delta\",\"index\":0,\"delta\":{\"type\":\"text_delta\",\"text\":%q}}\n\n", c)
+		}
+		fmt.Fprint(w, "event: message_stop\ndata: {\"type\":\"message_stop\"}\n\n")
+	}))
+	defer srv.Close()
+
+	var chunks []string
+	c, err := NewAnthropicProvider(srv.URL, "sk-ant-test", "claude-test").Complete(context.Background(), CompletionRequest{Prompt: "hi", OnChunk: func(s string) { chunks = append(chunks, s) }})
+	if err != nil {
+		t.Fatalf("Complete: %v", err)
+	}
+	if c.Text != "Hello" || c.Model != "claude-test-20250101" {
+		t.Errorf("completion = %+v", c)
+	}
+	if strings.Join(chunks, "|") != "Hel|lo" {
+		t.Errorf("chunks = %v", chunks)
+	}
+	if gotKey != "sk-ant-test" || gotVersion == "" || gotBody["model"] != "claude-test" || gotBody["stream"] != true {
+		t.Errorf("key = %q, version = %q, body = %v", gotKey, gotVersion, gotBody)
+	}
+}
+
+func TestAnthropicProvider_ReportsStreamError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, "event: error\ndata: {\"type\":\"error\",\"error\":{\"type\":\"overloaded_error\",\"message\":\"Overloaded\"}}\n\n")
+	}))
+	defer srv.Close()
+
+	_, err := NewAnthropicProvider(srv.URL, "k", "claude-test").Complete(context.Background(), CompletionRequest{Prompt: "hi"})
+	if err == nil || !strings.Contains(err.Error(), "Overloaded") {
+		t.Errorf("err = %v", err)
+	}
+}
+
+func TestOllamaProvider_StreamsChat(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/chat" {
+			t.Errorf("path = %s", r.URL.Path)
+		}
+		fmt.Fprintln(w, `{"model":"llama3.1","message":{"content":"{\"summary\":"},"done":false}`)
+		fmt.Fprintln(w, `{"model":"llama3.1","message":{"content":"\"ok\"}"},"done":false}`)
+		fmt.Fprintln(w, `{"model":"llama3.1","message":{"content":""},"done":true}`)
+	}))
+	defer srv.Close()
+
+	// Analysis works end to end through a non-CLI provider.
+	analyzer := NewAnalyzerWithProvider(NewOllamaProvider(srv.URL, "llama3.1"), 30*time.Second, "", 0)
+	var streamed strings.Builder
+	result, err := analyzer.AnalyzeDiffStream(context.Background(), AnalyzeDiffInput{DiffContent: "+x"}, func(s string) { streamed.WriteString(s) })
+	if err != nil {
+		t.Fatalf("AnalyzeDiffStream: %v", err)
+	}
+	if result.Summary != "ok" || result.Model != "llama3.1" {
+		t.Errorf("result = %+v", result)
+	}
+	if streamed.String() != `{"summary":"ok"}` {
+		t.Errorf("streamed = %q", streamed.String())
+	}
+}
+
+func TestOllamaProvider_ReportsStreamError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintln(w, `{"error":"model \"nope\" not found, try pulling it first"}`)
+	}))
+	defer srv.Close()
+
+	chat := NewChatServiceWithProvider(NewOllamaProvider(srv.URL, "nope"), 30*time.Second, nil, 0, 0, 0)
+	_, err := chat.AskOnce(context.Background(), ChatInput{Message: "hi"}, func(string) {})
+	if err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Errorf("err = %v", err)
+	}
+}
+
+func TestCLIProvider_PassesModel(t *testing.T) {
+	mock := &mockExecutor{stdout: resultEvent("ok")}
+	if _, err := NewCLIProvider(mock, "haiku").Complete(context.Background(), CompletionRequest{Prompt: "hi"}); err != nil {
+		t.Fatalf("Complete: %v", err)
+	}
+	args := strings.Join(mock.lastArgs, " ")
+	if !strings.Contains(args, "--model haiku") {
+		t.Errorf("args = %q, want --model haiku", args)
+	}
+
+	mock = &mockExecutor{stdout: resultEvent("ok")}
+	NewCLIProvider(mock, "").Complete(context.Background(), CompletionRequest{Prompt: "hi"})
+	if slices.Contains(mock.lastArgs, "--model") {
+		t.Errorf("args = %v, want the CLI default model", mock.lastArgs)
+	}
+}
+
+func TestAnalyzer_AnalyzeNeedsCLI(t *testing.T) {
+	analyzer := NewAnalyzerWithProvider(NewOllamaProvider("", "llama3.1"), time.Second, "", 0)
+	if _, err := analyzer.Analyze(context.Background(), AnalyzeInput{}, nil); err == nil || !strings.Contains(err.Error(), "Claude CLI") {
+		t.Errorf("err = %v, want a Claude CLI requirement", err)
+	}
+}
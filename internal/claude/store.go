@@ -37,16 +37,44 @@ func (s *AnalysisStore) Get(owner, repo string, number int) (*CachedAnalysis, er
 	return &cached, nil
 }
 
-// Put saves an analysis result to the cache.
-func (s *AnalysisStore) Put(owner, repo string, number int, diffContentHash string, result *AnalysisResult) error {
+// GetPrevious loads the analysis that was cached before the current one,
+// for comparing runs. Returns nil if there is none.
+func (s *AnalysisStore) GetPrevious(owner, repo string, number int) (*CachedAnalysis, error) {
+	data, err := os.ReadFile(s.previousPath(owner, repo, number))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read previous analysis file: %w", err)
+	}
+
+	var cached CachedAnalysis
+	if err := json.Unmarshal(data, &cached); err != nil {
+		return nil, fmt.Errorf("failed to parse previous analysis file: %w", err)
+	}
+	return &cached, nil
+}
+
+// Put saves an analysis result to the cache, along with the inputs that
+// produced it (may be nil). The analysis it replaces is kept for
+// GetPrevious.
+func (s *AnalysisStore) Put(owner, repo string, number int, diffContentHash string, result *AnalysisResult, inputs *AnalysisInputs) error {
 	if err := os.MkdirAll(s.cacheDir, 0o755); err != nil {
 		return fmt.Errorf("failed to create cache directory: %w", err)
 	}
 
+	path := s.cachePath(owner, repo, number)
+	if _, err := os.Stat(path); err == nil {
+		if err := os.Rename(path, s.previousPath(owner, repo, number)); err != nil {
+			return fmt.Errorf("failed to keep previous analysis: %w", err)
+		}
+	}
+
 	cached := CachedAnalysis{
 		DiffContentHash: diffContentHash,
 		AnalyzedAt: time.Now(),
 		Result:     result,
+		Inputs:     inputs,
 	}
 
 	data, err := json.MarshalIndent(cached, "", "  ")
@@ -54,9 +82,81 @@ func (s *AnalysisStore) Put(owner, repo string, number int, diffContentHash stri
 		return fmt.Errorf("failed to marshal analysis: %w", err)
 	}
 
-	path := s.cachePath(owner, repo, number)
+	return writeCacheFile(path, data)
+}
+
+// GetSummary loads the cached PR summary. Returns nil if not found.
+func (s *AnalysisStore) GetSummary(owner, repo string, number int) (*CachedSummary, error) {
+	data, err := os.ReadFile(s.summaryPath(owner, repo, number))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read summary cache file: %w", err)
+	}
+
+	var cached CachedSummary
+	if err := json.Unmarshal(data, &cached); err != nil {
+		return nil, fmt.Errorf("failed to parse summary cache file: %w", err)
+	}
+	return &cached, nil
+}
+
+// PutSummary caches a PR summary for the diff with the given content hash.
+func (s *AnalysisStore) PutSummary(owner, repo string, number int, diffContentHash string, summary *PRSummary) error {
+	if err := os.MkdirAll(s.cacheDir, 0o755); err != nil {
+		return fmt.Errorf("failed to create cache directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(CachedSummary{
+		DiffContentHash: diffContentHash,
+		SummarizedAt:    time.Now(),
+		Summary:         summary,
+	}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal summary: %w", err)
+	}
+
+	return writeCacheFile(s.summaryPath(owner, repo, number), data)
+}
+
+// GetTriage loads the cached triage of a PR. Returns nil if not found.
+func (s *AnalysisStore) GetTriage(owner, repo string, number int) (*CachedTriage, error) {
+	data, err := os.ReadFile(s.triagePath(owner, repo, number))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read triage cache file: %w", err)
+	}
+
+	var cached CachedTriage
+	if err := json.Unmarshal(data, &cached); err != nil {
+		return nil, fmt.Errorf("failed to parse triage cache file: %w", err)
+	}
+	return &cached, nil
+}
+
+// PutTriage caches the triage of a PR as of its updatedAt.
+func (s *AnalysisStore) PutTriage(owner, repo string, number int, updatedAt time.Time, triage *PRTriage) error {
+	if err := os.MkdirAll(s.cacheDir, 0o755); err != nil {
+		return fmt.Errorf("failed to create cache directory: %w", err)
+	}
 
-	// Write atomically: temp file + rename
+	data, err := json.MarshalIndent(CachedTriage{
+		UpdatedAt: updatedAt,
+		TriagedAt: time.Now(),
+		Triage:    triage,
+	}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal triage: %w", err)
+	}
+
+	return writeCacheFile(s.triagePath(owner, repo, number), data)
+}
+
+// writeCacheFile writes atomically: temp file + rename.
+func writeCacheFile(path string, data []byte) error {
 	tmpPath := path + ".tmp"
 	if err := os.WriteFile(tmpPath, data, 0o644); err != nil {
 		return fmt.Errorf("failed to write temp cache file: %w", err)
@@ -82,3 +182,18 @@ func (s *AnalysisStore) cachePath(owner, repo string, number int) string {
 	filename := fmt.Sprintf("%s_%s_%d.json", owner, repo, number)
 	return filepath.Join(s.cacheDir, filename)
 }
+
+func (s *AnalysisStore) previousPath(owner, repo string, number int) string {
+	filename := fmt.Sprintf("%s_%s_%d.prev.json", owner, repo, number)
+	return filepath.Join(s.cacheDir, filename)
+}
+
+func (s *AnalysisStore) summaryPath(owner, repo string, number int) string {
+	filename := fmt.Sprintf("%s_%s_%d.summary.json", owner, repo, number)
+	return filepath.Join(s.cacheDir, filename)
+}
+
+func (s *AnalysisStore) triagePath(owner, repo string, number int) string {
+	filename := fmt.Sprintf("%s_%s_%d.triage.json", owner, repo, number)
+	return filepath.Join(s.cacheDir, filename)
+}
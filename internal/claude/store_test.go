@@ -13,7 +13,7 @@ func TestAnalysisStore_PutAndGet(t *testing.T) {
 		Risk:    RiskAssessment{Level: "low", Reasoning: "Simple addition"},
 	}
 
-	err := store.Put("alice", "widget-factory", 42, "abc123", result)
+	err := store.Put("alice", "widget-factory", 42, "abc123", result, nil)
 	if err != nil {
 		t.Fatalf("Put failed: %v", err)
 	}
@@ -36,6 +36,41 @@ func TestAnalysisStore_PutAndGet(t *testing.T) {
 	}
 }
 
+func TestAnalysisStore_PutKeepsInputs(t *testing.T) {
+	store := NewAnalysisStore(t.TempDir())
+	inputs := &AnalysisInputs{PromptHash: "p1", DiffHash: "d1", Model: "claude-sonnet-4", Truncations: []string{"a.bin: patch omitted"}}
+
+	if err := store.Put("alice", "widget-factory", 7, "abc", &AnalysisResult{Summary: "s"}, inputs); err != nil {
+		t.Fatalf("Put failed: %v", err)
+	}
+	got, err := store.Get("alice", "widget-factory", 7)
+	if err != nil || got == nil || got.Inputs == nil {
+		t.Fatalf("Get = %+v, %v", got, err)
+	}
+	if got.Inputs.PromptHash != "p1" || got.Inputs.Model != "claude-sonnet-4" || len(got.Inputs.Truncations) != 1 {
+		t.Errorf("Inputs = %+v", got.Inputs)
+	}
+}
+
+func TestAnalysisStore_Triage(t *testing.T) {
+	store := NewAnalysisStore(t.TempDir())
+	updated := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	if got, err := store.GetTriage("alice", "widget-factory", 7); got != nil || err != nil {
+		t.Fatalf("GetTriage before Put = %+v, %v", got, err)
+	}
+	if err := store.PutTriage("alice", "widget-factory", 7, updated, &PRTriage{Summary: "Bump lodash", Tag: TriageTrivial}); err != nil {
+		t.Fatalf("PutTriage failed: %v", err)
+	}
+	got, err := store.GetTriage("alice", "widget-factory", 7)
+	if err != nil || got == nil || got.Triage == nil {
+		t.Fatalf("GetTriage = %+v, %v", got, err)
+	}
+	if !got.UpdatedAt.Equal(updated) || got.Triage.Tag != TriageTrivial || got.Triage.Summary != "Bump lodash" {
+		t.Errorf("cached triage = %+v / %+v", got, got.Triage)
+	}
+}
+
 func TestAnalysisStore_GetNotFound(t *testing.T) {
 	store := NewAnalysisStore(t.TempDir())
 
@@ -82,10 +117,10 @@ func TestAnalysisStore_Overwrite(t *testing.T) {
 	r1 := &AnalysisResult{Summary: "first"}
 	r2 := &AnalysisResult{Summary: "second"}
 
-	if err := store.Put("alice", "widget-factory", 1, "sha1", r1); err != nil {
+	if err := store.Put("alice", "widget-factory", 1, "sha1", r1, nil); err != nil {
 		t.Fatal(err)
 	}
-	if err := store.Put("alice", "widget-factory", 1, "sha2", r2); err != nil {
+	if err := store.Put("alice", "widget-factory", 1, "sha2", r2, nil); err != nil {
 		t.Fatal(err)
 	}
 
@@ -99,4 +134,34 @@ func TestAnalysisStore_Overwrite(t *testing.T) {
 	if got.DiffContentHash != "sha2" {
 		t.Errorf("DiffContentHash = %q, want %q", got.DiffContentHash, "sha2")
 	}
+
+	prev, err := store.GetPrevious("alice", "widget-factory", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if prev == nil || prev.Result.Summary != "first" || prev.DiffContentHash != "sha1" {
+		t.Errorf("previous = %+v, want the first run", prev)
+	}
+}
+
+func TestAnalysisStore_SummaryIsSeparateFromAnalysis(t *testing.T) {
+	store := NewAnalysisStore(t.TempDir())
+
+	if got, err := store.GetSummary("alice", "widget-factory", 42); got != nil || err != nil {
+		t.Fatalf("GetSummary before Put = %v, %v", got, err)
+	}
+	summary := &PRSummary{Bullets: []string{"What: adds frobnicate"}}
+	if err := store.PutSummary("alice", "widget-factory", 42, "abc123", summary); err != nil {
+		t.Fatalf("PutSummary: %v", err)
+	}
+	got, err := store.GetSummary("alice", "widget-factory", 42)
+	if err != nil || got == nil {
+		t.Fatalf("GetSummary = %v, %v", got, err)
+	}
+	if got.DiffContentHash != "abc123" || len(got.Summary.Bullets) != 1 {
+		t.Errorf("cached summary = %+v", got)
+	}
+	if analysis, _ := store.Get("alice", "widget-factory", 42); analysis != nil {
+		t.Error("a summary must not be mistaken for a cached analysis")
+	}
 }
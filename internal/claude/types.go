@@ -1,6 +1,10 @@
 package claude
 
-import "time"
+import (
+	"encoding/json"
+	"math"
+	"time"
+)
 
 // AnalysisResult is the structured output from Claude's PR analysis.
 type AnalysisResult struct {
@@ -10,6 +14,32 @@ type AnalysisResult struct {
 	FileReviews        []FileReview       `json:"fileReviews"`
 	TestCoverage       TestCoverage       `json:"testCoverage"`
 	Suggestions        []Suggestion       `json:"suggestions"`
+
+	EstimatedReviewMinutes int                `json:"estimatedReviewMinutes,omitempty"`
+	ReviewOrder            []ReviewOrderEntry `json:"reviewOrder,omitempty"` // riskiest files first
+
+	Model string `json:"-"` // model the CLI reported for this run, not part of the schema
+}
+
+// UnmarshalJSON accepts a fractional estimatedReviewMinutes, rounding it,
+// since backends that don't enforce the schema may answer 7.5.
+func (r *AnalysisResult) UnmarshalJSON(data []byte) error {
+	type plain AnalysisResult
+	aux := struct {
+		*plain
+		EstimatedReviewMinutes float64 `json:"estimatedReviewMinutes,omitempty"`
+	}{plain: (*plain)(r)}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	r.EstimatedReviewMinutes = int(math.Round(aux.EstimatedReviewMinutes))
+	return nil
+}
+
+// ReviewOrderEntry is one step of the suggested file review order.
+type ReviewOrderEntry struct {
+	File   string `json:"file"`
+	Reason string `json:"reason"`
 }
 
 // RiskAssessment describes the overall risk level of the PR.
@@ -68,6 +98,7 @@ type InlineReviewComment struct {
 	Body      string `json:"body"`                 // comment text
 	StartLine int    `json:"start_line,omitempty"` // start line for multi-line comments
 	StartSide string `json:"start_side,omitempty"` // start side for multi-line comments
+	Severity  string `json:"severity,omitempty"`   // AI review only: "critical", "warning" or "suggestion"
 }
 
 // ChatMessage represents a single message in a chat conversation.
@@ -80,6 +111,11 @@ type ChatMessage struct {
 type ChatSession struct {
 	Messages  []ChatMessage
 	PRContext string
+
+	// Summary condenses the first SummarizedCount messages, which are then
+	// left out of the prompt so long discussions stay within history limits.
+	Summary         string
+	SummarizedCount int
 }
 
 // CachedAnalysis wraps an analysis result with cache metadata.
@@ -87,12 +123,75 @@ type CachedAnalysis struct {
 	DiffContentHash string          `json:"diffContentHash"`
 	AnalyzedAt time.Time       `json:"analyzedAt"`
 	Result     *AnalysisResult `json:"result"`
+	Inputs     *AnalysisInputs `json:"inputs,omitempty"` // nil for analyses cached before inputs were recorded
+}
+
+// PRSummary is a five-bullet what/why/risk overview of a PR.
+type PRSummary struct {
+	Bullets []string `json:"bullets"`
+	Model   string   `json:"model,omitempty"`
+}
+
+// CachedSummary wraps a PRSummary with the diff it describes.
+type CachedSummary struct {
+	DiffContentHash string     `json:"diffContentHash"`
+	SummarizedAt    time.Time  `json:"summarizedAt"`
+	Summary         *PRSummary `json:"summary"`
+}
+
+// Triage tags, from least to most attention needed.
+const (
+	TriageTrivial = "trivial"
+	TriageCareful = "needs-careful-review"
+	TriageRisky   = "risky"
+)
+
+// PRTriage is a quick one-line take on a PR waiting for review, to help
+// decide which to open first.
+type PRTriage struct {
+	Summary string `json:"summary"`
+	Tag     string `json:"tag"` // one of the Triage* tags
+}
+
+// CachedTriage wraps a PRTriage with the PR's updatedAt it was made at.
+type CachedTriage struct {
+	UpdatedAt time.Time `json:"updatedAt"`
+	TriagedAt time.Time `json:"triagedAt"`
+	Triage    *PRTriage `json:"triage"`
+}
+
+// MergeMessage is a drafted squash-merge commit message and release note.
+type MergeMessage struct {
+	Title       string `json:"title"`
+	Body        string `json:"body"`
+	ReleaseNote string `json:"releaseNote"`
+}
+
+// CommitMessage returns the title and body as one commit message.
+func (m MergeMessage) CommitMessage() string {
+	if m.Body == "" {
+		return m.Title
+	}
+	return m.Title + "\n\n" + m.Body
+}
+
+// AnalysisInputs records exactly what went into an analysis, so that an odd
+// result can be explained and the run repeated.
+type AnalysisInputs struct {
+	PromptHash   string   `json:"promptHash"`             // full prompt, including any custom instructions
+	DiffHash     string   `json:"diffHash"`               // diff text as sent, after truncation
+	Model        string   `json:"model,omitempty"`        // empty when the CLI didn't report one
+	CustomPrompt string   `json:"customPrompt,omitempty"` // per-repo prompt file applied, if any
+	Truncations  []string `json:"truncations,omitempty"`  // what was left out of the diff
+	CIChecks     []string `json:"ciChecks,omitempty"`     // failing checks whose log tails were sent
 }
 
 // ProgressEvent reports analysis progress back to the TUI.
 type ProgressEvent struct {
 	Type    string // "tool_use", "thinking", "text"
 	Message string
+	Tool    string // for tool_use: the tool, e.g. "Grep"
+	Detail  string // for tool_use: what it was used on, e.g. a file path or pattern
 }
 
 // ProgressFunc is a callback for receiving progress updates during analysis.
@@ -101,8 +200,14 @@ type ProgressFunc func(event ProgressEvent)
 // StreamEvent represents a single event from Claude's stream-json output.
 type StreamEvent struct {
 	Type    string      `json:"type"`
+	Model   string      `json:"model,omitempty"` // set on the "system" init event
 	Result  interface{} `json:"result,omitempty"`
 	CostUSD float64     `json:"cost_usd,omitempty"`
+
+	// Set on the "result" event: the run's token counts and cost.
+	TotalCostUSD float64 `json:"total_cost_usd,omitempty"`
+	Usage        *Usage  `json:"usage,omitempty"`
+
 	Message *struct {
 		Content []ContentBlock `json:"content,omitempty"`
 	} `json:"message,omitempty"`
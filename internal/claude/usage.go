@@ -0,0 +1,160 @@
+package claude
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Usage counts the tokens and cost of one or more model calls. The JSON
+// names match the usage block of the CLI's result event.
+type Usage struct {
+	Calls            int     `json:"calls,omitempty"`
+	InputTokens      int     `json:"input_tokens"`
+	OutputTokens     int     `json:"output_tokens"`
+	CacheReadTokens  int     `json:"cache_read_input_tokens,omitempty"`
+	CacheWriteTokens int     `json:"cache_creation_input_tokens,omitempty"`
+	CostUSD          float64 `json:"cost_usd,omitempty"` // reported by the CLI, estimated for the API, 0 when unknown
+}
+
+// Add accumulates o into u.
+func (u *Usage) Add(o Usage) {
+	u.Calls += o.Calls
+	u.InputTokens += o.InputTokens
+	u.OutputTokens += o.OutputTokens
+	u.CacheReadTokens += o.CacheReadTokens
+	u.CacheWriteTokens += o.CacheWriteTokens
+	u.CostUSD += o.CostUSD
+}
+
+// PromptTokens is all input, cached or not.
+func (u Usage) PromptTokens() int {
+	return u.InputTokens + u.CacheReadTokens + u.CacheWriteTokens
+}
+
+// resultUsage reads the usage of a CLI run from its result event.
+func resultUsage(event *StreamEvent) Usage {
+	var u Usage
+	if event == nil {
+		return u
+	}
+	if event.Usage != nil {
+		u = *event.Usage
+	}
+	u.Calls = 1
+	u.CostUSD = event.TotalCostUSD
+	if u.CostUSD == 0 {
+		u.CostUSD = event.CostUSD
+	}
+	return u
+}
+
+// anthropicPrices are USD per million input and output tokens, matched
+// against the model name in order.
+var anthropicPrices = []struct {
+	match         string
+	input, output float64
+}{
+	{"haiku", 1, 5},
+	{"sonnet", 3, 15},
+	{"opus-4-5", 5, 25},
+	{"opus", 15, 75},
+}
+
+// estimateAnthropicCost prices u at Anthropic's list rates for model. Cache
+// reads cost a tenth of input and cache writes a quarter more.
+func estimateAnthropicCost(model string, u Usage) float64 {
+	for _, p := range anthropicPrices {
+		if strings.Contains(model, p.match) {
+			in := float64(u.InputTokens) + 0.1*float64(u.CacheReadTokens) + 1.25*float64(u.CacheWriteTokens)
+			return (in*p.input + float64(u.OutputTokens)*p.output) / 1e6
+		}
+	}
+	return 0
+}
+
+type usageRecorderKey struct{}
+
+// WithUsageRecorder returns a context whose model calls report their usage
+// to fn once they finish. fn is called on the goroutine making the call.
+func WithUsageRecorder(ctx context.Context, fn func(Usage)) context.Context {
+	return context.WithValue(ctx, usageRecorderKey{}, fn)
+}
+
+// recordUsage reports u to the context's usage recorder, if any.
+func recordUsage(ctx context.Context, u Usage) {
+	if fn, ok := ctx.Value(usageRecorderKey{}).(func(Usage)); ok && fn != nil {
+		fn(u)
+	}
+}
+
+// UsageLedger keeps running usage totals per calendar month on disk, so a
+// monthly budget spans sessions.
+type UsageLedger struct {
+	mu     sync.Mutex
+	path   string
+	months map[string]Usage // "2006-01" → totals
+}
+
+// NewUsageLedger opens the ledger at path. A missing file starts an empty
+// ledger; so does an unreadable one, which is also reported as the error.
+func NewUsageLedger(path string) (*UsageLedger, error) {
+	l := &UsageLedger{path: path, months: map[string]Usage{}}
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return l, nil
+	}
+	if err == nil {
+		err = json.Unmarshal(data, &l.months)
+	}
+	if err != nil || l.months == nil { // "null" decodes to a nil map
+		l.months = map[string]Usage{}
+	}
+	if err != nil {
+		return l, fmt.Errorf("failed to read usage ledger %s: %w", path, err)
+	}
+	return l, nil
+}
+
+// UsageMonth is the ledger key for t.
+func UsageMonth(t time.Time) string {
+	return t.Format("2006-01")
+}
+
+// Month returns the totals recorded for month.
+func (l *UsageLedger) Month(month string) Usage {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.months[month]
+}
+
+// Add records u against month and saves the ledger.
+func (l *UsageLedger) Add(month string, u Usage) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	total := l.months[month]
+	total.Add(u)
+	l.months[month] = total
+
+	if err := os.MkdirAll(filepath.Dir(l.path), 0o755); err != nil {
+		return fmt.Errorf("failed to create usage directory: %w", err)
+	}
+	data, err := json.MarshalIndent(l.months, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal usage: %w", err)
+	}
+	tmpPath := l.path + ".tmp"
+	if err := os.WriteFile(tmpPath, data, 0o644); err != nil {
+		return fmt.Errorf("failed to write usage file: %w", err)
+	}
+	if err := os.Rename(tmpPath, l.path); err != nil {
+		os.Remove(tmpPath)
+		return fmt.Errorf("failed to rename usage file: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,78 @@
+package claude
+
+import (
+	"context"
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestChatService_RecordsCLIUsage(t *testing.T) {
+	mock := &mockExecutor{stdout: `{"type":"result","result":"hi","total_cost_usd":0.0125,"usage":{"input_tokens":100,"cache_read_input_tokens":2000,"output_tokens":40}}`}
+	chat := NewChatService(mock, time.Second, nil, 0, 0, 0)
+
+	var got Usage
+	ctx := WithUsageRecorder(context.Background(), func(u Usage) { got.Add(u) })
+	if _, err := chat.AskOnce(ctx, ChatInput{Message: "hi"}, func(string) {}); err != nil {
+		t.Fatalf("AskOnce: %v", err)
+	}
+	want := Usage{Calls: 1, InputTokens: 100, CacheReadTokens: 2000, OutputTokens: 40, CostUSD: 0.0125}
+	if got != want {
+		t.Errorf("usage = %+v, want %+v", got, want)
+	}
+	if got.PromptTokens() != 2100 {
+		t.Errorf("prompt tokens = %d", got.PromptTokens())
+	}
+}
+
+func TestEstimateAnthropicCost(t *testing.T) {
+	u := Usage{InputTokens: 1_000_000, OutputTokens: 100_000}
+	if got := estimateAnthropicCost("claude-sonnet-4-5-20250929", u); math.Abs(got-4.5) > 1e-9 {
+		t.Errorf("sonnet = %v, want 4.5", got)
+	}
+	if got := estimateAnthropicCost("gpt-4o", u); got != 0 {
+		t.Errorf("unknown model = %v, want 0", got)
+	}
+}
+
+func TestUsageLedger_PersistsMonths(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "usage.json")
+	l, err := NewUsageLedger(path)
+	if err != nil {
+		t.Fatalf("NewUsageLedger on a missing file: %v", err)
+	}
+	if err := l.Add("2026-10", Usage{Calls: 1, CostUSD: 0.5}); err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+	l.Add("2026-10", Usage{Calls: 1, CostUSD: 0.25})
+	l.Add("2026-09", Usage{Calls: 1, CostUSD: 9})
+
+	reopened, _ := NewUsageLedger(path)
+	if got := reopened.Month("2026-10"); got.Calls != 2 || got.CostUSD != 0.75 {
+		t.Errorf("October = %+v", got)
+	}
+	if got := reopened.Month("2026-11"); got != (Usage{}) {
+		t.Errorf("unrecorded month = %+v", got)
+	}
+}
+
+func TestUsageLedger_UnreadableFile(t *testing.T) {
+	for _, content := range []string{"null", "{not json"} {
+		path := filepath.Join(t.TempDir(), "usage.json")
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		l, err := NewUsageLedger(path)
+		if content != "null" && err == nil {
+			t.Errorf("%q: expected the load error to be reported", content)
+		}
+		if err := l.Add("2026-10", Usage{Calls: 1}); err != nil {
+			t.Fatalf("%q: Add: %v", content, err)
+		}
+		if got := l.Month("2026-10"); got.Calls != 1 {
+			t.Errorf("%q: October = %+v", content, got)
+		}
+	}
+}
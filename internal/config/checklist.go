@@ -0,0 +1,69 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// DefaultChecklistName is the checklist file used for repositories without
+// one of their own.
+const DefaultChecklistName = "default"
+
+// ChecklistsDir returns the path to the review checklists directory.
+func ChecklistsDir() string {
+	return filepath.Join(DefaultConfigDir(), "checklists")
+}
+
+// GetRepoChecklist loads the review checklist for a repository from
+// {owner}_{repo}.yml (or .yaml) in dir, falling back to default.yml.
+// Missing files mean no checklist.
+func GetRepoChecklist(dir, owner, repo string) ([]string, error) {
+	for _, name := range []string{owner + "_" + repo, DefaultChecklistName} {
+		for _, ext := range []string{".yml", ".yaml"} {
+			data, err := os.ReadFile(filepath.Join(dir, name+ext))
+			if os.IsNotExist(err) {
+				continue
+			}
+			if err != nil {
+				return nil, fmt.Errorf("failed to read checklist: %w", err)
+			}
+			return parseChecklist(string(data)), nil
+		}
+	}
+	return nil, nil
+}
+
+// parseChecklist reads the YAML list of checklist items, either a bare
+// sequence or one under an "items:" key:
+//
+//	items:
+//	  - Migrations reviewed
+//	  - "Docs updated"
+//
+// Only this subset of YAML is supported; other lines are ignored.
+func parseChecklist(data string) []string {
+	var items []string
+	for _, line := range strings.Split(data, "\n") {
+		line = strings.TrimSpace(line)
+		item, ok := strings.CutPrefix(line, "- ")
+		if !ok {
+			continue
+		}
+		item = strings.TrimSpace(item)
+		if i := strings.Index(item, " #"); i >= 0 && !strings.HasPrefix(item, `"`) && !strings.HasPrefix(item, "'") {
+			item = strings.TrimSpace(item[:i])
+		}
+		if unquoted, err := strconv.Unquote(item); err == nil && strings.HasPrefix(item, `"`) {
+			item = unquoted
+		} else if len(item) >= 2 && item[0] == '\'' && item[len(item)-1] == '\'' {
+			item = strings.ReplaceAll(item[1:len(item)-1], "''", "'")
+		}
+		if item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
@@ -4,24 +4,43 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"os/exec"
 	"path/filepath"
 	"runtime"
+	"sort"
+	"strings"
 	"time"
 )
 
 // Config holds application configuration.
 type Config struct {
-	ClaudeTimeout        int      `json:"claudeTimeoutMs"`
-	PollInterval         int      `json:"pollIntervalMs"`
-	PollEnabled          bool     `json:"pollEnabled"`
-	NotificationsEnabled bool     `json:"notificationsEnabled"`
-	DefaultPRTab         string   `json:"defaultPRTab"`         // "review" (default) or "mine"
-	StartCollapsed       []string `json:"startCollapsed"`       // panels to collapse on boot, e.g. ["right"]
-	CollapseThreshold    int      `json:"collapseThreshold"`    // terminal width below which panels auto-collapse
+	ClaudeTimeout        int       `json:"claudeTimeoutMs"`
+	PollInterval         int       `json:"pollIntervalMs"`
+	PollEnabled          bool      `json:"pollEnabled"`
+	NotificationsEnabled bool      `json:"notificationsEnabled"`
+	DefaultPRTab         string    `json:"defaultPRTab"`          // "review" (default) or "mine"
+	StartCollapsed       []string  `json:"startCollapsed"`        // panels to collapse on boot, e.g. ["right"]
+	CollapseThreshold    int       `json:"collapseThreshold"`     // terminal width below which panels auto-collapse
+	PanelRatios          []float64 `json:"panelRatios,omitempty"` // relative widths of the left, center and right panels
+	WorkspaceSize        int       `json:"workspaceSize"`         // PRs kept in memory for instant switching, including the current one
+
+	// Status bar segments: absent keeps "ai", "timer", "focus", "mode", "pr"
+	StatusBarSegments   []string       `json:"statusBarSegments,omitempty"`   // right-hand segments, in display order
+	StatusBarPriorities map[string]int `json:"statusBarPriorities,omitempty"` // per-segment priority; lowest is dropped first when narrow
 
 	// Tier 1: fetch & notification tuning
-	PRFetchLimit          int `json:"prFetchLimit"`          // max PRs to fetch per query
-	NotificationThreshold int `json:"notificationThreshold"` // above this, batch notifications into summary
+	PRFetchLimit          int      `json:"prFetchLimit"`          // max PRs to fetch per query
+	NotificationThreshold int      `json:"notificationThreshold"` // above this, batch notifications into summary
+	NotifyMuted           []string `json:"notifyMuted"`           // notification triggers turned off, e.g. ["comments"]
+	PRCacheTTLHours       int      `json:"prCacheTTLHours"`       // hours a PR's fetched data is kept to show on selection; negative disables
+
+	// Outbound webhook (Slack incoming webhook or generic HTTP endpoint)
+	WebhookURL    string   `json:"webhookUrl"`    // empty disables the webhook
+	WebhookEvents []string `json:"webhookEvents"` // events to post; absent means all
+
+	// Reviewer workload (:workload)
+	WorkloadScopes    []string `json:"workloadScopes,omitempty"` // orgs or repos to aggregate, e.g. ["acme", "acme/api"]
+	WorkloadStaleDays int      `json:"workloadStaleDays"`        // days a review request waits before it counts as stale
 
 	// Tier 2: AI tuning
 	MaxChatHistory    int `json:"maxChatHistory"`    // max messages in chat history
@@ -30,6 +49,94 @@ type Config struct {
 	AnalysisMaxTurns  int `json:"analysisMaxTurns"`  // max turns for analysis
 	StreamCheckpointMs  int    `json:"streamCheckpointMs"`  // stream rendering checkpoint interval in ms
 	DefaultReviewAction string `json:"defaultReviewAction"` // "approve", "comment", or "request_changes"
+	AIReviewMinSeverity string `json:"aiReviewMinSeverity,omitempty"` // AI review comments below "warning" or "critical" wait in :review triage; empty accepts all
+	AIErrorBudget       int    `json:"aiErrorBudget"`       // consecutive Claude failures before AI is degraded, then disabled
+	AIMonthlyBudgetUSD  float64 `json:"aiMonthlyBudgetUsd,omitempty"` // warn once this month's AI spend reaches it; 0 = no budget
+	AITriage            bool    `json:"aiTriage"`                     // summarize and tag each PR waiting for your review in the background
+	AnalysisCILogs      bool    `json:"analysisCiLogs"`               // send the log tails of failing checks with analysis and AI review
+	ChatRepoTools       bool    `json:"chatRepoTools"`                // let chat read the PR repo's local clone (localClones) with Read/Grep/Glob
+
+	// AI backends, chosen per feature: "claude" (the Claude CLI), "anthropic", "openai" or "ollama"
+	ChatProvider     string `json:"chatProvider"`            // chat and quick questions
+	AnalysisProvider string `json:"analysisProvider"`        // analysis and AI review
+	ChatModel        string `json:"chatModel,omitempty"`     // Claude CLI --model for chat, e.g. "haiku"; empty is the CLI default
+	AnalysisModel    string `json:"analysisModel,omitempty"` // Claude CLI --model for analysis and AI review, e.g. "opus"
+	AnthropicModel   string `json:"anthropicModel"`          // Messages API model; the key comes from ANTHROPIC_API_KEY
+	OpenAIBaseURL    string `json:"openaiBaseUrl,omitempty"` // any OpenAI-compatible API root; empty is api.openai.com
+	OpenAIAPIKey     string `json:"openaiApiKey,omitempty"`  // OPENAI_API_KEY takes precedence
+	OpenAIModel      string `json:"openaiModel"`
+	OllamaURL        string `json:"ollamaUrl,omitempty"` // empty is http://localhost:11434
+	OllamaModel      string `json:"ollamaModel"`
+
+	// Local runs (:run) in a clone of the PR's repository
+	LocalClones   map[string]string `json:"localClones,omitempty"` // "owner/repo" → path of a local clone
+	RunCommands   map[string]string `json:"runCommands"`           // shell commands by name, e.g. {"test": "make test"}; {} disables :run
+	RunInWorktree bool              `json:"runInWorktree"`         // check PRs out into a temporary git worktree instead of switching the clone's branch
+
+	// CI coverage overlay: artifact names (globs) holding an lcov, Cobertura or Go coverage report
+	CoverageArtifacts []string `json:"coverageArtifacts"` // absent means ["coverage*"]; [] disables the overlay
+
+	// Display
+	ShowOutdatedComments bool              `json:"showOutdatedComments"`  // re-anchor outdated review comments in the diff
+	DiffMinimap          bool              `json:"diffMinimap"`           // widen the diff scrollbar into a minimap of changes and comments
+	AbsoluteTimestamps   bool              `json:"absoluteTimestamps"`    // show times as local dates instead of "3h ago"
+	Theme                string            `json:"theme,omitempty"`       // "auto" (default), "dark", "light", "solarized", or "high-contrast"
+	ThemeColors          map[string]string `json:"themeColors,omitempty"` // per-color overrides of the theme, e.g. {"accent": "#ff8800"}
+	CompatMode           string            `json:"compatMode,omitempty"`  // "auto" (default) detects 16-color and non-UTF-8 terminals, "on", or "off"
+
+	// Chat
+	ChatPresets []ChatPreset `json:"chatPresets"` // prompt presets offered by the chat input picker (ctrl+t)
+
+	// Text inputs
+	Snippets map[string]string `json:"snippets"` // expansions offered after "/" in comment and chat inputs, by name
+
+	// GitHub authentication without the gh CLI
+	GitHubToken    string `json:"githubToken,omitempty"`    // used instead of gh when set
+	GitHubClientID string `json:"githubClientId,omitempty"` // OAuth app client ID for `prtea auth login`
+}
+
+// ChatPreset is a canned prompt selectable from the chat input. The prompt
+// may reference PR context placeholders: {{title}}, {{number}}, {{repo}},
+// {{author}}, {{base}}, {{head}} and {{url}}.
+type ChatPreset struct {
+	Name   string `json:"name"`
+	Prompt string `json:"prompt"`
+	Tab    string `json:"tab,omitempty"` // "chat" (default) or "comments"
+}
+
+// DefaultChatPresets returns the presets used when none are configured.
+func DefaultChatPresets() []ChatPreset {
+	return []ChatPreset{
+		{Name: "Summarize this PR", Prompt: "Summarize PR #{{number}} ({{title}}) in a few bullet points: what changes, why, and anything reviewers should look at closely."},
+		{Name: "What are the riskiest parts?", Prompt: "What are the riskiest parts of PR #{{number}} ({{title}})? List them in order of risk and explain what could break."},
+		{Name: "Draft release notes", Prompt: "Draft concise, user-facing release notes for PR #{{number}} ({{title}}) in {{repo}}."},
+	}
+}
+
+// DefaultSnippets returns the snippets used when none are configured.
+func DefaultSnippets() map[string]string {
+	return map[string]string{
+		"nit":        "nit: ",
+		"question":   "question: ",
+		"blocking":   "blocking: ",
+		"suggestion": "```suggestion\n\n```",
+		"lgtm":       "LGTM, thanks! :shipit:",
+		"details":    "<details>\n<summary>Details</summary>\n\n</details>",
+	}
+}
+
+// DefaultRunCommands returns the :run commands used when none are configured.
+func DefaultRunCommands() map[string]string {
+	return map[string]string{
+		"test": "make test",
+		"lint": "make lint",
+	}
+}
+
+// DefaultCoverageArtifacts returns the artifact names searched for coverage
+// reports when none are configured.
+func DefaultCoverageArtifacts() []string {
+	return []string{"coverage*"}
 }
 
 // Defaults
@@ -44,8 +151,96 @@ const (
 	DefaultChatMaxTurns          = 3
 	DefaultAnalysisMaxTurns      = 30
 	DefaultStreamCheckpointMs    = 300
+	DefaultAIErrorBudget         = 3
+	DefaultWorkspaceSize         = 5
+	DefaultWorkloadStaleDays     = 2
+	DefaultPRCacheTTLHours       = 72
+	DefaultAnthropicModel        = "claude-sonnet-4-5"
+	DefaultOpenAIModel           = "gpt-4o-mini"
+	DefaultOllamaModel           = "llama3.1"
+)
+
+// AI providers for ChatProvider and AnalysisProvider.
+const (
+	ProviderClaude    = "claude"    // the Claude CLI (default)
+	ProviderAnthropic = "anthropic" // the Anthropic Messages API, falling back to the CLI without a key
+	ProviderOpenAI    = "openai"    // an OpenAI-compatible HTTP API
+	ProviderOllama    = "ollama"    // a local Ollama server
 )
 
+// Notification triggers, individually mutable via NotifyMuted.
+const (
+	NotifyNewPR         = "new_pr"   // a PR newly requests my review
+	NotifyCIFinished    = "ci"       // CI finished on one of my PRs
+	NotifyComments      = "comments" // new comments on a PR I'm reviewing
+	NotifyReviewOutcome = "review"   // my PR was approved or had changes requested
+	NotifyReReview      = "rereview" // my review was requested again
+)
+
+// NotifyTriggerEnabled reports whether a notification trigger is on.
+// Triggers are on unless listed in NotifyMuted.
+func (c *Config) NotifyTriggerEnabled(trigger string) bool {
+	for _, t := range c.NotifyMuted {
+		if t == trigger {
+			return false
+		}
+	}
+	return true
+}
+
+// WebhookEventEnabled reports whether event should be posted to the webhook.
+// An absent event list enables every event; an explicit [] disables all.
+func (c *Config) WebhookEventEnabled(event string) bool {
+	if c.WebhookURL == "" {
+		return false
+	}
+	if c.WebhookEvents == nil {
+		return true
+	}
+	for _, e := range c.WebhookEvents {
+		if e == event {
+			return true
+		}
+	}
+	return false
+}
+
+// LocalClone returns the configured local clone of owner/repo, with a
+// leading "~/" expanded, or "" if there is none. Repo names match
+// case-insensitively, as on GitHub.
+func (c *Config) LocalClone(owner, repo string) string {
+	want := owner + "/" + repo
+	for name, path := range c.LocalClones {
+		if !strings.EqualFold(name, want) || path == "" {
+			continue
+		}
+		return expandHome(path)
+	}
+	return ""
+}
+
+// LocalClonePaths returns the paths of all configured local clones, sorted.
+func (c *Config) LocalClonePaths() []string {
+	var paths []string
+	for _, path := range c.LocalClones {
+		if path != "" {
+			paths = append(paths, expandHome(path))
+		}
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+// expandHome expands a leading "~/" to the user's home directory.
+func expandHome(path string) string {
+	if rest, ok := strings.CutPrefix(path, "~/"); ok {
+		if home, err := os.UserHomeDir(); err == nil {
+			return filepath.Join(home, rest)
+		}
+	}
+	return path
+}
+
 // DefaultConfigDir returns the platform-appropriate config directory.
 func DefaultConfigDir() string {
 	home, err := os.UserHomeDir()
@@ -71,8 +266,7 @@ func DefaultConfigDir() string {
 
 // Load reads the config file, returning defaults for missing fields.
 func Load() (*Config, error) {
-	configPath := filepath.Join(DefaultConfigDir(), "config.json")
-	data, err := os.ReadFile(configPath)
+	data, err := os.ReadFile(ConfigFile())
 	if err != nil {
 		if os.IsNotExist(err) {
 			return defaults(), nil
@@ -89,7 +283,8 @@ func Load() (*Config, error) {
 	return &cfg, nil
 }
 
-// Save writes the config to disk.
+// Save writes the config to disk. The file is only readable by the owner
+// since it may hold a GitHub token or webhook URL.
 func Save(cfg *Config) error {
 	dir := DefaultConfigDir()
 	if err := os.MkdirAll(dir, 0o755); err != nil {
@@ -101,10 +296,10 @@ func Save(cfg *Config) error {
 		return fmt.Errorf("failed to marshal config: %w", err)
 	}
 
-	configPath := filepath.Join(dir, "config.json")
+	configPath := ConfigFile()
 	tmpPath := configPath + ".tmp"
 
-	if err := os.WriteFile(tmpPath, data, 0o644); err != nil {
+	if err := os.WriteFile(tmpPath, data, 0o600); err != nil {
 		return fmt.Errorf("failed to write config: %w", err)
 	}
 
@@ -116,16 +311,47 @@ func Save(cfg *Config) error {
 	return nil
 }
 
+// ConfigFile returns the path to the config file.
+func ConfigFile() string {
+	return filepath.Join(DefaultConfigDir(), "config.json")
+}
+
 // AnalysesCacheDir returns the path to the analysis cache directory.
 func AnalysesCacheDir() string {
 	return filepath.Join(DefaultConfigDir(), "analyses")
 }
 
+// HTTPCacheDir returns the path to the ETag-validated GitHub response cache.
+func HTTPCacheDir() string {
+	return filepath.Join(DefaultConfigDir(), "http")
+}
+
+// PRCacheDir returns the path to the cache of fetched PR data.
+func PRCacheDir() string {
+	return filepath.Join(DefaultConfigDir(), "prs")
+}
+
 // ChatCacheDir returns the path to the chat session cache directory.
 func ChatCacheDir() string {
 	return filepath.Join(DefaultConfigDir(), "chats")
 }
 
+// ScratchDir returns the path to the autosaved text input directory.
+func ScratchDir() string {
+	return filepath.Join(DefaultConfigDir(), "scratch")
+}
+
+// ReviewedFile returns the path to the ledger of PR heads last reviewed and
+// viewed.
+func ReviewedFile() string {
+	return filepath.Join(DefaultConfigDir(), "reviewed.json")
+}
+
+// UsageFile returns the path to the monthly AI usage ledger.
+func UsageFile() string {
+	return filepath.Join(DefaultConfigDir(), "usage.json")
+}
+
 // PromptsDir returns the path to the custom prompts directory.
 func PromptsDir() string {
 	return filepath.Join(DefaultConfigDir(), "prompts")
@@ -144,11 +370,49 @@ func GetRepoPrompt(owner, repo string) (string, error) {
 	return string(data), nil
 }
 
+// ghInstalled reports whether the gh CLI is on PATH. Tests replace it.
+var ghInstalled = func() bool {
+	_, err := exec.LookPath("gh")
+	return err == nil
+}
+
+// GitHubAuthToken returns the token to authenticate with instead of the gh
+// CLI. Empty means use gh.
+func (c *Config) GitHubAuthToken() string {
+	token, _ := c.GitHubAuth()
+	return token
+}
+
+// GitHubAuth picks the GitHub credentials and names where they came from.
+// A saved token (prtea auth login) is an explicit opt-in and always wins;
+// otherwise gh is used when it is installed, and only without gh do
+// GITHUB_TOKEN or GH_TOKEN stand in for it. Those variables are often
+// narrowly scoped tokens meant for other tools, and gh reads them itself.
+func (c *Config) GitHubAuth() (token, source string) {
+	switch {
+	case c.GitHubToken != "":
+		return c.GitHubToken, "saved token"
+	case ghInstalled():
+		return "", "gh CLI"
+	case os.Getenv("GITHUB_TOKEN") != "":
+		return os.Getenv("GITHUB_TOKEN"), "GITHUB_TOKEN"
+	case os.Getenv("GH_TOKEN") != "":
+		return os.Getenv("GH_TOKEN"), "GH_TOKEN"
+	}
+	return "", "gh CLI"
+}
+
 // ClaudeTimeoutDuration returns the configured claude timeout as a time.Duration.
 func (c *Config) ClaudeTimeoutDuration() time.Duration {
 	return time.Duration(c.ClaudeTimeout) * time.Millisecond
 }
 
+// PRCacheTTL returns how long fetched PR data is cached, or 0 when the
+// cache is off.
+func (c *Config) PRCacheTTL() time.Duration {
+	return time.Duration(max(c.PRCacheTTLHours, 0)) * time.Hour
+}
+
 // PollIntervalDuration returns the configured poll interval as a time.Duration.
 func (c *Config) PollIntervalDuration() time.Duration {
 	return time.Duration(c.PollInterval) * time.Millisecond
@@ -166,6 +430,19 @@ func defaults() *Config {
 		ChatMaxTurns:          DefaultChatMaxTurns,
 		AnalysisMaxTurns:      DefaultAnalysisMaxTurns,
 		StreamCheckpointMs:    DefaultStreamCheckpointMs,
+		AIErrorBudget:         DefaultAIErrorBudget,
+		WorkspaceSize:         DefaultWorkspaceSize,
+		WorkloadStaleDays:     DefaultWorkloadStaleDays,
+		PRCacheTTLHours:       DefaultPRCacheTTLHours,
+		ChatProvider:          ProviderClaude,
+		AnalysisProvider:      ProviderClaude,
+		AnthropicModel:        DefaultAnthropicModel,
+		OpenAIModel:           DefaultOpenAIModel,
+		OllamaModel:           DefaultOllamaModel,
+		ChatPresets:           DefaultChatPresets(),
+		Snippets:              DefaultSnippets(),
+		CoverageArtifacts:     DefaultCoverageArtifacts(),
+		RunCommands:           DefaultRunCommands(),
 	}
 }
 
@@ -200,4 +477,45 @@ func applyDefaults(cfg *Config) {
 	if cfg.StreamCheckpointMs == 0 {
 		cfg.StreamCheckpointMs = DefaultStreamCheckpointMs
 	}
+	if cfg.AIErrorBudget == 0 {
+		cfg.AIErrorBudget = DefaultAIErrorBudget
+	}
+	if cfg.WorkspaceSize == 0 {
+		cfg.WorkspaceSize = DefaultWorkspaceSize
+	}
+	if cfg.WorkloadStaleDays == 0 {
+		cfg.WorkloadStaleDays = DefaultWorkloadStaleDays
+	}
+	if cfg.PRCacheTTLHours == 0 {
+		cfg.PRCacheTTLHours = DefaultPRCacheTTLHours
+	}
+	if cfg.ChatProvider == "" {
+		cfg.ChatProvider = ProviderClaude
+	}
+	if cfg.AnalysisProvider == "" {
+		cfg.AnalysisProvider = ProviderClaude
+	}
+	if cfg.AnthropicModel == "" {
+		cfg.AnthropicModel = DefaultAnthropicModel
+	}
+	if cfg.OpenAIModel == "" {
+		cfg.OpenAIModel = DefaultOpenAIModel
+	}
+	if cfg.OllamaModel == "" {
+		cfg.OllamaModel = DefaultOllamaModel
+	}
+	// A nil slice means the key is absent; an explicit [] disables presets.
+	if cfg.ChatPresets == nil {
+		cfg.ChatPresets = DefaultChatPresets()
+	}
+	// Likewise a nil map means the key is absent; {} disables snippets.
+	if cfg.Snippets == nil {
+		cfg.Snippets = DefaultSnippets()
+	}
+	if cfg.RunCommands == nil {
+		cfg.RunCommands = DefaultRunCommands()
+	}
+	if cfg.CoverageArtifacts == nil {
+		cfg.CoverageArtifacts = DefaultCoverageArtifacts()
+	}
 }
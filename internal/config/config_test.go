@@ -4,6 +4,9 @@ import (
 	"encoding/json"
 	"os"
 	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
 	"testing"
 	"time"
 )
@@ -45,6 +48,59 @@ func TestApplyDefaults(t *testing.T) {
 	})
 }
 
+func TestApplyDefaults_ChatPresets(t *testing.T) {
+	t.Run("absent key uses defaults", func(t *testing.T) {
+		var cfg Config
+		if err := json.Unmarshal([]byte(`{}`), &cfg); err != nil {
+			t.Fatal(err)
+		}
+		applyDefaults(&cfg)
+		if len(cfg.ChatPresets) != len(DefaultChatPresets()) {
+			t.Errorf("ChatPresets len = %d, want %d", len(cfg.ChatPresets), len(DefaultChatPresets()))
+		}
+	})
+
+	t.Run("explicit empty list disables presets", func(t *testing.T) {
+		var cfg Config
+		if err := json.Unmarshal([]byte(`{"chatPresets": []}`), &cfg); err != nil {
+			t.Fatal(err)
+		}
+		applyDefaults(&cfg)
+		if len(cfg.ChatPresets) != 0 {
+			t.Errorf("ChatPresets len = %d, want 0", len(cfg.ChatPresets))
+		}
+	})
+}
+
+func TestNotifyTriggerEnabled(t *testing.T) {
+	cfg := defaults()
+	if !cfg.NotifyTriggerEnabled(NotifyCIFinished) {
+		t.Error("triggers should default to enabled")
+	}
+	cfg.NotifyMuted = []string{NotifyComments}
+	if cfg.NotifyTriggerEnabled(NotifyComments) {
+		t.Error("muted trigger reported as enabled")
+	}
+	if !cfg.NotifyTriggerEnabled(NotifyNewPR) {
+		t.Error("unmuted trigger reported as disabled")
+	}
+}
+
+func TestWebhookEventEnabled(t *testing.T) {
+	cfg := defaults()
+	if cfg.WebhookEventEnabled("ci_failed") {
+		t.Error("events should be off without a webhook URL")
+	}
+	cfg.WebhookURL = "https://example.com/hook"
+	if !cfg.WebhookEventEnabled("ci_failed") {
+		t.Error("absent event list should enable all events")
+	}
+	cfg.WebhookEvents = []string{"review_submitted"}
+	if cfg.WebhookEventEnabled("ci_failed") || !cfg.WebhookEventEnabled("review_submitted") {
+		t.Errorf("event list %v not honoured", cfg.WebhookEvents)
+	}
+}
+
 func TestClaudeTimeoutDuration(t *testing.T) {
 	cfg := &Config{ClaudeTimeout: 120000}
 	got := cfg.ClaudeTimeoutDuration()
@@ -54,6 +110,35 @@ func TestClaudeTimeoutDuration(t *testing.T) {
 	}
 }
 
+func TestGitHubAuthToken(t *testing.T) {
+	installed := true
+	prev := ghInstalled
+	ghInstalled = func() bool { return installed }
+	t.Cleanup(func() { ghInstalled = prev })
+	t.Setenv("GITHUB_TOKEN", "github-env")
+	t.Setenv("GH_TOKEN", "gh-env")
+
+	cfg := &Config{GitHubToken: "saved"}
+	if got := cfg.GitHubAuthToken(); got != "saved" {
+		t.Errorf("GitHubAuthToken() = %q, want the saved token over gh and the environment", got)
+	}
+
+	cfg.GitHubToken = ""
+	if got, source := cfg.GitHubAuth(); got != "" || source != "gh CLI" {
+		t.Errorf("GitHubAuth() = %q, %q; want gh to win over environment tokens when installed", got, source)
+	}
+
+	installed = false
+	if got := cfg.GitHubAuthToken(); got != "github-env" {
+		t.Errorf("GitHubAuthToken() = %q, want GITHUB_TOKEN without gh", got)
+	}
+
+	t.Setenv("GITHUB_TOKEN", "")
+	if got := cfg.GitHubAuthToken(); got != "gh-env" {
+		t.Errorf("GitHubAuthToken() = %q, want GH_TOKEN without gh", got)
+	}
+}
+
 func TestSaveAndLoadRoundTrip(t *testing.T) {
 	tmpDir := t.TempDir()
 
@@ -104,3 +189,76 @@ func TestGetRepoPrompt_NotFound(t *testing.T) {
 		t.Errorf("expected empty prompt, got %q", prompt)
 	}
 }
+
+func TestGetRepoChecklist(t *testing.T) {
+	dir := t.TempDir()
+	if items, err := GetRepoChecklist(dir, "alice", "widget"); err != nil || items != nil {
+		t.Fatalf("no checklist: items = %v, err = %v", items, err)
+	}
+
+	os.WriteFile(filepath.Join(dir, "default.yml"), []byte("- Tests added\n"), 0o644)
+	os.WriteFile(filepath.Join(dir, "alice_widget.yaml"), []byte(`# widget checklist
+items:
+  - Migrations reviewed  # if any
+  - "Docs updated: README #setup"
+  - 'Security ''considered'''
+`), 0o644)
+
+	items, err := GetRepoChecklist(dir, "alice", "widget")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []string{"Migrations reviewed", "Docs updated: README #setup", "Security 'considered'"}
+	if len(items) != len(want) {
+		t.Fatalf("items = %q, want %q", items, want)
+	}
+	for i := range want {
+		if items[i] != want[i] {
+			t.Errorf("items[%d] = %q, want %q", i, items[i], want[i])
+		}
+	}
+
+	if items, _ := GetRepoChecklist(dir, "alice", "other"); len(items) != 1 || items[0] != "Tests added" {
+		t.Errorf("default checklist = %q", items)
+	}
+}
+
+func TestLoadRC(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "prtearc")
+	if cmds, err := LoadRC(path); err != nil || cmds != nil {
+		t.Fatalf("missing file: cmds = %v, err = %v", cmds, err)
+	}
+
+	os.WriteFile(path, []byte("# startup\nopen 123\n\n  :diff; zoom ;\n# done\n"), 0o644)
+	cmds, err := LoadRC(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []string{"open 123", "diff", "zoom"}
+	if strings.Join(cmds, "|") != strings.Join(want, "|") {
+		t.Errorf("cmds = %q, want %q", cmds, want)
+	}
+}
+
+func TestLocalClone(t *testing.T) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		t.Skip("no home directory")
+	}
+	cfg := defaults()
+	cfg.LocalClones = map[string]string{"Shhac/PRTea": "~/src/prtea", "acme/api": "/work/api"}
+	if got := cfg.LocalClone("shhac", "prtea"); got != filepath.Join(home, "src/prtea") {
+		t.Errorf("LocalClone(shhac/prtea) = %q", got)
+	}
+	if got := cfg.LocalClone("acme", "api"); got != "/work/api" {
+		t.Errorf("LocalClone(acme/api) = %q", got)
+	}
+	if got := cfg.LocalClone("acme", "web"); got != "" {
+		t.Errorf("unconfigured repo = %q, want empty", got)
+	}
+	want := []string{"/work/api", filepath.Join(home, "src/prtea")}
+	sort.Strings(want)
+	if got := cfg.LocalClonePaths(); !slices.Equal(got, want) {
+		t.Errorf("LocalClonePaths() = %q", got)
+	}
+}
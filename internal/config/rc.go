@@ -0,0 +1,49 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// RCPath returns the path to the startup commands file.
+func RCPath() string {
+	return filepath.Join(DefaultConfigDir(), "prtearc")
+}
+
+// LoadRC reads the startup commands in the file at path. A missing file
+// means no commands.
+func LoadRC(path string) ([]string, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	return SplitCommands(string(data)), nil
+}
+
+// SplitCommands splits a script of palette commands, one per line or
+// separated by ";". Blank lines, "#" comments and a leading ":" are
+// ignored:
+//
+//	# open my current PR zoomed in on the diff
+//	open 123
+//	:diff; zoom
+func SplitCommands(script string) []string {
+	var cmds []string
+	for _, line := range strings.Split(script, "\n") {
+		if strings.HasPrefix(strings.TrimSpace(line), "#") {
+			continue
+		}
+		for _, cmd := range strings.Split(line, ";") {
+			cmd = strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(cmd), ":"))
+			if cmd != "" {
+				cmds = append(cmds, cmd)
+			}
+		}
+	}
+	return cmds
+}
@@ -144,6 +144,7 @@ var prDetails = map[int]*github.PRDetail{
 		BaseBranch:     "main", HeadBranch: "demo-user/optimize-allocator",
 		HeadSHA:        "e5f6a1b2c3d4e5f6a1b2c3d4e5f6a1b2c3d4e5f6",
 		Mergeable:      true, MergeableState: "clean",
+		AutoMerge:      "SQUASH",
 	},
 	606: {
 		Number: 606, Title: "Add type hints to data pipeline",
@@ -696,8 +697,8 @@ var ciStatuses = map[int]*github.CIStatus{
 		TotalCount: 3, OverallStatus: "failing",
 		Checks: []github.CICheck{
 			{ID: 9011, Name: "lint", Status: "completed", Conclusion: "success", HTMLURL: "https://github.com/acme/dashboard/actions/runs/9011", WorkflowRunID: 9010},
-			{ID: 9012, Name: "test", Status: "completed", Conclusion: "failure", HTMLURL: "https://github.com/acme/dashboard/actions/runs/9012", WorkflowRunID: 9010},
-			{ID: 9013, Name: "typecheck", Status: "completed", Conclusion: "failure", HTMLURL: "https://github.com/acme/dashboard/actions/runs/9013", WorkflowRunID: 9010},
+			{ID: 9012, Name: "test", Status: "completed", Conclusion: "failure", HTMLURL: "https://github.com/acme/dashboard/actions/runs/9010/job/9012", WorkflowRunID: 9010, JobID: 9012},
+			{ID: 9013, Name: "typecheck", Status: "completed", Conclusion: "failure", HTMLURL: "https://github.com/acme/dashboard/actions/runs/9010/job/9013", WorkflowRunID: 9010, JobID: 9013},
 		},
 	},
 	303: {
@@ -710,7 +711,7 @@ var ciStatuses = map[int]*github.CIStatus{
 		TotalCount: 3, OverallStatus: "mixed",
 		Checks: []github.CICheck{
 			{ID: 9031, Name: "build", Status: "completed", Conclusion: "success", HTMLURL: "https://github.com/acme/platform/actions/runs/9031", WorkflowRunID: 9030},
-			{ID: 9032, Name: "test", Status: "completed", Conclusion: "failure", HTMLURL: "https://github.com/acme/platform/actions/runs/9032", WorkflowRunID: 9030},
+			{ID: 9032, Name: "test", Status: "completed", Conclusion: "failure", HTMLURL: "https://github.com/acme/platform/actions/runs/9030/job/9032", WorkflowRunID: 9030, JobID: 9032},
 			{ID: 9033, Name: "lint", Status: "completed", Conclusion: "skipped", HTMLURL: "https://github.com/acme/platform/actions/runs/9033", WorkflowRunID: 9030},
 		},
 	},
@@ -727,6 +728,68 @@ var ciStatuses = map[int]*github.CIStatus{
 	},
 }
 
+// -- CI Job Logs --
+
+// jobLogs are raw GitHub Actions job logs keyed by job ID, including the
+// timestamp prefixes and ANSI colors the real API returns.
+var jobLogs = map[int64]string{
+	9012: "2026-02-15T09:58:01.1000000Z ##[group]Run npm test\n" +
+		"2026-02-15T09:58:01.1000000Z \x1b[36;1mnpm test\x1b[0m\n" +
+		"2026-02-15T09:58:01.2000000Z ##[endgroup]\n" +
+		"2026-02-15T09:58:04.0000000Z \x1b[32m PASS \x1b[0m src/utils/format.test.ts\n" +
+		"2026-02-15T09:58:05.0000000Z \x1b[31m FAIL \x1b[0m components/ProductList.test.tsx\n" +
+		"2026-02-15T09:58:05.0000000Z   ● ProductList › renders products from server action\n" +
+		"2026-02-15T09:58:05.0000000Z     Error: 'use server' must be the first statement in the file\n" +
+		"2026-02-15T09:58:05.0000000Z       at Object.<anonymous> (components/ProductList.tsx:7:3)\n" +
+		"2026-02-15T09:58:06.0000000Z Tests: 1 failed, 11 passed, 12 total\n" +
+		"2026-02-15T09:58:06.1000000Z ##[error]Process completed with exit code 1.\n",
+	9013: "2026-02-15T09:58:01.1000000Z ##[group]Run npx tsc --noEmit\n" +
+		"2026-02-15T09:58:01.2000000Z ##[endgroup]\n" +
+		"2026-02-15T09:58:09.0000000Z components/ProductList.tsx(12,5): \x1b[31merror\x1b[0m TS2322: Type 'Promise<Product[]>' is not assignable to type 'Product[]'.\n" +
+		"2026-02-15T09:58:09.1000000Z ##[error]Process completed with exit code 2.\n",
+	9032: "2026-02-15T09:40:01.1000000Z ##[group]Run dotnet test\n" +
+		"2026-02-15T09:40:01.2000000Z ##[endgroup]\n" +
+		"2026-02-15T09:40:20.0000000Z   Passed OrderServiceTests.CreateOrder_ReservesItems [12 ms]\n" +
+		"2026-02-15T09:40:20.5000000Z   Failed OrderServiceTests.CreateOrder_PaymentFails_ReleasesReservation [31 ms]\n" +
+		"2026-02-15T09:40:20.5000000Z   Error Message:\n" +
+		"2026-02-15T09:40:20.5000000Z    Expected reservation to be released, but it was still held.\n" +
+		"2026-02-15T09:40:21.0000000Z Failed!  - Failed: 1, Passed: 23, Skipped: 0, Total: 24\n" +
+		"2026-02-15T09:40:21.1000000Z ##[error]Process completed with exit code 1.\n",
+}
+
+// checkAnnotations are check run annotations keyed by check run (job) ID.
+var checkAnnotations = map[int64][]github.CheckAnnotation{
+	9013: {
+		{Path: "components/ProductList.tsx", StartLine: 12, EndLine: 12, Level: "failure", Title: "TS2322",
+			Message: "Type 'Promise<Product[]>' is not assignable to type 'Product[]'."},
+	},
+}
+
+// -- Merge Requirements --
+
+var mergeRequirements = map[int]*github.MergeRequirements{
+	101: {ProtectionKnown: true, Protected: true, RequiredChecks: []string{"lint", "test"}, RequiredApprovals: 1},
+	202: {ProtectionKnown: true, Protected: true, RequiredChecks: []string{"test", "typecheck"}, RequiredApprovals: 1, UnresolvedThreads: 2},
+	303: {ProtectionKnown: true, Protected: true, RequiredChecks: []string{"ci"}, RequiredApprovals: 1},
+	404: {ProtectionKnown: true, Protected: true, RequiredChecks: []string{"build", "test"}, RequiredApprovals: 2, StrictUpToDate: true, UnresolvedThreads: 1},
+	505: {ProtectionKnown: true, Protected: true, RequiredChecks: []string{"test"}, RequiredApprovals: -1},
+	606: {ProtectionKnown: true, RequiredApprovals: 0},
+}
+
+// baseChangedFiles lists files changed on main since each demo PR branched,
+// keyed by repo name. Overlaps with a PR's files are shown as conflict sources.
+var baseChangedFiles = map[string][]string{
+	"platform": {"Services/OrderService.cs", "README.md", "Program.cs"},
+}
+
+// codeOwners holds each demo repo's CODEOWNERS file, keyed by repo name.
+var codeOwners = map[string]string{
+	"gateway": "# Gateway owners\n* @acme/gateway-maintainers\n/middleware/ @acme/platform-team\n*_test.go @" + demoUsername + "\n",
+}
+
+// demoTeams are the teams the demo user belongs to.
+var demoTeams = []string{"@acme/gateway-maintainers"}
+
 // -- Reviews --
 
 var reviewSummaries = map[int]*github.ReviewSummary{
@@ -6,6 +6,7 @@ package demo
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/shhac/prtea/internal/github"
 )
@@ -23,7 +24,13 @@ type Service struct {
 	comments map[int][]github.Comment
 	inline   map[int][]github.InlineComment
 	ci       map[int]*github.CIStatus
+	jobLogs  map[int64]string
 	reviews  map[int]*github.ReviewSummary
+	merge    map[int]*github.MergeRequirements
+	// files changed on base since each demo PR branched, keyed by repo name
+	baseChanges map[string][]string
+	codeOwners  map[string]string                  // CODEOWNERS content keyed by repo name
+	annotations map[int64][]github.CheckAnnotation // check run annotations keyed by check run ID
 }
 
 // NewService creates a DemoService populated with fake PR data.
@@ -37,7 +44,13 @@ func NewService() *Service {
 		comments: issueComments,
 		inline:   inlineComments,
 		ci:       ciStatuses,
+		jobLogs:  jobLogs,
 		reviews:  reviewSummaries,
+		merge:    mergeRequirements,
+
+		annotations: checkAnnotations,
+		baseChanges: baseChangedFiles,
+		codeOwners:  codeOwners,
 	}
 }
 
@@ -53,6 +66,34 @@ func (s *Service) GetMyPRs(_ context.Context) ([]github.PRItem, error) {
 	return s.myPRs, nil
 }
 
+func (s *Service) GetRecentlyClosedPRs(_ context.Context) ([]github.PRItem, error) {
+	return nil, nil
+}
+
+func (s *Service) GetReviewWorkload(_ context.Context, _ []string) ([]github.WorkloadPR, error) {
+	return nil, nil
+}
+
+func (s *Service) GetPRListsSince(_ context.Context, _ time.Time) ([]github.PRItem, []github.PRItem, error) {
+	return nil, nil, nil
+}
+
+func (s *Service) GetPRLists(_ context.Context) ([]github.PRItem, []github.PRItem, error) {
+	toReview := append([]github.PRItem(nil), s.toReview...)
+	mine := append([]github.PRItem(nil), s.myPRs...)
+	for _, prs := range [][]github.PRItem{toReview, mine} {
+		for i := range prs {
+			if r, ok := s.reviews[prs[i].Number]; ok {
+				prs[i].ReviewDecision = r.ReviewDecision
+			}
+			if ci, ok := s.ci[prs[i].Number]; ok {
+				prs[i].CIStatus = ci.OverallStatus
+			}
+		}
+	}
+	return toReview, mine, nil
+}
+
 func (s *Service) GetPRDetail(_ context.Context, _, _ string, number int) (*github.PRDetail, error) {
 	if d, ok := s.details[number]; ok {
 		return d, nil
@@ -82,6 +123,63 @@ func (s *Service) GetCIStatus(_ context.Context, _, _ string, _ string, number i
 	return &github.CIStatus{}, nil
 }
 
+func (s *Service) GetJobLogs(_ context.Context, _, _ string, jobID int64) (string, error) {
+	if logs, ok := s.jobLogs[jobID]; ok {
+		return logs, nil
+	}
+	return "", fmt.Errorf("demo: logs for job %d not found", jobID)
+}
+
+func (s *Service) GetCheckAnnotations(_ context.Context, _, _ string, checkRunID int64) ([]github.CheckAnnotation, error) {
+	return s.annotations[checkRunID], nil
+}
+
+func (s *Service) GetMergeRequirements(_ context.Context, _, _, _ string, number int) (*github.MergeRequirements, error) {
+	if r, ok := s.merge[number]; ok {
+		return r, nil
+	}
+	return &github.MergeRequirements{RequiredApprovals: -1}, nil
+}
+
+func (s *Service) GetBaseChangedFiles(_ context.Context, _, repo, _, _ string) ([]string, error) {
+	return s.baseChanges[repo], nil
+}
+
+func (s *Service) GetCompareFiles(_ context.Context, _, _, _, _ string) ([]github.PRFile, error) {
+	return nil, nil
+}
+
+func (s *Service) GetCompareStatus(_ context.Context, _, _, _, _ string) (string, error) {
+	return "ahead", nil
+}
+
+func (s *Service) GetOpenPRStackInfo(_ context.Context, _, _ string) ([]github.StackPR, error) {
+	return nil, nil
+}
+
+func (s *Service) GetDeployments(_ context.Context, _, _, _ string) ([]github.Deployment, error) {
+	return nil, nil
+}
+
+func (s *Service) GetSecurityAlerts(_ context.Context, _, _, _, _ string, _ int) ([]github.SecurityAlert, error) {
+	return nil, nil
+}
+
+func (s *Service) GetCoverage(_ context.Context, _, _ string, _ []int64, _ []string) (*github.CoverageReport, error) {
+	return nil, nil
+}
+
+func (s *Service) GetCodeOwners(_ context.Context, _, repo, _ string) ([]github.CodeOwnerRule, error) {
+	if content, ok := s.codeOwners[repo]; ok {
+		return github.ParseCodeOwners(content), nil
+	}
+	return nil, nil
+}
+
+func (s *Service) GetMyTeams(_ context.Context) ([]string, error) {
+	return demoTeams, nil
+}
+
 func (s *Service) GetReviews(_ context.Context, _, _ string, number int) (*github.ReviewSummary, error) {
 	if r, ok := s.reviews[number]; ok {
 		return r, nil
@@ -101,10 +199,24 @@ func (s *Service) GetReviewDecisions(_ context.Context, prs []github.PRItem) (ma
 	return decisions, nil
 }
 
+func (s *Service) GetCIRollups(_ context.Context, prs []github.PRItem) (map[string]string, error) {
+	rollups := make(map[string]string)
+	for _, pr := range prs {
+		if ci, ok := s.ci[pr.Number]; ok && ci.OverallStatus != "" {
+			rollups[fmt.Sprintf("%s#%d", pr.Repo.FullName, pr.Number)] = ci.OverallStatus
+		}
+	}
+	return rollups, nil
+}
+
 // -- Configuration (no-op) --
 
 func (s *Service) SetFetchLimit(_ int) {}
 
+func (s *Service) GetRateLimit(_ context.Context) (*github.RateLimit, error) {
+	return &github.RateLimit{Limit: 5000, Remaining: 4987, Reset: time.Now().Add(time.Hour)}, nil
+}
+
 // -- Write operations (all blocked) --
 
 func (s *Service) ApprovePR(_ context.Context, _, _ string, _ int, _ string) error {
@@ -135,6 +247,42 @@ func (s *Service) RerunWorkflow(_ context.Context, _, _ string, _ int64, _ bool)
 	return ErrDemoMode
 }
 
+func (s *Service) RerunJob(_ context.Context, _, _ string, _ int64) error {
+	return ErrDemoMode
+}
+
+func (s *Service) UpdateBranch(_ context.Context, _, _ string, _ int, _ string) error {
+	return ErrDemoMode
+}
+
+func (s *Service) EnableAutoMerge(_ context.Context, _, _ string, _ int, _ string, _ *github.MergeCommit) error {
+	return ErrDemoMode
+}
+
+func (s *Service) DisableAutoMerge(_ context.Context, _, _ string, _ int) error {
+	return ErrDemoMode
+}
+
+func (s *Service) ReopenPR(_ context.Context, _, _ string, _ int) error {
+	return ErrDemoMode
+}
+
+func (s *Service) UpdatePRBody(_ context.Context, _, _ string, _ int, _ string) error {
+	return ErrDemoMode
+}
+
+func (s *Service) MarkReadyForReview(_ context.Context, _, _ string, _ int) error {
+	return ErrDemoMode
+}
+
+func (s *Service) ConvertToDraft(_ context.Context, _, _ string, _ int) error {
+	return ErrDemoMode
+}
+
 func (s *Service) ReplyToComment(_ context.Context, _, _ string, _ int, _ int64, _ string) error {
 	return ErrDemoMode
 }
+
+func (s *Service) ApplySuggestion(_ context.Context, _, _, _ string, _, _ int, _, _ []string, _ string) error {
+	return ErrDemoMode
+}
@@ -56,6 +56,26 @@ func TestGetMyPRs(t *testing.T) {
 	}
 }
 
+func TestGetPRLists(t *testing.T) {
+	s := NewService()
+	toReview, mine, err := s.GetPRLists(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(toReview) != len(s.toReview) || len(mine) != len(s.myPRs) {
+		t.Fatalf("lists = %d/%d, want %d/%d", len(toReview), len(mine), len(s.toReview), len(s.myPRs))
+	}
+	enriched := false
+	for _, pr := range append(toReview, mine...) {
+		if pr.CIStatus != "" || pr.ReviewDecision != "" {
+			enriched = true
+		}
+	}
+	if !enriched {
+		t.Error("expected review decisions or CI status on batched PRs")
+	}
+}
+
 func TestGetPRDetail_Found(t *testing.T) {
 	s := NewService()
 	detail, err := s.GetPRDetail(context.Background(), "acme", "gateway", 101)
@@ -149,6 +169,37 @@ func TestGetCIStatus_NotFound(t *testing.T) {
 	}
 }
 
+func TestGetJobLogs(t *testing.T) {
+	s := NewService()
+	logs, err := s.GetJobLogs(context.Background(), "acme", "dashboard", 9012)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if logs == "" {
+		t.Error("expected non-empty logs")
+	}
+
+	if _, err := s.GetJobLogs(context.Background(), "acme", "dashboard", 1); err == nil {
+		t.Error("expected error for unknown job")
+	}
+}
+
+func TestGetMergeRequirements(t *testing.T) {
+	s := NewService()
+	req, err := s.GetMergeRequirements(context.Background(), "acme", "platform", "main", 404)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if req.RequiredApprovals != 2 || !req.StrictUpToDate {
+		t.Errorf("unexpected requirements: %+v", req)
+	}
+
+	unknown, err := s.GetMergeRequirements(context.Background(), "acme", "x", "main", 999)
+	if err != nil || unknown.RequiredApprovals != -1 {
+		t.Errorf("expected unknown requirements for missing PR, got %+v, %v", unknown, err)
+	}
+}
+
 func TestGetReviews_Found(t *testing.T) {
 	s := NewService()
 	reviews, err := s.GetReviews(context.Background(), "acme", "gateway", 101)
@@ -189,12 +240,19 @@ func TestWriteOperationsReturnErrDemoMode(t *testing.T) {
 		{"ApprovePR", func() error { return s.ApprovePR(ctx, "o", "r", 1, "lgtm") }},
 		{"PostComment", func() error { return s.PostComment(ctx, "o", "r", 1, "comment") }},
 		{"ClosePR", func() error { return s.ClosePR(ctx, "o", "r", 1) }},
+		{"ReopenPR", func() error { return s.ReopenPR(ctx, "o", "r", 1) }},
 		{"RequestChangesPR", func() error { return s.RequestChangesPR(ctx, "o", "r", 1, "changes") }},
 		{"CommentReviewPR", func() error { return s.CommentReviewPR(ctx, "o", "r", 1, "note") }},
 		{"SubmitReviewWithComments", func() error {
 			return s.SubmitReviewWithComments(ctx, "o", "r", 1, "COMMENT", "body", []github.ReviewCommentPayload{})
 		}},
 		{"RerunWorkflow", func() error { return s.RerunWorkflow(ctx, "o", "r", 1, false) }},
+		{"RerunJob", func() error { return s.RerunJob(ctx, "o", "r", 1) }},
+		{"UpdateBranch", func() error { return s.UpdateBranch(ctx, "o", "r", 1, "") }},
+		{"EnableAutoMerge", func() error { return s.EnableAutoMerge(ctx, "o", "r", 1, "SQUASH", nil) }},
+		{"DisableAutoMerge", func() error { return s.DisableAutoMerge(ctx, "o", "r", 1) }},
+		{"MarkReadyForReview", func() error { return s.MarkReadyForReview(ctx, "o", "r", 1) }},
+		{"ConvertToDraft", func() error { return s.ConvertToDraft(ctx, "o", "r", 1) }},
 		{"ReplyToComment", func() error { return s.ReplyToComment(ctx, "o", "r", 1, 123, "reply") }},
 	}
 
@@ -39,6 +39,28 @@ func (c *Client) ClosePR(ctx context.Context, owner, repo string, number int) er
 	return nil
 }
 
+// ReopenPR reopens a closed, unmerged PR.
+func (c *Client) ReopenPR(ctx context.Context, owner, repo string, number int) error {
+	repoFlag := owner + "/" + repo
+	if _, err := c.ghExec(ctx, "pr", "reopen", fmt.Sprintf("%d", number), "-R", repoFlag); err != nil {
+		return fmt.Errorf("failed to reopen PR #%d: %w", number, err)
+	}
+	return nil
+}
+
+// UpdatePRBody replaces a PR's description.
+func (c *Client) UpdatePRBody(ctx context.Context, owner, repo string, number int, body string) error {
+	payload, err := json.Marshal(map[string]string{"body": body})
+	if err != nil {
+		return err
+	}
+	endpoint := fmt.Sprintf("repos/%s/%s/pulls/%d", owner, repo, number)
+	if _, err := c.ghExecWithStdin(ctx, string(payload), "api", endpoint, "--method", "PATCH", "--input", "-"); err != nil {
+		return fmt.Errorf("failed to update the description of PR #%d: %w", number, err)
+	}
+	return nil
+}
+
 // RequestChangesPR submits a "request changes" review on a PR.
 // The body is required by the GitHub API for this review type.
 func (c *Client) RequestChangesPR(ctx context.Context, owner, repo string, number int, body string) error {
@@ -74,6 +96,15 @@ func (c *Client) RerunWorkflow(ctx context.Context, owner, repo string, runID in
 	return nil
 }
 
+// RerunJob re-runs a single GitHub Actions job (and any jobs that depend on it).
+func (c *Client) RerunJob(ctx context.Context, owner, repo string, jobID int64) error {
+	repoFlag := owner + "/" + repo
+	if _, err := c.ghExec(ctx, "run", "rerun", "--job", fmt.Sprintf("%d", jobID), "-R", repoFlag); err != nil {
+		return fmt.Errorf("failed to re-run job %d: %w", jobID, err)
+	}
+	return nil
+}
+
 // ReviewCommentPayload is a single inline comment in a review submission.
 type ReviewCommentPayload struct {
 	Path      string `json:"path"`
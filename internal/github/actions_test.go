@@ -199,6 +199,33 @@ func TestRerunWorkflow_FailedOnly(t *testing.T) {
 	}
 }
 
+func TestRerunJob(t *testing.T) {
+	var got string
+	client := NewTestClient("alice", func(ctx context.Context, args ...string) (string, error) {
+		got = strings.Join(args, " ")
+		return "", nil
+	})
+
+	if err := client.RerunJob(context.Background(), "alice", "widget", 678); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := "run rerun --job 678 -R alice/widget"; got != want {
+		t.Errorf("args = %q, want %q", got, want)
+	}
+}
+
+func TestRerunJob_Error(t *testing.T) {
+	client := NewTestClient("alice", fakeErrorRunner("job is not re-runnable"))
+
+	err := client.RerunJob(context.Background(), "alice", "widget", 678)
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	if !strings.Contains(err.Error(), "failed to re-run job 678") {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
 func TestRequestChangesPR(t *testing.T) {
 	client := NewTestClient("alice", fakeRunner(map[string]string{
 		"pr review": "",
@@ -225,3 +252,26 @@ func TestCommentReviewPR(t *testing.T) {
 func errorf(format string, args ...interface{}) error {
 	return fmt.Errorf(format, args...)
 }
+
+func TestUpdatePRBody(t *testing.T) {
+	var capturedStdin string
+	var capturedArgs string
+	client := &Client{
+		username: "alice",
+		run:      fakeRunner(map[string]string{}),
+		runStdin: func(ctx context.Context, stdin string, args ...string) (string, error) {
+			capturedStdin, capturedArgs = stdin, strings.Join(args, " ")
+			return "", nil
+		},
+	}
+	if err := client.UpdatePRBody(context.Background(), "alice", "widget", 42, "- [x] done"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if capturedArgs != "api repos/alice/widget/pulls/42 --method PATCH --input -" {
+		t.Errorf("args = %q", capturedArgs)
+	}
+	var payload map[string]string
+	if err := json.Unmarshal([]byte(capturedStdin), &payload); err != nil || payload["body"] != "- [x] done" {
+		t.Errorf("payload = %q (%v)", capturedStdin, err)
+	}
+}
@@ -45,6 +45,7 @@ func (c *Client) GetCIStatus(ctx context.Context, owner, repo string, ref string
 			Conclusion:    normalizeConclusionStr(cr.Conclusion),
 			HTMLURL:       cr.DetailsURL,
 			WorkflowRunID: parseWorkflowRunID(cr.DetailsURL),
+			JobID:         parseJobID(cr.DetailsURL),
 		})
 	}
 
@@ -57,6 +58,57 @@ func (c *Client) GetCIStatus(ctx context.Context, owner, repo string, ref string
 	}, nil
 }
 
+// ghPRChecksListItem is the JSON shape for batch CI fetching via gh pr list.
+type ghPRChecksListItem struct {
+	Number            int          `json:"number"`
+	StatusCheckRollup []ghCheckRun `json:"statusCheckRollup"`
+}
+
+// GetCIRollups fetches the overall CI status for a batch of the user's own
+// PRs, keyed by "owner/repo#number". PRs without any checks are omitted.
+// Like GetReviewDecisions, repos that fail to load are skipped.
+func (c *Client) GetCIRollups(ctx context.Context, prs []PRItem) (map[string]string, error) {
+	byRepo := make(map[string][]int) // key: "owner/repo"
+	for _, pr := range prs {
+		byRepo[pr.Repo.FullName] = append(byRepo[pr.Repo.FullName], pr.Number)
+	}
+
+	rollups := make(map[string]string)
+	for repoFull, numbers := range byRepo {
+		var items []ghPRChecksListItem
+		err := c.ghJSON(ctx, &items,
+			"pr", "list",
+			"-R", repoFull,
+			"--author=@me",
+			"--state=open",
+			"--limit", fmt.Sprintf("%d", len(numbers)),
+			"--json", "number,statusCheckRollup",
+		)
+		if err != nil {
+			continue // best-effort: skip repos that fail
+		}
+
+		wanted := make(map[int]bool, len(numbers))
+		for _, n := range numbers {
+			wanted[n] = true
+		}
+		for _, item := range items {
+			if !wanted[item.Number] || len(item.StatusCheckRollup) == 0 {
+				continue
+			}
+			checks := make([]CICheck, 0, len(item.StatusCheckRollup))
+			for _, cr := range item.StatusCheckRollup {
+				checks = append(checks, CICheck{
+					Status:     normalizeStatus(cr.Status),
+					Conclusion: normalizeConclusionStr(cr.Conclusion),
+				})
+			}
+			rollups[fmt.Sprintf("%s#%d", repoFull, item.Number)] = computeOverallStatus(checks)
+		}
+	}
+	return rollups, nil
+}
+
 // normalizeStatus converts gh CLI status values to our lowercase convention.
 func normalizeStatus(s string) string {
 	switch strings.ToUpper(s) {
@@ -93,6 +145,65 @@ func parseWorkflowRunID(url string) int64 {
 	return id
 }
 
+// actionsJobIDRe matches GitHub Actions job URLs like /actions/runs/12345/job/67890
+var actionsJobIDRe = regexp.MustCompile(`/actions/runs/\d+/job/(\d+)`)
+
+// parseJobID extracts the GitHub Actions job ID from a detailsUrl.
+// Returns 0 if the URL doesn't point at a specific Actions job.
+func parseJobID(url string) int64 {
+	m := actionsJobIDRe.FindStringSubmatch(url)
+	if len(m) < 2 {
+		return 0
+	}
+	id, err := strconv.ParseInt(m[1], 10, 64)
+	if err != nil {
+		return 0
+	}
+	return id
+}
+
+// GetJobLogs downloads the plain-text log for a GitHub Actions job.
+func (c *Client) GetJobLogs(ctx context.Context, owner, repo string, jobID int64) (string, error) {
+	endpoint := fmt.Sprintf("repos/%s/%s/actions/jobs/%d/logs", owner, repo, jobID)
+	out, err := c.ghExec(ctx, "api", endpoint)
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch logs for job %d: %w", jobID, err)
+	}
+	return out, nil
+}
+
+// ghCheckAnnotation is the JSON shape from the check run annotations API.
+type ghCheckAnnotation struct {
+	Path            string `json:"path"`
+	StartLine       int    `json:"start_line"`
+	EndLine         int    `json:"end_line"`
+	AnnotationLevel string `json:"annotation_level"`
+	Title           string `json:"title"`
+	Message         string `json:"message"`
+}
+
+// GetCheckAnnotations lists the annotations of a check run. For GitHub
+// Actions checks the check run ID is the job ID.
+func (c *Client) GetCheckAnnotations(ctx context.Context, owner, repo string, checkRunID int64) ([]CheckAnnotation, error) {
+	var raw []ghCheckAnnotation
+	endpoint := fmt.Sprintf("repos/%s/%s/check-runs/%d/annotations", owner, repo, checkRunID)
+	if err := c.ghAPIJSON(ctx, &raw, endpoint, true); err != nil {
+		return nil, fmt.Errorf("failed to list annotations for check run %d: %w", checkRunID, err)
+	}
+	annotations := make([]CheckAnnotation, 0, len(raw))
+	for _, a := range raw {
+		annotations = append(annotations, CheckAnnotation{
+			Path:      a.Path,
+			StartLine: a.StartLine,
+			EndLine:   a.EndLine,
+			Level:     a.AnnotationLevel,
+			Title:     a.Title,
+			Message:   a.Message,
+		})
+	}
+	return annotations, nil
+}
+
 // FailedRunIDs returns deduplicated workflow run IDs for failed checks.
 // Only checks backed by GitHub Actions (WorkflowRunID > 0) are included.
 func (s *CIStatus) FailedRunIDs() []int64 {
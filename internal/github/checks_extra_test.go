@@ -25,6 +25,27 @@ func TestParseWorkflowRunID(t *testing.T) {
 	}
 }
 
+func TestParseJobID(t *testing.T) {
+	tests := []struct {
+		name string
+		url  string
+		want int64
+	}{
+		{"URL with job", "https://github.com/owner/repo/actions/runs/99999/job/67890", 67890},
+		{"run only", "https://github.com/owner/repo/actions/runs/12345", 0},
+		{"external CI", "https://circleci.com/gh/owner/repo/123", 0},
+		{"empty string", "", 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := parseJobID(tt.url)
+			if got != tt.want {
+				t.Errorf("parseJobID(%q) = %d, want %d", tt.url, got, tt.want)
+			}
+		})
+	}
+}
+
 func TestFailedRunIDs(t *testing.T) {
 	t.Run("nil receiver", func(t *testing.T) {
 		var s *CIStatus
@@ -26,14 +26,15 @@ type Client struct {
 	username   string
 	run        CommandRunner
 	runStdin   StdinCommandRunner
-	Timeout    time.Duration // deadline for gh CLI commands (0 uses DefaultTimeout)
-	FetchLimit int           // max PRs per query (0 uses default 100)
+	Timeout    time.Duration  // deadline for gh CLI commands (0 uses DefaultTimeout)
+	FetchLimit int            // max PRs per query (0 uses default 100)
+	cache      *ResponseCache // optional ETag cache for REST GETs
 }
 
 // NewClient verifies the gh CLI is installed and authenticated, then caches the current user.
 func NewClient() (*Client, error) {
 	if _, err := exec.LookPath("gh"); err != nil {
-		return nil, fmt.Errorf("gh CLI not found: install from https://cli.github.com")
+		return nil, fmt.Errorf("gh CLI not found: install from https://cli.github.com, set GITHUB_TOKEN, or run 'prtea auth login'")
 	}
 
 	c := &Client{
@@ -129,6 +129,68 @@ func TestGetCIStatus(t *testing.T) {
 	}
 }
 
+func TestGetCIRollups(t *testing.T) {
+	items := []ghPRChecksListItem{
+		{Number: 1, StatusCheckRollup: []ghCheckRun{{Status: "COMPLETED", Conclusion: "SUCCESS"}}},
+		{Number: 2, StatusCheckRollup: []ghCheckRun{{Status: "COMPLETED", Conclusion: "FAILURE"}}},
+		{Number: 3}, // no checks configured
+		{Number: 9, StatusCheckRollup: []ghCheckRun{{Status: "QUEUED"}}}, // not requested
+	}
+	data, _ := json.Marshal(items)
+
+	client := NewTestClient("alice", fakeRunner(map[string]string{
+		"pr list -R alice/widget-factory --author=@me": string(data),
+	}))
+
+	prs := []PRItem{
+		{Number: 1, Repo: Repo{FullName: "alice/widget-factory"}},
+		{Number: 2, Repo: Repo{FullName: "alice/widget-factory"}},
+		{Number: 3, Repo: Repo{FullName: "alice/widget-factory"}},
+	}
+	rollups, err := client.GetCIRollups(context.Background(), prs)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := map[string]string{
+		"alice/widget-factory#1": "passing",
+		"alice/widget-factory#2": "failing",
+	}
+	if len(rollups) != len(want) {
+		t.Fatalf("rollups = %v, want %v", rollups, want)
+	}
+	for k, v := range want {
+		if rollups[k] != v {
+			t.Errorf("rollups[%s] = %q, want %q", k, rollups[k], v)
+		}
+	}
+}
+
+func TestGetJobLogs(t *testing.T) {
+	client := NewTestClient("alice", fakeRunner(map[string]string{
+		"api repos/alice/widget-factory/actions/jobs/678/logs": "step 1\nerror: boom\n",
+	}))
+
+	logs, err := client.GetJobLogs(context.Background(), "alice", "widget-factory", 678)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if logs != "step 1\nerror: boom\n" {
+		t.Errorf("logs = %q", logs)
+	}
+}
+
+func TestGetJobLogs_Error(t *testing.T) {
+	client := NewTestClient("alice", fakeErrorRunner("not found"))
+
+	_, err := client.GetJobLogs(context.Background(), "alice", "widget-factory", 678)
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	if !strings.Contains(err.Error(), "job 678") {
+		t.Errorf("error = %q, want job ID in message", err)
+	}
+}
+
 func TestGetReviews(t *testing.T) {
 	reviews := ghPRReviews{
 		LatestReviews: []ghReview{
@@ -196,6 +258,42 @@ func TestClosePR(t *testing.T) {
 	}
 }
 
+func TestReopenPR(t *testing.T) {
+	var got string
+	client := NewTestClient("alice", func(ctx context.Context, args ...string) (string, error) {
+		got = strings.Join(args, " ")
+		return "", nil
+	})
+
+	if err := client.ReopenPR(context.Background(), "bob", "test-project", 7); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got != "pr reopen 7 -R bob/test-project" {
+		t.Errorf("command = %q", got)
+	}
+}
+
+func TestGetRecentlyClosedPRs(t *testing.T) {
+	var got string
+	client := NewTestClient("alice", func(ctx context.Context, args ...string) (string, error) {
+		got = strings.Join(args, " ")
+		return `[{"number":9,"title":"Old idea","repository":{"name":"widget","nameWithOwner":"alice/widget"}}]`, nil
+	})
+
+	items, err := client.GetRecentlyClosedPRs(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(items) != 1 || items[0].Number != 9 || items[0].Repo.Owner != "alice" {
+		t.Errorf("items = %+v", items)
+	}
+	for _, want := range []string{"is:unmerged", "--author=@me", "--state=closed", "--sort=updated"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("command %q missing %q", got, want)
+		}
+	}
+}
+
 func TestPostComment(t *testing.T) {
 	client := NewTestClient("alice", fakeRunner(map[string]string{
 		"pr comment": "",
@@ -239,3 +337,32 @@ func TestGetComments(t *testing.T) {
 		t.Errorf("Body = %q", result[0].Body)
 	}
 }
+
+func TestGetRateLimit(t *testing.T) {
+	client := NewTestClient("alice", fakeRunner(map[string]string{
+		"api rate_limit": `{"resources":{"core":{"limit":5000,"remaining":4321,"reset":1700000000}}}`,
+	}))
+
+	rl, err := client.GetRateLimit(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if rl.Limit != 5000 || rl.Remaining != 4321 || rl.Reset.Unix() != 1700000000 {
+		t.Errorf("rate limit = %+v", rl)
+	}
+}
+
+func TestGetCheckAnnotations(t *testing.T) {
+	client := NewTestClient("alice", fakeRunner(map[string]string{
+		"check-runs/77/annotations": `[{"path":"main.go","start_line":12,"end_line":12,"annotation_level":"failure","title":"vet","message":"unused variable x"}]`,
+	}))
+
+	got, err := client.GetCheckAnnotations(context.Background(), "alice", "widget", 77)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := CheckAnnotation{Path: "main.go", StartLine: 12, EndLine: 12, Level: "failure", Title: "vet", Message: "unused variable x"}
+	if len(got) != 1 || got[0] != want {
+		t.Errorf("annotations = %+v, want [%+v]", got, want)
+	}
+}
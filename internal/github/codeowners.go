@@ -0,0 +1,127 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// CodeOwnerRule is one line of a CODEOWNERS file: a path pattern and the
+// users (@login), teams (@org/slug) or emails that own matching paths.
+type CodeOwnerRule struct {
+	Pattern string
+	Owners  []string
+	re      *regexp.Regexp
+}
+
+// codeOwnersPaths are the locations GitHub reads CODEOWNERS from, in order.
+var codeOwnersPaths = []string{".github/CODEOWNERS", "CODEOWNERS", "docs/CODEOWNERS"}
+
+// ParseCodeOwners parses CODEOWNERS content. Comments, blank lines and
+// patterns that cannot be compiled are skipped.
+func ParseCodeOwners(content string) []CodeOwnerRule {
+	var rules []CodeOwnerRule
+	for _, line := range strings.Split(content, "\n") {
+		if i := strings.Index(line, "#"); i >= 0 {
+			line = line[:i]
+		}
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		re, err := codeOwnersPattern(fields[0])
+		if err != nil {
+			continue
+		}
+		rules = append(rules, CodeOwnerRule{Pattern: fields[0], Owners: fields[1:], re: re})
+	}
+	return rules
+}
+
+// codeOwnersPattern compiles a gitignore-style CODEOWNERS pattern. Patterns
+// without a slash (other than a trailing one) match at any depth; a trailing
+// slash or a directory name matches everything beneath it.
+func codeOwnersPattern(pattern string) (*regexp.Regexp, error) {
+	anchored := strings.HasPrefix(pattern, "/") || strings.Contains(strings.TrimSuffix(pattern, "/"), "/")
+	p := strings.TrimPrefix(pattern, "/")
+	p = strings.TrimSuffix(p, "/")
+	if p == "*" {
+		return regexp.Compile(`.*`)
+	}
+
+	var b strings.Builder
+	b.WriteString("^")
+	if !anchored {
+		b.WriteString("(?:.*/)?")
+	}
+	for i := 0; i < len(p); i++ {
+		switch {
+		case strings.HasPrefix(p[i:], "**/"):
+			b.WriteString("(?:.*/)?")
+			i += 2
+		case strings.HasPrefix(p[i:], "**"):
+			b.WriteString(".*")
+			i++
+		case p[i] == '*':
+			b.WriteString("[^/]*")
+		case p[i] == '?':
+			b.WriteString("[^/]")
+		default:
+			b.WriteString(regexp.QuoteMeta(p[i : i+1]))
+		}
+	}
+	b.WriteString("(?:/.*)?$")
+	return regexp.Compile(b.String())
+}
+
+// OwnersFor returns the owners of path. As on GitHub, the last matching
+// rule wins; a matching rule with no owners leaves the path unowned.
+func OwnersFor(rules []CodeOwnerRule, path string) []string {
+	for i := len(rules) - 1; i >= 0; i-- {
+		if rules[i].re != nil && rules[i].re.MatchString(path) {
+			return rules[i].Owners
+		}
+	}
+	return nil
+}
+
+// GetCodeOwners fetches and parses the repository's CODEOWNERS file at ref.
+// Returns nil rules when the repository has no CODEOWNERS file.
+func (c *Client) GetCodeOwners(ctx context.Context, owner, repo, ref string) ([]CodeOwnerRule, error) {
+	for _, path := range codeOwnersPaths {
+		endpoint := fmt.Sprintf("repos/%s/%s/contents/%s?ref=%s", owner, repo, path, ref)
+		out, err := c.ghExec(ctx, "api", endpoint, "-H", "Accept: application/vnd.github.raw")
+		if err != nil {
+			if strings.Contains(err.Error(), "404") || strings.Contains(err.Error(), "Not Found") {
+				continue
+			}
+			return nil, fmt.Errorf("failed to fetch %s: %w", path, err)
+		}
+		return ParseCodeOwners(out), nil
+	}
+	return nil, nil
+}
+
+// ghTeam is the JSON shape from the user teams API.
+type ghTeam struct {
+	Slug         string `json:"slug"`
+	Organization struct {
+		Login string `json:"login"`
+	} `json:"organization"`
+}
+
+// GetMyTeams returns the authenticated user's teams as "@org/slug". Listing
+// teams needs the read:org scope; without it the error is returned and
+// callers should treat team ownership as unknown.
+func (c *Client) GetMyTeams(ctx context.Context) ([]string, error) {
+	var list []ghTeam
+	if err := c.ghJSON(ctx, &list, "api", "user/teams", "--paginate"); err != nil {
+		return nil, fmt.Errorf("failed to list teams: %w", err)
+	}
+	teams := make([]string, 0, len(list))
+	for _, t := range list {
+		teams = append(teams, "@"+t.Organization.Login+"/"+t.Slug)
+	}
+	return teams, nil
+}
@@ -0,0 +1,82 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestOwnersFor(t *testing.T) {
+	rules := ParseCodeOwners(`# default owners
+*                   @acme/core
+*.md                @docs-writer
+/build/             @acme/infra
+docs/**/*.png       @designer
+apps/web            @acme/frontend
+vendor/             # unowned
+`)
+
+	tests := []struct {
+		path string
+		want string
+	}{
+		{"main.go", "@acme/core"},
+		{"pkg/README.md", "@docs-writer"},
+		{"build/ci/deploy.sh", "@acme/infra"},
+		{"src/build/x.go", "@acme/core"}, // anchored to the root
+		{"docs/img/a/logo.png", "@designer"},
+		{"apps/web/index.ts", "@acme/frontend"},
+		{"vendor/lib.go", ""},
+		{"third_party/vendor/lib.go", ""}, // unanchored directory matches at any depth
+	}
+	for _, tt := range tests {
+		got := strings.Join(OwnersFor(rules, tt.path), " ")
+		if got != tt.want {
+			t.Errorf("OwnersFor(%q) = %q, want %q", tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestGetCodeOwners_FallsBackThroughLocations(t *testing.T) {
+	var tried []string
+	client := NewTestClient("alice", func(_ context.Context, args ...string) (string, error) {
+		tried = append(tried, args[1])
+		if strings.Contains(args[1], "contents/CODEOWNERS") {
+			return "* @alice\n", nil
+		}
+		return "", fmt.Errorf("gh: Not Found (HTTP 404)")
+	})
+
+	rules, err := client.GetCodeOwners(context.Background(), "acme", "widget", "main")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := OwnersFor(rules, "x.go"); len(got) != 1 || got[0] != "@alice" {
+		t.Errorf("owners = %v", got)
+	}
+	if len(tried) != 2 || !strings.Contains(tried[0], ".github/CODEOWNERS?ref=main") {
+		t.Errorf("tried = %v, want .github/CODEOWNERS then CODEOWNERS", tried)
+	}
+}
+
+func TestGetCodeOwners_NoFile(t *testing.T) {
+	client := NewTestClient("alice", fakeErrorRunner("HTTP 404: Not Found"))
+	rules, err := client.GetCodeOwners(context.Background(), "acme", "widget", "main")
+	if err != nil || rules != nil {
+		t.Errorf("got %v, %v; want nil rules and no error", rules, err)
+	}
+}
+
+func TestGetMyTeams(t *testing.T) {
+	client := NewTestClient("alice", fakeRunner(map[string]string{
+		"user/teams": `[{"slug":"core","organization":{"login":"acme"}},{"slug":"infra","organization":{"login":"acme"}}]`,
+	}))
+	teams, err := client.GetMyTeams(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(teams) != 2 || teams[1] != "@acme/infra" {
+		t.Errorf("teams = %v", teams)
+	}
+}
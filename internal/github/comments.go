@@ -39,6 +39,24 @@ type ghInlineComment struct {
 	Side        string    `json:"side"`
 	InReplyToID *int64    `json:"in_reply_to_id"`
 	Position    *int      `json:"position"`
+	DiffHunk    string    `json:"diff_hunk"`
+	Reactions   map[string]any `json:"reactions"`
+}
+
+// reactionContents are the GitHub reaction types, in the order GitHub
+// shows them.
+var reactionContents = []string{"+1", "-1", "laugh", "hooray", "confused", "heart", "rocket", "eyes"}
+
+// reactionCounts picks the non-zero counts out of a REST reactions rollup,
+// which also holds its url and total_count.
+func reactionCounts(rollup map[string]any) []Reaction {
+	var reactions []Reaction
+	for _, content := range reactionContents {
+		if n, ok := rollup[content].(float64); ok && n > 0 {
+			reactions = append(reactions, Reaction{Content: content, Count: int(n)})
+		}
+	}
+	return reactions
 }
 
 // GetComments fetches issue-level comments on a PR (general conversation).
@@ -75,7 +93,7 @@ func (c *Client) GetComments(ctx context.Context, owner, repo string, number int
 func (c *Client) GetInlineComments(ctx context.Context, owner, repo string, number int) ([]InlineComment, error) {
 	var raw []ghInlineComment
 	endpoint := fmt.Sprintf("repos/%s/%s/pulls/%d/comments", owner, repo, number)
-	if err := c.ghJSON(ctx, &raw, "api", endpoint, "--paginate"); err != nil {
+	if err := c.ghAPIJSON(ctx, &raw, endpoint, true); err != nil {
 		return nil, fmt.Errorf("failed to list inline comments for PR #%d: %w", number, err)
 	}
 
@@ -109,6 +127,8 @@ func (c *Client) GetInlineComments(ctx context.Context, owner, repo string, numb
 			Side:        c.Side,
 			InReplyToID: inReplyToID,
 			Outdated:    outdated,
+			DiffHunk:    c.DiffHunk,
+			Reactions:   reactionCounts(c.Reactions),
 		})
 	}
 
@@ -0,0 +1,243 @@
+package github
+
+import (
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"path"
+	"strconv"
+	"strings"
+)
+
+// Coverage maps a source file path, as written in the coverage report, to
+// the hit count of each instrumented line.
+type Coverage map[string]map[int]int
+
+// CoverageReport is the line coverage found in a CI run's artifact.
+type CoverageReport struct {
+	Artifact string // name of the artifact the report came from
+	Lines    Coverage
+}
+
+// ForFile returns the line hits for a repository-relative path. Reports
+// often record absolute paths or Go import paths, so a report path that
+// ends with "/"+name also matches.
+func (c Coverage) ForFile(name string) map[int]int {
+	if lines, ok := c[name]; ok {
+		return lines
+	}
+	for p, lines := range c {
+		if strings.HasSuffix(p, "/"+name) {
+			return lines
+		}
+	}
+	return nil
+}
+
+// add records hits for a line, keeping the highest count seen.
+func (c Coverage) add(file string, line, hits int) {
+	lines, ok := c[file]
+	if !ok {
+		lines = make(map[int]int)
+		c[file] = lines
+	}
+	if prev, seen := lines[line]; !seen || hits > prev {
+		lines[line] = hits
+	}
+}
+
+// ParseCoverage parses an lcov tracefile, a Cobertura XML report or a Go
+// cover profile into dst, detecting the format from the content.
+func ParseCoverage(dst Coverage, data []byte) error {
+	trimmed := bytes.TrimSpace(data)
+	switch {
+	case bytes.HasPrefix(trimmed, []byte("<")):
+		return parseCobertura(dst, trimmed)
+	case bytes.HasPrefix(trimmed, []byte("mode:")):
+		return parseGoCoverProfile(dst, trimmed)
+	default:
+		return parseLcov(dst, trimmed)
+	}
+}
+
+// parseLcov reads the SF (source file) and DA (line hits) records of an
+// lcov tracefile.
+func parseLcov(dst Coverage, data []byte) error {
+	file := ""
+	found := false
+	sc := bufio.NewScanner(bytes.NewReader(data))
+	sc.Buffer(make([]byte, 64*1024), 1024*1024)
+	for sc.Scan() {
+		line := strings.TrimSpace(sc.Text())
+		switch {
+		case strings.HasPrefix(line, "SF:"):
+			file = strings.TrimPrefix(line, "SF:")
+		case strings.HasPrefix(line, "DA:") && file != "":
+			parts := strings.Split(strings.TrimPrefix(line, "DA:"), ",")
+			if len(parts) < 2 {
+				continue
+			}
+			n, err1 := strconv.Atoi(parts[0])
+			hits, err2 := strconv.Atoi(parts[1])
+			if err1 != nil || err2 != nil {
+				continue
+			}
+			dst.add(file, n, hits)
+			found = true
+		case line == "end_of_record":
+			file = ""
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return err
+	}
+	if !found {
+		return fmt.Errorf("not an lcov report")
+	}
+	return nil
+}
+
+// coberturaReport is the subset of a Cobertura XML report holding line hits.
+type coberturaReport struct {
+	Packages []struct {
+		Classes []struct {
+			Filename string `xml:"filename,attr"`
+			Lines    []struct {
+				Number int `xml:"number,attr"`
+				Hits   int `xml:"hits,attr"`
+			} `xml:"lines>line"`
+		} `xml:"classes>class"`
+	} `xml:"packages>package"`
+}
+
+func parseCobertura(dst Coverage, data []byte) error {
+	var report coberturaReport
+	if err := xml.Unmarshal(data, &report); err != nil {
+		return fmt.Errorf("failed to parse Cobertura report: %w", err)
+	}
+	for _, pkg := range report.Packages {
+		for _, class := range pkg.Classes {
+			for _, l := range class.Lines {
+				dst.add(class.Filename, l.Number, l.Hits)
+			}
+		}
+	}
+	return nil
+}
+
+// parseGoCoverProfile reads the blocks of a `go test -coverprofile` file,
+// "file.go:startLine.col,endLine.col statements count", marking every line
+// of a block with its count.
+func parseGoCoverProfile(dst Coverage, data []byte) error {
+	sc := bufio.NewScanner(bytes.NewReader(data))
+	for sc.Scan() {
+		line := strings.TrimSpace(sc.Text())
+		if line == "" || strings.HasPrefix(line, "mode:") {
+			continue
+		}
+		colon := strings.LastIndex(line, ":")
+		if colon < 0 {
+			continue
+		}
+		file, rest := line[:colon], line[colon+1:]
+		var startLine, startCol, endLine, endCol, stmts, count int
+		if _, err := fmt.Sscanf(rest, "%d.%d,%d.%d %d %d", &startLine, &startCol, &endLine, &endCol, &stmts, &count); err != nil {
+			continue
+		}
+		for n := startLine; n <= endLine; n++ {
+			dst.add(file, n, count)
+		}
+	}
+	return sc.Err()
+}
+
+// coverageFile reports whether an archive entry looks like a coverage report.
+func coverageFile(name string) bool {
+	base := strings.ToLower(path.Base(name))
+	switch path.Ext(base) {
+	case ".info", ".lcov", ".out", ".xml":
+		return strings.Contains(base, "cov") || strings.Contains(base, "lcov") || path.Ext(base) == ".info"
+	}
+	return false
+}
+
+// ParseCoverageArchive parses every coverage report in an artifact zip.
+func ParseCoverageArchive(data []byte) (Coverage, error) {
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to open artifact: %w", err)
+	}
+	cov := make(Coverage)
+	for _, f := range zr.File {
+		if f.FileInfo().IsDir() || !coverageFile(f.Name) {
+			continue
+		}
+		rc, err := f.Open()
+		if err != nil {
+			continue
+		}
+		content, err := io.ReadAll(rc)
+		rc.Close()
+		if err != nil {
+			continue
+		}
+		// Files that are not in a known format are skipped.
+		_ = ParseCoverage(cov, content)
+	}
+	if len(cov) == 0 {
+		return nil, fmt.Errorf("no lcov, Cobertura or Go coverage report in the artifact")
+	}
+	return cov, nil
+}
+
+// ghArtifacts is the JSON shape from the workflow run artifacts API.
+type ghArtifacts struct {
+	Artifacts []struct {
+		ID      int64  `json:"id"`
+		Name    string `json:"name"`
+		Expired bool   `json:"expired"`
+	} `json:"artifacts"`
+}
+
+// GetCoverage finds the first artifact of the given workflow runs whose name
+// matches one of patterns (path.Match globs such as "coverage*"), downloads
+// it and parses the coverage reports inside. It returns nil when no run has
+// a matching artifact.
+func (c *Client) GetCoverage(ctx context.Context, owner, repo string, runIDs []int64, patterns []string) (*CoverageReport, error) {
+	for _, runID := range runIDs {
+		var list ghArtifacts
+		endpoint := fmt.Sprintf("repos/%s/%s/actions/runs/%d/artifacts?per_page=100", owner, repo, runID)
+		if err := c.ghAPIJSON(ctx, &list, endpoint, false); err != nil {
+			return nil, fmt.Errorf("failed to list artifacts of run %d: %w", runID, err)
+		}
+		for _, a := range list.Artifacts {
+			if a.Expired || !matchesAny(a.Name, patterns) {
+				continue
+			}
+			out, err := c.ghExec(ctx, "api", fmt.Sprintf("repos/%s/%s/actions/artifacts/%d/zip", owner, repo, a.ID))
+			if err != nil {
+				return nil, fmt.Errorf("failed to download artifact %s: %w", a.Name, err)
+			}
+			cov, err := ParseCoverageArchive([]byte(out))
+			if err != nil {
+				return nil, fmt.Errorf("artifact %s: %w", a.Name, err)
+			}
+			return &CoverageReport{Artifact: a.Name, Lines: cov}, nil
+		}
+	}
+	return nil, nil
+}
+
+// matchesAny reports whether name matches any of the glob patterns.
+func matchesAny(name string, patterns []string) bool {
+	for _, p := range patterns {
+		if ok, _ := path.Match(p, name); ok {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,106 @@
+package github
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"testing"
+)
+
+func TestParseCoverage(t *testing.T) {
+	tests := []struct {
+		name   string
+		report string
+		file   string
+		want   map[int]int
+	}{
+		{
+			name:   "lcov",
+			report: "TN:\nSF:/home/runner/work/prtea/prtea/internal/ui/app.go\nDA:10,3\nDA:11,0\nend_of_record\n",
+			file:   "internal/ui/app.go",
+			want:   map[int]int{10: 3, 11: 0},
+		},
+		{
+			name: "cobertura",
+			report: `<?xml version="1.0"?><coverage><packages><package name="ui"><classes>
+				<class filename="internal/ui/app.go"><lines><line number="10" hits="1"/><line number="12" hits="0"/></lines></class>
+				</classes></package></packages></coverage>`,
+			file: "internal/ui/app.go",
+			want: map[int]int{10: 1, 12: 0},
+		},
+		{
+			name:   "go cover profile",
+			report: "mode: set\ngithub.com/shhac/prtea/internal/ui/app.go:10.2,12.5 3 1\ngithub.com/shhac/prtea/internal/ui/app.go:12.5,13.3 1 0\n",
+			file:   "internal/ui/app.go",
+			want:   map[int]int{10: 1, 11: 1, 12: 1, 13: 0},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cov := make(Coverage)
+			if err := ParseCoverage(cov, []byte(tt.report)); err != nil {
+				t.Fatalf("ParseCoverage: %v", err)
+			}
+			got := cov.ForFile(tt.file)
+			if len(got) != len(tt.want) {
+				t.Fatalf("lines = %v, want %v", got, tt.want)
+			}
+			for line, hits := range tt.want {
+				if got[line] != hits {
+					t.Errorf("line %d hits = %d, want %d", line, got[line], hits)
+				}
+			}
+		})
+	}
+	if err := ParseCoverage(make(Coverage), []byte("hello")); err == nil {
+		t.Error("expected an error for an unknown format")
+	}
+}
+
+func coverageZip(t *testing.T, files map[string]string) string {
+	t.Helper()
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for name, content := range files {
+		w, err := zw.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		w.Write([]byte(content))
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.String()
+}
+
+func TestGetCoverage(t *testing.T) {
+	archive := coverageZip(t, map[string]string{
+		"README.txt":    "not coverage",
+		"lcov.info":     "SF:src/app.ts\nDA:1,1\nDA:2,0\nend_of_record\n",
+		"sub/cover.out": "mode: count\nexample.com/m/main.go:3.1,4.2 1 2\n",
+	})
+	client := NewTestClient("alice", fakeRunner(map[string]string{
+		"actions/runs/1/artifacts": `{"artifacts": [{"id": 5, "name": "build"}]}`,
+		"actions/runs/2/artifacts": `{"artifacts": [{"id": 6, "name": "coverage-old", "expired": true}, {"id": 7, "name": "coverage-report"}]}`,
+		"actions/artifacts/7/zip":  archive,
+	}))
+	report, err := client.GetCoverage(context.Background(), "shhac", "prtea", []int64{1, 2}, []string{"coverage*"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if report == nil || report.Artifact != "coverage-report" {
+		t.Fatalf("report = %+v, want the unexpired coverage-report artifact", report)
+	}
+	if lines := report.Lines.ForFile("src/app.ts"); lines[1] != 1 || lines[2] != 0 {
+		t.Errorf("app.ts = %v", lines)
+	}
+	if lines := report.Lines.ForFile("main.go"); lines[3] != 2 || lines[4] != 2 {
+		t.Errorf("main.go = %v", lines)
+	}
+
+	report, err = client.GetCoverage(context.Background(), "shhac", "prtea", []int64{1}, []string{"coverage*"})
+	if err != nil || report != nil {
+		t.Errorf("without a matching artifact got %+v, %v; want nil, nil", report, err)
+	}
+}
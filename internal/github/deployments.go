@@ -0,0 +1,72 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+)
+
+// Deployment is the latest deployment of a commit to one environment, such
+// as a preview environment, with its most recent status.
+type Deployment struct {
+	ID          int64
+	Environment string
+	State       string // latest status: success, failure, error, in_progress, queued, pending, inactive
+	URL         string // environment URL (e.g. the preview site), if reported
+	LogURL      string // deployment log URL, if reported
+	Creator     string
+}
+
+// ghDeployment is the JSON shape from the deployments API.
+type ghDeployment struct {
+	ID          int64  `json:"id"`
+	Environment string `json:"environment"`
+	Creator     struct {
+		Login string `json:"login"`
+	} `json:"creator"`
+}
+
+// ghDeploymentStatus is the JSON shape from the deployment statuses API.
+type ghDeploymentStatus struct {
+	State          string `json:"state"`
+	EnvironmentURL string `json:"environment_url"`
+	LogURL         string `json:"log_url"`
+}
+
+// maxDeployments caps how many deployments of a commit are listed, since
+// each needs a further request for its status.
+const maxDeployments = 10
+
+// GetDeployments returns the latest deployment of a commit to each
+// environment, newest first, with its current state and URL.
+func (c *Client) GetDeployments(ctx context.Context, owner, repo, sha string) ([]Deployment, error) {
+	var raw []ghDeployment
+	endpoint := fmt.Sprintf("repos/%s/%s/deployments?sha=%s&per_page=30", owner, repo, url.QueryEscape(sha))
+	if err := c.ghAPIJSON(ctx, &raw, endpoint, false); err != nil {
+		return nil, fmt.Errorf("failed to list deployments for %s: %w", sha, err)
+	}
+
+	var deployments []Deployment
+	seen := make(map[string]bool)
+	for _, d := range raw {
+		// The API lists newest first, so the first per environment is current.
+		if seen[d.Environment] || len(deployments) == maxDeployments {
+			continue
+		}
+		seen[d.Environment] = true
+
+		var statuses []ghDeploymentStatus
+		endpoint := fmt.Sprintf("repos/%s/%s/deployments/%d/statuses?per_page=1", owner, repo, d.ID)
+		if err := c.ghAPIJSON(ctx, &statuses, endpoint, false); err != nil {
+			return nil, fmt.Errorf("failed to get status of deployment %d: %w", d.ID, err)
+		}
+		dep := Deployment{ID: d.ID, Environment: d.Environment, State: "pending", Creator: d.Creator.Login}
+		if len(statuses) > 0 {
+			dep.State = statuses[0].State
+			dep.URL = statuses[0].EnvironmentURL
+			dep.LogURL = statuses[0].LogURL
+		}
+		deployments = append(deployments, dep)
+	}
+	return deployments, nil
+}
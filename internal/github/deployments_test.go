@@ -0,0 +1,38 @@
+package github
+
+import (
+	"context"
+	"testing"
+)
+
+func TestGetDeployments(t *testing.T) {
+	client := NewTestClient("alice", fakeRunner(map[string]string{
+		"repos/shhac/prtea/deployments?sha=abc123": `[
+			{"id": 3, "environment": "preview", "creator": {"login": "vercel[bot]"}},
+			{"id": 2, "environment": "staging", "creator": {"login": "alice"}},
+			{"id": 1, "environment": "preview", "creator": {"login": "vercel[bot]"}}
+		]`,
+		"deployments/3/statuses": `[{"state": "success", "environment_url": "https://pr-7.preview.example.com", "log_url": "https://ci.example.com/3"}]`,
+		"deployments/2/statuses": `[]`,
+	}))
+	deployments, err := client.GetDeployments(context.Background(), "shhac", "prtea", "abc123")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(deployments) != 2 {
+		t.Fatalf("got %d deployments, want the latest per environment: %+v", len(deployments), deployments)
+	}
+	if d := deployments[0]; d.Environment != "preview" || d.State != "success" || d.URL != "https://pr-7.preview.example.com" {
+		t.Errorf("preview = %+v", d)
+	}
+	if d := deployments[1]; d.Environment != "staging" || d.State != "pending" || d.URL != "" {
+		t.Errorf("staging without statuses = %+v, want pending", d)
+	}
+}
+
+func TestGetDeployments_Error(t *testing.T) {
+	client := NewTestClient("alice", fakeErrorRunner("HTTP 404"))
+	if _, err := client.GetDeployments(context.Background(), "shhac", "prtea", "abc123"); err == nil {
+		t.Error("expected an error")
+	}
+}
@@ -0,0 +1,144 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// DeviceCode is the code a user enters at VerificationURI to authorize a
+// device flow login.
+type DeviceCode struct {
+	DeviceCode      string `json:"device_code"`
+	UserCode        string `json:"user_code"`
+	VerificationURI string `json:"verification_uri"`
+	ExpiresIn       int    `json:"expires_in"`
+	Interval        int    `json:"interval"`
+}
+
+// DeviceFlow runs GitHub's OAuth device authorization flow for an OAuth app
+// with device flow enabled.
+type DeviceFlow struct {
+	ClientID string
+	Scopes   []string
+	BaseURL  string // https://github.com when empty
+
+	http  *http.Client
+	sleep func(ctx context.Context, d time.Duration) error
+}
+
+// DefaultDeviceScopes are the token scopes prtea needs: private repositories
+// and team membership for CODEOWNERS checks.
+var DefaultDeviceScopes = []string{"repo", "read:org"}
+
+// NewDeviceFlow creates a device flow for the given OAuth app client ID.
+func NewDeviceFlow(clientID string) *DeviceFlow {
+	return &DeviceFlow{ClientID: clientID, Scopes: DefaultDeviceScopes}
+}
+
+func (f *DeviceFlow) post(ctx context.Context, path string, form url.Values, dest interface{}) error {
+	base := f.BaseURL
+	if base == "" {
+		base = "https://github.com"
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, strings.TrimSuffix(base, "/")+path, strings.NewReader(form.Encode()))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	client := f.http
+	if client == nil {
+		client = http.DefaultClient
+	}
+	res, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	if res.StatusCode >= 300 {
+		return fmt.Errorf("%s (HTTP %d)", path, res.StatusCode)
+	}
+	return json.NewDecoder(res.Body).Decode(dest)
+}
+
+// RequestCode starts the flow and returns the code to show the user.
+func (f *DeviceFlow) RequestCode(ctx context.Context) (*DeviceCode, error) {
+	var code DeviceCode
+	form := url.Values{"client_id": {f.ClientID}, "scope": {strings.Join(f.Scopes, " ")}}
+	if err := f.post(ctx, "/login/device/code", form, &code); err != nil {
+		return nil, fmt.Errorf("failed to request device code: %w", err)
+	}
+	if code.DeviceCode == "" {
+		return nil, fmt.Errorf("failed to request device code: empty response (is device flow enabled for the OAuth app?)")
+	}
+	return &code, nil
+}
+
+// PollToken waits for the user to authorize code and returns the access
+// token. It honours the server's polling interval, including slow_down.
+func (f *DeviceFlow) PollToken(ctx context.Context, code *DeviceCode) (string, error) {
+	interval := time.Duration(code.Interval) * time.Second
+	if interval <= 0 {
+		interval = 5 * time.Second
+	}
+	sleep := f.sleep
+	if sleep == nil {
+		sleep = sleepContext
+	}
+	form := url.Values{
+		"client_id":   {f.ClientID},
+		"device_code": {code.DeviceCode},
+		"grant_type":  {"urn:ietf:params:oauth:grant-type:device_code"},
+	}
+
+	for {
+		if err := sleep(ctx, interval); err != nil {
+			return "", err
+		}
+		var resp struct {
+			AccessToken string `json:"access_token"`
+			Error       string `json:"error"`
+			Description string `json:"error_description"`
+			Interval    int    `json:"interval"`
+		}
+		if err := f.post(ctx, "/login/oauth/access_token", form, &resp); err != nil {
+			return "", fmt.Errorf("failed to poll for token: %w", err)
+		}
+		switch resp.Error {
+		case "":
+			if resp.AccessToken == "" {
+				return "", fmt.Errorf("no access token in response")
+			}
+			return resp.AccessToken, nil
+		case "authorization_pending":
+		case "slow_down":
+			if resp.Interval > 0 {
+				interval = time.Duration(resp.Interval) * time.Second
+			} else {
+				interval += 5 * time.Second
+			}
+		default:
+			if resp.Description != "" {
+				return "", fmt.Errorf("%s: %s", resp.Error, resp.Description)
+			}
+			return "", fmt.Errorf("%s", resp.Error)
+		}
+	}
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
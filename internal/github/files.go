@@ -18,10 +18,43 @@ type ghFile struct {
 func (c *Client) GetPRFiles(ctx context.Context, owner, repo string, number int) ([]PRFile, error) {
 	var files []ghFile
 	endpoint := fmt.Sprintf("repos/%s/%s/pulls/%d/files", owner, repo, number)
-	if err := c.ghJSON(ctx, &files, "api", endpoint, "--paginate"); err != nil {
+	if err := c.ghAPIJSON(ctx, &files, endpoint, true); err != nil {
 		return nil, fmt.Errorf("failed to list files for PR #%d: %w", number, err)
 	}
 
+	return convertFiles(files), nil
+}
+
+// ghCompareDiff is the JSON shape of the compare API's changed files.
+type ghCompareDiff struct {
+	Files []ghFile `json:"files"`
+}
+
+// GetCompareFiles returns the files changed on head since it diverged from
+// base, with their patches, as a PR from head into base would show them.
+// The compare API lists at most 300 files.
+func (c *Client) GetCompareFiles(ctx context.Context, owner, repo, base, head string) ([]PRFile, error) {
+	var cmp ghCompareDiff
+	endpoint := fmt.Sprintf("repos/%s/%s/compare/%s...%s", owner, repo, base, head)
+	if err := c.ghAPIJSON(ctx, &cmp, endpoint, false); err != nil {
+		return nil, fmt.Errorf("failed to compare %s with %s: %w", head, base, err)
+	}
+	return convertFiles(cmp.Files), nil
+}
+
+// GetCompareStatus returns how head relates to base: "ahead" when head
+// only adds commits on top of base, "identical", or "behind" / "diverged"
+// when base is no longer in head's history, as after a force-push.
+func (c *Client) GetCompareStatus(ctx context.Context, owner, repo, base, head string) (string, error) {
+	var cmp ghCompare
+	endpoint := fmt.Sprintf("repos/%s/%s/compare/%s...%s", owner, repo, base, head)
+	if err := c.ghAPIJSON(ctx, &cmp, endpoint, false); err != nil {
+		return "", fmt.Errorf("failed to compare %s with %s: %w", head, base, err)
+	}
+	return cmp.Status, nil
+}
+
+func convertFiles(files []ghFile) []PRFile {
 	result := make([]PRFile, 0, len(files))
 	for _, f := range files {
 		result = append(result, PRFile{
@@ -32,5 +65,5 @@ func (c *Client) GetPRFiles(ctx context.Context, owner, repo string, number int)
 			Patch:     f.Patch,
 		})
 	}
-	return result, nil
+	return result
 }
@@ -0,0 +1,175 @@
+package github
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ResponseCache is a disk-backed store of REST responses keyed by endpoint
+// and validated with ETags. Conditional requests answered with 304 Not
+// Modified do not count against the GitHub rate limit.
+type ResponseCache struct {
+	dir string
+}
+
+// NewResponseCache creates a cache that stores responses in dir.
+func NewResponseCache(dir string) *ResponseCache {
+	return &ResponseCache{dir: dir}
+}
+
+// cachedResponse is one endpoint's last response body and its ETag.
+type cachedResponse struct {
+	ETag     string    `json:"etag"`
+	Body     string    `json:"body"`
+	StoredAt time.Time `json:"storedAt"`
+}
+
+func (rc *ResponseCache) path(endpoint string) string {
+	sum := sha256.Sum256([]byte(endpoint))
+	return filepath.Join(rc.dir, hex.EncodeToString(sum[:16])+".json")
+}
+
+// get returns the cached response for endpoint, or nil if none is stored.
+func (rc *ResponseCache) get(endpoint string) *cachedResponse {
+	data, err := os.ReadFile(rc.path(endpoint))
+	if err != nil {
+		return nil
+	}
+	var cached cachedResponse
+	if err := json.Unmarshal(data, &cached); err != nil || cached.ETag == "" {
+		return nil
+	}
+	return &cached
+}
+
+// put stores body under endpoint. Failures are ignored: the cache only
+// saves requests, it never affects correctness.
+func (rc *ResponseCache) put(endpoint, etag, body string) {
+	if err := os.MkdirAll(rc.dir, 0o755); err != nil {
+		return
+	}
+	data, err := json.Marshal(cachedResponse{ETag: etag, Body: body, StoredAt: time.Now()})
+	if err != nil {
+		return
+	}
+	path := rc.path(endpoint)
+	tmpPath := path + ".tmp"
+	if err := os.WriteFile(tmpPath, data, 0o644); err != nil {
+		return
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		os.Remove(tmpPath)
+	}
+}
+
+// SetResponseCache enables ETag caching of REST GET requests.
+func (c *Client) SetResponseCache(rc *ResponseCache) {
+	c.cache = rc
+}
+
+// apiPageSize is the per_page requested from paginated endpoints.
+const apiPageSize = 100
+
+// ghAPIGet fetches a REST endpoint, revalidating any cached copy with
+// If-None-Match and serving it on 304. Paginated endpoints are fetched
+// apiPageSize at a time, and only cached while they fit in one page that is
+// not full: a 304 on page 1 says nothing about later pages, so longer
+// results are always fetched in full with --paginate.
+func (c *Client) ghAPIGet(ctx context.Context, endpoint string, paginate bool) (string, error) {
+	if paginate {
+		sep := "?"
+		if strings.Contains(endpoint, "?") {
+			sep = "&"
+		}
+		endpoint += fmt.Sprintf("%sper_page=%d", sep, apiPageSize)
+	}
+	if c.cache == nil {
+		args := []string{"api", endpoint}
+		if paginate {
+			args = append(args, "--paginate")
+		}
+		return c.ghExec(ctx, args...)
+	}
+
+	cached := c.cache.get(endpoint)
+	args := []string{"api", endpoint, "--include"}
+	if cached != nil {
+		args = append(args, "-H", "If-None-Match: "+cached.ETag)
+	}
+	out, err := c.ghExec(ctx, args...)
+	if err != nil {
+		// gh exits non-zero for any status above 299, including 304.
+		if cached != nil && strings.Contains(err.Error(), "HTTP 304") {
+			return cached.Body, nil
+		}
+		return "", err
+	}
+
+	status, headers, body := parseHTTPResponse(out)
+	if status == 304 && cached != nil {
+		return cached.Body, nil
+	}
+	if paginate && (strings.Contains(headers["link"], `rel="next"`) || !singlePage(body)) {
+		if body, err = c.ghExec(ctx, "api", endpoint, "--paginate"); err != nil {
+			return "", err
+		}
+		return body, nil
+	}
+	if etag := headers["etag"]; etag != "" {
+		c.cache.put(endpoint, etag, body)
+	}
+	return body, nil
+}
+
+// singlePage reports whether a page of a paginated endpoint is the whole
+// result for certain: a page that is not full. A full page may be followed
+// by one that GitHub does not link yet, or that an addition would create
+// without changing page 1.
+func singlePage(body string) bool {
+	var items []json.RawMessage
+	if err := json.Unmarshal([]byte(body), &items); err != nil {
+		return false
+	}
+	return len(items) < apiPageSize
+}
+
+// ghAPIJSON is ghAPIGet followed by unmarshalling the body into dest.
+func (c *Client) ghAPIJSON(ctx context.Context, dest interface{}, endpoint string, paginate bool) error {
+	out, err := c.ghAPIGet(ctx, endpoint, paginate)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal([]byte(out), dest); err != nil {
+		return fmt.Errorf("failed to parse gh output: %w", err)
+	}
+	return nil
+}
+
+// parseHTTPResponse splits `gh api --include` output into the status code,
+// lower-cased headers and body.
+func parseHTTPResponse(out string) (status int, headers map[string]string, body string) {
+	headers = make(map[string]string)
+	out = strings.ReplaceAll(out, "\r\n", "\n")
+	head, body, found := strings.Cut(out, "\n\n")
+	if !found {
+		return 0, headers, out
+	}
+	lines := strings.Split(head, "\n")
+	if fields := strings.Fields(lines[0]); len(fields) >= 2 && strings.HasPrefix(fields[0], "HTTP/") {
+		status, _ = strconv.Atoi(fields[1])
+	}
+	for _, line := range lines[1:] {
+		if name, value, ok := strings.Cut(line, ":"); ok {
+			headers[strings.ToLower(strings.TrimSpace(name))] = strings.TrimSpace(value)
+		}
+	}
+	return status, headers, body
+}
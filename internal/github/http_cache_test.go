@@ -0,0 +1,130 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestParseHTTPResponse(t *testing.T) {
+	out := "HTTP/2.0 200 OK\r\nEtag: W/\"abc\"\r\nLink: <https://api.github.com/x?page=2>; rel=\"next\"\r\n\r\n[{\"a\":1}]"
+	status, headers, body := parseHTTPResponse(out)
+	if status != 200 {
+		t.Errorf("status = %d, want 200", status)
+	}
+	if headers["etag"] != `W/"abc"` {
+		t.Errorf("etag = %q", headers["etag"])
+	}
+	if !strings.Contains(headers["link"], `rel="next"`) {
+		t.Errorf("link = %q", headers["link"])
+	}
+	if body != `[{"a":1}]` {
+		t.Errorf("body = %q", body)
+	}
+}
+
+func TestGhAPIGet_ServesCachedBodyOn304(t *testing.T) {
+	var calls [][]string
+	responses := []func() (string, error){
+		func() (string, error) { return "HTTP/2.0 200 OK\nETag: \"v1\"\n\n{\"n\":1}", nil },
+		func() (string, error) { return "", fmt.Errorf("gh api failed: gh: HTTP 304") },
+	}
+	c := NewTestClient("alice", func(_ context.Context, args ...string) (string, error) {
+		calls = append(calls, args)
+		return responses[len(calls)-1]()
+	})
+	c.SetResponseCache(NewResponseCache(t.TempDir()))
+
+	first, err := c.ghAPIGet(context.Background(), "repos/o/r/pulls/1/files", false)
+	if err != nil || first != `{"n":1}` {
+		t.Fatalf("first fetch = %q, %v", first, err)
+	}
+	second, err := c.ghAPIGet(context.Background(), "repos/o/r/pulls/1/files", false)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if second != first {
+		t.Errorf("304 should serve cached body, got %q", second)
+	}
+	if got := strings.Join(calls[1], " "); !strings.Contains(got, `If-None-Match: "v1"`) {
+		t.Errorf("second request should be conditional, got %q", got)
+	}
+}
+
+func TestGhAPIGet_RefetchesAllPagesWhenChanged(t *testing.T) {
+	c := NewTestClient("alice", fakeRunner(map[string]string{
+		"--include":  "HTTP/2.0 200 OK\nETag: \"v2\"\nLink: <x?page=2>; rel=\"next\"\n\n[1]",
+		"--paginate": "[1,2]",
+	}))
+	rc := NewResponseCache(t.TempDir())
+	c.SetResponseCache(rc)
+
+	body, err := c.ghAPIGet(context.Background(), "repos/o/r/pulls/1/comments", true)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if body != "[1,2]" {
+		t.Errorf("body = %q, want all pages", body)
+	}
+	if cached := rc.get("repos/o/r/pulls/1/comments?per_page=100"); cached != nil {
+		t.Errorf("a result spanning pages should not be cached, got %+v", cached)
+	}
+}
+
+func TestGhAPIGet_LaterPageChangedBehindUnchangedFirstPage(t *testing.T) {
+	// Page 1 never changes and answers 304 to a conditional request; page 2
+	// gains a comment between the two fetches.
+	pages := []string{"[1,2]", "[1,2,3]"}
+	fetches := 0
+	c := NewTestClient("alice", func(_ context.Context, args ...string) (string, error) {
+		key := strings.Join(args, " ")
+		switch {
+		case strings.Contains(key, "If-None-Match"):
+			return "", fmt.Errorf("gh api failed: gh: HTTP 304")
+		case strings.Contains(key, "--include"):
+			return "HTTP/2.0 200 OK\nETag: \"p1\"\nLink: <x?page=2>; rel=\"next\"\n\n[1]", nil
+		case strings.Contains(key, "--paginate"):
+			fetches++
+			return pages[fetches-1], nil
+		}
+		return "", fmt.Errorf("unexpected command: gh %s", key)
+	})
+	c.SetResponseCache(NewResponseCache(t.TempDir()))
+
+	if body, err := c.ghAPIGet(context.Background(), "repos/o/r/pulls/1/comments", true); err != nil || body != "[1,2]" {
+		t.Fatalf("first fetch = %q, %v", body, err)
+	}
+	body, err := c.ghAPIGet(context.Background(), "repos/o/r/pulls/1/comments", true)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if body != "[1,2,3]" {
+		t.Errorf("second fetch = %q, want the changed later page", body)
+	}
+}
+
+func TestGhAPIGet_CachesSinglePartialPage(t *testing.T) {
+	c := NewTestClient("alice", fakeRunner(map[string]string{
+		"api repos/o/r/pulls/1/files?per_page=100 --include": "HTTP/2.0 200 OK\nETag: \"v1\"\n\n[1,2]",
+	}))
+	rc := NewResponseCache(t.TempDir())
+	c.SetResponseCache(rc)
+
+	if body, err := c.ghAPIGet(context.Background(), "repos/o/r/pulls/1/files", true); err != nil || body != "[1,2]" {
+		t.Fatalf("got %q, %v", body, err)
+	}
+	if cached := rc.get("repos/o/r/pulls/1/files?per_page=100"); cached == nil || cached.ETag != `"v1"` {
+		t.Errorf("cached = %+v", cached)
+	}
+}
+
+func TestGhAPIGet_NoCachePassesThrough(t *testing.T) {
+	c := NewTestClient("alice", fakeRunner(map[string]string{
+		"api repos/o/r/pulls/1/files?per_page=100 --paginate": "[]",
+	}))
+	body, err := c.ghAPIGet(context.Background(), "repos/o/r/pulls/1/files", true)
+	if err != nil || body != "[]" {
+		t.Errorf("got %q, %v", body, err)
+	}
+}
@@ -3,6 +3,7 @@ package github
 import (
 	"context"
 	"encoding/json"
+	"reflect"
 	"testing"
 )
 
@@ -81,6 +82,40 @@ func TestGetInlineComments_LineFallback(t *testing.T) {
 	}
 }
 
+func TestGetInlineComments_DiffHunk(t *testing.T) {
+	data := `[{"id":6001,"user":{"login":"frank"},"body":"b","path":"a.go","line":3,"position":2,"diff_hunk":"@@ -1,2 +1,3 @@\n ctx\n+added"}]`
+
+	client := NewTestClient("alice", fakeRunner(map[string]string{
+		"api repos/": data,
+	}))
+
+	comments, err := client.GetInlineComments(context.Background(), "alice", "widget", 42)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := "@@ -1,2 +1,3 @@\n ctx\n+added"; comments[0].DiffHunk != want {
+		t.Errorf("DiffHunk = %q, want %q", comments[0].DiffHunk, want)
+	}
+}
+
+func TestGetInlineComments_Reactions(t *testing.T) {
+	data := `[{"id":7001,"user":{"login":"gina"},"body":"b","path":"a.go","line":3,"position":2,
+		"reactions":{"url":"https://api.github.com/x","total_count":4,"+1":2,"-1":0,"laugh":0,"hooray":0,"confused":0,"heart":1,"rocket":0,"eyes":1}}]`
+
+	client := NewTestClient("alice", fakeRunner(map[string]string{
+		"api repos/": data,
+	}))
+
+	comments, err := client.GetInlineComments(context.Background(), "alice", "widget", 42)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []Reaction{{"+1", 2}, {"heart", 1}, {"eyes", 1}}
+	if got := comments[0].Reactions; !reflect.DeepEqual(got, want) {
+		t.Errorf("Reactions = %+v, want %+v", got, want)
+	}
+}
+
 func TestGetInlineComments_NilPointers(t *testing.T) {
 	// StartLine and InReplyToID are nil
 	raw := []ghInlineComment{
@@ -0,0 +1,251 @@
+package github
+
+import (
+	"context"
+	"fmt"
+)
+
+// ghBranch is the JSON shape from the branches API. Its protection summary
+// is readable by anyone with read access, unlike the full protection API.
+type ghBranch struct {
+	Protected  bool `json:"protected"`
+	Protection struct {
+		RequiredStatusChecks struct {
+			Contexts []string `json:"contexts"`
+		} `json:"required_status_checks"`
+	} `json:"protection"`
+}
+
+// ghBranchProtection is the JSON shape from the branch protection API
+// (requires admin access on most repos).
+type ghBranchProtection struct {
+	RequiredStatusChecks *struct {
+		Strict bool `json:"strict"`
+	} `json:"required_status_checks"`
+	RequiredPullRequestReviews *struct {
+		RequiredApprovingReviewCount int `json:"required_approving_review_count"`
+	} `json:"required_pull_request_reviews"`
+}
+
+// ghReviewThreads is the GraphQL response shape for PR review thread resolution.
+type ghReviewThreads struct {
+	Data struct {
+		Repository struct {
+			PullRequest struct {
+				ReviewThreads struct {
+					Nodes []struct {
+						IsResolved bool `json:"isResolved"`
+						Comments   struct {
+							Nodes []struct {
+								DatabaseID int64 `json:"databaseId"`
+							} `json:"nodes"`
+						} `json:"comments"`
+					} `json:"nodes"`
+				} `json:"reviewThreads"`
+			} `json:"pullRequest"`
+		} `json:"repository"`
+	} `json:"data"`
+}
+
+const reviewThreadsQuery = `query($owner: String!, $name: String!, $number: Int!) {
+  repository(owner: $owner, name: $name) {
+    pullRequest(number: $number) {
+      reviewThreads(first: 100) { nodes { isResolved comments(first: 1) { nodes { databaseId } } } }
+    }
+  }
+}`
+
+// GetMergeRequirements fetches the base branch's protection rules and the
+// PR's unresolved review thread count. Protection details that the user
+// cannot read are reported as unknown rather than failing the call.
+func (c *Client) GetMergeRequirements(ctx context.Context, owner, repo, base string, number int) (*MergeRequirements, error) {
+	req := &MergeRequirements{RequiredApprovals: -1}
+
+	var branch ghBranch
+	if err := c.ghAPIJSON(ctx, &branch, fmt.Sprintf("repos/%s/%s/branches/%s", owner, repo, base), false); err == nil {
+		req.ProtectionKnown = true
+		req.Protected = branch.Protected
+		req.RequiredChecks = branch.Protection.RequiredStatusChecks.Contexts
+	}
+
+	if req.Protected {
+		var prot ghBranchProtection
+		if err := c.ghAPIJSON(ctx, &prot, fmt.Sprintf("repos/%s/%s/branches/%s/protection", owner, repo, base), false); err == nil {
+			req.RequiredApprovals = 0
+			if prot.RequiredPullRequestReviews != nil {
+				req.RequiredApprovals = prot.RequiredPullRequestReviews.RequiredApprovingReviewCount
+			}
+			if prot.RequiredStatusChecks != nil {
+				req.StrictUpToDate = prot.RequiredStatusChecks.Strict
+			}
+		}
+	} else if req.ProtectionKnown {
+		req.RequiredApprovals = 0
+	}
+
+	var threads ghReviewThreads
+	err := c.ghJSON(ctx, &threads,
+		"api", "graphql",
+		"-f", "query="+reviewThreadsQuery,
+		"-f", "owner="+owner,
+		"-f", "name="+repo,
+		"-F", fmt.Sprintf("number=%d", number),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch review threads for PR #%d: %w", number, err)
+	}
+	for _, t := range threads.Data.Repository.PullRequest.ReviewThreads.Nodes {
+		if !t.IsResolved {
+			req.UnresolvedThreads++
+		} else if len(t.Comments.Nodes) > 0 {
+			req.ResolvedThreadIDs = append(req.ResolvedThreadIDs, t.Comments.Nodes[0].DatabaseID)
+		}
+	}
+
+	return req, nil
+}
+
+// UpdateBranch merges the base branch into the PR's head branch (the
+// "Update branch" button). expectedHeadSHA guards against racing a push;
+// pass "" to skip the check. GitHub performs the update asynchronously.
+func (c *Client) UpdateBranch(ctx context.Context, owner, repo string, number int, expectedHeadSHA string) error {
+	args := []string{"api", "-X", "PUT", fmt.Sprintf("repos/%s/%s/pulls/%d/update-branch", owner, repo, number)}
+	if expectedHeadSHA != "" {
+		args = append(args, "-f", "expected_head_sha="+expectedHeadSHA)
+	}
+	if _, err := c.ghExec(ctx, args...); err != nil {
+		return fmt.Errorf("failed to update branch for PR #%d: %w", number, err)
+	}
+	return nil
+}
+
+// ghCompareFiles is the JSON shape of the files list from the compare API.
+type ghCompareFiles struct {
+	Files []struct {
+		Filename string `json:"filename"`
+	} `json:"files"`
+}
+
+// GetBaseChangedFiles returns the files changed on base since head diverged
+// from it. Files that also appear in the PR are the likely conflict sources.
+func (c *Client) GetBaseChangedFiles(ctx context.Context, owner, repo, base, head string) ([]string, error) {
+	var cmp ghCompareFiles
+	endpoint := fmt.Sprintf("repos/%s/%s/compare/%s...%s", owner, repo, head, base)
+	if err := c.ghAPIJSON(ctx, &cmp, endpoint, false); err != nil {
+		return nil, fmt.Errorf("failed to compare %s with %s: %w", head, base, err)
+	}
+	files := make([]string, 0, len(cmp.Files))
+	for _, f := range cmp.Files {
+		files = append(files, f.Filename)
+	}
+	return files, nil
+}
+
+// Auto-merge methods accepted by EnableAutoMerge.
+const (
+	MergeMethodMerge  = "MERGE"
+	MergeMethodSquash = "SQUASH"
+	MergeMethodRebase = "REBASE"
+)
+
+// ghPRNodeID is the JSON shape for a PR's GraphQL node ID from gh pr view.
+type ghPRNodeID struct {
+	ID string `json:"id"`
+}
+
+// MergeCommit overrides the commit message GitHub writes when it merges a PR.
+type MergeCommit struct {
+	Headline string
+	Body     string
+}
+
+const enableAutoMergeMutation = `mutation($id: ID!, $method: PullRequestMergeMethod!, $headline: String, $body: String) {
+  enablePullRequestAutoMerge(input: {pullRequestId: $id, mergeMethod: $method, commitHeadline: $headline, commitBody: $body}) { clientMutationId }
+}`
+
+const disableAutoMergeMutation = `mutation($id: ID!) {
+  disablePullRequestAutoMerge(input: {pullRequestId: $id}) { clientMutationId }
+}`
+
+// prNodeID resolves a PR number to the GraphQL node ID that mutations require.
+func (c *Client) prNodeID(ctx context.Context, owner, repo string, number int) (string, error) {
+	var pr ghPRNodeID
+	err := c.ghJSON(ctx, &pr,
+		"pr", "view", fmt.Sprintf("%d", number),
+		"-R", owner+"/"+repo,
+		"--json", "id",
+	)
+	if err != nil {
+		return "", err
+	}
+	return pr.ID, nil
+}
+
+// EnableAutoMerge turns on auto-merge for a PR so GitHub merges it with the
+// given method (MergeMethodMerge, MergeMethodSquash or MergeMethodRebase)
+// once all requirements are met. A nil commit keeps GitHub's default message.
+func (c *Client) EnableAutoMerge(ctx context.Context, owner, repo string, number int, method string, commit *MergeCommit) error {
+	id, err := c.prNodeID(ctx, owner, repo, number)
+	if err != nil {
+		return fmt.Errorf("failed to enable auto-merge for PR #%d: %w", number, err)
+	}
+	args := []string{
+		"api", "graphql",
+		"-f", "query=" + enableAutoMergeMutation,
+		"-f", "id=" + id,
+		"-f", "method=" + method,
+	}
+	if commit != nil {
+		args = append(args, "-f", "headline="+commit.Headline, "-f", "body="+commit.Body)
+	}
+	if _, err = c.ghExec(ctx, args...); err != nil {
+		return fmt.Errorf("failed to enable auto-merge for PR #%d: %w", number, err)
+	}
+	return nil
+}
+
+// DisableAutoMerge turns off a pending auto-merge for a PR.
+func (c *Client) DisableAutoMerge(ctx context.Context, owner, repo string, number int) error {
+	if err := c.prMutation(ctx, owner, repo, number, disableAutoMergeMutation); err != nil {
+		return fmt.Errorf("failed to disable auto-merge for PR #%d: %w", number, err)
+	}
+	return nil
+}
+
+const markReadyForReviewMutation = `mutation($id: ID!) {
+  markPullRequestReadyForReview(input: {pullRequestId: $id}) { clientMutationId }
+}`
+
+const convertToDraftMutation = `mutation($id: ID!) {
+  convertPullRequestToDraft(input: {pullRequestId: $id}) { clientMutationId }
+}`
+
+// MarkReadyForReview takes a draft PR out of draft so reviewers are notified.
+func (c *Client) MarkReadyForReview(ctx context.Context, owner, repo string, number int) error {
+	if err := c.prMutation(ctx, owner, repo, number, markReadyForReviewMutation); err != nil {
+		return fmt.Errorf("failed to mark PR #%d ready for review: %w", number, err)
+	}
+	return nil
+}
+
+// ConvertToDraft converts an open PR back to a draft.
+func (c *Client) ConvertToDraft(ctx context.Context, owner, repo string, number int) error {
+	if err := c.prMutation(ctx, owner, repo, number, convertToDraftMutation); err != nil {
+		return fmt.Errorf("failed to convert PR #%d to draft: %w", number, err)
+	}
+	return nil
+}
+
+// prMutation runs a GraphQL mutation whose only variable is the PR's node ID.
+func (c *Client) prMutation(ctx context.Context, owner, repo string, number int, mutation string) error {
+	id, err := c.prNodeID(ctx, owner, repo, number)
+	if err != nil {
+		return err
+	}
+	_, err = c.ghExec(ctx,
+		"api", "graphql",
+		"-f", "query="+mutation,
+		"-f", "id="+id,
+	)
+	return err
+}
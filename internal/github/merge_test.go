@@ -0,0 +1,248 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// mergeRunner answers the branch, protection and review-thread calls made by
+// GetMergeRequirements. An empty response makes that call fail.
+func mergeRunner(branch, protection, threads string) CommandRunner {
+	return func(ctx context.Context, args ...string) (string, error) {
+		key := strings.Join(args, " ")
+		var resp string
+		switch {
+		case strings.HasSuffix(key, "/protection"):
+			resp = protection
+		case strings.Contains(key, "/branches/"):
+			resp = branch
+		case strings.Contains(key, "graphql"):
+			resp = threads
+		}
+		if resp == "" {
+			return "", fmt.Errorf("HTTP 404: Not Found (gh %s)", key)
+		}
+		return resp, nil
+	}
+}
+
+const threadsJSON = `{"data":{"repository":{"pullRequest":{"reviewThreads":{"nodes":[
+	{"isResolved":true},{"isResolved":false},{"isResolved":false}]}}}}}`
+
+func TestGetMergeRequirements_FullAccess(t *testing.T) {
+	client := NewTestClient("alice", mergeRunner(
+		`{"protected":true,"protection":{"required_status_checks":{"contexts":["build","lint"]}}}`,
+		`{"required_status_checks":{"strict":true},"required_pull_request_reviews":{"required_approving_review_count":2}}`,
+		threadsJSON,
+	))
+
+	req, err := client.GetMergeRequirements(context.Background(), "alice", "widget", "main", 42)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !req.ProtectionKnown || !req.Protected {
+		t.Errorf("expected known protected branch, got %+v", req)
+	}
+	if len(req.RequiredChecks) != 2 || req.RequiredChecks[0] != "build" {
+		t.Errorf("RequiredChecks = %v", req.RequiredChecks)
+	}
+	if req.RequiredApprovals != 2 {
+		t.Errorf("RequiredApprovals = %d, want 2", req.RequiredApprovals)
+	}
+	if !req.StrictUpToDate {
+		t.Error("expected StrictUpToDate")
+	}
+	if req.UnresolvedThreads != 2 {
+		t.Errorf("UnresolvedThreads = %d, want 2", req.UnresolvedThreads)
+	}
+}
+
+func TestGetMergeRequirements_NoAdminAccess(t *testing.T) {
+	client := NewTestClient("alice", mergeRunner(
+		`{"protected":true,"protection":{"required_status_checks":{"contexts":["build"]}}}`,
+		"",
+		threadsJSON,
+	))
+
+	req, err := client.GetMergeRequirements(context.Background(), "alice", "widget", "main", 42)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if req.RequiredApprovals != -1 {
+		t.Errorf("RequiredApprovals = %d, want -1 (unknown)", req.RequiredApprovals)
+	}
+	if len(req.RequiredChecks) != 1 {
+		t.Errorf("RequiredChecks = %v", req.RequiredChecks)
+	}
+}
+
+func TestGetMergeRequirements_UnprotectedBranch(t *testing.T) {
+	client := NewTestClient("alice", mergeRunner(`{"protected":false}`, "", threadsJSON))
+
+	req, err := client.GetMergeRequirements(context.Background(), "alice", "widget", "main", 42)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if req.Protected || req.RequiredApprovals != 0 {
+		t.Errorf("expected unprotected branch with no required approvals, got %+v", req)
+	}
+}
+
+func TestGetMergeRequirements_ThreadsError(t *testing.T) {
+	client := NewTestClient("alice", mergeRunner(`{"protected":false}`, "", ""))
+
+	_, err := client.GetMergeRequirements(context.Background(), "alice", "widget", "main", 42)
+	if err == nil || !strings.Contains(err.Error(), "failed to fetch review threads for PR #42") {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestUpdateBranch(t *testing.T) {
+	var got string
+	client := NewTestClient("alice", func(ctx context.Context, args ...string) (string, error) {
+		got = strings.Join(args, " ")
+		return "{}", nil
+	})
+
+	if err := client.UpdateBranch(context.Background(), "alice", "widget", 42, "abc123"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := "api -X PUT repos/alice/widget/pulls/42/update-branch -f expected_head_sha=abc123"
+	if got != want {
+		t.Errorf("args = %q, want %q", got, want)
+	}
+}
+
+func TestUpdateBranch_Error(t *testing.T) {
+	client := NewTestClient("alice", fakeErrorRunner("HTTP 422: merge conflict between base and head"))
+
+	err := client.UpdateBranch(context.Background(), "alice", "widget", 42, "")
+	if err == nil || !strings.Contains(err.Error(), "failed to update branch for PR #42") {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestGetBaseChangedFiles(t *testing.T) {
+	client := NewTestClient("alice", fakeRunner(map[string]string{
+		"compare/feature...main": `{"ahead_by":2,"files":[{"filename":"go.mod"},{"filename":"main.go"}]}`,
+	}))
+
+	files, err := client.GetBaseChangedFiles(context.Background(), "alice", "widget", "main", "feature")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(files) != 2 || files[0] != "go.mod" || files[1] != "main.go" {
+		t.Errorf("files = %v", files)
+	}
+}
+
+func TestEnableAutoMerge(t *testing.T) {
+	var calls []string
+	client := NewTestClient("alice", func(ctx context.Context, args ...string) (string, error) {
+		key := strings.Join(args, " ")
+		calls = append(calls, key)
+		if strings.HasPrefix(key, "pr view") {
+			return `{"id":"PR_kwDOA1"}`, nil
+		}
+		return `{"data":{}}`, nil
+	})
+
+	if err := client.EnableAutoMerge(context.Background(), "alice", "widget", 42, MergeMethodSquash, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(calls) != 2 {
+		t.Fatalf("calls = %d, want 2", len(calls))
+	}
+	if calls[0] != "pr view 42 -R alice/widget --json id" {
+		t.Errorf("node id lookup = %q", calls[0])
+	}
+	for _, want := range []string{"enablePullRequestAutoMerge", "id=PR_kwDOA1", "method=SQUASH"} {
+		if !strings.Contains(calls[1], want) {
+			t.Errorf("mutation %q missing %q", calls[1], want)
+		}
+	}
+	if strings.Contains(calls[1], "headline=") {
+		t.Error("no commit message was given")
+	}
+
+	calls = nil
+	commit := &MergeCommit{Headline: "Add retry", Body: "Uploads retry twice."}
+	if err := client.EnableAutoMerge(context.Background(), "alice", "widget", 42, MergeMethodSquash, commit); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, want := range []string{"headline=Add retry", "body=Uploads retry twice."} {
+		if !strings.Contains(calls[1], want) {
+			t.Errorf("mutation %q missing %q", calls[1], want)
+		}
+	}
+}
+
+func TestDisableAutoMerge_Error(t *testing.T) {
+	client := NewTestClient("alice", fakeErrorRunner("HTTP 404: Not Found"))
+
+	err := client.DisableAutoMerge(context.Background(), "alice", "widget", 42)
+	if err == nil || !strings.Contains(err.Error(), "failed to disable auto-merge for PR #42") {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestGetMergeRequirements_ResolvedThreadIDs(t *testing.T) {
+	threads := `{"data":{"repository":{"pullRequest":{"reviewThreads":{"nodes":[
+		{"isResolved":true,"comments":{"nodes":[{"databaseId":101}]}},
+		{"isResolved":false,"comments":{"nodes":[{"databaseId":102}]}}]}}}}}`
+	client := NewTestClient("alice", mergeRunner(`{"protected":false}`, "", threads))
+
+	req, err := client.GetMergeRequirements(context.Background(), "alice", "widget", "main", 42)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(req.ResolvedThreadIDs) != 1 || req.ResolvedThreadIDs[0] != 101 {
+		t.Errorf("ResolvedThreadIDs = %v, want [101]", req.ResolvedThreadIDs)
+	}
+	if req.UnresolvedThreads != 1 {
+		t.Errorf("UnresolvedThreads = %d, want 1", req.UnresolvedThreads)
+	}
+}
+
+func TestMarkReadyForReview(t *testing.T) {
+	var calls []string
+	client := NewTestClient("alice", func(ctx context.Context, args ...string) (string, error) {
+		key := strings.Join(args, " ")
+		calls = append(calls, key)
+		if strings.HasPrefix(key, "pr view") {
+			return `{"id":"PR_kwDOA1"}`, nil
+		}
+		return `{"data":{}}`, nil
+	})
+
+	if err := client.MarkReadyForReview(context.Background(), "alice", "widget", 42); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(calls) != 2 {
+		t.Fatalf("calls = %d, want 2", len(calls))
+	}
+	for _, want := range []string{"markPullRequestReadyForReview", "id=PR_kwDOA1"} {
+		if !strings.Contains(calls[1], want) {
+			t.Errorf("mutation %q missing %q", calls[1], want)
+		}
+	}
+
+	calls = nil
+	if err := client.ConvertToDraft(context.Background(), "alice", "widget", 42); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(calls) != 2 || !strings.Contains(calls[1], "convertPullRequestToDraft") {
+		t.Errorf("calls = %q, want a convertPullRequestToDraft mutation", calls)
+	}
+}
+
+func TestConvertToDraft_Error(t *testing.T) {
+	client := NewTestClient("alice", fakeErrorRunner("HTTP 403: Forbidden"))
+
+	err := client.ConvertToDraft(context.Background(), "alice", "widget", 42)
+	if err == nil || !strings.Contains(err.Error(), "failed to convert PR #42 to draft") {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
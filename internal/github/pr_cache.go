@@ -0,0 +1,114 @@
+package github
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// PRCache is a disk-backed store of the data last fetched for each PR: its
+// diff, comments, CI status and reviews. It lets a PR render instantly
+// when selected while fresh data is fetched in the background. Entries are
+// keyed by the PR and its updatedAt, so a PR that changed since is a miss,
+// and expire after a TTL.
+type PRCache struct {
+	mu  sync.Mutex
+	dir string
+	ttl time.Duration
+}
+
+// NewPRCache creates a cache that stores PR data in dir for ttl.
+func NewPRCache(dir string, ttl time.Duration) *PRCache {
+	return &PRCache{dir: dir, ttl: ttl}
+}
+
+// CachedPR is the data cached for one PR. Each part is nil until fetched.
+type CachedPR struct {
+	UpdatedAt      time.Time       `json:"updatedAt"` // the PR's updatedAt the data belongs to
+	CachedAt       time.Time       `json:"cachedAt"`  // when the data was last written
+	Files          []PRFile        `json:"files,omitempty"`
+	Comments       []Comment       `json:"comments,omitempty"`
+	InlineComments []InlineComment `json:"inlineComments,omitempty"`
+	CI             *CIStatus       `json:"ci,omitempty"`
+	Reviews        *ReviewSummary  `json:"reviews,omitempty"`
+}
+
+func (c *PRCache) path(owner, repo string, number int) string {
+	return filepath.Join(c.dir, fmt.Sprintf("%s_%s_%d.json", owner, repo, number))
+}
+
+// read loads a PR's entry, or nil if none is stored.
+func (c *PRCache) read(owner, repo string, number int) *CachedPR {
+	data, err := os.ReadFile(c.path(owner, repo, number))
+	if err != nil {
+		return nil
+	}
+	var cached CachedPR
+	if err := json.Unmarshal(data, &cached); err != nil {
+		return nil
+	}
+	return &cached
+}
+
+// Get returns the data cached for a PR at updatedAt, or nil if there is
+// none, it is for another updatedAt, or it is older than the TTL.
+func (c *PRCache) Get(owner, repo string, number int, updatedAt time.Time) *CachedPR {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	cached := c.read(owner, repo, number)
+	if cached == nil || !cached.UpdatedAt.Equal(updatedAt) || time.Since(cached.CachedAt) > c.ttl {
+		return nil
+	}
+	return cached
+}
+
+// Update applies fn to a PR's entry for updatedAt, starting a new one if
+// the stored entry is for another updatedAt, and saves it. Failures are
+// returned but safe to ignore: the cache only speeds up rendering.
+func (c *PRCache) Update(owner, repo string, number int, updatedAt time.Time, fn func(*CachedPR)) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	cached := c.read(owner, repo, number)
+	if cached == nil || !cached.UpdatedAt.Equal(updatedAt) {
+		cached = &CachedPR{UpdatedAt: updatedAt}
+	}
+	fn(cached)
+	cached.CachedAt = time.Now()
+
+	if err := os.MkdirAll(c.dir, 0o755); err != nil {
+		return fmt.Errorf("failed to create PR cache directory: %w", err)
+	}
+	data, err := json.Marshal(cached)
+	if err != nil {
+		return fmt.Errorf("failed to marshal cached PR: %w", err)
+	}
+	path := c.path(owner, repo, number)
+	tmpPath := path + ".tmp"
+	if err := os.WriteFile(tmpPath, data, 0o644); err != nil {
+		return fmt.Errorf("failed to write PR cache file: %w", err)
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		os.Remove(tmpPath)
+		return fmt.Errorf("failed to rename PR cache file: %w", err)
+	}
+	return nil
+}
+
+// Prune removes entries older than the TTL.
+func (c *PRCache) Prune() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entries, err := os.ReadDir(c.dir)
+	if err != nil {
+		return
+	}
+	for _, e := range entries {
+		info, err := e.Info()
+		if err == nil && time.Since(info.ModTime()) > c.ttl {
+			os.Remove(filepath.Join(c.dir, e.Name()))
+		}
+	}
+}
@@ -0,0 +1,43 @@
+package github
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestPRCache_UpdateMergesPartsPerUpdatedAt(t *testing.T) {
+	cache := NewPRCache(filepath.Join(t.TempDir(), "prs"), time.Hour)
+	v1 := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	v2 := v1.Add(time.Hour)
+
+	if cache.Get("shhac", "prtea", 7, v1) != nil {
+		t.Fatal("empty cache should miss")
+	}
+	if err := cache.Update("shhac", "prtea", 7, v1, func(c *CachedPR) { c.Files = []PRFile{{Filename: "a.go"}} }); err != nil {
+		t.Fatalf("update: %v", err)
+	}
+	if err := cache.Update("shhac", "prtea", 7, v1, func(c *CachedPR) { c.CI = &CIStatus{OverallStatus: "passing"} }); err != nil {
+		t.Fatalf("update: %v", err)
+	}
+	got := cache.Get("shhac", "prtea", 7, v1)
+	if got == nil || len(got.Files) != 1 || got.CI == nil || got.CI.OverallStatus != "passing" {
+		t.Fatalf("cached = %+v, want files and CI", got)
+	}
+
+	// Data for a newer updatedAt replaces the entry rather than merging.
+	if err := cache.Update("shhac", "prtea", 7, v2, func(c *CachedPR) { c.Reviews = &ReviewSummary{} }); err != nil {
+		t.Fatalf("update: %v", err)
+	}
+	if cache.Get("shhac", "prtea", 7, v1) != nil {
+		t.Error("the old updatedAt should miss")
+	}
+	if got := cache.Get("shhac", "prtea", 7, v2); got == nil || got.Files != nil || got.Reviews == nil {
+		t.Errorf("cached = %+v, want only reviews", got)
+	}
+
+	expired := NewPRCache(cache.dir, -time.Second)
+	if expired.Get("shhac", "prtea", 7, v2) != nil {
+		t.Error("entries older than the TTL should miss")
+	}
+}
@@ -0,0 +1,198 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// graphQLSearchLimit is the most nodes a single GraphQL search can return.
+const graphQLSearchLimit = 100
+
+// errFetchLimitTooLarge means the configured fetch limit needs paginated
+// REST searches instead of one GraphQL round trip.
+var errFetchLimitTooLarge = errors.New("fetch limit exceeds a single GraphQL search")
+
+// ghGraphQLPR is the GraphQL shape of a PR search result node.
+type ghGraphQLPR struct {
+	Number       int       `json:"number"`
+	Title        string    `json:"title"`
+	URL          string    `json:"url"`
+	CreatedAt    time.Time `json:"createdAt"`
+	UpdatedAt    time.Time `json:"updatedAt"`
+	IsDraft      bool      `json:"isDraft"`
+	Additions    int       `json:"additions"`
+	Deletions    int       `json:"deletions"`
+	ChangedFiles int       `json:"changedFiles"`
+	Author       struct {
+		Login string `json:"login"`
+	} `json:"author"`
+	Repository struct {
+		Name          string `json:"name"`
+		NameWithOwner string `json:"nameWithOwner"`
+	} `json:"repository"`
+	Labels struct {
+		Nodes []struct {
+			Name  string `json:"name"`
+			Color string `json:"color"`
+		} `json:"nodes"`
+	} `json:"labels"`
+	Comments struct {
+		TotalCount int `json:"totalCount"`
+	} `json:"comments"`
+	ReviewDecision string `json:"reviewDecision"`
+	BaseRefName    string `json:"baseRefName"`
+	HeadRefName    string `json:"headRefName"`
+	AutoMerge      *struct {
+		MergeMethod string `json:"mergeMethod"`
+	} `json:"autoMergeRequest"`
+	Commits struct {
+		Nodes []struct {
+			Commit struct {
+				StatusCheckRollup *struct {
+					State string `json:"state"`
+				} `json:"statusCheckRollup"`
+			} `json:"commit"`
+		} `json:"nodes"`
+	} `json:"commits"`
+}
+
+// ghPRLists is the GraphQL response shape for prListsQuery.
+type ghPRLists struct {
+	Data struct {
+		ToReview struct {
+			Nodes []ghGraphQLPR `json:"nodes"`
+		} `json:"toReview"`
+		Mine struct {
+			Nodes []ghGraphQLPR `json:"nodes"`
+		} `json:"mine"`
+	} `json:"data"`
+}
+
+// Search qualifiers of the To Review and My PRs lists.
+const (
+	toReviewSearch = "is:pr is:open review-requested:@me"
+	mineSearch     = "is:pr is:open author:@me"
+)
+
+// prListsQuery fetches both PR lists, with review decisions, CI rollups,
+// auto-merge, labels, and comment counts, in a single round trip.
+const prListsQuery = `query($limit: Int!, $toReview: String!, $mine: String!) {
+  toReview: search(query: $toReview, type: ISSUE, first: $limit) {
+    nodes { ...prFields }
+  }
+  mine: search(query: $mine, type: ISSUE, first: $limit) {
+    nodes { ...prFields }
+  }
+}
+fragment prFields on PullRequest {
+  number title url createdAt updatedAt isDraft additions deletions changedFiles
+  author { login }
+  repository { name nameWithOwner }
+  labels(first: 20) { nodes { name color } }
+  comments { totalCount }
+  reviewDecision baseRefName headRefName
+  autoMergeRequest { mergeMethod }
+  commits(last: 1) { nodes { commit { statusCheckRollup { state } } } }
+}`
+
+// GetPRLists returns the To Review and My PRs lists, including review
+// decisions and CI status, using one GraphQL query. Callers should fall back
+// to GetPRsForReview and GetMyPRs on error.
+func (c *Client) GetPRLists(ctx context.Context) (toReview, mine []PRItem, err error) {
+	limit := c.FetchLimit
+	if limit <= 0 {
+		limit = graphQLSearchLimit
+	}
+	if limit > graphQLSearchLimit {
+		return nil, nil, errFetchLimitTooLarge
+	}
+	return c.searchPRLists(ctx, limit, "")
+}
+
+// GetPRListsSince returns the PRs of both lists updated at or after since,
+// for polls that only need what changed. PRs that left a list, e.g. by
+// being merged, are not returned; a full GetPRLists finds those.
+func (c *Client) GetPRListsSince(ctx context.Context, since time.Time) (toReview, mine []PRItem, err error) {
+	return c.searchPRLists(ctx, graphQLSearchLimit, " updated:>="+since.UTC().Format(time.RFC3339))
+}
+
+// searchPRLists runs prListsQuery with extra search qualifiers.
+func (c *Client) searchPRLists(ctx context.Context, limit int, qualifiers string) (toReview, mine []PRItem, err error) {
+	var resp ghPRLists
+	err = c.ghJSON(ctx, &resp,
+		"api", "graphql",
+		"-f", "query="+prListsQuery,
+		"-F", fmt.Sprintf("limit=%d", limit),
+		"-f", "toReview="+toReviewSearch+qualifiers,
+		"-f", "mine="+mineSearch+qualifiers,
+	)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to fetch PR lists: %w", err)
+	}
+	return convertGraphQLPRs(resp.Data.ToReview.Nodes), convertGraphQLPRs(resp.Data.Mine.Nodes), nil
+}
+
+func convertGraphQLPRs(nodes []ghGraphQLPR) []PRItem {
+	prs := make([]PRItem, 0, len(nodes))
+	for _, n := range nodes {
+		if n.Number == 0 {
+			continue // search can return non-PR nodes as empty objects
+		}
+		owner, name := parseNameWithOwner(n.Repository.NameWithOwner)
+
+		labels := make([]Label, 0, len(n.Labels.Nodes))
+		for _, l := range n.Labels.Nodes {
+			labels = append(labels, Label{Name: l.Name, Color: l.Color})
+		}
+
+		ci := ""
+		if len(n.Commits.Nodes) > 0 && n.Commits.Nodes[0].Commit.StatusCheckRollup != nil {
+			ci = rollupStateStatus(n.Commits.Nodes[0].Commit.StatusCheckRollup.State)
+		}
+
+		autoMerge := ""
+		if n.AutoMerge != nil {
+			autoMerge = n.AutoMerge.MergeMethod
+		}
+
+		prs = append(prs, PRItem{
+			Number:         n.Number,
+			Title:          n.Title,
+			HTMLURL:        n.URL,
+			Repo:           Repo{Owner: owner, Name: name, FullName: n.Repository.NameWithOwner},
+			Author:         User{Login: n.Author.Login},
+			Labels:         labels,
+			Draft:          n.IsDraft,
+			CreatedAt:      n.CreatedAt,
+			UpdatedAt:      n.UpdatedAt,
+			Additions:      n.Additions,
+			Deletions:      n.Deletions,
+			ChangedFiles:   n.ChangedFiles,
+			CommentsCount:  n.Comments.TotalCount,
+			ReviewDecision: n.ReviewDecision,
+			CIStatus:       ci,
+			BaseBranch:     n.BaseRefName,
+			HeadBranch:     n.HeadRefName,
+			AutoMerge:      autoMerge,
+		})
+	}
+	return prs
+}
+
+// rollupStateStatus maps a GraphQL StatusState to the CIStatus.OverallStatus
+// vocabulary ("passing", "failing", "pending").
+func rollupStateStatus(state string) string {
+	switch strings.ToUpper(state) {
+	case "SUCCESS":
+		return "passing"
+	case "FAILURE", "ERROR":
+		return "failing"
+	case "PENDING", "EXPECTED":
+		return "pending"
+	default:
+		return ""
+	}
+}
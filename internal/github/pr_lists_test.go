@@ -0,0 +1,117 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestGetPRLists(t *testing.T) {
+	resp := `{"data": {
+		"toReview": {"nodes": [
+			{"number": 42, "title": "Add frobnicate", "url": "https://github.com/alice/widget-factory/pull/42",
+			 "author": {"login": "bob"},
+			 "repository": {"name": "widget-factory", "nameWithOwner": "alice/widget-factory"},
+			 "labels": {"nodes": [{"name": "bug", "color": "d73a4a"}]},
+			 "comments": {"totalCount": 3},
+			 "reviewDecision": "REVIEW_REQUIRED", "baseRefName": "main", "headRefName": "frobnicate",
+			 "commits": {"nodes": [{"commit": {"statusCheckRollup": {"state": "FAILURE"}}}]}},
+			{}
+		]},
+		"mine": {"nodes": [
+			{"number": 7, "title": "Mine", "author": {"login": "alice"},
+			 "repository": {"name": "api", "nameWithOwner": "alice/api"},
+			 "reviewDecision": "APPROVED", "autoMergeRequest": {"mergeMethod": "SQUASH"},
+			 "commits": {"nodes": [{"commit": {"statusCheckRollup": null}}]}}
+		]}
+	}}`
+	client := NewTestClient("alice", fakeRunner(map[string]string{
+		"api graphql": resp,
+	}))
+
+	toReview, mine, err := client.GetPRLists(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(toReview) != 1 {
+		t.Fatalf("toReview len = %d, want 1 (empty non-PR node skipped)", len(toReview))
+	}
+	pr := toReview[0]
+	if pr.Number != 42 || pr.Repo.Owner != "alice" || pr.Author.Login != "bob" {
+		t.Errorf("unexpected PR: %+v", pr)
+	}
+	if pr.CommentsCount != 3 || pr.ReviewDecision != "REVIEW_REQUIRED" || pr.CIStatus != "failing" {
+		t.Errorf("comments/decision/CI = %d/%q/%q", pr.CommentsCount, pr.ReviewDecision, pr.CIStatus)
+	}
+	if pr.BaseBranch != "main" || pr.HeadBranch != "frobnicate" {
+		t.Errorf("branches = %q <- %q", pr.BaseBranch, pr.HeadBranch)
+	}
+	if len(pr.Labels) != 1 || pr.Labels[0].Name != "bug" {
+		t.Errorf("labels = %+v", pr.Labels)
+	}
+	if len(mine) != 1 || mine[0].ReviewDecision != "APPROVED" || mine[0].CIStatus != "" || mine[0].AutoMerge != MergeMethodSquash {
+		t.Errorf("mine = %+v", mine)
+	}
+	if pr.AutoMerge != "" {
+		t.Errorf("auto-merge = %q, want off", pr.AutoMerge)
+	}
+	if !strings.Contains(prListsQuery, "autoMergeRequest { mergeMethod }") {
+		t.Error("the list query should fetch auto-merge")
+	}
+}
+
+func TestGetPRListsSince(t *testing.T) {
+	var got string
+	client := NewTestClient("alice", func(ctx context.Context, args ...string) (string, error) {
+		got = strings.Join(args, " ")
+		return `{"data": {"toReview": {"nodes": []}, "mine": {"nodes": [
+			{"number": 7, "title": "Mine", "updatedAt": "2026-10-01T12:30:00Z",
+			 "repository": {"name": "api", "nameWithOwner": "alice/api"}}
+		]}}}`, nil
+	})
+	client.SetFetchLimit(200) // deltas are small; the GraphQL limit still applies
+
+	since := time.Date(2026, 10, 1, 14, 0, 0, 0, time.FixedZone("CEST", 2*3600))
+	toReview, mine, err := client.GetPRListsSince(context.Background(), since)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, want := range []string{
+		"toReview=is:pr is:open review-requested:@me updated:>=2026-10-01T12:00:00Z",
+		"mine=is:pr is:open author:@me updated:>=2026-10-01T12:00:00Z",
+		"limit=100",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("args %q missing %q", got, want)
+		}
+	}
+	if len(toReview) != 0 || len(mine) != 1 || !mine[0].UpdatedAt.Equal(since.Add(30*time.Minute)) {
+		t.Errorf("toReview = %+v, mine = %+v", toReview, mine)
+	}
+}
+
+func TestGetPRLists_LimitTooLarge(t *testing.T) {
+	client := NewTestClient("alice", fakeErrorRunner("should not be called"))
+	client.SetFetchLimit(200)
+	if _, _, err := client.GetPRLists(context.Background()); !errors.Is(err, errFetchLimitTooLarge) {
+		t.Errorf("err = %v, want errFetchLimitTooLarge", err)
+	}
+}
+
+func TestRollupStateStatus(t *testing.T) {
+	tests := map[string]string{
+		"SUCCESS":  "passing",
+		"FAILURE":  "failing",
+		"ERROR":    "failing",
+		"PENDING":  "pending",
+		"EXPECTED": "pending",
+		"":         "",
+	}
+	for state, want := range tests {
+		if got := rollupStateStatus(state); got != want {
+			t.Errorf("rollupStateStatus(%q) = %q, want %q", state, got, want)
+		}
+	}
+}
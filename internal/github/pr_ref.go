@@ -0,0 +1,40 @@
+package github
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+)
+
+// PRRef identifies a pull request, e.g. as given on the command line.
+type PRRef struct {
+	Owner  string
+	Repo   string
+	Number int
+}
+
+func (r PRRef) String() string {
+	return fmt.Sprintf("%s/%s#%d", r.Owner, r.Repo, r.Number)
+}
+
+var (
+	prRefShort = regexp.MustCompile(`^([\w.-]+)/([\w.-]+)#(\d+)$`)
+	prRefURL   = regexp.MustCompile(`^https?://[^/]+/([\w.-]+)/([\w.-]+)/pull/(\d+)(?:[/?#].*)?$`)
+)
+
+// ParsePRRef parses "owner/repo#123" or a pull request URL such as
+// "https://github.com/owner/repo/pull/123".
+func ParsePRRef(s string) (PRRef, error) {
+	m := prRefShort.FindStringSubmatch(s)
+	if m == nil {
+		m = prRefURL.FindStringSubmatch(s)
+	}
+	if m == nil {
+		return PRRef{}, fmt.Errorf("invalid pull request %q: want owner/repo#123 or a PR URL", s)
+	}
+	n, err := strconv.Atoi(m[3])
+	if err != nil || n <= 0 {
+		return PRRef{}, fmt.Errorf("invalid pull request number in %q", s)
+	}
+	return PRRef{Owner: m[1], Repo: m[2], Number: n}, nil
+}
@@ -0,0 +1,35 @@
+package github
+
+import "testing"
+
+func TestParsePRRef(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    PRRef
+		wantErr bool
+	}{
+		{in: "shhac/prtea#123", want: PRRef{"shhac", "prtea", 123}},
+		{in: "my-org/my.repo#7", want: PRRef{"my-org", "my.repo", 7}},
+		{in: "https://github.com/shhac/prtea/pull/42", want: PRRef{"shhac", "prtea", 42}},
+		{in: "https://github.example.com/a/b/pull/9/files", want: PRRef{"a", "b", 9}},
+		{in: "shhac/prtea", wantErr: true},
+		{in: "prtea#1", wantErr: true},
+		{in: "shhac/prtea#0", wantErr: true},
+		{in: "https://github.com/shhac/prtea/issues/4", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := ParsePRRef(tt.in)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("ParsePRRef(%q) = %+v, want error", tt.in, got)
+			}
+			continue
+		}
+		if err != nil || got != tt.want {
+			t.Errorf("ParsePRRef(%q) = %+v, %v; want %+v", tt.in, got, err, tt.want)
+		}
+	}
+	if s := (PRRef{"shhac", "prtea", 5}).String(); s != "shhac/prtea#5" {
+		t.Errorf("String() = %q", s)
+	}
+}
@@ -14,7 +14,9 @@ type ghSearchPR struct {
 	Title     string    `json:"title"`
 	URL       string    `json:"url"`
 	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
 	IsDraft   bool      `json:"isDraft"`
+	Comments  int       `json:"commentsCount"`
 	Author    struct {
 		Login string `json:"login"`
 	} `json:"author"`
@@ -35,6 +37,8 @@ type ghPRView struct {
 	Body           string `json:"body"`
 	URL            string `json:"url"`
 	Mergeable      string `json:"mergeable"` // "MERGEABLE", "CONFLICTING", "UNKNOWN"
+	IsDraft        bool   `json:"isDraft"`
+	State          string `json:"state"` // "OPEN", "CLOSED", "MERGED"
 	MergeStateStatus string `json:"mergeStateStatus"`
 	BaseRefName    string `json:"baseRefName"`
 	HeadRefName    string `json:"headRefName"`
@@ -42,12 +46,22 @@ type ghPRView struct {
 	Author         struct {
 		Login string `json:"login"`
 	} `json:"author"`
+	AutoMergeRequest *struct {
+		MergeMethod string `json:"mergeMethod"`
+	} `json:"autoMergeRequest"`
+	HeadRepository *struct {
+		Name string `json:"name"`
+	} `json:"headRepository"`
+	HeadRepositoryOwner *struct {
+		Login string `json:"login"`
+	} `json:"headRepositoryOwner"`
 }
 
 // ghCompare is the JSON shape from the compare API.
 type ghCompare struct {
-	AheadBy  int `json:"ahead_by"`
-	BehindBy int `json:"behind_by"`
+	Status   string `json:"status"`
+	AheadBy  int    `json:"ahead_by"`
+	BehindBy int    `json:"behind_by"`
 }
 
 // fetchLimit returns the configured PR fetch limit, falling back to 100.
@@ -66,7 +80,7 @@ func (c *Client) GetPRsForReview(ctx context.Context) ([]PRItem, error) {
 		"--review-requested=@me",
 		"--state=open",
 		"--limit", c.fetchLimit(),
-		"--json", "number,title,url,createdAt,isDraft,author,repository,labels",
+		"--json", "number,title,url,createdAt,updatedAt,isDraft,commentsCount,author,repository,labels",
 	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to search PRs for review: %w", err)
@@ -82,7 +96,7 @@ func (c *Client) GetMyPRs(ctx context.Context) ([]PRItem, error) {
 		"--author=@me",
 		"--state=open",
 		"--limit", c.fetchLimit(),
-		"--json", "number,title,url,createdAt,isDraft,author,repository,labels",
+		"--json", "number,title,url,createdAt,updatedAt,isDraft,commentsCount,author,repository,labels",
 	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to search my PRs: %w", err)
@@ -90,6 +104,27 @@ func (c *Client) GetMyPRs(ctx context.Context) ([]PRItem, error) {
 	return convertSearchResults(results), nil
 }
 
+// recentlyClosedLimit caps the "Recently closed" list of the user's PRs.
+const recentlyClosedLimit = 20
+
+// GetRecentlyClosedPRs returns the authenticated user's closed, unmerged PRs,
+// most recently updated first, so they can be reopened.
+func (c *Client) GetRecentlyClosedPRs(ctx context.Context) ([]PRItem, error) {
+	var results []ghSearchPR
+	err := c.ghJSON(ctx, &results,
+		"search", "prs", "is:unmerged",
+		"--author=@me",
+		"--state=closed",
+		"--sort=updated",
+		"--limit", strconv.Itoa(recentlyClosedLimit),
+		"--json", "number,title,url,createdAt,updatedAt,isDraft,commentsCount,author,repository,labels",
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search closed PRs: %w", err)
+	}
+	return convertSearchResults(results), nil
+}
+
 // GetPRDetail fetches full PR information including mergeable state and behind-by count.
 func (c *Client) GetPRDetail(ctx context.Context, owner, repo string, number int) (*PRDetail, error) {
 	repoFlag := owner + "/" + repo
@@ -98,7 +133,7 @@ func (c *Client) GetPRDetail(ctx context.Context, owner, repo string, number int
 	err := c.ghJSON(ctx, &pr,
 		"pr", "view", fmt.Sprintf("%d", number),
 		"-R", repoFlag,
-		"--json", "number,title,body,url,mergeable,mergeStateStatus,baseRefName,headRefName,headRefOid,author",
+		"--json", "number,title,body,url,isDraft,state,mergeable,mergeStateStatus,baseRefName,headRefName,headRefOid,author,autoMergeRequest,headRepository,headRepositoryOwner",
 	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get PR #%d: %w", number, err)
@@ -108,12 +143,23 @@ func (c *Client) GetPRDetail(ctx context.Context, owner, repo string, number int
 	behindBy := 0
 	var cmp ghCompare
 	endpoint := fmt.Sprintf("repos/%s/%s/compare/%s...%s", owner, repo, pr.HeadRefName, pr.BaseRefName)
-	if err := c.ghJSON(ctx, &cmp, "api", endpoint); err != nil {
+	if err := c.ghAPIJSON(ctx, &cmp, endpoint, false); err != nil {
 		behindBy = -1
 	} else {
 		behindBy = cmp.AheadBy
 	}
 
+	autoMerge := ""
+	if pr.AutoMergeRequest != nil {
+		autoMerge = pr.AutoMergeRequest.MergeMethod
+	}
+
+	// The head branch lives in the base repo unless the PR comes from a fork.
+	headRepo := repoFlag
+	if pr.HeadRepository != nil && pr.HeadRepositoryOwner != nil {
+		headRepo = pr.HeadRepositoryOwner.Login + "/" + pr.HeadRepository.Name
+	}
+
 	return &PRDetail{
 		Number:         pr.Number,
 		Title:          pr.Title,
@@ -124,9 +170,13 @@ func (c *Client) GetPRDetail(ctx context.Context, owner, repo string, number int
 		BaseBranch:     pr.BaseRefName,
 		HeadBranch:     pr.HeadRefName,
 		HeadSHA:        pr.HeadRefOid,
+		HeadRepo:       headRepo,
+		State:          pr.State,
+		Draft:          pr.IsDraft,
 		Mergeable:      pr.Mergeable == "MERGEABLE",
 		MergeableState: pr.MergeStateStatus,
 		BehindBy:       behindBy,
+		AutoMerge:      autoMerge,
 	}, nil
 }
 
@@ -141,14 +191,16 @@ func convertSearchResults(results []ghSearchPR) []PRItem {
 		}
 
 		prs = append(prs, PRItem{
-			Number:    r.Number,
-			Title:     r.Title,
-			HTMLURL:   r.URL,
-			Repo:      Repo{Owner: owner, Name: name, FullName: r.Repository.NameWithOwner},
-			Author:    User{Login: r.Author.Login},
-			Labels:    labels,
-			Draft:     r.IsDraft,
-			CreatedAt: r.CreatedAt,
+			Number:        r.Number,
+			Title:         r.Title,
+			HTMLURL:       r.URL,
+			Repo:          Repo{Owner: owner, Name: name, FullName: r.Repository.NameWithOwner},
+			Author:        User{Login: r.Author.Login},
+			Labels:        labels,
+			Draft:         r.IsDraft,
+			CreatedAt:     r.CreatedAt,
+			UpdatedAt:     r.UpdatedAt,
+			CommentsCount: r.Comments,
 		})
 	}
 	return prs
@@ -56,6 +56,21 @@ func TestGetPRDetail_Success(t *testing.T) {
 	}
 }
 
+func TestGetPRDetail_AutoMerge(t *testing.T) {
+	client := NewTestClient("alice", fakeRunner(map[string]string{
+		"pr view 42": `{"number":42,"mergeable":"MERGEABLE","autoMergeRequest":{"mergeMethod":"SQUASH"}}`,
+		"api repos/": `{"ahead_by":0}`,
+	}))
+
+	detail, err := client.GetPRDetail(context.Background(), "alice", "widget", 42)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if detail.AutoMerge != "SQUASH" {
+		t.Errorf("AutoMerge = %q, want SQUASH", detail.AutoMerge)
+	}
+}
+
 func TestGetPRDetail_CompareAPIFailure(t *testing.T) {
 	prView := ghPRView{
 		Number:      7,
@@ -0,0 +1,39 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// RateLimit is the core REST API quota for the authenticated user.
+type RateLimit struct {
+	Limit     int
+	Remaining int
+	Reset     time.Time
+}
+
+type ghRateLimit struct {
+	Resources struct {
+		Core struct {
+			Limit     int   `json:"limit"`
+			Remaining int   `json:"remaining"`
+			Reset     int64 `json:"reset"`
+		} `json:"core"`
+	} `json:"resources"`
+}
+
+// GetRateLimit fetches the current API quota. The rate_limit endpoint does
+// not count against the quota itself.
+func (c *Client) GetRateLimit(ctx context.Context) (*RateLimit, error) {
+	var resp ghRateLimit
+	if err := c.ghJSON(ctx, &resp, "api", "rate_limit"); err != nil {
+		return nil, fmt.Errorf("failed to fetch rate limit: %w", err)
+	}
+	core := resp.Resources.Core
+	return &RateLimit{
+		Limit:     core.Limit,
+		Remaining: core.Remaining,
+		Reset:     time.Unix(core.Reset, 0),
+	}, nil
+}
@@ -0,0 +1,151 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"sort"
+)
+
+// SecurityAlert is a security finding introduced by a PR: a code scanning
+// alert open on the PR head but not on its base, or a vulnerable dependency
+// the PR adds.
+type SecurityAlert struct {
+	Source   string // "code scanning" or "dependency"
+	Severity string // critical, high, medium, low (or error, warning, note for non-security rules)
+	Title    string // rule description, or "package@version: advisory summary"
+	Path     string // file (or manifest) the alert points at
+	Line     int    // 0 when the alert has no line
+	URL      string
+}
+
+// severityRank orders alert severities, most severe first.
+var severityRank = map[string]int{"critical": 0, "high": 1, "error": 1, "medium": 2, "moderate": 2, "warning": 2, "low": 3, "note": 4}
+
+// SeverityRank returns the sort rank of an alert severity; lower is worse.
+func SeverityRank(severity string) int {
+	if r, ok := severityRank[severity]; ok {
+		return r
+	}
+	return len(severityRank)
+}
+
+// ghCodeScanningAlert is the JSON shape from the code scanning alerts API.
+type ghCodeScanningAlert struct {
+	Number  int    `json:"number"`
+	HTMLURL string `json:"html_url"`
+	Rule    struct {
+		Description           string `json:"description"`
+		Severity              string `json:"severity"`
+		SecuritySeverityLevel string `json:"security_severity_level"`
+	} `json:"rule"`
+	MostRecentInstance struct {
+		Location struct {
+			Path      string `json:"path"`
+			StartLine int    `json:"start_line"`
+		} `json:"location"`
+	} `json:"most_recent_instance"`
+}
+
+// ghDependencyChange is the JSON shape from the dependency review API.
+type ghDependencyChange struct {
+	ChangeType      string `json:"change_type"`
+	Manifest        string `json:"manifest"`
+	Name            string `json:"name"`
+	Version         string `json:"version"`
+	Vulnerabilities []struct {
+		Severity        string `json:"severity"`
+		AdvisorySummary string `json:"advisory_summary"`
+		AdvisoryURL     string `json:"advisory_url"`
+	} `json:"vulnerabilities"`
+}
+
+// GetSecurityAlerts returns the security alerts a PR introduces, most severe
+// first: code scanning alerts open on the PR head but not on base, and
+// known vulnerabilities in dependencies the PR adds. Either source may be
+// unavailable (not enabled, or no access); an error is returned only when
+// both fail.
+func (c *Client) GetSecurityAlerts(ctx context.Context, owner, repo, base, headSHA string, number int) ([]SecurityAlert, error) {
+	scanning, scanErr := c.codeScanningAlerts(ctx, owner, repo, base, number)
+	deps, depErr := c.vulnerableDependencies(ctx, owner, repo, base, headSHA)
+	if scanErr != nil && depErr != nil {
+		return nil, errors.Join(scanErr, depErr)
+	}
+	alerts := append(scanning, deps...)
+	sort.SliceStable(alerts, func(i, j int) bool {
+		return SeverityRank(alerts[i].Severity) < SeverityRank(alerts[j].Severity)
+	})
+	return alerts, nil
+}
+
+// codeScanningAlerts returns the open code scanning alerts on the PR head
+// that are not also open on base.
+func (c *Client) codeScanningAlerts(ctx context.Context, owner, repo, base string, number int) ([]SecurityAlert, error) {
+	list := func(ref string) ([]ghCodeScanningAlert, error) {
+		var raw []ghCodeScanningAlert
+		endpoint := fmt.Sprintf("repos/%s/%s/code-scanning/alerts?ref=%s&state=open&per_page=100", owner, repo, url.QueryEscape(ref))
+		if err := c.ghAPIJSON(ctx, &raw, endpoint, false); err != nil {
+			return nil, fmt.Errorf("failed to list code scanning alerts for %s: %w", ref, err)
+		}
+		return raw, nil
+	}
+	head, err := list(fmt.Sprintf("refs/pull/%d/head", number))
+	if err != nil {
+		return nil, err
+	}
+	if len(head) == 0 {
+		return nil, nil
+	}
+	onBase := make(map[int]bool)
+	if baseAlerts, err := list("refs/heads/" + base); err == nil {
+		for _, a := range baseAlerts {
+			onBase[a.Number] = true
+		}
+	}
+	var alerts []SecurityAlert
+	for _, a := range head {
+		if onBase[a.Number] {
+			continue
+		}
+		severity := a.Rule.SecuritySeverityLevel
+		if severity == "" {
+			severity = a.Rule.Severity
+		}
+		alerts = append(alerts, SecurityAlert{
+			Source:   "code scanning",
+			Severity: severity,
+			Title:    a.Rule.Description,
+			Path:     a.MostRecentInstance.Location.Path,
+			Line:     a.MostRecentInstance.Location.StartLine,
+			URL:      a.HTMLURL,
+		})
+	}
+	return alerts, nil
+}
+
+// vulnerableDependencies returns the known vulnerabilities of dependencies
+// added between base and head, per the dependency review API.
+func (c *Client) vulnerableDependencies(ctx context.Context, owner, repo, base, head string) ([]SecurityAlert, error) {
+	var changes []ghDependencyChange
+	endpoint := fmt.Sprintf("repos/%s/%s/dependency-graph/compare/%s...%s", owner, repo, base, head)
+	if err := c.ghAPIJSON(ctx, &changes, endpoint, false); err != nil {
+		return nil, fmt.Errorf("failed to review dependency changes: %w", err)
+	}
+	var alerts []SecurityAlert
+	for _, ch := range changes {
+		if ch.ChangeType != "added" {
+			continue
+		}
+		for _, v := range ch.Vulnerabilities {
+			alerts = append(alerts, SecurityAlert{
+				Source:   "dependency",
+				Severity: v.Severity,
+				Title:    fmt.Sprintf("%s@%s: %s", ch.Name, ch.Version, v.AdvisorySummary),
+				Path:     ch.Manifest,
+				URL:      v.AdvisoryURL,
+			})
+		}
+	}
+	return alerts, nil
+}
@@ -0,0 +1,52 @@
+package github
+
+import (
+	"context"
+	"testing"
+)
+
+func TestGetSecurityAlerts(t *testing.T) {
+	client := NewTestClient("alice", fakeRunner(map[string]string{
+		"code-scanning/alerts?ref=refs%2Fpull%2F7%2Fhead": `[
+			{"number": 4, "html_url": "https://github.com/shhac/prtea/security/code-scanning/4",
+			 "rule": {"description": "SQL injection", "severity": "error", "security_severity_level": "high"},
+			 "most_recent_instance": {"location": {"path": "db/query.go", "start_line": 42}}},
+			{"number": 2, "rule": {"description": "Old finding", "severity": "warning"},
+			 "most_recent_instance": {"location": {"path": "main.go", "start_line": 3}}}
+		]`,
+		"code-scanning/alerts?ref=refs%2Fheads%2Fmain": `[{"number": 2}]`,
+		"dependency-graph/compare/main...abc123": `[
+			{"change_type": "added", "manifest": "go.mod", "name": "example.com/yaml", "version": "1.0.0",
+			 "vulnerabilities": [{"severity": "critical", "advisory_summary": "RCE in parser", "advisory_url": "https://github.com/advisories/GHSA-x"}]},
+			{"change_type": "removed", "manifest": "go.mod", "name": "example.com/old", "version": "0.1.0",
+			 "vulnerabilities": [{"severity": "high", "advisory_summary": "fixed by removal"}]}
+		]`,
+	}))
+	alerts, err := client.GetSecurityAlerts(context.Background(), "shhac", "prtea", "main", "abc123", 7)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(alerts) != 2 {
+		t.Fatalf("got %+v, want the new scanning alert and the added vulnerable dependency", alerts)
+	}
+	if a := alerts[0]; a.Source != "dependency" || a.Severity != "critical" || a.Path != "go.mod" || a.Title != "example.com/yaml@1.0.0: RCE in parser" {
+		t.Errorf("first alert = %+v, want the critical dependency", a)
+	}
+	if a := alerts[1]; a.Source != "code scanning" || a.Severity != "high" || a.Path != "db/query.go" || a.Line != 42 {
+		t.Errorf("second alert = %+v", a)
+	}
+}
+
+func TestGetSecurityAlerts_OneSourceUnavailable(t *testing.T) {
+	client := NewTestClient("alice", fakeRunner(map[string]string{
+		"dependency-graph/compare": `[]`,
+	}))
+	alerts, err := client.GetSecurityAlerts(context.Background(), "shhac", "prtea", "main", "abc123", 7)
+	if err != nil || len(alerts) != 0 {
+		t.Errorf("alerts = %+v, err = %v; code scanning being off should not fail the call", alerts, err)
+	}
+	client = NewTestClient("alice", fakeErrorRunner("HTTP 403"))
+	if _, err := client.GetSecurityAlerts(context.Background(), "shhac", "prtea", "main", "abc123", 7); err == nil {
+		t.Error("expected an error when both sources fail")
+	}
+}
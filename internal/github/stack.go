@@ -0,0 +1,162 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+)
+
+// StackPR is an open PR with the branch and body references that tie it
+// into a stack of dependent PRs.
+type StackPR struct {
+	Number     int
+	Title      string
+	HTMLURL    string
+	BaseBranch string
+	HeadBranch string
+	Draft      bool
+	DependsOn  []int // PRs named by "Depends on #N" in the body
+}
+
+// StackMember is one PR of a stack, with its depth below the stack's root.
+type StackMember struct {
+	StackPR
+	Depth int
+}
+
+// ghStackPR is the JSON shape returned by gh pr list for stack detection.
+type ghStackPR struct {
+	Number      int    `json:"number"`
+	Title       string `json:"title"`
+	URL         string `json:"url"`
+	IsDraft     bool   `json:"isDraft"`
+	BaseRefName string `json:"baseRefName"`
+	HeadRefName string `json:"headRefName"`
+	Body        string `json:"body"`
+}
+
+// dependsOnRe matches body references to a PR this one builds on.
+var dependsOnRe = regexp.MustCompile(`(?i)\b(?:depends on|based on|stacked on|blocked by)\s+#(\d+)`)
+
+// ParseDependsOn returns the PR numbers a body says the PR depends on, such
+// as "Depends on #12", in order of first mention.
+func ParseDependsOn(body string) []int {
+	var deps []int
+	seen := make(map[int]bool)
+	for _, m := range dependsOnRe.FindAllStringSubmatch(body, -1) {
+		n, err := strconv.Atoi(m[1])
+		if err != nil || seen[n] {
+			continue
+		}
+		seen[n] = true
+		deps = append(deps, n)
+	}
+	return deps
+}
+
+// GetOpenPRStackInfo returns a repository's open PRs with the branches and
+// dependencies used to detect stacks. At most 100 PRs are listed.
+func (c *Client) GetOpenPRStackInfo(ctx context.Context, owner, repo string) ([]StackPR, error) {
+	var results []ghStackPR
+	err := c.ghJSON(ctx, &results,
+		"pr", "list",
+		"-R", owner+"/"+repo,
+		"--state=open",
+		"--limit", "100",
+		"--json", "number,title,url,isDraft,baseRefName,headRefName,body",
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list open PRs in %s/%s: %w", owner, repo, err)
+	}
+	prs := make([]StackPR, 0, len(results))
+	for _, r := range results {
+		prs = append(prs, StackPR{
+			Number:     r.Number,
+			Title:      r.Title,
+			HTMLURL:    r.URL,
+			BaseBranch: r.BaseRefName,
+			HeadBranch: r.HeadRefName,
+			Draft:      r.IsDraft,
+			DependsOn:  ParseDependsOn(r.Body),
+		})
+	}
+	return prs, nil
+}
+
+// stackParent returns the PR that pr builds on: the open PR whose head
+// branch is pr's base branch, or else the first open PR its body depends on.
+func stackParent(prs map[int]StackPR, pr StackPR) (StackPR, bool) {
+	var byBranch []int
+	for n, p := range prs {
+		if n != pr.Number && p.HeadBranch != "" && p.HeadBranch == pr.BaseBranch {
+			byBranch = append(byBranch, n)
+		}
+	}
+	if len(byBranch) > 0 {
+		sort.Ints(byBranch)
+		return prs[byBranch[0]], true
+	}
+	for _, n := range pr.DependsOn {
+		if p, ok := prs[n]; ok && n != pr.Number {
+			return p, true
+		}
+	}
+	return StackPR{}, false
+}
+
+// BuildStack returns the stack containing PR number, root first and each
+// PR followed by the PRs that build on it, or nil if the PR is not stacked.
+func BuildStack(prs []StackPR, number int) []StackMember {
+	byNumber := make(map[int]StackPR, len(prs))
+	for _, pr := range prs {
+		byNumber[pr.Number] = pr
+	}
+	pr, ok := byNumber[number]
+	if !ok {
+		return nil
+	}
+
+	parents := make(map[int]int) // child number → parent number
+	children := make(map[int][]int)
+	for n, p := range byNumber {
+		if parent, ok := stackParent(byNumber, p); ok {
+			parents[n] = parent.Number
+			children[parent.Number] = append(children[parent.Number], n)
+		}
+	}
+
+	// Follow parents to the root, stopping at a cycle.
+	root := pr.Number
+	seen := map[int]bool{root: true}
+	for {
+		parent, ok := parents[root]
+		if !ok || seen[parent] {
+			break
+		}
+		seen[parent] = true
+		root = parent
+	}
+
+	var stack []StackMember
+	visited := make(map[int]bool)
+	var walk func(n, depth int)
+	walk = func(n, depth int) {
+		if visited[n] {
+			return
+		}
+		visited[n] = true
+		stack = append(stack, StackMember{StackPR: byNumber[n], Depth: depth})
+		kids := children[n]
+		sort.Ints(kids)
+		for _, k := range kids {
+			walk(k, depth+1)
+		}
+	}
+	walk(root, 0)
+	if len(stack) < 2 {
+		return nil
+	}
+	return stack
+}
@@ -0,0 +1,89 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"testing"
+)
+
+func TestParseDependsOn(t *testing.T) {
+	body := "Depends on #12.\n\nAlso blocked by #9, and depends on #12 again. See #4."
+	got := ParseDependsOn(body)
+	if fmt.Sprint(got) != "[12 9]" {
+		t.Errorf("ParseDependsOn = %v, want [12 9]", got)
+	}
+}
+
+func TestBuildStack(t *testing.T) {
+	prs := []StackPR{
+		{Number: 10, BaseBranch: "main", HeadBranch: "api"},
+		{Number: 11, BaseBranch: "api", HeadBranch: "client"},
+		{Number: 13, BaseBranch: "main", HeadBranch: "docs", DependsOn: []int{11}},
+		{Number: 12, BaseBranch: "client", HeadBranch: "ui"},
+		{Number: 20, BaseBranch: "main", HeadBranch: "unrelated"},
+	}
+
+	stack := BuildStack(prs, 12)
+	var got []string
+	for _, m := range stack {
+		got = append(got, fmt.Sprintf("%d@%d", m.Number, m.Depth))
+	}
+	if fmt.Sprint(got) != "[10@0 11@1 12@2 13@2]" {
+		t.Errorf("stack = %v, want root first with children in number order", got)
+	}
+	if stack := BuildStack(prs, 20); stack != nil {
+		t.Errorf("unstacked PR got %v, want nil", stack)
+	}
+	if stack := BuildStack(prs, 99); stack != nil {
+		t.Errorf("unknown PR got %v, want nil", stack)
+	}
+}
+
+func TestBuildStack_Cycle(t *testing.T) {
+	prs := []StackPR{
+		{Number: 1, BaseBranch: "b", HeadBranch: "a"},
+		{Number: 2, BaseBranch: "a", HeadBranch: "b"},
+	}
+	if stack := BuildStack(prs, 1); len(stack) != 2 {
+		t.Errorf("stack = %+v, want both PRs once", stack)
+	}
+}
+
+func TestGetOpenPRStackInfo(t *testing.T) {
+	client := NewTestClient("alice", fakeRunner(map[string]string{
+		"pr list -R shhac/prtea --state=open": `[{"number":11,"title":"Client","baseRefName":"api","headRefName":"client","body":"Depends on #10"}]`,
+	}))
+	prs, err := client.GetOpenPRStackInfo(context.Background(), "shhac", "prtea")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(prs) != 1 || prs[0].BaseBranch != "api" || prs[0].HeadBranch != "client" || fmt.Sprint(prs[0].DependsOn) != "[10]" {
+		t.Errorf("prs = %+v", prs)
+	}
+}
+
+func TestGetCompareStatus(t *testing.T) {
+	client := NewTestClient("alice", fakeRunner(map[string]string{
+		"repos/shhac/prtea/compare/abc...def": `{"status":"diverged","ahead_by":2,"behind_by":1}`,
+	}))
+	status, err := client.GetCompareStatus(context.Background(), "shhac", "prtea", "abc", "def")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if status != "diverged" {
+		t.Errorf("status = %q, want diverged", status)
+	}
+}
+
+func TestGetCompareFiles(t *testing.T) {
+	client := NewTestClient("alice", fakeRunner(map[string]string{
+		"repos/shhac/prtea/compare/main...ui": `{"files":[{"filename":"ui.go","status":"added","additions":3,"patch":"@@ -0,0 +1,3 @@"}]}`,
+	}))
+	files, err := client.GetCompareFiles(context.Background(), "shhac", "prtea", "main", "ui")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(files) != 1 || files[0].Filename != "ui.go" || files[0].Additions != 3 {
+		t.Errorf("files = %+v", files)
+	}
+}
@@ -0,0 +1,104 @@
+package github
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+)
+
+// ghContent is the JSON shape of a file from the contents API.
+type ghContent struct {
+	SHA      string `json:"sha"`
+	Content  string `json:"content"`
+	Encoding string `json:"encoding"`
+}
+
+// ApplySuggestion commits a review suggestion to the PR's head branch by
+// replacing lines startLine..endLine (1-based, inclusive) of path with lines.
+// original is what those lines held when the suggestion was shown; if the
+// branch has moved on and they differ, nothing is committed. headRepo is
+// "owner/name" of the repository holding branch.
+func (c *Client) ApplySuggestion(ctx context.Context, headRepo, branch, path string, startLine, endLine int, original, lines []string, message string) error {
+	endpoint := fmt.Sprintf("repos/%s/contents/%s", headRepo, escapePath(path))
+
+	var file ghContent
+	if err := c.ghJSON(ctx, &file, "api", endpoint+"?ref="+url.QueryEscape(branch)); err != nil {
+		return fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	if file.Encoding != "base64" {
+		return fmt.Errorf("failed to read %s: unexpected encoding %q", path, file.Encoding)
+	}
+	raw, err := base64.StdEncoding.DecodeString(strings.ReplaceAll(file.Content, "\n", ""))
+	if err != nil {
+		return fmt.Errorf("failed to decode %s: %w", path, err)
+	}
+
+	updated, err := replaceLines(string(raw), startLine, endLine, original, lines)
+	if err != nil {
+		return fmt.Errorf("failed to apply suggestion to %s: %w", path, err)
+	}
+
+	payload, err := json.Marshal(map[string]string{
+		"message": message,
+		"content": base64.StdEncoding.EncodeToString([]byte(updated)),
+		"sha":     file.SHA,
+		"branch":  branch,
+	})
+	if err != nil {
+		return err
+	}
+	if _, err := c.ghExecWithStdin(ctx, string(payload),
+		"api", endpoint, "--method", "PUT",
+		"-H", "Accept: application/vnd.github+json",
+		"--input", "-",
+	); err != nil {
+		return fmt.Errorf("failed to commit suggestion to %s: %w", path, err)
+	}
+	return nil
+}
+
+// errSuggestionStale means the lines a suggestion replaces were changed by a
+// later push.
+var errSuggestionStale = errors.New("file changed since the suggestion; reload the PR and try again")
+
+// escapePath escapes each segment of a repository file path for a URL.
+func escapePath(path string) string {
+	segments := strings.Split(path, "/")
+	for i, s := range segments {
+		segments[i] = url.PathEscape(s)
+	}
+	return strings.Join(segments, "/")
+}
+
+// replaceLines replaces lines start..end (1-based, inclusive) of content,
+// keeping the file's line endings and trailing newline. It fails with
+// errSuggestionStale unless those lines still equal original.
+func replaceLines(content string, start, end int, original, lines []string) (string, error) {
+	eol := "\n"
+	if strings.Contains(content, "\r\n") {
+		eol = "\r\n"
+	}
+	trailing := strings.HasSuffix(content, eol)
+	existing := strings.Split(strings.TrimSuffix(content, eol), eol)
+	if start < 1 || end < start || end > len(existing) {
+		return "", fmt.Errorf("lines %d-%d out of range (file has %d lines)", start, end, len(existing))
+	}
+	if !slices.Equal(existing[start-1:end], original) {
+		return "", errSuggestionStale
+	}
+
+	out := make([]string, 0, len(existing)-(end-start+1)+len(lines))
+	out = append(out, existing[:start-1]...)
+	out = append(out, lines...)
+	out = append(out, existing[end:]...)
+	result := strings.Join(out, eol)
+	if trailing {
+		result += eol
+	}
+	return result, nil
+}
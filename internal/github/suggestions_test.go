@@ -0,0 +1,102 @@
+package github
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestReplaceLines(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		start    int
+		end      int
+		original []string
+		lines    []string
+		want     string
+	}{
+		{"single line", "a\nb\nc\n", 2, 2, []string{"b"}, []string{"B"}, "a\nB\nc\n"},
+		{"range", "a\nb\nc\n", 1, 2, []string{"a", "b"}, []string{"x"}, "x\nc\n"},
+		{"delete", "a\nb\nc\n", 2, 2, []string{"b"}, nil, "a\nc\n"},
+		{"no trailing newline", "a\nb", 2, 2, []string{"b"}, []string{"B", "C"}, "a\nB\nC"},
+		{"crlf", "a\r\nb\r\n", 1, 1, []string{"a"}, []string{"A"}, "A\r\nb\r\n"},
+	}
+	for _, tt := range tests {
+		got, err := replaceLines(tt.content, tt.start, tt.end, tt.original, tt.lines)
+		if err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("%s: got %q, want %q", tt.name, got, tt.want)
+		}
+	}
+
+	if _, err := replaceLines("a\nb\n", 2, 3, []string{"b", "c"}, []string{"x"}); err == nil {
+		t.Error("expected out of range error")
+	}
+	if _, err := replaceLines("a\nB\n", 2, 2, []string{"b"}, []string{"x"}); !errors.Is(err, errSuggestionStale) {
+		t.Errorf("changed lines: err = %v, want errSuggestionStale", err)
+	}
+}
+
+func TestApplySuggestion(t *testing.T) {
+	var put map[string]string
+	client, _ := newTestTokenClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/repos/bob/widgets/contents/src/a.go" {
+			t.Errorf("path = %s", r.URL.Path)
+		}
+		switch r.Method {
+		case http.MethodGet:
+			if r.URL.Query().Get("ref") != "fix/thing" {
+				t.Errorf("ref = %q", r.URL.Query().Get("ref"))
+			}
+			fmt.Fprintf(w, `{"sha":"abc","encoding":"base64","content":%q}`,
+				base64.StdEncoding.EncodeToString([]byte("one\ntwo\nthree\n")))
+		case http.MethodPut:
+			json.NewDecoder(r.Body).Decode(&put)
+			fmt.Fprint(w, `{}`)
+		}
+	})
+
+	err := client.ApplySuggestion(context.Background(), "bob/widgets", "fix/thing", "src/a.go", 2, 2, []string{"two"}, []string{"TWO"}, "Apply suggestion")
+	if err != nil {
+		t.Fatalf("ApplySuggestion: %v", err)
+	}
+	content, _ := base64.StdEncoding.DecodeString(put["content"])
+	if string(content) != "one\nTWO\nthree\n" {
+		t.Errorf("content = %q", content)
+	}
+	if put["sha"] != "abc" || put["branch"] != "fix/thing" || put["message"] != "Apply suggestion" {
+		t.Errorf("payload = %v", put)
+	}
+}
+
+func TestApplySuggestion_BranchMovedOn(t *testing.T) {
+	var escaped string
+	put := false
+	client, _ := newTestTokenClient(t, func(w http.ResponseWriter, r *http.Request) {
+		escaped = r.URL.EscapedPath()
+		if r.Method == http.MethodPut {
+			put = true
+		}
+		fmt.Fprintf(w, `{"sha":"def","encoding":"base64","content":%q}`,
+			base64.StdEncoding.EncodeToString([]byte("zero\none\ntwo\n")))
+	})
+
+	err := client.ApplySuggestion(context.Background(), "bob/widgets", "fix/thing", "docs/a b#1?.md", 2, 2, []string{"two"}, []string{"TWO"}, "Apply suggestion")
+	if !errors.Is(err, errSuggestionStale) {
+		t.Fatalf("err = %v, want errSuggestionStale", err)
+	}
+	if put {
+		t.Error("nothing should be committed once the lines have moved")
+	}
+	if escaped != "/repos/bob/widgets/contents/docs/a%20b%231%3F.md" {
+		t.Errorf("path = %s, want each segment escaped", escaped)
+	}
+}
@@ -0,0 +1,786 @@
+package github
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// DefaultAPIURL is the REST API root used for token authentication.
+// GITHUB_API_URL overrides it (e.g. for GitHub Enterprise Server).
+const DefaultAPIURL = "https://api.github.com"
+
+// NewTokenClient authenticates with a GitHub token instead of the gh CLI,
+// talking to the REST and GraphQL APIs directly, then caches the current user.
+func NewTokenClient(token string) (*Client, error) {
+	baseURL := os.Getenv("GITHUB_API_URL")
+	if baseURL == "" {
+		baseURL = DefaultAPIURL
+	}
+	return newTokenClient(newAPIRunner(token, baseURL, http.DefaultClient))
+}
+
+func newTokenClient(api *apiRunner) (*Client, error) {
+	c := &Client{
+		run:      api.run,
+		runStdin: api.runStdin,
+		Timeout:  DefaultTimeout,
+	}
+
+	var user struct {
+		Login string `json:"login"`
+	}
+	if err := c.ghJSON(context.Background(), &user, "api", "user"); err != nil {
+		return nil, fmt.Errorf("token authentication failed: %w", err)
+	}
+	c.username = user.Login
+	return c, nil
+}
+
+// apiRunner serves the gh subcommands the client uses (api, pr, run, search,
+// auth status) straight from the GitHub APIs, so a Client built on it behaves
+// like one that shells out to gh. Output matches gh's JSON shapes.
+type apiRunner struct {
+	token      string
+	baseURL    string // REST root, without trailing slash
+	graphqlURL string
+	http       *http.Client
+}
+
+func newAPIRunner(token, baseURL string, client *http.Client) *apiRunner {
+	baseURL = strings.TrimSuffix(baseURL, "/")
+	graphqlURL := baseURL + "/graphql"
+	// GitHub Enterprise Server serves REST under /api/v3 and GraphQL under /api/graphql.
+	if strings.HasSuffix(baseURL, "/api/v3") {
+		graphqlURL = strings.TrimSuffix(baseURL, "/v3") + "/graphql"
+	}
+	return &apiRunner{token: token, baseURL: baseURL, graphqlURL: graphqlURL, http: client}
+}
+
+// run implements CommandRunner.
+func (r *apiRunner) run(ctx context.Context, args ...string) (string, error) {
+	return r.runStdin(ctx, "", args...)
+}
+
+// runStdin implements StdinCommandRunner. Errors are prefixed like the gh
+// runner's so callers matching on "HTTP 304" or "Not Found" keep working.
+func (r *apiRunner) runStdin(ctx context.Context, stdin string, args ...string) (string, error) {
+	out, err := r.dispatch(ctx, stdin, args)
+	if err != nil {
+		return "", fmt.Errorf("gh %s failed: %w", strings.Join(args[:min(2, len(args))], " "), err)
+	}
+	return out, nil
+}
+
+func (r *apiRunner) dispatch(ctx context.Context, stdin string, args []string) (string, error) {
+	if len(args) < 2 {
+		return "", fmt.Errorf("unsupported command")
+	}
+	switch args[0] + " " + args[1] {
+	case "auth status":
+		_, err := r.do(ctx, http.MethodGet, "user", nil, nil)
+		return "", err
+	case "pr view":
+		return r.prView(ctx, args[2:])
+	case "pr list":
+		return r.prList(ctx, args[2:])
+	case "pr review":
+		return r.prReview(ctx, args[2:])
+	case "pr comment":
+		return r.prComment(ctx, args[2:])
+	case "pr close":
+		return r.prSetState(ctx, args[2:], "closed")
+	case "pr reopen":
+		return r.prSetState(ctx, args[2:], "open")
+	case "run rerun":
+		return r.runRerun(ctx, args[2:])
+	case "search prs":
+		return r.searchPRs(ctx, args[2:])
+	}
+	if args[0] == "api" {
+		return r.api(ctx, stdin, args[1:])
+	}
+	return "", fmt.Errorf("not supported with token authentication")
+}
+
+// -- HTTP --
+
+// apiResponse is a completed API request.
+type apiResponse struct {
+	status int
+	header http.Header
+	body   []byte
+}
+
+// apiError formats a non-2xx response the way gh does: "Message (HTTP 404)".
+func apiError(resp *apiResponse) error {
+	var payload struct {
+		Message string `json:"message"`
+	}
+	_ = json.Unmarshal(resp.body, &payload)
+	if payload.Message == "" {
+		payload.Message = http.StatusText(resp.status)
+	}
+	return fmt.Errorf("%s (HTTP %d)", payload.Message, resp.status)
+}
+
+// do sends a request to endpoint, which is either relative to the REST root
+// or an absolute URL (pagination links). Non-2xx responses are returned
+// together with an error.
+func (r *apiRunner) do(ctx context.Context, method, endpoint string, headers http.Header, body io.Reader) (*apiResponse, error) {
+	target := endpoint
+	if !strings.HasPrefix(endpoint, "https://") && !strings.HasPrefix(endpoint, "http://") {
+		target = r.baseURL + "/" + strings.TrimPrefix(endpoint, "/")
+	}
+	req, err := http.NewRequestWithContext(ctx, method, target, body)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("Authorization", "Bearer "+r.token)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	for k, v := range headers {
+		req.Header[k] = v
+	}
+
+	res, err := r.http.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	data, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+	resp := &apiResponse{status: res.StatusCode, header: res.Header, body: data}
+	if res.StatusCode >= 300 {
+		return resp, apiError(resp)
+	}
+	return resp, nil
+}
+
+// doJSON sends payload as a JSON body and returns the response body.
+func (r *apiRunner) doJSON(ctx context.Context, method, endpoint string, payload interface{}) (string, error) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return "", err
+	}
+	resp, err := r.do(ctx, method, endpoint, nil, bytes.NewReader(data))
+	if err != nil {
+		return "", err
+	}
+	return string(resp.body), nil
+}
+
+// graphql runs a GraphQL query and returns the full response body. As with
+// gh, a response carrying errors is reported as a failure.
+func (r *apiRunner) graphql(ctx context.Context, query string, variables map[string]interface{}) (string, error) {
+	out, err := r.doJSON(ctx, http.MethodPost, r.graphqlURL, map[string]interface{}{
+		"query":     query,
+		"variables": variables,
+	})
+	if err != nil {
+		return "", err
+	}
+	var resp struct {
+		Errors []struct {
+			Message string `json:"message"`
+		} `json:"errors"`
+	}
+	if err := json.Unmarshal([]byte(out), &resp); err == nil && len(resp.Errors) > 0 {
+		return "", fmt.Errorf("GraphQL: %s", resp.Errors[0].Message)
+	}
+	return out, nil
+}
+
+// linkNextRe extracts the next-page URL from a Link header.
+var linkNextRe = regexp.MustCompile(`<([^>]+)>;\s*rel="next"`)
+
+// paginate GETs endpoint and every following page, merging JSON array pages
+// into a single array.
+func (r *apiRunner) paginate(ctx context.Context, endpoint string, headers http.Header) (string, error) {
+	var all []json.RawMessage
+	next := endpoint
+	for next != "" {
+		resp, err := r.do(ctx, http.MethodGet, next, headers, nil)
+		if err != nil {
+			return "", err
+		}
+		var page []json.RawMessage
+		if err := json.Unmarshal(resp.body, &page); err != nil {
+			if next == endpoint {
+				return string(resp.body), nil // not a list endpoint
+			}
+			return "", fmt.Errorf("unexpected page format: %w", err)
+		}
+		all = append(all, page...)
+		next = ""
+		if m := linkNextRe.FindStringSubmatch(resp.header.Get("Link")); m != nil {
+			next = m[1]
+		}
+	}
+	if all == nil {
+		all = []json.RawMessage{}
+	}
+	data, err := json.Marshal(all)
+	return string(data), err
+}
+
+// -- Argument parsing --
+
+// valueFlags are the gh flags prtea passes that take a value.
+var valueFlags = map[string]bool{
+	"-X": true, "--method": true, "-f": true, "--raw-field": true, "-F": true, "--field": true,
+	"-H": true, "--header": true, "--input": true, "--jq": true, "-R": true, "--repo": true,
+	"--json": true, "--limit": true, "-b": true, "--body": true, "--job": true,
+	"--state": true, "--author": true, "--review-requested": true,
+}
+
+// ghArgs is a parsed gh command line.
+type ghArgs struct {
+	positional []string
+	flags      map[string][]string
+}
+
+func parseGHArgs(args []string) ghArgs {
+	p := ghArgs{flags: make(map[string][]string)}
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if !strings.HasPrefix(arg, "-") {
+			p.positional = append(p.positional, arg)
+			continue
+		}
+		if name, value, ok := strings.Cut(arg, "="); ok && strings.HasPrefix(arg, "--") {
+			p.flags[name] = append(p.flags[name], value)
+			continue
+		}
+		if valueFlags[arg] && i+1 < len(args) {
+			p.flags[arg] = append(p.flags[arg], args[i+1])
+			i++
+			continue
+		}
+		p.flags[arg] = append(p.flags[arg], "")
+	}
+	return p
+}
+
+// get returns the last value of the first flag name present.
+func (p ghArgs) get(names ...string) string {
+	for _, n := range names {
+		if v := p.flags[n]; len(v) > 0 {
+			return v[len(v)-1]
+		}
+	}
+	return ""
+}
+
+func (p ghArgs) has(names ...string) bool {
+	for _, n := range names {
+		if _, ok := p.flags[n]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// repo splits the -R owner/name flag.
+func (p ghArgs) repo() (owner, name string, err error) {
+	owner, name, ok := strings.Cut(p.get("-R", "--repo"), "/")
+	if !ok || owner == "" || name == "" {
+		return "", "", fmt.Errorf("missing -R owner/repo")
+	}
+	return owner, name, nil
+}
+
+// number returns the first positional argument as a PR, run or job number.
+func (p ghArgs) number() (int, error) {
+	if len(p.positional) == 0 {
+		return 0, fmt.Errorf("missing number")
+	}
+	return strconv.Atoi(p.positional[0])
+}
+
+// typedField converts a -F value the way gh does: booleans, null and
+// integers become JSON values, anything else stays a string.
+func typedField(v string) interface{} {
+	switch v {
+	case "true":
+		return true
+	case "false":
+		return false
+	case "null":
+		return nil
+	}
+	if n, err := strconv.Atoi(v); err == nil {
+		return n
+	}
+	return v
+}
+
+// -- gh api --
+
+func (r *apiRunner) api(ctx context.Context, stdin string, args []string) (string, error) {
+	p := parseGHArgs(args)
+	if len(p.positional) != 1 {
+		return "", fmt.Errorf("expected one endpoint")
+	}
+	if p.has("--jq") {
+		return "", fmt.Errorf("--jq is not supported with token authentication")
+	}
+	endpoint := p.positional[0]
+
+	fields := make(map[string]interface{})
+	for _, f := range p.flags["-f"] {
+		k, v, _ := strings.Cut(f, "=")
+		fields[k] = v
+	}
+	for _, f := range p.flags["--raw-field"] {
+		k, v, _ := strings.Cut(f, "=")
+		fields[k] = v
+	}
+	for _, f := range append(p.flags["-F"], p.flags["--field"]...) {
+		k, v, _ := strings.Cut(f, "=")
+		fields[k] = typedField(v)
+	}
+
+	if endpoint == "graphql" {
+		query, _ := fields["query"].(string)
+		delete(fields, "query")
+		return r.graphql(ctx, query, fields)
+	}
+
+	headers := make(http.Header)
+	for _, h := range append(p.flags["-H"], p.flags["--header"]...) {
+		if k, v, ok := strings.Cut(h, ":"); ok {
+			headers.Set(strings.TrimSpace(k), strings.TrimSpace(v))
+		}
+	}
+
+	method := strings.ToUpper(p.get("-X", "--method"))
+	var body io.Reader
+	switch {
+	case p.get("--input") == "-":
+		body = strings.NewReader(stdin)
+	case len(fields) > 0 && method != http.MethodGet:
+		data, err := json.Marshal(fields)
+		if err != nil {
+			return "", err
+		}
+		body = bytes.NewReader(data)
+	case len(fields) > 0:
+		q := url.Values{}
+		for k, v := range fields {
+			q.Set(k, fmt.Sprint(v))
+		}
+		endpoint += "?" + q.Encode()
+	}
+	if method == "" {
+		method = http.MethodGet
+		if body != nil {
+			method = http.MethodPost
+		}
+	}
+
+	if p.has("--paginate") && method == http.MethodGet {
+		return r.paginate(ctx, endpoint, headers)
+	}
+
+	resp, err := r.do(ctx, method, endpoint, headers, body)
+	if err != nil {
+		return "", err
+	}
+	if !p.has("--include") {
+		return string(resp.body), nil
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "HTTP/1.1 %d %s\r\n", resp.status, http.StatusText(resp.status))
+	for k, vs := range resp.header {
+		for _, v := range vs {
+			fmt.Fprintf(&b, "%s: %s\r\n", k, v)
+		}
+	}
+	b.WriteString("\r\n")
+	b.Write(resp.body)
+	return b.String(), nil
+}
+
+// -- gh pr view / pr list --
+
+// prFieldSelections maps gh --json field names to GraphQL selections on a
+// PullRequest. Connection fields are flattened to their nodes afterwards.
+var prFieldSelections = map[string]string{
+	"id":                  "id",
+	"number":              "number",
+	"title":               "title",
+	"body":                "body",
+	"url":                 "url",
+	"isDraft":             "isDraft",
+	"state":               "state",
+	"mergeable":           "mergeable",
+	"mergeStateStatus":    "mergeStateStatus",
+	"baseRefName":         "baseRefName",
+	"headRefName":         "headRefName",
+	"headRefOid":          "headRefOid",
+	"reviewDecision":      "reviewDecision",
+	"createdAt":           "createdAt",
+	"author":              "author { login }",
+	"autoMergeRequest":    "autoMergeRequest { enabledAt mergeMethod }",
+	"headRepository":      "headRepository { name }",
+	"headRepositoryOwner": "headRepositoryOwner { login }",
+	"comments":            "comments(first: 100) { nodes { id author { login } body createdAt url } }",
+	"reviews":             "reviews(first: 100) { nodes { id author { login } state body submittedAt } }",
+	"latestReviews":       "latestReviews(first: 100) { nodes { id author { login } state body submittedAt } }",
+	"reviewRequests":      "reviewRequests(first: 100) { nodes { requestedReviewer { __typename ... on User { login } ... on Team { name } } } }",
+	"statusCheckRollup": "statusCheckRollup: commits(last: 1) { nodes { commit { statusCheckRollup { contexts(first: 100) { nodes { " +
+		"__typename ... on CheckRun { name status conclusion detailsUrl startedAt completedAt } " +
+		"... on StatusContext { context state targetUrl } } } } } } }",
+}
+
+// prSelection builds the GraphQL selection for the requested --json fields.
+func prSelection(jsonFields string) (string, []string, error) {
+	fields := strings.Split(jsonFields, ",")
+	parts := make([]string, 0, len(fields))
+	for _, f := range fields {
+		sel, ok := prFieldSelections[f]
+		if !ok {
+			return "", nil, fmt.Errorf("unsupported --json field %q", f)
+		}
+		parts = append(parts, sel)
+	}
+	return strings.Join(parts, " "), fields, nil
+}
+
+// flattenPR reshapes a GraphQL PullRequest into gh's --json output.
+func flattenPR(pr map[string]interface{}, fields []string) map[string]interface{} {
+	out := make(map[string]interface{}, len(fields))
+	for _, f := range fields {
+		v := pr[f]
+		switch f {
+		case "comments", "reviews", "latestReviews":
+			v = nodesOf(v)
+		case "reviewRequests":
+			var reqs []interface{}
+			for _, n := range nodesOf(v) {
+				if node, ok := n.(map[string]interface{}); ok {
+					reqs = append(reqs, node["requestedReviewer"])
+				}
+			}
+			v = reqs
+		case "statusCheckRollup":
+			var checks []interface{}
+			if commits := nodesOf(v); len(commits) > 0 {
+				node, _ := commits[0].(map[string]interface{})
+				commit, _ := node["commit"].(map[string]interface{})
+				if rollup, ok := commit["statusCheckRollup"].(map[string]interface{}); ok {
+					checks = nodesOf(rollup["contexts"])
+				}
+			}
+			v = checks
+		}
+		if v == nil && (f == "comments" || f == "reviews" || f == "latestReviews" || f == "reviewRequests" || f == "statusCheckRollup") {
+			v = []interface{}{}
+		}
+		out[f] = v
+	}
+	return out
+}
+
+// nodesOf returns the nodes list of a GraphQL connection object.
+func nodesOf(v interface{}) []interface{} {
+	conn, ok := v.(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	nodes, _ := conn["nodes"].([]interface{})
+	return nodes
+}
+
+func (r *apiRunner) prView(ctx context.Context, args []string) (string, error) {
+	p := parseGHArgs(args)
+	owner, name, err := p.repo()
+	if err != nil {
+		return "", err
+	}
+	number, err := p.number()
+	if err != nil {
+		return "", err
+	}
+	sel, fields, err := prSelection(p.get("--json"))
+	if err != nil {
+		return "", err
+	}
+
+	query := `query($owner: String!, $name: String!, $number: Int!) {
+  repository(owner: $owner, name: $name) { pullRequest(number: $number) { ` + sel + ` } }
+}`
+	out, err := r.graphql(ctx, query, map[string]interface{}{"owner": owner, "name": name, "number": number})
+	if err != nil {
+		return "", err
+	}
+	var resp struct {
+		Data struct {
+			Repository struct {
+				PullRequest map[string]interface{} `json:"pullRequest"`
+			} `json:"repository"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal([]byte(out), &resp); err != nil {
+		return "", err
+	}
+	if resp.Data.Repository.PullRequest == nil {
+		return "", fmt.Errorf("pull request #%d not found", number)
+	}
+	data, err := json.Marshal(flattenPR(resp.Data.Repository.PullRequest, fields))
+	return string(data), err
+}
+
+func (r *apiRunner) prList(ctx context.Context, args []string) (string, error) {
+	p := parseGHArgs(args)
+	owner, name, err := p.repo()
+	if err != nil {
+		return "", err
+	}
+	limit, _ := strconv.Atoi(p.get("--limit"))
+	if limit <= 0 || limit > 100 {
+		limit = 100
+	}
+	sel, fields, err := prSelection(p.get("--json"))
+	if err != nil {
+		return "", err
+	}
+	states := "[OPEN]"
+	switch p.get("--state") {
+	case "closed":
+		states = "[CLOSED]"
+	case "merged":
+		states = "[MERGED]"
+	case "all":
+		states = "[OPEN, CLOSED, MERGED]"
+	}
+	// --author is filtered client-side, so fetch a full page first.
+	author := p.get("--author")
+	first := limit
+	if author != "" {
+		first = 100
+	}
+
+	query := `query($owner: String!, $name: String!, $first: Int!) {
+  viewer { login }
+  repository(owner: $owner, name: $name) {
+    pullRequests(first: $first, states: ` + states + `, orderBy: {field: CREATED_AT, direction: DESC}) {
+      nodes { prteaAuthor: author { login } ` + sel + ` }
+    }
+  }
+}`
+	out, err := r.graphql(ctx, query, map[string]interface{}{"owner": owner, "name": name, "first": first})
+	if err != nil {
+		return "", err
+	}
+	var resp struct {
+		Data struct {
+			Viewer struct {
+				Login string `json:"login"`
+			} `json:"viewer"`
+			Repository struct {
+				PullRequests struct {
+					Nodes []map[string]interface{} `json:"nodes"`
+				} `json:"pullRequests"`
+			} `json:"repository"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal([]byte(out), &resp); err != nil {
+		return "", err
+	}
+	if author == "@me" {
+		author = resp.Data.Viewer.Login
+	}
+
+	items := []map[string]interface{}{}
+	for _, pr := range resp.Data.Repository.PullRequests.Nodes {
+		if author != "" {
+			a, _ := pr["prteaAuthor"].(map[string]interface{})
+			if login, _ := a["login"].(string); !strings.EqualFold(login, author) {
+				continue
+			}
+		}
+		items = append(items, flattenPR(pr, fields))
+		if len(items) == limit {
+			break
+		}
+	}
+	data, err := json.Marshal(items)
+	return string(data), err
+}
+
+// -- gh search prs --
+
+// restSearchIssue is the REST search/issues item shape.
+type restSearchIssue struct {
+	Number    int    `json:"number"`
+	Title     string `json:"title"`
+	HTMLURL   string `json:"html_url"`
+	CreatedAt string `json:"created_at"`
+	UpdatedAt string `json:"updated_at"`
+	Draft     bool   `json:"draft"`
+	Comments  int    `json:"comments"`
+	User      struct {
+		Login string `json:"login"`
+	} `json:"user"`
+	RepositoryURL string `json:"repository_url"`
+	Labels        []struct {
+		Name  string `json:"name"`
+		Color string `json:"color"`
+	} `json:"labels"`
+}
+
+func (r *apiRunner) searchPRs(ctx context.Context, args []string) (string, error) {
+	p := parseGHArgs(args)
+	terms := append([]string{"is:pr"}, p.positional...)
+	if s := p.get("--state"); s != "" {
+		terms = append(terms, "is:"+s)
+	}
+	if a := p.get("--author"); a != "" {
+		terms = append(terms, "author:"+a)
+	}
+	if rr := p.get("--review-requested"); rr != "" {
+		terms = append(terms, "review-requested:"+rr)
+	}
+	limit, _ := strconv.Atoi(p.get("--limit"))
+	if limit <= 0 || limit > 100 {
+		limit = 100
+	}
+	q := url.Values{}
+	q.Set("q", strings.Join(terms, " "))
+	q.Set("per_page", strconv.Itoa(limit))
+	if sort := p.get("--sort"); sort != "" {
+		q.Set("sort", sort)
+	}
+
+	resp, err := r.do(ctx, http.MethodGet, "search/issues?"+q.Encode(), nil, nil)
+	if err != nil {
+		return "", err
+	}
+	var result struct {
+		Items []restSearchIssue `json:"items"`
+	}
+	if err := json.Unmarshal(resp.body, &result); err != nil {
+		return "", err
+	}
+
+	items := make([]map[string]interface{}, 0, len(result.Items))
+	for _, it := range result.Items {
+		_, nameWithOwner, _ := strings.Cut(it.RepositoryURL, "/repos/")
+		_, repoName, _ := strings.Cut(nameWithOwner, "/")
+		labels := make([]map[string]string, 0, len(it.Labels))
+		for _, l := range it.Labels {
+			labels = append(labels, map[string]string{"name": l.Name, "color": l.Color})
+		}
+		items = append(items, map[string]interface{}{
+			"number":        it.Number,
+			"title":         it.Title,
+			"url":           it.HTMLURL,
+			"createdAt":     it.CreatedAt,
+			"updatedAt":     it.UpdatedAt,
+			"isDraft":       it.Draft,
+			"commentsCount": it.Comments,
+			"author":        map[string]string{"login": it.User.Login},
+			"repository":    map[string]string{"name": repoName, "nameWithOwner": nameWithOwner},
+			"labels":        labels,
+		})
+	}
+	data, err := json.Marshal(items)
+	return string(data), err
+}
+
+// -- gh pr review / comment / close / reopen, gh run rerun --
+
+func (r *apiRunner) prReview(ctx context.Context, args []string) (string, error) {
+	p := parseGHArgs(args)
+	owner, name, err := p.repo()
+	if err != nil {
+		return "", err
+	}
+	number, err := p.number()
+	if err != nil {
+		return "", err
+	}
+	var event string
+	switch {
+	case p.has("--approve", "-a"):
+		event = "APPROVE"
+	case p.has("--request-changes", "-r"):
+		event = "REQUEST_CHANGES"
+	case p.has("--comment", "-c"):
+		event = "COMMENT"
+	default:
+		return "", fmt.Errorf("missing review type")
+	}
+	payload := map[string]string{"event": event}
+	if body := p.get("-b", "--body"); body != "" {
+		payload["body"] = body
+	}
+	return r.doJSON(ctx, http.MethodPost, fmt.Sprintf("repos/%s/%s/pulls/%d/reviews", owner, name, number), payload)
+}
+
+func (r *apiRunner) prComment(ctx context.Context, args []string) (string, error) {
+	p := parseGHArgs(args)
+	owner, name, err := p.repo()
+	if err != nil {
+		return "", err
+	}
+	number, err := p.number()
+	if err != nil {
+		return "", err
+	}
+	return r.doJSON(ctx, http.MethodPost, fmt.Sprintf("repos/%s/%s/issues/%d/comments", owner, name, number),
+		map[string]string{"body": p.get("-b", "--body")})
+}
+
+// prSetState closes ("closed") or reopens ("open") a PR.
+func (r *apiRunner) prSetState(ctx context.Context, args []string, state string) (string, error) {
+	p := parseGHArgs(args)
+	owner, name, err := p.repo()
+	if err != nil {
+		return "", err
+	}
+	number, err := p.number()
+	if err != nil {
+		return "", err
+	}
+	return r.doJSON(ctx, http.MethodPatch, fmt.Sprintf("repos/%s/%s/pulls/%d", owner, name, number),
+		map[string]string{"state": state})
+}
+
+func (r *apiRunner) runRerun(ctx context.Context, args []string) (string, error) {
+	p := parseGHArgs(args)
+	owner, name, err := p.repo()
+	if err != nil {
+		return "", err
+	}
+	var endpoint string
+	switch {
+	case p.get("--job") != "":
+		endpoint = fmt.Sprintf("repos/%s/%s/actions/jobs/%s/rerun", owner, name, p.get("--job"))
+	case p.has("--failed") && len(p.positional) > 0:
+		endpoint = fmt.Sprintf("repos/%s/%s/actions/runs/%s/rerun-failed-jobs", owner, name, p.positional[0])
+	case len(p.positional) > 0:
+		endpoint = fmt.Sprintf("repos/%s/%s/actions/runs/%s/rerun", owner, name, p.positional[0])
+	default:
+		return "", fmt.Errorf("missing run ID")
+	}
+	resp, err := r.do(ctx, http.MethodPost, endpoint, nil, nil)
+	if err != nil {
+		return "", err
+	}
+	return string(resp.body), nil
+}
@@ -0,0 +1,311 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// newTestTokenClient starts an API server backed by handler and returns a
+// token client pointed at it, along with the server URL.
+func newTestTokenClient(t *testing.T, handler http.HandlerFunc) (*Client, string) {
+	t.Helper()
+	mux := http.NewServeMux()
+	mux.HandleFunc("/user", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer tok" {
+			w.WriteHeader(http.StatusUnauthorized)
+			fmt.Fprint(w, `{"message":"Bad credentials"}`)
+			return
+		}
+		fmt.Fprint(w, `{"login":"alice"}`)
+	})
+	mux.HandleFunc("/", handler)
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+
+	client, err := newTokenClient(newAPIRunner("tok", srv.URL, srv.Client()))
+	if err != nil {
+		t.Fatalf("newTokenClient: %v", err)
+	}
+	return client, srv.URL
+}
+
+func TestNewTokenClient_BadToken(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		fmt.Fprint(w, `{"message":"Bad credentials"}`)
+	}))
+	defer srv.Close()
+
+	_, err := newTokenClient(newAPIRunner("nope", srv.URL, srv.Client()))
+	if err == nil || !strings.Contains(err.Error(), "Bad credentials (HTTP 401)") {
+		t.Errorf("err = %v", err)
+	}
+}
+
+func TestTokenClient_Username(t *testing.T) {
+	client, _ := newTestTokenClient(t, http.NotFound)
+	if client.GetUsername() != "alice" {
+		t.Errorf("username = %q", client.GetUsername())
+	}
+}
+
+func TestTokenClient_GetReviewsFlattensGraphQL(t *testing.T) {
+	client, _ := newTestTokenClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/graphql" {
+			http.NotFound(w, r)
+			return
+		}
+		var req struct {
+			Query     string                 `json:"query"`
+			Variables map[string]interface{} `json:"variables"`
+		}
+		json.NewDecoder(r.Body).Decode(&req)
+		if req.Variables["number"] != float64(7) || req.Variables["owner"] != "acme" {
+			t.Errorf("variables = %v", req.Variables)
+		}
+		fmt.Fprint(w, `{"data":{"repository":{"pullRequest":{
+			"reviews":{"nodes":[{"id":"1","author":{"login":"bob"},"state":"APPROVED","body":"","submittedAt":"2024-01-01T00:00:00Z"}]},
+			"latestReviews":{"nodes":[{"id":"1","author":{"login":"bob"},"state":"APPROVED","body":"","submittedAt":"2024-01-01T00:00:00Z"}]},
+			"reviewDecision":"REVIEW_REQUIRED",
+			"reviewRequests":{"nodes":[{"requestedReviewer":{"__typename":"Team","name":"core"}}]}
+		}}}}`)
+	})
+
+	summary, err := client.GetReviews(context.Background(), "acme", "widgets", 7)
+	if err != nil {
+		t.Fatalf("GetReviews: %v", err)
+	}
+	if len(summary.Approved) != 1 || summary.Approved[0].Author.Login != "bob" {
+		t.Errorf("approved = %+v", summary.Approved)
+	}
+	if summary.ReviewDecision != "REVIEW_REQUIRED" {
+		t.Errorf("decision = %q", summary.ReviewDecision)
+	}
+	if len(summary.PendingReviewers) != 1 {
+		t.Errorf("pending = %+v", summary.PendingReviewers)
+	}
+}
+
+func TestTokenClient_GetCIStatusFlattensRollup(t *testing.T) {
+	client, _ := newTestTokenClient(t, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"data":{"repository":{"pullRequest":{"statusCheckRollup":{"nodes":[{"commit":{"statusCheckRollup":{"contexts":{"nodes":[
+			{"__typename":"CheckRun","name":"test","status":"COMPLETED","conclusion":"FAILURE","detailsUrl":"https://github.com/acme/widgets/actions/runs/5/job/6"}
+		]}}}}]}}}}}`)
+	})
+
+	status, err := client.GetCIStatus(context.Background(), "acme", "widgets", "", 7)
+	if err != nil {
+		t.Fatalf("GetCIStatus: %v", err)
+	}
+	if status.TotalCount != 1 || status.Checks[0].Name != "test" || status.OverallStatus != "failing" {
+		t.Errorf("status = %+v", status)
+	}
+}
+
+func TestTokenClient_ApprovePR(t *testing.T) {
+	var got map[string]string
+	client, _ := newTestTokenClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/repos/acme/widgets/pulls/7/reviews" {
+			t.Errorf("request = %s %s", r.Method, r.URL.Path)
+		}
+		json.NewDecoder(r.Body).Decode(&got)
+		fmt.Fprint(w, `{}`)
+	})
+
+	if err := client.ApprovePR(context.Background(), "acme", "widgets", 7, "LGTM"); err != nil {
+		t.Fatalf("ApprovePR: %v", err)
+	}
+	if got["event"] != "APPROVE" || got["body"] != "LGTM" {
+		t.Errorf("payload = %v", got)
+	}
+}
+
+func TestTokenClient_ReopenPR(t *testing.T) {
+	var got map[string]string
+	client, _ := newTestTokenClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPatch || r.URL.Path != "/repos/acme/widgets/pulls/7" {
+			t.Errorf("request = %s %s", r.Method, r.URL.Path)
+		}
+		json.NewDecoder(r.Body).Decode(&got)
+		fmt.Fprint(w, `{}`)
+	})
+
+	if err := client.ReopenPR(context.Background(), "acme", "widgets", 7); err != nil {
+		t.Fatalf("ReopenPR: %v", err)
+	}
+	if got["state"] != "open" {
+		t.Errorf("payload = %v", got)
+	}
+}
+
+func TestTokenClient_SearchPassesQualifiersAndSort(t *testing.T) {
+	var query, sort string
+	client, _ := newTestTokenClient(t, func(w http.ResponseWriter, r *http.Request) {
+		query, sort = r.URL.Query().Get("q"), r.URL.Query().Get("sort")
+		fmt.Fprint(w, `{"items":[]}`)
+	})
+
+	if _, err := client.GetRecentlyClosedPRs(context.Background()); err != nil {
+		t.Fatalf("GetRecentlyClosedPRs: %v", err)
+	}
+	if query != "is:pr is:unmerged is:closed author:@me" || sort != "updated" {
+		t.Errorf("q = %q, sort = %q", query, sort)
+	}
+}
+
+func TestTokenClient_SubmitReviewSendsStdinBody(t *testing.T) {
+	var body string
+	client, _ := newTestTokenClient(t, func(w http.ResponseWriter, r *http.Request) {
+		data, _ := io.ReadAll(r.Body)
+		body = string(data)
+		fmt.Fprint(w, `{}`)
+	})
+
+	err := client.SubmitReviewWithComments(context.Background(), "acme", "widgets", 7, "COMMENT", "hi",
+		[]ReviewCommentPayload{{Path: "a.go", Line: 3, Side: "RIGHT", Body: "nit"}})
+	if err != nil {
+		t.Fatalf("SubmitReviewWithComments: %v", err)
+	}
+	if !strings.Contains(body, `"path":"a.go"`) {
+		t.Errorf("body = %s", body)
+	}
+}
+
+func TestTokenClient_PaginatesFiles(t *testing.T) {
+	var srvURL string
+	client, srvURL := newTestTokenClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("page") == "2" {
+			fmt.Fprint(w, `[{"filename":"b.go","status":"added"}]`)
+			return
+		}
+		w.Header().Set("Link", fmt.Sprintf(`<%s%s?page=2>; rel="next"`, srvURL, r.URL.Path))
+		fmt.Fprint(w, `[{"filename":"a.go","status":"modified"}]`)
+	})
+
+	files, err := client.GetPRFiles(context.Background(), "acme", "widgets", 7)
+	if err != nil {
+		t.Fatalf("GetPRFiles: %v", err)
+	}
+	if len(files) != 2 || files[1].Filename != "b.go" {
+		t.Errorf("files = %+v", files)
+	}
+}
+
+func TestTokenClient_ETagRevalidation(t *testing.T) {
+	hits := 0
+	client, _ := newTestTokenClient(t, func(w http.ResponseWriter, r *http.Request) {
+		hits++
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		fmt.Fprint(w, `[{"filename":"a.go","status":"modified"}]`)
+	})
+	client.SetResponseCache(NewResponseCache(t.TempDir()))
+
+	for i := 0; i < 2; i++ {
+		files, err := client.GetPRFiles(context.Background(), "acme", "widgets", 7)
+		if err != nil {
+			t.Fatalf("GetPRFiles #%d: %v", i, err)
+		}
+		if len(files) != 1 {
+			t.Errorf("files #%d = %+v", i, files)
+		}
+	}
+	if hits != 2 {
+		t.Errorf("hits = %d, want 2", hits)
+	}
+}
+
+func TestTokenClient_UnsupportedJQ(t *testing.T) {
+	client, _ := newTestTokenClient(t, http.NotFound)
+	if _, err := client.ghExec(context.Background(), "api", "user", "--jq", ".login"); err == nil {
+		t.Error("expected --jq to be rejected")
+	}
+}
+
+func TestParseGHArgs(t *testing.T) {
+	p := parseGHArgs([]string{"42", "-R", "acme/widgets", "--state=open", "--approve", "-b", "ok"})
+	if n, _ := p.number(); n != 42 {
+		t.Errorf("number = %d", n)
+	}
+	if owner, name, _ := p.repo(); owner != "acme" || name != "widgets" {
+		t.Errorf("repo = %s/%s", owner, name)
+	}
+	if p.get("--state") != "open" || p.get("-b") != "ok" || !p.has("--approve") {
+		t.Errorf("flags = %v", p.flags)
+	}
+}
+
+func TestDeviceFlow(t *testing.T) {
+	polls := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		if r.Form.Get("client_id") != "cid" {
+			t.Errorf("client_id = %q", r.Form.Get("client_id"))
+		}
+		switch r.URL.Path {
+		case "/login/device/code":
+			fmt.Fprint(w, `{"device_code":"dc","user_code":"ABCD-1234","verification_uri":"https://github.com/login/device","expires_in":900,"interval":5}`)
+		case "/login/oauth/access_token":
+			polls++
+			switch polls {
+			case 1:
+				fmt.Fprint(w, `{"error":"authorization_pending"}`)
+			case 2:
+				fmt.Fprint(w, `{"error":"slow_down","interval":10}`)
+			default:
+				fmt.Fprint(w, `{"access_token":"gho_x","token_type":"bearer"}`)
+			}
+		}
+	}))
+	defer srv.Close()
+
+	var waits []time.Duration
+	flow := NewDeviceFlow("cid")
+	flow.BaseURL = srv.URL
+	flow.sleep = func(ctx context.Context, d time.Duration) error {
+		waits = append(waits, d)
+		return nil
+	}
+
+	code, err := flow.RequestCode(context.Background())
+	if err != nil {
+		t.Fatalf("RequestCode: %v", err)
+	}
+	if code.UserCode != "ABCD-1234" {
+		t.Errorf("user code = %q", code.UserCode)
+	}
+	token, err := flow.PollToken(context.Background(), code)
+	if err != nil {
+		t.Fatalf("PollToken: %v", err)
+	}
+	if token != "gho_x" {
+		t.Errorf("token = %q", token)
+	}
+	if len(waits) != 3 || waits[0] != 5*time.Second || waits[2] != 10*time.Second {
+		t.Errorf("waits = %v", waits)
+	}
+}
+
+func TestDeviceFlow_Denied(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"error":"access_denied","error_description":"The user has denied your application access."}`)
+	}))
+	defer srv.Close()
+
+	flow := NewDeviceFlow("cid")
+	flow.BaseURL = srv.URL
+	flow.sleep = func(context.Context, time.Duration) error { return nil }
+	if _, err := flow.PollToken(context.Background(), &DeviceCode{DeviceCode: "dc"}); err == nil || !strings.Contains(err.Error(), "access_denied") {
+		t.Errorf("err = %v", err)
+	}
+}
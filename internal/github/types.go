@@ -32,10 +32,16 @@ type PRItem struct {
 	Labels         []Label
 	Draft          bool
 	CreatedAt      time.Time
+	UpdatedAt      time.Time
 	Additions      int
 	Deletions      int
 	ChangedFiles   int
+	CommentsCount  int
 	ReviewDecision string // "APPROVED", "CHANGES_REQUESTED", "REVIEW_REQUIRED", ""
+	CIStatus       string // overall CI status as in CIStatus.OverallStatus; "" when not fetched
+	BaseBranch     string // "" when not fetched
+	HeadBranch     string // "" when not fetched
+	AutoMerge      string // enabled auto-merge method ("MERGE", "SQUASH", "REBASE"); "" when off or not fetched
 }
 
 // PRDetail is the full PR representation including merge state.
@@ -49,9 +55,13 @@ type PRDetail struct {
 	BaseBranch     string
 	HeadBranch     string
 	HeadSHA        string
+	HeadRepo       string // "owner/name" of the repository holding the head branch
+	State          string // "OPEN", "CLOSED" or "MERGED"
+	Draft          bool
 	Mergeable      bool
 	MergeableState string
 	BehindBy       int
+	AutoMerge      string // enabled auto-merge method ("MERGE", "SQUASH", "REBASE"); "" when off
 }
 
 // PRFile represents a single changed file in a PR.
@@ -63,6 +73,18 @@ type PRFile struct {
 	Patch     string
 }
 
+// MergeRequirements describes the base branch protection rules that gate
+// merging a PR, plus the PR's unresolved review thread count.
+type MergeRequirements struct {
+	ProtectionKnown   bool     // false if the branch could not be read
+	Protected         bool     // base branch has protection rules
+	RequiredChecks    []string // status check contexts required to pass
+	RequiredApprovals int      // approving reviews required; -1 if unknown (needs admin access)
+	StrictUpToDate    bool     // branch must be up to date with base before merging
+	UnresolvedThreads int
+	ResolvedThreadIDs []int64 // root comment IDs of resolved review threads
+}
+
 // CICheck represents an individual CI check run.
 type CICheck struct {
 	ID            int64
@@ -71,6 +93,18 @@ type CICheck struct {
 	Conclusion    string // "success", "failure", "neutral", "cancelled", "skipped", "timed_out", "action_required"
 	HTMLURL       string
 	WorkflowRunID int64 // extracted from detailsUrl for GitHub Actions checks; 0 if not available
+	JobID         int64 // extracted from detailsUrl for GitHub Actions jobs; 0 if not available
+}
+
+// CheckAnnotation is a file/line message attached to a check run, e.g. a
+// compiler error or lint finding.
+type CheckAnnotation struct {
+	Path      string
+	StartLine int
+	EndLine   int
+	Level     string // "notice", "warning", "failure"
+	Title     string
+	Message   string
 }
 
 // CIStatus is the aggregate CI status for a commit.
@@ -122,4 +156,13 @@ type InlineComment struct {
 	Side        string // "LEFT", "RIGHT"
 	InReplyToID int64
 	Outdated    bool
+	DiffHunk    string // original diff context the comment was made on, ending at the commented line
+	Reactions   []Reaction
+}
+
+// Reaction is the count of one kind of reaction to a comment, e.g. "+1"
+// or "heart".
+type Reaction struct {
+	Content string
+	Count   int
 }
@@ -0,0 +1,174 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// PendingReview is a review request that has not been answered yet.
+type PendingReview struct {
+	Reviewer string // user login, or "org/team" for a team
+	IsTeam   bool
+	Since    time.Time // when the review was (last) requested
+}
+
+// WorkloadPR is an open PR with its outstanding review requests.
+type WorkloadPR struct {
+	PR       PRItem
+	Requests []PendingReview
+}
+
+// ghRequestedReviewer is the GraphQL RequestedReviewer union, resolved to a
+// user login or a team's "org/slug".
+type ghRequestedReviewer struct {
+	Typename     string `json:"__typename"`
+	Login        string `json:"login"`
+	CombinedSlug string `json:"combinedSlug"`
+}
+
+func (r ghRequestedReviewer) name() string {
+	if r.Typename == "Team" {
+		return r.CombinedSlug
+	}
+	return r.Login
+}
+
+// ghWorkload is the GraphQL response shape for workloadQuery.
+type ghWorkload struct {
+	Data struct {
+		Search struct {
+			Nodes []struct {
+				Number    int       `json:"number"`
+				Title     string    `json:"title"`
+				URL       string    `json:"url"`
+				CreatedAt time.Time `json:"createdAt"`
+				Author    struct {
+					Login string `json:"login"`
+				} `json:"author"`
+				Repository struct {
+					NameWithOwner string `json:"nameWithOwner"`
+				} `json:"repository"`
+				ReviewRequests struct {
+					Nodes []struct {
+						RequestedReviewer ghRequestedReviewer `json:"requestedReviewer"`
+					} `json:"nodes"`
+				} `json:"reviewRequests"`
+				TimelineItems struct {
+					Nodes []struct {
+						CreatedAt         time.Time           `json:"createdAt"`
+						RequestedReviewer ghRequestedReviewer `json:"requestedReviewer"`
+					} `json:"nodes"`
+				} `json:"timelineItems"`
+			} `json:"nodes"`
+		} `json:"search"`
+	} `json:"data"`
+}
+
+const workloadQuery = `query($q: String!, $limit: Int!) {
+  search(query: $q, type: ISSUE, first: $limit) {
+    nodes { ... on PullRequest {
+      number title url createdAt
+      author { login }
+      repository { nameWithOwner }
+      reviewRequests(first: 20) { nodes { requestedReviewer { ...reviewer } } }
+      timelineItems(last: 50, itemTypes: [REVIEW_REQUESTED_EVENT]) {
+        nodes { ... on ReviewRequestedEvent { createdAt requestedReviewer { ...reviewer } } }
+      }
+    } }
+  }
+}
+fragment reviewer on RequestedReviewer {
+  __typename
+  ... on User { login }
+  ... on Team { combinedSlug }
+}`
+
+// workloadSearchQuery builds the search for open, non-draft PRs in scopes,
+// each an org ("acme") or a repository ("acme/api").
+func workloadSearchQuery(scopes []string) (string, error) {
+	terms := []string{"is:pr", "is:open", "draft:false"}
+	for _, s := range scopes {
+		s = strings.TrimSpace(s)
+		switch strings.Count(s, "/") {
+		case 0:
+			if s != "" {
+				terms = append(terms, "org:"+s)
+				continue
+			}
+		case 1:
+			if owner, repo, _ := strings.Cut(s, "/"); owner != "" && repo != "" {
+				terms = append(terms, "repo:"+s)
+				continue
+			}
+		}
+		return "", fmt.Errorf("invalid workload scope %q: want an org or owner/repo", s)
+	}
+	if len(terms) == 3 {
+		return "", fmt.Errorf("no workload scopes configured")
+	}
+	return strings.Join(terms, " "), nil
+}
+
+// GetReviewWorkload returns the open PRs in scopes (orgs or owner/repo
+// names) that have outstanding review requests, with when each reviewer was
+// asked. At most 100 PRs are searched.
+func (c *Client) GetReviewWorkload(ctx context.Context, scopes []string) ([]WorkloadPR, error) {
+	q, err := workloadSearchQuery(scopes)
+	if err != nil {
+		return nil, err
+	}
+	var resp ghWorkload
+	err = c.ghJSON(ctx, &resp,
+		"api", "graphql",
+		"-f", "query="+workloadQuery,
+		"-f", "q="+q,
+		"-F", fmt.Sprintf("limit=%d", graphQLSearchLimit),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch review workload: %w", err)
+	}
+
+	var prs []WorkloadPR
+	for _, n := range resp.Data.Search.Nodes {
+		if n.Number == 0 || len(n.ReviewRequests.Nodes) == 0 {
+			continue
+		}
+		// The latest request event per reviewer dates their pending request.
+		requested := make(map[string]time.Time)
+		for _, e := range n.TimelineItems.Nodes {
+			if name := e.RequestedReviewer.name(); name != "" && e.CreatedAt.After(requested[name]) {
+				requested[name] = e.CreatedAt
+			}
+		}
+		owner, name := parseNameWithOwner(n.Repository.NameWithOwner)
+		pr := WorkloadPR{PR: PRItem{
+			Number:    n.Number,
+			Title:     n.Title,
+			HTMLURL:   n.URL,
+			Repo:      Repo{Owner: owner, Name: name, FullName: n.Repository.NameWithOwner},
+			Author:    User{Login: n.Author.Login},
+			CreatedAt: n.CreatedAt,
+		}}
+		for _, r := range n.ReviewRequests.Nodes {
+			reviewer := r.RequestedReviewer.name()
+			if reviewer == "" {
+				continue // e.g. a bot or mannequin
+			}
+			since, ok := requested[reviewer]
+			if !ok {
+				since = n.CreatedAt
+			}
+			pr.Requests = append(pr.Requests, PendingReview{
+				Reviewer: reviewer,
+				IsTeam:   r.RequestedReviewer.Typename == "Team",
+				Since:    since,
+			})
+		}
+		if len(pr.Requests) > 0 {
+			prs = append(prs, pr)
+		}
+	}
+	return prs, nil
+}
@@ -0,0 +1,64 @@
+package github
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWorkloadSearchQuery(t *testing.T) {
+	q, err := workloadSearchQuery([]string{"acme", " acme/api "})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if q != "is:pr is:open draft:false org:acme repo:acme/api" {
+		t.Errorf("query = %q", q)
+	}
+	for _, bad := range [][]string{nil, {""}, {"a/b/c"}, {"/api"}} {
+		if _, err := workloadSearchQuery(bad); err == nil {
+			t.Errorf("scopes %q should be rejected", bad)
+		}
+	}
+}
+
+func TestGetReviewWorkload(t *testing.T) {
+	resp := `{"data":{"search":{"nodes":[
+		{"number":7,"title":"Add cache","url":"https://github.com/acme/api/pull/7","createdAt":"2024-05-01T00:00:00Z",
+		 "author":{"login":"carol"},"repository":{"nameWithOwner":"acme/api"},
+		 "reviewRequests":{"nodes":[
+			{"requestedReviewer":{"__typename":"User","login":"alice"}},
+			{"requestedReviewer":{"__typename":"Team","combinedSlug":"acme/platform"}}]},
+		 "timelineItems":{"nodes":[
+			{"createdAt":"2024-05-02T00:00:00Z","requestedReviewer":{"__typename":"User","login":"alice"}},
+			{"createdAt":"2024-05-04T00:00:00Z","requestedReviewer":{"__typename":"User","login":"alice"}}]}},
+		{"number":8,"title":"Reviewed already","createdAt":"2024-05-01T00:00:00Z","repository":{"nameWithOwner":"acme/api"},
+		 "reviewRequests":{"nodes":[]},"timelineItems":{"nodes":[]}},
+		{}]}}}`
+	var got string
+	client := NewTestClient("alice", func(ctx context.Context, args ...string) (string, error) {
+		got = strings.Join(args, " ")
+		return resp, nil
+	})
+
+	prs, err := client.GetReviewWorkload(context.Background(), []string{"acme/api"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(got, "q=is:pr is:open draft:false repo:acme/api") {
+		t.Errorf("command = %q", got)
+	}
+	if len(prs) != 1 || prs[0].PR.Number != 7 || prs[0].PR.Repo.Owner != "acme" {
+		t.Fatalf("prs = %+v, want only #7", prs)
+	}
+	reqs := prs[0].Requests
+	if len(reqs) != 2 {
+		t.Fatalf("requests = %+v", reqs)
+	}
+	if reqs[0].Reviewer != "alice" || reqs[0].IsTeam || !reqs[0].Since.Equal(time.Date(2024, 5, 4, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("alice = %+v, want the latest request time", reqs[0])
+	}
+	if reqs[1].Reviewer != "acme/platform" || !reqs[1].IsTeam || !reqs[1].Since.Equal(prs[0].PR.CreatedAt) {
+		t.Errorf("team = %+v, want the PR creation time without a request event", reqs[1])
+	}
+}
@@ -0,0 +1,119 @@
+// Package localrun runs commands in a local clone of a PR's repository,
+// with the PR branch checked out, streaming their output line by line.
+package localrun
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// waitDelay bounds how long a cancelled command's children may keep its
+// output open before the run is considered finished.
+const waitDelay = 2 * time.Second
+
+// git runs a git command in dir and returns its trimmed stdout.
+func git(ctx context.Context, dir string, args ...string) (string, error) {
+	cmd := exec.CommandContext(ctx, "git", args...)
+	cmd.Dir = dir
+	out, err := cmd.Output()
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && len(exitErr.Stderr) > 0 {
+			return "", fmt.Errorf("git %s: %s", strings.Join(args, " "), strings.TrimSpace(string(exitErr.Stderr)))
+		}
+		return "", fmt.Errorf("git %s: %w", strings.Join(args, " "), err)
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// EnsureClean fails when the clone at dir has uncommitted changes, which a
+// checkout would carry onto the PR branch or refuse to overwrite.
+func EnsureClean(ctx context.Context, dir string) error {
+	out, err := git(ctx, dir, "status", "--porcelain", "--untracked-files=no")
+	if err != nil {
+		return err
+	}
+	if out != "" {
+		return fmt.Errorf("%s has uncommitted changes; commit or stash them first", dir)
+	}
+	return nil
+}
+
+// CheckoutPR checks out PR number in the clone at dir with `gh pr checkout`,
+// which fetches the head branch (from a fork if need be). An existing local
+// branch is only fast-forwarded: one with commits the PR lacks is an error
+// rather than reset, so unpushed work is never lost.
+func CheckoutPR(ctx context.Context, dir string, number int) error {
+	return ghCheckout(ctx, dir, number)
+}
+
+// ghCheckout runs `gh pr checkout number` in dir with extra flags.
+func ghCheckout(ctx context.Context, dir string, number int, flags ...string) error {
+	args := append([]string{"pr", "checkout", strconv.Itoa(number)}, flags...)
+	cmd := exec.CommandContext(ctx, "gh", args...)
+	cmd.Dir = dir
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return checkoutError(number, string(out))
+	}
+	return nil
+}
+
+// checkoutError describes a failed `gh pr checkout`, explaining the refusal
+// to move a local branch that has diverged from the PR.
+func checkoutError(number int, out string) error {
+	out = strings.TrimSpace(out)
+	if strings.Contains(strings.ToLower(out), "fast-forward") {
+		return fmt.Errorf("gh pr checkout %d: the local branch has commits the PR doesn't; push or reset it, or set runInWorktree (%s)", number, out)
+	}
+	return fmt.Errorf("gh pr checkout %d: %s", number, out)
+}
+
+// Run runs a shell command in dir, calling onLine with each line of its
+// combined stdout and stderr as it is written. It returns the command's exit
+// code; err is set only when the command could not be run or was cancelled.
+func Run(ctx context.Context, dir, command string, onLine func(string)) (int, error) {
+	cmd := exec.CommandContext(ctx, "sh", "-c", command)
+	cmd.Dir = dir
+	cmd.WaitDelay = waitDelay
+	pr, pw := io.Pipe()
+	cmd.Stdout = pw
+	cmd.Stderr = pw
+	if err := cmd.Start(); err != nil {
+		return -1, err
+	}
+
+	waitErr := make(chan error, 1)
+	go func() {
+		err := cmd.Wait()
+		pw.Close()
+		waitErr <- err
+	}()
+
+	sc := bufio.NewScanner(pr)
+	sc.Buffer(make([]byte, 64*1024), 1024*1024)
+	for sc.Scan() {
+		onLine(sc.Text())
+	}
+	// Drain anything left after an over-long line so Wait can return.
+	io.Copy(io.Discard, pr)
+
+	err := <-waitErr
+	if ctx.Err() != nil {
+		return -1, ctx.Err()
+	}
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		return exitErr.ExitCode(), nil
+	}
+	if err != nil {
+		return -1, err
+	}
+	return 0, nil
+}
@@ -0,0 +1,128 @@
+package localrun
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRun_StreamsLinesAndExitCode(t *testing.T) {
+	var lines []string
+	code, err := Run(context.Background(), t.TempDir(), "echo one; echo two >&2; exit 3", func(l string) {
+		lines = append(lines, l)
+	})
+	if err != nil || code != 3 {
+		t.Fatalf("code = %d, err = %v; want 3, nil", code, err)
+	}
+	if strings.Join(lines, ",") != "one,two" {
+		t.Errorf("lines = %q, want stdout and stderr", lines)
+	}
+}
+
+func TestRun_Cancelled(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	if _, err := Run(ctx, t.TempDir(), "sleep 30", func(string) {}); err == nil {
+		t.Error("expected an error for a cancelled run")
+	}
+	if time.Since(start) > 5*time.Second {
+		t.Error("cancelling should stop the command")
+	}
+}
+
+func TestCheckoutPR_NeverForces(t *testing.T) {
+	bin := t.TempDir()
+	argsFile := filepath.Join(bin, "args")
+	script := "#!/bin/sh\necho \"$@\" > " + argsFile + "\necho 'fatal: Not possible to fast-forward, aborting.' >&2\nexit 1\n"
+	if err := os.WriteFile(filepath.Join(bin, "gh"), []byte(script), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", bin+string(os.PathListSeparator)+os.Getenv("PATH"))
+
+	err := CheckoutPR(context.Background(), t.TempDir(), 7)
+	if err == nil || !strings.Contains(err.Error(), "commits the PR doesn't") {
+		t.Errorf("err = %v, want the diverged branch explained", err)
+	}
+	if args, _ := os.ReadFile(argsFile); strings.TrimSpace(string(args)) != "pr checkout 7" {
+		t.Errorf("gh args = %q, want no --force", args)
+	}
+}
+
+func TestEnsureClean(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	dir := t.TempDir()
+	ctx := context.Background()
+	for _, args := range [][]string{
+		{"init", "-q"},
+		{"-c", "user.name=t", "-c", "user.email=t@example.com", "commit", "-q", "--allow-empty", "-m", "init"},
+	} {
+		if _, err := git(ctx, dir, args...); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := EnsureClean(ctx, dir); err != nil {
+		t.Errorf("fresh clone: %v", err)
+	}
+
+	path := filepath.Join(dir, "a.txt")
+	os.WriteFile(path, []byte("a"), 0o644)
+	if err := EnsureClean(ctx, dir); err != nil {
+		t.Errorf("untracked files should not block a checkout: %v", err)
+	}
+	git(ctx, dir, "add", "a.txt")
+	if err := EnsureClean(ctx, dir); err == nil || !strings.Contains(err.Error(), "uncommitted changes") {
+		t.Errorf("staged changes: err = %v", err)
+	}
+}
+
+func TestWorktrees_AddListPrune(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	dir := t.TempDir()
+	ctx := context.Background()
+	for _, args := range [][]string{
+		{"init", "-q"},
+		{"-c", "user.name=t", "-c", "user.email=t@example.com", "commit", "-q", "--allow-empty", "-m", "init"},
+	} {
+		if _, err := git(ctx, dir, args...); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// A worktree of the user's own is never listed or pruned.
+	own := filepath.Join(t.TempDir(), "own")
+	if _, err := git(ctx, dir, "worktree", "add", "-q", "--detach", own); err != nil {
+		t.Fatal(err)
+	}
+
+	path := worktreePath(dir, 7)
+	t.Cleanup(func() { os.RemoveAll(path) })
+	got, err := addWorktree(ctx, dir, path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if again, err := addWorktree(ctx, dir, path); err != nil || again != got {
+		t.Errorf("adding the worktree again = %q, %v; want it reused", again, err)
+	}
+	if list, err := Worktrees(ctx, dir); err != nil || len(list) != 1 {
+		t.Fatalf("Worktrees = %q, %v; want the one prtea created", list, err)
+	}
+
+	n, err := PruneWorktrees(ctx, dir)
+	if err != nil || n != 1 {
+		t.Fatalf("PruneWorktrees = %d, %v; want 1", n, err)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Error("the worktree directory should be removed")
+	}
+	if _, err := os.Stat(own); err != nil {
+		t.Errorf("the user's worktree should be kept: %v", err)
+	}
+}
@@ -0,0 +1,125 @@
+package localrun
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// WorktreeRoot is the directory holding the temporary worktrees prtea
+// creates. Worktrees of every clone live here, so they can be told apart
+// from the user's own.
+func WorktreeRoot() string {
+	return filepath.Join(os.TempDir(), "prtea-worktrees")
+}
+
+// worktreePath is the worktree used for PR number of the clone at dir. The
+// hash of the clone's path keeps clones with the same name apart.
+func worktreePath(dir string, number int) string {
+	h := fnv.New32a()
+	h.Write([]byte(dir))
+	return filepath.Join(WorktreeRoot(), fmt.Sprintf("%s-%08x-pr-%d", filepath.Base(dir), h.Sum32(), number))
+}
+
+// CheckoutPRWorktree checks PR number out into a temporary worktree of the
+// clone at dir, leaving the clone's own branch and changes alone, and returns
+// the worktree's path. The worktree is reused by later runs on the same PR
+// until PruneWorktrees removes it.
+func CheckoutPRWorktree(ctx context.Context, dir string, number int) (string, error) {
+	path, err := addWorktree(ctx, dir, worktreePath(dir, number))
+	if err != nil {
+		return "", err
+	}
+	// Detached, since the PR branch may already be checked out in the clone.
+	if err := ghCheckout(ctx, path, number, "--detach"); err != nil {
+		return "", err
+	}
+	return path, nil
+}
+
+// addWorktree creates a detached worktree of the clone at dir at path,
+// unless there already is one.
+func addWorktree(ctx context.Context, dir, path string) (string, error) {
+	existing, err := Worktrees(ctx, dir)
+	if err != nil {
+		return "", err
+	}
+	for _, w := range existing {
+		if sameDir(w, path) {
+			return w, nil
+		}
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return "", err
+	}
+	if _, err := git(ctx, dir, "worktree", "add", "--detach", path); err != nil {
+		return "", err
+	}
+	return path, nil
+}
+
+// Worktrees lists the worktrees of the clone at dir that prtea created.
+func Worktrees(ctx context.Context, dir string) ([]string, error) {
+	out, err := git(ctx, dir, "worktree", "list", "--porcelain")
+	if err != nil {
+		return nil, err
+	}
+	var paths []string
+	for _, line := range strings.Split(out, "\n") {
+		path, ok := strings.CutPrefix(line, "worktree ")
+		if ok && inWorktreeRoot(path) {
+			paths = append(paths, path)
+		}
+	}
+	return paths, nil
+}
+
+// PruneWorktrees removes the worktrees prtea created for the clone at dir,
+// along with any uncommitted changes in them, and returns how many it
+// removed.
+func PruneWorktrees(ctx context.Context, dir string) (int, error) {
+	paths, err := Worktrees(ctx, dir)
+	if err != nil {
+		return 0, err
+	}
+	removed := 0
+	for _, path := range paths {
+		if _, err := git(ctx, dir, "worktree", "remove", "--force", path); err != nil {
+			return removed, err
+		}
+		removed++
+	}
+	// Drop the records of worktrees whose directories were deleted, e.g. by
+	// a cleaned temporary directory.
+	_, err = git(ctx, dir, "worktree", "prune")
+	return removed, err
+}
+
+// inWorktreeRoot reports whether path is inside WorktreeRoot. git reports
+// paths with symlinks resolved, which on macOS differ from os.TempDir.
+func inWorktreeRoot(path string) bool {
+	root := WorktreeRoot()
+	roots := []string{root}
+	if resolved, err := filepath.EvalSymlinks(filepath.Dir(root)); err == nil {
+		roots = append(roots, filepath.Join(resolved, filepath.Base(root)))
+	}
+	for _, r := range roots {
+		if strings.HasPrefix(path, r+string(filepath.Separator)) {
+			return true
+		}
+	}
+	return false
+}
+
+// sameDir reports whether a and b name the same existing directory.
+func sameDir(a, b string) bool {
+	if a == b {
+		return true
+	}
+	ia, errA := os.Stat(a)
+	ib, errB := os.Stat(b)
+	return errA == nil && errB == nil && os.SameFile(ia, ib)
+}
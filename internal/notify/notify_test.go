@@ -1,6 +1,12 @@
 package notify
 
-import "testing"
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
 
 func TestEscapeAppleScript(t *testing.T) {
 	tests := []struct {
@@ -37,3 +43,43 @@ func TestEscapeAppleScript_BackslashBeforeQuotes(t *testing.T) {
 		t.Errorf("escapeAppleScript(%q) = %q, want %q", input, got, want)
 	}
 }
+
+func TestWebhookPost_Generic(t *testing.T) {
+	var got webhookPayload
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if ct := r.Header.Get("Content-Type"); ct != "application/json" {
+			t.Errorf("Content-Type = %q", ct)
+		}
+		_ = json.NewDecoder(r.Body).Decode(&got)
+	}))
+	defer srv.Close()
+
+	err := Webhook{URL: srv.URL}.Post(context.Background(), EventCIFailed, "CI failed on #1", "https://github.com/o/r/pull/1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := webhookPayload{Event: EventCIFailed, Text: "CI failed on #1", URL: "https://github.com/o/r/pull/1"}
+	if got != want {
+		t.Errorf("payload = %+v, want %+v", got, want)
+	}
+}
+
+func TestWebhookPost_ErrorStatus(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer srv.Close()
+
+	if err := (Webhook{URL: srv.URL}).Post(context.Background(), EventReviewSubmitted, "x", ""); err == nil {
+		t.Error("expected error for non-2xx status")
+	}
+}
+
+func TestIsSlackWebhook(t *testing.T) {
+	if !isSlackWebhook("https://hooks.slack.com/services/T/B/X") {
+		t.Error("expected Slack webhook URL to be detected")
+	}
+	if isSlackWebhook("https://example.com/hooks.slack.com") {
+		t.Error("generic URL detected as Slack")
+	}
+}
@@ -0,0 +1,79 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// Webhook events that can be mirrored to an outbound endpoint.
+const (
+	EventReviewSubmitted = "review_submitted" // a review was submitted from prtea
+	EventCIFailed        = "ci_failed"        // CI failed on one of my PRs
+)
+
+// webhookTimeout bounds a single webhook delivery.
+const webhookTimeout = 10 * time.Second
+
+// Webhook posts event messages to a Slack incoming webhook or a generic
+// HTTP endpoint.
+type Webhook struct {
+	URL    string
+	Client *http.Client // nil uses a client with webhookTimeout
+}
+
+// webhookPayload is the JSON body sent to generic endpoints. Slack incoming
+// webhooks receive only the text field.
+type webhookPayload struct {
+	Event string `json:"event,omitempty"`
+	Text  string `json:"text"`
+	URL   string `json:"url,omitempty"`
+}
+
+// Post delivers a message for event. link is the PR URL, if any; Slack
+// renders it inline, generic endpoints receive it as a separate field.
+func (w Webhook) Post(ctx context.Context, event, text, link string) error {
+	payload := webhookPayload{Event: event, Text: text, URL: link}
+	if isSlackWebhook(w.URL) {
+		payload = webhookPayload{Text: text}
+		if link != "" {
+			payload.Text = fmt.Sprintf("%s <%s>", text, link)
+		}
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to encode webhook payload: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.URL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("invalid webhook URL: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	client := w.Client
+	if client == nil {
+		client = &http.Client{Timeout: webhookTimeout}
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("webhook request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook returned %s", resp.Status)
+	}
+	return nil
+}
+
+// isSlackWebhook reports whether rawURL points at a Slack incoming webhook.
+func isSlackWebhook(rawURL string) bool {
+	u, err := url.Parse(rawURL)
+	return err == nil && u.Host == "hooks.slack.com"
+}
@@ -0,0 +1,202 @@
+package rpc
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Snapshot is the read-only view of prtea state served over the socket.
+type Snapshot struct {
+	Selected        *SelectedPR `json:"selected"`        // nil when no PR is selected
+	PendingComments int         `json:"pendingComments"` // pending inline comments on the selected PR
+	ActiveTab       string      `json:"activeTab"`       // "review" or "mine"
+	ToReview        []PR        `json:"toReview"`
+	MyPRs           []PR        `json:"myPRs"`
+}
+
+// PR is a single entry in the PR list.
+type PR struct {
+	Number         int    `json:"number"`
+	Title          string `json:"title"`
+	Repo           string `json:"repo"` // owner/name
+	Author         string `json:"author"`
+	URL            string `json:"url"`
+	ReviewDecision string `json:"reviewDecision,omitempty"`
+	Draft          bool   `json:"draft,omitempty"`
+}
+
+// SelectedPR describes the PR loaded in the diff viewer.
+type SelectedPR struct {
+	Number        int    `json:"number"`
+	Title         string `json:"title"`
+	Repo          string `json:"repo"`
+	URL           string `json:"url"`
+	CIStatus      string `json:"ciStatus,omitempty"` // "passing", "failing", "pending", "mixed"
+	Files         int    `json:"files"`
+	SelectedHunks int    `json:"selectedHunks"`
+}
+
+// QueryFunc returns the current snapshot. It is called once per request.
+type QueryFunc func(ctx context.Context) (*Snapshot, error)
+
+// queryTimeout bounds how long a request waits for the UI to answer.
+const queryTimeout = 2 * time.Second
+
+// Server answers snapshot queries on a unix socket.
+type Server struct {
+	path  string
+	ln    net.Listener
+	query QueryFunc
+}
+
+// DefaultSocketPath returns the socket path used when --rpc has no value.
+func DefaultSocketPath() string {
+	dir := os.Getenv("XDG_RUNTIME_DIR")
+	if dir == "" {
+		dir = os.TempDir()
+	}
+	return filepath.Join(dir, fmt.Sprintf("prtea-%d.sock", os.Getuid()))
+}
+
+// Listen creates the socket at path and starts serving requests in the
+// background. A stale socket left by a crashed instance is replaced; a live
+// one, or anything at path that isn't a socket, is an error.
+func Listen(path string, query QueryFunc) (*Server, error) {
+	if conn, err := net.Dial("unix", path); err == nil {
+		conn.Close()
+		return nil, fmt.Errorf("socket %s is already in use", path)
+	}
+	if err := removeStaleSocket(path); err != nil {
+		return nil, err
+	}
+
+	ln, err := listenPrivate(path)
+	if err != nil {
+		return nil, err
+	}
+	s := &Server{path: path, ln: ln, query: query}
+	go s.serve()
+	return s, nil
+}
+
+// removeStaleSocket removes a socket nobody is listening on. It refuses to
+// touch anything else, so a mistyped --rpc path can't delete a file.
+func removeStaleSocket(path string) error {
+	info, err := os.Lstat(path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to check %s: %w", path, err)
+	}
+	if info.Mode()&os.ModeSocket == 0 {
+		return fmt.Errorf("%s exists and is not a socket", path)
+	}
+	if err := os.Remove(path); err != nil {
+		return fmt.Errorf("failed to remove stale socket %s: %w", path, err)
+	}
+	return nil
+}
+
+// listenPrivate binds the socket inside a fresh 0700 directory, restricts
+// it to the owner and only then moves it to path, so it is never reachable
+// by other users with the umask's permissions.
+func listenPrivate(path string) (net.Listener, error) {
+	dir, err := os.MkdirTemp(filepath.Dir(path), ".prtea-rpc")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create socket directory: %w", err)
+	}
+	defer os.Remove(dir)
+
+	tmp := filepath.Join(dir, "s")
+	ln, err := net.Listen("unix", tmp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen on %s: %w", path, err)
+	}
+	// The socket is renamed away from tmp; Close removes path instead.
+	ln.(*net.UnixListener).SetUnlinkOnClose(false)
+	if err := os.Chmod(tmp, 0o600); err != nil {
+		ln.Close()
+		os.Remove(tmp)
+		return nil, fmt.Errorf("failed to restrict socket permissions: %w", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		ln.Close()
+		os.Remove(tmp)
+		return nil, fmt.Errorf("failed to listen on %s: %w", path, err)
+	}
+	return ln, nil
+}
+
+// Path returns the socket path.
+func (s *Server) Path() string {
+	return s.path
+}
+
+// Close stops the server and removes the socket file.
+func (s *Server) Close() error {
+	err := s.ln.Close()
+	os.Remove(s.path)
+	return err
+}
+
+func (s *Server) serve() {
+	for {
+		conn, err := s.ln.Accept()
+		if err != nil {
+			if errors.Is(err, net.ErrClosed) {
+				return
+			}
+			continue
+		}
+		go s.handle(conn)
+	}
+}
+
+// handle reads one request per line and writes one JSON response per line.
+// Requests: "state" (or empty), "selected", "pending", "list".
+func (s *Server) handle(conn net.Conn) {
+	defer conn.Close()
+	scanner := bufio.NewScanner(conn)
+	enc := json.NewEncoder(conn)
+	for scanner.Scan() {
+		ctx, cancel := context.WithTimeout(context.Background(), queryTimeout)
+		resp := s.respond(ctx, strings.TrimSpace(scanner.Text()))
+		cancel()
+		if err := enc.Encode(resp); err != nil {
+			return
+		}
+	}
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+// respond builds the response for a single request.
+func (s *Server) respond(ctx context.Context, req string) any {
+	snap, err := s.query(ctx)
+	if err != nil {
+		return errorResponse{Error: err.Error()}
+	}
+	switch req {
+	case "", "state":
+		return snap
+	case "selected":
+		return snap.Selected
+	case "pending":
+		return map[string]int{"pendingComments": snap.PendingComments}
+	case "list":
+		return map[string][]PR{"toReview": snap.ToReview, "myPRs": snap.MyPRs}
+	default:
+		return errorResponse{Error: fmt.Sprintf("unknown request %q (want state, selected, pending or list)", req)}
+	}
+}
@@ -0,0 +1,146 @@
+package rpc
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// socketPath returns a short socket path; t.TempDir can exceed the unix socket length limit.
+func socketPath(t *testing.T) string {
+	dir, err := os.MkdirTemp("", "prtea")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.RemoveAll(dir) })
+	return filepath.Join(dir, "s.sock")
+}
+
+func request(t *testing.T, path, req string) map[string]any {
+	t.Helper()
+	conn, err := net.Dial("unix", path)
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+	defer conn.Close()
+	if _, err := conn.Write([]byte(req + "\n")); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	line, err := bufio.NewReader(conn).ReadBytes('\n')
+	if err != nil {
+		t.Fatalf("read: %v", err)
+	}
+	var out map[string]any
+	if err := json.Unmarshal(line, &out); err != nil {
+		t.Fatalf("unmarshal %q: %v", line, err)
+	}
+	return out
+}
+
+func TestServer_Requests(t *testing.T) {
+	snap := &Snapshot{
+		Selected:        &SelectedPR{Number: 42, Title: "Add retries", Repo: "acme/api"},
+		PendingComments: 3,
+		ActiveTab:       "review",
+		ToReview:        []PR{{Number: 42, Title: "Add retries", Repo: "acme/api"}},
+	}
+	path := socketPath(t)
+	srv, err := Listen(path, func(ctx context.Context) (*Snapshot, error) { return snap, nil })
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer srv.Close()
+
+	state := request(t, path, "state")
+	if state["pendingComments"].(float64) != 3 {
+		t.Errorf("state.pendingComments = %v, want 3", state["pendingComments"])
+	}
+
+	selected := request(t, path, "selected")
+	if selected["number"].(float64) != 42 {
+		t.Errorf("selected.number = %v, want 42", selected["number"])
+	}
+
+	pending := request(t, path, "pending")
+	if pending["pendingComments"].(float64) != 3 {
+		t.Errorf("pending = %v", pending)
+	}
+
+	list := request(t, path, "list")
+	if prs, ok := list["toReview"].([]any); !ok || len(prs) != 1 {
+		t.Errorf("list.toReview = %v", list["toReview"])
+	}
+
+	if bad := request(t, path, "bogus"); bad["error"] == nil {
+		t.Error("expected error for unknown request")
+	}
+}
+
+func TestServer_QueryError(t *testing.T) {
+	path := socketPath(t)
+	srv, err := Listen(path, func(ctx context.Context) (*Snapshot, error) { return nil, errors.New("ui busy") })
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer srv.Close()
+
+	if resp := request(t, path, "state"); resp["error"] != "ui busy" {
+		t.Errorf("error = %v, want %q", resp["error"], "ui busy")
+	}
+}
+
+func TestListen_RejectsLiveSocketAndReplacesStale(t *testing.T) {
+	path := socketPath(t)
+	query := func(ctx context.Context) (*Snapshot, error) { return &Snapshot{}, nil }
+
+	srv, err := Listen(path, query)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Listen(path, query); err == nil {
+		t.Fatal("expected error when socket is in use")
+	}
+	srv.Close()
+
+	// A socket left behind by a crashed instance should be replaced.
+	ln, err := net.Listen("unix", path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ln.(*net.UnixListener).SetUnlinkOnClose(false)
+	ln.Close()
+	srv, err = Listen(path, query)
+	if err != nil {
+		t.Fatalf("expected stale socket to be replaced: %v", err)
+	}
+	if info, err := os.Stat(path); err != nil || info.Mode().Perm() != 0o600 {
+		t.Errorf("socket mode = %v (%v), want 0600", info.Mode().Perm(), err)
+	}
+	srv.Close()
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Error("Close should remove the socket file")
+	}
+	if entries, _ := os.ReadDir(filepath.Dir(path)); len(entries) != 0 {
+		t.Errorf("left behind %v", entries)
+	}
+}
+
+func TestListen_RefusesToReplaceRegularFile(t *testing.T) {
+	path := socketPath(t)
+	if err := os.WriteFile(path, []byte("notes"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	_, err := Listen(path, func(ctx context.Context) (*Snapshot, error) { return &Snapshot{}, nil })
+	if err == nil || !strings.Contains(err.Error(), "not a socket") {
+		t.Fatalf("Listen = %v, want a not-a-socket error", err)
+	}
+	if data, _ := os.ReadFile(path); string(data) != "notes" {
+		t.Error("the regular file at the socket path was touched")
+	}
+}
@@ -0,0 +1,129 @@
+package ui
+
+import (
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/shhac/prtea/internal/config"
+)
+
+// aiHealth is how much work prtea asks of Claude after repeated failures.
+type aiHealth int
+
+const (
+	aiHealthy  aiHealth = iota
+	aiDegraded          // shorter prompts, less history, fewer agentic turns
+	aiDisabled          // AI features refuse to start until :ai reset
+)
+
+// aiErrorBudget counts consecutive Claude failures. Each time the budget is
+// used up, AI health drops a level and the count starts over.
+type aiErrorBudget struct {
+	budget   int // consecutive failures allowed per level
+	failures int // consecutive failures at the current level
+	health   aiHealth
+}
+
+// recordFailure counts a failed Claude call and reports whether health
+// dropped a level.
+func (b *aiErrorBudget) recordFailure() bool {
+	if b.health == aiDisabled {
+		return false
+	}
+	b.failures++
+	if b.budget <= 0 || b.failures < b.budget {
+		return false
+	}
+	b.failures = 0
+	b.health++
+	return true
+}
+
+// recordSuccess clears the failure streak. Health is only restored by reset,
+// so a degraded session stays cheap until the user asks otherwise.
+func (b *aiErrorBudget) recordSuccess() {
+	b.failures = 0
+}
+
+func (b *aiErrorBudget) reset() {
+	b.failures = 0
+	b.health = aiHealthy
+}
+
+// statusLabel is the status bar notice for the budget, empty when healthy
+// with no recent failures.
+func (b aiErrorBudget) statusLabel() string {
+	switch b.health {
+	case aiDegraded:
+		return "⚠ AI degraded"
+	case aiDisabled:
+		return "⊘ AI off"
+	}
+	if b.failures > 0 {
+		return fmt.Sprintf("AI ✗%d/%d", b.failures, b.budget)
+	}
+	return ""
+}
+
+// aiDisabledReason is shown in place of starting an AI feature while the
+// budget has disabled AI.
+const aiDisabledReason = "AI is off after repeated Claude failures. Run :ai reset to try again."
+
+// aiTuning returns the prompt and turn limits for the current health.
+// Degraded mode halves the prompt, history and analysis budgets and limits
+// chat to a single turn.
+func aiTuning(cfg *config.Config, health aiHealth) (promptTokens, history, chatTurns, analysisTurns int) {
+	promptTokens, history = cfg.MaxPromptTokens, cfg.MaxChatHistory
+	chatTurns, analysisTurns = cfg.ChatMaxTurns, cfg.AnalysisMaxTurns
+	if health == aiHealthy {
+		return
+	}
+	return max(1, promptTokens/2), max(2, history/2), 1, max(1, analysisTurns/2)
+}
+
+// applyAIHealth pushes the limits for the current AI health to the Claude
+// services and refreshes the status bar notice.
+func (m *App) applyAIHealth() {
+	m.statusBar.SetAIInfo(m.aiBudget.statusLabel())
+	if m.appConfig == nil {
+		return
+	}
+	promptTokens, history, chatTurns, analysisTurns := aiTuning(m.appConfig, m.aiBudget.health)
+	if m.analyzer != nil {
+		m.analyzer.SetAnalysisMaxTurns(analysisTurns)
+	}
+	if m.chatService != nil {
+		m.chatService.SetMaxPromptTokens(promptTokens)
+		m.chatService.SetMaxHistoryMessages(history)
+		m.chatService.SetMaxTurns(chatTurns)
+	}
+}
+
+// recordAIResult feeds the outcome of a Claude call into the error budget,
+// announcing any change in AI health, and refreshes the usage totals.
+func (m *App) recordAIResult(err error) tea.Cmd {
+	usageCmd := m.refreshUsage()
+	if err == nil {
+		m.aiBudget.recordSuccess()
+		m.statusBar.SetAIInfo(m.aiBudget.statusLabel())
+		return usageCmd
+	}
+	dropped := m.aiBudget.recordFailure()
+	m.applyAIHealth()
+	if !dropped {
+		return usageCmd
+	}
+	msg := fmt.Sprintf("Claude failed %d times in a row — AI switched to degraded mode (shorter prompts). :ai reset to restore", m.aiBudget.budget)
+	if m.aiBudget.health == aiDisabled {
+		msg = "Claude keeps failing — AI features are off. :ai reset to try again"
+	}
+	return tea.Batch(usageCmd, m.statusBar.SetTemporaryMessage(msg, 6*time.Second))
+}
+
+// resetAIHealth restores full AI features after the budget degraded them.
+func (m *App) resetAIHealth() tea.Cmd {
+	m.aiBudget.reset()
+	m.applyAIHealth()
+	return m.statusBar.SetTemporaryMessage("AI features restored", 2*time.Second)
+}
@@ -0,0 +1,96 @@
+package ui
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/shhac/prtea/internal/config"
+)
+
+func TestAIErrorBudget_DegradesThenDisables(t *testing.T) {
+	b := aiErrorBudget{budget: 2}
+
+	if b.recordFailure() {
+		t.Fatal("first failure should stay within budget")
+	}
+	if got := b.statusLabel(); got != "AI ✗1/2" {
+		t.Errorf("label = %q", got)
+	}
+	if !b.recordFailure() || b.health != aiDegraded {
+		t.Fatalf("health = %v, want degraded after 2 failures", b.health)
+	}
+	if b.failures != 0 {
+		t.Errorf("failures = %d, want reset after a level drop", b.failures)
+	}
+
+	b.recordFailure()
+	b.recordSuccess()
+	b.recordFailure()
+	if b.health != aiDegraded {
+		t.Errorf("a success in between should break the streak, health = %v", b.health)
+	}
+	if !b.recordFailure() || b.health != aiDisabled {
+		t.Fatalf("health = %v, want disabled", b.health)
+	}
+	if b.recordFailure() || b.health != aiDisabled {
+		t.Error("disabled is the lowest level")
+	}
+
+	b.reset()
+	if b.health != aiHealthy || b.statusLabel() != "" {
+		t.Errorf("after reset: health = %v, label = %q", b.health, b.statusLabel())
+	}
+}
+
+func TestAITuning(t *testing.T) {
+	cfg := &config.Config{MaxPromptTokens: 100000, MaxChatHistory: 16, ChatMaxTurns: 3, AnalysisMaxTurns: 30}
+
+	tokens, history, chatTurns, analysisTurns := aiTuning(cfg, aiHealthy)
+	if tokens != 100000 || history != 16 || chatTurns != 3 || analysisTurns != 30 {
+		t.Errorf("healthy = %d/%d/%d/%d, want config values", tokens, history, chatTurns, analysisTurns)
+	}
+	tokens, history, chatTurns, analysisTurns = aiTuning(cfg, aiDegraded)
+	if tokens != 50000 || history != 8 || chatTurns != 1 || analysisTurns != 15 {
+		t.Errorf("degraded = %d/%d/%d/%d", tokens, history, chatTurns, analysisTurns)
+	}
+}
+
+// fakeChatServiceForBudget accepts tuning changes; any other call panics.
+type fakeChatServiceForBudget struct{ AIChatService }
+
+func (fakeChatServiceForBudget) SetMaxPromptTokens(int)    {}
+func (fakeChatServiceForBudget) SetMaxHistoryMessages(int) {}
+func (fakeChatServiceForBudget) SetMaxTurns(int)           {}
+
+func TestRecordAIResult_AnnouncesDropAndBlocksChat(t *testing.T) {
+	m := App{
+		appConfig: &config.Config{},
+		aiBudget:  aiErrorBudget{budget: 1},
+		statusBar: NewStatusBarModel(),
+		chatPanel: NewChatPanelModel(),
+		session:   &PRSession{Number: 7},
+	}
+	m.chatService = fakeChatServiceForBudget{}
+
+	if cmd := m.recordAIResult(errors.New("claude timed out")); cmd == nil {
+		t.Fatal("expected a status message when AI is degraded")
+	}
+	if !strings.Contains(m.statusBar.statusMessage, "degraded") {
+		t.Errorf("status = %q", m.statusBar.statusMessage)
+	}
+	m.recordAIResult(errors.New("claude timed out"))
+	if m.aiBudget.health != aiDisabled {
+		t.Fatalf("health = %v, want disabled", m.aiBudget.health)
+	}
+
+	model, _ := m.handleChatSend("hello")
+	if got := model.(App).chatPanel.chat.chatError; got != aiDisabledReason {
+		t.Errorf("chat error = %q, want the disabled notice", got)
+	}
+
+	m.resetAIHealth()
+	if m.aiBudget.health != aiHealthy || m.statusBar.aiInfo != "" {
+		t.Errorf("after :ai reset: health = %v, info = %q", m.aiBudget.health, m.statusBar.aiInfo)
+	}
+}
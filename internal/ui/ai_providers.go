@@ -0,0 +1,62 @@
+package ui
+
+import (
+	"os"
+
+	"github.com/shhac/prtea/internal/claude"
+	"github.com/shhac/prtea/internal/config"
+)
+
+// newLLMProvider builds the backend named by a ChatProvider or
+// AnalysisProvider setting; cliModel is that feature's Claude CLI model. The Anthropic API falls back to the Claude CLI
+// when ANTHROPIC_API_KEY isn't set. It returns nil when the backend can't be
+// used, i.e. it comes down to the Claude CLI and that isn't installed.
+func newLLMProvider(name, cliModel string, cfg *config.Config, claudePath string) claude.LLMProvider {
+	switch name {
+	case config.ProviderAnthropic:
+		if key := os.Getenv("ANTHROPIC_API_KEY"); key != "" {
+			return claude.NewAnthropicProvider("", key, cfg.AnthropicModel)
+		}
+	case config.ProviderOpenAI:
+		key := os.Getenv("OPENAI_API_KEY")
+		if key == "" {
+			key = cfg.OpenAIAPIKey
+		}
+		return claude.NewOpenAIProvider(cfg.OpenAIBaseURL, key, cfg.OpenAIModel)
+	case config.ProviderOllama:
+		return claude.NewOllamaProvider(cfg.OllamaURL, cfg.OllamaModel)
+	}
+	if claudePath == "" {
+		return nil
+	}
+	return claude.NewCLIProvider(claude.NewCLIExecutor(claudePath), cliModel)
+}
+
+// claudeNotFoundReason explains why AI features are unavailable when the
+// Claude CLI backend is chosen but missing.
+const claudeNotFoundReason = "Claude CLI not found.\nInstall from https://docs.anthropic.com/en/docs/claude-code\nor choose another AI backend in Settings."
+
+// applyAIProviders points the analyzer and chat service at the configured
+// backends, creating them if needed. A feature whose backend is unavailable
+// is left without a service.
+func (m *App) applyAIProviders() {
+	cfg := m.appConfig
+	if cfg == nil {
+		return
+	}
+	if p := newLLMProvider(cfg.AnalysisProvider, cfg.AnalysisModel, cfg, m.claudePath); p == nil {
+		m.analyzer = nil
+	} else if m.analyzer != nil {
+		m.analyzer.SetProvider(p)
+	} else {
+		m.analyzer = claude.NewAnalyzerWithProvider(p, cfg.ClaudeTimeoutDuration(), config.PromptsDir(), cfg.AnalysisMaxTurns)
+	}
+	if p := newLLMProvider(cfg.ChatProvider, cfg.ChatModel, cfg, m.claudePath); p == nil {
+		m.chatService = nil
+	} else if m.chatService != nil {
+		m.chatService.SetProvider(p)
+	} else {
+		m.chatService = claude.NewChatServiceWithProvider(p, cfg.ClaudeTimeoutDuration(), m.chatStore, cfg.MaxPromptTokens, cfg.MaxChatHistory, cfg.ChatMaxTurns)
+	}
+	m.applyAIHealth()
+}
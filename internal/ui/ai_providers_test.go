@@ -0,0 +1,59 @@
+package ui
+
+import (
+	"testing"
+
+	"github.com/shhac/prtea/internal/claude"
+	"github.com/shhac/prtea/internal/config"
+)
+
+func TestNewLLMProvider(t *testing.T) {
+	cfg := &config.Config{OpenAIModel: "gpt-test", OllamaModel: "llama3.1"}
+
+	if p := newLLMProvider(config.ProviderClaude, "", cfg, ""); p != nil {
+		t.Errorf("claude without the CLI = %v, want nil", p)
+	}
+	if _, ok := newLLMProvider("", "", cfg, "/usr/bin/claude").(*claude.CLIProvider); !ok {
+		t.Error("an unset provider should default to the Claude CLI")
+	}
+	t.Setenv("ANTHROPIC_API_KEY", "")
+	if _, ok := newLLMProvider(config.ProviderAnthropic, "", cfg, "/usr/bin/claude").(*claude.CLIProvider); !ok {
+		t.Error("the Anthropic API without a key should fall back to the Claude CLI")
+	}
+	t.Setenv("ANTHROPIC_API_KEY", "sk-ant-test")
+	if _, ok := newLLMProvider(config.ProviderAnthropic, "", cfg, "").(*claude.AnthropicProvider); !ok {
+		t.Error("the Anthropic API with a key should not need the CLI")
+	}
+	t.Setenv("OPENAI_API_KEY", "")
+	if p := newLLMProvider(config.ProviderOpenAI, "", cfg, ""); p == nil || p.Name() != "OpenAI-compatible API (gpt-test)" {
+		t.Errorf("openai = %v", p)
+	}
+	if p := newLLMProvider(config.ProviderOllama, "", cfg, ""); p == nil || p.Name() != "Ollama (llama3.1)" {
+		t.Errorf("ollama = %v", p)
+	}
+}
+
+func TestApplyAIProviders_PerFeature(t *testing.T) {
+	m := App{
+		appConfig: &config.Config{ChatProvider: config.ProviderOllama, AnalysisProvider: config.ProviderClaude},
+		statusBar: NewStatusBarModel(),
+	}
+
+	m.applyAIProviders()
+	if m.analyzer != nil {
+		t.Error("analysis on the missing Claude CLI should leave no analyzer")
+	}
+	if m.chatService == nil {
+		t.Fatal("chat on Ollama should work without the Claude CLI")
+	}
+
+	m.appConfig.AnalysisProvider = config.ProviderOpenAI
+	chat := m.chatService
+	m.applyAIProviders()
+	if m.analyzer == nil {
+		t.Error("switching analysis to OpenAI should create an analyzer")
+	}
+	if m.chatService != chat {
+		t.Error("an existing chat service should be kept and re-pointed")
+	}
+}
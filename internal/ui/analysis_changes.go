@@ -0,0 +1,107 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/shhac/prtea/internal/claude"
+)
+
+// compareWithPrevious works out what changed since the analysis before
+// result and shows it above the result. It is shown at once when the diff
+// has changed since that run, so a re-analysis after a push leads with
+// what is new. It returns the changes, or nil without a previous run.
+func (m *App) compareWithPrevious(prev *claude.CachedAnalysis, result *claude.AnalysisResult, diffHash string) *claude.AnalysisDiff {
+	if prev == nil || prev.Result == nil || result == nil {
+		return nil
+	}
+	changes := claude.DiffAnalyses(prev.Result, result)
+	m.chatPanel.SetAnalysisChanges(changes, prev.AnalyzedAt, prev.DiffContentHash != diffHash)
+	return changes
+}
+
+// analysisChangesStatus summarizes a re-analysis for the status bar.
+func analysisChangesStatus(d *claude.AnalysisDiff) string {
+	if d.Empty() {
+		return "Analysis ready: no new findings since the last run"
+	}
+	return fmt.Sprintf("Analysis ready: %d new, %d resolved since the last run (:analysis diff)",
+		len(d.NewFindings)+len(d.NewSuggestions), len(d.ResolvedFindings)+len(d.DroppedSuggestions))
+}
+
+// toggleAnalysisChanges shows or hides what changed since the previous
+// analysis on the Analysis tab.
+func (m App) toggleAnalysisChanges() (tea.Model, tea.Cmd) {
+	if m.chatPanel.AnalysisResult() == nil {
+		return m, m.statusBar.SetTemporaryMessage("No analysis yet — press 'a' to analyze this PR", 2*time.Second)
+	}
+	if m.chatPanel.AnalysisChanges() == nil {
+		return m, m.statusBar.SetTemporaryMessage("No earlier analysis of this PR to compare with", 2*time.Second)
+	}
+	m.chatPanel.ToggleAnalysisChanges()
+	m.chatPanel.SetActiveTab(ChatTabAnalysis)
+	m.chatPanel.viewport.GotoTop()
+	m.showAndFocusPanel(PanelRight)
+	return m, nil
+}
+
+// renderAnalysisChanges renders the changes since the previous analysis:
+// new findings first, then what is no longer raised.
+func renderAnalysisChanges(d *claude.AnalysisDiff, since time.Time, width int) string {
+	var b strings.Builder
+	b.WriteString(sectionHeaderStyle.Render("Since the Last Analysis"))
+	b.WriteString(dimStyle.Render(" (" + formatTimestamp(since) + ")"))
+	b.WriteString("\n")
+	if d.Empty() {
+		b.WriteString(dimStyle.Render("  No new findings: this run raised the same points."))
+		b.WriteString("\n\n")
+		return b.String()
+	}
+
+	// Changed and new lines in full, dropped ones dimmed.
+	line := func(mark, text string) {
+		text = wordWrap(text, width-2)
+		switch mark {
+		case "+":
+			b.WriteString(diffAddedStyle.Render(mark) + " " + text)
+		case "-":
+			b.WriteString(dimStyle.Render(mark + " " + text))
+		default:
+			b.WriteString(boldStyle.Render(mark) + " " + text)
+		}
+		b.WriteString("\n")
+	}
+	if d.RiskBefore != d.RiskAfter && d.RiskBefore != "" {
+		line("~", fmt.Sprintf("Risk %s → %s", d.RiskBefore, d.RiskAfter))
+	}
+	if d.SummaryBefore != "" {
+		line("~", "Summary changed; it was: "+d.SummaryBefore)
+	}
+	for _, f := range d.NewFindings {
+		line("+", findingLabel(f)+f.Comment)
+	}
+	for _, f := range d.NewFiles {
+		line("+", "Now reviewed: "+f)
+	}
+	for _, s := range d.NewSuggestions {
+		line("+", "Suggestion: "+s.Title)
+	}
+	for _, f := range d.ResolvedFindings {
+		line("-", findingLabel(f)+f.Comment)
+	}
+	for _, f := range d.DroppedFiles {
+		line("-", "No longer reviewed: "+f)
+	}
+	for _, s := range d.DroppedSuggestions {
+		line("-", "Suggestion: "+s.Title)
+	}
+	b.WriteString("\n")
+	return b.String()
+}
+
+// findingLabel prefixes a finding with its severity and file.
+func findingLabel(f claude.Finding) string {
+	return fmt.Sprintf("[%s] %s: ", f.Severity, f.File)
+}
@@ -0,0 +1,120 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/shhac/prtea/internal/claude"
+	"github.com/shhac/prtea/internal/github"
+)
+
+// diffTruncations lists what buildDiffContent leaves out of the diff sent
+// for analysis.
+func diffTruncations(files []github.PRFile) []string {
+	var out []string
+	for _, f := range files {
+		if f.Patch == "" {
+			out = append(out, f.Filename+": patch omitted (binary or too large)")
+		}
+	}
+	return out
+}
+
+// showAnalysisInfo toggles the inputs section at the top of the Analysis tab.
+func (m App) showAnalysisInfo() (tea.Model, tea.Cmd) {
+	if m.chatPanel.AnalysisResult() == nil {
+		return m, m.statusBar.SetTemporaryMessage("No analysis yet — press 'a' to analyze this PR", 2*time.Second)
+	}
+	m.chatPanel.ToggleAnalysisInputs()
+	m.chatPanel.SetActiveTab(ChatTabAnalysis)
+	m.chatPanel.viewport.GotoTop()
+	m.showAndFocusPanel(PanelRight)
+	return m, nil
+}
+
+// rerunAnalysis repeats the last analysis run this session with exactly the
+// same input, bypassing the cache, for when a result looks odd. If only a
+// cached result is known, the current diff is analyzed afresh instead.
+func (m App) rerunAnalysis() (tea.Model, tea.Cmd) {
+	if !m.analysisReady() {
+		return m, nil
+	}
+	last := m.session.LastAnalysis
+	if last == nil {
+		model, cmd := m.analyzeCurrentDiff(diffContentHash(m.session.DiffFiles), true)
+		m = model.(App)
+		clearCmd := m.statusBar.SetTemporaryMessage("No run to repeat in this session — analyzing the current diff", 3*time.Second)
+		return m, tea.Batch(cmd, clearCmd)
+	}
+	input := last.input
+	return m.runAnalysis(last.diffHash, true, func() claude.AnalyzeDiffInput { return input })
+}
+
+// rerunComparison describes how a re-run's inputs differ from the run it
+// repeated.
+func rerunComparison(before, after *claude.AnalysisInputs) string {
+	switch {
+	case before == nil || after == nil:
+		return "Analysis re-run complete"
+	case before.DiffHash != after.DiffHash:
+		return "Re-run analyzed a different diff than the original"
+	case before.PromptHash != after.PromptHash:
+		return "Re-run prompt differs from the original (custom prompt edited?)"
+	case before.Model != after.Model:
+		return fmt.Sprintf("Re-run used model %s (original: %s)", modelLabel(after.Model), modelLabel(before.Model))
+	}
+	return "Re-ran analysis with identical inputs"
+}
+
+func modelLabel(model string) string {
+	if model == "" {
+		return "unknown"
+	}
+	return model
+}
+
+// renderAnalysisInputs renders the :analysis info section.
+func renderAnalysisInputs(in *claude.AnalysisInputs, canRerun bool, width int) string {
+	var b strings.Builder
+	b.WriteString(sectionHeaderStyle.Render("Analysis Inputs"))
+	b.WriteString("\n")
+	if in == nil {
+		b.WriteString(wordWrap("Not recorded: this result was cached before analysis inputs were kept.", width))
+		b.WriteString("\n")
+	} else {
+		row := func(label, value string) {
+			b.WriteString(dimStyle.Render(fmt.Sprintf("  %-10s", label)))
+			b.WriteString(value)
+			b.WriteString("\n")
+		}
+		row("Model", modelLabel(in.Model))
+		prompt := in.PromptHash
+		if in.CustomPrompt != "" {
+			prompt += " + " + in.CustomPrompt
+		}
+		row("Prompt", prompt)
+		row("Diff", in.DiffHash)
+		if len(in.Truncations) == 0 {
+			row("Truncated", "nothing")
+		}
+		for i, t := range in.Truncations {
+			label := ""
+			if i == 0 {
+				label = "Truncated"
+			}
+			row(label, t)
+		}
+		if len(in.CIChecks) > 0 {
+			row("CI logs", strings.Join(in.CIChecks, ", "))
+		}
+	}
+	hint := "  :analysis rerun to repeat this run with the same inputs"
+	if !canRerun {
+		hint = "  :analysis rerun to analyze the current diff afresh (this run's input isn't held)"
+	}
+	b.WriteString(dimStyle.Render(hint))
+	b.WriteString("\n\n")
+	return b.String()
+}
@@ -0,0 +1,193 @@
+package ui
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/x/ansi"
+	"github.com/shhac/prtea/internal/claude"
+	"github.com/shhac/prtea/internal/github"
+)
+
+// recordingAnalyzer hands each streamed analysis input to the test.
+type recordingAnalyzer struct {
+	inputs chan claude.AnalyzeDiffInput
+}
+
+func (a recordingAnalyzer) Analyze(context.Context, claude.AnalyzeInput, claude.ProgressFunc) (*claude.AnalysisResult, error) {
+	return nil, nil
+}
+
+func (a recordingAnalyzer) AnalyzeDiff(context.Context, claude.AnalyzeDiffInput, claude.ProgressFunc) (*claude.AnalysisResult, error) {
+	return nil, nil
+}
+
+func (a recordingAnalyzer) AnalyzeDiffStream(_ context.Context, input claude.AnalyzeDiffInput, _ func(string)) (*claude.AnalysisResult, error) {
+	a.inputs <- input
+	return &claude.AnalysisResult{Summary: "again", Model: "m1"}, nil
+}
+
+func (a recordingAnalyzer) SummarizeDiff(context.Context, claude.AnalyzeDiffInput) (*claude.PRSummary, error) {
+	return nil, nil
+}
+
+func (a recordingAnalyzer) DraftMergeMessage(context.Context, claude.AnalyzeDiffInput) (*claude.MergeMessage, error) {
+	return nil, nil
+}
+
+func (a recordingAnalyzer) TriagePR(context.Context, claude.AnalyzeDiffInput) (*claude.PRTriage, error) {
+	return nil, nil
+}
+
+func (a recordingAnalyzer) DiffAnalysisInputs(input claude.AnalyzeDiffInput) *claude.AnalysisInputs {
+	return &claude.AnalysisInputs{PromptHash: "p-" + input.PRTitle, DiffHash: "d"}
+}
+
+func (a recordingAnalyzer) AnalyzeForReview(context.Context, claude.ReviewInput, claude.ProgressFunc) (*claude.ReviewAnalysis, error) {
+	return nil, nil
+}
+
+func (a recordingAnalyzer) SetTimeout(time.Duration)       {}
+func (a recordingAnalyzer) SetAnalysisMaxTurns(int)        {}
+func (a recordingAnalyzer) SetProvider(claude.LLMProvider) {}
+
+func analysisInputsTestApp(t *testing.T) (App, recordingAnalyzer) {
+	analyzer := recordingAnalyzer{inputs: make(chan claude.AnalyzeDiffInput, 1)}
+	m := App{
+		statusBar:     NewStatusBarModel(),
+		chatPanel:     NewChatPanelModel(),
+		claudePath:    "claude",
+		analyzer:      analyzer,
+		analysisStore: claude.NewAnalysisStore(t.TempDir()),
+		session: &PRSession{
+			Owner: "acme", Repo: "widget", Number: 1, Title: "v1",
+			DiffFiles: []github.PRFile{{Filename: "a.go", Patch: "@@ -1 +1 @@\n+x"}},
+		},
+	}
+	return m, analyzer
+}
+
+func TestDiffTruncations(t *testing.T) {
+	got := diffTruncations([]github.PRFile{
+		{Filename: "a.go", Patch: "+x"},
+		{Filename: "logo.png"},
+	})
+	if len(got) != 1 || !strings.HasPrefix(got[0], "logo.png: ") {
+		t.Errorf("truncations = %v", got)
+	}
+}
+
+func TestRerunComparison(t *testing.T) {
+	base := &claude.AnalysisInputs{PromptHash: "p", DiffHash: "d", Model: "m1"}
+	tests := []struct {
+		after *claude.AnalysisInputs
+		want  string
+	}{
+		{&claude.AnalysisInputs{PromptHash: "p", DiffHash: "d", Model: "m1"}, "identical inputs"},
+		{&claude.AnalysisInputs{PromptHash: "q", DiffHash: "d", Model: "m1"}, "prompt differs"},
+		{&claude.AnalysisInputs{PromptHash: "p", DiffHash: "d", Model: "m2"}, "model m2 (original: m1)"},
+	}
+	for _, tt := range tests {
+		if got := rerunComparison(base, tt.after); !strings.Contains(got, tt.want) {
+			t.Errorf("rerunComparison(%+v) = %q, want it to mention %q", tt.after, got, tt.want)
+		}
+	}
+}
+
+func TestRerunAnalysisRepeatsSessionInput(t *testing.T) {
+	m, analyzer := analysisInputsTestApp(t)
+	input := claude.AnalyzeDiffInput{Owner: "acme", Repo: "widget", PRNumber: 1, PRTitle: "v1", DiffContent: "old diff"}
+	model, _ := m.handleAnalysisMsg(AnalysisCompleteMsg{
+		PRNumber: 1, DiffHash: "h1",
+		Result: &claude.AnalysisResult{Summary: "first"},
+		Input:  input,
+		Inputs: &claude.AnalysisInputs{PromptHash: "p-v1", DiffHash: "d", Model: "m1"},
+	})
+	m = model.(App)
+	if m.session.LastAnalysis == nil || !m.chatPanel.analysis.canRerun {
+		t.Fatal("a completed run should be held for :analysis rerun")
+	}
+	cached, _ := m.analysisStore.Get("acme", "widget", 1)
+	if cached == nil || cached.Inputs == nil || cached.Inputs.Model != "m1" {
+		t.Fatalf("cached inputs = %+v", cached)
+	}
+
+	// The diff changes after the run; the re-run still sends the original.
+	m.session.DiffFiles = []github.PRFile{{Filename: "a.go", Patch: "new"}}
+	model, _ = m.rerunAnalysis()
+	m = model.(App)
+	if got := <-analyzer.inputs; got.DiffContent != "old diff" {
+		t.Errorf("re-run sent diff %q, want the original", got.DiffContent)
+	}
+
+	msg := (<-m.session.AnalysisStreamCh).(AnalysisCompleteMsg)
+	if !msg.Rerun || msg.DiffHash != "h1" || msg.Inputs.Model != "m1" {
+		t.Fatalf("complete msg = %+v", msg)
+	}
+	model, _ = m.handleAnalysisMsg(msg)
+	if status := model.(App).statusBar.statusMessage; !strings.Contains(status, "identical inputs") {
+		t.Errorf("status = %q", status)
+	}
+}
+
+func TestAnalysisInfoShowsInputs(t *testing.T) {
+	m, _ := analysisInputsTestApp(t)
+	m.chatPanel.SetSize(80, 30)
+	m.chatPanel.SetAnalysisResult(&claude.AnalysisResult{Summary: "looks fine"})
+	m.chatPanel.SetAnalysisInputs(&claude.AnalysisInputs{
+		PromptHash: "abc123", DiffHash: "def456", Model: "claude-sonnet-4",
+		Truncations: []string{"logo.png: patch omitted (binary or too large)"},
+	}, false)
+
+	model, _ := m.showAnalysisInfo()
+	m = model.(App)
+	view := m.chatPanel.analysis.Render(60, "")
+	for _, want := range []string{"Analysis Inputs", "abc123", "claude-sonnet-4", "logo.png", "analyze the current diff afresh"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("info view missing %q", want)
+		}
+	}
+
+	model, _ = m.showAnalysisInfo()
+	m = model.(App)
+	if view := m.chatPanel.analysis.Render(60, ""); strings.Contains(view, "Analysis Inputs") {
+		t.Error(":analysis info again should hide the inputs")
+	}
+}
+
+func TestAnalysisChangesAfterPush(t *testing.T) {
+	m, _ := analysisInputsTestApp(t)
+	m.chatPanel.SetSize(100, 60)
+	review := func(comments ...string) *claude.AnalysisResult {
+		fr := claude.FileReview{File: "a.go"}
+		for _, c := range comments {
+			fr.Comments = append(fr.Comments, claude.ReviewComment{Severity: "warning", Comment: c})
+		}
+		return &claude.AnalysisResult{Summary: "Adds x", FileReviews: []claude.FileReview{fr}}
+	}
+	model, _ := m.handleAnalysisMsg(AnalysisCompleteMsg{PRNumber: 1, DiffHash: "h1", Result: review("Missing nil check on the input")})
+	m = model.(App)
+	if m.chatPanel.AnalysisChanges() != nil {
+		t.Fatal("the first run has nothing to compare with")
+	}
+
+	model, _ = m.handleAnalysisMsg(AnalysisCompleteMsg{PRNumber: 1, DiffHash: "h2",
+		Result: review("Missing a nil check on input", "Loop never terminates on empty lists")})
+	m = model.(App)
+	if status := m.statusBar.statusMessage; !strings.Contains(status, "1 new, 0 resolved") {
+		t.Errorf("status = %q", status)
+	}
+	out := ansi.Strip(m.chatPanel.analysis.Render(100, ""))
+	since := strings.Index(out, "Since the Last Analysis")
+	if since < 0 || strings.Index(out, "+ [warning] a.go: Loop never terminates") < since || strings.Contains(out[:strings.Index(out, "Summary")], "nil check") {
+		t.Errorf("after a push the new finding should lead, without the reworded old one:\n%s", out)
+	}
+
+	model, _ = m.toggleAnalysisChanges()
+	m = model.(App)
+	if strings.Contains(ansi.Strip(m.chatPanel.analysis.Render(100, "")), "Since the Last Analysis") {
+		t.Error(":analysis diff should hide the changes")
+	}
+}
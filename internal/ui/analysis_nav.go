@@ -0,0 +1,144 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// analysisStop is a place j/k stop at on the Analysis tab: a section
+// header or a file review.
+type analysisStop struct {
+	key  string // section key, or "file:" + path
+	file string // the reviewed file, "" for sections
+	row  int    // line in the rendered tab
+}
+
+// analysisNav is the interactive state of the Analysis tab: folded
+// sections, the stop under the cursor and the severity filter. A nil
+// analysisNav renders a plain, fully expanded result, as while streaming.
+type analysisNav struct {
+	folded      map[string]bool
+	cursor      int    // index into stops, -1 until j/k is pressed
+	minSeverity string // hide file review comments below this; "" shows all
+	stops       []analysisStop
+}
+
+func newAnalysisNav() *analysisNav {
+	return &analysisNav{folded: make(map[string]bool), cursor: -1}
+}
+
+// analysisFilterSteps is the order f cycles the severity filter through.
+var analysisFilterSteps = []string{"", "warning", "critical"}
+
+// header writes a section or file review header, recording it as a stop,
+// and reports whether it is folded.
+func (n *analysisNav) header(b *strings.Builder, key, file, title string) bool {
+	if n == nil {
+		b.WriteString(title + "\n")
+		return false
+	}
+	row := strings.Count(b.String(), "\n")
+	n.stops = append(n.stops, analysisStop{key: key, file: file, row: row})
+	glyph := "▾ "
+	if n.folded[key] {
+		glyph = "▸ "
+	}
+	if n.cursor == len(n.stops)-1 {
+		title = lipgloss.NewStyle().Reverse(true).Render(ansi.Strip(title))
+	}
+	b.WriteString(dimStyle.Render(glyph) + title + "\n")
+	return n.folded[key]
+}
+
+// keep reports whether a comment of this severity passes the filter.
+func (n *analysisNav) keep(severity string) bool {
+	return n == nil || n.minSeverity == "" || aiSeverityRank(severity) >= aiSeverityRank(n.minSeverity)
+}
+
+// filterLabel describes the severity filter, or "" when it is off.
+func (n *analysisNav) filterLabel() string {
+	if n == nil || n.minSeverity == "" {
+		return ""
+	}
+	if n.minSeverity == "critical" {
+		return "critical only"
+	}
+	return n.minSeverity + " and above"
+}
+
+// updateAnalysisNav handles the Analysis tab's navigation keys: j/k move
+// between sections and file reviews, Space folds, f filters by severity
+// and Enter on a file review opens it in the diff. It reports whether it
+// handled the key.
+func (m *ChatPanelModel) updateAnalysisNav(msg tea.KeyMsg) (bool, tea.Cmd) {
+	t := &m.analysis
+	if t.result == nil || t.loading || t.nav == nil {
+		return false, nil
+	}
+	nav := t.nav
+	switch msg.String() {
+	case "j", "down", "k", "up":
+		if len(nav.stops) == 0 {
+			return true, nil
+		}
+		if msg.String() == "j" || msg.String() == "down" {
+			nav.cursor = min(nav.cursor+1, len(nav.stops)-1)
+		} else {
+			nav.cursor = max(nav.cursor-1, 0)
+		}
+	case " ":
+		if nav.cursor < 0 {
+			return true, nil
+		}
+		key := nav.stops[nav.cursor].key
+		nav.folded[key] = !nav.folded[key]
+	case "f":
+		i := 0
+		for j, step := range analysisFilterSteps {
+			if step == nav.minSeverity {
+				i = j
+			}
+		}
+		nav.minSeverity = analysisFilterSteps[(i+1)%len(analysisFilterSteps)]
+	case "enter":
+		if nav.cursor < 0 {
+			return false, nil
+		}
+		stop := nav.stops[nav.cursor]
+		if stop.file != "" {
+			return true, func() tea.Msg { return CitationJumpMsg{Path: stop.file} }
+		}
+		nav.folded[stop.key] = !nav.folded[stop.key]
+	default:
+		return false, nil
+	}
+	t.cache = ""
+	m.refreshViewport()
+	if nav.cursor >= 0 && nav.cursor < len(nav.stops) {
+		row := nav.stops[nav.cursor].row
+		if row < m.viewport.YOffset || row >= m.viewport.YOffset+m.viewport.Height {
+			m.viewport.SetYOffset(max(0, row-2))
+		}
+	}
+	return true, nil
+}
+
+// analysisNavHint describes the Analysis tab's keys for the input line.
+func (m ChatPanelModel) analysisNavHint() string {
+	nav := m.analysis.nav
+	if m.analysis.result == nil || nav == nil {
+		return ""
+	}
+	hint := "j/k sections · Space fold · f filter"
+	if label := nav.filterLabel(); label != "" {
+		hint = fmt.Sprintf("j/k sections · Space fold · f filter (%s)", label)
+	}
+	if nav.cursor >= 0 && nav.cursor < len(nav.stops) && nav.stops[nav.cursor].file != "" {
+		hint += " · Enter open file"
+	}
+	return hint
+}
@@ -0,0 +1,148 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/shhac/prtea/internal/claude"
+)
+
+// confirmPostAnalysis asks before posting the current analysis to the PR
+// conversation (:analysis post), since everyone on the PR will see it.
+func (m App) confirmPostAnalysis() (tea.Model, tea.Cmd) {
+	s := m.session
+	if s == nil {
+		return m, m.statusBar.SetTemporaryMessage("No PR selected", 2*time.Second)
+	}
+	if m.ghClient == nil {
+		return m, nil
+	}
+	r := m.chatPanel.AnalysisResult()
+	if r == nil {
+		return m, m.statusBar.SetTemporaryMessage("No analysis yet — press 'a' to analyze this PR", 2*time.Second)
+	}
+	findings := 0
+	for _, fr := range r.FileReviews {
+		findings += len(fr.Comments)
+	}
+	what := fmt.Sprintf("%d finding(s) across %d file(s), %d suggestion(s)", findings, len(r.FileReviews), len(r.Suggestions))
+	if r.Risk.Level != "" {
+		what = r.Risk.Level + " risk, " + what
+	}
+	m.confirm.SetSize(m.width, m.height)
+	m.confirm.Show(
+		fmt.Sprintf("Post analysis to PR #%d?", s.Number),
+		fmt.Sprintf("%s/%s#%d %s\n\nThe analysis (%s) is posted as a comment on the PR conversation, where everyone on the PR can see it.",
+			s.Owner, s.Repo, s.Number, s.Title, what),
+		"Post comment",
+		AnalysisPostMsg{Owner: s.Owner, Repo: s.Repo, Number: s.Number, Body: analysisCommentMarkdown(r)},
+	)
+	m.setMode(ModeOverlay)
+	return m, nil
+}
+
+// postAnalysisCmd posts a confirmed analysis comment.
+func postAnalysisCmd(client GitHubService, msg AnalysisPostMsg) tea.Cmd {
+	return func() tea.Msg {
+		err := client.PostComment(context.Background(), msg.Owner, msg.Repo, msg.Number, msg.Body)
+		return AnalysisPostedMsg{PRNumber: msg.Number, Err: err}
+	}
+}
+
+// handleAnalysisPosted reports the post and refreshes the Comments tab so
+// the new comment shows up there.
+func (m App) handleAnalysisPosted(msg AnalysisPostedMsg) (tea.Model, tea.Cmd) {
+	if msg.Err != nil {
+		return m, m.statusBar.SetTemporaryMessage("✗ Posting analysis failed: "+msg.Err.Error(), 5*time.Second)
+	}
+	clearCmd := m.statusBar.SetTemporaryMessage(fmt.Sprintf("✓ Posted the analysis to PR #%d", msg.PRNumber), 3*time.Second)
+	if m.ghClient != nil && m.session.MatchesPR(msg.PRNumber) {
+		return m, tea.Batch(clearCmd, fetchCommentsCmd(m.ghClient, m.session.Owner, m.session.Repo, m.session.Number))
+	}
+	return m, clearCmd
+}
+
+// analysisCommentMarkdown renders an analysis result as a PR comment: the
+// risk and summary up front, the longer sections folded into <details> so
+// the conversation stays readable.
+func analysisCommentMarkdown(r *claude.AnalysisResult) string {
+	var b strings.Builder
+	b.WriteString("### AI analysis\n\n")
+	if r.Risk.Level != "" {
+		fmt.Fprintf(&b, "**Risk: %s**", strings.ToUpper(r.Risk.Level))
+		if r.Risk.Reasoning != "" {
+			b.WriteString(" — " + r.Risk.Reasoning)
+		}
+		b.WriteString("\n\n")
+	}
+	if r.EstimatedReviewMinutes > 0 {
+		fmt.Fprintf(&b, "Estimated review time: ~%d min\n\n", r.EstimatedReviewMinutes)
+	}
+	if r.Summary != "" {
+		b.WriteString(r.Summary + "\n\n")
+	}
+
+	details := func(summary, body string) {
+		fmt.Fprintf(&b, "<details>\n<summary>%s</summary>\n\n%s\n</details>\n\n", summary, strings.TrimRight(body, "\n")+"\n")
+	}
+	if len(r.FileReviews) > 0 {
+		var body strings.Builder
+		findings := 0
+		for _, fr := range r.FileReviews {
+			findings += len(fr.Comments)
+			fmt.Fprintf(&body, "**`%s`**", fr.File)
+			if fr.Summary != "" {
+				body.WriteString(" — " + fr.Summary)
+			}
+			body.WriteString("\n\n")
+			for _, c := range fr.Comments {
+				loc := ""
+				if c.Line > 0 {
+					loc = fmt.Sprintf(" (line %d)", c.Line)
+				}
+				fmt.Fprintf(&body, "- **%s**%s: %s\n", c.Severity, loc, c.Comment)
+			}
+			if len(fr.Comments) > 0 {
+				body.WriteString("\n")
+			}
+		}
+		details(fmt.Sprintf("File reviews (%d %s, %d finding(s))", len(r.FileReviews), pluralFiles(len(r.FileReviews)), findings), body.String())
+	}
+	if len(r.ReviewOrder) > 0 {
+		var body strings.Builder
+		for i, e := range r.ReviewOrder {
+			fmt.Fprintf(&body, "%d. `%s` — %s\n", i+1, e.File, e.Reason)
+		}
+		details("Suggested review order", body.String())
+	}
+	if a := r.ArchitectureImpact; a.HasImpact || a.Description != "" {
+		body := a.Description
+		if len(a.AffectedModules) > 0 {
+			body += "\n\nAffected modules: " + strings.Join(a.AffectedModules, ", ")
+		}
+		details("Architecture impact", body)
+	}
+	if t := r.TestCoverage; t.Assessment != "" || len(t.Gaps) > 0 {
+		body := t.Assessment + "\n\n"
+		for _, gap := range t.Gaps {
+			body += "- " + gap + "\n"
+		}
+		details("Test coverage", body)
+	}
+	if len(r.Suggestions) > 0 {
+		var body strings.Builder
+		for _, s := range r.Suggestions {
+			fmt.Fprintf(&body, "- **%s**", s.Title)
+			if s.File != "" {
+				fmt.Fprintf(&body, " (`%s`)", s.File)
+			}
+			body.WriteString(": " + s.Description + "\n")
+		}
+		details(fmt.Sprintf("Suggestions (%d)", len(r.Suggestions)), body.String())
+	}
+	b.WriteString("<sub>Generated by an AI review in prtea; verify before acting on it.</sub>\n")
+	return b.String()
+}
@@ -59,7 +59,7 @@ func (r *AnalysisStreamRenderer) View(width int) string {
 		return ""
 	}
 	if r.rendered == "" {
-		r.rendered = renderAnalysisContent(r.parsed, width)
+		r.rendered = renderAnalysisContent(r.parsed, width, nil, nil)
 	}
 	return r.rendered
 }
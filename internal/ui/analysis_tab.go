@@ -3,6 +3,7 @@ package ui
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/charmbracelet/lipgloss"
 	"github.com/shhac/prtea/internal/claude"
@@ -16,6 +17,17 @@ type AnalysisTabModel struct {
 	stream     AnalysisStreamRenderer
 	cache      string
 	cacheWidth int
+	links      []link // numbered links of the rendered result
+
+	inputs     *claude.AnalysisInputs // what produced result, nil if unknown
+	canRerun   bool                   // the run's exact input is held for :analysis rerun
+	showInputs bool                   // toggled by :analysis info
+
+	changes      *claude.AnalysisDiff // what changed since the previous run, nil if none
+	changesSince time.Time            // when the previous run was made
+	showChanges  bool                 // toggled by :analysis diff
+
+	nav *analysisNav // folds, cursor and severity filter of result
 }
 
 // SetLoading puts the analysis tab into loading state.
@@ -23,6 +35,8 @@ func (t *AnalysisTabModel) SetLoading() {
 	t.loading = true
 	t.error = ""
 	t.result = nil
+	t.inputs = nil
+	t.changes = nil
 	t.stream.Reset()
 	t.cache = ""
 }
@@ -30,6 +44,9 @@ func (t *AnalysisTabModel) SetLoading() {
 // SetResult sets the analysis result and clears loading state.
 func (t *AnalysisTabModel) SetResult(result *claude.AnalysisResult) {
 	t.result = result
+	t.nav = newAnalysisNav()
+	t.inputs = nil
+	t.changes = nil
 	t.loading = false
 	t.error = ""
 	t.stream.Reset()
@@ -41,10 +58,44 @@ func (t *AnalysisTabModel) SetError(err string) {
 	t.error = err
 	t.loading = false
 	t.result = nil
+	t.inputs = nil
+	t.changes = nil
 	t.stream.Reset()
 	t.cache = ""
 }
 
+// SetInputs records the inputs behind the current result.
+func (t *AnalysisTabModel) SetInputs(inputs *claude.AnalysisInputs, canRerun bool) {
+	t.inputs = inputs
+	t.canRerun = canRerun
+	t.cache = ""
+}
+
+// ToggleInputs shows or hides the inputs section and reports whether it is
+// now shown.
+func (t *AnalysisTabModel) ToggleInputs() bool {
+	t.showInputs = !t.showInputs
+	t.cache = ""
+	return t.showInputs
+}
+
+// SetChanges records what changed since the previous run, shown above the
+// result when show is set.
+func (t *AnalysisTabModel) SetChanges(changes *claude.AnalysisDiff, since time.Time, show bool) {
+	t.changes = changes
+	t.changesSince = since
+	t.showChanges = show
+	t.cache = ""
+}
+
+// ToggleChanges shows or hides the changes section and reports whether it
+// is now shown.
+func (t *AnalysisTabModel) ToggleChanges() bool {
+	t.showChanges = !t.showChanges
+	t.cache = ""
+	return t.showChanges
+}
+
 // AppendStreamChunk appends a text chunk during analysis streaming.
 func (t *AnalysisTabModel) AppendStreamChunk(chunk string) {
 	t.stream.Append(chunk)
@@ -58,7 +109,7 @@ func (t *AnalysisTabModel) Render(width int, spinnerView string) string {
 		if t.stream.HasContent() {
 			var b strings.Builder
 			b.WriteString(lipgloss.NewStyle().
-				Foreground(lipgloss.Color("244")).
+				Foreground(theme.Muted).
 				Render(spinnerView + " Analyzing PR with Claude..."))
 			b.WriteString("\n\n")
 			streamView := t.stream.View(width)
@@ -66,13 +117,13 @@ func (t *AnalysisTabModel) Render(width int, spinnerView string) string {
 				b.WriteString(streamView)
 			} else {
 				b.WriteString(lipgloss.NewStyle().
-					Foreground(lipgloss.Color("244")).
+					Foreground(theme.Muted).
 					Render("Waiting for analysis data..."))
 			}
 			return b.String()
 		}
 		return lipgloss.NewStyle().
-			Foreground(lipgloss.Color("244")).
+			Foreground(theme.Muted).
 			Padding(1, 0).
 			Render(spinnerView + " Analyzing PR with Claude...\n\nThis may take a minute.")
 	}
@@ -88,7 +139,25 @@ func (t *AnalysisTabModel) Render(width int, spinnerView string) string {
 		return t.cache
 	}
 
-	result := renderAnalysisContent(t.result, width)
+	var prefix string
+	if t.showInputs {
+		prefix += renderAnalysisInputs(t.inputs, t.canRerun, width)
+	}
+	if t.showChanges && t.changes != nil {
+		prefix += renderAnalysisChanges(t.changes, t.changesSince, width)
+	}
+	if t.nav == nil {
+		t.nav = newAnalysisNav()
+	}
+	var links linkHints
+	t.nav.stops = t.nav.stops[:0]
+	result := prefix + renderAnalysisContent(t.result, width, &links, t.nav)
+	t.links = links.links
+	// Stops are recorded relative to the result; shift them past the prefix.
+	offset := strings.Count(prefix, "\n")
+	for i := range t.nav.stops {
+		t.nav.stops[i].row += offset
+	}
 	t.cache = result
 	t.cacheWidth = width
 	return result
@@ -96,74 +165,79 @@ func (t *AnalysisTabModel) Render(width int, spinnerView string) string {
 
 // renderAnalysisContent renders an AnalysisResult with lipgloss styling.
 // Sections with zero values are skipped, making this suitable for both
-// complete results and partial (streaming) results.
-func renderAnalysisContent(r *claude.AnalysisResult, width int) string {
+// complete results and partial (streaming) results. Links in the text are
+// numbered into links, unless it is nil. With nav, section headers are
+// recorded as stops, folded sections collapse to their header and file
+// review comments below the severity filter are hidden.
+func renderAnalysisContent(r *claude.AnalysisResult, width int, links *linkHints, nav *analysisNav) string {
 	var b strings.Builder
+	wrap := func(s string, w int) string { return wordWrap(links.annotate(s), w) }
 
 	// Risk badge
 	if r.Risk.Level != "" {
 		riskBadge := lipgloss.NewStyle().
 			Bold(true).
-			Foreground(lipgloss.Color("0")).
+			Foreground(theme.Inverse).
 			Background(riskLevelColor(r.Risk.Level)).
 			Padding(0, 1).
 			Render(strings.ToUpper(r.Risk.Level) + " RISK")
 		b.WriteString(riskBadge)
 		b.WriteString("\n")
 		if r.Risk.Reasoning != "" {
-			b.WriteString(wordWrap(r.Risk.Reasoning, width))
+			b.WriteString(wrap(r.Risk.Reasoning, width))
 		}
 		b.WriteString("\n\n")
 	}
 
+	// Review time estimate
+	if r.EstimatedReviewMinutes > 0 {
+		b.WriteString(dimStyle.Render(fmt.Sprintf("Estimated review time: ~%d min", r.EstimatedReviewMinutes)))
+		b.WriteString("\n\n")
+	}
+
 	// Summary
 	if r.Summary != "" {
-		b.WriteString(sectionHeaderStyle.Render("Summary"))
+		if !nav.header(&b, "summary", "", sectionHeaderStyle.Render("Summary")) {
+			b.WriteString(wrap(r.Summary, width))
+			b.WriteString("\n")
+		}
 		b.WriteString("\n")
-		b.WriteString(wordWrap(r.Summary, width))
-		b.WriteString("\n\n")
+	}
+
+	// Suggested review order
+	if len(r.ReviewOrder) > 0 {
+		if nav.header(&b, "order", "", sectionHeaderStyle.Render("Suggested Review Order")) {
+			b.WriteString("\n")
+		} else {
+			renderReviewOrder(&b, r.ReviewOrder, wrap, width)
+		}
 	}
 
 	// Architecture impact
 	if r.ArchitectureImpact.HasImpact {
-		b.WriteString(sectionHeaderStyle.Render("Architecture Impact"))
-		b.WriteString("\n")
-		if r.ArchitectureImpact.Description != "" {
-			b.WriteString(wordWrap(r.ArchitectureImpact.Description, width))
-		}
-		if len(r.ArchitectureImpact.AffectedModules) > 0 {
-			b.WriteString("\nAffected: ")
-			b.WriteString(strings.Join(r.ArchitectureImpact.AffectedModules, ", "))
+		if !nav.header(&b, "arch", "", sectionHeaderStyle.Render("Architecture Impact")) {
+			if r.ArchitectureImpact.Description != "" {
+				b.WriteString(wrap(r.ArchitectureImpact.Description, width))
+			}
+			if len(r.ArchitectureImpact.AffectedModules) > 0 {
+				b.WriteString("\nAffected: ")
+				b.WriteString(strings.Join(r.ArchitectureImpact.AffectedModules, ", "))
+			}
+			b.WriteString("\n")
 		}
-		b.WriteString("\n\n")
+		b.WriteString("\n")
 	}
 
 	// File reviews
 	if len(r.FileReviews) > 0 {
-		b.WriteString(sectionHeaderStyle.Render(fmt.Sprintf("File Reviews (%d)", len(r.FileReviews))))
-		b.WriteString("\n")
-		for _, fr := range r.FileReviews {
-			b.WriteString("\n")
-			b.WriteString(contentAuthorStyle.Render(fr.File))
-			b.WriteString("\n")
-			if fr.Summary != "" {
-				b.WriteString(wordWrap(fr.Summary, width))
-				b.WriteString("\n")
-			}
-			for _, c := range fr.Comments {
-				sev, ok := severityStyles[c.Severity]
-				if !ok {
-					sev = defaultSeverityStyle
-				}
-				sevLabel := sev.Render(c.Severity)
-				if c.Line > 0 {
-					sevLabel += fmt.Sprintf(" L%d", c.Line)
-				}
-				b.WriteString("  ")
-				b.WriteString(sevLabel)
-				b.WriteString(" ")
-				b.WriteString(wordWrap(c.Comment, width-4))
+		title := sectionHeaderStyle.Render(fmt.Sprintf("File Reviews (%d)", len(r.FileReviews)))
+		if label := nav.filterLabel(); label != "" {
+			title += dimStyle.Render(" · " + label)
+		}
+		if !nav.header(&b, "files", "", title) {
+			for _, fr := range r.FileReviews {
 				b.WriteString("\n")
+				renderFileReview(&b, fr, wrap, width, nav)
 			}
 		}
 		b.WriteString("\n")
@@ -171,50 +245,102 @@ func renderAnalysisContent(r *claude.AnalysisResult, width int) string {
 
 	// Test coverage
 	if r.TestCoverage.Assessment != "" {
-		b.WriteString(sectionHeaderStyle.Render("Test Coverage"))
-		b.WriteString("\n")
-		b.WriteString(wordWrap(r.TestCoverage.Assessment, width))
-		if len(r.TestCoverage.Gaps) > 0 {
-			b.WriteString("\nGaps:")
-			for _, gap := range r.TestCoverage.Gaps {
-				b.WriteString("\n  • ")
-				b.WriteString(wordWrap(gap, width-4))
+		if !nav.header(&b, "tests", "", sectionHeaderStyle.Render("Test Coverage")) {
+			b.WriteString(wrap(r.TestCoverage.Assessment, width))
+			if len(r.TestCoverage.Gaps) > 0 {
+				b.WriteString("\nGaps:")
+				for _, gap := range r.TestCoverage.Gaps {
+					b.WriteString("\n  • ")
+					b.WriteString(wrap(gap, width-4))
+				}
 			}
+			b.WriteString("\n")
 		}
-		b.WriteString("\n\n")
+		b.WriteString("\n")
 	}
 
 	// Suggestions
 	if len(r.Suggestions) > 0 {
-		b.WriteString(sectionHeaderStyle.Render(fmt.Sprintf("Suggestions (%d)", len(r.Suggestions))))
-		b.WriteString("\n")
-		for _, s := range r.Suggestions {
-			b.WriteString("\n  • ")
-			b.WriteString(boldStyle.Render(s.Title))
-			if s.Description != "" {
-				b.WriteString("\n    ")
-				b.WriteString(wordWrap(s.Description, width-4))
-			}
-			if s.File != "" {
-				b.WriteString(fmt.Sprintf("\n    File: %s", s.File))
+		if !nav.header(&b, "suggestions", "", sectionHeaderStyle.Render(fmt.Sprintf("Suggestions (%d)", len(r.Suggestions)))) {
+			for _, s := range r.Suggestions {
+				b.WriteString("\n  • ")
+				b.WriteString(boldStyle.Render(s.Title))
+				if s.Description != "" {
+					b.WriteString("\n    ")
+					b.WriteString(wrap(s.Description, width-4))
+				}
+				if s.File != "" {
+					b.WriteString(fmt.Sprintf("\n    File: %s", s.File))
+				}
+				b.WriteString("\n")
 			}
-			b.WriteString("\n")
 		}
 	}
 
 	return b.String()
 }
 
+// renderReviewOrder renders the body of the suggested review order.
+func renderReviewOrder(b *strings.Builder, order []claude.ReviewOrderEntry, wrap func(string, int) string, width int) {
+	for i, e := range order {
+		b.WriteString(fmt.Sprintf("  %d. ", i+1))
+		b.WriteString(contentAuthorStyle.Render(e.File))
+		if e.Reason != "" {
+			b.WriteString("\n     ")
+			b.WriteString(wrap(e.Reason, width-5))
+		}
+		b.WriteString("\n")
+	}
+	b.WriteString(dimStyle.Render("  :guide to step through in this order (f/F next/prev file)"))
+	b.WriteString("\n\n")
+}
+
+// renderFileReview renders one file review, its comments filtered by
+// nav's severity filter.
+func renderFileReview(b *strings.Builder, fr claude.FileReview, wrap func(string, int) string, width int, nav *analysisNav) {
+	if nav.header(b, "file:"+fr.File, fr.File, contentAuthorStyle.Render(fr.File)) {
+		return
+	}
+	if fr.Summary != "" {
+		b.WriteString(wrap(fr.Summary, width))
+		b.WriteString("\n")
+	}
+	hidden := 0
+	for _, c := range fr.Comments {
+		if !nav.keep(c.Severity) {
+			hidden++
+			continue
+		}
+		sev, ok := severityStyles[c.Severity]
+		if !ok {
+			sev = defaultSeverityStyle
+		}
+		sevLabel := sev.Render(c.Severity)
+		if c.Line > 0 {
+			sevLabel += fmt.Sprintf(" L%d", c.Line)
+		}
+		b.WriteString("  ")
+		b.WriteString(sevLabel)
+		b.WriteString(" ")
+		b.WriteString(wrap(c.Comment, width-4))
+		b.WriteString("\n")
+	}
+	if hidden > 0 {
+		b.WriteString(dimStyle.Render(fmt.Sprintf("  (%d hidden by the severity filter)", hidden)))
+		b.WriteString("\n")
+	}
+}
+
 func riskLevelColor(level string) lipgloss.Color {
 	switch level {
 	case "low":
-		return lipgloss.Color("42") // green
+		return theme.Success
 	case "medium":
-		return lipgloss.Color("214") // orange
+		return theme.Warning
 	case "high", "critical":
-		return lipgloss.Color("196") // red
+		return theme.Error
 	default:
-		return lipgloss.Color("244") // gray
+		return theme.Muted
 	}
 }
 
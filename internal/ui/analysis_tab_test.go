@@ -1,8 +1,11 @@
 package ui
 
 import (
+	"strings"
 	"testing"
 
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
 	"github.com/shhac/prtea/internal/claude"
 )
 
@@ -134,6 +137,21 @@ func TestAnalysisTab_StateTransitions(t *testing.T) {
 	}
 }
 
+func TestRenderAnalysisContent_ReviewOrder(t *testing.T) {
+	out := renderAnalysisContent(&claude.AnalysisResult{
+		EstimatedReviewMinutes: 25,
+		ReviewOrder: []claude.ReviewOrderEntry{
+			{File: "auth/token.go", Reason: "handles credentials"},
+		},
+	}, 80, nil, nil)
+
+	for _, want := range []string{"~25 min", "Suggested Review Order", "auth/token.go", "handles credentials"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q", want)
+		}
+	}
+}
+
 func TestRiskLevelColor(t *testing.T) {
 	tests := []struct {
 		level    string
@@ -162,3 +180,48 @@ func TestRiskLevelColor(t *testing.T) {
 		t.Error("low and high risk should have different colors")
 	}
 }
+
+func TestAnalysisTab_NavigateFoldAndFilter(t *testing.T) {
+	m := NewChatPanelModel()
+	m.SetSize(80, 40)
+	m.SetActiveTab(ChatTabAnalysis)
+	m.SetAnalysisResult(&claude.AnalysisResult{
+		Summary: "Adds a cache.",
+		FileReviews: []claude.FileReview{
+			{File: "cache.go", Comments: []claude.ReviewComment{
+				{Line: 3, Severity: "critical", Comment: "Map is not guarded"},
+				{Line: 9, Severity: "suggestion", Comment: "Name the TTL"},
+			}},
+		},
+	})
+	m.refreshViewport()
+	press := func(k string) tea.Cmd {
+		var cmd tea.Cmd
+		m, cmd = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(k)})
+		return cmd
+	}
+	render := func() string { return ansi.Strip(m.analysis.Render(80, "")) }
+
+	// Stops: Summary, File Reviews, cache.go.
+	press("j")
+	press(" ")
+	if out := render(); strings.Contains(out, "Adds a cache.") || !strings.Contains(out, "▸ Summary") {
+		t.Errorf("Space should fold the summary:\n%s", out)
+	}
+
+	press("f")
+	out := render()
+	if strings.Contains(out, "Name the TTL") || !strings.Contains(out, "Map is not guarded") || !strings.Contains(out, "1 hidden") {
+		t.Errorf("f should hide the suggestion:\n%s", out)
+	}
+
+	press("j")
+	press("j")
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil {
+		t.Fatal("Enter on a file review should jump to it")
+	}
+	if jump, ok := cmd().(CitationJumpMsg); !ok || jump.Path != "cache.go" {
+		t.Errorf("Enter = %#v, want a jump to cache.go", jump)
+	}
+}
@@ -10,10 +10,12 @@ import (
 	"github.com/charmbracelet/bubbles/spinner"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/fsnotify/fsnotify"
 	"github.com/shhac/prtea/internal/claude"
 	"github.com/shhac/prtea/internal/config"
 	"github.com/shhac/prtea/internal/demo"
 	"github.com/shhac/prtea/internal/github"
+	"github.com/shhac/prtea/internal/notify"
 )
 
 // App is the root Bubbletea model for the PR dashboard.
@@ -29,10 +31,27 @@ type App struct {
 	commandMode    CommandModeModel
 	settingsPanel  SettingsModel
 	commentOverlay CommentOverlayModel
+	logViewer      LogViewerModel
+	quickAnswer    QuickAnswerModel
+	usageOverlay   UsageOverlayModel
+	mergeMessage   MergeMessageOverlayModel
+	prompts        PromptsOverlayModel
+	pendingList    PendingCommentsModel
+	globalSearch   GlobalSearchModel
+	hunkOrder      HunkOrderModel
+	chatContext    ChatContextModel
+	commentTriage  CommentTriageModel
+	quickfix       QuickfixModel
+	confirm        ConfirmModel
+	workload       WorkloadModel
+	links          LinksModel
 
 	// GitHub client (nil until GHClientReadyMsg)
 	ghClient GitHubService
 
+	// Counter for the temporary (negative) IDs of replies shown while posting
+	optimisticReplySeq int64
+
 	// Currently selected PR session (nil until a PR is selected)
 	session *PRSession
 
@@ -44,15 +63,26 @@ type App struct {
 	analysisStore *claude.AnalysisStore
 	chatStore     *claude.ChatStore
 
+	// Autosave of in-progress text inputs; scratch is nil in tests and demo mode
+	scratch         *scratchStore
+	scratchSaved    scratchText  // the current PR's text as last saved
+	scratchRecovery *scratchFile // unsent text from an earlier session, offered by :recover
+
+	// Heads of PRs as last reviewed and viewed, for :since; nil in tests and demo mode
+	reviewed *reviewLedger
+	prCache  *github.PRCache // last fetched data of each PR, shown on selection while it refreshes; nil when off
+	usage    *usageTracker   // AI token usage and cost; nil disables tracking
+
 	// Layout state
 	focused           Panel
 	width             int
 	height            int
-	panelVisible      [3]bool // which panels are currently visible
-	zoomed            bool    // zoom mode: only focused panel shown
-	preZoomVisible    [3]bool // saved visibility before zoom
-	initialized       bool    // whether first WindowSizeMsg has been processed
-	collapseThreshold int     // terminal width below which panels auto-collapse
+	panelVisible      [3]bool     // which panels are currently visible
+	panelRatios       PanelRatios // user-adjusted panel widths (zero = built-in)
+	zoomed            bool        // zoom mode: only focused panel shown
+	preZoomVisible    [3]bool     // saved visibility before zoom
+	initialized       bool        // whether first WindowSizeMsg has been processed
+	collapseThreshold int         // terminal width below which panels auto-collapse
 
 	// Mode
 	mode AppMode
@@ -70,6 +100,52 @@ type App struct {
 	notifyEnabled   bool            // whether OS notifications are enabled
 	initialLoadDone bool            // true after first successful PR fetch
 	knownPRs        map[string]bool // PR keys seen since boot (for new-PR detection)
+	activity        activityTracker // PR list state for comment/CI/review triggers
+	polled          polledLists     // PR lists as last fetched, for delta polls
+	triage          triageQueue     // background AI triage of the To Review list
+
+	// Per-PR diff search, restored after a refresh or switching back to a PR
+	searchStates map[string]diffSearchState
+
+	// Per-PR review timers started with :timer, keyed by prKey
+	reviewTimers map[string]*reviewTimer
+	timerTicking bool // whether a reviewTimerTickMsg loop is running
+
+	// Recently selected PRs kept resident for Ctrl+O / :switch
+	workspace prWorkspace
+
+	// Batch action on marked PRs (:batch); nil when none is running
+	batch    *batchRun
+	batchSeq int
+
+	// Local runs (:run); runChan is nil when no run is in progress
+	runSeq    int
+	runChan   runStreamChan
+	runCancel context.CancelFunc
+
+	// 1-based position in the quickfix list for :cnext/:cprev; 0 before the first jump
+	quickfixPos int
+
+	// Pending start of a diff motion: "[" or "]" ("]c", "]f") or a count
+	// ("42G"), and the sequence number of its timeout so stale timeouts are
+	// ignored
+	motionPrefix string
+	motionSeq    int
+
+	// Whether a statusBarTickMsg loop is running for the clock and poll segments
+	statusTicking bool
+
+	// Consecutive Claude failures; degrades then disables AI features
+	aiBudget aiErrorBudget
+
+	// Commands from the RC file and --cmd still to run, nil when done
+	script *startupScript
+
+	// :ready or :draft awaiting its confirming repeat, nil if none
+	draftConfirm *draftConfirm
+
+	// Watches the config file to apply edits made outside prtea, nil if unwatched
+	configWatcher *fsnotify.Watcher
 
 	// Demo mode
 	demoMode bool
@@ -80,7 +156,12 @@ type AppOption func(*App)
 
 // WithDemo enables demo mode with mock GitHub data.
 func WithDemo() AppOption {
-	return func(a *App) { a.demoMode = true }
+	return func(a *App) {
+		a.demoMode = true
+		a.scratch = nil  // demo text is not worth recovering
+		a.reviewed = nil // nor are demo heads worth remembering
+		a.prCache = nil  // demo data loads instantly anyway
+	}
 }
 
 // NewApp creates a new App model with default state.
@@ -93,18 +174,12 @@ func NewApp(opts ...AppOption) App {
 		log.Printf("warning: config load failed, using defaults: %v", cfgErr)
 	}
 
+	applyConfigTheme(cfg)
+
 	claudePath, _ := claude.FindClaude()
 
 	chatStore := claude.NewChatStore(config.ChatCacheDir())
 
-	var analyzer AIAnalyzer
-	var chatSvc AIChatService
-	if claudePath != "" {
-		executor := claude.NewCLIExecutor(claudePath)
-		analyzer = claude.NewAnalyzer(executor, cfg.ClaudeTimeoutDuration(), config.PromptsDir(), cfg.AnalysisMaxTurns)
-		chatSvc = claude.NewChatService(executor, cfg.ClaudeTimeoutDuration(), chatStore, cfg.MaxPromptTokens, cfg.MaxChatHistory, cfg.ChatMaxTurns)
-	}
-
 	store := claude.NewAnalysisStore(config.AnalysesCacheDir())
 
 	// Map config default PR tab to constant
@@ -133,43 +208,77 @@ func NewApp(opts ...AppOption) App {
 	chatPanel := NewChatPanelModel()
 	chatPanel.SetStreamCheckpoint(time.Duration(cfg.StreamCheckpointMs) * time.Millisecond)
 	chatPanel.SetDefaultReviewAction(cfg.DefaultReviewAction)
+	chatPanel.SetPresets(cfg.ChatPresets)
+	setSnippets(cfg.Snippets)
+	absoluteTimestamps = cfg.AbsoluteTimestamps
+
+	diffViewer := NewDiffViewerModel()
+	diffViewer.showOutdated = cfg.ShowOutdatedComments
+	diffViewer.minimap = cfg.DiffMinimap
+	ledger, ledgerErr := claude.NewUsageLedger(config.UsageFile())
 
 	app := App{
 		prList:            NewPRListModel(defaultTab),
-		diffViewer:        NewDiffViewerModel(),
+		diffViewer:        diffViewer,
 		chatPanel:         chatPanel,
 		statusBar:         NewStatusBarModel(),
 		helpOverlay:       NewHelpOverlayModel(),
 		commandMode:       NewCommandModeModel(),
 		settingsPanel:     NewSettingsModel(),
 		commentOverlay:    NewCommentOverlayModel(),
+		logViewer:         NewLogViewerModel(),
+		quickAnswer:       NewQuickAnswerModel(),
+		usageOverlay:      NewUsageOverlayModel(),
+		mergeMessage:      NewMergeMessageOverlayModel(),
+		prompts:           NewPromptsOverlayModel(),
+		pendingList:       NewPendingCommentsModel(),
+		globalSearch:      NewGlobalSearchModel(),
+		hunkOrder:         NewHunkOrderModel(),
+		chatContext:       NewChatContextModel(),
+		commentTriage:     NewCommentTriageModel(),
+		quickfix:          NewQuickfixModel(),
+		confirm:           NewConfirmModel(),
+		workload:          NewWorkloadModel(),
+		links:             NewLinksModel(),
 		focused:           PanelLeft,
 		panelVisible:      panelVisible,
+		panelRatios:       panelRatiosFromConfig(cfg.PanelRatios),
 		mode:              ModeNavigation,
 		collapseThreshold: cfg.CollapseThreshold,
 		claudePath:        claudePath,
 		appConfig:         cfg,
-		analyzer:          analyzer,
-		chatService:       chatSvc,
 		analysisStore:     store,
 		chatStore:         chatStore,
+		scratch:           newScratchStore(config.ScratchDir()),
+		reviewed:          newReviewLedger(config.ReviewedFile()),
+		prCache:           newPRCache(cfg),
+		usage:             newUsageTracker(ledger, ledgerErr),
 		pollInterval:      cfg.PollIntervalDuration(),
 		pollEnabled:       cfg.PollEnabled,
 		notifyEnabled:     cfg.NotificationsEnabled,
 		knownPRs:          make(map[string]bool),
+		searchStates:      make(map[string]diffSearchState),
+		reviewTimers:      make(map[string]*reviewTimer),
+		aiBudget:          aiErrorBudget{budget: cfg.AIErrorBudget},
+		workspace:         prWorkspace{size: cfg.WorkspaceSize},
 	}
+	app.statusBar.SetSegments(statusSegmentsFromConfig(cfg))
+	app.applyAIProviders()
 	for _, opt := range opts {
 		opt(&app)
 	}
+	if !app.demoMode {
+		app.configWatcher = newConfigWatcher()
+	}
 	return app
 }
 
 func (m App) Init() tea.Cmd {
-	initCmd := initGHClientCmd
+	initCmd := initGHClientCmd(m.githubToken())
 	if m.demoMode {
 		initCmd = initDemoClientCmd
 	}
-	return tea.Batch(initCmd, m.prList.spinner.Tick)
+	return tea.Batch(initCmd, m.prList.spinner.Tick, autosaveTickCmd(), timestampTickCmd(), watchConfigCmd(m.configWatcher))
 }
 
 // initDemoClientCmd creates a demo GitHubService with fake data.
@@ -186,42 +295,65 @@ func (m App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	// PR list domain: client init, fetching, polling, selection
 	case GHClientReadyMsg, GHClientErrorMsg,
-		PRsLoadedMsg, PRsErrorMsg, PRReviewDecisionsMsg,
-		pollTickMsg, pollPRsLoadedMsg, pollErrorMsg,
-		PRSelectedMsg, PRSelectedAndAdvanceMsg:
+		PRsLoadedMsg, PRsErrorMsg, PRReviewDecisionsMsg, ClosedPRsRequestMsg, ClosedPRsLoadedMsg,
+		pollTickMsg, pollPRsLoadedMsg, pollErrorMsg, prFlashDoneMsg, ciRollupsMsg, webhookErrMsg,
+		PRSelectedMsg, PRSelectedAndAdvanceMsg, BatchStartMsg, BatchItemDoneMsg:
 		return m.handlePRListMsg(msg)
 
 	// Diff domain: diff loading, PR detail, comments, CI, reviews
 	case HunkSelectedAndAdvanceMsg,
-		DiffLoadedMsg, PRDetailLoadedMsg,
-		CommentsLoadedMsg, CIStatusLoadedMsg,
+		DiffLoadedMsg, PRDetailLoadedMsg, MergeRequirementsLoadedMsg, CodeOwnersLoadedMsg,
+		BaseChangedFilesLoadedMsg, StackLoadedMsg, StackDiffLoadedMsg, SinceDiffLoadedMsg, HeadChangedMsg, DeploymentsLoadedMsg, SecurityAlertsLoadedMsg, CoverageLoadedMsg, OpenPreviewRequestMsg, UpdateBranchRequestMsg, UpdateBranchDoneMsg, TaskToggleRequestMsg, TaskToggleDoneMsg, AutoMergeRequestMsg, AutoMergeDoneMsg, DraftStateDoneMsg, GuidedReviewStepMsg, FocusStepMsg, branchUpdateRefreshMsg,
+		CommentsLoadedMsg, CIStatusLoadedMsg, CheckAnnotationsLoadedMsg,
 		CIRerunRequestMsg, CIRerunDoneMsg, CIRerunErrMsg,
+		CIRerunCheckRequestMsg, CIRerunCheckDoneMsg, ciWatchTickMsg, ciLiveTickMsg, RunOutputMsg, RunDoneMsg, WorktreesMsg,
+		CILogsRequestMsg, CILogsLoadedMsg,
 		ReviewsLoadedMsg:
 		return m.handleDiffMsg(msg)
 
 	// Analysis domain: AI analysis and AI review
 	case AnalysisStreamChunkMsg, AnalysisCompleteMsg, AnalysisErrorMsg,
-		AIReviewCompleteMsg, AIReviewErrorMsg:
+		AIReviewCompleteMsg, AIReviewErrorMsg,
+		PRSummaryCompleteMsg, PRSummaryErrorMsg,
+		MergeMessageCompleteMsg, MergeMessageErrorMsg, PRTriagedMsg:
 		return m.handleAnalysisMsg(msg)
 
 	// Chat domain: chat streaming, comments, inline comments
 	case ChatClearMsg, ChatSendMsg,
-		ChatStreamChunkMsg, ChatResponseMsg,
+		ChatStreamChunkMsg, ChatToolUseMsg, ChatResponseMsg,
 		CommentPostMsg, CommentPostedMsg,
+		AnalysisPostMsg, AnalysisPostedMsg,
 		InlineCommentAddMsg,
-		InlineCommentReplyMsg, InlineCommentReplyDoneMsg:
+		InlineCommentReplyMsg, InlineCommentReplyDoneMsg,
+		ApplySuggestionMsg, SuggestionAppliedMsg,
+		HunkQuestionMsg, QuickAnswerChunkMsg, QuickAnswerDoneMsg,
+		ExplainHunkMsg, HunkExplainedMsg, DraftReplyMsg, ReplyDraftedMsg,
+		CitationJumpMsg:
 		return m.handleChatMsg(msg)
 
 	// Review domain: review submission, approval, PR close
 	case ReviewValidationMsg, ReviewSubmitMsg,
 		ReviewSubmitDoneMsg, ReviewSubmitErrMsg,
 		PRApproveDoneMsg, PRApproveErrMsg,
-		PRCloseDoneMsg, PRCloseErrMsg:
+		PRStateRequestMsg, PRCloseDoneMsg, PRCloseErrMsg, PRReopenDoneMsg, PRReopenErrMsg,
+		CISummaryRequestMsg, CISummaryReadyMsg,
+		FileScopeToggleMsg:
 		return m.handleReviewMsg(msg)
 
 	// Config domain: settings, overlays, mode changes, commands
-	case ConfigChangedMsg, HelpClosedMsg, SettingsClosedMsg,
-		ShowCommentOverlayMsg, CommentOverlayClosedMsg,
+	case ConfigChangedMsg, configFileChangedMsg, HelpClosedMsg, SettingsClosedMsg,
+		ShowCommentOverlayMsg, CommentOverlayClosedMsg, CommentOverlayStepMsg,
+		LogViewerClosedMsg, QuickAnswerClosedMsg, UsageClosedMsg,
+		MergeMessageClosedMsg, MergeMessageAcceptedMsg, CopyToClipboardMsg,
+		ExportDoneMsg,
+		PromptsClosedMsg, PromptEditedMsg,
+		PendingCommentsClosedMsg, PendingCommentsChangedMsg,
+		GlobalSearchClosedMsg, ConfirmClosedMsg, QuitConfirmedMsg,
+		WorkloadLoadedMsg, WorkloadClosedMsg, LinksClosedMsg,
+		motionTimeoutMsg,
+		ShowHunkOrderMsg, HunkOrderClosedMsg, QuickfixClosedMsg,
+		ShowChatContextMsg, ChatContextClosedMsg, ChatCILogsMsg,
+		ShowCommentTriageMsg, CommentTriageClosedMsg,
 		CommandExecuteMsg, CommandModeExitMsg, CommandNotFoundMsg,
 		ModeChangedMsg:
 		return m.handleConfigMsg(msg)
@@ -230,13 +362,40 @@ func (m App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case spinner.TickMsg:
 		return m.handleSpinnerTick(msg.(spinner.TickMsg))
 
+	case rpcQueryMsg:
+		msg.(rpcQueryMsg).reply <- m.rpcSnapshot()
+		return m, nil
+
 	case StatusBarClearMsg:
 		m.statusBar.ClearIfSeqMatch(msg.(StatusBarClearMsg).Seq)
 		return m, nil
 
+	case reviewTimerTickMsg:
+		return m.handleReviewTimerTick(msg.(reviewTimerTickMsg).Now)
+
+	case autosaveTickMsg:
+		return m, tea.Batch(m.autosave(), autosaveTickCmd())
+
+	case timestampTickMsg:
+		return m.refreshTimestamps()
+
+	case ScratchLoadedMsg:
+		return m, m.offerScratch(msg.(ScratchLoadedMsg))
+
+	case statusBarTickMsg:
+		return m.handleStatusBarTick(msg.(statusBarTickMsg).Now)
+
+	case rateLimitLoadedMsg:
+		m.statusBar.SetRateLimit(msg.(rateLimitLoadedMsg).RateLimit)
+		return m, nil
+
 	// Key input
 	case tea.KeyMsg:
 		return m.handleKeyMsg(msg.(tea.KeyMsg))
+
+	// Mouse input (only delivered when started with --mouse)
+	case tea.MouseMsg:
+		return m.handleMouseMsg(msg.(tea.MouseMsg))
 	}
 
 	return m, nil
@@ -250,6 +409,20 @@ func (m App) handleWindowSize(msg tea.WindowSizeMsg) (tea.Model, tea.Cmd) {
 	m.commandMode.SetSize(m.width, m.height)
 	m.settingsPanel.SetSize(m.width, m.height)
 	m.commentOverlay.SetSize(m.width, m.height)
+	m.logViewer.SetSize(m.width, m.height)
+	m.quickAnswer.SetSize(m.width, m.height)
+	m.usageOverlay.SetSize(m.width, m.height)
+	m.mergeMessage.SetSize(m.width, m.height)
+	m.prompts.SetSize(m.width, m.height)
+	m.pendingList.SetSize(m.width, m.height)
+	m.globalSearch.SetSize(m.width, m.height)
+	m.hunkOrder.SetSize(m.width, m.height)
+	m.chatContext.SetSize(m.width, m.height)
+	m.commentTriage.SetSize(m.width, m.height)
+	m.quickfix.SetSize(m.width, m.height)
+	m.confirm.SetSize(m.width, m.height)
+	m.workload.SetSize(m.width, m.height)
+	m.links.SetSize(m.width, m.height)
 	if !m.initialized {
 		m.initialized = true
 		if m.width < m.collapseThreshold {
@@ -264,11 +437,19 @@ func (m App) handleWindowSize(msg tea.WindowSizeMsg) (tea.Model, tea.Cmd) {
 }
 
 func (m App) View() string {
-	sizes := CalculatePanelSizes(m.width, m.height, m.panelVisible)
+	if plainOutput || compatOutput {
+		return toASCII(m.view())
+	}
+	return m.view()
+}
+
+// view renders the full screen: panels, status bar and any overlay on top.
+func (m App) view() string {
+	sizes := m.panelSizes()
 
 	if sizes.TooSmall {
 		msg := lipgloss.NewStyle().
-			Foreground(lipgloss.Color("196")).
+			Foreground(theme.Error).
 			Bold(true).
 			Render("Terminal too small. Please resize to at least 80×10.")
 		return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, msg)
@@ -289,15 +470,86 @@ func (m App) View() string {
 	m.statusBar.SetFiltering(m.focused == PanelLeft && m.prList.IsFiltering())
 	m.statusBar.SetDiffSearching(m.focused == PanelCenter && m.diffViewer.IsSearching())
 	m.statusBar.SetDiffSearchInfo(m.diffViewer.SearchInfo())
+	m.statusBar.SetFocusInfo(m.diffViewer.FocusInfo())
 	bar := m.statusBar.View()
 
 	base := lipgloss.JoinVertical(lipgloss.Left, panels, bar)
 
+	// Render the confirmation prompt above everything else
+	if m.confirm.IsVisible() {
+		return m.confirm.View()
+	}
+
 	// Render comment overlay on top if active
 	if m.commentOverlay.IsVisible() {
 		return m.commentOverlay.View()
 	}
 
+	// Render CI log viewer on top if active
+	if m.logViewer.IsVisible() {
+		return m.logViewer.View()
+	}
+
+	// Render quick hunk answer popup on top if active
+	if m.quickAnswer.IsVisible() {
+		return m.quickAnswer.View()
+	}
+
+	// Render AI usage overlay on top if active
+	if m.usageOverlay.IsVisible() {
+		return m.usageOverlay.View()
+	}
+
+	// Render merge message overlay on top if active
+	if m.mergeMessage.IsVisible() {
+		return m.mergeMessage.View()
+	}
+
+	// Render custom prompts overlay on top if active
+	if m.prompts.IsVisible() {
+		return m.prompts.View()
+	}
+
+	// Render pending comments overlay on top if active
+	if m.pendingList.IsVisible() {
+		return m.pendingList.View()
+	}
+
+	// Render global search overlay on top if active
+	if m.globalSearch.IsVisible() {
+		return m.globalSearch.View()
+	}
+
+	// Render hunk order overlay on top if active
+	if m.hunkOrder.IsVisible() {
+		return m.hunkOrder.View()
+	}
+
+	// Render chat context panel on top if active
+	if m.chatContext.IsVisible() {
+		return m.chatContext.View()
+	}
+
+	// Render AI comment triage on top if active
+	if m.commentTriage.IsVisible() {
+		return m.commentTriage.View()
+	}
+
+	// Render reviewer workload overlay on top if active
+	if m.workload.IsVisible() {
+		return m.workload.View()
+	}
+
+	// Render follow-link overlay on top if active
+	if m.links.IsVisible() {
+		return m.links.View()
+	}
+
+	// Render quickfix list on top if active
+	if m.quickfix.IsVisible() {
+		return m.quickfix.View()
+	}
+
 	// Render help overlay on top if active
 	if m.helpOverlay.IsVisible() {
 		return m.helpOverlay.View()
@@ -320,31 +572,52 @@ func (m App) View() string {
 // resets panel state, kicks off data fetches, and optionally advances focus.
 func (m App) selectPR(owner, repo string, number int, htmlURL string, advance bool) (tea.Model, tea.Cmd) {
 	title := ""
-	if item, ok := m.prList.list.SelectedItem().(PRItem); ok {
-		title = item.title
+	var updatedAt time.Time
+	if item, ok := m.prList.findPR(owner, repo, number); ok {
+		title, updatedAt = item.title, item.updatedAt
 	}
 	// Save current chat session before switching PRs
 	if m.chatService != nil && m.session != nil {
 		m.chatService.SaveSession(m.session.Owner, m.session.Repo, m.session.Number)
 	}
 
-	// Cancel any active streams from the previous session
+	// Save the previous PR's unsent text, keep it resident, then cancel any
+	// of its active streams
+	saveCmd := m.autosave()
 	if m.session != nil {
+		m.saveSearchState()
+		if m.session.Owner != owner || m.session.Repo != repo || m.session.Number != number {
+			m.stashSession()
+		}
 		m.session.CancelStreams()
 	}
 
+	// A resident PR comes back as it was left, without refetching
+	if e, ok := m.workspace.take(owner, repo, number); ok {
+		m.restoreSession(e)
+		if advance {
+			m.showAndFocusPanel(PanelCenter)
+		}
+		return m, tea.Batch(saveCmd, m.scratchPRSelected())
+	}
+
 	// Create a fresh session for the new PR
 	m.session = &PRSession{
-		Owner:   owner,
-		Repo:    repo,
-		Number:  number,
-		Title:   title,
-		HTMLURL: htmlURL,
+		Owner:     owner,
+		Repo:      repo,
+		Number:    number,
+		Title:     title,
+		HTMLURL:   htmlURL,
+		UpdatedAt: updatedAt,
 	}
+	m.chatPanel.SetPresetVars(presetVars(m.session, nil))
 
+	m.chatPanel.SetCitationFiles(nil)  // until the new diff loads
 	m.chatPanel.SetAnalysisResult(nil) // clear old analysis
 	m.chatPanel.ClearComments()        // clear old comments
 	m.chatPanel.ClearReview()          // clear old review
+	checklist, _ := config.GetRepoChecklist(config.ChecklistsDir(), owner, repo)
+	m.chatPanel.SetReviewChecklist(checklist)
 
 	// Restore chat from previous session (memory or disk) instead of clearing
 	m.chatPanel.ClearChat()
@@ -354,6 +627,9 @@ func (m App) selectPR(owner, repo string, number int, htmlURL string, advance bo
 		}
 	}
 	m.statusBar.SetSelectedPR(number)
+	m.syncPendingCommentCount()
+	m.syncUsageFooter()
+	m.quickfixPos = 0
 	m.prList.SetSelectedPR(number)
 	m.prList.SetCIStatus("")
 	m.prList.SetReviewDecision("")
@@ -361,9 +637,13 @@ func (m App) selectPR(owner, repo string, number int, htmlURL string, advance bo
 	if advance {
 		m.showAndFocusPanel(PanelCenter)
 	}
+	scratchCmd := tea.Batch(saveCmd, m.scratchPRSelected())
 	if m.ghClient != nil {
 		m.chatPanel.SetCommentsLoading()
+		cachedCmd := m.showCachedPR()
 		return m, tea.Batch(
+			scratchCmd,
+			cachedCmd,
 			fetchDiffCmd(m.ghClient, owner, repo, number),
 			fetchPRDetailCmd(m.ghClient, owner, repo, number),
 			fetchCommentsCmd(m.ghClient, owner, repo, number),
@@ -373,7 +653,42 @@ func (m App) selectPR(owner, repo string, number int, htmlURL string, advance bo
 			m.chatPanel.spinner.Tick,
 		)
 	}
-	return m, nil
+	return m, scratchCmd
+}
+
+// wantCIRollups reports whether CI status of the user's own PRs should be
+// fetched with the PR lists, for notifications or the outbound webhook.
+func (m App) wantCIRollups() bool {
+	return (m.notifyEnabled && m.appConfig.NotifyTriggerEnabled(config.NotifyCIFinished)) ||
+		m.appConfig.WebhookEventEnabled(notify.EventCIFailed)
+}
+
+// reviewWebhookCmd mirrors a review submitted from prtea to the outbound
+// webhook. verb describes the review, e.g. "approved".
+func (m App) reviewWebhookCmd(verb string) tea.Cmd {
+	if m.session == nil {
+		return nil
+	}
+	who := "Review"
+	if m.ghClient != nil && m.ghClient.GetUsername() != "" {
+		who = m.ghClient.GetUsername()
+	}
+	text := fmt.Sprintf("%s %s %s/%s#%d: %s", who, verb, m.session.Owner, m.session.Repo, m.session.Number, m.session.Title)
+	return postWebhookCmd(m.appConfig, notify.EventReviewSubmitted, text, m.session.HTMLURL)
+}
+
+// saveSearchState remembers the diff search for the current PR. Nothing is
+// saved while the diff is still loading, since SetLoading has cleared it.
+func (m *App) saveSearchState() {
+	if m.session == nil || m.diffViewer.loading || m.diffViewer.prNumber != m.session.Number {
+		return
+	}
+	key := prKey(m.session.Owner, m.session.Repo, m.session.Number)
+	if st := m.diffViewer.searchState(); st.term != "" {
+		m.searchStates[key] = st
+	} else {
+		delete(m.searchStates, key)
+	}
 }
 
 // setMode updates the app mode and synchronises the status bar.
@@ -397,8 +712,49 @@ func (m *App) focusPanel(p Panel) {
 	m.statusBar.SetState(m.focused, m.mode)
 }
 
+// panelSizes returns the current panel layout.
+func (m App) panelSizes() PanelSizes {
+	return CalculatePanelSizesWithRatios(m.width, m.height, m.panelVisible, m.panelRatios)
+}
+
+// resizeFocusedPanel grows (dir > 0) or shrinks (dir < 0) the focused panel
+// and persists the new ratios to config.
+func (m *App) resizeFocusedPanel(dir int) tea.Cmd {
+	sizes := m.panelSizes()
+	if sizes.TooSmall || visibleCount(m.panelVisible) < 2 {
+		return m.statusBar.SetTemporaryMessage("Show another panel to resize", 2*time.Second)
+	}
+	step := max(1, int(float64(m.width)*panelResizeStep))
+	m.panelRatios = resizePanelRatios(m.panelRatios, sizes, m.panelVisible, m.focused, dir*step)
+	m.recalcLayout()
+	m.savePanelRatios()
+	return nil
+}
+
+// resetPanelRatios restores the built-in panel proportions.
+func (m *App) resetPanelRatios() tea.Cmd {
+	m.panelRatios = PanelRatios{}
+	m.recalcLayout()
+	m.savePanelRatios()
+	return m.statusBar.SetTemporaryMessage("Panel sizes reset", 2*time.Second)
+}
+
+func (m *App) savePanelRatios() {
+	if m.appConfig == nil {
+		return
+	}
+	if m.panelRatios == (PanelRatios{}) {
+		m.appConfig.PanelRatios = nil
+	} else {
+		m.appConfig.PanelRatios = m.panelRatios[:]
+	}
+	if err := config.Save(m.appConfig); err != nil {
+		log.Printf("warning: failed to save panel sizes: %v", err)
+	}
+}
+
 func (m *App) recalcLayout() {
-	sizes := CalculatePanelSizes(m.width, m.height, m.panelVisible)
+	sizes := m.panelSizes()
 	if sizes.TooSmall {
 		return
 	}
@@ -482,6 +838,8 @@ func (m App) updateFocusedPanel(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		m.prList, cmd = m.prList.Update(msg)
 	case PanelCenter:
 		m.diffViewer, cmd = m.diffViewer.Update(msg)
+		// Switching to the CI tab while checks are pending starts live updates.
+		cmd = tea.Batch(cmd, m.watchCI())
 	case PanelRight:
 		m.chatPanel, cmd = m.chatPanel.Update(msg)
 	}
@@ -494,27 +852,64 @@ func (m App) updateChatPanel(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, cmd
 }
 
-// startAnalysis validates state and kicks off Claude analysis.
-func (m App) startAnalysis() (tea.Model, tea.Cmd) {
+// toggleGuidedReview starts or stops stepping through the diff in the
+// review order suggested by the latest analysis.
+func (m App) toggleGuidedReview() (tea.Model, tea.Cmd) {
+	if m.diffViewer.GuidedReviewActive() {
+		m.diffViewer.StopGuidedReview()
+		clearCmd := m.statusBar.SetTemporaryMessage("Guided review ended", 2*time.Second)
+		return m, clearCmd
+	}
+	result := m.chatPanel.AnalysisResult()
+	if m.session == nil || result == nil || len(result.ReviewOrder) == 0 {
+		clearCmd := m.statusBar.SetTemporaryMessage("Run analysis first to get a suggested review order", 3*time.Second)
+		return m, clearCmd
+	}
+	m.diffViewer.StopFocus() // the guided order spans every file
+	cmd := m.diffViewer.StartGuidedReview(result.ReviewOrder)
+	if cmd == nil {
+		return m, nil
+	}
+	m.showAndFocusPanel(PanelCenter)
+	return m, cmd
+}
+
+// analysisReady reports whether an analysis can start, explaining on the
+// Analysis tab why not.
+func (m *App) analysisReady() bool {
 	if m.session == nil {
 		m.chatPanel.SetAnalysisError("No PR selected. Select a PR first.")
 		m.chatPanel.SetActiveTab(ChatTabAnalysis)
 		m.showAndFocusPanel(PanelRight)
-		return m, nil
+		return false
 	}
-	if m.claudePath == "" {
-		m.chatPanel.SetAnalysisError("Claude CLI not found.\nInstall from https://docs.anthropic.com/en/docs/claude-code")
+	if m.analyzer == nil {
+		m.chatPanel.SetAnalysisError(claudeNotFoundReason)
 		m.chatPanel.SetActiveTab(ChatTabAnalysis)
 		m.showAndFocusPanel(PanelRight)
-		return m, nil
+		return false
 	}
 	if m.session.Analyzing {
-		return m, nil
+		return false
+	}
+	if m.aiBudget.health == aiDisabled {
+		m.chatPanel.SetAnalysisError(aiDisabledReason)
+		m.chatPanel.SetActiveTab(ChatTabAnalysis)
+		m.showAndFocusPanel(PanelRight)
+		return false
 	}
 	if len(m.session.DiffFiles) == 0 {
 		m.chatPanel.SetAnalysisError("No diff loaded. Select a PR to load its diff first.")
 		m.chatPanel.SetActiveTab(ChatTabAnalysis)
 		m.showAndFocusPanel(PanelRight)
+		return false
+	}
+	return true
+}
+
+// startAnalysis validates state and kicks off Claude analysis.
+func (m App) startAnalysis() (tea.Model, tea.Cmd) {
+	if !m.analysisReady() {
 		return m, nil
 	}
 
@@ -522,12 +917,44 @@ func (m App) startAnalysis() (tea.Model, tea.Cmd) {
 	hash := diffContentHash(m.session.DiffFiles)
 	cached, _ := m.analysisStore.Get(m.session.Owner, m.session.Repo, m.session.Number)
 	if cached != nil && !m.analysisStore.IsStale(cached, hash) {
+		m.session.AnalysisInputs = cached.Inputs
 		m.chatPanel.SetAnalysisResult(cached.Result)
+		m.chatPanel.SetAnalysisInputs(cached.Inputs, m.session.LastAnalysis != nil)
+		if prev, _ := m.analysisStore.GetPrevious(m.session.Owner, m.session.Repo, m.session.Number); prev != nil {
+			m.compareWithPrevious(prev, cached.Result, cached.DiffContentHash)
+		}
 		m.chatPanel.SetActiveTab(ChatTabAnalysis)
 		m.showAndFocusPanel(PanelRight)
 		return m, nil
 	}
 
+	return m.analyzeCurrentDiff(hash, false)
+}
+
+// analyzeCurrentDiff analyzes the session's diff as it is now.
+func (m App) analyzeCurrentDiff(hash string, rerun bool) (tea.Model, tea.Cmd) {
+	s := m.session
+	files := s.DiffFiles
+	client, failing := m.ghClient, m.analysisCIChecks()
+	return m.runAnalysis(hash, rerun, func() claude.AnalyzeDiffInput {
+		input := claude.AnalyzeDiffInput{
+			Owner:       s.Owner,
+			Repo:        s.Repo,
+			PRNumber:    s.Number,
+			PRTitle:     s.Title,
+			DiffContent: buildDiffContent(files),
+			Truncations: diffTruncations(files),
+		}
+		if len(failing) > 0 {
+			input.CILogs, input.CIChecks = ciLogTails(context.Background(), client, s.Owner, s.Repo, failing)
+		}
+		return input
+	})
+}
+
+// runAnalysis streams an analysis of the input built by buildInput, which
+// runs off the UI goroutine. hash is the cache key of the files analyzed.
+func (m App) runAnalysis(hash string, rerun bool, buildInput func() claude.AnalyzeDiffInput) (tea.Model, tea.Cmd) {
 	// Cancel any previous analysis stream
 	if m.session.AnalysisStreamCancel != nil {
 		m.session.AnalysisStreamCancel()
@@ -540,21 +967,14 @@ func (m App) startAnalysis() (tea.Model, tea.Cmd) {
 	m.showAndFocusPanel(PanelRight)
 
 	s := m.session
-	files := s.DiffFiles
 	analyzer := m.analyzer
-	ctx, cancel := context.WithCancel(context.Background())
+	ctx, cancel := context.WithCancel(m.usageContext())
 	ch := make(analysisStreamChan)
 
 	go func() {
 		defer close(ch)
-		diffContent := buildDiffContent(files)
-		input := claude.AnalyzeDiffInput{
-			Owner:       s.Owner,
-			Repo:        s.Repo,
-			PRNumber:    s.Number,
-			PRTitle:     s.Title,
-			DiffContent: diffContent,
-		}
+		input := buildInput()
+		inputs := analyzer.DiffAnalysisInputs(input)
 
 		result, err := analyzer.AnalyzeDiffStream(ctx, input, func(text string) {
 			select {
@@ -568,8 +988,10 @@ func (m App) startAnalysis() (tea.Model, tea.Cmd) {
 			case <-ctx.Done():
 			}
 		} else {
+			inputs.Model = result.Model
+			msg := AnalysisCompleteMsg{PRNumber: s.Number, DiffHash: hash, Result: result, Input: input, Inputs: inputs, Rerun: rerun}
 			select {
-			case ch <- AnalysisCompleteMsg{PRNumber: s.Number, DiffHash: hash, Result: result}:
+			case ch <- msg:
 			case <-ctx.Done():
 			}
 		}
@@ -581,15 +1003,20 @@ func (m App) startAnalysis() (tea.Model, tea.Cmd) {
 }
 
 // startAIReview kicks off AI review generation and navigates to the Review tab.
-func (m App) startAIReview() (tea.Model, tea.Cmd) {
+// With selectionOnly, only the selected hunks are sent and reviewed, so the
+// inline comments target just that code.
+func (m App) startAIReview(selectionOnly bool) (tea.Model, tea.Cmd) {
+	if selectionOnly && len(m.diffViewer.selectedHunks) == 0 {
+		return m, m.statusBar.SetTemporaryMessage("Select hunks first (s/Space in the diff)", 2*time.Second)
+	}
 	if m.session == nil {
 		m.chatPanel.SetAIReviewError("No PR selected. Select a PR first.")
 		m.chatPanel.SetActiveTab(ChatTabReview)
 		m.showAndFocusPanel(PanelRight)
 		return m, nil
 	}
-	if m.claudePath == "" {
-		m.chatPanel.SetAIReviewError("Claude CLI not found.\nInstall from https://docs.anthropic.com/en/docs/claude-code")
+	if m.analyzer == nil {
+		m.chatPanel.SetAIReviewError(claudeNotFoundReason)
 		m.chatPanel.SetActiveTab(ChatTabReview)
 		m.showAndFocusPanel(PanelRight)
 		return m, nil
@@ -597,6 +1024,12 @@ func (m App) startAIReview() (tea.Model, tea.Cmd) {
 	if m.chatPanel.IsAIReviewLoading() {
 		return m, nil
 	}
+	if m.aiBudget.health == aiDisabled {
+		m.chatPanel.SetAIReviewError(aiDisabledReason)
+		m.chatPanel.SetActiveTab(ChatTabReview)
+		m.showAndFocusPanel(PanelRight)
+		return m, nil
+	}
 	if len(m.session.DiffFiles) == 0 {
 		m.chatPanel.SetAIReviewError("No diff loaded. Select a PR to load its diff first.")
 		m.chatPanel.SetActiveTab(ChatTabReview)
@@ -609,14 +1042,19 @@ func (m App) startAIReview() (tea.Model, tea.Cmd) {
 		m.session.AIReviewCancel()
 	}
 
-	ctx, cancel := context.WithCancel(context.Background())
+	ctx, cancel := context.WithCancel(m.usageContext())
 	m.session.AIReviewCancel = cancel
 
 	m.chatPanel.SetAIReviewLoading()
 	m.chatPanel.SetActiveTab(ChatTabReview)
 	m.showAndFocusPanel(PanelRight)
 
-	return m, tea.Batch(aiReviewCmd(ctx, m.analyzer, m.session, m.session.DiffFiles), m.chatPanel.spinner.Tick)
+	focus := m.diffViewer.GetFocusHunkContent()
+	var scope map[string]bool
+	if selectionOnly {
+		scope = m.diffViewer.selectedHunkLines()
+	}
+	return m, tea.Batch(aiReviewCmd(ctx, m.analyzer, m.ghClient, m.session, m.session.DiffFiles, focus, scope, m.analysisCIChecks()), m.chatPanel.spinner.Tick)
 }
 
 // refreshPRList re-fetches the PR lists (To Review + My PRs).
@@ -625,7 +1063,15 @@ func (m App) refreshPRList() (tea.Model, tea.Cmd) {
 	if m.ghClient != nil {
 		return m, tea.Batch(fetchPRsCmd(m.ghClient), m.prList.spinner.Tick)
 	}
-	return m, tea.Batch(initGHClientCmd, m.prList.spinner.Tick)
+	return m, tea.Batch(initGHClientCmd(m.githubToken()), m.prList.spinner.Tick)
+}
+
+// githubToken returns the token to use instead of the gh CLI, if any.
+func (m App) githubToken() string {
+	if m.appConfig == nil {
+		return ""
+	}
+	return m.appConfig.GitHubAuthToken()
 }
 
 // refreshSelectedPR re-fetches all data for the currently selected PR
@@ -662,18 +1108,24 @@ func (m App) handleChatSend(message string) (tea.Model, tea.Cmd) {
 		return m, nil
 	}
 	if m.chatService == nil {
-		m.chatPanel.SetChatError("Claude CLI not found.\nInstall from https://docs.anthropic.com/en/docs/claude-code")
+		m.chatPanel.SetChatError(claudeNotFoundReason)
+		return m, nil
+	}
+	if m.aiBudget.health == aiDisabled {
+		m.chatPanel.SetChatError(aiDisabledReason)
 		return m, nil
 	}
 
 	s := m.session
-	var prContext string
-	var hunksSelected bool
-	if selected := m.diffViewer.GetSelectedHunkContent(); selected != "" {
-		prContext = buildSelectedHunkContext(s, s.DiffFiles, selected)
-		hunksSelected = true
-	} else {
-		prContext = buildChatContext(s, s.DiffFiles)
+	prContext, hunksSelected := m.buildChatPRContext()
+
+	// The diff is cut to fit the prompt budget; say so rather than let
+	// Claude answer about files it never saw.
+	var budgetCmd tea.Cmd
+	if tokens, budget := claude.EstimateTokens(prContext), m.chatContextBudget(); tokens > budget {
+		budgetCmd = m.statusBar.SetTemporaryMessage(fmt.Sprintf(
+			"Chat context is ~%s tokens, over the %s budget: the diff will be cut (:context to trim)",
+			formatTokenCount(tokens), formatTokenCount(budget)), 5*time.Second)
 	}
 
 	input := claude.ChatInput{
@@ -684,14 +1136,26 @@ func (m App) handleChatSend(message string) (tea.Model, tea.Cmd) {
 		HunksSelected: hunksSelected,
 		Message:       message,
 	}
+	ch := make(chatStreamChan)
+	ctx, cancel := context.WithCancel(m.usageContext())
+	if dir := m.chatRepoPath(); dir != "" {
+		input.RepoPath = dir
+		input.OnProgress = func(e claude.ProgressEvent) {
+			if e.Type != "tool_use" {
+				return
+			}
+			select {
+			case ch <- ChatToolUseMsg{Line: chatToolLine(e, dir)}:
+			case <-ctx.Done():
+			}
+		}
+	}
 
 	// Cancel any previous stream before starting a new one
 	if s.StreamCancel != nil {
 		s.StreamCancel()
 	}
-	ctx, cancel := context.WithCancel(context.Background())
 
-	ch := make(chatStreamChan)
 	go func() {
 		defer close(ch)
 		response, err := m.chatService.ChatStream(ctx, input, func(text string) {
@@ -715,7 +1179,58 @@ func (m App) handleChatSend(message string) (tea.Model, tea.Cmd) {
 
 	s.StreamChan = ch
 	s.StreamCancel = cancel
-	return m, listenForStream(ch)
+	return m, tea.Batch(budgetCmd, listenForStream(ch))
+}
+
+// handleHunkQuestion sends a one-off question about a single hunk and opens
+// the quick answer popup. The exchange bypasses the chat session history.
+func (m App) handleHunkQuestion(msg HunkQuestionMsg) (tea.Model, tea.Cmd) {
+	if m.session == nil {
+		return m, nil
+	}
+	if m.chatService == nil {
+		clearCmd := m.statusBar.SetTemporaryMessage("Claude CLI not found — install it or choose another chat backend in Settings", 3*time.Second)
+		return m, clearCmd
+	}
+	if m.aiBudget.health == aiDisabled {
+		return m, m.statusBar.SetTemporaryMessage(aiDisabledReason, 3*time.Second)
+	}
+
+	s := m.session
+	input := claude.ChatInput{
+		Owner:     s.Owner,
+		Repo:      s.Repo,
+		PRNumber:  s.Number,
+		PRContext: fmt.Sprintf("PR #%d: \"%s\" in %s/%s\n\n%s", s.Number, s.Title, s.Owner, s.Repo, msg.Hunk),
+		Message:   msg.Question,
+	}
+
+	if s.QuickAskCancel != nil {
+		s.QuickAskCancel()
+	}
+	ctx, cancel := context.WithCancel(m.usageContext())
+
+	ch := make(chatStreamChan)
+	go func() {
+		defer close(ch)
+		answer, err := m.chatService.AskOnce(ctx, input, func(text string) {
+			select {
+			case ch <- QuickAnswerChunkMsg{Content: text}:
+			case <-ctx.Done():
+			}
+		})
+		select {
+		case ch <- QuickAnswerDoneMsg{Content: answer, Err: err}:
+		case <-ctx.Done():
+		}
+	}()
+
+	s.QuickAskChan = ch
+	s.QuickAskCancel = cancel
+	m.quickAnswer.SetSize(m.width, m.height)
+	spinCmd := m.quickAnswer.Show(msg.Target, msg.Question)
+	m.setMode(ModeOverlay)
+	return m, tea.Batch(spinCmd, listenForStream(ch))
 }
 
 // handleReviewSubmit validates state and dispatches the review action.
@@ -741,9 +1256,11 @@ func (m App) handleReviewSubmit(msg ReviewSubmitMsg) (tea.Model, tea.Cmd) {
 	}
 	clearCmd := m.statusBar.SetTemporaryMessage(fmt.Sprintf("%s PR #%d...", actionLabels[action], s.Number), 3*time.Second)
 
-	// Use session's pending pool instead of msg.InlineComments
+	// Use session's pending pool instead of msg.InlineComments, holding
+	// back drafts on files marked out of scope
+	submit, _ := s.SubmittableComments()
 	var inlineComments []claude.InlineReviewComment
-	for _, c := range s.PendingInlineComments {
+	for _, c := range submit {
 		inlineComments = append(inlineComments, c.InlineReviewComment)
 	}
 	return m, tea.Batch(clearCmd, submitReviewCmd(client, s.Owner, s.Repo, s.Number, action, body, inlineComments))
@@ -798,7 +1315,7 @@ func (m App) handleInlineCommentAdd(msg InlineCommentAddMsg) (tea.Model, tea.Cmd
 			}
 		}
 		m.diffViewer.SetPendingInlineComments(m.session.PendingInlineComments)
-		m.chatPanel.SetPendingCommentCount(len(m.session.PendingInlineComments))
+		m.syncPendingCommentCount()
 		if removed {
 			clearCmd := m.statusBar.SetTemporaryMessage(
 				fmt.Sprintf("Comment removed on %s:%d", msg.Path, msg.Line), 2*time.Second)
@@ -834,7 +1351,7 @@ func (m App) handleInlineCommentAdd(msg InlineCommentAddMsg) (tea.Model, tea.Cmd
 		m.session.PendingInlineComments = append(m.session.PendingInlineComments, comment)
 	}
 	m.diffViewer.SetPendingInlineComments(m.session.PendingInlineComments)
-	m.chatPanel.SetPendingCommentCount(len(m.session.PendingInlineComments))
+	m.syncPendingCommentCount()
 	action := "added"
 	if found {
 		action = "updated"
@@ -906,26 +1423,103 @@ func (m *App) detectNewPRs(toReview []github.PRItem) []github.PRItem {
 }
 
 // executeCommand dispatches a named command from the command palette.
-func (m App) executeCommand(name string) (tea.Model, tea.Cmd) {
+func (m App) executeCommand(name, args string) (tea.Model, tea.Cmd) {
 	switch name {
 	case "analyze":
 		return m.startAnalysis()
+	case "analysis info":
+		return m.showAnalysisInfo()
+	case "analysis diff":
+		return m.toggleAnalysisChanges()
+	case "analysis post":
+		return m.confirmPostAnalysis()
+	case "analysis rerun":
+		return m.rerunAnalysis()
+	case "summarize":
+		return m.startSummary()
+	case "merge message":
+		return m.startMergeMessage()
 	case "review":
-		return m.startAIReview()
+		return m.startAIReview(false)
+	case "review selection":
+		return m.startAIReview(true)
+	case "review triage":
+		return m.openCommentTriage()
+	case "close":
+		return m.confirmClosePR()
+	case "reopen":
+		return m.confirmReopenPR()
+	case "workload":
+		return m.showWorkload()
+	case "link":
+		return m.followLink(args)
+	case "stack next":
+		return m.moveInStack(1)
+	case "stack prev":
+		return m.moveInStack(-1)
+	case "stack diff":
+		return m.toggleStackDiff()
+	case "since":
+		return m.toggleSinceDiff()
+	case "preview":
+		return m.openPreview()
+	case "security":
+		return m.jumpToSecurityAlert(args)
+	case "coverage":
+		return m.toggleCoverage()
+	case "run":
+		return m.startRun(args)
+	case "worktree":
+		return m.worktreeCommand(args)
+	case "recover":
+		return m.recoverScratch(args)
+	case "batch":
+		return m.batchCommand(args)
 	case "open":
-		if m.session != nil && m.session.HTMLURL != "" {
-			return m, openBrowserCmd(m.session.HTMLURL)
-		}
-		return m, nil
+		return m.openPR(args)
 	case "clear selection":
 		if m.diffViewer.activeTab == TabDiff && len(m.diffViewer.selectedHunks) > 0 {
-			for idx := range m.diffViewer.selectedHunks {
-				m.diffViewer.markHunkDirty(idx)
-			}
-			m.diffViewer.selectedHunks = nil
+			m.diffViewer.clearHunkSelection()
 			m.diffViewer.refreshContent()
 		}
 		return m, nil
+	case "switch":
+		return m.switchPR(args)
+	case "file":
+		return m.jumpToNamedFile(args)
+	case "goto":
+		return m.gotoCommand(args)
+	case "marks":
+		return m.marksCommand(args)
+	case "find":
+		return m.showGlobalSearch()
+	case "export patch":
+		return m.exportPatch(args)
+	case "export analysis":
+		return m.exportAnalysis(args)
+	case "export report":
+		return m.exportReport(args)
+	case "yank url":
+		return m.yankURL()
+	case "yank path":
+		return m.yankPath()
+	case "cnext":
+		return m.quickfixStep(1)
+	case "cprev":
+		return m.quickfixStep(-1)
+	case "copen":
+		return m.openQuickfix()
+	case "order hunks":
+		if len(m.diffViewer.selectedHunks) == 0 {
+			return m, m.statusBar.SetTemporaryMessage("Select hunks first (s/Space in the diff)", 2*time.Second)
+		}
+		primary := -1
+		if p, ok := m.diffViewer.primarySelectedHunk(); ok {
+			primary = p
+		}
+		return m.handleConfigMsg(ShowHunkOrderMsg{Items: m.diffViewer.hunkOrderItems(), Primary: primary})
+	case "context":
+		return m.openChatContext()
 	case "comment":
 		if m.focused != PanelCenter || m.diffViewer.activeTab != TabDiff || len(m.diffViewer.hunks) == 0 {
 			clearCmd := m.statusBar.SetTemporaryMessage("Focus the diff viewer to add comments", 2*time.Second)
@@ -939,6 +1533,32 @@ func (m App) executeCommand(name string) (tea.Model, tea.Cmd) {
 		return m, nil
 	case "rerun ci":
 		return m, func() tea.Msg { return CIRerunRequestMsg{} }
+	case "update branch":
+		return m, func() tea.Msg { return UpdateBranchRequestMsg{} }
+	case "ci summary":
+		return m, func() tea.Msg { return CISummaryRequestMsg{} }
+	case "auto-merge squash", "auto-merge merge", "auto-merge rebase":
+		method := strings.ToUpper(strings.TrimPrefix(name, "auto-merge "))
+		return m, func() tea.Msg { return AutoMergeRequestMsg{Method: method} }
+	case "auto-merge off":
+		return m, func() tea.Msg { return AutoMergeRequestMsg{} }
+	case "ready":
+		return m.setDraftState(false)
+	case "draft":
+		return m.setDraftState(true)
+	case "focus":
+		return m.toggleFocus()
+	case "guide":
+		return m.toggleGuidedReview()
+	case "exclude file":
+		path := m.diffViewer.focusedFilename()
+		if path == "" {
+			clearCmd := m.statusBar.SetTemporaryMessage("No file focused in the diff", 2*time.Second)
+			return m, clearCmd
+		}
+		return m.toggleFileScope(path)
+	case "timer":
+		return m.reviewTimerCommand(args)
 	case "refresh":
 		if m.focused == PanelLeft {
 			return m.refreshPRList()
@@ -947,7 +1567,7 @@ func (m App) executeCommand(name string) (tea.Model, tea.Cmd) {
 	case "new":
 		return m, func() tea.Msg { return ChatClearMsg{} }
 	case "quit":
-		return m, tea.Quit
+		return m, m.quit()
 	case "help":
 		m.setMode(ModeOverlay)
 		m.helpOverlay.SetSize(m.width, m.height)
@@ -961,6 +1581,16 @@ func (m App) executeCommand(name string) (tea.Model, tea.Cmd) {
 	case "zoom":
 		m.toggleZoom()
 		return m, nil
+	case "reset panels":
+		return m, m.resetPanelRatios()
+	case "ai reset":
+		return m, m.resetAIHealth()
+	case "usage":
+		return m.showUsage()
+	case "prompts":
+		return m.showPrompts()
+	case "pending":
+		return m.showPendingComments()
 	case "prs":
 		m.showAndFocusPanel(PanelLeft)
 		return m, nil
@@ -1004,10 +1634,12 @@ func (m App) renderCommandOverlay(base string) string {
 	overlayLines := strings.Split(overlay, "\n")
 	baseLines := strings.Split(base, "\n")
 
-	overlayH := len(overlayLines)
-	if overlayH > len(baseLines) {
-		overlayH = len(baseLines)
+	// When the palette is taller than the screen, keep its bottom lines so
+	// the input line stays visible.
+	if len(overlayLines) > len(baseLines) {
+		overlayLines = overlayLines[len(overlayLines)-len(baseLines):]
 	}
+	overlayH := len(overlayLines)
 
 	start := len(baseLines) - overlayH
 	for i := 0; i < len(overlayLines) && start+i < len(baseLines); i++ {
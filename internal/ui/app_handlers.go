@@ -2,6 +2,7 @@ package ui
 
 import (
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/charmbracelet/bubbles/key"
@@ -9,6 +10,7 @@ import (
 	"github.com/charmbracelet/bubbles/spinner"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/shhac/prtea/internal/config"
+	"github.com/shhac/prtea/internal/github"
 )
 
 // -- PR list domain handlers --
@@ -23,44 +25,84 @@ func (m App) handlePRListMsg(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	case GHClientErrorMsg:
 		m.prList.SetError(msg.Err.Error())
-		return m, nil
+		return m.scriptListLoaded()
 
 	case PRsLoadedMsg:
 		toReview := convertPRItems(msg.ToReview)
 		myPRs := convertPRItems(msg.MyPRs)
+		if !msg.Batched {
+			m.prList.keepAutoMerge(toReview, nil)
+			m.prList.keepAutoMerge(myPRs, nil)
+		}
 		m.prList.SetItems(toReview, myPRs)
+		m.polled.record(msg.ToReview, msg.MyPRs)
 		if !m.initialLoadDone {
 			m.initialLoadDone = true
 			m.snapshotKnownPRs(msg.ToReview, msg.MyPRs)
 		}
 		var cmds []tea.Cmd
-		if m.ghClient != nil {
-			allPRs := append(msg.ToReview, msg.MyPRs...)
-			cmds = append(cmds, fetchReviewDecisionsCmd(m.ghClient, allPRs))
+		notes := m.activity.observePRs(msg.ToReview, msg.MyPRs, m.appConfig)
+		if m.notifyEnabled {
+			cmds = append(cmds, sendNotificationsCmd(notes, m.appConfig.NotificationThreshold))
 		}
+		cmds = append(cmds, m.prListFollowUps(msg.ToReview, msg.MyPRs, msg.Batched)...)
+		cmds = append(cmds, m.queueTriage(msg.ToReview))
 		if m.pollEnabled && m.pollInterval > 0 {
-			cmds = append(cmds, pollTickCmd(m.pollInterval))
+			cmds = append(cmds, m.schedulePollTick())
 		}
-		return m, tea.Batch(cmds...)
+		cmds = append(cmds, m.refreshRateLimit(), m.startStatusBarTick())
+		model, cmd := m.scriptListLoaded()
+		return model, tea.Batch(append(cmds, cmd)...)
+
+	case ClosedPRsRequestMsg:
+		if m.ghClient == nil {
+			return m, nil
+		}
+		return m, fetchClosedPRsCmd(m.ghClient)
+
+	case ClosedPRsLoadedMsg:
+		if msg.Err != nil {
+			m.prList.SetClosedError(msg.Err.Error())
+			return m, nil
+		}
+		m.prList.SetClosed(convertPRItems(msg.PRs))
+		return m, nil
+
+	case BatchStartMsg:
+		return m.startBatch(msg.Action, msg.PRs)
+
+	case BatchItemDoneMsg:
+		return m.batchItemDone(msg)
 
 	case PRReviewDecisionsMsg:
 		m.prList.UpdateReviewDecisions(msg.Decisions)
-		return m, nil
+		return m, m.observeDecisions(msg.Decisions)
+
+	case ciRollupsMsg:
+		return m, m.observeCIRollups(msg.Rollups)
+
+	case webhookErrMsg:
+		clearCmd := m.statusBar.SetTemporaryMessage("Webhook failed: "+formatUserError(msg.Err.Error()), 5*time.Second)
+		return m, clearCmd
 
 	case PRsErrorMsg:
 		m.prList.SetError(msg.Err.Error())
-		return m, nil
+		return m.scriptListLoaded()
 
 	case pollTickMsg:
 		if m.pollEnabled && m.ghClient != nil && m.prList.state == stateLoaded {
+			tickCmd := m.schedulePollTick()
 			return m, tea.Batch(
-				pollFetchPRsCmd(m.ghClient),
-				pollTickCmd(m.pollInterval),
+				pollFetchPRsCmd(m.ghClient, m.polled.since()),
+				m.refreshRateLimit(),
+				tickCmd,
 			)
 		}
 		if m.pollEnabled && m.pollInterval > 0 {
-			return m, pollTickCmd(m.pollInterval)
+			tickCmd := m.schedulePollTick()
+			return m, tickCmd
 		}
+		m.statusBar.SetNextPoll(time.Time{})
 		return m, nil
 
 	case pollErrorMsg:
@@ -70,23 +112,43 @@ func (m App) handlePRListMsg(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, clearCmd
 
 	case pollPRsLoadedMsg:
-		toReview := convertPRItems(msg.ToReview)
-		myPRs := convertPRItems(msg.MyPRs)
-		m.prList.MergeItems(toReview, myPRs)
-		var cmds []tea.Cmd
-		if m.ghClient != nil {
-			allPRs := append(msg.ToReview, msg.MyPRs...)
-			cmds = append(cmds, fetchReviewDecisionsCmd(m.ghClient, allPRs))
+		prsToReview, prsMine := msg.ToReview, msg.MyPRs
+		fresh := make(map[string]bool) // PRs that came with their auto-merge state
+		if msg.Delta {
+			toReview, mine := m.polled.dropUnchanged(msg.ToReview, msg.MyPRs)
+			if len(toReview) == 0 && len(mine) == 0 {
+				m.polled.merge(nil, nil)
+				return m, nil // nothing changed since the last poll
+			}
+			for _, pr := range append(append([]github.PRItem(nil), toReview...), mine...) {
+				fresh[prKey(pr.Repo.Owner, pr.Repo.Name, pr.Number)] = true
+			}
+			prsToReview, prsMine = m.polled.merge(toReview, mine)
+		} else {
+			m.polled.record(msg.ToReview, msg.MyPRs)
+		}
+		items, myItems := convertPRItems(prsToReview), convertPRItems(prsMine)
+		if msg.Delta || !msg.Batched {
+			m.prList.keepAutoMerge(items, fresh)
+			m.prList.keepAutoMerge(myItems, fresh)
 		}
+		flashCmd := m.prList.MergeItems(items, myItems)
+		notes := m.activity.observePRs(prsToReview, prsMine, m.appConfig)
+		cmds := append(m.prListFollowUps(prsToReview, prsMine, msg.Batched), flashCmd, m.queueTriage(prsToReview))
 		if m.notifyEnabled {
-			newPRs := m.detectNewPRs(msg.ToReview)
-			if len(newPRs) > 0 {
+			newPRs := m.detectNewPRs(prsToReview)
+			if len(newPRs) > 0 && m.appConfig.NotifyTriggerEnabled(config.NotifyNewPR) {
 				cmds = append(cmds, notifyNewPRsCmd(newPRs, m.appConfig.NotificationThreshold))
 			}
+			cmds = append(cmds, sendNotificationsCmd(notes, m.appConfig.NotificationThreshold))
 		}
-		m.snapshotKnownPRs(msg.ToReview, msg.MyPRs)
+		m.snapshotKnownPRs(prsToReview, prsMine)
 		return m, tea.Batch(cmds...)
 
+	case prFlashDoneMsg:
+		m.prList.endFlash(msg.gen)
+		return m, nil
+
 	case PRSelectedMsg:
 		return m.selectPR(msg.Owner, msg.Repo, msg.Number, msg.HTMLURL, false)
 
@@ -114,15 +176,40 @@ func (m App) handleDiffMsg(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if msg.PRNumber != m.diffViewer.prNumber {
 			return m, nil
 		}
+		var remapCmd, cacheCmd tea.Cmd
 		if msg.Err != nil {
 			m.diffViewer.SetError(msg.Err)
+		} else if m.sameAsCachedDiff(msg.Files) {
+			// Already shown from the cache; keep the reader's place.
+			m.session.CachedFiles = false
+			m.refreshOwnerCoverage()
+			m.syncPRSummary()
+			cacheCmd = m.cachePRCmd(msg.PRNumber, func(c *github.CachedPR) { c.Files = msg.Files })
 		} else {
+			// A refresh replaces the diff in place; keep the search going.
+			m.saveSearchState()
 			m.diffViewer.SetDiff(msg.Files)
+			m.diffViewer.SetStackDiff("")
+			m.diffViewer.SetSinceDiff("")
+			m.chatPanel.SetCitationFiles(citationFiles(msg.Files))
 			if m.session != nil {
+				// Pending comments follow their lines to where a push moved them.
+				m.session.LastRemap = remapPendingComments(m.session.PendingInlineComments, m.session.DiffFiles, msg.Files)
+				if m.session.LastRemap != (pendingRemap{}) {
+					m.diffViewer.SetPendingInlineComments(m.session.PendingInlineComments)
+					remapCmd = m.statusBar.SetTemporaryMessage(fmt.Sprintf("PR #%d changed: %s", m.session.Number, m.session.LastRemap), 6*time.Second)
+				}
 				m.session.DiffFiles = msg.Files
+				m.session.CachedFiles = false
+				cacheCmd = m.cachePRCmd(msg.PRNumber, func(c *github.CachedPR) { c.Files = msg.Files })
+				m.diffViewer.restoreSearchState(m.searchStates[prKey(m.session.Owner, m.session.Repo, m.session.Number)])
+				m.refreshOwnerCoverage()
+				m.syncPRSummary()
 			}
 		}
-		return m, m.refreshFetchDone(msg.PRNumber)
+		refreshCmd := m.refreshFetchDone(msg.PRNumber)
+		model, cmd := m.scriptDiffLoaded(msg.PRNumber)
+		return model, tea.Batch(refreshCmd, remapCmd, cacheCmd, cmd)
 
 	case PRDetailLoadedMsg:
 		if !m.session.MatchesPR(msg.PRNumber) {
@@ -137,9 +224,244 @@ func (m App) handleDiffMsg(msg tea.Msg) (tea.Model, tea.Cmd) {
 				msg.Detail.Author.Login,
 				msg.Detail.HTMLURL,
 			)
+			m.chatPanel.SetPresetVars(presetVars(m.session, msg.Detail))
+			if m.session.Title == "" { // opened by reference rather than from the list
+				m.session.Title = msg.Detail.Title
+			}
+			if m.session.HTMLURL == "" {
+				m.session.HTMLURL = msg.Detail.HTMLURL
+			}
+			seenCmd := m.noteHeadSeen(msg.Detail.HeadSHA)
+			oldHead := m.session.HeadSHA
+			m.session.HeadSHA = msg.Detail.HeadSHA
+			m.session.Author = msg.Detail.Author.Login
+			m.session.HeadRepo = msg.Detail.HeadRepo
+			m.session.HeadBranch = msg.Detail.HeadBranch
+			m.session.Draft = msg.Detail.Draft
+			m.session.State = msg.Detail.State
+			m.prList.SetDraft(m.session.Owner, m.session.Repo, msg.PRNumber, msg.Detail.Draft)
+			m.diffViewer.SetMergeState(msg.Detail.Mergeable, msg.Detail.MergeableState, msg.Detail.BehindBy)
+			m.diffViewer.SetAutoMerge(msg.Detail.AutoMerge)
+			m.prList.SetAutoMerge(m.session.Owner, m.session.Repo, msg.PRNumber, msg.Detail.AutoMerge)
+			if m.ghClient != nil {
+				s := m.session
+				cmds := []tea.Cmd{
+					seenCmd,
+					m.refreshFetchDone(msg.PRNumber),
+					fetchMergeRequirementsCmd(m.ghClient, s.Owner, s.Repo, msg.Detail.BaseBranch, msg.PRNumber),
+					fetchCodeOwnersCmd(m.ghClient, s.Owner, s.Repo, msg.Detail.BaseBranch, msg.PRNumber),
+					fetchStackCmd(m.ghClient, s.Owner, s.Repo, msg.PRNumber),
+				}
+				if msg.Detail.HeadSHA != "" {
+					cmds = append(cmds,
+						fetchDeploymentsCmd(m.ghClient, s.Owner, s.Repo, msg.Detail.HeadSHA, msg.PRNumber),
+						fetchSecurityAlertsCmd(m.ghClient, s.Owner, s.Repo, msg.Detail.BaseBranch, msg.Detail.HeadSHA, msg.PRNumber),
+					)
+				}
+				if oldHead != "" && oldHead != msg.Detail.HeadSHA {
+					cmds = append(cmds, checkHeadChangeCmd(m.ghClient, s.Owner, s.Repo, msg.PRNumber, oldHead, msg.Detail.HeadSHA))
+				}
+				if hasConflicts(msg.Detail.Mergeable, msg.Detail.MergeableState) {
+					cmds = append(cmds, fetchBaseChangedFilesCmd(m.ghClient, s.Owner, s.Repo, msg.Detail.BaseBranch, msg.Detail.HeadBranch, msg.PRNumber))
+				}
+				return m, tea.Batch(cmds...)
+			}
+			return m, tea.Batch(seenCmd, m.refreshFetchDone(msg.PRNumber))
 		}
 		return m, m.refreshFetchDone(msg.PRNumber)
 
+	case DeploymentsLoadedMsg:
+		// Best-effort: without deployments the PR Info tab just omits the section.
+		if m.session.MatchesPR(msg.PRNumber) && msg.Err == nil {
+			m.diffViewer.SetDeployments(msg.Deployments)
+		}
+		return m, nil
+
+	case SecurityAlertsLoadedMsg:
+		// Best-effort: code scanning and dependency review are often not enabled.
+		if m.session.MatchesPR(msg.PRNumber) && msg.Err == nil {
+			m.diffViewer.SetSecurityAlerts(msg.Alerts)
+		}
+		return m, nil
+
+	case CoverageLoadedMsg:
+		if !m.session.MatchesPR(msg.PRNumber) {
+			return m, nil
+		}
+		if msg.Err != nil {
+			m.diffViewer.coverageErr = msg.Err.Error()
+			return m, nil
+		}
+		if msg.Report == nil {
+			return m, nil
+		}
+		m.diffViewer.SetCoverage(msg.Report)
+		return m, m.statusBar.SetTemporaryMessage(coverageSummary(&m.diffViewer), 4*time.Second)
+
+	case OpenPreviewRequestMsg:
+		return m.openPreview()
+
+	case BaseChangedFilesLoadedMsg:
+		// Best-effort: without the list, the PR Info tab still reports the conflict.
+		if m.session.MatchesPR(msg.PRNumber) && msg.Err == nil {
+			m.diffViewer.SetBaseChangedFiles(msg.Files)
+		}
+		return m, nil
+
+	case StackLoadedMsg:
+		// Best-effort: without it the PR Info tab just has no stack section.
+		if m.session.MatchesPR(msg.PRNumber) && msg.Err == nil {
+			m.session.Stack = msg.Stack
+			m.diffViewer.SetStack(msg.Stack)
+		}
+		return m, nil
+
+	case HeadChangedMsg:
+		return m.headChanged(msg)
+
+	case SinceDiffLoadedMsg:
+		return m.sinceDiffLoaded(msg)
+
+	case StackDiffLoadedMsg:
+		if !m.session.MatchesPR(msg.PRNumber) || msg.PRNumber != m.diffViewer.prNumber {
+			return m, nil
+		}
+		if msg.Err != nil {
+			return m, m.statusBar.SetTemporaryMessage(fmt.Sprintf("Combined diff failed: %s", formatUserError(msg.Err.Error())), 5*time.Second)
+		}
+		compare := msg.Base + "..." + msg.Head
+		m.diffViewer.SetDiff(msg.Files)
+		m.diffViewer.SetStackDiff(compare)
+		m.diffViewer.SetSinceDiff("")
+		return m, m.statusBar.SetTemporaryMessage(fmt.Sprintf("Showing the combined stack diff %s — :stack diff to go back", compare), 4*time.Second)
+
+	case UpdateBranchRequestMsg:
+		if m.session == nil || m.ghClient == nil {
+			return m, nil
+		}
+		if m.diffViewer.behindBy <= 0 {
+			clearCmd := m.statusBar.SetTemporaryMessage("Branch is already up to date with base", 2*time.Second)
+			return m, clearCmd
+		}
+		clearCmd := m.statusBar.SetTemporaryMessage(fmt.Sprintf("Updating branch for PR #%d...", m.session.Number), 15*time.Second)
+		return m, tea.Batch(clearCmd, updateBranchCmd(m.ghClient, m.session.Owner, m.session.Repo, m.session.Number, m.session.HeadSHA))
+
+	case UpdateBranchDoneMsg:
+		if msg.Err != nil {
+			clearCmd := m.statusBar.SetTemporaryMessage(
+				fmt.Sprintf("Update branch failed: %s", formatUserError(msg.Err.Error())), 5*time.Second,
+			)
+			return m, clearCmd
+		}
+		clearCmd := m.statusBar.SetTemporaryMessage("Branch update requested — refreshing shortly...", 3*time.Second)
+		return m, tea.Batch(clearCmd, tea.Tick(branchUpdateRefreshDelay, func(time.Time) tea.Msg {
+			return branchUpdateRefreshMsg{PRNumber: msg.PRNumber}
+		}))
+
+	case TaskToggleRequestMsg:
+		return m.toggleTask(msg)
+
+	case TaskToggleDoneMsg:
+		if !m.session.MatchesPR(msg.PRNumber) {
+			return m, nil
+		}
+		if msg.Err != nil {
+			m.diffViewer.SetPRBody(msg.Previous)
+			return m, m.statusBar.SetTemporaryMessage(fmt.Sprintf("Task update failed: %s", formatUserError(msg.Err.Error())), 5*time.Second)
+		}
+		m.diffViewer.SetPRBody(msg.Body)
+		return m, m.statusBar.SetTemporaryMessage("Task list updated", 2*time.Second)
+
+	case GuidedReviewStepMsg:
+		status := fmt.Sprintf("Step %d/%d: %s", msg.Step, msg.Total, msg.File)
+		if msg.Reason != "" {
+			status += " — " + msg.Reason
+		}
+		if msg.Step == msg.Total {
+			status += " (last file)"
+		}
+		clearCmd := m.statusBar.SetTemporaryMessage(status, 6*time.Second)
+		return m, clearCmd
+
+	case FocusStepMsg:
+		return m.focusStepped(msg)
+
+	case AutoMergeRequestMsg:
+		if m.session == nil || m.ghClient == nil {
+			return m, nil
+		}
+		status := fmt.Sprintf("Disabling auto-merge for PR #%d...", m.session.Number)
+		if msg.Method != "" {
+			status = fmt.Sprintf("Enabling auto-merge (%s) for PR #%d...", autoMergeMethodLabel(msg.Method), m.session.Number)
+		}
+		clearCmd := m.statusBar.SetTemporaryMessage(status, 15*time.Second)
+		return m, tea.Batch(clearCmd, setAutoMergeCmd(m.ghClient, m.session.Owner, m.session.Repo, m.session.Number, msg.Method, m.session.squashCommit(msg.Method)))
+
+	case AutoMergeDoneMsg:
+		if msg.Err != nil {
+			clearCmd := m.statusBar.SetTemporaryMessage(
+				fmt.Sprintf("Auto-merge failed: %s", formatUserError(msg.Err.Error())), 5*time.Second,
+			)
+			return m, clearCmd
+		}
+		m.prList.SetAutoMerge(msg.Owner, msg.Repo, msg.PRNumber, msg.Method)
+		if m.session.MatchesPR(msg.PRNumber) {
+			m.diffViewer.SetAutoMerge(msg.Method)
+		}
+		status := fmt.Sprintf("Auto-merge disabled for PR #%d", msg.PRNumber)
+		if msg.Method != "" {
+			status = fmt.Sprintf("Auto-merge (%s) enabled for PR #%d", autoMergeMethodLabel(msg.Method), msg.PRNumber)
+		}
+		clearCmd := m.statusBar.SetTemporaryMessage(status, 3*time.Second)
+		return m, clearCmd
+
+	case DraftStateDoneMsg:
+		if msg.Err != nil {
+			clearCmd := m.statusBar.SetTemporaryMessage(
+				fmt.Sprintf("Draft change failed: %s", formatUserError(msg.Err.Error())), 5*time.Second,
+			)
+			return m, clearCmd
+		}
+		m.prList.SetDraft(msg.Owner, msg.Repo, msg.PRNumber, msg.Draft)
+		status := fmt.Sprintf("PR #%d is ready for review", msg.PRNumber)
+		if msg.Draft {
+			status = fmt.Sprintf("PR #%d converted to a draft", msg.PRNumber)
+		}
+		clearCmd := m.statusBar.SetTemporaryMessage(status, 3*time.Second)
+		if s := m.session; s.MatchesPR(msg.PRNumber) && s.Owner == msg.Owner && s.Repo == msg.Repo {
+			s.Draft = msg.Draft
+			// Reload the detail so the merge state reflects the change
+			return m, tea.Batch(clearCmd, fetchPRDetailCmd(m.ghClient, s.Owner, s.Repo, s.Number))
+		}
+		return m, clearCmd
+
+	case branchUpdateRefreshMsg:
+		if !m.session.MatchesPR(msg.PRNumber) {
+			return m, nil
+		}
+		return m.refreshSelectedPR()
+
+	case MergeRequirementsLoadedMsg:
+		if !m.session.MatchesPR(msg.PRNumber) {
+			return m, nil
+		}
+		if msg.Err != nil {
+			m.diffViewer.SetMergeRequirementsError(msg.Err.Error())
+		} else {
+			m.diffViewer.SetMergeRequirements(msg.Requirements)
+		}
+		return m, nil
+
+	case CodeOwnersLoadedMsg:
+		// Best-effort: without CODEOWNERS the Review tab just omits the section.
+		if !m.session.MatchesPR(msg.PRNumber) {
+			return m, nil
+		}
+		m.session.CodeOwners = msg.Rules
+		m.session.MyTeams = msg.Teams
+		m.refreshOwnerCoverage()
+		return m, nil
+
 	case CommentsLoadedMsg:
 		if !m.session.MatchesPR(msg.PRNumber) {
 			return m, nil
@@ -149,6 +471,10 @@ func (m App) handleDiffMsg(msg tea.Msg) (tea.Model, tea.Cmd) {
 		} else {
 			m.chatPanel.SetComments(msg.Comments, msg.InlineComments)
 			m.diffViewer.SetGitHubInlineComments(msg.InlineComments)
+			cacheCmd := m.cachePRCmd(msg.PRNumber, func(c *github.CachedPR) {
+				c.Comments, c.InlineComments = msg.Comments, msg.InlineComments
+			})
+			return m, tea.Batch(cacheCmd, m.refreshFetchDone(msg.PRNumber))
 		}
 		return m, m.refreshFetchDone(msg.PRNumber)
 
@@ -159,11 +485,40 @@ func (m App) handleDiffMsg(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if msg.Err != nil {
 			m.diffViewer.SetCIError(msg.Err.Error())
 		} else if msg.Status != nil {
+			var clearCmd tea.Cmd
+			if check, done := m.diffViewer.CIWatchFinished(msg.Status); done {
+				clearCmd = m.statusBar.SetTemporaryMessage(
+					fmt.Sprintf("%s finished: %s", check.Name, check.Conclusion), 5*time.Second,
+				)
+			}
+			prev := ""
+			if m.diffViewer.ciStatus != nil {
+				prev = m.diffViewer.ciStatus.OverallStatus
+			}
 			m.diffViewer.SetCIStatus(msg.Status)
-			m.prList.SetCIStatus(msg.Status.OverallStatus)
+			overall := m.diffViewer.ciStatus.OverallStatus
+			m.prList.SetCIStatus(overall)
+			m.statusBar.SetCIStatus(overall)
+			var liveCmd tea.Cmd
+			if m.diffViewer.ciLive {
+				liveCmd = m.ciStatusFlipped(prev, overall)
+				if overall != "pending" {
+					m.diffViewer.stopCILive()
+				}
+			} else {
+				liveCmd = m.watchCI()
+			}
+			cacheCmd := m.cachePRCmd(msg.PRNumber, func(c *github.CachedPR) { c.CI = msg.Status })
+			return m, tea.Batch(clearCmd, liveCmd, cacheCmd, m.refreshCheckAnnotations(), m.refreshCoverage(), m.refreshFetchDone(msg.PRNumber))
 		}
 		return m, m.refreshFetchDone(msg.PRNumber)
 
+	case CheckAnnotationsLoadedMsg:
+		if m.session.MatchesPR(msg.PRNumber) {
+			m.diffViewer.SetCheckAnnotations(msg.Annotations)
+		}
+		return m, nil
+
 	case CIRerunRequestMsg:
 		if m.session == nil || m.ghClient == nil {
 			return m, nil
@@ -194,6 +549,90 @@ func (m App) handleDiffMsg(msg tea.Msg) (tea.Model, tea.Cmd) {
 		)
 		return m, clearCmd
 
+	case CIRerunCheckRequestMsg:
+		if m.session == nil || m.ghClient == nil {
+			return m, nil
+		}
+		if msg.Check.JobID == 0 {
+			clearCmd := m.statusBar.SetTemporaryMessage("Only GitHub Actions jobs can be re-run individually", 2*time.Second)
+			return m, clearCmd
+		}
+		if msg.Check.Status != "completed" {
+			clearCmd := m.statusBar.SetTemporaryMessage(msg.Check.Name+" is still running", 2*time.Second)
+			return m, clearCmd
+		}
+		clearCmd := m.statusBar.SetTemporaryMessage(fmt.Sprintf("Re-running %s...", msg.Check.Name), 15*time.Second)
+		return m, tea.Batch(clearCmd, rerunCICheckCmd(m.ghClient, m.session.Owner, m.session.Repo, m.session.Number, msg.Check))
+
+	case CIRerunCheckDoneMsg:
+		if !m.session.MatchesPR(msg.PRNumber) {
+			return m, nil
+		}
+		seq := m.diffViewer.WatchCICheck(msg.Check)
+		clearCmd := m.statusBar.SetTemporaryMessage(
+			fmt.Sprintf("Re-ran %s — watching for completion...", msg.Check.Name), 3*time.Second,
+		)
+		return m, tea.Batch(clearCmd, m.diffViewer.spinner.Tick, ciWatchTickCmd(msg.PRNumber, seq))
+
+	case ciWatchTickMsg:
+		if !m.session.MatchesPR(msg.PRNumber) || m.ghClient == nil || !m.diffViewer.CIWatchActive(msg.Seq) {
+			return m, nil
+		}
+		return m, tea.Batch(
+			fetchCIStatusCmd(m.ghClient, m.session.Owner, m.session.Repo, m.session.Number),
+			ciWatchTickCmd(msg.PRNumber, msg.Seq),
+		)
+
+	case RunOutputMsg:
+		m.diffViewer.appendRunOutput(msg.ID, msg.Line)
+		if m.runChan == nil {
+			return m, nil
+		}
+		return m, listenForStream(m.runChan)
+
+	case RunDoneMsg:
+		return m.finishRun(msg)
+
+	case WorktreesMsg:
+		return m, m.statusBar.SetTemporaryMessage(worktreesStatus(msg), 4*time.Second)
+
+	case ciLiveTickMsg:
+		if !m.session.MatchesPR(msg.PRNumber) || m.ghClient == nil || !m.diffViewer.CILiveActive(msg.Seq) {
+			return m, nil
+		}
+		if m.diffViewer.activeTab != TabCI {
+			m.diffViewer.stopCILive()
+			return m, nil
+		}
+		return m, tea.Batch(
+			fetchCIStatusCmd(m.ghClient, m.session.Owner, m.session.Repo, m.session.Number),
+			ciLiveTickCmd(msg.PRNumber, msg.Seq),
+		)
+
+	case CILogsRequestMsg:
+		if m.session == nil || m.ghClient == nil {
+			return m, nil
+		}
+		if msg.Check.JobID == 0 {
+			clearCmd := m.statusBar.SetTemporaryMessage("Logs are only available for GitHub Actions jobs", 2*time.Second)
+			return m, clearCmd
+		}
+		m.logViewer.SetSize(m.width, m.height)
+		cmd := m.logViewer.Show(msg.Check.Name, msg.Check.JobID)
+		m.setMode(ModeOverlay)
+		return m, tea.Batch(cmd, fetchJobLogsCmd(m.ghClient, m.session.Owner, m.session.Repo, m.session.Number, msg.Check.JobID))
+
+	case CILogsLoadedMsg:
+		if !m.session.MatchesPR(msg.PRNumber) || !m.logViewer.IsVisible() || m.logViewer.JobID() != msg.JobID {
+			return m, nil
+		}
+		if msg.Err != nil {
+			m.logViewer.SetError(msg.Err.Error())
+		} else {
+			m.logViewer.SetLogs(msg.Logs)
+		}
+		return m, nil
+
 	case ReviewsLoadedMsg:
 		if !m.session.MatchesPR(msg.PRNumber) {
 			return m, nil
@@ -203,6 +642,9 @@ func (m App) handleDiffMsg(msg tea.Msg) (tea.Model, tea.Cmd) {
 		} else if msg.Summary != nil {
 			m.diffViewer.SetReviewSummary(msg.Summary)
 			m.prList.SetReviewDecision(msg.Summary.ReviewDecision)
+			m.refreshOwnerCoverage()
+			cacheCmd := m.cachePRCmd(msg.PRNumber, func(c *github.CachedPR) { c.Reviews = msg.Summary })
+			return m, tea.Batch(cacheCmd, m.refreshFetchDone(msg.PRNumber))
 		}
 		return m, m.refreshFetchDone(msg.PRNumber)
 	}
@@ -226,14 +668,25 @@ func (m App) handleAnalysisMsg(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.session.Analyzing = false
 			m.session.AnalysisStreamCh = nil
 		}
+		var cmd tea.Cmd
 		if m.session.MatchesPR(msg.PRNumber) {
+			if msg.Rerun {
+				cmd = m.statusBar.SetTemporaryMessage(rerunComparison(m.session.AnalysisInputs, msg.Inputs), 4*time.Second)
+			}
+			m.session.AnalysisInputs = msg.Inputs
+			m.session.LastAnalysis = &analysisSnapshot{input: msg.Input, diffHash: msg.DiffHash}
 			m.chatPanel.SetAnalysisResult(msg.Result)
+			m.chatPanel.SetAnalysisInputs(msg.Inputs, true)
+			prev, _ := m.analysisStore.Get(m.session.Owner, m.session.Repo, m.session.Number)
 			_ = m.analysisStore.Put(
 				m.session.Owner, m.session.Repo, m.session.Number,
-				msg.DiffHash, msg.Result,
+				msg.DiffHash, msg.Result, msg.Inputs,
 			)
+			if changes := m.compareWithPrevious(prev, msg.Result, msg.DiffHash); changes != nil && cmd == nil {
+				cmd = m.statusBar.SetTemporaryMessage(analysisChangesStatus(changes), 4*time.Second)
+			}
 		}
-		return m, nil
+		return m, tea.Batch(cmd, m.recordAIResult(nil))
 
 	case AnalysisErrorMsg:
 		if m.session != nil {
@@ -243,33 +696,87 @@ func (m App) handleAnalysisMsg(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if m.session.MatchesPR(msg.PRNumber) {
 			m.chatPanel.SetAnalysisError(msg.Err.Error())
 		}
-		return m, nil
+		return m, m.recordAIResult(msg.Err)
 
 	case AIReviewCompleteMsg:
+		usageCmd := m.recordAIResult(nil)
 		if m.session.MatchesPR(msg.PRNumber) {
+			if msg.Scope != nil {
+				msg.Result.Comments = commentsInScope(msg.Result.Comments, msg.Scope)
+			}
 			m.chatPanel.SetAIReviewResult(msg.Result)
-			m.mergeAIComments(msg.Result.Comments)
+			m.session.AIReviewComments = msg.Result.Comments
+			accepted := autoAcceptedAIComments(msg.Result.Comments, m.aiReviewMinSeverity())
+			m.mergeAIComments(accepted)
 			m.diffViewer.ClearAIInlineComments()
 			m.diffViewer.SetPendingInlineComments(m.session.PendingInlineComments)
-			m.chatPanel.SetPendingCommentCount(len(m.session.PendingInlineComments))
-			clearCmd := m.statusBar.SetTemporaryMessage(
-				fmt.Sprintf("AI review ready: %d inline comments", len(msg.Result.Comments)),
-				3*time.Second,
-			)
-			return m, clearCmd
+			m.syncPendingCommentCount()
+			status := fmt.Sprintf("AI review ready: %d inline comments", len(msg.Result.Comments))
+			if held := len(msg.Result.Comments) - len(accepted); held > 0 {
+				status = fmt.Sprintf("AI review ready: %d inline comments, %d below %s to triage (:review triage)",
+					len(accepted), held, m.aiReviewMinSeverity())
+			}
+			clearCmd := m.statusBar.SetTemporaryMessage(status, 3*time.Second)
+			return m, tea.Batch(clearCmd, usageCmd)
 		}
-		return m, nil
+		return m, usageCmd
 
-	case AIReviewErrorMsg:
+	case PRSummaryCompleteMsg:
+		// Cached even if the user moved on, so it shows when they return.
+		_ = m.analysisStore.PutSummary(msg.Owner, msg.Repo, msg.PRNumber, msg.DiffHash, msg.Summary)
 		if m.session.MatchesPR(msg.PRNumber) {
-			m.chatPanel.SetAIReviewError(msg.Err.Error())
-			clearCmd := m.statusBar.SetTemporaryMessage(
+			m.session.Summarizing = false
+			m.diffViewer.SetPRSummary(msg.Summary)
+		}
+		return m, m.recordAIResult(nil)
+
+	case PRSummaryErrorMsg:
+		if m.session.MatchesPR(msg.PRNumber) {
+			m.session.Summarizing = false
+			m.diffViewer.SetPRSummaryError(msg.Err.Error())
+		}
+		return m, m.recordAIResult(msg.Err)
+
+	case PRTriagedMsg:
+		return m.triaged(msg)
+
+	case MergeMessageCompleteMsg:
+		usageCmd := m.recordAIResult(nil)
+		if !m.session.MatchesPR(msg.PRNumber) {
+			return m, usageCmd
+		}
+		m.session.DraftingMergeMessage = false
+		if m.mode != ModeNavigation {
+			// Don't steal focus from typing or another overlay.
+			m.session.MergeMessageDraft = msg.Message
+			return m, tea.Batch(usageCmd, m.statusBar.SetTemporaryMessage("Merge message drafted — :merge message to view it", 3*time.Second))
+		}
+		m.statusBar.ClearMessage()
+		m.showMergeMessage(msg.Message)
+		return m, usageCmd
+
+	case MergeMessageErrorMsg:
+		budgetCmd := m.recordAIResult(msg.Err)
+		if m.session.MatchesPR(msg.PRNumber) {
+			m.session.DraftingMergeMessage = false
+			if budgetCmd == nil {
+				budgetCmd = m.statusBar.SetTemporaryMessage("Merge message failed: "+formatUserError(msg.Err.Error()), 4*time.Second)
+			}
+		}
+		return m, budgetCmd
+
+	case AIReviewErrorMsg:
+		budgetCmd := m.recordAIResult(msg.Err)
+		if budgetCmd == nil && m.session.MatchesPR(msg.PRNumber) {
+			budgetCmd = m.statusBar.SetTemporaryMessage(
 				"AI review failed: "+formatUserError(msg.Err.Error()),
 				5*time.Second,
 			)
-			return m, clearCmd
 		}
-		return m, nil
+		if m.session.MatchesPR(msg.PRNumber) {
+			m.chatPanel.SetAIReviewError(msg.Err.Error())
+		}
+		return m, budgetCmd
 	}
 	return m, nil
 }
@@ -304,6 +811,13 @@ func (m App) handleChatMsg(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.chatPanel.AppendStreamChunk(msg.Content)
 		return m, listenForStream(m.session.StreamChan)
 
+	case ChatToolUseMsg:
+		if m.session == nil || m.session.StreamChan == nil {
+			return m, nil
+		}
+		m.chatPanel.AddToolUse(msg.Line)
+		return m, listenForStream(m.session.StreamChan)
+
 	case ChatResponseMsg:
 		if m.session == nil || m.session.StreamChan == nil {
 			return m, nil
@@ -314,11 +828,69 @@ func (m App) handleChatMsg(msg tea.Msg) (tea.Model, tea.Cmd) {
 		} else {
 			m.chatPanel.AddResponse(msg.Content)
 		}
+		return m, m.recordAIResult(msg.Err)
+
+	case CitationJumpMsg:
+		m.diffViewer.activeTab = TabDiff
+		m.diffViewer.refreshContent()
+		if m.diffViewer.jumpToLine(msg.Path, msg.Line) {
+			m.diffViewer.refreshContent()
+		}
+		m.showAndFocusPanel(PanelCenter)
 		return m, nil
 
+	case HunkQuestionMsg:
+		return m.handleHunkQuestion(msg)
+
+	case ExplainHunkMsg:
+		return m.handleExplainHunk(msg)
+
+	case DraftReplyMsg:
+		return m.handleDraftReply(msg)
+
+	case ReplyDraftedMsg:
+		return m.handleReplyDrafted(msg)
+
+	case HunkExplainedMsg:
+		if m.session == nil || m.session.Number != msg.PRNumber {
+			return m, nil
+		}
+		m.diffViewer.SetHunkExplanation(msg.Key, msg.Text, msg.Err)
+		return m, m.recordAIResult(msg.Err)
+
+	case QuickAnswerChunkMsg:
+		if m.session == nil || m.session.QuickAskChan == nil {
+			return m, nil
+		}
+		m.quickAnswer.AppendChunk(msg.Content)
+		return m, listenForStream(m.session.QuickAskChan)
+
+	case QuickAnswerDoneMsg:
+		if m.session == nil || m.session.QuickAskChan == nil {
+			return m, nil
+		}
+		m.session.QuickAskChan = nil
+		m.session.QuickAskCancel = nil
+		if msg.Err != nil {
+			m.quickAnswer.SetError(msg.Err.Error())
+		} else {
+			m.quickAnswer.SetAnswer(msg.Content)
+		}
+		return m, m.recordAIResult(msg.Err)
+
 	case CommentPostMsg:
 		return m.handleCommentPost(msg.Body)
 
+	case AnalysisPostMsg:
+		if m.ghClient == nil {
+			return m, nil
+		}
+		clearCmd := m.statusBar.SetTemporaryMessage(fmt.Sprintf("Posting the analysis to PR #%d...", msg.Number), 15*time.Second)
+		return m, tea.Batch(clearCmd, postAnalysisCmd(m.ghClient, msg))
+
+	case AnalysisPostedMsg:
+		return m.handleAnalysisPosted(msg)
+
 	case CommentPostedMsg:
 		m.chatPanel.SetCommentPosted(msg.Err)
 		if msg.Err == nil && m.ghClient != nil && m.session != nil {
@@ -333,11 +905,25 @@ func (m App) handleChatMsg(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if m.session == nil || m.ghClient == nil {
 			return m, nil
 		}
+		// Show the reply in its thread right away; a failure takes it back out.
+		m.optimisticReplySeq++
+		reply := github.InlineComment{
+			ID:          -m.optimisticReplySeq,
+			Author:      github.User{Login: m.ghClient.GetUsername()},
+			Body:        msg.Body,
+			CreatedAt:   time.Now(),
+			InReplyToID: msg.CommentID,
+		}
+		if m.diffViewer.AddOptimisticReply(reply) {
+			m.commentOverlay.AddReply(reply)
+		}
 		clearCmd := m.statusBar.SetTemporaryMessage("Posting reply...", 2*time.Second)
-		return m, tea.Batch(clearCmd, replyToCommentCmd(m.ghClient, m.session.Owner, m.session.Repo, m.session.Number, msg.CommentID, msg.Body))
+		return m, tea.Batch(clearCmd, replyToCommentCmd(m.ghClient, m.session.Owner, m.session.Repo, m.session.Number, msg.CommentID, reply.ID, msg.Body))
 
 	case InlineCommentReplyDoneMsg:
 		if msg.Err != nil {
+			m.diffViewer.RemoveInlineComment(msg.ReplyID)
+			m.commentOverlay.RemoveReply(msg.ReplyID)
 			clearCmd := m.statusBar.SetTemporaryMessage(
 				fmt.Sprintf("Reply failed: %v", msg.Err), 3*time.Second)
 			return m, clearCmd
@@ -348,6 +934,24 @@ func (m App) handleChatMsg(msg tea.Msg) (tea.Model, tea.Cmd) {
 			refreshCmd = fetchCommentsCmd(m.ghClient, m.session.Owner, m.session.Repo, m.session.Number)
 		}
 		return m, tea.Batch(clearCmd, refreshCmd)
+
+	case ApplySuggestionMsg:
+		if !m.canApplySuggestions() {
+			return m, nil
+		}
+		clearCmd := m.statusBar.SetTemporaryMessage("Applying suggestion...", 5*time.Second)
+		return m, tea.Batch(clearCmd, applySuggestionCmd(m.ghClient, m.session.HeadRepo, m.session.HeadBranch, msg))
+
+	case SuggestionAppliedMsg:
+		if msg.Err != nil {
+			clearCmd := m.statusBar.SetTemporaryMessage(
+				fmt.Sprintf("Apply failed: %v", msg.Err), 3*time.Second)
+			return m, clearCmd
+		}
+		model, refreshCmd := m.refreshSelectedPR()
+		m = model.(App)
+		clearCmd := m.statusBar.SetTemporaryMessage("Suggestion committed to "+msg.Path, 2*time.Second)
+		return m, tea.Batch(refreshCmd, clearCmd)
 	}
 	return m, nil
 }
@@ -364,6 +968,35 @@ func (m App) handleReviewMsg(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case ReviewSubmitMsg:
 		return m.handleReviewSubmit(msg)
 
+	case FileScopeToggleMsg:
+		return m.toggleFileScope(msg.Path)
+
+	case CISummaryRequestMsg:
+		if m.session == nil {
+			return m, nil
+		}
+		if m.diffViewer.ciStatus == nil {
+			clearCmd := m.statusBar.SetTemporaryMessage("CI status not loaded yet", 2*time.Second)
+			return m, clearCmd
+		}
+		failing, _, _ := ciCheckGroups(m.diffViewer.ciStatus.Checks)
+		if len(failing) == 0 {
+			clearCmd := m.statusBar.SetTemporaryMessage("No failing checks to summarize", 2*time.Second)
+			return m, clearCmd
+		}
+		clearCmd := m.statusBar.SetTemporaryMessage("Building CI failure summary...", 15*time.Second)
+		return m, tea.Batch(clearCmd, ciSummaryCmd(m.ghClient, m.session.Owner, m.session.Repo, m.session.Number, failing))
+
+	case CISummaryReadyMsg:
+		if !m.session.MatchesPR(msg.PRNumber) {
+			return m, nil
+		}
+		m.chatPanel.AppendToReviewBody(msg.Body)
+		m.chatPanel.SetActiveTab(ChatTabReview)
+		m.showAndFocusPanel(PanelRight)
+		clearCmd := m.statusBar.SetTemporaryMessage("CI failure summary added to review", 3*time.Second)
+		return m, clearCmd
+
 	case ReviewSubmitDoneMsg:
 		if !m.session.MatchesPR(msg.PRNumber) {
 			return m, nil
@@ -375,12 +1008,14 @@ func (m App) handleReviewMsg(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		label := actionLabels[msg.Action]
 		clearCmd := m.statusBar.SetTemporaryMessage(fmt.Sprintf("✓ %s PR #%d", label, msg.PRNumber), 3*time.Second)
+		hookCmd := m.reviewWebhookCmd(strings.ToLower(label))
+		seenCmd := recordHeadCmd(m.reviewed, prKey(m.session.Owner, m.session.Repo, m.session.Number), m.session.HeadSHA, true)
 		m.chatPanel.SetReviewSubmitted(nil)
-		// Clear pending comments — they've been submitted
-		m.session.PendingInlineComments = nil
-		m.diffViewer.SetPendingInlineComments(nil)
-		m.chatPanel.SetPendingCommentCount(0)
-		return m, tea.Batch(clearCmd, fetchReviewsCmd(m.ghClient, m.session.Owner, m.session.Repo, m.session.Number))
+		// Clear submitted comments; drafts held back or on out-of-scope files are kept
+		_, m.session.PendingInlineComments = m.session.SubmittableComments()
+		m.diffViewer.SetPendingInlineComments(m.session.PendingInlineComments)
+		m.syncPendingCommentCount()
+		return m, tea.Batch(clearCmd, hookCmd, seenCmd, fetchReviewsCmd(m.ghClient, m.session.Owner, m.session.Repo, m.session.Number))
 
 	case ReviewSubmitErrMsg:
 		if m.session.MatchesPR(msg.PRNumber) {
@@ -394,25 +1029,52 @@ func (m App) handleReviewMsg(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, nil
 		}
 		clearCmd := m.statusBar.SetTemporaryMessage(fmt.Sprintf("✓ Approved PR #%d", msg.PRNumber), 3*time.Second)
-		return m, tea.Batch(clearCmd, fetchReviewsCmd(m.ghClient, m.session.Owner, m.session.Repo, m.session.Number))
+		return m, tea.Batch(clearCmd, m.reviewWebhookCmd("approved"), fetchReviewsCmd(m.ghClient, m.session.Owner, m.session.Repo, m.session.Number))
 
 	case PRApproveErrMsg:
 		clearCmd := m.statusBar.SetTemporaryMessage(fmt.Sprintf("✗ Approve failed: %s", msg.Err), 5*time.Second)
 		return m, clearCmd
 
+	case PRStateRequestMsg:
+		if m.ghClient == nil {
+			return m, nil
+		}
+		if msg.Reopen {
+			clearCmd := m.statusBar.SetTemporaryMessage(fmt.Sprintf("Reopening PR #%d...", msg.Number), 15*time.Second)
+			return m, tea.Batch(clearCmd, reopenPRCmd(m.ghClient, msg.Owner, msg.Repo, msg.Number))
+		}
+		clearCmd := m.statusBar.SetTemporaryMessage(fmt.Sprintf("Closing PR #%d...", msg.Number), 15*time.Second)
+		return m, tea.Batch(clearCmd, closePRCmd(m.ghClient, msg.Owner, msg.Repo, msg.Number))
+
 	case PRCloseDoneMsg:
 		if !m.session.MatchesPR(msg.PRNumber) {
 			return m, nil
 		}
+		m.session.State = "CLOSED"
 		clearCmd := m.statusBar.SetTemporaryMessage(fmt.Sprintf("✓ Closed PR #%d", msg.PRNumber), 3*time.Second)
 		if m.ghClient != nil {
-			return m, tea.Batch(clearCmd, fetchPRsCmd(m.ghClient))
+			return m, tea.Batch(clearCmd, fetchPRsCmd(m.ghClient), m.refreshClosedPRs())
 		}
 		return m, clearCmd
 
 	case PRCloseErrMsg:
 		clearCmd := m.statusBar.SetTemporaryMessage(fmt.Sprintf("✗ Close failed: %s", msg.Err), 5*time.Second)
 		return m, clearCmd
+
+	case PRReopenDoneMsg:
+		if !m.session.MatchesPR(msg.PRNumber) {
+			return m, nil
+		}
+		m.session.State = "OPEN"
+		clearCmd := m.statusBar.SetTemporaryMessage(fmt.Sprintf("✓ Reopened PR #%d", msg.PRNumber), 3*time.Second)
+		if m.ghClient != nil {
+			return m, tea.Batch(clearCmd, fetchPRsCmd(m.ghClient), m.refreshClosedPRs())
+		}
+		return m, clearCmd
+
+	case PRReopenErrMsg:
+		clearCmd := m.statusBar.SetTemporaryMessage(fmt.Sprintf("✗ Reopen failed: %s", msg.Err), 5*time.Second)
+		return m, clearCmd
 	}
 	return m, nil
 }
@@ -423,37 +1085,64 @@ func (m App) handleReviewMsg(msg tea.Msg) (tea.Model, tea.Cmd) {
 func (m App) handleConfigMsg(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case ConfigChangedMsg:
-		if m.settingsPanel.IsDirty() {
-			cfg := m.settingsPanel.Config()
-			m.appConfig = cfg
-			_ = config.Save(cfg)
-			var cmds []tea.Cmd
-			wasEnabled := m.pollEnabled
-			m.pollEnabled = cfg.PollEnabled
-			m.pollInterval = cfg.PollIntervalDuration()
-			m.notifyEnabled = cfg.NotificationsEnabled
-			if !wasEnabled && m.pollEnabled && m.pollInterval > 0 && m.prList.state == stateLoaded {
-				cmds = append(cmds, pollTickCmd(m.pollInterval))
-			}
-			m.chatPanel.SetStreamCheckpoint(time.Duration(cfg.StreamCheckpointMs) * time.Millisecond)
-			m.chatPanel.UpdateDefaultReviewAction(cfg.DefaultReviewAction)
-			m.collapseThreshold = cfg.CollapseThreshold
-			if m.ghClient != nil {
-				m.ghClient.SetFetchLimit(cfg.PRFetchLimit)
+		cfg := msg.Reloaded
+		if cfg == nil {
+			if !m.settingsPanel.IsDirty() {
+				return m, nil
 			}
-			if m.analyzer != nil {
-				m.analyzer.SetTimeout(cfg.ClaudeTimeoutDuration())
-				m.analyzer.SetAnalysisMaxTurns(cfg.AnalysisMaxTurns)
-			}
-			if m.chatService != nil {
-				m.chatService.SetTimeout(cfg.ClaudeTimeoutDuration())
-				m.chatService.SetMaxPromptTokens(cfg.MaxPromptTokens)
-				m.chatService.SetMaxHistoryMessages(cfg.MaxChatHistory)
-				m.chatService.SetMaxTurns(cfg.ChatMaxTurns)
-			}
-			return m, tea.Batch(cmds...)
+			cfg = m.settingsPanel.Config()
+			_ = config.Save(cfg)
 		}
-		return m, nil
+		m.appConfig = cfg
+		var cmds []tea.Cmd
+		wasEnabled := m.pollEnabled
+		m.pollEnabled = cfg.PollEnabled
+		m.pollInterval = cfg.PollIntervalDuration()
+		m.notifyEnabled = cfg.NotificationsEnabled
+		if !wasEnabled && m.pollEnabled && m.pollInterval > 0 && m.prList.state == stateLoaded {
+			cmds = append(cmds, m.schedulePollTick())
+		}
+		if !m.pollEnabled {
+			m.statusBar.SetNextPoll(time.Time{})
+		}
+		m.statusBar.SetSegments(statusSegmentsFromConfig(cfg))
+		cmds = append(cmds, m.startStatusBarTick(), m.refreshRateLimit())
+		m.chatPanel.SetStreamCheckpoint(time.Duration(cfg.StreamCheckpointMs) * time.Millisecond)
+		m.chatPanel.UpdateDefaultReviewAction(cfg.DefaultReviewAction)
+		m.chatPanel.SetPresets(cfg.ChatPresets)
+		setSnippets(cfg.Snippets)
+		if absoluteTimestamps != cfg.AbsoluteTimestamps {
+			absoluteTimestamps = cfg.AbsoluteTimestamps
+			m.rerenderTimestamps()
+		}
+		m.diffViewer.SetShowOutdatedComments(cfg.ShowOutdatedComments)
+		m.diffViewer.SetMinimap(cfg.DiffMinimap)
+		m.workspace.setSize(cfg.WorkspaceSize)
+		if applyConfigTheme(cfg) {
+			m.diffViewer.cachedLines = nil
+			m.diffViewer.refreshContent()
+			m.chatPanel.refreshViewport()
+		}
+		m.collapseThreshold = cfg.CollapseThreshold
+		if m.ghClient != nil {
+			m.ghClient.SetFetchLimit(cfg.PRFetchLimit)
+		}
+		m.aiBudget.budget = cfg.AIErrorBudget
+		m.applyAIProviders()
+		if m.analyzer != nil {
+			m.analyzer.SetTimeout(cfg.ClaudeTimeoutDuration())
+		}
+		if m.chatService != nil {
+			m.chatService.SetTimeout(cfg.ClaudeTimeoutDuration())
+		}
+		cmds = append(cmds, m.queueTriage(m.polled.toReview))
+		if msg.Reloaded != nil {
+			cmds = append(cmds, m.statusBar.SetTemporaryMessage("Config reloaded", 3*time.Second))
+		}
+		return m, tea.Batch(cmds...)
+
+	case configFileChangedMsg:
+		return m.configFileChanged(msg)
 
 	case HelpClosedMsg:
 		m.setMode(ModeNavigation)
@@ -464,6 +1153,7 @@ func (m App) handleConfigMsg(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, nil
 
 	case ShowCommentOverlayMsg:
+		msg.CanApplySuggestions = m.canApplySuggestions()
 		m.commentOverlay.SetSize(m.width, m.height)
 		cmd := m.commentOverlay.Show(msg)
 		m.setMode(ModeOverlay)
@@ -473,9 +1163,171 @@ func (m App) handleConfigMsg(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.setMode(ModeNavigation)
 		return m, nil
 
+	case CommentOverlayStepMsg:
+		return m.stepCommentOverlay(msg.Delta)
+
+	case LogViewerClosedMsg:
+		m.setMode(ModeNavigation)
+		return m, nil
+
+	case ShowHunkOrderMsg:
+		m.hunkOrder.SetSize(m.width, m.height)
+		m.hunkOrder.Show(msg.Items, msg.Primary)
+		m.setMode(ModeOverlay)
+		return m, nil
+
+	case HunkOrderClosedMsg:
+		m.setMode(ModeNavigation)
+		if msg.Applied {
+			m.diffViewer.SetHunkOrder(msg.Order, msg.Primary)
+		}
+		return m, nil
+
+	case ShowChatContextMsg:
+		m.chatContext.SetSize(m.width, m.height)
+		m.chatContext.Show(msg.Items, msg.Budget)
+		m.setMode(ModeOverlay)
+		return m, nil
+
+	case ChatContextClosedMsg:
+		m.setMode(ModeNavigation)
+		if msg.Applied {
+			return m.applyChatContext(msg.Items)
+		}
+		return m, nil
+
+	case ShowCommentTriageMsg:
+		m.commentTriage.SetSize(m.width, m.height)
+		m.commentTriage.Show(msg.Items)
+		m.setMode(ModeOverlay)
+		return m, nil
+
+	case CommentTriageClosedMsg:
+		m.setMode(ModeNavigation)
+		if msg.Applied {
+			return m.applyCommentTriage(msg.Items)
+		}
+		return m, nil
+
+	case ChatCILogsMsg:
+		if !m.session.MatchesPR(msg.PRNumber) {
+			return m, nil
+		}
+		m.session.ChatCILogs = msg.Logs
+		clearCmd := m.statusBar.SetTemporaryMessage(fmt.Sprintf("CI logs added to chat context (~%s tokens)", formatTokenCount(m.chatContextTokens())), 3*time.Second)
+		return m, clearCmd
+
+	case QuickfixClosedMsg:
+		m.setMode(ModeNavigation)
+		if msg.Pos > 0 && msg.Pos <= len(msg.Items) {
+			return m.quickfixJump(msg.Items, msg.Pos)
+		}
+		return m, nil
+
+	case QuickAnswerClosedMsg:
+		if m.session != nil && m.session.QuickAskCancel != nil {
+			m.session.QuickAskCancel()
+			m.session.QuickAskCancel = nil
+			m.session.QuickAskChan = nil
+		}
+		m.setMode(ModeNavigation)
+		return m, nil
+
+	case UsageClosedMsg:
+		m.setMode(ModeNavigation)
+		return m, nil
+
+	case PromptsClosedMsg:
+		m.setMode(ModeNavigation)
+		return m, nil
+
+	case PromptEditedMsg:
+		if msg.Err != nil {
+			return m, m.statusBar.SetTemporaryMessage("Editor failed: "+msg.Err.Error(), 4*time.Second)
+		}
+		if m.prompts.IsVisible() {
+			if entries, err := m.promptEntries(); err == nil {
+				m.prompts.SetEntries(entries, m.promptPreview())
+			}
+		}
+		return m, m.statusBar.SetTemporaryMessage("Saved "+msg.Path, 2*time.Second)
+
+	case motionTimeoutMsg:
+		if msg.seq != m.motionSeq || m.motionPrefix == "" {
+			return m, nil
+		}
+		m.flushMotionPrefix()
+		return m, nil
+
+	case GlobalSearchClosedMsg:
+		m.setMode(ModeNavigation)
+		if msg.Hit != nil {
+			m.openSearchHit(*msg.Hit)
+		}
+		return m, nil
+
+	case WorkloadLoadedMsg:
+		m.workload.SetWorkload(msg.PRs, msg.Err)
+		return m, nil
+
+	case WorkloadClosedMsg:
+		m.setMode(ModeNavigation)
+		if pr := msg.Open; pr != nil {
+			return m.selectPR(pr.Owner, pr.Repo, pr.Number, pr.HTMLURL, true)
+		}
+		return m, nil
+
+	case LinksClosedMsg:
+		m.setMode(ModeNavigation)
+		if msg.Open != "" {
+			return m, openBrowserCmd(msg.Open)
+		}
+		return m, nil
+
+	case ConfirmClosedMsg:
+		m.setMode(ModeNavigation)
+		if action := msg.Action; action != nil {
+			return m, func() tea.Msg { return action }
+		}
+		return m, nil
+
+	case QuitConfirmedMsg:
+		return m.quitConfirmed(msg)
+
+	case PendingCommentsClosedMsg:
+		m.setMode(ModeNavigation)
+		if c := msg.Jump; c != nil {
+			return m, func() tea.Msg { return CitationJumpMsg{Path: c.Path, Line: c.Line} }
+		}
+		return m, nil
+
+	case PendingCommentsChangedMsg:
+		m.setPendingComments(msg.Comments)
+		if msg.Note != "" {
+			return m, m.statusBar.SetTemporaryMessage(msg.Note, 2*time.Second)
+		}
+		return m, nil
+
+	case MergeMessageClosedMsg:
+		m.setMode(ModeNavigation)
+		return m, nil
+
+	case MergeMessageAcceptedMsg:
+		m.setMode(ModeNavigation)
+		return m, m.acceptMergeMessage(msg.Message)
+
+	case CopyToClipboardMsg:
+		return m, m.copyToClipboard(msg.Text, msg.What)
+
+	case ExportDoneMsg:
+		if msg.Err != nil {
+			return m, m.statusBar.SetTemporaryMessage("Export failed: "+msg.Err.Error(), 4*time.Second)
+		}
+		return m, m.statusBar.SetTemporaryMessage("Exported "+msg.What+" to "+msg.Path, 3*time.Second)
+
 	case CommandExecuteMsg:
 		m.setMode(ModeNavigation)
-		return m.executeCommand(msg.Name)
+		return m.executeCommand(msg.Name, msg.Args)
 
 	case CommandModeExitMsg:
 		m.setMode(ModeNavigation)
@@ -507,6 +1359,76 @@ func (m App) handleKeyMsg(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			m.commentOverlay, cmd = m.commentOverlay.Update(msg)
 			return m, cmd
 		}
+		if m.confirm.IsVisible() {
+			var cmd tea.Cmd
+			m.confirm, cmd = m.confirm.Update(msg)
+			return m, cmd
+		}
+		if m.logViewer.IsVisible() {
+			var cmd tea.Cmd
+			m.logViewer, cmd = m.logViewer.Update(msg)
+			return m, cmd
+		}
+		if m.quickAnswer.IsVisible() {
+			var cmd tea.Cmd
+			m.quickAnswer, cmd = m.quickAnswer.Update(msg)
+			return m, cmd
+		}
+		if m.usageOverlay.IsVisible() {
+			var cmd tea.Cmd
+			m.usageOverlay, cmd = m.usageOverlay.Update(msg)
+			return m, cmd
+		}
+		if m.mergeMessage.IsVisible() {
+			var cmd tea.Cmd
+			m.mergeMessage, cmd = m.mergeMessage.Update(msg)
+			return m, cmd
+		}
+		if m.prompts.IsVisible() {
+			var cmd tea.Cmd
+			m.prompts, cmd = m.prompts.Update(msg)
+			return m, cmd
+		}
+		if m.pendingList.IsVisible() {
+			var cmd tea.Cmd
+			m.pendingList, cmd = m.pendingList.Update(msg)
+			return m, cmd
+		}
+		if m.globalSearch.IsVisible() {
+			var cmd tea.Cmd
+			m.globalSearch, cmd = m.globalSearch.Update(msg)
+			return m, cmd
+		}
+		if m.hunkOrder.IsVisible() {
+			var cmd tea.Cmd
+			m.hunkOrder, cmd = m.hunkOrder.Update(msg)
+			return m, cmd
+		}
+		if m.chatContext.IsVisible() {
+			var cmd tea.Cmd
+			m.chatContext, cmd = m.chatContext.Update(msg)
+			return m, cmd
+		}
+		if m.commentTriage.IsVisible() {
+			var cmd tea.Cmd
+			m.commentTriage, cmd = m.commentTriage.Update(msg)
+			return m, cmd
+		}
+		if m.quickfix.IsVisible() {
+			var cmd tea.Cmd
+			m.quickfix, cmd = m.quickfix.Update(msg)
+			return m, cmd
+		}
+		if m.workload.IsVisible() {
+			var cmd tea.Cmd
+			m.workload, cmd = m.workload.Update(msg)
+			return m, cmd
+		}
+		if m.links.IsVisible() {
+			var cmd tea.Cmd
+			m.links, cmd = m.links.Update(msg)
+			return m, cmd
+		}
 		if m.settingsPanel.IsVisible() {
 			var cmd tea.Cmd
 			m.settingsPanel, cmd = m.settingsPanel.Update(msg)
@@ -539,11 +1461,40 @@ func (m App) handleKeyMsg(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		return m.updateFocusedPanel(msg)
 	}
 
+	// While asking about a hunk, route all keys to the diff viewer
+	if m.focused == PanelCenter && m.diffViewer.IsAsking() {
+		return m.updateFocusedPanel(msg)
+	}
+
 	// While commenting in the diff viewer, route all keys to the diff viewer
 	if m.focused == PanelCenter && m.diffViewer.IsCommenting() {
 		return m.updateFocusedPanel(msg)
 	}
 
+	// Motions in the diff: "]c" / "[c", "]f" / "[f" and "{count}G"
+	if m.motionPrefix != "" {
+		return m.finishMotion(msg)
+	}
+	if m.focused == PanelCenter && m.diffViewer.activeTab == TabDiff && startsMotion(msg) {
+		return m.startMotion(msg.String())
+	}
+
+	// The diff's jump list: Ctrl+O goes back, switching PRs instead when
+	// there is nothing older, and Tab, which terminals send for Ctrl+I,
+	// goes forward only after Ctrl+O
+	if m.focused == PanelCenter && m.diffViewer.activeTab == TabDiff {
+		switch {
+		case key.Matches(msg, DiffViewerKeys.JumpBack):
+			if m.diffViewer.jumpBack() {
+				return m, m.jumpStatus()
+			}
+		case key.Matches(msg, DiffViewerKeys.JumpForward):
+			if m.diffViewer.jumpForward() {
+				return m, m.jumpStatus()
+			}
+		}
+	}
+
 	// Global key handling in navigation mode
 	switch {
 	case key.Matches(msg, GlobalKeys.Help):
@@ -553,7 +1504,7 @@ func (m App) handleKeyMsg(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		return m, nil
 
 	case key.Matches(msg, GlobalKeys.Quit):
-		return m, tea.Quit
+		return m, m.quit()
 
 	case key.Matches(msg, GlobalKeys.Tab):
 		if m.zoomed {
@@ -608,12 +1559,27 @@ func (m App) handleKeyMsg(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		m.toggleZoom()
 		return m, nil
 
+	case key.Matches(msg, GlobalKeys.GrowPanel):
+		return m, m.resizeFocusedPanel(1)
+
+	case key.Matches(msg, GlobalKeys.ShrinkPanel):
+		return m, m.resizeFocusedPanel(-1)
+
+	case key.Matches(msg, GlobalKeys.GlobalSearch):
+		return m.showGlobalSearch()
+
+	case key.Matches(msg, GlobalKeys.SwitchPR):
+		return m.switchPR("")
+
 	case key.Matches(msg, GlobalKeys.OpenBrowser):
 		if m.session != nil && m.session.HTMLURL != "" {
 			return m, openBrowserCmd(m.session.HTMLURL)
 		}
 		return m, nil
 
+	case key.Matches(msg, GlobalKeys.FollowLink):
+		return m.showLinks()
+
 	case key.Matches(msg, GlobalKeys.Analyze):
 		return m.startAnalysis()
 
@@ -652,5 +1618,29 @@ func (m App) handleSpinnerTick(msg spinner.TickMsg) (tea.Model, tea.Cmd) {
 	cmds = append(cmds, cmd)
 	m.chatPanel, cmd = m.chatPanel.Update(msg)
 	cmds = append(cmds, cmd)
+	m.logViewer, cmd = m.logViewer.Update(msg)
+	cmds = append(cmds, cmd)
+	m.quickAnswer, cmd = m.quickAnswer.Update(msg)
+	cmds = append(cmds, cmd)
 	return m, tea.Batch(cmds...)
 }
+
+// handleMouseMsg forwards mouse events over the diff panel to the diff viewer
+// in panel-local coordinates. Mouse input is ignored while an overlay, the
+// command line or insert mode has the keyboard.
+func (m App) handleMouseMsg(msg tea.MouseMsg) (tea.Model, tea.Cmd) {
+	if m.mode != ModeNavigation {
+		return m, nil
+	}
+	sizes := m.panelSizes()
+	if sizes.TooSmall || sizes.CenterWidth == 0 {
+		return m, nil
+	}
+	msg.X -= sizes.LeftWidth
+	if !m.diffViewer.scrollDrag && (msg.X < 0 || msg.X >= sizes.CenterWidth || msg.Y >= sizes.PanelHeight) {
+		return m, nil
+	}
+	var cmd tea.Cmd
+	m.diffViewer, cmd = m.diffViewer.Update(msg)
+	return m, cmd
+}
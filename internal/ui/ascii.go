@@ -0,0 +1,57 @@
+package ui
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+)
+
+// plainOutput is set by WithNoColor: colors are disabled and the rendered
+// view is mapped to ASCII for limited terminals (serial consoles, bare SSH
+// sessions, piped output).
+var plainOutput bool
+
+// WithNoColor disables colors and replaces unicode glyphs and box-drawing
+// borders with ASCII equivalents.
+func WithNoColor() AppOption {
+	return func(a *App) {
+		plainOutput = true
+		lipgloss.SetColorProfile(termenv.Ascii)
+	}
+}
+
+// asciiReplacer maps every glyph the UI draws to ASCII of the same display
+// width, so panel layout is unchanged: status icons become +, -, * and !,
+// and wide emoji become two characters. Used by --no-color and
+// compatibility mode.
+var asciiReplacer = strings.NewReplacer(
+	// Borders (normal, rounded, thick, double)
+	"─", "-", "━", "-", "═", "=",
+	"│", "|", "┃", "|", "║", "|",
+	"┌", "+", "┐", "+", "└", "+", "┘", "+",
+	"╭", "+", "╮", "+", "╰", "+", "╯", "+",
+	"┏", "+", "┓", "+", "┗", "+", "┛", "+",
+	"╔", "+", "╗", "+", "╚", "+", "╝", "+",
+	"├", "+", "┤", "+", "┬", "+", "┴", "+", "┼", "+",
+	// Status icons and markers
+	"✓", "+", "✗", "-", "×", "x", "●", "*", "○", "o", "•", "*",
+	"⚠", "!", "▸", ">", "▾", "v", "▶", ">", "⏵", ">", "◂", "<",
+	"▲", "^", "▼", "v", "▌", "|", "▎", "|", "█", "#", "░", ".",
+	"→", ">", "↔", "=", "↳", ">", "↑", "^", "↓", "v", "↻", "@", "⏎", "~",
+	"·", ".", "…", ".", "—", "-", "−", "-",
+	"☐", "_", "☑", "x", "★", "*", "⏱", "T", "◎", "@", "◐", "~", "✎", "e", "⊘", "/",
+	// Minimap shades, diff stat bars and the coverage gutter
+	"▒", ":", "▓", "%", "■", "#", "▕", "|",
+	// Loading spinner (spinner.Dot frames)
+	"⣾", "|", "⣽", "/", "⣻", "-", "⢿", "\\", "⡿", "|", "⣟", "/", "⣯", "-", "⣷", "\\",
+	// Wide emoji
+	"💬", "C:", "📝", "N:", "🤖", "AI", "📜", "L:", "⌛", "..", "✨", "**",
+	// Reactions
+	"👍", "+1", "👎", "-1", "😄", ":D", "🎉", "\\o", "😕", ":/", "❤️", "<3", "🚀", "^^", "👀", "oo",
+)
+
+// toASCII applies asciiReplacer to a rendered view.
+func toASCII(s string) string {
+	return asciiReplacer.Replace(s)
+}
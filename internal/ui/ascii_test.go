@@ -0,0 +1,174 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/shhac/prtea/internal/claude"
+	"github.com/shhac/prtea/internal/github"
+)
+
+func TestToASCII_KeepsWidth(t *testing.T) {
+	in := "╭──╮ ✓ passing ✗ failing ● ○ ⚠ ▸ ▾ ⣾ 💬 ✨ @bob · 3 → …\n│▎ │ ▲ 10% ▼ ☑ ☐ ★ ⏱ ◎ ▒ ■ ▕ ⊘ ↻ ◐ ✎\n╰──╯"
+
+	out := toASCII(in)
+	for i, line := range strings.Split(in, "\n") {
+		if got, want := ansi.StringWidth(strings.Split(out, "\n")[i]), ansi.StringWidth(line); got != want {
+			t.Errorf("line %d width = %d, want %d (layout must not shift)", i, got, want)
+		}
+	}
+}
+
+// feedCmds feeds cmd's messages back into m, as the bubbletea loop would,
+// dropping ticks and anything else that doesn't answer at once.
+func feedCmds(m App, cmd tea.Cmd) App {
+	if cmd == nil {
+		return m
+	}
+	done := make(chan tea.Msg, 1)
+	go func() { done <- cmd() }()
+	select {
+	case msg := <-done:
+		switch msg := msg.(type) {
+		case nil:
+		case tea.BatchMsg:
+			for _, c := range msg {
+				m = feedCmds(m, c)
+			}
+		default:
+			model, next := m.Update(msg)
+			m = feedCmds(model.(App), next)
+		}
+	case <-time.After(50 * time.Millisecond):
+	}
+	return m
+}
+
+// compatDemoApp loads the demo PRs into an App in compatibility mode.
+func compatDemoApp(t *testing.T) App {
+	t.Helper()
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_CONFIG_HOME", home)
+	prevTheme := theme
+	t.Cleanup(func() {
+		compatForced = false
+		setCompat(false)
+		SetTheme(prevTheme)
+	})
+
+	m := NewApp(WithDemo(), WithCompat())
+	model, _ := m.Update(tea.WindowSizeMsg{Width: 180, Height: 120})
+	return feedCmds(model.(App), initDemoClientCmd)
+}
+
+func assertASCII(t *testing.T, name, view string) {
+	t.Helper()
+	for _, r := range view {
+		if r >= 0x80 {
+			t.Errorf("%s: non-ASCII %q in compatibility mode:\n%s", name, r, view)
+			return
+		}
+	}
+}
+
+func TestCompatMode_ViewsAreASCII(t *testing.T) {
+	m := compatDemoApp(t)
+	press := func(m App, keys ...string) App {
+		for _, k := range keys {
+			msg := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(k)}
+			switch k {
+			case "enter":
+				msg = tea.KeyMsg{Type: tea.KeyEnter}
+			case " ":
+				msg = tea.KeyMsg{Type: tea.KeySpace}
+			}
+			model, cmd := m.Update(msg)
+			m = feedCmds(model.(App), cmd)
+		}
+		return m
+	}
+	command := func(m App, name, args string) App {
+		model, cmd := m.executeCommand(name, args)
+		return feedCmds(model.(App), cmd)
+	}
+
+	assertASCII(t, "PR list", m.View())
+	assertASCII(t, "PR list batch marks", press(m, "v", " ").View())
+
+	m = press(m, "enter")
+	if m.session == nil {
+		t.Fatal("Enter should select the first demo PR")
+	}
+	assertASCII(t, "diff", m.View())
+
+	// Decorate the diff with every marker it can draw: selected hunks in
+	// order, an excluded file, coverage, the minimap, an explanation and a
+	// draft comment.
+	m.diffViewer.toggleExplanation() // the request itself would need Claude
+	m = press(m, "s", "n", "s")
+	m = command(m, "exclude file", "")
+	dv := &m.diffViewer
+	dv.SetHunkOrder([]int{0, 1}, 1)
+	dv.minimap = true
+	dv.SetCoverage(&github.CoverageReport{Artifact: "coverage", Lines: github.Coverage{
+		dv.files[0].Filename: {1: 1, 2: 0, 3: 4},
+	}})
+	dv.SetHunkExplanation(explainKey(dv.hunks[0]), "Adds a per-IP limiter.", nil)
+	m.session.PendingInlineComments = []PendingInlineComment{pending(dv.files[0].Filename, 3)}
+	dv.SetPendingInlineComments(m.session.PendingInlineComments)
+	m.syncPendingCommentCount()
+	dv.refreshContent()
+	assertASCII(t, "decorated diff", m.View())
+	small, _ := m.Update(tea.WindowSizeMsg{Width: 100, Height: 24})
+	assertASCII(t, "decorated diff, scrolling", small.View())
+	assertASCII(t, "PR Info", press(m, "l").View())
+	assertASCII(t, "CI", press(m, "l", "l").View())
+
+	// Every status bar segment, a review timer and focus mode.
+	status := command(command(m, "timer", "20m"), "focus", "")
+	status.statusBar.SetSegments(append([]string{segmentPoll, segmentPending, segmentCI}, defaultStatusSegments...), defaultSegmentPriority)
+	status.statusBar.SetNextPoll(time.Now().Add(time.Minute))
+	status.statusBar.SetCIStatus("mixed")
+	assertASCII(t, "status bar", status.View())
+
+	analysis := &claude.AnalysisResult{
+		Summary: "Adds rate limiting.",
+		Risk:    claude.RiskAssessment{Level: "medium", Reasoning: "Touches every request."},
+		FileReviews: []claude.FileReview{{File: dv.files[0].Filename, Comments: []claude.ReviewComment{
+			{Line: 3, Severity: "warning", Comment: "Visitors are never evicted"},
+		}}},
+		Suggestions: []claude.Suggestion{{Title: "Add eviction"}},
+	}
+	m.chatPanel.SetAnalysisResult(analysis)
+	for _, tab := range []ChatTab{ChatTabChat, ChatTabAnalysis, ChatTabComments, ChatTabReview} {
+		m.chatPanel.SetActiveTab(tab)
+		m.chatPanel.refreshViewport()
+		assertASCII(t, "chat panel tab", m.View())
+	}
+
+	overlays := []struct {
+		name string
+		open func(App) App
+	}{
+		{"help", func(m App) App { return press(m, "?") }},
+		{"settings", func(m App) App { return command(m, "config", "") }},
+		{"pending comments", func(m App) App { return command(m, "pending", "") }},
+		{"hunk order", func(m App) App { return command(m, "order hunks", "") }},
+		{"chat context", func(m App) App { return command(m, "context", "") }},
+		{"usage", func(m App) App { return command(m, "usage", "") }},
+		{"quickfix", func(m App) App { return command(m, "copen", "") }},
+		{"workload", func(m App) App { return command(m, "workload", "") }},
+		{"close confirmation", func(m App) App { return command(m, "close", "") }},
+		{"global search", func(m App) App {
+			model, cmd := m.Update(tea.KeyMsg{Type: tea.KeyCtrlF})
+			return feedCmds(model.(App), cmd)
+		}},
+	}
+	for _, o := range overlays {
+		assertASCII(t, o.name, o.open(m).View())
+	}
+}
@@ -0,0 +1,326 @@
+package ui
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// autosaveInterval is how often in-progress text inputs are saved.
+const autosaveInterval = 5 * time.Second
+
+// autosaveTickMsg saves in-progress text inputs every autosaveInterval.
+type autosaveTickMsg struct{}
+
+// autosaveTickCmd schedules the next autosave.
+func autosaveTickCmd() tea.Cmd {
+	return tea.Tick(autosaveInterval, func(time.Time) tea.Msg { return autosaveTickMsg{} })
+}
+
+// scratchText is the in-progress text of a PR's inputs: the chat message,
+// the inline comment being written and its target, and the review body.
+type scratchText struct {
+	Chat             string `json:"chat,omitempty"`
+	Comment          string `json:"comment,omitempty"`
+	CommentPath      string `json:"commentPath,omitempty"`
+	CommentLine      int    `json:"commentLine,omitempty"`
+	CommentStartLine int    `json:"commentStartLine,omitempty"`
+	Review           string `json:"review,omitempty"`
+}
+
+func (t scratchText) empty() bool {
+	return strings.TrimSpace(t.Chat) == "" && strings.TrimSpace(t.Comment) == "" && strings.TrimSpace(t.Review) == ""
+}
+
+// scratchFile is a PR's autosaved text as stored on disk, with the pending
+// comments saved as a draft when quitting.
+type scratchFile struct {
+	scratchText
+	Pending []PendingInlineComment `json:"pending,omitempty"`
+	SavedAt time.Time              `json:"savedAt"`
+}
+
+func (f *scratchFile) empty() bool {
+	return f.scratchText.empty() && len(f.Pending) == 0
+}
+
+// parts names what the file holds, e.g. "review body, 2 pending comments".
+func (f *scratchFile) parts() []string {
+	var parts []string
+	if strings.TrimSpace(f.Chat) != "" {
+		parts = append(parts, "chat message")
+	}
+	if strings.TrimSpace(f.Comment) != "" {
+		parts = append(parts, "comment")
+	}
+	if strings.TrimSpace(f.Review) != "" {
+		parts = append(parts, "review body")
+	}
+	if n := len(f.Pending); n > 0 {
+		parts = append(parts, pendingCount(n))
+	}
+	return parts
+}
+
+// scratchStore keeps one scratch file per PR. A file only exists while the
+// PR has unsent text, so one left behind means prtea exited before it was
+// sent.
+type scratchStore struct {
+	dir string
+}
+
+func newScratchStore(dir string) *scratchStore {
+	return &scratchStore{dir: dir}
+}
+
+func (s *scratchStore) path(owner, repo string, number int) string {
+	return filepath.Join(s.dir, fmt.Sprintf("%s_%s_%d.json", owner, repo, number))
+}
+
+// Get loads a PR's scratch file. Returns nil if there is none.
+func (s *scratchStore) Get(owner, repo string, number int) (*scratchFile, error) {
+	data, err := os.ReadFile(s.path(owner, repo, number))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read scratch file: %w", err)
+	}
+	var f scratchFile
+	if err := json.Unmarshal(data, &f); err != nil {
+		return nil, fmt.Errorf("failed to parse scratch file: %w", err)
+	}
+	return &f, nil
+}
+
+// Put saves a PR's in-progress text, removing its file when there is
+// nothing left in it. Pending comments saved as a draft are kept until
+// :recover handles them with PutDraft.
+func (s *scratchStore) Put(owner, repo string, number int, t scratchText) error {
+	var pending []PendingInlineComment
+	if f, _ := s.Get(owner, repo, number); f != nil {
+		pending = f.Pending
+	}
+	return s.PutDraft(owner, repo, number, t, pending)
+}
+
+// PutPending saves pending comments as a draft, keeping the PR's saved text.
+func (s *scratchStore) PutPending(owner, repo string, number int, pending []PendingInlineComment) error {
+	var t scratchText
+	if f, _ := s.Get(owner, repo, number); f != nil {
+		t = f.scratchText
+	}
+	return s.PutDraft(owner, repo, number, t, pending)
+}
+
+// PutDraft saves a PR's in-progress text along with its pending comments,
+// replacing any saved before.
+func (s *scratchStore) PutDraft(owner, repo string, number int, t scratchText, pending []PendingInlineComment) error {
+	path := s.path(owner, repo, number)
+	f := scratchFile{scratchText: t, Pending: pending, SavedAt: time.Now()}
+	if f.empty() {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to remove scratch file: %w", err)
+		}
+		return nil
+	}
+	if err := os.MkdirAll(s.dir, 0o700); err != nil {
+		return fmt.Errorf("failed to create scratch directory: %w", err)
+	}
+	data, err := json.MarshalIndent(f, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal scratch file: %w", err)
+	}
+
+	// Write atomically: temp file + rename
+	tmpPath := path + ".tmp"
+	if err := os.WriteFile(tmpPath, data, 0o600); err != nil {
+		return fmt.Errorf("failed to write temp scratch file: %w", err)
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		os.Remove(tmpPath)
+		return fmt.Errorf("failed to rename scratch file: %w", err)
+	}
+	return nil
+}
+
+// inputScratch returns the current PR's in-progress text.
+func (m App) inputScratch() scratchText {
+	t := scratchText{
+		Chat:   m.chatPanel.textInput.Value(),
+		Review: m.chatPanel.review.textArea.Value(),
+	}
+	if dv := m.diffViewer; dv.commentMode {
+		t.Comment = dv.commentInput.Value()
+		t.CommentPath = dv.commentTargetFile
+		t.CommentLine = dv.commentTargetLine
+		t.CommentStartLine = dv.commentTargetStartLine
+	}
+	return t
+}
+
+// autosave returns a command saving the current PR's text if it changed
+// since the last save. Text offered for recovery is kept until new text is
+// typed; draft pending comments stay on offer until :recover.
+func (m *App) autosave() tea.Cmd {
+	if m.scratch == nil || m.session == nil {
+		return nil
+	}
+	t := m.inputScratch()
+	if t == m.scratchSaved || (t.empty() && m.scratchRecovery != nil) {
+		return nil
+	}
+	m.scratchSaved = t
+	if f := m.scratchRecovery; f != nil && len(f.Pending) > 0 {
+		m.scratchRecovery = &scratchFile{Pending: f.Pending, SavedAt: f.SavedAt}
+	} else {
+		m.scratchRecovery = nil
+	}
+	return saveScratchCmd(m.scratch, m.session.Owner, m.session.Repo, m.session.Number, t)
+}
+
+// saveScratchCmd writes a PR's in-progress text to its scratch file.
+func saveScratchCmd(store *scratchStore, owner, repo string, number int, t scratchText) tea.Cmd {
+	return func() tea.Msg {
+		if err := store.Put(owner, repo, number, t); err != nil {
+			log.Printf("warning: autosave failed: %v", err)
+		}
+		return nil
+	}
+}
+
+// resetScratchCmd replaces a PR's scratch file with t, dropping the pending
+// comments of a draft once :recover has restored or discarded them.
+func resetScratchCmd(store *scratchStore, owner, repo string, number int, t scratchText) tea.Cmd {
+	return func() tea.Msg {
+		if err := store.PutDraft(owner, repo, number, t, nil); err != nil {
+			log.Printf("warning: autosave failed: %v", err)
+		}
+		return nil
+	}
+}
+
+// flushScratch saves the current PR's text right away, before quitting.
+func (m *App) flushScratch() {
+	if cmd := m.autosave(); cmd != nil {
+		cmd()
+	}
+}
+
+// loadScratchCmd looks for text of a PR left unsent by an earlier session.
+func loadScratchCmd(store *scratchStore, owner, repo string, number int) tea.Cmd {
+	return func() tea.Msg {
+		f, err := store.Get(owner, repo, number)
+		if err != nil {
+			log.Printf("warning: %v", err)
+		}
+		return ScratchLoadedMsg{Owner: owner, Repo: repo, PRNumber: number, File: f}
+	}
+}
+
+// scratchPRSelected resets autosave for a newly selected PR and looks for
+// text to recover.
+func (m *App) scratchPRSelected() tea.Cmd {
+	m.scratchRecovery = nil
+	if m.scratch == nil || m.session == nil {
+		return nil
+	}
+	m.scratchSaved = m.inputScratch()
+	return loadScratchCmd(m.scratch, m.session.Owner, m.session.Repo, m.session.Number)
+}
+
+// offerScratch offers text left unsent for the current PR for recovery.
+func (m *App) offerScratch(msg ScratchLoadedMsg) tea.Cmd {
+	s := m.session
+	if msg.File == nil || msg.File.empty() || s == nil || s.Owner != msg.Owner || s.Repo != msg.Repo || s.Number != msg.PRNumber {
+		return nil
+	}
+	if len(msg.File.Pending) == 0 && msg.File.scratchText == m.inputScratch() {
+		return nil
+	}
+	m.scratchRecovery = msg.File
+	status := fmt.Sprintf("Unsent %s from %s: :recover restores it, :recover discard drops it",
+		strings.Join(msg.File.parts(), ", "), msg.File.SavedAt.Format("Jan 2 15:04"))
+	return m.statusBar.SetTemporaryMessage(status, 10*time.Second)
+}
+
+// recoverScratch restores the text offered for recovery into its inputs, or
+// drops it (:recover, :recover discard).
+func (m App) recoverScratch(args string) (tea.Model, tea.Cmd) {
+	f := m.scratchRecovery
+	if f == nil {
+		return m, m.statusBar.SetTemporaryMessage("No unsent text to recover for this PR", 2*time.Second)
+	}
+	m.scratchRecovery = nil
+	switch strings.TrimSpace(args) {
+	case "discard":
+		// Overwrite the file with whatever is typed now, usually nothing.
+		m.scratchSaved = m.inputScratch()
+		s := m.session
+		save := resetScratchCmd(m.scratch, s.Owner, s.Repo, s.Number, m.scratchSaved)
+		return m, tea.Batch(save, m.statusBar.SetTemporaryMessage("Discarded unsent text", 2*time.Second))
+	case "":
+	default:
+		m.scratchRecovery = f
+		return m, m.statusBar.SetTemporaryMessage("Usage: recover [discard]", 2*time.Second)
+	}
+
+	t := f.scratchText
+	if strings.TrimSpace(t.Comment) != "" && len(m.diffViewer.files) == 0 {
+		m.scratchRecovery = f
+		return m, m.statusBar.SetTemporaryMessage("Wait for the diff to load to restore the comment", 2*time.Second)
+	}
+	status := "Restored " + strings.Join(f.parts(), ", ")
+	if len(f.Pending) > 0 {
+		m.setPendingComments(mergePending(m.session.PendingInlineComments, f.Pending))
+	}
+	if t.Chat != "" {
+		m.chatPanel.textInput.SetValue(t.Chat)
+		m.chatPanel.textInput.CursorEnd()
+	}
+	if t.Review != "" {
+		m.chatPanel.review.textArea.SetValue(t.Review)
+	}
+	var cmd tea.Cmd
+	switch {
+	case strings.TrimSpace(t.Comment) == "":
+	case m.diffViewer.fileIndex(t.CommentPath) >= 0:
+		model, _ := m.gotoLine(t.CommentPath, t.CommentLine)
+		m = model.(App)
+		cmd = m.diffViewer.restoreComment(t.CommentPath, t.CommentLine, t.CommentStartLine, t.Comment)
+	default:
+		// The file left the diff, e.g. after a force-push: keep the text in
+		// the review body rather than lose it.
+		body := m.chatPanel.review.textArea.Value()
+		m.chatPanel.review.textArea.SetValue(strings.TrimSpace(fmt.Sprintf("%s\n\n%s:%d: %s", body, t.CommentPath, t.CommentLine, t.Comment)))
+		status += fmt.Sprintf(" (the comment went into the review body: %s is not in the diff)", t.CommentPath)
+	}
+	// The pending comments are the session's now; the file keeps the text.
+	var save tea.Cmd
+	if len(f.Pending) > 0 {
+		m.scratchSaved = m.inputScratch()
+		s := m.session
+		save = resetScratchCmd(m.scratch, s.Owner, s.Repo, s.Number, m.scratchSaved)
+	}
+	return m, tea.Batch(cmd, save, m.statusBar.SetTemporaryMessage(status, 4*time.Second))
+}
+
+// restoreComment reopens the inline comment input at a target with text.
+func (m *DiffViewerModel) restoreComment(path string, line, startLine int, body string) tea.Cmd {
+	m.activeTab = TabDiff
+	m.commentTargetFile = path
+	m.commentTargetLine = line
+	m.commentTargetStartLine = startLine
+	m.commentMode = true
+	m.completer.reset()
+	m.commentInput.SetValue(body)
+	m.commentInput.CursorEnd()
+	m.refreshContent()
+	return m.commentInput.Focus()
+}
@@ -0,0 +1,141 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestScratchStore_PutGetRemove(t *testing.T) {
+	store := newScratchStore(t.TempDir())
+	if f, err := store.Get("shhac", "prtea", 12); f != nil || err != nil {
+		t.Fatalf("Get before Put = %v, %v", f, err)
+	}
+	text := scratchText{Comment: "off by one?", CommentPath: "a.go", CommentLine: 3, Review: "Looks good"}
+	if err := store.Put("shhac", "prtea", 12, text); err != nil {
+		t.Fatal(err)
+	}
+	f, err := store.Get("shhac", "prtea", 12)
+	if err != nil || f == nil || f.scratchText != text || f.SavedAt.IsZero() {
+		t.Fatalf("Get = %+v, %v", f, err)
+	}
+	if err := store.Put("shhac", "prtea", 12, scratchText{Chat: "  "}); err != nil {
+		t.Fatal(err)
+	}
+	if f, _ := store.Get("shhac", "prtea", 12); f != nil {
+		t.Errorf("empty text should remove the scratch file, got %+v", f)
+	}
+}
+
+func autosaveTestApp(t *testing.T) App {
+	m := quickfixTestApp()
+	m.scratch = newScratchStore(t.TempDir())
+	m.session.Owner, m.session.Repo = "shhac", "prtea"
+	m.session.PendingInlineComments = nil
+	return m
+}
+
+func TestAutosave_SavesChangesOnly(t *testing.T) {
+	m := autosaveTestApp(t)
+	if cmd := m.autosave(); cmd != nil {
+		t.Error("nothing typed, nothing to save")
+	}
+	m.chatPanel.review.textArea.SetValue("Needs tests")
+	cmd := m.autosave()
+	if cmd == nil {
+		t.Fatal("a changed review body should be saved")
+	}
+	cmd()
+	if cmd := m.autosave(); cmd != nil {
+		t.Error("unchanged text should not be saved again")
+	}
+	s := m.session
+	if f, _ := m.scratch.Get(s.Owner, s.Repo, s.Number); f == nil || f.Review != "Needs tests" {
+		t.Errorf("scratch file = %+v", f)
+	}
+}
+
+func TestRecoverScratch(t *testing.T) {
+	m := autosaveTestApp(t)
+	s := m.session
+	saved := &scratchFile{
+		scratchText: scratchText{Chat: "why retry?", Comment: "nit", CommentPath: "b.go", CommentLine: 2, Review: "LGTM"},
+		SavedAt:     time.Now(),
+	}
+	cmd := m.offerScratch(ScratchLoadedMsg{Owner: s.Owner, Repo: s.Repo, PRNumber: s.Number, File: saved})
+	if cmd == nil || !strings.Contains(m.statusBar.statusMessage, "chat message, comment, review body") {
+		t.Fatalf("offer status = %q", m.statusBar.statusMessage)
+	}
+	if m.autosave() != nil {
+		t.Error("empty inputs should not overwrite the text offered for recovery")
+	}
+
+	model, _ := m.recoverScratch("")
+	m = model.(App)
+	if m.chatPanel.textInput.Value() != "why retry?" || m.chatPanel.review.textArea.Value() != "LGTM" {
+		t.Errorf("chat = %q, review = %q", m.chatPanel.textInput.Value(), m.chatPanel.review.textArea.Value())
+	}
+	dv := m.diffViewer
+	if !dv.commentMode || dv.commentInput.Value() != "nit" || dv.commentTargetFile != "b.go" || dv.commentTargetLine != 2 {
+		t.Errorf("comment mode = %v, input = %q, target = %s:%d", dv.commentMode, dv.commentInput.Value(), dv.commentTargetFile, dv.commentTargetLine)
+	}
+	if m.scratchRecovery != nil {
+		t.Error("recovered text should no longer be offered")
+	}
+	model, _ = m.recoverScratch("")
+	if m = model.(App); !strings.Contains(m.statusBar.statusMessage, "No unsent text") {
+		t.Errorf("second :recover status = %q", m.statusBar.statusMessage)
+	}
+}
+
+func TestRecoverScratch_CommentOnFileNoLongerInDiff(t *testing.T) {
+	m := autosaveTestApp(t)
+	m.scratchRecovery = &scratchFile{scratchText: scratchText{Comment: "rename this", CommentPath: "gone.go", CommentLine: 9}}
+	model, _ := m.recoverScratch("")
+	m = model.(App)
+	if got := m.chatPanel.review.textArea.Value(); got != "gone.go:9: rename this" || m.diffViewer.commentMode {
+		t.Errorf("review body = %q, comment mode = %v", got, m.diffViewer.commentMode)
+	}
+}
+
+func TestScratchStore_PutKeepsDraftPending(t *testing.T) {
+	store := newScratchStore(t.TempDir())
+	drafted := []PendingInlineComment{pending("a.go", 2)}
+	if err := store.PutDraft("shhac", "prtea", 12, scratchText{Review: "nits"}, drafted); err != nil {
+		t.Fatal(err)
+	}
+	// Autosave of text typed before :recover must not drop the draft.
+	if err := store.Put("shhac", "prtea", 12, scratchText{Chat: "why?"}); err != nil {
+		t.Fatal(err)
+	}
+	if f, _ := store.Get("shhac", "prtea", 12); f == nil || len(f.Pending) != 1 || f.Chat != "why?" {
+		t.Fatalf("after autosave = %+v", f)
+	}
+	if err := store.PutPending("shhac", "prtea", 12, append(drafted, pending("b.go", 1))); err != nil {
+		t.Fatal(err)
+	}
+	if f, _ := store.Get("shhac", "prtea", 12); f == nil || len(f.Pending) != 2 || f.Chat != "why?" {
+		t.Fatalf("PutPending should keep the text: %+v", f)
+	}
+}
+
+func TestRecoverScratch_DropsRecoveredPendingFromFile(t *testing.T) {
+	for _, args := range []string{"", "discard"} {
+		m := autosaveTestApp(t)
+		s := m.session
+		if err := m.scratch.PutDraft(s.Owner, s.Repo, s.Number, scratchText{}, []PendingInlineComment{pending("a.go", 2)}); err != nil {
+			t.Fatal(err)
+		}
+		f, _ := m.scratch.Get(s.Owner, s.Repo, s.Number)
+		m.offerScratch(ScratchLoadedMsg{Owner: s.Owner, Repo: s.Repo, PRNumber: s.Number, File: f})
+		m.chatPanel.textInput.SetValue("typed meanwhile")
+		m.autosave()()
+
+		model, cmd := m.recoverScratch(args)
+		feedCmds(model.(App), cmd)
+		f, _ = m.scratch.Get(s.Owner, s.Repo, s.Number)
+		if f == nil || len(f.Pending) != 0 || f.Chat != "typed meanwhile" {
+			t.Errorf(":recover %s: file = %+v, want the text without the handled draft", args, f)
+		}
+	}
+}
@@ -0,0 +1,189 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// defaultSnooze is how long :batch snooze hides PRs without a duration.
+const defaultSnooze = 24 * time.Hour
+
+// batchRun is a batch action in progress over the marked PRs.
+type batchRun struct {
+	id     int
+	verb   string // "Approving", "Refreshing"
+	total  int
+	done   int
+	failed []string // "owner/repo#N: error" per PR that failed
+}
+
+// batchCommand runs a bulk action on the PRs marked in the list
+// (:batch approve, :batch open, :batch snooze [1d], :batch refresh).
+func (m App) batchCommand(args string) (tea.Model, tea.Cmd) {
+	action, rest, _ := strings.Cut(strings.TrimSpace(args), " ")
+	if action == "unsnooze" {
+		n := m.prList.Unsnooze()
+		model, cmd := m.refreshPRList()
+		m = model.(App)
+		return m, tea.Batch(cmd, m.statusBar.SetTemporaryMessage(fmt.Sprintf("Unsnoozed %d PR(s)", n), 2*time.Second))
+	}
+	switch action {
+	case "approve", "open", "snooze", "refresh":
+	default:
+		return m, m.statusBar.SetTemporaryMessage("Usage: batch approve|open|snooze [1d]|refresh|unsnooze", 3*time.Second)
+	}
+	prs := m.prList.MarkedPRs()
+	if len(prs) == 0 {
+		return m, m.statusBar.SetTemporaryMessage("No PRs marked: press v in the PR list, then Space to mark", 3*time.Second)
+	}
+	if m.batch != nil && action != "open" && action != "snooze" {
+		return m, m.statusBar.SetTemporaryMessage(m.batch.verb+" is still running", 2*time.Second)
+	}
+
+	switch action {
+	case "open":
+		cmds := make([]tea.Cmd, 0, len(prs)+1)
+		for _, pr := range prs {
+			cmds = append(cmds, openBrowserCmd(pr.htmlURL))
+		}
+		cmds = append(cmds, m.statusBar.SetTemporaryMessage(fmt.Sprintf("Opening %d PR(s) in the browser", len(prs)), 2*time.Second))
+		return m, tea.Batch(cmds...)
+	case "snooze":
+		d := defaultSnooze
+		if rest = strings.TrimSpace(rest); rest != "" {
+			var err error
+			if d, err = parseSnoozeDuration(rest); err != nil {
+				return m, m.statusBar.SetTemporaryMessage(err.Error(), 3*time.Second)
+			}
+		}
+		until := time.Now().Add(d)
+		m.prList.Snooze(prs, until)
+		return m, m.statusBar.SetTemporaryMessage(fmt.Sprintf("Snoozed %d PR(s) until %s (:batch unsnooze brings them back)", len(prs), until.Format("Mon 15:04")), 3*time.Second)
+	case "approve":
+		m.confirm.SetSize(m.width, m.height)
+		m.confirm.Show(
+			fmt.Sprintf("Approve %d PRs?", len(prs)),
+			batchList(prs)+"\n\nEach PR gets an approving review with no comment.",
+			"Approve all",
+			BatchStartMsg{Action: "approve", PRs: prs},
+		)
+		m.setMode(ModeOverlay)
+		return m, nil
+	}
+	return m.startBatch("refresh", prs)
+}
+
+// batchList lists PRs one per line, up to ten.
+func batchList(prs []PRItem) string {
+	var b strings.Builder
+	for i, pr := range prs {
+		if i == 10 {
+			fmt.Fprintf(&b, "… and %d more", len(prs)-10)
+			break
+		}
+		fmt.Fprintf(&b, "%s %s\n", pr.key(), pr.title)
+	}
+	return strings.TrimRight(b.String(), "\n")
+}
+
+// parseSnoozeDuration parses a :batch snooze duration; "2d" is two days.
+func parseSnoozeDuration(arg string) (time.Duration, error) {
+	if days, ok := strings.CutSuffix(arg, "d"); ok {
+		if n, err := strconv.Atoi(days); err == nil && n > 0 {
+			return time.Duration(n) * 24 * time.Hour, nil
+		}
+	}
+	d, err := time.ParseDuration(arg)
+	if err != nil || d <= 0 {
+		return 0, fmt.Errorf("invalid duration %q (try 4h or 2d)", arg)
+	}
+	return d, nil
+}
+
+// startBatch runs action on each PR at once, reporting progress in the
+// status bar as they finish.
+func (m App) startBatch(action string, prs []PRItem) (tea.Model, tea.Cmd) {
+	if m.ghClient == nil {
+		return m, nil
+	}
+	verb := "Refreshing"
+	if action == "approve" {
+		verb = "Approving"
+	}
+	m.batchSeq++
+	m.batch = &batchRun{id: m.batchSeq, verb: verb, total: len(prs)}
+	cmds := []tea.Cmd{m.statusBar.SetTemporaryMessage(m.batch.progress(), time.Minute)}
+	for _, pr := range prs {
+		cmds = append(cmds, batchItemCmd(m.ghClient, m.batchSeq, action, pr))
+	}
+	return m, tea.Batch(cmds...)
+}
+
+// batchItemCmd runs a batch action on one PR.
+func batchItemCmd(client GitHubService, id int, action string, pr PRItem) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		msg := BatchItemDoneMsg{BatchID: id, Owner: pr.owner, Repo: pr.repo, Number: pr.number}
+		switch action {
+		case "approve":
+			msg.Err = client.ApprovePR(ctx, pr.owner, pr.repo, pr.number, "")
+		case "refresh":
+			msg.Detail, msg.Err = client.GetPRDetail(ctx, pr.owner, pr.repo, pr.number)
+		}
+		return msg
+	}
+}
+
+func (b *batchRun) progress() string {
+	return fmt.Sprintf("%s %d/%d PRs...", b.verb, b.done, b.total)
+}
+
+// summary describes a finished batch, listing the PRs that failed.
+func (b *batchRun) summary() string {
+	done := map[string]string{"Approving": "Approved", "Refreshing": "Refreshed"}[b.verb]
+	s := fmt.Sprintf("%s %d/%d PRs", done, b.total-len(b.failed), b.total)
+	if len(b.failed) > 0 {
+		s = "✗ " + s + " — failed: " + strings.Join(b.failed, "; ")
+	} else {
+		s = "✓ " + s
+	}
+	return s
+}
+
+// batchItemDone records one PR's result, updating its list row after a
+// refresh, and reports the batch once every PR is done.
+func (m App) batchItemDone(msg BatchItemDoneMsg) (tea.Model, tea.Cmd) {
+	b := m.batch
+	if b == nil || b.id != msg.BatchID {
+		return m, nil
+	}
+	b.done++
+	ref := fmt.Sprintf("%s/%s#%d", msg.Owner, msg.Repo, msg.Number)
+	if msg.Err != nil {
+		log.Printf("batch: %s %s: %v", strings.ToLower(b.verb), ref, msg.Err)
+		b.failed = append(b.failed, ref+": "+formatUserError(msg.Err.Error()))
+	} else if d := msg.Detail; d != nil {
+		m.prList.updatePR(msg.Owner, msg.Repo, msg.Number, func(pr *PRItem) bool {
+			changed := pr.title != d.Title || pr.isDraft != d.Draft || pr.autoMerge != d.AutoMerge
+			pr.title, pr.isDraft, pr.autoMerge = d.Title, d.Draft, d.AutoMerge
+			return changed
+		})
+	}
+	if b.done < b.total {
+		return m, m.statusBar.SetTemporaryMessage(b.progress(), time.Minute)
+	}
+
+	// Marks are kept when some PRs failed, so the action can be retried.
+	m.batch = nil
+	if len(b.failed) == 0 {
+		m.prList.ClearMarks()
+	}
+	return m, m.statusBar.SetTemporaryMessage(b.summary(), 8*time.Second)
+}
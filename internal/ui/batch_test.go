@@ -0,0 +1,132 @@
+package ui
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/shhac/prtea/internal/github"
+)
+
+func batchTestList() PRListModel {
+	l := NewPRListModel(TabToReview)
+	l.SetSize(60, 30)
+	l.SetItems([]list.Item{
+		PRItem{number: 1, owner: "acme", repo: "api", title: "One"},
+		PRItem{number: 2, owner: "acme", repo: "api", title: "Two"},
+		PRItem{number: 3, owner: "acme", repo: "web", title: "Three"},
+	}, nil)
+	return l
+}
+
+func listKey(l PRListModel, k string) PRListModel {
+	msg := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(k)}
+	switch k {
+	case " ":
+		msg = tea.KeyMsg{Type: tea.KeySpace, Runes: []rune(" ")}
+	case "esc":
+		msg = tea.KeyMsg{Type: tea.KeyEsc}
+	}
+	l, _ = l.Update(msg)
+	return l
+}
+
+func TestPRList_MarkMode(t *testing.T) {
+	l := batchTestList()
+	l = listKey(l, " ")
+	if len(l.MarkedPRs()) != 0 {
+		t.Fatal("Space outside mark mode should select, not mark")
+	}
+	l = listKey(l, "v")
+	l = listKey(l, " ") // marks #1, moves to #2
+	l = listKey(l, "j")
+	l = listKey(l, " ") // marks #3
+	marked := l.MarkedPRs()
+	if len(marked) != 2 || marked[0].number != 1 || marked[1].number != 3 {
+		t.Fatalf("marked = %+v", marked)
+	}
+	if view := l.View(); !strings.Contains(view, "2 marked") || !strings.Contains(view, "☑ #1") {
+		t.Errorf("view:\n%s", view)
+	}
+	l = listKey(l, "esc")
+	if l.marking() || len(l.MarkedPRs()) != 0 {
+		t.Error("Esc should leave mark mode and clear the marks")
+	}
+}
+
+func TestPRList_Snooze(t *testing.T) {
+	l := batchTestList()
+	l.Snooze([]PRItem{{number: 2, owner: "acme", repo: "api"}}, time.Now().Add(time.Hour))
+	if len(l.toReview) != 2 {
+		t.Fatalf("snoozed PR still listed: %d items", len(l.toReview))
+	}
+	// Still hidden when the lists are fetched again.
+	fresh := batchTestList()
+	l.SetItems(fresh.toReview, nil)
+	if len(l.toReview) != 2 {
+		t.Errorf("snoozed PR came back on refetch: %d items", len(l.toReview))
+	}
+	l.snoozed["acme/api#2"] = time.Now().Add(-time.Minute)
+	l.SetItems(fresh.toReview, nil)
+	if len(l.toReview) != 3 || len(l.snoozed) != 0 {
+		t.Errorf("an expired snooze should end: %d items, %d snoozed", len(l.toReview), len(l.snoozed))
+	}
+	if d, err := parseSnoozeDuration("2d"); err != nil || d != 48*time.Hour {
+		t.Errorf("2d = %v, %v", d, err)
+	}
+}
+
+func TestBatchApprove_ProgressAndFailures(t *testing.T) {
+	client := github.NewTestClient("me", func(ctx context.Context, args ...string) (string, error) {
+		if strings.Contains(strings.Join(args, " "), "review 3") {
+			return "", errors.New("Can not approve your own pull request")
+		}
+		return "", nil
+	})
+	m := App{statusBar: NewStatusBarModel(), prList: batchTestList(), confirm: NewConfirmModel(), ghClient: client}
+	m.prList = listKey(m.prList, "v")
+	for range 3 {
+		m.prList = listKey(m.prList, " ")
+	}
+
+	model, _ := m.batchCommand("approve")
+	m = model.(App)
+	if !m.confirm.IsVisible() || !strings.Contains(m.confirm.message, "acme/web#3 Three") {
+		t.Fatalf("approving should ask first: %q", m.confirm.message)
+	}
+	start := m.confirm.onConfirm.(BatchStartMsg)
+	model, cmd := m.Update(start)
+	m = model.(App)
+	if m.batch == nil || m.statusBar.statusMessage != "Approving 0/3 PRs..." {
+		t.Fatalf("status = %q", m.statusBar.statusMessage)
+	}
+
+	var results []BatchItemDoneMsg
+	for _, c := range cmd().(tea.BatchMsg) {
+		if done, ok := c().(BatchItemDoneMsg); ok {
+			results = append(results, done)
+		}
+	}
+	if len(results) != 3 {
+		t.Fatalf("results = %d, want one per PR", len(results))
+	}
+	model, _ = m.Update(results[0])
+	if m = model.(App); m.statusBar.statusMessage != "Approving 1/3 PRs..." {
+		t.Errorf("progress = %q", m.statusBar.statusMessage)
+	}
+	for _, r := range results[1:] {
+		model, _ = m.Update(r)
+		m = model.(App)
+	}
+	got := m.statusBar.statusMessage
+	if m.batch != nil || !strings.Contains(got, "Approved 2/3 PRs") || !strings.Contains(got, "acme/web#3") {
+		t.Errorf("summary = %q", got)
+	}
+	if len(m.prList.MarkedPRs()) != 3 {
+		t.Error("marks should be kept after a failure so the batch can be retried")
+	}
+}
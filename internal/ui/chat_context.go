@@ -0,0 +1,401 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/shhac/prtea/internal/claude"
+	"github.com/shhac/prtea/internal/config"
+	"github.com/shhac/prtea/internal/github"
+)
+
+// chatContextOptions picks what chat messages send Claude besides the PR
+// title and diff. Set in the :context panel and kept per PR.
+type chatContextOptions struct {
+	Body     bool            // the PR description
+	Comments bool            // conversation and review comments
+	CILogs   bool            // log excerpts of failing checks
+	Excluded map[string]bool // files whose diffs are left out
+}
+
+// Keys of the chat context panel rows that are not files.
+const (
+	chatContextMeta     = "meta"
+	chatContextHunks    = "hunks"
+	chatContextBody     = "body"
+	chatContextComments = "comments"
+	chatContextCI       = "ci"
+)
+
+// chatContextFiles returns the files whose diffs chat sends.
+func (o chatContextOptions) chatContextFiles(files []github.PRFile) []github.PRFile {
+	if len(o.Excluded) == 0 {
+		return files
+	}
+	var kept []github.PRFile
+	for _, f := range files {
+		if !o.Excluded[f.Filename] {
+			kept = append(kept, f)
+		}
+	}
+	return kept
+}
+
+// chatContextBudget returns the max prompt tokens chat works within.
+func (m App) chatContextBudget() int {
+	if m.appConfig == nil {
+		return config.DefaultMaxPromptTokens
+	}
+	promptTokens, _, _, _ := aiTuning(m.appConfig, m.aiBudget.health)
+	return promptTokens
+}
+
+// chatContextSections returns the optional sections chat sends, keyed like
+// the panel rows, whether or not they are turned on.
+func (m App) chatContextSections() map[string]string {
+	sections := make(map[string]string, 3)
+	if body := strings.TrimSpace(m.diffViewer.prBody); body != "" {
+		sections[chatContextBody] = "PR description:\n" + body + "\n"
+	}
+	if c := chatCommentsContext(m.chatPanel.comments.comments, m.chatPanel.comments.inlineComments); c != "" {
+		sections[chatContextComments] = c
+	}
+	if m.session != nil && m.session.ChatCILogs != "" {
+		sections[chatContextCI] = m.session.ChatCILogs + "\n"
+	}
+	return sections
+}
+
+// chatContextExtras joins the optional sections turned on for chat.
+func (m App) chatContextExtras() string {
+	o := m.session.ChatContext
+	sections := m.chatContextSections()
+	var parts []string
+	for _, key := range []string{chatContextBody, chatContextComments, chatContextCI} {
+		on := key == chatContextBody && o.Body || key == chatContextComments && o.Comments || key == chatContextCI && o.CILogs
+		if on && sections[key] != "" {
+			parts = append(parts, sections[key])
+		}
+	}
+	return strings.Join(parts, "\n")
+}
+
+// buildChatPRContext returns the PR context for the next chat message, and
+// whether it is built around selected hunks.
+func (m App) buildChatPRContext() (string, bool) {
+	s := m.session
+	files := s.ChatContext.chatContextFiles(s.DiffFiles)
+	extras := m.chatContextExtras()
+	if primary, rest := m.diffViewer.GetSelectedHunkSections(); primary != "" || rest != "" {
+		return buildSelectedHunkContext(s, files, extras, primary, rest), true
+	}
+	return buildChatContext(s, files, extras), false
+}
+
+// chatCommentsContext formats the PR's conversation and review comments
+// for a chat prompt, or "" when there are none.
+func chatCommentsContext(comments []github.Comment, inline []github.InlineComment) string {
+	var b strings.Builder
+	if len(comments) > 0 {
+		b.WriteString("Conversation comments:\n")
+		for _, c := range comments {
+			fmt.Fprintf(&b, "@%s: %s\n", c.Author.Login, strings.TrimSpace(c.Body))
+		}
+	}
+	for _, outdated := range []bool{false, true} {
+		threads := reviewThreads(inline, outdated)
+		if len(threads) == 0 {
+			continue
+		}
+		if b.Len() > 0 {
+			b.WriteString("\n")
+		}
+		if outdated {
+			b.WriteString("Outdated review comments, on code that has since changed:\n")
+		} else {
+			b.WriteString("Review comments:\n")
+		}
+		for _, th := range threads {
+			fmt.Fprintf(&b, "%s:%d @%s: %s\n", th.Root.Path, th.Root.Line, th.Root.Author.Login, strings.TrimSpace(th.Root.Body))
+			for _, r := range th.Replies {
+				fmt.Fprintf(&b, "  reply from @%s: %s\n", r.Author.Login, strings.TrimSpace(r.Body))
+			}
+		}
+	}
+	return b.String()
+}
+
+// chatContextItems describes what the next chat message sends, one row per
+// part, for the chat context panel.
+func (m App) chatContextItems() []ChatContextItem {
+	s := m.session
+	o := s.ChatContext
+	sections := m.chatContextSections()
+	items := []ChatContextItem{{
+		Key:    chatContextMeta,
+		Label:  fmt.Sprintf("PR #%d title and repo", s.Number),
+		Tokens: claude.EstimateTokens(buildChatContext(s, nil, "")),
+		On:     true,
+		Fixed:  true,
+	}}
+
+	items = append(items, ChatContextItem{Key: chatContextBody, Label: "PR description", Tokens: claude.EstimateTokens(sections[chatContextBody]), On: o.Body})
+	if sections[chatContextBody] == "" {
+		items[len(items)-1].Label += " (empty)"
+	}
+
+	n := len(m.chatPanel.comments.comments) + len(m.chatPanel.comments.inlineComments)
+	items = append(items, ChatContextItem{Key: chatContextComments, Label: fmt.Sprintf("Comments (%d)", n), Tokens: claude.EstimateTokens(sections[chatContextComments]), On: o.Comments})
+
+	ci := ChatContextItem{Key: chatContextCI, Label: "CI logs", Tokens: claude.EstimateTokens(sections[chatContextCI]), On: o.CILogs}
+	switch failing := m.failingChecks(); {
+	case m.diffViewer.ciStatus == nil:
+		ci.Label += " (CI not loaded yet)"
+	case len(failing) == 0:
+		ci.Label += " (no failing checks)"
+	case s.ChatCILogs == "":
+		ci.Label += fmt.Sprintf(" (%d failing, fetched when applied)", len(failing))
+	default:
+		ci.Label += fmt.Sprintf(" (%d failing)", len(failing))
+	}
+	items = append(items, ci)
+
+	if primary, rest := m.diffViewer.GetSelectedHunkSections(); primary != "" || rest != "" {
+		items = append(items, ChatContextItem{
+			Key:    chatContextHunks,
+			Label:  fmt.Sprintf("Selected hunks (%d), in place of the full diff", len(m.diffViewer.selectedHunks)),
+			Tokens: claude.EstimateTokens(primary + rest),
+			On:     true,
+			Fixed:  true,
+		})
+		return items
+	}
+	for _, f := range s.DiffFiles {
+		items = append(items, ChatContextItem{
+			Key:    f.Filename,
+			Label:  f.Filename,
+			Tokens: claude.EstimateTokens(buildDiffContent([]github.PRFile{f})),
+			On:     !o.Excluded[f.Filename],
+			File:   true,
+		})
+	}
+	return items
+}
+
+// openChatContext shows the chat context panel for the selected PR.
+func (m App) openChatContext() (tea.Model, tea.Cmd) {
+	if m.session == nil {
+		return m, m.statusBar.SetTemporaryMessage("No PR selected", 2*time.Second)
+	}
+	return m.handleConfigMsg(ShowChatContextMsg{Items: m.chatContextItems(), Budget: m.chatContextBudget()})
+}
+
+// applyChatContext stores the choices made in the chat context panel,
+// fetching CI log excerpts when they were turned on and are not loaded.
+func (m App) applyChatContext(items []ChatContextItem) (tea.Model, tea.Cmd) {
+	s := m.session
+	if s == nil {
+		return m, nil
+	}
+	o := chatContextOptions{Excluded: make(map[string]bool)}
+	for _, item := range items {
+		switch {
+		case item.File:
+			if !item.On {
+				o.Excluded[item.Key] = true
+			}
+		case item.Key == chatContextBody:
+			o.Body = item.On
+		case item.Key == chatContextComments:
+			o.Comments = item.On
+		case item.Key == chatContextCI:
+			o.CILogs = item.On
+		}
+	}
+	s.ChatContext = o
+
+	if failing := m.failingChecks(); o.CILogs && s.ChatCILogs == "" && len(failing) > 0 {
+		clearCmd := m.statusBar.SetTemporaryMessage("Fetching CI logs for chat...", 15*time.Second)
+		return m, tea.Batch(clearCmd, chatCILogsCmd(m.ghClient, s.Owner, s.Repo, s.Number, failing))
+	}
+	msg := fmt.Sprintf("Chat context: ~%s tokens", formatTokenCount(m.chatContextTokens()))
+	return m, m.statusBar.SetTemporaryMessage(msg, 3*time.Second)
+}
+
+// chatContextTokens estimates the tokens of the next chat message's PR context.
+func (m App) chatContextTokens() int {
+	prContext, _ := m.buildChatPRContext()
+	return claude.EstimateTokens(prContext)
+}
+
+// chatCILogsCmd fetches log excerpts of the failing checks for chat.
+func chatCILogsCmd(client GitHubService, owner, repo string, number int, failing []github.CICheck) tea.Cmd {
+	return func() tea.Msg {
+		excerpts := fetchCIExcerpts(context.Background(), client, owner, repo, failing, ciLogExcerpt)
+		return ChatCILogsMsg{PRNumber: number, Logs: formatCIFailureSummary(failing, excerpts)}
+	}
+}
+
+// ChatContextItem is one part of the chat context in the panel: PR
+// metadata, an optional section, the selected hunks, or a file's diff.
+type ChatContextItem struct {
+	Key    string // file path for files, else a chatContext* key
+	Label  string
+	Tokens int  // estimated tokens when sent
+	On     bool // sent with the next message
+	Fixed  bool // always sent; cannot be toggled
+	File   bool // a file's diff
+}
+
+// ChatContextModel is an overlay showing what the next chat message sends
+// Claude, with toggles for the optional parts and each file's diff, and
+// how the total compares with the max prompt token budget.
+type ChatContextModel struct {
+	items   []ChatContextItem
+	budget  int // max prompt tokens
+	cursor  int
+	width   int
+	height  int
+	visible bool
+}
+
+// NewChatContextModel creates a chat context overlay.
+func NewChatContextModel() ChatContextModel {
+	return ChatContextModel{}
+}
+
+// Show opens the overlay with the given parts and token budget.
+func (m *ChatContextModel) Show(items []ChatContextItem, budget int) {
+	m.items = append([]ChatContextItem(nil), items...)
+	m.budget = budget
+	m.cursor = 0
+	m.visible = true
+}
+
+// IsVisible returns whether the overlay is currently shown.
+func (m ChatContextModel) IsVisible() bool {
+	return m.visible
+}
+
+// SetSize updates the terminal dimensions used to place the overlay.
+func (m *ChatContextModel) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+}
+
+// total returns the estimated tokens of the parts turned on.
+func (m ChatContextModel) total() int {
+	n := 0
+	for _, item := range m.items {
+		if item.On {
+			n += item.Tokens
+		}
+	}
+	return n
+}
+
+func (m ChatContextModel) Update(msg tea.Msg) (ChatContextModel, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return m, nil
+	}
+	switch keyMsg.String() {
+	case "j", "down":
+		if m.cursor < len(m.items)-1 {
+			m.cursor++
+		}
+	case "k", "up":
+		if m.cursor > 0 {
+			m.cursor--
+		}
+	case " ", "x":
+		if m.cursor < len(m.items) && !m.items[m.cursor].Fixed {
+			m.items[m.cursor].On = !m.items[m.cursor].On
+		}
+	case "a":
+		// Include every file, or leave them all out when all are included.
+		all := true
+		for _, item := range m.items {
+			if item.File && !item.On {
+				all = false
+			}
+		}
+		for i := range m.items {
+			if m.items[i].File {
+				m.items[i].On = !all
+			}
+		}
+	case "enter":
+		m.visible = false
+		items := m.items
+		return m, func() tea.Msg { return ChatContextClosedMsg{Applied: true, Items: items} }
+	case "esc", "q":
+		m.visible = false
+		return m, func() tea.Msg { return ChatContextClosedMsg{} }
+	}
+	return m, nil
+}
+
+func (m ChatContextModel) View() string {
+	if !m.visible {
+		return ""
+	}
+	overlayW := min(max(50, m.width*2/3), m.width)
+	innerW := max(1, overlayW-4)
+	warnStyle := lipgloss.NewStyle().Foreground(theme.Warning)
+
+	title := helpTitleStyle.Render(" Chat context ")
+	lines := []string{lipgloss.PlaceHorizontal(innerW, lipgloss.Left, title),
+		dimStyle.Render(fitWidth("What the next chat message sends Claude, besides the chat history.", innerW)), ""}
+
+	maxRows := max(1, m.height-11)
+	start, end := visibleRange(m.cursor, len(m.items), maxRows)
+	for i := start; i < end; i++ {
+		item := m.items[i]
+		marker := "  "
+		if i == m.cursor {
+			marker = "▸ "
+		}
+		box := "[ ]"
+		switch {
+		case item.Fixed:
+			box = "[•]"
+		case item.On:
+			box = "[x]"
+		}
+		tokens := "~" + formatTokenCount(item.Tokens)
+		label := ansi.Truncate(marker+box+" "+item.Label, max(1, innerW-len(tokens)-1), "…")
+		row := label + strings.Repeat(" ", max(1, innerW-ansi.StringWidth(label)-len(tokens))) + tokens
+		switch {
+		case i == m.cursor:
+			row = boldStyle.Render(row)
+		case !item.On:
+			row = dimStyle.Render(row)
+		}
+		lines = append(lines, row)
+	}
+
+	total := m.total()
+	lines = append(lines, "", fitWidth(fmt.Sprintf("Total ~%s of the %s-token prompt budget", formatTokenCount(total), formatTokenCount(m.budget)), innerW))
+	if total > m.budget {
+		lines = append(lines, warnStyle.Render(fitWidth("Over budget: the end of the diff will be cut off. Leave out files to fit.", innerW)))
+	} else {
+		lines = append(lines, dimStyle.Render(fitWidth(fmt.Sprintf("~%s left for chat history; the oldest messages are dropped first.", formatTokenCount(m.budget-total)), innerW)))
+	}
+
+	footer := helpFooterStyle.Render("Space toggle · a all files · Enter apply · Esc cancel")
+	lines = append(lines, "", fitWidth(lipgloss.PlaceHorizontal(innerW, lipgloss.Center, footer), innerW))
+
+	overlayStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(theme.Accent).
+		Padding(0, 1).
+		Width(overlayW - 2)
+
+	return placeOverlay(m.width, m.height, overlayStyle.Render(strings.Join(lines, "\n")))
+}
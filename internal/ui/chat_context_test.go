@@ -0,0 +1,68 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/x/ansi"
+	"github.com/shhac/prtea/internal/github"
+)
+
+func chatContextTestApp() App {
+	m := commentNavTestApp()
+	m.session.Title = "Add cache"
+	m.session.DiffFiles = []github.PRFile{
+		{Filename: "a.go", Patch: "@@ -1 +1 @@\n+alpha"},
+		{Filename: "gen/big.pb.go", Patch: "@@ -1 +1 @@\n+" + strings.Repeat("x", 3000)},
+	}
+	m.diffViewer.prBody = "Caches lookups for an hour."
+	m.chatPanel.comments.comments = []github.Comment{{Author: github.User{Login: "bob"}, Body: "LGTM once CI passes"}}
+	return m
+}
+
+func TestChatContext_ToggledPartsAreSent(t *testing.T) {
+	m := chatContextTestApp()
+	ctx, _ := m.buildChatPRContext()
+	if strings.Contains(ctx, "Caches lookups") || strings.Contains(ctx, "LGTM") || !strings.Contains(ctx, "big.pb.go") {
+		t.Fatalf("by default only the title and diff are sent:\n%s", ctx)
+	}
+
+	m.chatContext.Show(m.chatContextItems(), 100)
+	// Rows: metadata, description, comments, CI logs, a.go, gen/big.pb.go.
+	for _, k := range []string{"j", " ", "j", " ", "j", "j", "j", " "} {
+		m.chatContext, _ = m.chatContext.Update(keyMsg(k))
+	}
+	_, cmd := m.chatContext.Update(keyMsg("enter"))
+	model, _ := m.applyChatContext(cmd().(ChatContextClosedMsg).Items)
+	m = model.(App)
+
+	ctx, _ = m.buildChatPRContext()
+	body, diff := strings.Index(ctx, "Caches lookups"), strings.Index(ctx, "+alpha")
+	if body < 0 || !strings.Contains(ctx, "@bob: LGTM") || body > diff {
+		t.Errorf("description and comments should come before the diff:\n%s", ctx)
+	}
+	if strings.Contains(ctx, "big.pb.go") {
+		t.Errorf("excluded file was sent:\n%s", ctx)
+	}
+}
+
+func TestChatContextModel_FixedRowsAndBudget(t *testing.T) {
+	m := NewChatContextModel()
+	m.SetSize(100, 30)
+	m.Show([]ChatContextItem{
+		{Key: chatContextMeta, Label: "PR #1 title and repo", Tokens: 10, On: true, Fixed: true},
+		{Key: "a.go", Label: "a.go", Tokens: 200, On: true, File: true},
+	}, 150)
+
+	m, _ = m.Update(keyMsg(" "))
+	if !m.items[0].On {
+		t.Error("fixed rows cannot be turned off")
+	}
+	if out := ansi.Strip(m.View()); !strings.Contains(out, "Over budget") || !strings.Contains(out, "~210 of the 150-token") {
+		t.Errorf("expected the over-budget total:\n%s", out)
+	}
+	m, _ = m.Update(keyMsg("a"))
+	if out := ansi.Strip(m.View()); strings.Contains(out, "Over budget") || !strings.Contains(out, "~140 left for chat history") {
+		t.Errorf("leaving the file out should fit the budget:\n%s", out)
+	}
+}
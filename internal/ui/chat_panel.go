@@ -12,6 +12,7 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/shhac/prtea/internal/claude"
+	"github.com/shhac/prtea/internal/config"
 	"github.com/shhac/prtea/internal/github"
 )
 
@@ -40,6 +41,7 @@ const (
 	ReviewFocusTextArea ReviewFocus = iota
 	ReviewFocusRadio
 	ReviewFocusSubmit
+	ReviewFocusChecklist
 )
 
 // ChatPanelModel manages the chat/analysis panel as a thin coordinator
@@ -49,6 +51,7 @@ type ChatPanelModel struct {
 	viewport  viewport.Model
 	spinner   spinner.Model
 	textInput textinput.Model
+	completer textCompleter
 	md        MarkdownRenderer
 
 	// Panel state
@@ -64,6 +67,16 @@ type ChatPanelModel struct {
 	analysis AnalysisTabModel
 	comments CommentsTabModel
 	review   ReviewTabModel
+
+	// Prompt preset picker (ctrl+t in insert mode)
+	presets presetPicker
+
+	usageFooter string // AI usage summary shown under a finished analysis
+
+	// file:line citations in the Chat and Analysis tabs
+	citeFiles []string   // PR files citations may resolve to
+	citations []citation // found in the current content
+	citeIdx   int        // selected citation, -1 for none
 }
 
 func NewChatPanelModel() ChatPanelModel {
@@ -77,6 +90,7 @@ func NewChatPanelModel() ChatPanelModel {
 		chatMode:  ChatModeNormal,
 		activeTab: ChatTabChat,
 		review:    NewReviewTabModel(),
+		citeIdx:   -1,
 	}
 }
 
@@ -94,6 +108,14 @@ func (m ChatPanelModel) contentWidth() int {
 // SetActiveTab switches the active tab.
 func (m *ChatPanelModel) SetActiveTab(tab ChatTab) {
 	m.activeTab = tab
+	m.citeIdx = -1
+}
+
+// SetCitationFiles sets the PR files that file:line citations can open.
+func (m *ChatPanelModel) SetCitationFiles(files []string) {
+	m.citeFiles = files
+	m.citeIdx = -1
+	m.refreshViewport()
 }
 
 // IsAIReviewLoading returns whether the AI review is in progress.
@@ -117,6 +139,17 @@ func (m *ChatPanelModel) UpdateDefaultReviewAction(action string) {
 	m.review.UpdateDefaultAction(action)
 }
 
+// SetPresets sets the prompt presets offered by the chat input picker.
+func (m *ChatPanelModel) SetPresets(presets []config.ChatPreset) {
+	m.presets.presets = presets
+	m.presets.cursor = 0
+}
+
+// SetPresetVars sets the PR context substituted into preset prompts.
+func (m *ChatPanelModel) SetPresetVars(vars map[string]string) {
+	m.presets.vars = vars
+}
+
 // -- Analysis delegation --
 
 // SetAnalysisLoading puts the analysis tab into loading state.
@@ -131,6 +164,48 @@ func (m *ChatPanelModel) SetAnalysisResult(result *claude.AnalysisResult) {
 	m.refreshViewport()
 }
 
+// SetAnalysisInputs records the inputs behind the current analysis result.
+func (m *ChatPanelModel) SetAnalysisInputs(inputs *claude.AnalysisInputs, canRerun bool) {
+	m.analysis.SetInputs(inputs, canRerun)
+	m.refreshViewport()
+}
+
+// ToggleAnalysisInputs shows or hides the analysis inputs section.
+func (m *ChatPanelModel) ToggleAnalysisInputs() bool {
+	shown := m.analysis.ToggleInputs()
+	m.refreshViewport()
+	return shown
+}
+
+// SetAnalysisChanges records what changed since the previous analysis.
+func (m *ChatPanelModel) SetAnalysisChanges(changes *claude.AnalysisDiff, since time.Time, show bool) {
+	m.analysis.SetChanges(changes, since, show)
+	m.refreshViewport()
+}
+
+// ToggleAnalysisChanges shows or hides the changes since the previous analysis.
+func (m *ChatPanelModel) ToggleAnalysisChanges() bool {
+	shown := m.analysis.ToggleChanges()
+	m.refreshViewport()
+	return shown
+}
+
+// AnalysisChanges returns what changed since the previous analysis, or nil.
+func (m ChatPanelModel) AnalysisChanges() *claude.AnalysisDiff {
+	return m.analysis.changes
+}
+
+// SetUsageFooter sets the AI usage line shown under the analysis.
+func (m *ChatPanelModel) SetUsageFooter(footer string) {
+	m.usageFooter = footer
+	m.refreshViewport()
+}
+
+// AnalysisResult returns the current analysis result, or nil if none.
+func (m ChatPanelModel) AnalysisResult() *claude.AnalysisResult {
+	return m.analysis.result
+}
+
 // SetAnalysisError sets an error message on the analysis tab.
 func (m *ChatPanelModel) SetAnalysisError(err string) {
 	m.analysis.SetError(err)
@@ -163,6 +238,17 @@ func (m *ChatPanelModel) AppendStreamChunk(chunk string) {
 	}
 }
 
+// AddToolUse shows a tool Claude used while answering.
+// Only auto-scrolls if the user was already at the bottom.
+func (m *ChatPanelModel) AddToolUse(line string) {
+	m.chat.AddToolUse(line)
+	wasAtBottom := m.viewport.AtBottom()
+	m.refreshViewport()
+	if wasAtBottom {
+		m.viewport.GotoBottom()
+	}
+}
+
 // AddResponse appends a Claude response and clears the waiting state.
 // Only auto-scrolls if the user was already at the bottom.
 func (m *ChatPanelModel) AddResponse(content string) {
@@ -232,6 +318,11 @@ func (m *ChatPanelModel) ClearComments() {
 
 // -- Review delegation --
 
+// SetReviewChecklist sets the repo's review checklist items.
+func (m *ChatPanelModel) SetReviewChecklist(items []string) {
+	m.review.SetChecklist(items)
+}
+
 // ClearReview resets review state for a new PR.
 func (m *ChatPanelModel) ClearReview() {
 	m.review.Clear()
@@ -252,14 +343,26 @@ func (m *ChatPanelModel) SetAIReviewError(err string) {
 	m.review.SetAIReviewError(err)
 }
 
+// AppendToReviewBody adds text to the review body and selects Request Changes.
+func (m *ChatPanelModel) AppendToReviewBody(text string) {
+	m.review.AppendToBody(text)
+}
+
 // ClearAIReview resets AI review state.
 func (m *ChatPanelModel) ClearAIReview() {
 	m.review.ClearAIReview()
 }
 
-// SetPendingCommentCount sets the number of pending inline comments.
-func (m *ChatPanelModel) SetPendingCommentCount(n int) {
-	m.review.SetPendingCommentCount(n)
+// SetPendingCommentCount sets the number of pending inline comments to submit
+// and the number withheld on out-of-scope files.
+func (m *ChatPanelModel) SetPendingCommentCount(n, withheld int) {
+	m.review.SetPendingCommentCount(n, withheld)
+}
+
+// SetOwnerCoverage sets the CODEOWNERS coverage shown before approving.
+func (m *ChatPanelModel) SetOwnerCoverage(cov *ownerCoverage) {
+	m.review.ownerCoverage = cov
+	m.refreshViewport()
 }
 
 // SetReviewSubmitted clears the submitting state. On success, also resets the form.
@@ -300,6 +403,7 @@ func (m *ChatPanelModel) SetFocused(focused bool) {
 	if !focused && m.chatMode == ChatModeInsert {
 		m.chatMode = ChatModeNormal
 		m.textInput.Blur()
+		m.presets.open = false
 	}
 	if !focused {
 		m.review.Blur()
@@ -337,10 +441,23 @@ func (m ChatPanelModel) Update(msg tea.Msg) (ChatPanelModel, tea.Cmd) {
 }
 
 func (m ChatPanelModel) updateInsertMode(msg tea.KeyMsg) (ChatPanelModel, tea.Cmd) {
+	if m.presets.open {
+		return m.updatePresetPicker(msg)
+	}
+	if m.completer.handles(msg) {
+		return m, updateTextInput(&m.textInput, &m.completer, msg)
+	}
 	switch {
+	case key.Matches(msg, ChatKeys.Presets):
+		if len(m.presets.forTab(m.activeTab)) > 0 {
+			m.presets.open = true
+			m.presets.cursor = 0
+		}
+		return m, nil
 	case key.Matches(msg, ChatKeys.ExitInsert):
 		m.chatMode = ChatModeNormal
 		m.textInput.Blur()
+		m.completer.reset()
 		return m, func() tea.Msg { return ModeChangedMsg{Mode: ChatModeNormal} }
 	case key.Matches(msg, ChatKeys.Send):
 		if m.textInput.Value() == "" {
@@ -348,6 +465,7 @@ func (m ChatPanelModel) updateInsertMode(msg tea.KeyMsg) (ChatPanelModel, tea.Cm
 		}
 		userMsg := m.textInput.Value()
 		m.textInput.Reset()
+		m.completer.reset()
 
 		if m.activeTab == ChatTabComments {
 			if !m.comments.IsPosting() {
@@ -368,23 +486,27 @@ func (m ChatPanelModel) updateInsertMode(msg tea.KeyMsg) (ChatPanelModel, tea.Cm
 		}
 		return m, nil
 	default:
-		var cmd tea.Cmd
-		m.textInput, cmd = m.textInput.Update(msg)
-		return m, cmd
+		return m, updateTextInput(&m.textInput, &m.completer, msg)
 	}
 }
 
 func (m ChatPanelModel) updateNormalMode(msg tea.KeyMsg) (ChatPanelModel, tea.Cmd) {
+	// A selected file link keeps Enter; the Analysis tab's keys get the rest.
+	if m.activeTab == ChatTabAnalysis && (msg.String() != "enter" || m.citeIdx < 0) {
+		if ok, cmd := m.updateAnalysisNav(msg); ok {
+			return m, cmd
+		}
+	}
 	switch {
 	case key.Matches(msg, ChatKeys.PrevTab):
 		if m.activeTab > ChatTabChat {
-			m.activeTab--
+			m.SetActiveTab(m.activeTab - 1)
 		}
 		m.refreshViewport()
 		return m, nil
 	case key.Matches(msg, ChatKeys.NextTab):
 		if m.activeTab < ChatTabReview {
-			m.activeTab++
+			m.SetActiveTab(m.activeTab + 1)
 		}
 		m.refreshViewport()
 		return m, nil
@@ -393,7 +515,19 @@ func (m ChatPanelModel) updateNormalMode(msg tea.KeyMsg) (ChatPanelModel, tea.Cm
 			return m, func() tea.Msg { return ChatClearMsg{} }
 		}
 		return m, nil
+	case key.Matches(msg, ChatKeys.NextCitation):
+		m.selectCitation(1)
+		return m, nil
+	case key.Matches(msg, ChatKeys.PrevCitation):
+		m.selectCitation(-1)
+		return m, nil
 	case msg.String() == "enter":
+		if m.citeIdx >= 0 && m.citeIdx < len(m.citations) {
+			c := m.citations[m.citeIdx]
+			m.citeIdx = -1
+			m.refreshViewport()
+			return m, func() tea.Msg { return CitationJumpMsg{Path: c.Path, Line: c.Line} }
+		}
 		if m.activeTab == ChatTabAnalysis {
 			return m, nil
 		}
@@ -447,14 +581,57 @@ func (m *ChatPanelModel) refreshViewport() {
 	switch m.activeTab {
 	case ChatTabAnalysis:
 		content = m.analysis.Render(w, sv)
+		if m.usageFooter != "" && m.analysis.result != nil {
+			content += "\n\n" + dimStyle.Render(wordWrap(m.usageFooter, w))
+		}
 	case ChatTabComments:
 		content = m.comments.Render(w, sv, &m.md)
 	default:
 		content = m.chat.Render(w, &m.md)
 	}
+	m.citations = nil
+	if m.activeTab != ChatTabReview {
+		content, m.citations = linkCitations(content, m.citeFiles, m.citeIdx)
+	}
+	if m.citeIdx >= len(m.citations) {
+		m.citeIdx = -1
+	}
 	m.viewport.SetContent(content)
 }
 
+// selectCitation moves the citation selection by delta, wrapping around,
+// and scrolls the selected citation into view.
+func (m *ChatPanelModel) selectCitation(delta int) {
+	n := len(m.citations)
+	if n == 0 {
+		return
+	}
+	switch {
+	case m.citeIdx < 0 && delta > 0:
+		m.citeIdx = 0
+	case m.citeIdx < 0:
+		m.citeIdx = n - 1
+	default:
+		m.citeIdx = (m.citeIdx + delta + n) % n
+	}
+	m.refreshViewport()
+	row := m.citations[m.citeIdx].Row
+	if row < m.viewport.YOffset || row >= m.viewport.YOffset+m.viewport.Height {
+		m.viewport.SetYOffset(max(0, row-3))
+	}
+}
+
+// citationHint describes the selected citation or how to select one.
+func (m ChatPanelModel) citationHint() string {
+	if m.citeIdx >= 0 && m.citeIdx < len(m.citations) {
+		return fmt.Sprintf("Enter to open %s (%d/%d)", m.citations[m.citeIdx].Text, m.citeIdx+1, len(m.citations))
+	}
+	if len(m.citations) > 0 {
+		return "n/N select file links"
+	}
+	return ""
+}
+
 // -- View --
 
 func (m ChatPanelModel) View() string {
@@ -469,7 +646,9 @@ func (m ChatPanelModel) View() string {
 	}
 
 	var content string
-	if m.ready {
+	if m.ready && m.presets.open {
+		content = m.renderPresetPicker(m.viewport.Width, m.viewport.Height)
+	} else if m.ready {
 		content = m.viewport.View()
 	} else {
 		content = "Loading..."
@@ -541,9 +720,13 @@ func (m ChatPanelModel) renderInputSeparator() string {
 	if w < 1 {
 		w = 1
 	}
-	sepColor := lipgloss.Color("238")
+	// The completion hint takes the separator's row while it's offered.
+	if m.chatMode == ChatModeInsert && m.completer.active() {
+		return m.completer.View(w)
+	}
+	sepColor := theme.Faint
 	if m.chatMode == ChatModeInsert {
-		sepColor = lipgloss.Color("42")
+		sepColor = theme.Success
 	}
 	return lipgloss.NewStyle().
 		Foreground(sepColor).
@@ -555,25 +738,32 @@ func (m ChatPanelModel) renderInput() string {
 		return ""
 	}
 	if m.activeTab == ChatTabAnalysis {
-		dimStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("240")).Italic(true)
-		return dimStyle.Render("> press 'a' to analyze")
+		dimStyle := lipgloss.NewStyle().Foreground(theme.Subtle).Italic(true)
+		hint := "> press 'a' to analyze"
+		if nav := m.analysisNavHint(); nav != "" {
+			hint += " · " + nav
+		}
+		if cite := m.citationHint(); cite != "" {
+			hint += " · " + cite
+		}
+		return dimStyle.Render(hint)
 	}
 
 	if m.chatMode == ChatModeInsert {
 		prefix := lipgloss.NewStyle().
-			Foreground(lipgloss.Color("42")).
+			Foreground(theme.Success).
 			Bold(true).
 			Render("> ")
 
 		if m.activeTab == ChatTabComments && m.comments.IsPosting() {
 			return prefix + lipgloss.NewStyle().
-				Foreground(lipgloss.Color("244")).
+				Foreground(theme.Muted).
 				Italic(true).
 				Render("posting comment...")
 		}
 		if m.activeTab == ChatTabChat && m.chat.IsWaiting() {
 			return prefix + lipgloss.NewStyle().
-				Foreground(lipgloss.Color("244")).
+				Foreground(theme.Muted).
 				Italic(true).
 				Render("waiting for response...")
 		}
@@ -581,14 +771,20 @@ func (m ChatPanelModel) renderInput() string {
 	}
 
 	prefix := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("245")).
+		Foreground(theme.Muted).
 		Render("> ")
 	hint := "Enter to chat"
 	if m.activeTab == ChatTabComments {
 		hint = "Enter to comment"
+	} else if cite := m.citationHint(); cite != "" {
+		if m.citeIdx >= 0 {
+			hint = cite
+		} else {
+			hint += " · " + cite
+		}
 	}
 	return prefix + lipgloss.NewStyle().
-		Foreground(lipgloss.Color("245")).
+		Foreground(theme.Muted).
 		Italic(true).
 		Render(hint)
 }
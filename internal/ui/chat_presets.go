@@ -0,0 +1,117 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/shhac/prtea/internal/config"
+	"github.com/shhac/prtea/internal/github"
+)
+
+// presetPicker tracks the prompt preset picker opened from the chat input.
+type presetPicker struct {
+	presets []config.ChatPreset
+	vars    map[string]string // placeholder values for the current PR
+	open    bool
+	cursor  int
+}
+
+// presetTab returns the config tab name for a chat panel tab.
+func presetTab(tab ChatTab) string {
+	if tab == ChatTabComments {
+		return "comments"
+	}
+	return "chat"
+}
+
+// forTab returns the presets that apply to the given chat panel tab.
+// Presets without a tab default to the Chat tab.
+func (p presetPicker) forTab(tab ChatTab) []config.ChatPreset {
+	want := presetTab(tab)
+	var out []config.ChatPreset
+	for _, preset := range p.presets {
+		t := strings.ToLower(strings.TrimSpace(preset.Tab))
+		if t == "" {
+			t = "chat"
+		}
+		if t == want {
+			out = append(out, preset)
+		}
+	}
+	return out
+}
+
+// presetVars builds the placeholder values for a PR. detail may be nil
+// while the PR detail is still loading.
+func presetVars(s *PRSession, detail *github.PRDetail) map[string]string {
+	vars := map[string]string{}
+	if s != nil {
+		vars["title"] = s.Title
+		vars["number"] = fmt.Sprintf("%d", s.Number)
+		vars["repo"] = s.Owner + "/" + s.Repo
+		vars["url"] = s.HTMLURL
+	}
+	if detail != nil {
+		if detail.Title != "" {
+			vars["title"] = detail.Title
+		}
+		vars["author"] = detail.Author.Login
+		vars["base"] = detail.BaseBranch
+		vars["head"] = detail.HeadBranch
+	}
+	return vars
+}
+
+// expandPreset substitutes {{name}} placeholders in a preset prompt.
+// Unknown placeholders are left untouched.
+func expandPreset(prompt string, vars map[string]string) string {
+	pairs := make([]string, 0, len(vars)*2)
+	for k, v := range vars {
+		pairs = append(pairs, "{{"+k+"}}", v)
+	}
+	return strings.NewReplacer(pairs...).Replace(prompt)
+}
+
+// updatePresetPicker handles keys while the preset picker is open.
+// Enter fills the chat input with the expanded prompt for review before sending.
+func (m ChatPanelModel) updatePresetPicker(msg tea.KeyMsg) (ChatPanelModel, tea.Cmd) {
+	presets := m.presets.forTab(m.activeTab)
+	switch msg.String() {
+	case "esc", "ctrl+t":
+		m.presets.open = false
+	case "up", "k", "ctrl+p":
+		if m.presets.cursor > 0 {
+			m.presets.cursor--
+		}
+	case "down", "j", "ctrl+n":
+		if m.presets.cursor < len(presets)-1 {
+			m.presets.cursor++
+		}
+	case "enter":
+		if m.presets.cursor < len(presets) {
+			m.textInput.SetValue(expandPreset(presets[m.presets.cursor].Prompt, m.presets.vars))
+			m.textInput.CursorEnd()
+		}
+		m.presets.open = false
+	}
+	return m, nil
+}
+
+// renderPresetPicker renders the preset list in place of the tab content.
+func (m ChatPanelModel) renderPresetPicker(width, height int) string {
+	presets := m.presets.forTab(m.activeTab)
+	lines := []string{boldStyle.Render("Prompt presets"), ""}
+	for i, p := range presets {
+		name := ansi.Truncate(p.Name, width-2, "…")
+		if i == m.presets.cursor {
+			lines = append(lines, diffCursorGutterStyle.Render("▸ ")+boldStyle.Render(name))
+		} else {
+			lines = append(lines, "  "+name)
+		}
+	}
+	lines = append(lines, "", dimStyle.Render("Enter: use · Esc: cancel"))
+	return lipgloss.NewStyle().Width(width).Height(height).MaxHeight(height).Render(strings.Join(lines, "\n"))
+}
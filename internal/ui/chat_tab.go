@@ -11,11 +11,13 @@ import (
 type chatMessage struct {
 	role    string // "user" or "assistant"
 	content string
+	tools   []string // repo tools Claude used for an answer, e.g. "Read cache.go"
 }
 
 // ChatTabModel manages the interactive chat tab state and rendering.
 type ChatTabModel struct {
 	messages   []chatMessage
+	tools      []string // tools used so far for the answer being waited on
 	isWaiting  bool
 	chatError  string
 	chatStream StreamRenderer
@@ -36,14 +38,22 @@ func (t ChatTabModel) IsWaiting() bool {
 // SetWaiting adds a user message and enters the waiting state.
 func (t *ChatTabModel) SetWaiting(msg string) {
 	t.messages = append(t.messages, chatMessage{role: "user", content: msg})
+	t.tools = nil
 	t.isWaiting = true
 	t.chatError = ""
 	t.cache = ""
 }
 
+// AddToolUse records a tool Claude used for the answer being waited on.
+func (t *ChatTabModel) AddToolUse(line string) {
+	t.tools = append(t.tools, line)
+	t.cache = ""
+}
+
 // AddResponse appends a Claude response and clears the waiting state.
 func (t *ChatTabModel) AddResponse(content string) {
-	t.messages = append(t.messages, chatMessage{role: "assistant", content: content})
+	t.messages = append(t.messages, chatMessage{role: "assistant", content: content, tools: t.tools})
+	t.tools = nil
 	t.isWaiting = false
 	t.chatError = ""
 	t.chatStream.Reset()
@@ -53,6 +63,7 @@ func (t *ChatTabModel) AddResponse(content string) {
 // SetChatError sets a chat error and clears the waiting state.
 func (t *ChatTabModel) SetChatError(err string) {
 	t.chatError = err
+	t.tools = nil
 	t.isWaiting = false
 	t.chatStream.Reset()
 	t.cache = ""
@@ -61,6 +72,7 @@ func (t *ChatTabModel) SetChatError(err string) {
 // ClearChat resets all chat state.
 func (t *ChatTabModel) ClearChat() {
 	t.messages = nil
+	t.tools = nil
 	t.isWaiting = false
 	t.chatError = ""
 	t.chatStream.Reset()
@@ -102,6 +114,7 @@ func (t *ChatTabModel) Render(width int, md *MarkdownRenderer) string {
 			b.WriteString(chatAssistantStyle.Render("Claude:"))
 		}
 		b.WriteString("\n")
+		b.WriteString(renderToolUses(msg.tools, width))
 		if msg.role == "assistant" {
 			b.WriteString(md.RenderMarkdown(msg.content, width))
 		} else {
@@ -113,13 +126,16 @@ func (t *ChatTabModel) Render(width int, md *MarkdownRenderer) string {
 		if len(t.messages) > 0 {
 			b.WriteString("\n\n")
 		}
-		if t.chatStream.HasContent() {
+		if t.chatStream.HasContent() || len(t.tools) > 0 {
 			b.WriteString(chatAssistantStyle.Render("Claude:"))
 			b.WriteString("\n")
+			b.WriteString(renderToolUses(t.tools, width))
+		}
+		if t.chatStream.HasContent() {
 			b.WriteString(t.chatStream.View(wordWrap, width))
 		} else {
 			b.WriteString(lipgloss.NewStyle().
-				Foreground(lipgloss.Color("244")).
+				Foreground(theme.Muted).
 				Italic(true).
 				Render("Claude is thinking..."))
 		}
@@ -130,7 +146,7 @@ func (t *ChatTabModel) Render(width int, md *MarkdownRenderer) string {
 			b.WriteString("\n\n")
 		}
 		b.WriteString(lipgloss.NewStyle().
-			Foreground(lipgloss.Color("196")).
+			Foreground(theme.Error).
 			Bold(true).
 			Render(formatUserError(t.chatError)))
 	}
@@ -142,3 +158,13 @@ func (t *ChatTabModel) Render(width int, md *MarkdownRenderer) string {
 	}
 	return result
 }
+
+// renderToolUses renders the tools used for an answer as dim lines.
+func renderToolUses(tools []string, width int) string {
+	var b strings.Builder
+	for _, line := range tools {
+		b.WriteString(dimStyle.Render(fitWidth("▸ "+line, width)))
+		b.WriteString("\n")
+	}
+	return b.String()
+}
@@ -1,9 +1,13 @@
 package ui
 
 import (
+	"strings"
 	"testing"
 
+	tea "github.com/charmbracelet/bubbletea"
 	"github.com/shhac/prtea/internal/claude"
+	"github.com/shhac/prtea/internal/config"
+	"github.com/shhac/prtea/internal/github"
 )
 
 func TestChatTab_SetWaiting(t *testing.T) {
@@ -175,3 +179,77 @@ func TestChatTab_CacheInvalidation(t *testing.T) {
 		t.Error("RestoreMessages should invalidate cache")
 	}
 }
+
+func TestExpandPreset(t *testing.T) {
+	vars := presetVars(
+		&PRSession{Owner: "acme", Repo: "api", Number: 42, Title: "Old title"},
+		&github.PRDetail{Title: "Add retries", Author: github.User{Login: "bob"}, BaseBranch: "main", HeadBranch: "retries"},
+	)
+	got := expandPreset("#{{number}} {{title}} in {{repo}} by {{author}} ({{head}} → {{base}}) {{unknown}}", vars)
+	want := "#42 Add retries in acme/api by bob (retries → main) {{unknown}}"
+	if got != want {
+		t.Errorf("expandPreset() = %q, want %q", got, want)
+	}
+}
+
+func TestPresetPicker_ForTab(t *testing.T) {
+	p := presetPicker{presets: []config.ChatPreset{
+		{Name: "a"},
+		{Name: "b", Tab: "comments"},
+		{Name: "c", Tab: "Chat"},
+	}}
+	if got := p.forTab(ChatTabChat); len(got) != 2 || got[0].Name != "a" || got[1].Name != "c" {
+		t.Errorf("forTab(chat) = %v", got)
+	}
+	if got := p.forTab(ChatTabComments); len(got) != 1 || got[0].Name != "b" {
+		t.Errorf("forTab(comments) = %v", got)
+	}
+}
+
+func TestChatPanel_PresetPickerFillsInput(t *testing.T) {
+	m := NewChatPanelModel()
+	m.SetSize(60, 30)
+	m.SetPresets([]config.ChatPreset{
+		{Name: "first", Prompt: "one"},
+		{Name: "second", Prompt: "summarize #{{number}}"},
+	})
+	m.SetPresetVars(map[string]string{"number": "7"})
+	m.chatMode = ChatModeInsert
+	m.textInput.Focus()
+
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyCtrlT})
+	if !m.presets.open {
+		t.Fatal("expected picker to open on ctrl+t")
+	}
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyDown})
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if m.presets.open {
+		t.Error("expected picker to close after selection")
+	}
+	if got := m.textInput.Value(); got != "summarize #7" {
+		t.Errorf("input = %q, want %q", got, "summarize #7")
+	}
+	if m.chat.IsWaiting() {
+		t.Error("selecting a preset should not send the message")
+	}
+}
+
+func TestChatTab_ToolUsesShownWithAnswer(t *testing.T) {
+	tab := &ChatTabModel{}
+	tab.SetWaiting("where else is parseConfig called?")
+	tab.AddToolUse(chatToolLine(claude.ProgressEvent{Tool: "Grep", Detail: `"parseConfig" in /src/app/internal`}, "/src/app"))
+	tab.AddToolUse(chatToolLine(claude.ProgressEvent{Tool: "Read", Detail: "/src/app/main.go"}, "/src/app/"))
+
+	md := &MarkdownRenderer{}
+	if out := tab.Render(80, md); !strings.Contains(out, `Grep "parseConfig" in internal`) || !strings.Contains(out, "thinking") {
+		t.Errorf("tool uses should show while waiting:\n%s", out)
+	}
+	tab.AddResponse("From main.go")
+	if len(tab.messages[1].tools) != 2 || tab.messages[1].tools[1] != "Read main.go" {
+		t.Errorf("tools = %q", tab.messages[1].tools)
+	}
+	tab.SetWaiting("thanks")
+	if len(tab.tools) != 0 {
+		t.Error("a new question starts with no tool uses")
+	}
+}
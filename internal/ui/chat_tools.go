@@ -0,0 +1,27 @@
+package ui
+
+import (
+	"path/filepath"
+	"strings"
+
+	"github.com/shhac/prtea/internal/claude"
+)
+
+// chatRepoPath returns the local clone chat may explore with repo tools
+// (chatRepoTools), or "" when chat answers from the diff alone.
+func (m App) chatRepoPath() string {
+	if m.appConfig == nil || !m.appConfig.ChatRepoTools || m.session == nil {
+		return ""
+	}
+	return m.appConfig.LocalClone(m.session.Owner, m.session.Repo)
+}
+
+// chatToolLine describes a tool use for the chat transcript, with paths
+// shown relative to the clone at dir.
+func chatToolLine(e claude.ProgressEvent, dir string) string {
+	detail := strings.ReplaceAll(e.Detail, filepath.Clean(dir)+string(filepath.Separator), "")
+	if detail == "" {
+		return e.Tool
+	}
+	return e.Tool + " " + detail
+}
@@ -0,0 +1,79 @@
+package ui
+
+import (
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/shhac/prtea/internal/config"
+)
+
+// ciLiveInterval is how often the selected PR's checks are polled while its
+// CI tab is open and checks are pending, independent of the PR list poll.
+const ciLiveInterval = 10 * time.Second
+
+// ciLiveTickCmd returns a command that fires after ciLiveInterval to poll the
+// selected PR's checks.
+func ciLiveTickCmd(number, seq int) tea.Cmd {
+	return tea.Tick(ciLiveInterval, func(time.Time) tea.Msg {
+		return ciLiveTickMsg{PRNumber: number, Seq: seq}
+	})
+}
+
+// wantsCILive reports whether the CI tab is showing pending checks.
+func (m DiffViewerModel) wantsCILive() bool {
+	return m.activeTab == TabCI && m.ciStatus != nil && m.ciStatus.OverallStatus == "pending"
+}
+
+// startCILive starts live CI updates, returning the sequence number to tag
+// poll ticks with, or false if they are already running.
+func (m *DiffViewerModel) startCILive() (int, bool) {
+	if m.ciLive {
+		return 0, false
+	}
+	m.ciLiveSeq++
+	m.ciLive = true
+	m.refreshContent()
+	return m.ciLiveSeq, true
+}
+
+// stopCILive stops live CI updates; ticks already scheduled are ignored.
+func (m *DiffViewerModel) stopCILive() {
+	if m.ciLive {
+		m.ciLive = false
+		m.refreshContent()
+	}
+}
+
+// CILiveActive reports whether the live updates tagged seq are still running.
+func (m DiffViewerModel) CILiveActive(seq int) bool {
+	return m.ciLive && m.ciLiveSeq == seq
+}
+
+// watchCI starts live CI updates when the CI tab shows pending checks.
+func (m *App) watchCI() tea.Cmd {
+	if m.session == nil || m.ghClient == nil || !m.diffViewer.wantsCILive() {
+		return nil
+	}
+	seq, ok := m.diffViewer.startCILive()
+	if !ok {
+		return nil
+	}
+	return tea.Batch(m.diffViewer.spinner.Tick, ciLiveTickCmd(m.session.Number, seq))
+}
+
+// ciStatusFlipped reports a change of the selected PR's overall CI status
+// seen while live updates run, in the status bar and as a notification.
+func (m *App) ciStatusFlipped(prev, cur string) tea.Cmd {
+	if prev == cur || cur == "pending" || cur == "" {
+		return nil
+	}
+	s := m.session
+	msg := fmt.Sprintf("CI %s on PR #%d", cur, s.Number)
+	cmds := []tea.Cmd{m.statusBar.SetTemporaryMessage(msg, 5*time.Second)}
+	if m.notifyEnabled && m.appConfig != nil && m.appConfig.NotifyTriggerEnabled(config.NotifyCIFinished) {
+		note := notification{title: "prtea: CI " + cur, body: fmt.Sprintf("#%d %s in %s", s.Number, s.Title, s.Repo)}
+		cmds = append(cmds, sendNotificationsCmd([]notification{note}, m.appConfig.NotificationThreshold))
+	}
+	return tea.Batch(cmds...)
+}
@@ -0,0 +1,89 @@
+package ui
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/shhac/prtea/internal/github"
+)
+
+func pendingStatus() *github.CIStatus {
+	return &github.CIStatus{
+		TotalCount:    2,
+		OverallStatus: "pending",
+		Checks: []github.CICheck{
+			{Name: "lint", Status: "completed", Conclusion: "success"},
+			{Name: "test", Status: "in_progress"},
+		},
+	}
+}
+
+func newCILiveApp() App {
+	m := App{
+		statusBar:  NewStatusBarModel(),
+		prList:     NewPRListModel(TabMyPRs),
+		diffViewer: newTestDiffViewer(80, 24),
+		session:    &PRSession{Owner: "shhac", Repo: "prtea", Number: 7, Title: "Retry flaky jobs"},
+		ghClient:   github.NewTestClient("alice", func(ctx context.Context, args ...string) (string, error) { return "", nil }),
+	}
+	m.diffViewer.prNumber = 7
+	return m
+}
+
+func TestCILive_StartsOnlyOnPendingCITab(t *testing.T) {
+	m := newCILiveApp()
+	m.diffViewer.SetCIStatus(pendingStatus())
+	if cmd := m.watchCI(); cmd != nil || m.diffViewer.ciLive {
+		t.Fatal("live updates should wait for the CI tab")
+	}
+	m.diffViewer.activeTab = TabCI
+	if cmd := m.watchCI(); cmd == nil || !m.diffViewer.ciLive {
+		t.Fatal("pending checks on the CI tab should start live updates")
+	}
+	if cmd := m.watchCI(); cmd != nil {
+		t.Error("a second start should not schedule another poll loop")
+	}
+	if !strings.Contains(m.diffViewer.renderCITab(), "(in_progress)") {
+		t.Error("in-progress checks should still show their status")
+	}
+}
+
+func TestCILive_TickStopsOffCITab(t *testing.T) {
+	m := newCILiveApp()
+	m.diffViewer.SetCIStatus(pendingStatus())
+	m.diffViewer.activeTab = TabCI
+	m.watchCI()
+	seq := m.diffViewer.ciLiveSeq
+
+	m.diffViewer.activeTab = TabDiff
+	model, cmd := m.Update(ciLiveTickMsg{PRNumber: 7, Seq: seq})
+	m = model.(App)
+	if cmd != nil || m.diffViewer.ciLive {
+		t.Error("leaving the CI tab should stop live updates")
+	}
+}
+
+func TestCILive_FlipAnnouncesAndStops(t *testing.T) {
+	m := newCILiveApp()
+	m.diffViewer.SetCIStatus(pendingStatus())
+	m.diffViewer.activeTab = TabCI
+	m.watchCI()
+
+	done := &github.CIStatus{
+		TotalCount:    2,
+		OverallStatus: "failing",
+		Checks: []github.CICheck{
+			{Name: "lint", Status: "completed", Conclusion: "success"},
+			{Name: "test", Status: "completed", Conclusion: "failure"},
+		},
+	}
+	model, _ := m.Update(CIStatusLoadedMsg{PRNumber: 7, Status: done})
+	m = model.(App)
+	if m.diffViewer.ciLive {
+		t.Error("a finished run should stop live updates")
+	}
+	if !strings.Contains(m.statusBar.statusMessage, "CI failing on PR #7") {
+		t.Errorf("status = %q", m.statusBar.statusMessage)
+	}
+}
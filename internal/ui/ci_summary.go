@@ -0,0 +1,160 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/shhac/prtea/internal/github"
+)
+
+const (
+	ciSummaryMaxLogs      = 5   // failing checks whose logs are fetched for excerpts
+	ciSummaryExcerptLines = 4   // log lines quoted per check, starting at the first error
+	ciSummaryLineWidth    = 200 // excerpt lines are cut to this many characters
+	ciLogTailLines        = 40  // log lines sent per failing check with analysis (analysisCiLogs)
+)
+
+// failingChecks returns the failing checks of the loaded CI status.
+func (m App) failingChecks() []github.CICheck {
+	if m.diffViewer.ciStatus == nil {
+		return nil
+	}
+	failing, _, _ := ciCheckGroups(m.diffViewer.ciStatus.Checks)
+	return failing
+}
+
+// analysisCIChecks returns the failing checks whose log tails go with
+// analysis and AI review, which is none unless analysisCiLogs is on.
+func (m App) analysisCIChecks() []github.CICheck {
+	if m.appConfig == nil || !m.appConfig.AnalysisCILogs {
+		return nil
+	}
+	return m.failingChecks()
+}
+
+// ciSummaryCmd fetches log excerpts for failing checks (where they are
+// GitHub Actions jobs) and formats the review body summary.
+func ciSummaryCmd(client GitHubService, owner, repo string, number int, failing []github.CICheck) tea.Cmd {
+	return func() tea.Msg {
+		excerpts := fetchCIExcerpts(context.Background(), client, owner, repo, failing, ciLogExcerpt)
+		return CISummaryReadyMsg{PRNumber: number, Body: formatCIFailureSummary(failing, excerpts)}
+	}
+}
+
+// fetchCIExcerpts fetches the logs of up to ciSummaryMaxLogs failing
+// checks and returns the lines excerpt picks from each, parallel to failing.
+func fetchCIExcerpts(ctx context.Context, client GitHubService, owner, repo string, failing []github.CICheck, excerpt func(raw string) []string) [][]string {
+	excerpts := make([][]string, len(failing))
+	fetched := 0
+	for i, check := range failing {
+		if check.JobID == 0 || client == nil || fetched >= ciSummaryMaxLogs {
+			continue
+		}
+		fetched++
+		raw, err := client.GetJobLogs(ctx, owner, repo, check.JobID)
+		if err != nil {
+			continue // best-effort: the check is still listed without an excerpt
+		}
+		excerpts[i] = excerpt(raw)
+	}
+	return excerpts
+}
+
+// ciLogTails fetches the end of each failing check's log for an analysis
+// or review prompt. It returns the logs as text and the checks included.
+func ciLogTails(ctx context.Context, client GitHubService, owner, repo string, failing []github.CICheck) (string, []string) {
+	tails := fetchCIExcerpts(ctx, client, owner, repo, failing, ciLogTail)
+	var b strings.Builder
+	checks := make([]string, 0, len(failing))
+	for i, check := range failing {
+		checks = append(checks, check.Name)
+		fmt.Fprintf(&b, "### %s\n", check.Name)
+		if len(tails[i]) == 0 {
+			b.WriteString("(log not available)\n\n")
+			continue
+		}
+		b.WriteString(strings.Join(tails[i], "\n"))
+		b.WriteString("\n\n")
+	}
+	return b.String(), checks
+}
+
+// ciLogTail returns the last non-empty lines of a job log, where the
+// failure and the runner's summary of it usually are.
+func ciLogTail(raw string) []string {
+	var out []string
+	for _, l := range parseJobLog(raw) {
+		l = strings.TrimRight(ansi.Strip(l), " \t")
+		if strings.TrimSpace(l) == "" {
+			continue
+		}
+		if len(l) > ciSummaryLineWidth {
+			l = l[:ciSummaryLineWidth] + "…"
+		}
+		out = append(out, l)
+	}
+	return out[max(0, len(out)-ciLogTailLines):]
+}
+
+// ciLogExcerpt returns the key error lines from a job log: a few non-empty
+// lines starting at the first line that looks like an error.
+func ciLogExcerpt(raw string) []string {
+	lines := parseJobLog(raw)
+	plain := make([]string, len(lines))
+	for i, l := range lines {
+		plain[i] = ansi.Strip(l)
+	}
+	start := firstErrorLine(plain)
+	if start < 0 {
+		return nil
+	}
+	var out []string
+	for _, l := range plain[start:] {
+		l = strings.TrimSpace(strings.TrimPrefix(l, "✗ "))
+		if l == "" {
+			continue
+		}
+		if len(l) > ciSummaryLineWidth {
+			l = l[:ciSummaryLineWidth] + "…"
+		}
+		out = append(out, l)
+		if len(out) == ciSummaryExcerptLines {
+			break
+		}
+	}
+	return out
+}
+
+// formatCIFailureSummary renders failing checks as a markdown section for a
+// review body. excerpts is parallel to failing; nil entries are skipped.
+func formatCIFailureSummary(failing []github.CICheck, excerpts [][]string) string {
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf("**CI is failing** (%d %s):\n", len(failing), pluralChecks(len(failing))))
+	for i, check := range failing {
+		b.WriteString("\n- ")
+		if check.HTMLURL != "" {
+			b.WriteString(fmt.Sprintf("[`%s`](%s)", check.Name, check.HTMLURL))
+		} else {
+			b.WriteString(fmt.Sprintf("`%s`", check.Name))
+		}
+		b.WriteString("\n")
+		if i < len(excerpts) && len(excerpts[i]) > 0 {
+			b.WriteString("  ```\n")
+			for _, l := range excerpts[i] {
+				b.WriteString("  " + l + "\n")
+			}
+			b.WriteString("  ```\n")
+		}
+	}
+	return b.String()
+}
+
+func pluralChecks(n int) string {
+	if n == 1 {
+		return "check"
+	}
+	return "checks"
+}
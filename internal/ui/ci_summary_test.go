@@ -0,0 +1,89 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/shhac/prtea/internal/github"
+)
+
+func TestCILogExcerpt(t *testing.T) {
+	raw := "2024-01-01T00:00:00.0000000Z ##[group]Run go test\n" +
+		"2024-01-01T00:00:01.0000000Z ok  pkg/a\n" +
+		"2024-01-01T00:00:02.0000000Z --- FAIL: TestThing (0.00s)\n" +
+		"2024-01-01T00:00:02.0000000Z     thing_test.go:12: got 1, want 2\n" +
+		"2024-01-01T00:00:02.0000000Z \n" +
+		"2024-01-01T00:00:03.0000000Z FAIL\tpkg/b\n" +
+		"2024-01-01T00:00:04.0000000Z ##[error]Process completed with exit code 1.\n"
+
+	got := ciLogExcerpt(raw)
+	want := []string{
+		"--- FAIL: TestThing (0.00s)",
+		"thing_test.go:12: got 1, want 2",
+		"FAIL\tpkg/b",
+		"Process completed with exit code 1.",
+	}
+	if len(got) != len(want) {
+		t.Fatalf("excerpt = %q, want %q", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("line %d = %q, want %q", i, got[i], want[i])
+		}
+	}
+
+	if got := ciLogExcerpt("all good\n"); got != nil {
+		t.Errorf("expected no excerpt for a clean log, got %q", got)
+	}
+}
+
+func TestFormatCIFailureSummary(t *testing.T) {
+	failing := []github.CICheck{
+		{Name: "test", HTMLURL: "https://example.com/run/1"},
+		{Name: "external-ci"},
+	}
+	body := formatCIFailureSummary(failing, [][]string{{"--- FAIL: TestThing"}, nil})
+
+	for _, want := range []string{
+		"**CI is failing** (2 checks)",
+		"- [`test`](https://example.com/run/1)",
+		"  --- FAIL: TestThing",
+		"- `external-ci`",
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("summary missing %q:\n%s", want, body)
+		}
+	}
+	if strings.Count(body, "```") != 2 {
+		t.Errorf("expected one fenced excerpt, got:\n%s", body)
+	}
+}
+
+func TestReviewTab_AppendToBody(t *testing.T) {
+	r := NewReviewTabModel()
+	r.textArea.SetValue("Needs work.")
+	r.AppendToBody("**CI is failing**")
+	if got := r.textArea.Value(); got != "Needs work.\n\n**CI is failing**" {
+		t.Errorf("body = %q", got)
+	}
+	if r.action != ReviewRequestChanges {
+		t.Errorf("action = %v, want request changes", r.action)
+	}
+}
+
+func TestCILogTail(t *testing.T) {
+	var raw strings.Builder
+	for i := 1; i <= ciLogTailLines+10; i++ {
+		fmt.Fprintf(&raw, "2024-01-01T00:00:00.0000000Z line %d\n\n", i)
+	}
+	raw.WriteString("2024-01-01T00:00:04.0000000Z ##[error]Process completed with exit code 1.\n")
+
+	got := ciLogTail(raw.String())
+	if len(got) != ciLogTailLines {
+		t.Fatalf("got %d lines, want %d", len(got), ciLogTailLines)
+	}
+	if got[0] != "line 12" || got[len(got)-1] != "✗ Process completed with exit code 1." {
+		t.Errorf("tail = %q ... %q", got[0], got[len(got)-1])
+	}
+}
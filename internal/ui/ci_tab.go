@@ -8,9 +8,127 @@ import (
 	"github.com/shhac/prtea/internal/github"
 )
 
+// ciWatch tracks a single re-run check that is polled until it completes.
+type ciWatch struct {
+	name     string
+	oldJobID int64 // job ID before the re-run; the new attempt gets a new ID
+	seq      int
+}
+
+// rerunAttempt returns the watched check's new attempt in status, if it has started.
+func (w *ciWatch) rerunAttempt(status *github.CIStatus) (github.CICheck, bool) {
+	if w == nil || status == nil {
+		return github.CICheck{}, false
+	}
+	for _, c := range status.Checks {
+		if c.Name == w.name && c.JobID != w.oldJobID {
+			return c, true
+		}
+	}
+	return github.CICheck{}, false
+}
+
+// overlay shows the watched check as queued until its new attempt appears,
+// since the rollup keeps reporting the old result for a few seconds.
+func (w *ciWatch) overlay(status *github.CIStatus) *github.CIStatus {
+	if w == nil || status == nil {
+		return status
+	}
+	if _, ok := w.rerunAttempt(status); ok {
+		return status
+	}
+	out := *status
+	out.Checks = make([]github.CICheck, len(status.Checks))
+	copy(out.Checks, status.Checks)
+	for i, c := range out.Checks {
+		if c.Name == w.name {
+			out.Checks[i].Status = "queued"
+			out.Checks[i].Conclusion = ""
+			out.OverallStatus = "pending"
+		}
+	}
+	return &out
+}
+
+// WatchCICheck starts watching a re-run check and returns the watch sequence
+// number to tag poll ticks with.
+func (m *DiffViewerModel) WatchCICheck(check github.CICheck) int {
+	m.ciWatchSeq++
+	m.ciWatch = &ciWatch{name: check.Name, oldJobID: check.JobID, seq: m.ciWatchSeq}
+	m.SetCIStatus(m.ciStatus)
+	return m.ciWatchSeq
+}
+
+// CIWatchActive reports whether the watch tagged seq is still running.
+func (m DiffViewerModel) CIWatchActive(seq int) bool {
+	return m.ciWatch != nil && m.ciWatch.seq == seq
+}
+
+// CIWatchFinished returns the watched check's completed re-run attempt in
+// status and stops the watch, or false if it is still running.
+func (m *DiffViewerModel) CIWatchFinished(status *github.CIStatus) (github.CICheck, bool) {
+	check, ok := m.ciWatch.rerunAttempt(status)
+	if !ok || check.Status != "completed" {
+		return github.CICheck{}, false
+	}
+	m.ciWatch = nil
+	return check, true
+}
+
 // SetCIStatus sets CI check status data for the CI tab.
 func (m *DiffViewerModel) SetCIStatus(status *github.CIStatus) {
-	m.ciStatus = status
+	m.ciStatus = m.ciWatch.overlay(status)
+	m.prInfoCache = "" // merge readiness gates depend on CI
+	if n := len(m.ciOrderedChecks()); m.ciCursor >= n {
+		m.ciCursor = max(0, n-1)
+	}
+	m.refreshContent()
+}
+
+// ciCheckGroups splits checks into failing, in-progress, and passing/skipped
+// groups, in the order they are displayed on the CI tab.
+func ciCheckGroups(checks []github.CICheck) (failing, pending, passing []github.CICheck) {
+	for _, check := range checks {
+		switch {
+		case check.Status == "completed" && check.Conclusion == "failure":
+			failing = append(failing, check)
+		case check.Status == "queued" || check.Status == "in_progress":
+			pending = append(pending, check)
+		default:
+			passing = append(passing, check)
+		}
+	}
+	return failing, pending, passing
+}
+
+// ciOrderedChecks returns checks in CI tab display order (failures first).
+func (m DiffViewerModel) ciOrderedChecks() []github.CICheck {
+	if m.ciStatus == nil {
+		return nil
+	}
+	failing, pending, passing := ciCheckGroups(m.ciStatus.Checks)
+	ordered := make([]github.CICheck, 0, len(m.ciStatus.Checks))
+	ordered = append(ordered, failing...)
+	ordered = append(ordered, pending...)
+	return append(ordered, passing...)
+}
+
+// SelectedCICheck returns the check under the CI tab cursor.
+func (m DiffViewerModel) SelectedCICheck() (github.CICheck, bool) {
+	checks := m.ciOrderedChecks()
+	if m.ciCursor < 0 || m.ciCursor >= len(checks) {
+		return github.CICheck{}, false
+	}
+	return checks[m.ciCursor], true
+}
+
+// moveCICursor moves the CI tab check cursor by delta, clamped to the list.
+func (m *DiffViewerModel) moveCICursor(delta int) {
+	n := len(m.ciOrderedChecks())
+	if n == 0 {
+		return
+	}
+	m.ciCursor = max(0, min(n-1, m.ciCursor+delta))
 	m.refreshContent()
 }
 
@@ -29,6 +147,9 @@ func (m DiffViewerModel) ciTabLabel() string {
 		return "CI"
 	}
 	icon, _ := ciStatusIconColor(m.ciStatus.OverallStatus)
+	if m.ciWatch != nil || m.ciLive {
+		icon = m.spinner.View()
+	}
 	passCount := ciPassingCount(m.ciStatus.Checks)
 	switch m.ciStatus.OverallStatus {
 	case "passing":
@@ -63,13 +184,13 @@ func (m DiffViewerModel) renderCITab() string {
 
 	if m.ciStatus == nil {
 		return lipgloss.NewStyle().
-			Foreground(lipgloss.Color("244")).
+			Foreground(theme.Muted).
 			Padding(1, 2).
 			Render(m.spinner.View() + fmt.Sprintf(" Loading CI status for PR #%d...", m.prNumber))
 	}
 
-	sectionStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("33"))
-	dimStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("244"))
+	sectionStyle := lipgloss.NewStyle().Bold(true).Foreground(theme.Info)
+	dimStyle := lipgloss.NewStyle().Foreground(theme.Muted)
 
 	var b strings.Builder
 
@@ -84,7 +205,7 @@ func (m DiffViewerModel) renderCITab() string {
 
 	// Summary badge
 	icon, color := ciStatusIconColor(m.ciStatus.OverallStatus)
-	badge := lipgloss.NewStyle().Foreground(lipgloss.Color(color)).Render(icon)
+	badge := lipgloss.NewStyle().Foreground(color).Render(icon)
 	passCount := ciPassingCount(m.ciStatus.Checks)
 	label := ciStatusLabel(m.ciStatus.OverallStatus)
 	b.WriteString(fmt.Sprintf("%s %s — %d/%d checks passing\n\n", badge, label, passCount, m.ciStatus.TotalCount))
@@ -94,17 +215,7 @@ func (m DiffViewerModel) renderCITab() string {
 		title  string
 		checks []github.CICheck
 	}
-	var failing, pending, passing []github.CICheck
-	for _, check := range m.ciStatus.Checks {
-		switch {
-		case check.Status == "completed" && check.Conclusion == "failure":
-			failing = append(failing, check)
-		case check.Status == "queued" || check.Status == "in_progress":
-			pending = append(pending, check)
-		default:
-			passing = append(passing, check)
-		}
-	}
+	failing, pending, passing := ciCheckGroups(m.ciStatus.Checks)
 
 	groups := []checkGroup{
 		{"Failing", failing},
@@ -120,6 +231,7 @@ func (m DiffViewerModel) renderCITab() string {
 		}
 	}
 
+	idx := 0 // position in display order, for the check cursor
 	for _, group := range groups {
 		if len(group.checks) == 0 {
 			continue
@@ -130,41 +242,64 @@ func (m DiffViewerModel) renderCITab() string {
 		}
 		for _, check := range group.checks {
 			ci, cc := ciCheckIconColor(check)
-			checkIcon := lipgloss.NewStyle().Foreground(lipgloss.Color(cc)).Render(ci)
+			checkIcon := lipgloss.NewStyle().Foreground(cc).Render(ci)
 			conclusion := ""
-			if check.Status == "completed" && check.Conclusion != "" {
+			if m.ciWatch != nil && check.Name == m.ciWatch.name && check.Status != "completed" {
+				checkIcon = m.spinner.View()
+				conclusion = dimStyle.Render(" (re-running)")
+			} else if m.ciLive && check.Status != "completed" {
+				checkIcon = m.spinner.View()
+				conclusion = dimStyle.Render(fmt.Sprintf(" (%s)", check.Status))
+			} else if check.Status == "completed" && check.Conclusion != "" {
 				conclusion = dimStyle.Render(fmt.Sprintf(" (%s)", check.Conclusion))
 			} else if check.Status != "completed" {
 				conclusion = dimStyle.Render(fmt.Sprintf(" (%s)", check.Status))
 			}
-			b.WriteString(fmt.Sprintf("  %s %s%s\n", checkIcon, check.Name, conclusion))
+			marker := "  "
+			if idx == m.ciCursor {
+				marker = diffCursorGutterStyle.Render("▸") + " "
+			}
+			b.WriteString(fmt.Sprintf("%s%s %s%s\n", marker, checkIcon, check.Name, conclusion))
+			idx++
 		}
 		b.WriteString("\n")
 	}
 
-	// Show re-run hint when there are failed checks with rerunnable workflows
+	// Show action hints: logs for the selected check, re-run for failures
+	hintStyle := lipgloss.NewStyle().Foreground(theme.Muted).Italic(true)
+	if check, ok := m.SelectedCICheck(); ok && check.JobID > 0 {
+		hint := "Press L to view logs for " + check.Name
+		if check.Status == "completed" && m.ciWatch == nil {
+			hint += ", X to re-run it"
+		}
+		b.WriteString(hintStyle.Render(hint))
+		b.WriteString("\n")
+	}
 	if failedIDs := m.ciStatus.FailedRunIDs(); len(failedIDs) > 0 {
-		hintStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("244")).Italic(true)
 		b.WriteString(hintStyle.Render("Press x to re-run failed checks"))
 		b.WriteString("\n")
 	}
+	if failing, _, _ := ciCheckGroups(m.ciStatus.Checks); len(failing) > 0 {
+		b.WriteString(hintStyle.Render(":ci summary adds the failures to your review"))
+		b.WriteString("\n")
+	}
 
 	return b.String()
 }
 
 // ciStatusIconColor returns the icon and lipgloss color for an overall CI status.
-func ciStatusIconColor(status string) (string, string) {
+func ciStatusIconColor(status string) (string, lipgloss.Color) {
 	switch status {
 	case "passing":
-		return "✓", "42"
+		return "✓", theme.Success
 	case "failing":
-		return "✗", "196"
+		return "✗", theme.Error
 	case "pending":
-		return "●", "226"
+		return "●", theme.Highlight
 	case "mixed":
-		return "⚠", "208"
+		return "⚠", theme.Warning
 	default:
-		return "?", "244"
+		return "?", theme.Muted
 	}
 }
 
@@ -185,18 +320,18 @@ func ciStatusLabel(status string) string {
 }
 
 // ciCheckIconColor returns the icon and color for an individual CI check.
-func ciCheckIconColor(check github.CICheck) (string, string) {
+func ciCheckIconColor(check github.CICheck) (string, lipgloss.Color) {
 	switch {
 	case check.Status == "completed" && check.Conclusion == "success":
-		return "✓", "42"
+		return "✓", theme.Success
 	case check.Status == "completed" && (check.Conclusion == "skipped" || check.Conclusion == "neutral"):
-		return "−", "244"
+		return "−", theme.Muted
 	case check.Status == "completed" && check.Conclusion == "failure":
-		return "✗", "196"
+		return "✗", theme.Error
 	case check.Status == "queued" || check.Status == "in_progress":
-		return "●", "226"
+		return "●", theme.Highlight
 	default:
-		return "?", "244"
+		return "?", theme.Muted
 	}
 }
 
@@ -0,0 +1,137 @@
+package ui
+
+import (
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/x/ansi"
+	"github.com/shhac/prtea/internal/github"
+)
+
+// citation is a file:line reference in chat or analysis output that
+// resolves to a file in the PR, so it can be opened in the diff.
+type citation struct {
+	Text string // as written, e.g. "ui/app.go:42"
+	Path string // the PR file it resolves to
+	Line int    // new-side line number
+	Row  int    // content line of its first occurrence
+}
+
+// citationPattern matches "path/to/file.ext:123". Ranges such as ":12-20"
+// cite their first line.
+var citationPattern = regexp.MustCompile(`[\w./-]*\w\.\w+:\d+`)
+
+// SGR toggles that leave the surrounding colors alone.
+const (
+	citationOn       = "\x1b[4m"
+	citationOff      = "\x1b[24m"
+	citationSelected = "\x1b[7m"
+	citationSelOff   = "\x1b[27m"
+)
+
+// citationFiles lists the filenames of a diff for citation matching.
+func citationFiles(files []github.PRFile) []string {
+	names := make([]string, len(files))
+	for i, f := range files {
+		names[i] = f.Filename
+	}
+	return names
+}
+
+// resolveCitationPath finds the PR file a cited path refers to: an exact
+// match, or the only file ending in it (models often drop leading
+// directories). Returns "" when there is no single match.
+func resolveCitationPath(path string, files []string) string {
+	path = strings.TrimPrefix(path, "./")
+	if p, ok := strings.CutPrefix(path, "a/"); ok {
+		path = p
+	} else if p, ok := strings.CutPrefix(path, "b/"); ok {
+		path = p
+	}
+	match := ""
+	for _, f := range files {
+		if f == path {
+			return f
+		}
+		if strings.HasSuffix(f, "/"+path) {
+			if match != "" {
+				return ""
+			}
+			match = f
+		}
+	}
+	return match
+}
+
+// citationSpan is one occurrence of a citation in rendered content, in
+// display cells.
+type citationSpan struct {
+	start, end int
+	idx        int // index into the citation list
+}
+
+// linkCitations finds the citations in rendered content, underlines each
+// occurrence and highlights those of the selected one (-1 for none).
+// Citations are listed in order of first appearance.
+func linkCitations(content string, files []string, selected int) (string, []citation) {
+	if len(files) == 0 || content == "" {
+		return content, nil
+	}
+	var cites []citation
+	byText := make(map[string]int)
+	lines := strings.Split(content, "\n")
+	for row, line := range lines {
+		plain := ansi.Strip(line)
+		locs := citationPattern.FindAllStringIndex(plain, -1)
+		if len(locs) == 0 {
+			continue
+		}
+		var spans []citationSpan
+		for _, loc := range locs {
+			text := plain[loc[0]:loc[1]]
+			idx, ok := byText[text]
+			if !ok {
+				colon := strings.LastIndexByte(text, ':')
+				path := resolveCitationPath(text[:colon], files)
+				if path == "" {
+					continue
+				}
+				n, _ := strconv.Atoi(text[colon+1:])
+				idx = len(cites)
+				byText[text] = idx
+				cites = append(cites, citation{Text: text, Path: path, Line: n, Row: row})
+			}
+			spans = append(spans, citationSpan{
+				start: ansi.StringWidth(plain[:loc[0]]),
+				end:   ansi.StringWidth(plain[:loc[1]]),
+				idx:   idx,
+			})
+		}
+		lines[row] = decorateCitationSpans(line, spans, selected)
+	}
+	return strings.Join(lines, "\n"), cites
+}
+
+// decorateCitationSpans wraps each span of line in the link style.
+func decorateCitationSpans(line string, spans []citationSpan, selected int) string {
+	if len(spans) == 0 {
+		return line
+	}
+	sort.Slice(spans, func(i, j int) bool { return spans[i].start < spans[j].start })
+	width := ansi.StringWidth(line)
+	var b strings.Builder
+	pos := 0
+	for _, s := range spans {
+		b.WriteString(ansi.Cut(line, pos, s.start))
+		on, off := citationOn, citationOff
+		if s.idx == selected {
+			on, off = citationSelected, citationSelOff
+		}
+		b.WriteString(on + ansi.Cut(line, s.start, s.end) + off)
+		pos = s.end
+	}
+	b.WriteString(ansi.Cut(line, pos, width))
+	return b.String()
+}
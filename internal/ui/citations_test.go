@@ -0,0 +1,74 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+)
+
+func TestResolveCitationPath(t *testing.T) {
+	files := []string{"internal/ui/app.go", "internal/claude/app.go", "cmd/prtea/main.go"}
+	tests := map[string]string{
+		"internal/ui/app.go":   "internal/ui/app.go",
+		"./cmd/prtea/main.go":  "cmd/prtea/main.go",
+		"b/internal/ui/app.go": "internal/ui/app.go",
+		"ui/app.go":            "internal/ui/app.go",
+		"main.go":              "cmd/prtea/main.go",
+		"app.go":               "", // ambiguous
+		"other.go":             "",
+	}
+	for in, want := range tests {
+		if got := resolveCitationPath(in, files); got != want {
+			t.Errorf("resolveCitationPath(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestLinkCitations(t *testing.T) {
+	files := []string{"internal/ui/app.go", "README.md"}
+	content := "See \x1b[1mui/app.go:42\x1b[0m and notes.txt:3.\nAlso README.md:7 and ui/app.go:42 again."
+
+	out, cites := linkCitations(content, files, 1)
+	if len(cites) != 2 {
+		t.Fatalf("citations = %+v, want 2 (unknown files skipped, repeats merged)", cites)
+	}
+	if c := cites[0]; c.Path != "internal/ui/app.go" || c.Line != 42 || c.Row != 0 {
+		t.Errorf("first citation = %+v", c)
+	}
+	if c := cites[1]; c.Path != "README.md" || c.Line != 7 || c.Row != 1 {
+		t.Errorf("second citation = %+v", c)
+	}
+	if ansi.Strip(out) != ansi.Strip(content) {
+		t.Errorf("linking changed the text: %q", ansi.Strip(out))
+	}
+	if !strings.Contains(out, citationSelected+"README.md:7") {
+		t.Errorf("selected citation not highlighted: %q", out)
+	}
+	if strings.Count(out, citationOn) != 2 {
+		t.Errorf("both occurrences of the unselected citation should be underlined: %q", out)
+	}
+}
+
+func TestChatPanel_OpenCitation(t *testing.T) {
+	m := NewChatPanelModel()
+	m.SetSize(60, 30)
+	m.AddResponse("The bug is in main.go:12.")
+	m.SetCitationFiles([]string{"cmd/prtea/main.go"})
+
+	if len(m.citations) != 1 || !strings.Contains(m.renderInput(), "n/N") {
+		t.Fatalf("citations = %+v, hint = %q", m.citations, m.renderInput())
+	}
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("n")})
+	if m.citeIdx != 0 {
+		t.Fatalf("citeIdx = %d, want 0", m.citeIdx)
+	}
+	m, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if jump, ok := cmd().(CitationJumpMsg); !ok || jump.Path != "cmd/prtea/main.go" || jump.Line != 12 {
+		t.Errorf("Enter = %#v, want a jump to cmd/prtea/main.go:12", jump)
+	}
+	if m.citeIdx != -1 || m.chatMode != ChatModeNormal {
+		t.Error("opening a citation should clear the selection and stay in normal mode")
+	}
+}
@@ -0,0 +1,64 @@
+package ui
+
+import (
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/muesli/termenv"
+)
+
+// copyToClipboard copies text to the system clipboard: with an OSC 52
+// escape sequence, which most terminals honour, including over SSH, and
+// with the platform clipboard tool when one is installed.
+func (m *App) copyToClipboard(text, what string) tea.Cmd {
+	return tea.Batch(
+		func() tea.Msg {
+			termenv.Copy(text)
+			writePlatformClipboard(text)
+			return nil
+		},
+		m.statusBar.SetTemporaryMessage("Copied "+what+" to clipboard", 2*time.Second),
+	)
+}
+
+// platformClipboardCommand returns the command that writes stdin to the
+// system clipboard, or nil when none is available.
+func platformClipboardCommand() []string {
+	var candidates [][]string
+	switch runtime.GOOS {
+	case "darwin":
+		candidates = [][]string{{"pbcopy"}}
+	case "windows":
+		candidates = [][]string{{"clip.exe"}}
+	default: // linux, freebsd, etc.
+		if os.Getenv("WAYLAND_DISPLAY") != "" {
+			candidates = append(candidates, []string{"wl-copy"})
+		}
+		candidates = append(candidates,
+			[]string{"xclip", "-selection", "clipboard"},
+			[]string{"xsel", "--clipboard", "--input"},
+		)
+	}
+	for _, c := range candidates {
+		if _, err := exec.LookPath(c[0]); err == nil {
+			return c
+		}
+	}
+	return nil
+}
+
+// writePlatformClipboard pipes text into the platform clipboard tool.
+// Failures are ignored: OSC 52 has already been tried.
+func writePlatformClipboard(text string) {
+	args := platformClipboardCommand()
+	if args == nil {
+		return
+	}
+	cmd := exec.Command(args[0], args[1:]...)
+	cmd.Stdin = strings.NewReader(text)
+	_ = cmd.Run()
+}
@@ -0,0 +1,155 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/shhac/prtea/internal/github"
+)
+
+// maxUncoveredShown caps the paths listed under "Code owners" in the Review tab.
+const maxUncoveredShown = 5
+
+// ownedPath is a changed file that still needs another code owner's review.
+type ownedPath struct {
+	path      string
+	owners    []string
+	requested bool // at least one of its owners has a pending review request
+}
+
+// ownerCoverage summarises how far the user's approval satisfies CODEOWNERS
+// for a PR's changed files. Files with no owners are not counted.
+type ownerCoverage struct {
+	total     int         // changed files with code owners
+	mine      int         // owned by the user or one of their teams
+	approved  int         // not mine, but already approved by a listed owner
+	uncovered []ownedPath // still need another owner
+}
+
+// computeOwnerCoverage matches each changed file against the CODEOWNERS
+// rules. A file is covered by the user when they or one of their teams are
+// listed, and by others when a listed user has already approved. Team
+// members' approvals can't be attributed to their team, so those files stay
+// uncovered. Returns nil when the repository has no CODEOWNERS rules.
+func computeOwnerCoverage(rules []github.CodeOwnerRule, files []github.PRFile, me string, myTeams []string, reviews *github.ReviewSummary) *ownerCoverage {
+	if len(rules) == 0 {
+		return nil
+	}
+	mine := map[string]bool{"@" + strings.ToLower(me): true}
+	for _, t := range myTeams {
+		mine[strings.ToLower(t)] = true
+	}
+	approvers := map[string]bool{}
+	requested := map[string]bool{}
+	if reviews != nil {
+		for _, r := range reviews.Approved {
+			approvers["@"+strings.ToLower(r.Author.Login)] = true
+		}
+		for _, r := range reviews.PendingReviewers {
+			requested[strings.ToLower(strings.ReplaceAll(r.Login, " ", "-"))] = true
+		}
+	}
+
+	cov := &ownerCoverage{}
+	for _, f := range files {
+		owners := github.OwnersFor(rules, f.Filename)
+		if len(owners) == 0 {
+			continue
+		}
+		cov.total++
+		switch {
+		case anyOwner(owners, func(o string) bool { return mine[o] }):
+			cov.mine++
+		case anyOwner(owners, func(o string) bool { return approvers[o] }):
+			cov.approved++
+		default:
+			cov.uncovered = append(cov.uncovered, ownedPath{
+				path:   f.Filename,
+				owners: owners,
+				requested: anyOwner(owners, func(o string) bool {
+					// Users are requested by login, teams by name; compare
+					// the slug after the org.
+					name := strings.TrimPrefix(o, "@")
+					if _, slug, ok := strings.Cut(name, "/"); ok {
+						name = slug
+					}
+					return requested[name]
+				}),
+			})
+		}
+	}
+	return cov
+}
+
+// anyOwner reports whether match holds for any owner, compared lower-cased.
+func anyOwner(owners []string, match func(string) bool) bool {
+	for _, o := range owners {
+		if match(strings.ToLower(o)) {
+			return true
+		}
+	}
+	return false
+}
+
+// fetchCodeOwnersCmd fetches the base branch's CODEOWNERS rules and the
+// user's teams. Missing team access is not an error: team ownership is
+// then only credited to approvals by listed users.
+func fetchCodeOwnersCmd(client GitHubService, owner, repo, base string, number int) tea.Cmd {
+	return func() tea.Msg {
+		ctx := context.Background()
+		rules, err := client.GetCodeOwners(ctx, owner, repo, base)
+		if err != nil || len(rules) == 0 {
+			return CodeOwnersLoadedMsg{PRNumber: number, Err: err}
+		}
+		teams, _ := client.GetMyTeams(ctx)
+		return CodeOwnersLoadedMsg{PRNumber: number, Rules: rules, Teams: teams}
+	}
+}
+
+// refreshOwnerCoverage recomputes CODEOWNERS coverage for the Review tab
+// from the rules, diff and reviews loaded so far.
+func (m *App) refreshOwnerCoverage() {
+	if m.session == nil || m.ghClient == nil {
+		return
+	}
+	s := m.session
+	m.chatPanel.SetOwnerCoverage(computeOwnerCoverage(s.CodeOwners, s.DiffFiles, m.ghClient.GetUsername(), s.MyTeams, m.diffViewer.reviewSummary))
+}
+
+// renderOwnerCoverage renders the "Code owners" section shown before approving.
+func renderOwnerCoverage(cov *ownerCoverage) string {
+	var b strings.Builder
+	b.WriteString(reviewLabelStyle.Render("Code owners"))
+	b.WriteString("\n")
+	if len(cov.uncovered) == 0 {
+		detail := fmt.Sprintf("Your approval covers all %d owned file(s)", cov.mine)
+		if cov.approved > 0 {
+			detail = fmt.Sprintf("Your approval covers %d owned file(s); %d already approved by other owners", cov.mine, cov.approved)
+		}
+		b.WriteString("  " + lipgloss.NewStyle().Foreground(theme.Success).Render("✓ "+detail))
+		b.WriteString("\n\n")
+		return b.String()
+	}
+
+	b.WriteString("  " + lipgloss.NewStyle().Foreground(theme.Warning).Render(
+		fmt.Sprintf("● %d of %d owned file(s) still need another owner's review", len(cov.uncovered), cov.total)))
+	b.WriteString("\n")
+	for i, p := range cov.uncovered {
+		if i == maxUncoveredShown {
+			b.WriteString(dimStyle.Render(fmt.Sprintf("    … and %d more", len(cov.uncovered)-maxUncoveredShown)))
+			b.WriteString("\n")
+			break
+		}
+		line := "    " + p.path + " " + dimStyle.Render(strings.Join(p.owners, " "))
+		if p.requested {
+			line += dimStyle.Italic(true).Render(" (requested)")
+		}
+		b.WriteString(line)
+		b.WriteString("\n")
+	}
+	b.WriteString("\n")
+	return b.String()
+}
@@ -0,0 +1,60 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/shhac/prtea/internal/github"
+)
+
+func TestComputeOwnerCoverage(t *testing.T) {
+	rules := github.ParseCodeOwners("* @acme/core\n/api/ @acme/api\n/docs/ @bob\n*_test.go @alice\n")
+	files := []github.PRFile{
+		{Filename: "main.go"},             // @acme/core — my team
+		{Filename: "api/handler.go"},      // @acme/api — not mine
+		{Filename: "api/handler_test.go"}, // @alice — me
+		{Filename: "docs/guide.md"},       // @bob — already approved
+		{Filename: "api/routes.go"},       // @acme/api — not mine, requested
+	}
+	reviews := &github.ReviewSummary{
+		Approved:         []github.Review{{Author: github.User{Login: "Bob"}}},
+		PendingReviewers: []github.ReviewRequest{{Login: "API", IsTeam: true}},
+	}
+
+	cov := computeOwnerCoverage(rules, files, "alice", []string{"@acme/core"}, reviews)
+
+	if cov.total != 5 || cov.mine != 2 || cov.approved != 1 {
+		t.Errorf("coverage = %+v, want total 5, mine 2, approved 1", cov)
+	}
+	if len(cov.uncovered) != 2 || cov.uncovered[0].path != "api/handler.go" {
+		t.Fatalf("uncovered = %+v", cov.uncovered)
+	}
+	if !cov.uncovered[0].requested {
+		t.Error("@acme/api has a pending team review request")
+	}
+
+	out := renderOwnerCoverage(cov)
+	if !strings.Contains(out, "2 of 5 owned file(s) still need another owner's review") || !strings.Contains(out, "(requested)") {
+		t.Errorf("unexpected render:\n%s", out)
+	}
+}
+
+func TestComputeOwnerCoverage_NoRules(t *testing.T) {
+	if cov := computeOwnerCoverage(nil, []github.PRFile{{Filename: "a.go"}}, "alice", nil, nil); cov != nil {
+		t.Errorf("expected nil coverage without CODEOWNERS, got %+v", cov)
+	}
+}
+
+func TestReviewTab_ShowsOwnersOnlyWhenApproving(t *testing.T) {
+	tab := NewReviewTabModel()
+	tab.ownerCoverage = &ownerCoverage{total: 1, uncovered: []ownedPath{{path: "api/x.go", owners: []string{"@acme/api"}}}}
+
+	tab.action = ReviewComment
+	if strings.Contains(tab.Render(80, ""), "Code owners") {
+		t.Error("owner coverage should only show for Approve")
+	}
+	tab.action = ReviewApprove
+	if !strings.Contains(tab.Render(80, ""), "api/x.go") {
+		t.Error("approving should list paths needing another owner")
+	}
+}
@@ -14,6 +14,7 @@ type Command struct {
 	Aliases     []string // short aliases (e.g., ["rev"])
 	QuickKey    string   // single key for quick mode, empty if not in quick palette
 	Description string   // human-readable description
+	TakesArgs   bool     // whether text after the name is passed as arguments
 }
 
 // commandRegistry is the canonical list of all commands.
@@ -21,7 +22,7 @@ type Command struct {
 var commandRegistry = []Command{
 	// Actions with quick keys
 	{Name: "analyze", Aliases: []string{"an"}, QuickKey: "a", Description: "Analyze PR with Claude"},
-	{Name: "open", Aliases: []string{"op"}, QuickKey: "o", Description: "Open PR in browser"},
+	{Name: "open", Aliases: []string{"op"}, QuickKey: "o", Description: "Open PR in browser, or select one (e.g. open 123, open owner/repo#123)", TakesArgs: true},
 	{Name: "new", Aliases: nil, QuickKey: "n", Description: "New chat (clear)"},
 	{Name: "quit", Aliases: []string{"q"}, QuickKey: "q", Description: "Quit prtea"},
 	{Name: "help", Aliases: []string{"h", "?"}, QuickKey: "?", Description: "Show help"},
@@ -31,12 +32,67 @@ var commandRegistry = []Command{
 	{Name: "toggle left", Aliases: []string{"tl"}, QuickKey: "1", Description: "Toggle left panel"},
 	{Name: "toggle center", Aliases: []string{"tc"}, QuickKey: "2", Description: "Toggle center panel"},
 	{Name: "toggle right", Aliases: []string{"tr"}, QuickKey: "3", Description: "Toggle right panel"},
+	{Name: "reset panels", Aliases: []string{"rp"}, Description: "Restore default panel sizes"},
 	// Full mode only
 	{Name: "config", Aliases: []string{"settings", "cfg"}, QuickKey: "s", Description: "Open settings"},
 	{Name: "clear selection", Aliases: []string{"cs"}, Description: "Clear hunk selection"},
+	{Name: "switch", Aliases: []string{"sw", "b"}, Description: "Switch to an open PR (e.g. switch 123; no number = previous)", TakesArgs: true},
+	{Name: "file", Aliases: []string{"fi"}, Description: "Jump to a file in the diff by fuzzy name (e.g. file app.go)", TakesArgs: true},
+	{Name: "goto", Aliases: []string{"go"}, Description: "Jump to a file and line in the diff (e.g. goto ui/app.go:42)", TakesArgs: true},
+	{Name: "marks", Description: "List diff bookmarks set with m{a-z} (marks clear [a b ...] deletes them)", TakesArgs: true},
+	{Name: "yank url", Aliases: []string{"yu"}, Description: "Copy the PR URL to the clipboard"},
+	{Name: "yank path", Aliases: []string{"yp"}, Description: "Copy the path of the file under the diff cursor"},
+	{Name: "export patch", Aliases: []string{"xp"}, Description: "Write the selected hunks (or whole diff) as a .patch (e.g. export patch fix.patch)", TakesArgs: true},
+	{Name: "export analysis", Aliases: []string{"xa"}, Description: "Write the AI analysis as markdown (e.g. export analysis ~/notes.md)", TakesArgs: true},
+	{Name: "export report", Aliases: []string{"xr"}, Description: "Write the PR, diff and comments as markdown, or HTML for a .html path", TakesArgs: true},
+	{Name: "find", Aliases: nil, Description: "Search the diff, description, comments, reviews, analysis and chat (Ctrl+F)"},
+	{Name: "cnext", Aliases: []string{"cn"}, Description: "Jump to the next quickfix item (threads, AI findings, CI, drafts)"},
+	{Name: "cprev", Aliases: []string{"cp", "cN"}, Description: "Jump to the previous quickfix item"},
+	{Name: "copen", Aliases: []string{"qf"}, Description: "List quickfix items"},
+	{Name: "order hunks", Aliases: []string{"oh"}, Description: "Reorder selected hunks and mark the primary focus"},
+	{Name: "context", Aliases: []string{"ctx"}, Description: "Show and choose what chat sends Claude"},
+	{Name: "analysis info", Aliases: []string{"ani"}, Description: "Show the inputs behind the current analysis (toggle)"},
+	{Name: "analysis rerun", Aliases: []string{"anr"}, Description: "Re-run the analysis with the same inputs"},
+	{Name: "analysis diff", Aliases: []string{"and"}, Description: "Show what changed since the previous analysis (toggle)"},
+	{Name: "analysis post", Aliases: []string{"anp"}, Description: "Post the analysis as a comment on the PR (asks first)"},
+	{Name: "summarize", Aliases: []string{"sum"}, Description: "Five-bullet AI summary at the top of PR Info"},
+	{Name: "merge message", Aliases: []string{"mm"}, Description: "Draft a squash commit message and release note (your PRs)"},
 	{Name: "review", Aliases: []string{"rev"}, Description: "Generate AI review"},
+	{Name: "review selection", Aliases: []string{"revs"}, Description: "AI review of the selected hunks only"},
+	{Name: "review triage", Aliases: []string{"revt"}, Description: "Accept or reject the AI review's inline comments by severity"},
 	{Name: "approve", Aliases: []string{"ap"}, Description: "Quick-approve PR"},
+	{Name: "close", Aliases: nil, Description: "Close the PR without merging (asks to confirm)"},
+	{Name: "reopen", Aliases: nil, Description: "Reopen a closed PR (asks to confirm)"},
+	{Name: "stack next", Aliases: []string{"sn"}, Description: "Open the next PR up the stack (towards its tip)"},
+	{Name: "stack prev", Aliases: []string{"sp"}, Description: "Open the previous PR down the stack (towards its base)"},
+	{Name: "stack diff", Aliases: []string{"sd"}, Description: "Show the combined diff of the stack up to this PR (toggle)"},
+	{Name: "since", Description: "Show only the changes since your last review of this PR (toggle)"},
+	{Name: "preview", Aliases: nil, Description: "Open the PR's preview deployment in the browser"},
+	{Name: "run", Aliases: nil, Description: "Run a configured command (run test, run lint) on the PR branch in its local clone; run stop cancels", TakesArgs: true},
+	{Name: "batch", Description: "Act on the PRs marked with v/Space: batch approve, open, snooze [1d], refresh; batch unsnooze", TakesArgs: true},
+	{Name: "recover", Description: "Restore text left unsent when prtea last exited; recover discard drops it", TakesArgs: true},
+	{Name: "worktree", Aliases: []string{"wt"}, Description: "Count the temporary worktrees local runs created; worktree prune removes them", TakesArgs: true},
+	{Name: "coverage", Aliases: []string{"cov"}, Description: "Toggle the CI coverage gutter and show changed-line coverage"},
+	{Name: "security", Aliases: nil, Description: "Jump to security alert N of the PR in the diff (e.g. security 2)", TakesArgs: true},
+	{Name: "workload", Aliases: []string{"wl"}, Description: "Show open review requests per reviewer across workloadScopes"},
+	{Name: "link", Aliases: nil, Description: "Open numbered link [N] of the focused view (e.g. link 2), or list its links", TakesArgs: true},
 	{Name: "rerun ci", Aliases: []string{"rerun"}, Description: "Re-run failed CI checks"},
+	{Name: "ci summary", Aliases: []string{"cis"}, Description: "Add CI failure summary to review body"},
+	{Name: "update branch", Aliases: []string{"ub"}, Description: "Merge base into the PR branch"},
+	{Name: "auto-merge squash", Aliases: []string{"ams"}, Description: "Enable auto-merge (squash)"},
+	{Name: "auto-merge merge", Aliases: []string{"amm"}, Description: "Enable auto-merge (merge commit)"},
+	{Name: "auto-merge rebase", Aliases: []string{"amr"}, Description: "Enable auto-merge (rebase)"},
+	{Name: "auto-merge off", Aliases: []string{"amo"}, Description: "Disable auto-merge"},
+	{Name: "ready", Aliases: []string{"rfr"}, Description: "Mark your draft PR ready for review (repeat to confirm)"},
+	{Name: "draft", Aliases: []string{"dr"}, Description: "Convert your PR back to a draft (repeat to confirm)"},
+	{Name: "focus", Description: "Review one file at a time, marking each viewed (toggle)"},
+	{Name: "guide", Aliases: []string{"gr"}, Description: "Guided review in AI-suggested file order (toggle)"},
+	{Name: "exclude file", Aliases: []string{"ex"}, Description: "Toggle focused file out of review scope"},
+	{Name: "ai reset", Aliases: []string{"air"}, Description: "Restore AI features after repeated Claude failures"},
+	{Name: "usage", Aliases: []string{"us"}, Description: "AI token usage and cost for this PR, session and month"},
+	{Name: "prompts", Aliases: nil, Description: "Manage custom review prompts and preview them"},
+	{Name: "pending", Aliases: nil, Description: "Review, edit and reorder pending inline comments"},
+	{Name: "timer", Aliases: []string{"tm"}, Description: "Review timer for this PR (e.g. timer 20m, timer off)", TakesArgs: true},
 	{Name: "refresh", Aliases: []string{"ref"}, Description: "Refresh current view"},
 	{Name: "diff", Aliases: []string{"d"}, Description: "Focus diff panel"},
 	{Name: "chat", Aliases: []string{"ch"}, Description: "Focus chat panel"},
@@ -72,6 +128,7 @@ func NewCommandModeModel() CommandModeModel {
 func (m *CommandModeModel) SetSize(width, height int) {
 	m.width = width
 	m.height = height
+	m.input.Width = max(1, width-3)
 }
 
 // Open activates command mode. quick=true for Ctrl+P, quick=false for :.
@@ -151,6 +208,11 @@ func (m CommandModeModel) updateFull(msg tea.KeyMsg) (CommandModeModel, tea.Cmd)
 			m.Close()
 			return m, func() tea.Msg { return CommandModeExitMsg{} }
 		}
+		// A command followed by arguments, e.g. "timer 20m"
+		if name, args, ok := splitCommandArgs(input); ok {
+			m.Close()
+			return m, func() tea.Msg { return CommandExecuteMsg{Name: name, Args: args} }
+		}
 		// Execute the highlighted suggestion if available
 		if len(m.filtered) > 0 && m.selected < len(m.filtered) {
 			name := m.filtered[m.selected].Name
@@ -226,6 +288,23 @@ func (m CommandModeModel) resolveCommand(input string) string {
 	return ""
 }
 
+// splitCommandArgs splits input such as "timer 20m" into a command that takes
+// arguments and the argument text.
+func splitCommandArgs(input string) (name, args string, ok bool) {
+	lower := strings.ToLower(input)
+	for _, cmd := range commandRegistry {
+		if !cmd.TakesArgs {
+			continue
+		}
+		for _, n := range append([]string{cmd.Name}, cmd.Aliases...) {
+			if strings.HasPrefix(lower, strings.ToLower(n)+" ") {
+				return cmd.Name, strings.TrimSpace(input[len(n):]), true
+			}
+		}
+	}
+	return "", "", false
+}
+
 func (m *CommandModeModel) filterCommands() {
 	input := strings.ToLower(strings.TrimSpace(m.input.Value()))
 	if input == "" {
@@ -236,7 +315,8 @@ func (m *CommandModeModel) filterCommands() {
 
 	var filtered []Command
 	for _, cmd := range commandRegistry {
-		if strings.HasPrefix(strings.ToLower(cmd.Name), input) {
+		if strings.HasPrefix(strings.ToLower(cmd.Name), input) ||
+			(cmd.TakesArgs && strings.HasPrefix(input, strings.ToLower(cmd.Name)+" ")) {
 			filtered = append(filtered, cmd)
 			continue
 		}
@@ -304,11 +384,11 @@ func (m CommandModeModel) viewQuick() string {
 		if i+half < len(cmds) {
 			right = formatQuickEntry(cmds[i+half], colWidth)
 		}
-		b.WriteString(left + right + "\n")
+		b.WriteString(fitWidth(left+right, m.width) + "\n")
 	}
 
 	// Footer hint
-	b.WriteString(cmdPaletteHintStyle.Render(" Press a key · Esc to cancel"))
+	b.WriteString(fitWidth(cmdPaletteHintStyle.Render(" Press a key · Esc to cancel"), m.width))
 
 	return b.String()
 }
@@ -337,9 +417,11 @@ func (m CommandModeModel) viewFull() string {
 	b.WriteString(cmdPaletteDividerStyle.Render("─") + title + cmdPaletteDividerStyle.Render(strings.Repeat("─", remaining)))
 	b.WriteString("\n")
 
-	// Suggestions (max 8 visible)
-	maxShow := min(8, len(m.filtered))
-	for i := 0; i < maxShow; i++ {
+	// Suggestions: at most 8, fewer when the terminal is short, scrolled so
+	// the selection stays visible.
+	maxShow := min(8, len(m.filtered), m.maxSuggestions())
+	first := max(0, m.selected-maxShow+1)
+	for i := first; i < first+maxShow; i++ {
 		cmd := m.filtered[i]
 		marker := "  "
 		nameStyle := cmdPaletteDescStyle
@@ -356,7 +438,7 @@ func (m CommandModeModel) viewFull() string {
 			aliasStr = cmdPaletteAliasStyle.Render(" (" + strings.Join(cmd.Aliases, ", ") + ")")
 		}
 
-		b.WriteString(marker + name + aliasStr + " " + desc + "\n")
+		b.WriteString(fitWidth(marker+name+aliasStr+" "+desc, m.width) + "\n")
 	}
 
 	if len(m.filtered) == 0 && m.input.Value() != "" {
@@ -368,3 +450,12 @@ func (m CommandModeModel) viewFull() string {
 
 	return b.String()
 }
+
+// maxSuggestions returns how many suggestion rows fit between the title
+// divider and the input line.
+func (m CommandModeModel) maxSuggestions() int {
+	if m.height <= 0 {
+		return 8
+	}
+	return max(1, m.height-2)
+}
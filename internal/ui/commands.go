@@ -12,41 +12,75 @@ import (
 	"github.com/charmbracelet/bubbles/list"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/shhac/prtea/internal/claude"
+	"github.com/shhac/prtea/internal/config"
 	"github.com/shhac/prtea/internal/github"
 	"github.com/shhac/prtea/internal/notify"
 )
 
-// initGHClientCmd creates the GitHub client in a goroutine.
-func initGHClientCmd() tea.Msg {
-	client, err := github.NewClient()
-	if err != nil {
-		return GHClientErrorMsg{Err: err}
+// initGHClientCmd creates the GitHub client in a goroutine. With a token it
+// talks to the GitHub API directly; otherwise it goes through the gh CLI.
+func initGHClientCmd(token string) tea.Cmd {
+	return func() tea.Msg {
+		var client *github.Client
+		var err error
+		if token != "" {
+			client, err = github.NewTokenClient(token)
+		} else {
+			client, err = github.NewClient()
+		}
+		if err != nil {
+			return GHClientErrorMsg{Err: err}
+		}
+		client.SetResponseCache(github.NewResponseCache(config.HTTPCacheDir()))
+		return GHClientReadyMsg{Client: client}
 	}
-	return GHClientReadyMsg{Client: client}
 }
 
 // fetchPRData fetches both PR lists from GitHub. Shared by foreground and poll fetchers.
-func fetchPRData(client GitHubService) ([]github.PRItem, []github.PRItem, error) {
+// It tries a single batched GraphQL query first (batched is true when it
+// succeeds) and falls back to the two REST searches.
+func fetchPRData(client GitHubService) (toReview, myPRs []github.PRItem, batched bool, err error) {
 	ctx := context.Background()
-	toReview, err := client.GetPRsForReview(ctx)
+	if toReview, myPRs, err = client.GetPRLists(ctx); err == nil {
+		return toReview, myPRs, true, nil
+	}
+	toReview, err = client.GetPRsForReview(ctx)
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, false, err
 	}
-	myPRs, err := client.GetMyPRs(ctx)
+	myPRs, err = client.GetMyPRs(ctx)
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, false, err
 	}
-	return toReview, myPRs, nil
+	return toReview, myPRs, false, nil
 }
 
 // fetchPRsCmd returns a command that fetches both PR lists.
 func fetchPRsCmd(client GitHubService) tea.Cmd {
 	return func() tea.Msg {
-		toReview, myPRs, err := fetchPRData(client)
+		toReview, myPRs, batched, err := fetchPRData(client)
 		if err != nil {
 			return PRsErrorMsg{Err: err}
 		}
-		return PRsLoadedMsg{ToReview: toReview, MyPRs: myPRs}
+		return PRsLoadedMsg{ToReview: toReview, MyPRs: myPRs, Batched: batched}
+	}
+}
+
+// fetchClosedPRsCmd returns a command that fetches the user's recently
+// closed PRs for the PR list's "Recently closed" filter.
+func fetchClosedPRsCmd(client GitHubService) tea.Cmd {
+	return func() tea.Msg {
+		prs, err := client.GetRecentlyClosedPRs(context.Background())
+		return ClosedPRsLoadedMsg{PRs: prs, Err: err}
+	}
+}
+
+// fetchWorkloadCmd returns a command that fetches the open review requests
+// across scopes for the :workload overlay.
+func fetchWorkloadCmd(client GitHubService, scopes []string) tea.Cmd {
+	return func() tea.Msg {
+		prs, err := client.GetReviewWorkload(context.Background(), scopes)
+		return WorkloadLoadedMsg{PRs: prs, Err: err}
 	}
 }
 
@@ -64,6 +98,10 @@ func convertPRItems(prs []github.PRItem) []list.Item {
 			htmlURL:        pr.HTMLURL,
 			reviewDecision: pr.ReviewDecision,
 			isDraft:        pr.Draft,
+			baseBranch:     pr.BaseBranch,
+			headBranch:     pr.HeadBranch,
+			updatedAt:      pr.UpdatedAt,
+			autoMerge:      pr.AutoMerge,
 		}
 	}
 	return items
@@ -74,10 +112,9 @@ func convertPRItems(prs []github.PRItem) []list.Item {
 func fetchReviewDecisionsCmd(client GitHubService, prs []github.PRItem) tea.Cmd {
 	return func() tea.Msg {
 		ctx := context.Background()
+		// Delivered even when empty: an empty set is the baseline the
+		// review-outcome notification trigger compares against.
 		decisions, _ := client.GetReviewDecisions(ctx, prs)
-		if len(decisions) == 0 {
-			return nil
-		}
 		return PRReviewDecisionsMsg{Decisions: decisions}
 	}
 }
@@ -89,15 +126,22 @@ func pollTickCmd(interval time.Duration) tea.Cmd {
 	})
 }
 
-// pollFetchPRsCmd returns a command that fetches PR lists for background polling.
+// pollFetchPRsCmd returns a command that fetches PR lists for background
+// polling: only the PRs updated since the given time, or both lists in full
+// when since is zero or the delta query fails.
 // Errors are surfaced as pollErrorMsg so the user sees transient issues.
-func pollFetchPRsCmd(client GitHubService) tea.Cmd {
+func pollFetchPRsCmd(client GitHubService, since time.Time) tea.Cmd {
 	return func() tea.Msg {
-		toReview, myPRs, err := fetchPRData(client)
+		if !since.IsZero() {
+			if toReview, myPRs, err := client.GetPRListsSince(context.Background(), since); err == nil {
+				return pollPRsLoadedMsg{ToReview: toReview, MyPRs: myPRs, Batched: true, Delta: true}
+			}
+		}
+		toReview, myPRs, batched, err := fetchPRData(client)
 		if err != nil {
 			return pollErrorMsg{Err: err}
 		}
-		return pollPRsLoadedMsg{ToReview: toReview, MyPRs: myPRs}
+		return pollPRsLoadedMsg{ToReview: toReview, MyPRs: myPRs, Batched: batched}
 	}
 }
 
@@ -184,6 +228,60 @@ func fetchCIStatusCmd(client GitHubService, owner, repo string, number int) tea.
 	}
 }
 
+// fetchMergeRequirementsCmd returns a command that fetches the merge gates for a PR's base branch.
+func fetchMergeRequirementsCmd(client GitHubService, owner, repo, base string, number int) tea.Cmd {
+	return func() tea.Msg {
+		ctx := context.Background()
+		req, err := client.GetMergeRequirements(ctx, owner, repo, base, number)
+		return MergeRequirementsLoadedMsg{PRNumber: number, Requirements: req, Err: err}
+	}
+}
+
+// fetchBaseChangedFilesCmd returns a command that fetches files changed on base since head diverged.
+func fetchBaseChangedFilesCmd(client GitHubService, owner, repo, base, head string, number int) tea.Cmd {
+	return func() tea.Msg {
+		ctx := context.Background()
+		files, err := client.GetBaseChangedFiles(ctx, owner, repo, base, head)
+		return BaseChangedFilesLoadedMsg{PRNumber: number, Files: files, Err: err}
+	}
+}
+
+// branchUpdateRefreshDelay gives GitHub time to apply an update-branch merge before reloading.
+const branchUpdateRefreshDelay = 3 * time.Second
+
+// updateBranchCmd returns a command that merges base into the PR's head branch.
+func updateBranchCmd(client GitHubService, owner, repo string, number int, headSHA string) tea.Cmd {
+	return func() tea.Msg {
+		ctx := context.Background()
+		err := client.UpdateBranch(ctx, owner, repo, number, headSHA)
+		return UpdateBranchDoneMsg{PRNumber: number, Err: err}
+	}
+}
+
+// setAutoMergeCmd returns a command that enables auto-merge with the given
+// method, or disables it when method is "".
+func setAutoMergeCmd(client GitHubService, owner, repo string, number int, method string, commit *github.MergeCommit) tea.Cmd {
+	return func() tea.Msg {
+		ctx := context.Background()
+		var err error
+		if method == "" {
+			err = client.DisableAutoMerge(ctx, owner, repo, number)
+		} else {
+			err = client.EnableAutoMerge(ctx, owner, repo, number, method, commit)
+		}
+		return AutoMergeDoneMsg{Owner: owner, Repo: repo, PRNumber: number, Method: method, Err: err}
+	}
+}
+
+// fetchJobLogsCmd returns a command that fetches the raw log for a CI job.
+func fetchJobLogsCmd(client GitHubService, owner, repo string, number int, jobID int64) tea.Cmd {
+	return func() tea.Msg {
+		ctx := context.Background()
+		logs, err := client.GetJobLogs(ctx, owner, repo, jobID)
+		return CILogsLoadedMsg{PRNumber: number, JobID: jobID, Logs: logs, Err: err}
+	}
+}
+
 // fetchReviewsCmd returns a command that fetches review status for a PR.
 func fetchReviewsCmd(client GitHubService, owner, repo string, number int) tea.Cmd {
 	return func() tea.Msg {
@@ -206,6 +304,27 @@ func rerunFailedCICmd(client GitHubService, owner, repo string, number int, runI
 	}
 }
 
+// rerunCICheckCmd returns a command that re-runs a single GitHub Actions job.
+func rerunCICheckCmd(client GitHubService, owner, repo string, number int, check github.CICheck) tea.Cmd {
+	return func() tea.Msg {
+		ctx := context.Background()
+		if err := client.RerunJob(ctx, owner, repo, check.JobID); err != nil {
+			return CIRerunErrMsg{PRNumber: number, Err: err}
+		}
+		return CIRerunCheckDoneMsg{PRNumber: number, Check: check}
+	}
+}
+
+// ciWatchInterval is how often CI status is polled while watching a re-run check.
+const ciWatchInterval = 5 * time.Second
+
+// ciWatchTickCmd returns a command that fires after ciWatchInterval to poll a watched check.
+func ciWatchTickCmd(number, seq int) tea.Cmd {
+	return tea.Tick(ciWatchInterval, func(t time.Time) tea.Msg {
+		return ciWatchTickMsg{PRNumber: number, Seq: seq}
+	})
+}
+
 // openBrowserCmd returns a command that opens a URL in the default browser.
 func openBrowserCmd(url string) tea.Cmd {
 	return func() tea.Msg {
@@ -247,82 +366,108 @@ func closePRCmd(client GitHubService, owner, repo string, number int) tea.Cmd {
 	}
 }
 
+// reopenPRCmd returns a command that reopens a closed PR.
+func reopenPRCmd(client GitHubService, owner, repo string, number int) tea.Cmd {
+	return func() tea.Msg {
+		err := client.ReopenPR(context.Background(), owner, repo, number)
+		if err != nil {
+			return PRReopenErrMsg{PRNumber: number, Err: err}
+		}
+		return PRReopenDoneMsg{PRNumber: number}
+	}
+}
+
 // submitReviewCmd returns a command that submits a PR review, optionally with inline comments.
 func submitReviewCmd(client GitHubService, owner, repo string, number int, action ReviewAction, body string, inlineComments []claude.InlineReviewComment) tea.Cmd {
 	return func() tea.Msg {
-		ctx := context.Background()
-		var err error
+		if err := submitReview(context.Background(), client, owner, repo, number, action, body, inlineComments); err != nil {
+			return ReviewSubmitErrMsg{PRNumber: number, Err: err}
+		}
+		return ReviewSubmitDoneMsg{PRNumber: number, Action: action}
+	}
+}
 
-		// If there are inline comments, use the REST API for the full review
-		if len(inlineComments) > 0 {
-			eventMap := map[ReviewAction]string{
-				ReviewApprove:        "APPROVE",
-				ReviewComment:        "COMMENT",
-				ReviewRequestChanges: "REQUEST_CHANGES",
+// submitReview submits a PR review, optionally with inline comments.
+func submitReview(ctx context.Context, client GitHubService, owner, repo string, number int, action ReviewAction, body string, inlineComments []claude.InlineReviewComment) error {
+	// If there are inline comments, use the REST API for the full review
+	if len(inlineComments) > 0 {
+		eventMap := map[ReviewAction]string{
+			ReviewApprove:        "APPROVE",
+			ReviewComment:        "COMMENT",
+			ReviewRequestChanges: "REQUEST_CHANGES",
+		}
+		comments := make([]github.ReviewCommentPayload, len(inlineComments))
+		for i, c := range inlineComments {
+			side := c.Side
+			if side == "" {
+				side = "RIGHT"
 			}
-			comments := make([]github.ReviewCommentPayload, len(inlineComments))
-			for i, c := range inlineComments {
-				side := c.Side
-				if side == "" {
-					side = "RIGHT"
-				}
-				payload := github.ReviewCommentPayload{
-					Path: c.Path,
-					Line: c.Line,
-					Side: side,
-					Body: c.Body,
-				}
-				if c.StartLine > 0 {
-					payload.StartLine = c.StartLine
-					startSide := c.StartSide
-					if startSide == "" {
-						startSide = side
-					}
-					payload.StartSide = startSide
-				}
-				comments[i] = payload
+			payload := github.ReviewCommentPayload{
+				Path: c.Path,
+				Line: c.Line,
+				Side: side,
+				Body: c.Body,
 			}
-			err = client.SubmitReviewWithComments(ctx, owner, repo, number, eventMap[action], body, comments)
-		} else {
-			// No inline comments — use simple gh pr review
-			switch action {
-			case ReviewApprove:
-				err = client.ApprovePR(ctx, owner, repo, number, body)
-			case ReviewComment:
-				err = client.CommentReviewPR(ctx, owner, repo, number, body)
-			case ReviewRequestChanges:
-				err = client.RequestChangesPR(ctx, owner, repo, number, body)
+			if c.StartLine > 0 {
+				payload.StartLine = c.StartLine
+				startSide := c.StartSide
+				if startSide == "" {
+					startSide = side
+				}
+				payload.StartSide = startSide
 			}
+			comments[i] = payload
 		}
+		return client.SubmitReviewWithComments(ctx, owner, repo, number, eventMap[action], body, comments)
+	}
 
-		if err != nil {
-			return ReviewSubmitErrMsg{PRNumber: number, Err: err}
-		}
-		return ReviewSubmitDoneMsg{PRNumber: number, Action: action}
+	// No inline comments — use simple gh pr review
+	switch action {
+	case ReviewApprove:
+		return client.ApprovePR(ctx, owner, repo, number, body)
+	case ReviewComment:
+		return client.CommentReviewPR(ctx, owner, repo, number, body)
+	case ReviewRequestChanges:
+		return client.RequestChangesPR(ctx, owner, repo, number, body)
 	}
+	return nil
 }
 
-// replyToCommentCmd posts a reply to an existing GitHub review comment thread.
-func replyToCommentCmd(client GitHubService, owner, repo string, prNumber int, commentID int64, body string) tea.Cmd {
+// replyToCommentCmd posts a reply to an existing GitHub review comment
+// thread. replyID is the temporary ID of the reply shown while it posts.
+func replyToCommentCmd(client GitHubService, owner, repo string, prNumber int, commentID, replyID int64, body string) tea.Cmd {
 	return func() tea.Msg {
 		ctx := context.Background()
 		err := client.ReplyToComment(ctx, owner, repo, prNumber, commentID, body)
-		return InlineCommentReplyDoneMsg{Err: err}
+		return InlineCommentReplyDoneMsg{ReplyID: replyID, Err: err}
 	}
 }
 
 // aiReviewCmd returns a command that runs Claude to generate an AI review with inline comments.
-func aiReviewCmd(ctx context.Context, analyzer AIAnalyzer, pr *PRSession, files []github.PRFile) tea.Cmd {
+// A non-nil scope (commentKeys of the selected hunks' lines) sends only the
+// focus hunks instead of the whole diff. The log tails of failing are sent
+// along too.
+func aiReviewCmd(ctx context.Context, analyzer AIAnalyzer, client GitHubService, pr *PRSession, files []github.PRFile, focusHunks string, scope map[string]bool, failing []github.CICheck) tea.Cmd {
 	return func() tea.Msg {
-		diffContent := buildDiffContent(files)
-
 		input := claude.ReviewInput{
-			Owner:       pr.Owner,
-			Repo:        pr.Repo,
-			PRNumber:    pr.Number,
-			PRTitle:     pr.Title,
-			PRBody:      "", // TODO: include PR body when available
-			DiffContent: diffContent,
+			Owner:    pr.Owner,
+			Repo:     pr.Repo,
+			PRNumber: pr.Number,
+			PRTitle:  pr.Title,
+			PRBody:   "", // TODO: include PR body when available
+		}
+		if scope != nil {
+			input.DiffContent = focusHunks
+			input.Scoped = true
+			for _, f := range files {
+				input.ChangedFiles = append(input.ChangedFiles, f.Filename)
+			}
+		} else {
+			input.DiffContent = buildDiffContent(files)
+			input.FocusHunks = focusHunks
+		}
+		if len(failing) > 0 {
+			input.CILogs, _ = ciLogTails(ctx, client, pr.Owner, pr.Repo, failing)
 		}
 
 		result, err := analyzer.AnalyzeForReview(ctx, input, nil)
@@ -333,6 +478,7 @@ func aiReviewCmd(ctx context.Context, analyzer AIAnalyzer, pr *PRSession, files
 		return AIReviewCompleteMsg{
 			PRNumber: pr.Number,
 			Result:   result,
+			Scope:    scope,
 		}
 	}
 }
@@ -352,9 +498,14 @@ func listenForStream(ch <-chan tea.Msg) tea.Cmd {
 // -- Context builders --
 
 // buildChatContext constructs the PR context string for chat from metadata + diff.
-func buildChatContext(pr *PRSession, files []github.PRFile) string {
+// extras (description, comments, CI logs) goes before the diff, which is cut
+// first when the prompt is over budget.
+func buildChatContext(pr *PRSession, files []github.PRFile, extras string) string {
 	var b strings.Builder
 	fmt.Fprintf(&b, "PR #%d: \"%s\" in %s/%s\n", pr.Number, pr.Title, pr.Owner, pr.Repo)
+	if extras != "" {
+		b.WriteString("\n" + extras)
+	}
 	if len(files) > 0 {
 		b.WriteString("\nChanges in this PR:\n\n")
 		b.WriteString(buildDiffContent(files))
@@ -366,9 +517,12 @@ func buildChatContext(pr *PRSession, files []github.PRFile) string {
 
 // buildSelectedHunkContext constructs PR context with selected hunks as the primary
 // focus, plus a brief file list for broader context.
-func buildSelectedHunkContext(pr *PRSession, files []github.PRFile, selectedDiff string) string {
+func buildSelectedHunkContext(pr *PRSession, files []github.PRFile, extras, primaryDiff, selectedDiff string) string {
 	var b strings.Builder
 	fmt.Fprintf(&b, "PR #%d: \"%s\" in %s/%s\n", pr.Number, pr.Title, pr.Owner, pr.Repo)
+	if extras != "" {
+		b.WriteString("\n" + extras)
+	}
 
 	// Include file list for broader context
 	if len(files) > 0 {
@@ -382,7 +536,16 @@ func buildSelectedHunkContext(pr *PRSession, files []github.PRFile, selectedDiff
 		b.WriteString("\n")
 	}
 
-	b.WriteString("\nThe user selected the following hunks to discuss:\n\n")
+	if primaryDiff != "" {
+		b.WriteString("\nThe user's primary concern is this hunk. Address it first, before anything else:\n\n")
+		b.WriteString(primaryDiff)
+		if selectedDiff != "" {
+			b.WriteString("\nThe user also selected these hunks, in the order they want them discussed:\n\n")
+			b.WriteString(selectedDiff)
+		}
+		return b.String()
+	}
+	b.WriteString("\nThe user selected the following hunks to discuss, in order:\n\n")
 	b.WriteString(selectedDiff)
 	return b.String()
 }
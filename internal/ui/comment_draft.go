@@ -0,0 +1,134 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/shhac/prtea/internal/claude"
+	"github.com/shhac/prtea/internal/github"
+)
+
+// draftReplyQuestion is what Claude is asked when drafting a thread reply.
+const draftReplyQuestion = "Draft my reply to the last comment in this review thread. " +
+	"Answer the point it raises directly, using the diff: agree and say what I'll change, or explain briefly why the code is right. " +
+	"Write it as the reply itself in GitHub markdown — no preamble, no sign-off, no quoting the thread — and keep it short."
+
+// startReplyDraft asks the app to draft a reply to the selected thread.
+func (m *CommentOverlayModel) startReplyDraft() tea.Cmd {
+	commentID := m.replyTargetID()
+	if commentID == 0 || m.drafting {
+		return nil
+	}
+	m.drafting = true
+	msg := DraftReplyMsg{CommentID: commentID, Thread: m.threadTranscript(), Diff: m.diffContext()}
+	return func() tea.Msg { return msg }
+}
+
+// SetReplyDraft pre-fills the reply input with Claude's draft, if the
+// overlay is still on the thread it was drafted for.
+func (m *CommentOverlayModel) SetReplyDraft(commentID int64, draft string) tea.Cmd {
+	if !m.visible || !m.drafting {
+		return nil
+	}
+	m.drafting = false
+	if m.replyTargetID() != commentID {
+		return nil
+	}
+	m.textarea.SetValue(strings.TrimSpace(draft))
+	m.composing = true
+	return m.textarea.Focus()
+}
+
+// threadTranscript renders the selected thread as plain text for a prompt.
+func (m CommentOverlayModel) threadTranscript() string {
+	t := m.ghThreads[m.replyIdx]
+	var b strings.Builder
+	for _, c := range append([]github.InlineComment{t.Root}, t.Replies...) {
+		fmt.Fprintf(&b, "@%s: %s\n\n", c.Author.Login, strings.TrimSpace(c.Body))
+	}
+	return b.String()
+}
+
+// diffContext returns the diff lines around the comment, marking its line.
+func (m CommentOverlayModel) diffContext() string {
+	var b strings.Builder
+	for i, line := range m.diffLines {
+		marker := "  "
+		if i == m.diffTarget {
+			marker = "> "
+		}
+		b.WriteString(marker + line + "\n")
+	}
+	return b.String()
+}
+
+// handleDraftReply asks Claude to draft a reply to a review thread.
+func (m App) handleDraftReply(msg DraftReplyMsg) (tea.Model, tea.Cmd) {
+	reason := ""
+	switch {
+	case m.session == nil:
+		reason = "No PR selected"
+	case m.chatService == nil:
+		reason = "Claude CLI not found — install it or choose another chat backend in Settings"
+	case m.aiBudget.health == aiDisabled:
+		reason = aiDisabledReason
+	}
+	if reason != "" {
+		m.commentOverlay.drafting = false
+		return m, m.statusBar.SetTemporaryMessage(reason, 3*time.Second)
+	}
+
+	s := m.session
+	role := "a reviewer"
+	if m.isOwnPR() {
+		role = "the PR's author"
+	}
+	input := claude.ChatInput{
+		Owner:    s.Owner,
+		Repo:     s.Repo,
+		PRNumber: s.Number,
+		PRContext: fmt.Sprintf("PR #%d: \"%s\" in %s/%s\nI am @%s, %s.\n\nReview thread on %s:%d:\n\n%s\nDiff around the comment (> marks its line):\n%s",
+			s.Number, s.Title, s.Owner, s.Repo, m.ghClientUsername(), role,
+			m.commentOverlay.targetPath, m.commentOverlay.targetLine, msg.Thread, msg.Diff),
+		Message: draftReplyQuestion,
+	}
+	clearCmd := m.statusBar.SetTemporaryMessage("Drafting a reply...", 30*time.Second)
+	return m, tea.Batch(clearCmd, draftReplyCmd(m.usageContext(), m.chatService, input, s.Number, msg.CommentID))
+}
+
+// ghClientUsername returns the signed-in GitHub user, or "" without a client.
+func (m App) ghClientUsername() string {
+	if m.ghClient == nil {
+		return ""
+	}
+	return m.ghClient.GetUsername()
+}
+
+// draftReplyCmd asks Claude for a reply draft, outside the chat history.
+func draftReplyCmd(ctx context.Context, chat AIChatService, input claude.ChatInput, prNumber int, commentID int64) tea.Cmd {
+	return func() tea.Msg {
+		text, err := chat.AskOnce(ctx, input, func(string) {})
+		return ReplyDraftedMsg{PRNumber: prNumber, CommentID: commentID, Text: text, Err: err}
+	}
+}
+
+// handleReplyDrafted puts a finished draft into the comment overlay.
+func (m App) handleReplyDrafted(msg ReplyDraftedMsg) (tea.Model, tea.Cmd) {
+	if m.session == nil || m.session.Number != msg.PRNumber {
+		return m, nil
+	}
+	budgetCmd := m.recordAIResult(msg.Err)
+	if msg.Err != nil || strings.TrimSpace(msg.Text) == "" {
+		m.commentOverlay.drafting = false
+		reason := "Claude returned an empty draft"
+		if msg.Err != nil {
+			reason = "Draft failed: " + msg.Err.Error()
+		}
+		return m, tea.Batch(budgetCmd, m.statusBar.SetTemporaryMessage(reason, 5*time.Second))
+	}
+	clearCmd := m.statusBar.SetTemporaryMessage("Reply drafted — edit it, then Ctrl+S to post", 3*time.Second)
+	return m, tea.Batch(budgetCmd, clearCmd, m.commentOverlay.SetReplyDraft(msg.CommentID, msg.Text))
+}
@@ -2,13 +2,17 @@ package ui
 
 import (
 	"fmt"
+	"slices"
+	"sort"
 	"strings"
 
 	"github.com/charmbracelet/bubbles/textarea"
 	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
 	"github.com/shhac/prtea/internal/claude"
+	"github.com/shhac/prtea/internal/github"
 )
 
 // CommentOverlayModel renders a centered overlay showing diff context,
@@ -18,6 +22,7 @@ type CommentOverlayModel struct {
 	textarea textarea.Model
 	visible  bool
 	composing bool // true when textarea is focused
+	completer textCompleter
 	ready     bool
 
 	// Submit mode
@@ -30,16 +35,28 @@ type CommentOverlayModel struct {
 	// Comment target
 	targetPath      string
 	targetLine      int
-	targetStartLine int    // non-zero for multi-line range comments
-	diffCtx         string // pre-rendered diff context lines
+	targetStartLine int      // non-zero for multi-line range comments
+	diffLines       []string // raw diff context lines
+	diffTarget      int      // index of the target line in diffLines
 
 	// Comment data
 	ghThreads       []ghCommentThread
 	aiComments      []claude.InlineReviewComment
 	pendingComments []PendingInlineComment
 
-	// Reply target: root comment ID for the first GitHub thread (0 if none)
-	replyTargetID int64
+	// Reply target: index into ghThreads of the thread a reply answers
+	replyIdx int
+	drafting bool // waiting on Claude's draft of a reply (D)
+
+	// Suggestions: current content of each suggestion's target lines, keyed
+	// by root comment ID, and whether they can be applied (own PRs only)
+	suggestionBase map[int64][]string
+	canApply       bool
+
+	// Position of this line among the diff's commented lines, 0 if unknown
+	position, total int
+
+	md MarkdownRenderer
 }
 
 func NewCommentOverlayModel() CommentOverlayModel {
@@ -56,25 +73,28 @@ func NewCommentOverlayModel() CommentOverlayModel {
 func (m *CommentOverlayModel) Show(msg ShowCommentOverlayMsg) tea.Cmd {
 	m.visible = true
 	m.composing = false
+	m.drafting = false
 	m.targetPath = msg.Path
 	m.targetLine = msg.Line
 	m.targetStartLine = msg.StartLine
-	m.ghThreads = msg.GHThreads
+	// Current threads first, then outdated ones under their heading
+	m.ghThreads = slices.Clone(msg.GHThreads)
+	sort.SliceStable(m.ghThreads, func(i, j int) bool {
+		return !m.ghThreads[i].Root.Outdated && m.ghThreads[j].Root.Outdated
+	})
 	m.aiComments = msg.AIComments
 	m.pendingComments = msg.PendingComments
+	m.suggestionBase = msg.SuggestionBase
+	m.canApply = msg.CanApplySuggestions
+	m.position, m.total = msg.Position, msg.Total
 	m.textarea.SetValue("")
 
-	// Determine reply target and default submit mode
-	if len(msg.GHThreads) > 0 {
-		m.replyTargetID = msg.GHThreads[0].Root.ID
-		m.postImmediately = true
-	} else {
-		m.replyTargetID = 0
-		m.postImmediately = false
-	}
+	// Reply to the first thread by default, posting immediately
+	m.replyIdx = 0
+	m.postImmediately = len(msg.GHThreads) > 0
 
-	// Build diff context
-	m.diffCtx = m.renderDiffContext(msg.DiffLines, msg.TargetLineInCtx)
+	m.diffLines = msg.DiffLines
+	m.diffTarget = msg.TargetLineInCtx
 
 	// Rebuild thread content in viewport
 	m.refreshContent()
@@ -82,11 +102,49 @@ func (m *CommentOverlayModel) Show(msg ShowCommentOverlayMsg) tea.Cmd {
 	return nil
 }
 
+// replyTargetID returns the root comment ID of the thread a reply answers,
+// or 0 when there is no GitHub thread.
+func (m CommentOverlayModel) replyTargetID() int64 {
+	if m.replyIdx < len(m.ghThreads) {
+		return m.ghThreads[m.replyIdx].Root.ID
+	}
+	return 0
+}
+
+// AddReply shows a reply in its thread as soon as it is sent, before GitHub
+// confirms it.
+func (m *CommentOverlayModel) AddReply(reply github.InlineComment) {
+	for i := range m.ghThreads {
+		if m.ghThreads[i].Root.ID == reply.InReplyToID {
+			m.ghThreads[i].Replies = append(m.ghThreads[i].Replies, reply)
+			m.refreshContent()
+			m.viewport.GotoBottom()
+			return
+		}
+	}
+}
+
+// RemoveReply drops a reply that failed to post.
+func (m *CommentOverlayModel) RemoveReply(id int64) {
+	for i := range m.ghThreads {
+		replies := m.ghThreads[i].Replies
+		for j := range replies {
+			if replies[j].ID == id {
+				m.ghThreads[i].Replies = append(replies[:j:j], replies[j+1:]...)
+				m.refreshContent()
+				return
+			}
+		}
+	}
+}
+
 // Hide dismisses the overlay.
 func (m *CommentOverlayModel) Hide() {
 	m.visible = false
 	m.composing = false
+	m.drafting = false
 	m.textarea.Blur()
+	m.completer.reset()
 }
 
 // IsVisible returns whether the overlay is currently shown.
@@ -100,6 +158,7 @@ func (m *CommentOverlayModel) SetSize(termWidth, termHeight int) {
 	m.height = termHeight
 	_, vpH := m.viewportDimensions()
 	vpW := m.innerWidth()
+	m.textarea.SetWidth(vpW)
 	if !m.ready {
 		m.viewport = viewport.New(vpW, vpH)
 		m.ready = true
@@ -139,6 +198,43 @@ func (m CommentOverlayModel) updateViewing(msg tea.KeyMsg) (CommentOverlayModel,
 		m.composing = true
 		cmd := m.textarea.Focus()
 		return m, cmd
+	case "n", "N":
+		// Choose which thread a reply answers when the line has several.
+		if n := len(m.ghThreads); n > 1 {
+			if msg.String() == "n" {
+				m.replyIdx = (m.replyIdx + 1) % n
+			} else {
+				m.replyIdx = (m.replyIdx + n - 1) % n
+			}
+			m.refreshContent()
+		}
+		return m, nil
+	case "]", "[":
+		// Step to the next or previous commented line, staying open.
+		delta := 1
+		if msg.String() == "[" {
+			delta = -1
+		}
+		return m, func() tea.Msg { return CommentOverlayStepMsg{Delta: delta} }
+	case "D":
+		// Draft a reply to the selected thread with Claude.
+		return m, m.startReplyDraft()
+	case "g", "home":
+		m.viewport.GotoTop()
+		return m, nil
+	case "G", "end":
+		m.viewport.GotoBottom()
+		return m, nil
+	case "a":
+		apply := m.applicableSuggestion()
+		if apply == nil {
+			return m, nil
+		}
+		m.Hide()
+		return m, tea.Batch(
+			func() tea.Msg { return CommentOverlayClosedMsg{} },
+			func() tea.Msg { return *apply },
+		)
 	default:
 		// Scroll the thread viewport
 		var cmd tea.Cmd
@@ -149,13 +245,17 @@ func (m CommentOverlayModel) updateViewing(msg tea.KeyMsg) (CommentOverlayModel,
 
 // updateComposing handles keys when the textarea is focused.
 func (m CommentOverlayModel) updateComposing(msg tea.KeyMsg) (CommentOverlayModel, tea.Cmd) {
+	if m.completer.handles(msg) {
+		return m, updateTextarea(&m.textarea, &m.completer, msg)
+	}
 	switch msg.String() {
 	case "esc":
 		m.composing = false
 		m.textarea.Blur()
+		m.completer.reset()
 		return m, nil
 	case "tab":
-		if m.replyTargetID > 0 {
+		if m.replyTargetID() > 0 {
 			m.postImmediately = !m.postImmediately
 		}
 		return m, nil
@@ -164,13 +264,17 @@ func (m CommentOverlayModel) updateComposing(msg tea.KeyMsg) (CommentOverlayMode
 		if body == "" {
 			return m, nil
 		}
-		m.Hide()
-		if m.postImmediately && m.replyTargetID > 0 {
-			commentID := m.replyTargetID
+		if commentID := m.replyTargetID(); m.postImmediately && commentID > 0 {
+			// Stay open: the reply appears in the thread while it posts.
+			m.composing = false
+			m.textarea.Blur()
+			m.textarea.SetValue("")
+			m.completer.reset()
 			return m, func() tea.Msg {
 				return InlineCommentReplyMsg{CommentID: commentID, Body: body}
 			}
 		}
+		m.Hide()
 		path := m.targetPath
 		line := m.targetLine
 		startLine := m.targetStartLine
@@ -178,9 +282,7 @@ func (m CommentOverlayModel) updateComposing(msg tea.KeyMsg) (CommentOverlayMode
 			return InlineCommentAddMsg{Path: path, Line: line, Body: body, StartLine: startLine}
 		}
 	}
-	var cmd tea.Cmd
-	m.textarea, cmd = m.textarea.Update(msg)
-	return m, cmd
+	return m, updateTextarea(&m.textarea, &m.completer, msg)
 }
 
 func (m CommentOverlayModel) View() string {
@@ -198,11 +300,15 @@ func (m CommentOverlayModel) View() string {
 	} else {
 		titleText = fmt.Sprintf(" 💬 %s:%d ", m.targetPath, m.targetLine)
 	}
+	if m.position > 0 && m.total > 1 {
+		titleText += fmt.Sprintf("· %d/%d ", m.position, m.total)
+	}
 	title := commentOverlayTitleStyle.Render(titleText)
 	titleLine := lipgloss.PlaceHorizontal(innerW, lipgloss.Left, title)
 
-	// Diff context
-	ctx := m.diffCtx
+	// Diff context, trimmed to what fits around the target line
+	ctxLines, _ := m.layout()
+	ctx := renderDiffContext(m.diffLines, m.diffTarget, innerW, ctxLines)
 
 	// Separator
 	sep := commentOverlaySepStyle.Render(strings.Repeat("─", min(innerW, 50)))
@@ -220,10 +326,17 @@ func (m CommentOverlayModel) View() string {
 	taView := m.textarea.View()
 
 	// Footer
-	footer := m.renderFooter(innerW)
+	footer := fitWidth(m.renderFooter(innerW), innerW)
+	if m.composing && m.completer.active() {
+		footer = m.completer.View(innerW)
+	}
 
 	// Assemble parts
-	parts := []string{titleLine, "", ctx, sep, thread}
+	parts := []string{titleLine, ""}
+	if ctx != "" {
+		parts = append(parts, ctx)
+	}
+	parts = append(parts, sep, thread)
 	if scrollInd != "" {
 		parts = append(parts, scrollInd)
 	}
@@ -232,13 +345,12 @@ func (m CommentOverlayModel) View() string {
 
 	overlayStyle := lipgloss.NewStyle().
 		Border(lipgloss.RoundedBorder()).
-		BorderForeground(lipgloss.Color("62")).
+		BorderForeground(theme.Accent).
 		Padding(0, 1).
 		Width(overlayW - 2).
 		Height(overlayH - 2)
 
-	rendered := overlayStyle.Render(box)
-	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, rendered)
+	return placeOverlay(m.width, m.height, overlayStyle.Render(box))
 }
 
 // overlayDimensions returns the outer box dimensions.
@@ -264,16 +376,26 @@ func (m CommentOverlayModel) innerWidth() int {
 	return w
 }
 
+// commentOverlayChrome is the number of rows in the overlay besides the
+// diff context and thread: border (2), title + blank (2), separators (2),
+// scroll indicator (1), textarea (3), blank (1) and footer (1).
+const commentOverlayChrome = 12
+
+// layout splits the rows left after the chrome between the diff context and
+// the thread viewport. The thread keeps at least three rows while there is
+// room for them; the diff context gives up rows first.
+func (m CommentOverlayModel) layout() (ctxLines, threadH int) {
+	_, oh := m.overlayDimensions()
+	avail := oh - commentOverlayChrome
+	ctxLines = max(0, min(len(m.diffLines), avail-3))
+	threadH = max(1, avail-ctxLines)
+	return ctxLines, threadH
+}
+
 // viewportDimensions returns the thread viewport dimensions.
 func (m CommentOverlayModel) viewportDimensions() (width, height int) {
-	_, oh := m.overlayDimensions()
-	width = m.innerWidth()
-	// Subtract: border(2) + title(2) + diffCtx(~7) + 2 separators(2) + textarea(5) + footer(2) + blanks(3)
-	height = oh - 23
-	if height < 3 {
-		height = 3
-	}
-	return width, height
+	_, height = m.layout()
+	return m.innerWidth(), height
 }
 
 func (m *CommentOverlayModel) refreshContent() {
@@ -288,13 +410,22 @@ func (m *CommentOverlayModel) refreshContent() {
 	m.viewport.SetContent(content)
 }
 
-func (m CommentOverlayModel) renderDiffContext(diffLines []string, targetIdx int) string {
-	if len(diffLines) == 0 {
+// renderDiffContext renders up to maxLines of diffLines, keeping the line at
+// targetIdx in view and truncating each line to width.
+func renderDiffContext(diffLines []string, targetIdx, width, maxLines int) string {
+	if maxLines <= 0 || len(diffLines) == 0 {
 		return ""
 	}
+	start := 0
+	if len(diffLines) > maxLines {
+		start = max(0, min(targetIdx-maxLines/2, len(diffLines)-maxLines))
+	}
+	end := min(len(diffLines), start+maxLines)
+
 	var b strings.Builder
-	for i, line := range diffLines {
-		if i > 0 {
+	for i := start; i < end; i++ {
+		line := ansi.Truncate(diffLines[i], width, "…")
+		if i > start {
 			b.WriteString("\n")
 		}
 		var style lipgloss.Style
@@ -316,7 +447,29 @@ func (m CommentOverlayModel) renderDiffContext(diffLines []string, targetIdx int
 	return b.String()
 }
 
-func (m CommentOverlayModel) renderThreadContent() string {
+// applicableSuggestion returns the first thread's suggestion as an apply
+// request, or nil when there is none or it cannot be applied.
+func (m CommentOverlayModel) applicableSuggestion() *ApplySuggestionMsg {
+	if !m.canApply {
+		return nil
+	}
+	for _, t := range m.ghThreads {
+		s, ok := parseSuggestion(t.Root.Body)
+		base, known := m.suggestionBase[t.Root.ID]
+		start, end := suggestionTarget(t.Root.StartLine, t.Root.Line)
+		// Without every replaced line from the diff, a later push to the
+		// branch couldn't be detected.
+		if !ok || !known || len(base) != end-start+1 {
+			continue
+		}
+		return &ApplySuggestionMsg{Path: t.Root.Path, StartLine: start, Line: end, Original: base, Lines: s.lines}
+	}
+	return nil
+}
+
+// renderThreadContent renders every comment on the line in full, with
+// markdown and reactions; the viewport pages through it.
+func (m *CommentOverlayModel) renderThreadContent() string {
 	var b strings.Builder
 	innerW := m.innerWidth()
 
@@ -330,31 +483,69 @@ func (m CommentOverlayModel) renderThreadContent() string {
 		header := commentBoxHeaderStyle.Render("🤖 Claude AI")
 		b.WriteString(header)
 		b.WriteString("\n")
-		b.WriteString(wordWrapPlain(c.Body, innerW))
+		b.WriteString(m.md.RenderMarkdown(c.Body, innerW))
 		hasContent = true
 	}
 
-	// GitHub threads
-	for _, t := range m.ghThreads {
+	// GitHub threads; outdated ones come last, under their own heading
+	outdatedShown := false
+	for i, t := range m.ghThreads {
 		if hasContent {
 			b.WriteString("\n\n")
 		}
-		// Root
-		header := commentBoxHeaderStyle.Render("💬 @"+t.Root.Author.Login) +
-			commentBoxMetaStyle.Render(" · "+t.Root.CreatedAt.Format("Jan 2 15:04"))
+		if t.Root.Outdated && !outdatedShown {
+			outdatedShown = true
+			b.WriteString(commentBoxOutdatedStyle.Render("── Outdated ──"))
+			b.WriteString("\n")
+		}
+		// Root, marked as the reply target when there is a choice
+		marker := ""
+		if len(m.ghThreads) > 1 && i == m.replyIdx {
+			marker = commentOverlayActiveToggle.Render("▸ ")
+		}
+		header := marker + commentBoxHeaderStyle.Render("💬 @"+t.Root.Author.Login) +
+			commentBoxMetaStyle.Render(" · "+formatTimestamp(t.Root.CreatedAt))
+		if t.Root.Outdated {
+			header += commentBoxOutdatedStyle.Render(" · outdated")
+		}
 		b.WriteString(header)
 		b.WriteString("\n")
-		b.WriteString(wordWrapPlain(t.Root.Body, innerW))
+		// Outdated threads show the original context they were made on,
+		// since the current diff lines above may have changed.
+		if t.Root.Outdated && t.Root.DiffHunk != "" {
+			hunkLines := strings.Split(strings.TrimRight(t.Root.DiffHunk, "\n"), "\n")
+			b.WriteString(commentBoxMetaStyle.Render("Original context:"))
+			b.WriteString("\n")
+			b.WriteString(renderDiffContext(hunkLines, len(hunkLines)-1, innerW, len(hunkLines)))
+			b.WriteString("\n\n")
+		}
+		if s, ok := parseSuggestion(t.Root.Body); ok {
+			b.WriteString(commentBoxMetaStyle.Render("Suggested change:"))
+			b.WriteString("\n")
+			b.WriteString(strings.Join(renderSuggestionDiff(m.suggestionBase[t.Root.ID], s.lines, innerW), "\n"))
+			if s.prose != "" {
+				b.WriteString("\n\n")
+				b.WriteString(m.md.RenderMarkdown(s.prose, innerW))
+			}
+		} else {
+			b.WriteString(m.md.RenderMarkdown(t.Root.Body, innerW))
+		}
+		b.WriteString(renderReactions(t.Root.Reactions))
 
 		// All replies (no trimming in overlay — show full thread)
 		for _, r := range t.Replies {
 			b.WriteString("\n\n")
+			meta := " · " + formatTimestamp(r.CreatedAt)
+			if r.ID < 0 {
+				meta = " · posting..."
+			}
 			replyHeader := commentBoxReplyStyle.Render("  ↳ ") +
 				commentBoxHeaderStyle.Render("@"+r.Author.Login) +
-				commentBoxMetaStyle.Render(" · "+r.CreatedAt.Format("Jan 2 15:04"))
+				commentBoxMetaStyle.Render(meta)
 			b.WriteString(replyHeader)
 			b.WriteString("\n")
-			b.WriteString(wordWrapPlain(r.Body, innerW))
+			b.WriteString(m.md.RenderMarkdown(r.Body, innerW))
+			b.WriteString(renderReactions(r.Reactions))
 		}
 		hasContent = true
 	}
@@ -371,13 +562,13 @@ func (m CommentOverlayModel) renderThreadContent() string {
 		header := commentBoxHeaderStyle.Render("📝 " + source)
 		b.WriteString(header)
 		b.WriteString("\n")
-		b.WriteString(wordWrapPlain(c.Body, innerW))
+		b.WriteString(m.md.RenderMarkdown(c.Body, innerW))
 		hasContent = true
 	}
 
 	if !hasContent {
 		return lipgloss.NewStyle().
-			Foreground(lipgloss.Color("244")).
+			Foreground(theme.Muted).
 			Italic(true).
 			Render("No comments yet. Press i to write one.")
 	}
@@ -388,7 +579,11 @@ func (m CommentOverlayModel) renderThreadContent() string {
 func (m CommentOverlayModel) renderFooter(innerW int) string {
 	var parts []string
 
-	if m.replyTargetID > 0 {
+	if m.replyTargetID() > 0 {
+		if len(m.ghThreads) > 1 {
+			target := m.ghThreads[m.replyIdx].Root.Author.Login
+			parts = append(parts, commentOverlayHintStyle.Render(fmt.Sprintf("↳ @%s (%d/%d)", target, m.replyIdx+1, len(m.ghThreads))))
+		}
 		if m.postImmediately {
 			parts = append(parts, commentOverlayActiveToggle.Render("● post now"))
 			parts = append(parts, commentOverlayInactiveToggle.Render("○ add to review"))
@@ -404,10 +599,23 @@ func (m CommentOverlayModel) renderFooter(innerW int) string {
 	left := strings.Join(parts, " ")
 
 	var right string
-	if m.composing {
+	step := ""
+	if m.total > 1 {
+		step = "[/]: line  "
+	}
+	switch {
+	case m.composing:
 		right = commentOverlayHintStyle.Render("Ctrl+S: submit  Esc: cancel")
-	} else {
-		right = commentOverlayHintStyle.Render("i: reply  Esc: close")
+	case m.drafting:
+		right = commentOverlayHintStyle.Render("Drafting a reply...  Esc: close")
+	case m.applicableSuggestion() != nil:
+		right = commentOverlayHintStyle.Render("a: apply  " + step + "i: reply  Esc: close")
+	case len(m.ghThreads) > 1:
+		right = commentOverlayHintStyle.Render("n/N: thread  " + step + "i: reply  D: draft  Esc: close")
+	case m.replyTargetID() > 0:
+		right = commentOverlayHintStyle.Render(step + "i: reply  D: draft  Esc: close")
+	default:
+		right = commentOverlayHintStyle.Render(step + "i: reply  Esc: close")
 	}
 
 	gap := innerW - lipgloss.Width(left) - lipgloss.Width(right)
@@ -417,6 +625,40 @@ func (m CommentOverlayModel) renderFooter(innerW int) string {
 	return left + strings.Repeat(" ", gap) + right
 }
 
+// reactionEmoji shows GitHub reaction types the way GitHub does.
+var reactionEmoji = map[string]string{
+	"+1": "👍", "-1": "👎", "laugh": "😄", "hooray": "🎉",
+	"confused": "😕", "heart": "❤️", "rocket": "🚀", "eyes": "👀",
+}
+
+// renderReactions renders a comment's reaction counts as a line of their
+// own, or "" when it has none.
+func renderReactions(reactions []github.Reaction) string {
+	if len(reactions) == 0 {
+		return ""
+	}
+	parts := make([]string, 0, len(reactions))
+	for _, r := range reactions {
+		parts = append(parts, fmt.Sprintf("%s %d", reactionEmoji[r.Content], r.Count))
+	}
+	return "\n" + commentBoxMetaStyle.Render(strings.Join(parts, "  "))
+}
+
+// stepCommentOverlay moves the diff cursor to the next or previous
+// commented line and shows its comments in the open overlay.
+func (m App) stepCommentOverlay(delta int) (tea.Model, tea.Cmd) {
+	if _, total := m.diffViewer.jumpToComment(delta); total == 0 {
+		return m, nil
+	}
+	m.diffViewer.refreshContent()
+	msg := m.diffViewer.buildCommentOverlayMsg()
+	if msg == nil {
+		return m, nil
+	}
+	msg.CanApplySuggestions = m.canApplySuggestions()
+	return m, m.commentOverlay.Show(*msg)
+}
+
 // wordWrapPlain wraps text at the given width without any styling.
 func wordWrapPlain(text string, width int) string {
 	if width <= 0 {
@@ -0,0 +1,151 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/shhac/prtea/internal/github"
+)
+
+func replyTestApp(calls *[]string) App {
+	m := App{
+		statusBar:      NewStatusBarModel(),
+		diffViewer:     newTestDiffViewer(80, 40),
+		commentOverlay: NewCommentOverlayModel(),
+		width:          120,
+		height:         40,
+		session:        &PRSession{Owner: "acme", Repo: "api", Number: 7},
+		ghClient:       recordingClient(calls, nil),
+	}
+	m.diffViewer.SetDiff([]github.PRFile{
+		{Filename: "cache.go", Status: "modified", Patch: "@@ -1,2 +1,3 @@\n ctx\n+ttl := 5\n ctx"},
+	})
+	at := time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC)
+	m.diffViewer.SetGitHubInlineComments([]github.InlineComment{
+		{ID: 100, Author: github.User{Login: "bob"}, Body: "Why 5?", Path: "cache.go", Line: 2, CreatedAt: at},
+		{ID: 200, Author: github.User{Login: "carol"}, Body: "Make it configurable", Path: "cache.go", Line: 2, CreatedAt: at.Add(time.Hour)},
+	})
+	model, _ := m.Update(ShowCommentOverlayMsg{Path: "cache.go", Line: 2, GHThreads: m.diffViewer.ghCommentThreads[commentKey("cache.go", 2)]})
+	return model.(App)
+}
+
+func typeKeys(m App, keys ...tea.KeyMsg) (App, tea.Cmd) {
+	var cmd tea.Cmd
+	for _, k := range keys {
+		var model tea.Model
+		model, cmd = m.Update(k)
+		m = model.(App)
+	}
+	return m, cmd
+}
+
+func TestCommentOverlay_RepliesToChosenThreadOptimistically(t *testing.T) {
+	var calls []string
+	m := replyTestApp(&calls)
+
+	m, _ = typeKeys(m,
+		tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("n")},
+		tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("i")},
+		tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("Done")},
+	)
+	m, cmd := typeKeys(m, tea.KeyMsg{Type: tea.KeyCtrlS})
+	reply, ok := cmd().(InlineCommentReplyMsg)
+	if !ok || reply.CommentID != 200 || reply.Body != "Done" {
+		t.Fatalf("reply = %#v, want a reply to carol's thread", reply)
+	}
+	if !m.commentOverlay.IsVisible() {
+		t.Fatal("the overlay should stay open after posting a reply")
+	}
+
+	model, cmd := m.Update(reply)
+	m = model.(App)
+	threads := m.diffViewer.ghCommentThreads[commentKey("cache.go", 2)]
+	if len(threads) != 2 || len(threads[1].Replies) != 1 || threads[1].Replies[0].Author.Login != "alice" {
+		t.Fatalf("threads = %+v, want alice's reply on carol's thread", threads)
+	}
+	if !strings.Contains(m.commentOverlay.renderThreadContent(), "posting...") {
+		t.Error("the overlay should show the reply while it posts")
+	}
+	if cmd == nil {
+		t.Fatal("the reply should be posted")
+	}
+
+	tempID := threads[1].Replies[0].ID
+	model, _ = m.Update(InlineCommentReplyDoneMsg{ReplyID: tempID, Err: errors.New("boom")})
+	m = model.(App)
+	if threads := m.diffViewer.ghCommentThreads[commentKey("cache.go", 2)]; len(threads[1].Replies) != 0 {
+		t.Error("a failed reply should be taken back out of the thread")
+	}
+	if strings.Contains(m.commentOverlay.renderThreadContent(), "posting...") {
+		t.Error("a failed reply should be removed from the overlay")
+	}
+}
+
+func TestReplyToCommentCmd(t *testing.T) {
+	var calls []string
+	m := replyTestApp(&calls)
+	done := replyToCommentCmd(m.ghClient, "acme", "api", 7, 200, -1, "Done")().(InlineCommentReplyDoneMsg)
+	if done.Err != nil || done.ReplyID != -1 {
+		t.Fatalf("done = %#v", done)
+	}
+	if len(calls) != 1 || !strings.Contains(calls[0], "pulls/7/comments/200/replies") {
+		t.Errorf("calls = %q", calls)
+	}
+}
+
+func TestCommentOverlay_FullThreadWithReactions(t *testing.T) {
+	var calls []string
+	m := replyTestApp(&calls)
+	at := time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC)
+	var replies []github.InlineComment
+	for i := range 5 {
+		replies = append(replies, github.InlineComment{ID: int64(300 + i), InReplyToID: 100, Author: github.User{Login: "dan"},
+			Body: fmt.Sprintf("reply **%d**", i), Path: "cache.go", Line: 2, CreatedAt: at})
+	}
+	m.commentOverlay.Show(ShowCommentOverlayMsg{Path: "cache.go", Line: 2, GHThreads: []ghCommentThread{{
+		Root:    github.InlineComment{ID: 100, Author: github.User{Login: "bob"}, Body: "Why 5?", Reactions: []github.Reaction{{Content: "+1", Count: 2}, {Content: "eyes", Count: 1}}},
+		Replies: replies,
+	}}})
+
+	out := ansi.Strip(m.commentOverlay.renderThreadContent())
+	if !strings.Contains(out, "👍 2  👀 1") {
+		t.Errorf("reactions missing:\n%s", out)
+	}
+	for i := range 5 {
+		if !strings.Contains(out, fmt.Sprintf("reply %d", i)) {
+			t.Errorf("reply %d missing or not rendered as markdown:\n%s", i, out)
+		}
+	}
+	if strings.Contains(out, "more replies") {
+		t.Error("the overlay should not trim replies")
+	}
+}
+
+func TestCommentOverlay_StepsBetweenCommentedLines(t *testing.T) {
+	m := commentNavTestApp()
+	m.commentOverlay = NewCommentOverlayModel()
+	m.width, m.height = 120, 40
+	m = pressKeys(t, m, "]", "c")
+	model, _ := m.Update(*m.diffViewer.buildCommentOverlayMsg())
+	m = model.(App)
+	first := m.commentOverlay.targetPath + fmt.Sprint(m.commentOverlay.targetLine)
+	if !m.commentOverlay.IsVisible() || m.commentOverlay.position != 1 || m.commentOverlay.total < 2 {
+		t.Fatalf("overlay at %s %d/%d, want the first commented line", first, m.commentOverlay.position, m.commentOverlay.total)
+	}
+
+	model, cmd := m.Update(keyMsg("]"))
+	m = model.(App)
+	if cmd == nil {
+		t.Fatal("] should step the overlay")
+	}
+	model, _ = m.Update(cmd())
+	m = model.(App)
+	if m.commentOverlay.position != 2 || m.commentOverlay.targetPath+fmt.Sprint(m.commentOverlay.targetLine) == first {
+		t.Errorf("overlay at position %d, want the second commented line", m.commentOverlay.position)
+	}
+}
@@ -0,0 +1,300 @@
+package ui
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/shhac/prtea/internal/claude"
+)
+
+// aiSeverityRank orders AI review severities, most severe highest. An
+// unknown or missing severity counts as a warning, so it is not held back.
+func aiSeverityRank(severity string) int {
+	switch severity {
+	case "critical":
+		return 3
+	case "suggestion":
+		return 1
+	case "praise":
+		return 0
+	}
+	return 2
+}
+
+// autoAcceptedAIComments returns the AI review comments at or above the
+// minimum severity (aiReviewMinSeverity); the rest wait for triage.
+func autoAcceptedAIComments(comments []claude.InlineReviewComment, minSeverity string) []claude.InlineReviewComment {
+	if minSeverity == "" {
+		return comments
+	}
+	var accepted []claude.InlineReviewComment
+	for _, c := range comments {
+		if aiSeverityRank(c.Severity) >= aiSeverityRank(minSeverity) {
+			accepted = append(accepted, c)
+		}
+	}
+	return accepted
+}
+
+// aiReviewMinSeverity returns the severity below which AI review comments
+// wait for triage, or "" when all are accepted.
+func (m App) aiReviewMinSeverity() string {
+	if m.appConfig == nil {
+		return ""
+	}
+	return m.appConfig.AIReviewMinSeverity
+}
+
+// sameAIComment reports whether a pending comment is the AI review comment c.
+func sameAIComment(p PendingInlineComment, c claude.InlineReviewComment) bool {
+	return p.Source == "ai" && p.Path == c.Path && p.Line == c.Line && p.Body == c.Body
+}
+
+// commentTriageItems lists the last AI review's comments by severity, then
+// file and line, each on when it is pending.
+func (m App) commentTriageItems() []CommentTriageItem {
+	items := make([]CommentTriageItem, 0, len(m.session.AIReviewComments))
+	for _, c := range m.session.AIReviewComments {
+		on := slices.ContainsFunc(m.session.PendingInlineComments, func(p PendingInlineComment) bool {
+			return sameAIComment(p, c)
+		})
+		items = append(items, CommentTriageItem{Comment: c, On: on})
+	}
+	slices.SortStableFunc(items, func(a, b CommentTriageItem) int {
+		if r := aiSeverityRank(b.Comment.Severity) - aiSeverityRank(a.Comment.Severity); r != 0 {
+			return r
+		}
+		return cmp.Or(cmp.Compare(a.Comment.Path, b.Comment.Path), cmp.Compare(a.Comment.Line, b.Comment.Line))
+	})
+	return items
+}
+
+// openCommentTriage shows the last AI review's comments for triage.
+func (m App) openCommentTriage() (tea.Model, tea.Cmd) {
+	if m.session == nil || len(m.session.AIReviewComments) == 0 {
+		return m, m.statusBar.SetTemporaryMessage("No AI review comments to triage — run :review first", 3*time.Second)
+	}
+	return m.handleConfigMsg(ShowCommentTriageMsg{Items: m.commentTriageItems()})
+}
+
+// applyCommentTriage adds the accepted AI comments to the pending comments
+// and drops the rejected ones. Comments edited since the review, and lines
+// the user has commented on, are left alone.
+func (m App) applyCommentTriage(items []CommentTriageItem) (tea.Model, tea.Cmd) {
+	s := m.session
+	if s == nil {
+		return m, nil
+	}
+	kept := s.PendingInlineComments[:0]
+	for _, p := range s.PendingInlineComments {
+		if !slices.ContainsFunc(items, func(item CommentTriageItem) bool { return !item.On && sameAIComment(p, item.Comment) }) {
+			kept = append(kept, p)
+		}
+	}
+	s.PendingInlineComments = kept
+
+	userLines := make(map[string]bool)
+	for _, p := range s.PendingInlineComments {
+		if p.Source != "ai" {
+			userLines[commentKey(p.Path, p.Line)] = true
+		}
+	}
+	accepted := 0
+	for _, item := range items {
+		if !item.On {
+			continue
+		}
+		accepted++
+		pending := slices.ContainsFunc(s.PendingInlineComments, func(p PendingInlineComment) bool { return sameAIComment(p, item.Comment) })
+		if !pending && !userLines[commentKey(item.Comment.Path, item.Comment.Line)] {
+			s.PendingInlineComments = append(s.PendingInlineComments, PendingInlineComment{InlineReviewComment: item.Comment, Source: "ai"})
+		}
+	}
+	m.diffViewer.SetPendingInlineComments(s.PendingInlineComments)
+	m.syncPendingCommentCount()
+	msg := fmt.Sprintf("AI comments: %d accepted, %d rejected", accepted, len(items)-accepted)
+	return m, m.statusBar.SetTemporaryMessage(msg, 3*time.Second)
+}
+
+// CommentTriageItem is one AI review comment in the triage view.
+type CommentTriageItem struct {
+	Comment claude.InlineReviewComment
+	On      bool // accepted: kept as a pending comment
+}
+
+// CommentTriageModel is an overlay listing an AI review's inline comments
+// grouped by severity, to accept or reject each before it is submitted.
+type CommentTriageModel struct {
+	items   []CommentTriageItem
+	cursor  int
+	width   int
+	height  int
+	visible bool
+}
+
+// NewCommentTriageModel creates a comment triage overlay.
+func NewCommentTriageModel() CommentTriageModel {
+	return CommentTriageModel{}
+}
+
+// Show opens the overlay with the given comments, sorted by severity.
+func (m *CommentTriageModel) Show(items []CommentTriageItem) {
+	m.items = append([]CommentTriageItem(nil), items...)
+	m.cursor = 0
+	m.visible = true
+}
+
+// IsVisible returns whether the overlay is currently shown.
+func (m CommentTriageModel) IsVisible() bool {
+	return m.visible
+}
+
+// SetSize updates the terminal dimensions used to place the overlay.
+func (m *CommentTriageModel) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+}
+
+func (m CommentTriageModel) Update(msg tea.Msg) (CommentTriageModel, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return m, nil
+	}
+	switch keyMsg.String() {
+	case "j", "down":
+		if m.cursor < len(m.items)-1 {
+			m.cursor++
+		}
+	case "k", "up":
+		if m.cursor > 0 {
+			m.cursor--
+		}
+	case " ", "x":
+		if m.cursor < len(m.items) {
+			m.items[m.cursor].On = !m.items[m.cursor].On
+		}
+	case "a":
+		// Accept the cursor's whole severity group, or reject it when all
+		// of it is accepted.
+		if m.cursor >= len(m.items) {
+			return m, nil
+		}
+		rank := aiSeverityRank(m.items[m.cursor].Comment.Severity)
+		all := true
+		for _, item := range m.items {
+			if aiSeverityRank(item.Comment.Severity) == rank && !item.On {
+				all = false
+			}
+		}
+		for i := range m.items {
+			if aiSeverityRank(m.items[i].Comment.Severity) == rank {
+				m.items[i].On = !all
+			}
+		}
+	case "enter":
+		m.visible = false
+		items := m.items
+		return m, func() tea.Msg { return CommentTriageClosedMsg{Applied: true, Items: items} }
+	case "esc", "q":
+		m.visible = false
+		return m, func() tea.Msg { return CommentTriageClosedMsg{} }
+	}
+	return m, nil
+}
+
+// severityGroupLabels names each severity group's heading, by rank.
+var severityGroupLabels = map[int]string{3: "critical", 2: "warning", 1: "suggestion"}
+
+func (m CommentTriageModel) View() string {
+	if !m.visible {
+		return ""
+	}
+	overlayW := min(max(50, m.width*2/3), m.width)
+	innerW := max(1, overlayW-4)
+
+	accepted := 0
+	for _, item := range m.items {
+		if item.On {
+			accepted++
+		}
+	}
+	title := helpTitleStyle.Render(" Triage AI comments ")
+	lines := []string{lipgloss.PlaceHorizontal(innerW, lipgloss.Left, title),
+		dimStyle.Render(fitWidth(fmt.Sprintf("%d of %d accepted as pending comments; rejected ones are dropped.", accepted, len(m.items)), innerW)), ""}
+
+	// Rows with severity headings; keep the cursor row on screen.
+	var rows []string
+	cursorRow := 0
+	for i, item := range m.items {
+		rank := aiSeverityRank(item.Comment.Severity)
+		if i == 0 || rank != aiSeverityRank(m.items[i-1].Comment.Severity) {
+			n := 0
+			for _, other := range m.items {
+				if aiSeverityRank(other.Comment.Severity) == rank {
+					n++
+				}
+			}
+			label := severityGroupLabels[rank]
+			style, ok := severityStyles[label]
+			if !ok {
+				style = defaultSeverityStyle
+			}
+			rows = append(rows, style.Render(fmt.Sprintf("%s (%d)", label, n)))
+		}
+		if i == m.cursor {
+			cursorRow = len(rows)
+		}
+		marker := "  "
+		if i == m.cursor {
+			marker = "▸ "
+		}
+		box := "[ ]"
+		if item.On {
+			box = "[x]"
+		}
+		loc := fmt.Sprintf("%s:%d", item.Comment.Path, item.Comment.Line)
+		text := ansi.Truncate(marker+box+" "+loc+"  "+firstLine(item.Comment.Body), innerW, "…")
+		switch {
+		case i == m.cursor:
+			text = boldStyle.Render(text)
+		case !item.On:
+			text = dimStyle.Render(text)
+		}
+		rows = append(rows, text)
+	}
+
+	maxRows := max(1, m.height-14)
+	start := 0
+	if cursorRow >= maxRows {
+		start = cursorRow - maxRows + 1
+	}
+	for i := start; i < len(rows) && i < start+maxRows; i++ {
+		lines = append(lines, rows[i])
+	}
+
+	// The whole comment under the cursor, a few lines of it
+	if m.cursor < len(m.items) {
+		body := strings.Split(wordWrapPlain(strings.TrimSpace(m.items[m.cursor].Comment.Body), innerW), "\n")
+		if len(body) > 4 {
+			body = append(body[:4], "…")
+		}
+		lines = append(lines, "", dimItalicStyle.Render(strings.Join(body, "\n")))
+	}
+
+	footer := helpFooterStyle.Render("Space accept/reject · a whole group · Enter apply · Esc cancel")
+	lines = append(lines, "", fitWidth(lipgloss.PlaceHorizontal(innerW, lipgloss.Center, footer), innerW))
+
+	overlayStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(theme.Accent).
+		Padding(0, 1).
+		Width(overlayW - 2)
+
+	return placeOverlay(m.width, m.height, overlayStyle.Render(strings.Join(lines, "\n")))
+}
@@ -0,0 +1,54 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/x/ansi"
+	"github.com/shhac/prtea/internal/claude"
+	"github.com/shhac/prtea/internal/config"
+)
+
+func TestCommentTriage_HoldsLowSeverityUntilAccepted(t *testing.T) {
+	m := commentNavTestApp()
+	m.appConfig = &config.Config{AIReviewMinSeverity: "warning"}
+	model, _ := m.Update(AIReviewCompleteMsg{PRNumber: 1, Result: &claude.ReviewAnalysis{Comments: []claude.InlineReviewComment{
+		{Path: "b.go", Line: 2, Body: "Rename", Severity: "suggestion"},
+		{Path: "a.go", Line: 2, Body: "Nil deref", Severity: "critical"},
+		{Path: "a.go", Line: 3, Body: "Leaks", Severity: "warning"},
+	}}})
+	m = model.(App)
+	if got := len(m.session.PendingInlineComments); got != 2 {
+		t.Fatalf("want the critical and warning comments pending, got %d", got)
+	}
+
+	items := m.commentTriageItems()
+	var order []string
+	for _, item := range items {
+		order = append(order, item.Comment.Severity)
+	}
+	if strings.Join(order, ",") != "critical,warning,suggestion" || items[2].On {
+		t.Fatalf("want items by severity with the suggestion held back: %+v", items)
+	}
+
+	m.commentTriage.SetSize(100, 40)
+	m.commentTriage.Show(items)
+	if out := ansi.Strip(m.commentTriage.View()); !strings.Contains(out, "suggestion (1)") || !strings.Contains(out, "[ ] b.go:2  Rename") {
+		t.Errorf("expected severity groups with file:line rows:\n%s", out)
+	}
+	// Reject the warning, accept the suggestion.
+	for _, k := range []string{"j", " ", "j", " "} {
+		m.commentTriage, _ = m.commentTriage.Update(keyMsg(k))
+	}
+	_, cmd := m.commentTriage.Update(keyMsg("enter"))
+	model, _ = m.applyCommentTriage(cmd().(CommentTriageClosedMsg).Items)
+	m = model.(App)
+
+	var bodies []string
+	for _, p := range m.session.PendingInlineComments {
+		bodies = append(bodies, p.Body)
+	}
+	if strings.Join(bodies, ",") != "Nil deref,Rename" {
+		t.Errorf("pending after triage = %v", bodies)
+	}
+}
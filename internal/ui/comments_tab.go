@@ -2,6 +2,7 @@ package ui
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/charmbracelet/lipgloss"
@@ -17,6 +18,64 @@ type CommentsTabModel struct {
 	posting        bool
 	cache          string
 	cacheWidth     int
+	links          []link // numbered links of the rendered comments
+}
+
+// threadContextLines is how much of a review comment's diff hunk the
+// Comments tab shows above it, ending at the commented line.
+const threadContextLines = 6
+
+// reviewThreads groups the current or the outdated review comments into
+// threads, ordered by file and line.
+func reviewThreads(inline []github.InlineComment, outdated bool) []ghCommentThread {
+	var threads []ghCommentThread
+	index := make(map[int64]int)
+	for _, c := range inline {
+		if c.Outdated == outdated && c.InReplyToID == 0 {
+			index[c.ID] = len(threads)
+			threads = append(threads, ghCommentThread{Root: c})
+		}
+	}
+	for _, c := range inline {
+		if i, ok := index[c.InReplyToID]; ok && c.InReplyToID != 0 {
+			threads[i].Replies = append(threads[i].Replies, c)
+		}
+	}
+	for _, th := range threads {
+		sort.SliceStable(th.Replies, func(i, j int) bool { return th.Replies[i].CreatedAt.Before(th.Replies[j].CreatedAt) })
+	}
+	sort.SliceStable(threads, func(i, j int) bool {
+		a, b := threads[i].Root, threads[j].Root
+		if a.Path != b.Path {
+			return a.Path < b.Path
+		}
+		return a.Line < b.Line
+	})
+	return threads
+}
+
+// renderReviewThread renders a review thread under its file:line, which
+// the panel's file links can jump to, and the diff it was written against.
+func (t *CommentsTabModel) renderReviewThread(th ghCommentThread, width int, md *MarkdownRenderer, links *linkHints) string {
+	var b strings.Builder
+	b.WriteString(contentAuthorStyle.Render(fmt.Sprintf("%s:%d", th.Root.Path, th.Root.Line)))
+	b.WriteString("\n")
+	if hunk := strings.TrimRight(th.Root.DiffHunk, "\n"); hunk != "" {
+		lines := strings.Split(hunk, "\n")
+		b.WriteString(renderDiffContext(lines, len(lines)-1, width, threadContextLines))
+		b.WriteString("\n")
+	}
+	for i, c := range append([]github.InlineComment{th.Root}, th.Replies...) {
+		if i > 0 {
+			b.WriteString(commentBoxReplyStyle.Render("↳ "))
+		}
+		b.WriteString(contentAuthorStyle.Render(c.Author.Login))
+		b.WriteString(dimStyle.Render(" · " + formatTimestamp(c.CreatedAt)))
+		b.WriteString("\n")
+		b.WriteString(md.RenderMarkdown(links.annotate(c.Body), width))
+		b.WriteString("\n")
+	}
+	return b.String()
 }
 
 // SetLoading puts the comments tab into loading state.
@@ -77,7 +136,7 @@ func (t *CommentsTabModel) SetPosting(posting bool) {
 func (t *CommentsTabModel) Render(width int, spinnerView string, md *MarkdownRenderer) string {
 	if t.loading {
 		return lipgloss.NewStyle().
-			Foreground(lipgloss.Color("244")).
+			Foreground(theme.Muted).
 			Padding(1, 0).
 			Render(spinnerView + " Loading comments...")
 	}
@@ -94,6 +153,7 @@ func (t *CommentsTabModel) Render(width int, spinnerView string, md *MarkdownRen
 	}
 
 	var b strings.Builder
+	var links linkHints
 
 	if len(t.comments) > 0 {
 		b.WriteString(sectionHeaderStyle.Render(fmt.Sprintf("Conversation (%d)", len(t.comments))))
@@ -103,23 +163,45 @@ func (t *CommentsTabModel) Render(width int, spinnerView string, md *MarkdownRen
 				b.WriteString("\n")
 			}
 			b.WriteString(contentAuthorStyle.Render(c.Author.Login))
-			b.WriteString(dimStyle.Render(" · " + c.CreatedAt.Format("Jan 2 15:04")))
+			b.WriteString(dimStyle.Render(" · " + formatTimestamp(c.CreatedAt)))
 			b.WriteString("\n")
-			b.WriteString(md.RenderMarkdown(c.Body, width))
+			b.WriteString(md.RenderMarkdown(links.annotate(c.Body), width))
 			b.WriteString("\n")
 		}
 	}
 
-	if len(t.inlineComments) > 0 {
-		if len(t.comments) > 0 {
+	if current := reviewThreads(t.inlineComments, false); len(current) > 0 {
+		if b.Len() > 0 {
 			b.WriteString("\n")
 		}
-		b.WriteString(dimStyle.Render(fmt.Sprintf("%d review comments shown inline in diff", len(t.inlineComments))))
+		b.WriteString(sectionHeaderStyle.Render(fmt.Sprintf("Review comments (%d)", len(current))))
+		b.WriteString(dimStyle.Render("  n/N pick a file:line · Enter jumps to it"))
 		b.WriteString("\n")
+		for i, th := range current {
+			if i > 0 {
+				b.WriteString("\n")
+			}
+			b.WriteString(t.renderReviewThread(th, width, md, &links))
+		}
+	}
+
+	if outdated := reviewThreads(t.inlineComments, true); len(outdated) > 0 {
+		if b.Len() > 0 {
+			b.WriteString("\n")
+		}
+		b.WriteString(sectionHeaderStyle.Render(fmt.Sprintf("Outdated (%d)", len(outdated))))
+		b.WriteString("\n")
+		for i, th := range outdated {
+			if i > 0 {
+				b.WriteString("\n")
+			}
+			b.WriteString(t.renderReviewThread(th, width, md, &links))
+		}
 	}
 
 	result := b.String()
 	t.cache = result
 	t.cacheWidth = width
+	t.links = links.links
 	return result
 }
@@ -0,0 +1,112 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/shhac/prtea/internal/github"
+)
+
+func TestCommentsTab_OutdatedSection(t *testing.T) {
+	at := time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC)
+	var tab CommentsTabModel
+	tab.SetComments(nil, []github.InlineComment{
+		{ID: 1, Author: github.User{Login: "bob"}, Body: "Current", Path: "a.go", Line: 3},
+		{ID: 2, Author: github.User{Login: "carol"}, Body: "Rename this", Path: "z.go", Line: 9, Outdated: true, CreatedAt: at,
+			DiffHunk: "@@ -1,3 +1,3 @@\n ctx\n-old := 1\n+tmp := 1"},
+		{ID: 3, Author: github.User{Login: "dave"}, Body: "Done", Path: "z.go", Line: 9, Outdated: true, InReplyToID: 2, CreatedAt: at.Add(time.Hour)},
+		{ID: 4, Author: github.User{Login: "erin"}, Body: "Typo", Path: "b.go", Line: 1, Outdated: true, CreatedAt: at},
+	})
+
+	out := ansi.Strip(tab.Render(80, "", &MarkdownRenderer{}))
+	if !strings.Contains(out, "Review comments (1)") || strings.Index(out, "a.go:3") > strings.Index(out, "Outdated") {
+		t.Errorf("the current comment should be listed before the outdated ones:\n%s", out)
+	}
+	if !strings.Contains(out, "Outdated (2)") {
+		t.Fatalf("expected an Outdated section with both threads:\n%s", out)
+	}
+	b, z := strings.Index(out, "b.go:1"), strings.Index(out, "z.go:9")
+	if b < 0 || z < b {
+		t.Errorf("threads should be listed by file:\n%s", out)
+	}
+	for _, want := range []string{"+tmp := 1", "Rename this", "↳ dave", "Done"} {
+		if !strings.Contains(out[z:], want) {
+			t.Errorf("z.go thread is missing %q:\n%s", want, out[z:])
+		}
+	}
+}
+
+func TestCommentOverlay_OutdatedThreadsUnderHeading(t *testing.T) {
+	o := NewCommentOverlayModel()
+	o.SetSize(120, 40)
+	o.Show(ShowCommentOverlayMsg{Path: "a.go", Line: 3, GHThreads: []ghCommentThread{
+		{Root: github.InlineComment{ID: 1, Author: github.User{Login: "old"}, Body: "Was wrong", Outdated: true, DiffHunk: "@@ -1 +1 @@\n+x"}},
+		{Root: github.InlineComment{ID: 2, Author: github.User{Login: "new"}, Body: "Still wrong"}},
+	}})
+
+	out := ansi.Strip(o.renderThreadContent())
+	heading, current, outdated := strings.Index(out, "Outdated ──"), strings.Index(out, "@new"), strings.Index(out, "@old")
+	if heading < 0 || !(current < heading && heading < outdated) {
+		t.Errorf("want current threads, then the Outdated heading, then outdated ones:\n%s", out)
+	}
+	if o.replyTargetID() != 2 {
+		t.Errorf("reply target = %d, want the current thread", o.replyTargetID())
+	}
+}
+
+func TestCommentsTab_JumpsToReviewComment(t *testing.T) {
+	m := NewChatPanelModel()
+	m.SetSize(80, 40)
+	m.SetCitationFiles([]string{"internal/cache.go"})
+	m.SetActiveTab(ChatTabComments)
+	m.SetComments(nil, []github.InlineComment{
+		{ID: 1, Author: github.User{Login: "bob"}, Body: "Why 5?", Path: "internal/cache.go", Line: 12,
+			DiffHunk: "@@ -10,2 +10,3 @@\n ctx\n+ttl := 5"},
+	})
+	out := ansi.Strip(m.viewport.View())
+	if hunk, body := strings.Index(out, "+ttl := 5"), strings.Index(out, "Why 5?"); hunk < 0 || hunk > body {
+		t.Errorf("the diff snippet should come above the comment:\n%s", out)
+	}
+
+	m, _ = m.Update(keyMsg("n"))
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil {
+		t.Fatal("Enter on a picked file:line should jump")
+	}
+	if jump, ok := cmd().(CitationJumpMsg); !ok || jump.Path != "internal/cache.go" || jump.Line != 12 {
+		t.Errorf("jump = %#v", jump)
+	}
+}
+
+func TestCommentOverlay_DraftReplyPrefillsInput(t *testing.T) {
+	o := NewCommentOverlayModel()
+	o.SetSize(120, 40)
+	o.Show(ShowCommentOverlayMsg{Path: "a.go", Line: 3, DiffLines: []string{" ctx", "+ttl := 5"}, TargetLineInCtx: 1,
+		GHThreads: []ghCommentThread{{
+			Root:    github.InlineComment{ID: 7, Author: github.User{Login: "bob"}, Body: "Why 5?"},
+			Replies: []github.InlineComment{{ID: 8, Author: github.User{Login: "me"}, Body: "Seconds."}},
+		}}})
+
+	o, cmd := o.Update(keyMsg("D"))
+	if cmd == nil {
+		t.Fatal("D should ask for a draft")
+	}
+	draft, ok := cmd().(DraftReplyMsg)
+	if !ok || draft.CommentID != 7 || !strings.Contains(draft.Thread, "@bob: Why 5?") || !strings.Contains(draft.Thread, "@me: Seconds.") ||
+		!strings.Contains(draft.Diff, "> +ttl := 5") {
+		t.Fatalf("draft request = %#v", draft)
+	}
+
+	o.SetReplyDraft(9, "Wrong thread")
+	if o.composing {
+		t.Error("a draft for another thread should be ignored")
+	}
+	o, _ = o.Update(keyMsg("D"))
+	o.SetReplyDraft(7, "It's seconds; I'll name it ttlSeconds.\n")
+	if !o.composing || o.textarea.Value() != "It's seconds; I'll name it ttlSeconds." {
+		t.Errorf("want the draft in the reply input, got composing=%v %q", o.composing, o.textarea.Value())
+	}
+}
@@ -0,0 +1,102 @@
+package ui
+
+import (
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+)
+
+// compatOutput is compatibility mode (compatMode): the view is mapped to
+// ASCII as with --no-color, but keeps color, limited to the 16 basic ANSI
+// colors. Meant for terminals that garble anything more, such as the Linux
+// console or SSH sessions without a UTF-8 locale.
+var compatOutput bool
+
+// compatForced is set by WithCompat and overrides compatMode.
+var compatForced bool
+
+// startupProfile is the terminal's detected color profile, restored when
+// compatibility mode is turned off.
+var startupProfile = sync.OnceValue(lipgloss.ColorProfile)
+
+// WithCompat turns compatibility mode on whatever compatMode says.
+func WithCompat() AppOption {
+	return func(a *App) {
+		compatForced = true
+		if a.appConfig != nil {
+			applyConfigTheme(a.appConfig)
+		}
+	}
+}
+
+// compatWanted resolves compatMode: "on", "off", or "auto" (or "") to
+// detect a limited terminal.
+func compatWanted(mode string) bool {
+	if compatForced {
+		return true
+	}
+	switch strings.ToLower(mode) {
+	case "on":
+		return true
+	case "off":
+		return false
+	}
+	return limitedTerminal(startupProfile(), os.Getenv)
+}
+
+// limitedTerminal reports whether the terminal only has the 16 basic colors,
+// or the locale isn't UTF-8 so box drawing and emoji come out garbled. An
+// unset locale is given the benefit of the doubt.
+func limitedTerminal(profile termenv.Profile, getenv func(string) string) bool {
+	if profile == termenv.ANSI {
+		return true
+	}
+	for _, name := range []string{"LC_ALL", "LC_CTYPE", "LANG"} {
+		if v := strings.ToLower(getenv(name)); v != "" {
+			return !strings.Contains(v, "utf-8") && !strings.Contains(v, "utf8")
+		}
+	}
+	return false
+}
+
+// setCompat switches compatibility mode, holding the color profile to 16
+// colors while it is on so theme color overrides degrade too. --no-color
+// already has the narrowest profile and is left alone.
+func setCompat(on bool) {
+	compatOutput = on
+	if plainOutput {
+		return
+	}
+	profile := startupProfile()
+	if on && profile < termenv.ANSI {
+		profile = termenv.ANSI
+	}
+	lipgloss.SetColorProfile(profile)
+}
+
+// compatDarkTheme and compatLightTheme use only the 16 basic ANSI colors,
+// which the terminal's own palette keeps legible.
+var compatDarkTheme = Theme{
+	Name: "compat", Dark: true,
+	Text: "7", Muted: "8", MutedHi: "7", Subtle: "8", Faint: "8",
+	Accent: "4", Focus: "5", Info: "6",
+	Success: "2", Warning: "3", WarningHi: "11", Error: "1",
+	Highlight: "3", HighlightHi: "11", AI: "6", AIHi: "14",
+	OnAccent: "15", Inverse: "0",
+	Surface: "0", CursorBg: "4", SelectionBg: "5",
+	SearchMatchBg: "3", SearchCurrentBg: "11",
+}
+
+var compatLightTheme = Theme{
+	Name: "compat",
+	Text: "0", Muted: "8", MutedHi: "0", Subtle: "8", Faint: "7",
+	Accent: "4", Focus: "5", Info: "6",
+	Success: "2", Warning: "3", WarningHi: "1", Error: "1",
+	Highlight: "3", HighlightHi: "5", AI: "6", AIHi: "4",
+	OnAccent: "15", Inverse: "15",
+	Surface: "7", CursorBg: "14", SelectionBg: "12",
+	SearchMatchBg: "11", SearchCurrentBg: "3",
+}
@@ -0,0 +1,64 @@
+package ui
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/muesli/termenv"
+)
+
+func TestLimitedTerminal(t *testing.T) {
+	env := func(vars map[string]string) func(string) string {
+		return func(name string) string { return vars[name] }
+	}
+	tests := []struct {
+		name    string
+		profile termenv.Profile
+		vars    map[string]string
+		want    bool
+	}{
+		{"256 colors, UTF-8", termenv.ANSI256, map[string]string{"LANG": "en_US.UTF-8"}, false},
+		{"16 colors", termenv.ANSI, map[string]string{"LANG": "en_US.UTF-8"}, true},
+		{"C locale", termenv.TrueColor, map[string]string{"LANG": "C"}, true},
+		{"latin-1 locale", termenv.ANSI256, map[string]string{"LC_CTYPE": "de_DE.ISO-8859-1", "LANG": "de_DE.UTF-8"}, true},
+		{"LC_ALL wins", termenv.ANSI256, map[string]string{"LC_ALL": "en_GB.utf8", "LANG": "C"}, false},
+		{"no locale", termenv.ANSI256, nil, false},
+	}
+	for _, tt := range tests {
+		if got := limitedTerminal(tt.profile, env(tt.vars)); got != tt.want {
+			t.Errorf("%s: limitedTerminal = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestCompatWanted(t *testing.T) {
+	if !compatWanted("on") || compatWanted("off") {
+		t.Error("on and off should override detection")
+	}
+	compatForced = true
+	t.Cleanup(func() { compatForced = false })
+	if !compatWanted("off") {
+		t.Error("--compat should override compatMode")
+	}
+}
+
+func TestResolveTheme_Compat(t *testing.T) {
+	dark, err := ResolveTheme("compat", map[string]string{"accent": "12"}, func() bool { return true })
+	if err != nil {
+		t.Fatal(err)
+	}
+	if dark.Text != compatDarkTheme.Text || dark.Accent != "12" {
+		t.Errorf("dark compat theme = %+v", dark)
+	}
+	if light, _ := ResolveTheme("compat", nil, func() bool { return false }); light != compatLightTheme {
+		t.Errorf("light background should get the light compat palette, got %+v", light)
+	}
+	// Every color is one of the 16 basic ones.
+	for _, th := range []Theme{compatDarkTheme, compatLightTheme} {
+		for name, c := range th.slots() {
+			if n, err := strconv.Atoi(string(*c)); err != nil || n < 0 || n > 15 {
+				t.Errorf("%s: %s = %q is not a basic ANSI color", th.Name, name, *c)
+			}
+		}
+	}
+}
@@ -0,0 +1,155 @@
+package ui
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/shhac/prtea/internal/config"
+)
+
+// secretConfigKeys are masked by ConfigListing.
+var secretConfigKeys = []string{"githubToken", "openaiApiKey", "webhookUrl"}
+
+// configField returns the field of cfg stored under key in the config file.
+func configField(cfg *config.Config, key string) (reflect.Value, error) {
+	v := reflect.ValueOf(cfg).Elem()
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		name, _, _ := strings.Cut(t.Field(i).Tag.Get("json"), ",")
+		if name == key {
+			return v.Field(i), nil
+		}
+	}
+	return reflect.Value{}, fmt.Errorf("unknown config key %q (prtea config list shows them all)", key)
+}
+
+// ConfigKeys returns the config file's keys, in the order of the Config
+// struct.
+func ConfigKeys() []string {
+	t := reflect.TypeOf(config.Config{})
+	keys := make([]string, 0, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		if name, _, _ := strings.Cut(t.Field(i).Tag.Get("json"), ","); name != "" && name != "-" {
+			keys = append(keys, name)
+		}
+	}
+	return keys
+}
+
+// GetConfigValue returns the value of a config key as JSON.
+func GetConfigValue(cfg *config.Config, key string) (string, error) {
+	field, err := configField(cfg, key)
+	if err != nil {
+		return "", err
+	}
+	data, err := json.Marshal(field.Interface())
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal %s: %w", key, err)
+	}
+	return string(data), nil
+}
+
+// SetConfigValue sets a config key from its command-line form: strings as
+// is, anything else as JSON (true, 30000, ["right"]). Values the settings
+// panel offers are held to its bounds and choices.
+func SetConfigValue(cfg *config.Config, key, value string) error {
+	field, err := configField(cfg, key)
+	if err != nil {
+		return err
+	}
+	parsed := reflect.New(field.Type())
+	if field.Kind() == reflect.String {
+		parsed.Elem().SetString(value)
+	} else if err := json.Unmarshal([]byte(value), parsed.Interface()); err != nil {
+		return fmt.Errorf("invalid value for %s: want %s", key, jsonTypeName(field.Type()))
+	}
+	if err := validateSetting(key, parsed.Elem()); err != nil {
+		return err
+	}
+	field.Set(parsed.Elem())
+	return nil
+}
+
+// ValidateConfig checks every key the settings panel offers against its
+// bounds and choices, e.g. after the config file was edited by hand.
+func ValidateConfig(cfg *config.Config) error {
+	var problems []string
+	for _, item := range settingsSchema {
+		if item.key == "" {
+			continue
+		}
+		field, err := configField(cfg, item.key)
+		if err != nil {
+			return err // settingsSchema names a key Config lacks
+		}
+		if err := validateSetting(item.key, field); err != nil {
+			problems = append(problems, err.Error())
+		}
+	}
+	if len(problems) > 0 {
+		return fmt.Errorf("%s", strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+// validateSetting checks a value for key against the settings panel's
+// entry for it, if any. Zero values are fine: they mean the default.
+func validateSetting(key string, v reflect.Value) error {
+	i := slices.IndexFunc(settingsSchema, func(item settingItem) bool { return item.key == key })
+	if i < 0 || v.IsZero() {
+		return nil
+	}
+	item := settingsSchema[i]
+	switch item.kind {
+	case settingNumber:
+		minVal, maxVal, unit := item.min, item.max, ""
+		if item.unitSec {
+			minVal, maxVal, unit = minVal*1000, maxVal*1000, " ms"
+		}
+		if n := int(v.Int()); n < minVal || n > maxVal {
+			return fmt.Errorf("%s must be between %d and %d%s, got %d", key, minVal, maxVal, unit, n)
+		}
+	case settingSelect:
+		if s := v.String(); !slices.Contains(item.values, s) {
+			return fmt.Errorf("%s must be one of %s, got %q", key, strings.Join(item.values, ", "), s)
+		}
+	}
+	return nil
+}
+
+// jsonTypeName describes a config value type for error messages.
+func jsonTypeName(t reflect.Type) string {
+	switch t.Kind() {
+	case reflect.Bool:
+		return "true or false"
+	case reflect.Int, reflect.Int64:
+		return "a whole number"
+	case reflect.Float64:
+		return "a number"
+	case reflect.Slice:
+		return "a JSON array, e.g. [\"a\", \"b\"]"
+	case reflect.Map:
+		return "a JSON object, e.g. {\"key\": \"value\"}"
+	}
+	return "JSON"
+}
+
+// ConfigListing returns every config key with its value as JSON, one per
+// line, with secrets masked.
+func ConfigListing(cfg *config.Config) string {
+	var b strings.Builder
+	for _, key := range ConfigKeys() {
+		value, err := GetConfigValue(cfg, key)
+		if err != nil {
+			continue
+		}
+		if slices.Contains(secretConfigKeys, key) && value != `""` {
+			value = "(set)"
+		}
+		fmt.Fprintf(&b, "%s = %s\n", key, value)
+	}
+	return b.String()
+}
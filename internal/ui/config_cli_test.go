@@ -0,0 +1,74 @@
+package ui
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/shhac/prtea/internal/config"
+)
+
+func TestConfigKeys_CoverSettingsSchema(t *testing.T) {
+	keys := ConfigKeys()
+	for _, item := range settingsSchema {
+		if item.key != "" && !slices.Contains(keys, item.key) {
+			t.Errorf("setting %q names config key %q, which doesn't exist", item.label, item.key)
+		}
+	}
+}
+
+func TestSetConfigValue(t *testing.T) {
+	cfg := &config.Config{}
+	for key, value := range map[string]string{
+		"pollIntervalMs":    "30000",
+		"theme":             "light",
+		"pollEnabled":       "true",
+		"statusBarSegments": `["ai", "pr"]`,
+		"openaiModel":       "gpt-test",
+	} {
+		if err := SetConfigValue(cfg, key, value); err != nil {
+			t.Errorf("set %s %s: %v", key, value, err)
+		}
+	}
+	if cfg.PollInterval != 30000 || cfg.Theme != "light" || !cfg.PollEnabled || cfg.OpenAIModel != "gpt-test" {
+		t.Errorf("config = %+v", cfg)
+	}
+	if got, _ := GetConfigValue(cfg, "statusBarSegments"); got != `["ai","pr"]` {
+		t.Errorf("statusBarSegments = %s", got)
+	}
+
+	for key, value := range map[string]string{
+		"pollIntervalMs": "5000",   // below 10s
+		"theme":          "neon",   // not a theme
+		"pollEnabled":    "yes",    // not JSON
+		"nope":           "1",      // unknown key
+		"workspaceSize":  "[1, 2]", // wrong type
+	} {
+		if err := SetConfigValue(cfg, key, value); err == nil {
+			t.Errorf("set %s %s: expected an error", key, value)
+		}
+	}
+	if cfg.PollInterval != 30000 || cfg.Theme != "light" {
+		t.Error("a rejected value should leave the config unchanged")
+	}
+}
+
+func TestValidateConfig(t *testing.T) {
+	if err := ValidateConfig(&config.Config{}); err != nil {
+		t.Errorf("zero values mean the defaults: %v", err)
+	}
+	err := ValidateConfig(&config.Config{CollapseThreshold: 20, DefaultPRTab: "all"})
+	if err == nil || !strings.Contains(err.Error(), "collapseThreshold") || !strings.Contains(err.Error(), "defaultPRTab") {
+		t.Errorf("err = %v, want both bad settings named", err)
+	}
+}
+
+func TestConfigListing_MasksSecrets(t *testing.T) {
+	out := ConfigListing(&config.Config{GitHubToken: "ghp_secret", PollInterval: 60000})
+	if strings.Contains(out, "ghp_secret") || !strings.Contains(out, "githubToken = (set)") {
+		t.Errorf("token not masked:\n%s", out)
+	}
+	if !strings.Contains(out, `openaiApiKey = ""`) || !strings.Contains(out, "pollIntervalMs = 60000") {
+		t.Errorf("listing:\n%s", out)
+	}
+}
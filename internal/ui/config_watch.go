@@ -0,0 +1,99 @@
+package ui
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/fsnotify/fsnotify"
+	"github.com/shhac/prtea/internal/config"
+)
+
+// configReloadDelay is how long the config file must stay unchanged before
+// it is reloaded, since editors often save in several writes.
+const configReloadDelay = 200 * time.Millisecond
+
+// configFileChangedMsg carries the config reloaded after the config file
+// changed on disk.
+type configFileChangedMsg struct {
+	Config *config.Config
+	Err    error
+}
+
+// newConfigWatcher watches the config file's directory, as editors and
+// config.Save replace the file rather than write to it. Returns nil when
+// the directory can't be watched, e.g. before a config was first saved.
+func newConfigWatcher() *fsnotify.Watcher {
+	w, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil
+	}
+	if err := w.Add(filepath.Dir(config.ConfigFile())); err != nil {
+		w.Close()
+		return nil
+	}
+	return w
+}
+
+// watchConfigCmd returns a command that waits for the config file to change
+// and settle, then reloads it.
+func watchConfigCmd(w *fsnotify.Watcher) tea.Cmd {
+	if w == nil {
+		return nil
+	}
+	path := config.ConfigFile()
+	return func() tea.Msg {
+		if !waitForFileChange(w, path, configReloadDelay) {
+			return nil // watcher closed
+		}
+		cfg, err := config.Load()
+		return configFileChangedMsg{Config: cfg, Err: err}
+	}
+}
+
+// waitForFileChange blocks until path is written, created or renamed over
+// and then left alone for delay. Returns false if the watcher is closed.
+func waitForFileChange(w *fsnotify.Watcher, path string, delay time.Duration) bool {
+	var settled <-chan time.Time
+	for {
+		select {
+		case ev, ok := <-w.Events:
+			if !ok {
+				return false
+			}
+			if filepath.Clean(ev.Name) == path && ev.Op&(fsnotify.Write|fsnotify.Create|fsnotify.Rename) != 0 {
+				settled = time.After(delay)
+			}
+		case _, ok := <-w.Errors:
+			if !ok {
+				return false
+			}
+		case <-settled:
+			return true
+		}
+	}
+}
+
+// sameConfig reports whether two configs would be saved identically, so
+// the reload that follows prtea's own save can be ignored.
+func sameConfig(a, b *config.Config) bool {
+	aj, errA := json.Marshal(a)
+	bj, errB := json.Marshal(b)
+	return errA == nil && errB == nil && string(aj) == string(bj)
+}
+
+// configFileChanged applies a config edited outside prtea through the same
+// path as the settings panel, and keeps watching.
+func (m App) configFileChanged(msg configFileChangedMsg) (tea.Model, tea.Cmd) {
+	watchCmd := watchConfigCmd(m.configWatcher)
+	if msg.Err != nil {
+		return m, tea.Batch(watchCmd, m.statusBar.SetTemporaryMessage(fmt.Sprintf("Config reload failed: %s", msg.Err), 5*time.Second))
+	}
+	if sameConfig(msg.Config, m.appConfig) {
+		return m, watchCmd
+	}
+	model, cmd := m.handleConfigMsg(ConfigChangedMsg{Reloaded: msg.Config})
+	return model, tea.Batch(watchCmd, cmd)
+}
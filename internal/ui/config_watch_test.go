@@ -0,0 +1,84 @@
+package ui
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/shhac/prtea/internal/config"
+)
+
+func TestWaitForFileChange(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.json")
+	w, err := fsnotify.NewWatcher()
+	if err != nil {
+		t.Skipf("fsnotify unavailable: %v", err)
+	}
+	if err := w.Add(dir); err != nil {
+		t.Fatal(err)
+	}
+
+	done := make(chan bool, 1)
+	go func() { done <- waitForFileChange(w, path, 20*time.Millisecond) }()
+
+	// Other files in the directory don't count; a save by rename does.
+	os.WriteFile(filepath.Join(dir, "reviewed.json"), []byte("{}"), 0o644)
+	os.WriteFile(path+".tmp", []byte(`{"pollEnabled": true}`), 0o600)
+	os.Rename(path+".tmp", path)
+
+	select {
+	case changed := <-done:
+		if !changed {
+			t.Error("expected a change to be reported")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("no change reported")
+	}
+
+	go func() { done <- waitForFileChange(w, path, 20*time.Millisecond) }()
+	w.Close()
+	select {
+	case changed := <-done:
+		if changed {
+			t.Error("a closed watcher should stop waiting without a change")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("closing the watcher didn't stop the wait")
+	}
+}
+
+func TestConfigFileChanged(t *testing.T) {
+	m := commentNavTestApp()
+	m.prList = NewPRListModel(TabToReview)
+	m.appConfig = &config.Config{}
+	t.Cleanup(func() { setSnippets(config.DefaultSnippets()) }) // applying a config replaces them
+
+	edited := &config.Config{DiffMinimap: true}
+	model, _ := m.configFileChanged(configFileChangedMsg{Config: edited})
+	m = model.(App)
+	if m.appConfig != edited || !m.diffViewer.minimap {
+		t.Error("expected the edited config to be applied")
+	}
+	if got := m.statusBar.statusMessage; got != "Config reloaded" {
+		t.Errorf("status = %q", got)
+	}
+
+	// The reload that follows prtea's own save changes nothing.
+	m.statusBar.statusMessage = ""
+	model, _ = m.configFileChanged(configFileChangedMsg{Config: &config.Config{DiffMinimap: true}})
+	m = model.(App)
+	if m.appConfig != edited || m.statusBar.statusMessage != "" {
+		t.Errorf("an unchanged config should be ignored (status %q)", m.statusBar.statusMessage)
+	}
+
+	model, _ = m.configFileChanged(configFileChangedMsg{Err: errors.New("failed to parse config: unexpected EOF")})
+	m = model.(App)
+	if m.appConfig != edited || !strings.HasPrefix(m.statusBar.statusMessage, "Config reload failed") {
+		t.Errorf("a broken config should be reported and not applied (status %q)", m.statusBar.statusMessage)
+	}
+}
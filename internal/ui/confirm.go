@@ -0,0 +1,173 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// confirmClosePR asks before closing the current PR without merging (:close).
+func (m App) confirmClosePR() (tea.Model, tea.Cmd) {
+	s := m.session
+	if s == nil {
+		return m, m.statusBar.SetTemporaryMessage("No PR selected", 2*time.Second)
+	}
+	if m.ghClient == nil {
+		return m, nil
+	}
+	switch s.State {
+	case "CLOSED":
+		return m, m.statusBar.SetTemporaryMessage(fmt.Sprintf("PR #%d is already closed", s.Number), 2*time.Second)
+	case "MERGED":
+		return m, m.statusBar.SetTemporaryMessage(fmt.Sprintf("PR #%d is already merged", s.Number), 2*time.Second)
+	}
+	m.confirm.SetSize(m.width, m.height)
+	m.confirm.Show(
+		fmt.Sprintf("Close PR #%d?", s.Number),
+		fmt.Sprintf("%s/%s#%d %s\n\nThe PR is closed without merging. It can be reopened later with :reopen.", s.Owner, s.Repo, s.Number, s.Title),
+		"Close PR",
+		PRStateRequestMsg{Owner: s.Owner, Repo: s.Repo, Number: s.Number},
+	)
+	m.setMode(ModeOverlay)
+	return m, nil
+}
+
+// confirmReopenPR asks before reopening the current closed PR (:reopen).
+func (m App) confirmReopenPR() (tea.Model, tea.Cmd) {
+	s := m.session
+	if s == nil {
+		return m, m.statusBar.SetTemporaryMessage("No PR selected", 2*time.Second)
+	}
+	if m.ghClient == nil {
+		return m, nil
+	}
+	switch s.State {
+	case "OPEN":
+		return m, m.statusBar.SetTemporaryMessage(fmt.Sprintf("PR #%d is already open", s.Number), 2*time.Second)
+	case "MERGED":
+		return m, m.statusBar.SetTemporaryMessage("Merged PRs can't be reopened", 2*time.Second)
+	}
+	m.confirm.SetSize(m.width, m.height)
+	m.confirm.Show(
+		fmt.Sprintf("Reopen PR #%d?", s.Number),
+		fmt.Sprintf("%s/%s#%d %s\n\nReviewers and watchers are notified that the PR is open again.", s.Owner, s.Repo, s.Number, s.Title),
+		"Reopen PR",
+		PRStateRequestMsg{Owner: s.Owner, Repo: s.Repo, Number: s.Number, Reopen: true},
+	)
+	m.setMode(ModeOverlay)
+	return m, nil
+}
+
+// refreshClosedPRs marks the "Recently closed" PRs stale after a close or
+// reopen, fetching them again if they are on screen.
+func (m *App) refreshClosedPRs() tea.Cmd {
+	if !m.prList.InvalidateClosed() || m.ghClient == nil {
+		return nil
+	}
+	return fetchClosedPRsCmd(m.ghClient)
+}
+
+// ConfirmModel is a yes/no overlay guarding destructive actions. Confirming
+// sends the action's message; cancelling drops it.
+type ConfirmModel struct {
+	title     string
+	message   string
+	action    string  // label of the confirm choice, e.g. "Close PR"
+	onConfirm tea.Msg // sent when the user confirms
+	altKey    string  // optional third choice, e.g. "s"; "" when there is none
+	altAction string
+	onAlt     tea.Msg
+	width     int
+	height    int
+	visible   bool
+}
+
+// NewConfirmModel creates a confirmation overlay.
+func NewConfirmModel() ConfirmModel {
+	return ConfirmModel{}
+}
+
+// Show opens the overlay; onConfirm is sent if the user confirms.
+func (m *ConfirmModel) Show(title, message, action string, onConfirm tea.Msg) {
+	m.title = title
+	m.message = message
+	m.action = action
+	m.onConfirm = onConfirm
+	m.altKey, m.altAction, m.onAlt = "", "", nil
+	m.visible = true
+}
+
+// SetAlternative adds a third choice to the shown overlay: pressing key
+// sends onAlt instead of the confirm action.
+func (m *ConfirmModel) SetAlternative(key, action string, onAlt tea.Msg) {
+	m.altKey = key
+	m.altAction = action
+	m.onAlt = onAlt
+}
+
+// IsVisible returns whether the overlay is currently shown.
+func (m ConfirmModel) IsVisible() bool {
+	return m.visible
+}
+
+// SetSize updates the terminal dimensions used to place the overlay.
+func (m *ConfirmModel) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+}
+
+func (m ConfirmModel) overlayWidth() int {
+	return min(max(50, m.width/2), m.width)
+}
+
+func (m ConfirmModel) Update(msg tea.Msg) (ConfirmModel, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return m, nil
+	}
+	if m.altKey != "" && keyMsg.String() == m.altKey {
+		m.visible = false
+		action := m.onAlt
+		return m, func() tea.Msg { return ConfirmClosedMsg{Action: action} }
+	}
+	switch keyMsg.String() {
+	case "y", "Y":
+		m.visible = false
+		action := m.onConfirm
+		return m, func() tea.Msg { return ConfirmClosedMsg{Action: action} }
+	case "n", "N", "esc", "q":
+		m.visible = false
+		return m, func() tea.Msg { return ConfirmClosedMsg{} }
+	}
+	return m, nil
+}
+
+func (m ConfirmModel) View() string {
+	if !m.visible {
+		return ""
+	}
+	overlayW := m.overlayWidth()
+	innerW := max(1, overlayW-4)
+
+	title := helpTitleStyle.Render(" " + m.title + " ")
+	lines := []string{lipgloss.PlaceHorizontal(innerW, lipgloss.Left, title), ""}
+	lines = append(lines, strings.Split(wordWrap(m.message, innerW), "\n")...)
+
+	choices := "y " + m.action
+	if m.altKey != "" {
+		choices += " · " + m.altKey + " " + m.altAction
+	}
+	footer := helpFooterStyle.Render(choices + " · n/Esc cancel")
+	lines = append(lines, "", fitWidth(lipgloss.PlaceHorizontal(innerW, lipgloss.Center, footer), innerW))
+
+	overlayStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(theme.Error).
+		Padding(0, 1).
+		Width(overlayW - 2)
+
+	return placeOverlay(m.width, m.height, overlayStyle.Render(strings.Join(lines, "\n")))
+}
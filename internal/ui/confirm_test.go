@@ -0,0 +1,175 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/shhac/prtea/internal/claude"
+)
+
+func confirmTestApp(state string, calls *[]string) App {
+	m := App{
+		prList:     NewPRListModel(TabMyPRs),
+		statusBar:  NewStatusBarModel(),
+		diffViewer: newTestDiffViewer(80, 24),
+		width:      100,
+		height:     30,
+		session:    &PRSession{Owner: "shhac", Repo: "prtea", Number: 12, Title: "Add retries", State: state},
+		ghClient:   recordingClient(calls, nil),
+	}
+	return m
+}
+
+func TestClosePR_ConfirmOverlay(t *testing.T) {
+	var calls []string
+	m := confirmTestApp("OPEN", &calls)
+
+	model, _ := m.executeCommand("close", "")
+	m = model.(App)
+	if !m.confirm.IsVisible() || m.mode != ModeOverlay {
+		t.Fatal(":close should ask for confirmation")
+	}
+	if view := m.confirm.View(); !strings.Contains(view, "Close PR #12?") || !strings.Contains(view, "Add retries") {
+		t.Errorf("confirmation view:\n%s", view)
+	}
+
+	// Cancelling drops the action.
+	model, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("n")})
+	m = model.(App)
+	closed, ok := cmd().(ConfirmClosedMsg)
+	if !ok || closed.Action != nil || m.confirm.IsVisible() {
+		t.Fatalf("n = %#v, want a cancelled confirmation", closed)
+	}
+	model, _ = m.Update(closed)
+	m = model.(App)
+	if m.mode != ModeNavigation {
+		t.Errorf("mode = %v after cancelling, want navigation", m.mode)
+	}
+
+	// Confirming sends the close request.
+	model, _ = m.executeCommand("close", "")
+	m = model.(App)
+	model, cmd = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("y")})
+	m = model.(App)
+	closed = cmd().(ConfirmClosedMsg)
+	req, ok := closed.Action.(PRStateRequestMsg)
+	if !ok || req.Number != 12 || req.Reopen {
+		t.Fatalf("action = %#v, want a close request for #12", closed.Action)
+	}
+	if len(calls) != 0 {
+		t.Fatalf("calls = %q before the request was handled", calls)
+	}
+	if done := closePRCmd(m.ghClient, req.Owner, req.Repo, req.Number)(); done != (PRCloseDoneMsg{PRNumber: 12}) {
+		t.Errorf("close = %#v", done)
+	}
+	if len(calls) != 1 || calls[0] != "pr close 12 -R shhac/prtea" {
+		t.Errorf("calls = %q", calls)
+	}
+
+	model, _ = m.Update(PRCloseDoneMsg{PRNumber: 12})
+	if s := model.(App).session; s.State != "CLOSED" {
+		t.Errorf("session state = %q, want CLOSED", s.State)
+	}
+}
+
+func TestReopenPR_RefusesOpenAndMergedPRs(t *testing.T) {
+	for state, want := range map[string]string{"OPEN": "already open", "MERGED": "can't be reopened"} {
+		var calls []string
+		m := confirmTestApp(state, &calls)
+		model, _ := m.executeCommand("reopen", "")
+		m = model.(App)
+		if m.confirm.IsVisible() || !strings.Contains(m.statusBar.statusMessage, want) {
+			t.Errorf("%s: status = %q, want %q", state, m.statusBar.statusMessage, want)
+		}
+	}
+
+	var calls []string
+	m := confirmTestApp("CLOSED", &calls)
+	model, _ := m.executeCommand("reopen", "")
+	m = model.(App)
+	if !m.confirm.IsVisible() {
+		t.Fatal(":reopen on a closed PR should ask for confirmation")
+	}
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("y")})
+	if req, ok := cmd().(ConfirmClosedMsg).Action.(PRStateRequestMsg); !ok || !req.Reopen {
+		t.Errorf("action = %#v, want a reopen request", req)
+	}
+}
+
+func TestPRList_RecentlyClosedFilter(t *testing.T) {
+	m := NewPRListModel(TabMyPRs)
+	m.SetSize(60, 30)
+	m.SetItems(nil, []list.Item{PRItem{number: 1, owner: "shhac", repo: "prtea", title: "Open one"}})
+
+	m, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("c")})
+	if _, ok := cmd().(ClosedPRsRequestMsg); !ok || !m.showClosed {
+		t.Fatal("c should show the closed filter and request its PRs")
+	}
+	if !strings.Contains(m.View(), "Loading closed PRs") {
+		t.Error("the filter should show it is loading")
+	}
+
+	m.SetClosed([]list.Item{PRItem{number: 7, owner: "shhac", repo: "prtea", title: "Abandoned idea"}})
+	view := m.View()
+	if !strings.Contains(view, "Closed (1)") || !strings.Contains(view, "Abandoned idea") || strings.Contains(view, "Open one") {
+		t.Errorf("closed filter view:\n%s", view)
+	}
+	if pr, ok := m.findPR("shhac", "prtea", 7); !ok || pr.title != "Abandoned idea" {
+		t.Error("closed PRs should be found when selected")
+	}
+
+	m, cmd = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("c")})
+	if cmd != nil || m.showClosed || !strings.Contains(m.View(), "Open one") {
+		t.Error("c again should return to open PRs without refetching")
+	}
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("c")})
+	if !m.InvalidateClosed() {
+		t.Error("invalidating while the filter shows should ask for a refetch")
+	}
+}
+
+func TestPostAnalysis_ConfirmsThenPosts(t *testing.T) {
+	var calls []string
+	m := confirmTestApp("OPEN", &calls)
+	m.chatPanel = NewChatPanelModel()
+
+	model, _ := m.executeCommand("analysis post", "")
+	m = model.(App)
+	if m.confirm.IsVisible() || !strings.Contains(m.statusBar.statusMessage, "No analysis yet") {
+		t.Fatalf("without an analysis: status = %q", m.statusBar.statusMessage)
+	}
+
+	m.chatPanel.SetAnalysisResult(&claude.AnalysisResult{
+		Summary: "Adds retries to the client.",
+		Risk:    claude.RiskAssessment{Level: "medium"},
+		FileReviews: []claude.FileReview{{File: "client.go", Comments: []claude.ReviewComment{
+			{Line: 8, Severity: "warning", Comment: "Retries ignore the context"},
+		}}},
+	})
+	model, _ = m.executeCommand("analysis post", "")
+	m = model.(App)
+	if view := m.confirm.View(); !m.confirm.IsVisible() || !strings.Contains(view, "Post analysis to PR #12?") {
+		t.Fatalf("confirmation view:\n%s", view)
+	}
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("y")})
+	post, ok := cmd().(ConfirmClosedMsg).Action.(AnalysisPostMsg)
+	if !ok || post.Number != 12 {
+		t.Fatalf("action = %#v, want an analysis post for #12", post)
+	}
+	for _, want := range []string{"**Risk: MEDIUM**", "Adds retries to the client.", "<details>\n<summary>File reviews (1 file, 1 finding(s))</summary>", "- **warning** (line 8): Retries ignore the context"} {
+		if !strings.Contains(post.Body, want) {
+			t.Errorf("comment body missing %q:\n%s", want, post.Body)
+		}
+	}
+	if len(calls) != 0 {
+		t.Fatalf("calls = %q before confirming", calls)
+	}
+	if done := postAnalysisCmd(m.ghClient, post)(); done != (AnalysisPostedMsg{PRNumber: 12}) {
+		t.Errorf("post = %#v", done)
+	}
+	if len(calls) != 1 || !strings.HasPrefix(calls[0], "pr comment 12 -R shhac/prtea --body ### AI analysis") {
+		t.Errorf("calls = %q", calls)
+	}
+}
@@ -0,0 +1,166 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/shhac/prtea/internal/github"
+)
+
+// fetchCoverageCmd returns a command that looks for a coverage report among
+// the artifacts of a PR's workflow runs.
+func fetchCoverageCmd(client GitHubService, owner, repo string, number int, runIDs []int64, patterns []string) tea.Cmd {
+	return func() tea.Msg {
+		report, err := client.GetCoverage(context.Background(), owner, repo, runIDs, patterns)
+		return CoverageLoadedMsg{PRNumber: number, Report: report, Err: err}
+	}
+}
+
+// coverageRunIDs returns the workflow runs of a CI status, in check order.
+func coverageRunIDs(status *github.CIStatus) []int64 {
+	var ids []int64
+	seen := make(map[int64]bool)
+	for _, c := range status.Checks {
+		if c.WorkflowRunID > 0 && !seen[c.WorkflowRunID] {
+			seen[c.WorkflowRunID] = true
+			ids = append(ids, c.WorkflowRunID)
+		}
+	}
+	return ids
+}
+
+// refreshCoverage fetches the PR's coverage report once its CI has
+// finished, if that hasn't been tried yet.
+func (m *App) refreshCoverage() tea.Cmd {
+	dv := &m.diffViewer
+	if m.ghClient == nil || m.session == nil || dv.coverageTried || dv.ciStatus == nil ||
+		dv.ciStatus.OverallStatus == "pending" || m.appConfig == nil || len(m.appConfig.CoverageArtifacts) == 0 {
+		return nil
+	}
+	runIDs := coverageRunIDs(dv.ciStatus)
+	if len(runIDs) == 0 {
+		return nil
+	}
+	dv.coverageTried = true
+	s := m.session
+	return fetchCoverageCmd(m.ghClient, s.Owner, s.Repo, s.Number, runIDs, m.appConfig.CoverageArtifacts)
+}
+
+// SetCoverage shows a coverage report in the diff gutter.
+func (m *DiffViewerModel) SetCoverage(report *github.CoverageReport) {
+	m.coverageReport = report
+	m.coverage = make(map[string]map[int]int)
+	m.cachedLines = nil
+	m.cachedLineInfo = nil
+	m.refreshContent()
+}
+
+// coverageFor returns the line hits of a diff file, resolving its path in
+// the report once.
+func (m DiffViewerModel) coverageFor(filename string) map[int]int {
+	if m.coverageReport == nil {
+		return nil
+	}
+	lines, ok := m.coverage[filename]
+	if !ok {
+		lines = m.coverageReport.Lines.ForFile(filename)
+		m.coverage[filename] = lines
+	}
+	return lines
+}
+
+// coverageGutter marks a gutter with the coverage of a new-side line: a
+// green bar when tests ran it, a red one when none did. Lines the report
+// doesn't instrument keep the plain gutter.
+func (m DiffViewerModel) coverageGutter(gutter, filename string, line int) string {
+	if m.coverageReport == nil || m.coverageHidden {
+		return gutter
+	}
+	hits, ok := m.coverageFor(filename)[line]
+	if !ok {
+		return gutter
+	}
+	mark := diffCoveredStyle.Render("▕")
+	if hits == 0 {
+		mark = diffUncoveredStyle.Render("▕")
+	}
+	return strings.TrimSuffix(gutter, " ") + mark
+}
+
+// changedLineCoverage counts the added lines the report instruments and how
+// many of them tests ran.
+func (m DiffViewerModel) changedLineCoverage() (covered, total int) {
+	for _, f := range m.files {
+		lines := m.coverageFor(f.Filename)
+		if lines == nil {
+			continue
+		}
+		for _, n := range addedLines(f.Patch) {
+			if hits, ok := lines[n]; ok {
+				total++
+				if hits > 0 {
+					covered++
+				}
+			}
+		}
+	}
+	return covered, total
+}
+
+// addedLines returns the new-side line numbers of a patch's added lines.
+func addedLines(patch string) []int {
+	var lines []int
+	n := 0
+	for _, l := range strings.Split(patch, "\n") {
+		switch {
+		case strings.HasPrefix(l, "@@"):
+			n = parseHunkNewStart(l)
+		case strings.HasPrefix(l, "+"):
+			lines = append(lines, n)
+			n++
+		case strings.HasPrefix(l, "-"), strings.HasPrefix(l, `\`):
+		default:
+			n++
+		}
+	}
+	return lines
+}
+
+// toggleCoverage shows or hides the coverage gutter and reports how much
+// of the change tests cover (:coverage).
+func (m App) toggleCoverage() (tea.Model, tea.Cmd) {
+	dv := &m.diffViewer
+	if dv.coverageReport == nil {
+		msg := "No coverage report for this PR yet"
+		switch {
+		case m.appConfig != nil && len(m.appConfig.CoverageArtifacts) == 0:
+			msg = "Coverage overlay is disabled (coverageArtifacts is empty)"
+		case dv.coverageErr != "":
+			msg = "Coverage: " + dv.coverageErr
+		case dv.coverageTried:
+			msg = "No CI artifact matching " + strings.Join(m.appConfig.CoverageArtifacts, ", ")
+		}
+		return m, m.statusBar.SetTemporaryMessage(msg, 3*time.Second)
+	}
+	dv.coverageHidden = !dv.coverageHidden
+	dv.cachedLines = nil
+	dv.cachedLineInfo = nil
+	dv.refreshContent()
+	if dv.coverageHidden {
+		return m, m.statusBar.SetTemporaryMessage("Coverage gutter hidden", 2*time.Second)
+	}
+	return m, m.statusBar.SetTemporaryMessage(coverageSummary(dv), 4*time.Second)
+}
+
+// coverageSummary describes the changed-line coverage, e.g. "Coverage
+// (coverage-report): 18/24 changed lines tested (75%)".
+func coverageSummary(dv *DiffViewerModel) string {
+	covered, total := dv.changedLineCoverage()
+	if total == 0 {
+		return fmt.Sprintf("Coverage (%s): no changed lines are instrumented", dv.coverageReport.Artifact)
+	}
+	return fmt.Sprintf("Coverage (%s): %d/%d changed lines tested (%d%%)", dv.coverageReport.Artifact, covered, total, covered*100/total)
+}
@@ -0,0 +1,81 @@
+package ui
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/x/ansi"
+	"github.com/shhac/prtea/internal/config"
+	"github.com/shhac/prtea/internal/github"
+)
+
+func coverageTestApp() App {
+	m := App{
+		statusBar:  NewStatusBarModel(),
+		diffViewer: newTestDiffViewer(80, 40),
+		session:    &PRSession{Owner: "shhac", Repo: "prtea", Number: 7},
+		ghClient:   github.NewTestClient("alice", func(ctx context.Context, args ...string) (string, error) { return "", nil }),
+		appConfig:  &config.Config{CoverageArtifacts: []string{"coverage*"}},
+	}
+	m.diffViewer.prNumber = 7
+	m.diffViewer.SetDiff([]github.PRFile{
+		{Filename: "pkg/a.go", Status: "modified", Patch: "@@ -1,2 +1,4 @@\n one\n+two\n+three\n four"},
+	})
+	return m
+}
+
+func TestCoverageGutter(t *testing.T) {
+	m := coverageTestApp()
+	m.diffViewer.SetCoverage(&github.CoverageReport{Artifact: "coverage", Lines: github.Coverage{
+		"github.com/shhac/prtea/pkg/a.go": {1: 4, 2: 1, 3: 0},
+	}})
+
+	var marked []string
+	for _, line := range m.diffViewer.cachedLines {
+		if plain := ansi.Strip(line); strings.Contains(plain, "▕") {
+			marked = append(marked, strings.TrimSpace(plain))
+		}
+	}
+	if len(marked) != 3 {
+		t.Errorf("marked lines = %q, want the three instrumented lines", marked)
+	}
+	if covered, total := m.diffViewer.changedLineCoverage(); covered != 1 || total != 2 {
+		t.Errorf("changed-line coverage = %d/%d, want 1/2", covered, total)
+	}
+
+	model, _ := m.toggleCoverage()
+	m = model.(App)
+	if !m.diffViewer.coverageHidden || strings.Contains(ansi.Strip(strings.Join(m.diffViewer.cachedLines, "\n")), "▕") {
+		t.Error(":coverage should hide the gutter")
+	}
+	model, _ = m.toggleCoverage()
+	if m = model.(App); !strings.Contains(m.statusBar.statusMessage, "1/2 changed lines tested (50%)") {
+		t.Errorf("status = %q", m.statusBar.statusMessage)
+	}
+}
+
+func TestRefreshCoverage_WaitsForCI(t *testing.T) {
+	m := coverageTestApp()
+	m.diffViewer.SetCIStatus(&github.CIStatus{OverallStatus: "pending", Checks: []github.CICheck{
+		{Name: "test", Status: "in_progress", WorkflowRunID: 3},
+	}})
+	if cmd := m.refreshCoverage(); cmd != nil {
+		t.Error("coverage should wait for CI to finish")
+	}
+	m.diffViewer.SetCIStatus(&github.CIStatus{OverallStatus: "passing", Checks: []github.CICheck{
+		{Name: "test", Status: "completed", Conclusion: "success", WorkflowRunID: 3},
+	}})
+	if cmd := m.refreshCoverage(); cmd == nil || !m.diffViewer.coverageTried {
+		t.Fatal("finished CI should fetch coverage")
+	}
+	if cmd := m.refreshCoverage(); cmd != nil {
+		t.Error("coverage should be fetched once per PR")
+	}
+
+	m.appConfig.CoverageArtifacts = []string{}
+	model, _ := m.toggleCoverage()
+	if m = model.(App); !strings.Contains(m.statusBar.statusMessage, "disabled") {
+		t.Errorf("status = %q", m.statusBar.statusMessage)
+	}
+}
@@ -0,0 +1,109 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/shhac/prtea/internal/github"
+)
+
+// fetchDeploymentsCmd returns a command that fetches the deployments of a
+// PR's head commit.
+func fetchDeploymentsCmd(client GitHubService, owner, repo, sha string, number int) tea.Cmd {
+	return func() tea.Msg {
+		deployments, err := client.GetDeployments(context.Background(), owner, repo, sha)
+		return DeploymentsLoadedMsg{PRNumber: number, Deployments: deployments, Err: err}
+	}
+}
+
+// SetDeployments sets the head commit's deployments for the PR Info tab.
+func (m *DiffViewerModel) SetDeployments(deployments []github.Deployment) {
+	m.deployments = deployments
+	m.prInfoCache = ""
+	m.refreshContent()
+}
+
+// previewDeployment returns the live deployment to open as the PR's
+// preview: a successful one with a URL, preferring a "preview" environment.
+func previewDeployment(deployments []github.Deployment) (github.Deployment, bool) {
+	var found *github.Deployment
+	for i, d := range deployments {
+		if d.State != "success" || d.URL == "" {
+			continue
+		}
+		if strings.Contains(strings.ToLower(d.Environment), "preview") {
+			return d, true
+		}
+		if found == nil {
+			found = &deployments[i]
+		}
+	}
+	if found == nil {
+		return github.Deployment{}, false
+	}
+	return *found, true
+}
+
+// deploymentIconColor returns the icon and color for a deployment state.
+func deploymentIconColor(state string) (string, lipgloss.Color) {
+	switch state {
+	case "success":
+		return "✓", theme.Success
+	case "failure", "error":
+		return "✗", theme.Error
+	case "inactive":
+		return "○", theme.Muted
+	default:
+		return "●", theme.Warning
+	}
+}
+
+// renderDeployments renders the PR Info tab's deployments section, if the
+// head commit has been deployed anywhere.
+func (m *DiffViewerModel) renderDeployments(width int) string {
+	if len(m.deployments) == 0 {
+		return ""
+	}
+	var b strings.Builder
+	b.WriteString("\n")
+	b.WriteString(sectionHeaderStyle.Render("Deployments"))
+	b.WriteString("\n")
+	for _, d := range m.deployments {
+		icon, color := deploymentIconColor(d.State)
+		line := fmt.Sprintf("  %s %s %s",
+			lipgloss.NewStyle().Foreground(color).Render(icon),
+			d.Environment,
+			dimStyle.Render(strings.ReplaceAll(d.State, "_", " ")),
+		)
+		if d.URL != "" {
+			line += " " + d.URL
+		}
+		b.WriteString(fitWidth(line, width))
+		b.WriteString("\n")
+	}
+	if d, ok := previewDeployment(m.deployments); ok {
+		b.WriteString(dimStyle.Italic(true).Render("  Press P (or :preview) to open " + d.Environment))
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// openPreview opens the PR's live preview deployment in the browser (P on
+// the PR Info tab, or :preview).
+func (m App) openPreview() (tea.Model, tea.Cmd) {
+	if m.session == nil {
+		return m, nil
+	}
+	d, ok := previewDeployment(m.diffViewer.deployments)
+	if !ok {
+		return m, m.statusBar.SetTemporaryMessage("No live preview deployment for this PR's head commit", 2*time.Second)
+	}
+	return m, tea.Batch(
+		m.statusBar.SetTemporaryMessage("Opening "+d.Environment+"...", 2*time.Second),
+		openBrowserCmd(d.URL),
+	)
+}
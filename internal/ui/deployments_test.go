@@ -0,0 +1,65 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/shhac/prtea/internal/github"
+)
+
+func TestPreviewDeployment(t *testing.T) {
+	deployments := []github.Deployment{
+		{Environment: "production", State: "success", URL: "https://example.com"},
+		{Environment: "staging", State: "in_progress", URL: "https://staging.example.com"},
+		{Environment: "Preview – web", State: "success", URL: "https://pr-7.example.com"},
+	}
+	if d, ok := previewDeployment(deployments); !ok || d.URL != "https://pr-7.example.com" {
+		t.Errorf("preview = %+v, %v; want the preview environment", d, ok)
+	}
+	if d, ok := previewDeployment(deployments[:2]); !ok || d.Environment != "production" {
+		t.Errorf("without a preview environment got %+v, want the first live deployment", d)
+	}
+	if _, ok := previewDeployment(deployments[1:2]); ok {
+		t.Error("an in-progress deployment is not a live preview")
+	}
+}
+
+func TestRenderDeployments(t *testing.T) {
+	m := newTestDiffViewer(100, 40)
+	m.prNumber = 7
+	m.SetPRInfo("Add retries", "", "alice", "")
+	m.SetDeployments([]github.Deployment{
+		{Environment: "preview", State: "success", URL: "https://pr-7.example.com"},
+		{Environment: "staging", State: "in_progress"},
+	})
+	got := m.renderPRInfo()
+	for _, want := range []string{"Deployments", "preview", "https://pr-7.example.com", "in progress", "Press P"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("PR Info missing %q", want)
+		}
+	}
+
+	m.activeTab = TabPRInfo
+	m.focused = true
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("P")})
+	if cmd == nil {
+		t.Fatal("P should request the preview")
+	}
+	if _, ok := cmd().(OpenPreviewRequestMsg); !ok {
+		t.Error("P should emit OpenPreviewRequestMsg")
+	}
+}
+
+func TestOpenPreview_NoneLive(t *testing.T) {
+	m := App{
+		statusBar:  NewStatusBarModel(),
+		diffViewer: newTestDiffViewer(80, 24),
+		session:    &PRSession{Owner: "shhac", Repo: "prtea", Number: 7},
+	}
+	m.diffViewer.SetDeployments([]github.Deployment{{Environment: "preview", State: "failure"}})
+	model, _ := m.openPreview()
+	if m = model.(App); !strings.Contains(m.statusBar.statusMessage, "No live preview") {
+		t.Errorf("status = %q", m.statusBar.statusMessage)
+	}
+}
@@ -0,0 +1,73 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// enterAskMode opens the one-line question input for the focused hunk.
+func (m *DiffViewerModel) enterAskMode() tea.Cmd {
+	if m.focusedHunkIdx < 0 || m.focusedHunkIdx >= len(m.hunks) {
+		return nil
+	}
+	m.askMode = true
+	m.askInput.SetValue("")
+	m.completer.reset()
+	return m.askInput.Focus()
+}
+
+// handleAskModeKey processes key events while the hunk question input is active.
+func (m *DiffViewerModel) handleAskModeKey(msg tea.KeyMsg) (DiffViewerModel, tea.Cmd) {
+	if m.completer.handles(msg) {
+		return *m, updateTextInput(&m.askInput, &m.completer, msg)
+	}
+	switch msg.String() {
+	case "esc":
+		m.askMode = false
+		m.askInput.Blur()
+		m.completer.reset()
+		return *m, nil
+	case "enter":
+		question := strings.TrimSpace(m.askInput.Value())
+		m.askMode = false
+		m.askInput.Blur()
+		m.completer.reset()
+		if question == "" || m.focusedHunkIdx < 0 || m.focusedHunkIdx >= len(m.hunks) {
+			return *m, nil
+		}
+		hunk := m.hunks[m.focusedHunkIdx]
+		ask := HunkQuestionMsg{
+			Target:   hunk.Filename + " " + hunk.Header,
+			Hunk:     m.hunkContent(m.focusedHunkIdx),
+			Question: question,
+		}
+		return *m, func() tea.Msg { return ask }
+	default:
+		return *m, updateTextInput(&m.askInput, &m.completer, msg)
+	}
+}
+
+// IsAsking returns true while the hunk question input is being typed into.
+func (m DiffViewerModel) IsAsking() bool {
+	return m.askMode
+}
+
+// hunkContent returns a single hunk as a unified diff with file headers.
+func (m DiffViewerModel) hunkContent(idx int) string {
+	hunk := m.hunks[idx]
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf("--- a/%s\n", hunk.Filename))
+	b.WriteString(fmt.Sprintf("+++ b/%s\n", hunk.Filename))
+	for _, line := range hunk.Lines {
+		b.WriteString(line)
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// renderAskBar renders the question input bar.
+func (m DiffViewerModel) renderAskBar() string {
+	return m.withCompletions(diffSearchInfoStyle.Render("Ask about hunk: ") + m.askInput.View())
+}
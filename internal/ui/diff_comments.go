@@ -13,11 +13,15 @@ import (
 
 // handleCommentModeKey processes key events while comment input mode is active.
 func (m *DiffViewerModel) handleCommentModeKey(msg tea.KeyMsg) (DiffViewerModel, tea.Cmd) {
+	if m.completer.handles(msg) {
+		return *m, updateTextInput(&m.commentInput, &m.completer, msg)
+	}
 	switch msg.String() {
 	case "esc":
 		m.commentMode = false
 		m.commentInput.SetValue("")
 		m.commentInput.Blur()
+		m.completer.reset()
 		m.cancelSelection()
 		m.refreshContent()
 		return *m, nil
@@ -28,15 +32,14 @@ func (m *DiffViewerModel) handleCommentModeKey(msg tea.KeyMsg) (DiffViewerModel,
 		startLine := m.commentTargetStartLine
 		m.commentMode = false
 		m.commentInput.Blur()
+		m.completer.reset()
 		m.cancelSelection()
 		m.refreshContent()
 		return *m, func() tea.Msg {
 			return InlineCommentAddMsg{Path: path, Line: line, Body: body, StartLine: startLine}
 		}
 	default:
-		var cmd tea.Cmd
-		m.commentInput, cmd = m.commentInput.Update(msg)
-		return *m, cmd
+		return *m, updateTextInput(&m.commentInput, &m.completer, msg)
 	}
 }
 
@@ -67,6 +70,9 @@ func (m *DiffViewerModel) ClearAIInlineComments() {
 func (m *DiffViewerModel) SetPendingInlineComments(comments []PendingInlineComment) {
 	m.pendingCommentsByFileLine = make(map[string][]PendingInlineComment)
 	for _, c := range comments {
+		if c.Stale {
+			continue // its line is gone; the pending list still shows it
+		}
 		key := commentKey(c.Path, c.Line)
 		m.pendingCommentsByFileLine[key] = append(m.pendingCommentsByFileLine[key], c)
 	}
@@ -77,9 +83,13 @@ func (m *DiffViewerModel) SetPendingInlineComments(comments []PendingInlineComme
 
 // SetGitHubInlineComments stores GitHub review comments, groups them into threads,
 // and rebuilds the diff cache so they render at their line positions.
+// Outdated threads are re-anchored to matching content in the current diff
+// when showOutdated is enabled; otherwise they stay in the Comments tab only.
 func (m *DiffViewerModel) SetGitHubInlineComments(comments []github.InlineComment) {
+	m.ghInlineComments = comments
+	m.ghCommentThreads = nil
+	m.ghOutdatedThreads = nil
 	if len(comments) == 0 {
-		m.ghCommentThreads = nil
 		m.cachedLines = nil
 		m.cachedLineInfo = nil
 		m.refreshContent()
@@ -92,7 +102,7 @@ func (m *DiffViewerModel) SetGitHubInlineComments(comments []github.InlineCommen
 	var replies []github.InlineComment
 
 	for _, c := range comments {
-		if c.Outdated {
+		if c.Outdated && !m.showOutdated {
 			continue // outdated comments stay in Comments tab only
 		}
 		if c.InReplyToID != 0 {
@@ -116,10 +126,22 @@ func (m *DiffViewerModel) SetGitHubInlineComments(comments []github.InlineCommen
 		// still appear in the Comments tab flat list.
 	}
 
-	// Build the "path:line" → threads map.
+	// Build the "path:line" → threads maps.
 	m.ghCommentThreads = make(map[string][]ghCommentThread)
 	for _, id := range rootOrder {
 		t := rootByID[id]
+		if t.Root.Outdated {
+			line := reanchorOutdatedComment(m.hunks, t.Root)
+			if line == 0 {
+				continue // original content no longer in the diff
+			}
+			if m.ghOutdatedThreads == nil {
+				m.ghOutdatedThreads = make(map[string][]ghCommentThread)
+			}
+			key := commentKey(t.Root.Path, line)
+			m.ghOutdatedThreads[key] = append(m.ghOutdatedThreads[key], *t)
+			continue
+		}
 		key := commentKey(t.Root.Path, t.Root.Line)
 		m.ghCommentThreads[key] = append(m.ghCommentThreads[key], *t)
 	}
@@ -129,6 +151,96 @@ func (m *DiffViewerModel) SetGitHubInlineComments(comments []github.InlineCommen
 	m.refreshContent()
 }
 
+// AddOptimisticReply shows a reply in its thread while it is being posted.
+// The reply copies its root's position; GitHub's copy replaces it on the
+// next comments refresh. It reports whether the root was found.
+func (m *DiffViewerModel) AddOptimisticReply(reply github.InlineComment) bool {
+	for _, c := range m.ghInlineComments {
+		if c.ID == reply.InReplyToID {
+			reply.Path, reply.Line, reply.StartLine = c.Path, c.Line, c.StartLine
+			reply.Side, reply.Outdated, reply.DiffHunk = c.Side, c.Outdated, c.DiffHunk
+			m.SetGitHubInlineComments(append(m.ghInlineComments[:len(m.ghInlineComments):len(m.ghInlineComments)], reply))
+			return true
+		}
+	}
+	return false
+}
+
+// RemoveInlineComment drops a comment by ID, such as a reply that failed
+// to post.
+func (m *DiffViewerModel) RemoveInlineComment(id int64) {
+	for i, c := range m.ghInlineComments {
+		if c.ID == id {
+			kept := append(m.ghInlineComments[:i:i], m.ghInlineComments[i+1:]...)
+			m.SetGitHubInlineComments(kept)
+			return
+		}
+	}
+}
+
+// SetShowOutdatedComments toggles whether outdated GitHub comments are
+// re-anchored into the diff, rebuilding threads from the stored comments.
+func (m *DiffViewerModel) SetShowOutdatedComments(show bool) {
+	if m.showOutdated == show {
+		return
+	}
+	m.showOutdated = show
+	if m.ghInlineComments != nil {
+		m.SetGitHubInlineComments(m.ghInlineComments)
+	}
+}
+
+// reanchorOutdatedComment finds where an outdated comment's original line now
+// lives in the current diff. The commented line is the last line of the
+// comment's diff_hunk; it is matched by content against the + and context
+// lines of the same file, preferring the match closest to the original line.
+// Returns the new-side line number, or 0 if no match is found.
+func reanchorOutdatedComment(hunks []DiffHunk, c github.InlineComment) int {
+	target := outdatedTargetContent(c.DiffHunk)
+	if target == "" {
+		return 0
+	}
+
+	best, bestDist := 0, -1
+	for _, h := range hunks {
+		if h.Filename != c.Path {
+			continue
+		}
+		newLine := 0
+		for _, line := range h.Lines {
+			switch {
+			case strings.HasPrefix(line, "@@"):
+				newLine = parseHunkNewStart(line)
+				continue
+			case strings.HasPrefix(line, "-"), strings.HasPrefix(line, `\`):
+				continue
+			}
+			if newLine > 0 && len(line) > 0 && strings.TrimSpace(line[1:]) == target {
+				dist := newLine - c.Line
+				if dist < 0 {
+					dist = -dist
+				}
+				if bestDist < 0 || dist < bestDist {
+					best, bestDist = newLine, dist
+				}
+			}
+			newLine++
+		}
+	}
+	return best
+}
+
+// outdatedTargetContent returns the trimmed content of the commented line,
+// which GitHub places as the last line of a review comment's diff_hunk.
+func outdatedTargetContent(diffHunk string) string {
+	lines := strings.Split(strings.TrimRight(diffHunk, "\n"), "\n")
+	last := lines[len(lines)-1]
+	if last == "" || strings.HasPrefix(last, "@@") {
+		return ""
+	}
+	return strings.TrimSpace(last[1:])
+}
+
 // EnterCommentMode activates comment input mode targeting the cursor line.
 // If the cursor is on a non-commentable line, it snaps to the nearest commentable
 // line within the same hunk. Returns nil if no commentable line is found.
@@ -162,6 +274,7 @@ func (m *DiffViewerModel) EnterCommentMode() tea.Cmd {
 	}
 
 	m.commentMode = true
+	m.completer.reset()
 
 	// Pre-fill if editing existing comment at this location
 	key := commentKey(m.commentTargetFile, m.commentTargetLine)
@@ -341,12 +454,24 @@ func (m *DiffViewerModel) buildCommentOverlayMsg() *ShowCommentOverlayMsg {
 		}
 	} else {
 		// Single-line: match all threads at this line (existing behavior)
-		ghThreads = m.ghCommentThreads[key]
+		ghThreads = append(ghThreads, m.ghCommentThreads[key]...)
+		ghThreads = append(ghThreads, m.ghOutdatedThreads[key]...)
 		aiComments = m.aiCommentsByFileLine[key]
 		pendingComments = m.pendingCommentsByFileLine[key]
 	}
 
+	suggestionBase := make(map[int64][]string)
+	for _, t := range ghThreads {
+		if _, ok := parseSuggestion(t.Root.Body); ok && !t.Root.Outdated {
+			start, end := suggestionTarget(t.Root.StartLine, t.Root.Line)
+			suggestionBase[t.Root.ID] = m.newSideLines(targetFile, start, end)
+		}
+	}
+
+	pos, total := m.commentPosition()
 	return &ShowCommentOverlayMsg{
+		Position:        pos,
+		Total:           total,
 		Path:            targetFile,
 		Line:            targetLine,
 		StartLine:       startLine,
@@ -355,6 +480,7 @@ func (m *DiffViewerModel) buildCommentOverlayMsg() *ShowCommentOverlayMsg {
 		GHThreads:       ghThreads,
 		AIComments:      aiComments,
 		PendingComments: pendingComments,
+		SuggestionBase:  suggestionBase,
 	}
 }
 
@@ -373,7 +499,15 @@ func (m DiffViewerModel) renderCommentBar() string {
 	}
 	promptStyle := lipgloss.NewStyle().Foreground(commentBoxPendingBorder).Bold(true)
 	prompt := promptStyle.Render("📝 " + target + " > ")
-	return prompt + m.commentInput.View()
+	return m.withCompletions(prompt + m.commentInput.View())
+}
+
+// withCompletions appends the input's completion hint to a one-line bar.
+func (m DiffViewerModel) withCompletions(bar string) string {
+	if !m.completer.active() {
+		return bar
+	}
+	return bar + "  " + m.completer.View(max(0, m.width-4-lipgloss.Width(bar)-2))
 }
 
 // commentBoxMaxPreviewLines is the maximum body lines shown in the inline preview.
@@ -447,24 +581,36 @@ func (m *DiffViewerModel) renderGHCommentThread(t ghCommentThread, highlighted b
 
 	// Header: 💬 @author · Jan 2 15:04
 	header := commentBoxHeaderStyle.Render("💬 @"+t.Root.Author.Login) +
-		commentBoxMetaStyle.Render(" · "+t.Root.CreatedAt.Format("Jan 2 15:04"))
+		commentBoxMetaStyle.Render(" · "+formatTimestamp(t.Root.CreatedAt))
 
-	// Build body: root body + replies
+	// Build body: root body + replies. A suggestion is shown as a mini-diff
+	// against the lines it replaces, followed by the rest of the comment.
 	var body strings.Builder
-	body.WriteString(m.renderMarkdown(t.Root.Body, boxInnerWidth))
+	if s, ok := parseSuggestion(t.Root.Body); ok {
+		header += commentBoxMetaStyle.Render(" · suggested change")
+		start, end := suggestionTarget(t.Root.StartLine, t.Root.Line)
+		original := m.newSideLines(t.Root.Path, start, end)
+		body.WriteString(strings.Join(renderSuggestionDiff(original, s.lines, boxInnerWidth), "\n"))
+		if s.prose != "" {
+			body.WriteString("\n")
+			body.WriteString(m.renderMarkdown(s.prose, boxInnerWidth))
+		}
+	} else {
+		body.WriteString(m.renderMarkdown(t.Root.Body, boxInnerWidth))
+	}
 
 	for i, r := range t.Replies {
 		if i >= 1 {
 			// Trim after first reply
 			remaining := len(t.Replies) - 1
 			body.WriteString("\n")
-			body.WriteString(commentBoxTrimStyle.Render(fmt.Sprintf("[+%d more replies]", remaining)))
+			body.WriteString(commentBoxTrimStyle.Render(fmt.Sprintf("[+%d more replies · c: full thread]", remaining)))
 			break
 		}
 		body.WriteString("\n")
 		replyHeader := commentBoxReplyStyle.Render("↳ ") +
 			commentBoxHeaderStyle.Render("@"+r.Author.Login) +
-			commentBoxMetaStyle.Render(" · "+r.CreatedAt.Format("Jan 2 15:04"))
+			commentBoxMetaStyle.Render(" · "+formatTimestamp(r.CreatedAt))
 		body.WriteString(replyHeader)
 		body.WriteString("\n")
 		body.WriteString(m.renderMarkdown(r.Body, boxInnerWidth))
@@ -477,6 +623,39 @@ func (m *DiffViewerModel) renderGHCommentThread(t ghCommentThread, highlighted b
 	return m.renderCommentBox(header, body.String(), borderColor, highlighted, gutter)
 }
 
+// outdatedBoxHunkLines is the number of original diff_hunk lines shown in the
+// collapsed outdated box. The full hunk is shown in the comment overlay.
+const outdatedBoxHunkLines = 2
+
+// renderOutdatedThread renders an outdated GitHub thread as a collapsed box
+// showing the tail of its original diff_hunk and the first line of the comment.
+func (m *DiffViewerModel) renderOutdatedThread(t ghCommentThread, highlighted bool, gutter string) []string {
+	header := commentBoxOutdatedStyle.Render("⌛ outdated") +
+		commentBoxMetaStyle.Render(" · @"+t.Root.Author.Login+" · "+formatTimestamp(t.Root.CreatedAt))
+	if n := len(t.Replies); n > 0 {
+		header += commentBoxMetaStyle.Render(fmt.Sprintf(" · %d replies", n))
+	}
+
+	var body []string
+	hunkLines := strings.Split(strings.TrimRight(t.Root.DiffHunk, "\n"), "\n")
+	if len(hunkLines) > outdatedBoxHunkLines {
+		hunkLines = hunkLines[len(hunkLines)-outdatedBoxHunkLines:]
+	}
+	for _, line := range hunkLines {
+		style, display := styleDiffLine(line, false, false)
+		body = append(body, style.Faint(true).Render(display))
+	}
+	if first, _, _ := strings.Cut(strings.TrimSpace(t.Root.Body), "\n"); first != "" {
+		body = append(body, first)
+	}
+
+	borderColor := commentBoxOutdatedBorder
+	if highlighted {
+		borderColor = commentBoxOutdatedBorderHi
+	}
+	return m.renderCommentBox(header, strings.Join(body, "\n"), borderColor, highlighted, gutter)
+}
+
 // injectInlineComments appends any inline comment boxes (AI, GitHub, pending) that
 // are attached to the given file:line. It returns the augmented lines and infos slices.
 func (m *DiffViewerModel) injectInlineComments(
@@ -524,6 +703,17 @@ func (m *DiffViewerModel) injectInlineComments(
 		}
 	}
 
+	// Outdated GitHub threads re-anchored to this line (collapsed)
+	if threads, ok := m.ghOutdatedThreads[key]; ok {
+		for _, t := range threads {
+			threadLines := m.renderOutdatedThread(t, isTargeted, commentGutter)
+			for range threadLines {
+				infos = append(infos, lineInfo{hunkIdx: hunkIdx, filename: filename, comment: commentGitHub})
+			}
+			lines = append(lines, threadLines...)
+		}
+	}
+
 	// Pending inline comments (user + AI drafts)
 	if comments, ok := m.pendingCommentsByFileLine[key]; ok {
 		for _, c := range comments {
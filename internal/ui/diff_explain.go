@@ -0,0 +1,164 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/shhac/prtea/internal/claude"
+)
+
+// explainHunkQuestion is what Claude is asked about a hunk with E.
+const explainHunkQuestion = "Explain what changed in this hunk and why it matters: what the code did before, " +
+	"what it does now, and what could break or behave differently as a result. Keep it to a short paragraph or a few bullets."
+
+// hunkExplanation is Claude's explanation of a hunk, shown in a box under it.
+type hunkExplanation struct {
+	text    string
+	err     string
+	loading bool
+}
+
+// explainKey identifies a hunk across refreshes of the same diff.
+func explainKey(h DiffHunk) string {
+	return h.Filename + " " + h.Header
+}
+
+// toggleExplanation asks Claude to explain the focused hunk, or hides its
+// explanation when one is shown.
+func (m *DiffViewerModel) toggleExplanation() tea.Cmd {
+	if m.focusedHunkIdx < 0 || m.focusedHunkIdx >= len(m.hunks) {
+		return nil
+	}
+	hunk := m.hunks[m.focusedHunkIdx]
+	key := explainKey(hunk)
+	if ex, ok := m.explanations[key]; ok {
+		if ex.loading {
+			return nil
+		}
+		delete(m.explanations, key)
+		m.cachedLines = nil
+		m.refreshContent()
+		return nil
+	}
+	if m.explanations == nil {
+		m.explanations = make(map[string]*hunkExplanation)
+	}
+	m.explanations[key] = &hunkExplanation{loading: true}
+	m.cachedLines = nil
+	m.refreshContent()
+	msg := ExplainHunkMsg{Key: key, Hunk: m.hunkContent(m.focusedHunkIdx), FileContext: m.hunkFileContext(m.focusedHunkIdx)}
+	return func() tea.Msg { return msg }
+}
+
+// SetHunkExplanation shows Claude's explanation (or the error) under the
+// hunk it was asked about, if that hunk is still in the diff.
+func (m *DiffViewerModel) SetHunkExplanation(key, text string, err error) {
+	ex, ok := m.explanations[key]
+	if !ok {
+		return
+	}
+	ex.loading = false
+	ex.text = strings.TrimSpace(text)
+	if err != nil {
+		ex.err = err.Error()
+	}
+	m.cachedLines = nil
+	m.refreshContent()
+}
+
+// hunkFileContext describes the file around a hunk: its name, status and
+// the other changes in it, by hunk header.
+func (m DiffViewerModel) hunkFileContext(idx int) string {
+	hunk := m.hunks[idx]
+	var b strings.Builder
+	fmt.Fprintf(&b, "File: %s", hunk.Filename)
+	if hunk.FileIndex < len(m.files) {
+		fmt.Fprintf(&b, " (%s, +%d -%d)", m.files[hunk.FileIndex].Status, m.files[hunk.FileIndex].Additions, m.files[hunk.FileIndex].Deletions)
+	}
+	b.WriteString("\n")
+	var others []string
+	for i, h := range m.hunks {
+		if i != idx && h.FileIndex == hunk.FileIndex && h.Header != "" {
+			others = append(others, "  "+h.Header)
+		}
+	}
+	if len(others) > 0 {
+		b.WriteString("Other changes in this file:\n" + strings.Join(others, "\n") + "\n")
+	}
+	return b.String()
+}
+
+// renderExplanation renders the explanation box under a hunk, if it has one.
+func (m *DiffViewerModel) renderExplanation(hunkIdx int, isFocused bool) []string {
+	ex, ok := m.explanations[explainKey(m.hunks[hunkIdx])]
+	if !ok {
+		return nil
+	}
+	gutter := "  "
+	if isFocused {
+		gutter = diffFocusGutterStyle.Render("▎") + " "
+	}
+	boxWidth := max(14, m.viewport.Width-2)
+	innerWidth := max(10, boxWidth-4)
+
+	header := commentBoxHeaderStyle.Render("✨ Explanation") + "  " + commentBoxHintStyle.Render("[E hide]")
+	var body string
+	switch {
+	case ex.loading:
+		header = commentBoxHeaderStyle.Render("✨ Explanation")
+		body = dimItalicStyle.Render("Claude is reading this hunk...")
+	case ex.err != "":
+		body = errTextStyle.Render(wordWrap(formatUserError(ex.err), innerWidth))
+	default:
+		body = strings.TrimRight(m.renderMarkdown(ex.text, innerWidth), "\n")
+	}
+
+	box := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(commentBoxAIBorder).
+		Width(boxWidth - 2).
+		PaddingLeft(1).PaddingRight(1).
+		Render(header + "\n" + body)
+	lines := strings.Split(box, "\n")
+	for i, line := range lines {
+		lines[i] = gutter + line
+	}
+	return lines
+}
+
+// explainHunkCmd asks Claude to explain a hunk, outside the chat history.
+func explainHunkCmd(ctx context.Context, chat AIChatService, input claude.ChatInput, prNumber int, key string) tea.Cmd {
+	return func() tea.Msg {
+		text, err := chat.AskOnce(ctx, input, func(string) {})
+		return HunkExplainedMsg{PRNumber: prNumber, Key: key, Text: text, Err: err}
+	}
+}
+
+// handleExplainHunk sends a hunk to Claude for the explanation box.
+func (m App) handleExplainHunk(msg ExplainHunkMsg) (tea.Model, tea.Cmd) {
+	reason := ""
+	switch {
+	case m.session == nil:
+		reason = "No PR selected"
+	case m.chatService == nil:
+		reason = claudeNotFoundReason
+	case m.aiBudget.health == aiDisabled:
+		reason = aiDisabledReason
+	}
+	if reason != "" {
+		m.diffViewer.SetHunkExplanation(msg.Key, "", fmt.Errorf("%s", reason))
+		return m, nil
+	}
+	s := m.session
+	input := claude.ChatInput{
+		Owner:     s.Owner,
+		Repo:      s.Repo,
+		PRNumber:  s.Number,
+		PRContext: fmt.Sprintf("PR #%d: \"%s\" in %s/%s\n\n%s\n%s", s.Number, s.Title, s.Owner, s.Repo, msg.FileContext, msg.Hunk),
+		Message:   explainHunkQuestion,
+	}
+	return m, explainHunkCmd(m.usageContext(), m.chatService, input, s.Number, msg.Key)
+}
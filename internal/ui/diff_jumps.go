@@ -0,0 +1,138 @@
+package ui
+
+import (
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// maxJumps bounds the jump list; the oldest positions are dropped first.
+const maxJumps = 100
+
+// jumpPos is a diff position remembered by the jump list.
+type jumpPos struct {
+	path   string
+	line   int // new-side line, 0 for removed lines
+	cursor int // cached line, used when the line is no longer shown
+}
+
+// jumpList is the editor-style history of positions the diff cursor
+// jumped away from: hunk, search, comment and file jumps, top/bottom and
+// goto. idx is the entry being visited, len(entries) when at the newest
+// position.
+type jumpList struct {
+	entries []jumpPos
+	idx     int
+}
+
+// jumpHere returns the cursor's position, or false when the diff has no
+// rendered lines yet.
+func (m DiffViewerModel) jumpHere() (jumpPos, bool) {
+	if m.cursorLine < 0 || m.cursorLine >= len(m.cachedLineInfo) {
+		return jumpPos{}, false
+	}
+	li := m.cachedLineInfo[m.cursorLine]
+	return jumpPos{path: li.filename, line: li.newLineNum, cursor: m.cursorLine}, true
+}
+
+// pushJump remembers the cursor position before a jump. Positions visited
+// with Ctrl+O after it are dropped, as in a browser's history.
+func (m *DiffViewerModel) pushJump() {
+	pos, ok := m.jumpHere()
+	if !ok {
+		return
+	}
+	j := &m.jumps
+	j.entries = j.entries[:min(j.idx, len(j.entries))]
+	if n := len(j.entries); n > 0 && j.entries[n-1] == pos {
+		j.idx = n
+		return
+	}
+	j.entries = append(j.entries, pos)
+	if len(j.entries) > maxJumps {
+		j.entries = j.entries[len(j.entries)-maxJumps:]
+	}
+	j.idx = len(j.entries)
+}
+
+// canJumpForward reports whether Ctrl+O has left newer positions to return to.
+func (m DiffViewerModel) canJumpForward() bool {
+	return m.jumps.idx < len(m.jumps.entries)-1
+}
+
+// jumpBack returns to the position before the last jump. At the newest
+// position the cursor's position is kept first so it can be returned to.
+// Returns false when there is nothing older.
+func (m *DiffViewerModel) jumpBack() bool {
+	j := &m.jumps
+	if j.idx == 0 || len(j.entries) == 0 {
+		return false
+	}
+	if j.idx >= len(j.entries) {
+		if pos, ok := m.jumpHere(); ok && pos != j.entries[len(j.entries)-1] {
+			j.entries = append(j.entries, pos)
+		}
+		if len(j.entries) == 1 {
+			j.idx = 1
+			return false
+		}
+		j.idx = len(j.entries) - 1
+	}
+	j.idx--
+	m.restoreJump(j.entries[j.idx])
+	return true
+}
+
+// jumpForward undoes a jumpBack. Returns false at the newest position.
+func (m *DiffViewerModel) jumpForward() bool {
+	if !m.canJumpForward() {
+		return false
+	}
+	m.jumps.idx++
+	m.restoreJump(m.jumps.entries[m.jumps.idx])
+	return true
+}
+
+// restoreJump places the cursor on a remembered position: the same line
+// of the same file when it is still shown, otherwise the same cached line.
+func (m *DiffViewerModel) restoreJump(pos jumpPos) {
+	if len(m.cachedLineInfo) == 0 {
+		return
+	}
+	target := min(max(pos.cursor, 0), len(m.cachedLineInfo)-1)
+	if pos.line > 0 {
+		// The line may have moved as comments were shown or hidden; take
+		// the match closest to where it was.
+		best, bestDist := -1, 0
+		for i, li := range m.cachedLineInfo {
+			if !li.isDiffLine || li.filename != pos.path || li.newLineNum != pos.line {
+				continue
+			}
+			dist := i - pos.cursor
+			if dist < 0 {
+				dist = -dist
+			}
+			if best < 0 || dist < bestDist {
+				best, bestDist = i, dist
+			}
+		}
+		if best >= 0 {
+			target = best
+		}
+	}
+	m.placeCursor(target)
+	m.refreshContent()
+}
+
+// jumpPosition returns the 1-based entry being visited and the list length,
+// counting the newest position.
+func (m DiffViewerModel) jumpPosition() (int, int) {
+	return m.jumps.idx + 1, len(m.jumps.entries)
+}
+
+// jumpStatus shows where Ctrl+O / Ctrl+I left the cursor in the jump list.
+func (m *App) jumpStatus() tea.Cmd {
+	pos, total := m.diffViewer.jumpPosition()
+	return m.statusBar.SetTemporaryMessage(fmt.Sprintf("jump %d/%d", pos, total), 2*time.Second)
+}
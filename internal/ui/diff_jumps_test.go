@@ -0,0 +1,118 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func cursorAt(m App) (string, int) {
+	li := m.diffViewer.cachedLineInfo[m.diffViewer.cursorLine]
+	return li.filename, li.newLineNum
+}
+
+func pressJump(t *testing.T, m App, k tea.KeyType) App {
+	t.Helper()
+	model, _ := m.handleKeyMsg(tea.KeyMsg{Type: k})
+	return model.(App)
+}
+
+func TestJumpList_BackAndForward(t *testing.T) {
+	m := commentNavTestApp()
+	m = pressKeys(t, m, "]", "c") // a.go:2
+	m = pressKeys(t, m, "]", "c") // a.go:4
+	m = pressKeys(t, m, "]", "f") // b.go
+	file, line := cursorAt(m)
+
+	m = pressJump(t, m, tea.KeyCtrlO)
+	if f, l := cursorAt(m); f != "a.go" || l != 4 {
+		t.Fatalf("first Ctrl+O: cursor at %s:%d, want a.go:4", f, l)
+	}
+	if m.statusBar.statusMessage != "jump 3/4" {
+		t.Errorf("status = %q", m.statusBar.statusMessage)
+	}
+	m = pressJump(t, m, tea.KeyCtrlO)
+	if f, l := cursorAt(m); f != "a.go" || l != 2 {
+		t.Fatalf("second Ctrl+O: cursor at %s:%d, want a.go:2", f, l)
+	}
+
+	m = pressJump(t, m, tea.KeyTab)
+	m = pressJump(t, m, tea.KeyTab)
+	if f, l := cursorAt(m); f != file || l != line {
+		t.Errorf("Tab back to the newest position: cursor at %s:%d, want %s:%d", f, l, file, line)
+	}
+	if m.focused != PanelCenter {
+		t.Fatal("Tab should jump forward, not cycle panels, after Ctrl+O")
+	}
+	m = pressJump(t, m, tea.KeyTab)
+	if m.focused == PanelCenter {
+		t.Error("with no newer jumps Tab should cycle panels again")
+	}
+}
+
+func TestJumpList_NewJumpDropsForwardHistory(t *testing.T) {
+	m := commentNavTestApp()
+	m = pressKeys(t, m, "]", "c", "]", "c") // a.go:2, then a.go:4
+	m = pressJump(t, m, tea.KeyCtrlO)       // back to a.go:2
+	m = pressKeys(t, m, "]", "f")           // b.go
+	if m.diffViewer.canJumpForward() {
+		t.Error("a new jump should drop the positions ahead of it")
+	}
+	m = pressJump(t, m, tea.KeyCtrlO)
+	if f, l := cursorAt(m); f != "a.go" || l != 2 {
+		t.Errorf("Ctrl+O after a new jump: cursor at %s:%d, want a.go:2", f, l)
+	}
+}
+
+func TestJumpList_ResetByNewDiff(t *testing.T) {
+	m := commentNavTestApp()
+	m = pressKeys(t, m, "]", "c")
+	m.diffViewer.SetDiff(m.diffViewer.files)
+	if m.diffViewer.jumpBack() {
+		t.Error("a newly loaded diff should start with an empty jump list")
+	}
+}
+
+func TestMarks_SetJumpAndList(t *testing.T) {
+	m := commentNavTestApp()
+	m = pressKeys(t, m, "]", "c", "m", "a") // a.go:2
+	if m.statusBar.statusMessage != "Mark a set at a.go:2" {
+		t.Fatalf("status = %q", m.statusBar.statusMessage)
+	}
+	m = pressKeys(t, m, "]", "f", "m", "b") // b.go
+	m = pressKeys(t, m, "'", "a")
+	if f, l := cursorAt(m); f != "a.go" || l != 2 {
+		t.Fatalf("'a: cursor at %s:%d, want a.go:2", f, l)
+	}
+	if m.motionPrefix != "" {
+		t.Errorf("motionPrefix = %q, want it cleared", m.motionPrefix)
+	}
+	m = pressJump(t, m, tea.KeyCtrlO)
+	if f, _ := cursorAt(m); f != "b.go" {
+		t.Errorf("Ctrl+O after 'a: cursor in %s, want b.go", f)
+	}
+
+	m = pressKeys(t, m, "'", "z")
+	if m.statusBar.statusMessage != "Mark z not set" {
+		t.Errorf("status = %q", m.statusBar.statusMessage)
+	}
+	var marks []string
+	for _, it := range m.quickfixItems() {
+		if it.Kind == qfMark {
+			marks = append(marks, it.Text)
+		}
+	}
+	if len(marks) != 2 || marks[0] != "'a" {
+		t.Errorf("quickfix marks = %v", marks)
+	}
+
+	model, _ := m.marksCommand("")
+	if m = model.(App); !strings.HasPrefix(m.statusBar.statusMessage, "Marks: a a.go:2 · b b.go") {
+		t.Errorf(":marks = %q", m.statusBar.statusMessage)
+	}
+	model, _ = m.marksCommand("clear a")
+	if m = model.(App); len(m.session.Bookmarks) != 1 {
+		t.Errorf("marks after clear a = %v", m.session.Bookmarks)
+	}
+}
@@ -0,0 +1,98 @@
+package ui
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// isMarkName reports whether s names a bookmark: a single letter a-z.
+func isMarkName(s string) bool {
+	return len(s) == 1 && s[0] >= 'a' && s[0] <= 'z'
+}
+
+// location is the "path:line" of a remembered position.
+func (p jumpPos) location() string {
+	if p.line == 0 {
+		return p.path
+	}
+	return fmt.Sprintf("%s:%d", p.path, p.line)
+}
+
+// markNames returns the current PR's bookmark names in order.
+func (m App) markNames() []string {
+	if m.session == nil {
+		return nil
+	}
+	names := make([]string, 0, len(m.session.Bookmarks))
+	for name := range m.session.Bookmarks {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// setMark pins the diff cursor's position as a bookmark (m{a-z}). Setting
+// a mark that exists moves it.
+func (m App) setMark(name string) (tea.Model, tea.Cmd) {
+	pos, ok := m.diffViewer.jumpHere()
+	if m.session == nil || !ok {
+		return m, m.statusBar.SetTemporaryMessage("No diff line to mark", 2*time.Second)
+	}
+	if m.session.Bookmarks == nil {
+		m.session.Bookmarks = make(map[string]jumpPos)
+	}
+	m.session.Bookmarks[name] = pos
+	return m, m.statusBar.SetTemporaryMessage(fmt.Sprintf("Mark %s set at %s", name, pos.location()), 2*time.Second)
+}
+
+// jumpToMark moves the diff cursor to a bookmark ('{a-z}), remembering
+// where it was in the jump list.
+func (m App) jumpToMark(name string) (tea.Model, tea.Cmd) {
+	var pos jumpPos
+	ok := false
+	if m.session != nil {
+		pos, ok = m.session.Bookmarks[name]
+	}
+	if !ok {
+		return m, m.statusBar.SetTemporaryMessage(fmt.Sprintf("Mark %s not set", name), 2*time.Second)
+	}
+	m.diffViewer.pushJump()
+	m.diffViewer.restoreJump(pos)
+	return m, m.statusBar.SetTemporaryMessage(fmt.Sprintf("mark %s: %s", name, pos.location()), 2*time.Second)
+}
+
+// marksCommand handles :marks, listing the current PR's bookmarks, and
+// :marks clear [a b ...], deleting some or all of them.
+func (m App) marksCommand(args string) (tea.Model, tea.Cmd) {
+	if m.session == nil {
+		return m, m.statusBar.SetTemporaryMessage("No PR selected", 2*time.Second)
+	}
+	fields := strings.Fields(args)
+	if len(fields) > 0 && fields[0] != "clear" {
+		return m, m.statusBar.SetTemporaryMessage("Usage: marks [clear [a b ...]]", 2*time.Second)
+	}
+	if len(fields) > 0 {
+		if len(fields) == 1 {
+			m.session.Bookmarks = nil
+			return m, m.statusBar.SetTemporaryMessage("Cleared all marks", 2*time.Second)
+		}
+		for _, name := range fields[1:] {
+			delete(m.session.Bookmarks, name)
+		}
+		return m, m.statusBar.SetTemporaryMessage("Cleared mark "+strings.Join(fields[1:], " "), 2*time.Second)
+	}
+
+	names := m.markNames()
+	if len(names) == 0 {
+		return m, m.statusBar.SetTemporaryMessage("No marks set (m{a-z} in the diff sets one)", 2*time.Second)
+	}
+	parts := make([]string, len(names))
+	for i, name := range names {
+		parts[i] = name + " " + m.session.Bookmarks[name].location()
+	}
+	return m, m.statusBar.SetTemporaryMessage("Marks: "+strings.Join(parts, " · "), 5*time.Second)
+}
@@ -0,0 +1,230 @@
+package ui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// motionTimeout is how long a lone "[", "]" or digit waits for the rest of
+// a diff motion before it does what it does on its own.
+const motionTimeout = 400 * time.Millisecond
+
+// motionTimeoutMsg fires when a motion prefix was not completed in time.
+type motionTimeoutMsg struct {
+	seq int
+}
+
+// startsMotion reports whether a key pressed in the diff may start a
+// motion: a bracket ("]c"), a count digit ("42G") or a bookmark ("ma", "'a").
+func startsMotion(msg tea.KeyMsg) bool {
+	if key.Matches(msg, GlobalKeys.ToggleLeft) || key.Matches(msg, GlobalKeys.ToggleRight) {
+		return true
+	}
+	s := msg.String()
+	return isMarkPrefix(s) || len(s) == 1 && s[0] >= '1' && s[0] <= '9'
+}
+
+// isMarkPrefix reports whether a motion prefix sets ("m") or jumps to
+// ("'") a bookmark. Neither key means anything on its own, so they wait
+// for the mark name without a timeout.
+func isMarkPrefix(prefix string) bool {
+	return prefix == "m" || prefix == "'"
+}
+
+// isCount reports whether a motion prefix is a count.
+func isCount(prefix string) bool {
+	return prefix != "" && prefix[0] >= '0' && prefix[0] <= '9'
+}
+
+// startMotion holds a key pressed in the diff until the next one shows
+// whether it starts a motion or keeps its usual meaning.
+func (m App) startMotion(prefix string) (tea.Model, tea.Cmd) {
+	m.motionPrefix = prefix
+	m.motionSeq++
+	if isMarkPrefix(prefix) {
+		return m, nil
+	}
+	seq := m.motionSeq
+	return m, tea.Tick(motionTimeout, func(time.Time) tea.Msg { return motionTimeoutMsg{seq: seq} })
+}
+
+// finishMotion runs the motion named by the key after a prefix. Any other
+// key applies the prefix on its own and is then handled normally.
+func (m App) finishMotion(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	prefix := m.motionPrefix
+	s := msg.String()
+	if s == "esc" {
+		m.motionPrefix = ""
+		return m, nil
+	}
+	if isMarkPrefix(prefix) {
+		m.motionPrefix = ""
+		switch {
+		case !isMarkName(s):
+			return m, m.statusBar.SetTemporaryMessage("Marks are named a-z", 2*time.Second)
+		case prefix == "m":
+			return m.setMark(s)
+		default:
+			return m.jumpToMark(s)
+		}
+	}
+	if isCount(prefix) {
+		switch {
+		case len(s) == 1 && s[0] >= '0' && s[0] <= '9':
+			return m.startMotion(prefix + s)
+		case s == "G":
+			m.motionPrefix = ""
+			n, _ := strconv.Atoi(prefix)
+			return m.gotoLine("", n)
+		}
+	} else {
+		forward := prefix == "]"
+		switch s {
+		case "c":
+			m.motionPrefix = ""
+			return m.jumpToComment(forward)
+		case "f":
+			m.motionPrefix = ""
+			return m.stepFile(forward)
+		}
+	}
+	m.flushMotionPrefix()
+	return m.handleKeyMsg(msg)
+}
+
+// flushMotionPrefix applies a pending prefix as the key it was on its own:
+// "[" and "]" toggle their panel and a single digit focuses its panel. A
+// count of more than one digit is dropped.
+func (m *App) flushMotionPrefix() {
+	prefix := m.motionPrefix
+	m.motionPrefix = ""
+	switch prefix {
+	case "[", "]":
+		panel := PanelLeft
+		if prefix == "]" {
+			panel = PanelRight
+		}
+		if m.zoomed {
+			m.exitZoom()
+		}
+		m.togglePanel(panel)
+	case "1":
+		m.showAndFocusPanel(PanelLeft)
+	case "2":
+		m.showAndFocusPanel(PanelCenter)
+	case "3":
+		m.showAndFocusPanel(PanelRight)
+	}
+}
+
+// jumpToComment moves the diff cursor to the next or previous line with
+// an inline comment, wrapping around, and shows its position.
+func (m App) jumpToComment(forward bool) (tea.Model, tea.Cmd) {
+	delta := 1
+	if !forward {
+		delta = -1
+	}
+	pos, total := m.diffViewer.jumpToComment(delta)
+	if total == 0 {
+		return m, m.statusBar.SetTemporaryMessage("No comments in the diff", 2*time.Second)
+	}
+	m.diffViewer.refreshContent()
+	return m, m.statusBar.SetTemporaryMessage(fmt.Sprintf("comment %d/%d", pos, total), 2*time.Second)
+}
+
+// stepFile moves the diff to the next or previous file header, wrapping
+// around, and shows which file was reached.
+func (m App) stepFile(forward bool) (tea.Model, tea.Cmd) {
+	delta := 1
+	if !forward {
+		delta = -1
+	}
+	pos, total := m.diffViewer.stepFile(delta)
+	if total == 0 {
+		return m, nil
+	}
+	m.diffViewer.refreshContent()
+	name := m.diffViewer.files[pos-1].Filename
+	return m, m.statusBar.SetTemporaryMessage(fmt.Sprintf("file %d/%d: %s", pos, total, name), 2*time.Second)
+}
+
+// parseGotoTarget splits a :goto argument such as "ui/app.go:42" into a
+// path and line. A bare number is a line in the current file and a bare
+// path means the top of that file.
+func parseGotoTarget(arg string) (path string, line int, ok bool) {
+	arg = strings.TrimSpace(arg)
+	if arg == "" {
+		return "", 0, false
+	}
+	if n, err := strconv.Atoi(strings.TrimPrefix(arg, ":")); err == nil {
+		return "", n, n > 0
+	}
+	if i := strings.LastIndexByte(arg, ':'); i >= 0 {
+		n, err := strconv.Atoi(arg[i+1:])
+		if err != nil || n <= 0 {
+			return "", 0, false
+		}
+		return arg[:i], n, arg[:i] != ""
+	}
+	return arg, 0, true
+}
+
+// gotoLine places the diff cursor on a new-side line of a file, or the
+// closest line the diff shows. An empty path means the current file; the
+// path may be partial, as in AI output and CI logs.
+func (m App) gotoLine(path string, line int) (tea.Model, tea.Cmd) {
+	files := m.diffViewer.files
+	if len(files) == 0 {
+		return m, m.statusBar.SetTemporaryMessage("No diff loaded yet", 2*time.Second)
+	}
+	if path == "" {
+		idx := m.diffViewer.currentFileIndex()
+		if idx < 0 {
+			idx = 0
+		}
+		path = files[idx].Filename
+	} else {
+		names := citationFiles(files)
+		resolved := resolveCitationPath(path, names)
+		if resolved == "" {
+			if idx := matchFile(path, names); idx >= 0 {
+				resolved = names[idx]
+			}
+		}
+		if resolved == "" {
+			return m, m.statusBar.SetTemporaryMessage("No file matches "+path, 2*time.Second)
+		}
+		path = resolved
+	}
+
+	m.diffViewer.activeTab = TabDiff
+	m.diffViewer.refreshContent()
+	m.diffViewer.jumpToLine(path, line)
+	m.diffViewer.refreshContent()
+	m.showAndFocusPanel(PanelCenter)
+
+	target := path
+	if line > 0 {
+		target = fmt.Sprintf("%s:%d", path, line)
+		if c := m.diffViewer.cursorLine; c >= 0 && c < len(m.diffViewer.cachedLineInfo) {
+			if li := m.diffViewer.cachedLineInfo[c]; li.filename == path && li.newLineNum != line {
+				target += fmt.Sprintf(" (not in the diff, nearest %d)", li.newLineNum)
+			}
+		}
+	}
+	return m, m.statusBar.SetTemporaryMessage(target, 2*time.Second)
+}
+
+// gotoCommand handles :goto path/to/file.go:123.
+func (m App) gotoCommand(arg string) (tea.Model, tea.Cmd) {
+	path, line, ok := parseGotoTarget(arg)
+	if !ok {
+		return m, m.statusBar.SetTemporaryMessage("Usage: goto path/to/file.go:123", 2*time.Second)
+	}
+	return m.gotoLine(path, line)
+}
@@ -0,0 +1,156 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/shhac/prtea/internal/github"
+)
+
+func commentNavTestApp() App {
+	m := App{
+		statusBar:    NewStatusBarModel(),
+		diffViewer:   newTestDiffViewer(80, 40),
+		chatPanel:    NewChatPanelModel(),
+		focused:      PanelCenter,
+		panelVisible: [3]bool{true, true, true},
+		session:      &PRSession{Number: 1},
+	}
+	m.diffViewer.focused = true
+	m.diffViewer.SetDiff([]github.PRFile{
+		{Filename: "a.go", Status: "modified", Patch: "@@ -1,3 +1,4 @@\n one\n+two\n three\n four"},
+		{Filename: "b.go", Status: "modified", Patch: "@@ -1,2 +1,3 @@\n one\n+two\n three"},
+	})
+	m.diffViewer.SetGitHubInlineComments([]github.InlineComment{
+		{ID: 10, Author: github.User{Login: "carol"}, Body: "Why?", Path: "a.go", Line: 4},
+		{ID: 11, Author: github.User{Login: "dave"}, Body: "Nit", Path: "a.go", Line: 2},
+	})
+	m.diffViewer.SetPendingInlineComments([]PendingInlineComment{pending("b.go", 2)})
+	return m
+}
+
+func pressKeys(t *testing.T, m App, keys ...string) App {
+	t.Helper()
+	for _, k := range keys {
+		model, _ := m.handleKeyMsg(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(k)})
+		m = model.(App)
+	}
+	return m
+}
+
+func TestBracketCommentNavigationWraps(t *testing.T) {
+	m := commentNavTestApp()
+	steps := []struct {
+		keys   []string
+		file   string
+		line   int
+		status string
+	}{
+		{[]string{"]", "c"}, "a.go", 2, "comment 1/3"},
+		{[]string{"]", "c"}, "a.go", 4, "comment 2/3"},
+		{[]string{"]", "c"}, "b.go", 2, "comment 3/3"},
+		{[]string{"]", "c"}, "a.go", 2, "comment 1/3"},
+		{[]string{"[", "c"}, "b.go", 2, "comment 3/3"},
+	}
+	for i, step := range steps {
+		m = pressKeys(t, m, step.keys...)
+		li := m.diffViewer.cachedLineInfo[m.diffViewer.cursorLine]
+		if li.filename != step.file || li.newLineNum != step.line {
+			t.Errorf("step %d: cursor at %s:%d, want %s:%d", i, li.filename, li.newLineNum, step.file, step.line)
+		}
+		if !strings.Contains(m.statusBar.statusMessage, step.status) {
+			t.Errorf("step %d: status = %q, want %q", i, m.statusBar.statusMessage, step.status)
+		}
+	}
+	if !m.panelVisible[PanelLeft] || !m.panelVisible[PanelRight] {
+		t.Error("bracket motions must not toggle the side panels")
+	}
+}
+
+func TestBracketPrefixStillTogglesPanels(t *testing.T) {
+	m := pressKeys(t, commentNavTestApp(), "]")
+	if !m.panelVisible[PanelRight] {
+		t.Fatal("the toggle should wait for the next key")
+	}
+	model, _ := m.Update(motionTimeoutMsg{seq: m.motionSeq})
+	m = model.(App)
+	if m.panelVisible[PanelRight] {
+		t.Error("a lone ] should toggle the right panel after the timeout")
+	}
+
+	m = pressKeys(t, m, "[", "j")
+	if m.panelVisible[PanelLeft] {
+		t.Error("[ followed by another key should toggle the left panel")
+	}
+	if m.motionPrefix != "" {
+		t.Errorf("motionPrefix = %q, want it cleared", m.motionPrefix)
+	}
+}
+
+func TestBracketFileNavigationWraps(t *testing.T) {
+	m := commentNavTestApp()
+	m.diffViewer.height = 11 // a 6-line viewport, short enough to scroll to each header
+	m.diffViewer.refreshContent()
+	for i, want := range []string{"b.go", "a.go", "b.go"} {
+		keys := []string{"]", "f"}
+		if i == 2 {
+			keys = []string{"[", "f"}
+		}
+		m = pressKeys(t, m, keys...)
+		li := m.diffViewer.cachedLineInfo[m.diffViewer.cursorLine]
+		if li.filename != want {
+			t.Errorf("step %d: cursor in %s, want %s", i, li.filename, want)
+		}
+		if off := m.diffViewer.fileOffsets[m.diffViewer.fileIndex(want)]; m.diffViewer.viewport.YOffset != off {
+			t.Errorf("step %d: viewport at %d, want the file header at %d", i, m.diffViewer.viewport.YOffset, off)
+		}
+	}
+}
+
+func TestCountG(t *testing.T) {
+	m := pressKeys(t, commentNavTestApp(), "]", "f", "3", "G")
+	li := m.diffViewer.cachedLineInfo[m.diffViewer.cursorLine]
+	if li.filename != "b.go" || li.newLineNum != 3 {
+		t.Errorf("3G: cursor at %s:%d, want b.go:3 in the current file", li.filename, li.newLineNum)
+	}
+	if m.focused != PanelCenter || m.motionPrefix != "" {
+		t.Errorf("3G should stay in the diff with no pending count, focused=%d prefix=%q", m.focused, m.motionPrefix)
+	}
+
+	m = pressKeys(t, m, "1")
+	model, _ := m.Update(motionTimeoutMsg{seq: m.motionSeq})
+	if m = model.(App); m.focused != PanelLeft {
+		t.Error("a lone 1 should still focus the PR list")
+	}
+}
+
+func TestGotoCommand(t *testing.T) {
+	tests := []struct {
+		arg        string
+		file       string
+		line       int
+		wantStatus string
+	}{
+		{"b.go:2", "b.go", 2, "b.go:2"},
+		{"./a.go:4", "a.go", 4, "a.go:4"},
+		{"a.go:99", "a.go", 4, "nearest 4"},
+		{"b", "b.go", 1, "b.go"},
+	}
+	for _, tt := range tests {
+		model, _ := commentNavTestApp().gotoCommand(tt.arg)
+		m := model.(App)
+		li := m.diffViewer.cachedLineInfo[m.diffViewer.cursorLine]
+		if li.filename != tt.file || li.newLineNum != tt.line {
+			t.Errorf("goto %s: cursor at %s:%d, want %s:%d", tt.arg, li.filename, li.newLineNum, tt.file, tt.line)
+		}
+		if !strings.Contains(m.statusBar.statusMessage, tt.wantStatus) {
+			t.Errorf("goto %s: status = %q, want %q", tt.arg, m.statusBar.statusMessage, tt.wantStatus)
+		}
+	}
+
+	model, _ := commentNavTestApp().gotoCommand("main.rs:1")
+	if m := model.(App); !strings.Contains(m.statusBar.statusMessage, "No file matches") {
+		t.Errorf("status = %q", m.statusBar.statusMessage)
+	}
+}
@@ -1,5 +1,7 @@
 package ui
 
+import "sort"
+
 // syncFocusToScroll updates focusedHunkIdx to match the current viewport scroll position.
 // It picks the last hunk whose header is in the top third of the viewport.
 func (m *DiffViewerModel) syncFocusToScroll() {
@@ -32,6 +34,179 @@ func (m *DiffViewerModel) scrollToFocusedHunk() {
 	m.viewport.SetYOffset(target)
 }
 
+// jumpToFile focuses the first hunk of the given file and scrolls to it.
+// Files without parsed hunks (e.g. binary) just scroll to their header.
+func (m *DiffViewerModel) jumpToFile(fileIdx int) bool {
+	m.pushJump()
+	for i, h := range m.hunks {
+		if h.FileIndex == fileIdx {
+			m.cancelSelection()
+			m.focusedHunkIdx = i
+			m.scrollToFocusedHunk()
+			m.syncCursorToFocusedHunk()
+			return true
+		}
+	}
+	if fileIdx >= 0 && fileIdx < len(m.fileOffsets) {
+		m.viewport.SetYOffset(m.fileOffsets[fileIdx])
+		return true
+	}
+	return false
+}
+
+// currentFileIndex returns the file under the cursor when the cursor is on
+// screen, otherwise the last file whose header is at or above the top of
+// the viewport; -1 when the diff is empty.
+func (m DiffViewerModel) currentFileIndex() int {
+	vp := m.viewport
+	if m.cursorLine >= vp.YOffset && m.cursorLine < vp.YOffset+vp.Height && m.cursorLine < len(m.cachedLineInfo) {
+		if idx := m.fileIndex(m.cachedLineInfo[m.cursorLine].filename); idx >= 0 {
+			return idx
+		}
+	}
+	idx := -1
+	for i, off := range m.fileOffsets {
+		if off > vp.YOffset {
+			break
+		}
+		idx = i
+	}
+	return idx
+}
+
+// stepFile moves to the header of the next (delta > 0) or previous file,
+// wrapping around, with the cursor on its first changed line. Returns the
+// 1-based position of the file reached and the number of files; total is
+// 0 when the diff has no files.
+func (m *DiffViewerModel) stepFile(delta int) (pos, total int) {
+	n := len(m.fileOffsets)
+	if n == 0 || len(m.files) != n {
+		return 0, 0
+	}
+	next := 0
+	if cur := m.currentFileIndex(); cur >= 0 {
+		next = ((cur+delta)%n + n) % n
+	} else if delta < 0 {
+		next = n - 1
+	}
+	m.showFile(next)
+	return next + 1, n
+}
+
+// showFile jumps to a file and scrolls its header to the top.
+func (m *DiffViewerModel) showFile(idx int) {
+	m.jumpToFile(idx)
+	m.viewport.SetYOffset(m.fileOffsets[idx])
+	m.ensureCursorVisible()
+}
+
+// fileIndex returns the index of the named file in the diff, or -1.
+func (m DiffViewerModel) fileIndex(path string) int {
+	for i, f := range m.files {
+		if f.Filename == path {
+			return i
+		}
+	}
+	return -1
+}
+
+// jumpToLine moves the cursor to the diff line showing the given new-side
+// line of a file, or the closest line of that file shown in the diff. Falls
+// back to the file's first hunk when no line matches (e.g. line 0).
+func (m *DiffViewerModel) jumpToLine(path string, line int) bool {
+	best, bestDist := -1, 0
+	for i, li := range m.cachedLineInfo {
+		if !li.isDiffLine || li.filename != path || li.newLineNum == 0 || line == 0 {
+			continue
+		}
+		dist := li.newLineNum - line
+		if dist < 0 {
+			dist = -dist
+		}
+		if best < 0 || dist < bestDist {
+			best, bestDist = i, dist
+		}
+	}
+	if best < 0 {
+		return m.jumpToFile(m.fileIndex(path))
+	}
+	m.pushJump()
+	m.placeCursor(best)
+	return true
+}
+
+// placeCursor moves the cursor to cached line i, focusing its hunk and
+// scrolling so the line sits a few lines from the top with its context.
+func (m *DiffViewerModel) placeCursor(i int) {
+	m.cancelSelection()
+	if m.cursorLine >= 0 && m.cursorLine < len(m.cachedLineInfo) {
+		if old := m.cachedLineInfo[m.cursorLine].hunkIdx; old >= 0 {
+			m.markHunkDirty(old)
+		}
+	}
+	m.cursorLine = i
+	if h := m.cachedLineInfo[i].hunkIdx; h >= 0 {
+		m.focusedHunkIdx = h
+		m.markHunkDirty(h)
+	}
+	m.viewport.SetYOffset(max(0, i-3))
+	m.ensureCursorVisible()
+}
+
+// commentStops returns the cached lines of diff lines that carry inline
+// comments (GitHub, AI or pending), in display order. Comment boxes are
+// rendered directly below the line they belong to.
+func (m DiffViewerModel) commentStops() []int {
+	var stops []int
+	anchor := -1
+	for i, li := range m.cachedLineInfo {
+		if li.isDiffLine {
+			anchor = i
+			continue
+		}
+		if li.comment != commentNone && anchor >= 0 && (len(stops) == 0 || stops[len(stops)-1] != anchor) {
+			stops = append(stops, anchor)
+		}
+	}
+	return stops
+}
+
+// jumpToComment moves the cursor to the next (delta > 0) or previous
+// commented line, wrapping around the diff. Returns the 1-based position
+// of the line reached and the number of commented lines; total is 0 when
+// there are none.
+func (m *DiffViewerModel) jumpToComment(delta int) (pos, total int) {
+	stops := m.commentStops()
+	if len(stops) == 0 {
+		return 0, 0
+	}
+	var idx int
+	if delta > 0 {
+		idx = sort.SearchInts(stops, m.cursorLine+1)
+		if idx == len(stops) {
+			idx = 0
+		}
+	} else {
+		idx = sort.SearchInts(stops, m.cursorLine) - 1
+		if idx < 0 {
+			idx = len(stops) - 1
+		}
+	}
+	m.pushJump()
+	m.placeCursor(stops[idx])
+	return idx + 1, len(stops)
+}
+
+// commentPosition returns the 1-based position of the cursor line among the
+// commented lines, 0 when it isn't one, and the number of commented lines.
+func (m *DiffViewerModel) commentPosition() (pos, total int) {
+	stops := m.commentStops()
+	if i := sort.SearchInts(stops, m.cursorLine); i < len(stops) && stops[i] == m.cursorLine {
+		return i + 1, len(stops)
+	}
+	return 0, len(stops)
+}
+
 // moveCursor moves the line cursor by delta positions, skipping non-diff lines.
 // It also updates focusedHunkIdx and marks affected hunks dirty.
 func (m *DiffViewerModel) moveCursor(delta int) {
@@ -26,6 +26,22 @@ func (m *DiffViewerModel) buildCachedLines() {
 
 	nonHunkInfo := lineInfo{hunkIdx: -1}
 
+	if m.sinceDiff != "" {
+		lines = append(lines, sinceBannerStyle.Render(fitWidth(m.sinceDiff+" — :since for the full diff", innerWidth)))
+		infos = append(infos, nonHunkInfo)
+	}
+	if m.focus != nil {
+		lines = append(lines, guidedStepStyle.Render(fitWidth(m.focusBanner(), innerWidth)))
+		infos = append(infos, nonHunkInfo)
+	} else {
+		for _, l := range m.stats.render(m.files, innerWidth) {
+			lines = append(lines, l)
+			infos = append(infos, nonHunkInfo)
+		}
+	}
+	lines = append(lines, "")
+	infos = append(infos, nonHunkInfo)
+
 	for i, f := range m.files {
 		if i > 0 {
 			lines = append(lines, "")
@@ -35,7 +51,23 @@ func (m *DiffViewerModel) buildCachedLines() {
 		m.fileOffsets[i] = len(lines)
 
 		// File header
-		lines = append(lines, diffFileHeaderStyle.Render(fileStatusLabel(f)))
+		header := diffFileHeaderStyle.Render(fileStatusLabel(f))
+		if bar := changeBar(f.Additions, f.Deletions, m.stats.maxChanges); bar != "" {
+			header += " " + bar
+		}
+		if i < len(m.testPairs) && missingTestChange(f, m.testPairs[i]) {
+			header += "  " + missingTestBadgeStyle.Render("⚠ no test changes")
+		}
+		if m.excludedFiles[f.Filename] {
+			header += "  " + outOfScopeBadgeStyle.Render("⊘ out of scope")
+		}
+		if badge := m.guidedHeaderBadge(i); badge != "" {
+			header += "  " + badge
+		}
+		if badge := m.focusViewedBadge(f); badge != "" {
+			header += "  " + badge
+		}
+		lines = append(lines, header)
 		infos = append(infos, nonHunkInfo)
 
 		// Separator
@@ -81,7 +113,7 @@ func (m *DiffViewerModel) renderHunkLines(hunkIdx int) ([]string, []lineInfo) {
 	hunk := m.hunks[hunkIdx]
 	selected := m.selectedHunks[hunkIdx]
 	isFocused := hunkIdx == m.focusedHunkIdx
-	hasInlineComments := len(m.aiCommentsByFileLine) > 0 || len(m.ghCommentThreads) > 0 || len(m.pendingCommentsByFileLine) > 0
+	hasInlineComments := len(m.aiCommentsByFileLine) > 0 || len(m.ghCommentThreads) > 0 || len(m.ghOutdatedThreads) > 0 || len(m.pendingCommentsByFileLine) > 0
 	lines := make([]string, 0, len(hunk.Lines))
 	infos := make([]lineInfo, 0, len(hunk.Lines))
 
@@ -134,6 +166,9 @@ func (m *DiffViewerModel) renderHunkLines(hunkIdx int) ([]string, []lineInfo) {
 		commentable := newLine > 0 && !strings.HasPrefix(line, "-") && !strings.HasPrefix(line, `\`) && !strings.HasPrefix(line, "@@")
 
 		gutter := renderGutter(isCursorLine, isInSelection, isFocused)
+		if commentable {
+			gutter = m.coverageGutter(gutter, hunk.Filename, newLine)
+		}
 		style, displayLine := styleDiffLine(line, isFocused, selected)
 
 		if selected {
@@ -158,7 +193,17 @@ func (m *DiffViewerModel) renderHunkLines(hunkIdx int) ([]string, []lineInfo) {
 			}
 			lines = append(lines, gutter+renderLineWithHighlights(displayLine, lineMatches, prefixLen, style, currentMatchPos))
 		} else {
-			lines = append(lines, gutter+style.Render(displayLine))
+			rendered := gutter + style.Render(displayLine)
+			if selected && lineIdx == 0 {
+				if badge := m.hunkOrderBadge(hunkIdx); badge != "" {
+					rendered += " " + primaryHunkBadgeStyle.Render(badge)
+				}
+			}
+			lines = append(lines, rendered)
+		}
+		var change byte
+		if len(line) > 0 && (line[0] == '+' || line[0] == '-') {
+			change = line[0]
 		}
 		infos = append(infos, lineInfo{
 			hunkIdx:       hunkIdx,
@@ -166,6 +211,7 @@ func (m *DiffViewerModel) renderHunkLines(hunkIdx int) ([]string, []lineInfo) {
 			newLineNum:    newLine,
 			isCommentable: commentable,
 			isDiffLine:    true,
+			change:        change,
 		})
 
 		// Inject inline comments after matching lines (+ or context lines)
@@ -179,6 +225,11 @@ func (m *DiffViewerModel) renderHunkLines(hunkIdx int) ([]string, []lineInfo) {
 		}
 	}
 
+	for _, line := range m.renderExplanation(hunkIdx, isFocused) {
+		lines = append(lines, line)
+		infos = append(infos, lineInfo{hunkIdx: hunkIdx, filename: hunk.Filename})
+	}
+
 	return lines, infos
 }
 
@@ -241,7 +292,7 @@ func (m *DiffViewerModel) rerenderHunkInCache(hunkIdx int) {
 	if hunkIdx < 0 || hunkIdx >= len(m.hunkLineRanges) {
 		return
 	}
-	if len(m.aiCommentsByFileLine) > 0 || len(m.ghCommentThreads) > 0 || len(m.pendingCommentsByFileLine) > 0 {
+	if len(m.aiCommentsByFileLine) > 0 || len(m.ghCommentThreads) > 0 || len(m.ghOutdatedThreads) > 0 || len(m.pendingCommentsByFileLine) > 0 {
 		m.cachedLines = nil
 		return
 	}
@@ -2,10 +2,12 @@ package ui
 
 import (
 	"fmt"
+	"regexp"
 	"strings"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
 )
 
 // handleSearchModeKey processes key events while search input mode is active.
@@ -25,6 +27,12 @@ func (m *DiffViewerModel) handleSearchModeKey(msg tea.KeyMsg) (DiffViewerModel,
 		m.searchInput.Blur()
 		m.refreshContent()
 		return *m, nil
+	case "ctrl+r":
+		m.searchRegex = !m.searchRegex
+		m.computeSearchMatches()
+		m.cachedLines = nil
+		m.refreshContent()
+		return *m, nil
 	default:
 		var cmd tea.Cmd
 		m.searchInput, cmd = m.searchInput.Update(msg)
@@ -52,6 +60,9 @@ func (m DiffViewerModel) SearchInfo() string {
 	if m.searchTerm == "" {
 		return ""
 	}
+	if m.searchErr != "" {
+		return "Invalid regex"
+	}
 	if len(m.searchMatches) == 0 {
 		return "No matches"
 	}
@@ -70,6 +81,7 @@ func (m *DiffViewerModel) clearSearch() {
 	m.searchMatches = nil
 	m.searchMatchesByHunk = nil
 	m.searchMatchIdx = 0
+	m.searchErr = ""
 	m.searchInput.SetValue("")
 	m.searchInput.Blur()
 }
@@ -79,30 +91,51 @@ func (m DiffViewerModel) searchBarVisible() bool {
 	return m.searchMode || m.searchTerm != ""
 }
 
-// computeSearchMatches scans all hunks for case-insensitive matches of the search term.
+// searchPattern compiles a search term, literally or as a regular
+// expression. Smart case: the search ignores case unless the term has an
+// upper-case letter (escapes such as \S in a regex don't count).
+func searchPattern(term string, regex bool) (*regexp.Regexp, error) {
+	expr := term
+	letters := term
+	if regex {
+		letters = regexEscapes.ReplaceAllString(term, "")
+	} else {
+		expr = regexp.QuoteMeta(term)
+	}
+	if strings.ToLower(letters) == letters {
+		expr = "(?i)" + expr
+	}
+	return regexp.Compile(expr)
+}
+
+// regexEscapes matches backslash escapes in a regex search term.
+var regexEscapes = regexp.MustCompile(`\\.`)
+
+// computeSearchMatches scans all hunks for matches of the search term.
 func (m *DiffViewerModel) computeSearchMatches() {
 	m.searchMatches = nil
 	m.searchMatchesByHunk = nil
 	m.searchMatchIdx = 0
+	m.searchErr = ""
 
 	if m.searchTerm == "" {
 		return
 	}
 
-	lowerTerm := strings.ToLower(m.searchTerm)
+	re, err := searchPattern(m.searchTerm, m.searchRegex)
+	if err != nil {
+		m.searchErr = err.Error()
+		return
+	}
 	m.searchMatchesByHunk = make(map[int]map[int][]matchPos)
 
 	for hunkIdx, hunk := range m.hunks {
 		for lineIdx, line := range hunk.Lines {
-			lower := strings.ToLower(line)
-			start := 0
-			for {
-				idx := strings.Index(lower[start:], lowerTerm)
-				if idx == -1 {
-					break
+			for _, loc := range re.FindAllStringIndex(line, -1) {
+				absStart, absEnd := loc[0], loc[1]
+				if absStart == absEnd {
+					continue // empty matches such as "x*" have nothing to highlight
 				}
-				absStart := start + idx
-				absEnd := absStart + len(lowerTerm)
 
 				m.searchMatches = append(m.searchMatches, searchMatch{
 					hunkIdx:    hunkIdx,
@@ -118,8 +151,6 @@ func (m *DiffViewerModel) computeSearchMatches() {
 					m.searchMatchesByHunk[hunkIdx][lineIdx],
 					matchPos{startCol: absStart, endCol: absEnd},
 				)
-
-				start = absEnd
 			}
 		}
 	}
@@ -146,16 +177,55 @@ func (m *DiffViewerModel) getLineSearchMatches(hunkIdx, lineIdx int) []matchPos
 	return nil
 }
 
+// searchPrompt is the search bar prompt, "re/" in regex mode.
+func (m DiffViewerModel) searchPrompt() string {
+	if m.searchRegex {
+		return "re/"
+	}
+	return "/"
+}
+
 // renderSearchBar renders the search input bar (shown during active search mode).
 func (m DiffViewerModel) renderSearchBar() string {
-	prompt := diffSearchInfoStyle.Render("/")
-	return prompt + m.searchInput.View()
+	prompt := diffSearchInfoStyle.Render(m.searchPrompt())
+	bar := prompt + m.searchInput.View()
+	hint := "Ctrl+R regex"
+	if m.searchRegex {
+		hint = "Ctrl+R literal"
+	}
+	if m.searchErr != "" {
+		hint = "invalid regex"
+	}
+	return bar + "  " + dimStyle.Render(hint)
 }
 
-// renderSearchInfo renders the search term and match count (shown when search is active but not typing).
+// renderSearchInfo renders the search term, match count and matches per
+// file (shown when search is active but not typing).
 func (m DiffViewerModel) renderSearchInfo() string {
 	info := m.SearchInfo()
-	return diffSearchInfoStyle.Render(fmt.Sprintf(" /%s  %s ", m.searchTerm, info))
+	line := diffSearchInfoStyle.Render(fmt.Sprintf(" %s%s  %s ", m.searchPrompt(), m.searchTerm, info))
+	if summary := m.searchFileSummary(); summary != "" {
+		line += " " + dimStyle.Render(summary)
+	}
+	return ansi.Truncate(line, max(1, m.width-4), "…")
+}
+
+// searchFileSummary lists the match count of each file with matches, in
+// diff order, e.g. "app.go 5 · main.go 2".
+func (m DiffViewerModel) searchFileSummary() string {
+	var parts []string
+	counts := make(map[string]int)
+	for _, match := range m.searchMatches {
+		name := m.hunks[match.hunkIdx].Filename
+		if counts[name] == 0 {
+			parts = append(parts, name)
+		}
+		counts[name]++
+	}
+	for i, name := range parts {
+		parts[i] = fmt.Sprintf("%s %d", name[strings.LastIndexByte(name, '/')+1:], counts[name])
+	}
+	return strings.Join(parts, " · ")
 }
 
 // renderLineWithHighlights renders a display line with search match highlights applied.
@@ -199,3 +269,34 @@ func renderLineWithHighlights(displayLine string, matches []matchPos, prefixLen
 
 	return b.String()
 }
+
+// diffSearchState is the per-PR search context remembered across refreshes
+// and PR switches.
+type diffSearchState struct {
+	term     string
+	regex    bool
+	matchIdx int
+}
+
+// searchState captures the active search so it can be restored later.
+func (m DiffViewerModel) searchState() diffSearchState {
+	return diffSearchState{term: m.searchTerm, regex: m.searchRegex, matchIdx: m.searchMatchIdx}
+}
+
+// restoreSearchState re-applies a saved search against the current diff.
+// The match index is clamped in case the diff changed since it was saved.
+func (m *DiffViewerModel) restoreSearchState(st diffSearchState) {
+	if st.term == "" {
+		return
+	}
+	m.searchTerm = st.term
+	m.searchRegex = st.regex
+	m.searchInput.SetValue(st.term)
+	m.computeSearchMatches()
+	if st.matchIdx < len(m.searchMatches) {
+		m.searchMatchIdx = st.matchIdx
+	}
+	m.cachedLines = nil
+	m.refreshContent()
+	m.scrollToCurrentMatch()
+}
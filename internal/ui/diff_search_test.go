@@ -238,3 +238,97 @@ func TestFileStatusLabel(t *testing.T) {
 		})
 	}
 }
+
+func TestDiffLoaded_RestoresSearchPerPR(t *testing.T) {
+	files := []github.PRFile{
+		{Filename: "a.go", Status: "modified", Patch: "@@ -1,2 +1,2 @@\n-old hello\n+new hello"},
+	}
+	m := App{
+		diffViewer:   newTestDiffViewer(80, 10),
+		session:      &PRSession{Owner: "acme", Repo: "api", Number: 42},
+		searchStates: make(map[string]diffSearchState),
+	}
+	m.diffViewer.SetLoading(42)
+	model, _ := m.Update(DiffLoadedMsg{PRNumber: 42, Files: files})
+	m = model.(App)
+	m.diffViewer.searchTerm = "hello"
+	m.diffViewer.computeSearchMatches()
+	m.diffViewer.searchMatchIdx = 1
+
+	// Refresh in place keeps the search.
+	model, _ = m.Update(DiffLoadedMsg{PRNumber: 42, Files: files})
+	m = model.(App)
+	if got := m.diffViewer.SearchInfo(); got != "2/2" {
+		t.Errorf("after refresh SearchInfo = %q, want 2/2", got)
+	}
+
+	// Switching away and back restores it too.
+	m.saveSearchState()
+	m.session = &PRSession{Owner: "acme", Repo: "api", Number: 7}
+	m.diffViewer.SetLoading(7)
+	model, _ = m.Update(DiffLoadedMsg{PRNumber: 7, Files: files})
+	m = model.(App)
+	if m.diffViewer.HasActiveSearch() {
+		t.Error("search from PR 42 leaked into PR 7")
+	}
+	m.saveSearchState()
+	m.session = &PRSession{Owner: "acme", Repo: "api", Number: 42}
+	m.diffViewer.SetLoading(42)
+	model, _ = m.Update(DiffLoadedMsg{PRNumber: 42, Files: files})
+	m = model.(App)
+	if got := m.diffViewer.SearchInfo(); got != "2/2" {
+		t.Errorf("after switching back SearchInfo = %q, want 2/2", got)
+	}
+}
+
+func TestComputeSearchMatches_SmartCase(t *testing.T) {
+	m := newSearchTestModel([]github.PRFile{
+		{Filename: "main.go", Patch: "@@ -1,2 +1,2 @@\n+Hello World\n+hello again"},
+	})
+
+	m.searchTerm = "Hello"
+	m.computeSearchMatches()
+	if len(m.searchMatches) != 1 {
+		t.Errorf("got %d matches, want 1 (an upper-case letter makes the search case-sensitive)", len(m.searchMatches))
+	}
+}
+
+func TestComputeSearchMatches_Regex(t *testing.T) {
+	m := newSearchTestModel([]github.PRFile{
+		{Filename: "a/main.go", Patch: "@@ -1,2 +1,2 @@\n+err := fetch(ctx)\n+Err2 := retry(ctx)"},
+		{Filename: "b/util.go", Patch: "@@ -1,1 +1,1 @@\n+return err"},
+	})
+	m.searchRegex = true
+
+	m.searchTerm = `err\d? :=`
+	m.computeSearchMatches()
+	if len(m.searchMatches) != 2 {
+		t.Fatalf("got %d matches, want 2 (escapes don't make the search case-sensitive)", len(m.searchMatches))
+	}
+	if got := m.searchMatches[1]; got.startCol != 1 || got.endCol != 8 {
+		t.Errorf("match[1] = [%d:%d], want [1:8]", got.startCol, got.endCol)
+	}
+
+	m.searchTerm = "err"
+	m.computeSearchMatches()
+	if got := m.searchFileSummary(); got != "main.go 2 · util.go 1" {
+		t.Errorf("summary = %q", got)
+	}
+
+	m.searchTerm = "q*"
+	m.computeSearchMatches()
+	if len(m.searchMatches) != 0 {
+		t.Errorf("empty matches should be skipped, got %d", len(m.searchMatches))
+	}
+
+	m.searchTerm = "fetch("
+	m.computeSearchMatches()
+	if info := m.SearchInfo(); info != "Invalid regex" {
+		t.Errorf("SearchInfo = %q, want Invalid regex", info)
+	}
+	m.searchRegex = false
+	m.computeSearchMatches()
+	if len(m.searchMatches) != 1 {
+		t.Errorf("literal search for fetch( got %d matches, want 1", len(m.searchMatches))
+	}
+}
@@ -0,0 +1,148 @@
+package ui
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/x/ansi"
+	"github.com/shhac/prtea/internal/github"
+)
+
+// diffBarWidth is the widest per-file add/del bar, drawn for the file with
+// the most changed lines; other files are scaled against it.
+const diffBarWidth = 10
+
+// diffStatsLargest is how many of the largest files the stats header names.
+const diffStatsLargest = 3
+
+// typeCount is the number of changed files with one extension.
+type typeCount struct {
+	ext string
+	n   int
+}
+
+// diffStats summarises a PR's diff for the header of the Diff tab. It is
+// computed once per diff, in SetDiff.
+type diffStats struct {
+	files      int
+	additions  int
+	deletions  int
+	byType     []typeCount // most files first
+	largest    []int       // file indices, most changed lines first
+	maxChanges int         // changed lines of the largest file, scaling the bars
+	testLines  int         // lines changed in test files
+	codeLines  int         // lines changed in other files of a language with test conventions
+}
+
+// fileType returns the lower-case extension of a file without its dot, or
+// its name when it has none (Makefile, Dockerfile).
+func fileType(filename string) string {
+	base := path.Base(filename)
+	if ext := path.Ext(base); ext != "" && ext != base {
+		return strings.ToLower(ext[1:])
+	}
+	return base
+}
+
+// computeDiffStats tallies the files of a diff.
+func computeDiffStats(files []github.PRFile) diffStats {
+	s := diffStats{files: len(files)}
+	types := make(map[string]int)
+	for i, f := range files {
+		s.additions += f.Additions
+		s.deletions += f.Deletions
+		changes := f.Additions + f.Deletions
+		s.maxChanges = max(s.maxChanges, changes)
+		types[fileType(f.Filename)]++
+		if t, ok := classifyTestFile(f.Filename); ok {
+			if t.isTest {
+				s.testLines += changes
+			} else {
+				s.codeLines += changes
+			}
+		}
+		if changes > 0 {
+			s.largest = append(s.largest, i)
+		}
+	}
+	for ext, n := range types {
+		s.byType = append(s.byType, typeCount{ext: ext, n: n})
+	}
+	sort.Slice(s.byType, func(i, j int) bool {
+		if s.byType[i].n != s.byType[j].n {
+			return s.byType[i].n > s.byType[j].n
+		}
+		return s.byType[i].ext < s.byType[j].ext
+	})
+	sort.SliceStable(s.largest, func(i, j int) bool {
+		a, b := files[s.largest[i]], files[s.largest[j]]
+		return a.Additions+a.Deletions > b.Additions+b.Deletions
+	})
+	if len(s.largest) > diffStatsLargest {
+		s.largest = s.largest[:diffStatsLargest]
+	}
+	return s
+}
+
+// render returns the stats header lines, each cut to width: totals and
+// file types, the largest files, and the test-to-code ratio when the diff
+// touches code in a language with test conventions.
+func (s diffStats) render(files []github.PRFile, width int) []string {
+	types := make([]string, 0, len(s.byType))
+	for _, t := range s.byType {
+		types = append(types, fmt.Sprintf("%s %d", t.ext, t.n))
+	}
+	noun := "files"
+	if s.files == 1 {
+		noun = "file"
+	}
+	lines := []string{
+		boldStyle.Render(fmt.Sprintf("%d %s", s.files, noun)) + "  " +
+			diffAddedStyle.Render(fmt.Sprintf("+%d", s.additions)) + " " +
+			diffRemovedStyle.Render(fmt.Sprintf("-%d", s.deletions)) +
+			dimStyle.Render("  ·  "+strings.Join(types, ", ")),
+	}
+
+	if len(s.largest) > 1 {
+		parts := make([]string, len(s.largest))
+		for i, idx := range s.largest {
+			f := files[idx]
+			parts[i] = fmt.Sprintf("%s +%d/-%d", path.Base(f.Filename), f.Additions, f.Deletions)
+		}
+		lines = append(lines, dimStyle.Render("Largest: "+strings.Join(parts, ", ")))
+	}
+
+	if s.testLines+s.codeLines > 0 {
+		ratio := "no test changes"
+		if s.testLines > 0 {
+			ratio = fmt.Sprintf("%d%% of code changes are tests", s.testLines*100/(s.testLines+s.codeLines))
+		}
+		lines = append(lines, dimStyle.Render(fmt.Sprintf("Tests %d lines · code %d lines · %s", s.testLines, s.codeLines, ratio)))
+	}
+
+	for i, l := range lines {
+		lines[i] = ansi.Truncate(l, width, "…")
+	}
+	return lines
+}
+
+// changeBar draws a file's added and removed lines as a bar scaled against
+// the largest file, never empty for a file with changes.
+func changeBar(additions, deletions, maxChanges int) string {
+	changes := additions + deletions
+	if changes == 0 || maxChanges == 0 {
+		return ""
+	}
+	cells := max(1, (changes*diffBarWidth+maxChanges-1)/maxChanges)
+	adds := additions * cells / changes
+	if additions > 0 && adds == 0 {
+		adds = 1
+	}
+	dels := cells - adds
+	if deletions > 0 && dels == 0 && cells > 1 {
+		adds, dels = adds-1, 1
+	}
+	return diffAddedStyle.Render(strings.Repeat("■", adds)) + diffRemovedStyle.Render(strings.Repeat("■", dels))
+}
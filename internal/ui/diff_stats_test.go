@@ -0,0 +1,77 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/x/ansi"
+	"github.com/shhac/prtea/internal/github"
+)
+
+var statsTestFiles = []github.PRFile{
+	{Filename: "ui/app.go", Status: "modified", Additions: 40, Deletions: 10, Patch: "@@ -1,1 +1,1 @@\n-a\n+b"},
+	{Filename: "ui/app_test.go", Status: "modified", Additions: 25, Deletions: 0, Patch: "@@ -1,0 +1,1 @@\n+c"},
+	{Filename: "README.md", Status: "modified", Additions: 3, Deletions: 2, Patch: "@@ -1,1 +1,1 @@\n-d\n+e"},
+	{Filename: "Makefile", Status: "modified", Additions: 1, Deletions: 0, Patch: "@@ -1,0 +1,1 @@\n+f"},
+	{Filename: "ui/keys.go", Status: "modified", Additions: 5, Deletions: 0, Patch: "@@ -1,0 +1,1 @@\n+g"},
+}
+
+func TestComputeDiffStats(t *testing.T) {
+	s := computeDiffStats(statsTestFiles)
+	if s.files != 5 || s.additions != 74 || s.deletions != 12 || s.maxChanges != 50 {
+		t.Errorf("totals = %+v", s)
+	}
+	if s.byType[0] != (typeCount{"go", 3}) || len(s.byType) != 3 {
+		t.Errorf("byType = %v", s.byType)
+	}
+	if len(s.largest) != 3 || s.largest[0] != 0 || s.largest[1] != 1 || s.largest[2] != 2 {
+		t.Errorf("largest = %v", s.largest)
+	}
+	if s.testLines != 25 || s.codeLines != 55 {
+		t.Errorf("test/code lines = %d/%d", s.testLines, s.codeLines)
+	}
+
+	got := ansi.Strip(strings.Join(s.render(statsTestFiles, 200), "\n"))
+	for _, want := range []string{
+		"5 files  +74 -12  ·  go 3, Makefile 1, md 1",
+		"Largest: app.go +40/-10, app_test.go +25/-0, README.md +3/-2",
+		"Tests 25 lines · code 55 lines · 31% of code changes are tests",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("stats header missing %q:\n%s", want, got)
+		}
+	}
+}
+
+func TestChangeBar(t *testing.T) {
+	tests := []struct {
+		adds, dels, max int
+		want            string
+	}{
+		{40, 10, 50, "■■■■■■■■■■"},
+		{1, 0, 50, "■"},
+		{0, 0, 50, ""},
+		{1, 1, 50, "■"},
+		{10, 10, 40, "■■■■■"},
+	}
+	for _, tt := range tests {
+		if got := ansi.Strip(changeBar(tt.adds, tt.dels, tt.max)); got != tt.want {
+			t.Errorf("changeBar(%d, %d, %d) = %q, want %q", tt.adds, tt.dels, tt.max, got, tt.want)
+		}
+	}
+}
+
+func TestDiffStats_HeaderAboveFiles(t *testing.T) {
+	m := newTestDiffViewer(100, 40)
+	m.SetDiff(statsTestFiles)
+	if !strings.Contains(ansi.Strip(m.cachedLines[0]), "5 files") {
+		t.Errorf("first line = %q, want the stats header", ansi.Strip(m.cachedLines[0]))
+	}
+	header := ansi.Strip(m.cachedLines[m.fileOffsets[0]])
+	if !strings.HasSuffix(header, "ui/app.go (+40/-10) ■■■■■■■■■■") {
+		t.Errorf("file header = %q, want a full-width change bar", header)
+	}
+	if li := m.cachedLineInfo[m.cursorLine]; !li.isDiffLine {
+		t.Error("the cursor should start on a diff line below the header")
+	}
+}
@@ -14,6 +14,7 @@ const (
 	TabDiff   DiffViewerTab = iota
 	TabPRInfo
 	TabCI
+	TabRuns // shown once there is a local run
 )
 
 // DiffHunk represents a single hunk within a file's patch.
@@ -47,6 +48,7 @@ type lineInfo struct {
 	newLineNum    int         // new-side file line number (0 = not a file line)
 	isCommentable bool        // true for + and context lines (commentable on RIGHT side)
 	isDiffLine    bool        // true for actual diff content lines (cursor can land here)
+	change        byte        // '+' or '-' for added and removed lines, 0 otherwise
 	comment       commentKind // non-zero for inline comment lines
 }
 
@@ -27,7 +27,11 @@ type DiffViewerModel struct {
 	// Diff data
 	files          []github.PRFile
 	fileOffsets    []int // viewport line index where each file header starts
+	stats          diffStats // totals for the header of the Diff tab
 	currentFileIdx int
+	testPairs      []int // file index → paired test/impl file index, -1 if none
+	guide          *guidedReview // AI-ordered guided review, nil when off
+	focus          *focusReview  // one-file-at-a-time review (:focus), nil when off
 	loading        bool
 	prNumber       int
 	err            error
@@ -37,6 +41,9 @@ type DiffViewerModel struct {
 	hunkOffsets    []int        // viewport line offset where each hunk starts
 	focusedHunkIdx int          // explicitly tracked focused hunk
 	selectedHunks  map[int]bool // hunk index → selected
+	hunkOrder      []int        // selection order, as rearranged in the hunk order overlay
+	primaryHunk    int          // hunk marked as the primary focus for Claude
+	hasPrimaryHunk bool
 
 	// Cached rendering — avoids re-parsing and re-styling on every scroll.
 	cachedLines       []string     // per-line styled output (nil = needs full rebuild)
@@ -47,6 +54,7 @@ type DiffViewerModel struct {
 
 	// Line-level cursor for precise inline comment targeting.
 	cursorLine int
+	jumps      jumpList // positions jumped away from, for Ctrl+O / Ctrl+I
 
 	// Multi-line selection (visual mode) for range comments.
 	selectionAnchor int // -1 means no active selection
@@ -56,10 +64,17 @@ type DiffViewerModel struct {
 	aiCommentsByFileLine  map[string][]claude.InlineReviewComment // "path:line" → comments
 
 	// GitHub inline comment state
-	ghCommentThreads map[string][]ghCommentThread // "path:line" → threaded comments
+	ghInlineComments  []github.InlineComment       // raw comments, kept to rebuild threads on toggle
+	ghCommentThreads  map[string][]ghCommentThread // "path:line" → threaded comments
+	ghOutdatedThreads map[string][]ghCommentThread // "path:line" → re-anchored outdated threads
+	showOutdated      bool                         // re-anchor outdated comments into the diff
+	minimap           bool                         // widen the scrollbar into a change/comment minimap
 
 	// Pending inline comment state (user + AI drafts)
 	pendingCommentsByFileLine map[string][]PendingInlineComment // "path:line" → comments
+	excludedFiles             map[string]bool                   // files out of scope for review submission
+
+	explanations map[string]*hunkExplanation // "path @@ header" → Claude's explanation (E)
 
 	// Comment input mode
 	commentMode           bool
@@ -72,10 +87,23 @@ type DiffViewerModel struct {
 	searchMode          bool
 	searchInput         textinput.Model
 	searchTerm          string
+	searchRegex         bool   // search term is a regular expression
+	searchErr           string // regex compile error, "" when valid
 	searchMatches       []searchMatch
 	searchMatchIdx      int
 	searchMatchesByHunk map[int]map[int][]matchPos // hunkIdx → lineInHunk → match positions
 
+	// Quick question about the focused hunk
+	askMode  bool
+	askInput textinput.Model
+
+	// Completion for the comment and ask inputs, one of which is open at a time
+	completer textCompleter
+
+	// Scrollbar thumb drag (mouse)
+	scrollDrag       bool
+	scrollDragOffset int // row within the thumb where the drag started
+
 	// PR info data (for PR Info tab)
 	prTitle   string
 	prBody    string
@@ -83,6 +111,15 @@ type DiffViewerModel struct {
 	prURL     string
 	prInfoErr string
 
+	// Task list cursor on the PR Info tab, -1 until n/N picks a task
+	taskCursor int
+	links      []link // numbered links of the rendered PR description
+
+	// AI summary shown at the top of the PR Info tab
+	prSummary        *claude.PRSummary
+	prSummaryLoading bool
+	prSummaryErr     string
+
 	// Shared markdown renderer (cached per width)
 	md MarkdownRenderer
 
@@ -91,12 +128,49 @@ type DiffViewerModel struct {
 	prInfoCacheWidth int
 
 	// CI status data
-	ciStatus *github.CIStatus
-	ciError  string
+	ciStatus   *github.CIStatus
+	ciError    string
+	ciCursor   int      // index into ciOrderedChecks for per-check actions
+	ciWatch    *ciWatch // single re-run check being polled, nil when idle
+	ciWatchSeq int      // bumped per watch so stale poll ticks are ignored
+	ciLive     bool     // polling the PR's pending checks while the CI tab is open
+	ciLiveSeq  int      // bumped per live run so stale poll ticks are ignored
+
+	// Check run annotations for failing checks, keyed by job ID
+	ciAnnotations map[int64][]github.CheckAnnotation
 
 	// Review status data
 	reviewSummary *github.ReviewSummary
 	reviewError   string
+
+	// Merge readiness data (for the PR Info tab)
+	mergeable        bool
+	mergeState       string   // mergeStateStatus from the PR detail, e.g. "CLEAN", "DIRTY"
+	behindBy         int      // commits behind base; -1 if unknown
+	baseChangedFiles []string // files changed on base since the PR branched (fetched on conflict)
+	mergeReq         *github.MergeRequirements
+	mergeReqError    string
+	autoMerge        string // enabled auto-merge method, "" when off
+	mergeMessage     string // title of the accepted squash merge message
+
+	// Stacked PRs (for the PR Info tab)
+	stack     []github.StackMember // open PRs stacked with this one, root first; nil if not stacked
+	stackDiff string               // "base...head" while the Diff tab shows the combined stack diff
+	sinceDiff string               // banner while the Diff tab shows only the changes since the last review
+
+	deployments    []github.Deployment    // head commit deployments, latest per environment (PR Info tab)
+	securityAlerts []github.SecurityAlert // alerts the PR introduces, most severe first (PR Info tab)
+
+	// CI coverage overlay
+	coverageReport *github.CoverageReport
+	coverage       map[string]map[int]int // diff filename → line hits, resolved from the report on first use
+	coverageErr    string
+	coverageTried  bool // the PR's artifacts have been searched
+	coverageHidden bool // gutter toggled off with :coverage
+
+	// Local runs (:run), newest first; kept across PR switches
+	runs      []*localRun
+	runCursor int
 }
 
 func NewDiffViewerModel() DiffViewerModel {
@@ -108,11 +182,18 @@ func NewDiffViewerModel() DiffViewerModel {
 	ci.Prompt = ""
 	ci.CharLimit = 500
 
+	ai := textinput.New()
+	ai.Prompt = ""
+	ai.Placeholder = "does this handle nil?"
+	ai.CharLimit = 200
+
 	return DiffViewerModel{
 		spinner:         newLoadingSpinner(),
 		searchInput:     si,
 		commentInput:    ci,
+		askInput:        ai,
 		selectionAnchor: -1,
+		taskCursor:      -1,
 	}
 }
 
@@ -124,7 +205,28 @@ func (m DiffViewerModel) Update(msg tea.Msg) (DiffViewerModel, tea.Cmd) {
 			m.spinner, cmd = m.spinner.Update(msg)
 			return m, cmd
 		}
+		if m.prSummaryLoading {
+			var cmd tea.Cmd
+			m.spinner, cmd = m.spinner.Update(msg)
+			if m.activeTab == TabPRInfo {
+				m.prInfoCache = ""
+				m.refreshContent()
+			}
+			return m, cmd
+		}
+		if m.ciWatch != nil || m.ciLive || m.runActive() {
+			var cmd tea.Cmd
+			m.spinner, cmd = m.spinner.Update(msg)
+			if m.activeTab == TabCI || m.activeTab == TabRuns {
+				m.refreshContent()
+			}
+			return m, cmd
+		}
 		return m, nil
+	case tea.MouseMsg:
+		if m.handleScrollbarMouse(msg) {
+			return m, nil
+		}
 	case tea.KeyMsg:
 		if !m.focused {
 			return m, nil
@@ -135,6 +237,11 @@ func (m DiffViewerModel) Update(msg tea.Msg) (DiffViewerModel, tea.Cmd) {
 			return m.handleCommentModeKey(msg)
 		}
 
+		// Ask mode: capture all keys for the hunk question input
+		if m.askMode {
+			return m.handleAskModeKey(msg)
+		}
+
 		// Search mode: capture all keys for the search input
 		if m.searchMode {
 			return m.handleSearchModeKey(msg)
@@ -145,6 +252,7 @@ func (m DiffViewerModel) Update(msg tea.Msg) (DiffViewerModel, tea.Cmd) {
 			switch {
 			case key.Matches(msg, DiffViewerKeys.NextHunk):
 				if len(m.searchMatches) > 0 {
+					m.pushJump()
 					m.searchMatchIdx = (m.searchMatchIdx + 1) % len(m.searchMatches)
 					m.scrollToCurrentMatch()
 					m.cachedLines = nil
@@ -153,6 +261,7 @@ func (m DiffViewerModel) Update(msg tea.Msg) (DiffViewerModel, tea.Cmd) {
 				return m, nil
 			case key.Matches(msg, DiffViewerKeys.PrevHunk):
 				if len(m.searchMatches) > 0 {
+					m.pushJump()
 					m.searchMatchIdx = (m.searchMatchIdx - 1 + len(m.searchMatches)) % len(m.searchMatches)
 					m.scrollToCurrentMatch()
 					m.cachedLines = nil
@@ -168,6 +277,37 @@ func (m DiffViewerModel) Update(msg tea.Msg) (DiffViewerModel, tea.Cmd) {
 			}
 		}
 
+		// "U" merges base into the PR branch from the PR Info tab
+		if m.activeTab == TabPRInfo && key.Matches(msg, DiffViewerKeys.UpdateBranch) {
+			if m.behindBy > 0 && !hasConflicts(m.mergeable, m.mergeState) {
+				return m, func() tea.Msg { return UpdateBranchRequestMsg{} }
+			}
+			return m, nil
+		}
+
+		// "P" opens the preview deployment from the PR Info tab
+		if m.activeTab == TabPRInfo && key.Matches(msg, DiffViewerKeys.OpenPreview) {
+			return m, func() tea.Msg { return OpenPreviewRequestMsg{} }
+		}
+
+		// PR Info tab: n/N pick a task list item, Space toggles it
+		if m.activeTab == TabPRInfo {
+			switch {
+			case key.Matches(msg, DiffViewerKeys.NextHunk):
+				m.moveTaskCursor(1)
+				return m, nil
+			case key.Matches(msg, DiffViewerKeys.PrevHunk):
+				m.moveTaskCursor(-1)
+				return m, nil
+			case key.Matches(msg, DiffViewerKeys.ToggleTask):
+				if idx, task, ok := m.selectedTask(); ok {
+					req := TaskToggleRequestMsg{Index: idx, Text: task.text, Checked: !task.checked}
+					return m, func() tea.Msg { return req }
+				}
+				return m, nil
+			}
+		}
+
 		// "x" re-runs failed CI on CI tab
 		if m.activeTab == TabCI && key.Matches(msg, DiffViewerKeys.RerunCI) {
 			if m.ciStatus != nil && len(m.ciStatus.FailedRunIDs()) > 0 {
@@ -176,8 +316,102 @@ func (m DiffViewerModel) Update(msg tea.Msg) (DiffViewerModel, tea.Cmd) {
 			return m, nil
 		}
 
+		// Runs tab: n/N select a run
+		if m.activeTab == TabRuns {
+			switch {
+			case key.Matches(msg, DiffViewerKeys.NextHunk):
+				m.moveRunCursor(1)
+				return m, nil
+			case key.Matches(msg, DiffViewerKeys.PrevHunk):
+				m.moveRunCursor(-1)
+				return m, nil
+			}
+		}
+
+		// CI tab: n/N move the check cursor, L opens the selected check's logs
+		if m.activeTab == TabCI {
+			switch {
+			case key.Matches(msg, DiffViewerKeys.NextHunk):
+				m.moveCICursor(1)
+				return m, nil
+			case key.Matches(msg, DiffViewerKeys.PrevHunk):
+				m.moveCICursor(-1)
+				return m, nil
+			case key.Matches(msg, DiffViewerKeys.ViewLogs):
+				if check, ok := m.SelectedCICheck(); ok {
+					return m, func() tea.Msg { return CILogsRequestMsg{Check: check} }
+				}
+				return m, nil
+			case key.Matches(msg, DiffViewerKeys.RerunCheck):
+				if check, ok := m.SelectedCICheck(); ok {
+					return m, func() tea.Msg { return CIRerunCheckRequestMsg{Check: check} }
+				}
+				return m, nil
+			}
+		}
+
+		// "x" marks the focused file in or out of scope for the review
+		if m.activeTab == TabDiff && len(m.hunks) > 0 && key.Matches(msg, DiffViewerKeys.ExcludeFile) {
+			if path := m.focusedFilename(); path != "" {
+				return m, func() tea.Msg { return FileScopeToggleMsg{Path: path} }
+			}
+			return m, nil
+		}
+
+		// "t" jumps between an implementation file and its tests
+		if m.activeTab == TabDiff && key.Matches(msg, DiffViewerKeys.TestPair) {
+			if m.jumpToTestPair() {
+				m.refreshContent()
+			}
+			return m, nil
+		}
+
+		// "y" copies the cursor line or selection, "Y" the hunks as a patch
+		if m.activeTab == TabDiff && len(m.hunks) > 0 {
+			switch {
+			case key.Matches(msg, DiffViewerKeys.Yank):
+				return m, m.yankDiffLines()
+			case key.Matches(msg, DiffViewerKeys.YankPatch):
+				return m, m.yankDiffPatch()
+			}
+		}
+
+		// "A" asks a quick question about the focused hunk
+		if m.activeTab == TabDiff && len(m.hunks) > 0 && key.Matches(msg, DiffViewerKeys.AskHunk) {
+			cmd := m.enterAskMode()
+			return m, cmd
+		}
+
+		// "E" explains the focused hunk in a box under it, or hides the box
+		if m.activeTab == TabDiff && len(m.hunks) > 0 && key.Matches(msg, DiffViewerKeys.ExplainHunk) {
+			return m, m.toggleExplanation()
+		}
+
+		// f/F step through files in the guided review order
+		if m.activeTab == TabDiff && m.guide != nil {
+			switch {
+			case key.Matches(msg, DiffViewerKeys.GuideNext):
+				return m, m.guidedStep(1)
+			case key.Matches(msg, DiffViewerKeys.GuidePrev):
+				return m, m.guidedStep(-1)
+			}
+		}
+
+		// In focus mode f/F move between files and V marks one viewed
+		if m.activeTab == TabDiff && m.focus != nil {
+			switch {
+			case key.Matches(msg, DiffViewerKeys.GuideNext):
+				return m, m.focusStep(1)
+			case key.Matches(msg, DiffViewerKeys.GuidePrev):
+				return m, m.focusStep(-1)
+			case key.Matches(msg, DiffViewerKeys.MarkViewed):
+				return m, m.markViewedAndNext()
+			}
+		}
+
 		// "/" enters search mode on diff tab
 		if m.activeTab == TabDiff && key.Matches(msg, DiffViewerKeys.Search) {
+			m.pushJump()
 			m.searchMode = true
 			m.searchInput.SetValue(m.searchTerm)
 			m.searchInput.CursorEnd()
@@ -194,7 +428,7 @@ func (m DiffViewerModel) Update(msg tea.Msg) (DiffViewerModel, tea.Cmd) {
 			}
 			return m, nil
 		case key.Matches(msg, DiffViewerKeys.NextTab):
-			if m.activeTab < TabCI {
+			if m.activeTab < m.lastTab() {
 				m.activeTab++
 				m.refreshContent()
 			}
@@ -202,6 +436,7 @@ func (m DiffViewerModel) Update(msg tea.Msg) (DiffViewerModel, tea.Cmd) {
 		case key.Matches(msg, DiffViewerKeys.NextHunk):
 			if m.activeTab == TabDiff && len(m.hunks) > 0 {
 				m.cancelSelection()
+				m.pushJump()
 				if m.focusedHunkIdx < len(m.hunks)-1 {
 					m.focusedHunkIdx++
 				}
@@ -213,6 +448,7 @@ func (m DiffViewerModel) Update(msg tea.Msg) (DiffViewerModel, tea.Cmd) {
 		case key.Matches(msg, DiffViewerKeys.PrevHunk):
 			if m.activeTab == TabDiff && len(m.hunks) > 0 {
 				m.cancelSelection()
+				m.pushJump()
 				if m.focusedHunkIdx > 0 {
 					m.focusedHunkIdx--
 				}
@@ -237,6 +473,7 @@ func (m DiffViewerModel) Update(msg tea.Msg) (DiffViewerModel, tea.Cmd) {
 			return m, nil
 		case key.Matches(msg, DiffViewerKeys.Top):
 			m.cancelSelection()
+			m.pushJump()
 			m.viewport.GotoTop()
 			m.syncFocusToScroll()
 			m.syncCursorToScroll()
@@ -244,6 +481,7 @@ func (m DiffViewerModel) Update(msg tea.Msg) (DiffViewerModel, tea.Cmd) {
 			return m, nil
 		case key.Matches(msg, DiffViewerKeys.Bottom):
 			m.cancelSelection()
+			m.pushJump()
 			m.viewport.GotoBottom()
 			m.syncFocusToScroll()
 			m.syncCursorToScroll()
@@ -303,13 +541,20 @@ func (m DiffViewerModel) Update(msg tea.Msg) (DiffViewerModel, tea.Cmd) {
 			return m, nil
 		case key.Matches(msg, DiffViewerKeys.ClearSelection):
 			if m.activeTab == TabDiff && len(m.selectedHunks) > 0 {
-				for idx := range m.selectedHunks {
-					m.markHunkDirty(idx)
-				}
-				m.selectedHunks = nil
+				m.clearHunkSelection()
 				m.refreshContent()
 			}
 			return m, nil
+		case key.Matches(msg, DiffViewerKeys.OrderHunks):
+			if m.activeTab == TabDiff && len(m.selectedHunks) > 0 {
+				items := m.hunkOrderItems()
+				primary := -1
+				if p, ok := m.primarySelectedHunk(); ok {
+					primary = p
+				}
+				return m, func() tea.Msg { return ShowHunkOrderMsg{Items: items, Primary: primary} }
+			}
+			return m, nil
 		}
 
 		// "c" opens comment overlay on Diff tab
@@ -346,6 +591,11 @@ func (m *DiffViewerModel) toggleHunkSelection(idx int) {
 		delete(m.selectedHunks, idx)
 	} else {
 		m.selectedHunks[idx] = true
+		m.noteHunkSelected(idx)
+	}
+	// Order badges on the other selected hunks shift too
+	for j := range m.selectedHunks {
+		m.markHunkDirty(j)
 	}
 	m.markHunkDirty(idx)
 	m.refreshContent()
@@ -371,19 +621,23 @@ func (m *DiffViewerModel) toggleFileHunkSelection(idx int) {
 		if h.FileIndex == fileIdx {
 			if allSelected {
 				delete(m.selectedHunks, j)
-			} else {
+			} else if !m.selectedHunks[j] {
 				m.selectedHunks[j] = true
+				m.noteHunkSelected(j)
 			}
 			m.markHunkDirty(j)
 		}
 	}
+	for j := range m.selectedHunks {
+		m.markHunkDirty(j)
+	}
 	m.refreshContent()
 }
 
 func (m *DiffViewerModel) SetSize(width, height int) {
 	m.width = width
 	m.height = height
-	innerWidth := width - 5
+	innerWidth := width - 4 - m.gutterWidth()
 	innerHeight := height - 5
 	if innerWidth < 1 {
 		innerWidth = 1
@@ -419,46 +673,101 @@ func (m *DiffViewerModel) SetLoading(prNumber int) {
 	m.focusedHunkIdx = 0
 	m.cursorLine = 0
 	m.selectionAnchor = -1
-	m.selectedHunks = nil
+	m.clearHunkSelection()
 	m.cachedLines = nil
 	m.cachedLineInfo = nil
 	m.hunkLineRanges = nil
 	m.lastRenderedFocus = 0
 	m.dirtyHunks = nil
+	m.explanations = nil
 	m.clearSearch()
 	m.commentMode = false
 	m.commentInput.SetValue("")
 	m.commentInput.Blur()
+	m.completer.reset()
 	m.aiInlineComments = nil
 	m.aiCommentsByFileLine = nil
+	m.ghInlineComments = nil
 	m.ghCommentThreads = nil
+	m.ghOutdatedThreads = nil
 	m.pendingCommentsByFileLine = nil
+	m.excludedFiles = nil
 	m.currentFileIdx = 0
 	m.err = nil
 	m.prTitle = ""
 	m.prBody = ""
+	m.taskCursor = -1
+	m.links = nil
 	m.prAuthor = ""
 	m.prURL = ""
 	m.prInfoErr = ""
+	m.prSummary = nil
+	m.prSummaryLoading = false
+	m.prSummaryErr = ""
 	m.ciStatus = nil
 	m.ciError = ""
+	m.ciCursor = 0
+	m.ciWatch = nil
+	m.ciLive = false
+	m.ciAnnotations = nil
+	m.guide = nil
+	m.focus = nil
 	m.reviewSummary = nil
 	m.reviewError = ""
+	m.mergeable = false
+	m.mergeState = ""
+	m.behindBy = -1
+	m.autoMerge = ""
+	m.mergeMessage = ""
+	m.baseChangedFiles = nil
+	m.mergeReq = nil
+	m.mergeReqError = ""
+	m.stack = nil
+	m.stackDiff = ""
+	m.sinceDiff = ""
+	m.deployments = nil
+	m.securityAlerts = nil
+	m.coverageReport = nil
+	m.coverage = nil
+	m.coverageErr = ""
+	m.coverageTried = false
 	m.refreshContent()
 }
 
 // SetDiff displays the fetched diff files.
 func (m *DiffViewerModel) SetDiff(files []github.PRFile) {
+	if m.focus != nil {
+		if len(files) > 0 {
+			m.focus.rebase(files)
+			m.showFocused()
+			return
+		}
+		m.focus = nil
+	}
+	m.showFiles(files)
+}
+
+// showFiles displays files, resetting the cursor and per-diff state.
+func (m *DiffViewerModel) showFiles(files []github.PRFile) {
 	m.loading = false
 	m.files = files
+	m.stats = computeDiffStats(files)
 	m.err = nil
 	m.currentFileIdx = 0
 	m.focusedHunkIdx = 0
 	m.cursorLine = 0
 	m.selectionAnchor = -1
-	m.selectedHunks = nil
+	m.clearHunkSelection()
 	m.clearSearch()
 	m.parseAllHunks()
+	m.testPairs = pairTestFiles(files)
+	m.guide = nil
+	m.jumps = jumpList{}
+	// Outdated threads are anchored by diff content, so re-resolve them
+	// when comments arrived before the diff did.
+	if m.showOutdated && len(m.ghInlineComments) > 0 {
+		m.SetGitHubInlineComments(m.ghInlineComments)
+	}
 	m.cachedLines = nil
 	m.cachedLineInfo = nil
 	m.refreshContent()
@@ -508,11 +817,16 @@ func (m *DiffViewerModel) refreshContent() {
 		return
 	}
 
+	if m.activeTab == TabRuns {
+		m.viewport.SetContent(m.renderRunsTab())
+		return
+	}
+
 	// Diff tab
 	if m.loading {
 		m.viewport.SetContent(
 			lipgloss.NewStyle().
-				Foreground(lipgloss.Color("244")).
+				Foreground(theme.Muted).
 				Padding(1, 2).
 				Render(m.spinner.View() + fmt.Sprintf(" Loading diff for PR #%d...", m.prNumber)),
 		)
@@ -554,10 +868,14 @@ func (m DiffViewerModel) View() string {
 	var content string
 	if m.ready {
 		content = m.viewport.View()
-		if m.viewport.TotalLineCount() > m.viewport.Height {
+		switch {
+		case m.viewport.TotalLineCount() <= m.viewport.Height:
+			pad := strings.Repeat(" ", m.gutterWidth())
+			content = lipgloss.JoinHorizontal(lipgloss.Top, content, strings.Repeat(pad+"\n", m.viewport.Height-1)+pad)
+		case m.minimap:
+			content = lipgloss.JoinHorizontal(lipgloss.Top, content, m.renderMinimap())
+		default:
 			content = lipgloss.JoinHorizontal(lipgloss.Top, content, m.renderScrollbar())
-		} else {
-			content = lipgloss.JoinHorizontal(lipgloss.Top, content, strings.Repeat(" \n", m.viewport.Height-1)+" ")
 		}
 	} else {
 		content = "Loading..."
@@ -579,6 +897,10 @@ func (m DiffViewerModel) View() string {
 		parts = append(parts, m.renderCommentBar())
 	}
 
+	if m.askMode {
+		parts = append(parts, m.renderAskBar())
+	}
+
 	inner := lipgloss.JoinVertical(lipgloss.Left, parts...)
 	style := panelStyle(m.focused, false, m.width-2, m.height-2)
 	return style.Render(inner)
@@ -605,6 +927,12 @@ func (m DiffViewerModel) renderTabs() string {
 		{TabPRInfo, prInfoLabel},
 		{TabCI, ciLabel},
 	}
+	if len(m.runs) > 0 {
+		tabNames = append(tabNames, struct {
+			tab   DiffViewerTab
+			label string
+		}{TabRuns, m.runsTabLabel()})
+	}
 
 	for _, t := range tabNames {
 		if m.activeTab == t.tab {
@@ -617,34 +945,8 @@ func (m DiffViewerModel) renderTabs() string {
 	return strings.Join(tabs, " ")
 }
 
-// GetSelectedHunkContent returns formatted diff content for only the selected hunks.
+// GetSelectedHunkContent returns formatted diff content for only the selected
+// hunks, in the user's order.
 func (m DiffViewerModel) GetSelectedHunkContent() string {
-	if len(m.selectedHunks) == 0 {
-		return ""
-	}
-
-	var b strings.Builder
-	lastFileIdx := -1
-
-	for i, hunk := range m.hunks {
-		if !m.selectedHunks[i] {
-			continue
-		}
-
-		if hunk.FileIndex != lastFileIdx {
-			if lastFileIdx >= 0 {
-				b.WriteString("\n")
-			}
-			b.WriteString(fmt.Sprintf("--- a/%s\n", hunk.Filename))
-			b.WriteString(fmt.Sprintf("+++ b/%s\n", hunk.Filename))
-			lastFileIdx = hunk.FileIndex
-		}
-
-		for _, line := range hunk.Lines {
-			b.WriteString(line)
-			b.WriteString("\n")
-		}
-	}
-
-	return b.String()
+	return m.formatHunks(m.orderedSelection())
 }
@@ -479,3 +479,145 @@ func TestCommentTargetFromCursor(t *testing.T) {
 		t.Error("expected non-zero line number from commentTargetFromCursor")
 	}
 }
+
+func TestReanchorOutdatedComment(t *testing.T) {
+	hunks := parsePatchHunks(0, "a.go", "@@ -1,3 +1,5 @@\n ctx\n+moved()\n+other()\n-gone()\n ctx2\n+return nil")
+
+	// Commented line was "return nil" at original line 3; now at line 5.
+	c := github.InlineComment{
+		Path: "a.go", Line: 3, Outdated: true,
+		DiffHunk: "@@ -1,2 +1,3 @@\n ctx\n+return nil",
+	}
+	if got := reanchorOutdatedComment(hunks, c); got != 5 {
+		t.Errorf("reanchor = %d, want 5", got)
+	}
+
+	// Content that no longer exists is not anchored.
+	c.DiffHunk = "@@ -1,2 +1,3 @@\n ctx\n+deleted()"
+	if got := reanchorOutdatedComment(hunks, c); got != 0 {
+		t.Errorf("reanchor = %d, want 0 for missing content", got)
+	}
+
+	// Removed lines are never anchor targets.
+	c.DiffHunk = "@@ -1,2 +1,3 @@\n ctx\n+gone()"
+	if got := reanchorOutdatedComment(hunks, c); got != 0 {
+		t.Errorf("reanchor = %d, want 0 for removed line", got)
+	}
+
+	// Other files are ignored.
+	c.Path = "b.go"
+	c.DiffHunk = "@@ -1,2 +1,3 @@\n ctx\n+return nil"
+	if got := reanchorOutdatedComment(hunks, c); got != 0 {
+		t.Errorf("reanchor = %d, want 0 for other file", got)
+	}
+}
+
+func TestReanchorOutdatedComment_PrefersClosest(t *testing.T) {
+	hunks := parsePatchHunks(0, "a.go", "@@ -1,4 +1,4 @@\n }\n+x := 1\n }\n+y := 2")
+	c := github.InlineComment{Path: "a.go", Line: 4, DiffHunk: "@@ -3,2 +3,2 @@\n+y := 2\n }"}
+	if got := reanchorOutdatedComment(hunks, c); got != 3 {
+		t.Errorf("reanchor = %d, want 3 (closest match)", got)
+	}
+}
+
+func TestSetGitHubInlineComments_OutdatedToggle(t *testing.T) {
+	m := newTestDiffViewer(80, 24)
+	m.files = []github.PRFile{
+		{Filename: "a.go", Status: "modified", Patch: "@@ -1,2 +1,3 @@\n ctx\n+added()\n ctx2"},
+	}
+	m.parseAllHunks()
+	comments := []github.InlineComment{
+		{ID: 1, Path: "a.go", Line: 2, Body: "current"},
+		{ID: 2, Path: "a.go", Line: 7, Body: "old", Outdated: true, DiffHunk: "@@ -5,2 +5,3 @@\n+added()"},
+		{ID: 3, Path: "a.go", Line: 7, Body: "reply", Outdated: true, InReplyToID: 2},
+	}
+
+	m.SetGitHubInlineComments(comments)
+	if len(m.ghOutdatedThreads) != 0 {
+		t.Fatalf("outdated threads should be hidden by default, got %d", len(m.ghOutdatedThreads))
+	}
+	if len(m.ghCommentThreads["a.go:2"]) != 1 {
+		t.Fatalf("expected current thread at a.go:2")
+	}
+
+	m.SetShowOutdatedComments(true)
+	threads := m.ghOutdatedThreads["a.go:2"]
+	if len(threads) != 1 {
+		t.Fatalf("expected outdated thread re-anchored to a.go:2, got %v", m.ghOutdatedThreads)
+	}
+	if len(threads[0].Replies) != 1 {
+		t.Errorf("outdated thread replies = %d, want 1", len(threads[0].Replies))
+	}
+
+	m.SetShowOutdatedComments(false)
+	if len(m.ghOutdatedThreads) != 0 {
+		t.Errorf("outdated threads should be cleared when disabled")
+	}
+}
+
+func TestCICursor_SelectsInDisplayOrder(t *testing.T) {
+	m := newTestDiffViewer(80, 24)
+	m.prNumber = 1
+	m.SetCIStatus(&github.CIStatus{
+		TotalCount: 3,
+		Checks: []github.CICheck{
+			{Name: "build", Status: "completed", Conclusion: "success"},
+			{Name: "lint", Status: "completed", Conclusion: "failure", JobID: 11},
+			{Name: "e2e", Status: "in_progress"},
+		},
+		OverallStatus: "pending",
+	})
+
+	check, ok := m.SelectedCICheck()
+	if !ok || check.Name != "lint" {
+		t.Fatalf("first selected check = %q, want lint (failures first)", check.Name)
+	}
+	m.moveCICursor(1)
+	m.moveCICursor(1)
+	m.moveCICursor(1)
+	if check, _ := m.SelectedCICheck(); check.Name != "build" {
+		t.Errorf("cursor should clamp at last check, got %q", check.Name)
+	}
+}
+
+func TestCIWatch_TracksRerunAttempt(t *testing.T) {
+	m := newTestDiffViewer(80, 24)
+	m.prNumber = 1
+	failed := github.CICheck{Name: "lint", Status: "completed", Conclusion: "failure", JobID: 11}
+	m.SetCIStatus(&github.CIStatus{TotalCount: 1, Checks: []github.CICheck{failed}, OverallStatus: "failing"})
+
+	seq := m.WatchCICheck(failed)
+	if !m.CIWatchActive(seq) {
+		t.Fatal("expected watch to be active")
+	}
+	if got := m.ciStatus.Checks[0].Status; got != "queued" {
+		t.Errorf("watched check status = %q, want queued until the new attempt appears", got)
+	}
+
+	// The rollup still reports the old attempt: keep showing it as queued.
+	stale := &github.CIStatus{TotalCount: 1, Checks: []github.CICheck{failed}, OverallStatus: "failing"}
+	if _, done := m.CIWatchFinished(stale); done {
+		t.Fatal("old attempt should not finish the watch")
+	}
+	m.SetCIStatus(stale)
+	if m.ciStatus.OverallStatus != "pending" {
+		t.Errorf("OverallStatus = %q, want pending", m.ciStatus.OverallStatus)
+	}
+	if stale.Checks[0].Status != "completed" {
+		t.Error("overlay must not mutate the fetched status")
+	}
+
+	running := &github.CIStatus{TotalCount: 1, Checks: []github.CICheck{{Name: "lint", Status: "in_progress", JobID: 12}}, OverallStatus: "pending"}
+	if _, done := m.CIWatchFinished(running); done {
+		t.Fatal("in-progress attempt should not finish the watch")
+	}
+
+	passed := &github.CIStatus{TotalCount: 1, Checks: []github.CICheck{{Name: "lint", Status: "completed", Conclusion: "success", JobID: 12}}, OverallStatus: "passing"}
+	check, done := m.CIWatchFinished(passed)
+	if !done || check.Conclusion != "success" {
+		t.Fatalf("CIWatchFinished = %+v, %v; want success, true", check, done)
+	}
+	if m.CIWatchActive(seq) {
+		t.Error("watch should stop once the re-run completes")
+	}
+}
@@ -0,0 +1,78 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// draftConfirmWindow is how long :ready or :draft waits for the repeated
+// command that confirms it.
+const draftConfirmWindow = 5 * time.Second
+
+// draftConfirm is a draft state change waiting for confirmation.
+type draftConfirm struct {
+	owner   string
+	repo    string
+	number  int
+	draft   bool
+	expires time.Time
+}
+
+// matches reports whether a repeated command confirms this change.
+func (c *draftConfirm) matches(s *PRSession, draft bool, now time.Time) bool {
+	return c != nil && c.owner == s.Owner && c.repo == s.Repo && c.number == s.Number &&
+		c.draft == draft && now.Before(c.expires)
+}
+
+// setDraftState marks the user's own PR ready for review (draft false) or
+// converts it back to a draft (draft true). The first :ready or :draft asks
+// for confirmation; repeating it within draftConfirmWindow applies it.
+func (m App) setDraftState(draft bool) (tea.Model, tea.Cmd) {
+	if m.session == nil {
+		return m, m.statusBar.SetTemporaryMessage("No PR selected", 2*time.Second)
+	}
+	if !m.isOwnPR() {
+		return m, m.statusBar.SetTemporaryMessage("Draft state can only be changed on your own PRs", 2*time.Second)
+	}
+	s := m.session
+	if s.Draft == draft {
+		state := "ready for review"
+		if draft {
+			state = "a draft"
+		}
+		return m, m.statusBar.SetTemporaryMessage(fmt.Sprintf("PR #%d is already %s", s.Number, state), 2*time.Second)
+	}
+
+	prompt := fmt.Sprintf("Mark PR #%d ready for review? Run :ready again to confirm", s.Number)
+	status := fmt.Sprintf("Marking PR #%d ready for review...", s.Number)
+	if draft {
+		prompt = fmt.Sprintf("Convert PR #%d to a draft? Run :draft again to confirm", s.Number)
+		status = fmt.Sprintf("Converting PR #%d to a draft...", s.Number)
+	}
+	now := time.Now()
+	if !m.draftConfirm.matches(s, draft, now) {
+		m.draftConfirm = &draftConfirm{owner: s.Owner, repo: s.Repo, number: s.Number, draft: draft, expires: now.Add(draftConfirmWindow)}
+		return m, m.statusBar.SetTemporaryMessage(prompt, draftConfirmWindow)
+	}
+	m.draftConfirm = nil
+	clearCmd := m.statusBar.SetTemporaryMessage(status, 15*time.Second)
+	return m, tea.Batch(clearCmd, setDraftCmd(m.ghClient, s.Owner, s.Repo, s.Number, draft))
+}
+
+// setDraftCmd returns a command that converts a PR to a draft or marks it
+// ready for review.
+func setDraftCmd(client GitHubService, owner, repo string, number int, draft bool) tea.Cmd {
+	return func() tea.Msg {
+		ctx := context.Background()
+		var err error
+		if draft {
+			err = client.ConvertToDraft(ctx, owner, repo, number)
+		} else {
+			err = client.MarkReadyForReview(ctx, owner, repo, number)
+		}
+		return DraftStateDoneMsg{Owner: owner, Repo: repo, PRNumber: number, Draft: draft, Err: err}
+	}
+}
@@ -0,0 +1,75 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/bubbles/list"
+)
+
+func draftTestApp(author string, calls *[]string) App {
+	m := App{
+		prList:     NewPRListModel(TabMyPRs),
+		statusBar:  NewStatusBarModel(),
+		diffViewer: newTestDiffViewer(80, 24),
+		session:    &PRSession{Owner: "shhac", Repo: "prtea", Number: 12, Author: author, Draft: true},
+		ghClient: recordingClient(calls, func(args []string) string {
+			if args[0] == "pr" {
+				return `{"id":"PR_1"}`
+			}
+			return `{"data":{}}`
+		}),
+	}
+	m.prList.SetItems(nil, []list.Item{PRItem{number: 12, owner: "shhac", repo: "prtea", isDraft: true}})
+	return m
+}
+
+func TestSetDraftState_ConfirmsBeforeMarkingReady(t *testing.T) {
+	var calls []string
+	m := draftTestApp("alice", &calls)
+
+	model, _ := m.executeCommand("ready", "")
+	m = model.(App)
+	if m.draftConfirm == nil || !strings.Contains(m.statusBar.statusMessage, "again to confirm") {
+		t.Fatalf("first :ready should ask for confirmation, status %q", m.statusBar.statusMessage)
+	}
+	if model, _ := m.executeCommand("draft", ""); !strings.Contains(model.(App).statusBar.statusMessage, "already a draft") {
+		t.Error(":draft on a draft PR should be refused")
+	}
+
+	model, cmd := m.executeCommand("ready", "")
+	m = model.(App)
+	if m.draftConfirm != nil || cmd == nil {
+		t.Fatal("repeating :ready should confirm it")
+	}
+	done, ok := setDraftCmd(m.ghClient, "shhac", "prtea", 12, false)().(DraftStateDoneMsg)
+	if !ok || done.Err != nil || done.Draft {
+		t.Fatalf("done = %+v", done)
+	}
+	if !strings.Contains(calls[len(calls)-1], "markPullRequestReadyForReview") {
+		t.Errorf("last call = %q, want the ready-for-review mutation", calls[len(calls)-1])
+	}
+
+	model, _ = m.Update(done)
+	m = model.(App)
+	if m.session.Draft {
+		t.Error("session should no longer be a draft")
+	}
+	if pr, _ := m.prList.findPR("shhac", "prtea", 12); pr.isDraft {
+		t.Error("list badge should drop the draft marker")
+	}
+}
+
+func TestSetDraftState_OnlyOwnPRs(t *testing.T) {
+	var calls []string
+	m := draftTestApp("bob", &calls)
+
+	model, _ := m.executeCommand("ready", "")
+	m = model.(App)
+	if m.draftConfirm != nil || !strings.Contains(m.statusBar.statusMessage, "your own PRs") {
+		t.Errorf("status = %q, want the own-PR refusal", m.statusBar.statusMessage)
+	}
+	if len(calls) != 0 {
+		t.Errorf("calls = %q, want none", calls)
+	}
+}
@@ -0,0 +1,22 @@
+package ui
+
+import (
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// EditorCommand returns the command that opens path in $VISUAL or $EDITOR,
+// vi if neither is set. The value is split on spaces, so editors with
+// flags ("code --wait") work without going through a shell.
+func EditorCommand(path string) *exec.Cmd {
+	editor := os.Getenv("VISUAL")
+	if editor == "" {
+		editor = os.Getenv("EDITOR")
+	}
+	args := strings.Fields(editor)
+	if len(args) == 0 {
+		args = []string{"vi"}
+	}
+	return exec.Command(args[0], append(args[1:], path)...)
+}
@@ -0,0 +1,24 @@
+package ui
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestEditorCommand(t *testing.T) {
+	for _, tt := range []struct {
+		visual, editor string
+		want           []string
+	}{
+		{"", "", []string{"vi", "f.md"}},
+		{"", "nano", []string{"nano", "f.md"}},
+		{"code --wait", "nano", []string{"code", "--wait", "f.md"}},
+		{"  ", "", []string{"vi", "f.md"}},
+	} {
+		t.Setenv("VISUAL", tt.visual)
+		t.Setenv("EDITOR", tt.editor)
+		if got := EditorCommand("f.md").Args; !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("VISUAL=%q EDITOR=%q: args = %q, want %q", tt.visual, tt.editor, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,292 @@
+package ui
+
+import (
+	"bytes"
+	"fmt"
+	"html"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/shhac/prtea/internal/claude"
+	"github.com/shhac/prtea/internal/github"
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/extension"
+)
+
+// exportPath resolves the path given to an :export command, defaulting to
+// def in the working directory and expanding a leading "~/".
+func exportPath(arg, def string) string {
+	path := strings.TrimSpace(arg)
+	if path == "" {
+		return def
+	}
+	if rest, ok := strings.CutPrefix(path, "~/"); ok {
+		if home, err := os.UserHomeDir(); err == nil {
+			path = filepath.Join(home, rest)
+		}
+	}
+	return path
+}
+
+// exportName is the default file name for an export of the current PR,
+// e.g. "prtea-42-analysis.md".
+func (m App) exportName(suffix, ext string) string {
+	return fmt.Sprintf("%s-%d%s%s", m.session.Repo, m.session.Number, suffix, ext)
+}
+
+// writeExportCmd writes an export off the update loop, creating parent
+// directories as needed.
+func writeExportCmd(path, what, content string) tea.Cmd {
+	return func() tea.Msg {
+		if dir := filepath.Dir(path); dir != "." {
+			if err := os.MkdirAll(dir, 0o755); err != nil {
+				return ExportDoneMsg{Path: path, What: what, Err: err}
+			}
+		}
+		err := os.WriteFile(path, []byte(content), 0o644)
+		return ExportDoneMsg{Path: path, What: what, Err: err}
+	}
+}
+
+// exportPatch writes the selected hunks, or the whole diff without a
+// selection, as a patch (:export patch).
+func (m App) exportPatch(arg string) (tea.Model, tea.Cmd) {
+	if m.session == nil {
+		return m, m.statusBar.SetTemporaryMessage("No PR selected", 2*time.Second)
+	}
+	if len(m.diffViewer.hunks) == 0 {
+		return m, m.statusBar.SetTemporaryMessage("No diff loaded yet", 2*time.Second)
+	}
+	patch, what := formatPatch(m.diffViewer.hunks), "diff"
+	if len(m.diffViewer.selectedHunks) > 0 {
+		var n int
+		patch, n = m.diffViewer.yankPatch()
+		what = fmt.Sprintf("%d hunk(s)", n)
+	}
+	return m, writeExportCmd(exportPath(arg, m.exportName("", ".patch")), what, patch)
+}
+
+// exportAnalysis writes the AI analysis as markdown (:export analysis).
+func (m App) exportAnalysis(arg string) (tea.Model, tea.Cmd) {
+	if m.session == nil {
+		return m, m.statusBar.SetTemporaryMessage("No PR selected", 2*time.Second)
+	}
+	r := m.chatPanel.AnalysisResult()
+	if r == nil {
+		return m, m.statusBar.SetTemporaryMessage("No analysis yet — run :analyze first", 2*time.Second)
+	}
+	title := fmt.Sprintf("Analysis of %s/%s#%d: %s", m.session.Owner, m.session.Repo, m.session.Number, m.session.Title)
+	content := "# " + title + "\n\n" + analysisMarkdown(r)
+	return m, writeExportCmd(exportPath(arg, m.exportName("-analysis", ".md")), "analysis", content)
+}
+
+// exportReport writes the PR's metadata, diff and comments as a single
+// markdown file, or as HTML for a path ending in .html (:export report).
+func (m App) exportReport(arg string) (tea.Model, tea.Cmd) {
+	if m.session == nil {
+		return m, m.statusBar.SetTemporaryMessage("No PR selected", 2*time.Second)
+	}
+	path := exportPath(arg, m.exportName("", ".md"))
+	report := m.prReportMarkdown()
+	if ext := strings.ToLower(filepath.Ext(path)); ext == ".html" || ext == ".htm" {
+		title := fmt.Sprintf("%s/%s#%d: %s", m.session.Owner, m.session.Repo, m.session.Number, m.session.Title)
+		page, err := markdownToHTML(title, report)
+		if err != nil {
+			return m, m.statusBar.SetTemporaryMessage("Export failed: "+err.Error(), 4*time.Second)
+		}
+		report = page
+	}
+	return m, writeExportCmd(path, "report", report)
+}
+
+// codeFence returns a backtick fence longer than any run of backticks in
+// content, so the content cannot close it early.
+func codeFence(content string) string {
+	longest, run := 0, 0
+	for _, r := range content {
+		if r == '`' {
+			run++
+			longest = max(longest, run)
+		} else {
+			run = 0
+		}
+	}
+	return strings.Repeat("`", max(3, longest+1))
+}
+
+// analysisMarkdown renders an analysis result as markdown, skipping empty
+// sections like the Analysis tab does.
+func analysisMarkdown(r *claude.AnalysisResult) string {
+	var b strings.Builder
+	if r.Risk.Level != "" {
+		fmt.Fprintf(&b, "**Risk: %s**", strings.ToUpper(r.Risk.Level))
+		if r.Risk.Reasoning != "" {
+			b.WriteString(" — " + r.Risk.Reasoning)
+		}
+		b.WriteString("\n\n")
+	}
+	if r.EstimatedReviewMinutes > 0 {
+		fmt.Fprintf(&b, "Estimated review time: ~%d min\n\n", r.EstimatedReviewMinutes)
+	}
+	if r.Summary != "" {
+		b.WriteString("## Summary\n\n" + r.Summary + "\n\n")
+	}
+	if len(r.ReviewOrder) > 0 {
+		b.WriteString("## Suggested review order\n\n")
+		for i, e := range r.ReviewOrder {
+			fmt.Fprintf(&b, "%d. `%s` — %s\n", i+1, e.File, e.Reason)
+		}
+		b.WriteString("\n")
+	}
+	if a := r.ArchitectureImpact; a.HasImpact || a.Description != "" {
+		b.WriteString("## Architecture impact\n\n")
+		if a.Description != "" {
+			b.WriteString(a.Description + "\n\n")
+		}
+		if len(a.AffectedModules) > 0 {
+			b.WriteString("Affected modules: " + strings.Join(a.AffectedModules, ", ") + "\n\n")
+		}
+	}
+	if len(r.FileReviews) > 0 {
+		b.WriteString("## File reviews\n\n")
+		for _, fr := range r.FileReviews {
+			fmt.Fprintf(&b, "### `%s`\n\n", fr.File)
+			if fr.Summary != "" {
+				b.WriteString(fr.Summary + "\n\n")
+			}
+			for _, c := range fr.Comments {
+				loc := ""
+				if c.Line > 0 {
+					loc = fmt.Sprintf(" (line %d)", c.Line)
+				}
+				fmt.Fprintf(&b, "- **%s**%s: %s\n", c.Severity, loc, c.Comment)
+			}
+			if len(fr.Comments) > 0 {
+				b.WriteString("\n")
+			}
+		}
+	}
+	if t := r.TestCoverage; t.Assessment != "" || len(t.Gaps) > 0 {
+		b.WriteString("## Test coverage\n\n")
+		if t.Assessment != "" {
+			b.WriteString(t.Assessment + "\n\n")
+		}
+		for _, gap := range t.Gaps {
+			b.WriteString("- " + gap + "\n")
+		}
+		if len(t.Gaps) > 0 {
+			b.WriteString("\n")
+		}
+	}
+	if len(r.Suggestions) > 0 {
+		b.WriteString("## Suggestions\n\n")
+		for _, s := range r.Suggestions {
+			fmt.Fprintf(&b, "- **%s**", s.Title)
+			if s.File != "" {
+				fmt.Fprintf(&b, " (`%s`)", s.File)
+			}
+			b.WriteString(": " + s.Description + "\n")
+		}
+		b.WriteString("\n")
+	}
+	return strings.TrimRight(b.String(), "\n") + "\n"
+}
+
+// prReportMarkdown renders everything loaded for the current PR — its
+// metadata and description, the diff, conversation comments, reviews and
+// inline comments — as one markdown document.
+func (m App) prReportMarkdown() string {
+	s := m.session
+	var b strings.Builder
+	fmt.Fprintf(&b, "# %s/%s#%d: %s\n\n", s.Owner, s.Repo, s.Number, s.Title)
+	if s.HTMLURL != "" {
+		fmt.Fprintf(&b, "- URL: %s\n", s.HTMLURL)
+	}
+	if s.Author != "" {
+		fmt.Fprintf(&b, "- Author: @%s\n", s.Author)
+	}
+	if s.HeadBranch != "" {
+		fmt.Fprintf(&b, "- Branch: `%s`\n", s.HeadBranch)
+	}
+	adds, dels := 0, 0
+	for _, f := range s.DiffFiles {
+		adds += f.Additions
+		dels += f.Deletions
+	}
+	fmt.Fprintf(&b, "- Changes: %d files, +%d/-%d\n\n", len(s.DiffFiles), adds, dels)
+
+	if body := strings.TrimSpace(m.diffViewer.prBody); body != "" {
+		b.WriteString("## Description\n\n" + body + "\n\n")
+	}
+
+	if len(s.DiffFiles) > 0 {
+		b.WriteString("## Diff\n\n")
+		for _, f := range s.DiffFiles {
+			fmt.Fprintf(&b, "### %s\n\n", fileStatusLabel(f))
+			if f.Patch == "" {
+				b.WriteString("_Diff not available._\n\n")
+				continue
+			}
+			fence := codeFence(f.Patch)
+			fmt.Fprintf(&b, "%sdiff\n%s\n%s\n\n", fence, f.Patch, fence)
+		}
+	}
+
+	if comments := m.chatPanel.comments.comments; len(comments) > 0 {
+		b.WriteString("## Conversation\n\n")
+		for _, c := range comments {
+			fmt.Fprintf(&b, "**@%s** · %s\n\n%s\n\n", c.Author.Login, c.CreatedAt.Format("2006-01-02 15:04"), strings.TrimSpace(c.Body))
+		}
+	}
+
+	if rs := m.diffViewer.reviewSummary; rs != nil {
+		var reviews []github.Review
+		reviews = append(reviews, rs.Approved...)
+		reviews = append(reviews, rs.ChangesRequested...)
+		reviews = append(reviews, rs.Commented...)
+		if len(reviews) > 0 {
+			sort.SliceStable(reviews, func(i, j int) bool { return reviews[i].SubmittedAt.Before(reviews[j].SubmittedAt) })
+			b.WriteString("## Reviews\n\n")
+			for _, r := range reviews {
+				state := strings.ToLower(strings.ReplaceAll(r.State, "_", " "))
+				fmt.Fprintf(&b, "**@%s** %s\n\n", r.Author.Login, state)
+				if body := strings.TrimSpace(r.Body); body != "" {
+					b.WriteString(body + "\n\n")
+				}
+			}
+		}
+	}
+
+	if inline := m.chatPanel.comments.inlineComments; len(inline) > 0 {
+		b.WriteString("## Inline comments\n\n")
+		for _, c := range inline {
+			outdated := ""
+			if c.Outdated {
+				outdated = " (outdated)"
+			}
+			fmt.Fprintf(&b, "**@%s** on `%s:%d`%s\n\n%s\n\n", c.Author.Login, c.Path, c.Line, outdated, strings.TrimSpace(c.Body))
+		}
+	}
+	return strings.TrimRight(b.String(), "\n") + "\n"
+}
+
+// exportCSS styles the HTML report so it reads well without any assets.
+const exportCSS = `body{font-family:-apple-system,Segoe UI,Helvetica,Arial,sans-serif;max-width:960px;margin:2em auto;padding:0 1em;line-height:1.5;color:#1f2328}
+pre{background:#f6f8fa;padding:1em;overflow-x:auto;border-radius:6px}
+code{font-family:ui-monospace,SFMono-Regular,Menlo,monospace;font-size:90%}
+h2{border-bottom:1px solid #d0d7de;padding-bottom:.3em}`
+
+// markdownToHTML converts a markdown report into a self-contained HTML page.
+func markdownToHTML(title, markdown string) (string, error) {
+	var body bytes.Buffer
+	md := goldmark.New(goldmark.WithExtensions(extension.GFM))
+	if err := md.Convert([]byte(markdown), &body); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("<!DOCTYPE html>\n<html>\n<head>\n<meta charset=\"utf-8\">\n<title>%s</title>\n<style>\n%s\n</style>\n</head>\n<body>\n%s</body>\n</html>\n",
+		html.EscapeString(title), exportCSS, body.String()), nil
+}
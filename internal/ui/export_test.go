@@ -0,0 +1,122 @@
+package ui
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/shhac/prtea/internal/claude"
+	"github.com/shhac/prtea/internal/github"
+)
+
+func TestExportPath(t *testing.T) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		t.Skip("no home directory")
+	}
+	tests := []struct {
+		arg, want string
+	}{
+		{"", "prtea-1.patch"},
+		{"  ", "prtea-1.patch"},
+		{"out/fix.patch", "out/fix.patch"},
+		{"~/fix.patch", filepath.Join(home, "fix.patch")},
+	}
+	for _, tt := range tests {
+		if got := exportPath(tt.arg, "prtea-1.patch"); got != tt.want {
+			t.Errorf("exportPath(%q) = %q, want %q", tt.arg, got, tt.want)
+		}
+	}
+}
+
+func TestCodeFence(t *testing.T) {
+	if got := codeFence("plain"); got != "```" {
+		t.Errorf("codeFence(plain) = %q", got)
+	}
+	if got := codeFence("+```go\n+x := `a`"); got != "````" {
+		t.Errorf("codeFence(with fence) = %q, want ````", got)
+	}
+}
+
+func TestAnalysisMarkdown(t *testing.T) {
+	md := analysisMarkdown(&claude.AnalysisResult{
+		Summary: "Adds retries.",
+		Risk:    claude.RiskAssessment{Level: "medium", Reasoning: "Touches the client."},
+		FileReviews: []claude.FileReview{{
+			File:     "client.go",
+			Summary:  "Retry loop.",
+			Comments: []claude.ReviewComment{{Line: 12, Severity: "warning", Comment: "Unbounded."}},
+		}},
+	})
+	for _, want := range []string{
+		"**Risk: MEDIUM** — Touches the client.",
+		"## Summary\n\nAdds retries.",
+		"### `client.go`",
+		"- **warning** (line 12): Unbounded.",
+	} {
+		if !strings.Contains(md, want) {
+			t.Errorf("analysis markdown missing %q:\n%s", want, md)
+		}
+	}
+	if strings.Contains(md, "## Test coverage") {
+		t.Errorf("empty sections should be skipped:\n%s", md)
+	}
+}
+
+func TestPRReportMarkdown(t *testing.T) {
+	m := commentNavTestApp()
+	m.session = &PRSession{
+		Owner: "shhac", Repo: "prtea", Number: 7, Title: "Add retries",
+		HTMLURL: "https://github.com/shhac/prtea/pull/7", Author: "carol",
+		DiffFiles: []github.PRFile{{Filename: "a.go", Status: "modified", Additions: 1, Patch: "@@ -1 +1,2 @@\n one\n+two"}},
+	}
+	m.diffViewer.prBody = "Retries flaky calls."
+	m.chatPanel.SetComments(
+		[]github.Comment{{Author: github.User{Login: "dave"}, Body: "LGTM"}},
+		[]github.InlineComment{{Author: github.User{Login: "erin"}, Body: "Why?", Path: "a.go", Line: 2}},
+	)
+	report := m.prReportMarkdown()
+	for _, want := range []string{
+		"# shhac/prtea#7: Add retries",
+		"- Author: @carol",
+		"- Changes: 1 files, +1/-0",
+		"## Description\n\nRetries flaky calls.",
+		"```diff\n@@ -1 +1,2 @@\n one\n+two\n```",
+		"**@dave**",
+		"**@erin** on `a.go:2`\n\nWhy?",
+	} {
+		if !strings.Contains(report, want) {
+			t.Errorf("report missing %q:\n%s", want, report)
+		}
+	}
+
+	page, err := markdownToHTML("shhac/prtea#7", report)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"<title>shhac/prtea#7</title>", "<h1>", `<code class="language-diff">`} {
+		if !strings.Contains(page, want) {
+			t.Errorf("HTML missing %q", want)
+		}
+	}
+}
+
+func TestExportPatchWritesSelection(t *testing.T) {
+	m := commentNavTestApp()
+	m.session = &PRSession{Repo: "prtea", Number: 7}
+	m.diffViewer.toggleHunkSelection(1)
+	path := filepath.Join(t.TempDir(), "sub", "b.patch")
+	_, cmd := m.exportPatch(path)
+	msg, ok := cmd().(ExportDoneMsg)
+	if !ok || msg.Err != nil {
+		t.Fatalf("export: %+v", msg)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "--- a/b.go\n+++ b/b.go\n@@ -1,2 +1,3 @@"; !strings.Contains(string(data), want) || strings.Contains(string(data), "a.go") {
+		t.Errorf("patch = %q, want only b.go's hunk", data)
+	}
+}
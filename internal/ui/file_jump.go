@@ -0,0 +1,77 @@
+package ui
+
+import (
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// matchFile picks the diff file that best matches a fuzzy query: an exact
+// path, then a file whose name matches, then a path containing the query,
+// then one containing its characters in order. Ties go to the shorter
+// path. Returns -1 when nothing matches.
+func matchFile(query string, files []string) int {
+	q := strings.ToLower(strings.TrimSpace(query))
+	if q == "" {
+		return -1
+	}
+	best, bestRank := -1, 0
+	for i, f := range files {
+		path := strings.ToLower(f)
+		base := path[strings.LastIndexByte(path, '/')+1:]
+		rank := 0
+		switch {
+		case path == q:
+			rank = 5
+		case base == q:
+			rank = 4
+		case strings.HasPrefix(base, q):
+			rank = 3
+		case strings.Contains(path, q):
+			rank = 2
+		case isSubsequence(q, path):
+			rank = 1
+		}
+		if rank == 0 {
+			continue
+		}
+		if rank > bestRank || (rank == bestRank && len(f) < len(files[best])) {
+			best, bestRank = i, rank
+		}
+	}
+	return best
+}
+
+// isSubsequence reports whether the characters of q appear in s in order.
+func isSubsequence(q, s string) bool {
+	for _, r := range s {
+		if len(q) == 0 {
+			break
+		}
+		if rune(q[0]) == r {
+			q = q[1:]
+		}
+	}
+	return len(q) == 0
+}
+
+// jumpToNamedFile handles :file <name>, showing the best-matching diff file.
+func (m App) jumpToNamedFile(query string) (tea.Model, tea.Cmd) {
+	if len(m.diffViewer.files) == 0 {
+		return m, m.statusBar.SetTemporaryMessage("No diff loaded yet", 2*time.Second)
+	}
+	if strings.TrimSpace(query) == "" {
+		return m, m.statusBar.SetTemporaryMessage("Usage: file <name>", 2*time.Second)
+	}
+	idx := matchFile(query, citationFiles(m.diffViewer.files))
+	if idx < 0 {
+		return m, m.statusBar.SetTemporaryMessage("No file matches "+query, 2*time.Second)
+	}
+	m.diffViewer.activeTab = TabDiff
+	m.diffViewer.refreshContent()
+	m.diffViewer.showFile(idx)
+	m.diffViewer.refreshContent()
+	m.showAndFocusPanel(PanelCenter)
+	return m, nil
+}
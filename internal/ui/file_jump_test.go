@@ -0,0 +1,30 @@
+package ui
+
+import "testing"
+
+func TestMatchFile(t *testing.T) {
+	files := []string{
+		"internal/ui/app.go",
+		"internal/ui/app_handlers.go",
+		"internal/ui/diff_viewer.go",
+		"cmd/prtea/main.go",
+	}
+	tests := []struct {
+		query string
+		want  int
+	}{
+		{"internal/ui/app.go", 0},
+		{"app.go", 0},
+		{"APP", 0},         // shortest name prefix
+		{"handlers", 1},    // substring
+		{"dfvwr", 2},       // characters in order
+		{"prtea/main", 3},  // path substring
+		{"nothing.rs", -1}, // no match
+		{"", -1},
+	}
+	for _, tt := range tests {
+		if got := matchFile(tt.query, files); got != tt.want {
+			t.Errorf("matchFile(%q) = %d, want %d", tt.query, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,186 @@
+package ui
+
+import (
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/shhac/prtea/internal/github"
+)
+
+// focusReview is the state of focus mode (:focus), which shows the PR's
+// changed files one per screen. While it is on, the viewer's files hold
+// only the focused one.
+type focusReview struct {
+	files  []github.PRFile // the whole diff
+	idx    int             // index into files of the file shown
+	viewed map[string]bool // files marked viewed, by filename
+}
+
+// fileWeight is how much a file counts towards lines viewed. Files without
+// changed lines, like renames, still count for one.
+func fileWeight(f github.PRFile) int {
+	return max(1, f.Additions+f.Deletions)
+}
+
+// viewedPercent returns the share of the diff's changed lines in files
+// marked viewed.
+func (f *focusReview) viewedPercent() int {
+	total, viewed := 0, 0
+	for _, file := range f.files {
+		total += fileWeight(file)
+		if f.viewed[file.Filename] {
+			viewed += fileWeight(file)
+		}
+	}
+	if total == 0 {
+		return 0
+	}
+	return viewed * 100 / total
+}
+
+// nextUnviewed returns the first file after the focused one, wrapping, not
+// yet marked viewed, or -1 when all are.
+func (f *focusReview) nextUnviewed() int {
+	for i := 1; i <= len(f.files); i++ {
+		idx := (f.idx + i) % len(f.files)
+		if !f.viewed[f.files[idx].Filename] {
+			return idx
+		}
+	}
+	return -1
+}
+
+// rebase swaps in a refreshed diff, staying on the focused file if it is
+// still in it. Viewed marks carry over by filename.
+func (f *focusReview) rebase(files []github.PRFile) {
+	name := f.files[f.idx].Filename
+	f.files = files
+	f.idx = min(f.idx, len(files)-1)
+	for i, file := range files {
+		if file.Filename == name {
+			f.idx = i
+			break
+		}
+	}
+}
+
+// StartFocus turns on focus mode at the file under the cursor. Returns nil
+// if there are no files.
+func (m *DiffViewerModel) StartFocus() tea.Cmd {
+	if len(m.files) == 0 {
+		return nil
+	}
+	f := &focusReview{files: m.files, viewed: map[string]bool{}}
+	if m.currentFileIdx < len(m.files) {
+		f.idx = m.currentFileIdx
+	}
+	m.focus = f
+	m.activeTab = TabDiff
+	return m.showFocused()
+}
+
+// StopFocus turns focus mode off, showing the whole diff again at the file
+// that was focused.
+func (m *DiffViewerModel) StopFocus() {
+	f := m.focus
+	if f == nil {
+		return
+	}
+	m.focus = nil
+	m.showFiles(f.files)
+	m.jumpToFile(f.idx)
+	m.refreshContent()
+}
+
+// FocusActive reports whether focus mode is on.
+func (m DiffViewerModel) FocusActive() bool {
+	return m.focus != nil
+}
+
+// FocusInfo returns the status bar's focus progress, e.g.
+// "◎ file 4/17 · 60% viewed", or "" when focus mode is off.
+func (m DiffViewerModel) FocusInfo() string {
+	f := m.focus
+	if f == nil {
+		return ""
+	}
+	return fmt.Sprintf("◎ file %d/%d · %d%% viewed", f.idx+1, len(f.files), f.viewedPercent())
+}
+
+// showFocused shows the focused file on its own and reports it.
+func (m *DiffViewerModel) showFocused() tea.Cmd {
+	f := m.focus
+	m.showFiles(f.files[f.idx : f.idx+1])
+
+	msg := FocusStepMsg{Index: f.idx + 1, Total: len(f.files), File: f.files[f.idx].Filename}
+	return func() tea.Msg { return msg }
+}
+
+// focusStep moves delta files through the diff (clamped).
+func (m *DiffViewerModel) focusStep(delta int) tea.Cmd {
+	f := m.focus
+	idx := max(0, min(f.idx+delta, len(f.files)-1))
+	if idx == f.idx {
+		return nil
+	}
+	f.idx = idx
+	return m.showFocused()
+}
+
+// markViewedAndNext marks the focused file viewed and moves on to the next
+// one not yet viewed, or reports that every file has been.
+func (m *DiffViewerModel) markViewedAndNext() tea.Cmd {
+	f := m.focus
+	f.viewed[f.files[f.idx].Filename] = true
+	next := f.nextUnviewed()
+	if next < 0 {
+		m.cachedLines = nil // re-render the banner and viewed badge
+		m.refreshContent()
+		msg := FocusStepMsg{Index: f.idx + 1, Total: len(f.files), File: f.files[f.idx].Filename, AllViewed: true}
+		return func() tea.Msg { return msg }
+	}
+	f.idx = next
+	return m.showFocused()
+}
+
+// focusBanner is the line replacing the diff stats in focus mode.
+func (m *DiffViewerModel) focusBanner() string {
+	f := m.focus
+	return fmt.Sprintf("Focus: file %d/%d · %d%% of lines viewed — V viewed & next · f/F · :focus to exit",
+		f.idx+1, len(f.files), f.viewedPercent())
+}
+
+// focusViewedBadge returns the header badge of a file marked viewed in
+// focus mode, or "".
+func (m *DiffViewerModel) focusViewedBadge(f github.PRFile) string {
+	if m.focus == nil || !m.focus.viewed[f.Filename] {
+		return ""
+	}
+	return dimStyle.Render("✓ viewed")
+}
+
+// toggleFocus turns focus mode on or off (:focus).
+func (m App) toggleFocus() (tea.Model, tea.Cmd) {
+	if m.diffViewer.FocusActive() {
+		m.diffViewer.StopFocus()
+		return m, m.statusBar.SetTemporaryMessage("Focus mode ended", 2*time.Second)
+	}
+	if m.diffViewer.GuidedReviewActive() {
+		m.diffViewer.StopGuidedReview()
+	}
+	cmd := m.diffViewer.StartFocus()
+	if cmd == nil {
+		return m, m.statusBar.SetTemporaryMessage("No files to focus on", 2*time.Second)
+	}
+	m.showAndFocusPanel(PanelCenter)
+	return m, cmd
+}
+
+// focusStepped reports the file focus mode moved to.
+func (m App) focusStepped(msg FocusStepMsg) (tea.Model, tea.Cmd) {
+	if msg.AllViewed {
+		return m, m.statusBar.SetTemporaryMessage(fmt.Sprintf("All %d %s viewed — :focus to exit", msg.Total, pluralFiles(msg.Total)), 4*time.Second)
+	}
+	return m, m.statusBar.SetTemporaryMessage(fmt.Sprintf("File %d/%d: %s", msg.Index, msg.Total, msg.File), 3*time.Second)
+}
@@ -0,0 +1,114 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/shhac/prtea/internal/github"
+)
+
+func focusTestFiles() []github.PRFile {
+	return []github.PRFile{
+		{Filename: "a.go", Status: "modified", Additions: 1, Deletions: 1, Patch: "@@ -1,2 +1,2 @@\n-old\n+new"},
+		{Filename: "b.go", Status: "modified", Additions: 3, Deletions: 0, Patch: "@@ -1,1 +1,4 @@\n one\n+two\n+three\n+four"},
+		{Filename: "c.go", Status: "renamed"},
+	}
+}
+
+func TestFocusMode_StepsAndMarksViewed(t *testing.T) {
+	m := newTestDiffViewer(80, 20)
+	m.SetDiff(focusTestFiles())
+	m.SetFocused(true)
+
+	cmd := m.StartFocus()
+	if cmd == nil || !m.FocusActive() {
+		t.Fatal("expected focus mode to start")
+	}
+	if step, ok := cmd().(FocusStepMsg); !ok || step.Index != 1 || step.Total != 3 || step.File != "a.go" {
+		t.Errorf("first step = %+v", step)
+	}
+	if len(m.files) != 1 || m.files[0].Filename != "a.go" {
+		t.Fatalf("shown files = %+v, want only a.go", m.files)
+	}
+	if got := m.FocusInfo(); got != "◎ file 1/3 · 0% viewed" {
+		t.Errorf("FocusInfo = %q", got)
+	}
+
+	// a.go (2 lines) of 6 weighted lines: a.go 2, b.go 3, c.go 1.
+	m, cmd = m.Update(keyMsg("V"))
+	if m.files[0].Filename != "b.go" {
+		t.Errorf("after V: shown %q, want b.go", m.files[0].Filename)
+	}
+	if step := cmd().(FocusStepMsg); step.Index != 2 || step.AllViewed {
+		t.Errorf("after V: step = %+v", step)
+	}
+	if got := m.FocusInfo(); got != "◎ file 2/3 · 33% viewed" {
+		t.Errorf("FocusInfo = %q", got)
+	}
+	if !strings.Contains(strings.Join(m.cachedLines, "\n"), "33% of lines viewed") {
+		t.Error("expected the focus banner in the diff")
+	}
+
+	m, _ = m.Update(keyMsg("F"))
+	if m.files[0].Filename != "a.go" {
+		t.Errorf("after F: shown %q, want a.go", m.files[0].Filename)
+	}
+
+	// a.go is viewed already, so V moves on to b.go, then c.go.
+	m, _ = m.Update(keyMsg("V"))
+	m, _ = m.Update(keyMsg("V"))
+	m, cmd = m.Update(keyMsg("V"))
+	if step := cmd().(FocusStepMsg); !step.AllViewed || step.Total != 3 {
+		t.Errorf("last V: step = %+v, want all viewed", step)
+	}
+	if got := m.FocusInfo(); got != "◎ file 3/3 · 100% viewed" {
+		t.Errorf("FocusInfo = %q", got)
+	}
+
+	m.StopFocus()
+	if m.FocusActive() || len(m.files) != 3 {
+		t.Errorf("after stop: active=%v files=%d, want the whole diff", m.FocusActive(), len(m.files))
+	}
+}
+
+func TestFocusMode_RefreshKeepsFileAndMarks(t *testing.T) {
+	m := newTestDiffViewer(80, 20)
+	m.SetDiff(focusTestFiles())
+	m.StartFocus()
+	m.markViewedAndNext() // a.go viewed, now on b.go
+
+	refreshed := append([]github.PRFile{{Filename: "new.go", Status: "added", Additions: 2}}, focusTestFiles()...)
+	m.SetDiff(refreshed)
+	if !m.FocusActive() || m.files[0].Filename != "b.go" {
+		t.Fatalf("after refresh: active=%v shown=%q, want b.go", m.FocusActive(), m.files[0].Filename)
+	}
+	if got := m.FocusInfo(); got != "◎ file 3/4 · 25% viewed" {
+		t.Errorf("FocusInfo = %q", got)
+	}
+
+	m.SetLoading(2)
+	if m.FocusActive() {
+		t.Error("expected switching PR to end focus mode")
+	}
+}
+
+func TestFocusMode_Command(t *testing.T) {
+	m := commentNavTestApp()
+	model, cmd := m.executeCommand("focus", "")
+	m = model.(App)
+	if !m.diffViewer.FocusActive() || cmd == nil {
+		t.Fatal("expected :focus to start focus mode")
+	}
+	m.statusBar.SetFocusInfo(m.diffViewer.FocusInfo()) // as View does
+	m.statusBar.SetSegments(defaultStatusSegments, defaultSegmentPriority)
+	m.statusBar.SetWidth(120)
+	if got := m.statusBar.View(); !strings.Contains(got, "◎ file 1/2 · 0% viewed") {
+		t.Errorf("status bar = %q, want focus progress", got)
+	}
+
+	model, _ = m.executeCommand("focus", "")
+	m = model.(App)
+	if m.diffViewer.FocusActive() {
+		t.Error("expected a second :focus to end focus mode")
+	}
+}
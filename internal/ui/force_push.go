@@ -0,0 +1,167 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/shhac/prtea/internal/github"
+)
+
+// pendingRemap counts what the last diff refresh did to the pending
+// comments whose lines moved.
+type pendingRemap struct {
+	moved int // re-anchored to the line's new position
+	stale int // line no longer in the diff; held until deleted
+}
+
+// String describes the remap for the status bar, or "" when nothing moved.
+func (r pendingRemap) String() string {
+	var parts []string
+	if r.moved > 0 {
+		parts = append(parts, fmt.Sprintf("%d moved with their lines", r.moved))
+	}
+	if r.stale > 0 {
+		parts = append(parts, fmt.Sprintf("%d held, their lines are gone", r.stale))
+	}
+	if len(parts) == 0 {
+		return ""
+	}
+	return "pending comments: " + strings.Join(parts, ", ")
+}
+
+// diffSideLines maps the line numbers a file's patch shows on one side,
+// "LEFT" for the old file and anything else for the new one, to their text.
+func diffSideLines(patch, side string) map[int]string {
+	lines := make(map[int]string)
+	oldLine, newLine := 0, 0
+	for _, line := range strings.Split(patch, "\n") {
+		if strings.HasPrefix(line, "@@") {
+			fmt.Sscanf(line, "@@ -%d", &oldLine)
+			newLine = parseHunkNewStart(line)
+			continue
+		}
+		if line == "" || line[0] == '\\' {
+			continue
+		}
+		switch line[0] {
+		case '+':
+			if side != "LEFT" {
+				lines[newLine] = line[1:]
+			}
+			newLine++
+		case '-':
+			if side == "LEFT" {
+				lines[oldLine] = line[1:]
+			}
+			oldLine++
+		default:
+			if side == "LEFT" {
+				lines[oldLine] = line[1:]
+			} else {
+				lines[newLine] = line[1:]
+			}
+			oldLine++
+			newLine++
+		}
+	}
+	return lines
+}
+
+// remapLine finds where a line with text now is among lines, preferring the
+// same number and then the closest match. Returns 0 if the text is gone.
+func remapLine(lines map[int]string, line int, text string) int {
+	if lines[line] == text {
+		return line
+	}
+	best, bestDist := 0, -1
+	for n, t := range lines {
+		if t != text {
+			continue
+		}
+		dist := n - line
+		if dist < 0 {
+			dist = -dist
+		}
+		if bestDist < 0 || dist < bestDist || (dist == bestDist && n < best) {
+			best, bestDist = n, dist
+		}
+	}
+	return best
+}
+
+// remapPendingComments moves pending comments to where their lines went
+// between two versions of a PR's diff, matching the commented line by
+// content. Comments whose line can't be found are marked stale and held,
+// so a review never posts them against whatever line took the old number.
+func remapPendingComments(comments []PendingInlineComment, oldFiles, newFiles []github.PRFile) pendingRemap {
+	var r pendingRemap
+	if len(oldFiles) == 0 {
+		return r
+	}
+	oldPatches := make(map[string]string, len(oldFiles))
+	for _, f := range oldFiles {
+		oldPatches[f.Filename] = f.Patch
+	}
+	newPatches := make(map[string]string, len(newFiles))
+	for _, f := range newFiles {
+		newPatches[f.Filename] = f.Patch
+	}
+
+	for i := range comments {
+		c := &comments[i]
+		oldPatch, ok := oldPatches[c.Path]
+		if c.Stale || !ok || oldPatch == newPatches[c.Path] {
+			continue
+		}
+		text, ok := diffSideLines(oldPatch, c.Side)[c.Line]
+		if !ok {
+			continue // not on a diff line to begin with
+		}
+		line := remapLine(diffSideLines(newPatches[c.Path], c.Side), c.Line, text)
+		switch {
+		case line == 0:
+			c.Stale, c.Held = true, true
+			r.stale++
+		case line != c.Line:
+			if c.StartLine > 0 {
+				c.StartLine += line - c.Line
+			}
+			c.Line = line
+			r.moved++
+		}
+	}
+	return r
+}
+
+// checkHeadChangeCmd returns a command that asks GitHub whether a PR's new
+// head still contains the old one.
+func checkHeadChangeCmd(client GitHubService, owner, repo string, number int, oldSHA, newSHA string) tea.Cmd {
+	return func() tea.Msg {
+		status, err := client.GetCompareStatus(context.Background(), owner, repo, oldSHA, newSHA)
+		return HeadChangedMsg{PRNumber: number, Old: oldSHA, New: newSHA, Status: status, Err: err}
+	}
+}
+
+// headChanged tells the user a PR they have open was pushed to, calling out
+// force-pushes, which rewrite the commits their review and comments were
+// made against. An old head GitHub no longer knows is taken as a force-push.
+func (m App) headChanged(msg HeadChangedMsg) (tea.Model, tea.Cmd) {
+	if !m.session.MatchesPR(msg.PRNumber) {
+		return m, nil
+	}
+	what := "has new commits"
+	if msg.Err != nil || msg.Status == "diverged" || msg.Status == "behind" {
+		what = "was force-pushed"
+	}
+	text := fmt.Sprintf("PR #%d %s (%s → %s)", msg.PRNumber, what, shortSHA(msg.Old), shortSHA(msg.New))
+	if remap := m.session.LastRemap.String(); remap != "" {
+		// The new diff came first; report what it did to pending comments
+		// here, once.
+		text += " — " + remap
+		m.session.LastRemap = pendingRemap{}
+	}
+	return m, m.statusBar.SetTemporaryMessage(text, 6*time.Second)
+}
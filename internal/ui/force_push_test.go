@@ -0,0 +1,102 @@
+package ui
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/shhac/prtea/internal/claude"
+	"github.com/shhac/prtea/internal/github"
+)
+
+func pendingAt(path string, line int, side string) PendingInlineComment {
+	return PendingInlineComment{InlineReviewComment: claude.InlineReviewComment{Path: path, Line: line, Side: side, Body: "note"}, Source: "user"}
+}
+
+func TestRemapPendingComments(t *testing.T) {
+	oldFiles := []github.PRFile{
+		{Filename: "a.go", Patch: "@@ -1,3 +1,4 @@\n one\n+two\n three\n-four\n+five"},
+		{Filename: "b.go", Patch: "@@ -1,2 +1,2 @@\n keep\n-x\n+y"},
+	}
+	// Two lines were added above, and "five" was rewritten.
+	newFiles := []github.PRFile{
+		{Filename: "a.go", Patch: "@@ -1,3 +1,6 @@\n+zero\n+half\n one\n+two\n three\n-four\n+six"},
+		{Filename: "b.go", Patch: "@@ -1,2 +1,2 @@\n keep\n-x\n+y"},
+	}
+	comments := []PendingInlineComment{
+		pendingAt("a.go", 2, ""),      // "two" moves to 4
+		pendingAt("a.go", 4, "RIGHT"), // "five" is gone
+		pendingAt("a.go", 3, "LEFT"),  // removed "four" keeps its old-side line
+		pendingAt("b.go", 2, ""),      // file unchanged
+	}
+	comments[0].StartLine = 1
+
+	r := remapPendingComments(comments, oldFiles, newFiles)
+	if r.moved != 1 || r.stale != 1 {
+		t.Fatalf("remap = %+v, want 1 moved and 1 stale", r)
+	}
+	if c := comments[0]; c.Line != 4 || c.StartLine != 3 || c.Stale {
+		t.Errorf("moved comment = %+v, want lines 3-4", c)
+	}
+	if c := comments[1]; !c.Stale || !c.Held {
+		t.Errorf("comment on a rewritten line = %+v, want stale and held", c)
+	}
+	if c := comments[2]; c.Line != 3 || c.Stale {
+		t.Errorf("old-side comment = %+v, want unchanged", c)
+	}
+	if c := comments[3]; c.Line != 2 || c.Stale {
+		t.Errorf("comment in unchanged file = %+v, want unchanged", c)
+	}
+
+	s := &PRSession{PendingInlineComments: comments}
+	if submit, withheld := s.SubmittableComments(); len(submit) != 3 || len(withheld) != 1 {
+		t.Errorf("submit %d, withheld %d: a stale comment must not be posted", len(submit), len(withheld))
+	}
+}
+
+func TestHeadChange_ReportsForcePushAndRemap(t *testing.T) {
+	var compared string
+	client := github.NewTestClient("me", func(ctx context.Context, args ...string) (string, error) {
+		compared = strings.Join(args, " ")
+		return `{"status":"diverged","ahead_by":1,"behind_by":2}`, nil
+	})
+	m := commentNavTestApp()
+	m.session.Owner, m.session.Repo = "shhac", "prtea"
+	m.session.DiffFiles = m.diffViewer.files
+	m.session.HeadSHA = "aaaaaaaaaa"
+	m.session.PendingInlineComments = []PendingInlineComment{pendingAt("a.go", 2, "")}
+	m.diffViewer.prNumber = 1
+
+	// The rewritten branch adds a line above the commented one.
+	model, _ := m.Update(DiffLoadedMsg{PRNumber: 1, Files: []github.PRFile{
+		{Filename: "a.go", Status: "modified", Patch: "@@ -1,3 +1,5 @@\n+zero\n one\n+two\n three\n four"},
+	}})
+	m = model.(App)
+	if c := m.session.PendingInlineComments[0]; c.Line != 3 {
+		t.Fatalf("pending comment at line %d, want 3", c.Line)
+	}
+	if _, ok := m.diffViewer.pendingCommentsByFileLine["a.go:3"]; !ok {
+		t.Error("the diff should show the pending comment on its new line")
+	}
+
+	msg := checkHeadChangeCmd(client, "shhac", "prtea", 1, "aaaaaaaaaa", "bbbbbbbbbb")().(HeadChangedMsg)
+	if !strings.Contains(compared, "compare/aaaaaaaaaa...bbbbbbbbbb") {
+		t.Errorf("compared %q", compared)
+	}
+	model, _ = m.Update(msg)
+	m = model.(App)
+	want := "PR #1 was force-pushed (aaaaaaa → bbbbbbb) — pending comments: 1 moved with their lines"
+	if m.statusBar.statusMessage != want {
+		t.Errorf("status = %q, want %q", m.statusBar.statusMessage, want)
+	}
+
+	model, _ = m.Update(HeadChangedMsg{PRNumber: 1, Old: "bbbbbbb", New: "ccccccc", Status: "ahead"})
+	if got := model.(App).statusBar.statusMessage; got != "PR #1 has new commits (bbbbbbb → ccccccc)" {
+		t.Errorf("status = %q, the remap should only be reported once", got)
+	}
+	model, _ = m.Update(HeadChangedMsg{PRNumber: 1, Old: "bbbbbbb", New: "ccccccc", Err: errors.New("404")})
+	if got := model.(App).statusBar.statusMessage; !strings.Contains(got, "force-pushed") {
+		t.Errorf("status = %q, an unknown old head means a force-push", got)
+	}
+}
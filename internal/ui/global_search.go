@@ -0,0 +1,389 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/shhac/prtea/internal/github"
+)
+
+// searchSource is where a global search hit was found.
+type searchSource int
+
+const (
+	sourceDiff searchSource = iota
+	sourcePRBody
+	sourceComments
+	sourceReviews
+	sourceAnalysis
+	sourceChat
+)
+
+// label returns the group heading for a source.
+func (s searchSource) label() string {
+	switch s {
+	case sourceDiff:
+		return "Diff"
+	case sourcePRBody:
+		return "PR description"
+	case sourceComments:
+		return "Comments"
+	case sourceReviews:
+		return "Reviews"
+	case sourceAnalysis:
+		return "Analysis"
+	default:
+		return "Chat"
+	}
+}
+
+// globalSearchMaxPerSource caps the hits listed for each source.
+const globalSearchMaxPerSource = 50
+
+// globalSearchHit is one matching line found by the Ctrl+F search.
+type globalSearchHit struct {
+	Source searchSource
+	Label  string // where in the source, e.g. "app.go:42" or "@carol"
+	Text   string // the matching line
+	Path   string // diff file to open, "" for none
+	Line   int    // new-side line in Path
+}
+
+// searchText is a block of text to search, attributed to a label.
+type searchText struct {
+	source searchSource
+	label  string
+	text   string
+	path   string
+	line   int
+}
+
+// globalSearchTexts gathers everything the Ctrl+F search covers, in
+// display order: the diff, the PR description, comments, reviews, the
+// analysis and the chat.
+func (m App) globalSearchTexts() []searchText {
+	var texts []searchText
+	for _, h := range m.diffViewer.hunks {
+		newLine := 0
+		for _, line := range h.Lines {
+			switch {
+			case strings.HasPrefix(line, "@@"):
+				newLine = parseHunkNewStart(line)
+				continue
+			case strings.HasPrefix(line, `\`):
+				continue
+			}
+			at := newLine
+			if !strings.HasPrefix(line, "-") {
+				newLine++
+			}
+			texts = append(texts, searchText{
+				source: sourceDiff,
+				label:  fmt.Sprintf("%s:%d", h.Filename, at),
+				text:   line,
+				path:   h.Filename,
+				line:   at,
+			})
+		}
+	}
+
+	if body := m.diffViewer.prBody; body != "" {
+		texts = append(texts, searchText{source: sourcePRBody, label: "description", text: body})
+	}
+
+	for _, c := range m.chatPanel.comments.comments {
+		texts = append(texts, searchText{source: sourceComments, label: "@" + c.Author.Login, text: c.Body})
+	}
+	for _, c := range m.chatPanel.comments.inlineComments {
+		texts = append(texts, searchText{
+			source: sourceComments,
+			label:  fmt.Sprintf("@%s %s:%d", c.Author.Login, c.Path, c.Line),
+			text:   c.Body,
+			path:   c.Path,
+			line:   c.Line,
+		})
+	}
+
+	if rs := m.diffViewer.reviewSummary; rs != nil {
+		for _, group := range [][]github.Review{rs.Approved, rs.ChangesRequested, rs.Commented} {
+			for _, r := range group {
+				texts = append(texts, searchText{source: sourceReviews, label: "@" + r.Author.Login, text: r.Body})
+			}
+		}
+	}
+
+	if r := m.chatPanel.analysis.result; r != nil {
+		add := func(label, text string) {
+			texts = append(texts, searchText{source: sourceAnalysis, label: label, text: text})
+		}
+		add("summary", r.Summary)
+		add("risk", r.Risk.Reasoning)
+		add("architecture", r.ArchitectureImpact.Description)
+		for _, fr := range r.FileReviews {
+			add(fr.File, fr.Summary)
+			for _, c := range fr.Comments {
+				texts = append(texts, searchText{
+					source: sourceAnalysis,
+					label:  fmt.Sprintf("%s:%d", fr.File, c.Line),
+					text:   c.Comment,
+					path:   fr.File,
+					line:   c.Line,
+				})
+			}
+		}
+		add("tests", r.TestCoverage.Assessment)
+		for _, gap := range r.TestCoverage.Gaps {
+			add("tests", gap)
+		}
+		for _, s := range r.Suggestions {
+			add("suggestion", s.Title+": "+s.Description)
+		}
+	}
+
+	for _, msg := range m.chatPanel.chat.messages {
+		label := "you"
+		if msg.role == "assistant" {
+			label = "Claude"
+		}
+		texts = append(texts, searchText{source: sourceChat, label: label, text: msg.content})
+	}
+	return texts
+}
+
+// globalSearch finds the lines of texts matching query, smart case, at
+// most globalSearchMaxPerSource per source.
+func globalSearch(texts []searchText, query string) []globalSearchHit {
+	if strings.TrimSpace(query) == "" {
+		return nil
+	}
+	re, err := searchPattern(query, false)
+	if err != nil {
+		return nil
+	}
+	var hits []globalSearchHit
+	perSource := make(map[searchSource]int)
+	for _, t := range texts {
+		if perSource[t.source] >= globalSearchMaxPerSource {
+			continue
+		}
+		for _, line := range strings.Split(t.text, "\n") {
+			if !re.MatchString(line) {
+				continue
+			}
+			hits = append(hits, globalSearchHit{
+				Source: t.source,
+				Label:  t.label,
+				Text:   strings.TrimSpace(line),
+				Path:   t.path,
+				Line:   t.line,
+			})
+			if perSource[t.source]++; perSource[t.source] >= globalSearchMaxPerSource {
+				break
+			}
+		}
+	}
+	return hits
+}
+
+// showGlobalSearch opens the Ctrl+F search over everything loaded for the
+// current PR.
+func (m App) showGlobalSearch() (tea.Model, tea.Cmd) {
+	m.globalSearch.SetSize(m.width, m.height)
+	cmd := m.globalSearch.Show(m.globalSearchTexts())
+	m.setMode(ModeOverlay)
+	return m, cmd
+}
+
+// openSearchHit shows where a global search hit came from: diff lines and
+// inline comments open in the diff, everything else on its tab.
+func (m *App) openSearchHit(hit globalSearchHit) {
+	switch {
+	case hit.Path != "" && m.diffViewer.fileIndex(hit.Path) >= 0:
+		m.diffViewer.activeTab = TabDiff
+		m.diffViewer.refreshContent()
+		if m.diffViewer.jumpToLine(hit.Path, hit.Line) {
+			m.diffViewer.refreshContent()
+		}
+		m.showAndFocusPanel(PanelCenter)
+	case hit.Source == sourcePRBody, hit.Source == sourceReviews:
+		m.diffViewer.activeTab = TabPRInfo
+		m.diffViewer.refreshContent()
+		m.showAndFocusPanel(PanelCenter)
+	default:
+		tab := ChatTabChat
+		switch hit.Source {
+		case sourceComments:
+			tab = ChatTabComments
+		case sourceAnalysis:
+			tab = ChatTabAnalysis
+		}
+		m.chatPanel.SetActiveTab(tab)
+		m.chatPanel.refreshViewport()
+		m.showAndFocusPanel(PanelRight)
+	}
+}
+
+// GlobalSearchModel is the Ctrl+F overlay searching the diff, PR
+// description, comments, reviews, analysis and chat at once, with results
+// grouped by where they were found.
+type GlobalSearchModel struct {
+	input   textinput.Model
+	texts   []searchText
+	hits    []globalSearchHit
+	cursor  int
+	width   int
+	height  int
+	visible bool
+}
+
+// NewGlobalSearchModel creates a global search overlay.
+func NewGlobalSearchModel() GlobalSearchModel {
+	ti := textinput.New()
+	ti.Placeholder = "Search diff, description, comments, reviews, analysis, chat..."
+	ti.Prompt = "/ "
+	ti.CharLimit = 200
+	return GlobalSearchModel{input: ti}
+}
+
+// Show opens the overlay over a snapshot of the searchable text, keeping
+// the previous query.
+func (m *GlobalSearchModel) Show(texts []searchText) tea.Cmd {
+	m.texts = texts
+	m.visible = true
+	m.input.CursorEnd()
+	m.search()
+	return m.input.Focus()
+}
+
+// IsVisible returns whether the overlay is currently shown.
+func (m GlobalSearchModel) IsVisible() bool {
+	return m.visible
+}
+
+// SetSize updates the terminal dimensions used to place the overlay.
+func (m *GlobalSearchModel) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+	m.input.Width = max(1, m.overlayWidth()-8)
+}
+
+func (m GlobalSearchModel) overlayWidth() int {
+	return min(max(60, m.width*3/4), m.width)
+}
+
+func (m *GlobalSearchModel) search() {
+	m.hits = globalSearch(m.texts, m.input.Value())
+	m.cursor = 0
+}
+
+func (m GlobalSearchModel) Update(msg tea.Msg) (GlobalSearchModel, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		var cmd tea.Cmd
+		m.input, cmd = m.input.Update(msg)
+		return m, cmd
+	}
+	switch keyMsg.String() {
+	case "esc", "ctrl+f":
+		m.visible = false
+		m.input.Blur()
+		return m, func() tea.Msg { return GlobalSearchClosedMsg{} }
+	case "enter":
+		if m.cursor >= len(m.hits) {
+			return m, nil
+		}
+		m.visible = false
+		m.input.Blur()
+		hit := m.hits[m.cursor]
+		return m, func() tea.Msg { return GlobalSearchClosedMsg{Hit: &hit} }
+	case "down", "ctrl+n", "ctrl+j":
+		if m.cursor < len(m.hits)-1 {
+			m.cursor++
+		}
+		return m, nil
+	case "up", "ctrl+p", "ctrl+k":
+		if m.cursor > 0 {
+			m.cursor--
+		}
+		return m, nil
+	}
+	before := m.input.Value()
+	var cmd tea.Cmd
+	m.input, cmd = m.input.Update(keyMsg)
+	if m.input.Value() != before {
+		m.search()
+	}
+	return m, cmd
+}
+
+func (m GlobalSearchModel) View() string {
+	if !m.visible {
+		return ""
+	}
+	overlayW := m.overlayWidth()
+	innerW := max(1, overlayW-4)
+
+	title := helpTitleStyle.Render(" Search everything ")
+	lines := []string{lipgloss.PlaceHorizontal(innerW, lipgloss.Left, title), "", m.input.View(), ""}
+
+	// One row per group heading and per hit; keep the cursor on screen.
+	type row struct {
+		text string
+		hit  int // index into hits, -1 for a heading
+	}
+	var rows []row
+	cursorRow := 0
+	for i, hit := range m.hits {
+		if i == 0 || hit.Source != m.hits[i-1].Source {
+			n := 0
+			for _, h := range m.hits[i:] {
+				if h.Source == hit.Source {
+					n++
+				}
+			}
+			rows = append(rows, row{text: boldStyle.Render(fmt.Sprintf("%s (%d)", hit.Source.label(), n)), hit: -1})
+		}
+		if i == m.cursor {
+			cursorRow = len(rows)
+		}
+		rows = append(rows, row{text: fmt.Sprintf("%s  %s", dimStyle.Render(hit.Label), hit.Text), hit: i})
+	}
+
+	switch {
+	case strings.TrimSpace(m.input.Value()) == "":
+		lines = append(lines, dimStyle.Render("Type to search the current PR."))
+	case len(rows) == 0:
+		lines = append(lines, dimStyle.Render("No matches"))
+	}
+
+	maxRows := max(1, m.height-10)
+	start := 0
+	if cursorRow >= maxRows {
+		start = cursorRow - maxRows + 1
+	}
+	for i := start; i < len(rows) && i < start+maxRows; i++ {
+		r := rows[i]
+		text := "  " + r.text
+		if r.hit < 0 {
+			text = r.text
+		} else if r.hit == m.cursor {
+			text = "▸ " + r.text
+		}
+		lines = append(lines, ansi.Truncate(text, innerW, "…"))
+	}
+
+	footer := helpFooterStyle.Render("↑/↓ move · Enter open · Esc close")
+	lines = append(lines, "", fitWidth(lipgloss.PlaceHorizontal(innerW, lipgloss.Center, footer), innerW))
+
+	overlayStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(theme.Accent).
+		Padding(0, 1).
+		Width(overlayW - 2)
+
+	return placeOverlay(m.width, m.height, overlayStyle.Render(strings.Join(lines, "\n")))
+}
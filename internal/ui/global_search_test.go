@@ -0,0 +1,78 @@
+package ui
+
+import (
+	"testing"
+
+	"github.com/shhac/prtea/internal/claude"
+	"github.com/shhac/prtea/internal/github"
+)
+
+func globalSearchTestApp() App {
+	m := App{
+		statusBar:    NewStatusBarModel(),
+		diffViewer:   newTestDiffViewer(80, 40),
+		chatPanel:    NewChatPanelModel(),
+		panelVisible: [3]bool{true, true, true},
+		session:      &PRSession{Number: 1},
+	}
+	m.diffViewer.SetDiff([]github.PRFile{
+		{Filename: "upload.go", Status: "modified", Patch: "@@ -10,2 +10,3 @@\n func upload() {\n+\tretry(3)\n }"},
+	})
+	m.diffViewer.prBody = "Adds a retry to uploads.\n\nFixes #12."
+	m.chatPanel.comments.SetComments(
+		[]github.Comment{{Author: github.User{Login: "carol"}, Body: "Why retry three times?"}},
+		[]github.InlineComment{{Author: github.User{Login: "dave"}, Body: "Make retry configurable", Path: "upload.go", Line: 11}},
+	)
+	m.diffViewer.reviewSummary = &github.ReviewSummary{
+		Approved: []github.Review{{Author: github.User{Login: "erin"}, Body: "LGTM once retry is tested"}},
+	}
+	m.chatPanel.analysis.SetResult(&claude.AnalysisResult{Summary: "Uploads now retry on failure."})
+	m.chatPanel.chat.messages = []chatMessage{{role: "assistant", content: "The retry has no backoff."}}
+	return m
+}
+
+func TestGlobalSearchGroupsBySource(t *testing.T) {
+	m := globalSearchTestApp()
+	hits := globalSearch(m.globalSearchTexts(), "retry")
+
+	var got []searchSource
+	for _, h := range hits {
+		got = append(got, h.Source)
+	}
+	want := []searchSource{sourceDiff, sourcePRBody, sourceComments, sourceComments, sourceReviews, sourceAnalysis, sourceChat}
+	if len(got) != len(want) {
+		t.Fatalf("sources = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("sources = %v, want %v", got, want)
+		}
+	}
+	if hits[0].Label != "upload.go:11" || hits[0].Path != "upload.go" || hits[0].Line != 11 {
+		t.Errorf("diff hit = %+v, want upload.go:11", hits[0])
+	}
+	if hits := globalSearch(m.globalSearchTexts(), "Retry"); len(hits) != 0 {
+		t.Errorf("smart case: Retry matched %d lines, want 0", len(hits))
+	}
+}
+
+func TestGlobalSearchOpensHit(t *testing.T) {
+	m := globalSearchTestApp()
+	hits := globalSearch(m.globalSearchTexts(), "retry")
+
+	m.openSearchHit(hits[3]) // dave's inline comment opens at its line
+	li := m.diffViewer.cachedLineInfo[m.diffViewer.cursorLine]
+	if m.focused != PanelCenter || li.filename != "upload.go" || li.newLineNum != 11 {
+		t.Errorf("inline comment hit: focused=%d cursor at %s:%d", m.focused, li.filename, li.newLineNum)
+	}
+
+	m.openSearchHit(hits[4])
+	if m.diffViewer.activeTab != TabPRInfo {
+		t.Errorf("review hit should open PR Info, tab = %d", m.diffViewer.activeTab)
+	}
+
+	m.openSearchHit(hits[5])
+	if m.focused != PanelRight || m.chatPanel.activeTab != ChatTabAnalysis {
+		t.Errorf("analysis hit: focused=%d tab=%d", m.focused, m.chatPanel.activeTab)
+	}
+}
@@ -0,0 +1,102 @@
+package ui
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/shhac/prtea/internal/claude"
+	"github.com/shhac/prtea/internal/github"
+)
+
+// guidedReview steps through the PR's files in the AI-suggested review order.
+type guidedReview struct {
+	files   []int    // file indices in review order
+	reasons []string // parallel to files; "" for files the AI didn't rank
+	step    int
+}
+
+// guidedFileOrder maps the AI's review order onto the PR's files. Files the
+// AI listed come first in its order; any it missed follow in diff order so
+// the guided flow still covers every changed file.
+func guidedFileOrder(files []github.PRFile, order []claude.ReviewOrderEntry) *guidedReview {
+	byName := make(map[string]int, len(files))
+	for i, f := range files {
+		byName[f.Filename] = i
+	}
+
+	g := &guidedReview{}
+	seen := make(map[int]bool, len(files))
+	for _, e := range order {
+		idx, ok := byName[e.File]
+		if !ok || seen[idx] {
+			continue
+		}
+		seen[idx] = true
+		g.files = append(g.files, idx)
+		g.reasons = append(g.reasons, e.Reason)
+	}
+	for i := range files {
+		if !seen[i] {
+			g.files = append(g.files, i)
+			g.reasons = append(g.reasons, "")
+		}
+	}
+	return g
+}
+
+// StartGuidedReview begins stepping through files in the given review order,
+// jumping to the first one. Returns nil if there are no files to guide through.
+func (m *DiffViewerModel) StartGuidedReview(order []claude.ReviewOrderEntry) tea.Cmd {
+	if len(m.files) == 0 {
+		return nil
+	}
+	m.guide = guidedFileOrder(m.files, order)
+	m.activeTab = TabDiff
+	return m.guidedStep(0)
+}
+
+// StopGuidedReview ends the guided review.
+func (m *DiffViewerModel) StopGuidedReview() {
+	if m.guide == nil {
+		return
+	}
+	m.guide = nil
+	m.cachedLines = nil
+	m.refreshContent()
+}
+
+// GuidedReviewActive reports whether a guided review is in progress.
+func (m DiffViewerModel) GuidedReviewActive() bool {
+	return m.guide != nil
+}
+
+// guidedStep moves delta steps through the guided order (clamped), jumps to
+// that file, and reports the step so the app can show its reason.
+func (m *DiffViewerModel) guidedStep(delta int) tea.Cmd {
+	g := m.guide
+	if g == nil || len(g.files) == 0 {
+		return nil
+	}
+	g.step = max(0, min(g.step+delta, len(g.files)-1))
+	m.cachedLines = nil // re-render file header step badges
+	m.refreshContent()
+	m.jumpToFile(g.files[g.step])
+	m.refreshContent()
+
+	msg := GuidedReviewStepMsg{
+		Step:   g.step + 1,
+		Total:  len(g.files),
+		File:   m.files[g.files[g.step]].Filename,
+		Reason: g.reasons[g.step],
+	}
+	return func() tea.Msg { return msg }
+}
+
+// guidedHeaderBadge returns the step badge for a file header, or "" when the
+// file isn't the current guided step.
+func (m *DiffViewerModel) guidedHeaderBadge(fileIdx int) string {
+	if m.guide == nil || len(m.guide.files) == 0 || m.guide.files[m.guide.step] != fileIdx {
+		return ""
+	}
+	return guidedStepStyle.Render(fmt.Sprintf("▶ step %d/%d", m.guide.step+1, len(m.guide.files)))
+}
@@ -0,0 +1,71 @@
+package ui
+
+import (
+	"testing"
+
+	"github.com/shhac/prtea/internal/claude"
+	"github.com/shhac/prtea/internal/github"
+)
+
+func TestGuidedFileOrder(t *testing.T) {
+	files := []github.PRFile{
+		{Filename: "README.md"},
+		{Filename: "auth/token.go"},
+		{Filename: "auth/token_test.go"},
+	}
+	order := []claude.ReviewOrderEntry{
+		{File: "auth/token.go", Reason: "security-sensitive"},
+		{File: "gone.go", Reason: "not in the PR"},
+		{File: "auth/token.go", Reason: "duplicate"},
+		{File: "auth/token_test.go"},
+	}
+
+	g := guidedFileOrder(files, order)
+	want := []int{1, 2, 0}
+	if len(g.files) != len(want) {
+		t.Fatalf("files = %v, want %v", g.files, want)
+	}
+	for i := range want {
+		if g.files[i] != want[i] {
+			t.Errorf("files[%d] = %d, want %d", i, g.files[i], want[i])
+		}
+	}
+	if g.reasons[0] != "security-sensitive" || g.reasons[2] != "" {
+		t.Errorf("reasons = %q", g.reasons)
+	}
+}
+
+func TestGuidedReview_Steps(t *testing.T) {
+	m := newTestDiffViewer(80, 10)
+	m.SetDiff([]github.PRFile{
+		{Filename: "a.go", Status: "modified", Patch: "@@ -1,2 +1,2 @@\n-old\n+new"},
+		{Filename: "b.go", Status: "modified", Patch: "@@ -1,2 +1,2 @@\n-old\n+new"},
+	})
+
+	cmd := m.StartGuidedReview([]claude.ReviewOrderEntry{{File: "b.go", Reason: "risky"}})
+	if cmd == nil || !m.GuidedReviewActive() {
+		t.Fatal("expected guided review to start")
+	}
+	step, ok := cmd().(GuidedReviewStepMsg)
+	if !ok || step.Step != 1 || step.Total != 2 || step.File != "b.go" || step.Reason != "risky" {
+		t.Errorf("first step = %+v", step)
+	}
+	if m.focusedHunkIdx != 1 {
+		t.Errorf("focused hunk = %d, want 1 (b.go)", m.focusedHunkIdx)
+	}
+
+	m.SetFocused(true)
+	m, _ = m.Update(keyMsg("f"))
+	if m.focusedHunkIdx != 0 {
+		t.Errorf("after f: focused hunk = %d, want 0 (a.go)", m.focusedHunkIdx)
+	}
+	m, _ = m.Update(keyMsg("f")) // clamps at the last step
+	if m.guide.step != 1 {
+		t.Errorf("step = %d, want clamp at 1", m.guide.step)
+	}
+
+	m.StopGuidedReview()
+	if m.GuidedReviewActive() {
+		t.Error("expected guided review to stop")
+	}
+}
@@ -0,0 +1,171 @@
+package ui
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/shhac/prtea/internal/claude"
+	"github.com/shhac/prtea/internal/config"
+	"github.com/shhac/prtea/internal/github"
+)
+
+// newHeadlessClient creates a GitHub client with the credentials the TUI
+// would use.
+func newHeadlessClient(cfg *config.Config) (*github.Client, error) {
+	var client *github.Client
+	var err error
+	if token := cfg.GitHubAuthToken(); token != "" {
+		client, err = github.NewTokenClient(token)
+	} else {
+		client, err = github.NewClient()
+	}
+	if err != nil {
+		return nil, err
+	}
+	client.SetResponseCache(github.NewResponseCache(config.HTTPCacheDir()))
+	return client, nil
+}
+
+// PRAnalysis is the result of a headless analysis (prtea analyze).
+type PRAnalysis struct {
+	Ref    github.PRRef
+	Title  string
+	Result *claude.AnalysisResult
+	Cached bool // served from the analysis cache
+}
+
+// Markdown renders the analysis as the :export analysis command does.
+func (a *PRAnalysis) Markdown() string {
+	return fmt.Sprintf("# Analysis of %s: %s\n\n", a.Ref, a.Title) + analysisMarkdown(a.Result)
+}
+
+// AnalyzePR fetches a PR and analyzes it with the configured AI backend,
+// without the TUI. A cached analysis of the same diff is reused unless
+// fresh is set; new results are cached for the TUI to pick up.
+func AnalyzePR(ctx context.Context, ref github.PRRef, fresh bool) (*PRAnalysis, error) {
+	cfg, err := config.Load()
+	if err != nil {
+		return nil, err
+	}
+	claudePath, _ := claude.FindClaude()
+	provider := newLLMProvider(cfg.AnalysisProvider, cfg.AnalysisModel, cfg, claudePath)
+	if provider == nil {
+		return nil, errors.New("Claude CLI not found: install it or choose another AI backend in the config")
+	}
+
+	client, err := newHeadlessClient(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	detail, err := client.GetPRDetail(ctx, ref.Owner, ref.Repo, ref.Number)
+	if err != nil {
+		return nil, err
+	}
+	files, err := client.GetPRFiles(ctx, ref.Owner, ref.Repo, ref.Number)
+	if err != nil {
+		return nil, err
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("%s has no changed files", ref)
+	}
+	out := &PRAnalysis{Ref: ref, Title: detail.Title}
+
+	store := claude.NewAnalysisStore(config.AnalysesCacheDir())
+	hash := diffContentHash(files)
+	if !fresh {
+		if cached, _ := store.Get(ref.Owner, ref.Repo, ref.Number); cached != nil && !store.IsStale(cached, hash) {
+			out.Result, out.Cached = cached.Result, true
+			return out, nil
+		}
+	}
+
+	analyzer := claude.NewAnalyzerWithProvider(provider, cfg.ClaudeTimeoutDuration(), config.PromptsDir(), cfg.AnalysisMaxTurns)
+	input := claude.AnalyzeDiffInput{
+		Owner:       ref.Owner,
+		Repo:        ref.Repo,
+		PRNumber:    ref.Number,
+		PRTitle:     detail.Title,
+		DiffContent: buildDiffContent(files),
+		Truncations: diffTruncations(files),
+	}
+	ledger, err := claude.NewUsageLedger(config.UsageFile())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	}
+	ctx = claude.WithUsageRecorder(ctx, func(u claude.Usage) {
+		_ = ledger.Add(claude.UsageMonth(time.Now()), u) // best-effort
+	})
+	result, err := analyzer.AnalyzeDiff(ctx, input, nil)
+	if err != nil {
+		return nil, err
+	}
+	inputs := analyzer.DiffAnalysisInputs(input)
+	inputs.Model = result.Model
+	_ = store.Put(ref.Owner, ref.Repo, ref.Number, hash, result, inputs) // best-effort
+	out.Result = result
+	return out, nil
+}
+
+// ParseReviewAction parses a review action as given on the command line:
+// "approve", "comment" or "request-changes".
+func ParseReviewAction(s string) (ReviewAction, error) {
+	switch strings.ReplaceAll(strings.ToLower(s), "_", "-") {
+	case "approve":
+		return ReviewApprove, nil
+	case "comment":
+		return ReviewComment, nil
+	case "request-changes":
+		return ReviewRequestChanges, nil
+	}
+	return 0, fmt.Errorf("unknown review action %q", s)
+}
+
+// ParseReviewComments reads inline review comments as JSON: either an array
+// of comments or an AI review object ({"body": ..., "comments": [...]}),
+// each comment with path, line and body plus optional side, start_line and
+// start_side. body is the object's body, "" for an array.
+func ParseReviewComments(data []byte) (body string, comments []claude.InlineReviewComment, err error) {
+	trimmed := strings.TrimSpace(string(data))
+	if trimmed == "" {
+		return "", nil, nil
+	}
+	if strings.HasPrefix(trimmed, "[") {
+		err = json.Unmarshal([]byte(trimmed), &comments)
+	} else {
+		var review claude.ReviewAnalysis
+		err = json.Unmarshal([]byte(trimmed), &review)
+		body, comments = review.Body, review.Comments
+	}
+	if err != nil {
+		return "", nil, fmt.Errorf("invalid review comments: %w", err)
+	}
+	for i, c := range comments {
+		if c.Path == "" || c.Line <= 0 || strings.TrimSpace(c.Body) == "" {
+			return "", nil, fmt.Errorf("review comment %d needs a path, a line and a body", i+1)
+		}
+	}
+	return body, comments, nil
+}
+
+// SubmitPRReview submits a review without the TUI (prtea review), as the
+// Review tab does.
+func SubmitPRReview(ctx context.Context, ref github.PRRef, action ReviewAction, body string, comments []claude.InlineReviewComment) error {
+	if msg := reviewValidationError(action, body); msg != "" {
+		return errors.New(msg)
+	}
+	cfg, err := config.Load()
+	if err != nil {
+		return err
+	}
+	client, err := newHeadlessClient(cfg)
+	if err != nil {
+		return err
+	}
+	return submitReview(ctx, client, ref.Owner, ref.Repo, ref.Number, action, body, comments)
+}
@@ -0,0 +1,56 @@
+package ui
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/shhac/prtea/internal/github"
+)
+
+func TestParseReviewAction(t *testing.T) {
+	tests := map[string]ReviewAction{
+		"approve":         ReviewApprove,
+		"comment":         ReviewComment,
+		"request-changes": ReviewRequestChanges,
+		"REQUEST_CHANGES": ReviewRequestChanges,
+	}
+	for in, want := range tests {
+		if got, err := ParseReviewAction(in); err != nil || got != want {
+			t.Errorf("ParseReviewAction(%q) = %v, %v; want %v", in, got, err, want)
+		}
+	}
+	if _, err := ParseReviewAction("lgtm"); err == nil {
+		t.Error("ParseReviewAction(lgtm) should fail")
+	}
+}
+
+func TestParseReviewComments(t *testing.T) {
+	body, comments, err := ParseReviewComments([]byte(`[{"path":"a.go","line":3,"body":"Nit"}]`))
+	if err != nil || body != "" || len(comments) != 1 || comments[0].Path != "a.go" || comments[0].Line != 3 {
+		t.Errorf("array: got %q, %+v, %v", body, comments, err)
+	}
+
+	body, comments, err = ParseReviewComments([]byte(`{"action":"comment","body":"Looks good","comments":[{"path":"b.go","line":9,"start_line":7,"body":"Range"}]}`))
+	if err != nil || body != "Looks good" || len(comments) != 1 || comments[0].StartLine != 7 {
+		t.Errorf("review object: got %q, %+v, %v", body, comments, err)
+	}
+
+	if _, comments, err := ParseReviewComments([]byte("  \n")); err != nil || comments != nil {
+		t.Errorf("empty input: got %+v, %v", comments, err)
+	}
+
+	for _, bad := range []string{`[{"path":"a.go","body":"no line"}]`, `{"comments": [`, `[{"path":"a.go","line":1,"body":" "}]`} {
+		if _, _, err := ParseReviewComments([]byte(bad)); err == nil {
+			t.Errorf("ParseReviewComments(%s) should fail", bad)
+		}
+	}
+}
+
+func TestSubmitPRReviewRequiresBody(t *testing.T) {
+	ref := github.PRRef{Owner: "o", Repo: "r", Number: 1}
+	err := SubmitPRReview(context.Background(), ref, ReviewRequestChanges, "  ", nil)
+	if err == nil || !strings.Contains(err.Error(), "Review body is required") {
+		t.Errorf("err = %v, want a missing body error", err)
+	}
+}
@@ -28,6 +28,7 @@ func (m *HelpOverlayModel) Show(context Panel) {
 	m.visible = true
 	m.context = context
 	m.refreshContent()
+	m.viewport.GotoTop()
 }
 
 // Hide dismisses the overlay.
@@ -102,7 +103,7 @@ func (m HelpOverlayModel) View() string {
 
 	// Center the title and footer
 	titleLine := lipgloss.PlaceHorizontal(innerW, lipgloss.Center, title)
-	footerLine := lipgloss.PlaceHorizontal(innerW, lipgloss.Center, footer)
+	footerLine := fitWidth(lipgloss.PlaceHorizontal(innerW, lipgloss.Center, footer), innerW)
 
 	boxParts := []string{titleLine, "", content}
 	if indicator := scrollIndicator(m.viewport, innerW); indicator != "" {
@@ -115,13 +116,12 @@ func (m HelpOverlayModel) View() string {
 
 	overlayStyle := lipgloss.NewStyle().
 		Border(lipgloss.RoundedBorder()).
-		BorderForeground(lipgloss.Color("62")).
+		BorderForeground(theme.Accent).
 		Padding(0, 1).
 		Width(overlayW - 2).   // account for border
 		Height(overlayH - 2)
 
-	rendered := overlayStyle.Render(box)
-	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, rendered)
+	return placeOverlay(m.width, m.height, overlayStyle.Render(box))
 }
 
 // overlayDimensions returns the outer dimensions of the overlay box.
@@ -158,7 +158,8 @@ func (m *HelpOverlayModel) refreshContent() {
 	}
 	content := m.renderHelpContent()
 	m.viewport.SetContent(content)
-	m.viewport.GotoTop()
+	// Re-wrapped content may be shorter; keep the offset in range.
+	m.viewport.SetYOffset(m.viewport.YOffset)
 }
 
 func (m HelpOverlayModel) renderHelpContent() string {
@@ -181,9 +182,13 @@ func (m HelpOverlayModel) renderHelpContent() string {
 				{"1 / 2 / 3", "Jump to panel"},
 				{"[ / \\ / ]", "Toggle left/center/right panel"},
 				{"z", "Zoom focused panel"},
+				{"Ctrl+H / Ctrl+L", "Shrink/grow focused panel"},
+				{"Ctrl+O", "Switch to the previously selected PR"},
+				{"Ctrl+F", "Search diff, description, comments, reviews, analysis and chat"},
 				{"r", "Refresh (PR list / selected PR)"},
 				{"a", "Analyze PR"},
 				{"o", "Open in browser"},
+				{"#", "Follow a numbered link [N] (PR Info, Comments, Analysis)"},
 				{"Ctrl+P", "Quick command palette"},
 				{":", "Command mode"},
 				{"?", "Toggle this help"},
@@ -201,6 +206,8 @@ func (m HelpOverlayModel) renderHelpContent() string {
 				{"Esc", "Clear filter"},
 				{"Space", "Select PR"},
 				{"Enter", "Select PR + focus diff"},
+				{"c", "My PRs: recently closed (toggle)"},
+				{"v", "Mark mode: Space marks PRs for :batch"},
 			},
 		},
 		{
@@ -214,11 +221,29 @@ func (m HelpOverlayModel) renderHelpContent() string {
 				{"Ctrl+d / Ctrl+u", "Half page down/up"},
 				{"n / N", "Next/prev hunk (or search match)"},
 				{"g / G", "Jump to top/bottom"},
+				{"{count}G", "Go to line {count} of the current file"},
+				{"Ctrl+O / Ctrl+I", "Back/forward through jumps (Ctrl+I is Tab; Ctrl+O switches PR when nothing is older)"},
 				{"s / Space", "Select/deselect hunk"},
 				{"Enter", "Select hunk + focus chat"},
 				{"S", "Select/deselect file hunks"},
-				{"c", "View/reply to comments"},
-			{"/", "Search in diff"},
+				{"O", "Reorder selected hunks / mark primary focus"},
+				{"y", "Copy line or selection to clipboard"},
+				{"Y", "Copy selected/focused hunks as a patch"},
+				{"c", "View/reply to comments (D in the popup drafts a reply)"},
+				{"]c / [c", "Next/prev line with a comment (wraps)"},
+				{"]f / [f", "Next/prev file (wraps)"},
+				{"m{a-z} / '{a-z}", "Set a bookmark / jump to it (:marks lists them)"},
+				{"x", "Toggle file out of review scope (drafts kept)"},
+				{"t", "Jump between file and its tests"},
+				{"f / F", "Next/prev file in guided review (:guide) or focus mode (:focus)"},
+				{"V", "Mark file viewed and go to the next unviewed one (focus mode)"},
+				{"A", "Ask a quick question about the focused hunk"},
+				{"E", "Explain the focused hunk (again to hide)"},
+				{"U", "Update branch from base (PR Info tab)"},
+				{"P", "Open preview deployment (PR Info tab)"},
+				{"L", "View CI check logs (CI tab)"},
+				{"X", "Re-run selected CI check and watch it (CI tab)"},
+			{"/", "Search in diff (smart case; Ctrl+R toggles regex)"},
 			{"Esc", "Clear search"},
 			},
 		},
@@ -229,8 +254,13 @@ func (m HelpOverlayModel) renderHelpContent() string {
 			keys: []helpEntry{
 				{"h / l", "Prev/next tab"},
 				{"j / k", "Scroll history"},
-				{"Enter", "Enter insert mode"},
+				{"Enter", "Enter insert mode / open selected file link"},
+				{"n / N", "Select next/prev file:line link"},
 				{"C", "New chat (clear conversation)"},
+				{"j / k (Analysis)", "Move between sections and file reviews"},
+				{"Space (Analysis)", "Fold/unfold section"},
+				{"Enter (Analysis)", "Open file review in diff / fold section"},
+				{"f (Analysis)", "Filter file review comments by severity"},
 			},
 		},
 		{
@@ -239,6 +269,7 @@ func (m HelpOverlayModel) renderHelpContent() string {
 			match: false,
 			keys: []helpEntry{
 				{"Enter", "Send message"},
+				{"Ctrl+t", "Pick a prompt preset"},
 				{"Esc", "Exit insert mode"},
 			},
 		},
@@ -247,9 +278,11 @@ func (m HelpOverlayModel) renderHelpContent() string {
 			panel: PanelRight,
 			match: false,
 			keys: []helpEntry{
-				{"Tab", "Next field (body → action → submit)"},
+				{"Tab", "Next field (body → checklist → action → submit)"},
+				{"Space / x", "Toggle the focused checklist item"},
 				{"Shift+Tab", "Previous field"},
 				{"Enter", "Activate text area / submit review"},
+				{"Ctrl+s", "Express submit with current action (press twice)"},
 				{"Esc", "Deactivate text area"},
 				{"j / k", "Change review action"},
 			},
@@ -339,32 +372,46 @@ func padRight(s string, width int) string {
 	return s + strings.Repeat(" ", width-lipgloss.Width(s))
 }
 
+// visibleRange returns the rows [start, end) of an n-row overlay list that
+// fit in maxRows, scrolled to keep the cursor row on screen.
+func visibleRange(cursor, n, maxRows int) (start, end int) {
+	if cursor >= maxRows {
+		start = cursor - maxRows + 1
+	}
+	return start, min(n, start+maxRows)
+}
+
 // Help overlay styles
 var (
-	helpTitleStyle = lipgloss.NewStyle().
-			Bold(true).
-			Foreground(lipgloss.Color("252")).
-			Background(lipgloss.Color("62")).
-			Padding(0, 1)
+	helpTitleStyle         lipgloss.Style
+	helpFooterStyle        lipgloss.Style
+	helpSectionStyle       lipgloss.Style
+	helpSectionActiveStyle lipgloss.Style
+	helpDividerStyle       lipgloss.Style
+	helpKeyStyle           lipgloss.Style
+	helpDescStyle          lipgloss.Style
+)
 
+// buildHelpStyles derives the help overlay styles from the active theme.
+func buildHelpStyles() {
+	helpTitleStyle = lipgloss.NewStyle().
+		Bold(true).
+		Foreground(theme.OnAccent).
+		Background(theme.Accent).
+		Padding(0, 1)
 	helpFooterStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("244")).
-			Italic(true)
-
+		Foreground(theme.Muted).
+		Italic(true)
 	helpSectionStyle = lipgloss.NewStyle().
-				Bold(true).
-				Foreground(lipgloss.Color("33"))
-
+		Bold(true).
+		Foreground(theme.Info)
 	helpSectionActiveStyle = lipgloss.NewStyle().
-				Bold(true).
-				Foreground(lipgloss.Color("42"))
-
+		Bold(true).
+		Foreground(theme.Success)
 	helpDividerStyle = lipgloss.NewStyle().
-				Foreground(lipgloss.Color("240"))
-
+		Foreground(theme.Subtle)
 	helpKeyStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("214"))
-
+		Foreground(theme.Warning)
 	helpDescStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("252"))
-)
+		Foreground(theme.Text)
+}
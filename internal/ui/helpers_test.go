@@ -0,0 +1,21 @@
+package ui
+
+import (
+	"context"
+	"strings"
+
+	"github.com/shhac/prtea/internal/github"
+)
+
+// recordingClient returns a GitHub client for alice that records each gh
+// invocation's arguments, space-joined, in calls. respond gives the output
+// for a call; nil answers everything with nothing.
+func recordingClient(calls *[]string, respond func(args []string) string) *github.Client {
+	return github.NewTestClient("alice", func(ctx context.Context, args ...string) (string, error) {
+		*calls = append(*calls, strings.Join(args, " "))
+		if respond == nil {
+			return "", nil
+		}
+		return respond(args), nil
+	})
+}
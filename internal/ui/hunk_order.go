@@ -0,0 +1,326 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/shhac/prtea/internal/claude"
+)
+
+// orderedSelection returns the selected hunk indices in the order they
+// should be presented to Claude: the user's explicit order first, then any
+// remaining selected hunks in diff order.
+func (m DiffViewerModel) orderedSelection() []int {
+	if len(m.selectedHunks) == 0 {
+		return nil
+	}
+	seen := make(map[int]bool, len(m.selectedHunks))
+	order := make([]int, 0, len(m.selectedHunks))
+	for _, idx := range m.hunkOrder {
+		if m.selectedHunks[idx] && !seen[idx] {
+			seen[idx] = true
+			order = append(order, idx)
+		}
+	}
+	var rest []int
+	for idx := range m.selectedHunks {
+		if !seen[idx] {
+			rest = append(rest, idx)
+		}
+	}
+	sort.Ints(rest)
+	return append(order, rest...)
+}
+
+// primarySelectedHunk returns the hunk marked as the primary focus, if it is
+// still selected.
+func (m DiffViewerModel) primarySelectedHunk() (int, bool) {
+	if !m.hasPrimaryHunk || !m.selectedHunks[m.primaryHunk] {
+		return 0, false
+	}
+	return m.primaryHunk, true
+}
+
+// noteHunkSelected moves idx to the end of the selection order.
+func (m *DiffViewerModel) noteHunkSelected(idx int) {
+	m.hunkOrder = append(removeInt(m.hunkOrder, idx), idx)
+}
+
+// clearHunkSelection deselects all hunks and forgets their order.
+func (m *DiffViewerModel) clearHunkSelection() {
+	for idx := range m.selectedHunks {
+		m.markHunkDirty(idx)
+	}
+	m.selectedHunks = nil
+	m.hunkOrder = nil
+	m.hasPrimaryHunk = false
+}
+
+// SetHunkOrder applies an order and primary focus chosen in the hunk order
+// overlay. primary < 0 clears the primary focus.
+func (m *DiffViewerModel) SetHunkOrder(order []int, primary int) {
+	m.hunkOrder = order
+	m.primaryHunk = primary
+	m.hasPrimaryHunk = primary >= 0
+	for idx := range m.selectedHunks {
+		m.markHunkDirty(idx)
+	}
+	m.refreshContent()
+}
+
+// hunkOrderBadge returns the header badge for a selected hunk: its position
+// in the selection when more than one hunk is selected, and a star for the
+// primary focus.
+func (m DiffViewerModel) hunkOrderBadge(idx int) string {
+	var parts []string
+	if order := m.orderedSelection(); len(order) > 1 {
+		for pos, h := range order {
+			if h == idx {
+				parts = append(parts, fmt.Sprintf("#%d", pos+1))
+				break
+			}
+		}
+	}
+	if p, ok := m.primarySelectedHunk(); ok && p == idx {
+		parts = append(parts, "★ primary")
+	}
+	return strings.Join(parts, " ")
+}
+
+// formatHunks renders the given hunks as a unified diff, repeating the file
+// header whenever the file changes.
+func (m DiffViewerModel) formatHunks(idxs []int) string {
+	var b strings.Builder
+	lastFileIdx := -1
+	for _, i := range idxs {
+		if i < 0 || i >= len(m.hunks) {
+			continue
+		}
+		hunk := m.hunks[i]
+		if hunk.FileIndex != lastFileIdx {
+			if lastFileIdx >= 0 {
+				b.WriteString("\n")
+			}
+			b.WriteString(fmt.Sprintf("--- a/%s\n", hunk.Filename))
+			b.WriteString(fmt.Sprintf("+++ b/%s\n", hunk.Filename))
+			lastFileIdx = hunk.FileIndex
+		}
+		for _, line := range hunk.Lines {
+			b.WriteString(line)
+			b.WriteString("\n")
+		}
+	}
+	return b.String()
+}
+
+// GetSelectedHunkSections splits the selected hunks into the primary focus
+// (empty when none is marked) and the rest, in the user's order.
+func (m DiffViewerModel) GetSelectedHunkSections() (primary, rest string) {
+	order := m.orderedSelection()
+	if p, ok := m.primarySelectedHunk(); ok {
+		primary = m.formatHunks([]int{p})
+		order = removeInt(order, p)
+	}
+	return primary, m.formatHunks(order)
+}
+
+// GetFocusHunkContent returns the selected hunks for the AI review prompt,
+// primary focus first, or "" when nothing is selected.
+func (m DiffViewerModel) GetFocusHunkContent() string {
+	order := m.orderedSelection()
+	if p, ok := m.primarySelectedHunk(); ok {
+		order = append([]int{p}, removeInt(order, p)...)
+	}
+	return m.formatHunks(order)
+}
+
+// selectedHunkLines returns the commentKeys of the new-side lines in the
+// selected hunks: the lines a selection-scoped AI review may comment on.
+func (m DiffViewerModel) selectedHunkLines() map[string]bool {
+	lines := make(map[string]bool)
+	for idx := range m.selectedHunks {
+		if idx < 0 || idx >= len(m.hunks) {
+			continue
+		}
+		h := m.hunks[idx]
+		n := parseHunkNewStart(h.Header)
+		for _, line := range h.Lines {
+			if strings.HasPrefix(line, "@@") || strings.HasPrefix(line, "-") || strings.HasPrefix(line, "\\") {
+				continue
+			}
+			lines[commentKey(h.Filename, n)] = true
+			n++
+		}
+	}
+	return lines
+}
+
+// commentsInScope drops AI comments outside the reviewed lines.
+func commentsInScope(comments []claude.InlineReviewComment, scope map[string]bool) []claude.InlineReviewComment {
+	var kept []claude.InlineReviewComment
+	for _, c := range comments {
+		if scope[commentKey(c.Path, c.Line)] {
+			kept = append(kept, c)
+		}
+	}
+	return kept
+}
+
+// hunkOrderItems describes the selected hunks for the order overlay.
+func (m DiffViewerModel) hunkOrderItems() []HunkOrderItem {
+	order := m.orderedSelection()
+	items := make([]HunkOrderItem, 0, len(order))
+	for _, idx := range order {
+		h := m.hunks[idx]
+		header := h.Header
+		if header == "" && len(h.Lines) > 0 {
+			header = h.Lines[0]
+		}
+		items = append(items, HunkOrderItem{Index: idx, Label: h.Filename + " " + header})
+	}
+	return items
+}
+
+func removeInt(s []int, v int) []int {
+	out := s[:0:0]
+	for _, x := range s {
+		if x != v {
+			out = append(out, x)
+		}
+	}
+	return out
+}
+
+// HunkOrderItem is one selected hunk in the order overlay.
+type HunkOrderItem struct {
+	Index int    // hunk index in the diff viewer
+	Label string // "path @@ header"
+}
+
+// HunkOrderModel is an overlay listing the selected hunks so they can be
+// reordered and one marked as the primary focus before asking Claude.
+type HunkOrderModel struct {
+	items   []HunkOrderItem
+	primary int // hunk index of the primary focus, -1 for none
+	cursor  int
+	width   int
+	height  int
+	visible bool
+}
+
+// NewHunkOrderModel creates a hunk order overlay.
+func NewHunkOrderModel() HunkOrderModel {
+	return HunkOrderModel{primary: -1}
+}
+
+// Show opens the overlay for the given selection.
+func (m *HunkOrderModel) Show(items []HunkOrderItem, primary int) {
+	m.items = append([]HunkOrderItem(nil), items...)
+	m.primary = primary
+	m.cursor = 0
+	m.visible = true
+}
+
+// IsVisible returns whether the overlay is currently shown.
+func (m HunkOrderModel) IsVisible() bool {
+	return m.visible
+}
+
+// SetSize updates the terminal dimensions used to place the overlay.
+func (m *HunkOrderModel) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+}
+
+func (m HunkOrderModel) Update(msg tea.Msg) (HunkOrderModel, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return m, nil
+	}
+	switch keyMsg.String() {
+	case "j", "down":
+		if m.cursor < len(m.items)-1 {
+			m.cursor++
+		}
+	case "k", "up":
+		if m.cursor > 0 {
+			m.cursor--
+		}
+	case "J", "shift+down":
+		if m.cursor < len(m.items)-1 {
+			m.items[m.cursor], m.items[m.cursor+1] = m.items[m.cursor+1], m.items[m.cursor]
+			m.cursor++
+		}
+	case "K", "shift+up":
+		if m.cursor > 0 {
+			m.items[m.cursor], m.items[m.cursor-1] = m.items[m.cursor-1], m.items[m.cursor]
+			m.cursor--
+		}
+	case "p", " ":
+		if len(m.items) > 0 {
+			if idx := m.items[m.cursor].Index; m.primary == idx {
+				m.primary = -1
+			} else {
+				m.primary = idx
+			}
+		}
+	case "enter":
+		m.visible = false
+		order := make([]int, len(m.items))
+		for i, item := range m.items {
+			order[i] = item.Index
+		}
+		primary := m.primary
+		return m, func() tea.Msg { return HunkOrderClosedMsg{Applied: true, Order: order, Primary: primary} }
+	case "esc", "q":
+		m.visible = false
+		return m, func() tea.Msg { return HunkOrderClosedMsg{} }
+	}
+	return m, nil
+}
+
+func (m HunkOrderModel) View() string {
+	if !m.visible {
+		return ""
+	}
+	overlayW := min(max(50, m.width*2/3), m.width)
+	innerW := max(1, overlayW-4)
+
+	title := helpTitleStyle.Render(" Selected hunks ")
+	lines := []string{lipgloss.PlaceHorizontal(innerW, lipgloss.Left, title),
+		dimStyle.Render(fitWidth("Claude reads these in order; the primary focus is addressed first.", innerW)), ""}
+
+	maxRows := max(1, m.height-8)
+	start, end := visibleRange(m.cursor, len(m.items), maxRows)
+	for i := start; i < end; i++ {
+		item := m.items[i]
+		marker := "  "
+		if i == m.cursor {
+			marker = "▸ "
+		}
+		label := fmt.Sprintf("%d. %s", i+1, item.Label)
+		if item.Index == m.primary {
+			label += " " + primaryHunkBadgeStyle.Render("★ primary")
+		}
+		row := ansi.Truncate(marker+label, innerW, "…")
+		if i == m.cursor {
+			row = boldStyle.Render(row)
+		}
+		lines = append(lines, row)
+	}
+
+	footer := helpFooterStyle.Render("J/K move · p primary · Enter apply · Esc cancel")
+	lines = append(lines, "", fitWidth(lipgloss.PlaceHorizontal(innerW, lipgloss.Center, footer), innerW))
+
+	overlayStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(theme.Accent).
+		Padding(0, 1).
+		Width(overlayW - 2)
+
+	return placeOverlay(m.width, m.height, overlayStyle.Render(strings.Join(lines, "\n")))
+}
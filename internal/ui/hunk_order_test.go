@@ -0,0 +1,144 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/shhac/prtea/internal/claude"
+	"github.com/shhac/prtea/internal/github"
+)
+
+func newHunkOrderViewer() DiffViewerModel {
+	m := newTestDiffViewer(80, 24)
+	m.files = []github.PRFile{
+		{Filename: "a.go", Patch: "@@ -1,1 +1,1 @@\n-a1\n+A1\n@@ -10,1 +10,1 @@\n-a2\n+A2"},
+		{Filename: "b.go", Patch: "@@ -1,1 +1,1 @@\n-b1\n+B1"},
+	}
+	m.parseAllHunks()
+	return m
+}
+
+func TestOrderedSelection_FollowsSelectionOrder(t *testing.T) {
+	m := newHunkOrderViewer()
+	m.toggleHunkSelection(2)
+	m.toggleHunkSelection(0)
+	if got := m.orderedSelection(); len(got) != 2 || got[0] != 2 || got[1] != 0 {
+		t.Fatalf("order = %v, want [2 0]", got)
+	}
+
+	// Deselecting and reselecting moves a hunk to the end.
+	m.toggleHunkSelection(2)
+	m.toggleHunkSelection(1)
+	m.toggleHunkSelection(2)
+	if got := m.orderedSelection(); len(got) != 3 || got[0] != 0 || got[1] != 1 || got[2] != 2 {
+		t.Errorf("order = %v, want [0 1 2]", got)
+	}
+
+	content := m.GetSelectedHunkContent()
+	if strings.Index(content, "+A1") > strings.Index(content, "+B1") {
+		t.Errorf("content should follow selection order:\n%s", content)
+	}
+}
+
+func TestGetSelectedHunkSections_PrimaryFirst(t *testing.T) {
+	m := newHunkOrderViewer()
+	for _, idx := range []int{0, 1, 2} {
+		m.toggleHunkSelection(idx)
+	}
+	m.SetHunkOrder([]int{2, 0, 1}, 1)
+
+	primary, rest := m.GetSelectedHunkSections()
+	if !strings.Contains(primary, "+A2") || strings.Contains(primary, "+A1") {
+		t.Errorf("primary = %q", primary)
+	}
+	if strings.Index(rest, "+B1") > strings.Index(rest, "+A1") {
+		t.Errorf("rest should keep the user's order:\n%s", rest)
+	}
+	if focus := m.GetFocusHunkContent(); !strings.HasPrefix(focus, "--- a/a.go") || strings.Index(focus, "+A2") > strings.Index(focus, "+B1") {
+		t.Errorf("focus content should lead with the primary hunk:\n%s", focus)
+	}
+	if badge := m.hunkOrderBadge(1); badge != "#3 ★ primary" {
+		t.Errorf("badge = %q", badge)
+	}
+
+	// A primary hunk that is deselected no longer counts.
+	m.toggleHunkSelection(1)
+	if primary, _ := m.GetSelectedHunkSections(); primary != "" {
+		t.Errorf("primary = %q after deselecting it", primary)
+	}
+}
+
+func TestHunkOrderModel_MoveAndMarkPrimary(t *testing.T) {
+	m := NewHunkOrderModel()
+	m.SetSize(100, 30)
+	m.Show([]HunkOrderItem{{Index: 4, Label: "a.go"}, {Index: 7, Label: "b.go"}}, -1)
+
+	m, _ = m.Update(keyMsg("J"))
+	if m.items[1].Index != 4 || m.cursor != 1 {
+		t.Fatalf("items = %+v cursor = %d after J", m.items, m.cursor)
+	}
+	m, _ = m.Update(keyMsg("p"))
+	if !strings.Contains(m.View(), "★ primary") {
+		t.Error("view should badge the primary hunk")
+	}
+
+	m, cmd := m.Update(keyMsg("enter"))
+	if m.IsVisible() {
+		t.Error("enter should close the overlay")
+	}
+	msg, ok := cmd().(HunkOrderClosedMsg)
+	if !ok || !msg.Applied || msg.Primary != 4 || msg.Order[0] != 7 || msg.Order[1] != 4 {
+		t.Errorf("msg = %+v", msg)
+	}
+}
+
+func TestBuildSelectedHunkContext_Primary(t *testing.T) {
+	pr := &PRSession{Number: 1, Title: "t", Owner: "o", Repo: "r"}
+	ctx := buildSelectedHunkContext(pr, nil, "", "PRIMARY", "OTHERS")
+	if strings.Index(ctx, "PRIMARY") > strings.Index(ctx, "OTHERS") || !strings.Contains(ctx, "primary concern") {
+		t.Errorf("context = %q", ctx)
+	}
+	if ctx := buildSelectedHunkContext(pr, nil, "", "", "OTHERS"); strings.Contains(ctx, "primary concern") {
+		t.Errorf("context without a primary = %q", ctx)
+	}
+}
+
+func TestSelectedHunkLines(t *testing.T) {
+	m := newTestDiffViewer(80, 24)
+	m.files = []github.PRFile{{Filename: "a.go", Patch: "@@ -4,3 +4,3 @@\n ctx\n-old\n+new\n tail\n\\ No newline at end of file"}}
+	m.parseAllHunks()
+	m.toggleHunkSelection(0)
+
+	got := m.selectedHunkLines()
+	for _, key := range []string{"a.go:4", "a.go:5", "a.go:6"} {
+		if !got[key] {
+			t.Errorf("missing %s in %v", key, got)
+		}
+	}
+	if len(got) != 3 {
+		t.Errorf("lines = %v, want a.go:4-6", got)
+	}
+}
+
+func TestScopedAIReviewDropsCommentsOutsideSelection(t *testing.T) {
+	m := App{
+		statusBar:  NewStatusBarModel(),
+		diffViewer: newHunkOrderViewer(),
+		chatPanel:  NewChatPanelModel(),
+		session:    &PRSession{Number: 1},
+	}
+	m.diffViewer.toggleHunkSelection(1) // a.go line 10
+	model, _ := m.handleAnalysisMsg(AIReviewCompleteMsg{
+		PRNumber: 1,
+		Scope:    m.diffViewer.selectedHunkLines(),
+		Result: &claude.ReviewAnalysis{Comments: []claude.InlineReviewComment{
+			{Path: "a.go", Line: 10, Body: "in scope"},
+			{Path: "a.go", Line: 1, Body: "outside"},
+			{Path: "b.go", Line: 1, Body: "other file"},
+		}},
+	})
+	m = model.(App)
+	if got := m.session.PendingInlineComments; len(got) != 1 || got[0].Body != "in scope" {
+		t.Errorf("pending = %+v, want only the in-scope comment", got)
+	}
+}
@@ -14,39 +14,72 @@ type GitHubService interface {
 	GetUsername() string
 	GetPRsForReview(ctx context.Context) ([]github.PRItem, error)
 	GetMyPRs(ctx context.Context) ([]github.PRItem, error)
+	GetPRLists(ctx context.Context) (toReview, mine []github.PRItem, err error)
+	GetPRListsSince(ctx context.Context, since time.Time) (toReview, mine []github.PRItem, err error)
+	GetRecentlyClosedPRs(ctx context.Context) ([]github.PRItem, error)
+	GetReviewWorkload(ctx context.Context, scopes []string) ([]github.WorkloadPR, error)
 	GetPRDetail(ctx context.Context, owner, repo string, number int) (*github.PRDetail, error)
 	GetPRFiles(ctx context.Context, owner, repo string, number int) ([]github.PRFile, error)
 	GetComments(ctx context.Context, owner, repo string, number int) ([]github.Comment, error)
 	GetInlineComments(ctx context.Context, owner, repo string, number int) ([]github.InlineComment, error)
 	GetCIStatus(ctx context.Context, owner, repo string, ref string, number int) (*github.CIStatus, error)
+	GetJobLogs(ctx context.Context, owner, repo string, jobID int64) (string, error)
+	GetCheckAnnotations(ctx context.Context, owner, repo string, checkRunID int64) ([]github.CheckAnnotation, error)
 	GetReviews(ctx context.Context, owner, repo string, number int) (*github.ReviewSummary, error)
+	GetMergeRequirements(ctx context.Context, owner, repo, base string, number int) (*github.MergeRequirements, error)
+	GetBaseChangedFiles(ctx context.Context, owner, repo, base, head string) ([]string, error)
+	GetCompareFiles(ctx context.Context, owner, repo, base, head string) ([]github.PRFile, error)
+	GetCompareStatus(ctx context.Context, owner, repo, base, head string) (string, error)
+	GetOpenPRStackInfo(ctx context.Context, owner, repo string) ([]github.StackPR, error)
+	GetDeployments(ctx context.Context, owner, repo, sha string) ([]github.Deployment, error)
+	GetSecurityAlerts(ctx context.Context, owner, repo, base, headSHA string, number int) ([]github.SecurityAlert, error)
+	GetCoverage(ctx context.Context, owner, repo string, runIDs []int64, patterns []string) (*github.CoverageReport, error)
+	GetCodeOwners(ctx context.Context, owner, repo, ref string) ([]github.CodeOwnerRule, error)
+	GetMyTeams(ctx context.Context) ([]string, error)
+	UpdateBranch(ctx context.Context, owner, repo string, number int, expectedHeadSHA string) error
+	EnableAutoMerge(ctx context.Context, owner, repo string, number int, method string, commit *github.MergeCommit) error
+	DisableAutoMerge(ctx context.Context, owner, repo string, number int) error
+	MarkReadyForReview(ctx context.Context, owner, repo string, number int) error
+	ConvertToDraft(ctx context.Context, owner, repo string, number int) error
 	ApprovePR(ctx context.Context, owner, repo string, number int, body string) error
 	PostComment(ctx context.Context, owner, repo string, number int, body string) error
 	ClosePR(ctx context.Context, owner, repo string, number int) error
+	ReopenPR(ctx context.Context, owner, repo string, number int) error
+	UpdatePRBody(ctx context.Context, owner, repo string, number int, body string) error
 	RequestChangesPR(ctx context.Context, owner, repo string, number int, body string) error
 	CommentReviewPR(ctx context.Context, owner, repo string, number int, body string) error
 	SubmitReviewWithComments(ctx context.Context, owner, repo string, number int, event string, body string, comments []github.ReviewCommentPayload) error
 	RerunWorkflow(ctx context.Context, owner, repo string, runID int64, failedOnly bool) error
+	RerunJob(ctx context.Context, owner, repo string, jobID int64) error
 	ReplyToComment(ctx context.Context, owner, repo string, prNumber int, commentID int64, body string) error
+	ApplySuggestion(ctx context.Context, headRepo, branch, path string, startLine, endLine int, original, lines []string, message string) error
 	GetReviewDecisions(ctx context.Context, prs []github.PRItem) (map[string]string, error)
+	GetCIRollups(ctx context.Context, prs []github.PRItem) (map[string]string, error)
+	GetRateLimit(ctx context.Context) (*github.RateLimit, error)
 	SetFetchLimit(limit int)
 }
 
 // AIAnalyzer defines the analysis operations used by the UI layer.
-// *claude.Analyzer satisfies this interface.
+// *claude.Analyzer satisfies this interface, whichever LLMProvider backs it.
 type AIAnalyzer interface {
 	Analyze(ctx context.Context, input claude.AnalyzeInput, onProgress claude.ProgressFunc) (*claude.AnalysisResult, error)
 	AnalyzeDiff(ctx context.Context, input claude.AnalyzeDiffInput, onProgress claude.ProgressFunc) (*claude.AnalysisResult, error)
 	AnalyzeDiffStream(ctx context.Context, input claude.AnalyzeDiffInput, onChunk func(string)) (*claude.AnalysisResult, error)
+	SummarizeDiff(ctx context.Context, input claude.AnalyzeDiffInput) (*claude.PRSummary, error)
+	DraftMergeMessage(ctx context.Context, input claude.AnalyzeDiffInput) (*claude.MergeMessage, error)
+	TriagePR(ctx context.Context, input claude.AnalyzeDiffInput) (*claude.PRTriage, error)
+	DiffAnalysisInputs(input claude.AnalyzeDiffInput) *claude.AnalysisInputs
 	AnalyzeForReview(ctx context.Context, input claude.ReviewInput, onProgress claude.ProgressFunc) (*claude.ReviewAnalysis, error)
 	SetTimeout(d time.Duration)
 	SetAnalysisMaxTurns(n int)
+	SetProvider(p claude.LLMProvider)
 }
 
 // AIChatService defines the chat operations used by the UI layer.
 // *claude.ChatService satisfies this interface.
 type AIChatService interface {
 	ChatStream(ctx context.Context, input claude.ChatInput, onChunk func(text string)) (string, error)
+	AskOnce(ctx context.Context, input claude.ChatInput, onChunk func(text string)) (string, error)
 	ClearSession(owner, repo string, prNumber int)
 	SaveSession(owner, repo string, prNumber int)
 	GetSessionMessages(owner, repo string, prNumber int) []claude.ChatMessage
@@ -54,4 +87,5 @@ type AIChatService interface {
 	SetMaxPromptTokens(n int)
 	SetMaxHistoryMessages(n int)
 	SetMaxTurns(n int)
+	SetProvider(p claude.LLMProvider)
 }
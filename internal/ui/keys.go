@@ -13,11 +13,16 @@ type GlobalKeyMap struct {
 	Panel3       key.Binding
 	Analyze      key.Binding
 	OpenBrowser  key.Binding
+	FollowLink   key.Binding
 	Refresh      key.Binding
 	ToggleLeft   key.Binding
 	ToggleCenter key.Binding
 	ToggleRight  key.Binding
 	Zoom         key.Binding
+	GrowPanel    key.Binding
+	ShrinkPanel  key.Binding
+	SwitchPR     key.Binding
+	GlobalSearch key.Binding
 	CommandMode  key.Binding
 	ExCommand    key.Binding
 }
@@ -59,6 +64,10 @@ var GlobalKeys = GlobalKeyMap{
 		key.WithKeys("o"),
 		key.WithHelp("o", "open in browser"),
 	),
+	FollowLink: key.NewBinding(
+		key.WithKeys("#"),
+		key.WithHelp("#", "follow a numbered link"),
+	),
 	Refresh: key.NewBinding(
 		key.WithKeys("r"),
 		key.WithHelp("r", "refresh"),
@@ -79,6 +88,22 @@ var GlobalKeys = GlobalKeyMap{
 		key.WithKeys("z"),
 		key.WithHelp("z", "zoom panel"),
 	),
+	GrowPanel: key.NewBinding(
+		key.WithKeys("ctrl+l"),
+		key.WithHelp("Ctrl+L", "grow focused panel"),
+	),
+	ShrinkPanel: key.NewBinding(
+		key.WithKeys("ctrl+h"),
+		key.WithHelp("Ctrl+H", "shrink focused panel"),
+	),
+	SwitchPR: key.NewBinding(
+		key.WithKeys("ctrl+o"),
+		key.WithHelp("Ctrl+O", "switch to previous PR"),
+	),
+	GlobalSearch: key.NewBinding(
+		key.WithKeys("ctrl+f"),
+		key.WithHelp("Ctrl+F", "search everything"),
+	),
 	CommandMode: key.NewBinding(
 		key.WithKeys("ctrl+p"),
 		key.WithHelp("Ctrl+P", "quick palette"),
@@ -97,6 +122,8 @@ type PRListKeyMap struct {
 	SelectAndAdvance key.Binding
 	PrevTab          key.Binding
 	NextTab          key.Binding
+	ToggleClosed     key.Binding
+	Mark             key.Binding
 }
 
 var PRListKeys = PRListKeyMap{
@@ -124,6 +151,14 @@ var PRListKeys = PRListKeyMap{
 		key.WithKeys("l", "right"),
 		key.WithHelp("l", "next tab"),
 	),
+	ToggleClosed: key.NewBinding(
+		key.WithKeys("c"),
+		key.WithHelp("c", "recently closed"),
+	),
+	Mark: key.NewBinding(
+		key.WithKeys("v"),
+		key.WithHelp("v", "mark PRs"),
+	),
 }
 
 // DiffViewerKeyMap defines keys for the diff viewer panel.
@@ -146,6 +181,23 @@ type DiffViewerKeyMap struct {
 	ClearSelection        key.Binding
 	Search                key.Binding
 	RerunCI               key.Binding
+	ViewLogs              key.Binding
+	RerunCheck            key.Binding
+	UpdateBranch          key.Binding
+	OpenPreview           key.Binding
+	ToggleTask            key.Binding
+	TestPair              key.Binding
+	GuideNext             key.Binding
+	GuidePrev             key.Binding
+	MarkViewed            key.Binding
+	AskHunk               key.Binding
+	ExplainHunk           key.Binding
+	ExcludeFile           key.Binding
+	OrderHunks            key.Binding
+	Yank                  key.Binding
+	YankPatch             key.Binding
+	JumpBack              key.Binding
+	JumpForward           key.Binding
 }
 
 var DiffViewerKeys = DiffViewerKeyMap{
@@ -222,6 +274,74 @@ var DiffViewerKeys = DiffViewerKeyMap{
 		key.WithKeys("x"),
 		key.WithHelp("x", "re-run failed CI"),
 	),
+	ViewLogs: key.NewBinding(
+		key.WithKeys("L"),
+		key.WithHelp("L", "view CI check logs"),
+	),
+	RerunCheck: key.NewBinding(
+		key.WithKeys("X"),
+		key.WithHelp("X", "re-run selected CI check"),
+	),
+	UpdateBranch: key.NewBinding(
+		key.WithKeys("U"),
+		key.WithHelp("U", "update branch from base"),
+	),
+	OpenPreview: key.NewBinding(
+		key.WithKeys("P"),
+		key.WithHelp("P", "open preview deployment"),
+	),
+	ToggleTask: key.NewBinding(
+		key.WithKeys(" "),
+		key.WithHelp("Space", "toggle PR task"),
+	),
+	TestPair: key.NewBinding(
+		key.WithKeys("t"),
+		key.WithHelp("t", "jump to test/implementation"),
+	),
+	GuideNext: key.NewBinding(
+		key.WithKeys("f"),
+		key.WithHelp("f", "next file (guided review)"),
+	),
+	GuidePrev: key.NewBinding(
+		key.WithKeys("F"),
+		key.WithHelp("F", "prev file (guided review)"),
+	),
+	MarkViewed: key.NewBinding(
+		key.WithKeys("V"),
+		key.WithHelp("V", "mark file viewed & next (focus mode)"),
+	),
+	AskHunk: key.NewBinding(
+		key.WithKeys("A"),
+		key.WithHelp("A", "ask about hunk"),
+	),
+	ExplainHunk: key.NewBinding(
+		key.WithKeys("E"),
+		key.WithHelp("E", "explain hunk"),
+	),
+	ExcludeFile: key.NewBinding(
+		key.WithKeys("x"),
+		key.WithHelp("x", "exclude file from review"),
+	),
+	OrderHunks: key.NewBinding(
+		key.WithKeys("O"),
+		key.WithHelp("O", "order selected hunks"),
+	),
+	Yank: key.NewBinding(
+		key.WithKeys("y"),
+		key.WithHelp("y", "copy line/selection"),
+	),
+	YankPatch: key.NewBinding(
+		key.WithKeys("Y"),
+		key.WithHelp("Y", "copy hunks as patch"),
+	),
+	JumpBack: key.NewBinding(
+		key.WithKeys("ctrl+o"),
+		key.WithHelp("Ctrl+O", "jump back"),
+	),
+	JumpForward: key.NewBinding(
+		key.WithKeys("tab"),
+		key.WithHelp("Ctrl+I/Tab", "jump forward"),
+	),
 }
 
 // ChatKeyMap defines keys for the chat panel.
@@ -233,6 +353,10 @@ type ChatKeyMap struct {
 	PrevTab    key.Binding
 	NextTab    key.Binding
 	NewChat    key.Binding
+	Presets    key.Binding
+
+	NextCitation key.Binding
+	PrevCitation key.Binding
 }
 
 var ChatKeys = ChatKeyMap{
@@ -264,4 +388,16 @@ var ChatKeys = ChatKeyMap{
 		key.WithKeys("C"),
 		key.WithHelp("C", "new chat"),
 	),
+	Presets: key.NewBinding(
+		key.WithKeys("ctrl+t"),
+		key.WithHelp("Ctrl+t", "prompt presets"),
+	),
+	NextCitation: key.NewBinding(
+		key.WithKeys("n"),
+		key.WithHelp("n", "next file link"),
+	),
+	PrevCitation: key.NewBinding(
+		key.WithKeys("N"),
+		key.WithHelp("N", "prev file link"),
+	),
 }
@@ -0,0 +1,270 @@
+package ui
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// linkRe matches, in order of preference, a markdown link or image
+// "[text](url "title")", an autolink "<url>" and a bare URL.
+var linkRe = regexp.MustCompile(`!?\[([^\]]*)\]\((https?://[^)\s]+)(?:\s+"[^"]*")?\)|<(https?://[^>\s]+)>|https?://[^\s<>()\[\]` + "`" + `]+`)
+
+// link is a numbered link target of a rendered view.
+type link struct {
+	label string
+	url   string
+}
+
+// linkHints numbers the links of a rendered view, which glamour would
+// otherwise print without their targets, so they can be followed by number.
+// A URL that appears twice keeps its first number.
+type linkHints struct {
+	links []link
+	index map[string]int // url → 1-based number
+}
+
+// add numbers url, returning its existing number if it has one.
+func (h *linkHints) add(label, url string) int {
+	if n, ok := h.index[url]; ok {
+		return n
+	}
+	if h.index == nil {
+		h.index = make(map[string]int)
+	}
+	h.links = append(h.links, link{label: label, url: url})
+	h.index[url] = len(h.links)
+	return len(h.links)
+}
+
+// annotate rewrites the links of markdown text as "text [N]", and bare
+// URLs as "url [N]", numbering each. Code spans and fenced code blocks are
+// left alone. A nil receiver returns the text unchanged.
+func (h *linkHints) annotate(markdown string) string {
+	if h == nil || !strings.Contains(markdown, "http") {
+		return markdown
+	}
+	lines := strings.Split(markdown, "\n")
+	fenced := false
+	for i, line := range lines {
+		if trimmed := strings.TrimSpace(line); strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+			fenced = !fenced
+			continue
+		}
+		if fenced {
+			continue
+		}
+		// Odd parts between backticks are code spans.
+		parts := strings.Split(line, "`")
+		for j := 0; j < len(parts); j += 2 {
+			parts[j] = linkRe.ReplaceAllStringFunc(parts[j], h.hint)
+		}
+		lines[i] = strings.Join(parts, "`")
+	}
+	return strings.Join(lines, "\n")
+}
+
+// hint rewrites one linkRe match.
+func (h *linkHints) hint(match string) string {
+	m := linkRe.FindStringSubmatch(match)
+	switch {
+	case m[2] != "":
+		label := m[1]
+		if label == "" {
+			label = m[2]
+		}
+		return fmt.Sprintf("%s [%d]", label, h.add(label, m[2]))
+	case m[3] != "":
+		return fmt.Sprintf("%s [%d]", m[3], h.add(m[3], m[3]))
+	}
+	// Sentence punctuation after a bare URL is not part of it.
+	url := strings.TrimRight(match, ".,;:!?'\"")
+	return fmt.Sprintf("%s [%d]%s", url, h.add(url, url), match[len(url):])
+}
+
+// visibleLinks returns the numbered links of the focused view: the PR
+// description on the PR Info tab, or the Comments or Analysis tab.
+func (m App) visibleLinks() (string, []link) {
+	switch m.focused {
+	case PanelCenter:
+		if m.diffViewer.activeTab == TabPRInfo {
+			return "PR description", m.diffViewer.links
+		}
+	case PanelRight:
+		switch m.chatPanel.activeTab {
+		case ChatTabComments:
+			return "Comments", m.chatPanel.comments.links
+		case ChatTabAnalysis:
+			return "Analysis", m.chatPanel.analysis.links
+		}
+	}
+	return "", nil
+}
+
+// showLinks opens the numbered links of the focused view (#).
+func (m App) showLinks() (tea.Model, tea.Cmd) {
+	source, links := m.visibleLinks()
+	if source == "" {
+		return m, m.statusBar.SetTemporaryMessage("Links can be followed from the PR Info, Comments and Analysis tabs", 2*time.Second)
+	}
+	if len(links) == 0 {
+		return m, m.statusBar.SetTemporaryMessage("No links in the "+source, 2*time.Second)
+	}
+	m.links.SetSize(m.width, m.height)
+	m.links.Show(source, links)
+	m.setMode(ModeOverlay)
+	return m, nil
+}
+
+// followLink opens link N of the focused view in the browser (:link N).
+func (m App) followLink(args string) (tea.Model, tea.Cmd) {
+	n, err := strconv.Atoi(strings.Trim(strings.TrimSpace(args), "[]"))
+	if err != nil {
+		return m.showLinks()
+	}
+	source, links := m.visibleLinks()
+	if n < 1 || n > len(links) {
+		if source == "" {
+			source = "focused view"
+		}
+		return m, m.statusBar.SetTemporaryMessage(fmt.Sprintf("No link [%d] in the %s", n, source), 2*time.Second)
+	}
+	return m, openBrowserCmd(links[n-1].url)
+}
+
+// LinksModel is the follow-link overlay: the numbered links of a view,
+// opened in the browser by number or selection.
+type LinksModel struct {
+	source  string
+	links   []link
+	cursor  int
+	typed   string // digits typed to jump to a number
+	width   int
+	height  int
+	visible bool
+}
+
+// NewLinksModel creates a follow-link overlay.
+func NewLinksModel() LinksModel {
+	return LinksModel{}
+}
+
+// Show opens the overlay on a view's links.
+func (m *LinksModel) Show(source string, links []link) {
+	m.source = source
+	m.links = links
+	m.cursor = 0
+	m.typed = ""
+	m.visible = true
+}
+
+// IsVisible returns whether the overlay is currently shown.
+func (m LinksModel) IsVisible() bool {
+	return m.visible
+}
+
+// SetSize updates the terminal dimensions used to place the overlay.
+func (m *LinksModel) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+}
+
+func (m LinksModel) overlayWidth() int {
+	return min(max(60, m.width*3/4), m.width)
+}
+
+func (m LinksModel) Update(msg tea.Msg) (LinksModel, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return m, nil
+	}
+	switch s := keyMsg.String(); s {
+	case "j", "down":
+		m.cursor = min(m.cursor+1, len(m.links)-1)
+		m.typed = ""
+	case "k", "up":
+		m.cursor = max(m.cursor-1, 0)
+		m.typed = ""
+	case "backspace":
+		if m.typed != "" {
+			m.typed = m.typed[:len(m.typed)-1]
+		}
+	case "enter":
+		url := m.links[m.cursor].url
+		m.visible = false
+		return m, func() tea.Msg { return LinksClosedMsg{Open: url} }
+	case "esc", "q":
+		m.visible = false
+		return m, func() tea.Msg { return LinksClosedMsg{} }
+	default:
+		if len(s) != 1 || s[0] < '0' || s[0] > '9' {
+			return m, nil
+		}
+		typed := m.typed + s
+		n, _ := strconv.Atoi(typed)
+		if n < 1 || n > len(m.links) {
+			typed, n = s, int(s[0]-'0')
+		}
+		if n < 1 || n > len(m.links) {
+			return m, nil
+		}
+		m.typed, m.cursor = typed, n-1
+		// Open at once when no longer number starts with the typed digits.
+		if n*10 > len(m.links) {
+			url := m.links[m.cursor].url
+			m.visible = false
+			return m, func() tea.Msg { return LinksClosedMsg{Open: url} }
+		}
+	}
+	return m, nil
+}
+
+func (m LinksModel) View() string {
+	if !m.visible {
+		return ""
+	}
+	overlayW := m.overlayWidth()
+	innerW := max(1, overlayW-4)
+
+	title := helpTitleStyle.Render(fmt.Sprintf(" Links in the %s (%d) ", m.source, len(m.links)))
+	lines := []string{lipgloss.PlaceHorizontal(innerW, lipgloss.Left, title), ""}
+
+	maxRows := max(1, m.height-8)
+	start, end := visibleRange(m.cursor, len(m.links), maxRows)
+	numW := len(strconv.Itoa(len(m.links))) + 2
+	for i := start; i < end; i++ {
+		l := m.links[i]
+		marker := "  "
+		if i == m.cursor {
+			marker = "▸ "
+		}
+		text := fmt.Sprintf("%s%-*s ", marker, numW, fmt.Sprintf("[%d]", i+1))
+		if l.label != l.url {
+			text += l.label + " " + dimStyle.Render(l.url)
+		} else {
+			text += l.url
+		}
+		row := ansi.Truncate(text, innerW, "…")
+		if i == m.cursor {
+			row = boldStyle.Render(row)
+		}
+		lines = append(lines, row)
+	}
+
+	footer := helpFooterStyle.Render("number or j/k + Enter open in browser · Esc close")
+	lines = append(lines, "", fitWidth(lipgloss.PlaceHorizontal(innerW, lipgloss.Center, footer), innerW))
+
+	overlayStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(theme.Accent).
+		Padding(0, 1).
+		Width(overlayW - 2)
+
+	return placeOverlay(m.width, m.height, overlayStyle.Render(strings.Join(lines, "\n")))
+}
@@ -0,0 +1,92 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+)
+
+func TestLinkHints_Annotate(t *testing.T) {
+	var h linkHints
+	got := h.annotate("See [the docs](https://example.com/docs \"Docs\") and https://ci.example.com/run/1.\n" +
+		"`https://in.code` <https://example.com/x> ![diagram](https://img.example.com/a.png)\n" +
+		"```\nhttps://fenced.example.com\n```\nAgain: https://example.com/docs")
+
+	for _, want := range []string{
+		"See the docs [1] and https://ci.example.com/run/1 [2].",
+		"`https://in.code` https://example.com/x [3] diagram [4]",
+		"https://fenced.example.com\n",
+		"Again: https://example.com/docs [1]",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("annotated text missing %q:\n%s", want, got)
+		}
+	}
+	if len(h.links) != 4 || h.links[0] != (link{label: "the docs", url: "https://example.com/docs"}) {
+		t.Errorf("links = %+v", h.links)
+	}
+
+	var none *linkHints
+	if got := none.annotate("[x](https://example.com)"); got != "[x](https://example.com)" {
+		t.Errorf("nil hints changed the text: %q", got)
+	}
+}
+
+func TestLinksModel_OpensByNumber(t *testing.T) {
+	links := make([]link, 12)
+	for i := range links {
+		links[i] = link{url: "https://example.com/" + strings.Repeat("x", i)}
+		links[i].label = links[i].url
+	}
+	m := NewLinksModel()
+	m.SetSize(100, 30)
+	m.Show("Comments", links)
+
+	// "1" could still become 10-12, so it only moves the cursor.
+	m, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("1")})
+	if cmd != nil || m.cursor != 0 {
+		t.Fatalf("1: cursor = %d, cmd = %v", m.cursor, cmd)
+	}
+	m, cmd = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("2")})
+	if closed, ok := cmd().(LinksClosedMsg); !ok || closed.Open != links[11].url || m.IsVisible() {
+		t.Errorf("12 = %#v, want link 12 opened", closed)
+	}
+
+	m.Show("Comments", links)
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("j")})
+	_, cmd = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if closed := cmd().(LinksClosedMsg); closed.Open != links[1].url {
+		t.Errorf("Enter = %#v, want link 2", closed)
+	}
+}
+
+func TestShowLinks_PRDescription(t *testing.T) {
+	m := App{
+		statusBar:  NewStatusBarModel(),
+		diffViewer: newTestDiffViewer(80, 24),
+		links:      NewLinksModel(),
+		focused:    PanelCenter,
+		width:      100,
+		height:     30,
+	}
+	m.diffViewer.prNumber = 7
+	m.diffViewer.activeTab = TabPRInfo
+	m.diffViewer.SetPRInfo("Add retries", "Fixes [the flake](https://example.com/issue/3).", "alice", "")
+
+	if !strings.Contains(ansi.Strip(m.diffViewer.viewport.View()), "the flake [1]") {
+		t.Errorf("PR Info should show the link number:\n%s", m.diffViewer.viewport.View())
+	}
+	model, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("#")})
+	m = model.(App)
+	if !m.links.IsVisible() || !strings.Contains(m.links.View(), "https://example.com/issue/3") {
+		t.Fatalf("# should list the description's links:\n%s", m.links.View())
+	}
+
+	m.focused = PanelLeft
+	model, _ = m.followLink("1")
+	if m = model.(App); !strings.Contains(m.statusBar.statusMessage, "No link [1]") {
+		t.Errorf("status = %q, want no link outside a view with links", m.statusBar.statusMessage)
+	}
+}
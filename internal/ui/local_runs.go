@@ -0,0 +1,381 @@
+package ui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/shhac/prtea/internal/localrun"
+)
+
+// maxRunLines caps the output kept per run; older lines are dropped.
+const maxRunLines = 5000
+
+// localRun is one :run of a configured command in a local clone.
+type localRun struct {
+	id       int
+	name     string
+	command  string
+	pr       int
+	lines    []string
+	dropped  int // lines dropped from the start past maxRunLines
+	started  time.Time
+	elapsed  time.Duration
+	done     bool
+	exitCode int
+	err      string
+}
+
+// passed reports whether a finished run exited cleanly.
+func (r *localRun) passed() bool {
+	return r.done && r.err == "" && r.exitCode == 0
+}
+
+// status summarizes a run, e.g. "passed in 8s" or "failed (exit 2) in 20s".
+func (r *localRun) status() string {
+	if !r.done {
+		return fmt.Sprintf("running %s", time.Since(r.started).Truncate(time.Second))
+	}
+	took := r.elapsed.Truncate(time.Second)
+	switch {
+	case r.err != "":
+		return "error: " + r.err
+	case r.exitCode == 0:
+		return fmt.Sprintf("passed in %s", took)
+	default:
+		return fmt.Sprintf("failed (exit %d) in %s", r.exitCode, took)
+	}
+}
+
+// runStreamChan carries the output lines and result of a local run.
+type runStreamChan chan tea.Msg
+
+// startRun runs a configured command against the PR branch in the repo's
+// local clone, streaming its output into the Runs tab (:run test).
+func (m App) startRun(args string) (tea.Model, tea.Cmd) {
+	name := strings.TrimSpace(args)
+	if name == "stop" {
+		return m.stopRun()
+	}
+	if m.session == nil {
+		return m, m.statusBar.SetTemporaryMessage("Select a PR first", 2*time.Second)
+	}
+	cfg := m.appConfig
+	if cfg == nil || len(cfg.RunCommands) == 0 {
+		return m, m.statusBar.SetTemporaryMessage("No run commands configured (runCommands)", 2*time.Second)
+	}
+	command, ok := cfg.RunCommands[name]
+	if !ok {
+		names := make([]string, 0, len(cfg.RunCommands))
+		for n := range cfg.RunCommands {
+			names = append(names, n)
+		}
+		sort.Strings(names)
+		return m, m.statusBar.SetTemporaryMessage("Usage: run "+strings.Join(names, "|")+"|stop", 3*time.Second)
+	}
+	s := m.session
+	dir := cfg.LocalClone(s.Owner, s.Repo)
+	if dir == "" {
+		return m, m.statusBar.SetTemporaryMessage(fmt.Sprintf("No local clone of %s/%s configured (localClones)", s.Owner, s.Repo), 3*time.Second)
+	}
+	if m.runChan != nil {
+		return m, m.statusBar.SetTemporaryMessage("A run is already in progress; :run stop cancels it", 2*time.Second)
+	}
+
+	m.runSeq++
+	run := &localRun{id: m.runSeq, name: name, command: command, pr: s.Number, started: time.Now()}
+	m.diffViewer.addRun(run)
+	m.diffViewer.activeTab = TabRuns
+	m.diffViewer.refreshContent()
+	m.showAndFocusPanel(PanelCenter)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	ch := make(runStreamChan)
+	go func() {
+		defer close(ch)
+		emit := func(line string) {
+			select {
+			case ch <- RunOutputMsg{ID: run.id, Line: line}:
+			case <-ctx.Done():
+			}
+		}
+		done := RunDoneMsg{ID: run.id}
+		done.ExitCode, done.Err = runInClone(ctx, dir, s.Number, command, cfg.RunInWorktree, emit)
+		// Sent even when stopped: the listener reads until the channel closes.
+		ch <- done
+	}()
+	m.runChan = ch
+	m.runCancel = cancel
+	return m, tea.Batch(m.diffViewer.spinner.Tick, listenForStream(ch))
+}
+
+// runInClone checks the PR out in the clone at dir, or in a temporary
+// worktree of it, and runs command there.
+func runInClone(ctx context.Context, dir string, number int, command string, worktree bool, emit func(string)) (int, error) {
+	if worktree {
+		emit(fmt.Sprintf("$ gh pr checkout %d --detach  (in a worktree of %s)", number, dir))
+		path, err := localrun.CheckoutPRWorktree(ctx, dir, number)
+		if err != nil {
+			return -1, err
+		}
+		emit("$ " + command + "  (in " + path + ")")
+		return localrun.Run(ctx, path, command, emit)
+	}
+	if err := localrun.EnsureClean(ctx, dir); err != nil {
+		return -1, err
+	}
+	emit(fmt.Sprintf("$ gh pr checkout %d  (in %s)", number, dir))
+	if err := localrun.CheckoutPR(ctx, dir, number); err != nil {
+		return -1, err
+	}
+	emit("$ " + command)
+	return localrun.Run(ctx, dir, command, emit)
+}
+
+// stopRun cancels the run in progress (:run stop).
+func (m App) stopRun() (tea.Model, tea.Cmd) {
+	if m.runCancel == nil {
+		return m, m.statusBar.SetTemporaryMessage("No run in progress", 2*time.Second)
+	}
+	m.runCancel()
+	return m, m.statusBar.SetTemporaryMessage("Stopping run...", 2*time.Second)
+}
+
+// finishRun records a run's result and announces it in the status bar.
+func (m App) finishRun(msg RunDoneMsg) (tea.Model, tea.Cmd) {
+	m.runChan = nil
+	if m.runCancel != nil {
+		m.runCancel()
+		m.runCancel = nil
+	}
+	run := m.diffViewer.finishRun(msg)
+	if run == nil {
+		return m, nil
+	}
+	return m, m.statusBar.SetTemporaryMessage(fmt.Sprintf("%s on PR #%d %s", run.name, run.pr, run.status()), 5*time.Second)
+}
+
+// addRun adds a run at the top of the Runs tab and selects it.
+func (m *DiffViewerModel) addRun(run *localRun) {
+	m.runs = append([]*localRun{run}, m.runs...)
+	m.runCursor = 0
+}
+
+// runByID returns the run with the given ID, or nil.
+func (m DiffViewerModel) runByID(id int) *localRun {
+	for _, r := range m.runs {
+		if r.id == id {
+			return r
+		}
+	}
+	return nil
+}
+
+// runActive reports whether a run is still in progress.
+func (m DiffViewerModel) runActive() bool {
+	return len(m.runs) > 0 && !m.runs[0].done
+}
+
+// appendRunOutput adds an output line to a run, following the output while
+// the run is selected and the view is at the bottom.
+func (m *DiffViewerModel) appendRunOutput(id int, line string) {
+	run := m.runByID(id)
+	if run == nil {
+		return
+	}
+	run.lines = append(run.lines, line)
+	if over := len(run.lines) - maxRunLines; over > 0 {
+		run.lines = run.lines[over:]
+		run.dropped += over
+	}
+	if m.activeTab == TabRuns {
+		follow := m.viewport.AtBottom()
+		m.refreshContent()
+		if follow {
+			m.viewport.GotoBottom()
+		}
+	}
+}
+
+// finishRun marks a run done, returning it.
+func (m *DiffViewerModel) finishRun(msg RunDoneMsg) *localRun {
+	run := m.runByID(msg.ID)
+	if run == nil {
+		return nil
+	}
+	run.done = true
+	run.elapsed = time.Since(run.started)
+	run.exitCode = msg.ExitCode
+	switch {
+	case errors.Is(msg.Err, context.Canceled):
+		run.err = "stopped"
+	case msg.Err != nil:
+		run.err = msg.Err.Error()
+	}
+	m.refreshContent()
+	return run
+}
+
+// moveRunCursor selects another run on the Runs tab.
+func (m *DiffViewerModel) moveRunCursor(delta int) {
+	if len(m.runs) == 0 {
+		return
+	}
+	m.runCursor = max(0, min(len(m.runs)-1, m.runCursor+delta))
+	m.refreshContent()
+	m.viewport.GotoBottom()
+}
+
+// runIconColor returns the icon and color for a run's state.
+func (m DiffViewerModel) runIconColor(r *localRun) (string, lipgloss.Color) {
+	switch {
+	case !r.done:
+		return m.spinner.View(), theme.Warning
+	case r.passed():
+		return "✓", theme.Success
+	default:
+		return "✗", theme.Error
+	}
+}
+
+// runsTabLabel is the Runs tab header with the newest run's state.
+func (m DiffViewerModel) runsTabLabel() string {
+	icon, _ := m.runIconColor(m.runs[0])
+	return "Runs (" + icon + ")"
+}
+
+// renderRunsTab lists the runs, newest first, followed by the selected
+// run's output.
+func (m DiffViewerModel) renderRunsTab() string {
+	if len(m.runs) == 0 {
+		return renderEmptyState("No local runs yet", "Use :run test or :run lint with a local clone configured")
+	}
+	width := max(10, m.viewport.Width)
+	var b strings.Builder
+	b.WriteString(sectionHeaderStyle.Render(fmt.Sprintf("Runs (%d)", len(m.runs))))
+	b.WriteString("\n")
+	for i, r := range m.runs {
+		marker := "  "
+		if i == m.runCursor {
+			marker = diffCursorGutterStyle.Render("▸") + " "
+		}
+		icon, color := m.runIconColor(r)
+		line := fmt.Sprintf("%s%s %s #%d %s %s", marker,
+			lipgloss.NewStyle().Foreground(color).Render(icon),
+			boldStyle.Render(r.name), r.pr, dimStyle.Render(r.command), r.status())
+		b.WriteString(fitWidth(line, width))
+		b.WriteString("\n")
+	}
+
+	r := m.runs[m.runCursor]
+	b.WriteString("\n")
+	b.WriteString(dimStyle.Render(fmt.Sprintf("── %s #%d output ", r.name, r.pr)))
+	b.WriteString("\n")
+	if r.dropped > 0 {
+		b.WriteString(dimStyle.Render(fmt.Sprintf("… %d earlier lines dropped", r.dropped)))
+		b.WriteString("\n")
+	}
+	for _, line := range r.lines {
+		b.WriteString(fitWidth(line, width))
+		b.WriteString("\n")
+	}
+	if r.done {
+		_, color := m.runIconColor(r)
+		b.WriteString(lipgloss.NewStyle().Bold(true).Foreground(color).Render(r.status()))
+		b.WriteString("\n")
+	}
+	hint := "n/N select run · :run stop cancels"
+	b.WriteString(lipgloss.NewStyle().Foreground(theme.Muted).Italic(true).Render(hint))
+	return b.String()
+}
+
+// lastTab is the rightmost tab: Runs once there is a local run, else CI.
+func (m DiffViewerModel) lastTab() DiffViewerTab {
+	if len(m.runs) > 0 {
+		return TabRuns
+	}
+	return TabCI
+}
+
+// worktreeCommand lists or removes the temporary worktrees :run created in
+// the configured local clones (:worktree, :worktree prune).
+func (m App) worktreeCommand(args string) (tea.Model, tea.Cmd) {
+	var clones []string
+	if m.appConfig != nil {
+		clones = m.appConfig.LocalClonePaths()
+	}
+	if len(clones) == 0 {
+		return m, m.statusBar.SetTemporaryMessage("No local clones configured (localClones)", 2*time.Second)
+	}
+	switch strings.TrimSpace(args) {
+	case "":
+		return m, listWorktreesCmd(clones)
+	case "prune":
+		if m.runChan != nil {
+			return m, m.statusBar.SetTemporaryMessage("A run is in progress; :run stop cancels it", 2*time.Second)
+		}
+		return m, pruneWorktreesCmd(clones)
+	}
+	return m, m.statusBar.SetTemporaryMessage("Usage: worktree [prune]", 2*time.Second)
+}
+
+// listWorktreesCmd counts the temporary worktrees of the clones.
+func listWorktreesCmd(clones []string) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		var msg WorktreesMsg
+		for _, dir := range clones {
+			paths, err := localrun.Worktrees(ctx, dir)
+			if err != nil {
+				msg.Err = err
+				continue
+			}
+			msg.Count += len(paths)
+		}
+		return msg
+	}
+}
+
+// pruneWorktreesCmd removes the temporary worktrees of the clones.
+func pruneWorktreesCmd(clones []string) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+		defer cancel()
+		msg := WorktreesMsg{Pruned: true}
+		for _, dir := range clones {
+			n, err := localrun.PruneWorktrees(ctx, dir)
+			msg.Count += n
+			if err != nil {
+				msg.Err = err
+			}
+		}
+		return msg
+	}
+}
+
+// worktreesStatus describes a WorktreesMsg for the status bar.
+func worktreesStatus(msg WorktreesMsg) string {
+	noun := "worktrees"
+	if msg.Count == 1 {
+		noun = "worktree"
+	}
+	var s string
+	switch {
+	case msg.Pruned:
+		s = fmt.Sprintf("Removed %d %s", msg.Count, noun)
+	case msg.Count == 0:
+		s = "No run worktrees"
+	default:
+		s = fmt.Sprintf("%d run %s (:worktree prune removes them)", msg.Count, noun)
+	}
+	if msg.Err != nil {
+		s += " — " + msg.Err.Error()
+	}
+	return s
+}
@@ -0,0 +1,131 @@
+package ui
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/shhac/prtea/internal/config"
+)
+
+func localRunTestApp(cfg *config.Config) App {
+	return App{
+		statusBar:  NewStatusBarModel(),
+		diffViewer: newTestDiffViewer(80, 24),
+		session:    &PRSession{Owner: "shhac", Repo: "prtea", Number: 12},
+		appConfig:  cfg,
+	}
+}
+
+func TestStartRun_Validation(t *testing.T) {
+	cfg := &config.Config{RunCommands: config.DefaultRunCommands()}
+	tests := []struct {
+		name string
+		cfg  *config.Config
+		args string
+		want string
+	}{
+		{"unknown command", cfg, "bench", "Usage: run lint|test|stop"},
+		{"no clone", cfg, "test", "No local clone of shhac/prtea"},
+		{"no commands", &config.Config{RunCommands: map[string]string{}}, "test", "No run commands configured"},
+		{"stop with nothing running", cfg, "stop", "No run in progress"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			model, _ := localRunTestApp(tt.cfg).startRun(tt.args)
+			m := model.(App)
+			if !strings.Contains(m.statusBar.statusMessage, tt.want) {
+				t.Errorf("status = %q, want %q", m.statusBar.statusMessage, tt.want)
+			}
+			if len(m.diffViewer.runs) != 0 {
+				t.Error("no run should have started")
+			}
+		})
+	}
+}
+
+func TestLocalRun_OutputAndResult(t *testing.T) {
+	m := localRunTestApp(&config.Config{})
+	m.diffViewer.addRun(&localRun{id: 1, name: "test", command: "make test", pr: 12})
+	m.diffViewer.activeTab = TabRuns
+	m.runChan = make(runStreamChan)
+
+	model, cmd := m.Update(RunOutputMsg{ID: 1, Line: "ok  pkg/a"})
+	m = model.(App)
+	if cmd == nil {
+		t.Error("output should keep listening to the run")
+	}
+	if got := m.diffViewer.renderRunsTab(); !strings.Contains(got, "ok  pkg/a") || !strings.Contains(got, "running") {
+		t.Errorf("Runs tab while running:\n%s", got)
+	}
+
+	model, _ = m.Update(RunDoneMsg{ID: 1, ExitCode: 2})
+	m = model.(App)
+	run := m.diffViewer.runs[0]
+	if m.runChan != nil || !run.done || run.passed() || !strings.HasPrefix(run.status(), "failed (exit 2)") {
+		t.Errorf("run = %+v, runChan = %v", run, m.runChan)
+	}
+	if !strings.Contains(m.statusBar.statusMessage, "test on PR #12 failed (exit 2)") {
+		t.Errorf("status = %q", m.statusBar.statusMessage)
+	}
+
+	m.diffViewer.addRun(&localRun{id: 2, name: "lint", pr: 12})
+	m.diffViewer.finishRun(RunDoneMsg{ID: 2, Err: context.Canceled})
+	if got := m.diffViewer.runs[0].status(); got != "error: stopped" {
+		t.Errorf("stopped run status = %q", got)
+	}
+	m.diffViewer.addRun(&localRun{id: 3, name: "lint", pr: 12})
+	m.diffViewer.finishRun(RunDoneMsg{ID: 3, ExitCode: -1, Err: errors.New("uncommitted changes")})
+	if got := m.diffViewer.runs[0].status(); got != "error: uncommitted changes" {
+		t.Errorf("failed checkout status = %q", got)
+	}
+}
+
+func TestRunsTab_ShownOnceThereIsARun(t *testing.T) {
+	dv := newTestDiffViewer(80, 24)
+	dv.focused = true
+	dv.activeTab = TabCI
+	dv, _ = dv.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("l")})
+	if dv.activeTab != TabCI || strings.Contains(dv.renderTabs(), "Runs") {
+		t.Fatal("the Runs tab should not exist before a run")
+	}
+
+	dv.addRun(&localRun{id: 1, name: "test", pr: 12, done: true})
+	dv.addRun(&localRun{id: 2, name: "lint", pr: 12, done: true, exitCode: 1})
+	dv, _ = dv.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("l")})
+	if dv.activeTab != TabRuns || !strings.Contains(dv.renderTabs(), "Runs (✗)") {
+		t.Fatalf("tab = %v, tabs = %q", dv.activeTab, dv.renderTabs())
+	}
+	dv, _ = dv.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("n")})
+	if dv.runCursor != 1 {
+		t.Errorf("n should select the older run, cursor = %d", dv.runCursor)
+	}
+}
+
+func TestWorktreeCommand(t *testing.T) {
+	model, cmd := localRunTestApp(&config.Config{}).worktreeCommand("prune")
+	if m := model.(App); cmd == nil || !strings.Contains(m.statusBar.statusMessage, "No local clones configured") {
+		t.Errorf("status = %q", m.statusBar.statusMessage)
+	}
+	model, _ = localRunTestApp(&config.Config{LocalClones: map[string]string{"shhac/prtea": "/src/prtea"}}).worktreeCommand("gc")
+	if m := model.(App); !strings.Contains(m.statusBar.statusMessage, "Usage: worktree [prune]") {
+		t.Errorf("status = %q", m.statusBar.statusMessage)
+	}
+
+	m := localRunTestApp(&config.Config{})
+	model, _ = m.Update(WorktreesMsg{Count: 2, Pruned: true})
+	if m = model.(App); m.statusBar.statusMessage != "Removed 2 worktrees" {
+		t.Errorf("status = %q", m.statusBar.statusMessage)
+	}
+	for msg, want := range map[WorktreesMsg]string{
+		{}:         "No run worktrees",
+		{Count: 1}: "1 run worktree (:worktree prune removes them)",
+		{Count: 1, Pruned: true, Err: errors.New("locked")}: "Removed 1 worktree — locked",
+	} {
+		if got := worktreesStatus(msg); got != want {
+			t.Errorf("worktreesStatus(%+v) = %q, want %q", msg, got, want)
+		}
+	}
+}
@@ -0,0 +1,440 @@
+package ui
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/spinner"
+	"github.com/charmbracelet/bubbles/textinput"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// LogViewerModel renders a CI job log in a scrollable, searchable overlay.
+// Log lines keep their ANSI colors; search and error detection run against
+// the ANSI-stripped text.
+type LogViewerModel struct {
+	viewport viewport.Model
+	spinner  spinner.Model
+	width    int
+	height   int
+	visible  bool
+	ready    bool
+
+	title   string
+	jobID   int64
+	loading bool
+	err     string
+
+	lines []string // display lines (timestamps removed, ANSI preserved)
+	plain []string // ANSI-stripped lines for search and error detection
+
+	// Search state
+	searchMode  bool
+	searchInput textinput.Model
+	searchTerm  string
+	matches     []int // line indices containing the search term
+	matchIdx    int
+}
+
+// NewLogViewerModel creates a log viewer overlay.
+func NewLogViewerModel() LogViewerModel {
+	si := textinput.New()
+	si.Prompt = "/"
+	si.CharLimit = 100
+	return LogViewerModel{
+		spinner:     newLoadingSpinner(),
+		searchInput: si,
+	}
+}
+
+// Show opens the overlay in a loading state for the given job.
+func (m *LogViewerModel) Show(title string, jobID int64) tea.Cmd {
+	m.visible = true
+	m.title = title
+	m.jobID = jobID
+	m.loading = true
+	m.err = ""
+	m.lines = nil
+	m.plain = nil
+	m.clearSearch()
+	m.refreshContent()
+	return m.spinner.Tick
+}
+
+// Hide dismisses the overlay.
+func (m *LogViewerModel) Hide() {
+	m.visible = false
+	m.searchMode = false
+	m.searchInput.Blur()
+}
+
+// IsVisible returns whether the overlay is currently shown.
+func (m LogViewerModel) IsVisible() bool {
+	return m.visible
+}
+
+// JobID returns the job whose logs are shown (or loading).
+func (m LogViewerModel) JobID() int64 {
+	return m.jobID
+}
+
+// SetLogs populates the viewer with raw job log output and jumps to the
+// first line that looks like an error.
+func (m *LogViewerModel) SetLogs(raw string) {
+	m.loading = false
+	m.err = ""
+	m.lines = parseJobLog(raw)
+	m.plain = make([]string, len(m.lines))
+	for i, l := range m.lines {
+		m.plain[i] = ansi.Strip(l)
+	}
+	m.refreshContent()
+	m.jumpToFirstError()
+}
+
+// SetError shows a fetch error in place of the log.
+func (m *LogViewerModel) SetError(err string) {
+	m.loading = false
+	m.err = err
+	m.refreshContent()
+}
+
+// SetSize updates the overlay dimensions and rebuilds the viewport.
+func (m *LogViewerModel) SetSize(termWidth, termHeight int) {
+	m.width = termWidth
+	m.height = termHeight
+
+	innerW, innerH := m.innerDimensions()
+	if !m.ready {
+		m.viewport = viewport.New(innerW, innerH)
+		m.ready = true
+	} else {
+		m.viewport.Width = innerW
+		m.viewport.Height = innerH
+	}
+	m.searchInput.Width = innerW - 2
+	m.refreshContent()
+}
+
+func (m LogViewerModel) Update(msg tea.Msg) (LogViewerModel, tea.Cmd) {
+	switch msg := msg.(type) {
+	case spinner.TickMsg:
+		if m.loading {
+			var cmd tea.Cmd
+			m.spinner, cmd = m.spinner.Update(msg)
+			m.refreshContent()
+			return m, cmd
+		}
+		return m, nil
+	case tea.KeyMsg:
+		if m.searchMode {
+			return m.updateSearching(msg)
+		}
+		switch {
+		case msg.String() == "esc":
+			if m.searchTerm != "" {
+				m.clearSearch()
+				m.refreshContent()
+				return m, nil
+			}
+			return m.close()
+		case msg.String() == "q":
+			return m.close()
+		case key.Matches(msg, DiffViewerKeys.Search):
+			m.searchMode = true
+			m.searchInput.SetValue(m.searchTerm)
+			m.searchInput.CursorEnd()
+			return m, m.searchInput.Focus()
+		case key.Matches(msg, DiffViewerKeys.NextHunk):
+			m.stepMatch(1)
+			return m, nil
+		case key.Matches(msg, DiffViewerKeys.PrevHunk):
+			m.stepMatch(-1)
+			return m, nil
+		case msg.String() == "e":
+			m.jumpToFirstError()
+			return m, nil
+		case key.Matches(msg, DiffViewerKeys.Top):
+			m.viewport.GotoTop()
+			return m, nil
+		case key.Matches(msg, DiffViewerKeys.Bottom):
+			m.viewport.GotoBottom()
+			return m, nil
+		}
+		var cmd tea.Cmd
+		m.viewport, cmd = m.viewport.Update(msg)
+		return m, cmd
+	}
+	return m, nil
+}
+
+// updateSearching handles keys while the search input is focused.
+func (m LogViewerModel) updateSearching(msg tea.KeyMsg) (LogViewerModel, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		m.searchMode = false
+		m.searchInput.Blur()
+		return m, nil
+	case "enter":
+		m.searchMode = false
+		m.searchInput.Blur()
+		m.searchTerm = strings.TrimSpace(m.searchInput.Value())
+		m.matches = findLogMatches(m.plain, m.searchTerm)
+		m.matchIdx = 0
+		m.refreshContent()
+		m.scrollToMatch()
+		return m, nil
+	}
+	var cmd tea.Cmd
+	m.searchInput, cmd = m.searchInput.Update(msg)
+	return m, cmd
+}
+
+// close hides the overlay and notifies the app.
+func (m LogViewerModel) close() (LogViewerModel, tea.Cmd) {
+	m.Hide()
+	return m, func() tea.Msg { return LogViewerClosedMsg{} }
+}
+
+func (m *LogViewerModel) clearSearch() {
+	m.searchMode = false
+	m.searchTerm = ""
+	m.searchInput.SetValue("")
+	m.searchInput.Blur()
+	m.matches = nil
+	m.matchIdx = 0
+}
+
+// stepMatch moves to the next (dir=1) or previous (dir=-1) search match.
+func (m *LogViewerModel) stepMatch(dir int) {
+	if len(m.matches) == 0 {
+		return
+	}
+	m.matchIdx = (m.matchIdx + dir + len(m.matches)) % len(m.matches)
+	m.refreshContent()
+	m.scrollToMatch()
+}
+
+// scrollToMatch scrolls to the current search match.
+func (m *LogViewerModel) scrollToMatch() {
+	if len(m.matches) == 0 {
+		return
+	}
+	m.scrollToLine(m.matches[m.matchIdx])
+}
+
+// jumpToFirstError scrolls to the first line that looks like an error.
+func (m *LogViewerModel) jumpToFirstError() {
+	if idx := firstErrorLine(m.plain); idx >= 0 {
+		m.scrollToLine(idx)
+	}
+}
+
+// scrollToLine positions line idx a few lines below the top of the viewport
+// so the lead-up context stays visible.
+func (m *LogViewerModel) scrollToLine(idx int) {
+	m.viewport.SetYOffset(max(0, idx-3))
+}
+
+func (m *LogViewerModel) refreshContent() {
+	if !m.ready {
+		return
+	}
+	m.viewport.SetContent(m.renderLog())
+}
+
+// renderLog builds the viewport content with search-match gutter markers.
+func (m LogViewerModel) renderLog() string {
+	if m.loading {
+		return dimStyle.Render(m.spinner.View() + " Fetching job logs...")
+	}
+	if m.err != "" {
+		return renderErrorWithHint(formatUserError(m.err), "Press Esc to close")
+	}
+	if len(m.lines) == 0 {
+		return dimItalicStyle.Render("(empty log)")
+	}
+
+	matchSet := make(map[int]bool, len(m.matches))
+	for _, i := range m.matches {
+		matchSet[i] = true
+	}
+	current := -1
+	if len(m.matches) > 0 {
+		current = m.matches[m.matchIdx]
+	}
+
+	var b strings.Builder
+	for i, line := range m.lines {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		switch {
+		case i == current:
+			b.WriteString(diffCursorGutterStyle.Render("▸") + " ")
+		case matchSet[i]:
+			b.WriteString(diffSearchInfoStyle.Render("▌") + " ")
+		default:
+			b.WriteString("  ")
+		}
+		b.WriteString(line)
+	}
+	return b.String()
+}
+
+func (m LogViewerModel) View() string {
+	if !m.visible {
+		return ""
+	}
+
+	overlayW, overlayH := m.overlayDimensions()
+	innerW := overlayW - 4
+	if innerW < 1 {
+		innerW = 1
+	}
+
+	title := helpTitleStyle.Render(" 📜 " + m.title + " ")
+	titleLine := lipgloss.PlaceHorizontal(innerW, lipgloss.Left, title)
+
+	var content string
+	if m.ready {
+		content = m.viewport.View()
+	}
+
+	var bottom string
+	switch {
+	case m.searchMode:
+		bottom = m.searchInput.View()
+	case m.searchTerm != "" && len(m.matches) == 0:
+		bottom = diffSearchInfoStyle.Render(fmt.Sprintf("No matches for %q", m.searchTerm))
+	case m.searchTerm != "":
+		bottom = diffSearchInfoStyle.Render(fmt.Sprintf("%q %d/%d", m.searchTerm, m.matchIdx+1, len(m.matches)))
+	default:
+		bottom = scrollIndicator(m.viewport, innerW)
+	}
+
+	footer := helpFooterStyle.Render("/ search · n/N next/prev · e first error · g/G top/bottom · Esc close")
+	footerLine := fitWidth(lipgloss.PlaceHorizontal(innerW, lipgloss.Center, footer), innerW)
+
+	box := lipgloss.JoinVertical(lipgloss.Left, titleLine, "", content, bottom, footerLine)
+
+	overlayStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(theme.Accent).
+		Padding(0, 1).
+		Width(overlayW - 2).
+		Height(overlayH - 2)
+
+	return placeOverlay(m.width, m.height, overlayStyle.Render(box))
+}
+
+// overlayDimensions returns the outer dimensions of the overlay box.
+// Logs are wide, so the viewer takes most of the terminal.
+func (m LogViewerModel) overlayDimensions() (width, height int) {
+	width = int(float64(m.width) * 0.9)
+	height = int(float64(m.height) * 0.85)
+	if width < 50 {
+		width = min(50, m.width)
+	}
+	if height < 15 {
+		height = min(15, m.height)
+	}
+	return width, height
+}
+
+// innerDimensions returns the viewport dimensions inside the overlay box.
+func (m LogViewerModel) innerDimensions() (width, height int) {
+	ow, oh := m.overlayDimensions()
+	// Subtract border (2), padding (2), title + blank (2), status line (1), footer (1)
+	width = ow - 4
+	height = oh - 6
+	if width < 1 {
+		width = 1
+	}
+	if height < 1 {
+		height = 1
+	}
+	return width, height
+}
+
+// -- Log parsing --
+
+// logTimestampRe matches the RFC 3339 timestamp GitHub Actions prefixes to every log line.
+var logTimestampRe = regexp.MustCompile(`^\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}(\.\d+)?Z `)
+
+// logErrorRe matches common error markers in CI output.
+var logErrorRe = regexp.MustCompile(`(?i)(\berror\b|\bfailed\b|\bfailure\b|\bpanic:|\bfatal\b|^\s*FAIL\b|^--- FAIL)`)
+
+// parseJobLog converts raw Actions log output into display lines: timestamps
+// are removed and workflow commands (##[group], ##[error], ...) are styled.
+func parseJobLog(raw string) []string {
+	raw = strings.ReplaceAll(raw, "\r\n", "\n")
+	raw = strings.TrimRight(raw, "\n")
+	if raw == "" {
+		return nil
+	}
+	src := strings.Split(raw, "\n")
+	lines := make([]string, 0, len(src))
+	for _, l := range src {
+		l = strings.TrimPrefix(l, "\ufeff")
+		l = logTimestampRe.ReplaceAllString(l, "")
+		switch {
+		case strings.HasPrefix(l, "##[group]"):
+			l = boldStyle.Render("▼ " + strings.TrimPrefix(l, "##[group]"))
+		case strings.HasPrefix(l, "##[endgroup]"):
+			continue
+		case strings.HasPrefix(l, "##[error]"):
+			l = errTextStyle.Render("✗ " + strings.TrimPrefix(l, "##[error]"))
+		case strings.HasPrefix(l, "##[warning]"):
+			l = diffSearchInfoStyle.Render("⚠ " + strings.TrimPrefix(l, "##[warning]"))
+		}
+		lines = append(lines, l)
+	}
+	return lines
+}
+
+// firstErrorLine returns the index of the first line that looks like an
+// error, or -1. The first ##[error] annotation (rendered with a ✗ prefix)
+// bounds the search: it is usually the final "exit code" summary, so an
+// earlier keyword match is preferred when one exists.
+func firstErrorLine(plain []string) int {
+	for i, l := range plain {
+		if strings.HasPrefix(l, "✗ ") {
+			if j := firstKeywordError(plain[:i]); j >= 0 {
+				return j
+			}
+			return i
+		}
+	}
+	return firstKeywordError(plain)
+}
+
+// firstKeywordError returns the first line matching logErrorRe, or -1.
+func firstKeywordError(plain []string) int {
+	for i, l := range plain {
+		if logErrorRe.MatchString(l) {
+			return i
+		}
+	}
+	return -1
+}
+
+// findLogMatches returns indices of lines containing term (case-insensitive).
+func findLogMatches(plain []string, term string) []int {
+	if term == "" {
+		return nil
+	}
+	term = strings.ToLower(term)
+	var matches []int
+	for i, l := range plain {
+		if strings.Contains(strings.ToLower(l), term) {
+			matches = append(matches, i)
+		}
+	}
+	return matches
+}
@@ -0,0 +1,70 @@
+package ui
+
+import (
+	"testing"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+func plainLines(lines []string) []string {
+	out := make([]string, len(lines))
+	for i, l := range lines {
+		out[i] = ansi.Strip(l)
+	}
+	return out
+}
+
+func TestParseJobLog_StripsTimestampsAndGroups(t *testing.T) {
+	raw := "\ufeff2024-01-15T10:00:00.1234567Z ##[group]Run go test\r\n" +
+		"2024-01-15T10:00:01.0000000Z ok  \tpkg\t0.1s\r\n" +
+		"2024-01-15T10:00:02.0000000Z ##[endgroup]\r\n" +
+		"2024-01-15T10:00:03.0000000Z ##[error]Process completed with exit code 1.\r\n"
+
+	got := plainLines(parseJobLog(raw))
+	want := []string{"▼ Run go test", "ok  \tpkg\t0.1s", "✗ Process completed with exit code 1."}
+	if len(got) != len(want) {
+		t.Fatalf("got %d lines %q, want %d", len(got), got, len(want))
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("line %d = %q, want %q", i, got[i], want[i])
+		}
+	}
+}
+
+func TestParseJobLog_Empty(t *testing.T) {
+	if got := parseJobLog("\n"); got != nil {
+		t.Errorf("expected nil for empty log, got %q", got)
+	}
+}
+
+func TestFirstErrorLine(t *testing.T) {
+	tests := []struct {
+		name  string
+		lines []string
+		want  int
+	}{
+		{"none", []string{"building", "ok"}, -1},
+		{"keyword", []string{"building", "--- FAIL: TestFoo", "ok"}, 1},
+		{"annotation only", []string{"building", "✗ exit code 1"}, 1},
+		{"keyword before annotation", []string{"setup", "panic: boom", "✗ exit code 2"}, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := firstErrorLine(tt.lines); got != tt.want {
+				t.Errorf("firstErrorLine() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFindLogMatches_CaseInsensitive(t *testing.T) {
+	lines := []string{"Running Tests", "no match", "all tests passed"}
+	got := findLogMatches(lines, "TESTS")
+	if len(got) != 2 || got[0] != 0 || got[1] != 2 {
+		t.Errorf("findLogMatches() = %v, want [0 2]", got)
+	}
+	if findLogMatches(lines, "") != nil {
+		t.Error("expected nil matches for empty term")
+	}
+}
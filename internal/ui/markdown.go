@@ -5,6 +5,7 @@ import (
 	"strings"
 
 	"github.com/charmbracelet/glamour"
+	"github.com/charmbracelet/glamour/styles"
 	"github.com/charmbracelet/lipgloss"
 )
 
@@ -16,6 +17,7 @@ const markdownCacheMaxSize = 100
 type MarkdownRenderer struct {
 	renderer *glamour.TermRenderer
 	width    int
+	theme    string            // theme the renderer was built for
 	cache    map[string]string // content-level LRU (key: "width:content")
 }
 
@@ -27,7 +29,7 @@ func (mr *MarkdownRenderer) RenderMarkdown(markdown string, width int) string {
 		width = 10
 	}
 
-	key := fmt.Sprintf("%d:%s", width, markdown)
+	key := fmt.Sprintf("%s:%d:%s", theme.Name, width, markdown)
 	if cached, ok := mr.cache[key]; ok {
 		return cached
 	}
@@ -54,11 +56,18 @@ func (mr *MarkdownRenderer) RenderMarkdown(markdown string, width int) string {
 }
 
 func (mr *MarkdownRenderer) getOrCreate(width int) *glamour.TermRenderer {
-	if mr.renderer != nil && mr.width == width {
+	if mr.renderer != nil && mr.width == width && mr.theme == theme.Name {
 		return mr.renderer
 	}
+	style := glamour.WithStandardStyle(styles.DarkStyle)
+	if !theme.Dark {
+		style = glamour.WithStandardStyle(styles.LightStyle)
+	}
+	if plainOutput {
+		style = glamour.WithStandardStyle(styles.NoTTYStyle)
+	}
 	r, err := glamour.NewTermRenderer(
-		glamour.WithAutoStyle(),
+		style,
 		glamour.WithWordWrap(width),
 	)
 	if err != nil {
@@ -66,6 +75,7 @@ func (mr *MarkdownRenderer) getOrCreate(width int) *glamour.TermRenderer {
 	}
 	mr.renderer = r
 	mr.width = width
+	mr.theme = theme.Name
 	return r
 }
 
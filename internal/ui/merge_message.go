@@ -0,0 +1,259 @@
+package ui
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/shhac/prtea/internal/claude"
+	"github.com/shhac/prtea/internal/github"
+)
+
+// isOwnPR reports whether the current PR was authored by the user.
+func (m App) isOwnPR() bool {
+	if m.session == nil || m.ghClient == nil {
+		return false
+	}
+	return strings.EqualFold(m.session.Author, m.ghClient.GetUsername())
+}
+
+// startMergeMessage drafts a squash-merge commit message and release note
+// for one of the user's own PRs.
+func (m App) startMergeMessage() (tea.Model, tea.Cmd) {
+	if m.session == nil {
+		return m, m.statusBar.SetTemporaryMessage("No PR selected", 2*time.Second)
+	}
+	if !m.isOwnPR() {
+		return m, m.statusBar.SetTemporaryMessage("Merge messages can only be drafted for your own PRs", 2*time.Second)
+	}
+	if m.analyzer == nil {
+		return m, m.statusBar.SetTemporaryMessage("AI unavailable — install the Claude CLI or choose another AI backend in Settings", 3*time.Second)
+	}
+	if m.aiBudget.health == aiDisabled {
+		return m, m.statusBar.SetTemporaryMessage(aiDisabledReason, 3*time.Second)
+	}
+	if len(m.session.DiffFiles) == 0 {
+		return m, m.statusBar.SetTemporaryMessage("No diff loaded yet", 2*time.Second)
+	}
+	if m.session.DraftingMergeMessage {
+		return m, nil
+	}
+	if draft := m.session.MergeMessageDraft; draft != nil {
+		m.session.MergeMessageDraft = nil
+		m.showMergeMessage(draft)
+		return m, nil
+	}
+
+	m.session.DraftingMergeMessage = true
+	s := m.session
+	input := claude.AnalyzeDiffInput{
+		Owner:       s.Owner,
+		Repo:        s.Repo,
+		PRNumber:    s.Number,
+		PRTitle:     s.Title,
+		PRBody:      m.diffViewer.prBody,
+		DiffContent: buildDiffContent(s.DiffFiles),
+	}
+	clearCmd := m.statusBar.SetTemporaryMessage("Drafting merge message...", 30*time.Second)
+	return m, tea.Batch(clearCmd, mergeMessageCmd(m.usageContext(), m.analyzer, input))
+}
+
+// mergeMessageCmd runs the merge message prompt off the UI goroutine.
+func mergeMessageCmd(ctx context.Context, analyzer AIAnalyzer, input claude.AnalyzeDiffInput) tea.Cmd {
+	return func() tea.Msg {
+		msg, err := analyzer.DraftMergeMessage(ctx, input)
+		if err != nil {
+			return MergeMessageErrorMsg{PRNumber: input.PRNumber, Err: err}
+		}
+		return MergeMessageCompleteMsg{PRNumber: input.PRNumber, Message: msg}
+	}
+}
+
+// showMergeMessage opens the overlay on a drafted message.
+func (m *App) showMergeMessage(msg *claude.MergeMessage) {
+	m.mergeMessage.SetSize(m.width, m.height)
+	m.mergeMessage.Show(msg)
+	m.setMode(ModeOverlay)
+}
+
+// acceptMergeMessage makes msg the commit message for squash auto-merge,
+// re-enabling a pending squash auto-merge so GitHub picks it up.
+func (m *App) acceptMergeMessage(msg *claude.MergeMessage) tea.Cmd {
+	if m.session == nil {
+		return nil
+	}
+	m.session.MergeMessage = msg
+	m.diffViewer.SetMergeMessage(msg.Title)
+	if m.diffViewer.autoMerge == github.MergeMethodSquash {
+		return func() tea.Msg { return AutoMergeRequestMsg{Method: github.MergeMethodSquash} }
+	}
+	return m.statusBar.SetTemporaryMessage("Squash auto-merge will use this message — :auto-merge squash", 3*time.Second)
+}
+
+// squashCommit returns the accepted merge message for a squash auto-merge,
+// or nil to keep GitHub's default.
+func (s *PRSession) squashCommit(method string) *github.MergeCommit {
+	if s == nil || s.MergeMessage == nil || method != github.MergeMethodSquash {
+		return nil
+	}
+	return &github.MergeCommit{Headline: s.MergeMessage.Title, Body: s.MergeMessage.Body}
+}
+
+// MergeMessageOverlayModel shows a drafted merge message with actions to
+// copy it or use it for squash auto-merge.
+type MergeMessageOverlayModel struct {
+	viewport viewport.Model
+	width    int
+	height   int
+	visible  bool
+	ready    bool
+
+	message *claude.MergeMessage
+}
+
+// NewMergeMessageOverlayModel creates a merge message overlay.
+func NewMergeMessageOverlayModel() MergeMessageOverlayModel {
+	return MergeMessageOverlayModel{}
+}
+
+// Show opens the overlay with a drafted message.
+func (m *MergeMessageOverlayModel) Show(msg *claude.MergeMessage) {
+	m.visible = true
+	m.message = msg
+	m.refreshContent()
+	m.viewport.GotoTop()
+}
+
+// Hide dismisses the overlay.
+func (m *MergeMessageOverlayModel) Hide() {
+	m.visible = false
+}
+
+// IsVisible returns whether the overlay is currently shown.
+func (m MergeMessageOverlayModel) IsVisible() bool {
+	return m.visible
+}
+
+// SetSize updates the overlay dimensions and rebuilds the viewport.
+func (m *MergeMessageOverlayModel) SetSize(termWidth, termHeight int) {
+	m.width = termWidth
+	m.height = termHeight
+
+	innerW, innerH := m.innerDimensions()
+	if !m.ready {
+		m.viewport = viewport.New(innerW, innerH)
+		m.ready = true
+	} else {
+		m.viewport.Width = innerW
+		m.viewport.Height = innerH
+	}
+	m.refreshContent()
+}
+
+func (m MergeMessageOverlayModel) Update(msg tea.Msg) (MergeMessageOverlayModel, tea.Cmd) {
+	if msg, ok := msg.(tea.KeyMsg); ok {
+		switch msg.String() {
+		case "esc", "q":
+			m.Hide()
+			return m, func() tea.Msg { return MergeMessageClosedMsg{} }
+		case "c":
+			text := m.message.CommitMessage()
+			return m, func() tea.Msg { return CopyToClipboardMsg{Text: text, What: "commit message"} }
+		case "r":
+			if m.message.ReleaseNote == "" {
+				return m, nil
+			}
+			text := m.message.ReleaseNote
+			return m, func() tea.Msg { return CopyToClipboardMsg{Text: text, What: "release note"} }
+		case "s", "enter":
+			m.Hide()
+			accepted := m.message
+			return m, func() tea.Msg { return MergeMessageAcceptedMsg{Message: accepted} }
+		}
+		var cmd tea.Cmd
+		m.viewport, cmd = m.viewport.Update(msg)
+		return m, cmd
+	}
+	return m, nil
+}
+
+func (m *MergeMessageOverlayModel) refreshContent() {
+	if !m.ready || m.message == nil {
+		return
+	}
+	m.viewport.SetContent(renderMergeMessage(m.message, m.viewport.Width))
+}
+
+// renderMergeMessage renders the overlay body.
+func renderMergeMessage(msg *claude.MergeMessage, width int) string {
+	wrap := lipgloss.NewStyle().Width(max(1, width))
+	var b strings.Builder
+	b.WriteString(boldStyle.Render("Commit message") + "\n")
+	b.WriteString(wrap.Render(msg.Title) + "\n")
+	if msg.Body != "" {
+		b.WriteString("\n" + wrap.Render(msg.Body) + "\n")
+	}
+	b.WriteString("\n" + boldStyle.Render("Release note") + "\n")
+	if msg.ReleaseNote == "" {
+		b.WriteString(dimStyle.Render("None — internal change") + "\n")
+	} else {
+		b.WriteString(wrap.Render(msg.ReleaseNote) + "\n")
+	}
+	return b.String()
+}
+
+func (m MergeMessageOverlayModel) View() string {
+	if !m.visible {
+		return ""
+	}
+
+	overlayW, overlayH := m.overlayDimensions()
+	innerW := max(1, overlayW-4)
+
+	title := helpTitleStyle.Render(" Merge message ")
+	titleLine := lipgloss.PlaceHorizontal(innerW, lipgloss.Center, title)
+
+	var content string
+	if m.ready {
+		content = m.viewport.View()
+	}
+
+	footer := helpFooterStyle.Render("s use for squash merge · c copy message · r copy release note · Esc close")
+	footerLine := fitWidth(lipgloss.PlaceHorizontal(innerW, lipgloss.Center, footer), innerW)
+
+	box := lipgloss.JoinVertical(lipgloss.Left, titleLine, "", content, footerLine)
+
+	overlayStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(theme.Accent).
+		Padding(0, 1).
+		Width(overlayW - 2).
+		Height(overlayH - 2)
+
+	return placeOverlay(m.width, m.height, overlayStyle.Render(box))
+}
+
+// overlayDimensions returns the outer dimensions of the overlay.
+func (m MergeMessageOverlayModel) overlayDimensions() (width, height int) {
+	width = int(float64(m.width) * 0.6)
+	height = int(float64(m.height) * 0.6)
+	if width < 60 {
+		width = min(60, m.width)
+	}
+	if height < 20 {
+		height = min(20, m.height)
+	}
+	return width, height
+}
+
+// innerDimensions returns the viewport dimensions inside the overlay.
+func (m MergeMessageOverlayModel) innerDimensions() (width, height int) {
+	ow, oh := m.overlayDimensions()
+	// Subtract border (2), padding (2), title + blank (2), footer (1)
+	width = max(1, ow-4)
+	height = max(1, oh-5)
+	return width, height
+}
@@ -0,0 +1,56 @@
+package ui
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/shhac/prtea/internal/claude"
+	"github.com/shhac/prtea/internal/github"
+)
+
+func TestMergeMessage_AcceptUpdatesPendingSquashAutoMerge(t *testing.T) {
+	m := App{
+		statusBar:  NewStatusBarModel(),
+		diffViewer: newTestDiffViewer(80, 24),
+		session:    &PRSession{Owner: "acme", Repo: "widget", Number: 1},
+	}
+	m.diffViewer.SetAutoMerge(github.MergeMethodSquash)
+
+	msg := &claude.MergeMessage{Title: "Add retry", Body: "Uploads retry twice."}
+	cmd := m.acceptMergeMessage(msg)
+	if cmd == nil {
+		t.Fatal("a pending squash auto-merge should be re-enabled")
+	}
+	if req, ok := cmd().(AutoMergeRequestMsg); !ok || req.Method != github.MergeMethodSquash {
+		t.Errorf("cmd = %#v, want a squash auto-merge request", req)
+	}
+	if m.diffViewer.mergeMessage != "Add retry" {
+		t.Errorf("PR Info should show the squash message, got %q", m.diffViewer.mergeMessage)
+	}
+
+	commit := m.session.squashCommit(github.MergeMethodSquash)
+	if commit == nil || commit.Headline != "Add retry" || commit.Body != "Uploads retry twice." {
+		t.Errorf("squash commit = %+v", commit)
+	}
+	if m.session.squashCommit(github.MergeMethodRebase) != nil {
+		t.Error("the message only applies to squash merges")
+	}
+}
+
+func TestMergeMessageOverlay_Keys(t *testing.T) {
+	o := NewMergeMessageOverlayModel()
+	o.SetSize(100, 40)
+	o.Show(&claude.MergeMessage{Title: "Add retry", Body: "Uploads retry twice."})
+
+	_, cmd := o.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("c")})
+	if copyMsg, ok := cmd().(CopyToClipboardMsg); !ok || copyMsg.Text != "Add retry\n\nUploads retry twice." {
+		t.Errorf("c = %#v", copyMsg)
+	}
+	if _, cmd := o.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("r")}); cmd != nil {
+		t.Error("there is no release note to copy")
+	}
+	o, cmd = o.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("s")})
+	if _, ok := cmd().(MergeMessageAcceptedMsg); !ok || o.IsVisible() {
+		t.Error("s should accept the message and close the overlay")
+	}
+}
@@ -0,0 +1,342 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/shhac/prtea/internal/github"
+)
+
+// gateState is the outcome of a single merge-readiness gate.
+type gateState int
+
+const (
+	gateUnknown gateState = iota
+	gatePass
+	gateWarn
+	gateFail
+)
+
+// mergeGate is one row of the "Ready to merge?" section.
+type mergeGate struct {
+	label  string
+	state  gateState
+	detail string
+}
+
+// SetMergeState sets the PR's mergeability, merge state and behind-by count
+// from the PR detail.
+func (m *DiffViewerModel) SetMergeState(mergeable bool, state string, behindBy int) {
+	m.mergeable = mergeable
+	m.mergeState = state
+	m.behindBy = behindBy
+	m.prInfoCache = ""
+	m.refreshContent()
+}
+
+// SetAutoMerge sets the PR's enabled auto-merge method ("" when off).
+func (m *DiffViewerModel) SetAutoMerge(method string) {
+	m.autoMerge = method
+	m.prInfoCache = ""
+	m.refreshContent()
+}
+
+// SetMergeMessage sets the title of the accepted squash merge message.
+func (m *DiffViewerModel) SetMergeMessage(title string) {
+	m.mergeMessage = title
+	m.prInfoCache = ""
+	m.refreshContent()
+}
+
+// autoMergeMethodLabel returns the lowercase display name for an auto-merge
+// method, e.g. "SQUASH" → "squash".
+func autoMergeMethodLabel(method string) string {
+	return strings.ToLower(method)
+}
+
+// SetMergeRequirements sets the base branch protection rules for the PR Info tab.
+func (m *DiffViewerModel) SetMergeRequirements(req *github.MergeRequirements) {
+	m.mergeReq = req
+	m.mergeReqError = ""
+	m.prInfoCache = ""
+	m.refreshContent()
+}
+
+// SetMergeRequirementsError sets an error message for merge requirements loading.
+func (m *DiffViewerModel) SetMergeRequirementsError(err string) {
+	m.mergeReqError = err
+	m.prInfoCache = ""
+	m.refreshContent()
+}
+
+// SetBaseChangedFiles sets the files changed on base since the PR branched,
+// used to list likely conflict sources.
+func (m *DiffViewerModel) SetBaseChangedFiles(files []string) {
+	m.baseChangedFiles = files
+	m.prInfoCache = ""
+	m.refreshContent()
+}
+
+// hasConflicts reports whether GitHub considers the PR unmergeable. An
+// UNKNOWN state means GitHub is still computing mergeability.
+func hasConflicts(mergeable bool, state string) bool {
+	switch strings.ToUpper(state) {
+	case "DIRTY":
+		return true
+	case "", "UNKNOWN":
+		return false
+	default:
+		return !mergeable
+	}
+}
+
+// conflictingFiles returns PR files that were also changed on base since the
+// PR branched. GitHub doesn't expose the exact conflict list, so this is the
+// set of files touched on both sides.
+func conflictingFiles(prFiles []github.PRFile, baseChanged []string) []string {
+	onBase := make(map[string]bool, len(baseChanged))
+	for _, f := range baseChanged {
+		onBase[f] = true
+	}
+	var out []string
+	for _, f := range prFiles {
+		if onBase[f.Filename] {
+			out = append(out, f.Filename)
+		}
+	}
+	return out
+}
+
+// mergeStateLabel returns a display label and color for a PR's mergeStateStatus.
+func mergeStateLabel(state string) (string, lipgloss.Color) {
+	switch strings.ToUpper(state) {
+	case "CLEAN":
+		return "✓ Clean", theme.Success
+	case "HAS_HOOKS":
+		return "✓ Clean (hooks pending)", theme.Success
+	case "DIRTY":
+		return "✗ Merge conflicts", theme.Error
+	case "BLOCKED":
+		return "✗ Blocked", theme.Error
+	case "BEHIND":
+		return "● Behind base", theme.Warning
+	case "UNSTABLE":
+		return "● Unstable (checks failing)", theme.Warning
+	case "DRAFT":
+		return "○ Draft", theme.Muted
+	default:
+		return "? Checking mergeability", theme.Muted
+	}
+}
+
+// mergeGates evaluates each merge-readiness gate from the data loaded so far.
+// Any input may be nil while still loading; those gates report unknown.
+func mergeGates(req *github.MergeRequirements, ci *github.CIStatus, reviews *github.ReviewSummary, mergeable bool, mergeState string, behindBy int) []mergeGate {
+	return []mergeGate{
+		checksGate(req, ci),
+		approvalsGate(req, reviews),
+		threadsGate(req),
+		conflictsGate(mergeable, mergeState),
+		upToDateGate(req, behindBy),
+	}
+}
+
+// checksGate checks the branch's required status checks, or all checks when
+// no required checks are known.
+func checksGate(req *github.MergeRequirements, ci *github.CIStatus) mergeGate {
+	if ci == nil {
+		return mergeGate{label: "Checks", detail: "loading..."}
+	}
+	if req == nil || len(req.RequiredChecks) == 0 {
+		g := mergeGate{label: "Checks"}
+		switch {
+		case ci.TotalCount == 0:
+			g.state, g.detail = gatePass, "none configured"
+		case ci.OverallStatus == "passing":
+			g.state, g.detail = gatePass, fmt.Sprintf("all %d passing", ci.TotalCount)
+		case ci.OverallStatus == "pending":
+			g.state, g.detail = gateWarn, "in progress"
+		default:
+			g.state, g.detail = gateFail, fmt.Sprintf("%d/%d passing", ciPassingCount(ci.Checks), ci.TotalCount)
+		}
+		return g
+	}
+
+	byName := make(map[string]github.CICheck, len(ci.Checks))
+	for _, c := range ci.Checks {
+		byName[c.Name] = c
+	}
+	var failing, pending []string
+	for _, name := range req.RequiredChecks {
+		c, ok := byName[name]
+		switch {
+		case !ok || c.Status != "completed":
+			pending = append(pending, name)
+		case c.Conclusion != "success" && c.Conclusion != "skipped" && c.Conclusion != "neutral":
+			failing = append(failing, name)
+		}
+	}
+	g := mergeGate{label: "Required checks"}
+	switch {
+	case len(failing) > 0:
+		g.state, g.detail = gateFail, strings.Join(failing, ", ")+" failing"
+	case len(pending) > 0:
+		g.state, g.detail = gateWarn, strings.Join(pending, ", ")+" pending"
+	default:
+		g.state, g.detail = gatePass, fmt.Sprintf("%d/%d passing", len(req.RequiredChecks), len(req.RequiredChecks))
+	}
+	return g
+}
+
+// approvalsGate compares approvals given against the number required.
+// Without admin access the required count is unknown, so the review
+// decision is used instead.
+func approvalsGate(req *github.MergeRequirements, reviews *github.ReviewSummary) mergeGate {
+	g := mergeGate{label: "Approvals"}
+	if reviews == nil {
+		g.detail = "loading..."
+		return g
+	}
+	given := len(reviews.Approved)
+	if reviews.ReviewDecision == "CHANGES_REQUESTED" {
+		g.state, g.detail = gateFail, "changes requested"
+		return g
+	}
+	if req != nil && req.RequiredApprovals >= 0 {
+		g.detail = fmt.Sprintf("%d/%d", given, req.RequiredApprovals)
+		if given >= req.RequiredApprovals && reviews.ReviewDecision != "REVIEW_REQUIRED" {
+			g.state = gatePass
+		} else {
+			g.state = gateFail
+		}
+		return g
+	}
+	switch {
+	case reviews.ReviewDecision == "APPROVED":
+		g.state, g.detail = gatePass, fmt.Sprintf("%d given", given)
+	case reviews.ReviewDecision == "REVIEW_REQUIRED":
+		g.state, g.detail = gateFail, fmt.Sprintf("review required (%d given)", given)
+	case given > 0:
+		g.state, g.detail = gatePass, fmt.Sprintf("%d given", given)
+	default:
+		g.state, g.detail = gateWarn, "none yet"
+	}
+	return g
+}
+
+// threadsGate reports unresolved review threads.
+func threadsGate(req *github.MergeRequirements) mergeGate {
+	g := mergeGate{label: "Review threads"}
+	switch {
+	case req == nil:
+		g.detail = "loading..."
+	case req.UnresolvedThreads == 0:
+		g.state, g.detail = gatePass, "all resolved"
+	default:
+		g.state, g.detail = gateFail, fmt.Sprintf("%d unresolved", req.UnresolvedThreads)
+	}
+	return g
+}
+
+// conflictsGate reports merge conflicts from the PR's merge state.
+func conflictsGate(mergeable bool, mergeState string) mergeGate {
+	g := mergeGate{label: "Conflicts"}
+	switch {
+	case hasConflicts(mergeable, mergeState):
+		g.state, g.detail = gateFail, "merge conflicts with base"
+	case mergeState == "" || strings.EqualFold(mergeState, "UNKNOWN"):
+		g.detail = "checking..."
+	default:
+		g.state, g.detail = gatePass, "none"
+	}
+	return g
+}
+
+// upToDateGate reports how far the branch is behind base. Being behind only
+// blocks merging when the branch protection requires up-to-date branches.
+func upToDateGate(req *github.MergeRequirements, behindBy int) mergeGate {
+	g := mergeGate{label: "Up to date"}
+	switch {
+	case behindBy < 0:
+		g.detail = "unknown"
+	case behindBy == 0:
+		g.state, g.detail = gatePass, "up to date with base"
+	default:
+		g.state, g.detail = gateWarn, fmt.Sprintf("behind by %d commit(s)", behindBy)
+		if req != nil && req.StrictUpToDate {
+			g.state = gateFail
+		}
+	}
+	return g
+}
+
+// gateIconColor returns the icon and lipgloss color for a gate state.
+func gateIconColor(s gateState) (string, lipgloss.Color) {
+	switch s {
+	case gatePass:
+		return "✓", theme.Success
+	case gateFail:
+		return "✗", theme.Error
+	case gateWarn:
+		return "●", theme.Warning
+	default:
+		return "?", theme.Muted
+	}
+}
+
+// renderMergeReadiness renders the "Ready to merge?" section of the PR Info tab.
+func (m *DiffViewerModel) renderMergeReadiness() string {
+	gates := mergeGates(m.mergeReq, m.ciStatus, m.reviewSummary, m.mergeable, m.mergeState, m.behindBy)
+
+	verdict, verdictColor := "Ready", theme.Success
+	for _, g := range gates {
+		if g.state == gateFail {
+			verdict, verdictColor = "Blocked", theme.Error
+			break
+		}
+		if g.state != gatePass {
+			verdict, verdictColor = "Not yet", theme.Warning
+		}
+	}
+
+	var b strings.Builder
+	b.WriteString(sectionHeaderStyle.Render("Ready to merge?"))
+	b.WriteString(" ")
+	b.WriteString(lipgloss.NewStyle().Bold(true).Foreground(verdictColor).Render(verdict))
+	b.WriteString("\n")
+	for _, g := range gates {
+		icon, color := gateIconColor(g.state)
+		b.WriteString(fmt.Sprintf("  %s %s %s\n",
+			lipgloss.NewStyle().Foreground(color).Render(icon),
+			g.label,
+			dimStyle.Render(g.detail),
+		))
+	}
+	if m.mergeReqError != "" {
+		b.WriteString(errTextStyle.Render("  " + formatUserError(m.mergeReqError)))
+		b.WriteString("\n")
+	} else if m.mergeReq != nil && !m.mergeReq.ProtectionKnown {
+		b.WriteString(dimStyle.Render("  Branch protection unavailable"))
+		b.WriteString("\n")
+	}
+
+	if hasConflicts(m.mergeable, m.mergeState) {
+		b.WriteString("\n")
+		b.WriteString(sectionHeaderStyle.Render("Conflicting files"))
+		b.WriteString("\n")
+		if files := conflictingFiles(m.files, m.baseChangedFiles); len(files) > 0 {
+			for _, f := range files {
+				b.WriteString("  " + errTextStyle.Render("✗") + " " + f + "\n")
+			}
+			b.WriteString(dimStyle.Render("  Changed on both this branch and base; resolve locally and push"))
+		} else {
+			b.WriteString(dimStyle.Render("  Conflicts with base; resolve locally and push"))
+		}
+		b.WriteString("\n")
+	} else if m.behindBy > 0 {
+		b.WriteString(dimStyle.Italic(true).Render(fmt.Sprintf("  Press U to update branch (merge %d commit(s) from base)", m.behindBy)))
+		b.WriteString("\n")
+	}
+	return b.String()
+}
@@ -0,0 +1,117 @@
+package ui
+
+import (
+	"testing"
+
+	"github.com/shhac/prtea/internal/github"
+)
+
+func TestChecksGate_RequiredChecks(t *testing.T) {
+	ci := &github.CIStatus{TotalCount: 3, OverallStatus: "failing", Checks: []github.CICheck{
+		{Name: "build", Status: "completed", Conclusion: "success"},
+		{Name: "lint", Status: "completed", Conclusion: "failure"},
+		{Name: "e2e", Status: "in_progress"},
+	}}
+
+	tests := []struct {
+		name     string
+		required []string
+		want     gateState
+	}{
+		{"required passing despite optional failure", []string{"build"}, gatePass},
+		{"required pending", []string{"build", "e2e"}, gateWarn},
+		{"required not reported", []string{"deploy"}, gateWarn},
+		{"required failing", []string{"build", "lint", "e2e"}, gateFail},
+		{"no required checks falls back to overall", nil, gateFail},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := checksGate(&github.MergeRequirements{RequiredChecks: tt.required}, ci)
+			if g.state != tt.want {
+				t.Errorf("state = %v, want %v (detail %q)", g.state, tt.want, g.detail)
+			}
+		})
+	}
+}
+
+func TestApprovalsGate(t *testing.T) {
+	approved := []github.Review{{State: "APPROVED"}}
+	tests := []struct {
+		name     string
+		required int
+		reviews  *github.ReviewSummary
+		want     gateState
+	}{
+		{"loading", 1, nil, gateUnknown},
+		{"enough approvals", 1, &github.ReviewSummary{Approved: approved, ReviewDecision: "APPROVED"}, gatePass},
+		{"not enough approvals", 2, &github.ReviewSummary{Approved: approved, ReviewDecision: "REVIEW_REQUIRED"}, gateFail},
+		{"changes requested", 1, &github.ReviewSummary{Approved: approved, ReviewDecision: "CHANGES_REQUESTED"}, gateFail},
+		{"unknown requirement uses decision", -1, &github.ReviewSummary{ReviewDecision: "REVIEW_REQUIRED"}, gateFail},
+		{"unknown requirement no reviews", -1, &github.ReviewSummary{}, gateWarn},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := approvalsGate(&github.MergeRequirements{RequiredApprovals: tt.required}, tt.reviews)
+			if g.state != tt.want {
+				t.Errorf("state = %v, want %v (detail %q)", g.state, tt.want, g.detail)
+			}
+		})
+	}
+}
+
+func TestMergeGates_ConflictsThreadsAndBehind(t *testing.T) {
+	if g := conflictsGate(false, "DIRTY"); g.state != gateFail {
+		t.Errorf("DIRTY should fail, got %v", g.state)
+	}
+	if g := conflictsGate(true, "clean"); g.state != gatePass {
+		t.Errorf("clean should pass, got %v", g.state)
+	}
+	if g := conflictsGate(false, "UNKNOWN"); g.state != gateUnknown {
+		t.Errorf("UNKNOWN should be unknown, got %v", g.state)
+	}
+
+	if g := threadsGate(&github.MergeRequirements{UnresolvedThreads: 2}); g.state != gateFail || g.detail != "2 unresolved" {
+		t.Errorf("threadsGate = %+v", g)
+	}
+
+	if g := upToDateGate(&github.MergeRequirements{}, 3); g.state != gateWarn {
+		t.Errorf("behind without strict protection should warn, got %v", g.state)
+	}
+	if g := upToDateGate(&github.MergeRequirements{StrictUpToDate: true}, 3); g.state != gateFail {
+		t.Errorf("behind with strict protection should fail, got %v", g.state)
+	}
+	if g := upToDateGate(nil, -1); g.state != gateUnknown {
+		t.Errorf("unknown behind count should be unknown, got %v", g.state)
+	}
+}
+
+func TestHasConflicts(t *testing.T) {
+	tests := []struct {
+		mergeable bool
+		state     string
+		want      bool
+	}{
+		{false, "DIRTY", true},
+		{true, "CLEAN", false},
+		{false, "UNKNOWN", false}, // still computing
+		{false, "", false},
+		{false, "blocked", true},
+		{true, "BEHIND", false},
+	}
+	for _, tt := range tests {
+		if got := hasConflicts(tt.mergeable, tt.state); got != tt.want {
+			t.Errorf("hasConflicts(%v, %q) = %v, want %v", tt.mergeable, tt.state, got, tt.want)
+		}
+	}
+}
+
+func TestConflictingFiles(t *testing.T) {
+	prFiles := []github.PRFile{{Filename: "a.go"}, {Filename: "b.go"}, {Filename: "c.go"}}
+	got := conflictingFiles(prFiles, []string{"c.go", "README.md", "a.go"})
+	if len(got) != 2 || got[0] != "a.go" || got[1] != "c.go" {
+		t.Errorf("conflictingFiles() = %v, want [a.go c.go] in PR file order", got)
+	}
+	if got := conflictingFiles(prFiles, nil); len(got) != 0 {
+		t.Errorf("expected no conflicts without base changes, got %v", got)
+	}
+}
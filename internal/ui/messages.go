@@ -3,7 +3,9 @@ package ui
 import (
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/shhac/prtea/internal/claude"
+	"github.com/shhac/prtea/internal/config"
 	"github.com/shhac/prtea/internal/github"
+	"github.com/shhac/prtea/internal/rpc"
 )
 
 // -- GitHub client lifecycle --
@@ -24,6 +26,7 @@ type GHClientErrorMsg struct {
 type PRsLoadedMsg struct {
 	ToReview []github.PRItem
 	MyPRs    []github.PRItem
+	Batched  bool // review decisions and CI status are included in the items
 }
 
 // PRsErrorMsg is sent when PR fetching fails.
@@ -31,6 +34,16 @@ type PRsErrorMsg struct {
 	Err error
 }
 
+// ClosedPRsRequestMsg is emitted when the PR list's "Recently closed"
+// filter is shown before its PRs have been fetched.
+type ClosedPRsRequestMsg struct{}
+
+// ClosedPRsLoadedMsg delivers the user's recently closed, unmerged PRs.
+type ClosedPRsLoadedMsg struct {
+	PRs []github.PRItem
+	Err error
+}
+
 // PRReviewDecisionsMsg delivers review decisions fetched asynchronously after PR list load.
 type PRReviewDecisionsMsg struct {
 	Decisions map[string]string // key: "owner/repo#number", value: review decision
@@ -70,6 +83,179 @@ type PRDetailLoadedMsg struct {
 	Err      error
 }
 
+// BaseChangedFilesLoadedMsg is sent when the files changed on base since a conflicting PR branched have been fetched.
+type BaseChangedFilesLoadedMsg struct {
+	PRNumber int
+	Files    []string
+	Err      error
+}
+
+// StackLoadedMsg delivers the stack of open PRs a PR belongs to. Stack is
+// nil when the PR is not stacked.
+type StackLoadedMsg struct {
+	PRNumber int
+	Stack    []github.StackMember
+	Err      error
+}
+
+// StackDiffLoadedMsg delivers the combined diff of a stack, from the base of
+// its root ("Base") to the PR's head branch.
+type StackDiffLoadedMsg struct {
+	PRNumber int
+	Base     string
+	Head     string
+	Files    []github.PRFile
+	Err      error
+}
+
+// SinceDiffLoadedMsg delivers what changed on a PR's head ("Head") since an
+// earlier head the user reviewed or viewed ("Base").
+type SinceDiffLoadedMsg struct {
+	PRNumber int
+	Base     string
+	Head     string
+	Files    []github.PRFile
+	Err      error
+}
+
+// HeadChangedMsg reports how a PR's head moved between two refreshes.
+type HeadChangedMsg struct {
+	PRNumber int
+	Old      string
+	New      string
+	Status   string // compare status of New against Old; "diverged" or "behind" after a force-push
+	Err      error
+}
+
+// DeploymentsLoadedMsg delivers the deployments of a PR's head commit.
+type DeploymentsLoadedMsg struct {
+	PRNumber    int
+	Deployments []github.Deployment
+	Err         error
+}
+
+// SecurityAlertsLoadedMsg delivers the security alerts a PR introduces.
+type SecurityAlertsLoadedMsg struct {
+	PRNumber int
+	Alerts   []github.SecurityAlert
+	Err      error
+}
+
+// CoverageLoadedMsg delivers the coverage report found among a PR's CI
+// artifacts; Report is nil when no artifact matched.
+type CoverageLoadedMsg struct {
+	PRNumber int
+	Report   *github.CoverageReport
+	Err      error
+}
+
+// OpenPreviewRequestMsg is emitted when the user asks to open the PR's
+// preview deployment (P on the PR Info tab).
+type OpenPreviewRequestMsg struct{}
+
+// UpdateBranchRequestMsg is emitted when the user asks to merge base into the PR branch (U or :update branch).
+type UpdateBranchRequestMsg struct{}
+
+// UpdateBranchDoneMsg is sent when an update-branch request completes.
+type UpdateBranchDoneMsg struct {
+	PRNumber int
+	Err      error
+}
+
+// TaskToggleRequestMsg is emitted when the user toggles a task list item of
+// the PR description (Space on the PR Info tab).
+type TaskToggleRequestMsg struct {
+	Index   int    // position in the body's task list
+	Text    string // the task's text, to detect concurrent edits
+	Checked bool   // the new state
+}
+
+// TaskToggleDoneMsg is sent when a task toggle has been saved, or has failed.
+// Previous is the description shown before the toggle, restored on failure.
+type TaskToggleDoneMsg struct {
+	PRNumber int
+	Body     string
+	Previous string
+	Err      error
+}
+
+// GuidedReviewStepMsg is emitted when the guided review lands on a file.
+type GuidedReviewStepMsg struct {
+	Step   int // 1-based
+	Total  int
+	File   string
+	Reason string
+}
+
+// FocusStepMsg is emitted when focus mode shows another file, or when the
+// last unviewed file is marked viewed (AllViewed).
+type FocusStepMsg struct {
+	Index     int // 1-based
+	Total     int
+	File      string
+	AllViewed bool
+}
+
+// AutoMergeRequestMsg is emitted when the user enables (Method set) or
+// disables (Method "") auto-merge via the command palette.
+type AutoMergeRequestMsg struct {
+	Method string // github.MergeMethod* or "" to disable
+}
+
+// DraftStateDoneMsg is sent when converting a PR to a draft (Draft true) or
+// marking it ready for review completes.
+type DraftStateDoneMsg struct {
+	Owner    string
+	Repo     string
+	PRNumber int
+	Draft    bool
+	Err      error
+}
+
+// AutoMergeDoneMsg is sent when an auto-merge enable/disable request completes.
+type AutoMergeDoneMsg struct {
+	Owner    string
+	Repo     string
+	PRNumber int
+	Method   string
+	Err      error
+}
+
+// branchUpdateRefreshMsg fires shortly after an update-branch request to reload the PR.
+type branchUpdateRefreshMsg struct {
+	PRNumber int
+}
+
+// CheckAnnotationsLoadedMsg is sent when annotations for failing checks
+// have been fetched, keyed by job ID.
+type CheckAnnotationsLoadedMsg struct {
+	PRNumber    int
+	Annotations map[int64][]github.CheckAnnotation
+}
+
+// QuickfixClosedMsg is sent when the quickfix overlay closes. Pos is the
+// 1-based item to jump to, or 0 when dismissed.
+type QuickfixClosedMsg struct {
+	Items []quickfixItem
+	Pos   int
+}
+
+// MergeRequirementsLoadedMsg is sent when branch protection and review thread data has been fetched.
+type MergeRequirementsLoadedMsg struct {
+	PRNumber     int
+	Requirements *github.MergeRequirements
+	Err          error
+}
+
+// CodeOwnersLoadedMsg is sent when the base branch's CODEOWNERS rules and
+// the user's teams have been fetched. Rules is nil when there is no file.
+type CodeOwnersLoadedMsg struct {
+	PRNumber int
+	Rules    []github.CodeOwnerRule
+	Teams    []string
+	Err      error
+}
+
 // -- Comments --
 
 // CommentsLoadedMsg is sent when PR comments have been fetched.
@@ -113,6 +299,46 @@ type CIRerunErrMsg struct {
 	Err      error
 }
 
+// CIRerunCheckRequestMsg is emitted when the user re-runs the selected CI check (X on the CI tab).
+type CIRerunCheckRequestMsg struct {
+	Check github.CICheck
+}
+
+// CIRerunCheckDoneMsg is sent when a single-job re-run has been accepted.
+type CIRerunCheckDoneMsg struct {
+	PRNumber int
+	Check    github.CICheck
+}
+
+// ciWatchTickMsg fires periodically while a re-run check is being watched.
+type ciWatchTickMsg struct {
+	PRNumber int
+	Seq      int
+}
+
+// ciLiveTickMsg fires periodically while the selected PR's pending checks
+// are polled for live updates.
+type ciLiveTickMsg struct {
+	PRNumber int
+	Seq      int
+}
+
+// CILogsRequestMsg is emitted when the user asks to view a CI check's job logs.
+type CILogsRequestMsg struct {
+	Check github.CICheck
+}
+
+// CILogsLoadedMsg is sent when a CI job's logs have been fetched.
+type CILogsLoadedMsg struct {
+	PRNumber int
+	JobID    int64
+	Logs     string
+	Err      error
+}
+
+// LogViewerClosedMsg is sent when the log viewer overlay is dismissed.
+type LogViewerClosedMsg struct{}
+
 // -- Claude analysis --
 
 // AnalysisCompleteMsg is sent when Claude analysis finishes successfully.
@@ -120,6 +346,9 @@ type AnalysisCompleteMsg struct {
 	PRNumber int
 	DiffHash string
 	Result   *claude.AnalysisResult
+	Input    claude.AnalyzeDiffInput // exact input sent, kept for :analysis rerun
+	Inputs   *claude.AnalysisInputs
+	Rerun    bool
 }
 
 // AnalysisErrorMsg is sent when Claude analysis fails.
@@ -128,6 +357,45 @@ type AnalysisErrorMsg struct {
 	Err      error
 }
 
+// PRSummaryCompleteMsg is sent when a PR summary has been generated.
+type PRSummaryCompleteMsg struct {
+	Owner    string
+	Repo     string
+	PRNumber int
+	DiffHash string
+	Summary  *claude.PRSummary
+}
+
+// PRSummaryErrorMsg is sent when generating a PR summary fails.
+type PRSummaryErrorMsg struct {
+	PRNumber int
+	Err      error
+}
+
+// PRTriagedMsg is sent when the background triage of a PR in the To Review
+// list finishes. FetchErr is set when its diff couldn't be fetched, Err when
+// the AI call failed.
+type PRTriagedMsg struct {
+	Owner    string
+	Repo     string
+	Number   int
+	Triage   *claude.PRTriage
+	FetchErr error
+	Err      error
+}
+
+// MergeMessageCompleteMsg is sent when a merge message has been drafted.
+type MergeMessageCompleteMsg struct {
+	PRNumber int
+	Message  *claude.MergeMessage
+}
+
+// MergeMessageErrorMsg is sent when drafting a merge message fails.
+type MergeMessageErrorMsg struct {
+	PRNumber int
+	Err      error
+}
+
 // -- PR actions --
 
 // PRApproveDoneMsg is sent when PR approval succeeds.
@@ -141,6 +409,15 @@ type PRApproveErrMsg struct {
 	Err      error
 }
 
+// PRStateRequestMsg is sent once the user confirms closing (or, with
+// Reopen, reopening) a PR.
+type PRStateRequestMsg struct {
+	Owner  string
+	Repo   string
+	Number int
+	Reopen bool
+}
+
 // PRCloseDoneMsg is sent when PR close succeeds.
 type PRCloseDoneMsg struct {
 	PRNumber int
@@ -152,6 +429,17 @@ type PRCloseErrMsg struct {
 	Err      error
 }
 
+// PRReopenDoneMsg is sent when PR reopen succeeds.
+type PRReopenDoneMsg struct {
+	PRNumber int
+}
+
+// PRReopenErrMsg is sent when PR reopen fails.
+type PRReopenErrMsg struct {
+	PRNumber int
+	Err      error
+}
+
 // -- Review submission --
 
 // ReviewAction represents the type of PR review to submit.
@@ -182,6 +470,15 @@ type ReviewSubmitErrMsg struct {
 	Err      error
 }
 
+// CISummaryRequestMsg asks for a CI failure summary to be added to the review body.
+type CISummaryRequestMsg struct{}
+
+// CISummaryReadyMsg carries the formatted CI failure summary.
+type CISummaryReadyMsg struct {
+	PRNumber int
+	Body     string
+}
+
 // ReviewValidationMsg is emitted by the review tab when validation fails
 // (e.g. empty body for Request Changes or Comment).
 type ReviewValidationMsg struct {
@@ -194,6 +491,7 @@ type ReviewValidationMsg struct {
 type AIReviewCompleteMsg struct {
 	PRNumber int
 	Result   *claude.ReviewAnalysis
+	Scope    map[string]bool // commentKeys the review was limited to; nil for the whole PR
 }
 
 // AIReviewErrorMsg is sent when AI review generation fails.
@@ -228,6 +526,12 @@ type ChatStreamChunkMsg struct {
 	Content string
 }
 
+// ChatToolUseMsg reports a tool Claude used in the local clone while
+// answering, e.g. "Grep \"parseConfig\"".
+type ChatToolUseMsg struct {
+	Line string
+}
+
 // CommentPostMsg is emitted when the user wants to post a PR comment.
 type CommentPostMsg struct {
 	Body string
@@ -238,6 +542,22 @@ type CommentPostedMsg struct {
 	Err error
 }
 
+// AnalysisPostMsg posts the analysis as a PR comment once confirmed
+// (:analysis post).
+type AnalysisPostMsg struct {
+	Owner  string
+	Repo   string
+	Number int
+	Body   string
+}
+
+// AnalysisPostedMsg is sent after the analysis comment has been posted
+// (or failed).
+type AnalysisPostedMsg struct {
+	PRNumber int
+	Err      error
+}
+
 // -- Navigation --
 
 // HunkSelectedAndAdvanceMsg is sent when ENTER selects a hunk and should advance focus to the chat panel.
@@ -257,6 +577,7 @@ type StatusBarClearMsg struct {
 // CommandExecuteMsg is sent when a command should be executed.
 type CommandExecuteMsg struct {
 	Name string
+	Args string // text after the command name, for commands that take arguments
 }
 
 // CommandModeExitMsg is sent when command mode is dismissed without executing.
@@ -269,8 +590,11 @@ type CommandNotFoundMsg struct {
 
 // -- Settings --
 
-// ConfigChangedMsg is sent when the user changes settings in the settings panel.
-type ConfigChangedMsg struct{}
+// ConfigChangedMsg is sent when the user changes settings in the settings
+// panel, or with Reloaded set when the config file was edited on disk.
+type ConfigChangedMsg struct {
+	Reloaded *config.Config
+}
 
 // SettingsClosedMsg is sent when the settings overlay is dismissed.
 type SettingsClosedMsg struct{}
@@ -285,6 +609,19 @@ type pollTickMsg struct{}
 type pollPRsLoadedMsg struct {
 	ToReview []github.PRItem
 	MyPRs    []github.PRItem
+	Batched  bool
+	Delta    bool // only the PRs updated since the last poll
+}
+
+// ciRollupsMsg delivers overall CI status for the user's own PRs, used to
+// notify when checks finish.
+type ciRollupsMsg struct {
+	Rollups map[string]string // key: "owner/repo#number", value: overall status
+}
+
+// webhookErrMsg is sent when posting to the outbound webhook fails.
+type webhookErrMsg struct {
+	Err error
 }
 
 // pollErrorMsg is sent when background polling fails, so transient issues
@@ -295,6 +632,11 @@ type pollErrorMsg struct {
 
 // -- Inline comment authoring --
 
+// FileScopeToggleMsg marks a file in or out of scope for review submission.
+type FileScopeToggleMsg struct {
+	Path string
+}
+
 // InlineCommentAddMsg is emitted by the diff viewer when the user saves an inline comment.
 type InlineCommentAddMsg struct {
 	Path      string
@@ -308,6 +650,8 @@ type InlineCommentAddMsg struct {
 type PendingInlineComment struct {
 	claude.InlineReviewComment
 	Source string // "ai" or "user"
+	Held   bool   // left out of the next review, kept as a draft
+	Stale  bool   // its line was gone after the head changed; held until deleted
 }
 
 // -- Comment overlay --
@@ -322,6 +666,36 @@ type ShowCommentOverlayMsg struct {
 	GHThreads       []ghCommentThread
 	AIComments      []claude.InlineReviewComment
 	PendingComments []PendingInlineComment
+
+	// SuggestionBase holds the current content of the lines each thread's
+	// suggestion would replace, keyed by root comment ID.
+	SuggestionBase map[int64][]string
+	// CanApplySuggestions is set by the app for the user's own PRs.
+	CanApplySuggestions bool
+	// Position is the line's 1-based place among the diff's commented
+	// lines, of Total; 0 when it has no comments yet.
+	Position, Total int
+}
+
+// CommentOverlayStepMsg moves the open comment overlay to the next (Delta
+// 1) or previous (-1) commented line.
+type CommentOverlayStepMsg struct {
+	Delta int
+}
+
+// DraftReplyMsg asks for an AI draft of a reply to a review thread.
+type DraftReplyMsg struct {
+	CommentID int64  // root comment of the thread being answered
+	Thread    string // the thread so far, one comment per paragraph
+	Diff      string // the diff around the comment
+}
+
+// ReplyDraftedMsg carries Claude's draft of a thread reply.
+type ReplyDraftedMsg struct {
+	PRNumber  int
+	CommentID int64
+	Text      string
+	Err       error
 }
 
 // CommentOverlayClosedMsg signals the comment overlay was dismissed.
@@ -334,8 +708,27 @@ type InlineCommentReplyMsg struct {
 }
 
 // InlineCommentReplyDoneMsg signals the reply was posted (or failed).
+// ReplyID is the temporary ID of the reply shown while it posted.
 type InlineCommentReplyDoneMsg struct {
-	Err error
+	ReplyID int64
+	Err     error
+}
+
+// ApplySuggestionMsg commits a review comment's suggestion to the PR branch,
+// replacing lines StartLine..Line of Path with Lines. Original is what
+// those lines held in the loaded diff.
+type ApplySuggestionMsg struct {
+	Path      string
+	StartLine int
+	Line      int
+	Original  []string
+	Lines     []string
+}
+
+// SuggestionAppliedMsg signals the suggestion was committed (or failed).
+type SuggestionAppliedMsg struct {
+	Path string
+	Err  error
 }
 
 // -- Internal streaming --
@@ -343,6 +736,59 @@ type InlineCommentReplyDoneMsg struct {
 // chatStreamChan carries streaming chunks and the final response from Claude chat.
 type chatStreamChan chan tea.Msg
 
+// RunOutputMsg carries a line of output from a local run.
+type RunOutputMsg struct {
+	ID   int
+	Line string
+}
+
+// RunDoneMsg is sent when a local run finishes, fails to start, or is stopped.
+type RunDoneMsg struct {
+	ID       int
+	ExitCode int
+	Err      error
+}
+
+// BatchStartMsg starts a confirmed batch action on marked PRs.
+type BatchStartMsg struct {
+	Action string // "approve"
+	PRs    []PRItem
+}
+
+// BatchItemDoneMsg reports a batch action's result for one PR. Detail is set
+// by a refresh.
+type BatchItemDoneMsg struct {
+	BatchID int
+	Owner   string
+	Repo    string
+	Number  int
+	Detail  *github.PRDetail
+	Err     error
+}
+
+// QuitConfirmedMsg is sent when quitting with unsubmitted drafts is
+// confirmed; SaveDraft keeps them for :recover.
+type QuitConfirmedMsg struct {
+	SaveDraft bool
+}
+
+// ScratchLoadedMsg carries a PR's text left unsent by an earlier session,
+// nil if there is none.
+type ScratchLoadedMsg struct {
+	Owner    string
+	Repo     string
+	PRNumber int
+	File     *scratchFile
+}
+
+// WorktreesMsg reports the temporary worktrees of the local clones, counted
+// by :worktree or removed by :worktree prune.
+type WorktreesMsg struct {
+	Count  int
+	Pruned bool
+	Err    error
+}
+
 // analysisStreamChan carries streaming chunks and the final result from Claude analysis.
 type analysisStreamChan chan tea.Msg
 
@@ -350,3 +796,179 @@ type analysisStreamChan chan tea.Msg
 type AnalysisStreamChunkMsg struct {
 	Content string
 }
+
+// -- Quick hunk questions --
+
+// CitationJumpMsg is emitted when a file:line link in chat or analysis
+// output is opened.
+type CitationJumpMsg struct {
+	Path string
+	Line int
+}
+
+// HunkQuestionMsg is emitted when the user asks a one-line question about the focused hunk.
+type HunkQuestionMsg struct {
+	Target   string // "path @@ header", shown in the popup
+	Hunk     string // the hunk as a unified diff
+	Question string
+}
+
+// ExplainHunkMsg is emitted when the user asks Claude to explain the focused hunk.
+type ExplainHunkMsg struct {
+	Key         string // identifies the hunk's explanation box
+	Hunk        string // the hunk as a unified diff
+	FileContext string // the file and its other hunk headers
+}
+
+// HunkExplainedMsg carries Claude's explanation of a hunk.
+type HunkExplainedMsg struct {
+	PRNumber int
+	Key      string
+	Text     string
+	Err      error
+}
+
+// QuickAnswerChunkMsg carries a streamed chunk of a quick answer.
+type QuickAnswerChunkMsg struct {
+	Content string
+}
+
+// QuickAnswerDoneMsg is sent when a quick answer completes.
+type QuickAnswerDoneMsg struct {
+	Content string
+	Err     error
+}
+
+// QuickAnswerClosedMsg is sent when the quick answer popup is dismissed.
+type QuickAnswerClosedMsg struct{}
+
+// UsageClosedMsg is sent when the :usage overlay is dismissed.
+type UsageClosedMsg struct{}
+
+// PromptsClosedMsg is sent when the :prompts overlay is dismissed.
+type PromptsClosedMsg struct{}
+
+// PromptEditedMsg is sent when the editor opened on a custom prompt exits.
+type PromptEditedMsg struct {
+	Path string
+	Err  error
+}
+
+// GlobalSearchClosedMsg is sent when the Ctrl+F search overlay is
+// dismissed. Hit is the result to open, or nil.
+type GlobalSearchClosedMsg struct {
+	Hit *globalSearchHit
+}
+
+// WorkloadLoadedMsg delivers the open review requests for the :workload
+// overlay.
+type WorkloadLoadedMsg struct {
+	PRs []github.WorkloadPR
+	Err error
+}
+
+// WorkloadClosedMsg is sent when the :workload overlay is dismissed. Open is
+// the PR to open, or nil.
+type WorkloadClosedMsg struct {
+	Open *PRSelectedMsg
+}
+
+// LinksClosedMsg is sent when the follow-link overlay is dismissed. Open is
+// the URL to open in the browser, or "".
+type LinksClosedMsg struct {
+	Open string
+}
+
+// ConfirmClosedMsg is sent when the confirmation overlay is dismissed.
+// Action is the confirmed action's message, or nil if cancelled.
+type ConfirmClosedMsg struct {
+	Action tea.Msg
+}
+
+// PendingCommentsClosedMsg is sent when the :pending overlay is dismissed.
+// Jump is the comment to open in the diff, or nil.
+type PendingCommentsClosedMsg struct {
+	Jump *PendingInlineComment
+}
+
+// PendingCommentsChangedMsg carries the pending comments after they were
+// edited, deleted or reordered in the :pending overlay.
+type PendingCommentsChangedMsg struct {
+	Comments []PendingInlineComment
+	Note     string // status message, "" for none
+}
+
+// MergeMessageClosedMsg is sent when the merge message overlay is dismissed.
+type MergeMessageClosedMsg struct{}
+
+// MergeMessageAcceptedMsg is sent when a drafted merge message is chosen
+// for squash auto-merge.
+type MergeMessageAcceptedMsg struct {
+	Message *claude.MergeMessage
+}
+
+// ExportDoneMsg is sent when an :export file has been written.
+type ExportDoneMsg struct {
+	Path string
+	What string // e.g. "analysis"
+	Err  error
+}
+
+// CopyToClipboardMsg asks the app to copy text to the system clipboard.
+type CopyToClipboardMsg struct {
+	Text string
+	What string // shown in the confirmation, e.g. "commit message"
+}
+
+// ShowHunkOrderMsg opens the overlay for reordering the selected hunks.
+type ShowHunkOrderMsg struct {
+	Items   []HunkOrderItem
+	Primary int // hunk index of the primary focus, -1 for none
+}
+
+// HunkOrderClosedMsg is sent when the hunk order overlay is dismissed.
+// Order and Primary are only meaningful when Applied is true.
+type HunkOrderClosedMsg struct {
+	Applied bool
+	Order   []int
+	Primary int
+}
+
+// ShowChatContextMsg opens the panel showing what chat sends Claude.
+type ShowChatContextMsg struct {
+	Items  []ChatContextItem
+	Budget int // max prompt tokens
+}
+
+// ChatContextClosedMsg is sent when the chat context panel is dismissed.
+// Items is only meaningful when Applied is true.
+type ChatContextClosedMsg struct {
+	Applied bool
+	Items   []ChatContextItem
+}
+
+// ShowCommentTriageMsg opens the triage view of the AI review's comments.
+type ShowCommentTriageMsg struct {
+	Items []CommentTriageItem
+}
+
+// CommentTriageClosedMsg is sent when the triage view is dismissed. Items
+// is only meaningful when Applied is true.
+type CommentTriageClosedMsg struct {
+	Applied bool
+	Items   []CommentTriageItem
+}
+
+// ChatCILogsMsg carries failing check log excerpts fetched for chat.
+type ChatCILogsMsg struct {
+	PRNumber int
+	Logs     string
+}
+
+// -- RPC socket --
+
+// rpcQueryMsg asks the App for a state snapshot on behalf of the RPC socket.
+// The reply channel must be buffered so the App never blocks on it.
+type rpcQueryMsg struct {
+	reply chan *rpc.Snapshot
+}
@@ -0,0 +1,96 @@
+package ui
+
+import (
+	"strings"
+)
+
+// minimapWidth is the width of the diff minimap: a column each for the
+// density of added and removed lines, one for comment markers and the
+// scrollbar track.
+const minimapWidth = 4
+
+// minimapShades draws line density from none to every line of a row.
+var minimapShades = []string{" ", "░", "▒", "▓", "█"}
+
+// gutterWidth returns the width of the column right of the viewport.
+func (m DiffViewerModel) gutterWidth() int {
+	if m.minimap {
+		return minimapWidth
+	}
+	return 1
+}
+
+// SetMinimap widens the scrollbar into the minimap, or back.
+func (m *DiffViewerModel) SetMinimap(on bool) {
+	if m.minimap == on {
+		return
+	}
+	m.minimap = on
+	if m.ready {
+		m.SetSize(m.width, m.height)
+	}
+}
+
+// minimapDensity counts, per scrollbar row, the added and removed lines
+// and all lines drawn at that row.
+func (m DiffViewerModel) minimapDensity() (adds, removes, lines []int) {
+	height := m.viewport.Height
+	adds, removes, lines = make([]int, height), make([]int, height), make([]int, height)
+	if m.activeTab != TabDiff || m.viewport.TotalLineCount() <= 0 {
+		return adds, removes, lines
+	}
+	for i, info := range m.cachedLineInfo {
+		row := m.scrollbarRow(i)
+		lines[row]++
+		switch info.change {
+		case '+':
+			adds[row]++
+		case '-':
+			removes[row]++
+		}
+	}
+	return adds, removes, lines
+}
+
+// minimapShade picks the shade for n of total lines, never blank when n > 0.
+func minimapShade(n, total int) string {
+	if n == 0 || total == 0 {
+		return minimapShades[0]
+	}
+	level := (n*(len(minimapShades)-1) + total - 1) / total
+	return minimapShades[max(1, min(level, len(minimapShades)-1))]
+}
+
+// renderMinimap builds the minimap column: per row, how much of the diff
+// drawn there was added and removed, where comments are, and the scrollbar
+// thumb over the visible portion.
+func (m DiffViewerModel) renderMinimap() string {
+	height := m.viewport.Height
+	if m.viewport.TotalLineCount() <= 0 || height <= 0 {
+		pad := strings.Repeat(" ", minimapWidth)
+		return strings.Repeat(pad+"\n", max(0, height-1)) + pad
+	}
+
+	thumbStart, thumbSize := m.scrollbarThumb()
+	markers := m.scrollbarMarkers()
+	adds, removes, lines := m.minimapDensity()
+
+	rows := make([]string, height)
+	for i := range rows {
+		var b strings.Builder
+		b.WriteString(minimapAddStyle.Render(minimapShade(adds[i], lines[i])))
+		b.WriteString(minimapRemoveStyle.Render(minimapShade(removes[i], lines[i])))
+		if markers[i] != commentNone {
+			b.WriteString(scrollbarCommentStyle(markers[i]).Render("●"))
+		} else {
+			b.WriteString(" ")
+		}
+		if i >= thumbStart && i < thumbStart+thumbSize {
+			b.WriteString(scrollbarThumbStyle.Render("┃"))
+		} else {
+			b.WriteString(scrollbarTrackStyle.Render("│"))
+		}
+		rows[i] = b.String()
+	}
+	return strings.Join(rows, "\n")
+}
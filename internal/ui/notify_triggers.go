@@ -0,0 +1,228 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/shhac/prtea/internal/config"
+	"github.com/shhac/prtea/internal/github"
+	"github.com/shhac/prtea/internal/notify"
+)
+
+// notification is a single desktop notification produced by a trigger.
+type notification struct {
+	title string
+	body  string
+}
+
+// activityTracker remembers what the PR lists looked like at the last fetch
+// so that background polls can notify on changes. The first observation of
+// each kind only records a baseline.
+type activityTracker struct {
+	seeded          bool
+	toReview        map[string]bool          // To Review PR keys at the last fetch
+	everToReview    map[string]bool          // every PR key ever seen in To Review
+	comments        map[string]int           // To Review PR key → comment count
+	myPRs           map[string]github.PRItem // my PR key → PR
+	decisionsSeeded bool
+	decisions       map[string]string // my PR key → review decision
+	ci              map[string]string // my PR key → overall CI status
+}
+
+// observePRs records a PR list fetch and returns notifications for new
+// comments on PRs I'm reviewing and for review re-requests.
+func (t *activityTracker) observePRs(toReview, myPRs []github.PRItem, cfg *config.Config) []notification {
+	var notes []notification
+	current := make(map[string]bool, len(toReview))
+	comments := make(map[string]int, len(toReview))
+	if t.everToReview == nil {
+		t.everToReview = make(map[string]bool)
+	}
+
+	for _, pr := range toReview {
+		key := prKey(pr.Repo.Owner, pr.Repo.Name, pr.Number)
+		current[key] = true
+		comments[key] = pr.CommentsCount
+		if !t.seeded {
+			continue
+		}
+		if t.everToReview[key] && !t.toReview[key] && cfg.NotifyTriggerEnabled(config.NotifyReReview) {
+			notes = append(notes, notification{
+				title: "prtea: Review requested again",
+				body:  fmt.Sprintf("#%d %s in %s", pr.Number, pr.Title, pr.Repo.Name),
+			})
+		}
+		if prev, ok := t.comments[key]; ok && pr.CommentsCount > prev && cfg.NotifyTriggerEnabled(config.NotifyComments) {
+			noun := "comments"
+			if pr.CommentsCount-prev == 1 {
+				noun = "comment"
+			}
+			notes = append(notes, notification{
+				title: "prtea: New comments",
+				body:  fmt.Sprintf("%d new %s on #%d %s in %s", pr.CommentsCount-prev, noun, pr.Number, pr.Title, pr.Repo.Name),
+			})
+		}
+	}
+	for key := range current {
+		t.everToReview[key] = true
+	}
+	t.toReview = current
+	t.comments = comments
+
+	t.myPRs = make(map[string]github.PRItem, len(myPRs))
+	for _, pr := range myPRs {
+		t.myPRs[prKey(pr.Repo.Owner, pr.Repo.Name, pr.Number)] = pr
+	}
+	t.seeded = true
+	return notes
+}
+
+// observeDecisions records review decisions and returns notifications for my
+// PRs that were just approved or had changes requested.
+func (t *activityTracker) observeDecisions(decisions map[string]string, cfg *config.Config) []notification {
+	var notes []notification
+	next := make(map[string]string)
+	for key, pr := range t.myPRs {
+		cur := decisions[key]
+		next[key] = cur
+		if !t.decisionsSeeded || cur == t.decisions[key] || !cfg.NotifyTriggerEnabled(config.NotifyReviewOutcome) {
+			continue
+		}
+		switch cur {
+		case "APPROVED":
+			notes = append(notes, notification{
+				title: "prtea: PR approved",
+				body:  fmt.Sprintf("#%d %s in %s", pr.Number, pr.Title, pr.Repo.Name),
+			})
+		case "CHANGES_REQUESTED":
+			notes = append(notes, notification{
+				title: "prtea: Changes requested",
+				body:  fmt.Sprintf("#%d %s in %s", pr.Number, pr.Title, pr.Repo.Name),
+			})
+		}
+	}
+	t.decisions = next
+	t.decisionsSeeded = true
+	return notes
+}
+
+// observeCI records CI rollups for my PRs and returns notifications for
+// those whose checks were pending and have now finished, plus the PRs whose
+// finished run failed (for the outbound webhook).
+func (t *activityTracker) observeCI(rollups map[string]string, cfg *config.Config) (notes []notification, failed []github.PRItem) {
+	for key, status := range rollups {
+		pr, mine := t.myPRs[key]
+		if !mine || t.ci[key] != "pending" || status == "pending" {
+			continue
+		}
+		if cfg.NotifyTriggerEnabled(config.NotifyCIFinished) {
+			notes = append(notes, notification{
+				title: "prtea: CI " + status,
+				body:  fmt.Sprintf("#%d %s in %s", pr.Number, pr.Title, pr.Repo.Name),
+			})
+		}
+		if status == "failing" || status == "mixed" {
+			failed = append(failed, pr)
+		}
+	}
+	t.ci = rollups
+	return notes, failed
+}
+
+// prListFollowUps returns the commands that complete a PR list fetch. A
+// batched fetch already carries review decisions and CI status, so they are
+// observed directly; otherwise they are fetched per repo in the background.
+func (m *App) prListFollowUps(toReview, myPRs []github.PRItem, batched bool) []tea.Cmd {
+	var cmds []tea.Cmd
+	if batched {
+		decisions := make(map[string]string)
+		for _, pr := range append(append([]github.PRItem(nil), toReview...), myPRs...) {
+			if pr.ReviewDecision != "" {
+				decisions[prKey(pr.Repo.Owner, pr.Repo.Name, pr.Number)] = pr.ReviewDecision
+			}
+		}
+		rollups := make(map[string]string)
+		for _, pr := range myPRs {
+			if pr.CIStatus != "" {
+				rollups[prKey(pr.Repo.Owner, pr.Repo.Name, pr.Number)] = pr.CIStatus
+			}
+		}
+		return append(cmds, m.observeDecisions(decisions), m.observeCIRollups(rollups))
+	}
+	if m.ghClient != nil {
+		allPRs := append(append([]github.PRItem(nil), toReview...), myPRs...)
+		cmds = append(cmds, fetchReviewDecisionsCmd(m.ghClient, allPRs))
+		if len(myPRs) > 0 && m.wantCIRollups() {
+			cmds = append(cmds, fetchCIRollupsCmd(m.ghClient, myPRs))
+		}
+	}
+	return cmds
+}
+
+// observeDecisions feeds review decisions to the activity tracker and
+// returns the resulting notifications, if enabled.
+func (m *App) observeDecisions(decisions map[string]string) tea.Cmd {
+	notes := m.activity.observeDecisions(decisions, m.appConfig)
+	if !m.notifyEnabled {
+		return nil
+	}
+	return sendNotificationsCmd(notes, m.appConfig.NotificationThreshold)
+}
+
+// observeCIRollups feeds CI status for my PRs to the activity tracker and
+// returns notifications and webhook posts for finished runs.
+func (m *App) observeCIRollups(rollups map[string]string) tea.Cmd {
+	notes, failed := m.activity.observeCI(rollups, m.appConfig)
+	var cmds []tea.Cmd
+	if m.notifyEnabled {
+		cmds = append(cmds, sendNotificationsCmd(notes, m.appConfig.NotificationThreshold))
+	}
+	for _, pr := range failed {
+		text := fmt.Sprintf("CI failed on %s#%d: %s", pr.Repo.FullName, pr.Number, pr.Title)
+		cmds = append(cmds, postWebhookCmd(m.appConfig, notify.EventCIFailed, text, pr.HTMLURL))
+	}
+	return tea.Batch(cmds...)
+}
+
+// fetchCIRollupsCmd fetches overall CI status for the user's own PRs.
+func fetchCIRollupsCmd(client GitHubService, prs []github.PRItem) tea.Cmd {
+	return func() tea.Msg {
+		ctx := context.Background()
+		rollups, _ := client.GetCIRollups(ctx, prs)
+		return ciRollupsMsg{Rollups: rollups}
+	}
+}
+
+// postWebhookCmd mirrors an event to the configured outbound webhook.
+// Returns nil when no webhook is configured or the event is not enabled.
+func postWebhookCmd(cfg *config.Config, event, text, link string) tea.Cmd {
+	if cfg == nil || !cfg.WebhookEventEnabled(event) {
+		return nil
+	}
+	hook := notify.Webhook{URL: cfg.WebhookURL}
+	return func() tea.Msg {
+		if err := hook.Post(context.Background(), event, text, link); err != nil {
+			return webhookErrMsg{Err: err}
+		}
+		return nil
+	}
+}
+
+// sendNotificationsCmd delivers trigger notifications. If more than threshold
+// arrive at once, a single summary notification is sent instead.
+func sendNotificationsCmd(notes []notification, threshold int) tea.Cmd {
+	if len(notes) == 0 {
+		return nil
+	}
+	return func() tea.Msg {
+		if len(notes) > threshold {
+			_ = notify.Send("prtea", fmt.Sprintf("%d updates on your PRs", len(notes)))
+			return nil
+		}
+		for _, n := range notes {
+			_ = notify.Send(n.title, n.body)
+		}
+		return nil
+	}
+}
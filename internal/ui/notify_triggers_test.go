@@ -0,0 +1,72 @@
+package ui
+
+import (
+	"testing"
+
+	"github.com/shhac/prtea/internal/config"
+	"github.com/shhac/prtea/internal/github"
+)
+
+func triggerPR(number, comments int) github.PRItem {
+	return github.PRItem{
+		Number:        number,
+		Title:         "Change",
+		Repo:          github.Repo{Owner: "acme", Name: "api", FullName: "acme/api"},
+		CommentsCount: comments,
+	}
+}
+
+func TestActivityTracker_CommentsAndReReview(t *testing.T) {
+	cfg := &config.Config{}
+	var tr activityTracker
+
+	if notes := tr.observePRs([]github.PRItem{triggerPR(1, 2), triggerPR(2, 0)}, nil, cfg); len(notes) != 0 {
+		t.Fatalf("first observation should only seed, got %v", notes)
+	}
+	// PR 2 leaves To Review (I reviewed it); PR 1 gets comments.
+	notes := tr.observePRs([]github.PRItem{triggerPR(1, 5)}, nil, cfg)
+	if len(notes) != 1 || notes[0].title != "prtea: New comments" {
+		t.Fatalf("notes = %v, want one new-comments note", notes)
+	}
+	// PR 2 comes back: review requested again.
+	notes = tr.observePRs([]github.PRItem{triggerPR(1, 5), triggerPR(2, 0)}, nil, cfg)
+	if len(notes) != 1 || notes[0].title != "prtea: Review requested again" {
+		t.Fatalf("notes = %v, want one re-review note", notes)
+	}
+
+	cfg.NotifyMuted = []string{config.NotifyComments}
+	if notes := tr.observePRs([]github.PRItem{triggerPR(1, 9), triggerPR(2, 0)}, nil, cfg); len(notes) != 0 {
+		t.Errorf("muted comments trigger still notified: %v", notes)
+	}
+}
+
+func TestActivityTracker_DecisionsAndCI(t *testing.T) {
+	cfg := &config.Config{}
+	var tr activityTracker
+	tr.observePRs(nil, []github.PRItem{triggerPR(7, 0)}, cfg)
+
+	if notes := tr.observeDecisions(map[string]string{}, cfg); len(notes) != 0 {
+		t.Fatalf("first decisions should only seed, got %v", notes)
+	}
+	notes := tr.observeDecisions(map[string]string{"acme/api#7": "APPROVED"}, cfg)
+	if len(notes) != 1 || notes[0].title != "prtea: PR approved" {
+		t.Fatalf("notes = %v, want approval note", notes)
+	}
+	if notes := tr.observeDecisions(map[string]string{"acme/api#7": "APPROVED"}, cfg); len(notes) != 0 {
+		t.Errorf("unchanged decision re-notified: %v", notes)
+	}
+
+	if notes, _ := tr.observeCI(map[string]string{"acme/api#7": "pending"}, cfg); len(notes) != 0 {
+		t.Fatalf("pending CI should not notify, got %v", notes)
+	}
+	notes, failed := tr.observeCI(map[string]string{"acme/api#7": "failing"}, cfg)
+	if len(notes) != 1 || notes[0].title != "prtea: CI failing" {
+		t.Fatalf("notes = %v, want CI failing note", notes)
+	}
+	if len(failed) != 1 || failed[0].Number != 7 {
+		t.Errorf("failed = %v, want PR 7", failed)
+	}
+	if notes, failed := tr.observeCI(map[string]string{"acme/api#7": "passing"}, cfg); len(notes) != 0 || len(failed) != 0 {
+		t.Errorf("CI change without a pending run should not notify: %v %v", notes, failed)
+	}
+}
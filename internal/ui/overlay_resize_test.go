@@ -0,0 +1,231 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/shhac/prtea/internal/claude"
+	"github.com/shhac/prtea/internal/config"
+)
+
+// assertFits fails if view is larger than a w×h terminal.
+func assertFits(t *testing.T, name string, view string, w, h int) {
+	t.Helper()
+	if got := lipgloss.Height(view); got > h {
+		t.Errorf("%s at %dx%d: height %d exceeds terminal", name, w, h, got)
+	}
+	if got := lipgloss.Width(view); got > w {
+		t.Errorf("%s at %dx%d: width %d exceeds terminal", name, w, h, got)
+	}
+}
+
+func TestOverlays_FitAfterShrinking(t *testing.T) {
+	diffLines := []string{"@@ -1,6 +1,6 @@", " a", "-" + strings.Repeat("x", 200), "+b", " c", " d", " e"}
+	for _, size := range [][2]int{{80, 10}, {50, 8}, {30, 5}, {120, 40}} {
+		w, h := size[0], size[1]
+
+		help := NewHelpOverlayModel()
+		help.SetSize(200, 60)
+		help.Show(PanelCenter)
+		help.SetSize(w, h)
+		assertFits(t, "help", help.View(), w, h)
+
+		settings := NewSettingsModel()
+		settings.SetSize(200, 60)
+		settings.Show(&config.Config{})
+		settings.SetSize(w, h)
+		assertFits(t, "settings", settings.View(), w, h)
+
+		comment := NewCommentOverlayModel()
+		comment.SetSize(200, 60)
+		comment.Show(ShowCommentOverlayMsg{Path: "a.go", Line: 3, DiffLines: diffLines, TargetLineInCtx: 3})
+		comment.SetSize(w, h)
+		assertFits(t, "comment", comment.View(), w, h)
+
+		logs := NewLogViewerModel()
+		logs.SetSize(200, 60)
+		logs.Show("job", 1)
+		logs.SetSize(w, h)
+		assertFits(t, "log", logs.View(), w, h)
+
+		quick := NewQuickAnswerModel()
+		quick.SetSize(200, 60)
+		quick.Show("a.go @@", "why?")
+		quick.SetSize(w, h)
+		assertFits(t, "quick answer", quick.View(), w, h)
+
+		usage := NewUsageOverlayModel()
+		usage.SetSize(200, 60)
+		usage.Show([]usageSection{{title: "This session"}}, 20, "")
+		usage.SetSize(w, h)
+		assertFits(t, "usage", usage.View(), w, h)
+
+		mergeMsg := NewMergeMessageOverlayModel()
+		mergeMsg.SetSize(200, 60)
+		mergeMsg.Show(&claude.MergeMessage{Title: "Add retry", Body: "Uploads retry twice.", ReleaseNote: "Uploads are more reliable."})
+		mergeMsg.SetSize(w, h)
+		assertFits(t, "merge message", mergeMsg.View(), w, h)
+
+		prompts := NewPromptsOverlayModel()
+		prompts.SetSize(200, 60)
+		prompts.Show([]promptEntry{{label: "Default (all repos)"}, {label: "acme/widget", exists: true, applies: true}}, "")
+		prompts.SetSize(w, h)
+		assertFits(t, "prompts", prompts.View(), w, h)
+
+		search := NewGlobalSearchModel()
+		search.SetSize(200, 60)
+		search.Show([]searchText{
+			{source: sourceDiff, label: "internal/ui/app.go:12", text: "+func retryUpload(ctx context.Context) error {"},
+			{source: sourceChat, label: "Claude", text: "retryUpload should back off\nbetween attempts"},
+		})
+		search.input.SetValue("retry")
+		search.search()
+		search.SetSize(w, h)
+		assertFits(t, "global search", search.View(), w, h)
+
+		pending := NewPendingCommentsModel()
+		pending.SetSize(200, 60)
+		pending.Show([]PendingInlineComment{
+			{InlineReviewComment: claude.InlineReviewComment{Path: "internal/ui/app.go", Line: 42, Body: "Handle the nil session here"}, Source: "ai"},
+			{InlineReviewComment: claude.InlineReviewComment{Path: "docs/README.md", Line: 3, Body: "Typo"}, Source: "user"},
+		}, map[string]bool{"docs/README.md": true})
+		pending.SetSize(w, h)
+		assertFits(t, "pending comments", pending.View(), w, h)
+
+		confirm := NewConfirmModel()
+		confirm.SetSize(200, 60)
+		confirm.Show("Close PR #12?", "shhac/prtea#12 Add retries\n\nThe PR is closed without merging.", "Close PR", nil)
+		confirm.SetSize(w, h)
+		assertFits(t, "confirm", confirm.View(), w, h)
+
+		workload := NewWorkloadModel()
+		workload.SetSize(200, 60)
+		workload.Show("acme, acme/api", 48*time.Hour)
+		workload.SetWorkload(workloadFixture(), nil)
+		workload.SetSize(w, h)
+		assertFits(t, "workload", workload.View(), w, h)
+
+		links := NewLinksModel()
+		links.SetSize(200, 60)
+		links.Show("PR description", []link{{label: "design doc", url: "https://example.com/" + strings.Repeat("a", 150)}})
+		links.SetSize(w, h)
+		assertFits(t, "links", links.View(), w, h)
+
+		palette := NewCommandModeModel()
+		palette.SetSize(w, h)
+		palette.Open(true)
+		assertFits(t, "quick palette", palette.View(), w, 1000)
+	}
+}
+
+func TestCommentOverlay_TrimsDiffContextAroundTarget(t *testing.T) {
+	var lines []string
+	for i := 0; i < 20; i++ {
+		lines = append(lines, " line"+string(rune('a'+i)))
+	}
+	m := NewCommentOverlayModel()
+	m.SetSize(100, 20)
+	m.Show(ShowCommentOverlayMsg{Path: "a.go", Line: 15, DiffLines: lines, TargetLineInCtx: 15})
+
+	view := m.View()
+	if !strings.Contains(view, " line"+string(rune('a'+15))) {
+		t.Error("target line should stay in the trimmed diff context")
+	}
+	if strings.Contains(view, " linea") {
+		t.Error("far-away context should be dropped on a short terminal")
+	}
+	if _, vpH := m.viewportDimensions(); vpH < 3 {
+		t.Errorf("thread viewport height = %d, want at least 3", vpH)
+	}
+}
+
+func TestCommentOverlay_TextareaTracksWidth(t *testing.T) {
+	m := NewCommentOverlayModel()
+	m.SetSize(200, 60)
+	m.SetSize(80, 24)
+	if got, want := lipgloss.Width(m.textarea.View()), m.innerWidth(); got != want {
+		t.Errorf("textarea width = %d, want %d", got, want)
+	}
+}
+
+func TestHelpOverlay_ResizeKeepsScrollPosition(t *testing.T) {
+	m := NewHelpOverlayModel()
+	m.SetSize(100, 30)
+	m.Show(PanelCenter)
+	m.viewport.SetYOffset(5)
+
+	m.SetSize(110, 30)
+	if m.viewport.YOffset != 5 {
+		t.Errorf("YOffset = %d after resize, want 5", m.viewport.YOffset)
+	}
+
+	m.Show(PanelCenter)
+	if m.viewport.YOffset != 0 {
+		t.Errorf("YOffset = %d after reopening, want 0", m.viewport.YOffset)
+	}
+}
+
+func TestSettings_ResizeKeepsCursorVisible(t *testing.T) {
+	m := NewSettingsModel()
+	m.SetSize(120, 60)
+	m.Show(&config.Config{})
+	m.cursor = len(navigableItems()) - 1
+	m.refreshViewport()
+	m.ensureVisible()
+
+	m.SetSize(80, 12)
+	if !strings.Contains(m.viewport.View(), "▸") {
+		t.Error("focused row should remain visible after shrinking")
+	}
+}
+
+func TestCommandPalette_SelectionScrollsIntoView(t *testing.T) {
+	m := NewCommandModeModel()
+	m.SetSize(80, 6)
+	m.Open(false)
+	m.selected = len(m.filtered) - 1
+
+	view := m.View()
+	if got := lipgloss.Height(view); got > 6 {
+		t.Errorf("palette height = %d, want at most 6", got)
+	}
+	if !strings.Contains(view, m.filtered[m.selected].Name) {
+		t.Error("selected command should be visible")
+	}
+}
+
+func TestRenderCommandOverlay_KeepsInputLineOnShortScreen(t *testing.T) {
+	m := App{width: 80, commandMode: NewCommandModeModel()}
+	m.commandMode.SetSize(80, 0)
+	m.commandMode.Open(false)
+
+	out := m.renderCommandOverlay("one\ntwo\nthree")
+	lines := strings.Split(out, "\n")
+	if len(lines) != 3 {
+		t.Fatalf("got %d lines, want 3", len(lines))
+	}
+	if !strings.Contains(lines[2], ":") {
+		t.Errorf("last line should be the input, got %q", lines[2])
+	}
+}
+
+func TestVisibleRange(t *testing.T) {
+	tests := []struct {
+		cursor, n, maxRows int
+		start, end         int
+	}{
+		{0, 3, 5, 0, 3},
+		{4, 10, 5, 0, 5},
+		{5, 10, 5, 1, 6},
+		{9, 10, 5, 5, 10},
+	}
+	for _, tt := range tests {
+		start, end := visibleRange(tt.cursor, tt.n, tt.maxRows)
+		if start != tt.start || end != tt.end {
+			t.Errorf("visibleRange(%d, %d, %d) = %d, %d; want %d, %d",
+				tt.cursor, tt.n, tt.maxRows, start, end, tt.start, tt.end)
+		}
+	}
+}
@@ -1,5 +1,7 @@
 package ui
 
+import "math"
+
 // Panel identifies which panel has focus.
 type Panel int
 
@@ -36,8 +38,42 @@ const (
 	twoCRCenterRatio = 0.60 // Center + Right: center panel share
 
 	statusBarHeight = 1
+
+	// panelResizeStep is the fraction of the terminal width one resize key
+	// press moves between panels.
+	panelResizeStep = 0.04
 )
 
+// PanelRatios are the relative widths of the left, center and right panels.
+// Only the visible panels' shares are used, scaled to fill the terminal. The
+// zero value means the built-in proportions above.
+type PanelRatios [3]float64
+
+// defaultPanelRatios matches the built-in 3-panel proportions; it seeds the
+// ratios the first time a panel is resized.
+var defaultPanelRatios = PanelRatios{leftRatio, 1 - leftRatio - rightRatio, rightRatio}
+
+// panelRatiosFromConfig converts the configured ratios, returning the zero
+// value (built-in proportions) when they are missing or invalid.
+func panelRatiosFromConfig(values []float64) PanelRatios {
+	var r PanelRatios
+	if len(values) != 3 {
+		return r
+	}
+	sum := 0.0
+	for i, v := range values {
+		if v < 0 {
+			return PanelRatios{}
+		}
+		r[i] = v
+		sum += v
+	}
+	if sum == 0 {
+		return PanelRatios{}
+	}
+	return r
+}
+
 // PanelSizes holds calculated panel dimensions.
 type PanelSizes struct {
 	LeftWidth   int
@@ -48,8 +84,14 @@ type PanelSizes struct {
 }
 
 // CalculatePanelSizes determines panel widths based on terminal dimensions
-// and which panels are visible.
+// and which panels are visible, using the built-in proportions.
 func CalculatePanelSizes(termWidth, termHeight int, visible [3]bool) PanelSizes {
+	return CalculatePanelSizesWithRatios(termWidth, termHeight, visible, PanelRatios{})
+}
+
+// CalculatePanelSizesWithRatios is CalculatePanelSizes with user-adjusted
+// panel ratios. Zero ratios fall back to the built-in proportions.
+func CalculatePanelSizesWithRatios(termWidth, termHeight int, visible [3]bool, ratios PanelRatios) PanelSizes {
 	numVisible := visibleCount(visible)
 	if numVisible == 0 || termWidth < minTotalWidth {
 		return PanelSizes{TooSmall: true}
@@ -75,9 +117,15 @@ func CalculatePanelSizes(termWidth, termHeight int, visible [3]bool) PanelSizes
 		return sizes
 
 	case 2:
+		if ratios != (PanelRatios{}) {
+			return calcWeightedPanels(usableWidth, panelHeight, visible, ratios)
+		}
 		return calcTwoPanels(usableWidth, panelHeight, visible)
 
 	case 3:
+		if ratios != (PanelRatios{}) {
+			return calcWeightedPanels(usableWidth, panelHeight, visible, ratios)
+		}
 		return calcThreePanels(usableWidth, panelHeight)
 	}
 
@@ -141,6 +189,121 @@ func calcThreePanels(width, height int) PanelSizes {
 	}
 }
 
+// panelMinWidths are the minimum widths of the left, center and right panels.
+var panelMinWidths = [3]int{minLeftWidth, minCenterWidth, minRightWidth}
+
+// calcWeightedPanels splits width between the visible panels in proportion
+// to ratios, keeping every panel at or above its minimum width.
+func calcWeightedPanels(width, height int, visible [3]bool, ratios PanelRatios) PanelSizes {
+	total := ratios.visibleShare(visible)
+	if total == 0 {
+		ratios = defaultPanelRatios
+		total = ratios.visibleShare(visible)
+	}
+
+	var widths [3]int
+	used := 0
+	for p, v := range visible {
+		if !v {
+			continue
+		}
+		widths[p] = max(panelMinWidths[p], int(float64(width)*ratios[p]/total))
+		used += widths[p]
+	}
+
+	// Rounding slack goes to the center panel (or the last visible one).
+	if slack := width - used; slack > 0 {
+		target := PanelCenter
+		if !visible[PanelCenter] {
+			target = PanelRight
+		}
+		widths[target] += slack
+	}
+	// Minimum widths may overflow; take the excess from whichever panel has
+	// the most room above its minimum.
+	for over := used - width; over > 0; {
+		best, room := -1, 0
+		for p, v := range visible {
+			if v && widths[p]-panelMinWidths[p] > room {
+				best, room = p, widths[p]-panelMinWidths[p]
+			}
+		}
+		if best < 0 {
+			break
+		}
+		take := min(room, over)
+		widths[best] -= take
+		over -= take
+	}
+
+	return PanelSizes{
+		LeftWidth:   widths[PanelLeft],
+		CenterWidth: widths[PanelCenter],
+		RightWidth:  widths[PanelRight],
+		PanelHeight: height,
+	}
+}
+
+// visibleShare sums the ratios of the visible panels.
+func (r PanelRatios) visibleShare(visible [3]bool) float64 {
+	sum := 0.0
+	for p, v := range visible {
+		if v {
+			sum += r[p]
+		}
+	}
+	return sum
+}
+
+// resizePanelRatios returns ratios with panel p grown by delta columns (or
+// shrunk when negative) relative to the current sizes. The other visible
+// panels give up or gain space in proportion to their widths; hidden panels
+// keep their ratio for when they are shown again.
+func resizePanelRatios(ratios PanelRatios, sizes PanelSizes, visible [3]bool, p Panel, delta int) PanelRatios {
+	base := ratios
+	if base == (PanelRatios{}) {
+		base = defaultPanelRatios
+	}
+	widths := [3]float64{float64(sizes.LeftWidth), float64(sizes.CenterWidth), float64(sizes.RightWidth)}
+	width := widths[0] + widths[1] + widths[2]
+	if width == 0 || !visible[p] {
+		return ratios
+	}
+
+	othersMin := 0
+	for q, v := range visible {
+		if v && Panel(q) != p {
+			othersMin += panelMinWidths[q]
+		}
+	}
+	newW := float64(max(panelMinWidths[p], min(int(widths[p])+delta, int(width)-othersMin)))
+	moved := newW - widths[p]
+	others := width - widths[p]
+	for q, v := range visible {
+		if !v {
+			continue
+		}
+		if Panel(q) == p {
+			widths[q] = newW
+		} else if others > 0 {
+			widths[q] -= moved * widths[q] / others
+		}
+	}
+
+	// Visible panels share what the hidden ones don't claim.
+	share := base.visibleShare(visible)
+	if share == 0 {
+		share = 1
+	}
+	next := base
+	for q, v := range visible {
+		if v {
+			next[q] = math.Round(widths[q]/width*share*1000) / 1000
+		}
+	}
+	return next
+}
+
 // visibleCount returns the number of visible panels.
 func visibleCount(visible [3]bool) int {
 	n := 0
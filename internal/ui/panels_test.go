@@ -212,3 +212,63 @@ func TestCalculatePanelSizes_TwoPanels(t *testing.T) {
 		})
 	}
 }
+
+func TestCalculatePanelSizesWithRatios(t *testing.T) {
+	sizes := CalculatePanelSizesWithRatios(200, 50, [3]bool{true, true, true}, PanelRatios{1, 2, 1})
+	if sizes.LeftWidth != 50 || sizes.CenterWidth != 100 || sizes.RightWidth != 50 {
+		t.Errorf("sizes = %+v, want 50/100/50", sizes)
+	}
+
+	// Hidden panels drop out and the rest are rescaled.
+	sizes = CalculatePanelSizesWithRatios(150, 40, [3]bool{true, false, true}, PanelRatios{1, 2, 2})
+	if sizes.LeftWidth != 50 || sizes.RightWidth != 100 {
+		t.Errorf("left+right sizes = %+v, want 50/100", sizes)
+	}
+
+	// Minimum widths win over extreme ratios.
+	sizes = CalculatePanelSizesWithRatios(120, 40, [3]bool{true, true, true}, PanelRatios{0.9, 0.05, 0.05})
+	if sizes.CenterWidth < minCenterWidth || sizes.RightWidth < minRightWidth {
+		t.Errorf("sizes = %+v, want minimums respected", sizes)
+	}
+	if total := sizes.LeftWidth + sizes.CenterWidth + sizes.RightWidth; total != 120 {
+		t.Errorf("total width = %d, want 120", total)
+	}
+}
+
+func TestResizePanelRatios(t *testing.T) {
+	visible := [3]bool{true, true, true}
+	sizes := CalculatePanelSizes(200, 50, visible)
+
+	grown := resizePanelRatios(PanelRatios{}, sizes, visible, PanelCenter, 10)
+	after := CalculatePanelSizesWithRatios(200, 50, visible, grown)
+	if after.CenterWidth != sizes.CenterWidth+10 {
+		t.Errorf("center width = %d, want %d", after.CenterWidth, sizes.CenterWidth+10)
+	}
+	if after.LeftWidth >= sizes.LeftWidth || after.RightWidth >= sizes.RightWidth {
+		t.Errorf("other panels should shrink: before %+v, after %+v", sizes, after)
+	}
+
+	// Shrinking stops at the panel's minimum width.
+	shrunk := resizePanelRatios(PanelRatios{}, sizes, visible, PanelLeft, -100)
+	if got := CalculatePanelSizesWithRatios(200, 50, visible, shrunk).LeftWidth; got != minLeftWidth {
+		t.Errorf("left width = %d, want %d", got, minLeftWidth)
+	}
+
+	// Hidden panels keep their ratio.
+	twoUp := [3]bool{true, true, false}
+	r := resizePanelRatios(PanelRatios{}, CalculatePanelSizes(150, 40, twoUp), twoUp, PanelLeft, 5)
+	if r[PanelRight] != defaultPanelRatios[PanelRight] {
+		t.Errorf("hidden right ratio = %v, want %v", r[PanelRight], defaultPanelRatios[PanelRight])
+	}
+}
+
+func TestPanelRatiosFromConfig(t *testing.T) {
+	if got := panelRatiosFromConfig([]float64{0.2, 0.5, 0.3}); got != (PanelRatios{0.2, 0.5, 0.3}) {
+		t.Errorf("got %v", got)
+	}
+	for _, bad := range [][]float64{nil, {0.5, 0.5}, {0, 0, 0}, {-1, 1, 1}} {
+		if got := panelRatiosFromConfig(bad); got != (PanelRatios{}) {
+			t.Errorf("panelRatiosFromConfig(%v) = %v, want zero", bad, got)
+		}
+	}
+}
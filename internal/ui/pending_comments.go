@@ -0,0 +1,342 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textarea"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// showPendingComments opens the :pending overlay on the review's draft
+// inline comments.
+func (m App) showPendingComments() (tea.Model, tea.Cmd) {
+	if m.session == nil {
+		return m, m.statusBar.SetTemporaryMessage("No PR selected", 2*time.Second)
+	}
+	if len(m.session.PendingInlineComments) == 0 {
+		return m, m.statusBar.SetTemporaryMessage("No pending inline comments", 2*time.Second)
+	}
+	m.pendingList.SetSize(m.width, m.height)
+	m.pendingList.Show(m.session.PendingInlineComments, m.session.ExcludedFiles)
+	m.setMode(ModeOverlay)
+	return m, nil
+}
+
+// setPendingComments replaces the pending comment pool after an edit in the
+// :pending overlay.
+func (m *App) setPendingComments(comments []PendingInlineComment) {
+	if m.session == nil {
+		return
+	}
+	m.session.PendingInlineComments = comments
+	m.diffViewer.SetPendingInlineComments(comments)
+	m.syncPendingCommentCount()
+}
+
+// pendingLocation formats a comment's target as "path:line" or
+// "path:start-end" for range comments.
+func pendingLocation(c PendingInlineComment) string {
+	if c.StartLine > 0 && c.StartLine != c.Line {
+		return fmt.Sprintf("%s:%d-%d", c.Path, c.StartLine, c.Line)
+	}
+	return fmt.Sprintf("%s:%d", c.Path, c.Line)
+}
+
+// PendingCommentsModel is an overlay listing every pending inline comment in
+// submission order, so the review can be audited and tidied before it is
+// sent. Edits are applied to a copy and reported with
+// PendingCommentsChangedMsg.
+type PendingCommentsModel struct {
+	comments []PendingInlineComment
+	excluded map[string]bool
+	cursor   int
+	editing  bool
+	editor   textarea.Model
+	complete textCompleter
+	width    int
+	height   int
+	visible  bool
+}
+
+// NewPendingCommentsModel creates a pending comments overlay.
+func NewPendingCommentsModel() PendingCommentsModel {
+	ta := textarea.New()
+	ta.CharLimit = 65535
+	ta.SetHeight(5)
+	ta.ShowLineNumbers = false
+	ta.Blur()
+	return PendingCommentsModel{editor: ta}
+}
+
+// Show opens the overlay on a copy of the pending comments.
+func (m *PendingCommentsModel) Show(comments []PendingInlineComment, excluded map[string]bool) {
+	m.comments = append([]PendingInlineComment(nil), comments...)
+	m.excluded = excluded
+	m.cursor = 0
+	m.editing = false
+	m.editor.Blur()
+	m.visible = true
+}
+
+// IsVisible returns whether the overlay is currently shown.
+func (m PendingCommentsModel) IsVisible() bool {
+	return m.visible
+}
+
+// SetSize updates the terminal dimensions used to place the overlay.
+func (m *PendingCommentsModel) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+	m.editor.SetWidth(max(1, m.overlayWidth()-4))
+}
+
+func (m PendingCommentsModel) overlayWidth() int {
+	return min(max(60, m.width*3/4), m.width)
+}
+
+// changed reports the edited list to the App.
+func (m PendingCommentsModel) changed(note string) tea.Cmd {
+	comments := append([]PendingInlineComment(nil), m.comments...)
+	return func() tea.Msg { return PendingCommentsChangedMsg{Comments: comments, Note: note} }
+}
+
+func (m PendingCommentsModel) Update(msg tea.Msg) (PendingCommentsModel, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return m, nil
+	}
+	if m.editing {
+		return m.updateEditor(keyMsg)
+	}
+	switch keyMsg.String() {
+	case "j", "down":
+		if m.cursor < len(m.comments)-1 {
+			m.cursor++
+		}
+	case "k", "up":
+		if m.cursor > 0 {
+			m.cursor--
+		}
+	case "g", "home":
+		m.cursor = 0
+	case "G", "end":
+		m.cursor = max(0, len(m.comments)-1)
+	case "J", "shift+down":
+		if m.cursor < len(m.comments)-1 {
+			m.comments[m.cursor], m.comments[m.cursor+1] = m.comments[m.cursor+1], m.comments[m.cursor]
+			m.cursor++
+			return m, m.changed("")
+		}
+	case "K", "shift+up":
+		if m.cursor > 0 && m.cursor < len(m.comments) {
+			m.comments[m.cursor], m.comments[m.cursor-1] = m.comments[m.cursor-1], m.comments[m.cursor]
+			m.cursor--
+			return m, m.changed("")
+		}
+	case "e":
+		if m.cursor < len(m.comments) {
+			m.editing = true
+			m.editor.SetValue(m.comments[m.cursor].Body)
+			m.editor.CursorEnd()
+			return m, m.editor.Focus()
+		}
+	case "d", "x":
+		if m.cursor < len(m.comments) {
+			note := "Removed comment on " + pendingLocation(m.comments[m.cursor])
+			m.comments = append(m.comments[:m.cursor], m.comments[m.cursor+1:]...)
+			m.cursor = min(m.cursor, max(0, len(m.comments)-1))
+			return m, m.changed(note)
+		}
+	case " ":
+		if m.cursor < len(m.comments) {
+			c := &m.comments[m.cursor]
+			if c.Stale {
+				return m, m.changed("Comment on " + pendingLocation(*c) + " lost its line in a push; delete it or comment on the new line")
+			}
+			c.Held = !c.Held
+			note := "Including comment on " + pendingLocation(*c) + " in the review"
+			if c.Held {
+				note = "Holding back comment on " + pendingLocation(*c) + " as a draft"
+			} else if m.excluded[c.Path] {
+				note += " once " + c.Path + " is back in scope"
+			}
+			return m, m.changed(note)
+		}
+	case "a":
+		// Include every comment, or hold them all back if all are included.
+		hold := true
+		for _, c := range m.comments {
+			if c.Held && !c.Stale {
+				hold = false
+				break
+			}
+		}
+		for i := range m.comments {
+			m.comments[i].Held = hold || m.comments[i].Stale
+		}
+		if len(m.comments) == 0 {
+			return m, nil
+		}
+		if hold {
+			return m, m.changed("Holding back all comments as drafts")
+		}
+		return m, m.changed("Including all comments in the review")
+	case "D":
+		kept := m.comments[:0]
+		for _, c := range m.comments {
+			if c.Source != "ai" {
+				kept = append(kept, c)
+			}
+		}
+		dropped := len(m.comments) - len(kept)
+		if dropped == 0 {
+			return m, nil
+		}
+		m.comments = kept
+		m.cursor = min(m.cursor, max(0, len(m.comments)-1))
+		return m, m.changed(fmt.Sprintf("Dropped %d AI comment(s)", dropped))
+	case "enter":
+		m.visible = false
+		var jump *PendingInlineComment
+		if m.cursor < len(m.comments) {
+			c := m.comments[m.cursor]
+			jump = &c
+		}
+		return m, func() tea.Msg { return PendingCommentsClosedMsg{Jump: jump} }
+	case "esc", "q":
+		m.visible = false
+		return m, func() tea.Msg { return PendingCommentsClosedMsg{} }
+	}
+	return m, nil
+}
+
+// updateEditor handles keys while a comment body is being edited. Saving an
+// edited AI comment makes it the user's own, as in the diff editor.
+func (m PendingCommentsModel) updateEditor(msg tea.KeyMsg) (PendingCommentsModel, tea.Cmd) {
+	if m.complete.handles(msg) {
+		return m, updateTextarea(&m.editor, &m.complete, msg)
+	}
+	switch msg.String() {
+	case "esc":
+		m.editing = false
+		m.editor.Blur()
+		m.complete.reset()
+		return m, nil
+	case "ctrl+s":
+		m.editing = false
+		m.editor.Blur()
+		m.complete.reset()
+		body := strings.TrimSpace(m.editor.Value())
+		if body == "" || body == m.comments[m.cursor].Body {
+			return m, nil
+		}
+		m.comments[m.cursor].Body = body
+		m.comments[m.cursor].Source = "user"
+		return m, m.changed("Updated comment on " + pendingLocation(m.comments[m.cursor]))
+	}
+	return m, updateTextarea(&m.editor, &m.complete, msg)
+}
+
+func (m PendingCommentsModel) View() string {
+	if !m.visible {
+		return ""
+	}
+	overlayW := m.overlayWidth()
+	innerW := max(1, overlayW-4)
+
+	ai, withheld, included, stale := 0, 0, 0, 0
+	for _, c := range m.comments {
+		if c.Source == "ai" {
+			ai++
+		}
+		if c.Stale {
+			stale++
+		}
+		switch {
+		case m.excluded[c.Path]:
+			withheld++
+		case !c.Held:
+			included++
+		}
+	}
+	title := helpTitleStyle.Render(fmt.Sprintf(" Pending comments (%d, %d AI, %d to submit) ", len(m.comments), ai, included))
+	lines := []string{lipgloss.PlaceHorizontal(innerW, lipgloss.Left, title), ""}
+
+	if len(m.comments) == 0 {
+		lines = append(lines, dimStyle.Render("No pending inline comments."))
+	}
+
+	maxRows := max(1, m.height-8)
+	if m.editing {
+		maxRows = max(1, maxRows-m.editor.Height()-1)
+	} else if withheld > 0 || stale > 0 {
+		maxRows = max(1, maxRows-2)
+	}
+	start, end := visibleRange(m.cursor, len(m.comments), maxRows)
+	for i := start; i < end; i++ {
+		c := m.comments[i]
+		marker := "  "
+		if i == m.cursor {
+			marker = "▸ "
+		}
+		badge := "user"
+		if c.Source == "ai" {
+			badge = "AI"
+		}
+		body, _, _ := strings.Cut(c.Body, "\n")
+		box := "☑"
+		if c.Held {
+			box = "☐"
+		}
+		sep := "  "
+		switch {
+		case c.Stale:
+			sep = " ⚠ "
+		case m.excluded[c.Path]:
+			sep = " ⊘ "
+		}
+		row := ansi.Truncate(fmt.Sprintf("%s%s %2d. %-4s %s%s%s", marker, box, i+1, badge, pendingLocation(c), sep, body), innerW, "…")
+		switch {
+		case i == m.cursor:
+			row = boldStyle.Render(row)
+		case c.Held || m.excluded[c.Path]:
+			row = dimStyle.Render(row)
+		}
+		lines = append(lines, row)
+	}
+
+	footerText := "j/k move · Space include/hold · a all · J/K reorder · Enter jump · e edit · d delete · D drop all AI · Esc close"
+	if m.editing {
+		lines = append(lines, "", m.editor.View())
+		footerText = "Ctrl+S save · Esc cancel"
+	} else {
+		var notes []string
+		if withheld > 0 {
+			notes = append(notes, "⊘ out-of-scope file — kept as a draft, not submitted")
+		}
+		if stale > 0 {
+			notes = append(notes, "⚠ line gone after a push — held; delete it or comment on the new line")
+		}
+		if len(notes) > 0 {
+			lines = append(lines, "", dimStyle.Render(ansi.Truncate(strings.Join(notes, " · "), innerW, "…")))
+		}
+	}
+
+	footer := fitWidth(lipgloss.PlaceHorizontal(innerW, lipgloss.Center, helpFooterStyle.Render(footerText)), innerW)
+	if m.editing && m.complete.active() {
+		footer = m.complete.View(innerW)
+	}
+	lines = append(lines, "", footer)
+
+	overlayStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(theme.Accent).
+		Padding(0, 1).
+		Width(overlayW - 2)
+
+	return placeOverlay(m.width, m.height, overlayStyle.Render(strings.Join(lines, "\n")))
+}
@@ -0,0 +1,135 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/shhac/prtea/internal/claude"
+)
+
+func pendingFixture() []PendingInlineComment {
+	return []PendingInlineComment{
+		{InlineReviewComment: claude.InlineReviewComment{Path: "a.go", Line: 1, Body: "ai one"}, Source: "ai"},
+		{InlineReviewComment: claude.InlineReviewComment{Path: "a.go", Line: 5, Body: "mine"}, Source: "user"},
+		{InlineReviewComment: claude.InlineReviewComment{Path: "b.go", Line: 2, Body: "ai two"}, Source: "ai"},
+	}
+}
+
+// pendingKey sends a key to the overlay and returns the change it reports,
+// if any.
+func pendingKey(t *testing.T, m *PendingCommentsModel, key string) *PendingCommentsChangedMsg {
+	t.Helper()
+	var cmd tea.Cmd
+	*m, cmd = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)})
+	if cmd == nil {
+		return nil
+	}
+	if msg, ok := cmd().(PendingCommentsChangedMsg); ok {
+		return &msg
+	}
+	return nil
+}
+
+func bodies(comments []PendingInlineComment) []string {
+	var out []string
+	for _, c := range comments {
+		out = append(out, c.Body)
+	}
+	return out
+}
+
+func TestPendingComments_ReorderDeleteDropAI(t *testing.T) {
+	original := pendingFixture()
+	m := NewPendingCommentsModel()
+	m.SetSize(120, 40)
+	m.Show(original, nil)
+
+	changed := pendingKey(t, &m, "J")
+	if changed == nil {
+		t.Fatal("J should report a reorder")
+	}
+	if got := bodies(changed.Comments); got[0] != "mine" || got[1] != "ai one" {
+		t.Errorf("after J = %v, want the first comment moved down", got)
+	}
+	if original[0].Body != "ai one" {
+		t.Error("the overlay must not edit the session's slice in place")
+	}
+
+	changed = pendingKey(t, &m, "D")
+	if changed == nil || len(changed.Comments) != 1 || changed.Comments[0].Body != "mine" {
+		t.Fatalf("D should keep only the user's comment, got %+v", changed)
+	}
+
+	changed = pendingKey(t, &m, "d")
+	if changed == nil || len(changed.Comments) != 0 {
+		t.Fatalf("d should delete the comment under the cursor, got %+v", changed)
+	}
+}
+
+func TestPendingComments_EditTakesOwnership(t *testing.T) {
+	m := NewPendingCommentsModel()
+	m.SetSize(120, 40)
+	m.Show(pendingFixture(), nil)
+
+	pendingKey(t, &m, "e")
+	if !m.editing {
+		t.Fatal("e should open the editor")
+	}
+	m.editor.SetValue("reworded")
+	var cmd tea.Cmd
+	m, cmd = m.Update(tea.KeyMsg{Type: tea.KeyCtrlS})
+	changed, ok := cmd().(PendingCommentsChangedMsg)
+	if !ok {
+		t.Fatal("ctrl+s should report the edit")
+	}
+	if c := changed.Comments[0]; c.Body != "reworded" || c.Source != "user" {
+		t.Errorf("edited comment = %+v, want the new body owned by the user", c)
+	}
+}
+
+func TestPendingComments_AppliesToSession(t *testing.T) {
+	m := App{
+		session:    &PRSession{Owner: "acme", Repo: "widget", Number: 1, PendingInlineComments: pendingFixture()},
+		statusBar:  NewStatusBarModel(),
+		chatPanel:  NewChatPanelModel(),
+		diffViewer: newTestDiffViewer(80, 24),
+	}
+	kept := pendingFixture()[1:2]
+	model, _ := m.Update(PendingCommentsChangedMsg{Comments: kept, Note: "Dropped 2 AI comment(s)"})
+	m = model.(App)
+	if got := bodies(m.session.PendingInlineComments); len(got) != 1 || got[0] != "mine" {
+		t.Errorf("session comments = %v", got)
+	}
+	if len(m.diffViewer.pendingCommentsByFileLine) != 1 {
+		t.Errorf("diff viewer should show the remaining comment, got %d lines", len(m.diffViewer.pendingCommentsByFileLine))
+	}
+}
+
+func TestPendingComments_HoldBackSubset(t *testing.T) {
+	m := NewPendingCommentsModel()
+	m.Show(pendingFixture(), map[string]bool{"b.go": true})
+	m.SetSize(120, 40)
+
+	pendingKey(t, &m, "j")
+	changed := pendingKey(t, &m, " ")
+	if changed == nil || !changed.Comments[1].Held || changed.Comments[0].Held {
+		t.Fatalf("Space should hold back the selected comment, got %+v", changed)
+	}
+	if view := m.View(); !strings.Contains(view, "1 to submit") || !strings.Contains(view, "☐  2.") {
+		t.Errorf("view:\n%s", view)
+	}
+
+	s := &PRSession{PendingInlineComments: changed.Comments, ExcludedFiles: map[string]bool{"b.go": true}}
+	submit, withheld := s.SubmittableComments()
+	if len(submit) != 1 || submit[0].Body != "ai one" || len(withheld) != 2 {
+		t.Errorf("submit = %v, withheld = %v", bodies(submit), bodies(withheld))
+	}
+
+	if changed = pendingKey(t, &m, "a"); changed == nil || changed.Comments[1].Held {
+		t.Error("a should include every comment when some are held back")
+	}
+	if changed = pendingKey(t, &m, "a"); changed == nil || !changed.Comments[0].Held || !changed.Comments[2].Held {
+		t.Error("a should hold back every comment when all are included")
+	}
+}
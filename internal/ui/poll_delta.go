@@ -0,0 +1,168 @@
+package ui
+
+import (
+	"time"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/shhac/prtea/internal/github"
+)
+
+// fullPollEvery is how often a poll fetches the full PR lists instead of
+// only the PRs updated since the last one. Delta polls can't see PRs that
+// left a list, e.g. merged or with the review request withdrawn.
+const fullPollEvery = 10
+
+// pollDeltaLimit is the most PRs a delta poll returns per list; a delta
+// that size may be cut short, so the next poll is a full one.
+const pollDeltaLimit = 100
+
+// prFlashDuration is how long rows changed by a poll stay highlighted.
+const prFlashDuration = 3 * time.Second
+
+// polledLists is the last known state of both PR lists, which delta polls
+// are merged into.
+type polledLists struct {
+	toReview []github.PRItem
+	mine     []github.PRItem
+	cursor   time.Time // latest updatedAt seen; the next delta poll asks for PRs updated since
+	deltas   int       // delta polls since the last full one
+}
+
+// record stores lists fetched in full.
+func (p *polledLists) record(toReview, mine []github.PRItem) {
+	p.toReview, p.mine, p.deltas = toReview, mine, 0
+	p.cursor = latestUpdate(p.cursor, toReview, mine)
+}
+
+// merge folds the PRs of a delta poll into the known lists and returns the
+// merged lists.
+func (p *polledLists) merge(toReview, mine []github.PRItem) ([]github.PRItem, []github.PRItem) {
+	p.toReview = mergePRDelta(p.toReview, toReview)
+	p.mine = mergePRDelta(p.mine, mine)
+	p.cursor = latestUpdate(p.cursor, toReview, mine)
+	p.deltas++
+	if len(toReview) >= pollDeltaLimit || len(mine) >= pollDeltaLimit {
+		p.deltas = fullPollEvery
+	}
+	return p.toReview, p.mine
+}
+
+// dropUnchanged drops the PRs of a delta poll that are already known with the
+// same updatedAt. The delta query asks for updated:>= the cursor, so it
+// always returns the PR the cursor came from; a strict > would miss PRs
+// updated later in the same second.
+func (p polledLists) dropUnchanged(toReview, mine []github.PRItem) ([]github.PRItem, []github.PRItem) {
+	return dropKnownPRs(p.toReview, toReview), dropKnownPRs(p.mine, mine)
+}
+
+// dropKnownPRs returns the PRs of delta that base lacks or has with a
+// different updatedAt.
+func dropKnownPRs(base, delta []github.PRItem) []github.PRItem {
+	known := make(map[string]time.Time, len(base))
+	for _, pr := range base {
+		known[prKey(pr.Repo.Owner, pr.Repo.Name, pr.Number)] = pr.UpdatedAt
+	}
+	var changed []github.PRItem
+	for _, pr := range delta {
+		if at, ok := known[prKey(pr.Repo.Owner, pr.Repo.Name, pr.Number)]; !ok || !at.Equal(pr.UpdatedAt) {
+			changed = append(changed, pr)
+		}
+	}
+	return changed
+}
+
+// since returns the cursor for the next poll, or zero when it should fetch
+// the full lists.
+func (p polledLists) since() time.Time {
+	if p.deltas >= fullPollEvery-1 {
+		return time.Time{}
+	}
+	return p.cursor
+}
+
+// latestUpdate returns the latest updatedAt among cursor and the PRs.
+func latestUpdate(cursor time.Time, lists ...[]github.PRItem) time.Time {
+	for _, prs := range lists {
+		for _, pr := range prs {
+			if pr.UpdatedAt.After(cursor) {
+				cursor = pr.UpdatedAt
+			}
+		}
+	}
+	return cursor
+}
+
+// mergePRDelta replaces the PRs of base that appear in delta and puts the
+// ones base lacks first, as the most recently active.
+func mergePRDelta(base, delta []github.PRItem) []github.PRItem {
+	if len(delta) == 0 {
+		return base
+	}
+	index := make(map[string]int, len(base))
+	for i, pr := range base {
+		index[prKey(pr.Repo.Owner, pr.Repo.Name, pr.Number)] = i
+	}
+	merged := append([]github.PRItem(nil), base...)
+	var added []github.PRItem
+	for _, pr := range delta {
+		if i, ok := index[prKey(pr.Repo.Owner, pr.Repo.Name, pr.Number)]; ok {
+			merged[i] = pr
+		} else {
+			added = append(added, pr)
+		}
+	}
+	return append(added, merged...)
+}
+
+// prFlash holds the rows a poll changed, highlighted until gen's timer
+// fires. Heap-allocated so the delegate's pointer survives value copies.
+type prFlash struct {
+	keys map[string]bool // PRItem.key() of changed rows
+	gen  int
+}
+
+// prFlashDoneMsg ends the highlight of the rows flashed by generation gen.
+type prFlashDoneMsg struct{ gen int }
+
+// prRowChanged reports whether a polled row differs from what the list shows.
+func prRowChanged(old, updated PRItem) bool {
+	return !old.updatedAt.Equal(updated.updatedAt) || old.title != updated.title ||
+		old.reviewDecision != updated.reviewDecision || old.isDraft != updated.isDraft
+}
+
+// flashChanged highlights the rows of items that are new or changed
+// compared with before, returning the command that ends the highlight.
+func (m *PRListModel) flashChanged(before, items []list.Item) tea.Cmd {
+	old := make(map[string]PRItem, len(before))
+	for _, it := range before {
+		if pr, ok := it.(PRItem); ok {
+			old[pr.key()] = pr
+		}
+	}
+	changed := make(map[string]bool)
+	for _, it := range items {
+		pr, ok := it.(PRItem)
+		if !ok {
+			continue
+		}
+		if prev, seen := old[pr.key()]; !seen || prRowChanged(prev, pr) {
+			changed[pr.key()] = true
+		}
+	}
+	if len(changed) == 0 {
+		return nil
+	}
+	m.flash.gen++
+	m.flash.keys = changed
+	gen := m.flash.gen
+	return tea.Tick(prFlashDuration, func(time.Time) tea.Msg { return prFlashDoneMsg{gen: gen} })
+}
+
+// endFlash clears the highlight started by generation gen, unless a later
+// poll has flashed other rows since.
+func (m *PRListModel) endFlash(gen int) {
+	if m.flash.gen == gen {
+		m.flash.keys = nil
+	}
+}
@@ -0,0 +1,163 @@
+package ui
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shhac/prtea/internal/config"
+	"github.com/shhac/prtea/internal/github"
+)
+
+func polledPR(number int, title string, updated time.Time) github.PRItem {
+	return github.PRItem{
+		Number: number, Title: title, UpdatedAt: updated,
+		Repo: github.Repo{Owner: "shhac", Name: "prtea", FullName: "shhac/prtea"},
+	}
+}
+
+func TestPolledLists_DeltasAndFullPolls(t *testing.T) {
+	t0 := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	var p polledLists
+	if !p.since().IsZero() {
+		t.Fatal("the first poll should be a full one")
+	}
+	p.record([]github.PRItem{polledPR(1, "one", t0), polledPR(2, "two", t0.Add(time.Minute))}, nil)
+	if got := p.since(); !got.Equal(t0.Add(time.Minute)) {
+		t.Fatalf("cursor = %v, want the latest updatedAt", got)
+	}
+
+	toReview, _ := p.merge([]github.PRItem{polledPR(2, "two v2", t0.Add(time.Hour)), polledPR(3, "three", t0.Add(time.Hour))}, nil)
+	if len(toReview) != 3 || toReview[0].Number != 3 || toReview[2].Title != "two v2" {
+		t.Errorf("merged = %+v, want the new PR first and #2 replaced in place", toReview)
+	}
+	if !p.since().Equal(t0.Add(time.Hour)) {
+		t.Errorf("cursor = %v after the delta", p.since())
+	}
+
+	// updated:>= the cursor returns the PRs updated at the cursor again.
+	again := []github.PRItem{polledPR(2, "two v2", t0.Add(time.Hour)), polledPR(3, "three", t0.Add(time.Hour))}
+	if toReview, mine := p.dropUnchanged(again, nil); len(toReview) != 0 || len(mine) != 0 {
+		t.Errorf("dropUnchanged = %+v, %+v; want nothing for PRs seen at the cursor", toReview, mine)
+	}
+	later := []github.PRItem{polledPR(3, "three", t0.Add(time.Hour)), polledPR(4, "four", t0.Add(time.Hour))}
+	if toReview, _ := p.dropUnchanged(later, nil); len(toReview) != 1 || toReview[0].Number != 4 {
+		t.Errorf("dropUnchanged = %+v, want the PR updated in the cursor's second kept", toReview)
+	}
+
+	for p.deltas < fullPollEvery-1 {
+		p.merge(nil, nil)
+	}
+	if !p.since().IsZero() {
+		t.Error("every fullPollEvery-th poll should fetch the full lists")
+	}
+}
+
+func TestPRList_AutoMergeBadgeSurvivesRefreshes(t *testing.T) {
+	t0 := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	m := App{
+		statusBar: NewStatusBarModel(),
+		prList:    NewPRListModel(TabMyPRs),
+		knownPRs:  make(map[string]bool),
+		appConfig: &config.Config{},
+	}
+	one, two := polledPR(1, "one", t0), polledPR(2, "two", t0)
+	one.AutoMerge = github.MergeMethodSquash
+	model, _ := m.Update(PRsLoadedMsg{MyPRs: []github.PRItem{one, two}, Batched: true})
+	m = model.(App)
+	autoMerge := func(m App, number int) string {
+		for _, it := range m.prList.myPRs {
+			if pr := it.(PRItem); pr.number == number {
+				return pr.autoMerge
+			}
+		}
+		return "missing"
+	}
+	if got := autoMerge(m, 1); got != github.MergeMethodSquash {
+		t.Fatalf("badge from the list query = %q", got)
+	}
+
+	// The REST fallback doesn't report auto-merge: keep what the list shows.
+	model, _ = m.Update(pollPRsLoadedMsg{MyPRs: []github.PRItem{polledPR(1, "one", t0), two}})
+	m = model.(App)
+	if got := autoMerge(m, 1); got != github.MergeMethodSquash {
+		t.Errorf("badge after a REST refresh = %q", got)
+	}
+
+	// A delta poll reports the PRs it returns; the others keep their badge.
+	two.UpdatedAt, two.AutoMerge = t0.Add(time.Hour), github.MergeMethodMerge
+	model, _ = m.Update(pollPRsLoadedMsg{MyPRs: []github.PRItem{two}, Batched: true, Delta: true})
+	m = model.(App)
+	if autoMerge(m, 1) != github.MergeMethodSquash || autoMerge(m, 2) != github.MergeMethodMerge {
+		t.Errorf("badges after a delta = %q, %q", autoMerge(m, 1), autoMerge(m, 2))
+	}
+
+	// A full GraphQL refresh is authoritative.
+	model, _ = m.Update(pollPRsLoadedMsg{MyPRs: []github.PRItem{polledPR(1, "one", t0)}, Batched: true})
+	if got := autoMerge(model.(App), 1); got != "" {
+		t.Errorf("badge after auto-merge was turned off = %q", got)
+	}
+}
+
+func TestPoll_DeltaFlashesChangedRows(t *testing.T) {
+	t0 := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	var args string
+	client := github.NewTestClient("me", func(ctx context.Context, a ...string) (string, error) {
+		args = strings.Join(a, " ")
+		return `{"data": {"toReview": {"nodes": [{"number": 2, "title": "two v2", "updatedAt": "2026-10-01T13:00:00Z",
+			"repository": {"name": "prtea", "nameWithOwner": "shhac/prtea"}}]}, "mine": {"nodes": []}}}`, nil
+	})
+	m := App{
+		statusBar: NewStatusBarModel(),
+		prList:    NewPRListModel(TabToReview),
+		knownPRs:  make(map[string]bool),
+		appConfig: &config.Config{},
+	}
+	full := []github.PRItem{polledPR(1, "one", t0), polledPR(2, "two", t0)}
+	model, _ := m.Update(PRsLoadedMsg{ToReview: full})
+	m = model.(App)
+
+	msg := pollFetchPRsCmd(client, m.polled.since())().(pollPRsLoadedMsg)
+	if !msg.Delta || !strings.Contains(args, "updated:>=2026-10-01T12:00:00Z") {
+		t.Fatalf("delta = %v, args %q", msg.Delta, args)
+	}
+	model, cmd := m.Update(msg)
+	m = model.(App)
+	if cmd == nil {
+		t.Fatal("expected a command ending the highlight")
+	}
+	items := m.prList.activeItems()
+	if len(items) != 2 || items[1].(PRItem).title != "two v2" {
+		t.Fatalf("items = %+v", items)
+	}
+	if keys := m.prList.flash.keys; len(keys) != 1 || !keys["shhac/prtea#2"] {
+		t.Errorf("flashed = %v, want only #2", keys)
+	}
+
+	model, _ = m.Update(prFlashDoneMsg{gen: m.prList.flash.gen})
+	if keys := model.(App).prList.flash.keys; len(keys) != 0 {
+		t.Errorf("flash not cleared: %v", keys)
+	}
+
+	// The next poll asks for updated:>= the new cursor, which returns #2
+	// again unchanged: nothing to merge, flash or notify.
+	msg = pollFetchPRsCmd(client, m.polled.since())().(pollPRsLoadedMsg)
+	if !strings.Contains(args, "updated:>=2026-10-01T13:00:00Z") || len(msg.ToReview) != 1 {
+		t.Fatalf("args %q, delta %+v", args, msg.ToReview)
+	}
+	model, cmd = m.Update(msg)
+	m = model.(App)
+	if cmd != nil || m.prList.flash.keys != nil {
+		t.Errorf("unchanged poll: cmd %v, flashed %v", cmd, m.prList.flash.keys)
+	}
+	if m.polled.deltas != 2 {
+		t.Errorf("deltas = %d, want the unchanged poll counted", m.polled.deltas)
+	}
+
+	// A delta with nothing in it leaves the list alone.
+	model, cmd = m.Update(pollPRsLoadedMsg{Delta: true, Batched: true})
+	if cmd != nil || model.(App).polled.deltas != 3 {
+		t.Errorf("empty delta: cmd %v, deltas %d", cmd, model.(App).polled.deltas)
+	}
+}
@@ -0,0 +1,79 @@
+package ui
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/shhac/prtea/internal/config"
+	"github.com/shhac/prtea/internal/github"
+)
+
+// newPRCache opens the cache of fetched PR data, dropping expired entries,
+// or returns nil when the config turns it off.
+func newPRCache(cfg *config.Config) *github.PRCache {
+	ttl := cfg.PRCacheTTL()
+	if ttl <= 0 {
+		return nil
+	}
+	cache := github.NewPRCache(config.PRCacheDir(), ttl)
+	cache.Prune()
+	return cache
+}
+
+// showCachedPR fills a freshly selected PR's panels from the PR cache, so
+// it renders at once instead of blank while its data is fetched. The fresh
+// data replaces it as it arrives. Returns a status hint, or nil on a miss.
+func (m *App) showCachedPR() tea.Cmd {
+	s := m.session
+	if m.prCache == nil || s == nil || s.UpdatedAt.IsZero() {
+		return nil
+	}
+	cached := m.prCache.Get(s.Owner, s.Repo, s.Number, s.UpdatedAt)
+	if cached == nil {
+		return nil
+	}
+	s.CachedFiles = cached.Files != nil
+	if cached.Files != nil {
+		m.diffViewer.SetDiff(cached.Files)
+		m.chatPanel.SetCitationFiles(citationFiles(cached.Files))
+		s.DiffFiles = cached.Files
+	}
+	if cached.Comments != nil || cached.InlineComments != nil {
+		m.chatPanel.SetComments(cached.Comments, cached.InlineComments)
+		m.diffViewer.SetGitHubInlineComments(cached.InlineComments)
+	}
+	if cached.CI != nil {
+		m.diffViewer.SetCIStatus(cached.CI)
+		m.prList.SetCIStatus(cached.CI.OverallStatus)
+		m.statusBar.SetCIStatus(cached.CI.OverallStatus)
+	}
+	if cached.Reviews != nil {
+		m.diffViewer.SetReviewSummary(cached.Reviews)
+		m.prList.SetReviewDecision(cached.Reviews.ReviewDecision)
+	}
+	return m.statusBar.SetTemporaryMessage(fmt.Sprintf("PR #%d from cache (%s old), refreshing...", s.Number, waitAge(time.Since(cached.CachedAt))), 3*time.Second)
+}
+
+// cachePRCmd returns a command saving part of a PR's freshly fetched data to
+// the PR cache, under the updatedAt it was listed with.
+func (m App) cachePRCmd(number int, update func(*github.CachedPR)) tea.Cmd {
+	s := m.session
+	if m.prCache == nil || !s.MatchesPR(number) || s.UpdatedAt.IsZero() {
+		return nil
+	}
+	cache, owner, repo, updatedAt := m.prCache, s.Owner, s.Repo, s.UpdatedAt
+	return func() tea.Msg {
+		_ = cache.Update(owner, repo, number, updatedAt, update) // best-effort
+		return nil
+	}
+}
+
+// sameAsCachedDiff reports whether a fetched diff matches the cached one
+// already shown, so it can be kept as is rather than reset under the
+// reader's cursor.
+func (m App) sameAsCachedDiff(files []github.PRFile) bool {
+	s := m.session
+	return s != nil && s.CachedFiles && slices.Equal(s.DiffFiles, files)
+}
@@ -0,0 +1,77 @@
+package ui
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/shhac/prtea/internal/github"
+)
+
+func TestPRCache_ShowsCachedDataAndKeepsPlace(t *testing.T) {
+	updated := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	files := []github.PRFile{
+		{Filename: "a.go", Status: "modified", Patch: "@@ -1,3 +1,4 @@\n one\n+two\n three\n four"},
+	}
+	cache := github.NewPRCache(filepath.Join(t.TempDir(), "prs"), time.Hour)
+
+	// A first visit caches what it fetches.
+	m := commentNavTestApp()
+	m.prList = NewPRListModel(TabToReview)
+	m.prCache = cache
+	m.session.Owner, m.session.Repo, m.session.UpdatedAt = "shhac", "prtea", updated
+	m.diffViewer.prNumber = 1
+	model, cmd := m.Update(DiffLoadedMsg{PRNumber: 1, Files: files})
+	m = model.(App)
+	runCmds(cmd)
+	model, cmd = m.Update(ReviewsLoadedMsg{PRNumber: 1, Summary: &github.ReviewSummary{ReviewDecision: "APPROVED"}})
+	m = model.(App)
+	runCmds(cmd)
+
+	// Selecting it again renders the cached diff before anything is fetched.
+	m = commentNavTestApp()
+	m.prList = NewPRListModel(TabToReview)
+	m.prCache = cache
+	m.session.Owner, m.session.Repo, m.session.UpdatedAt = "shhac", "prtea", updated
+	m.diffViewer.SetLoading(1)
+	if m.showCachedPR() == nil {
+		t.Fatal("expected a cache hit")
+	}
+	if len(m.diffViewer.files) != 1 || m.diffViewer.loading || !m.session.CachedFiles {
+		t.Fatalf("cached diff not shown: %d files, loading %v", len(m.diffViewer.files), m.diffViewer.loading)
+	}
+	if !strings.Contains(m.statusBar.statusMessage, "from cache") {
+		t.Errorf("status = %q", m.statusBar.statusMessage)
+	}
+	if m.diffViewer.reviewSummary == nil || m.diffViewer.reviewSummary.ReviewDecision != "APPROVED" {
+		t.Errorf("cached reviews not shown: %+v", m.diffViewer.reviewSummary)
+	}
+
+	// The same diff arriving fresh leaves the cursor where the reader put it.
+	m.diffViewer.placeCursor(3)
+	model, _ = m.Update(DiffLoadedMsg{PRNumber: 1, Files: files})
+	m = model.(App)
+	if m.diffViewer.cursorLine != 3 || m.session.CachedFiles {
+		t.Errorf("cursor = %d after an unchanged refetch, want 3", m.diffViewer.cursorLine)
+	}
+
+	// A PR updated since is a miss.
+	if cache.Get("shhac", "prtea", 1, updated.Add(time.Minute)) != nil {
+		t.Error("a newer updatedAt should miss the cache")
+	}
+}
+
+// runCmds runs a command and any commands batched in it, ignoring their
+// messages.
+func runCmds(cmd tea.Cmd) {
+	if cmd == nil {
+		return
+	}
+	if batch, ok := cmd().(tea.BatchMsg); ok {
+		for _, c := range batch {
+			runCmds(c)
+		}
+	}
+}
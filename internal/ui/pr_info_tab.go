@@ -5,6 +5,7 @@ import (
 	"strings"
 
 	"github.com/charmbracelet/lipgloss"
+	"github.com/shhac/prtea/internal/claude"
 	"github.com/shhac/prtea/internal/github"
 )
 
@@ -26,6 +27,31 @@ func (m *DiffViewerModel) SetPRInfoError(err string) {
 	m.refreshContent()
 }
 
+// SetPRSummaryLoading shows that an AI summary is being generated.
+func (m *DiffViewerModel) SetPRSummaryLoading() {
+	m.prSummaryLoading = true
+	m.prSummaryErr = ""
+	m.prInfoCache = ""
+	m.refreshContent()
+}
+
+// SetPRSummary sets the AI summary shown at the top of the PR Info tab.
+func (m *DiffViewerModel) SetPRSummary(summary *claude.PRSummary) {
+	m.prSummary = summary
+	m.prSummaryLoading = false
+	m.prSummaryErr = ""
+	m.prInfoCache = ""
+	m.refreshContent()
+}
+
+// SetPRSummaryError shows why the AI summary failed.
+func (m *DiffViewerModel) SetPRSummaryError(err string) {
+	m.prSummaryLoading = false
+	m.prSummaryErr = err
+	m.prInfoCache = ""
+	m.refreshContent()
+}
+
 // SetReviewSummary sets review status data for the PR Info tab.
 func (m *DiffViewerModel) SetReviewSummary(summary *github.ReviewSummary) {
 	m.reviewSummary = summary
@@ -55,7 +81,7 @@ func (m *DiffViewerModel) renderPRInfo() string {
 
 	if m.prTitle == "" {
 		return lipgloss.NewStyle().
-			Foreground(lipgloss.Color("244")).
+			Foreground(theme.Muted).
 			Padding(1, 2).
 			Render(m.spinner.View() + fmt.Sprintf(" Loading PR #%d info...", m.prNumber))
 	}
@@ -71,6 +97,7 @@ func (m *DiffViewerModel) renderPRInfo() string {
 	}
 
 	var b strings.Builder
+	var links linkHints
 
 	// Title
 	b.WriteString(sectionHeaderStyle.Render(fmt.Sprintf("PR #%d", m.prNumber)))
@@ -78,6 +105,25 @@ func (m *DiffViewerModel) renderPRInfo() string {
 	b.WriteString(boldStyle.Render(m.prTitle))
 	b.WriteString("\n\n")
 
+	b.WriteString(m.renderPRSummary(innerWidth))
+
+	// Merge state
+	if m.mergeState != "" {
+		label, color := mergeStateLabel(m.mergeState)
+		b.WriteString(lipgloss.NewStyle().Bold(true).Foreground(color).Render(label))
+		b.WriteString("\n")
+	}
+	if m.autoMerge != "" {
+		b.WriteString(lipgloss.NewStyle().Bold(true).Foreground(theme.Success).
+			Render(fmt.Sprintf("⏵ Auto-merge enabled (%s)", autoMergeMethodLabel(m.autoMerge))))
+		b.WriteString("\n")
+	}
+	if m.mergeMessage != "" {
+		b.WriteString(dimStyle.Render("Squash message: "))
+		b.WriteString(m.mergeMessage)
+		b.WriteString("\n")
+	}
+
 	// Author
 	b.WriteString(dimStyle.Render("Author: "))
 	b.WriteString(m.prAuthor)
@@ -90,6 +136,15 @@ func (m *DiffViewerModel) renderPRInfo() string {
 		b.WriteString("\n")
 	}
 
+	b.WriteString(m.renderStack(innerWidth))
+
+	// Merge readiness
+	b.WriteString("\n")
+	b.WriteString(m.renderMergeReadiness())
+
+	b.WriteString(m.renderSecurity(innerWidth))
+	b.WriteString(m.renderDeployments(innerWidth))
+
 	// Reviews
 	if m.reviewError != "" {
 		b.WriteString("\n")
@@ -105,24 +160,24 @@ func (m *DiffViewerModel) renderPRInfo() string {
 		// Overall decision badge
 		if m.reviewSummary.ReviewDecision != "" {
 			icon, color := reviewDecisionIconColor(m.reviewSummary.ReviewDecision)
-			badge := lipgloss.NewStyle().Foreground(lipgloss.Color(color)).Render(icon)
+			badge := lipgloss.NewStyle().Foreground(color).Render(icon)
 			label := reviewDecisionLabel(m.reviewSummary.ReviewDecision)
 			b.WriteString(fmt.Sprintf("%s %s\n", badge, label))
 		}
 
 		// Per-reviewer status
 		for _, r := range m.reviewSummary.Approved {
-			approvedIcon := lipgloss.NewStyle().Foreground(lipgloss.Color("76")).Render("✓")
-			b.WriteString(fmt.Sprintf("  %s %s approved\n", approvedIcon, r.Author.Login))
+			approvedIcon := lipgloss.NewStyle().Foreground(theme.Success).Render("✓")
+			b.WriteString(fmt.Sprintf("  %s %s approved%s\n", approvedIcon, r.Author.Login, reviewAge(r)))
 		}
 		for _, r := range m.reviewSummary.ChangesRequested {
-			changesIcon := lipgloss.NewStyle().Foreground(lipgloss.Color("196")).Render("✗")
-			b.WriteString(fmt.Sprintf("  %s %s requested changes\n", changesIcon, r.Author.Login))
+			changesIcon := lipgloss.NewStyle().Foreground(theme.Error).Render("✗")
+			b.WriteString(fmt.Sprintf("  %s %s requested changes%s\n", changesIcon, r.Author.Login, reviewAge(r)))
 		}
 
 		// Pending reviewers
 		for _, rr := range m.reviewSummary.PendingReviewers {
-			pendingIcon := lipgloss.NewStyle().Foreground(lipgloss.Color("214")).Render("○")
+			pendingIcon := lipgloss.NewStyle().Foreground(theme.Warning).Render("○")
 			name := rr.Login
 			if rr.IsTeam {
 				name += " (team)"
@@ -141,8 +196,11 @@ func (m *DiffViewerModel) renderPRInfo() string {
 	if m.prBody != "" {
 		b.WriteString("\n")
 		b.WriteString(sectionHeaderStyle.Render("Description"))
+		if tasks := parseTasks(m.prBody); len(tasks) > 0 {
+			b.WriteString(dimStyle.Render("  " + taskProgress(tasks) + " · n/N pick · Space toggle"))
+		}
 		b.WriteString("\n")
-		b.WriteString(m.renderMarkdown(m.prBody, innerWidth))
+		b.WriteString(m.renderMarkdown(links.annotate(renderTaskGlyphs(m.prBody, m.taskCursor)), innerWidth))
 	} else {
 		b.WriteString("\n")
 		b.WriteString(dimStyle.Render("No description provided."))
@@ -151,20 +209,42 @@ func (m *DiffViewerModel) renderPRInfo() string {
 	result := b.String()
 	m.prInfoCache = result
 	m.prInfoCacheWidth = innerWidth
+	m.links = links.links
 	return result
 }
 
+// renderPRSummary renders the AI summary section, if there is one.
+func (m *DiffViewerModel) renderPRSummary(width int) string {
+	var body string
+	switch {
+	case m.prSummaryLoading:
+		body = dimStyle.Render(m.spinner.View() + " Summarizing...")
+	case m.prSummaryErr != "":
+		body = errTextStyle.Render(formatUserError(m.prSummaryErr))
+	case m.prSummary != nil:
+		var lines []string
+		for _, bullet := range m.prSummary.Bullets {
+			wrapped := wordWrap(bullet, max(10, width-2))
+			lines = append(lines, "• "+strings.ReplaceAll(wrapped, "\n", "\n  "))
+		}
+		body = strings.Join(lines, "\n")
+	default:
+		return ""
+	}
+	return sectionHeaderStyle.Render("AI Summary") + "\n" + body + "\n\n"
+}
+
 // reviewDecisionIconColor returns the icon and lipgloss color for a review decision.
-func reviewDecisionIconColor(decision string) (string, string) {
+func reviewDecisionIconColor(decision string) (string, lipgloss.Color) {
 	switch decision {
 	case "APPROVED":
-		return "✓", "76"
+		return "✓", theme.Success
 	case "CHANGES_REQUESTED":
-		return "✗", "196"
+		return "✗", theme.Error
 	case "REVIEW_REQUIRED":
-		return "○", "214"
+		return "○", theme.Warning
 	default:
-		return "?", "244"
+		return "?", theme.Muted
 	}
 }
 
@@ -181,3 +261,11 @@ func reviewDecisionLabel(decision string) string {
 		return decision
 	}
 }
+
+// reviewAge returns " · 3h ago" for a submitted review, dimmed.
+func reviewAge(r github.Review) string {
+	if at := formatTimestamp(r.SubmittedAt); at != "" {
+		return dimStyle.Render(" · " + at)
+	}
+	return ""
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"io"
 	"strings"
+	"time"
 
 	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/list"
@@ -11,6 +12,7 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/charmbracelet/x/ansi"
+	"github.com/shhac/prtea/internal/claude"
 )
 
 // PRListTab identifies which sub-tab is active.
@@ -40,7 +42,17 @@ type PRItem struct {
 	author         string
 	htmlURL        string
 	reviewDecision string // "APPROVED", "CHANGES_REQUESTED", "REVIEW_REQUIRED", ""
+	autoMerge      string // enabled auto-merge method, "" when off or not yet known
 	isDraft        bool
+	baseBranch     string // "" when not fetched
+	headBranch     string // "" when not fetched
+	stackedOn      int    // listed PR whose head branch this PR is based on, 0 for none
+	updatedAt      time.Time
+}
+
+// key identifies the PR across tabs, as "owner/repo#number".
+func (i PRItem) key() string {
+	return fmt.Sprintf("%s/%s#%d", i.owner, i.repo, i.number)
 }
 
 func (i PRItem) FilterValue() string {
@@ -48,6 +60,9 @@ func (i PRItem) FilterValue() string {
 }
 func (i PRItem) Title() string       { return fmt.Sprintf("#%d %s", i.number, i.title) }
 func (i PRItem) Description() string {
+	if i.stackedOn != 0 {
+		return fmt.Sprintf("%s · %s · on #%d", i.author, i.repo, i.stackedOn)
+	}
 	return fmt.Sprintf("%s · %s", i.author, i.repo)
 }
 
@@ -58,6 +73,16 @@ type prItemDelegate struct {
 	selectedPRNumber *int    // points to PRListModel.selectedPRNumber
 	ciOverallStatus  *string // points to PRListModel.ciOverallStatus
 	reviewDecision   *string // points to PRListModel.reviewDecision
+	marks            *prMarks
+	flash            *prFlash
+	triage           map[string]claude.PRTriage // points to PRListModel.triage
+}
+
+// prMarks holds the rows marked for a batch action (v, then Space).
+// Heap-allocated so the delegate's pointer survives value copies.
+type prMarks struct {
+	on   bool            // mark mode: Space marks rows instead of selecting
+	keys map[string]bool // PRItem.key() of marked PRs
 }
 
 func (d prItemDelegate) Height() int                             { return 2 }
@@ -75,6 +100,16 @@ func (d prItemDelegate) Render(w io.Writer, m list.Model, index int, item list.I
 
 	title := i.Title()
 	desc := i.Description()
+	if age := formatTimestamp(i.updatedAt); age != "" {
+		desc += " · " + age
+	}
+	if d.marks != nil && d.marks.on {
+		if d.marks.keys[i.key()] {
+			title = "☑ " + title
+		} else {
+			title = "☐ " + title
+		}
+	}
 
 	isCursor := index == m.Index()
 	isActive := d.selectedPRNumber != nil && *d.selectedPRNumber != 0 && i.number == *d.selectedPRNumber
@@ -98,11 +133,24 @@ func (d prItemDelegate) Render(w io.Writer, m list.Model, index int, item list.I
 		badges += b
 		badgeWidth += w
 	}
+	if i.autoMerge != "" {
+		b := " " + lipgloss.NewStyle().Foreground(theme.Success).Render("auto")
+		badges += b
+		badgeWidth += 5
+	}
 	if i.isDraft {
-		b := " " + lipgloss.NewStyle().Foreground(lipgloss.Color("243")).Render("draft")
+		b := " " + lipgloss.NewStyle().Foreground(theme.Muted).Render("draft")
 		badges += b
 		badgeWidth += 6
 	}
+	if t, ok := d.triage[i.key()]; ok {
+		b, w := triageBadgeForList(t.Tag)
+		badges += b
+		badgeWidth += w
+		if t.Summary != "" {
+			desc += " · " + t.Summary
+		}
+	}
 
 	// Truncate text to fit — leave 2 chars for prefix (▸ or padding)
 	textWidth := m.Width() - 4
@@ -121,11 +169,11 @@ func (d prItemDelegate) Render(w io.Writer, m list.Model, index int, item list.I
 		// Cursor on the active/loaded PR: left border + accent color
 		titleStyle := lipgloss.NewStyle().
 			Border(lipgloss.NormalBorder(), false, false, false, true).
-			BorderForeground(lipgloss.Color("62")).
-			Foreground(lipgloss.Color("62")).
+			BorderForeground(theme.Accent).
+			Foreground(theme.Accent).
 			Bold(true).
 			Padding(0, 0, 0, 1)
-		descStyle := titleStyle.Bold(false).Foreground(lipgloss.Color("99"))
+		descStyle := titleStyle.Bold(false).Foreground(theme.Focus)
 		title = titleStyle.Render(title)
 		desc = descStyle.Render(desc)
 	case isCursor:
@@ -140,9 +188,9 @@ func (d prItemDelegate) Render(w io.Writer, m list.Model, index int, item list.I
 		desc = descStyle.Render(desc)
 	case isActive:
 		// Active/loaded PR without cursor: ▸ marker in accent color
-		marker := lipgloss.NewStyle().Foreground(lipgloss.Color("62")).Bold(true).Render("▸ ")
-		titleStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("252")).Bold(true)
-		descStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("244")).Padding(0, 0, 0, 2)
+		marker := lipgloss.NewStyle().Foreground(theme.Accent).Bold(true).Render("▸ ")
+		titleStyle := lipgloss.NewStyle().Foreground(theme.Text).Bold(true)
+		descStyle := lipgloss.NewStyle().Foreground(theme.Muted).Padding(0, 0, 0, 2)
 		title = marker + titleStyle.Render(title)
 		desc = descStyle.Render(desc)
 	default:
@@ -151,6 +199,10 @@ func (d prItemDelegate) Render(w io.Writer, m list.Model, index int, item list.I
 			Foreground(lipgloss.AdaptiveColor{Light: "#1a1a1a", Dark: "#dddddd"}).
 			Padding(0, 0, 0, 2)
 		descStyle := titleStyle.Foreground(lipgloss.AdaptiveColor{Light: "#A49FA5", Dark: "#777777"})
+		if d.flash != nil && d.flash.keys[i.key()] {
+			// Changed by the last poll
+			titleStyle = titleStyle.Foreground(theme.Warning).Bold(true)
+		}
 		title = titleStyle.Render(title)
 		desc = descStyle.Render(desc)
 	}
@@ -177,22 +229,46 @@ type PRListModel struct {
 	// Review decision for the selected PR (heap-allocated, shared with delegate).
 	reviewDecision *string
 
+	// Rows marked for batch actions (heap-allocated, shared with delegate).
+	marks *prMarks
+
+	// Rows the last poll changed, briefly highlighted (heap-allocated, shared with delegate).
+	flash *prFlash
+
+	// AI triage of listed PRs by key (shared with delegate).
+	triage map[string]claude.PRTriage
+
+	// Snoozed PRs by key, hidden from both tabs until the time passes
+	snoozed map[string]time.Time
+
 	// Data state
 	state    loadState
 	errMsg   string
 	toReview []list.Item
 	myPRs    []list.Item
+
+	// "Recently closed" filter on My PRs, fetched the first time it is shown
+	showClosed   bool
+	closed       []list.Item
+	closedLoaded bool
+	closedErr    string
 }
 
 func NewPRListModel(defaultTab PRListTab) PRListModel {
 	selected := new(int)       // heap-allocated, shared with delegate
 	ciStatus := new(string)    // heap-allocated, shared with delegate
 	reviewDec := new(string)   // heap-allocated, shared with delegate
+	marks := &prMarks{keys: make(map[string]bool)}
+	flash := &prFlash{}
+	triage := make(map[string]claude.PRTriage)
 
 	delegate := prItemDelegate{
 		selectedPRNumber: selected,
 		ciOverallStatus:  ciStatus,
 		reviewDecision:   reviewDec,
+		marks:            marks,
+		flash:            flash,
+		triage:           triage,
 	}
 
 	l := list.New(nil, delegate, 0, 0)
@@ -212,9 +288,27 @@ func NewPRListModel(defaultTab PRListTab) PRListModel {
 		selectedPRNumber: selected,
 		ciOverallStatus:  ciStatus,
 		reviewDecision:   reviewDec,
+		marks:            marks,
+		flash:            flash,
+		triage:           triage,
+		snoozed:          make(map[string]time.Time),
 	}
 }
 
+// findPR looks a PR up in both tabs by number, and by owner and repo unless
+// they are "".
+func (m PRListModel) findPR(owner, repo string, number int) (PRItem, bool) {
+	for _, items := range [][]list.Item{m.toReview, m.myPRs, m.closed} {
+		for _, it := range items {
+			pr, ok := it.(PRItem)
+			if ok && pr.number == number && (owner == "" || pr.owner == owner) && (repo == "" || pr.repo == repo) {
+				return pr, true
+			}
+		}
+	}
+	return PRItem{}, false
+}
+
 // SetSelectedPR marks which PR is currently loaded in the diff/chat panels.
 func (m *PRListModel) SetSelectedPR(number int) {
 	*m.selectedPRNumber = number
@@ -248,48 +342,118 @@ func (m *PRListModel) UpdateReviewDecisions(decisions map[string]string) {
 
 	// Refresh active tab if not filtering
 	if !m.HasActiveFilter() {
-		switch m.activeTab {
-		case TabToReview:
-			m.list.SetItems(m.toReview)
-		case TabMyPRs:
-			m.list.SetItems(m.myPRs)
+		m.list.SetItems(m.activeItems())
+	}
+}
+
+// SetAutoMerge records a PR's auto-merge method ("" when off) for its list
+// badge, as PR details load or the user toggles it.
+func (m *PRListModel) SetAutoMerge(owner, repo string, number int, method string) {
+	m.updatePR(owner, repo, number, func(pr *PRItem) bool {
+		changed := pr.autoMerge != method
+		pr.autoMerge = method
+		return changed
+	})
+}
+
+// keepAutoMerge copies the listed PRs' auto-merge badges onto items fetched
+// without auto-merge: from the REST fallback, or the PRs a delta poll didn't
+// return. Items whose key is in fresh came with it and are left alone.
+func (m PRListModel) keepAutoMerge(items []list.Item, fresh map[string]bool) {
+	known := make(map[string]string)
+	for _, tab := range [][]list.Item{m.toReview, m.myPRs} {
+		for _, it := range tab {
+			if pr, ok := it.(PRItem); ok {
+				known[pr.key()] = pr.autoMerge
+			}
+		}
+	}
+	for i, it := range items {
+		pr, ok := it.(PRItem)
+		if !ok || fresh[pr.key()] {
+			continue
+		}
+		if method, ok := known[pr.key()]; ok {
+			pr.autoMerge = method
+			items[i] = pr
+		}
+	}
+}
+
+// SetDraft records whether a PR is a draft for its list badge, after the
+// user converts it or its detail shows a change.
+func (m *PRListModel) SetDraft(owner, repo string, number int, draft bool) {
+	m.updatePR(owner, repo, number, func(pr *PRItem) bool {
+		changed := pr.isDraft != draft
+		pr.isDraft = draft
+		return changed
+	})
+}
+
+// updatePR applies update to a PR in both tabs; update reports whether it
+// changed anything.
+func (m *PRListModel) updatePR(owner, repo string, number int, update func(*PRItem) bool) {
+	updateItems := func(items []list.Item) bool {
+		changed := false
+		for i, item := range items {
+			if pr, ok := item.(PRItem); ok && pr.owner == owner && pr.repo == repo && pr.number == number && update(&pr) {
+				items[i] = pr
+				changed = true
+			}
+		}
+		return changed
+	}
+	changed := updateItems(m.toReview)
+	changed = updateItems(m.myPRs) || changed
+	changed = updateItems(m.closed) || changed
+	if !changed {
+		return
+	}
+
+	// Refresh visible items in place so an active filter is preserved
+	for i, item := range m.list.Items() {
+		if pr, ok := item.(PRItem); ok && pr.owner == owner && pr.repo == repo && pr.number == number {
+			update(&pr)
+			m.list.SetItem(i, pr)
 		}
 	}
 }
 
 // ciBadgeForList returns a styled CI badge string and its visual width for the PR list.
 func ciBadgeForList(status string) (string, int) {
-	var icon, color string
+	var icon string
+	var color lipgloss.Color
 	switch status {
 	case "passing":
-		icon, color = "✓", "42"
+		icon, color = "✓", theme.Success
 	case "failing":
-		icon, color = "✗", "196"
+		icon, color = "✗", theme.Error
 	case "pending":
-		icon, color = "●", "226"
+		icon, color = "●", theme.Highlight
 	case "mixed":
-		icon, color = "⚠", "208"
+		icon, color = "⚠", theme.Warning
 	default:
 		return "", 0
 	}
-	styled := " " + lipgloss.NewStyle().Foreground(lipgloss.Color(color)).Render(icon)
+	styled := " " + lipgloss.NewStyle().Foreground(color).Render(icon)
 	return styled, 2
 }
 
 // reviewBadgeForList returns a styled review badge string and its visual width for the PR list.
 func reviewBadgeForList(decision string) (string, int) {
-	var icon, color string
+	var icon string
+	var color lipgloss.Color
 	switch decision {
 	case "APPROVED":
-		icon, color = "✓", "76"
+		icon, color = "✓", theme.Success
 	case "CHANGES_REQUESTED":
-		icon, color = "✗", "196"
+		icon, color = "✗", theme.Error
 	case "REVIEW_REQUIRED":
-		icon, color = "○", "214"
+		icon, color = "○", theme.Warning
 	default:
 		return "", 0
 	}
-	styled := " " + lipgloss.NewStyle().Foreground(lipgloss.Color(color)).Render(icon)
+	styled := " " + lipgloss.NewStyle().Foreground(color).Render(icon)
 	return styled, 2
 }
 
@@ -307,36 +471,97 @@ func (m *PRListModel) SetError(err string) {
 
 // SetItems populates both tab datasets and switches to the loaded state.
 func (m *PRListModel) SetItems(toReview, myPRs []list.Item) {
-	m.toReview = toReview
-	m.myPRs = myPRs
+	markStacked(toReview, myPRs)
+	m.toReview = m.withoutSnoozed(toReview)
+	m.myPRs = m.withoutSnoozed(myPRs)
 	m.state = stateLoaded
 	m.errMsg = ""
 
 	// Show the active tab's data
-	switch m.activeTab {
-	case TabToReview:
-		m.list.SetItems(m.toReview)
-	case TabMyPRs:
-		m.list.SetItems(m.myPRs)
+	m.list.SetItems(m.activeItems())
+}
+
+// activeItems returns the items shown for the active tab and filter.
+func (m PRListModel) activeItems() []list.Item {
+	switch {
+	case m.activeTab == TabToReview:
+		return m.toReview
+	case m.showClosed:
+		return m.closed
+	default:
+		return m.myPRs
 	}
 }
 
+// SetClosed populates the "Recently closed" filter.
+func (m *PRListModel) SetClosed(items []list.Item) {
+	m.closed = items
+	m.closedLoaded = true
+	m.closedErr = ""
+	if m.activeTab == TabMyPRs && m.showClosed {
+		m.list.SetItems(m.closed)
+	}
+}
+
+// SetClosedError records why the "Recently closed" PRs failed to load.
+func (m *PRListModel) SetClosedError(err string) {
+	m.closed = nil
+	m.closedLoaded = true
+	m.closedErr = err
+	if m.activeTab == TabMyPRs && m.showClosed {
+		m.list.SetItems(nil)
+	}
+}
+
+// InvalidateClosed marks the "Recently closed" PRs stale after a PR was
+// closed or reopened. It reports whether the filter is showing, in which
+// case the caller should fetch them again.
+func (m *PRListModel) InvalidateClosed() bool {
+	m.closedLoaded = false
+	return m.activeTab == TabMyPRs && m.showClosed
+}
+
+// toggleClosed switches My PRs between open and recently closed PRs,
+// requesting the closed ones the first time they are shown.
+func (m *PRListModel) toggleClosed() tea.Cmd {
+	m.showClosed = !m.showClosed
+	m.list.ResetFilter()
+	if m.state == stateLoaded {
+		m.list.SetItems(m.activeItems())
+	}
+	return m.requestClosed()
+}
+
+// requestClosed asks for the "Recently closed" PRs when they are shown but
+// not yet loaded, or failed to load.
+func (m PRListModel) requestClosed() tea.Cmd {
+	if m.activeTab != TabMyPRs || !m.showClosed || (m.closedLoaded && m.closedErr == "") {
+		return nil
+	}
+	return func() tea.Msg { return ClosedPRsRequestMsg{} }
+}
+
 // MergeItems updates both tab datasets without disrupting user state.
 // Unlike SetItems, it preserves the cursor position (by PR number),
 // skips the update while a filter is active, and does not change loadState.
-func (m *PRListModel) MergeItems(toReview, myPRs []list.Item) {
+// Rows that are new or changed are highlighted briefly; the returned
+// command ends the highlight.
+func (m *PRListModel) MergeItems(toReview, myPRs []list.Item) tea.Cmd {
 	if m.state != stateLoaded {
-		return
+		return nil
 	}
 
 	// Always update cached data for both tabs
-	m.toReview = toReview
-	m.myPRs = myPRs
+	markStacked(toReview, myPRs)
+	before := append(append([]list.Item(nil), m.toReview...), m.myPRs...)
+	m.toReview = m.withoutSnoozed(toReview)
+	m.myPRs = m.withoutSnoozed(myPRs)
+	flashCmd := m.flashChanged(before, append(append([]list.Item(nil), m.toReview...), m.myPRs...))
 
 	// If a filter is active, don't touch the list — cached data is updated
 	// and will take effect when the filter is cleared or the tab switches.
 	if m.HasActiveFilter() {
-		return
+		return flashCmd
 	}
 
 	// Remember which PR the cursor is on (by number, not index)
@@ -346,13 +571,7 @@ func (m *PRListModel) MergeItems(toReview, myPRs []list.Item) {
 	}
 
 	// Replace items for the active tab
-	var newItems []list.Item
-	switch m.activeTab {
-	case TabToReview:
-		newItems = m.toReview
-	case TabMyPRs:
-		newItems = m.myPRs
-	}
+	newItems := m.activeItems()
 	m.list.SetItems(newItems)
 
 	// Restore cursor to the same PR
@@ -360,12 +579,13 @@ func (m *PRListModel) MergeItems(toReview, myPRs []list.Item) {
 		for i, item := range newItems {
 			if pr, ok := item.(PRItem); ok && pr.number == cursorPRNumber {
 				m.list.Select(i)
-				return
+				return flashCmd
 			}
 		}
 		// PR disappeared from the list — cursor stays at whatever index
 		// bubbles clamped it to (typically the last item if list shrank).
 	}
+	return flashCmd
 }
 
 // IsFiltering returns true when the user is actively typing in the filter input.
@@ -398,13 +618,28 @@ func (m PRListModel) Update(msg tea.Msg) (PRListModel, tea.Cmd) {
 			}
 			break
 		}
+		if m.marking() {
+			switch {
+			case key.Matches(msg, PRListKeys.Select):
+				m.toggleMark()
+				return m, nil
+			case msg.Type == tea.KeyEsc, key.Matches(msg, PRListKeys.Mark):
+				m.ClearMarks()
+				return m, nil
+			}
+		}
 		switch {
+		case key.Matches(msg, PRListKeys.Mark):
+			if m.marks != nil && m.state == stateLoaded {
+				m.marks.on = true
+			}
+			return m, nil
 		case key.Matches(msg, PRListKeys.PrevTab):
 			if m.activeTab == TabMyPRs {
 				m.activeTab = TabToReview
 				m.list.ResetFilter()
 				if m.state == stateLoaded {
-					m.list.SetItems(m.toReview)
+					m.list.SetItems(m.activeItems())
 				}
 			}
 			return m, nil
@@ -413,8 +648,14 @@ func (m PRListModel) Update(msg tea.Msg) (PRListModel, tea.Cmd) {
 				m.activeTab = TabMyPRs
 				m.list.ResetFilter()
 				if m.state == stateLoaded {
-					m.list.SetItems(m.myPRs)
+					m.list.SetItems(m.activeItems())
 				}
+				return m, m.requestClosed()
+			}
+			return m, nil
+		case key.Matches(msg, PRListKeys.ToggleClosed):
+			if m.activeTab == TabMyPRs {
+				return m, m.toggleClosed()
 			}
 			return m, nil
 		case key.Matches(msg, PRListKeys.SelectAndAdvance):
@@ -492,6 +733,9 @@ func (m PRListModel) View() string {
 	if m.HasActiveFilter() && !m.IsFiltering() {
 		sections = append(sections, m.renderFilterBadge())
 	}
+	if m.marking() {
+		sections = append(sections, m.renderMarkBadge())
+	}
 	sections = append(sections, content)
 	inner := lipgloss.JoinVertical(lipgloss.Left, sections...)
 
@@ -509,6 +753,12 @@ func (m PRListModel) renderTabs() string {
 		toReviewLabel = fmt.Sprintf("To Review (%d)", len(m.toReview))
 		myPRsLabel = fmt.Sprintf("My PRs (%d)", len(m.myPRs))
 	}
+	if m.showClosed {
+		myPRsLabel = "Closed"
+		if m.closedLoaded {
+			myPRsLabel = fmt.Sprintf("Closed (%d)", len(m.closed))
+		}
+	}
 
 	if m.activeTab == TabToReview {
 		tabs = append(tabs, activeTabStyle().Render(toReviewLabel))
@@ -523,11 +773,11 @@ func (m PRListModel) renderTabs() string {
 
 func (m PRListModel) renderFilterBadge() string {
 	label := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("214")).
+		Foreground(theme.Warning).
 		Italic(true).
 		Render("▸ filtered")
 	hint := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("240")).
+		Foreground(theme.Subtle).
 		Italic(true).
 		Render("  Esc clear · / edit")
 	return "\n" + label + hint
@@ -535,7 +785,7 @@ func (m PRListModel) renderFilterBadge() string {
 
 func (m PRListModel) renderLoading() string {
 	return lipgloss.NewStyle().
-		Foreground(lipgloss.Color("244")).
+		Foreground(theme.Muted).
 		Padding(1, 2).
 		Render(m.spinner.View() + " Loading PRs...")
 }
@@ -550,7 +800,7 @@ func (m PRListModel) activeTabEmpty() bool {
 	case TabToReview:
 		return len(m.toReview) == 0
 	case TabMyPRs:
-		return len(m.myPRs) == 0
+		return len(m.activeItems()) == 0
 	}
 	return false
 }
@@ -560,7 +810,16 @@ func (m PRListModel) renderEmpty() string {
 	case TabToReview:
 		return renderEmptyState("No PRs awaiting your review", "")
 	case TabMyPRs:
-		return renderEmptyState("You haven't opened any PRs", "")
+		switch {
+		case !m.showClosed:
+			return renderEmptyState("You haven't opened any PRs", "Press c for recently closed")
+		case !m.closedLoaded:
+			return lipgloss.NewStyle().Foreground(theme.Muted).Padding(1, 2).Render(m.spinner.View() + " Loading closed PRs...")
+		case m.closedErr != "":
+			return renderErrorWithHint(formatUserError(m.closedErr), "Press c twice to retry")
+		default:
+			return renderEmptyState("No recently closed PRs", "Press c for open PRs")
+		}
 	}
 	return ""
 }
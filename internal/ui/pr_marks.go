@@ -0,0 +1,123 @@
+package ui
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// marking reports whether the list is in mark mode.
+func (m PRListModel) marking() bool {
+	return m.marks != nil && m.marks.on
+}
+
+// toggleMark marks or unmarks the row under the cursor and moves down.
+func (m *PRListModel) toggleMark() {
+	item, ok := m.list.SelectedItem().(PRItem)
+	if !ok {
+		return
+	}
+	k := item.key()
+	if m.marks.keys[k] {
+		delete(m.marks.keys, k)
+	} else {
+		m.marks.keys[k] = true
+	}
+	m.list.CursorDown()
+}
+
+// ClearMarks unmarks every row and leaves mark mode.
+func (m *PRListModel) ClearMarks() {
+	if m.marks == nil {
+		return
+	}
+	m.marks.on = false
+	clear(m.marks.keys)
+}
+
+// MarkedPRs returns the marked PRs in list order, To Review first.
+func (m PRListModel) MarkedPRs() []PRItem {
+	if m.marks == nil || len(m.marks.keys) == 0 {
+		return nil
+	}
+	var marked []PRItem
+	seen := make(map[string]bool)
+	for _, items := range [][]list.Item{m.toReview, m.myPRs, m.closed} {
+		for _, it := range items {
+			pr, ok := it.(PRItem)
+			if ok && m.marks.keys[pr.key()] && !seen[pr.key()] {
+				seen[pr.key()] = true
+				marked = append(marked, pr)
+			}
+		}
+	}
+	return marked
+}
+
+// Snooze hides PRs from both tabs until a time, unmarking them.
+func (m *PRListModel) Snooze(prs []PRItem, until time.Time) {
+	if m.snoozed == nil {
+		m.snoozed = make(map[string]time.Time)
+	}
+	for _, pr := range prs {
+		m.snoozed[pr.key()] = until
+		if m.marks != nil {
+			delete(m.marks.keys, pr.key())
+		}
+	}
+	m.toReview = m.withoutSnoozed(m.toReview)
+	m.myPRs = m.withoutSnoozed(m.myPRs)
+	if m.state == stateLoaded {
+		m.list.SetItems(m.activeItems())
+	}
+}
+
+// Unsnooze forgets every snooze, returning how many PRs were snoozed. The
+// PRs come back with the next fetch of the lists.
+func (m *PRListModel) Unsnooze() int {
+	n := 0
+	now := time.Now()
+	for k, until := range m.snoozed {
+		if now.Before(until) {
+			n++
+		}
+		delete(m.snoozed, k)
+	}
+	return n
+}
+
+// withoutSnoozed drops snoozed PRs from items, forgetting snoozes that have
+// run out.
+func (m PRListModel) withoutSnoozed(items []list.Item) []list.Item {
+	if len(m.snoozed) == 0 {
+		return items
+	}
+	now := time.Now()
+	kept := make([]list.Item, 0, len(items))
+	for _, it := range items {
+		if pr, ok := it.(PRItem); ok {
+			if until, snoozed := m.snoozed[pr.key()]; snoozed {
+				if now.Before(until) {
+					continue
+				}
+				delete(m.snoozed, pr.key())
+			}
+		}
+		kept = append(kept, it)
+	}
+	return kept
+}
+
+func (m PRListModel) renderMarkBadge() string {
+	label := lipgloss.NewStyle().
+		Foreground(theme.Warning).
+		Italic(true).
+		Render(fmt.Sprintf("▸ %d marked", len(m.marks.keys)))
+	hint := lipgloss.NewStyle().
+		Foreground(theme.Subtle).
+		Italic(true).
+		Render("  Space mark · :batch approve/open/snooze/refresh · Esc done")
+	return "\n" + fitWidth(label+hint, max(1, m.width-4))
+}
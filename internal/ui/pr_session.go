@@ -2,7 +2,9 @@ package ui
 
 import (
 	"context"
+	"time"
 
+	"github.com/shhac/prtea/internal/claude"
 	"github.com/shhac/prtea/internal/github"
 )
 
@@ -15,20 +17,57 @@ type PRSession struct {
 	Number  int
 	Title   string
 	HTMLURL string
+	HeadSHA string // set once the PR detail loads; guards update-branch against racing pushes
+	UpdatedAt time.Time // as listed when selected, keying the PR cache; zero if opened by reference
+	Since   *sinceBaseline // earlier head the user reviewed or viewed, for :since; nil if none
+
+	// Set once the PR detail loads
+	Author     string // PR author login
+	HeadRepo   string // "owner/name" holding the head branch
+	HeadBranch string
+	State      string // "OPEN", "CLOSED" or "MERGED"
+	Draft      bool
+	Stack      []github.StackMember // open PRs stacked with this one, root first; nil if not stacked
 
 	// PR data
 	DiffFiles            []github.PRFile        // stored for analysis context
+	CachedFiles           bool                   // DiffFiles came from the PR cache and have not been refetched yet
 	PendingInlineComments []PendingInlineComment // unified pool of pending comments
+	LastRemap             pendingRemap           // what the last diff refresh did to pending comments, until reported
+	ExcludedFiles         map[string]bool        // files out of scope: their comments stay drafts
+	CodeOwners            []github.CodeOwnerRule // base branch CODEOWNERS rules, nil if none
+	MyTeams               []string               // user's teams as "@org/slug"
+	Bookmarks             map[string]jumpPos     // diff positions pinned with m{a-z}, by letter
+	ChatContext           chatContextOptions     // what chat sends besides the title and diff, from :context
+	ChatCILogs            string                 // failing check log excerpts for chat, "" until fetched
+	AIReviewComments      []claude.InlineReviewComment // the last AI review's inline comments, for :review triage
 
 	// Streaming state
 	StreamChan           chatStreamChan     // active chat streaming channel
 	StreamCancel         context.CancelFunc // cancels active stream goroutine
+	QuickAskChan         chatStreamChan     // active quick hunk question stream
+	QuickAskCancel       context.CancelFunc // cancels the quick question goroutine
 	AnalysisStreamCh     analysisStreamChan // active analysis streaming channel
 	AnalysisStreamCancel context.CancelFunc // cancels active analysis stream
 	AIReviewCancel       context.CancelFunc // cancels active AI review
 
 	// Analysis state
-	Analyzing bool
+	Analyzing      bool
+	Summarizing    bool
+	AnalysisInputs *claude.AnalysisInputs // inputs of the analysis shown, from the run or the cache
+	LastAnalysis   *analysisSnapshot      // last analysis run this session, nil if only cached
+
+	// Merge message state (own PRs only)
+	DraftingMergeMessage bool
+	MergeMessageDraft    *claude.MergeMessage // drafted while busy, shown by the next :merge message
+	MergeMessage         *claude.MergeMessage // accepted for squash auto-merge, nil for GitHub's default
+}
+
+// analysisSnapshot is the exact input of an analysis run this session, kept
+// so the run can be repeated even after the diff or prompts change.
+type analysisSnapshot struct {
+	input    claude.AnalyzeDiffInput
+	diffHash string // diffContentHash of the files analyzed, the cache key
 }
 
 // CancelStreams cancels any active chat, analysis, and AI review goroutines.
@@ -38,6 +77,11 @@ func (s *PRSession) CancelStreams() {
 		s.StreamCancel = nil
 	}
 	s.StreamChan = nil
+	if s.QuickAskCancel != nil {
+		s.QuickAskCancel()
+		s.QuickAskCancel = nil
+	}
+	s.QuickAskChan = nil
 	if s.AnalysisStreamCancel != nil {
 		s.AnalysisStreamCancel()
 		s.AnalysisStreamCancel = nil
@@ -48,6 +92,22 @@ func (s *PRSession) CancelStreams() {
 		s.AIReviewCancel = nil
 	}
 	s.Analyzing = false
+	s.Summarizing = false
+	s.DraftingMergeMessage = false
+}
+
+// SubmittableComments splits the pending pool into comments to submit and
+// drafts withheld because they are held back in :pending, their line is
+// gone after a push, or their file is out of scope.
+func (s *PRSession) SubmittableComments() (submit, withheld []PendingInlineComment) {
+	for _, c := range s.PendingInlineComments {
+		if c.Held || c.Stale || s.ExcludedFiles[c.Path] {
+			withheld = append(withheld, c)
+		} else {
+			submit = append(submit, c)
+		}
+	}
+	return submit, withheld
 }
 
 // MatchesPR returns true if this session is for the given PR number.
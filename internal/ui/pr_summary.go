@@ -0,0 +1,86 @@
+package ui
+
+import (
+	"context"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/shhac/prtea/internal/claude"
+)
+
+// startSummary generates a short AI summary of the current PR for the top
+// of the PR Info tab, reusing the cached one while the diff is unchanged.
+func (m App) startSummary() (tea.Model, tea.Cmd) {
+	if m.session == nil {
+		return m, m.statusBar.SetTemporaryMessage("No PR selected", 2*time.Second)
+	}
+	if m.analyzer == nil {
+		return m, m.statusBar.SetTemporaryMessage("AI unavailable — install the Claude CLI or choose another AI backend in Settings", 3*time.Second)
+	}
+	if m.aiBudget.health == aiDisabled {
+		return m, m.statusBar.SetTemporaryMessage(aiDisabledReason, 3*time.Second)
+	}
+	if len(m.session.DiffFiles) == 0 {
+		return m, m.statusBar.SetTemporaryMessage("No diff loaded yet", 2*time.Second)
+	}
+	if m.session.Summarizing {
+		return m, nil
+	}
+
+	m.diffViewer.activeTab = TabPRInfo
+	m.showAndFocusPanel(PanelCenter)
+
+	hash := diffContentHash(m.session.DiffFiles)
+	if m.loadCachedSummary(hash) {
+		return m, m.statusBar.SetTemporaryMessage("Summary is up to date with the diff", 2*time.Second)
+	}
+
+	m.session.Summarizing = true
+	m.diffViewer.SetPRSummaryLoading()
+	s := m.session
+	input := claude.AnalyzeDiffInput{
+		Owner:       s.Owner,
+		Repo:        s.Repo,
+		PRNumber:    s.Number,
+		PRTitle:     s.Title,
+		PRBody:      m.diffViewer.prBody,
+		DiffContent: buildDiffContent(s.DiffFiles),
+	}
+	return m, tea.Batch(summaryCmd(m.usageContext(), m.analyzer, input, hash), m.diffViewer.spinner.Tick)
+}
+
+// summaryCmd runs the summary prompt off the UI goroutine.
+func summaryCmd(ctx context.Context, analyzer AIAnalyzer, input claude.AnalyzeDiffInput, hash string) tea.Cmd {
+	return func() tea.Msg {
+		summary, err := analyzer.SummarizeDiff(ctx, input)
+		if err != nil {
+			return PRSummaryErrorMsg{PRNumber: input.PRNumber, Err: err}
+		}
+		return PRSummaryCompleteMsg{Owner: input.Owner, Repo: input.Repo, PRNumber: input.PRNumber, DiffHash: hash, Summary: summary}
+	}
+}
+
+// syncPRSummary shows the cached summary of the current diff, or none if
+// the diff has changed since it was made.
+func (m *App) syncPRSummary() {
+	if m.session == nil || m.session.Summarizing {
+		return
+	}
+	if !m.loadCachedSummary(diffContentHash(m.session.DiffFiles)) {
+		m.diffViewer.SetPRSummary(nil)
+	}
+}
+
+// loadCachedSummary shows the cached summary if it was made from the diff
+// with the given hash, and reports whether it did.
+func (m *App) loadCachedSummary(hash string) bool {
+	if m.session == nil || m.analysisStore == nil {
+		return false
+	}
+	cached, _ := m.analysisStore.GetSummary(m.session.Owner, m.session.Repo, m.session.Number)
+	if cached == nil || cached.Summary == nil || cached.DiffContentHash != hash {
+		return false
+	}
+	m.diffViewer.SetPRSummary(cached.Summary)
+	return true
+}
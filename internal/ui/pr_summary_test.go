@@ -0,0 +1,67 @@
+package ui
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/shhac/prtea/internal/claude"
+	"github.com/shhac/prtea/internal/github"
+)
+
+// summaryAnalyzer counts SummarizeDiff calls; other methods are unused.
+type summaryAnalyzer struct {
+	AIAnalyzer
+	calls int
+}
+
+func (a *summaryAnalyzer) SummarizeDiff(_ context.Context, input claude.AnalyzeDiffInput) (*claude.PRSummary, error) {
+	a.calls++
+	return &claude.PRSummary{Bullets: []string{"What: " + input.PRTitle, "Risk: none"}}, nil
+}
+
+func TestSummary_CachedByDiffHash(t *testing.T) {
+	analyzer := &summaryAnalyzer{}
+	files := []github.PRFile{{Filename: "a.go", Patch: "@@ -1 +1 @@\n+x"}}
+	m := App{
+		statusBar:     NewStatusBarModel(),
+		chatPanel:     NewChatPanelModel(),
+		diffViewer:    newTestDiffViewer(80, 24),
+		analyzer:      analyzer,
+		analysisStore: claude.NewAnalysisStore(t.TempDir()),
+		session:       &PRSession{Owner: "acme", Repo: "widget", Number: 1, Title: "frob", DiffFiles: files},
+	}
+
+	model, cmd := m.startSummary()
+	m = model.(App)
+	if !m.session.Summarizing || !m.diffViewer.prSummaryLoading || cmd == nil {
+		t.Fatal("summary should be generating")
+	}
+	if m.diffViewer.activeTab != TabPRInfo {
+		t.Error("the PR Info tab should be shown")
+	}
+	msg := summaryCmd(context.Background(), analyzer, claude.AnalyzeDiffInput{Owner: "acme", Repo: "widget", PRNumber: 1, PRTitle: "frob"}, diffContentHash(files))()
+	model, _ = m.handleAnalysisMsg(msg)
+	m = model.(App)
+	if m.session.Summarizing || m.diffViewer.prSummary == nil {
+		t.Fatal("summary should be shown once complete")
+	}
+	if got := m.diffViewer.renderPRSummary(80); !strings.Contains(got, "• What: frob") {
+		t.Errorf("rendered summary = %q", got)
+	}
+
+	// Unchanged diff: the cached summary is reused without another call.
+	m.diffViewer.SetPRSummary(nil)
+	model, _ = m.startSummary()
+	m = model.(App)
+	if analyzer.calls != 1 || m.diffViewer.prSummary == nil {
+		t.Errorf("calls = %d, summary = %v; want the cached summary", analyzer.calls, m.diffViewer.prSummary)
+	}
+
+	// A new diff hides the stale summary.
+	m.session.DiffFiles = []github.PRFile{{Filename: "a.go", Patch: "@@ -1 +1 @@\n+y"}}
+	m.syncPRSummary()
+	if m.diffViewer.prSummary != nil {
+		t.Error("a summary of an older diff should not be shown")
+	}
+}
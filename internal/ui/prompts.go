@@ -0,0 +1,310 @@
+package ui
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/shhac/prtea/internal/claude"
+	"github.com/shhac/prtea/internal/config"
+)
+
+// promptEntry is one custom prompt listed by :prompts.
+type promptEntry struct {
+	label   string // "Default (all repos)" or "owner/repo"
+	path    string
+	exists  bool
+	applies bool // used for the current PR's analysis and AI review
+}
+
+// promptEntries lists the default prompt and every per-repo prompt, plus
+// the current repo's even before it exists, so it can be created.
+func (m App) promptEntries() ([]promptEntry, error) {
+	dir := config.PromptsDir()
+	prompts, err := claude.ListCustomPrompts(dir)
+	if err != nil {
+		return nil, err
+	}
+	applies := ""
+	if s := m.session; s != nil {
+		applies = claude.CustomPromptPath(dir, s.Owner, s.Repo)
+	}
+
+	entries := []promptEntry{{label: "Default (all repos)", path: claude.DefaultPromptPath(dir)}}
+	haveCurrent := m.session == nil
+	for _, p := range prompts {
+		if p.IsDefault() {
+			entries[0].exists = true
+			continue
+		}
+		if s := m.session; s != nil && p.Owner == s.Owner && p.Repo == s.Repo {
+			haveCurrent = true
+		}
+		entries = append(entries, promptEntry{label: p.Owner + "/" + p.Repo, path: p.Path, exists: true})
+	}
+	if !haveCurrent {
+		s := m.session
+		current := promptEntry{label: s.Owner + "/" + s.Repo, path: claude.RepoPromptPath(dir, s.Owner, s.Repo)}
+		entries = append([]promptEntry{entries[0], current}, entries[1:]...)
+	}
+	for i := range entries {
+		entries[i].applies = entries[i].path == applies
+	}
+	return entries, nil
+}
+
+// promptPreview assembles the analysis prompt for the current PR with the
+// diff elided, or "" when there is no diff to preview.
+func (m App) promptPreview() string {
+	s := m.session
+	if s == nil || len(s.DiffFiles) == 0 {
+		return ""
+	}
+	diff := buildDiffContent(s.DiffFiles)
+	return claude.DiffAnalysisPrompt(config.PromptsDir(), claude.AnalyzeDiffInput{
+		Owner:       s.Owner,
+		Repo:        s.Repo,
+		PRNumber:    s.Number,
+		PRTitle:     s.Title,
+		DiffContent: fmt.Sprintf("[diff of %d files, %d lines — omitted from the preview]", len(s.DiffFiles), strings.Count(diff, "\n")),
+	})
+}
+
+// showPrompts opens the :prompts overlay.
+func (m App) showPrompts() (tea.Model, tea.Cmd) {
+	entries, err := m.promptEntries()
+	if err != nil {
+		return m, m.statusBar.SetTemporaryMessage(err.Error(), 3*time.Second)
+	}
+	m.prompts.SetSize(m.width, m.height)
+	m.prompts.Show(entries, m.promptPreview())
+	m.setMode(ModeOverlay)
+	return m, nil
+}
+
+// editPromptCmd opens a prompt file in $VISUAL or $EDITOR (vi if neither is
+// set), creating it first so the editor starts on an empty file.
+func editPromptCmd(path string) tea.Cmd {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return func() tea.Msg { return PromptEditedMsg{Path: path, Err: err} }
+	}
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		if err := os.WriteFile(path, nil, 0o644); err != nil {
+			return func() tea.Msg { return PromptEditedMsg{Path: path, Err: err} }
+		}
+	}
+	return tea.ExecProcess(EditorCommand(path), func(err error) tea.Msg {
+		return PromptEditedMsg{Path: path, Err: err}
+	})
+}
+
+// PromptsOverlayModel lists the custom review prompts, opens them in an
+// editor and previews the assembled prompt for the current PR.
+type PromptsOverlayModel struct {
+	viewport viewport.Model
+	width    int
+	height   int
+	visible  bool
+	ready    bool
+
+	entries     []promptEntry
+	cursor      int
+	preview     string // assembled prompt for the current PR, "" if none
+	showPreview bool
+}
+
+// NewPromptsOverlayModel creates a prompts overlay.
+func NewPromptsOverlayModel() PromptsOverlayModel {
+	return PromptsOverlayModel{}
+}
+
+// Show opens the overlay on the prompt list.
+func (m *PromptsOverlayModel) Show(entries []promptEntry, preview string) {
+	m.visible = true
+	m.cursor = 0
+	m.showPreview = false
+	m.SetEntries(entries, preview)
+}
+
+// SetEntries replaces the listed prompts and the preview, keeping the
+// cursor, e.g. after a prompt was edited.
+func (m *PromptsOverlayModel) SetEntries(entries []promptEntry, preview string) {
+	m.entries = entries
+	m.preview = preview
+	m.cursor = min(m.cursor, max(0, len(entries)-1))
+	m.refreshContent()
+}
+
+// Hide dismisses the overlay.
+func (m *PromptsOverlayModel) Hide() {
+	m.visible = false
+}
+
+// IsVisible returns whether the overlay is currently shown.
+func (m PromptsOverlayModel) IsVisible() bool {
+	return m.visible
+}
+
+// SetSize updates the overlay dimensions and rebuilds the viewport.
+func (m *PromptsOverlayModel) SetSize(termWidth, termHeight int) {
+	m.width = termWidth
+	m.height = termHeight
+
+	innerW, innerH := m.innerDimensions()
+	if !m.ready {
+		m.viewport = viewport.New(innerW, innerH)
+		m.ready = true
+	} else {
+		m.viewport.Width = innerW
+		m.viewport.Height = innerH
+	}
+	m.refreshContent()
+}
+
+func (m PromptsOverlayModel) Update(msg tea.Msg) (PromptsOverlayModel, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return m, nil
+	}
+	if m.showPreview {
+		switch keyMsg.String() {
+		case "esc", "p", "q":
+			m.showPreview = false
+			m.refreshContent()
+			m.viewport.GotoTop()
+			return m, nil
+		}
+		var cmd tea.Cmd
+		m.viewport, cmd = m.viewport.Update(keyMsg)
+		return m, cmd
+	}
+	switch keyMsg.String() {
+	case "esc", "q":
+		m.Hide()
+		return m, func() tea.Msg { return PromptsClosedMsg{} }
+	case "j", "down":
+		if m.cursor < len(m.entries)-1 {
+			m.cursor++
+			m.refreshContent()
+		}
+	case "k", "up":
+		if m.cursor > 0 {
+			m.cursor--
+			m.refreshContent()
+		}
+	case "enter", "e":
+		if m.cursor < len(m.entries) {
+			return m, editPromptCmd(m.entries[m.cursor].path)
+		}
+	case "p":
+		if m.preview != "" {
+			m.showPreview = true
+			m.refreshContent()
+			m.viewport.GotoTop()
+		}
+	}
+	return m, nil
+}
+
+func (m *PromptsOverlayModel) refreshContent() {
+	if !m.ready {
+		return
+	}
+	if m.showPreview {
+		m.viewport.SetContent(wordWrap(m.preview, m.viewport.Width))
+		return
+	}
+	m.viewport.SetContent(renderPromptEntries(m.entries, m.cursor, m.preview != ""))
+}
+
+// renderPromptEntries renders the prompt list.
+func renderPromptEntries(entries []promptEntry, cursor int, canPreview bool) string {
+	var b strings.Builder
+	for i, e := range entries {
+		prefix := "  "
+		if i == cursor {
+			prefix = "▸ "
+		}
+		label := e.label
+		if i == cursor {
+			label = boldStyle.Render(label)
+		}
+		b.WriteString(prefix + label)
+		if !e.exists {
+			b.WriteString(dimStyle.Render("  (not created)"))
+		}
+		if e.applies {
+			b.WriteString(lipgloss.NewStyle().Foreground(theme.Success).Render("  ● this PR"))
+		}
+		b.WriteString("\n")
+	}
+	b.WriteString("\n" + dimStyle.Render("A repo's own prompt replaces the default. Prompts are added to analysis and AI review instructions."))
+	if !canPreview {
+		b.WriteString("\n" + dimStyle.Render("Select a PR to preview its assembled prompt."))
+	}
+	return b.String()
+}
+
+func (m PromptsOverlayModel) View() string {
+	if !m.visible {
+		return ""
+	}
+
+	overlayW, overlayH := m.overlayDimensions()
+	innerW := max(1, overlayW-4)
+
+	titleText := " Custom prompts "
+	footerText := "Enter/e edit in $EDITOR · p preview for this PR · Esc close"
+	if m.showPreview {
+		titleText = " Assembled analysis prompt "
+		footerText = "j/k scroll · p/Esc back"
+	}
+	title := helpTitleStyle.Render(titleText)
+	titleLine := lipgloss.PlaceHorizontal(innerW, lipgloss.Center, title)
+
+	var content string
+	if m.ready {
+		content = m.viewport.View()
+	}
+
+	footer := helpFooterStyle.Render(footerText)
+	footerLine := fitWidth(lipgloss.PlaceHorizontal(innerW, lipgloss.Center, footer), innerW)
+
+	box := lipgloss.JoinVertical(lipgloss.Left, titleLine, "", content, footerLine)
+
+	overlayStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(theme.Accent).
+		Padding(0, 1).
+		Width(overlayW - 2).
+		Height(overlayH - 2)
+
+	return placeOverlay(m.width, m.height, overlayStyle.Render(box))
+}
+
+// overlayDimensions returns the outer dimensions of the overlay.
+func (m PromptsOverlayModel) overlayDimensions() (width, height int) {
+	width = int(float64(m.width) * 0.7)
+	height = int(float64(m.height) * 0.8)
+	if width < 60 {
+		width = min(60, m.width)
+	}
+	if height < 20 {
+		height = min(20, m.height)
+	}
+	return width, height
+}
+
+// innerDimensions returns the viewport dimensions inside the overlay.
+func (m PromptsOverlayModel) innerDimensions() (width, height int) {
+	ow, oh := m.overlayDimensions()
+	// Subtract border (2), padding (2), title + blank (2), footer (1)
+	width = max(1, ow-4)
+	height = max(1, oh-5)
+	return width, height
+}
@@ -0,0 +1,43 @@
+package ui
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/shhac/prtea/internal/claude"
+	"github.com/shhac/prtea/internal/config"
+	"github.com/shhac/prtea/internal/github"
+)
+
+func TestPromptEntries(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	dir := config.PromptsDir()
+	os.MkdirAll(dir, 0o755)
+	os.WriteFile(filepath.Join(dir, claude.DefaultPromptName), []byte("Be strict."), 0o644)
+	os.WriteFile(claude.RepoPromptPath(dir, "other", "lib"), []byte("Mind the API."), 0o644)
+
+	m := App{session: &PRSession{Owner: "acme", Repo: "widget", Number: 1, DiffFiles: []github.PRFile{{Filename: "a.go", Patch: "+x"}}}}
+	entries, err := m.promptEntries()
+	if err != nil {
+		t.Fatalf("promptEntries: %v", err)
+	}
+	var labels []string
+	for _, e := range entries {
+		labels = append(labels, e.label)
+	}
+	if got := strings.Join(labels, ", "); got != "Default (all repos), acme/widget, other/lib" {
+		t.Fatalf("entries = %s", got)
+	}
+	if !entries[0].exists || !entries[0].applies {
+		t.Error("the default prompt applies while the repo has none")
+	}
+	if entries[1].exists {
+		t.Error("the current repo's prompt should be offered before it exists")
+	}
+	if preview := m.promptPreview(); !strings.Contains(preview, "Be strict.") || !strings.Contains(preview, "omitted from the preview") {
+		t.Errorf("preview should include the default prompt and elide the diff:\n%s", preview)
+	}
+}
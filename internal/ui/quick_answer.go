@@ -0,0 +1,197 @@
+package ui
+
+import (
+	"github.com/charmbracelet/bubbles/spinner"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// QuickAnswerModel is a transient popup showing Claude's answer to a
+// one-line question about a single hunk. Nothing shown here is added to
+// the chat session.
+type QuickAnswerModel struct {
+	viewport viewport.Model
+	spinner  spinner.Model
+	md       MarkdownRenderer
+	width    int
+	height   int
+	visible  bool
+	ready    bool
+
+	target   string // "path @@ header" of the hunk being asked about
+	question string
+	answer   string
+	loading  bool
+	err      string
+}
+
+// NewQuickAnswerModel creates a quick-answer popup.
+func NewQuickAnswerModel() QuickAnswerModel {
+	return QuickAnswerModel{spinner: newLoadingSpinner()}
+}
+
+// Show opens the popup in a waiting state for the given question.
+func (m *QuickAnswerModel) Show(target, question string) tea.Cmd {
+	m.visible = true
+	m.target = target
+	m.question = question
+	m.answer = ""
+	m.loading = true
+	m.err = ""
+	m.refreshContent()
+	return m.spinner.Tick
+}
+
+// Hide dismisses the popup.
+func (m *QuickAnswerModel) Hide() {
+	m.visible = false
+}
+
+// IsVisible returns whether the popup is currently shown.
+func (m QuickAnswerModel) IsVisible() bool {
+	return m.visible
+}
+
+// AppendChunk appends streamed answer text.
+func (m *QuickAnswerModel) AppendChunk(chunk string) {
+	m.answer += chunk
+	m.refreshContent()
+}
+
+// SetAnswer sets the final answer and stops the spinner.
+func (m *QuickAnswerModel) SetAnswer(answer string) {
+	m.loading = false
+	m.answer = answer
+	m.refreshContent()
+}
+
+// SetError shows a failure in place of the answer.
+func (m *QuickAnswerModel) SetError(err string) {
+	m.loading = false
+	m.err = err
+	m.refreshContent()
+}
+
+// SetSize updates the popup dimensions and rebuilds the viewport.
+func (m *QuickAnswerModel) SetSize(termWidth, termHeight int) {
+	m.width = termWidth
+	m.height = termHeight
+
+	innerW, innerH := m.innerDimensions()
+	if !m.ready {
+		m.viewport = viewport.New(innerW, innerH)
+		m.ready = true
+	} else {
+		m.viewport.Width = innerW
+		m.viewport.Height = innerH
+	}
+	m.refreshContent()
+}
+
+func (m QuickAnswerModel) Update(msg tea.Msg) (QuickAnswerModel, tea.Cmd) {
+	switch msg := msg.(type) {
+	case spinner.TickMsg:
+		if m.loading {
+			var cmd tea.Cmd
+			m.spinner, cmd = m.spinner.Update(msg)
+			m.refreshContent()
+			return m, cmd
+		}
+		return m, nil
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "esc", "q", "enter":
+			m.Hide()
+			return m, func() tea.Msg { return QuickAnswerClosedMsg{} }
+		}
+		var cmd tea.Cmd
+		m.viewport, cmd = m.viewport.Update(msg)
+		return m, cmd
+	}
+	return m, nil
+}
+
+func (m *QuickAnswerModel) refreshContent() {
+	if !m.ready {
+		return
+	}
+	wasAtBottom := m.viewport.AtBottom()
+	m.viewport.SetContent(m.renderBody())
+	if m.loading && wasAtBottom {
+		m.viewport.GotoBottom()
+	}
+}
+
+// renderBody renders the question and the (possibly partial) answer.
+func (m *QuickAnswerModel) renderBody() string {
+	body := boldStyle.Render("Q: ") + wordWrap(m.question, max(10, m.viewport.Width-3)) + "\n\n"
+	switch {
+	case m.err != "":
+		body += renderErrorWithHint(formatUserError(m.err), "Press Esc to close")
+	case m.answer == "" && m.loading:
+		body += dimStyle.Render(m.spinner.View() + " Thinking...")
+	default:
+		body += m.md.RenderMarkdown(m.answer, m.viewport.Width)
+		if m.loading {
+			body += "\n" + dimStyle.Render(m.spinner.View())
+		}
+	}
+	return body
+}
+
+func (m QuickAnswerModel) View() string {
+	if !m.visible {
+		return ""
+	}
+
+	overlayW, overlayH := m.overlayDimensions()
+	innerW := max(1, overlayW-4)
+
+	title := helpTitleStyle.Render(" Quick question ")
+	titleLine := lipgloss.PlaceHorizontal(innerW, lipgloss.Left, title)
+	targetLine := dimStyle.Render(ansi.Truncate(m.target, innerW, "…"))
+
+	var content string
+	if m.ready {
+		content = m.viewport.View()
+	}
+
+	footer := helpFooterStyle.Render("j/k scroll · Esc close (not saved to chat)")
+	footerLine := fitWidth(lipgloss.PlaceHorizontal(innerW, lipgloss.Center, footer), innerW)
+
+	box := lipgloss.JoinVertical(lipgloss.Left, titleLine, targetLine, "", content, footerLine)
+
+	overlayStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(theme.Accent).
+		Padding(0, 1).
+		Width(overlayW - 2).
+		Height(overlayH - 2)
+
+	return placeOverlay(m.width, m.height, overlayStyle.Render(box))
+}
+
+// overlayDimensions returns the outer dimensions of the popup. Answers are
+// short, so it stays smaller than the other overlays.
+func (m QuickAnswerModel) overlayDimensions() (width, height int) {
+	width = int(float64(m.width) * 0.6)
+	height = int(float64(m.height) * 0.5)
+	if width < 50 {
+		width = min(50, m.width)
+	}
+	if height < 12 {
+		height = min(12, m.height)
+	}
+	return width, height
+}
+
+// innerDimensions returns the viewport dimensions inside the popup.
+func (m QuickAnswerModel) innerDimensions() (width, height int) {
+	ow, oh := m.overlayDimensions()
+	// Subtract border (2), padding (2), title + target + blank (3), footer (1)
+	width = max(1, ow-4)
+	height = max(1, oh-6)
+	return width, height
+}
@@ -0,0 +1,118 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/shhac/prtea/internal/github"
+)
+
+func TestDiffViewer_AskHunkEmitsQuestion(t *testing.T) {
+	m := newTestDiffViewer(80, 10)
+	m.SetDiff([]github.PRFile{
+		{Filename: "a.go", Status: "modified", Patch: "@@ -1,2 +1,2 @@\n-old\n+new"},
+	})
+	m.SetFocused(true)
+
+	m, _ = m.Update(keyMsg("A"))
+	if !m.IsAsking() {
+		t.Fatal("expected ask mode after A")
+	}
+	for _, r := range "why?" {
+		m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+	m, cmd := m.Update(keyMsg("enter"))
+	if m.IsAsking() {
+		t.Error("ask mode should close on enter")
+	}
+	if cmd == nil {
+		t.Fatal("expected a command on enter")
+	}
+	q, ok := cmd().(HunkQuestionMsg)
+	if !ok {
+		t.Fatalf("expected HunkQuestionMsg, got %T", cmd())
+	}
+	if q.Question != "why?" {
+		t.Errorf("question = %q", q.Question)
+	}
+	if !strings.HasPrefix(q.Target, "a.go @@") {
+		t.Errorf("target = %q", q.Target)
+	}
+	if !strings.Contains(q.Hunk, "+++ b/a.go") || !strings.Contains(q.Hunk, "+new") {
+		t.Errorf("hunk content missing diff: %q", q.Hunk)
+	}
+}
+
+func TestDiffViewer_AskHunkEscCancels(t *testing.T) {
+	m := newTestDiffViewer(80, 10)
+	m.SetDiff([]github.PRFile{
+		{Filename: "a.go", Status: "modified", Patch: "@@ -1,2 +1,2 @@\n-old\n+new"},
+	})
+	m.SetFocused(true)
+
+	m, _ = m.Update(keyMsg("A"))
+	m, cmd := m.Update(keyMsg("esc"))
+	if m.IsAsking() || cmd != nil {
+		t.Error("esc should cancel ask mode without emitting a question")
+	}
+}
+
+func TestQuickAnswer_StreamAndClose(t *testing.T) {
+	m := NewQuickAnswerModel()
+	m.SetSize(120, 40)
+	m.Show("a.go @@ -1,2 +1,2 @@", "why?")
+	if !m.IsVisible() {
+		t.Fatal("expected popup visible after Show")
+	}
+
+	m.AppendChunk("Because ")
+	m.SetAnswer("Because it is nil-safe.")
+	if !strings.Contains(m.View(), "nil-safe") {
+		t.Error("expected answer in view")
+	}
+
+	m, cmd := m.Update(keyMsg("esc"))
+	if m.IsVisible() {
+		t.Error("expected popup hidden after esc")
+	}
+	if cmd == nil {
+		t.Fatal("expected close command")
+	}
+	if _, ok := cmd().(QuickAnswerClosedMsg); !ok {
+		t.Error("expected QuickAnswerClosedMsg")
+	}
+}
+
+func TestDiffViewer_ExplainHunkBoxUnderHunk(t *testing.T) {
+	m := newTestDiffViewer(80, 10)
+	m.SetDiff([]github.PRFile{
+		{Filename: "a.go", Status: "modified", Patch: "@@ -1,2 +1,2 @@\n-old\n+new\n@@ -9 +9 @@\n+later"},
+	})
+	m.SetFocused(true)
+
+	m, cmd := m.Update(keyMsg("E"))
+	if cmd == nil {
+		t.Fatal("expected a command on E")
+	}
+	ex, ok := cmd().(ExplainHunkMsg)
+	if !ok {
+		t.Fatalf("expected ExplainHunkMsg, got %T", cmd())
+	}
+	if !strings.Contains(ex.Hunk, "+new") || strings.Contains(ex.Hunk, "+later") || !strings.Contains(ex.FileContext, "@@ -9 +9 @@") {
+		t.Errorf("want just the hunk plus the file's other headers: %#v", ex)
+	}
+
+	m.SetHunkExplanation(ex.Key, "Renames **old** to new.", nil)
+	out := ansi.Strip(strings.Join(m.cachedLines, "\n"))
+	box, later := strings.Index(out, "Renames old to new"), strings.Index(out, "+later")
+	if box < 0 || box < strings.Index(out, "+new") || box > later {
+		t.Errorf("the explanation should sit between its hunk and the next:\n%s", out)
+	}
+
+	m, _ = m.Update(keyMsg("E"))
+	if strings.Contains(ansi.Strip(strings.Join(m.cachedLines, "\n")), "Renames") {
+		t.Error("E again should hide the explanation")
+	}
+}
@@ -0,0 +1,388 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/shhac/prtea/internal/github"
+)
+
+// quickfixKind identifies where a quickfix item came from.
+type quickfixKind int
+
+const (
+	qfThread   quickfixKind = iota // unresolved GitHub review thread
+	qfAI                           // AI analysis finding
+	qfCheck                        // failing check annotation (or a failing check without any)
+	qfDraft                        // pending draft comment
+	qfSecurity                     // security alert introduced by the PR
+	qfMark                         // bookmark set with m{a-z}
+)
+
+func (k quickfixKind) label() string {
+	switch k {
+	case qfThread:
+		return "thread"
+	case qfAI:
+		return "AI"
+	case qfCheck:
+		return "CI"
+	case qfSecurity:
+		return "security"
+	case qfMark:
+		return "mark"
+	default:
+		return "draft"
+	}
+}
+
+// quickfixItem is one actionable item in the quickfix list.
+type quickfixItem struct {
+	Kind  quickfixKind
+	Path  string // "" for failing checks with no file annotation
+	Line  int    // new-side line number; 0 for file-level items
+	Text  string // one-line summary
+	Check int    // index into the CI tab's failing checks, for qfCheck items
+}
+
+// location is the "path:line" shown in the quickfix list.
+func (it quickfixItem) location() string {
+	switch {
+	case it.Path == "":
+		return "CI"
+	case it.Line == 0:
+		return it.Path
+	default:
+		return fmt.Sprintf("%s:%d", it.Path, it.Line)
+	}
+}
+
+// ciAnnotationMaxChecks caps how many failing checks have their annotations
+// fetched for the quickfix list.
+const ciAnnotationMaxChecks = 5
+
+// quickfixItems gathers every actionable item for the current PR: unresolved
+// review threads, AI findings, failing checks, security alerts and pending
+// drafts, ordered by file (in diff order) and line. Items without a file
+// location come last.
+func (m App) quickfixItems() []quickfixItem {
+	if m.session == nil {
+		return nil
+	}
+	dv := m.diffViewer
+	var items []quickfixItem
+
+	resolved := make(map[int64]bool)
+	if dv.mergeReq != nil {
+		for _, id := range dv.mergeReq.ResolvedThreadIDs {
+			resolved[id] = true
+		}
+	}
+	for _, threads := range dv.ghCommentThreads {
+		for _, t := range threads {
+			if resolved[t.Root.ID] {
+				continue
+			}
+			text := fmt.Sprintf("@%s: %s", t.Root.Author.Login, firstLine(t.Root.Body))
+			if n := len(t.Replies); n > 0 {
+				text += fmt.Sprintf(" (+%d)", n)
+			}
+			items = append(items, quickfixItem{Kind: qfThread, Path: t.Root.Path, Line: t.Root.Line, Text: text})
+		}
+	}
+
+	if result := m.chatPanel.AnalysisResult(); result != nil {
+		for _, fr := range result.FileReviews {
+			for _, c := range fr.Comments {
+				if c.Severity == "praise" {
+					continue
+				}
+				items = append(items, quickfixItem{Kind: qfAI, Path: fr.File, Line: c.Line,
+					Text: fmt.Sprintf("[%s] %s", c.Severity, firstLine(c.Comment))})
+			}
+		}
+	}
+	for _, c := range dv.aiInlineComments {
+		items = append(items, quickfixItem{Kind: qfAI, Path: c.Path, Line: c.Line, Text: firstLine(c.Body)})
+	}
+
+	if dv.ciStatus != nil {
+		failing, _, _ := ciCheckGroups(dv.ciStatus.Checks)
+		for i, check := range failing {
+			located := false
+			for _, a := range dv.ciAnnotations[check.JobID] {
+				if a.Level == "notice" || a.Path == "" || dv.fileIndex(a.Path) < 0 {
+					continue
+				}
+				msg := firstLine(a.Message)
+				if a.Title != "" {
+					msg = a.Title + ": " + msg
+				}
+				items = append(items, quickfixItem{Kind: qfCheck, Path: a.Path, Line: a.StartLine,
+					Text: fmt.Sprintf("%s — %s", check.Name, msg), Check: i})
+				located = true
+			}
+			if !located {
+				items = append(items, quickfixItem{Kind: qfCheck, Text: check.Name + " failed", Check: i})
+			}
+		}
+	}
+
+	for _, a := range dv.securityAlerts {
+		if dv.fileIndex(a.Path) < 0 {
+			continue
+		}
+		items = append(items, quickfixItem{Kind: qfSecurity, Path: a.Path, Line: a.Line,
+			Text: fmt.Sprintf("[%s] %s", a.Severity, a.Title)})
+	}
+
+	for _, c := range m.session.PendingInlineComments {
+		items = append(items, quickfixItem{Kind: qfDraft, Path: c.Path, Line: c.Line, Text: firstLine(c.Body)})
+	}
+
+	for _, name := range m.markNames() {
+		pos := m.session.Bookmarks[name]
+		items = append(items, quickfixItem{Kind: qfMark, Path: pos.path, Line: pos.line, Text: "'" + name})
+	}
+
+	sort.SliceStable(items, func(i, j int) bool {
+		a, b := items[i], items[j]
+		if (a.Path == "") != (b.Path == "") {
+			return b.Path == ""
+		}
+		fa, fb := dv.fileIndex(a.Path), dv.fileIndex(b.Path)
+		if fa < 0 {
+			fa = len(dv.files)
+		}
+		if fb < 0 {
+			fb = len(dv.files)
+		}
+		if fa != fb {
+			return fa < fb
+		}
+		if a.Path != b.Path {
+			return a.Path < b.Path
+		}
+		if a.Line != b.Line {
+			return a.Line < b.Line
+		}
+		return a.Kind < b.Kind
+	})
+	return items
+}
+
+// firstLine returns the first non-empty line of s, trimmed.
+func firstLine(s string) string {
+	for _, l := range strings.Split(s, "\n") {
+		if l = strings.TrimSpace(l); l != "" {
+			return l
+		}
+	}
+	return ""
+}
+
+// quickfixStep moves delta items through the quickfix list and jumps to the
+// item, like :cnext / :cprev.
+func (m App) quickfixStep(delta int) (tea.Model, tea.Cmd) {
+	items := m.quickfixItems()
+	if len(items) == 0 {
+		m.quickfixPos = 0
+		return m, m.statusBar.SetTemporaryMessage("Quickfix list is empty", 2*time.Second)
+	}
+	pos := m.quickfixPos + delta
+	if m.quickfixPos == 0 && delta < 0 {
+		pos = len(items)
+	}
+	if pos < 1 || pos > len(items) {
+		return m, m.statusBar.SetTemporaryMessage(fmt.Sprintf("No more items (%d of %d)", min(max(m.quickfixPos, 1), len(items)), len(items)), 2*time.Second)
+	}
+	return m.quickfixJump(items, pos)
+}
+
+// quickfixJump moves the diff cursor to the item at 1-based position pos.
+// Failing checks without a file annotation open the CI tab on that check.
+func (m App) quickfixJump(items []quickfixItem, pos int) (tea.Model, tea.Cmd) {
+	m.quickfixPos = pos
+	item := items[pos-1]
+	if item.Path == "" {
+		m.diffViewer.activeTab = TabCI
+		m.diffViewer.ciCursor = item.Check
+		m.diffViewer.refreshContent()
+	} else {
+		m.diffViewer.activeTab = TabDiff
+		m.diffViewer.refreshContent()
+		if m.diffViewer.jumpToLine(item.Path, item.Line) {
+			m.diffViewer.refreshContent()
+		}
+	}
+	m.showAndFocusPanel(PanelCenter)
+	msg := fmt.Sprintf("(%d of %d) %s %s: %s", pos, len(items), item.Kind.label(), item.location(), item.Text)
+	return m, m.statusBar.SetTemporaryMessage(msg, 4*time.Second)
+}
+
+// openQuickfix shows the quickfix list overlay.
+func (m App) openQuickfix() (tea.Model, tea.Cmd) {
+	items := m.quickfixItems()
+	if len(items) == 0 {
+		return m, m.statusBar.SetTemporaryMessage("Quickfix list is empty", 2*time.Second)
+	}
+	m.quickfix.SetSize(m.width, m.height)
+	m.quickfix.Show(items, max(0, min(m.quickfixPos, len(items))-1))
+	m.setMode(ModeOverlay)
+	return m, nil
+}
+
+// fetchCheckAnnotationsCmd fetches annotations for failing GitHub Actions
+// checks. Failures are skipped: the check is still listed without a location.
+func fetchCheckAnnotationsCmd(client GitHubService, owner, repo string, number int, jobIDs []int64) tea.Cmd {
+	return func() tea.Msg {
+		ctx := context.Background()
+		annotations := make(map[int64][]github.CheckAnnotation, len(jobIDs))
+		for _, id := range jobIDs {
+			list, err := client.GetCheckAnnotations(ctx, owner, repo, id)
+			if err != nil {
+				continue
+			}
+			annotations[id] = list
+		}
+		return CheckAnnotationsLoadedMsg{PRNumber: number, Annotations: annotations}
+	}
+}
+
+// refreshCheckAnnotations fetches annotations for failing checks that don't
+// have them yet.
+func (m App) refreshCheckAnnotations() tea.Cmd {
+	if m.ghClient == nil || m.session == nil || m.diffViewer.ciStatus == nil {
+		return nil
+	}
+	failing, _, _ := ciCheckGroups(m.diffViewer.ciStatus.Checks)
+	var jobIDs []int64
+	for _, check := range failing {
+		if check.JobID == 0 || len(jobIDs) >= ciAnnotationMaxChecks {
+			continue
+		}
+		if _, ok := m.diffViewer.ciAnnotations[check.JobID]; !ok {
+			jobIDs = append(jobIDs, check.JobID)
+		}
+	}
+	if len(jobIDs) == 0 {
+		return nil
+	}
+	return fetchCheckAnnotationsCmd(m.ghClient, m.session.Owner, m.session.Repo, m.session.Number, jobIDs)
+}
+
+// SetCheckAnnotations stores fetched check run annotations by job ID.
+func (m *DiffViewerModel) SetCheckAnnotations(annotations map[int64][]github.CheckAnnotation) {
+	if m.ciAnnotations == nil {
+		m.ciAnnotations = make(map[int64][]github.CheckAnnotation, len(annotations))
+	}
+	for id, list := range annotations {
+		m.ciAnnotations[id] = list
+	}
+}
+
+// QuickfixModel is an overlay listing the quickfix items; Enter jumps to
+// the item under the cursor.
+type QuickfixModel struct {
+	items   []quickfixItem
+	cursor  int
+	width   int
+	height  int
+	visible bool
+}
+
+// NewQuickfixModel creates a quickfix list overlay.
+func NewQuickfixModel() QuickfixModel {
+	return QuickfixModel{}
+}
+
+// Show opens the overlay with the cursor on the given item.
+func (m *QuickfixModel) Show(items []quickfixItem, cursor int) {
+	m.items = items
+	m.cursor = cursor
+	m.visible = true
+}
+
+// IsVisible returns whether the overlay is currently shown.
+func (m QuickfixModel) IsVisible() bool {
+	return m.visible
+}
+
+// SetSize updates the terminal dimensions used to place the overlay.
+func (m *QuickfixModel) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+}
+
+func (m QuickfixModel) Update(msg tea.Msg) (QuickfixModel, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return m, nil
+	}
+	switch keyMsg.String() {
+	case "j", "down":
+		if m.cursor < len(m.items)-1 {
+			m.cursor++
+		}
+	case "k", "up":
+		if m.cursor > 0 {
+			m.cursor--
+		}
+	case "g", "home":
+		m.cursor = 0
+	case "G", "end":
+		m.cursor = max(0, len(m.items)-1)
+	case "enter":
+		m.visible = false
+		items, pos := m.items, m.cursor+1
+		return m, func() tea.Msg { return QuickfixClosedMsg{Items: items, Pos: pos} }
+	case "esc", "q":
+		m.visible = false
+		return m, func() tea.Msg { return QuickfixClosedMsg{} }
+	}
+	return m, nil
+}
+
+func (m QuickfixModel) View() string {
+	if !m.visible {
+		return ""
+	}
+	overlayW := min(max(60, m.width*3/4), m.width)
+	innerW := max(1, overlayW-4)
+
+	title := helpTitleStyle.Render(fmt.Sprintf(" Quickfix (%d) ", len(m.items)))
+	lines := []string{lipgloss.PlaceHorizontal(innerW, lipgloss.Left, title), ""}
+
+	maxRows := max(1, m.height-8)
+	start, end := visibleRange(m.cursor, len(m.items), maxRows)
+	for i := start; i < end; i++ {
+		item := m.items[i]
+		marker := "  "
+		if i == m.cursor {
+			marker = "▸ "
+		}
+		kind := fmt.Sprintf("%-8s", item.Kind.label())
+		row := ansi.Truncate(fmt.Sprintf("%s%s %s  %s", marker, kind, item.location(), item.Text), innerW, "…")
+		if i == m.cursor {
+			row = boldStyle.Render(row)
+		}
+		lines = append(lines, row)
+	}
+
+	footer := helpFooterStyle.Render("j/k move · Enter jump · Esc close · :cnext/:cprev step")
+	lines = append(lines, "", fitWidth(lipgloss.PlaceHorizontal(innerW, lipgloss.Center, footer), innerW))
+
+	overlayStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(theme.Accent).
+		Padding(0, 1).
+		Width(overlayW - 2)
+
+	return placeOverlay(m.width, m.height, overlayStyle.Render(strings.Join(lines, "\n")))
+}
@@ -0,0 +1,118 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/shhac/prtea/internal/claude"
+	"github.com/shhac/prtea/internal/github"
+)
+
+func quickfixTestApp() App {
+	m := App{
+		statusBar:    NewStatusBarModel(),
+		diffViewer:   newTestDiffViewer(80, 40),
+		chatPanel:    NewChatPanelModel(),
+		panelVisible: [3]bool{true, true, true},
+		session: &PRSession{
+			Number:                1,
+			PendingInlineComments: []PendingInlineComment{pending("b.go", 2)},
+		},
+	}
+	m.diffViewer.SetDiff([]github.PRFile{
+		{Filename: "a.go", Status: "modified", Patch: "@@ -1,3 +1,4 @@\n one\n+two\n three\n four"},
+		{Filename: "b.go", Status: "modified", Patch: "@@ -1,2 +1,3 @@\n one\n+two\n three"},
+	})
+	m.diffViewer.SetGitHubInlineComments([]github.InlineComment{
+		{ID: 10, Author: github.User{Login: "carol"}, Body: "Why?\nmore", Path: "a.go", Line: 4},
+		{ID: 11, Author: github.User{Login: "dave"}, Body: "done", Path: "a.go", Line: 2},
+	})
+	m.diffViewer.mergeReq = &github.MergeRequirements{ResolvedThreadIDs: []int64{11}}
+	m.chatPanel.SetAnalysisResult(&claude.AnalysisResult{FileReviews: []claude.FileReview{
+		{File: "a.go", Comments: []claude.ReviewComment{
+			{Line: 2, Severity: "warning", Comment: "off by one"},
+			{Line: 3, Severity: "praise", Comment: "nice"},
+		}},
+	}})
+	m.diffViewer.SetCIStatus(&github.CIStatus{Checks: []github.CICheck{
+		{Name: "vet", Status: "completed", Conclusion: "failure", JobID: 7},
+		{Name: "deploy", Status: "completed", Conclusion: "failure", JobID: 8},
+		{Name: "lint", Status: "completed", Conclusion: "success"},
+	}})
+	m.diffViewer.SetCheckAnnotations(map[int64][]github.CheckAnnotation{
+		7: {{Path: "b.go", StartLine: 3, Level: "failure", Message: "unused x"}},
+	})
+	return m
+}
+
+func TestQuickfixItemsOrder(t *testing.T) {
+	items := quickfixTestApp().quickfixItems()
+	var got []string
+	for _, it := range items {
+		got = append(got, it.Kind.label()+" "+it.location())
+	}
+	want := []string{"AI a.go:2", "thread a.go:4", "draft b.go:2", "CI b.go:3", "CI CI"}
+	if strings.Join(got, ", ") != strings.Join(want, ", ") {
+		t.Errorf("items = %v, want %v", got, want)
+	}
+	if items[1].Text != "@carol: Why?" {
+		t.Errorf("thread text = %q", items[1].Text)
+	}
+	if items[4].Text != "deploy failed" || items[4].Check != 1 {
+		t.Errorf("unlocated check = %+v", items[4])
+	}
+}
+
+func TestQuickfixStepMovesDiffCursor(t *testing.T) {
+	m := quickfixTestApp()
+
+	model, _ := m.quickfixStep(1)
+	m = model.(App)
+	if m.quickfixPos != 1 {
+		t.Fatalf("quickfixPos = %d, want 1", m.quickfixPos)
+	}
+	li := m.diffViewer.cachedLineInfo[m.diffViewer.cursorLine]
+	if li.filename != "a.go" || li.newLineNum != 2 {
+		t.Errorf("cursor at %s:%d, want a.go:2", li.filename, li.newLineNum)
+	}
+	if !strings.Contains(m.statusBar.statusMessage, "(1 of 5)") {
+		t.Errorf("status = %q", m.statusBar.statusMessage)
+	}
+
+	model, _ = m.quickfixStep(1)
+	model, _ = model.(App).quickfixStep(1)
+	m = model.(App)
+	li = m.diffViewer.cachedLineInfo[m.diffViewer.cursorLine]
+	if li.filename != "b.go" || li.newLineNum != 2 {
+		t.Errorf("cursor at %s:%d, want b.go:2", li.filename, li.newLineNum)
+	}
+
+	model, _ = m.quickfixStep(-1)
+	m = model.(App)
+	if m.quickfixPos != 2 {
+		t.Errorf("quickfixPos after cprev = %d, want 2", m.quickfixPos)
+	}
+}
+
+func TestQuickfixUnlocatedCheckOpensCITab(t *testing.T) {
+	m := quickfixTestApp()
+	model, _ := m.quickfixStep(-1) // :cprev from the start wraps to the last item
+	m = model.(App)
+	if m.quickfixPos != 5 || m.diffViewer.activeTab != TabCI || m.diffViewer.ciCursor != 1 {
+		t.Errorf("pos=%d tab=%d ciCursor=%d, want 5/CI/1", m.quickfixPos, m.diffViewer.activeTab, m.diffViewer.ciCursor)
+	}
+
+	model, _ = m.quickfixStep(1)
+	m = model.(App)
+	if m.quickfixPos != 5 || !strings.Contains(m.statusBar.statusMessage, "No more items") {
+		t.Errorf("pos=%d status=%q", m.quickfixPos, m.statusBar.statusMessage)
+	}
+}
+
+func TestQuickfixEmpty(t *testing.T) {
+	m := App{statusBar: NewStatusBarModel(), diffViewer: newTestDiffViewer(80, 24), chatPanel: NewChatPanelModel(), session: &PRSession{Number: 1}}
+	model, _ := m.quickfixStep(1)
+	if msg := model.(App).statusBar.statusMessage; msg != "Quickfix list is empty" {
+		t.Errorf("status = %q", msg)
+	}
+}
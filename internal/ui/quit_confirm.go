@@ -0,0 +1,110 @@
+package ui
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// pendingCount formats a number of pending comments, e.g. "3 pending comments".
+func pendingCount(n int) string {
+	if n == 1 {
+		return "1 pending comment"
+	}
+	return fmt.Sprintf("%d pending comments", n)
+}
+
+// unsubmittedDrafts describes what quitting would lose: the current PR's
+// pending comments and review body, and the pending comments of PRs kept
+// in the workspace. Empty when there is nothing.
+func (m App) unsubmittedDrafts() string {
+	var lost []string
+	if s := m.session; s != nil {
+		var parts []string
+		if n := len(s.PendingInlineComments); n > 0 {
+			parts = append(parts, pendingCount(n))
+		}
+		if strings.TrimSpace(m.chatPanel.review.textArea.Value()) != "" {
+			parts = append(parts, "the review body")
+		}
+		if len(parts) > 0 {
+			lost = append(lost, fmt.Sprintf("%s on %s/%s#%d", strings.Join(parts, " and "), s.Owner, s.Repo, s.Number))
+		}
+	}
+	for _, e := range m.workspace.entries {
+		if n := len(e.session.PendingInlineComments); n > 0 {
+			lost = append(lost, fmt.Sprintf("%s on %s/%s#%d", pendingCount(n), e.session.Owner, e.session.Repo, e.session.Number))
+		}
+	}
+	return strings.Join(lost, ", ")
+}
+
+// quit exits, first asking for confirmation when the current PR has
+// unsubmitted pending comments or a review body, or a PR in the workspace
+// has pending comments. In-progress text is saved either way so it can be
+// recovered.
+func (m *App) quit() tea.Cmd {
+	drafts := m.unsubmittedDrafts()
+	if drafts == "" {
+		m.flushScratch()
+		return tea.Quit
+	}
+	message := drafts + " will be lost."
+	message = strings.ToUpper(message[:1]) + message[1:]
+	if m.scratch != nil {
+		message += "\n\nSave them as a draft to get them back with :recover the next time you open the PR."
+	}
+	m.confirm.SetSize(m.width, m.height)
+	m.confirm.Show("Quit with unsubmitted drafts?", message, "Quit", QuitConfirmedMsg{})
+	if m.scratch != nil {
+		m.confirm.SetAlternative("s", "Save draft & quit", QuitConfirmedMsg{SaveDraft: true})
+	}
+	m.setMode(ModeOverlay)
+	return nil
+}
+
+// quitConfirmed exits after the quit confirmation, saving the current PR's
+// pending comments and text, and the pending comments of the PRs in the
+// workspace, as drafts if asked to.
+func (m App) quitConfirmed(msg QuitConfirmedMsg) (tea.Model, tea.Cmd) {
+	if msg.SaveDraft && m.scratch != nil {
+		if s := m.session; s != nil {
+			m.scratchSaved = m.inputScratch()
+			if err := m.scratch.PutDraft(s.Owner, s.Repo, s.Number, m.scratchSaved, s.PendingInlineComments); err != nil {
+				log.Printf("warning: saving draft failed: %v", err)
+			}
+		}
+		for _, e := range m.workspace.entries {
+			s := e.session
+			if len(s.PendingInlineComments) == 0 {
+				continue
+			}
+			if err := m.scratch.PutPending(s.Owner, s.Repo, s.Number, s.PendingInlineComments); err != nil {
+				log.Printf("warning: saving draft failed: %v", err)
+			}
+		}
+	}
+	m.flushScratch()
+	return m, tea.Quit
+}
+
+// mergePending adds recovered pending comments to the current ones, skipping
+// any already there.
+func mergePending(current, recovered []PendingInlineComment) []PendingInlineComment {
+	merged := append([]PendingInlineComment(nil), current...)
+	for _, r := range recovered {
+		dup := false
+		for _, c := range current {
+			if c.Path == r.Path && c.Line == r.Line && c.Body == r.Body {
+				dup = true
+				break
+			}
+		}
+		if !dup {
+			merged = append(merged, r)
+		}
+	}
+	return merged
+}
@@ -0,0 +1,97 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/shhac/prtea/internal/claude"
+)
+
+func TestQuit_NoDraftsQuitsAtOnce(t *testing.T) {
+	m := autosaveTestApp(t)
+	cmd := m.quit()
+	if cmd == nil || m.confirm.IsVisible() {
+		t.Fatal("quitting without drafts should not ask")
+	}
+	if _, ok := cmd().(tea.QuitMsg); !ok {
+		t.Error("expected tea.Quit")
+	}
+}
+
+func TestQuit_ConfirmsAndSavesDraft(t *testing.T) {
+	m := autosaveTestApp(t)
+	m.confirm = NewConfirmModel()
+	m.width, m.height = 120, 40
+	pending := []PendingInlineComment{
+		{InlineReviewComment: claude.InlineReviewComment{Path: "a.go", Line: 2, Body: "Handle the error"}, Source: "user"},
+		{InlineReviewComment: claude.InlineReviewComment{Path: "b.go", Line: 1, Body: "Typo"}, Source: "ai"},
+	}
+	m.setPendingComments(pending)
+	m.chatPanel.review.textArea.SetValue("A few nits")
+
+	if cmd := m.quit(); cmd != nil || !m.confirm.IsVisible() {
+		t.Fatal("quitting with drafts should ask first")
+	}
+	if !strings.Contains(m.confirm.message, "2 pending comments and the review body") || !strings.Contains(m.confirm.View(), "s Save draft & quit") {
+		t.Errorf("message = %q", m.confirm.message)
+	}
+
+	var cmd tea.Cmd
+	m.confirm, cmd = m.confirm.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("s")})
+	closed := cmd().(ConfirmClosedMsg)
+	if req, ok := closed.Action.(QuitConfirmedMsg); !ok || !req.SaveDraft {
+		t.Fatalf("s = %#v, want saving the draft", closed.Action)
+	}
+	model, cmd := m.Update(closed.Action)
+	m = model.(App)
+	if _, ok := cmd().(tea.QuitMsg); !ok {
+		t.Error("saving the draft should quit")
+	}
+
+	// The next session offers the draft back.
+	next := autosaveTestApp(t)
+	next.scratch = m.scratch
+	s := next.session
+	f, err := next.scratch.Get(s.Owner, s.Repo, s.Number)
+	if err != nil || f == nil || len(f.Pending) != 2 || f.Review != "A few nits" {
+		t.Fatalf("draft = %+v, %v", f, err)
+	}
+	next.offerScratch(ScratchLoadedMsg{Owner: s.Owner, Repo: s.Repo, PRNumber: s.Number, File: f})
+	if !strings.Contains(next.statusBar.statusMessage, "review body, 2 pending comments") {
+		t.Errorf("offer = %q", next.statusBar.statusMessage)
+	}
+	model, _ = next.recoverScratch("")
+	next = model.(App)
+	if len(next.session.PendingInlineComments) != 2 || next.chatPanel.review.textArea.Value() != "A few nits" {
+		t.Errorf("recovered pending = %d, review = %q", len(next.session.PendingInlineComments), next.chatPanel.review.textArea.Value())
+	}
+}
+
+func TestQuit_SavesWorkspacePending(t *testing.T) {
+	m := autosaveTestApp(t)
+	m.confirm = NewConfirmModel()
+	m.width, m.height = 120, 40
+	resident := &PRSession{Owner: "shhac", Repo: "prtea", Number: 2, PendingInlineComments: []PendingInlineComment{pending("c.go", 4)}}
+	m.workspace.entries = []workspaceEntry{{session: resident}}
+
+	if cmd := m.quit(); cmd != nil || !strings.Contains(m.confirm.message, "1 pending comment on shhac/prtea#2") {
+		t.Fatalf("message = %q, want the resident PR's pending comment", m.confirm.message)
+	}
+	model, cmd := m.Update(QuitConfirmedMsg{SaveDraft: true})
+	if _, ok := cmd().(tea.QuitMsg); !ok {
+		t.Error("saving the draft should quit")
+	}
+	m = model.(App)
+	if f, _ := m.scratch.Get("shhac", "prtea", 2); f == nil || len(f.Pending) != 1 || f.Pending[0].Path != "c.go" {
+		t.Errorf("resident draft = %+v", f)
+	}
+}
+
+func TestMergePending_SkipsDuplicates(t *testing.T) {
+	c := PendingInlineComment{InlineReviewComment: claude.InlineReviewComment{Path: "a.go", Line: 2, Body: "x"}}
+	d := PendingInlineComment{InlineReviewComment: claude.InlineReviewComment{Path: "a.go", Line: 3, Body: "y"}}
+	if got := mergePending([]PendingInlineComment{c}, []PendingInlineComment{c, d}); len(got) != 2 {
+		t.Errorf("merged = %+v", got)
+	}
+}
@@ -0,0 +1,75 @@
+package ui
+
+import (
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// SetExcludedFiles marks files whose pending comments are withheld from the
+// review, and rebuilds the diff so their headers show a badge.
+func (m *DiffViewerModel) SetExcludedFiles(files map[string]bool) {
+	m.excludedFiles = files
+	m.cachedLines = nil
+	m.refreshContent()
+}
+
+// focusedFilename returns the file of the focused hunk, or "" when the diff
+// has no hunks.
+func (m DiffViewerModel) focusedFilename() string {
+	if m.focusedHunkIdx < 0 || m.focusedHunkIdx >= len(m.hunks) {
+		return ""
+	}
+	return m.hunks[m.focusedHunkIdx].Filename
+}
+
+// toggleFileScope marks path out of scope for review submission, or back in
+// scope. Pending comments on out-of-scope files stay as drafts.
+func (m App) toggleFileScope(path string) (tea.Model, tea.Cmd) {
+	if m.session == nil || path == "" {
+		return m, nil
+	}
+	if m.session.ExcludedFiles == nil {
+		m.session.ExcludedFiles = make(map[string]bool)
+	}
+
+	var msg string
+	if m.session.ExcludedFiles[path] {
+		delete(m.session.ExcludedFiles, path)
+		msg = path + " back in scope"
+	} else {
+		m.session.ExcludedFiles[path] = true
+		msg = path + " out of scope"
+		if n := pendingOnFile(m.session.PendingInlineComments, path); n > 0 {
+			msg += fmt.Sprintf(" — %d draft comment(s) withheld from the review", n)
+		}
+	}
+
+	m.diffViewer.SetExcludedFiles(m.session.ExcludedFiles)
+	m.syncPendingCommentCount()
+	clearCmd := m.statusBar.SetTemporaryMessage(msg, 3*time.Second)
+	return m, clearCmd
+}
+
+// syncPendingCommentCount updates the Review tab's pending and withheld counts.
+func (m *App) syncPendingCommentCount() {
+	if m.session == nil {
+		m.chatPanel.SetPendingCommentCount(0, 0)
+		m.statusBar.SetPendingCount(0)
+		return
+	}
+	submit, withheld := m.session.SubmittableComments()
+	m.chatPanel.SetPendingCommentCount(len(submit), len(withheld))
+	m.statusBar.SetPendingCount(len(submit))
+}
+
+func pendingOnFile(comments []PendingInlineComment, path string) int {
+	n := 0
+	for _, c := range comments {
+		if c.Path == path {
+			n++
+		}
+	}
+	return n
+}
@@ -0,0 +1,85 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/x/ansi"
+	"github.com/shhac/prtea/internal/claude"
+	"github.com/shhac/prtea/internal/github"
+)
+
+func pending(path string, line int) PendingInlineComment {
+	return PendingInlineComment{
+		InlineReviewComment: claude.InlineReviewComment{Path: path, Line: line, Body: "nit"},
+		Source:              "user",
+	}
+}
+
+func TestSubmittableComments(t *testing.T) {
+	s := &PRSession{
+		PendingInlineComments: []PendingInlineComment{pending("a.go", 1), pending("b.go", 2), pending("a.go", 3)},
+		ExcludedFiles:         map[string]bool{"a.go": true},
+	}
+	submit, withheld := s.SubmittableComments()
+	if len(submit) != 1 || submit[0].Path != "b.go" {
+		t.Errorf("submit = %+v", submit)
+	}
+	if len(withheld) != 2 {
+		t.Errorf("withheld = %+v", withheld)
+	}
+}
+
+func TestToggleFileScope(t *testing.T) {
+	m := App{
+		statusBar:  NewStatusBarModel(),
+		diffViewer: newTestDiffViewer(80, 24),
+		chatPanel:  NewChatPanelModel(),
+		session: &PRSession{
+			Number:                1,
+			PendingInlineComments: []PendingInlineComment{pending("a.go", 1), pending("b.go", 2)},
+		},
+	}
+	m.diffViewer.SetDiff([]github.PRFile{
+		{Filename: "a.go", Status: "modified", Patch: "@@ -1,1 +1,2 @@\n ctx\n+x"},
+	})
+
+	model, _ := m.toggleFileScope("a.go")
+	m = model.(App)
+	if !m.session.ExcludedFiles["a.go"] {
+		t.Fatal("a.go should be out of scope")
+	}
+	if !strings.Contains(m.statusBar.statusMessage, "1 draft comment(s) withheld") {
+		t.Errorf("status = %q", m.statusBar.statusMessage)
+	}
+	if m.chatPanel.review.pendingCount != 1 || m.chatPanel.review.withheldCount != 1 {
+		t.Errorf("counts = %d/%d, want 1/1", m.chatPanel.review.pendingCount, m.chatPanel.review.withheldCount)
+	}
+	if !strings.Contains(ansi.Strip(strings.Join(m.diffViewer.cachedLines, "\n")), "out of scope") {
+		t.Error("file header should show the out of scope badge")
+	}
+
+	model, _ = m.toggleFileScope("a.go")
+	m = model.(App)
+	if m.session.ExcludedFiles["a.go"] || m.chatPanel.review.withheldCount != 0 {
+		t.Error("toggling again should bring a.go back in scope")
+	}
+}
+
+func TestReviewSubmitDone_KeepsWithheldDrafts(t *testing.T) {
+	m := App{
+		statusBar:  NewStatusBarModel(),
+		diffViewer: newTestDiffViewer(80, 24),
+		chatPanel:  NewChatPanelModel(),
+		session: &PRSession{
+			Number:                1,
+			PendingInlineComments: []PendingInlineComment{pending("a.go", 1), pending("b.go", 2)},
+			ExcludedFiles:         map[string]bool{"a.go": true},
+		},
+	}
+	model, _ := m.handleReviewMsg(ReviewSubmitDoneMsg{PRNumber: 1, Action: ReviewComment})
+	m = model.(App)
+	if len(m.session.PendingInlineComments) != 1 || m.session.PendingInlineComments[0].Path != "a.go" {
+		t.Errorf("pending after submit = %+v, want only the a.go draft", m.session.PendingInlineComments)
+	}
+}
@@ -13,10 +13,12 @@ import (
 // ReviewTabModel manages the review submission tab state and rendering.
 type ReviewTabModel struct {
 	textArea      textarea.Model
+	completer     textCompleter
 	action        ReviewAction
 	radioFocus    int
 	focus         ReviewFocus
 	submitting    bool
+	confirming    bool // ctrl+s pressed once; a second press submits
 	defaultAction ReviewAction
 
 	// AI review state
@@ -24,8 +26,22 @@ type ReviewTabModel struct {
 	aiLoading bool
 	aiError   string
 
-	// Pending inline comment count (set by app)
-	pendingCount int
+	// Pending inline comment counts (set by app)
+	pendingCount  int
+	withheldCount int // pending comments held back or on out-of-scope files
+
+	// CODEOWNERS coverage of the user's approval (set by app), nil if unknown
+	ownerCoverage *ownerCoverage
+
+	// Repo review checklist (set by app), appended to the body on submit
+	checklist   []checklistItem
+	checkCursor int
+}
+
+// checklistItem is one toggleable review checklist entry.
+type checklistItem struct {
+	label string
+	done  bool
 }
 
 // NewReviewTabModel creates a ReviewTabModel with default state.
@@ -81,11 +97,81 @@ func (t *ReviewTabModel) Clear() {
 	t.radioFocus = int(t.defaultAction)
 	t.focus = ReviewFocusTextArea
 	t.submitting = false
+	t.confirming = false
 	t.textArea.Blur()
 	t.aiResult = nil
 	t.aiLoading = false
 	t.aiError = ""
 	t.pendingCount = 0
+	t.withheldCount = 0
+	t.ownerCoverage = nil
+	t.checklist = nil
+	t.checkCursor = 0
+}
+
+// SetChecklist sets the checklist items, keeping the state of items that
+// were already there.
+func (t *ReviewTabModel) SetChecklist(labels []string) {
+	done := make(map[string]bool, len(t.checklist))
+	for _, it := range t.checklist {
+		done[it.label] = it.done
+	}
+	t.checklist = make([]checklistItem, len(labels))
+	for i, l := range labels {
+		t.checklist[i] = checklistItem{label: l, done: done[l]}
+	}
+	t.checkCursor = min(t.checkCursor, max(0, len(labels)-1))
+	if len(labels) == 0 && t.focus == ReviewFocusChecklist {
+		t.focus = ReviewFocusTextArea
+	}
+}
+
+// resetChecklist unticks every checklist item.
+func (t *ReviewTabModel) resetChecklist() {
+	for i := range t.checklist {
+		t.checklist[i].done = false
+	}
+	t.checkCursor = 0
+}
+
+// checklistMarkdown renders the checklist as a GitHub task list for the
+// review body, or "" when there is none.
+func (t ReviewTabModel) checklistMarkdown() string {
+	if len(t.checklist) == 0 {
+		return ""
+	}
+	var b strings.Builder
+	b.WriteString("**Review checklist**\n")
+	for _, it := range t.checklist {
+		box := "[ ]"
+		if it.done {
+			box = "[x]"
+		}
+		b.WriteString("- " + box + " " + it.label + "\n")
+	}
+	return strings.TrimRight(b.String(), "\n")
+}
+
+// focusAfterBody moves focus past the review body: to the checklist when
+// there is one, else to the action radio group.
+func (t *ReviewTabModel) focusAfterBody() {
+	if len(t.checklist) > 0 {
+		t.focus = ReviewFocusChecklist
+		t.checkCursor = 0
+		return
+	}
+	t.focus = ReviewFocusRadio
+	t.radioFocus = int(t.action)
+}
+
+// focusBeforeRadio moves focus back from the action radio group.
+func (t *ReviewTabModel) focusBeforeRadio() {
+	if len(t.checklist) > 0 {
+		t.focus = ReviewFocusChecklist
+		t.checkCursor = len(t.checklist) - 1
+		return
+	}
+	t.focus = ReviewFocusTextArea
 }
 
 // SetAIReviewLoading puts the review tab into AI review loading state.
@@ -135,14 +221,30 @@ func (t ReviewTabModel) IsAIReviewLoading() bool {
 	return t.aiLoading
 }
 
-// SetPendingCommentCount sets the number of pending inline comments.
-func (t *ReviewTabModel) SetPendingCommentCount(n int) {
+// AppendToBody adds text to the end of the review body, separated from any
+// existing text by a blank line, and selects Request Changes.
+func (t *ReviewTabModel) AppendToBody(text string) {
+	body := strings.TrimRight(t.textArea.Value(), "\n")
+	if body != "" {
+		body += "\n\n"
+	}
+	t.textArea.SetValue(body + text)
+	t.action = ReviewRequestChanges
+	t.radioFocus = int(ReviewRequestChanges)
+	t.focus = ReviewFocusTextArea
+}
+
+// SetPendingCommentCount sets the number of pending inline comments to
+// submit and the number withheld: held back in :pending or out of scope.
+func (t *ReviewTabModel) SetPendingCommentCount(n, withheld int) {
 	t.pendingCount = n
+	t.withheldCount = withheld
 }
 
 // SetSubmitted clears the submitting state. On success, also resets the form.
 func (t *ReviewTabModel) SetSubmitted(err error) {
 	t.submitting = false
+	t.confirming = false
 	if err == nil {
 		t.textArea.Reset()
 		t.action = t.defaultAction
@@ -152,6 +254,7 @@ func (t *ReviewTabModel) SetSubmitted(err error) {
 		t.aiResult = nil
 		t.aiLoading = false
 		t.aiError = ""
+		t.resetChecklist()
 	}
 }
 
@@ -163,25 +266,41 @@ func (t ReviewTabModel) IsFocused() bool {
 // Blur removes focus from the textarea.
 func (t *ReviewTabModel) Blur() {
 	t.textArea.Blur()
+	t.completer.reset()
 }
 
 // Update handles key events when the Review tab is active.
 // Tab switching (h/l) is handled by the coordinator before delegation.
 func (t ReviewTabModel) Update(msg tea.KeyMsg) (ReviewTabModel, tea.Cmd) {
+	// Express submit: ctrl+s asks for confirmation inline, a second ctrl+s
+	// submits with the current action and body. Any other key cancels.
+	if msg.String() == "ctrl+s" {
+		return t.expressSubmit()
+	}
+	if t.confirming {
+		t.confirming = false
+		if msg.String() == "esc" {
+			return t, nil
+		}
+	}
+
 	// When textarea is focused, it captures all keys except ESC and Tab
 	if t.textArea.Focused() {
+		if t.completer.handles(msg) {
+			return t, updateTextarea(&t.textArea, &t.completer, msg)
+		}
 		switch msg.String() {
 		case "esc":
 			t.textArea.Blur()
+			t.completer.reset()
 			return t, func() tea.Msg { return ModeChangedMsg{Mode: ChatModeNormal} }
 		case "tab":
 			t.textArea.Blur()
-			t.focus = ReviewFocusRadio
+			t.completer.reset()
+			t.focusAfterBody()
 			return t, func() tea.Msg { return ModeChangedMsg{Mode: ChatModeNormal} }
 		default:
-			var cmd tea.Cmd
-			t.textArea, cmd = t.textArea.Update(msg)
-			return t, cmd
+			return t, updateTextarea(&t.textArea, &t.completer, msg)
 		}
 	}
 
@@ -193,8 +312,38 @@ func (t ReviewTabModel) Update(msg tea.KeyMsg) (ReviewTabModel, tea.Cmd) {
 			t.textArea.Focus()
 			return t, func() tea.Msg { return ModeChangedMsg{Mode: ChatModeInsert} }
 		case "tab", "j", "down":
+			t.focusAfterBody() // radio focus starts on the current selection
+			return t, nil
+		}
+
+	case ReviewFocusChecklist:
+		switch msg.String() {
+		case "j", "down":
+			if t.checkCursor < len(t.checklist)-1 {
+				t.checkCursor++
+			} else {
+				t.focus = ReviewFocusRadio
+				t.radioFocus = int(t.action)
+			}
+			return t, nil
+		case "k", "up":
+			if t.checkCursor > 0 {
+				t.checkCursor--
+			} else {
+				t.focus = ReviewFocusTextArea
+			}
+			return t, nil
+		case "enter", " ", "x":
+			if t.checkCursor < len(t.checklist) {
+				t.checklist[t.checkCursor].done = !t.checklist[t.checkCursor].done
+			}
+			return t, nil
+		case "tab":
 			t.focus = ReviewFocusRadio
-			t.radioFocus = int(t.action) // start focus on current selection
+			t.radioFocus = int(t.action)
+			return t, nil
+		case "shift+tab":
+			t.focus = ReviewFocusTextArea
 			return t, nil
 		}
 
@@ -211,7 +360,7 @@ func (t ReviewTabModel) Update(msg tea.KeyMsg) (ReviewTabModel, tea.Cmd) {
 			if t.radioFocus > int(ReviewApprove) {
 				t.radioFocus--
 			} else {
-				t.focus = ReviewFocusTextArea
+				t.focusBeforeRadio()
 			}
 			return t, nil
 		case "enter", " ":
@@ -221,7 +370,7 @@ func (t ReviewTabModel) Update(msg tea.KeyMsg) (ReviewTabModel, tea.Cmd) {
 			t.focus = ReviewFocusSubmit
 			return t, nil
 		case "shift+tab":
-			t.focus = ReviewFocusTextArea
+			t.focusBeforeRadio()
 			return t, nil
 		}
 
@@ -231,22 +380,7 @@ func (t ReviewTabModel) Update(msg tea.KeyMsg) (ReviewTabModel, tea.Cmd) {
 			if t.submitting {
 				return t, nil
 			}
-			body := strings.TrimSpace(t.textArea.Value())
-			if t.action == ReviewRequestChanges && body == "" {
-				return t, func() tea.Msg {
-					return ReviewValidationMsg{Message: "Review body is required for Request Changes"}
-				}
-			}
-			if t.action == ReviewComment && body == "" {
-				return t, func() tea.Msg {
-					return ReviewValidationMsg{Message: "Review body is required for Comment"}
-				}
-			}
-			t.submitting = true
-			action := t.action
-			return t, func() tea.Msg {
-				return ReviewSubmitMsg{Action: action, Body: body}
-			}
+			return t.submit()
 		case "tab":
 			t.focus = ReviewFocusTextArea
 			return t, nil
@@ -264,6 +398,68 @@ func (t ReviewTabModel) Update(msg tea.KeyMsg) (ReviewTabModel, tea.Cmd) {
 	return t, nil
 }
 
+// validationError returns why the review cannot be submitted as is, or "".
+func (t ReviewTabModel) validationError() string {
+	return reviewValidationError(t.action, t.textArea.Value())
+}
+
+// reviewValidationError returns why a review with this action and body
+// cannot be submitted, or "".
+func reviewValidationError(action ReviewAction, body string) string {
+	if strings.TrimSpace(body) != "" {
+		return ""
+	}
+	switch action {
+	case ReviewRequestChanges:
+		return "Review body is required for Request Changes"
+	case ReviewComment:
+		return "Review body is required for Comment"
+	}
+	return ""
+}
+
+// submit validates the form and emits ReviewSubmitMsg.
+func (t ReviewTabModel) submit() (ReviewTabModel, tea.Cmd) {
+	if msg := t.validationError(); msg != "" {
+		return t, func() tea.Msg { return ReviewValidationMsg{Message: msg} }
+	}
+	t.submitting = true
+	action := t.action
+	body := strings.TrimSpace(t.textArea.Value())
+	if checklist := t.checklistMarkdown(); checklist != "" {
+		if body != "" {
+			body += "\n\n"
+		}
+		body += checklist
+	}
+	return t, func() tea.Msg {
+		return ReviewSubmitMsg{Action: action, Body: body}
+	}
+}
+
+// expressSubmit handles ctrl+s: the first press validates and asks for
+// confirmation, the second submits without moving focus to the button.
+func (t ReviewTabModel) expressSubmit() (ReviewTabModel, tea.Cmd) {
+	if t.submitting {
+		return t, nil
+	}
+	if !t.confirming {
+		if msg := t.validationError(); msg != "" {
+			return t, func() tea.Msg { return ReviewValidationMsg{Message: msg} }
+		}
+		t.confirming = true
+		return t, nil
+	}
+	t.confirming = false
+	var cmds []tea.Cmd
+	if t.textArea.Focused() {
+		t.textArea.Blur()
+		cmds = append(cmds, func() tea.Msg { return ModeChangedMsg{Mode: ChatModeNormal} })
+	}
+	t, cmd := t.submit()
+	return t, tea.Batch(append(cmds, cmd)...)
+}
+
 // Render renders the Review tab content (textarea, radio options, submit button).
 func (t ReviewTabModel) Render(width int, spinnerView string) string {
 	var b strings.Builder
@@ -271,24 +467,24 @@ func (t ReviewTabModel) Render(width int, spinnerView string) string {
 	// AI review status banner
 	if t.aiLoading {
 		b.WriteString(lipgloss.NewStyle().
-			Foreground(lipgloss.Color("244")).
+			Foreground(theme.Muted).
 			Render(spinnerView + " Generating AI review..."))
 		b.WriteString("\n\n")
 	} else if t.aiError != "" {
 		b.WriteString(lipgloss.NewStyle().
-			Foreground(lipgloss.Color("196")).
+			Foreground(theme.Error).
 			Bold(true).
 			Render("AI review failed: " + formatUserError(t.aiError)))
 		b.WriteString("\n")
 		b.WriteString(lipgloss.NewStyle().
-			Foreground(lipgloss.Color("244")).
+			Foreground(theme.Muted).
 			Italic(true).
 			Render("Press R to retry"))
 		b.WriteString("\n\n")
 	} else if t.aiResult != nil {
 		badge := lipgloss.NewStyle().
-			Foreground(lipgloss.Color("0")).
-			Background(lipgloss.Color("75")).
+			Foreground(theme.Inverse).
+			Background(theme.AI).
 			Bold(true).
 			Padding(0, 1).
 			Render("AI REVIEW")
@@ -302,22 +498,46 @@ func (t ReviewTabModel) Render(width int, spinnerView string) string {
 		if t.pendingCount != 1 {
 			countText += "s"
 		}
-		countText += " will be submitted"
+		countText += " will be submitted (:pending to review)"
 		b.WriteString(lipgloss.NewStyle().
-			Foreground(lipgloss.Color("214")).
+			Foreground(theme.Warning).
 			Render(countText))
 		b.WriteString("\n\n")
 	}
+	if t.withheldCount > 0 {
+		countText := fmt.Sprintf("⊘ %d draft", t.withheldCount)
+		if t.withheldCount != 1 {
+			countText += "s"
+		}
+		countText += " will be kept, not submitted (held back or out of scope)"
+		b.WriteString(reviewOptionDimStyle.Render(countText))
+		b.WriteString("\n\n")
+	}
+
+	// Code owners still needed, shown while approving
+	if t.action == ReviewApprove && t.ownerCoverage != nil && t.ownerCoverage.total > 0 {
+		b.WriteString(renderOwnerCoverage(t.ownerCoverage))
+	}
 
 	// 1. Review body textarea
 	label := reviewLabelStyle.Render("Review Body")
 	if t.focus == ReviewFocusTextArea && !t.textArea.Focused() {
-		label += lipgloss.NewStyle().Foreground(lipgloss.Color("240")).Italic(true).Render("  press Enter to edit")
+		label += lipgloss.NewStyle().Foreground(theme.Subtle).Italic(true).Render("  press Enter to edit")
 	}
 	b.WriteString(label)
 	b.WriteString("\n")
 	b.WriteString(t.textArea.View())
-	b.WriteString("\n\n")
+	b.WriteString("\n")
+	if t.textArea.Focused() {
+		b.WriteString(t.completer.View(width))
+	}
+	b.WriteString("\n")
+
+	// Repo review checklist
+	if len(t.checklist) > 0 {
+		b.WriteString(t.renderChecklist())
+		b.WriteString("\n")
+	}
 
 	// 2. Review action radio group
 	b.WriteString(reviewLabelStyle.Render("Action"))
@@ -370,21 +590,24 @@ func (t ReviewTabModel) Render(width int, spinnerView string) string {
 		buttonText = "[ Submitting... ]"
 	}
 
-	if t.focus == ReviewFocusSubmit && !t.submitting {
+	if t.confirming {
+		b.WriteString("  " + lipgloss.NewStyle().Foreground(theme.Warning).Bold(true).
+			Render(fmt.Sprintf("Submit %s? ctrl+s to confirm, any other key to cancel", actionLabels[t.action])))
+	} else if t.focus == ReviewFocusSubmit && !t.submitting {
 		var style lipgloss.Style
 		switch t.action {
 		case ReviewApprove:
 			style = reviewSubmitFocusedStyle.
-				Foreground(lipgloss.Color("0")).
-				Background(lipgloss.Color("42"))
+				Foreground(theme.Inverse).
+				Background(theme.Success)
 		case ReviewRequestChanges:
 			style = reviewSubmitFocusedStyle.
-				Foreground(lipgloss.Color("255")).
-				Background(lipgloss.Color("196"))
+				Foreground(theme.OnAccent).
+				Background(theme.Error)
 		default:
 			style = reviewSubmitFocusedStyle.
-				Foreground(lipgloss.Color("252")).
-				Background(lipgloss.Color("62"))
+				Foreground(theme.OnAccent).
+				Background(theme.Accent)
 		}
 		b.WriteString("  " + style.Render(buttonText))
 	} else {
@@ -393,3 +616,34 @@ func (t ReviewTabModel) Render(width int, spinnerView string) string {
 
 	return b.String()
 }
+
+// renderChecklist renders the checklist with the cursor when focused.
+func (t ReviewTabModel) renderChecklist() string {
+	var b strings.Builder
+	done := 0
+	for _, it := range t.checklist {
+		if it.done {
+			done++
+		}
+	}
+	b.WriteString(reviewLabelStyle.Render("Checklist"))
+	b.WriteString(reviewOptionDimStyle.Render(fmt.Sprintf("  %d/%d", done, len(t.checklist))))
+	b.WriteString("\n")
+	for i, it := range t.checklist {
+		isFocused := t.focus == ReviewFocusChecklist && t.checkCursor == i
+		prefix := "  "
+		if isFocused {
+			prefix = "▸ "
+		}
+		line := prefix + "[ ] " + reviewOptionDimStyle.Render(it.label)
+		if it.done {
+			line = prefix + lipgloss.NewStyle().Foreground(theme.Success).Render("[x] "+it.label)
+		}
+		if isFocused {
+			line = lipgloss.NewStyle().Bold(true).Render(line)
+		}
+		b.WriteString(line)
+		b.WriteString("\n")
+	}
+	return b.String()
+}
@@ -386,3 +386,108 @@ type testError string
 
 func (e testError) Error() string { return string(e) }
 func errForTest(msg string) error { return testError(msg) }
+
+func TestReviewTab_ExpressSubmit_ConfirmsThenSubmits(t *testing.T) {
+	tab := NewReviewTabModel()
+	tab.textArea.SetValue("Looks good overall")
+	tab.textArea.Focus()
+	ctrlS := tea.KeyMsg{Type: tea.KeyCtrlS}
+
+	tab, cmd := tab.Update(ctrlS)
+	if cmd != nil {
+		t.Fatal("first ctrl+s should only ask for confirmation")
+	}
+	if !tab.confirming {
+		t.Fatal("first ctrl+s should enter confirmation")
+	}
+
+	tab, cmd = tab.Update(ctrlS)
+	if !tab.submitting {
+		t.Error("second ctrl+s should submit")
+	}
+	if tab.textArea.Focused() {
+		t.Error("express submit should leave insert mode")
+	}
+	var submit *ReviewSubmitMsg
+	for _, msg := range cmd().(tea.BatchMsg) {
+		if m, ok := msg().(ReviewSubmitMsg); ok {
+			submit = &m
+		}
+	}
+	if submit == nil || submit.Action != ReviewComment || submit.Body != "Looks good overall" {
+		t.Errorf("submit msg = %+v", submit)
+	}
+}
+
+func TestReviewTab_ExpressSubmit_OtherKeyCancels(t *testing.T) {
+	tab := NewReviewTabModel()
+	tab.textArea.SetValue("body")
+
+	tab, _ = tab.Update(tea.KeyMsg{Type: tea.KeyCtrlS})
+	tab, _ = tab.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if tab.confirming {
+		t.Error("esc should cancel the confirmation")
+	}
+	tab, cmd := tab.Update(tea.KeyMsg{Type: tea.KeyCtrlS})
+	if cmd != nil || tab.submitting {
+		t.Error("ctrl+s after cancelling should ask again, not submit")
+	}
+}
+
+func TestReviewTab_ExpressSubmit_ValidatesFirst(t *testing.T) {
+	tab := NewReviewTabModel()
+	tab.action = ReviewRequestChanges
+
+	tab, cmd := tab.Update(tea.KeyMsg{Type: tea.KeyCtrlS})
+	if tab.confirming {
+		t.Error("an invalid review should not ask for confirmation")
+	}
+	if cmd == nil {
+		t.Fatal("expected a validation message")
+	}
+	if _, ok := cmd().(ReviewValidationMsg); !ok {
+		t.Errorf("got %T, want ReviewValidationMsg", cmd())
+	}
+}
+
+func TestReviewTab_Checklist(t *testing.T) {
+	tab := NewReviewTabModel()
+	tab.SetChecklist([]string{"Migrations reviewed", "Docs updated"})
+	tab.textArea.SetValue("Looks good")
+
+	// Body → checklist, tick the second item, then on to the actions.
+	tab, _ = tab.Update(tea.KeyMsg{Type: tea.KeyTab})
+	if tab.focus != ReviewFocusChecklist {
+		t.Fatalf("focus = %d, want the checklist", tab.focus)
+	}
+	tab, _ = tab.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("j")})
+	tab, _ = tab.Update(tea.KeyMsg{Type: tea.KeySpace, Runes: []rune(" ")})
+	if !tab.checklist[1].done || tab.checklist[0].done {
+		t.Errorf("checklist = %+v", tab.checklist)
+	}
+	tab, _ = tab.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("j")})
+	if tab.focus != ReviewFocusRadio {
+		t.Errorf("focus = %d, want the actions after the last item", tab.focus)
+	}
+	tab, _ = tab.Update(tea.KeyMsg{Type: tea.KeyShiftTab})
+	if tab.focus != ReviewFocusChecklist || tab.checkCursor != 1 {
+		t.Errorf("shift+tab from the actions should return to the last item")
+	}
+
+	// Reloading the same items keeps their state.
+	tab.SetChecklist([]string{"Migrations reviewed", "Docs updated", "Security considered"})
+	if !tab.checklist[1].done {
+		t.Error("reloading the checklist lost its state")
+	}
+
+	tab.focus = ReviewFocusSubmit
+	_, cmd := tab.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	submit, ok := cmd().(ReviewSubmitMsg)
+	if !ok {
+		t.Fatal("expected ReviewSubmitMsg")
+	}
+	want := "Looks good\n\n**Review checklist**\n- [ ] Migrations reviewed\n- [x] Docs updated\n- [ ] Security considered"
+	if submit.Body != want {
+		t.Errorf("body = %q, want %q", submit.Body, want)
+	}
+}
@@ -0,0 +1,168 @@
+package ui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/shhac/prtea/internal/notify"
+)
+
+// reviewTimerNudge is how long before the deadline a heads-up is shown, for
+// timers long enough that a heads-up is useful.
+const reviewTimerNudge = 5 * time.Minute
+
+// reviewTimer is a time box for reviewing one PR, started with :timer.
+type reviewTimer struct {
+	number   int
+	duration time.Duration
+	deadline time.Time
+	nudged   bool // the "5 minutes left" heads-up was shown
+	expired  bool // the time's up reminder was shown
+}
+
+// reviewTimerTickMsg refreshes review timers once a second while any are running.
+type reviewTimerTickMsg struct {
+	Now time.Time
+}
+
+func reviewTimerTickCmd() tea.Cmd {
+	return tea.Tick(time.Second, func(t time.Time) tea.Msg {
+		return reviewTimerTickMsg{Now: t}
+	})
+}
+
+// parseTimerDuration parses a :timer argument. A bare number is minutes.
+func parseTimerDuration(arg string) (time.Duration, error) {
+	if n, err := strconv.Atoi(arg); err == nil {
+		arg = fmt.Sprintf("%dm", n)
+	}
+	d, err := time.ParseDuration(arg)
+	if err != nil || d <= 0 {
+		return 0, fmt.Errorf("invalid duration %q (try 20m or 1h30m)", arg)
+	}
+	return d.Round(time.Second), nil
+}
+
+// formatTimerRemaining renders time left as "12:34", or time over as "+01:05".
+func formatTimerRemaining(left time.Duration) string {
+	sign := ""
+	if left < 0 {
+		sign = "+"
+		left = -left
+	}
+	secs := int(left / time.Second)
+	if sign == "" && left%time.Second != 0 {
+		secs++ // count down to 00:00 rather than showing it a second early
+	}
+	if secs >= 3600 {
+		return fmt.Sprintf("%s%d:%02d:%02d", sign, secs/3600, secs/60%60, secs%60)
+	}
+	return fmt.Sprintf("%s%02d:%02d", sign, secs/60, secs%60)
+}
+
+// reviewTimerCommand handles ":timer <duration>", ":timer off", and ":timer"
+// (show the time left) for the selected PR.
+func (m App) reviewTimerCommand(args string) (tea.Model, tea.Cmd) {
+	if m.session == nil {
+		clearCmd := m.statusBar.SetTemporaryMessage("Select a PR to start a review timer", 2*time.Second)
+		return m, clearCmd
+	}
+	key := prKey(m.session.Owner, m.session.Repo, m.session.Number)
+	args = strings.TrimSpace(args)
+
+	switch strings.ToLower(args) {
+	case "":
+		t := m.reviewTimers[key]
+		if t == nil {
+			clearCmd := m.statusBar.SetTemporaryMessage("No review timer — start one with :timer 20m", 2*time.Second)
+			return m, clearCmd
+		}
+		left := time.Until(t.deadline)
+		msg := fmt.Sprintf("%s left of %s review timer", formatTimerRemaining(left), t.duration)
+		if left < 0 {
+			msg = fmt.Sprintf("%s over the %s review timer", strings.TrimPrefix(formatTimerRemaining(left), "+"), t.duration)
+		}
+		clearCmd := m.statusBar.SetTemporaryMessage(msg, 3*time.Second)
+		return m, clearCmd
+	case "off", "stop", "cancel":
+		delete(m.reviewTimers, key)
+		m.syncTimerStatus(time.Now())
+		clearCmd := m.statusBar.SetTemporaryMessage(fmt.Sprintf("Review timer for #%d stopped", m.session.Number), 2*time.Second)
+		return m, clearCmd
+	}
+
+	d, err := parseTimerDuration(args)
+	if err != nil {
+		clearCmd := m.statusBar.SetTemporaryMessage("Timer: "+err.Error(), 3*time.Second)
+		return m, clearCmd
+	}
+	now := time.Now()
+	m.reviewTimers[key] = &reviewTimer{
+		number:   m.session.Number,
+		duration: d,
+		deadline: now.Add(d),
+	}
+	m.syncTimerStatus(now)
+	cmds := []tea.Cmd{m.statusBar.SetTemporaryMessage(fmt.Sprintf("Review timer started: %s for #%d", d, m.session.Number), 2*time.Second)}
+	if !m.timerTicking {
+		m.timerTicking = true
+		cmds = append(cmds, reviewTimerTickCmd())
+	}
+	return m, tea.Batch(cmds...)
+}
+
+// handleReviewTimerTick refreshes the countdown and shows reminders. Timers
+// keep running while other PRs are selected; the tick loop stops once no
+// timers remain.
+func (m App) handleReviewTimerTick(now time.Time) (tea.Model, tea.Cmd) {
+	if len(m.reviewTimers) == 0 {
+		m.timerTicking = false
+		m.syncTimerStatus(now)
+		return m, nil
+	}
+
+	var cmds []tea.Cmd
+	for _, t := range m.reviewTimers {
+		left := t.deadline.Sub(now)
+		var reminder string
+		switch {
+		case left <= 0 && !t.expired:
+			t.expired, t.nudged = true, true
+			reminder = fmt.Sprintf("⏱ Time's up on #%d (%s) — a good moment to wrap up or take a break", t.number, t.duration)
+		case left <= reviewTimerNudge && !t.nudged && t.duration >= 2*reviewTimerNudge:
+			t.nudged = true
+			reminder = fmt.Sprintf("⏱ %s left on #%d", formatTimerRemaining(left), t.number)
+		}
+		if reminder == "" {
+			continue
+		}
+		cmds = append(cmds, m.statusBar.SetTemporaryMessage(reminder, 5*time.Second))
+		if t.expired && m.notifyEnabled {
+			body := fmt.Sprintf("Your %s review of #%d is up", t.duration, t.number)
+			cmds = append(cmds, func() tea.Msg {
+				_ = notify.Send("prtea: Review timer", body)
+				return nil
+			})
+		}
+	}
+	m.syncTimerStatus(now)
+	cmds = append(cmds, reviewTimerTickCmd())
+	return m, tea.Batch(cmds...)
+}
+
+// syncTimerStatus shows the selected PR's timer in the status bar.
+func (m *App) syncTimerStatus(now time.Time) {
+	if m.session == nil {
+		m.statusBar.SetTimerInfo("")
+		return
+	}
+	t := m.reviewTimers[prKey(m.session.Owner, m.session.Repo, m.session.Number)]
+	if t == nil {
+		m.statusBar.SetTimerInfo("")
+		return
+	}
+	m.statusBar.SetTimerInfo("⏱ " + formatTimerRemaining(t.deadline.Sub(now)))
+}
@@ -0,0 +1,138 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestParseTimerDuration(t *testing.T) {
+	tests := []struct {
+		in   string
+		want time.Duration
+	}{
+		{"20m", 20 * time.Minute},
+		{"1h30m", 90 * time.Minute},
+		{"15", 15 * time.Minute},
+		{"45s", 45 * time.Second},
+	}
+	for _, tt := range tests {
+		got, err := parseTimerDuration(tt.in)
+		if err != nil || got != tt.want {
+			t.Errorf("parseTimerDuration(%q) = %v, %v; want %v", tt.in, got, err, tt.want)
+		}
+	}
+	for _, bad := range []string{"soon", "-5m", "0"} {
+		if _, err := parseTimerDuration(bad); err == nil {
+			t.Errorf("parseTimerDuration(%q) should fail", bad)
+		}
+	}
+}
+
+func TestFormatTimerRemaining(t *testing.T) {
+	tests := []struct {
+		in   time.Duration
+		want string
+	}{
+		{20 * time.Minute, "20:00"},
+		{59*time.Second + 500*time.Millisecond, "01:00"},
+		{90 * time.Minute, "1:30:00"},
+		{-65 * time.Second, "+01:05"},
+	}
+	for _, tt := range tests {
+		if got := formatTimerRemaining(tt.in); got != tt.want {
+			t.Errorf("formatTimerRemaining(%v) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestSplitCommandArgs(t *testing.T) {
+	name, args, ok := splitCommandArgs("timer 20m")
+	if !ok || name != "timer" || args != "20m" {
+		t.Errorf("got %q %q %v", name, args, ok)
+	}
+	if name, args, ok = splitCommandArgs("tm off"); !ok || name != "timer" || args != "off" {
+		t.Errorf("alias: got %q %q %v", name, args, ok)
+	}
+	if _, _, ok = splitCommandArgs("toggle left"); ok {
+		t.Error("commands without arguments should not be split")
+	}
+}
+
+func TestCommandMode_EnterPassesArgs(t *testing.T) {
+	m := NewCommandModeModel()
+	m.SetSize(80, 24)
+	m.Open(false)
+	for _, r := range "timer 20m" {
+		m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+	if len(m.filtered) != 1 || m.filtered[0].Name != "timer" {
+		t.Errorf("filtered = %v, want timer", m.filtered)
+	}
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	got, ok := cmd().(CommandExecuteMsg)
+	if !ok || got.Name != "timer" || got.Args != "20m" {
+		t.Errorf("msg = %+v", got)
+	}
+}
+
+func timerTestApp() App {
+	return App{
+		statusBar:    NewStatusBarModel(),
+		session:      &PRSession{Owner: "acme", Repo: "widgets", Number: 7},
+		reviewTimers: make(map[string]*reviewTimer),
+	}
+}
+
+func TestReviewTimer_StartTickAndStop(t *testing.T) {
+	m := timerTestApp()
+	model, cmd := m.reviewTimerCommand("20m")
+	m = model.(App)
+	if cmd == nil || !m.timerTicking {
+		t.Fatal("starting a timer should start the tick loop")
+	}
+	if !strings.HasPrefix(m.statusBar.timerInfo, "⏱ 20:00") {
+		t.Errorf("timerInfo = %q", m.statusBar.timerInfo)
+	}
+
+	timer := m.reviewTimers[prKey("acme", "widgets", 7)]
+	model, _ = m.handleReviewTimerTick(timer.deadline.Add(-4 * time.Minute))
+	m = model.(App)
+	if !timer.nudged || timer.expired {
+		t.Error("expected the five-minute heads-up")
+	}
+
+	model, _ = m.handleReviewTimerTick(timer.deadline.Add(time.Minute))
+	m = model.(App)
+	if !timer.expired || !strings.Contains(m.statusBar.statusMessage, "Time's up on #7") {
+		t.Errorf("expected time's up reminder, got %q", m.statusBar.statusMessage)
+	}
+	if m.statusBar.timerInfo != "⏱ +01:00" {
+		t.Errorf("timerInfo = %q, want overtime", m.statusBar.timerInfo)
+	}
+
+	model, _ = m.reviewTimerCommand("off")
+	m = model.(App)
+	if len(m.reviewTimers) != 0 || m.statusBar.timerInfo != "" {
+		t.Error("timer off should remove the timer")
+	}
+	model, cmd = m.handleReviewTimerTick(time.Now())
+	if model.(App).timerTicking || cmd != nil {
+		t.Error("tick loop should stop when no timers remain")
+	}
+}
+
+func TestReviewTimer_ShownOnlyForSelectedPR(t *testing.T) {
+	m := timerTestApp()
+	model, _ := m.reviewTimerCommand("10m")
+	m = model.(App)
+
+	m.session = &PRSession{Owner: "acme", Repo: "widgets", Number: 8}
+	model, _ = m.handleReviewTimerTick(time.Now())
+	m = model.(App)
+	if m.statusBar.timerInfo != "" {
+		t.Errorf("timerInfo = %q for a PR without a timer", m.statusBar.timerInfo)
+	}
+}
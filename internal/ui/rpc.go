@@ -0,0 +1,75 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/shhac/prtea/internal/rpc"
+)
+
+// SnapshotQuery returns an rpc.QueryFunc that asks the running program for
+// its current state. send is typically (*tea.Program).Send.
+func SnapshotQuery(send func(tea.Msg)) rpc.QueryFunc {
+	return func(ctx context.Context) (*rpc.Snapshot, error) {
+		reply := make(chan *rpc.Snapshot, 1)
+		// Send blocks until the program starts, so don't tie up the caller.
+		go send(rpcQueryMsg{reply: reply})
+		select {
+		case snap := <-reply:
+			return snap, nil
+		case <-ctx.Done():
+			return nil, fmt.Errorf("timed out waiting for prtea to respond")
+		}
+	}
+}
+
+// rpcSnapshot builds the read-only state exposed over the RPC socket.
+func (m App) rpcSnapshot() *rpc.Snapshot {
+	snap := &rpc.Snapshot{
+		ActiveTab: "review",
+		ToReview:  rpcPRs(m.prList.toReview),
+		MyPRs:     rpcPRs(m.prList.myPRs),
+	}
+	if m.prList.activeTab == TabMyPRs {
+		snap.ActiveTab = "mine"
+	}
+	if m.session != nil {
+		sel := &rpc.SelectedPR{
+			Number:        m.session.Number,
+			Title:         m.session.Title,
+			Repo:          m.session.Owner + "/" + m.session.Repo,
+			URL:           m.session.HTMLURL,
+			Files:         len(m.diffViewer.files),
+			SelectedHunks: len(m.diffViewer.selectedHunks),
+		}
+		if m.diffViewer.ciStatus != nil {
+			sel.CIStatus = m.diffViewer.ciStatus.OverallStatus
+		}
+		snap.Selected = sel
+		snap.PendingComments = len(m.session.PendingInlineComments)
+	}
+	return snap
+}
+
+// rpcPRs converts PR list items to their RPC representation.
+func rpcPRs(items []list.Item) []rpc.PR {
+	prs := make([]rpc.PR, 0, len(items))
+	for _, it := range items {
+		pr, ok := it.(PRItem)
+		if !ok {
+			continue
+		}
+		prs = append(prs, rpc.PR{
+			Number:         pr.number,
+			Title:          pr.title,
+			Repo:           pr.repoFull,
+			Author:         pr.author,
+			URL:            pr.htmlURL,
+			ReviewDecision: pr.reviewDecision,
+			Draft:          pr.isDraft,
+		})
+	}
+	return prs
+}
@@ -0,0 +1,38 @@
+package ui
+
+import (
+	"context"
+	"testing"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestSnapshotQuery_RoutesThroughUpdate(t *testing.T) {
+	m := App{
+		prList: NewPRListModel(TabMyPRs),
+		session: &PRSession{
+			Owner: "acme", Repo: "api", Number: 42, Title: "Add retries",
+			PendingInlineComments: []PendingInlineComment{{}, {}},
+		},
+	}
+	m.prList.toReview = []list.Item{PRItem{number: 7, title: "Fix typo", repoFull: "acme/web", author: "bob"}}
+
+	query := SnapshotQuery(func(msg tea.Msg) { m.Update(msg) })
+	snap, err := query(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if snap.ActiveTab != "mine" {
+		t.Errorf("ActiveTab = %q, want mine", snap.ActiveTab)
+	}
+	if snap.Selected == nil || snap.Selected.Number != 42 || snap.Selected.Repo != "acme/api" {
+		t.Errorf("Selected = %+v", snap.Selected)
+	}
+	if snap.PendingComments != 2 {
+		t.Errorf("PendingComments = %d, want 2", snap.PendingComments)
+	}
+	if len(snap.ToReview) != 1 || snap.ToReview[0].Author != "bob" {
+		t.Errorf("ToReview = %+v", snap.ToReview)
+	}
+}
@@ -1,6 +1,56 @@
 package ui
 
-import "strings"
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// scrollbarThumb returns the first row and height of the scrollbar thumb.
+func (m DiffViewerModel) scrollbarThumb() (start, size int) {
+	height := m.viewport.Height
+	totalLines := m.viewport.TotalLineCount()
+	if totalLines <= 0 || height <= 0 {
+		return 0, 0
+	}
+	size = max(1, height*height/totalLines)
+	start = m.viewport.YOffset * height / totalLines
+	if start+size > height {
+		start = height - size
+	}
+	return start, size
+}
+
+// scrollbarRow maps a content line to its scrollbar row.
+func (m DiffViewerModel) scrollbarRow(line int) int {
+	height := m.viewport.Height
+	row := line * height / m.viewport.TotalLineCount()
+	if row >= height {
+		row = height - 1
+	}
+	return row
+}
+
+// scrollbarMarkers collects comment marker positions in scrollbar space,
+// keeping the highest-priority comment kind per row.
+func (m DiffViewerModel) scrollbarMarkers() []commentKind {
+	markers := make([]commentKind, m.viewport.Height)
+	if m.activeTab != TabDiff || m.cachedLineInfo == nil || m.viewport.TotalLineCount() <= 0 {
+		return markers
+	}
+	for i, info := range m.cachedLineInfo {
+		if info.comment == commentNone {
+			continue
+		}
+		row := m.scrollbarRow(i)
+		// Priority: pending > GitHub > AI (higher commentKind value wins)
+		if info.comment > markers[row] {
+			markers[row] = info.comment
+		}
+	}
+	return markers
+}
 
 // renderScrollbar builds a 1-char-wide vertical scrollbar column with comment markers.
 // Each row maps proportionally to the total content; the thumb shows the visible portion
@@ -12,31 +62,8 @@ func (m DiffViewerModel) renderScrollbar() string {
 		return strings.Repeat(" \n", height-1) + " "
 	}
 
-	// Thumb position and size
-	thumbSize := max(1, height*height/totalLines)
-	thumbStart := m.viewport.YOffset * height / totalLines
-	if thumbStart+thumbSize > height {
-		thumbStart = height - thumbSize
-	}
-
-	// Collect comment marker positions in scrollbar space.
-	// Track the highest-priority comment kind per scrollbar row.
-	commentMarkers := make([]commentKind, height)
-	if m.activeTab == TabDiff && m.cachedLineInfo != nil {
-		for i, info := range m.cachedLineInfo {
-			if info.comment == commentNone {
-				continue
-			}
-			row := i * height / totalLines
-			if row >= height {
-				row = height - 1
-			}
-			// Priority: pending > GitHub > AI (higher commentKind value wins)
-			if info.comment > commentMarkers[row] {
-				commentMarkers[row] = info.comment
-			}
-		}
-	}
+	thumbStart, thumbSize := m.scrollbarThumb()
+	commentMarkers := m.scrollbarMarkers()
 
 	// Render each scrollbar row
 	rows := make([]string, height)
@@ -59,3 +86,102 @@ func (m DiffViewerModel) renderScrollbar() string {
 	}
 	return strings.Join(rows, "\n")
 }
+
+// scrollbarHit translates panel-local mouse coordinates into a scrollbar row.
+// ok is false when the point is off the scrollbar or no scrollbar is shown.
+func (m DiffViewerModel) scrollbarHit(x, y int) (row int, ok bool) {
+	if !m.ready || m.viewport.TotalLineCount() <= m.viewport.Height {
+		return 0, false
+	}
+	// Left border, then the viewport, then the scrollbar column (or the
+	// minimap's columns).
+	if x < 1+m.viewport.Width || x >= 1+m.viewport.Width+m.gutterWidth() {
+		return 0, false
+	}
+	// Top border, then the tab row.
+	row = y - 1 - lipgloss.Height(m.renderTabs())
+	if row < 0 || row >= m.viewport.Height {
+		return 0, false
+	}
+	return row, true
+}
+
+// handleScrollbarMouse makes the scrollbar interactive: clicking the track
+// jumps to the proportional location, clicking a comment marker jumps to that
+// comment, and pressing on the thumb starts a drag. msg carries panel-local
+// coordinates. Returns false when the event is not for the scrollbar.
+func (m *DiffViewerModel) handleScrollbarMouse(msg tea.MouseMsg) bool {
+	if m.scrollDrag {
+		switch msg.Action {
+		case tea.MouseActionMotion:
+			row := msg.Y - 1 - lipgloss.Height(m.renderTabs())
+			m.scrollToScrollbarRow(row - m.scrollDragOffset)
+			return true
+		case tea.MouseActionRelease:
+			m.scrollDrag = false
+			return true
+		}
+	}
+
+	if msg.Action != tea.MouseActionPress || msg.Button != tea.MouseButtonLeft {
+		return false
+	}
+	row, ok := m.scrollbarHit(msg.X, msg.Y)
+	if !ok {
+		return false
+	}
+
+	thumbStart, thumbSize := m.scrollbarThumb()
+	inThumb := row >= thumbStart && row < thumbStart+thumbSize
+	if !inThumb && m.scrollbarMarkers()[row] != commentNone {
+		m.jumpToCommentAtRow(row)
+		return true
+	}
+	if inThumb {
+		m.scrollDrag = true
+		m.scrollDragOffset = row - thumbStart
+		return true
+	}
+	m.scrollToScrollbarRow(row)
+	return true
+}
+
+// scrollToScrollbarRow scrolls so the thumb starts at the given row.
+func (m *DiffViewerModel) scrollToScrollbarRow(row int) {
+	height := m.viewport.Height
+	row = max(0, min(row, height-1))
+	m.cancelSelection()
+	// Round up so the rendered thumb lands on the requested row.
+	m.viewport.SetYOffset((row*m.viewport.TotalLineCount() + height - 1) / height)
+	m.syncFocusToScroll()
+	if m.activeTab == TabDiff {
+		m.syncCursorToScroll()
+	}
+	m.refreshContent()
+}
+
+// jumpToCommentAtRow moves the cursor to the first commented line drawn at
+// the given scrollbar row and scrolls it into view.
+func (m *DiffViewerModel) jumpToCommentAtRow(row int) {
+	for i, info := range m.cachedLineInfo {
+		if info.comment == commentNone || m.scrollbarRow(i) != row {
+			continue
+		}
+		m.cancelSelection()
+		oldHunk := m.focusedHunkIdx
+		target := i
+		// Comment boxes follow their diff line; land the cursor on that line.
+		for target > 0 && !m.cachedLineInfo[target].isDiffLine {
+			target--
+		}
+		m.cursorLine = target
+		if info.hunkIdx >= 0 {
+			m.focusedHunkIdx = info.hunkIdx
+		}
+		m.viewport.SetYOffset(max(0, target-m.viewport.Height/3))
+		m.markHunkDirty(oldHunk)
+		m.markHunkDirty(m.focusedHunkIdx)
+		m.refreshContent()
+		return
+	}
+}
@@ -0,0 +1,145 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/shhac/prtea/internal/claude"
+	"github.com/shhac/prtea/internal/github"
+)
+
+// newScrollbarTestViewer returns a diff viewer with a 200-line file and a
+// 10-row viewport, so the scrollbar is shown.
+func newScrollbarTestViewer() DiffViewerModel {
+	var patch strings.Builder
+	patch.WriteString("@@ -0,0 +1,200 @@")
+	for i := 1; i <= 200; i++ {
+		fmt.Fprintf(&patch, "\n+line %d", i)
+	}
+	m := newTestDiffViewer(60, 10)
+	m.SetDiff([]github.PRFile{{Filename: "big.go", Status: "added", Patch: patch.String()}})
+	return m
+}
+
+// scrollbarClick builds a panel-local mouse event on the given scrollbar row.
+func scrollbarClick(m DiffViewerModel, row int, action tea.MouseAction) tea.MouseMsg {
+	return tea.MouseMsg{
+		X:      1 + m.viewport.Width,
+		Y:      1 + lipgloss.Height(m.renderTabs()) + row,
+		Button: tea.MouseButtonLeft,
+		Action: action,
+	}
+}
+
+func TestScrollbarClick_JumpsProportionally(t *testing.T) {
+	m := newScrollbarTestViewer()
+	total := m.viewport.TotalLineCount()
+
+	m, _ = m.Update(scrollbarClick(m, 5, tea.MouseActionPress))
+
+	if want := (5*total + m.viewport.Height - 1) / m.viewport.Height; m.viewport.YOffset != want && !m.viewport.AtBottom() {
+		t.Errorf("YOffset = %d, want %d", m.viewport.YOffset, want)
+	}
+	if m.cursorLine < m.viewport.YOffset {
+		t.Errorf("cursor %d left behind above viewport at %d", m.cursorLine, m.viewport.YOffset)
+	}
+}
+
+func TestScrollbarClick_OffScrollbarIgnored(t *testing.T) {
+	m := newScrollbarTestViewer()
+	msg := scrollbarClick(m, 5, tea.MouseActionPress)
+	msg.X -= 3
+
+	m, _ = m.Update(msg)
+
+	if m.viewport.YOffset != 0 {
+		t.Errorf("YOffset = %d, want 0 for a click outside the scrollbar", m.viewport.YOffset)
+	}
+}
+
+func TestScrollbarDrag_MovesThumb(t *testing.T) {
+	m := newScrollbarTestViewer()
+
+	m, _ = m.Update(scrollbarClick(m, 0, tea.MouseActionPress))
+	if !m.scrollDrag {
+		t.Fatal("pressing the thumb should start a drag")
+	}
+	m, _ = m.Update(scrollbarClick(m, 4, tea.MouseActionMotion))
+	if start, _ := m.scrollbarThumb(); start != 4 {
+		t.Errorf("thumb start = %d after drag, want 4", start)
+	}
+	m, _ = m.Update(scrollbarClick(m, 4, tea.MouseActionRelease))
+	if m.scrollDrag {
+		t.Error("release should end the drag")
+	}
+}
+
+func TestScrollbarClick_CommentMarkerJumpsToComment(t *testing.T) {
+	m := newScrollbarTestViewer()
+	m.SetPendingInlineComments([]PendingInlineComment{{
+		InlineReviewComment: claude.InlineReviewComment{Path: "big.go", Line: 150, Body: "look here"},
+		Source:              "user",
+	}})
+
+	row := -1
+	for r, kind := range m.scrollbarMarkers() {
+		if kind != commentNone {
+			row = r
+		}
+	}
+	if row < 0 {
+		t.Fatal("expected a comment marker")
+	}
+
+	m, _ = m.Update(scrollbarClick(m, row, tea.MouseActionPress))
+
+	info := m.cachedLineInfo[m.cursorLine]
+	if !info.isDiffLine || info.newLineNum != 150 {
+		t.Errorf("cursor on line %d (diff=%v), want new line 150", info.newLineNum, info.isDiffLine)
+	}
+	if m.cursorLine < m.viewport.YOffset || m.cursorLine >= m.viewport.YOffset+m.viewport.Height {
+		t.Errorf("cursor %d not visible in viewport starting at %d", m.cursorLine, m.viewport.YOffset)
+	}
+}
+
+func TestMinimap_DensityAndMouse(t *testing.T) {
+	var patch strings.Builder
+	patch.WriteString("@@ -1,100 +1,100 @@")
+	for i := 1; i <= 100; i++ {
+		fmt.Fprintf(&patch, "\n ctx %d", i)
+	}
+	for i := 1; i <= 100; i++ {
+		fmt.Fprintf(&patch, "\n+add %d", i)
+	}
+	m := newTestDiffViewer(60, 10)
+	m.SetSize(m.width, m.height)
+	width := m.viewport.Width
+	m.SetMinimap(true)
+	if m.viewport.Width != width-minimapWidth+1 {
+		t.Fatalf("viewport width = %d, want %d with the minimap", m.viewport.Width, width-minimapWidth+1)
+	}
+	m.SetDiff([]github.PRFile{{Filename: "big.go", Status: "modified", Patch: patch.String()}})
+
+	adds, removes, _ := m.minimapDensity()
+	if adds[0] != 0 || adds[m.viewport.Height-1] == 0 || removes[m.viewport.Height-1] != 0 {
+		t.Errorf("adds = %v, removes = %v: want additions only in the lower half", adds, removes)
+	}
+	rows := strings.Split(m.renderMinimap(), "\n")
+	if len(rows) != m.viewport.Height || lipgloss.Width(rows[0]) != minimapWidth {
+		t.Fatalf("minimap rows = %d, width %d", len(rows), lipgloss.Width(rows[0]))
+	}
+	if last := rows[len(rows)-1]; !strings.Contains(last, "█") {
+		t.Errorf("last row %q should show dense additions", last)
+	}
+
+	// Clicking any minimap column scrolls like the scrollbar.
+	click := scrollbarClick(m, 5, tea.MouseActionPress)
+	click.X += minimapWidth - 1
+	m, _ = m.Update(click)
+	if m.viewport.YOffset == 0 {
+		t.Error("a click on the minimap should scroll the diff")
+	}
+}
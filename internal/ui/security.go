@@ -0,0 +1,86 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/shhac/prtea/internal/github"
+)
+
+// fetchSecurityAlertsCmd returns a command that fetches the security alerts
+// a PR introduces.
+func fetchSecurityAlertsCmd(client GitHubService, owner, repo, base, headSHA string, number int) tea.Cmd {
+	return func() tea.Msg {
+		alerts, err := client.GetSecurityAlerts(context.Background(), owner, repo, base, headSHA, number)
+		return SecurityAlertsLoadedMsg{PRNumber: number, Alerts: alerts, Err: err}
+	}
+}
+
+// SetSecurityAlerts sets the PR's security alerts for the PR Info tab.
+func (m *DiffViewerModel) SetSecurityAlerts(alerts []github.SecurityAlert) {
+	m.securityAlerts = alerts
+	m.prInfoCache = ""
+	m.refreshContent()
+}
+
+// severityBadge renders an alert severity as a colored badge, e.g. "HIGH".
+func severityBadge(severity string) string {
+	color := theme.Muted
+	switch github.SeverityRank(severity) {
+	case 0, 1:
+		color = theme.Error
+	case 2:
+		color = theme.Warning
+	}
+	return lipgloss.NewStyle().Bold(true).Foreground(color).Render(strings.ToUpper(severity))
+}
+
+// renderSecurity renders the PR Info tab's security section, if the PR
+// introduces any alerts.
+func (m *DiffViewerModel) renderSecurity(width int) string {
+	if len(m.securityAlerts) == 0 {
+		return ""
+	}
+	var b strings.Builder
+	b.WriteString("\n")
+	b.WriteString(sectionHeaderStyle.Render(fmt.Sprintf("Security (%d)", len(m.securityAlerts))))
+	b.WriteString("\n")
+	for i, a := range m.securityAlerts {
+		loc := a.Path
+		if a.Line > 0 {
+			loc = fmt.Sprintf("%s:%d", a.Path, a.Line)
+		}
+		line := fmt.Sprintf("  %d. %s %s %s", i+1, severityBadge(a.Severity), a.Title, dimStyle.Render(a.Source+" · "+loc))
+		b.WriteString(fitWidth(line, width))
+		b.WriteString("\n")
+	}
+	b.WriteString(dimStyle.Render("  :security N jumps to alert N in the diff · :cnext steps through them"))
+	b.WriteString("\n")
+	return b.String()
+}
+
+// jumpToSecurityAlert moves the diff cursor to security alert N of the PR
+// (:security N; the first alert without N).
+func (m App) jumpToSecurityAlert(args string) (tea.Model, tea.Cmd) {
+	alerts := m.diffViewer.securityAlerts
+	if len(alerts) == 0 {
+		return m, m.statusBar.SetTemporaryMessage("No security alerts for this PR", 2*time.Second)
+	}
+	n := 1
+	if args = strings.TrimSpace(args); args != "" {
+		var err error
+		if n, err = strconv.Atoi(args); err != nil || n < 1 || n > len(alerts) {
+			return m, m.statusBar.SetTemporaryMessage(fmt.Sprintf("Usage: security N (1-%d)", len(alerts)), 2*time.Second)
+		}
+	}
+	a := alerts[n-1]
+	if m.diffViewer.fileIndex(a.Path) < 0 {
+		return m, m.statusBar.SetTemporaryMessage(a.Path+" is not in this PR's diff", 2*time.Second)
+	}
+	return m.gotoLine(a.Path, a.Line)
+}
@@ -0,0 +1,55 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/x/ansi"
+	"github.com/shhac/prtea/internal/github"
+)
+
+var testSecurityAlerts = []github.SecurityAlert{
+	{Source: "dependency", Severity: "critical", Title: "yaml@1.0.0: RCE in parser", Path: "go.mod"},
+	{Source: "code scanning", Severity: "high", Title: "SQL injection", Path: "b.go", Line: 3},
+}
+
+func TestRenderSecurity(t *testing.T) {
+	m := newTestDiffViewer(120, 40)
+	m.prNumber = 7
+	m.SetPRInfo("Add retries", "", "alice", "")
+	m.SetSecurityAlerts(testSecurityAlerts)
+	got := ansi.Strip(m.renderPRInfo())
+	for _, want := range []string{"Security (2)", "1. CRITICAL yaml@1.0.0: RCE in parser", "2. HIGH SQL injection", "code scanning · b.go:3"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("PR Info missing %q:\n%s", want, got)
+		}
+	}
+}
+
+func TestJumpToSecurityAlert(t *testing.T) {
+	m := quickfixTestApp()
+	m.diffViewer.SetSecurityAlerts(testSecurityAlerts)
+
+	model, _ := m.jumpToSecurityAlert("2")
+	m = model.(App)
+	li := m.diffViewer.cachedLineInfo[m.diffViewer.cursorLine]
+	if li.filename != "b.go" || li.newLineNum != 3 {
+		t.Errorf("cursor at %s:%d, want b.go:3", li.filename, li.newLineNum)
+	}
+
+	model, _ = m.jumpToSecurityAlert("1")
+	if m = model.(App); !strings.Contains(m.statusBar.statusMessage, "go.mod is not in this PR's diff") {
+		t.Errorf("status = %q", m.statusBar.statusMessage)
+	}
+
+	// Only alerts on files in the diff join the quickfix list.
+	var security []quickfixItem
+	for _, it := range m.quickfixItems() {
+		if it.Kind == qfSecurity {
+			security = append(security, it)
+		}
+	}
+	if len(security) != 1 || security[0].location() != "b.go:3" || security[0].Text != "[high] SQL injection" {
+		t.Errorf("security quickfix items = %+v", security)
+	}
+}
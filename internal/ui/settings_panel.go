@@ -29,18 +29,38 @@ const (
 	sidDefaultPRTab                        // Layout
 	sidCollapseRight                       // Layout
 	sidAutoCollapseWidth                   // Layout
+	sidWorkspaceSize                       // Layout
 	sidPollEnabled                         // Polling
 	sidPollInterval                        // Polling
 	sidNotifyEnabled                       // Notifications
 	sidNotifyBatchThresh                   // Notifications
+	sidNotifyNewPR                         // Notifications
+	sidNotifyCI                            // Notifications
+	sidNotifyComments                      // Notifications
+	sidNotifyReview                        // Notifications
+	sidNotifyReReview                      // Notifications
 	sidPRFetchLimit                        // Fetching
 	sidClaudeTimeout                       // AI
 	sidChatHistory                         // AI
 	sidPromptTokenLimit                    // AI
 	sidChatMaxTurns                        // AI
 	sidAnalysisMaxTurns                    // AI
+	sidAIErrorBudget                       // AI
+	sidAITriage                            // AI
+	sidAnalysisCILogs                      // AI
+	sidChatRepoTools                       // AI
+	sidChatProvider                        // AI
+	sidAnalysisProvider                    // AI
+	sidChatModel                           // AI
+	sidAnalysisModel                       // AI
 	sidRenderRefresh                       // Display
+	sidShowOutdated                        // Display
+	sidMinimap                             // Display
+	sidAbsoluteTimes                       // Display
+	sidTheme                               // Display
+	sidCompatMode                          // Display
 	sidDefaultAction                       // Review
+	sidAIReviewMinSeverity                 // Review
 )
 
 // settingItem describes a single configurable setting.
@@ -56,49 +76,87 @@ type settingItem struct {
 	unitMs  bool     // display milliseconds
 	options []string // for settingSelect: display labels
 	values  []string // for settingSelect: stored config values
+	key     string   // config file key the bounds or values apply to, for `prtea config set`
 }
 
 // settingsSchema defines all settings grouped into sections.
 var settingsSchema = []settingItem{
 	// Layout
 	{id: sidNone, label: "Layout", kind: settingSection},
-	{id: sidDefaultPRTab, label: "Default PR Tab", desc: "Which tab to show on startup", kind: settingSelect,
+	{id: sidDefaultPRTab, key: "defaultPRTab", label: "Default PR Tab", desc: "Which tab to show on startup", kind: settingSelect,
 		options: []string{"To Review", "My PRs"}, values: []string{"review", "mine"}},
 	{id: sidCollapseRight, label: "Collapse Right", desc: "Hide right panel on startup", kind: settingToggle},
-	{id: sidAutoCollapseWidth, label: "Auto-collapse Width", desc: "Terminal width to auto-hide panels", kind: settingNumber, min: 80, max: 200, step: 10},
+	{id: sidAutoCollapseWidth, key: "collapseThreshold", label: "Auto-collapse Width", desc: "Terminal width to auto-hide panels", kind: settingNumber, min: 80, max: 200, step: 10},
+	{id: sidWorkspaceSize, key: "workspaceSize", label: "Open PRs", desc: "PRs kept in memory for instant switching (Ctrl+O)", kind: settingNumber, min: 1, max: 20, step: 1},
 
 	// Polling
 	{id: sidNone, label: "Polling", kind: settingSection},
-	{id: sidPollEnabled, label: "Enabled", desc: "Auto-refresh PR list in the background", kind: settingToggle},
-	{id: sidPollInterval, label: "Interval", desc: "Seconds between background refreshes", kind: settingNumber, min: 10, max: 600, step: 10, unitSec: true},
+	{id: sidPollEnabled, key: "pollEnabled", label: "Enabled", desc: "Auto-refresh PR list in the background", kind: settingToggle},
+	{id: sidPollInterval, key: "pollIntervalMs", label: "Interval", desc: "Seconds between background refreshes", kind: settingNumber, min: 10, max: 600, step: 10, unitSec: true},
 
 	// Notifications
 	{id: sidNone, label: "Notifications", kind: settingSection},
-	{id: sidNotifyEnabled, label: "Enabled", desc: "Desktop notifications for new activity", kind: settingToggle},
-	{id: sidNotifyBatchThresh, label: "Batch Threshold", desc: "Summarize when more than N new PRs", kind: settingNumber, min: 1, max: 20, step: 1},
+	{id: sidNotifyEnabled, key: "notificationsEnabled", label: "Enabled", desc: "Desktop notifications for new activity", kind: settingToggle},
+	{id: sidNotifyBatchThresh, key: "notificationThreshold", label: "Batch Threshold", desc: "Summarize when more than N new PRs", kind: settingNumber, min: 1, max: 20, step: 1},
+	{id: sidNotifyNewPR, label: "New PRs", desc: "A PR requests your review", kind: settingToggle},
+	{id: sidNotifyCI, label: "CI Finished", desc: "Checks finish on one of your PRs", kind: settingToggle},
+	{id: sidNotifyComments, label: "New Comments", desc: "New comments on PRs you're reviewing", kind: settingToggle},
+	{id: sidNotifyReview, label: "Review Outcome", desc: "Your PR is approved or gets changes requested", kind: settingToggle},
+	{id: sidNotifyReReview, label: "Re-review", desc: "Your review is requested again", kind: settingToggle},
 
 	// Fetching
 	{id: sidNone, label: "Fetching", kind: settingSection},
-	{id: sidPRFetchLimit, label: "PR Fetch Limit", desc: "Max PRs to fetch per query", kind: settingNumber, min: 10, max: 500, step: 10},
+	{id: sidPRFetchLimit, key: "prFetchLimit", label: "PR Fetch Limit", desc: "Max PRs to fetch per query", kind: settingNumber, min: 10, max: 500, step: 10},
 
 	// AI
 	{id: sidNone, label: "AI", kind: settingSection},
-	{id: sidClaudeTimeout, label: "Claude Timeout", desc: "Seconds before analysis times out", kind: settingNumber, min: 30, max: 600, step: 30, unitSec: true},
-	{id: sidChatHistory, label: "Chat History", desc: "Max messages kept in chat context", kind: settingNumber, min: 4, max: 64, step: 4},
-	{id: sidPromptTokenLimit, label: "Prompt Token Limit", desc: "Max tokens for prompt context", kind: settingNumber, min: 10000, max: 500000, step: 10000},
-	{id: sidChatMaxTurns, label: "Chat Max Turns", desc: "Max agentic turns per chat message", kind: settingNumber, min: 1, max: 10, step: 1},
-	{id: sidAnalysisMaxTurns, label: "Analysis Max Turns", desc: "Max turns for full PR analysis", kind: settingNumber, min: 5, max: 100, step: 5},
+	{id: sidClaudeTimeout, key: "claudeTimeoutMs", label: "Claude Timeout", desc: "Seconds before analysis times out", kind: settingNumber, min: 30, max: 600, step: 30, unitSec: true},
+	{id: sidChatHistory, key: "maxChatHistory", label: "Chat History", desc: "Max messages kept in chat context", kind: settingNumber, min: 4, max: 64, step: 4},
+	{id: sidPromptTokenLimit, key: "maxPromptTokens", label: "Prompt Token Limit", desc: "Max tokens for prompt context", kind: settingNumber, min: 10000, max: 500000, step: 10000},
+	{id: sidChatMaxTurns, key: "chatMaxTurns", label: "Chat Max Turns", desc: "Max agentic turns per chat message", kind: settingNumber, min: 1, max: 10, step: 1},
+	{id: sidAnalysisMaxTurns, key: "analysisMaxTurns", label: "Analysis Max Turns", desc: "Max turns for full PR analysis", kind: settingNumber, min: 5, max: 100, step: 5},
+	{id: sidAIErrorBudget, key: "aiErrorBudget", label: "Error Budget", desc: "Claude failures in a row before AI is scaled back", kind: settingNumber, min: 1, max: 10, step: 1},
+	{id: sidAITriage, key: "aiTriage", label: "PR Triage", desc: "Summarize and tag each PR waiting for your review in the background", kind: settingToggle},
+	{id: sidAnalysisCILogs, key: "analysisCiLogs", label: "CI Logs", desc: "Send failing check log tails with analysis and AI review (costs tokens)", kind: settingToggle},
+	{id: sidChatRepoTools, key: "chatRepoTools", label: "Chat Repo Tools", desc: "Let chat read and search the repo's local clone (localClones); Claude CLI only", kind: settingToggle},
+	{id: sidChatProvider, key: "chatProvider", label: "Chat Backend", desc: "Model backend for chat and quick questions", kind: settingSelect,
+		options: aiProviderLabels, values: aiProviderValues},
+	{id: sidAnalysisProvider, key: "analysisProvider", label: "Analysis Backend", desc: "Model backend for analysis and AI review", kind: settingSelect,
+		options: aiProviderLabels, values: aiProviderValues},
+	{id: sidChatModel, label: "Chat Model", desc: "Claude CLI model for chat; a fast one keeps replies snappy", kind: settingSelect,
+		options: claudeModelLabels, values: claudeModelValues},
+	{id: sidAnalysisModel, label: "Analysis Model", desc: "Claude CLI model for analysis and AI review", kind: settingSelect,
+		options: claudeModelLabels, values: claudeModelValues},
 
 	// Display
 	{id: sidNone, label: "Display", kind: settingSection},
-	{id: sidRenderRefresh, label: "Render Refresh", desc: "Stream rendering interval", kind: settingNumber, min: 50, max: 1000, step: 50, unitMs: true},
+	{id: sidRenderRefresh, key: "streamCheckpointMs", label: "Render Refresh", desc: "Stream rendering interval", kind: settingNumber, min: 50, max: 1000, step: 50, unitMs: true},
+	{id: sidShowOutdated, key: "showOutdatedComments", label: "Outdated Comments", desc: "Show outdated review comments in the diff", kind: settingToggle},
+	{id: sidMinimap, key: "diffMinimap", label: "Diff Minimap", desc: "Widen the diff scrollbar into a map of added/removed lines and comments", kind: settingToggle},
+	{id: sidAbsoluteTimes, key: "absoluteTimestamps", label: "Absolute Times", desc: "Show comment, review and PR times as dates instead of \"3h ago\"", kind: settingToggle},
+	{id: sidTheme, key: "theme", label: "Theme", desc: "Color palette (auto follows the terminal background)", kind: settingSelect,
+		options: []string{"Auto", "Dark", "Light", "Solarized", "High Contrast"}, values: []string{"auto", "dark", "light", "solarized", "high-contrast"}},
+	{id: sidCompatMode, key: "compatMode", label: "Compatibility", desc: "ASCII icons and 16 colors (auto detects limited terminals)", kind: settingSelect,
+		options: []string{"Auto", "On", "Off"}, values: []string{"auto", "on", "off"}},
 
 	// Review
 	{id: sidNone, label: "Review", kind: settingSection},
-	{id: sidDefaultAction, label: "Default Action", desc: "Pre-selected review action", kind: settingSelect,
+	{id: sidDefaultAction, key: "defaultReviewAction", label: "Default Action", desc: "Pre-selected review action", kind: settingSelect,
 		options: []string{"Approve", "Comment", "Request Changes"}, values: []string{"approve", "comment", "request_changes"}},
+	{id: sidAIReviewMinSeverity, key: "aiReviewMinSeverity", label: "AI Auto-accept", desc: "AI review comments added as drafts at once; the rest wait in :review triage", kind: settingSelect,
+		options: []string{"All", "Warning+", "Critical"}, values: []string{"", "warning", "critical"}},
 }
 
+// AI backend choices shared by the Chat and Analysis Backend settings.
+var (
+	aiProviderLabels = []string{"Claude CLI", "Anthropic API", "OpenAI-compatible", "Ollama"}
+	aiProviderValues = []string{config.ProviderClaude, config.ProviderAnthropic, config.ProviderOpenAI, config.ProviderOllama}
+
+	// Claude CLI model aliases; any other model name can be set in the config file.
+	claudeModelLabels = []string{"CLI Default", "Haiku", "Sonnet", "Opus"}
+	claudeModelValues = []string{"", "haiku", "sonnet", "opus"}
+)
+
 // navigableItems returns indices of items that are not section headers.
 func navigableItems() []int {
 	var indices []int
@@ -162,6 +220,10 @@ func (m *SettingsModel) SetSize(termWidth, termHeight int) {
 		m.viewport.Height = innerH
 	}
 	m.refreshViewport()
+	if m.visible && m.cfg != nil {
+		m.viewport.SetYOffset(m.viewport.YOffset)
+		m.ensureVisible()
+	}
 }
 
 // Config returns the current (possibly modified) config.
@@ -331,6 +393,20 @@ func (m SettingsModel) getToggle(idx int) bool {
 		return m.cfg.PollEnabled
 	case sidNotifyEnabled:
 		return m.cfg.NotificationsEnabled
+	case sidShowOutdated:
+		return m.cfg.ShowOutdatedComments
+	case sidMinimap:
+		return m.cfg.DiffMinimap
+	case sidAbsoluteTimes:
+		return m.cfg.AbsoluteTimestamps
+	case sidAITriage:
+		return m.cfg.AITriage
+	case sidAnalysisCILogs:
+		return m.cfg.AnalysisCILogs
+	case sidChatRepoTools:
+		return m.cfg.ChatRepoTools
+	case sidNotifyNewPR, sidNotifyCI, sidNotifyComments, sidNotifyReview, sidNotifyReReview:
+		return m.cfg.NotifyTriggerEnabled(notifyTriggerFor(settingsSchema[idx].id))
 	case sidCollapseRight:
 		for _, s := range m.cfg.StartCollapsed {
 			if s == "right" {
@@ -349,6 +425,31 @@ func (m *SettingsModel) setToggle(idx int, val bool) {
 		m.cfg.PollEnabled = val
 	case sidNotifyEnabled:
 		m.cfg.NotificationsEnabled = val
+	case sidShowOutdated:
+		m.cfg.ShowOutdatedComments = val
+	case sidMinimap:
+		m.cfg.DiffMinimap = val
+	case sidAbsoluteTimes:
+		m.cfg.AbsoluteTimestamps = val
+	case sidAITriage:
+		m.cfg.AITriage = val
+	case sidAnalysisCILogs:
+		m.cfg.AnalysisCILogs = val
+	case sidChatRepoTools:
+		m.cfg.ChatRepoTools = val
+	case sidNotifyNewPR, sidNotifyCI, sidNotifyComments, sidNotifyReview, sidNotifyReReview:
+		trigger := notifyTriggerFor(settingsSchema[idx].id)
+		// Always build a new slice: the config copy shares its backing array.
+		muted := make([]string, 0, len(m.cfg.NotifyMuted)+1)
+		for _, t := range m.cfg.NotifyMuted {
+			if t != trigger {
+				muted = append(muted, t)
+			}
+		}
+		if !val {
+			muted = append(muted, trigger)
+		}
+		m.cfg.NotifyMuted = muted
 	case sidCollapseRight:
 		if val {
 			// Add "right" if not present
@@ -375,6 +476,23 @@ func (m *SettingsModel) setToggle(idx int, val bool) {
 	}
 }
 
+// notifyTriggerFor maps a notification trigger setting to its config name.
+func notifyTriggerFor(id settingID) string {
+	switch id {
+	case sidNotifyNewPR:
+		return config.NotifyNewPR
+	case sidNotifyCI:
+		return config.NotifyCIFinished
+	case sidNotifyComments:
+		return config.NotifyComments
+	case sidNotifyReview:
+		return config.NotifyReviewOutcome
+	case sidNotifyReReview:
+		return config.NotifyReReview
+	}
+	return ""
+}
+
 // getNumber returns the numeric value for a number setting.
 func (m SettingsModel) getNumber(idx int) int {
 	switch settingsSchema[idx].id {
@@ -384,6 +502,8 @@ func (m SettingsModel) getNumber(idx int) int {
 		return m.cfg.ClaudeTimeout
 	case sidAutoCollapseWidth:
 		return m.cfg.CollapseThreshold
+	case sidWorkspaceSize:
+		return m.cfg.WorkspaceSize
 	case sidPRFetchLimit:
 		return m.cfg.PRFetchLimit
 	case sidNotifyBatchThresh:
@@ -396,6 +516,8 @@ func (m SettingsModel) getNumber(idx int) int {
 		return m.cfg.ChatMaxTurns
 	case sidAnalysisMaxTurns:
 		return m.cfg.AnalysisMaxTurns
+	case sidAIErrorBudget:
+		return m.cfg.AIErrorBudget
 	case sidRenderRefresh:
 		return m.cfg.StreamCheckpointMs
 	}
@@ -411,6 +533,8 @@ func (m *SettingsModel) setNumber(idx int, val int) {
 		m.cfg.ClaudeTimeout = val
 	case sidAutoCollapseWidth:
 		m.cfg.CollapseThreshold = val
+	case sidWorkspaceSize:
+		m.cfg.WorkspaceSize = val
 	case sidPRFetchLimit:
 		m.cfg.PRFetchLimit = val
 	case sidNotifyBatchThresh:
@@ -423,6 +547,8 @@ func (m *SettingsModel) setNumber(idx int, val int) {
 		m.cfg.ChatMaxTurns = val
 	case sidAnalysisMaxTurns:
 		m.cfg.AnalysisMaxTurns = val
+	case sidAIErrorBudget:
+		m.cfg.AIErrorBudget = val
 	case sidRenderRefresh:
 		m.cfg.StreamCheckpointMs = val
 	}
@@ -441,6 +567,32 @@ func (m SettingsModel) getSelect(idx int) string {
 			return "comment"
 		}
 		return m.cfg.DefaultReviewAction
+	case sidTheme:
+		if m.cfg.Theme == "" {
+			return "auto"
+		}
+		return m.cfg.Theme
+	case sidCompatMode:
+		if m.cfg.CompatMode == "" {
+			return "auto"
+		}
+		return m.cfg.CompatMode
+	case sidChatProvider:
+		if m.cfg.ChatProvider == "" {
+			return config.ProviderClaude
+		}
+		return m.cfg.ChatProvider
+	case sidAnalysisProvider:
+		if m.cfg.AnalysisProvider == "" {
+			return config.ProviderClaude
+		}
+		return m.cfg.AnalysisProvider
+	case sidChatModel:
+		return m.cfg.ChatModel
+	case sidAnalysisModel:
+		return m.cfg.AnalysisModel
+	case sidAIReviewMinSeverity:
+		return m.cfg.AIReviewMinSeverity
 	}
 	return ""
 }
@@ -452,6 +604,20 @@ func (m *SettingsModel) setSelect(idx int, val string) {
 		m.cfg.DefaultPRTab = val
 	case sidDefaultAction:
 		m.cfg.DefaultReviewAction = val
+	case sidTheme:
+		m.cfg.Theme = val
+	case sidCompatMode:
+		m.cfg.CompatMode = val
+	case sidChatProvider:
+		m.cfg.ChatProvider = val
+	case sidAnalysisProvider:
+		m.cfg.AnalysisProvider = val
+	case sidChatModel:
+		m.cfg.ChatModel = val
+	case sidAnalysisModel:
+		m.cfg.AnalysisModel = val
+	case sidAIReviewMinSeverity:
+		m.cfg.AIReviewMinSeverity = val
 	}
 }
 
@@ -543,7 +709,7 @@ func (m SettingsModel) View() string {
 
 	// Footer
 	footer := settingsFooterStyle.Render(" j/k navigate · Enter/Space toggle · h/l adjust · Esc close ")
-	footerLine := lipgloss.PlaceHorizontal(innerW, lipgloss.Center, footer)
+	footerLine := fitWidth(lipgloss.PlaceHorizontal(innerW, lipgloss.Center, footer), innerW)
 
 	var content string
 	if m.vpReady {
@@ -563,13 +729,12 @@ func (m SettingsModel) View() string {
 
 	overlayStyle := lipgloss.NewStyle().
 		Border(lipgloss.RoundedBorder()).
-		BorderForeground(lipgloss.Color("62")).
+		BorderForeground(theme.Accent).
 		Padding(0, 1).
 		Width(overlayW - 2).
 		Height(overlayH - 2)
 
-	rendered := overlayStyle.Render(box)
-	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, rendered)
+	return placeOverlay(m.width, m.height, overlayStyle.Render(box))
 }
 
 // renderSettingRow renders a single setting row.
@@ -666,56 +831,61 @@ func (m SettingsModel) innerDimensions() (width, height int) {
 
 // Settings overlay styles
 var (
+	settingsTitleStyle         lipgloss.Style
+	settingsFooterStyle        lipgloss.Style
+	settingsSectionStyle       lipgloss.Style
+	settingsMarkerStyle        lipgloss.Style
+	settingsLabelStyle         lipgloss.Style
+	settingsLabelFocusedStyle  lipgloss.Style
+	settingsOnStyle            lipgloss.Style
+	settingsOffStyle           lipgloss.Style
+	settingsNumberStyle        lipgloss.Style
+	settingsNumberFocusedStyle lipgloss.Style
+	settingsSelectStyle        lipgloss.Style
+	settingsSelectFocusedStyle lipgloss.Style
+	settingsDescStyle          lipgloss.Style
+	settingsDirtyStyle         lipgloss.Style
+)
+
+// buildSettingsStyles derives the settings overlay styles from the active theme.
+func buildSettingsStyles() {
 	settingsTitleStyle = lipgloss.NewStyle().
 		Bold(true).
-		Foreground(lipgloss.Color("252")).
-		Background(lipgloss.Color("62")).
+		Foreground(theme.OnAccent).
+		Background(theme.Accent).
 		Padding(0, 1)
-
 	settingsFooterStyle = lipgloss.NewStyle().
-		Foreground(lipgloss.Color("244")).
+		Foreground(theme.Muted).
 		Italic(true)
-
 	settingsSectionStyle = lipgloss.NewStyle().
 		Bold(true).
-		Foreground(lipgloss.Color("33"))
-
+		Foreground(theme.Info)
 	settingsMarkerStyle = lipgloss.NewStyle().
-		Foreground(lipgloss.Color("42"))
-
+		Foreground(theme.Success)
 	settingsLabelStyle = lipgloss.NewStyle().
-		Foreground(lipgloss.Color("252"))
-
+		Foreground(theme.Text)
 	settingsLabelFocusedStyle = lipgloss.NewStyle().
-		Foreground(lipgloss.Color("42")).
+		Foreground(theme.Success).
 		Bold(true)
-
 	settingsOnStyle = lipgloss.NewStyle().
-		Foreground(lipgloss.Color("42")).
+		Foreground(theme.Success).
 		Bold(true)
-
 	settingsOffStyle = lipgloss.NewStyle().
-		Foreground(lipgloss.Color("244"))
-
+		Foreground(theme.Muted)
 	settingsNumberStyle = lipgloss.NewStyle().
-		Foreground(lipgloss.Color("214"))
-
+		Foreground(theme.Warning)
 	settingsNumberFocusedStyle = lipgloss.NewStyle().
-		Foreground(lipgloss.Color("214")).
+		Foreground(theme.Warning).
 		Bold(true)
-
 	settingsSelectStyle = lipgloss.NewStyle().
-		Foreground(lipgloss.Color("33"))
-
+		Foreground(theme.Info)
 	settingsSelectFocusedStyle = lipgloss.NewStyle().
-		Foreground(lipgloss.Color("33")).
+		Foreground(theme.Info).
 		Bold(true)
-
 	settingsDescStyle = lipgloss.NewStyle().
-		Foreground(lipgloss.Color("244")).
+		Foreground(theme.Muted).
 		Italic(true)
-
 	settingsDirtyStyle = lipgloss.NewStyle().
-		Foreground(lipgloss.Color("214")).
+		Foreground(theme.Warning).
 		Italic(true)
-)
+}
@@ -0,0 +1,225 @@
+package ui
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// reviewLedgerMaxAge is how long a PR stays in the review ledger after it
+// was last viewed or reviewed.
+const reviewLedgerMaxAge = 90 * 24 * time.Hour
+
+// reviewedPR is the head a PR was at when the user last reviewed it and
+// when they last opened it.
+type reviewedPR struct {
+	ReviewedSHA string    `json:"reviewedSha,omitempty"`
+	ReviewedAt  time.Time `json:"reviewedAt,omitzero"`
+	ViewedSHA   string    `json:"viewedSha,omitempty"`
+	ViewedAt    time.Time `json:"viewedAt,omitzero"`
+}
+
+// reviewLedger keeps reviewedPR records on disk, keyed by prKey, so a
+// re-review can show only what changed since the last one. Writes happen
+// from commands, so it is locked.
+type reviewLedger struct {
+	mu   sync.Mutex
+	path string
+	prs  map[string]reviewedPR
+}
+
+// newReviewLedger opens the ledger at path. A missing or unreadable file
+// starts an empty ledger.
+func newReviewLedger(path string) *reviewLedger {
+	l := &reviewLedger{path: path, prs: map[string]reviewedPR{}}
+	if data, err := os.ReadFile(path); err == nil {
+		_ = json.Unmarshal(data, &l.prs)
+	}
+	return l
+}
+
+// get returns the record of a PR.
+func (l *reviewLedger) get(key string) reviewedPR {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.prs[key]
+}
+
+// record updates a PR's record and saves the ledger, dropping PRs not
+// touched within reviewLedgerMaxAge.
+func (l *reviewLedger) record(key string, now time.Time, update func(*reviewedPR)) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	r := l.prs[key]
+	update(&r)
+	l.prs[key] = r
+	for k, r := range l.prs {
+		if now.Sub(r.ViewedAt) > reviewLedgerMaxAge && now.Sub(r.ReviewedAt) > reviewLedgerMaxAge {
+			delete(l.prs, k)
+		}
+	}
+
+	if err := os.MkdirAll(filepath.Dir(l.path), 0o755); err != nil {
+		return fmt.Errorf("failed to create ledger directory: %w", err)
+	}
+	data, err := json.MarshalIndent(l.prs, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal review ledger: %w", err)
+	}
+	tmpPath := l.path + ".tmp"
+	if err := os.WriteFile(tmpPath, data, 0o644); err != nil {
+		return fmt.Errorf("failed to write review ledger: %w", err)
+	}
+	if err := os.Rename(tmpPath, l.path); err != nil {
+		os.Remove(tmpPath)
+		return fmt.Errorf("failed to rename review ledger: %w", err)
+	}
+	return nil
+}
+
+// recordHeadCmd saves the head the user viewed, or reviewed, a PR at.
+// Best-effort: without it the next re-review just has no baseline.
+func recordHeadCmd(l *reviewLedger, key, sha string, reviewed bool) tea.Cmd {
+	if l == nil || sha == "" {
+		return nil
+	}
+	return func() tea.Msg {
+		now := time.Now()
+		_ = l.record(key, now, func(r *reviewedPR) {
+			r.ViewedSHA, r.ViewedAt = sha, now
+			if reviewed {
+				r.ReviewedSHA, r.ReviewedAt = sha, now
+			}
+		})
+		return nil
+	}
+}
+
+// sinceBaseline is the head to compare against for "changes since my last
+// review": the last reviewed head, or failing that the last viewed one.
+type sinceBaseline struct {
+	SHA      string
+	At       time.Time
+	Reviewed bool // SHA is from a review rather than a view
+}
+
+// label describes the baseline, e.g. "your review 2d ago (abc1234)".
+func (b sinceBaseline) label() string {
+	what := "last view"
+	if b.Reviewed {
+		what = "review"
+	}
+	return fmt.Sprintf("your %s %s ago (%s)", what, waitAge(time.Since(b.At)), shortSHA(b.SHA))
+}
+
+// shortSHA abbreviates a commit SHA.
+func shortSHA(sha string) string {
+	if len(sha) > 7 {
+		return sha[:7]
+	}
+	return sha
+}
+
+// baselineFor picks the baseline for a PR now at head, or nil when the
+// user has not seen an earlier head.
+func baselineFor(r reviewedPR, head string) *sinceBaseline {
+	switch {
+	case r.ReviewedSHA != "" && r.ReviewedSHA != head:
+		return &sinceBaseline{SHA: r.ReviewedSHA, At: r.ReviewedAt, Reviewed: true}
+	case r.ReviewedSHA == "" && r.ViewedSHA != "" && r.ViewedSHA != head:
+		return &sinceBaseline{SHA: r.ViewedSHA, At: r.ViewedAt}
+	}
+	return nil
+}
+
+// noteHeadSeen records a PR's head as viewed. The first time the head is
+// known this session, before the session's HeadSHA is set, it also picks
+// the baseline for :since from what was recorded earlier.
+func (m *App) noteHeadSeen(head string) tea.Cmd {
+	s := m.session
+	if m.reviewed == nil || head == "" {
+		return nil
+	}
+	key := prKey(s.Owner, s.Repo, s.Number)
+	recordCmd := recordHeadCmd(m.reviewed, key, head, false)
+	if s.HeadSHA == "" {
+		s.Since = baselineFor(m.reviewed.get(key), head)
+		if s.Since != nil {
+			hint := fmt.Sprintf("PR #%d changed since %s — :since shows what's new", s.Number, s.Since.label())
+			return tea.Batch(m.statusBar.SetTemporaryMessage(hint, 4*time.Second), recordCmd)
+		}
+	}
+	return recordCmd
+}
+
+// fetchSinceDiffCmd returns a command that fetches what changed on a PR's
+// head since an earlier head.
+func fetchSinceDiffCmd(client GitHubService, owner, repo string, number int, base, head string) tea.Cmd {
+	return func() tea.Msg {
+		files, err := client.GetCompareFiles(context.Background(), owner, repo, base, head)
+		return SinceDiffLoadedMsg{PRNumber: number, Base: base, Head: head, Files: files, Err: err}
+	}
+}
+
+// toggleSinceDiff switches the Diff tab between the PR's own diff and what
+// changed since the user's last review of it (:since).
+func (m App) toggleSinceDiff() (tea.Model, tea.Cmd) {
+	s := m.session
+	if s == nil {
+		return m, m.statusBar.SetTemporaryMessage("No PR selected", 2*time.Second)
+	}
+	if m.diffViewer.sinceDiff != "" {
+		m.diffViewer.SetDiff(s.DiffFiles)
+		m.diffViewer.SetSinceDiff("")
+		return m, m.statusBar.SetTemporaryMessage(fmt.Sprintf("Showing PR #%d's full diff", s.Number), 2*time.Second)
+	}
+	if s.HeadSHA == "" {
+		return m, m.statusBar.SetTemporaryMessage("PR details are still loading", 2*time.Second)
+	}
+	if s.Since == nil {
+		return m, m.statusBar.SetTemporaryMessage(fmt.Sprintf("Nothing new: you haven't reviewed or opened PR #%d at an earlier head", s.Number), 3*time.Second)
+	}
+	if m.ghClient == nil {
+		return m, nil
+	}
+	clearCmd := m.statusBar.SetTemporaryMessage("Loading changes since "+s.Since.label()+"...", 15*time.Second)
+	return m, tea.Batch(clearCmd, fetchSinceDiffCmd(m.ghClient, s.Owner, s.Repo, s.Number, s.Since.SHA, s.HeadSHA))
+}
+
+// sinceDiffLoaded shows the diff since the baseline in the Diff tab.
+func (m App) sinceDiffLoaded(msg SinceDiffLoadedMsg) (tea.Model, tea.Cmd) {
+	if !m.session.MatchesPR(msg.PRNumber) || msg.PRNumber != m.diffViewer.prNumber || m.session.Since == nil {
+		return m, nil
+	}
+	if msg.Err != nil {
+		// A force-push can leave the old head unreachable.
+		return m, m.statusBar.SetTemporaryMessage(fmt.Sprintf("Changes since your review failed: %s", formatUserError(msg.Err.Error())), 5*time.Second)
+	}
+	label := "Changes since " + m.session.Since.label()
+	m.diffViewer.SetDiff(msg.Files)
+	m.diffViewer.SetSinceDiff(label)
+	m.diffViewer.SetStackDiff("")
+	return m, m.statusBar.SetTemporaryMessage(fmt.Sprintf("%s: %d %s — :since for the full diff", label, len(msg.Files), pluralFiles(len(msg.Files))), 4*time.Second)
+}
+
+// pluralFiles returns "file" or "files" for n.
+func pluralFiles(n int) string {
+	if n == 1 {
+		return "file"
+	}
+	return "files"
+}
+
+// SetSinceDiff records that the Diff tab shows only the changes since an
+// earlier head, described by label, or "" for the PR's full diff.
+func (m *DiffViewerModel) SetSinceDiff(label string) {
+	m.sinceDiff = label
+	m.cachedLines = nil
+	m.refreshContent()
+}
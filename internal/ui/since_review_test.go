@@ -0,0 +1,98 @@
+package ui
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/x/ansi"
+	"github.com/shhac/prtea/internal/github"
+)
+
+func TestReviewLedger_RecordsAndReloads(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "reviewed.json")
+	l := newReviewLedger(path)
+	recordHeadCmd(l, "shhac/prtea#1", "aaa", true)()
+	recordHeadCmd(l, "shhac/prtea#1", "bbb", false)()
+	old := time.Now().Add(-2 * reviewLedgerMaxAge)
+	l.prs["shhac/prtea#2"] = reviewedPR{ViewedSHA: "ccc", ViewedAt: old}
+	recordHeadCmd(l, "shhac/prtea#3", "ddd", false)()
+
+	reloaded := newReviewLedger(path)
+	if r := reloaded.get("shhac/prtea#1"); r.ReviewedSHA != "aaa" || r.ViewedSHA != "bbb" {
+		t.Errorf("record = %+v, want reviewed at aaa and viewed at bbb", r)
+	}
+	if _, ok := reloaded.prs["shhac/prtea#2"]; ok {
+		t.Error("PRs untouched for longer than the max age should be dropped")
+	}
+}
+
+func TestBaselineFor(t *testing.T) {
+	tests := []struct {
+		name     string
+		r        reviewedPR
+		want     string
+		reviewed bool
+	}{
+		{"never seen", reviewedPR{}, "", false},
+		{"reviewed at an older head", reviewedPR{ReviewedSHA: "a", ViewedSHA: "b"}, "a", true},
+		{"reviewed at the current head", reviewedPR{ReviewedSHA: "head", ViewedSHA: "b"}, "", false},
+		{"only viewed before", reviewedPR{ViewedSHA: "b"}, "b", false},
+		{"only viewed at the current head", reviewedPR{ViewedSHA: "head"}, "", false},
+	}
+	for _, tt := range tests {
+		got := baselineFor(tt.r, "head")
+		switch {
+		case tt.want == "" && got != nil:
+			t.Errorf("%s: baseline = %+v, want none", tt.name, got)
+		case tt.want != "" && (got == nil || got.SHA != tt.want || got.Reviewed != tt.reviewed):
+			t.Errorf("%s: baseline = %+v, want %s (reviewed %v)", tt.name, got, tt.want, tt.reviewed)
+		}
+	}
+}
+
+func TestSinceDiff_ShowsChangesSinceReview(t *testing.T) {
+	var compared string
+	client := github.NewTestClient("me", func(ctx context.Context, args ...string) (string, error) {
+		compared = strings.Join(args, " ")
+		return `{"files":[{"filename":"b.go","status":"modified","additions":1,"patch":"@@ -1,1 +1,2 @@\n one\n+new"}]}`, nil
+	})
+	m := commentNavTestApp()
+	m.session.Owner, m.session.Repo = "shhac", "prtea"
+	m.session.DiffFiles = m.diffViewer.files
+	m.diffViewer.prNumber = 1
+	m.reviewed = newReviewLedger(filepath.Join(t.TempDir(), "reviewed.json"))
+	recordHeadCmd(m.reviewed, "shhac/prtea#1", "0ld0ld0ld", true)()
+
+	model, _ := m.Update(PRDetailLoadedMsg{PRNumber: 1, Detail: &github.PRDetail{HeadSHA: "n3wn3wn3w"}})
+	m = model.(App)
+	if m.session.Since == nil || m.session.Since.SHA != "0ld0ld0ld" {
+		t.Fatalf("baseline = %+v", m.session.Since)
+	}
+	if !strings.Contains(m.statusBar.statusMessage, ":since") {
+		t.Errorf("status = %q, want a hint about :since", m.statusBar.statusMessage)
+	}
+
+	m.ghClient = client
+	model, _ = m.toggleSinceDiff()
+	m = model.(App)
+	if !strings.Contains(m.statusBar.statusMessage, "Loading changes since your review") {
+		t.Errorf("status = %q", m.statusBar.statusMessage)
+	}
+	loaded := fetchSinceDiffCmd(client, "shhac", "prtea", 1, m.session.Since.SHA, m.session.HeadSHA)().(SinceDiffLoadedMsg)
+	if !strings.Contains(compared, "compare/0ld0ld0ld...n3wn3wn3w") {
+		t.Fatalf("compared %q", compared)
+	}
+	model, _ = m.Update(loaded)
+	m = model.(App)
+	if len(m.diffViewer.files) != 1 || !strings.Contains(ansi.Strip(m.diffViewer.cachedLines[0]), "Changes since your review") {
+		t.Fatalf("diff = %d files, first line %q", len(m.diffViewer.files), ansi.Strip(m.diffViewer.cachedLines[0]))
+	}
+
+	model, _ = m.toggleSinceDiff()
+	if m = model.(App); m.diffViewer.sinceDiff != "" || len(m.diffViewer.files) != 2 {
+		t.Errorf("toggling back should restore the full diff, got %d files", len(m.diffViewer.files))
+	}
+}
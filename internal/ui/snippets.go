@@ -0,0 +1,293 @@
+package ui
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textarea"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/shhac/prtea/internal/config"
+)
+
+// emojiShortcodes maps the GitHub shortcodes offered after ":" to their
+// emoji. GitHub renders the shortcodes too; inserting the emoji keeps the
+// text readable in the TUI.
+var emojiShortcodes = map[string]string{
+	"+1":                    "👍",
+	"-1":                    "👎",
+	"100":                   "💯",
+	"bug":                   "🐛",
+	"bulb":                  "💡",
+	"boom":                  "💥",
+	"check":                 "✔️",
+	"clap":                  "👏",
+	"confused":              "😕",
+	"construction":          "🚧",
+	"eyes":                  "👀",
+	"fire":                  "🔥",
+	"heart":                 "❤️",
+	"heavy_check_mark":      "✔️",
+	"hourglass":             "⌛",
+	"joy":                   "😂",
+	"laughing":              "😆",
+	"lock":                  "🔒",
+	"memo":                  "📝",
+	"muscle":                "💪",
+	"ok_hand":               "👌",
+	"pencil2":               "✏️",
+	"pray":                  "🙏",
+	"question":              "❓",
+	"recycle":               "♻️",
+	"rocket":                "🚀",
+	"rotating_light":        "🚨",
+	"see_no_evil":           "🙈",
+	"shipit":                "🐿️",
+	"smile":                 "😄",
+	"sparkles":              "✨",
+	"tada":                  "🎉",
+	"thinking":              "🤔",
+	"thumbsdown":            "👎",
+	"thumbsup":              "👍",
+	"warning":               "⚠️",
+	"wave":                  "👋",
+	"white_check_mark":      "✅",
+	"wrench":                "🔧",
+	"x":                     "❌",
+	"zap":                   "⚡",
+	"exclamation":           "❗",
+	"heavy_plus_sign":       "➕",
+	"heavy_minus_sign":      "➖",
+	"nail_care":             "💅",
+	"speech_balloon":        "💬",
+	"thought_balloon":       "💭",
+	"no_entry":              "⛔",
+	"grey_question":         "❔",
+	"raised_hands":          "🙌",
+	"slightly_smiling_face": "🙂",
+}
+
+// snippets are the "/" expansions offered in text inputs, by name. The App
+// replaces the defaults with the configured snippets.
+var snippets = config.DefaultSnippets()
+
+// setSnippets replaces the "/" expansions offered in text inputs.
+func setSnippets(s map[string]string) {
+	snippets = s
+}
+
+var (
+	// emojiTokenRe matches a shortcode being typed at the end of the text
+	// before the cursor, such as "looks good :thu".
+	emojiTokenRe = regexp.MustCompile(`(?:^|\s):([a-z0-9_+\-]{2,})$`)
+	// emojiDoneRe matches a shortcode just closed with ":", such as ":tada:".
+	emojiDoneRe = regexp.MustCompile(`(?:^|\s)(:([a-z0-9_+\-]+):)$`)
+	// snippetTokenRe matches a snippet name being typed, such as "/nit".
+	snippetTokenRe = regexp.MustCompile(`(?:^|\s)(/[a-z0-9_\-]*)$`)
+)
+
+// maxCompletions caps the candidates offered at once.
+const maxCompletions = 8
+
+// completionItem is one candidate: what the hint row shows and what
+// replaces the typed token when it's accepted.
+type completionItem struct {
+	label  string
+	insert string
+}
+
+// textCompleter offers ":" emoji and "/" snippet completions for the token
+// before the cursor of a text input. Tab accepts the selected candidate and
+// Ctrl+N/Ctrl+P cycle them; other keys go to the input as usual.
+type textCompleter struct {
+	token string // typed token, including its ":" or "/" trigger
+	items []completionItem
+	sel   int
+}
+
+// completions returns the candidates for the token at the end of before,
+// and the token, or no candidates when nothing completable is being typed.
+func completions(before string) (string, []completionItem) {
+	if m := emojiTokenRe.FindStringSubmatch(before); m != nil {
+		var items []completionItem
+		for _, name := range sortedKeys(emojiShortcodes) {
+			if strings.HasPrefix(name, m[1]) {
+				e := emojiShortcodes[name]
+				items = append(items, completionItem{label: e + " :" + name + ":", insert: e})
+			}
+		}
+		return ":" + m[1], items
+	}
+	if m := snippetTokenRe.FindStringSubmatch(before); m != nil {
+		var items []completionItem
+		for _, name := range sortedKeys(snippets) {
+			if strings.HasPrefix(name, m[1][1:]) {
+				items = append(items, completionItem{label: "/" + name, insert: snippets[name]})
+			}
+		}
+		return m[1], items
+	}
+	return "", nil
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// refresh recomputes the candidates from the text before the cursor,
+// keeping the selection while the same token is being typed.
+func (c *textCompleter) refresh(before string) {
+	token, items := completions(before)
+	if len(items) > maxCompletions {
+		items = items[:maxCompletions]
+	}
+	if token != c.token || c.sel >= len(items) {
+		c.sel = 0
+	}
+	c.token, c.items = token, items
+}
+
+// reset drops any offered candidates.
+func (c *textCompleter) reset() {
+	*c = textCompleter{}
+}
+
+// active reports whether candidates are being offered.
+func (c textCompleter) active() bool {
+	return len(c.items) > 0
+}
+
+// handles reports whether msg is a completion key while candidates are
+// offered, so callers can let it through before their own Tab handling.
+func (c textCompleter) handles(msg tea.KeyMsg) bool {
+	if !c.active() {
+		return false
+	}
+	switch msg.String() {
+	case "tab", "ctrl+n", "ctrl+p":
+		return true
+	}
+	return false
+}
+
+// key applies a completion key, returning the candidate to insert when one
+// is accepted.
+func (c *textCompleter) key(msg tea.KeyMsg) (completionItem, bool) {
+	switch msg.String() {
+	case "ctrl+n":
+		c.sel = (c.sel + 1) % len(c.items)
+	case "ctrl+p":
+		c.sel = (c.sel + len(c.items) - 1) % len(c.items)
+	case "tab":
+		item := c.items[c.sel]
+		c.reset()
+		return item, true
+	}
+	return completionItem{}, false
+}
+
+// View renders the candidates as one hint row, or "" when none are offered.
+func (c textCompleter) View(width int) string {
+	if !c.active() {
+		return ""
+	}
+	parts := make([]string, len(c.items))
+	for i, item := range c.items {
+		label := strings.ReplaceAll(item.label, "\n", "⏎")
+		if i == c.sel {
+			parts[i] = lipgloss.NewStyle().Foreground(theme.Accent).Bold(true).Render(label)
+		} else {
+			parts[i] = dimStyle.Render(label)
+		}
+	}
+	hint := dimStyle.Render("Tab ") + strings.Join(parts, dimStyle.Render(" · "))
+	return ansi.Truncate(hint, width, "…")
+}
+
+// updateTextarea passes a key to ta, first applying completion keys and
+// expanding a just-closed ":shortcode:", then refreshing c.
+func updateTextarea(ta *textarea.Model, c *textCompleter, msg tea.KeyMsg) tea.Cmd {
+	replace := func(n int, s string) {
+		for range n {
+			*ta, _ = ta.Update(tea.KeyMsg{Type: tea.KeyBackspace})
+		}
+		ta.InsertString(s)
+	}
+	var cmd tea.Cmd
+	if c.handles(msg) {
+		token := c.token
+		if item, ok := c.key(msg); ok {
+			replace(len([]rune(token)), item.insert)
+		}
+	} else {
+		*ta, cmd = ta.Update(msg)
+		if n, emoji, ok := closedShortcode(textareaBefore(*ta)); ok {
+			replace(n, emoji)
+		}
+	}
+	c.refresh(textareaBefore(*ta))
+	return cmd
+}
+
+// updateTextInput is updateTextarea for a single-line input. Multi-line
+// snippets are flattened by the input.
+func updateTextInput(ti *textinput.Model, c *textCompleter, msg tea.KeyMsg) tea.Cmd {
+	replace := func(n int, s string) {
+		value := []rune(ti.Value())
+		pos := ti.Position()
+		start := max(0, pos-n)
+		ti.SetValue(string(value[:start]) + s + string(value[pos:]))
+		ti.SetCursor(start + len([]rune(s)))
+	}
+	var cmd tea.Cmd
+	if c.handles(msg) {
+		token := c.token
+		if item, ok := c.key(msg); ok {
+			replace(len([]rune(token)), item.insert)
+		}
+	} else {
+		*ti, cmd = ti.Update(msg)
+		if n, emoji, ok := closedShortcode(textInputBefore(*ti)); ok {
+			replace(n, emoji)
+		}
+	}
+	c.refresh(textInputBefore(*ti))
+	return cmd
+}
+
+// closedShortcode reports a known ":shortcode:" just typed at the end of
+// before, with its length in runes and its emoji.
+func closedShortcode(before string) (int, string, bool) {
+	m := emojiDoneRe.FindStringSubmatch(before)
+	if m == nil {
+		return 0, "", false
+	}
+	emoji, ok := emojiShortcodes[m[2]]
+	return len([]rune(m[1])), emoji, ok
+}
+
+// textareaBefore returns the text of the cursor's line before the cursor.
+func textareaBefore(ta textarea.Model) string {
+	lines := strings.Split(ta.Value(), "\n")
+	row := ta.Line()
+	if row >= len(lines) {
+		return ""
+	}
+	line := []rune(lines[row])
+	info := ta.LineInfo()
+	return string(line[:min(len(line), info.StartColumn+info.ColumnOffset)])
+}
+
+// textInputBefore returns the text of a single-line input before the cursor.
+func textInputBefore(ti textinput.Model) string {
+	value := []rune(ti.Value())
+	return string(value[:min(len(value), ti.Position())])
+}
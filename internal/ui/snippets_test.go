@@ -0,0 +1,114 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/bubbles/textarea"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func typeInto(ti *textinput.Model, c *textCompleter, s string) {
+	for _, r := range s {
+		updateTextInput(ti, c, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+}
+
+func TestCompletions(t *testing.T) {
+	defer setSnippets(snippets)
+	setSnippets(map[string]string{"nit": "nit: ", "needs-test": "Needs a test.", "lgtm": "LGTM"})
+
+	tests := []struct {
+		before    string
+		wantToken string
+		wantFirst string
+	}{
+		{"looks good :thu", ":thu", "👎"},
+		{":+1", ":+1", "👍"},
+		{"/n", "/n", "Needs a test."},
+		{"ok /nit", "/nit", "nit: "},
+		{"/", "/", "LGTM"},
+	}
+	for _, tt := range tests {
+		token, items := completions(tt.before)
+		if token != tt.wantToken || len(items) == 0 || items[0].insert != tt.wantFirst {
+			t.Errorf("completions(%q) = %q %v, want %q starting with %q", tt.before, token, items, tt.wantToken, tt.wantFirst)
+		}
+	}
+
+	// Not at the start of a word, too short, or unknown: nothing offered.
+	for _, before := range []string{"a:thu", "src/nit", ":t", "/zzz", "time 10:30"} {
+		if _, items := completions(before); len(items) != 0 {
+			t.Errorf("completions(%q) = %v, want none", before, items)
+		}
+	}
+}
+
+func TestUpdateTextInput_AcceptsAndCycles(t *testing.T) {
+	ti := textinput.New()
+	ti.Focus()
+	var c textCompleter
+
+	typeInto(&ti, &c, "ship it :ta")
+	if !c.active() || !strings.Contains(c.View(80), ":tada:") {
+		t.Fatalf("hint = %q, want :tada: offered", c.View(80))
+	}
+	updateTextInput(&ti, &c, tea.KeyMsg{Type: tea.KeyTab})
+	if ti.Value() != "ship it 🎉" || c.active() {
+		t.Errorf("value = %q, active = %v after Tab", ti.Value(), c.active())
+	}
+
+	ti.SetValue("")
+	c.reset()
+	typeInto(&ti, &c, ":thumbs")
+	updateTextInput(&ti, &c, tea.KeyMsg{Type: tea.KeyCtrlN})
+	updateTextInput(&ti, &c, tea.KeyMsg{Type: tea.KeyTab})
+	if ti.Value() != "👍" {
+		t.Errorf("value = %q, want the second candidate (:thumbsup:)", ti.Value())
+	}
+}
+
+func TestUpdateTextInput_ClosedShortcodeExpands(t *testing.T) {
+	ti := textinput.New()
+	ti.Focus()
+	var c textCompleter
+
+	typeInto(&ti, &c, "nice :rocket: and :nope:")
+	if ti.Value() != "nice 🚀 and :nope:" {
+		t.Errorf("value = %q", ti.Value())
+	}
+}
+
+func TestUpdateTextarea_SnippetOnCursorLine(t *testing.T) {
+	defer setSnippets(snippets)
+	setSnippets(map[string]string{"suggestion": "```suggestion\n\n```"})
+
+	ta := textarea.New()
+	ta.Focus()
+	ta.SetValue("first line\n")
+	var c textCompleter
+	for _, r := range "/sug" {
+		updateTextarea(&ta, &c, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+	if !c.active() {
+		t.Fatal("/sug on the second line should offer the suggestion snippet")
+	}
+	updateTextarea(&ta, &c, tea.KeyMsg{Type: tea.KeyTab})
+	if want := "first line\n```suggestion\n\n```"; ta.Value() != want {
+		t.Errorf("value = %q, want %q", ta.Value(), want)
+	}
+}
+
+func TestCommentOverlay_TabCompletesBeforeTogglingPostMode(t *testing.T) {
+	m := NewCommentOverlayModel()
+	m.composing = true
+	m.textarea.Focus()
+	for _, r := range "/nit" {
+		m, _ = m.updateComposing(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+	m, _ = m.updateComposing(tea.KeyMsg{Type: tea.KeyTab})
+	if m.textarea.Value() != "nit: " {
+		t.Errorf("value = %q, want the nit snippet", m.textarea.Value())
+	}
+}
@@ -0,0 +1,167 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/shhac/prtea/internal/github"
+)
+
+// markStacked records, for each listed PR, the listed PR in the same repo
+// whose head branch it is based on. Items without branch data (from the
+// REST fallback) are left unmarked.
+func markStacked(lists ...[]list.Item) {
+	heads := make(map[string]int) // "owner/repo:branch" → PR number
+	for _, items := range lists {
+		for _, item := range items {
+			if pr, ok := item.(PRItem); ok && pr.headBranch != "" {
+				heads[pr.repoFull+":"+pr.headBranch] = pr.number
+			}
+		}
+	}
+	for _, items := range lists {
+		for i, item := range items {
+			pr, ok := item.(PRItem)
+			if !ok {
+				continue
+			}
+			pr.stackedOn = 0
+			if n, ok := heads[pr.repoFull+":"+pr.baseBranch]; ok && n != pr.number {
+				pr.stackedOn = n
+			}
+			items[i] = pr
+		}
+	}
+}
+
+// fetchStackCmd returns a command that finds the stack of open PRs the
+// given PR belongs to.
+func fetchStackCmd(client GitHubService, owner, repo string, number int) tea.Cmd {
+	return func() tea.Msg {
+		prs, err := client.GetOpenPRStackInfo(context.Background(), owner, repo)
+		if err != nil {
+			return StackLoadedMsg{PRNumber: number, Err: err}
+		}
+		return StackLoadedMsg{PRNumber: number, Stack: github.BuildStack(prs, number)}
+	}
+}
+
+// fetchStackDiffCmd returns a command that fetches the combined diff of a
+// stack, from the base of its root to head.
+func fetchStackDiffCmd(client GitHubService, owner, repo string, number int, base, head string) tea.Cmd {
+	return func() tea.Msg {
+		files, err := client.GetCompareFiles(context.Background(), owner, repo, base, head)
+		return StackDiffLoadedMsg{PRNumber: number, Base: base, Head: head, Files: files, Err: err}
+	}
+}
+
+// stackIndex returns the current PR's position in its stack, or -1.
+func (s *PRSession) stackIndex() int {
+	for i, member := range s.Stack {
+		if member.Number == s.Number {
+			return i
+		}
+	}
+	return -1
+}
+
+// moveInStack opens the PR delta places along the current PR's stack
+// (:stack next / :stack prev), root first.
+func (m App) moveInStack(delta int) (tea.Model, tea.Cmd) {
+	if m.session == nil {
+		return m, m.statusBar.SetTemporaryMessage("No PR selected", 2*time.Second)
+	}
+	i := m.session.stackIndex()
+	if i < 0 {
+		return m, m.statusBar.SetTemporaryMessage(fmt.Sprintf("PR #%d is not part of a stack", m.session.Number), 2*time.Second)
+	}
+	j := i + delta
+	if j < 0 || j >= len(m.session.Stack) {
+		end := "top"
+		if delta < 0 {
+			end = "base"
+		}
+		return m, m.statusBar.SetTemporaryMessage(fmt.Sprintf("Already at the %s of the stack", end), 2*time.Second)
+	}
+	target := m.session.Stack[j]
+	return m.selectPR(m.session.Owner, m.session.Repo, target.Number, target.HTMLURL, true)
+}
+
+// toggleStackDiff switches the Diff tab between the PR's own diff and the
+// combined diff of every PR below it in its stack (:stack diff).
+func (m App) toggleStackDiff() (tea.Model, tea.Cmd) {
+	s := m.session
+	if s == nil {
+		return m, m.statusBar.SetTemporaryMessage("No PR selected", 2*time.Second)
+	}
+	if m.diffViewer.stackDiff != "" {
+		m.diffViewer.SetDiff(s.DiffFiles)
+		m.diffViewer.SetStackDiff("")
+		return m, m.statusBar.SetTemporaryMessage(fmt.Sprintf("Showing PR #%d's own diff", s.Number), 2*time.Second)
+	}
+	i := s.stackIndex()
+	if i < 0 {
+		return m, m.statusBar.SetTemporaryMessage(fmt.Sprintf("PR #%d is not part of a stack", s.Number), 2*time.Second)
+	}
+	if s.Stack[i].Depth == 0 {
+		return m, m.statusBar.SetTemporaryMessage(fmt.Sprintf("PR #%d is the base of its stack; its diff is already the combined diff", s.Number), 3*time.Second)
+	}
+	if m.ghClient == nil {
+		return m, nil
+	}
+	base, head := s.Stack[0].BaseBranch, s.Stack[i].HeadBranch
+	clearCmd := m.statusBar.SetTemporaryMessage(fmt.Sprintf("Loading the combined diff %s...%s...", base, head), 15*time.Second)
+	return m, tea.Batch(clearCmd, fetchStackDiffCmd(m.ghClient, s.Owner, s.Repo, s.Number, base, head))
+}
+
+// SetStack sets the stack of open PRs shown on the PR Info tab.
+func (m *DiffViewerModel) SetStack(stack []github.StackMember) {
+	m.stack = stack
+	m.prInfoCache = ""
+	m.refreshContent()
+}
+
+// SetStackDiff records that the Diff tab shows the combined stack diff
+// "base...head", or "" for the PR's own diff.
+func (m *DiffViewerModel) SetStackDiff(compare string) {
+	m.stackDiff = compare
+	m.prInfoCache = ""
+	m.refreshContent()
+}
+
+// renderStack renders the PR Info tab's stack section, if the PR is stacked.
+func (m *DiffViewerModel) renderStack(width int) string {
+	if len(m.stack) == 0 {
+		return ""
+	}
+	var b strings.Builder
+	b.WriteString("\n")
+	b.WriteString(sectionHeaderStyle.Render(fmt.Sprintf("Stack (%d PRs)", len(m.stack))))
+	b.WriteString("\n")
+	for _, member := range m.stack {
+		indent := strings.Repeat("  ", member.Depth)
+		line := fmt.Sprintf("#%d %s", member.Number, member.Title)
+		if member.Number == m.prNumber {
+			line = lipgloss.NewStyle().Foreground(theme.Accent).Bold(true).Render("▸ " + line)
+		} else {
+			line = "  " + line
+		}
+		if member.Draft {
+			line += dimStyle.Render(" draft")
+		}
+		b.WriteString(fitWidth(indent+line, width))
+		b.WriteString("\n")
+	}
+	if m.stackDiff != "" {
+		b.WriteString(lipgloss.NewStyle().Foreground(theme.Warning).Render("Diff tab shows the combined diff " + m.stackDiff))
+		b.WriteString("\n")
+	}
+	b.WriteString(dimStyle.Render(":stack next / :stack prev to move · :stack diff for the combined diff"))
+	b.WriteString("\n")
+	return b.String()
+}
@@ -0,0 +1,94 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/bubbles/list"
+	"github.com/shhac/prtea/internal/github"
+)
+
+func TestMarkStacked(t *testing.T) {
+	toReview := []list.Item{
+		PRItem{number: 11, repoFull: "acme/api", repo: "api", author: "bob", baseBranch: "feature-a", headBranch: "feature-b"},
+		PRItem{number: 12, repoFull: "acme/web", repo: "web", author: "bob", baseBranch: "feature-a", headBranch: "feature-c"},
+	}
+	mine := []list.Item{
+		PRItem{number: 10, repoFull: "acme/api", repo: "api", author: "alice", baseBranch: "main", headBranch: "feature-a"},
+	}
+	markStacked(toReview, mine)
+
+	if pr := toReview[0].(PRItem); pr.stackedOn != 10 || pr.Description() != "bob · api · on #10" {
+		t.Errorf("#11 description = %q, want it stacked on #10", pr.Description())
+	}
+	if pr := toReview[1].(PRItem); pr.stackedOn != 0 {
+		t.Errorf("#12 is in another repo, stackedOn = %d", pr.stackedOn)
+	}
+	if pr := mine[0].(PRItem); pr.stackedOn != 0 {
+		t.Errorf("#10 is the base, stackedOn = %d", pr.stackedOn)
+	}
+}
+
+func stackTestApp(t *testing.T, calls *[]string) App {
+	m := workspaceTestApp(3)
+	m.ghClient = recordingClient(calls, func([]string) string {
+		return `{"files":[{"filename":"a.go","status":"modified","patch":"@@ -1 +1 @@\n-a\n+b"},{"filename":"b.go","status":"added","patch":"@@ -0,0 +1 @@\n+c"}]}`
+	})
+	m = loadPR(t, m, 11)
+	m.session.DiffFiles = m.diffViewer.files
+	m.session.Stack = []github.StackMember{
+		{StackPR: github.StackPR{Number: 10, Title: "API", BaseBranch: "main", HeadBranch: "api"}},
+		{StackPR: github.StackPR{Number: 11, Title: "Client", BaseBranch: "api", HeadBranch: "client"}, Depth: 1},
+		{StackPR: github.StackPR{Number: 12, Title: "UI", BaseBranch: "client", HeadBranch: "ui", Draft: true}, Depth: 2},
+	}
+	m.diffViewer.SetPRInfo("Client", "", "alice", "")
+	m.diffViewer.SetStack(m.session.Stack)
+	return m
+}
+
+func TestStack_PRInfoAndNavigation(t *testing.T) {
+	var calls []string
+	m := stackTestApp(t, &calls)
+
+	info := m.diffViewer.renderPRInfo()
+	if !strings.Contains(info, "Stack (3 PRs)") || !strings.Contains(info, "▸ #11 Client") || !strings.Contains(info, "#12 UI") {
+		t.Errorf("PR Info stack section:\n%s", info)
+	}
+
+	model, _ := m.executeCommand("stack next", "")
+	if got := model.(App).session.Number; got != 12 {
+		t.Errorf(":stack next opened #%d, want #12", got)
+	}
+	model, _ = m.executeCommand("stack prev", "")
+	m = model.(App)
+	if m.session.Number != 10 {
+		t.Fatalf(":stack prev opened #%d, want #10", m.session.Number)
+	}
+}
+
+func TestStack_CombinedDiffToggle(t *testing.T) {
+	var calls []string
+	m := stackTestApp(t, &calls)
+	own := m.diffViewer.files
+
+	model, _ := m.executeCommand("stack diff", "")
+	m = model.(App)
+	done := fetchStackDiffCmd(m.ghClient, "acme", "widget", 11, "main", "client")().(StackDiffLoadedMsg)
+	if len(calls) != 1 || !strings.Contains(calls[0], "repos/acme/widget/compare/main...client") {
+		t.Fatalf("calls = %q, want a compare from the stack's base", calls)
+	}
+	model, _ = m.Update(done)
+	m = model.(App)
+	if len(m.diffViewer.files) != 2 || m.diffViewer.stackDiff != "main...client" {
+		t.Fatalf("diff has %d files, stackDiff %q", len(m.diffViewer.files), m.diffViewer.stackDiff)
+	}
+	if !strings.Contains(m.diffViewer.renderPRInfo(), "combined diff main...client") {
+		t.Error("PR Info should say the Diff tab shows the combined diff")
+	}
+
+	model, _ = m.executeCommand("stack diff", "")
+	m = model.(App)
+	if m.diffViewer.stackDiff != "" || len(m.diffViewer.files) != len(own) {
+		t.Errorf("toggling again should restore the PR's own diff, got %d files", len(m.diffViewer.files))
+	}
+}
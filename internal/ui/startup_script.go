@@ -0,0 +1,118 @@
+package ui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/shhac/prtea/internal/github"
+)
+
+// startupScript holds the commands from the RC file and --cmd still to run.
+// Commands run once the PR lists have loaded, in order; after one opens a
+// PR, the rest wait for its diff so e.g. "open 123; analyze" works.
+type startupScript struct {
+	cmds    []string
+	started bool // the PR lists have loaded (or failed to)
+	waitPR  int  // PR whose diff must load before the next command, 0 for none
+}
+
+// WithStartupCommands queues palette commands to run at startup, e.g.
+// "open 123", "analyze" or "zoom".
+func WithStartupCommands(cmds ...string) AppOption {
+	return func(a *App) {
+		if len(cmds) > 0 {
+			a.script = &startupScript{cmds: cmds}
+		}
+	}
+}
+
+// parseCommandLine resolves a typed command, with or without arguments and
+// a leading ":", as the palette does. name is "" for unknown commands.
+func parseCommandLine(input string) (name, args string) {
+	input = strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(input), ":"))
+	if name, args, ok := splitCommandArgs(input); ok {
+		return name, args
+	}
+	return CommandModeModel{}.resolveCommand(input), ""
+}
+
+// resumeScript runs queued startup commands until one opens a PR whose diff
+// has yet to load.
+func (m App) resumeScript() (tea.Model, tea.Cmd) {
+	s := m.script
+	if s == nil || !s.started || s.waitPR != 0 {
+		return m, nil
+	}
+	var cmds []tea.Cmd
+	for len(s.cmds) > 0 && s.waitPR == 0 {
+		line := s.cmds[0]
+		s.cmds = s.cmds[1:]
+		name, args := parseCommandLine(line)
+		if name == "" {
+			cmds = append(cmds, m.statusBar.SetTemporaryMessage("Unknown startup command: "+line, 3*time.Second))
+			continue
+		}
+		before := m.session
+		model, cmd := m.executeCommand(name, args)
+		m = model.(App)
+		cmds = append(cmds, cmd)
+		if m.session != nil && m.session != before && len(m.session.DiffFiles) == 0 {
+			s.waitPR = m.session.Number
+		}
+	}
+	if len(s.cmds) == 0 && s.waitPR == 0 {
+		m.script = nil
+	}
+	return m, tea.Batch(cmds...)
+}
+
+// scriptListLoaded starts the startup script once the PR lists are in.
+func (m App) scriptListLoaded() (tea.Model, tea.Cmd) {
+	if m.script == nil || m.script.started {
+		return m, nil
+	}
+	m.script.started = true
+	return m.resumeScript()
+}
+
+// scriptDiffLoaded resumes the startup script when the diff it waits for
+// has loaded.
+func (m App) scriptDiffLoaded(number int) (tea.Model, tea.Cmd) {
+	if m.script == nil || m.script.waitPR != number {
+		return m, nil
+	}
+	m.script.waitPR = 0
+	return m.resumeScript()
+}
+
+// openPR selects a PR from the PR lists by number, or any PR by
+// owner/repo#123 or URL (:open 123). Without arguments it opens the current
+// PR in the browser.
+func (m App) openPR(arg string) (tea.Model, tea.Cmd) {
+	arg = strings.TrimSpace(arg)
+	if arg == "" {
+		if m.session != nil && m.session.HTMLURL != "" {
+			return m, openBrowserCmd(m.session.HTMLURL)
+		}
+		return m, nil
+	}
+	if ref, err := github.ParsePRRef(arg); err == nil {
+		url := ""
+		if item, ok := m.prList.findPR(ref.Owner, ref.Repo, ref.Number); ok {
+			url = item.htmlURL
+		}
+		return m.selectPR(ref.Owner, ref.Repo, ref.Number, url, true)
+	}
+	number, err := strconv.Atoi(strings.TrimPrefix(arg, "#"))
+	if err != nil {
+		return m, m.statusBar.SetTemporaryMessage("Usage: open [123 | owner/repo#123 | PR URL]", 3*time.Second)
+	}
+	item, ok := m.prList.findPR("", "", number)
+	if !ok {
+		return m, m.statusBar.SetTemporaryMessage(fmt.Sprintf("PR #%d is not in your PR lists — try open owner/repo#%d", number, number), 3*time.Second)
+	}
+	return m.selectPR(item.owner, item.repo, item.number, item.htmlURL, true)
+}
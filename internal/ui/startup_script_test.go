@@ -0,0 +1,95 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/bubbles/list"
+	"github.com/shhac/prtea/internal/github"
+)
+
+func TestParseCommandLine(t *testing.T) {
+	tests := []struct {
+		in, name, args string
+	}{
+		{"analyze", "analyze", ""},
+		{":zoom", "zoom", ""},
+		{"open 123", "open", "123"},
+		{"op shhac/prtea#4", "open", "shhac/prtea#4"},
+		{"timer 20m", "timer", "20m"},
+		{"toggle left", "toggle left", ""},
+		{"bogus", "", ""},
+	}
+	for _, tt := range tests {
+		name, args := parseCommandLine(tt.in)
+		if name != tt.name || args != tt.args {
+			t.Errorf("parseCommandLine(%q) = %q, %q; want %q, %q", tt.in, name, args, tt.name, tt.args)
+		}
+	}
+}
+
+func scriptTestApp(cmds ...string) App {
+	m := App{
+		prList:       NewPRListModel(TabToReview),
+		statusBar:    NewStatusBarModel(),
+		diffViewer:   newTestDiffViewer(80, 40),
+		chatPanel:    NewChatPanelModel(),
+		focused:      PanelLeft,
+		panelVisible: [3]bool{true, true, true},
+	}
+	m.prList.SetItems([]list.Item{
+		PRItem{number: 12, owner: "shhac", repo: "prtea", title: "Add retries", htmlURL: "https://github.com/shhac/prtea/pull/12"},
+	}, nil)
+	WithStartupCommands(cmds...)(&m)
+	return m
+}
+
+func TestStartupScriptWaitsForOpenedDiff(t *testing.T) {
+	m := scriptTestApp("open 12", "chat", "bogus")
+
+	model, _ := m.scriptListLoaded()
+	m = model.(App)
+	if m.session == nil || m.session.Number != 12 || m.session.Title != "Add retries" {
+		t.Fatalf("session = %+v, want #12 opened from the list", m.session)
+	}
+	if m.focused != PanelCenter {
+		t.Errorf("focused = %v before the diff loaded, want the diff panel", m.focused)
+	}
+	if m.script == nil || m.script.waitPR != 12 || len(m.script.cmds) != 2 {
+		t.Fatalf("script = %+v, want two commands waiting on #12", m.script)
+	}
+
+	// Another PR's diff does not resume the script
+	model, _ = m.scriptDiffLoaded(99)
+	m = model.(App)
+	if m.focused != PanelCenter {
+		t.Errorf("script resumed on the wrong PR's diff")
+	}
+
+	m.session.DiffFiles = []github.PRFile{{Filename: "a.go"}}
+	model, _ = m.scriptDiffLoaded(12)
+	m = model.(App)
+	if m.focused != PanelRight {
+		t.Errorf("focused = %v, want the chat panel after the script resumed", m.focused)
+	}
+	if !strings.Contains(m.statusBar.statusMessage, "Unknown startup command: bogus") {
+		t.Errorf("status = %q, want the unknown command reported", m.statusBar.statusMessage)
+	}
+	if m.script != nil {
+		t.Errorf("script = %+v, want nil once done", m.script)
+	}
+}
+
+func TestOpenPR(t *testing.T) {
+	m := scriptTestApp()
+	model, _ := m.openPR("#7")
+	if got := model.(App).statusBar.statusMessage; !strings.Contains(got, "PR #7 is not in your PR lists") {
+		t.Errorf("status = %q", got)
+	}
+
+	model, _ = m.openPR("other/repo#7")
+	s := model.(App).session
+	if s == nil || s.Owner != "other" || s.Repo != "repo" || s.Number != 7 {
+		t.Errorf("session = %+v, want other/repo#7", s)
+	}
+}
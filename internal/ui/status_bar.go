@@ -1,12 +1,13 @@
 package ui
 
 import (
-	"fmt"
 	"strings"
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/shhac/prtea/internal/github"
 )
 
 // StatusBarModel renders the bottom status bar.
@@ -18,6 +19,18 @@ type StatusBarModel struct {
 	filtering     bool // true when PR list filter input is active
 	diffSearching bool // true when diff viewer search input is active
 	diffSearchInfo string // e.g. "3/17" when search has matches
+	timerInfo      string // review timer countdown for the selected PR
+	focusInfo      string // focus mode progress
+	aiInfo         string // AI error budget notice, e.g. "⚠ AI degraded"
+
+	// Right-hand segments, in display order, and their truncation priorities
+	segments     []string
+	priorities   map[string]int
+	rateLimit    *github.RateLimit
+	nextPoll     time.Time // zero when polling is off
+	pendingCount int
+	ciStatus     string
+	now          time.Time // last status bar tick; zero means time.Now()
 
 	// Temporary flash message (e.g. "Refreshing PR #123...")
 	statusMessage string
@@ -28,7 +41,8 @@ type StatusBarModel struct {
 }
 
 func NewStatusBarModel() StatusBarModel {
-	return StatusBarModel{}
+	segments, priorities := statusSegmentsFromConfig(nil)
+	return StatusBarModel{segments: segments, priorities: priorities}
 }
 
 func (m *StatusBarModel) SetWidth(width int) {
@@ -55,7 +69,24 @@ func (m *StatusBarModel) SetDiffSearchInfo(info string) {
 	m.diffSearchInfo = info
 }
 
+// SetTimerInfo updates the review timer countdown (e.g. "⏱ 12:34").
+func (m *StatusBarModel) SetTimerInfo(info string) {
+	m.timerInfo = info
+}
+
+// SetFocusInfo updates the focus mode progress (e.g. "◎ file 4/17 · 60% viewed").
+func (m *StatusBarModel) SetFocusInfo(info string) {
+	m.focusInfo = info
+}
+
+func (m *StatusBarModel) SetAIInfo(info string) {
+	m.aiInfo = info
+}
+
 func (m *StatusBarModel) SetSelectedPR(number int) {
+	if number != m.selectedPR {
+		m.ciStatus = ""
+	}
 	m.selectedPR = number
 }
 
@@ -94,6 +125,9 @@ func (m StatusBarModel) View() string {
 		leftHints = m.keyHints()
 	}
 	rightInfo := m.contextInfo()
+	if room := m.width - lipgloss.Width(rightInfo); room < lipgloss.Width(leftHints) {
+		leftHints = ansi.Truncate(leftHints, max(0, room), "…")
+	}
 
 	leftRendered := statusBarAccentStyle.Render(leftHints)
 	rightRendered := statusBarStyle.Render(rightInfo)
@@ -139,23 +173,12 @@ func (m StatusBarModel) keyHints() string {
 	}
 }
 
+// contextInfo renders the right-hand segments that fit, leaving room for
+// at least a few key hints.
 func (m StatusBarModel) contextInfo() string {
-	modeStr := ""
-	switch m.mode {
-	case ModeInsert:
-		modeStr = " INSERT "
-	case ModeOverlay:
-		modeStr = " OVERLAY "
-	case ModeCommand:
-		modeStr = " COMMAND "
-	default:
-		modeStr = " NAV "
+	width := m.width - minKeyHintsWidth
+	if m.width == 0 {
+		width = 1 << 16
 	}
-
-	prInfo := ""
-	if m.selectedPR > 0 {
-		prInfo = fmt.Sprintf("PR #%d ", m.selectedPR)
-	}
-
-	return modeStr + prInfo
+	return m.fitSegments(max(0, width))
 }
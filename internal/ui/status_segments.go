@@ -0,0 +1,289 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/shhac/prtea/internal/config"
+	"github.com/shhac/prtea/internal/github"
+)
+
+// Status bar segments shown on the right of the status bar, in the order
+// given by the statusBarSegments config.
+const (
+	segmentAI        = "ai"        // AI error budget notice
+	segmentTimer     = "timer"     // review timer countdown
+	segmentFocus     = "focus"     // focus mode progress
+	segmentMode      = "mode"      // NAV / INSERT / COMMAND / OVERLAY
+	segmentPR        = "pr"        // selected PR number
+	segmentRateLimit = "ratelimit" // GitHub API quota remaining
+	segmentPoll      = "poll"      // countdown to the next background refresh
+	segmentPending   = "pending"   // draft inline comments for the selected PR
+	segmentCI        = "ci"        // CI status of the selected PR
+	segmentClock     = "clock"     // wall clock
+)
+
+// defaultStatusSegments matches the status bar before segments were
+// configurable.
+var defaultStatusSegments = []string{segmentAI, segmentTimer, segmentFocus, segmentMode, segmentPR}
+
+// defaultSegmentPriority decides which segments survive on narrow terminals:
+// the lowest priority is dropped first.
+var defaultSegmentPriority = map[string]int{
+	segmentMode:      100,
+	segmentPR:        90,
+	segmentFocus:     85,
+	segmentTimer:     80,
+	segmentAI:        70,
+	segmentPending:   60,
+	segmentCI:        50,
+	segmentRateLimit: 40,
+	segmentPoll:      30,
+	segmentClock:     20,
+}
+
+// minKeyHintsWidth is the room kept for key hints before segments are
+// dropped to make space.
+const minKeyHintsWidth = 24
+
+// statusSegmentsFromConfig returns the configured segment order and
+// priorities, ignoring unknown segment names.
+func statusSegmentsFromConfig(cfg *config.Config) ([]string, map[string]int) {
+	priorities := make(map[string]int, len(defaultSegmentPriority))
+	for name, p := range defaultSegmentPriority {
+		priorities[name] = p
+	}
+	if cfg == nil || cfg.StatusBarSegments == nil {
+		return defaultStatusSegments, priorities
+	}
+	for name, p := range cfg.StatusBarPriorities {
+		if _, ok := defaultSegmentPriority[name]; ok {
+			priorities[name] = p
+		}
+	}
+	var segments []string
+	for _, name := range cfg.StatusBarSegments {
+		if _, ok := defaultSegmentPriority[name]; !ok {
+			log.Printf("warning: unknown status bar segment %q", name)
+			continue
+		}
+		segments = append(segments, name)
+	}
+	return segments, priorities
+}
+
+// SetSegments sets which segments are shown, in order, and their truncation
+// priorities.
+func (m *StatusBarModel) SetSegments(segments []string, priorities map[string]int) {
+	m.segments = segments
+	m.priorities = priorities
+}
+
+// hasSegment reports whether a segment is enabled.
+func (m StatusBarModel) hasSegment(name string) bool {
+	for _, s := range m.segments {
+		if s == name {
+			return true
+		}
+	}
+	return false
+}
+
+// needsTick reports whether any enabled segment changes with the clock.
+func (m StatusBarModel) needsTick() bool {
+	return m.hasSegment(segmentClock) || m.hasSegment(segmentPoll)
+}
+
+// SetRateLimit updates the GitHub API quota shown by the ratelimit segment.
+func (m *StatusBarModel) SetRateLimit(rl *github.RateLimit) {
+	m.rateLimit = rl
+}
+
+// SetNextPoll sets when the next background refresh fires (zero when
+// polling is off).
+func (m *StatusBarModel) SetNextPoll(t time.Time) {
+	m.nextPoll = t
+}
+
+// SetPendingCount updates the draft comment count for the selected PR.
+func (m *StatusBarModel) SetPendingCount(n int) {
+	m.pendingCount = n
+}
+
+// SetCIStatus updates the selected PR's overall CI status ("passing",
+// "failing", "pending", "mixed", or "" when unknown).
+func (m *StatusBarModel) SetCIStatus(status string) {
+	m.ciStatus = status
+}
+
+// SetNow updates the time used by the clock and poll segments.
+func (m *StatusBarModel) SetNow(t time.Time) {
+	m.now = t
+}
+
+// renderSegment returns the text for one segment, or "" when it has
+// nothing to show.
+func (m StatusBarModel) renderSegment(name string) string {
+	now := m.now
+	if now.IsZero() {
+		now = time.Now()
+	}
+	switch name {
+	case segmentMode:
+		switch m.mode {
+		case ModeInsert:
+			return " INSERT "
+		case ModeOverlay:
+			return " OVERLAY "
+		case ModeCommand:
+			return " COMMAND "
+		default:
+			return " NAV "
+		}
+	case segmentPR:
+		if m.selectedPR > 0 {
+			return fmt.Sprintf("PR #%d ", m.selectedPR)
+		}
+	case segmentTimer:
+		if m.timerInfo != "" {
+			return m.timerInfo + " "
+		}
+	case segmentFocus:
+		if m.focusInfo != "" {
+			return m.focusInfo + " "
+		}
+	case segmentAI:
+		if m.aiInfo != "" {
+			return m.aiInfo + " "
+		}
+	case segmentRateLimit:
+		if m.rateLimit != nil && m.rateLimit.Limit > 0 {
+			warn := ""
+			if m.rateLimit.Remaining*10 < m.rateLimit.Limit {
+				warn = "⚠ "
+			}
+			return fmt.Sprintf("%sAPI %d/%d ", warn, m.rateLimit.Remaining, m.rateLimit.Limit)
+		}
+	case segmentPoll:
+		if !m.nextPoll.IsZero() {
+			left := max(0, m.nextPoll.Sub(now).Round(time.Second))
+			return fmt.Sprintf("↻ %d:%02d ", int(left.Minutes()), int(left.Seconds())%60)
+		}
+	case segmentPending:
+		if m.pendingCount > 0 {
+			return fmt.Sprintf("✎ %d ", m.pendingCount)
+		}
+	case segmentCI:
+		switch m.ciStatus {
+		case "passing":
+			return "CI ✓ "
+		case "failing":
+			return "CI ✗ "
+		case "pending":
+			return "CI ● "
+		case "mixed":
+			return "CI ◐ "
+		}
+	case segmentClock:
+		return now.Format("15:04") + " "
+	}
+	return ""
+}
+
+// fitSegments renders the enabled segments in order, dropping the lowest
+// priority ones until the result fits in width.
+func (m StatusBarModel) fitSegments(width int) string {
+	texts := make([]string, len(m.segments))
+	total := 0
+	for i, name := range m.segments {
+		texts[i] = m.renderSegment(name)
+		total += len([]rune(texts[i]))
+	}
+	for total > width {
+		drop := -1
+		for i, name := range m.segments {
+			if texts[i] == "" {
+				continue
+			}
+			if drop < 0 || m.priorities[name] < m.priorities[m.segments[drop]] {
+				drop = i
+			}
+		}
+		if drop < 0 {
+			break
+		}
+		total -= len([]rune(texts[drop]))
+		texts[drop] = ""
+	}
+	out := ""
+	for _, t := range texts {
+		out += t
+	}
+	return out
+}
+
+// statusBarTickMsg refreshes time-based status bar segments.
+type statusBarTickMsg struct {
+	Now time.Time
+}
+
+func statusBarTickCmd() tea.Cmd {
+	return tea.Tick(time.Second, func(t time.Time) tea.Msg {
+		return statusBarTickMsg{Now: t}
+	})
+}
+
+// startStatusBarTick starts the status bar tick loop if an enabled segment
+// needs it and it isn't already running.
+func (m *App) startStatusBarTick() tea.Cmd {
+	if m.statusTicking || !m.statusBar.needsTick() {
+		return nil
+	}
+	m.statusTicking = true
+	return statusBarTickCmd()
+}
+
+// handleStatusBarTick refreshes the clock and poll segments, stopping the
+// loop once no enabled segment needs it.
+func (m App) handleStatusBarTick(now time.Time) (tea.Model, tea.Cmd) {
+	m.statusBar.SetNow(now)
+	if !m.statusBar.needsTick() {
+		m.statusTicking = false
+		return m, nil
+	}
+	return m, statusBarTickCmd()
+}
+
+// rateLimitLoadedMsg carries a refreshed API quota for the ratelimit segment.
+type rateLimitLoadedMsg struct {
+	RateLimit *github.RateLimit
+}
+
+// fetchRateLimitCmd fetches the API quota; failures leave the segment as is.
+func fetchRateLimitCmd(client GitHubService) tea.Cmd {
+	return func() tea.Msg {
+		rl, err := client.GetRateLimit(context.Background())
+		if err != nil {
+			return nil
+		}
+		return rateLimitLoadedMsg{RateLimit: rl}
+	}
+}
+
+// refreshRateLimit fetches the API quota when the ratelimit segment is on.
+func (m App) refreshRateLimit() tea.Cmd {
+	if m.ghClient == nil || !m.statusBar.hasSegment(segmentRateLimit) {
+		return nil
+	}
+	return fetchRateLimitCmd(m.ghClient)
+}
+
+// schedulePollTick arms the next background poll and records when it fires
+// for the poll segment.
+func (m *App) schedulePollTick() tea.Cmd {
+	m.statusBar.SetNextPoll(time.Now().Add(m.pollInterval))
+	return pollTickCmd(m.pollInterval)
+}
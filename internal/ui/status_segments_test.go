@@ -0,0 +1,137 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shhac/prtea/internal/config"
+	"github.com/shhac/prtea/internal/github"
+)
+
+func TestStatusSegmentsDefaultOutput(t *testing.T) {
+	m := NewStatusBarModel()
+	m.SetSelectedPR(42)
+	m.SetTimerInfo("⏱ 12:00")
+	if got, want := m.contextInfo(), "⏱ 12:00  NAV PR #42 "; got != want {
+		t.Errorf("contextInfo = %q, want %q", got, want)
+	}
+}
+
+func TestStatusSegmentsFromConfig(t *testing.T) {
+	cfg := &config.Config{
+		StatusBarSegments:   []string{"clock", "bogus", "mode"},
+		StatusBarPriorities: map[string]int{"clock": 500, "bogus": 1},
+	}
+	segments, priorities := statusSegmentsFromConfig(cfg)
+	if strings.Join(segments, ",") != "clock,mode" {
+		t.Errorf("segments = %v, want [clock mode]", segments)
+	}
+	if priorities[segmentClock] != 500 || priorities[segmentMode] != defaultSegmentPriority[segmentMode] {
+		t.Errorf("priorities = %v", priorities)
+	}
+	if _, ok := priorities["bogus"]; ok {
+		t.Error("unknown segment should not get a priority")
+	}
+}
+
+func TestStatusSegmentsOrder(t *testing.T) {
+	m := NewStatusBarModel()
+	m.SetSegments([]string{segmentPR, segmentMode, segmentClock}, defaultSegmentPriority)
+	m.SetSelectedPR(7)
+	m.SetNow(time.Date(2026, 1, 2, 9, 5, 0, 0, time.Local))
+	if got, want := m.contextInfo(), "PR #7  NAV 09:05 "; got != want {
+		t.Errorf("contextInfo = %q, want %q", got, want)
+	}
+}
+
+func TestStatusSegmentsDropLowestPriorityWhenNarrow(t *testing.T) {
+	m := NewStatusBarModel()
+	m.SetSegments([]string{segmentClock, segmentMode, segmentPR}, defaultSegmentPriority)
+	m.SetSelectedPR(7)
+	m.SetNow(time.Date(2026, 1, 2, 9, 5, 0, 0, time.Local))
+
+	// " NAV PR #7 " is 11 columns; the clock has the lowest priority.
+	if got, want := m.fitSegments(11), " NAV PR #7 "; got != want {
+		t.Errorf("fitSegments(11) = %q, want %q", got, want)
+	}
+	if got, want := m.fitSegments(5), " NAV "; got != want {
+		t.Errorf("fitSegments(5) = %q, want %q", got, want)
+	}
+
+	// Raising the clock's priority keeps it over the PR number.
+	m.SetSegments(m.segments, map[string]int{segmentClock: 200, segmentMode: 100, segmentPR: 90})
+	if got, want := m.fitSegments(12), "09:05  NAV "; got != want {
+		t.Errorf("fitSegments(12) = %q, want %q", got, want)
+	}
+}
+
+func TestStatusBarTruncatesHintsBeforeWrapping(t *testing.T) {
+	m := NewStatusBarModel()
+	m.SetWidth(40)
+	m.SetState(PanelCenter, ModeNavigation)
+	m.SetSelectedPR(123)
+	view := m.View()
+	if strings.Contains(view, "\n") {
+		t.Errorf("status bar should stay on one line, got %q", view)
+	}
+	if !strings.Contains(view, "PR #123") {
+		t.Errorf("status bar should keep the PR segment, got %q", view)
+	}
+}
+
+func TestStatusSegmentTexts(t *testing.T) {
+	now := time.Now()
+	m := NewStatusBarModel()
+	m.SetNow(now)
+
+	m.SetRateLimit(&github.RateLimit{Limit: 5000, Remaining: 4000})
+	if got := m.renderSegment(segmentRateLimit); got != "API 4000/5000 " {
+		t.Errorf("ratelimit = %q", got)
+	}
+	m.SetRateLimit(&github.RateLimit{Limit: 5000, Remaining: 100})
+	if got := m.renderSegment(segmentRateLimit); !strings.HasPrefix(got, "⚠") {
+		t.Errorf("low ratelimit should warn, got %q", got)
+	}
+
+	if got := m.renderSegment(segmentPoll); got != "" {
+		t.Errorf("poll without a schedule = %q, want empty", got)
+	}
+	m.SetNextPoll(now.Add(90 * time.Second))
+	if got := m.renderSegment(segmentPoll); got != "↻ 1:30 " {
+		t.Errorf("poll = %q", got)
+	}
+
+	if got := m.renderSegment(segmentPending); got != "" {
+		t.Errorf("pending with no drafts = %q, want empty", got)
+	}
+	m.SetPendingCount(3)
+	if got := m.renderSegment(segmentPending); got != "✎ 3 " {
+		t.Errorf("pending = %q", got)
+	}
+
+	m.SetCIStatus("failing")
+	if got := m.renderSegment(segmentCI); got != "CI ✗ " {
+		t.Errorf("ci = %q", got)
+	}
+	m.SetSelectedPR(9)
+	if got := m.renderSegment(segmentCI); got != "" {
+		t.Errorf("ci should clear when the PR changes, got %q", got)
+	}
+}
+
+func TestStatusBarTickStopsWithoutTimeSegments(t *testing.T) {
+	m := App{statusBar: NewStatusBarModel()}
+	if cmd := m.startStatusBarTick(); cmd != nil {
+		t.Error("default segments should not need a tick")
+	}
+	m.statusBar.SetSegments([]string{segmentClock}, defaultSegmentPriority)
+	if cmd := m.startStatusBarTick(); cmd == nil || !m.statusTicking {
+		t.Fatal("clock segment should start the tick loop")
+	}
+	m.statusBar.SetSegments(defaultStatusSegments, defaultSegmentPriority)
+	model, cmd := m.handleStatusBarTick(time.Now())
+	if model.(App).statusTicking || cmd != nil {
+		t.Error("tick loop should stop once no segment needs it")
+	}
+}
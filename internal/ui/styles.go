@@ -11,97 +11,257 @@ import (
 
 // Panel border colors
 var (
-	focusedBorderColor   = lipgloss.Color("62")  // bright purple/blue
-	unfocusedBorderColor = lipgloss.Color("240") // dim gray
-	insertModeBorderColor = lipgloss.Color("42") // green
+	focusedBorderColor    lipgloss.Color
+	unfocusedBorderColor  lipgloss.Color
+	insertModeBorderColor lipgloss.Color
 )
 
 // Diff colors
 var (
-	diffAddedStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("42"))
-	diffRemovedStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("196"))
-	diffHunkHeaderStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("33")).Bold(true)
-	diffFileHeaderStyle = lipgloss.NewStyle().
-		Foreground(lipgloss.Color("220")).
-		Bold(true)
+	diffAddedStyle      lipgloss.Style
+	diffRemovedStyle    lipgloss.Style
+	diffHunkHeaderStyle lipgloss.Style
+	diffFileHeaderStyle lipgloss.Style
 )
 
 // Status bar
 var (
-	statusBarStyle = lipgloss.NewStyle().
-		Background(lipgloss.Color("236")).
-		Foreground(lipgloss.Color("252"))
-	statusBarAccentStyle = lipgloss.NewStyle().
-		Background(lipgloss.Color("236")).
-		Foreground(lipgloss.Color("62")).
-		Bold(true)
+	statusBarStyle       lipgloss.Style
+	statusBarAccentStyle lipgloss.Style
 )
 
 // Chat styles
 var (
-	chatUserStyle = lipgloss.NewStyle().
-		Foreground(lipgloss.Color("33")).
-		Bold(true)
-	chatAssistantStyle = lipgloss.NewStyle().
-		Foreground(lipgloss.Color("42")).
-		Bold(true)
+	chatUserStyle      lipgloss.Style
+	chatAssistantStyle lipgloss.Style
 )
 
-// Selected hunk highlight
-var diffSelectedBg = lipgloss.Color("236")
-
-// Focused hunk indicator
-var diffFocusedHunkStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("99")).Bold(true)
-
-// Focused hunk gutter marker (▎ in accent color)
-var diffFocusGutterStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("62"))
-
-// Line cursor: gutter arrow and subtle row highlight
-var (
-	diffCursorGutterStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("214")).Bold(true)
-	diffCursorBg          = lipgloss.Color("237")
-)
-
-// Multi-line selection (visual mode) highlight
+// Hunk focus, line cursor, and selection highlighting
 var (
-	diffSelectionGutterStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("75")).Bold(true)
-	diffSelectionBg          = lipgloss.Color("24")
+	diffSelectedBg           lipgloss.Color // selected hunk background
+	diffFocusedHunkStyle     lipgloss.Style // focused hunk indicator
+	diffFocusGutterStyle     lipgloss.Style // focused hunk gutter marker (▎ in accent color)
+	diffCursorGutterStyle    lipgloss.Style // line cursor gutter arrow
+	diffCursorBg             lipgloss.Color // line cursor row highlight
+	diffSelectionGutterStyle lipgloss.Style // multi-line selection (visual mode)
+	diffSelectionBg          lipgloss.Color
+	diffCoveredStyle         lipgloss.Style // coverage gutter mark on a line tests ran
+	diffUncoveredStyle       lipgloss.Style // coverage gutter mark on a line no test ran
 )
 
 // Search match highlight backgrounds
 var (
-	diffSearchMatchBg        = lipgloss.Color("58")  // dark olive for all matches
-	diffSearchCurrentMatchBg = lipgloss.Color("178") // bright gold for current match
-	diffSearchInfoStyle      = lipgloss.NewStyle().Foreground(lipgloss.Color("214"))
+	diffSearchMatchBg        lipgloss.Color // all matches
+	diffSearchCurrentMatchBg lipgloss.Color // current match
+	diffSearchInfoStyle      lipgloss.Style
 )
 
 // Inline comment box border colors (normal and highlighted)
 var (
-	commentBoxAIBorder      = lipgloss.Color("75")  // blue
-	commentBoxGitHubBorder  = lipgloss.Color("220") // yellow
-	commentBoxPendingBorder = lipgloss.Color("214") // orange
-
-	commentBoxAIBorderHi      = lipgloss.Color("117") // bright blue
-	commentBoxGitHubBorderHi  = lipgloss.Color("228") // bright yellow
-	commentBoxPendingBorderHi = lipgloss.Color("222") // bright orange
+	commentBoxAIBorder       lipgloss.Color
+	commentBoxGitHubBorder   lipgloss.Color
+	commentBoxPendingBorder  lipgloss.Color
+	commentBoxOutdatedBorder lipgloss.Color
+
+	commentBoxAIBorderHi       lipgloss.Color
+	commentBoxGitHubBorderHi   lipgloss.Color
+	commentBoxPendingBorderHi  lipgloss.Color
+	commentBoxOutdatedBorderHi lipgloss.Color
 )
 
 // Inline comment box header styles (used inside the box)
 var (
-	commentBoxHeaderStyle = lipgloss.NewStyle().Bold(true)
-	commentBoxMetaStyle   = lipgloss.NewStyle().Foreground(lipgloss.Color("244"))
-	commentBoxTrimStyle   = lipgloss.NewStyle().Foreground(lipgloss.Color("244")).Italic(true)
-	commentBoxReplyStyle  = lipgloss.NewStyle().Foreground(lipgloss.Color("244"))
-	commentBoxHintStyle   = lipgloss.NewStyle().Foreground(lipgloss.Color("244"))
-	commentBoxHintHiStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("252")).Bold(true)
+	commentBoxHeaderStyle   lipgloss.Style
+	commentBoxMetaStyle     lipgloss.Style
+	commentBoxTrimStyle     lipgloss.Style
+	commentBoxReplyStyle    lipgloss.Style
+	commentBoxHintStyle     lipgloss.Style
+	commentBoxHintHiStyle   lipgloss.Style
+	commentBoxOutdatedStyle lipgloss.Style
 )
 
 // PR list styles
 var (
-	prTitleStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("252"))
-	prMetaStyle  = lipgloss.NewStyle().Foreground(lipgloss.Color("244"))
+	prTitleStyle lipgloss.Style
+	prMetaStyle  lipgloss.Style
 )
 
+// Diff file header badges
+var (
+	missingTestBadgeStyle lipgloss.Style // changed source files with no paired test change
+	outOfScopeBadgeStyle  lipgloss.Style // files excluded from review submission
+	guidedStepStyle       lipgloss.Style // position in the guided review order
+	primaryHunkBadgeStyle lipgloss.Style // selected hunk order and primary focus
+)
+
+// buildStyles derives the package styles from the active theme. It runs at
+// init and again whenever SetTheme changes the palette.
+func buildStyles() {
+	focusedBorderColor = theme.Accent
+	unfocusedBorderColor = theme.Subtle
+	insertModeBorderColor = theme.Success
+
+	diffAddedStyle = lipgloss.NewStyle().Foreground(theme.Success)
+	diffRemovedStyle = lipgloss.NewStyle().Foreground(theme.Error)
+	diffHunkHeaderStyle = lipgloss.NewStyle().Foreground(theme.Info).Bold(true)
+	diffFileHeaderStyle = lipgloss.NewStyle().
+		Foreground(theme.Highlight).
+		Bold(true)
+
+	statusBarStyle = lipgloss.NewStyle().
+		Background(theme.Surface).
+		Foreground(theme.Text)
+	statusBarAccentStyle = lipgloss.NewStyle().
+		Background(theme.Surface).
+		Foreground(theme.Accent).
+		Bold(true)
+
+	chatUserStyle = lipgloss.NewStyle().
+		Foreground(theme.Info).
+		Bold(true)
+	chatAssistantStyle = lipgloss.NewStyle().
+		Foreground(theme.Success).
+		Bold(true)
+
+	diffSelectedBg = theme.Surface
+	diffFocusedHunkStyle = lipgloss.NewStyle().Foreground(theme.Focus).Bold(true)
+	diffFocusGutterStyle = lipgloss.NewStyle().Foreground(theme.Accent)
+	diffCursorGutterStyle = lipgloss.NewStyle().Foreground(theme.Warning).Bold(true)
+	diffCursorBg = theme.CursorBg
+	diffSelectionGutterStyle = lipgloss.NewStyle().Foreground(theme.AI).Bold(true)
+	diffSelectionBg = theme.SelectionBg
+	diffCoveredStyle = lipgloss.NewStyle().Foreground(theme.Success)
+	diffUncoveredStyle = lipgloss.NewStyle().Foreground(theme.Error).Bold(true)
+
+	diffSearchMatchBg = theme.SearchMatchBg
+	diffSearchCurrentMatchBg = theme.SearchCurrentBg
+	diffSearchInfoStyle = lipgloss.NewStyle().Foreground(theme.Warning)
+
+	commentBoxAIBorder = theme.AI
+	commentBoxGitHubBorder = theme.Highlight
+	commentBoxPendingBorder = theme.Warning
+	commentBoxOutdatedBorder = theme.Subtle
+	commentBoxAIBorderHi = theme.AIHi
+	commentBoxGitHubBorderHi = theme.HighlightHi
+	commentBoxPendingBorderHi = theme.WarningHi
+	commentBoxOutdatedBorderHi = theme.MutedHi
+
+	commentBoxHeaderStyle = lipgloss.NewStyle().Bold(true)
+	commentBoxMetaStyle = lipgloss.NewStyle().Foreground(theme.Muted)
+	commentBoxTrimStyle = lipgloss.NewStyle().Foreground(theme.Muted).Italic(true)
+	commentBoxReplyStyle = lipgloss.NewStyle().Foreground(theme.Muted)
+	commentBoxHintStyle = lipgloss.NewStyle().Foreground(theme.Muted)
+	commentBoxHintHiStyle = lipgloss.NewStyle().Foreground(theme.Text).Bold(true)
+	commentBoxOutdatedStyle = lipgloss.NewStyle().Foreground(theme.Muted).Bold(true)
+
+	prTitleStyle = lipgloss.NewStyle().Foreground(theme.Text)
+	prMetaStyle = lipgloss.NewStyle().Foreground(theme.Muted)
+
+	missingTestBadgeStyle = lipgloss.NewStyle().Foreground(theme.Warning)
+	outOfScopeBadgeStyle = lipgloss.NewStyle().Foreground(theme.Muted).Italic(true)
+	guidedStepStyle = lipgloss.NewStyle().Foreground(theme.Accent).Bold(true)
+	primaryHunkBadgeStyle = lipgloss.NewStyle().Foreground(theme.Highlight).Bold(true)
+
+	reviewApproveStyle = lipgloss.NewStyle().
+		Foreground(theme.Inverse).
+		Background(theme.Success).
+		Bold(true).
+		Padding(0, 1)
+	reviewCommentStyle = lipgloss.NewStyle().
+		Foreground(theme.OnAccent).
+		Background(theme.Accent).
+		Bold(true).
+		Padding(0, 1)
+	reviewRequestChangesStyle = lipgloss.NewStyle().
+		Foreground(theme.OnAccent).
+		Background(theme.Error).
+		Bold(true).
+		Padding(0, 1)
+	reviewOptionDimStyle = lipgloss.NewStyle().
+		Foreground(theme.Muted).
+		Padding(0, 1)
+	reviewSubmitFocusedStyle = lipgloss.NewStyle().
+		Bold(true).
+		Padding(0, 2)
+	reviewSubmitDimStyle = lipgloss.NewStyle().
+		Foreground(theme.Muted).
+		Padding(0, 2)
+	reviewLabelStyle = lipgloss.NewStyle().
+		Foreground(theme.Muted).
+		Bold(true)
+
+	cmdPaletteTitleStyle = lipgloss.NewStyle().
+		Bold(true).
+		Foreground(theme.OnAccent).
+		Background(theme.Accent)
+	cmdPaletteDividerStyle = lipgloss.NewStyle().
+		Foreground(theme.Subtle)
+	cmdPaletteKeyStyle = lipgloss.NewStyle().
+		Foreground(theme.Warning).
+		Bold(true)
+	cmdPaletteDescStyle = lipgloss.NewStyle().
+		Foreground(theme.Text)
+	cmdPaletteSelectedStyle = lipgloss.NewStyle().
+		Foreground(theme.Success).
+		Bold(true)
+	cmdPaletteMarkerStyle = lipgloss.NewStyle().
+		Foreground(theme.Success)
+	cmdPaletteAliasStyle = lipgloss.NewStyle().
+		Foreground(theme.Muted).
+		Italic(true)
+	cmdPaletteHintStyle = lipgloss.NewStyle().
+		Foreground(theme.Muted)
+	cmdPaletteErrorStyle = lipgloss.NewStyle().
+		Foreground(theme.Error).
+		Italic(true)
+	cmdPalettePromptStyle = lipgloss.NewStyle().
+		Bold(true).
+		Foreground(theme.Accent)
+	cmdPaletteInputTextStyle = lipgloss.NewStyle().
+		Foreground(theme.Text)
+
+	scrollbarTrackStyle = lipgloss.NewStyle().Foreground(theme.Faint)
+	scrollbarThumbStyle = lipgloss.NewStyle().Foreground(theme.MutedHi)
+	minimapAddStyle = lipgloss.NewStyle().Foreground(theme.Success)
+	minimapRemoveStyle = lipgloss.NewStyle().Foreground(theme.Error)
+	sinceBannerStyle = lipgloss.NewStyle().Bold(true).Foreground(theme.Warning)
+
+	sectionHeaderStyle = lipgloss.NewStyle().Bold(true).Foreground(theme.Info)
+	contentAuthorStyle = lipgloss.NewStyle().Bold(true).Foreground(theme.Highlight)
+	dimStyle = lipgloss.NewStyle().Foreground(theme.Muted)
+	boldStyle = lipgloss.NewStyle().Bold(true)
+	errTextStyle = lipgloss.NewStyle().Foreground(theme.Error)
+
+	severityStyles = map[string]lipgloss.Style{
+		"critical":   lipgloss.NewStyle().Bold(true).Foreground(theme.Error),
+		"warning":    lipgloss.NewStyle().Foreground(theme.Warning),
+		"suggestion": lipgloss.NewStyle().Foreground(theme.Info),
+		"praise":     lipgloss.NewStyle().Foreground(theme.Success),
+	}
+	defaultSeverityStyle = lipgloss.NewStyle().Foreground(theme.Muted)
+	dimItalicStyle = lipgloss.NewStyle().Foreground(theme.Muted).Italic(true)
+	scrollIndicatorStyle = lipgloss.NewStyle().Foreground(theme.Muted)
+
+	commentOverlayTitleStyle = lipgloss.NewStyle().
+		Bold(true).
+		Foreground(theme.OnAccent).
+		Background(theme.Accent).
+		Padding(0, 1)
+	commentOverlaySepStyle = lipgloss.NewStyle().
+		Foreground(theme.Subtle)
+	commentOverlayActiveToggle = lipgloss.NewStyle().
+		Foreground(theme.Success).
+		Bold(true)
+	commentOverlayInactiveToggle = lipgloss.NewStyle().
+		Foreground(theme.Muted)
+	commentOverlayHintStyle = lipgloss.NewStyle().
+		Foreground(theme.Muted).
+		Italic(true)
+
+	buildSettingsStyles()
+	buildHelpStyles()
+}
+
 // Panel style builders
 func panelStyle(focused bool, insertMode bool, width, height int) lipgloss.Style {
 	borderColor := unfocusedBorderColor
@@ -121,39 +281,39 @@ func panelStyle(focused bool, insertMode bool, width, height int) lipgloss.Style
 
 func panelHeaderStyle(focused bool) lipgloss.Style {
 	if focused {
-		return lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("252"))
+		return lipgloss.NewStyle().Bold(true).Foreground(theme.Text)
 	}
-	return lipgloss.NewStyle().Foreground(lipgloss.Color("244"))
+	return lipgloss.NewStyle().Foreground(theme.Muted)
 }
 
 // Tab styles
 func activeTabStyle() lipgloss.Style {
 	return lipgloss.NewStyle().
 		Bold(true).
-		Foreground(lipgloss.Color("252")).
-		Background(lipgloss.Color("62")).
+		Foreground(theme.OnAccent).
+		Background(theme.Accent).
 		Padding(0, 1)
 }
 
 func inactiveTabStyle() lipgloss.Style {
 	return lipgloss.NewStyle().
-		Foreground(lipgloss.Color("244")).
+		Foreground(theme.Muted).
 		Padding(0, 1)
 }
 
 // Mode badge styles
 func normalModeBadge() string {
 	return lipgloss.NewStyle().
-		Foreground(lipgloss.Color("244")).
-		Background(lipgloss.Color("238")).
+		Foreground(theme.Muted).
+		Background(theme.Faint).
 		Padding(0, 1).
 		Render("NORMAL")
 }
 
 func insertModeBadge() string {
 	return lipgloss.NewStyle().
-		Foreground(lipgloss.Color("0")).
-		Background(lipgloss.Color("42")).
+		Foreground(theme.Inverse).
+		Background(theme.Success).
 		Padding(0, 1).
 		Render("INSERT")
 }
@@ -162,21 +322,21 @@ func insertModeBadge() string {
 func newLoadingSpinner() spinner.Model {
 	s := spinner.New()
 	s.Spinner = spinner.Dot
-	s.Style = lipgloss.NewStyle().Foreground(lipgloss.Color("62"))
+	s.Style = lipgloss.NewStyle().Foreground(theme.Accent)
 	return s
 }
 
 // renderEmptyState renders a consistent empty state message with optional action hint.
 func renderEmptyState(message, hint string) string {
 	msg := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("244")).
+		Foreground(theme.Muted).
 		Padding(1, 2).
 		Render("— " + message)
 	if hint == "" {
 		return msg
 	}
 	h := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("240")).
+		Foreground(theme.Subtle).
 		Italic(true).
 		Padding(0, 2).
 		Render(hint)
@@ -186,7 +346,7 @@ func renderEmptyState(message, hint string) string {
 // renderErrorWithHint renders a consistent error message with retry hint.
 func renderErrorWithHint(errMsg, hint string) string {
 	msg := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("196")).
+		Foreground(theme.Error).
 		Bold(true).
 		Padding(1, 2).
 		Render(errMsg)
@@ -194,7 +354,7 @@ func renderErrorWithHint(errMsg, hint string) string {
 		return msg
 	}
 	h := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("244")).
+		Foreground(theme.Muted).
 		Padding(0, 2).
 		Render(hint)
 	return lipgloss.JoinVertical(lipgloss.Left, msg, h)
@@ -224,83 +384,51 @@ func formatUserError(err string) string {
 
 // Review tab styles
 var (
-	reviewApproveStyle = lipgloss.NewStyle().
-		Foreground(lipgloss.Color("0")).
-		Background(lipgloss.Color("42")).
-		Bold(true).
-		Padding(0, 1)
-	reviewCommentStyle = lipgloss.NewStyle().
-		Foreground(lipgloss.Color("252")).
-		Background(lipgloss.Color("62")).
-		Bold(true).
-		Padding(0, 1)
-	reviewRequestChangesStyle = lipgloss.NewStyle().
-		Foreground(lipgloss.Color("255")).
-		Background(lipgloss.Color("196")).
-		Bold(true).
-		Padding(0, 1)
-	reviewOptionDimStyle = lipgloss.NewStyle().
-		Foreground(lipgloss.Color("244")).
-		Padding(0, 1)
-	reviewSubmitFocusedStyle = lipgloss.NewStyle().
-		Bold(true).
-		Padding(0, 2)
-	reviewSubmitDimStyle = lipgloss.NewStyle().
-		Foreground(lipgloss.Color("244")).
-		Padding(0, 2)
-	reviewLabelStyle = lipgloss.NewStyle().
-		Foreground(lipgloss.Color("244")).
-		Bold(true)
+	reviewApproveStyle        lipgloss.Style
+	reviewCommentStyle        lipgloss.Style
+	reviewRequestChangesStyle lipgloss.Style
+	reviewOptionDimStyle      lipgloss.Style
+	reviewSubmitFocusedStyle  lipgloss.Style
+	reviewSubmitDimStyle      lipgloss.Style
+	reviewLabelStyle          lipgloss.Style
 )
 
 // Command palette styles
 var (
-	cmdPaletteTitleStyle = lipgloss.NewStyle().
-		Bold(true).
-		Foreground(lipgloss.Color("252")).
-		Background(lipgloss.Color("62"))
-	cmdPaletteDividerStyle = lipgloss.NewStyle().
-		Foreground(lipgloss.Color("240"))
-	cmdPaletteKeyStyle = lipgloss.NewStyle().
-		Foreground(lipgloss.Color("214")).
-		Bold(true)
-	cmdPaletteDescStyle = lipgloss.NewStyle().
-		Foreground(lipgloss.Color("252"))
-	cmdPaletteSelectedStyle = lipgloss.NewStyle().
-		Foreground(lipgloss.Color("42")).
-		Bold(true)
-	cmdPaletteMarkerStyle = lipgloss.NewStyle().
-		Foreground(lipgloss.Color("42"))
-	cmdPaletteAliasStyle = lipgloss.NewStyle().
-		Foreground(lipgloss.Color("244")).
-		Italic(true)
-	cmdPaletteHintStyle = lipgloss.NewStyle().
-		Foreground(lipgloss.Color("244"))
-	cmdPaletteErrorStyle = lipgloss.NewStyle().
-		Foreground(lipgloss.Color("196")).
-		Italic(true)
-	cmdPalettePromptStyle = lipgloss.NewStyle().
-		Bold(true).
-		Foreground(lipgloss.Color("62"))
-	cmdPaletteInputTextStyle = lipgloss.NewStyle().
-		Foreground(lipgloss.Color("252"))
+	cmdPaletteTitleStyle     lipgloss.Style
+	cmdPaletteDividerStyle   lipgloss.Style
+	cmdPaletteKeyStyle       lipgloss.Style
+	cmdPaletteDescStyle      lipgloss.Style
+	cmdPaletteSelectedStyle  lipgloss.Style
+	cmdPaletteMarkerStyle    lipgloss.Style
+	cmdPaletteAliasStyle     lipgloss.Style
+	cmdPaletteHintStyle      lipgloss.Style
+	cmdPaletteErrorStyle     lipgloss.Style
+	cmdPalettePromptStyle    lipgloss.Style
+	cmdPaletteInputTextStyle lipgloss.Style
 )
 
-// Vertical scrollbar styles (1-char wide column in diff viewer)
+// Vertical scrollbar styles (1-char wide column in diff viewer) and the
+// density columns of the minimap that can replace it
 var (
-	scrollbarTrackStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("238"))
-	scrollbarThumbStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("248"))
+	scrollbarTrackStyle lipgloss.Style
+	scrollbarThumbStyle lipgloss.Style
+	minimapAddStyle     lipgloss.Style
+	minimapRemoveStyle  lipgloss.Style
 )
 
+// Banner above a diff showing only the changes since the last review
+var sinceBannerStyle lipgloss.Style
+
 // scrollbarCommentStyle returns the style for a comment marker at the given kind.
 func scrollbarCommentStyle(kind commentKind) lipgloss.Style {
 	switch kind {
 	case commentAI:
-		return lipgloss.NewStyle().Foreground(lipgloss.Color("75")) // blue (matches AI prefix)
+		return lipgloss.NewStyle().Foreground(theme.AI) // matches AI prefix
 	case commentGitHub:
-		return lipgloss.NewStyle().Foreground(lipgloss.Color("220")) // yellow (matches GH author)
+		return lipgloss.NewStyle().Foreground(theme.Highlight) // matches GH author
 	case commentPending:
-		return lipgloss.NewStyle().Foreground(lipgloss.Color("214")) // orange (matches pending prefix)
+		return lipgloss.NewStyle().Foreground(theme.Warning) // matches pending prefix
 	default:
 		return scrollbarTrackStyle
 	}
@@ -308,30 +436,25 @@ func scrollbarCommentStyle(kind commentKind) lipgloss.Style {
 
 // Common content styles used across multiple tab renderers
 var (
-	sectionHeaderStyle = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("33"))
-	contentAuthorStyle = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("220"))
-	dimStyle           = lipgloss.NewStyle().Foreground(lipgloss.Color("244"))
-	boldStyle          = lipgloss.NewStyle().Bold(true)
-	errTextStyle       = lipgloss.NewStyle().Foreground(lipgloss.Color("196"))
+	sectionHeaderStyle lipgloss.Style
+	contentAuthorStyle lipgloss.Style
+	dimStyle           lipgloss.Style
+	boldStyle          lipgloss.Style
+	errTextStyle       lipgloss.Style
 )
 
 // Pre-computed severity styles for analysis file review comments (avoids
 // allocating a new lipgloss.Style on every call inside the review loop).
-var severityStyles = map[string]lipgloss.Style{
-	"critical":   lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("196")),
-	"warning":    lipgloss.NewStyle().Foreground(lipgloss.Color("214")),
-	"suggestion": lipgloss.NewStyle().Foreground(lipgloss.Color("33")),
-	"praise":     lipgloss.NewStyle().Foreground(lipgloss.Color("42")),
-}
+var severityStyles map[string]lipgloss.Style
 
 // defaultSeverityStyle is the fallback for unknown severity levels.
-var defaultSeverityStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("244"))
+var defaultSeverityStyle lipgloss.Style
 
 // Dim italic style for metadata, "no newline" markers, unavailable content, etc.
-var dimItalicStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("244")).Italic(true)
+var dimItalicStyle lipgloss.Style
 
 // Scroll indicator style
-var scrollIndicatorStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("244"))
+var scrollIndicatorStyle lipgloss.Style
 
 // scrollIndicator returns a scroll position line for a viewport.
 // Returns "" if all content fits within the viewport (no scrolling needed).
@@ -354,21 +477,25 @@ func scrollIndicator(vp viewport.Model, width int) string {
 	)
 }
 
+// placeOverlay centers a rendered overlay box on a termWidth×termHeight
+// screen. Boxes are sized from the terminal but keep a minimum size, so on
+// very small terminals the box is clipped rather than pushing the screen
+// taller or wider than the terminal.
+func placeOverlay(termWidth, termHeight int, box string) string {
+	box = lipgloss.NewStyle().MaxWidth(termWidth).MaxHeight(termHeight).Render(box)
+	return lipgloss.Place(termWidth, termHeight, lipgloss.Center, lipgloss.Center, box)
+}
+
+// fitWidth truncates each line of s to width cells.
+func fitWidth(s string, width int) string {
+	return lipgloss.NewStyle().MaxWidth(max(width, 0)).Render(s)
+}
+
 // Comment overlay styles
 var (
-	commentOverlayTitleStyle = lipgloss.NewStyle().
-					Bold(true).
-					Foreground(lipgloss.Color("252")).
-					Background(lipgloss.Color("62")).
-					Padding(0, 1)
-	commentOverlaySepStyle = lipgloss.NewStyle().
-				Foreground(lipgloss.Color("240"))
-	commentOverlayActiveToggle = lipgloss.NewStyle().
-					Foreground(lipgloss.Color("42")).
-					Bold(true)
-	commentOverlayInactiveToggle = lipgloss.NewStyle().
-					Foreground(lipgloss.Color("244"))
-	commentOverlayHintStyle = lipgloss.NewStyle().
-				Foreground(lipgloss.Color("244")).
-				Italic(true)
+	commentOverlayTitleStyle     lipgloss.Style
+	commentOverlaySepStyle       lipgloss.Style
+	commentOverlayActiveToggle   lipgloss.Style
+	commentOverlayInactiveToggle lipgloss.Style
+	commentOverlayHintStyle      lipgloss.Style
 )
@@ -0,0 +1,111 @@
+package ui
+
+import (
+	"context"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// suggestion is a ```suggestion block parsed out of a review comment.
+type suggestion struct {
+	prose string   // the comment body with the block removed
+	lines []string // replacement lines; empty means delete the target lines
+}
+
+// parseSuggestion extracts the first ```suggestion block from a comment body.
+func parseSuggestion(body string) (suggestion, bool) {
+	lines := strings.Split(strings.ReplaceAll(body, "\r\n", "\n"), "\n")
+	start := -1
+	for i, line := range lines {
+		if strings.TrimSpace(line) == "```suggestion" {
+			start = i
+			break
+		}
+	}
+	if start < 0 {
+		return suggestion{}, false
+	}
+	end := -1
+	for i := start + 1; i < len(lines); i++ {
+		if strings.TrimSpace(lines[i]) == "```" {
+			end = i
+			break
+		}
+	}
+	if end < 0 {
+		return suggestion{}, false
+	}
+
+	prose := append(append([]string{}, lines[:start]...), lines[end+1:]...)
+	return suggestion{
+		prose: strings.TrimSpace(strings.Join(prose, "\n")),
+		lines: append([]string{}, lines[start+1:end]...),
+	}, true
+}
+
+// renderSuggestionDiff renders a suggestion as a mini-diff of the original
+// target lines against the suggested replacement, truncated to width.
+func renderSuggestionDiff(original, suggested []string, width int) []string {
+	out := make([]string, 0, len(original)+len(suggested))
+	for _, line := range original {
+		out = append(out, diffRemovedStyle.Render(ansi.Truncate("-"+line, width, "…")))
+	}
+	for _, line := range suggested {
+		out = append(out, diffAddedStyle.Render(ansi.Truncate("+"+line, width, "…")))
+	}
+	return out
+}
+
+// newSideLines returns the new-side content of lines start..end of filename
+// as shown in the diff. Lines outside the diff's hunks are skipped.
+func (m *DiffViewerModel) newSideLines(filename string, start, end int) []string {
+	var out []string
+	for _, h := range m.hunks {
+		if h.Filename != filename {
+			continue
+		}
+		newLine := 0
+		for _, line := range h.Lines {
+			if strings.HasPrefix(line, "@@") {
+				newLine = parseHunkNewStart(line) - 1
+				continue
+			}
+			if strings.HasPrefix(line, "-") || strings.HasPrefix(line, `\`) {
+				continue
+			}
+			newLine++
+			if newLine >= start && newLine <= end && len(line) > 0 {
+				out = append(out, line[1:])
+			}
+		}
+	}
+	return out
+}
+
+// suggestionTarget returns the line range a comment's suggestion replaces.
+func suggestionTarget(startLine, line int) (int, int) {
+	if startLine > 0 && startLine < line {
+		return startLine, line
+	}
+	return line, line
+}
+
+// canApplySuggestions reports whether suggestions on the current PR can be
+// committed: only on the user's own PRs, once the head branch is known.
+func (m App) canApplySuggestions() bool {
+	if m.session == nil || m.ghClient == nil || m.session.HeadBranch == "" {
+		return false
+	}
+	return strings.EqualFold(m.session.Author, m.ghClient.GetUsername())
+}
+
+// applySuggestionCmd commits a suggestion to the PR's head branch.
+func applySuggestionCmd(client GitHubService, headRepo, branch string, msg ApplySuggestionMsg) tea.Cmd {
+	return func() tea.Msg {
+		err := client.ApplySuggestion(context.Background(), headRepo, branch, msg.Path,
+			msg.StartLine, msg.Line, msg.Original, msg.Lines, "Apply suggestion from code review")
+		return SuggestionAppliedMsg{Path: msg.Path, Err: err}
+	}
+}
@@ -0,0 +1,136 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/shhac/prtea/internal/github"
+)
+
+func TestParseSuggestion(t *testing.T) {
+	s, ok := parseSuggestion("Use a constant here.\n```suggestion\nconst x = 1\n```\nThanks!")
+	if !ok {
+		t.Fatal("expected a suggestion")
+	}
+	if len(s.lines) != 1 || s.lines[0] != "const x = 1" {
+		t.Errorf("lines = %q", s.lines)
+	}
+	if s.prose != "Use a constant here.\nThanks!" {
+		t.Errorf("prose = %q", s.prose)
+	}
+
+	if s, ok := parseSuggestion("```suggestion\r\n```"); !ok || len(s.lines) != 0 {
+		t.Errorf("empty suggestion = %+v, %v; want deletion", s, ok)
+	}
+	if _, ok := parseSuggestion("```go\nx := 1\n```"); ok {
+		t.Error("plain code block is not a suggestion")
+	}
+	if _, ok := parseSuggestion("```suggestion\nunterminated"); ok {
+		t.Error("unterminated block is not a suggestion")
+	}
+}
+
+func TestRenderSuggestionDiff(t *testing.T) {
+	got := renderSuggestionDiff([]string{"old()"}, []string{"new()", "more()"}, 40)
+	if len(got) != 3 {
+		t.Fatalf("got %d lines, want 3", len(got))
+	}
+	want := []string{"-old()", "+new()", "+more()"}
+	for i, line := range got {
+		if ansi.Strip(line) != want[i] {
+			t.Errorf("line %d = %q, want %q", i, ansi.Strip(line), want[i])
+		}
+	}
+}
+
+func TestNewSideLines(t *testing.T) {
+	m := newTestDiffViewer(80, 24)
+	m.files = []github.PRFile{
+		{Filename: "a.go", Status: "modified", Patch: "@@ -10,3 +10,3 @@\n one\n-two\n+TWO\n three"},
+	}
+	m.parseAllHunks()
+
+	got := m.newSideLines("a.go", 11, 12)
+	if strings.Join(got, ",") != "TWO,three" {
+		t.Errorf("newSideLines = %q", got)
+	}
+}
+
+func TestGHCommentThread_RendersSuggestionAsDiff(t *testing.T) {
+	m := newTestDiffViewer(80, 24)
+	m.files = []github.PRFile{
+		{Filename: "a.go", Status: "modified", Patch: "@@ -1,2 +1,3 @@\n ctx\n+added()\n ctx2"},
+	}
+	m.parseAllHunks()
+
+	thread := ghCommentThread{Root: github.InlineComment{
+		ID: 1, Path: "a.go", Line: 2,
+		Body: "Rename it.\n```suggestion\nrenamed()\n```",
+	}}
+	out := ansi.Strip(strings.Join(m.renderGHCommentThread(thread, false, ""), "\n"))
+	for _, want := range []string{"suggested change", "-added()", "+renamed()", "Rename it."} {
+		if !strings.Contains(out, want) {
+			t.Errorf("thread box missing %q:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "```") {
+		t.Errorf("raw fence should not be shown:\n%s", out)
+	}
+}
+
+func suggestionOverlay(canApply bool) CommentOverlayModel {
+	m := NewCommentOverlayModel()
+	m.SetSize(100, 40)
+	m.Show(ShowCommentOverlayMsg{
+		Path: "a.go",
+		Line: 3,
+		GHThreads: []ghCommentThread{{Root: github.InlineComment{
+			ID: 7, Path: "a.go", StartLine: 2, Line: 3,
+			Body: "```suggestion\nx\n```",
+		}}},
+		SuggestionBase:      map[int64][]string{7: {"a", "b"}},
+		CanApplySuggestions: canApply,
+	})
+	return m
+}
+
+func TestCommentOverlay_ApplySuggestion(t *testing.T) {
+	m := suggestionOverlay(true)
+	if !strings.Contains(m.View(), "a: apply") {
+		t.Error("footer should offer to apply the suggestion")
+	}
+
+	m, cmd := m.Update(keyMsg("a"))
+	if m.IsVisible() {
+		t.Error("overlay should close after applying")
+	}
+	if cmd == nil {
+		t.Fatal("expected a command")
+	}
+	var apply *ApplySuggestionMsg
+	for _, c := range cmd().(tea.BatchMsg) {
+		if msg, ok := c().(ApplySuggestionMsg); ok {
+			apply = &msg
+		}
+	}
+	if apply == nil {
+		t.Fatal("expected ApplySuggestionMsg")
+	}
+	if apply.Path != "a.go" || apply.StartLine != 2 || apply.Line != 3 || strings.Join(apply.Lines, "") != "x" ||
+		strings.Join(apply.Original, ",") != "a,b" {
+		t.Errorf("apply = %+v", *apply)
+	}
+}
+
+func TestCommentOverlay_ApplySuggestionNotOwnPR(t *testing.T) {
+	m := suggestionOverlay(false)
+	if strings.Contains(m.View(), "a: apply") {
+		t.Error("apply action should be hidden on other people's PRs")
+	}
+	m, cmd := m.Update(keyMsg("a"))
+	if !m.IsVisible() || cmd != nil {
+		t.Error("a should do nothing when suggestions cannot be applied")
+	}
+}
@@ -0,0 +1,170 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// taskRe matches a GitHub task list item: "- [ ] text" or "* [x] text".
+var taskRe = regexp.MustCompile(`^(\s*(?:[-*+]|\d+[.)])\s+\[)([ xX])(\]\s+)(.*)$`)
+
+// taskItem is one checkbox of a markdown task list.
+type taskItem struct {
+	line    int // 0-based line of the body
+	checked bool
+	text    string
+}
+
+// parseTasks returns the task list items of a markdown body, in order,
+// skipping fenced code blocks. Line endings may be "\n" or "\r\n".
+func parseTasks(body string) []taskItem {
+	var tasks []taskItem
+	fenced := false
+	for i, line := range strings.Split(body, "\n") {
+		line = strings.TrimSuffix(line, "\r")
+		if trimmed := strings.TrimSpace(line); strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+			fenced = !fenced
+			continue
+		}
+		if fenced {
+			continue
+		}
+		if m := taskRe.FindStringSubmatch(line); m != nil {
+			tasks = append(tasks, taskItem{line: i, checked: m[2] != " ", text: m[4]})
+		}
+	}
+	return tasks
+}
+
+// setTask checks or unchecks the idx-th task of body. It fails when the
+// body no longer has that task with the expected text, so a toggle never
+// lands on the wrong line after someone else edited the description.
+func setTask(body string, idx int, text string, checked bool) (string, error) {
+	tasks := parseTasks(body)
+	if idx < 0 || idx >= len(tasks) || tasks[idx].text != text {
+		return "", fmt.Errorf("the task list changed since it was loaded; refresh and try again")
+	}
+	mark := " "
+	if checked {
+		mark = "x"
+	}
+	lines := strings.Split(body, "\n")
+	lines[tasks[idx].line] = taskRe.ReplaceAllString(lines[tasks[idx].line], "${1}"+mark+"${3}${4}")
+	return strings.Join(lines, "\n"), nil
+}
+
+// renderTaskGlyphs replaces task list markers with checkbox glyphs for the
+// markdown renderer, pointing at the task at cursor (-1 for none).
+func renderTaskGlyphs(body string, cursor int) string {
+	tasks := parseTasks(body)
+	if len(tasks) == 0 {
+		return body
+	}
+	lines := strings.Split(body, "\n")
+	for i, task := range tasks {
+		glyph := "☐"
+		if task.checked {
+			glyph = "☑"
+		}
+		if i == cursor {
+			glyph = "▸ " + glyph
+		}
+		m := taskRe.FindStringSubmatch(strings.TrimSuffix(lines[task.line], "\r"))
+		prefix := strings.TrimSuffix(m[1], "[")
+		lines[task.line] = prefix + glyph + " " + m[4]
+	}
+	return strings.Join(lines, "\n")
+}
+
+// taskProgress summarizes a body's task list, e.g. "2/5 tasks done".
+func taskProgress(tasks []taskItem) string {
+	done := 0
+	for _, task := range tasks {
+		if task.checked {
+			done++
+		}
+	}
+	return fmt.Sprintf("%d/%d tasks done", done, len(tasks))
+}
+
+// moveTaskCursor moves the PR Info task cursor, starting at the first task.
+func (m *DiffViewerModel) moveTaskCursor(delta int) {
+	n := len(parseTasks(m.prBody))
+	if n == 0 {
+		return
+	}
+	if m.taskCursor < 0 {
+		m.taskCursor = 0
+	} else {
+		m.taskCursor = max(0, min(n-1, m.taskCursor+delta))
+	}
+	m.prInfoCache = ""
+	m.refreshContent()
+}
+
+// selectedTask returns the task under the PR Info task cursor.
+func (m DiffViewerModel) selectedTask() (int, taskItem, bool) {
+	tasks := parseTasks(m.prBody)
+	if m.taskCursor < 0 || m.taskCursor >= len(tasks) {
+		return 0, taskItem{}, false
+	}
+	return m.taskCursor, tasks[m.taskCursor], true
+}
+
+// SetPRBody replaces the PR description shown on the PR Info tab, keeping
+// the task cursor.
+func (m *DiffViewerModel) SetPRBody(body string) {
+	m.prBody = body
+	m.prInfoCache = ""
+	m.refreshContent()
+}
+
+// toggleTask checks or unchecks the selected task of the user's own PR,
+// showing the change at once and reverting it if the update fails.
+func (m App) toggleTask(msg TaskToggleRequestMsg) (tea.Model, tea.Cmd) {
+	if m.session == nil || m.ghClient == nil {
+		return m, nil
+	}
+	if !m.isOwnPR() {
+		return m, m.statusBar.SetTemporaryMessage("Tasks can only be toggled on your own PRs", 2*time.Second)
+	}
+	before := m.diffViewer.prBody
+	after, err := setTask(before, msg.Index, msg.Text, msg.Checked)
+	if err != nil {
+		return m, m.statusBar.SetTemporaryMessage(err.Error(), 3*time.Second)
+	}
+	m.diffViewer.SetPRBody(after)
+	s := m.session
+	return m, updateTaskCmd(m.ghClient, s.Owner, s.Repo, s.Number, before, msg)
+}
+
+// updateTaskCmd returns a command that toggles a task in the PR's current
+// description and saves it. The description is fetched again first so
+// edits made elsewhere since it was loaded are kept.
+func updateTaskCmd(client GitHubService, owner, repo string, number int, shown string, req TaskToggleRequestMsg) tea.Cmd {
+	return func() tea.Msg {
+		ctx := context.Background()
+		done := TaskToggleDoneMsg{PRNumber: number, Previous: shown}
+		detail, err := client.GetPRDetail(ctx, owner, repo, number)
+		if err != nil {
+			done.Err = err
+			return done
+		}
+		body, err := setTask(detail.Body, req.Index, req.Text, req.Checked)
+		if err != nil {
+			done.Err = err
+			return done
+		}
+		if err := client.UpdatePRBody(ctx, owner, repo, number, body); err != nil {
+			done.Err = err
+			return done
+		}
+		done.Body = body
+		return done
+	}
+}
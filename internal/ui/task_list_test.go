@@ -0,0 +1,115 @@
+package ui
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/shhac/prtea/internal/github"
+)
+
+const taskBody = "## Checklist\n- [ ] tests\n- [x] docs\n```md\n- [ ] not a task\n```\n1. [ ] changelog"
+
+func TestParseTasks(t *testing.T) {
+	tasks := parseTasks(taskBody)
+	want := []taskItem{{line: 1, text: "tests"}, {line: 2, checked: true, text: "docs"}, {line: 6, text: "changelog"}}
+	if len(tasks) != len(want) {
+		t.Fatalf("tasks = %+v, want %+v", tasks, want)
+	}
+	for i := range want {
+		if tasks[i] != want[i] {
+			t.Errorf("task %d = %+v, want %+v", i, tasks[i], want[i])
+		}
+	}
+	if got := taskProgress(tasks); got != "1/3 tasks done" {
+		t.Errorf("progress = %q", got)
+	}
+}
+
+func TestSetTask(t *testing.T) {
+	body, err := setTask(taskBody, 0, "tests", true)
+	if err != nil || !strings.Contains(body, "- [x] tests") || !strings.Contains(body, "- [ ] not a task") {
+		t.Errorf("checking tests: %q, %v", body, err)
+	}
+	body, err = setTask("- [X] a\r\n- [ ] b\r\n", 0, "a", false)
+	if err != nil || body != "- [ ] a\r\n- [ ] b\r\n" {
+		t.Errorf("unchecking with CRLF: %q, %v", body, err)
+	}
+	if _, err := setTask(taskBody, 1, "tests", true); err == nil {
+		t.Error("a task whose text changed should not be toggled")
+	}
+}
+
+func TestRenderTaskGlyphs(t *testing.T) {
+	got := renderTaskGlyphs(taskBody, 1)
+	for _, want := range []string{"- ☐ tests", "- ▸ ☑ docs", "- [ ] not a task", "1. ☐ changelog"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("rendered body missing %q:\n%s", want, got)
+		}
+	}
+}
+
+func TestToggleTask_OwnPRUpdatesBody(t *testing.T) {
+	var patched string
+	client := github.NewTestClient("alice", func(ctx context.Context, args ...string) (string, error) {
+		if args[0] == "pr" && args[1] == "view" {
+			out, _ := json.Marshal(map[string]any{"number": 12, "title": "Add retries", "body": taskBody, "author": map[string]string{"login": "alice"}})
+			return string(out), nil
+		}
+		if strings.Contains(strings.Join(args, " "), "--method PATCH") {
+			patched = strings.Join(args, " ")
+		}
+		return "", nil
+	})
+	m := App{
+		statusBar:  NewStatusBarModel(),
+		diffViewer: newTestDiffViewer(80, 24),
+		session:    &PRSession{Owner: "shhac", Repo: "prtea", Number: 12, Author: "alice"},
+		ghClient:   client,
+	}
+	m.diffViewer.prNumber = 12
+	m.diffViewer.SetPRInfo("Add retries", taskBody, "alice", "")
+	m.diffViewer.activeTab = TabPRInfo
+	m.diffViewer.focused = true
+
+	dv, _ := m.diffViewer.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("n")})
+	dv, cmd := dv.Update(tea.KeyMsg{Type: tea.KeySpace, Runes: []rune(" ")})
+	m.diffViewer = dv
+	req, ok := cmd().(TaskToggleRequestMsg)
+	if !ok || req.Index != 0 || req.Text != "tests" || !req.Checked {
+		t.Fatalf("Space = %#v, want checking the first task", req)
+	}
+
+	model, cmd := m.Update(req)
+	m = model.(App)
+	if !strings.Contains(m.diffViewer.prBody, "- [x] tests") {
+		t.Error("the toggle should show before it is saved")
+	}
+	done := cmd().(TaskToggleDoneMsg)
+	if done.Err != nil || !strings.Contains(done.Body, "- [x] tests") || !strings.Contains(patched, "repos/shhac/prtea/pulls/12") {
+		t.Fatalf("done = %#v, patched = %q", done, patched)
+	}
+
+	// A failed save restores the description shown before the toggle.
+	model, _ = m.Update(TaskToggleDoneMsg{PRNumber: 12, Previous: taskBody, Err: context.DeadlineExceeded})
+	if m = model.(App); m.diffViewer.prBody != taskBody {
+		t.Errorf("body after a failed save = %q", m.diffViewer.prBody)
+	}
+}
+
+func TestToggleTask_RefusesOthersPRs(t *testing.T) {
+	m := App{
+		statusBar:  NewStatusBarModel(),
+		diffViewer: newTestDiffViewer(80, 24),
+		session:    &PRSession{Owner: "shhac", Repo: "prtea", Number: 12, Author: "bob"},
+		ghClient:   github.NewTestClient("alice", func(ctx context.Context, args ...string) (string, error) { return "", nil }),
+	}
+	m.diffViewer.SetPRInfo("Add retries", taskBody, "bob", "")
+	model, cmd := m.Update(TaskToggleRequestMsg{Index: 0, Text: "tests", Checked: true})
+	m = model.(App)
+	if cmd == nil || !strings.Contains(m.statusBar.statusMessage, "your own PRs") || m.diffViewer.prBody != taskBody {
+		t.Errorf("status = %q, body = %q", m.statusBar.statusMessage, m.diffViewer.prBody)
+	}
+}
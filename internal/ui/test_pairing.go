@@ -0,0 +1,145 @@
+package ui
+
+import (
+	"path"
+	"strings"
+
+	"github.com/shhac/prtea/internal/github"
+)
+
+// testFileName describes a changed file in terms of test pairing: the
+// language family, the stem shared by an implementation and its tests, and
+// whether this file is the test side.
+type testFileName struct {
+	family string
+	dir    string
+	stem   string
+	isTest bool
+}
+
+// classifyTestFile splits a path into its pairing key. ok is false for files
+// in languages without a recognised test naming convention (docs, config, …).
+func classifyTestFile(filename string) (testFileName, bool) {
+	dir, base := path.Split(filename)
+	ext := path.Ext(base)
+	name := strings.TrimSuffix(base, ext)
+	t := testFileName{dir: dir}
+
+	switch ext {
+	case ".go":
+		t.family = "go"
+		t.stem, t.isTest = trimAnySuffix(name, "_test")
+	case ".ts", ".tsx", ".js", ".jsx", ".mjs", ".cjs":
+		t.family = "js"
+		t.stem, t.isTest = trimAnySuffix(name, ".test", ".spec")
+	case ".py":
+		t.family = "py"
+		if strings.HasPrefix(name, "test_") {
+			t.stem, t.isTest = strings.TrimPrefix(name, "test_"), true
+		} else {
+			t.stem, t.isTest = trimAnySuffix(name, "_test")
+		}
+	case ".rb":
+		t.family = "rb"
+		t.stem, t.isTest = trimAnySuffix(name, "_spec", "_test")
+	case ".java", ".kt", ".cs", ".swift":
+		t.family = ext
+		t.stem, t.isTest = trimAnySuffix(name, "Tests", "Test")
+	default:
+		return testFileName{}, false
+	}
+	if t.stem == "" {
+		return testFileName{}, false
+	}
+	// Outside Go, files under a test directory count as tests even without
+	// a marker in their name (e.g. tests/test_x.py layouts, __tests__/x.ts).
+	if !t.isTest && t.family != "go" && inTestDir(dir) {
+		t.isTest = true
+	}
+	return t, true
+}
+
+// trimAnySuffix strips the first matching suffix and reports whether one matched.
+func trimAnySuffix(s string, suffixes ...string) (string, bool) {
+	for _, suf := range suffixes {
+		if strings.HasSuffix(s, suf) {
+			return strings.TrimSuffix(s, suf), true
+		}
+	}
+	return s, false
+}
+
+// inTestDir reports whether any directory component is a conventional test folder.
+func inTestDir(dir string) bool {
+	for _, part := range strings.Split(strings.Trim(dir, "/"), "/") {
+		switch part {
+		case "test", "tests", "__tests__", "spec":
+			return true
+		}
+	}
+	return false
+}
+
+// pairTestFiles maps each changed file to the index of its test (or
+// implementation) counterpart among the changed files, or -1 if none.
+// Go requires the pair to share a directory; other languages commonly keep
+// tests in a parallel tree, so a same-directory match is preferred but a
+// matching stem anywhere in the PR is accepted.
+func pairTestFiles(files []github.PRFile) []int {
+	pairs := make([]int, len(files))
+	names := make([]testFileName, len(files))
+	known := make([]bool, len(files))
+	for i, f := range files {
+		pairs[i] = -1
+		names[i], known[i] = classifyTestFile(f.Filename)
+	}
+
+	for i := range files {
+		if !known[i] {
+			continue
+		}
+		a := names[i]
+		best := -1
+		for j := range files {
+			if j == i || !known[j] {
+				continue
+			}
+			b := names[j]
+			if a.family != b.family || a.stem != b.stem || a.isTest == b.isTest {
+				continue
+			}
+			if a.dir == b.dir {
+				best = j
+				break
+			}
+			if a.family != "go" && best < 0 {
+				best = j
+			}
+		}
+		pairs[i] = best
+	}
+	return pairs
+}
+
+// missingTestChange reports whether a changed file is source code in a
+// recognised language whose tests were not touched by the PR.
+func missingTestChange(f github.PRFile, pair int) bool {
+	if pair >= 0 || f.Status == "removed" {
+		return false
+	}
+	t, ok := classifyTestFile(f.Filename)
+	return ok && !t.isTest
+}
+
+// jumpToTestPair moves focus to the first hunk of the file paired with the
+// focused hunk's file. Returns false when the file has no changed counterpart.
+func (m *DiffViewerModel) jumpToTestPair() bool {
+	if m.focusedHunkIdx < 0 || m.focusedHunkIdx >= len(m.hunks) {
+		return false
+	}
+	fileIdx := m.hunks[m.focusedHunkIdx].FileIndex
+	if fileIdx >= len(m.testPairs) || m.testPairs[fileIdx] < 0 {
+		return false
+	}
+	return m.jumpToFile(m.testPairs[fileIdx])
+}
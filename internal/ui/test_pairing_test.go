@@ -0,0 +1,70 @@
+package ui
+
+import (
+	"testing"
+
+	"github.com/shhac/prtea/internal/github"
+)
+
+func TestPairTestFiles(t *testing.T) {
+	files := []github.PRFile{
+		{Filename: "internal/ui/app.go"},            // 0 ↔ 1
+		{Filename: "internal/ui/app_test.go"},       // 1 ↔ 0
+		{Filename: "internal/github/client.go"},     // 2: test is in another dir
+		{Filename: "internal/other/client_test.go"}, // 3
+		{Filename: "src/widget.ts"},                 // 4 ↔ 5
+		{Filename: "test/widget.test.ts"},           // 5 ↔ 4
+		{Filename: "pkg/parser.py"},                 // 6 ↔ 7
+		{Filename: "tests/test_parser.py"},          // 7 ↔ 6
+		{Filename: "README.md"},                     // 8
+	}
+	want := []int{1, 0, -1, -1, 5, 4, 7, 6, -1}
+
+	got := pairTestFiles(files)
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("pair[%d] (%s) = %d, want %d", i, files[i].Filename, got[i], want[i])
+		}
+	}
+}
+
+func TestMissingTestChange(t *testing.T) {
+	tests := []struct {
+		file github.PRFile
+		pair int
+		want bool
+	}{
+		{github.PRFile{Filename: "a.go", Status: "modified"}, -1, true},
+		{github.PRFile{Filename: "a.go", Status: "modified"}, 3, false},
+		{github.PRFile{Filename: "a_test.go", Status: "modified"}, -1, false},
+		{github.PRFile{Filename: "a.go", Status: "removed"}, -1, false},
+		{github.PRFile{Filename: "docs/guide.md", Status: "added"}, -1, false},
+		{github.PRFile{Filename: "src/__tests__/x.ts", Status: "added"}, -1, false},
+	}
+	for _, tt := range tests {
+		if got := missingTestChange(tt.file, tt.pair); got != tt.want {
+			t.Errorf("missingTestChange(%s, %d) = %v, want %v", tt.file.Filename, tt.pair, got, tt.want)
+		}
+	}
+}
+
+func TestJumpToTestPair(t *testing.T) {
+	m := newTestDiffViewer(80, 10)
+	m.SetDiff([]github.PRFile{
+		{Filename: "a.go", Status: "modified", Patch: "@@ -1,2 +1,2 @@\n-old\n+new"},
+		{Filename: "b.go", Status: "modified", Patch: "@@ -1,2 +1,2 @@\n-old\n+new"},
+		{Filename: "a_test.go", Status: "modified", Patch: "@@ -1,2 +1,2 @@\n-old\n+new\n@@ -9,2 +9,2 @@\n-x\n+y"},
+	})
+
+	if !m.jumpToTestPair() || m.focusedHunkIdx != 2 {
+		t.Fatalf("jump from a.go: focused hunk = %d, want 2", m.focusedHunkIdx)
+	}
+	m.focusedHunkIdx = 3 // second hunk of a_test.go
+	if !m.jumpToTestPair() || m.focusedHunkIdx != 0 {
+		t.Fatalf("jump from a_test.go: focused hunk = %d, want 0", m.focusedHunkIdx)
+	}
+	m.focusedHunkIdx = 1
+	if m.jumpToTestPair() {
+		t.Error("b.go has no changed test, jump should fail")
+	}
+}
@@ -0,0 +1,218 @@
+package ui
+
+import (
+	"fmt"
+	"log"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/shhac/prtea/internal/config"
+)
+
+// Theme is a named color palette. Every color the UI draws comes from the
+// active theme, so switching themes only needs SetTheme.
+type Theme struct {
+	Name string
+	Dark bool // dark background; picks the matching markdown style
+
+	Text        lipgloss.Color // primary foreground
+	Muted       lipgloss.Color // secondary text, hints, metadata
+	MutedHi     lipgloss.Color // highlighted secondary text (e.g. scrollbar thumb)
+	Subtle      lipgloss.Color // borders, dividers, de-emphasized text
+	Faint       lipgloss.Color // very low contrast (scrollbar track, badge backgrounds)
+	Accent      lipgloss.Color // focus borders, active tabs, titles
+	Focus       lipgloss.Color // focused hunk header, descriptions under the accent
+	Info        lipgloss.Color // section headers, hunk headers
+	Success     lipgloss.Color // additions, passing checks, approvals
+	Warning     lipgloss.Color // pending state, cursor, drafts
+	WarningHi   lipgloss.Color
+	Error       lipgloss.Color // deletions, failures, errors
+	Highlight   lipgloss.Color // file headers, authors, GitHub comments
+	HighlightHi lipgloss.Color
+	AI          lipgloss.Color // AI comments and badges
+	AIHi        lipgloss.Color
+	OnAccent    lipgloss.Color // text drawn on Accent or Error backgrounds
+	Inverse     lipgloss.Color // text drawn on Success or AI backgrounds
+
+	Surface         lipgloss.Color // status bar and selected hunk background
+	CursorBg        lipgloss.Color // cursor row background
+	SelectionBg     lipgloss.Color // multi-line selection background
+	SearchMatchBg   lipgloss.Color // search match background
+	SearchCurrentBg lipgloss.Color // current search match background
+}
+
+// darkTheme is the default palette (ANSI 256 colors).
+var darkTheme = Theme{
+	Name: "dark", Dark: true,
+	Text: "252", Muted: "244", MutedHi: "248", Subtle: "240", Faint: "238",
+	Accent: "62", Focus: "99", Info: "33",
+	Success: "42", Warning: "214", WarningHi: "222", Error: "196",
+	Highlight: "220", HighlightHi: "228", AI: "75", AIHi: "117",
+	OnAccent: "255", Inverse: "0",
+	Surface: "236", CursorBg: "237", SelectionBg: "24",
+	SearchMatchBg: "58", SearchCurrentBg: "178",
+}
+
+// lightTheme suits terminals with a light background.
+var lightTheme = Theme{
+	Name: "light",
+	Text: "235", Muted: "242", MutedHi: "239", Subtle: "247", Faint: "251",
+	Accent: "61", Focus: "92", Info: "25",
+	Success: "28", Warning: "166", WarningHi: "202", Error: "160",
+	Highlight: "136", HighlightHi: "130", AI: "31", AIHi: "25",
+	OnAccent: "255", Inverse: "255",
+	Surface: "254", CursorBg: "253", SelectionBg: "153",
+	SearchMatchBg: "229", SearchCurrentBg: "221",
+}
+
+// solarizedTheme is Solarized Dark.
+var solarizedTheme = Theme{
+	Name: "solarized", Dark: true,
+	Text: "#93a1a1", Muted: "#839496", MutedHi: "#eee8d5", Subtle: "#586e75", Faint: "#073642",
+	Accent: "#6c71c4", Focus: "#d33682", Info: "#268bd2",
+	Success: "#859900", Warning: "#cb4b16", WarningHi: "#e0692f", Error: "#dc322f",
+	Highlight: "#b58900", HighlightHi: "#d7a700", AI: "#2aa198", AIHi: "#35c2b6",
+	OnAccent: "#fdf6e3", Inverse: "#002b36",
+	Surface: "#073642", CursorBg: "#0a4350", SelectionBg: "#1c4e6e",
+	SearchMatchBg: "#4d4400", SearchCurrentBg: "#b58900",
+}
+
+// highContrastTheme uses the brightest basic colors on black.
+var highContrastTheme = Theme{
+	Name: "high-contrast", Dark: true,
+	Text: "15", Muted: "252", MutedHi: "15", Subtle: "248", Faint: "242",
+	Accent: "14", Focus: "13", Info: "12",
+	Success: "10", Warning: "208", WarningHi: "214", Error: "9",
+	Highlight: "11", HighlightHi: "229", AI: "14", AIHi: "51",
+	OnAccent: "0", Inverse: "0",
+	Surface: "0", CursorBg: "238", SelectionBg: "19",
+	SearchMatchBg: "94", SearchCurrentBg: "11",
+}
+
+// builtinThemes are the themes selectable by name in the config.
+var builtinThemes = map[string]Theme{
+	"dark":          darkTheme,
+	"light":         lightTheme,
+	"solarized":     solarizedTheme,
+	"high-contrast": highContrastTheme,
+}
+
+// ThemeNames returns the built-in theme names, plus "auto".
+func ThemeNames() []string {
+	names := make([]string, 0, len(builtinThemes)+1)
+	for name := range builtinThemes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return append([]string{"auto"}, names...)
+}
+
+// theme is the active palette. Change it with SetTheme.
+var theme = darkTheme
+
+func init() {
+	buildStyles()
+}
+
+// SetTheme makes t the active palette and rebuilds every style.
+func SetTheme(t Theme) {
+	theme = t
+	buildStyles()
+}
+
+// terminalHasDarkBackground queries the terminal once; later queries would
+// race bubbletea for stdin.
+var terminalHasDarkBackground = sync.OnceValue(lipgloss.HasDarkBackground)
+
+// applyConfigTheme resolves and applies the configured theme, logging any
+// config errors. Returns true if the active palette changed.
+func applyConfigTheme(cfg *config.Config) bool {
+	setCompat(compatWanted(cfg.CompatMode))
+	name := cfg.Theme
+	if compatOutput {
+		name = "compat"
+	}
+	t, err := ResolveTheme(name, cfg.ThemeColors, terminalHasDarkBackground)
+	if err != nil {
+		log.Printf("warning: theme: %v", err)
+	}
+	if reflect.DeepEqual(t, theme) {
+		return false
+	}
+	SetTheme(t)
+	return true
+}
+
+// ResolveTheme returns the named built-in theme with color overrides applied.
+// "auto" (or "") picks dark or light using hasDarkBackground, which is only
+// called in that case; so does "compat", the palette of compatibility mode. Overrides are keyed by slot name, e.g. "accent" or
+// "searchMatchBg", and take any lipgloss color ("62", "#ff8800").
+func ResolveTheme(name string, overrides map[string]string, hasDarkBackground func() bool) (Theme, error) {
+	var t Theme
+	switch strings.ToLower(name) {
+	case "", "auto":
+		t = darkTheme
+		if hasDarkBackground != nil && !hasDarkBackground() {
+			t = lightTheme
+		}
+	case "compat":
+		t = compatDarkTheme
+		if hasDarkBackground != nil && !hasDarkBackground() {
+			t = compatLightTheme
+		}
+	default:
+		var ok bool
+		t, ok = builtinThemes[strings.ToLower(name)]
+		if !ok {
+			return darkTheme, fmt.Errorf("unknown theme %q (choose from %s)", name, strings.Join(ThemeNames(), ", "))
+		}
+	}
+
+	slots := t.slots()
+	var unknown []string
+	for key, color := range overrides {
+		slot, ok := slots[strings.ToLower(key)]
+		if !ok {
+			unknown = append(unknown, key)
+			continue
+		}
+		*slot = lipgloss.Color(color)
+	}
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return t, fmt.Errorf("unknown theme color(s): %s", strings.Join(unknown, ", "))
+	}
+	return t, nil
+}
+
+// slots maps lower-cased override keys to the theme's color fields.
+func (t *Theme) slots() map[string]*lipgloss.Color {
+	return map[string]*lipgloss.Color{
+		"text":            &t.Text,
+		"muted":           &t.Muted,
+		"mutedhi":         &t.MutedHi,
+		"subtle":          &t.Subtle,
+		"faint":           &t.Faint,
+		"accent":          &t.Accent,
+		"focus":           &t.Focus,
+		"info":            &t.Info,
+		"success":         &t.Success,
+		"warning":         &t.Warning,
+		"warninghi":       &t.WarningHi,
+		"error":           &t.Error,
+		"highlight":       &t.Highlight,
+		"highlighthi":     &t.HighlightHi,
+		"ai":              &t.AI,
+		"aihi":            &t.AIHi,
+		"onaccent":        &t.OnAccent,
+		"inverse":         &t.Inverse,
+		"surface":         &t.Surface,
+		"cursorbg":        &t.CursorBg,
+		"selectionbg":     &t.SelectionBg,
+		"searchmatchbg":   &t.SearchMatchBg,
+		"searchcurrentbg": &t.SearchCurrentBg,
+	}
+}
@@ -0,0 +1,71 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+func TestResolveTheme(t *testing.T) {
+	dark := func() bool { return true }
+	light := func() bool { return false }
+
+	tests := []struct {
+		name     string
+		detector func() bool
+		want     string
+	}{
+		{"", dark, "dark"},
+		{"auto", light, "light"},
+		{"Solarized", light, "solarized"},
+		{"high-contrast", dark, "high-contrast"},
+	}
+	for _, tt := range tests {
+		got, err := ResolveTheme(tt.name, nil, tt.detector)
+		if err != nil {
+			t.Errorf("ResolveTheme(%q): %v", tt.name, err)
+		}
+		if got.Name != tt.want {
+			t.Errorf("ResolveTheme(%q) = %q, want %q", tt.name, got.Name, tt.want)
+		}
+	}
+}
+
+func TestResolveTheme_Overrides(t *testing.T) {
+	got, err := ResolveTheme("dark", map[string]string{"Accent": "#ff8800", "error": "9"}, nil)
+	if err != nil {
+		t.Fatalf("ResolveTheme: %v", err)
+	}
+	if got.Accent != "#ff8800" || got.Error != "9" {
+		t.Errorf("overrides not applied: accent=%q error=%q", got.Accent, got.Error)
+	}
+	if darkTheme.Accent == "#ff8800" {
+		t.Error("overrides must not modify the built-in theme")
+	}
+}
+
+func TestResolveTheme_Errors(t *testing.T) {
+	if _, err := ResolveTheme("neon", nil, nil); err == nil || !strings.Contains(err.Error(), "unknown theme") {
+		t.Errorf("unknown theme err = %v", err)
+	}
+	got, err := ResolveTheme("light", map[string]string{"sparkle": "1", "text": "0"}, nil)
+	if err == nil || !strings.Contains(err.Error(), "sparkle") {
+		t.Errorf("unknown color err = %v", err)
+	}
+	if got.Name != "light" || got.Text != "0" {
+		t.Errorf("valid overrides should still apply, got %+v", got)
+	}
+}
+
+func TestSetTheme_RebuildsStyles(t *testing.T) {
+	defer SetTheme(darkTheme)
+
+	SetTheme(lightTheme)
+	if got := diffAddedStyle.GetForeground(); got != lipgloss.Color(lightTheme.Success) {
+		t.Errorf("diffAddedStyle foreground = %v, want %v", got, lightTheme.Success)
+	}
+	if got := statusBarStyle.GetBackground(); got != lightTheme.Surface {
+		t.Errorf("statusBarStyle background = %v, want %v", got, lightTheme.Surface)
+	}
+}
@@ -0,0 +1,74 @@
+package ui
+
+import (
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// absoluteTimestamps shows comment, review and PR times as local dates
+// (absoluteTimestamps) instead of "3h ago".
+var absoluteTimestamps bool
+
+// timestampRefresh is how often relative times are redrawn.
+const timestampRefresh = time.Minute
+
+// timestampTickMsg redraws the views showing relative times.
+type timestampTickMsg struct{}
+
+func timestampTickCmd() tea.Cmd {
+	return tea.Tick(timestampRefresh, func(time.Time) tea.Msg { return timestampTickMsg{} })
+}
+
+// formatTimestamp formats a comment, review or PR time for display.
+func formatTimestamp(t time.Time) string {
+	return formatTimestampAt(t, time.Now())
+}
+
+// formatTimestampAt formats t relative to now ("5m ago", "3h ago", "2d
+// ago"), or as a local date with absoluteTimestamps or past a month, when
+// a date reads better.
+func formatTimestampAt(t, now time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	if !absoluteTimestamps {
+		switch d := now.Sub(t); {
+		case d < time.Minute:
+			return "just now" // also a little in the future, from clock skew
+		case d < time.Hour:
+			return fmt.Sprintf("%dm ago", int(d.Minutes()))
+		case d < 24*time.Hour:
+			return fmt.Sprintf("%dh ago", int(d.Hours()))
+		case d < 30*24*time.Hour:
+			return fmt.Sprintf("%dd ago", int(d.Hours()/24))
+		}
+	}
+	t = t.In(now.Location())
+	if t.Year() != now.Year() {
+		return t.Format("Jan 2 2006")
+	}
+	return t.Format("Jan 2 15:04")
+}
+
+// refreshTimestamps redraws the views showing relative times, which the
+// PR list does on its own, and keeps ticking.
+func (m App) refreshTimestamps() (tea.Model, tea.Cmd) {
+	if !absoluteTimestamps {
+		m.rerenderTimestamps()
+	}
+	return m, timestampTickCmd()
+}
+
+// rerenderTimestamps drops the cached renders that hold formatted times.
+func (m *App) rerenderTimestamps() {
+	m.diffViewer.prInfoCache = ""
+	m.diffViewer.cachedLines = nil
+	m.diffViewer.refreshContent()
+	m.chatPanel.comments.cache = ""
+	m.chatPanel.refreshViewport()
+	if m.commentOverlay.IsVisible() {
+		m.commentOverlay.refreshContent()
+	}
+}
@@ -0,0 +1,49 @@
+package ui
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFormatTimestampAt(t *testing.T) {
+	tokyo := time.FixedZone("JST", 9*60*60)
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, tokyo)
+	tests := []struct {
+		at   time.Time
+		want string
+	}{
+		{time.Time{}, ""},
+		{now.Add(-20 * time.Second), "just now"},
+		{now.Add(30 * time.Second), "just now"},
+		{now.Add(-5 * time.Minute), "5m ago"},
+		{now.Add(-3*time.Hour - 59*time.Minute), "3h ago"},
+		{now.Add(-50 * time.Hour), "2d ago"},
+		// Past a month, a date in the local timezone: 01:30 UTC is 10:30 JST.
+		{time.Date(2026, 1, 5, 1, 30, 0, 0, time.UTC), "Jan 5 10:30"},
+		{time.Date(2025, 12, 24, 9, 0, 0, 0, tokyo), "Dec 24 2025"},
+	}
+	for _, tt := range tests {
+		if got := formatTimestampAt(tt.at, now); got != tt.want {
+			t.Errorf("formatTimestampAt(%v) = %q, want %q", tt.at, got, tt.want)
+		}
+	}
+
+	absoluteTimestamps = true
+	t.Cleanup(func() { absoluteTimestamps = false })
+	if got := formatTimestampAt(time.Date(2026, 3, 10, 0, 15, 0, 0, time.UTC), now); got != "Mar 10 09:15" {
+		t.Errorf("absolute = %q, want the local date", got)
+	}
+}
+
+func TestRefreshTimestamps_RerendersComments(t *testing.T) {
+	m := commentNavTestApp()
+	m.chatPanel.comments.cache = "rendered a minute ago"
+	model, cmd := m.refreshTimestamps()
+	m = model.(App)
+	if m.chatPanel.comments.cache == "rendered a minute ago" {
+		t.Error("expected the comments render to be dropped")
+	}
+	if cmd == nil {
+		t.Error("expected the next tick")
+	}
+}
@@ -0,0 +1,135 @@
+package ui
+
+import (
+	"context"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/shhac/prtea/internal/claude"
+	"github.com/shhac/prtea/internal/github"
+)
+
+// triageQueue is the background AI triage of the To Review list (aiTriage):
+// PRs waiting for a quick summary and tag, worked through one at a time so
+// the job never competes with the user's own AI requests for long.
+type triageQueue struct {
+	pending []github.PRItem
+	running bool
+	queued  map[string]time.Time // PR key → updatedAt already queued or done
+}
+
+// queueTriage queues the PRs of the To Review list that are new or updated
+// since they were last triaged, showing cached triages straight away.
+func (m *App) queueTriage(toReview []github.PRItem) tea.Cmd {
+	if m.appConfig == nil || !m.appConfig.AITriage || m.analyzer == nil || m.ghClient == nil {
+		return nil
+	}
+	q := &m.triage
+	if q.queued == nil {
+		q.queued = make(map[string]time.Time)
+	}
+	for _, pr := range toReview {
+		key := prKey(pr.Repo.Owner, pr.Repo.Name, pr.Number)
+		if at, ok := q.queued[key]; ok && at.Equal(pr.UpdatedAt) {
+			continue
+		}
+		q.queued[key] = pr.UpdatedAt
+		if m.analysisStore != nil {
+			cached, _ := m.analysisStore.GetTriage(pr.Repo.Owner, pr.Repo.Name, pr.Number)
+			if cached != nil && cached.Triage != nil && cached.UpdatedAt.Equal(pr.UpdatedAt) {
+				m.prList.SetTriage(key, *cached.Triage)
+				continue
+			}
+		}
+		q.pending = append(q.pending, pr)
+	}
+	return m.nextTriage()
+}
+
+// nextTriage starts triaging the next queued PR, unless one is running or
+// AI is off.
+func (m *App) nextTriage() tea.Cmd {
+	q := &m.triage
+	if m.appConfig == nil || !m.appConfig.AITriage {
+		// Turned off: forget the queue so turning it back on requeues.
+		for _, pr := range q.pending {
+			delete(q.queued, prKey(pr.Repo.Owner, pr.Repo.Name, pr.Number))
+		}
+		q.pending = nil
+		return nil
+	}
+	if q.running || len(q.pending) == 0 || m.aiBudget.health == aiDisabled {
+		return nil
+	}
+	pr := q.pending[0]
+	q.pending = q.pending[1:]
+	q.running = true
+	ctx := m.usageContextFor(prKey(pr.Repo.Owner, pr.Repo.Name, pr.Number))
+	return triageCmd(ctx, m.ghClient, m.analyzer, m.analysisStore, pr)
+}
+
+// triageCmd fetches a PR's diff and triages it, caching the result.
+func triageCmd(ctx context.Context, client GitHubService, analyzer AIAnalyzer, store *claude.AnalysisStore, pr github.PRItem) tea.Cmd {
+	return func() tea.Msg {
+		msg := PRTriagedMsg{Owner: pr.Repo.Owner, Repo: pr.Repo.Name, Number: pr.Number}
+		files, err := client.GetPRFiles(ctx, pr.Repo.Owner, pr.Repo.Name, pr.Number)
+		if err != nil {
+			msg.FetchErr = err
+			return msg
+		}
+		msg.Triage, msg.Err = analyzer.TriagePR(ctx, claude.AnalyzeDiffInput{
+			Owner:       pr.Repo.Owner,
+			Repo:        pr.Repo.Name,
+			PRNumber:    pr.Number,
+			PRTitle:     pr.Title,
+			DiffContent: buildDiffContent(files),
+		})
+		if msg.Err == nil && store != nil {
+			_ = store.PutTriage(pr.Repo.Owner, pr.Repo.Name, pr.Number, pr.UpdatedAt, msg.Triage) // best-effort
+		}
+		return msg
+	}
+}
+
+// triaged shows a PR's triage in the list and moves on to the next one.
+// Failures are quiet: the row just goes without a tag.
+func (m App) triaged(msg PRTriagedMsg) (tea.Model, tea.Cmd) {
+	m.triage.running = false
+	var cmds []tea.Cmd
+	if msg.FetchErr == nil {
+		cmds = append(cmds, m.recordAIResult(msg.Err))
+	}
+	if msg.Triage != nil {
+		m.prList.SetTriage(prKey(msg.Owner, msg.Repo, msg.Number), *msg.Triage)
+	}
+	cmds = append(cmds, m.nextTriage())
+	return m, tea.Batch(cmds...)
+}
+
+// triageBadgeForList returns a styled triage tag and its visual width for
+// the PR list.
+func triageBadgeForList(tag string) (string, int) {
+	var label string
+	var color lipgloss.Color
+	switch tag {
+	case claude.TriageTrivial:
+		label, color = "trivial", theme.Success
+	case claude.TriageCareful:
+		label, color = "careful", theme.Warning
+	case claude.TriageRisky:
+		label, color = "risky", theme.Error
+	default:
+		return "", 0
+	}
+	styled := " " + lipgloss.NewStyle().Foreground(color).Render(label)
+	return styled, len(label) + 1
+}
+
+// SetTriage shows the AI triage of a listed PR, by PRItem.key().
+func (m *PRListModel) SetTriage(key string, t claude.PRTriage) {
+	if m.triage == nil {
+		return
+	}
+	m.triage[key] = t
+}
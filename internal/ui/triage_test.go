@@ -0,0 +1,113 @@
+package ui
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shhac/prtea/internal/claude"
+	"github.com/shhac/prtea/internal/config"
+	"github.com/shhac/prtea/internal/github"
+)
+
+// triageAnalyzer tags every PR risky; other methods are unused.
+type triageAnalyzer struct {
+	AIAnalyzer
+	titles []string
+}
+
+func (a *triageAnalyzer) TriagePR(_ context.Context, input claude.AnalyzeDiffInput) (*claude.PRTriage, error) {
+	a.titles = append(a.titles, input.PRTitle)
+	return &claude.PRTriage{Summary: "Rewrites " + input.PRTitle, Tag: claude.TriageRisky}, nil
+}
+
+func TestTriage_QueuesNewAndUpdatedPRs(t *testing.T) {
+	analyzer := &triageAnalyzer{}
+	store := claude.NewAnalysisStore(t.TempDir())
+	m := App{
+		statusBar:     NewStatusBarModel(),
+		prList:        NewPRListModel(TabToReview),
+		appConfig:     &config.Config{AITriage: true},
+		analyzer:      analyzer,
+		analysisStore: store,
+		ghClient: github.NewTestClient("me", func(ctx context.Context, args ...string) (string, error) {
+			if strings.Contains(strings.Join(args, " "), "/files") {
+				return `[{"filename": "auth.go", "patch": "@@ -1 +1 @@\n+x"}]`, nil
+			}
+			return "", nil
+		}),
+	}
+	updated := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	repo := github.Repo{Owner: "acme", Name: "api"}
+	prs := []github.PRItem{
+		{Number: 1, Title: "auth", Repo: repo, UpdatedAt: updated},
+		{Number: 2, Title: "docs", Repo: repo, UpdatedAt: updated},
+	}
+	// PR 2 was triaged at this updatedAt in an earlier session.
+	if err := store.PutTriage("acme", "api", 2, updated, &claude.PRTriage{Summary: "Fixes typos", Tag: claude.TriageTrivial}); err != nil {
+		t.Fatal(err)
+	}
+
+	cmd := m.queueTriage(prs)
+	if cmd == nil || !m.triage.running {
+		t.Fatal("expected PR 1 to be triaged")
+	}
+	if got := m.prList.triage["acme/api#2"]; got.Tag != claude.TriageTrivial {
+		t.Errorf("PR 2 triage = %+v, want the cached one", got)
+	}
+	msg, ok := cmd().(PRTriagedMsg)
+	if !ok || msg.Err != nil || msg.FetchErr != nil {
+		t.Fatalf("triage msg = %+v", msg)
+	}
+	model, _ := m.triaged(msg)
+	m = model.(App)
+	if got := m.prList.triage["acme/api#1"]; got.Tag != claude.TriageRisky || got.Summary != "Rewrites auth" {
+		t.Errorf("PR 1 triage = %+v", got)
+	}
+	if m.triage.running || len(m.triage.pending) != 0 {
+		t.Error("expected the queue to be done")
+	}
+	if cached, _ := store.GetTriage("acme", "api", 1); cached == nil || !cached.UpdatedAt.Equal(updated) {
+		t.Errorf("PR 1 triage not cached: %+v", cached)
+	}
+
+	if cmd := m.queueTriage(prs); cmd != nil {
+		t.Error("unchanged PRs should not be triaged again")
+	}
+	prs[0].UpdatedAt = updated.Add(time.Hour)
+	if cmd := m.queueTriage(prs); cmd == nil {
+		t.Error("an updated PR should be triaged again")
+	}
+	if len(analyzer.titles) != 1 {
+		t.Errorf("analyzer ran for %v, want only auth so far", analyzer.titles)
+	}
+}
+
+func TestTriage_OffByDefault(t *testing.T) {
+	m := App{
+		prList:    NewPRListModel(TabToReview),
+		appConfig: &config.Config{},
+		analyzer:  &triageAnalyzer{},
+		ghClient:  github.NewTestClient("me", func(ctx context.Context, args ...string) (string, error) { return "", nil }),
+	}
+	if cmd := m.queueTriage([]github.PRItem{{Number: 1, Repo: github.Repo{Owner: "acme", Name: "api"}}}); cmd != nil {
+		t.Error("triage should only run when aiTriage is on")
+	}
+}
+
+func TestTriageBadgeForList(t *testing.T) {
+	for tag, want := range map[string]string{
+		claude.TriageTrivial: "trivial",
+		claude.TriageCareful: "careful",
+		claude.TriageRisky:   "risky",
+	} {
+		badge, width := triageBadgeForList(tag)
+		if !strings.Contains(badge, want) || width != len(want)+1 {
+			t.Errorf("badge for %q = %q (%d)", tag, badge, width)
+		}
+	}
+	if badge, width := triageBadgeForList("scary"); badge != "" || width != 0 {
+		t.Errorf("unknown tag badge = %q (%d)", badge, width)
+	}
+}
@@ -0,0 +1,368 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/shhac/prtea/internal/claude"
+)
+
+// usageTracker totals AI token usage and cost for the session, per PR and,
+// through the ledger, per calendar month. Calls report from their own
+// goroutines, so it is shared by pointer and locked.
+type usageTracker struct {
+	mu      sync.Mutex
+	session claude.Usage
+	perPR   map[string]claude.Usage
+	ledger  *claude.UsageLedger // nil keeps monthly totals in memory only
+	loadErr error               // why the ledger started empty, if its file couldn't be read
+	month   claude.Usage        // this month's totals when there is no ledger
+	warned  bool                // the monthly budget warning was shown this session
+}
+
+func newUsageTracker(ledger *claude.UsageLedger, loadErr error) *usageTracker {
+	return &usageTracker{perPR: make(map[string]claude.Usage), ledger: ledger, loadErr: loadErr}
+}
+
+// add records one call's usage against a PR (empty key: no PR).
+func (t *usageTracker) add(key string, u claude.Usage) {
+	t.mu.Lock()
+	t.session.Add(u)
+	if key != "" {
+		pr := t.perPR[key]
+		pr.Add(u)
+		t.perPR[key] = pr
+	}
+	if t.ledger == nil {
+		t.month.Add(u)
+	}
+	t.mu.Unlock()
+	if t.ledger != nil {
+		_ = t.ledger.Add(claude.UsageMonth(time.Now()), u) // best-effort
+	}
+}
+
+// totals returns the usage of one PR and of the whole session.
+func (t *usageTracker) totals(key string) (pr, session claude.Usage) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.perPR[key], t.session
+}
+
+// thisMonth returns the calendar month's totals across sessions.
+func (t *usageTracker) thisMonth() claude.Usage {
+	if t.ledger != nil {
+		return t.ledger.Month(claude.UsageMonth(time.Now()))
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.month
+}
+
+// overBudget reports the month's spend the first time in a session that it
+// reaches budget; later calls report false.
+func (t *usageTracker) overBudget(budget float64) (float64, bool) {
+	if budget <= 0 {
+		return 0, false
+	}
+	spent := t.thisMonth().CostUSD
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.warned || spent < budget {
+		return spent, false
+	}
+	t.warned = true
+	return spent, true
+}
+
+// usageContext attributes the usage of AI calls made with the returned
+// context to the current PR.
+func (m App) usageContext() context.Context {
+	key := ""
+	if s := m.session; s != nil {
+		key = prKey(s.Owner, s.Repo, s.Number)
+	}
+	return m.usageContextFor(key)
+}
+
+// usageContextFor attributes the usage of AI calls made with the returned
+// context to the PR with the given key.
+func (m App) usageContextFor(key string) context.Context {
+	ctx := context.Background()
+	if m.usage == nil {
+		return ctx
+	}
+	tracker := m.usage
+	return claude.WithUsageRecorder(ctx, func(u claude.Usage) { tracker.add(key, u) })
+}
+
+// syncUsageFooter shows the current PR's usage under the analysis.
+func (m *App) syncUsageFooter() {
+	if m.usage == nil {
+		return
+	}
+	key := ""
+	if s := m.session; s != nil {
+		key = prKey(s.Owner, s.Repo, s.Number)
+	}
+	pr, session := m.usage.totals(key)
+	m.chatPanel.SetUsageFooter(usageFooter(pr, session))
+}
+
+// refreshUsage updates the usage footer after an AI call and warns once a
+// session when the month's spend reaches aiMonthlyBudgetUsd.
+func (m *App) refreshUsage() tea.Cmd {
+	m.syncUsageFooter()
+	if m.usage == nil || m.appConfig == nil {
+		return nil
+	}
+	budget := m.appConfig.AIMonthlyBudgetUSD
+	spent, over := m.usage.overBudget(budget)
+	if !over {
+		return nil
+	}
+	return m.statusBar.SetTemporaryMessage(
+		fmt.Sprintf("AI spend this month is $%.2f, past your $%.2f budget — :usage for details", spent, budget),
+		6*time.Second,
+	)
+}
+
+// usageFooter summarizes usage for the bottom of the Analysis tab; empty
+// until the session has made a call.
+func usageFooter(pr, session claude.Usage) string {
+	if session.Calls == 0 {
+		return ""
+	}
+	parts := []string{fmt.Sprintf("This PR: %s in / %s out", formatTokenCount(pr.PromptTokens()), formatTokenCount(pr.OutputTokens))}
+	if pr.CostUSD > 0 {
+		parts = append(parts, formatCost(pr.CostUSD))
+	}
+	parts = append(parts, "session "+formatCost(session.CostUSD))
+	return "AI usage · " + strings.Join(parts, " · ") + " · :usage"
+}
+
+// formatTokenCount shortens a token count, e.g. 12345 → "12.3k".
+func formatTokenCount(n int) string {
+	switch {
+	case n >= 1_000_000:
+		return fmt.Sprintf("%.2fM", float64(n)/1e6)
+	case n >= 1000:
+		return fmt.Sprintf("%.1fk", float64(n)/1e3)
+	}
+	return fmt.Sprintf("%d", n)
+}
+
+func formatCost(usd float64) string {
+	if usd > 0 && usd < 0.01 {
+		return "<$0.01"
+	}
+	return fmt.Sprintf("$%.2f", usd)
+}
+
+// showUsage opens the :usage overlay.
+func (m App) showUsage() (tea.Model, tea.Cmd) {
+	if m.usage == nil {
+		return m, m.statusBar.SetTemporaryMessage("Usage tracking is unavailable", 2*time.Second)
+	}
+	var sections []usageSection
+	if s := m.session; s != nil {
+		pr, _ := m.usage.totals(prKey(s.Owner, s.Repo, s.Number))
+		sections = append(sections, usageSection{title: fmt.Sprintf("This PR (%s/%s#%d)", s.Owner, s.Repo, s.Number), usage: pr})
+	}
+	_, session := m.usage.totals("")
+	sections = append(sections,
+		usageSection{title: "This session", usage: session},
+		usageSection{title: time.Now().Format("January 2006"), usage: m.usage.thisMonth()},
+	)
+	budget := 0.0
+	if m.appConfig != nil {
+		budget = m.appConfig.AIMonthlyBudgetUSD
+	}
+	m.usageOverlay.SetSize(m.width, m.height)
+	warning := ""
+	if m.usage.loadErr != nil {
+		warning = m.usage.loadErr.Error() + "; this month's totals only count this session."
+	}
+	m.usageOverlay.Show(sections, budget, warning)
+	m.setMode(ModeOverlay)
+	return m, nil
+}
+
+// usageSection is one block of the :usage overlay.
+type usageSection struct {
+	title string
+	usage claude.Usage
+}
+
+// UsageOverlayModel shows AI token usage and cost for the current PR, the
+// session and the month.
+type UsageOverlayModel struct {
+	viewport viewport.Model
+	width    int
+	height   int
+	visible  bool
+	ready    bool
+
+	sections []usageSection
+	budget   float64 // monthly budget in USD, 0 for none
+	warning  string  // shown above the totals, e.g. an unreadable ledger
+}
+
+// NewUsageOverlayModel creates a usage overlay.
+func NewUsageOverlayModel() UsageOverlayModel {
+	return UsageOverlayModel{}
+}
+
+// Show opens the overlay with a snapshot of the totals.
+func (m *UsageOverlayModel) Show(sections []usageSection, budget float64, warning string) {
+	m.visible = true
+	m.sections = sections
+	m.budget = budget
+	m.warning = warning
+	m.refreshContent()
+	m.viewport.GotoTop()
+}
+
+// Hide dismisses the overlay.
+func (m *UsageOverlayModel) Hide() {
+	m.visible = false
+}
+
+// IsVisible returns whether the overlay is currently shown.
+func (m UsageOverlayModel) IsVisible() bool {
+	return m.visible
+}
+
+// SetSize updates the overlay dimensions and rebuilds the viewport.
+func (m *UsageOverlayModel) SetSize(termWidth, termHeight int) {
+	m.width = termWidth
+	m.height = termHeight
+
+	innerW, innerH := m.innerDimensions()
+	if !m.ready {
+		m.viewport = viewport.New(innerW, innerH)
+		m.ready = true
+	} else {
+		m.viewport.Width = innerW
+		m.viewport.Height = innerH
+	}
+	m.refreshContent()
+}
+
+func (m UsageOverlayModel) Update(msg tea.Msg) (UsageOverlayModel, tea.Cmd) {
+	if msg, ok := msg.(tea.KeyMsg); ok {
+		switch msg.String() {
+		case "esc", "q", "enter":
+			m.Hide()
+			return m, func() tea.Msg { return UsageClosedMsg{} }
+		}
+		var cmd tea.Cmd
+		m.viewport, cmd = m.viewport.Update(msg)
+		return m, cmd
+	}
+	return m, nil
+}
+
+func (m *UsageOverlayModel) refreshContent() {
+	if !m.ready {
+		return
+	}
+	body := renderUsageSections(m.sections, m.budget)
+	if m.warning != "" {
+		innerW, _ := m.innerDimensions()
+		body = lipgloss.NewStyle().Foreground(theme.Warning).Render(wordWrap(m.warning, innerW)) + "\n\n" + body
+	}
+	m.viewport.SetContent(body)
+}
+
+// renderUsageSections renders the overlay body.
+func renderUsageSections(sections []usageSection, budget float64) string {
+	var b strings.Builder
+	row := func(label, value string) {
+		b.WriteString("  " + dimStyle.Render(padRight(label, 10)) + value + "\n")
+	}
+	for i, s := range sections {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		b.WriteString(boldStyle.Render(s.title) + "\n")
+		u := s.usage
+		row("Calls", fmt.Sprintf("%d", u.Calls))
+		input := fmt.Sprintf("%s tokens", formatTokenCount(u.PromptTokens()))
+		if u.CacheReadTokens > 0 {
+			input += fmt.Sprintf(" (%s cached)", formatTokenCount(u.CacheReadTokens))
+		}
+		row("Input", input)
+		row("Output", fmt.Sprintf("%s tokens", formatTokenCount(u.OutputTokens)))
+		row("Cost", formatCost(u.CostUSD))
+	}
+	if budget > 0 && len(sections) > 0 {
+		spent := sections[len(sections)-1].usage.CostUSD
+		line := fmt.Sprintf("%s of $%.2f (%.0f%%)", formatCost(spent), budget, 100*spent/budget)
+		if spent >= budget {
+			line = lipgloss.NewStyle().Foreground(theme.Warning).Render(line + " — over budget")
+		}
+		row("Budget", line)
+	}
+	b.WriteString("\n" + dimStyle.Render("Cost is reported by the Claude CLI and estimated for the Anthropic API; other backends count tokens only."))
+	return b.String()
+}
+
+func (m UsageOverlayModel) View() string {
+	if !m.visible {
+		return ""
+	}
+
+	overlayW, overlayH := m.overlayDimensions()
+	innerW := max(1, overlayW-4)
+
+	title := helpTitleStyle.Render(" AI usage ")
+	titleLine := lipgloss.PlaceHorizontal(innerW, lipgloss.Center, title)
+
+	var content string
+	if m.ready {
+		content = m.viewport.View()
+	}
+
+	footer := helpFooterStyle.Render("Esc close")
+	footerLine := fitWidth(lipgloss.PlaceHorizontal(innerW, lipgloss.Center, footer), innerW)
+
+	box := lipgloss.JoinVertical(lipgloss.Left, titleLine, "", content, footerLine)
+
+	overlayStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(theme.Accent).
+		Padding(0, 1).
+		Width(overlayW - 2).
+		Height(overlayH - 2)
+
+	return placeOverlay(m.width, m.height, overlayStyle.Render(box))
+}
+
+// overlayDimensions returns the outer dimensions of the overlay.
+func (m UsageOverlayModel) overlayDimensions() (width, height int) {
+	width = int(float64(m.width) * 0.5)
+	height = int(float64(m.height) * 0.7)
+	if width < 56 {
+		width = min(56, m.width)
+	}
+	if height < 24 {
+		height = min(24, m.height)
+	}
+	return width, height
+}
+
+// innerDimensions returns the viewport dimensions inside the overlay.
+func (m UsageOverlayModel) innerDimensions() (width, height int) {
+	ow, oh := m.overlayDimensions()
+	// Subtract border (2), padding (2), title + blank (2), footer (1)
+	width = max(1, ow-4)
+	height = max(1, oh-5)
+	return width, height
+}
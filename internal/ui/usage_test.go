@@ -0,0 +1,87 @@
+package ui
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/x/ansi"
+	"github.com/shhac/prtea/internal/claude"
+	"github.com/shhac/prtea/internal/config"
+)
+
+// fixedUsageProvider answers every prompt with the same usage.
+type fixedUsageProvider struct{ usage claude.Usage }
+
+func (fixedUsageProvider) Name() string { return "fixed" }
+
+func (p fixedUsageProvider) Complete(context.Context, claude.CompletionRequest) (*claude.Completion, error) {
+	return &claude.Completion{Text: "ok", Usage: p.usage}, nil
+}
+
+func TestUsageTracker_AttributesCallsToPRs(t *testing.T) {
+	m := App{
+		usage:     newUsageTracker(nil, nil),
+		appConfig: &config.Config{AIMonthlyBudgetUSD: 1},
+		statusBar: NewStatusBarModel(),
+		chatPanel: NewChatPanelModel(),
+		session:   &PRSession{Owner: "o", Repo: "r", Number: 7},
+	}
+
+	// A call is charged to the PR it started on, even if the user has moved on.
+	ctx := m.usageContext()
+	m.session = &PRSession{Owner: "o", Repo: "r", Number: 8}
+	chat := claude.NewChatServiceWithProvider(fixedUsageProvider{claude.Usage{Calls: 1, InputTokens: 12000, OutputTokens: 800, CostUSD: 0.6}}, time.Second, nil, 0, 0, 0)
+	if _, err := chat.AskOnce(ctx, claude.ChatInput{Message: "hi"}, func(string) {}); err != nil {
+		t.Fatalf("AskOnce: %v", err)
+	}
+
+	pr7, session := m.usage.totals(prKey("o", "r", 7))
+	if pr7.Calls != 1 || session.Calls != 1 {
+		t.Fatalf("pr7 = %+v, session = %+v", pr7, session)
+	}
+	if pr8, _ := m.usage.totals(prKey("o", "r", 8)); pr8.Calls != 0 {
+		t.Errorf("PR 8 should not be charged, got %+v", pr8)
+	}
+
+	if cmd := m.refreshUsage(); cmd != nil {
+		t.Error("no warning expected below the budget")
+	}
+	m.session = &PRSession{Owner: "o", Repo: "r", Number: 7}
+	m.syncUsageFooter()
+	if got := m.chatPanel.usageFooter; !strings.Contains(got, "12.0k in / 800 out") || !strings.Contains(got, "$0.60") {
+		t.Errorf("footer = %q", got)
+	}
+
+	m.usage.add("", claude.Usage{Calls: 1, CostUSD: 0.5})
+	if cmd := m.refreshUsage(); cmd == nil || !strings.Contains(m.statusBar.statusMessage, "budget") {
+		t.Errorf("expected a budget warning, status = %q", m.statusBar.statusMessage)
+	}
+	if cmd := m.refreshUsage(); cmd != nil {
+		t.Error("the budget warning should only be shown once a session")
+	}
+}
+
+func TestShowUsage_ReportsUnreadableLedger(t *testing.T) {
+	m := App{
+		usage:        newUsageTracker(nil, errors.New("failed to read usage ledger usage.json: invalid character")),
+		appConfig:    &config.Config{},
+		usageOverlay: NewUsageOverlayModel(),
+		width:        120,
+		height:       40,
+	}
+	model, _ := m.showUsage()
+	if got := ansi.Strip(model.(App).usageOverlay.View()); !strings.Contains(got, "failed to read usage ledger") {
+		t.Errorf("overlay should report the ledger error:\n%s", got)
+	}
+}
+
+func TestFormatTokenCount(t *testing.T) {
+	for n, want := range map[int]string{950: "950", 12345: "12.3k", 2_500_000: "2.50M"} {
+		if got := formatTokenCount(n); got != want {
+			t.Errorf("formatTokenCount(%d) = %q, want %q", n, got, want)
+		}
+	}
+}
@@ -0,0 +1,293 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/shhac/prtea/internal/github"
+)
+
+// workloadEntry is one PR waiting on a reviewer.
+type workloadEntry struct {
+	pr    github.PRItem
+	since time.Time // when the review was requested
+	stale bool
+}
+
+// reviewerLoad is a reviewer's queue of pending review requests, oldest
+// first.
+type reviewerLoad struct {
+	reviewer string
+	prs      []workloadEntry
+	stale    int
+}
+
+// aggregateWorkload groups pending review requests by reviewer. Requests
+// older than staleAfter count as stale. Reviewers are ordered by queue
+// depth, then stale count, then name.
+func aggregateWorkload(prs []github.WorkloadPR, staleAfter time.Duration, now time.Time) []reviewerLoad {
+	byReviewer := make(map[string]*reviewerLoad)
+	for _, pr := range prs {
+		for _, req := range pr.Requests {
+			load := byReviewer[req.Reviewer]
+			if load == nil {
+				load = &reviewerLoad{reviewer: req.Reviewer}
+				byReviewer[req.Reviewer] = load
+			}
+			stale := now.Sub(req.Since) >= staleAfter
+			if stale {
+				load.stale++
+			}
+			load.prs = append(load.prs, workloadEntry{pr: pr.PR, since: req.Since, stale: stale})
+		}
+	}
+
+	loads := make([]reviewerLoad, 0, len(byReviewer))
+	for _, load := range byReviewer {
+		sort.SliceStable(load.prs, func(i, j int) bool { return load.prs[i].since.Before(load.prs[j].since) })
+		loads = append(loads, *load)
+	}
+	sort.Slice(loads, func(i, j int) bool {
+		a, b := loads[i], loads[j]
+		if len(a.prs) != len(b.prs) {
+			return len(a.prs) > len(b.prs)
+		}
+		if a.stale != b.stale {
+			return a.stale > b.stale
+		}
+		return a.reviewer < b.reviewer
+	})
+	return loads
+}
+
+// waitAge formats how long a review request has been waiting.
+func waitAge(d time.Duration) string {
+	switch {
+	case d < time.Hour:
+		return "<1h"
+	case d < 24*time.Hour:
+		return fmt.Sprintf("%dh", int(d.Hours()))
+	default:
+		return fmt.Sprintf("%dd", int(d.Hours()/24))
+	}
+}
+
+// showWorkload opens the :workload overlay and fetches the open review
+// requests across the configured workloadScopes.
+func (m App) showWorkload() (tea.Model, tea.Cmd) {
+	if m.ghClient == nil {
+		return m, nil
+	}
+	var scopes []string
+	staleDays := 0
+	if m.appConfig != nil {
+		scopes = m.appConfig.WorkloadScopes
+		staleDays = m.appConfig.WorkloadStaleDays
+	}
+	if len(scopes) == 0 {
+		return m, m.statusBar.SetTemporaryMessage(`Set workloadScopes in config.json, e.g. ["acme", "acme/api"]`, 3*time.Second)
+	}
+	m.workload.SetSize(m.width, m.height)
+	m.workload.Show(strings.Join(scopes, ", "), time.Duration(max(1, staleDays))*24*time.Hour)
+	m.setMode(ModeOverlay)
+	return m, fetchWorkloadCmd(m.ghClient, scopes)
+}
+
+// WorkloadModel is the :workload overlay: open PRs grouped by requested
+// reviewer, showing each queue's depth and stale requests, with a drill-in
+// to a reviewer's PRs.
+type WorkloadModel struct {
+	scope      string // configured scopes, for the title
+	staleAfter time.Duration
+	loads      []reviewerLoad
+	loading    bool
+	err        error
+	cursor     int // reviewer, or PR when drilled in
+	selected   int // reviewer being shown, -1 for the summary
+	reviewer   int // reviewer cursor to restore when leaving the drill-in
+	now        func() time.Time
+	width      int
+	height     int
+	visible    bool
+}
+
+// NewWorkloadModel creates a reviewer workload overlay.
+func NewWorkloadModel() WorkloadModel {
+	return WorkloadModel{selected: -1, now: time.Now}
+}
+
+// Show opens the overlay in its loading state.
+func (m *WorkloadModel) Show(scope string, staleAfter time.Duration) {
+	m.scope = scope
+	m.staleAfter = staleAfter
+	m.loads = nil
+	m.err = nil
+	m.loading = true
+	m.cursor = 0
+	m.selected = -1
+	m.visible = true
+}
+
+// SetWorkload fills the overlay with fetched review requests.
+func (m *WorkloadModel) SetWorkload(prs []github.WorkloadPR, err error) {
+	m.loading = false
+	m.err = err
+	m.loads = aggregateWorkload(prs, m.staleAfter, m.now())
+	m.cursor = 0
+	m.selected = -1
+}
+
+// IsVisible returns whether the overlay is currently shown.
+func (m WorkloadModel) IsVisible() bool {
+	return m.visible
+}
+
+// SetSize updates the terminal dimensions used to place the overlay.
+func (m *WorkloadModel) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+}
+
+func (m WorkloadModel) overlayWidth() int {
+	return min(max(60, m.width*3/4), m.width)
+}
+
+// rows returns the number of selectable rows in the current level.
+func (m WorkloadModel) rows() int {
+	if m.selected >= 0 {
+		return len(m.loads[m.selected].prs)
+	}
+	return len(m.loads)
+}
+
+func (m WorkloadModel) Update(msg tea.Msg) (WorkloadModel, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return m, nil
+	}
+	switch keyMsg.String() {
+	case "j", "down":
+		if m.cursor < m.rows()-1 {
+			m.cursor++
+		}
+	case "k", "up":
+		if m.cursor > 0 {
+			m.cursor--
+		}
+	case "g", "home":
+		m.cursor = 0
+	case "G", "end":
+		m.cursor = max(0, m.rows()-1)
+	case "enter", "l", "right":
+		if m.cursor >= m.rows() {
+			return m, nil
+		}
+		if m.selected < 0 {
+			m.selected, m.reviewer, m.cursor = m.cursor, m.cursor, 0
+			return m, nil
+		}
+		if keyMsg.String() != "enter" {
+			return m, nil
+		}
+		pr := m.loads[m.selected].prs[m.cursor].pr
+		m.visible = false
+		open := &PRSelectedMsg{Owner: pr.Repo.Owner, Repo: pr.Repo.Name, Number: pr.Number, HTMLURL: pr.HTMLURL}
+		return m, func() tea.Msg { return WorkloadClosedMsg{Open: open} }
+	case "h", "left", "backspace":
+		if m.selected >= 0 {
+			m.selected, m.cursor = -1, m.reviewer
+		}
+	case "esc", "q":
+		if m.selected >= 0 {
+			m.selected, m.cursor = -1, m.reviewer
+			return m, nil
+		}
+		m.visible = false
+		return m, func() tea.Msg { return WorkloadClosedMsg{} }
+	}
+	return m, nil
+}
+
+func (m WorkloadModel) View() string {
+	if !m.visible {
+		return ""
+	}
+	overlayW := m.overlayWidth()
+	innerW := max(1, overlayW-4)
+	now := m.now()
+
+	heading := " Review workload "
+	if m.selected >= 0 {
+		load := m.loads[m.selected]
+		heading = fmt.Sprintf(" %s — %d waiting ", load.reviewer, len(load.prs))
+	}
+	title := helpTitleStyle.Render(heading)
+	lines := []string{
+		lipgloss.PlaceHorizontal(innerW, lipgloss.Left, title),
+		dimStyle.Render(ansi.Truncate(fmt.Sprintf("%s · stale after %s", m.scope, waitAge(m.staleAfter)), innerW, "…")),
+		"",
+	}
+
+	var rows []string
+	switch {
+	case m.loading:
+		lines = append(lines, dimStyle.Render("Loading review requests..."))
+	case m.err != nil:
+		lines = append(lines, errTextStyle.Render(ansi.Truncate("Error: "+m.err.Error(), innerW, "…")))
+	case len(m.loads) == 0:
+		lines = append(lines, dimStyle.Render("No open PRs are waiting on a review."))
+	case m.selected >= 0:
+		for _, e := range m.loads[m.selected].prs {
+			age := fmt.Sprintf("%4s", waitAge(now.Sub(e.since)))
+			if e.stale {
+				age = errTextStyle.Render(age)
+			}
+			rows = append(rows, fmt.Sprintf("%s  %s  %s", age, dimStyle.Render(fmt.Sprintf("%s#%d", e.pr.Repo.FullName, e.pr.Number)), e.pr.Title))
+		}
+	default:
+		nameW := 0
+		for _, load := range m.loads {
+			nameW = max(nameW, lipgloss.Width(load.reviewer))
+		}
+		nameW = min(nameW, innerW/2)
+		for _, load := range m.loads {
+			stale := dimStyle.Render("0 stale")
+			if load.stale > 0 {
+				stale = errTextStyle.Render(fmt.Sprintf("%d stale", load.stale))
+			}
+			name := ansi.Truncate(load.reviewer, nameW, "…")
+			rows = append(rows, fmt.Sprintf("%-*s  %3d waiting  %s  %s", nameW, name, len(load.prs), stale,
+				dimStyle.Render("oldest "+waitAge(now.Sub(load.prs[0].since)))))
+		}
+	}
+
+	maxRows := max(1, m.height-10)
+	start, end := visibleRange(m.cursor, len(rows), maxRows)
+	for i := start; i < end; i++ {
+		text := "  " + rows[i]
+		if i == m.cursor {
+			text = "▸ " + rows[i]
+		}
+		lines = append(lines, ansi.Truncate(text, innerW, "…"))
+	}
+
+	hint := "j/k move · Enter show PRs · Esc close"
+	if m.selected >= 0 {
+		hint = "j/k move · Enter open PR · h/Esc back"
+	}
+	footer := helpFooterStyle.Render(hint)
+	lines = append(lines, "", fitWidth(lipgloss.PlaceHorizontal(innerW, lipgloss.Center, footer), innerW))
+
+	overlayStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(theme.Accent).
+		Padding(0, 1).
+		Width(overlayW - 2)
+
+	return placeOverlay(m.width, m.height, overlayStyle.Render(strings.Join(lines, "\n")))
+}
@@ -0,0 +1,119 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/shhac/prtea/internal/config"
+	"github.com/shhac/prtea/internal/github"
+)
+
+var workloadNow = time.Date(2024, 5, 10, 12, 0, 0, 0, time.UTC)
+
+func workloadFixture() []github.WorkloadPR {
+	pr := func(number int, title string) github.PRItem {
+		return github.PRItem{Number: number, Title: title, Repo: github.Repo{Owner: "acme", Name: "api", FullName: "acme/api"}}
+	}
+	days := func(n int) time.Time { return workloadNow.Add(-time.Duration(n) * 24 * time.Hour) }
+	return []github.WorkloadPR{
+		{PR: pr(1, "Add cache"), Requests: []github.PendingReview{{Reviewer: "alice", Since: days(1)}, {Reviewer: "bob", Since: days(5)}}},
+		{PR: pr(2, "Fix login"), Requests: []github.PendingReview{{Reviewer: "alice", Since: days(4)}}},
+		{PR: pr(3, "Bump deps"), Requests: []github.PendingReview{{Reviewer: "acme/platform", IsTeam: true, Since: days(3)}}},
+	}
+}
+
+func TestAggregateWorkload(t *testing.T) {
+	loads := aggregateWorkload(workloadFixture(), 48*time.Hour, workloadNow)
+
+	var order []string
+	for _, l := range loads {
+		order = append(order, l.reviewer)
+	}
+	if strings.Join(order, ",") != "alice,acme/platform,bob" {
+		t.Fatalf("order = %v, want deepest queue first, then by name", order)
+	}
+	alice := loads[0]
+	if len(alice.prs) != 2 || alice.stale != 1 {
+		t.Errorf("alice = %d PRs, %d stale; want 2 and 1", len(alice.prs), alice.stale)
+	}
+	if alice.prs[0].pr.Number != 2 || !alice.prs[0].stale {
+		t.Errorf("alice's oldest request should come first and be stale, got #%d", alice.prs[0].pr.Number)
+	}
+}
+
+func TestWorkloadOverlay_DrillsInAndOpensPR(t *testing.T) {
+	m := NewWorkloadModel()
+	m.now = func() time.Time { return workloadNow }
+	m.SetSize(100, 30)
+	m.Show("acme", 48*time.Hour)
+	if !strings.Contains(m.View(), "Loading") {
+		t.Error("the overlay should show it is loading")
+	}
+	m.SetWorkload(workloadFixture(), nil)
+	if view := m.View(); !strings.Contains(view, "alice") || !strings.Contains(view, "2 waiting") || !strings.Contains(view, "oldest 4d") {
+		t.Errorf("summary view:\n%s", view)
+	}
+
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if view := m.View(); !strings.Contains(view, "acme/api#2") || !strings.Contains(view, "Fix login") {
+		t.Errorf("alice's queue:\n%s", view)
+	}
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("j")})
+	m, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	closed, ok := cmd().(WorkloadClosedMsg)
+	if !ok || closed.Open == nil || closed.Open.Number != 1 || closed.Open.Owner != "acme" {
+		t.Fatalf("closed = %#v, want #1 opened", closed)
+	}
+	if m.IsVisible() {
+		t.Error("opening a PR should close the overlay")
+	}
+}
+
+func TestWorkloadOverlay_EscBacksOutBeforeClosing(t *testing.T) {
+	m := NewWorkloadModel()
+	m.Show("acme", 48*time.Hour)
+	m.SetWorkload(workloadFixture(), nil)
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("j")})
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+
+	m, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if cmd != nil || m.selected != -1 || m.cursor != 1 {
+		t.Fatalf("esc in a queue should return to reviewer %d, got selected=%d cursor=%d", 1, m.selected, m.cursor)
+	}
+	_, cmd = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if closed, ok := cmd().(WorkloadClosedMsg); !ok || closed.Open != nil {
+		t.Errorf("esc on the summary should close without opening a PR, got %#v", closed)
+	}
+}
+
+func TestShowWorkload_NeedsScopes(t *testing.T) {
+	var calls []string
+	m := App{
+		statusBar: NewStatusBarModel(),
+		workload:  NewWorkloadModel(),
+		appConfig: &config.Config{},
+		ghClient: recordingClient(&calls, func([]string) string {
+			return `{"data":{"search":{"nodes":[]}}}`
+		}),
+	}
+	model, cmd := m.executeCommand("workload", "")
+	m = model.(App)
+	if m.workload.IsVisible() || cmd == nil || !strings.Contains(m.statusBar.statusMessage, "workloadScopes") {
+		t.Fatalf("status = %q, want a hint to configure workloadScopes", m.statusBar.statusMessage)
+	}
+
+	m.appConfig.WorkloadScopes = []string{"acme"}
+	model, cmd = m.executeCommand("workload", "")
+	m = model.(App)
+	if !m.workload.IsVisible() || m.mode != ModeOverlay {
+		t.Fatal(":workload should open the overlay")
+	}
+	if loaded, ok := cmd().(WorkloadLoadedMsg); !ok || loaded.Err != nil {
+		t.Fatalf("fetch = %#v", loaded)
+	}
+	if len(calls) != 1 || !strings.Contains(calls[0], "org:acme") {
+		t.Errorf("calls = %q", calls)
+	}
+}
@@ -0,0 +1,181 @@
+package ui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// workspaceEntry is the resident state of a PR that isn't currently shown:
+// everything needed to bring it back without refetching.
+type workspaceEntry struct {
+	session    *PRSession
+	diffViewer DiffViewerModel
+	chat       ChatTabModel
+	analysis   AnalysisTabModel
+	comments   CommentsTabModel
+	review     ReviewTabModel
+	chatTab    ChatTab
+	presetVars map[string]string
+}
+
+// prWorkspace keeps the last few selected PRs resident, like editor buffers.
+// Entries are most recently used first and never include the current PR.
+type prWorkspace struct {
+	entries []workspaceEntry
+	size    int // PRs kept, including the current one
+}
+
+func (w *prWorkspace) find(owner, repo string, number int) int {
+	for i, e := range w.entries {
+		if e.session.Owner == owner && e.session.Repo == repo && e.session.Number == number {
+			return i
+		}
+	}
+	return -1
+}
+
+// take removes and returns the resident entry for a PR.
+func (w *prWorkspace) take(owner, repo string, number int) (workspaceEntry, bool) {
+	i := w.find(owner, repo, number)
+	if i < 0 {
+		return workspaceEntry{}, false
+	}
+	e := w.entries[i]
+	w.entries = append(w.entries[:i:i], w.entries[i+1:]...)
+	return e, true
+}
+
+// push makes e the most recently used entry, evicting the oldest beyond the
+// workspace size.
+func (w *prWorkspace) push(e workspaceEntry) {
+	w.take(e.session.Owner, e.session.Repo, e.session.Number)
+	w.entries = append([]workspaceEntry{e}, w.entries...)
+	if keep := max(0, w.size-1); len(w.entries) > keep {
+		w.entries = w.entries[:keep]
+	}
+}
+
+// setSize changes how many PRs are kept, evicting the oldest if needed.
+func (w *prWorkspace) setSize(size int) {
+	w.size = size
+	if keep := max(0, size-1); len(w.entries) > keep {
+		w.entries = w.entries[:keep]
+	}
+}
+
+// settled reports whether the current PR has finished loading with nothing
+// in flight, so that its state can be kept as is. PRs switched away from
+// mid-load or mid-stream are dropped and refetched when selected again.
+func (m App) settled() bool {
+	s := m.session
+	return s != nil && !m.diffViewer.loading && m.diffViewer.prNumber == s.Number &&
+		s.StreamChan == nil && s.AnalysisStreamCh == nil && s.AIReviewCancel == nil && !s.Analyzing &&
+		!m.chatPanel.chat.isWaiting && !m.chatPanel.analysis.loading &&
+		!m.chatPanel.comments.loading && !m.chatPanel.comments.posting &&
+		!m.chatPanel.review.submitting && !m.chatPanel.review.aiLoading
+}
+
+// stashSession keeps the current PR resident before switching away.
+func (m *App) stashSession() {
+	if m.session == nil {
+		return
+	}
+	if !m.settled() || m.workspace.size < 2 {
+		m.workspace.take(m.session.Owner, m.session.Repo, m.session.Number)
+		return
+	}
+	m.workspace.push(workspaceEntry{
+		session:    m.session,
+		diffViewer: m.diffViewer,
+		chat:       m.chatPanel.chat,
+		analysis:   m.chatPanel.analysis,
+		comments:   m.chatPanel.comments,
+		review:     m.chatPanel.review,
+		chatTab:    m.chatPanel.activeTab,
+		presetVars: m.chatPanel.presets.vars,
+	})
+}
+
+// restoreSession brings a resident PR back as the current one.
+func (m *App) restoreSession(e workspaceEntry) {
+	m.session = e.session
+
+	width, height, focused := m.diffViewer.width, m.diffViewer.height, m.diffViewer.focused
+	m.diffViewer = e.diffViewer
+	m.diffViewer.SetFocused(focused)
+	m.diffViewer.SetShowOutdatedComments(m.appConfig != nil && m.appConfig.ShowOutdatedComments)
+	m.diffViewer.minimap = m.appConfig != nil && m.appConfig.DiffMinimap
+	m.diffViewer.SetSize(width, height) // also re-renders with the current theme
+
+	m.chatPanel.chat = e.chat
+	m.chatPanel.analysis = e.analysis
+	m.chatPanel.comments = e.comments
+	m.chatPanel.review = e.review
+	m.chatPanel.activeTab = e.chatTab
+	m.chatPanel.SetPresetVars(e.presetVars)
+	m.chatPanel.citeFiles = citationFiles(e.session.DiffFiles)
+	m.chatPanel.citeIdx = -1
+	m.syncUsageFooter()
+	m.chatPanel.refreshViewport()
+	m.syncPRSummary()
+
+	s := e.session
+	m.statusBar.SetSelectedPR(s.Number)
+	m.prList.SetSelectedPR(s.Number)
+	ci := ""
+	if m.diffViewer.ciStatus != nil {
+		ci = m.diffViewer.ciStatus.OverallStatus
+	}
+	m.prList.SetCIStatus(ci)
+	m.statusBar.SetCIStatus(ci)
+	decision := ""
+	if m.diffViewer.reviewSummary != nil {
+		decision = m.diffViewer.reviewSummary.ReviewDecision
+	}
+	m.prList.SetReviewDecision(decision)
+	m.syncPendingCommentCount()
+	m.quickfixPos = 0
+}
+
+// switchPR flips to a resident PR: with no argument the most recently used
+// one (like Ctrl+O), otherwise the given PR number.
+func (m App) switchPR(args string) (tea.Model, tea.Cmd) {
+	if len(m.workspace.entries) == 0 {
+		return m, m.statusBar.SetTemporaryMessage("No other open PRs — select one from the list", 2*time.Second)
+	}
+	e := m.workspace.entries[0]
+	if args = strings.TrimPrefix(strings.TrimSpace(args), "#"); args != "" {
+		number, err := strconv.Atoi(args)
+		if err != nil {
+			return m, m.statusBar.SetTemporaryMessage("Usage: switch [PR number]", 2*time.Second)
+		}
+		found := false
+		for _, c := range m.workspace.entries {
+			if c.session.Number == number {
+				e, found = c, true
+				break
+			}
+		}
+		if !found {
+			return m, m.statusBar.SetTemporaryMessage(fmt.Sprintf("PR #%d is not open (open: %s)", number, m.workspace.list()), 3*time.Second)
+		}
+	}
+	s := e.session
+	return m.selectPR(s.Owner, s.Repo, s.Number, s.HTMLURL, false)
+}
+
+// list describes the resident PRs for status messages, e.g. "#12, #34".
+func (w prWorkspace) list() string {
+	if len(w.entries) == 0 {
+		return "none"
+	}
+	parts := make([]string, len(w.entries))
+	for i, e := range w.entries {
+		parts[i] = fmt.Sprintf("#%d", e.session.Number)
+	}
+	return strings.Join(parts, ", ")
+}
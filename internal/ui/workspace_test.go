@@ -0,0 +1,99 @@
+package ui
+
+import (
+	"testing"
+
+	"github.com/shhac/prtea/internal/github"
+)
+
+func workspaceTestApp(size int) App {
+	m := App{
+		prList:     NewPRListModel(TabToReview),
+		statusBar:  NewStatusBarModel(),
+		diffViewer: newTestDiffViewer(80, 24),
+		chatPanel:  NewChatPanelModel(),
+		workspace:  prWorkspace{size: size},
+	}
+	return m
+}
+
+// loadPR selects a PR and fakes its diff arriving.
+func loadPR(t *testing.T, m App, number int) App {
+	t.Helper()
+	model, _ := m.selectPR("acme", "widget", number, "", false)
+	m = model.(App)
+	if m.diffViewer.loading {
+		m.diffViewer.SetDiff([]github.PRFile{{Filename: "a.go", Status: "modified", Patch: "@@ -1,1 +1,2 @@\n ctx\n+x"}})
+	}
+	return m
+}
+
+func TestWorkspaceKeepsPreviousPRResident(t *testing.T) {
+	m := loadPR(t, workspaceTestApp(3), 1)
+	first := m.session
+	first.PendingInlineComments = []PendingInlineComment{pending("a.go", 2)}
+	m.chatPanel.chat.messages = []chatMessage{{role: "user", content: "why?"}}
+
+	m = loadPR(t, m, 2)
+	if m.session == first || len(m.chatPanel.chat.messages) != 0 {
+		t.Fatal("PR #2 should start with a fresh session")
+	}
+	if got := m.workspace.list(); got != "#1" {
+		t.Fatalf("resident = %s, want #1", got)
+	}
+
+	model, cmd := m.switchPR("")
+	m = model.(App)
+	if m.session != first {
+		t.Fatal("Ctrl+O should bring back PR #1's session")
+	}
+	if cmd != nil || m.diffViewer.loading || len(m.diffViewer.files) != 1 {
+		t.Error("switching back should not refetch")
+	}
+	if len(m.chatPanel.chat.messages) != 1 || m.chatPanel.review.pendingCount != 1 {
+		t.Errorf("chat=%d pending=%d, want 1/1", len(m.chatPanel.chat.messages), m.chatPanel.review.pendingCount)
+	}
+	if got := m.workspace.list(); got != "#2" {
+		t.Errorf("resident = %s, want #2", got)
+	}
+}
+
+func TestWorkspaceEvictsOldest(t *testing.T) {
+	m := workspaceTestApp(3)
+	for _, n := range []int{1, 2, 3, 4} {
+		m = loadPR(t, m, n)
+	}
+	if got := m.workspace.list(); got != "#3, #2" {
+		t.Errorf("resident = %s, want #3, #2", got)
+	}
+	m.workspace.setSize(2)
+	if got := m.workspace.list(); got != "#3" {
+		t.Errorf("resident after shrink = %s, want #3", got)
+	}
+}
+
+func TestWorkspaceDropsUnsettledPR(t *testing.T) {
+	m := workspaceTestApp(3)
+	model, _ := m.selectPR("acme", "widget", 1, "", false) // diff never arrives
+	m = loadPR(t, model.(App), 2)
+	if len(m.workspace.entries) != 0 {
+		t.Errorf("a PR left mid-load should not stay resident, got %s", m.workspace.list())
+	}
+}
+
+func TestSwitchPRByNumber(t *testing.T) {
+	m := workspaceTestApp(5)
+	for _, n := range []int{1, 2, 3} {
+		m = loadPR(t, m, n)
+	}
+	model, _ := m.switchPR("#1")
+	m = model.(App)
+	if m.session.Number != 1 {
+		t.Fatalf("session = #%d, want #1", m.session.Number)
+	}
+
+	model, _ = m.switchPR("9")
+	if m = model.(App); m.session.Number != 1 || m.statusBar.statusMessage == "" {
+		t.Errorf("unknown PR should leave #1 selected with a message, got #%d %q", m.session.Number, m.statusBar.statusMessage)
+	}
+}
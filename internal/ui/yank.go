@@ -0,0 +1,143 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// rawLineAt returns the diff line shown at cached line pos, as in the
+// patch (with its +/-/space marker). Comment boxes are not diff lines.
+func (m DiffViewerModel) rawLineAt(pos int) (string, bool) {
+	if pos < 0 || pos >= len(m.cachedLineInfo) || !m.cachedLineInfo[pos].isDiffLine {
+		return "", false
+	}
+	h := m.cachedLineInfo[pos].hunkIdx
+	if h < 0 || h >= len(m.hunks) || h >= len(m.hunkLineRanges) {
+		return "", false
+	}
+	// Every hunk line renders as one cached line; injected comment lines
+	// are the only others in the hunk's range.
+	idx := 0
+	for i := m.hunkLineRanges[h][0]; i < pos; i++ {
+		if m.cachedLineInfo[i].comment == commentNone {
+			idx++
+		}
+	}
+	lines := m.hunks[h].Lines
+	if idx >= len(lines) {
+		return "", false
+	}
+	return lines[idx], true
+}
+
+// yankLines returns the code of the selected line range, or of the cursor
+// line without a selection, with diff markers and hunk headers removed.
+func (m DiffViewerModel) yankLines() (text string, n int) {
+	lo, hi := m.selectionRange()
+	if lo < 0 {
+		lo, hi = m.cursorLine, m.cursorLine
+	}
+	var lines []string
+	for i := lo; i <= hi; i++ {
+		line, ok := m.rawLineAt(i)
+		if !ok || strings.HasPrefix(line, "@@") || strings.HasPrefix(line, `\`) {
+			continue
+		}
+		if line != "" {
+			line = line[1:]
+		}
+		lines = append(lines, line)
+	}
+	return strings.Join(lines, "\n"), len(lines)
+}
+
+// yankPatch returns the selected hunks, or the focused hunk without a
+// selection, as a patch that git apply accepts.
+func (m DiffViewerModel) yankPatch() (text string, n int) {
+	var idxs []int
+	for i, sel := range m.selectedHunks {
+		if sel && i < len(m.hunks) {
+			idxs = append(idxs, i)
+		}
+	}
+	if len(idxs) == 0 && m.focusedHunkIdx >= 0 && m.focusedHunkIdx < len(m.hunks) {
+		idxs = []int{m.focusedHunkIdx}
+	}
+	sort.Ints(idxs)
+
+	hunks := make([]DiffHunk, len(idxs))
+	for i, idx := range idxs {
+		hunks[i] = m.hunks[idx]
+	}
+	return formatPatch(hunks), len(hunks)
+}
+
+// formatPatch joins hunks into a patch, with file headers before the first
+// hunk of each file.
+func formatPatch(hunks []DiffHunk) string {
+	var b strings.Builder
+	file := ""
+	for _, h := range hunks {
+		if h.Filename != file {
+			file = h.Filename
+			fmt.Fprintf(&b, "diff --git a/%s b/%s\n--- a/%s\n+++ b/%s\n", file, file, file, file)
+		}
+		b.WriteString(strings.Join(h.Lines, "\n"))
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// yankCmd reports text for the App to copy to the clipboard.
+func yankCmd(text, what string) tea.Cmd {
+	return func() tea.Msg { return CopyToClipboardMsg{Text: text, What: what} }
+}
+
+// yankDiffLines copies the cursor line or selected range ("y").
+func (m *DiffViewerModel) yankDiffLines() tea.Cmd {
+	text, n := m.yankLines()
+	if n == 0 {
+		return nil
+	}
+	what := "line"
+	if n > 1 {
+		what = fmt.Sprintf("%d lines", n)
+	}
+	m.cancelSelection()
+	m.refreshContent()
+	return yankCmd(text, what)
+}
+
+// yankDiffPatch copies the selected or focused hunks as a patch ("Y").
+func (m DiffViewerModel) yankDiffPatch() tea.Cmd {
+	text, n := m.yankPatch()
+	if n == 0 {
+		return nil
+	}
+	what := "hunk as a patch"
+	if n > 1 {
+		what = fmt.Sprintf("%d hunks as a patch", n)
+	}
+	return yankCmd(text, what)
+}
+
+// yankURL copies the PR's URL (:yank url).
+func (m App) yankURL() (tea.Model, tea.Cmd) {
+	if m.session == nil || m.session.HTMLURL == "" {
+		return m, m.statusBar.SetTemporaryMessage("No PR selected", 2*time.Second)
+	}
+	return m, m.copyToClipboard(m.session.HTMLURL, "PR URL")
+}
+
+// yankPath copies the path of the file under the diff cursor (:yank path).
+func (m App) yankPath() (tea.Model, tea.Cmd) {
+	idx := m.diffViewer.currentFileIndex()
+	if idx < 0 {
+		return m, m.statusBar.SetTemporaryMessage("No diff loaded yet", 2*time.Second)
+	}
+	return m, m.copyToClipboard(m.diffViewer.files[idx].Filename, "file path")
+}
@@ -0,0 +1,67 @@
+package ui
+
+import "testing"
+
+func TestYankLinesSkipsCommentBoxes(t *testing.T) {
+	m := commentNavTestApp()
+	dv := &m.diffViewer
+	tests := []struct {
+		path string
+		line int
+		want string
+	}{
+		{"a.go", 2, "two"},
+		{"a.go", 4, "four"},
+		{"b.go", 3, "three"},
+	}
+	for _, tt := range tests {
+		if !dv.jumpToLine(tt.path, tt.line) {
+			t.Fatalf("jumpToLine(%s, %d) failed", tt.path, tt.line)
+		}
+		if got, n := dv.yankLines(); got != tt.want || n != 1 {
+			t.Errorf("%s:%d: yankLines() = %q, %d; want %q, 1", tt.path, tt.line, got, n, tt.want)
+		}
+	}
+}
+
+func TestYankLinesSelection(t *testing.T) {
+	m := commentNavTestApp()
+	dv := &m.diffViewer
+	dv.jumpToLine("a.go", 2)
+	dv.extendSelection(1)
+	dv.extendSelection(1)
+	got, n := dv.yankLines()
+	if want := "two\nthree\nfour"; got != want || n != 3 {
+		t.Errorf("yankLines() = %q, %d; want %q, 3", got, n, want)
+	}
+}
+
+func TestYankPatch(t *testing.T) {
+	m := commentNavTestApp()
+	dv := &m.diffViewer
+
+	dv.focusedHunkIdx = 1
+	got, n := dv.yankPatch()
+	want := "diff --git a/b.go b/b.go\n--- a/b.go\n+++ b/b.go\n@@ -1,2 +1,3 @@\n one\n+two\n three\n"
+	if got != want || n != 1 {
+		t.Errorf("focused hunk: yankPatch() = %q, %d; want %q, 1", got, n, want)
+	}
+
+	dv.toggleHunkSelection(1)
+	dv.toggleHunkSelection(0)
+	got, n = dv.yankPatch()
+	want = "diff --git a/a.go b/a.go\n--- a/a.go\n+++ b/a.go\n@@ -1,3 +1,4 @@\n one\n+two\n three\n four\n" +
+		"diff --git a/b.go b/b.go\n--- a/b.go\n+++ b/b.go\n@@ -1,2 +1,3 @@\n one\n+two\n three\n"
+	if got != want || n != 2 {
+		t.Errorf("selected hunks: yankPatch() = %q, %d; want %q, 2", got, n, want)
+	}
+}
+
+func TestYankURLWithoutSession(t *testing.T) {
+	m := commentNavTestApp()
+	m.session = nil
+	model, _ := m.yankURL()
+	if got := model.(App).statusBar.statusMessage; got != "No PR selected" {
+		t.Errorf("status = %q, want %q", got, "No PR selected")
+	}
+}